@@ -0,0 +1,66 @@
+// Command arcade runs the tower defense as a kiosk cabinet: timed rounds, a
+// session leaderboard with initials entry, and a reset after a minute without
+// input. Hold Ctrl+Shift+Q for three seconds to leave.
+//
+//	go run ./cmd/arcade
+//
+// The examples are standalone main packages, so only games under engine/ can
+// join the rotation; add them to slots as they become importable.
+package main
+
+import (
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/arcade"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+)
+
+const (
+	screenWidth  = 800
+	screenHeight = 480
+
+	boardSize = 10
+)
+
+// tdSlot hosts the tower defense in the cabinet.
+type tdSlot struct {
+	td *game.TDGame
+}
+
+func newTDSlot() *tdSlot {
+	return &tdSlot{td: game.NewTDGame(screenWidth, screenHeight)}
+}
+
+func (s *tdSlot) Update() error { return s.td.Update() }
+
+// Reset starts a new game; the tower defense keeps no state between games.
+func (s *tdSlot) Reset() { s.td = game.NewTDGame(screenWidth, screenHeight) }
+
+func (s *tdSlot) Score() int { return s.td.Score }
+
+// Over ends the turn early on a loss or a cleared map.
+func (s *tdSlot) Over() bool {
+	return s.td.State == game.StateGameOver || s.td.State == game.StateVictory
+}
+
+func (s *tdSlot) Draw(screen *ebiten.Image) { s.td.Draw(screen) }
+
+func (s *tdSlot) Layout(_, _ int) (int, int) { return screenWidth, screenHeight }
+
+func slots() []arcade.Slot {
+	return []arcade.Slot{
+		{Name: "Tower Defense", Game: newTDSlot(), TimeLimit: 3 * 60},
+	}
+}
+
+func main() {
+	cabinet := arcade.NewCabinet(arcade.NewSession(slots(), boardSize))
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("NeuralWay Arcade")
+
+	if err := ebiten.RunGame(cabinet); err != nil {
+		log.Fatal(err)
+	}
+}
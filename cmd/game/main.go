@@ -2,14 +2,20 @@ package main
 
 import (
 	"log"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
 	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
 	screenWidth  = 800
 	screenHeight = 480
+
+	autoSaveInterval = 30 * time.Second
 )
 
 // GameWrapper wraps the TDGame to implement ebiten.Game interface.
@@ -21,6 +27,10 @@ func (g *GameWrapper) Update() error {
 	return g.tdGame.Update()
 }
 
+func (g *GameWrapper) Step() error {
+	return g.tdGame.Step()
+}
+
 func (g *GameWrapper) Draw(screen *ebiten.Image) {
 	g.tdGame.Draw(screen)
 }
@@ -33,6 +43,21 @@ func main() {
 	// Create TD game
 	tdGame := game.NewTDGame(screenWidth, screenHeight)
 
+	// Resume from and keep saving progress in the user data directory
+	app := paths.App{Vendor: "neuralway", Name: "td"}
+	if store, err := app.Open(paths.Data); err != nil {
+		log.Printf("autosave disabled: %v", err)
+	} else if err := tdGame.EnableAutoSave(game.NewSaveManagerFS(store), autoSaveInterval); err != nil {
+		log.Printf("autosave: %v", err)
+	}
+
+	// Use the player's key bindings, rebound from the pause screen
+	if store, err := app.Open(paths.Config); err != nil {
+		log.Printf("controls: %v", err)
+	} else if err := tdGame.EnableControls(store); err != nil {
+		log.Printf("controls: %v", err)
+	}
+
 	wrapper := &GameWrapper{tdGame: tdGame}
 
 	// Configure window
@@ -40,8 +65,18 @@ func main() {
 	ebiten.SetWindowTitle("Tower Defense - AI ECS Framework Demo")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	// Run the game
-	if err := ebiten.RunGame(wrapper); err != nil {
+	// Run the game with 1x/2x/4x buttons in the top bar, restoring the window
+	// where it was last closed
+	speed := engine.SpeedConfig{X: screenWidth - 104, Y: 11}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+	window := engine.WindowConfig{App: app}
+	windowed := engine.WithWindow(engine.WithFocus(engine.WithSpeed(wrapper, speed), focus), window)
+	err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name))
+
+	// Write any pending autosave before exiting
+	tdGame.Unload()
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,124 @@
+// Command shop opens the framework token shop on its own, to spend tokens
+// earned in any example without starting a game:
+//
+//	go run ./cmd/shop
+//
+// Escape, P, or Start opens a pause menu over the shop, which also opens
+// when the window loses focus.
+package main
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+const (
+	screenWidth  = 660
+	screenHeight = 480
+)
+
+// shopApp runs the shop scene until it is left.
+type shopApp struct {
+	scenes *engine.SceneManager
+	done   bool
+}
+
+func (a *shopApp) Update() error {
+	if a.done {
+		return ebiten.Termination
+	}
+
+	return a.scenes.Update()
+}
+
+func (a *shopApp) Draw(screen *ebiten.Image) {
+	a.scenes.Draw(screen)
+}
+
+func (a *shopApp) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// Pause opens the pause menu, for engine.FocusConfig.AutoPause.
+func (a *shopApp) Pause() {
+	a.scenes.Pause()
+}
+
+// Pause menu items.
+const (
+	pauseResume = "Resume"
+	pauseQuit   = "Quit"
+)
+
+// pauseMenu is the scene the pause key pushes over the shop.
+type pauseMenu struct {
+	app      *shopApp
+	under    engine.Scene
+	list     *ui.List
+	controls *input.Map
+}
+
+func newPauseMenu(app *shopApp, under engine.Scene) *pauseMenu {
+	list := ui.NewList((screenWidth-200)/2, screenHeight/2-10, 200, []string{pauseResume, pauseQuit})
+	list.RowH = 26
+
+	return &pauseMenu{app: app, under: under, list: list, controls: input.NewMap()}
+}
+
+func (m *pauseMenu) Load() error { return nil }
+
+func (m *pauseMenu) Unload() {}
+
+func (m *pauseMenu) Update() error {
+	if i := m.list.Update(m.controls); i >= 0 {
+		switch m.list.Items[i] {
+		case pauseResume:
+			m.app.scenes.Unpause()
+		case pauseQuit:
+			m.app.done = true
+		}
+	}
+
+	return nil
+}
+
+// Draw dims the shop under a panel holding the menu.
+func (m *pauseMenu) Draw(screen *ebiten.Image) {
+	m.under.Draw(screen)
+	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, color.RGBA{A: 180}, false)
+	ui.CenteredPanel(screenWidth, screenHeight, 260, 140, "PAUSED").Draw(screen)
+	m.list.Draw(screen)
+}
+
+func main() {
+	app := &shopApp{scenes: engine.NewSceneManager()}
+	app.scenes.SetPause(engine.PauseConfig{
+		Controls: input.NewMap(),
+		Menu:     func(scene engine.Scene) engine.Scene { return newPauseMenu(app, scene) },
+	})
+
+	shop := profile.NewShop(profile.Open())
+	shop.OnExit = func() { app.done = true }
+
+	if err := app.scenes.SetScene(shop); err != nil {
+		log.Fatal(err)
+	}
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Framework Token Shop")
+
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "shop"}}
+
+	if err := ebiten.RunGame(engine.WithWindow(engine.WithFocus(app, focus), window)); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+// Command validate checks every game's content tables before a contributor
+// submits changes to them. Run it from the repository root:
+//
+//	go run ./cmd/validate              # every game
+//	go run ./cmd/validate survivor     # just one
+//
+// The content lives in each game's own package, so validate runs each game
+// with the content.Flag flag, which checks the content and exits before
+// opening a window. It exits non-zero if any game reports an error.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/content"
+)
+
+// games are the examples whose main handles content.Flag.
+var games = []string{"survivor"}
+
+func main() {
+	selected := games
+	if len(os.Args) > 1 {
+		selected = os.Args[1:]
+	}
+
+	failed := 0
+
+	for _, game := range selected {
+		if !slices.Contains(games, game) {
+			fmt.Fprintf(os.Stderr, "validate: %s has no content checks; known games: %v\n", game, games)
+
+			failed++
+
+			continue
+		}
+
+		cmd := exec.Command("go", "run", "./examples/"+game, content.Flag)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "validate: %d of %d games failed\n", failed, len(selected))
+		os.Exit(1)
+	}
+}
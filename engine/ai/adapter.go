@@ -28,6 +28,13 @@ type GameAdapter interface {
 	Reset() error
 }
 
+// WorldBounder is optionally implemented by adapters whose world is not the
+// detector's default rectangle at the origin, e.g. worlds centered on the
+// spawn point. A new QASession uses it to configure its default detector.
+type WorldBounder interface {
+	WorldBounds() (x, y, width, height float64)
+}
+
 // GameState contains observable game state for AI analysis.
 type GameState struct {
 	Tick         int64          `json:"tick"`
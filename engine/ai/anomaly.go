@@ -67,6 +67,8 @@ type AnomalyDetector struct {
 	EntityLeakThreshold int     // Max entities before leak warning
 	DeathLoopRadius     float64 // Distance to consider same death location
 	HealthDrainRate     float64 // Health lost per tick to trigger warning
+	BoundsX             float64 // Left edge of the game world
+	BoundsY             float64 // Top edge of the game world
 	BoundsWidth         float64 // Game world width
 	BoundsHeight        float64 // Game world height
 }
@@ -264,8 +266,8 @@ func (d *AnomalyDetector) detectBoundaryViolation(history []Observation) []Anoma
 	for _, obs := range history {
 		pos := obs.State.PlayerPos
 
-		if pos[0] < 0 || pos[0] > d.BoundsWidth ||
-			pos[1] < 0 || pos[1] > d.BoundsHeight {
+		if pos[0] < d.BoundsX || pos[0] > d.BoundsX+d.BoundsWidth ||
+			pos[1] < d.BoundsY || pos[1] > d.BoundsY+d.BoundsHeight {
 			anomalies = append(anomalies, Anomaly{
 				Type:        AnomalyBoundaryViolation,
 				Severity:    SeverityHigh,
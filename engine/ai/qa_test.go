@@ -73,6 +73,15 @@ func (m *MockGameAdapter) Reset() error {
 	return nil
 }
 
+// centeredAdapter reports a world centered on the origin.
+type centeredAdapter struct {
+	*MockGameAdapter
+}
+
+func (c *centeredAdapter) WorldBounds() (x, y, width, height float64) {
+	return -50, -50, 100, 100
+}
+
 // TestObserver tests the Observer component.
 func TestObserver(t *testing.T) {
 	t.Run("NewObserver creates observer with max history", func(t *testing.T) {
@@ -194,6 +203,29 @@ func TestAnomalyDetector(t *testing.T) {
 		}
 	})
 
+	t.Run("DetectBoundaryViolation honors the bounds origin", func(t *testing.T) {
+		d := NewAnomalyDetector()
+		d.BoundsX, d.BoundsY = -500, -500
+		d.BoundsWidth, d.BoundsHeight = 1000, 1000
+
+		history := []Observation{
+			{Tick: 1, State: GameState{PlayerPos: [2]float64{-400, 300}}},
+			{Tick: 2, State: GameState{PlayerPos: [2]float64{-600, 0}}},
+		}
+
+		count := 0
+
+		for _, a := range d.Analyze(history) {
+			if a.Type == AnomalyBoundaryViolation {
+				count++
+			}
+		}
+
+		if count != 1 {
+			t.Errorf("boundary violations = %d, want 1 for the position past the left edge", count)
+		}
+	})
+
 	t.Run("DetectScoreRegression finds decreasing score", func(t *testing.T) {
 		d := NewAnomalyDetector()
 
@@ -272,6 +304,14 @@ func TestQASession(t *testing.T) {
 		}
 	})
 
+	t.Run("NewQASession applies game-reported bounds", func(t *testing.T) {
+		session := NewQASession(&centeredAdapter{NewMockGameAdapter()})
+
+		if d := session.detector; d.BoundsX != -50 || d.BoundsY != -50 || d.BoundsWidth != 100 {
+			t.Errorf("detector bounds = (%v, %v, %v), want the adapter's world", d.BoundsX, d.BoundsY, d.BoundsWidth)
+		}
+	})
+
 	t.Run("Run executes game runs", func(t *testing.T) {
 		adapter := NewMockGameAdapter()
 		session := NewQASession(adapter)
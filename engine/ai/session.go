@@ -64,10 +64,15 @@ type QASession struct {
 
 // NewQASession creates a QA session for the given game.
 func NewQASession(adapter GameAdapter) *QASession {
+	detector := NewAnomalyDetector()
+	if b, ok := adapter.(WorldBounder); ok {
+		detector.BoundsX, detector.BoundsY, detector.BoundsWidth, detector.BoundsHeight = b.WorldBounds()
+	}
+
 	return &QASession{
 		adapter:  adapter,
 		observer: NewObserver(10000),
-		detector: NewAnomalyDetector(),
+		detector: detector,
 		config:   DefaultSessionConfig(),
 	}
 }
@@ -0,0 +1,226 @@
+package arcade
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+)
+
+// exitHold is how long the operator exit keys must be held together.
+const exitHold = 3.0
+
+// Cabinet hosts a Session as an ebiten.Game. Hosted games draw themselves
+// while playing; the cabinet draws the time left, the intermission card, and
+// the initials entry over them.
+//
+// Quit shortcuts are disabled: closing the window is ignored and a game
+// returning ebiten.Termination only ends its turn. The operator leaves by
+// holding every key in ExitKeys for three seconds.
+//
+// With an Attract set, the cabinet plays its demo after that long without
+// input, whatever phase it is in, and starts a fresh rotation on the next
+// input or when the demo ends.
+type Cabinet struct {
+	Session  *Session
+	ExitKeys []ebiten.Key    // Empty means the cabinet cannot be exited from the keyboard
+	Attract  *engine.Attract // Nil plays no demo
+
+	exitHeld float64
+	input    engine.IdleDetector // Only its input polling is used
+}
+
+// NewCabinet creates a cabinet for s and tells ebiten to ignore window close.
+func NewCabinet(s *Session) *Cabinet {
+	ebiten.SetWindowClosingHandled(true)
+
+	return &Cabinet{
+		Session:  s,
+		ExitKeys: []ebiten.Key{ebiten.KeyControl, ebiten.KeyShift, ebiten.KeyQ},
+	}
+}
+
+// Update runs the current game and advances the session.
+func (c *Cabinet) Update() error {
+	dt := 1 / float64(ebiten.TPS())
+
+	if c.exitRequested(dt) {
+		return ebiten.Termination
+	}
+
+	s := c.Session
+	active := c.input.Poll()
+
+	if c.Attract != nil {
+		running, err := c.Attract.Update(dt, true, active)
+		if running {
+			if !c.Attract.Running() {
+				s.IdleReset()
+			}
+
+			return err
+		}
+	}
+
+	switch s.Phase() {
+	case PhasePlaying:
+		game := s.Current().Game
+
+		err := game.Update()
+		if err != nil && !errors.Is(err, ebiten.Termination) {
+			return err
+		}
+
+		if ender, ok := game.(Ender); err != nil || (ok && ender.Over()) {
+			s.EndGame()
+		}
+	case PhaseInitials:
+		c.updateInitials()
+	case PhaseIntermission:
+	}
+
+	s.Update(dt, active)
+
+	return nil
+}
+
+func (c *Cabinet) updateInitials() {
+	in := &c.Session.Initials
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		in.Cycle(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		in.Cycle(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		in.Move(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		in.Move(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		c.Session.SubmitInitials()
+	}
+}
+
+// exitRequested tracks how long the exit keys have been held together.
+func (c *Cabinet) exitRequested(dt float64) bool {
+	if len(c.ExitKeys) == 0 {
+		return false
+	}
+
+	for _, k := range c.ExitKeys {
+		if !ebiten.IsKeyPressed(k) {
+			c.exitHeld = 0
+
+			return false
+		}
+	}
+
+	c.exitHeld += dt
+
+	return c.exitHeld >= exitHold
+}
+
+// Draw draws the current game or the cabinet screens.
+func (c *Cabinet) Draw(screen *ebiten.Image) {
+	if c.Attract != nil && c.Attract.Running() {
+		c.Attract.Draw(screen)
+
+		return
+	}
+
+	s := c.Session
+
+	switch s.Phase() {
+	case PhasePlaying:
+		if d, ok := s.Current().Game.(interface{ Draw(*ebiten.Image) }); ok {
+			d.Draw(screen)
+		}
+
+		c.drawTimer(screen)
+	case PhaseIntermission:
+		c.drawIntermission(screen)
+	case PhaseInitials:
+		c.drawInitials(screen)
+	}
+}
+
+// Layout uses the current game's layout when it has one.
+func (c *Cabinet) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if l, ok := c.Session.Current().Game.(interface{ Layout(int, int) (int, int) }); ok {
+		return l.Layout(outsideWidth, outsideHeight)
+	}
+
+	return outsideWidth, outsideHeight
+}
+
+func (c *Cabinet) drawTimer(screen *ebiten.Image) {
+	s := c.Session
+	w := screen.Bounds().Dx()
+	secs := int(s.Remaining() + 0.999)
+	label := fmt.Sprintf("%s %d:%02d", s.Current().Name, secs/60, secs%60)
+
+	x := w - len(label)*6 - 7
+
+	vector.FillRect(screen, float32(x-5), 2, float32(len(label)*6+10), 18, color.NRGBA{A: 160}, false)
+	ebitenutil.DebugPrintAt(screen, label, x, 3)
+}
+
+func (c *Cabinet) drawIntermission(screen *ebiten.Image) {
+	s := c.Session
+	screen.Fill(color.RGBA{R: 15, G: 15, B: 30, A: 255})
+
+	y := 40
+	line := func(text string) {
+		ebitenutil.DebugPrintAt(screen, text, 40, y)
+		y += 18
+	}
+
+	line(fmt.Sprintf("GAME %d OF %d", s.Index()+1, len(s.Slots)))
+	line("UP NEXT: " + s.Current().Name)
+	line(fmt.Sprintf("Starting in %d...", int(s.Remaining()+0.999)))
+
+	if len(s.Scores()) > 0 {
+		y += 18
+		for i, v := range s.Scores() {
+			line(fmt.Sprintf("%-16s %8d", s.Slots[i].Name, v))
+		}
+
+		line(fmt.Sprintf("%-16s %8d", "TOTAL", s.Total()))
+	}
+
+	y += 18
+	c.drawBoard(screen, 40, y)
+}
+
+func (c *Cabinet) drawInitials(screen *ebiten.Image) {
+	s := c.Session
+	screen.Fill(color.RGBA{R: 15, G: 15, B: 30, A: 255})
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("NEW HIGH SCORE: %d", s.Total()), 40, 40)
+	ebitenutil.DebugPrintAt(screen, "ENTER YOUR INITIALS", 40, 58)
+
+	for i, ch := range s.Initials.String() {
+		x := 40 + i*24
+		ebitenutil.DebugPrintAt(screen, string(ch), x+4, 90)
+
+		if i == s.Initials.Cursor {
+			vector.FillRect(screen, float32(x), 108, 14, 2, color.White, false)
+		}
+	}
+
+	ebitenutil.DebugPrintAt(screen, "UP/DOWN letter  LEFT/RIGHT move  ENTER done", 40, 124)
+	c.drawBoard(screen, 40, 160)
+}
+
+func (c *Cabinet) drawBoard(screen *ebiten.Image, x, y int) {
+	ebitenutil.DebugPrintAt(screen, "SESSION LEADERBOARD", x, y)
+
+	for i, e := range c.Session.Board.Entries {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%2d. %s %8d", i+1, e.Initials, e.Total), x, y+18*(i+1))
+	}
+}
@@ -0,0 +1,282 @@
+// Package arcade runs a kiosk-style rotation of games for demo booths and
+// parties: each game gets a time limit, scores across the rotation are added
+// up into a session leaderboard with initials entry, and the cabinet resets
+// itself after a period of inactivity.
+//
+// Session holds the rotation rules and has no ebiten dependency, so it can be
+// driven and tested headless; Cabinet hosts a Session as an ebiten.Game.
+package arcade
+
+import "sort"
+
+// Default timings, in seconds.
+const (
+	DefaultTimeLimit    = 120
+	DefaultIdleTimeout  = 60
+	DefaultIntermission = 3
+)
+
+// Game is a game the cabinet can host. Reset starts a fresh round in place,
+// keeping loaded assets, and Score reports the current round's score.
+type Game interface {
+	Update() error
+	Reset()
+	Score() int
+}
+
+// Ender is implemented by games that can finish before their time limit,
+// e.g. when the player dies.
+type Ender interface {
+	Over() bool
+}
+
+// Slot is one game in the rotation.
+type Slot struct {
+	Name      string
+	Game      Game
+	TimeLimit float64 // Seconds; 0 uses DefaultTimeLimit
+}
+
+// Phase is the stage of the rotation the session is in.
+type Phase int
+
+const (
+	PhaseIntermission Phase = iota // Showing the next game before it starts
+	PhasePlaying
+	PhaseInitials // Entering initials for a leaderboard score
+)
+
+// Session steps through the slots in order. A rotation ends after the last
+// slot; if its total makes the leaderboard the player enters initials, then
+// every game is reset and the rotation starts over.
+type Session struct {
+	Slots        []Slot
+	IdleTimeout  float64 // Seconds without input before resetting; 0 never resets
+	Intermission float64 // Seconds the next game is announced before it starts
+	Board        Leaderboard
+	Initials     Initials
+
+	phase     Phase
+	index     int
+	remaining float64
+	idle      float64
+	scores    []int
+}
+
+// NewSession creates a session over slots, which must not be empty, with a
+// leaderboard of boardSize entries and the default timings.
+func NewSession(slots []Slot, boardSize int) *Session {
+	s := &Session{
+		Slots:        slots,
+		IdleTimeout:  DefaultIdleTimeout,
+		Intermission: DefaultIntermission,
+		Board:        Leaderboard{Size: boardSize},
+	}
+	s.restart()
+
+	return s
+}
+
+// Phase returns the current stage of the rotation.
+func (s *Session) Phase() Phase {
+	return s.phase
+}
+
+// Index returns the index of the current (or upcoming) slot.
+func (s *Session) Index() int {
+	return s.index
+}
+
+// Current returns the current (or upcoming) slot.
+func (s *Session) Current() *Slot {
+	return &s.Slots[min(s.index, len(s.Slots)-1)]
+}
+
+// Remaining returns the seconds left in the current game or intermission.
+func (s *Session) Remaining() float64 {
+	return s.remaining
+}
+
+// Scores returns the score of each finished slot in this rotation.
+func (s *Session) Scores() []int {
+	return s.scores
+}
+
+// Total returns the sum of the scores finished so far this rotation.
+func (s *Session) Total() int {
+	total := 0
+	for _, v := range s.scores {
+		total += v
+	}
+
+	return total
+}
+
+// Update advances the timers by dt seconds. active reports whether there was
+// any player input this frame.
+func (s *Session) Update(dt float64, active bool) {
+	if active {
+		s.idle = 0
+	} else {
+		s.idle += dt
+	}
+
+	if s.IdleTimeout > 0 && s.idle >= s.IdleTimeout {
+		s.IdleReset()
+
+		return
+	}
+
+	switch s.phase {
+	case PhaseIntermission:
+		s.remaining -= dt
+		if s.remaining <= 0 {
+			s.phase = PhasePlaying
+			s.remaining = s.Current().timeLimit()
+		}
+	case PhasePlaying:
+		s.remaining -= dt
+		if s.remaining <= 0 {
+			s.EndGame()
+		}
+	case PhaseInitials:
+		// Waits for SubmitInitials or the idle timeout
+	}
+}
+
+// EndGame records the current game's score and moves to the next slot, or to
+// initials entry after the last one. Call it when a game ends early.
+func (s *Session) EndGame() {
+	if s.phase != PhasePlaying {
+		return
+	}
+
+	s.scores = append(s.scores, s.Current().Game.Score())
+	s.index++
+
+	switch {
+	case s.index < len(s.Slots):
+		s.startIntermission()
+	case s.Board.Qualifies(s.Total()):
+		s.phase = PhaseInitials
+		s.Initials = NewInitials()
+	default:
+		s.restart()
+	}
+}
+
+// SubmitInitials adds the rotation's total to the leaderboard under the
+// entered initials and starts a new rotation.
+func (s *Session) SubmitInitials() {
+	if s.phase != PhaseInitials {
+		return
+	}
+
+	s.Board.Add(Entry{
+		Initials: s.Initials.String(),
+		Total:    s.Total(),
+		Scores:   append([]int(nil), s.scores...),
+	})
+	s.restart()
+}
+
+// IdleReset abandons the current rotation without recording it and starts
+// over from the first game. The leaderboard is kept.
+func (s *Session) IdleReset() {
+	s.restart()
+}
+
+func (s *Session) restart() {
+	s.index = 0
+	s.scores = s.scores[:0]
+	s.idle = 0
+	s.startIntermission()
+}
+
+func (s *Session) startIntermission() {
+	s.phase = PhaseIntermission
+	s.remaining = s.Intermission
+
+	if len(s.Slots) > 0 {
+		s.Current().Game.Reset()
+	}
+}
+
+func (sl *Slot) timeLimit() float64 {
+	if sl.TimeLimit > 0 {
+		return sl.TimeLimit
+	}
+
+	return DefaultTimeLimit
+}
+
+// Entry is one leaderboard row.
+type Entry struct {
+	Initials string
+	Total    int
+	Scores   []int // Per-slot scores that make up Total
+}
+
+// Leaderboard keeps the best Size rotation totals, highest first.
+type Leaderboard struct {
+	Size    int
+	Entries []Entry
+}
+
+// Qualifies reports whether total would place on the board.
+func (b *Leaderboard) Qualifies(total int) bool {
+	if total <= 0 || b.Size <= 0 {
+		return false
+	}
+
+	return len(b.Entries) < b.Size || total > b.Entries[len(b.Entries)-1].Total
+}
+
+// Add inserts e and returns its rank (0 is first), or -1 if it did not place.
+// Ties rank below earlier entries.
+func (b *Leaderboard) Add(e Entry) int {
+	if !b.Qualifies(e.Total) {
+		return -1
+	}
+
+	rank := sort.Search(len(b.Entries), func(i int) bool { return b.Entries[i].Total < e.Total })
+	b.Entries = append(b.Entries, Entry{})
+	copy(b.Entries[rank+1:], b.Entries[rank:])
+	b.Entries[rank] = e
+
+	if len(b.Entries) > b.Size {
+		b.Entries = b.Entries[:b.Size]
+	}
+
+	return rank
+}
+
+// InitialsLength is the number of letters in an initials entry.
+const InitialsLength = 3
+
+// Initials is an arcade-style three-letter name entered one letter at a time.
+type Initials struct {
+	Letters [InitialsLength]byte
+	Cursor  int
+}
+
+// NewInitials returns "AAA" with the cursor on the first letter.
+func NewInitials() Initials {
+	return Initials{Letters: [InitialsLength]byte{'A', 'A', 'A'}}
+}
+
+// Cycle changes the letter under the cursor by delta, wrapping A-Z.
+func (in *Initials) Cycle(delta int) {
+	c := int(in.Letters[in.Cursor]-'A') + delta
+	in.Letters[in.Cursor] = byte('A' + ((c%26)+26)%26)
+}
+
+// Move moves the cursor by delta, clamped to the letters.
+func (in *Initials) Move(delta int) {
+	in.Cursor = min(max(in.Cursor+delta, 0), InitialsLength-1)
+}
+
+// String returns the entered letters.
+func (in Initials) String() string {
+	return string(in.Letters[:])
+}
@@ -0,0 +1,155 @@
+package arcade
+
+import "testing"
+
+// fakeGame is a hosted game whose score and end state the test controls.
+type fakeGame struct {
+	score  int
+	over   bool
+	resets int
+}
+
+func (f *fakeGame) Update() error { return nil }
+func (f *fakeGame) Reset()        { f.resets++; f.score = 0; f.over = false }
+func (f *fakeGame) Score() int    { return f.score }
+func (f *fakeGame) Over() bool    { return f.over }
+
+func newTestSession(boardSize int) (*Session, []*fakeGame) {
+	games := []*fakeGame{{}, {}}
+	s := NewSession([]Slot{
+		{Name: "Snake", Game: games[0], TimeLimit: 10},
+		{Name: "Pong", Game: games[1], TimeLimit: 20},
+	}, boardSize)
+
+	return s, games
+}
+
+// step advances the session by secs in 0.1s frames with input held.
+func step(s *Session, secs float64) {
+	for range int(secs * 10) {
+		s.Update(0.1, true)
+	}
+}
+
+func TestSessionRotatesWithTimeLimits(t *testing.T) {
+	s, games := newTestSession(5)
+
+	if s.Phase() != PhaseIntermission || games[0].resets != 1 {
+		t.Fatalf("start: phase %v, resets %d", s.Phase(), games[0].resets)
+	}
+
+	step(s, DefaultIntermission+0.5)
+
+	if s.Phase() != PhasePlaying || s.Current().Name != "Snake" {
+		t.Fatalf("phase %v on %s, want playing Snake", s.Phase(), s.Current().Name)
+	}
+
+	games[0].score = 40
+	step(s, 10)
+
+	if s.Phase() != PhaseIntermission || s.Current().Name != "Pong" || games[1].resets != 1 {
+		t.Fatalf("after the limit: phase %v on %s", s.Phase(), s.Current().Name)
+	}
+
+	step(s, DefaultIntermission+0.5)
+
+	games[1].score = 60
+	s.EndGame()
+
+	if s.Phase() != PhaseInitials {
+		t.Fatalf("phase %v, want initials after the last game", s.Phase())
+	}
+
+	if s.Total() != 100 {
+		t.Errorf("total = %d, want 100", s.Total())
+	}
+
+	s.Initials.Cycle(2)
+	s.Initials.Move(1)
+	s.Initials.Cycle(-1)
+	s.SubmitInitials()
+
+	if len(s.Board.Entries) != 1 {
+		t.Fatalf("board = %v", s.Board.Entries)
+	}
+
+	e := s.Board.Entries[0]
+	if e.Initials != "CZA" || e.Total != 100 || len(e.Scores) != 2 || e.Scores[0] != 40 {
+		t.Errorf("entry = %+v", e)
+	}
+
+	if s.Phase() != PhaseIntermission || s.Index() != 0 || len(s.Scores()) != 0 {
+		t.Error("rotation did not restart after submitting")
+	}
+}
+
+func TestSessionIdleReset(t *testing.T) {
+	s, games := newTestSession(5)
+	step(s, DefaultIntermission+0.5)
+	games[0].score = 30
+	s.EndGame()
+
+	for range int(DefaultIdleTimeout*10) + 1 {
+		s.Update(0.1, false)
+	}
+
+	if s.Index() != 0 || len(s.Scores()) != 0 || s.Phase() != PhaseIntermission {
+		t.Errorf("idle: index %d, scores %v, phase %v; want a fresh rotation", s.Index(), s.Scores(), s.Phase())
+	}
+
+	if games[0].resets != 2 {
+		t.Errorf("first game reset %d times, want 2", games[0].resets)
+	}
+
+	if len(s.Board.Entries) != 0 {
+		t.Error("idle reset recorded a score")
+	}
+}
+
+func TestSessionSkipsInitialsWhenNotPlacing(t *testing.T) {
+	s, _ := newTestSession(5)
+	step(s, DefaultIntermission+0.5)
+	s.EndGame()
+	step(s, DefaultIntermission+0.5)
+	s.EndGame()
+
+	if s.Phase() != PhaseIntermission || s.Index() != 0 {
+		t.Errorf("zero total: phase %v, index %d; want a new rotation", s.Phase(), s.Index())
+	}
+}
+
+func TestLeaderboardKeepsBest(t *testing.T) {
+	b := Leaderboard{Size: 3}
+
+	for _, total := range []int{50, 80, 20, 80} {
+		b.Add(Entry{Initials: "AAA", Total: total})
+	}
+
+	got := []int{}
+	for _, e := range b.Entries {
+		got = append(got, e.Total)
+	}
+
+	if len(got) != 3 || got[0] != 80 || got[1] != 80 || got[2] != 50 {
+		t.Errorf("totals = %v, want [80 80 50]", got)
+	}
+
+	if b.Qualifies(50) || !b.Qualifies(51) {
+		t.Error("Qualifies should need to beat the last entry on a full board")
+	}
+
+	if rank := b.Add(Entry{Total: 10}); rank != -1 {
+		t.Errorf("rank = %d, want -1", rank)
+	}
+}
+
+func TestInitialsWrap(t *testing.T) {
+	in := NewInitials()
+	in.Cycle(-1)
+	in.Move(5)
+	in.Cycle(27)
+
+	if in.String() != "ZAB" || in.Cursor != InitialsLength-1 {
+		t.Errorf("initials = %s cursor %d, want ZAB cursor 2", in.String(), in.Cursor)
+	}
+}
@@ -0,0 +1,213 @@
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io/fs"
+	"runtime"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ImageProcessor transforms a decoded image on a worker goroutine,
+// e.g. chroma keying or scaling, before it is uploaded to the GPU.
+type ImageProcessor func(image.Image) image.Image
+
+// asyncJob is a queued image load.
+type asyncJob struct {
+	key     string
+	path    string
+	process ImageProcessor
+}
+
+// asyncResult is a decoded (but not yet uploaded) image.
+type asyncResult struct {
+	key  string
+	path string
+	img  image.Image
+	err  error
+}
+
+// AsyncLoader decodes and processes images on worker goroutines so loading does
+// not freeze the window. Decoded images are uploaded to GPU textures on the main
+// thread by calling Poll from Update, which makes it easy to drive a loading scene.
+type AsyncLoader struct {
+	// Placeholders, when set, stand in for images that fail to load; the
+	// errors are still reported by Errors.
+	Placeholders *Placeholders
+
+	fs      fs.FS
+	workers int
+	jobs    []asyncJob
+	results chan asyncResult
+	started bool
+
+	images   map[string]*ebiten.Image
+	errs     map[string]error
+	uploaded int
+}
+
+// NewAsyncLoader creates an async loader reading from fileSystem.
+// workers <= 0 uses one worker per CPU.
+func NewAsyncLoader(fileSystem fs.FS, workers int) *AsyncLoader {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	return &AsyncLoader{
+		fs:      fileSystem,
+		workers: workers,
+		images:  make(map[string]*ebiten.Image),
+		errs:    make(map[string]error),
+	}
+}
+
+// Add queues an image to load under key. process may be nil.
+// Images must be added before Start.
+func (l *AsyncLoader) Add(key, path string, process ImageProcessor) {
+	if l.started {
+		return
+	}
+
+	l.jobs = append(l.jobs, asyncJob{key: key, path: path, process: process})
+}
+
+// Start begins decoding all queued images in the background.
+func (l *AsyncLoader) Start() {
+	if l.started {
+		return
+	}
+
+	l.started = true
+	l.results = make(chan asyncResult, len(l.jobs))
+
+	queue := make(chan asyncJob)
+
+	var wg sync.WaitGroup
+
+	for range min(l.workers, max(len(l.jobs), 1)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range queue {
+				l.results <- l.decode(job)
+			}
+		}()
+	}
+
+	go func() {
+		for _, job := range l.jobs {
+			queue <- job
+		}
+
+		close(queue)
+		wg.Wait()
+		close(l.results)
+	}()
+}
+
+// decode reads, decodes, and processes a single image off the main thread.
+func (l *AsyncLoader) decode(job asyncJob) asyncResult {
+	data, err := fs.ReadFile(l.fs, job.path)
+	if err != nil {
+		err = fmt.Errorf("failed to read image %s: %w", job.path, err)
+
+		return asyncResult{key: job.key, path: job.path, err: err}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		err = fmt.Errorf("failed to decode image %s: %w", job.path, err)
+
+		return asyncResult{key: job.key, path: job.path, err: err}
+	}
+
+	if job.process != nil {
+		img = job.process(img)
+	}
+
+	return asyncResult{key: job.key, img: img}
+}
+
+// Poll uploads up to budget decoded images to GPU textures (budget <= 0 means
+// all that are ready) and returns how many were handled. Call it from Update.
+func (l *AsyncLoader) Poll(budget int) int {
+	if !l.started {
+		return 0
+	}
+
+	handled := 0
+
+	for budget <= 0 || handled < budget {
+		select {
+		case res, ok := <-l.results:
+			if !ok {
+				return handled
+			}
+
+			l.store(res)
+			handled++
+		default:
+			return handled
+		}
+	}
+
+	return handled
+}
+
+// Wait blocks until every queued image has been decoded and uploaded.
+// Useful for tests and headless tools; games should Poll instead.
+func (l *AsyncLoader) Wait() {
+	l.Start()
+
+	for res := range l.results {
+		l.store(res)
+	}
+}
+
+func (l *AsyncLoader) store(res asyncResult) {
+	l.uploaded++
+
+	if res.err != nil {
+		l.errs[res.key] = res.err
+
+		if l.Placeholders != nil {
+			l.Placeholders.Add(KindImage, res.path, res.err)
+			l.images[res.key] = MissingTexture()
+		}
+
+		return
+	}
+
+	l.images[res.key] = ebiten.NewImageFromImage(res.img)
+}
+
+// Progress returns the fraction of queued images that are ready, from 0 to 1.
+func (l *AsyncLoader) Progress() float64 {
+	if len(l.jobs) == 0 {
+		return 1
+	}
+
+	return float64(l.uploaded) / float64(len(l.jobs))
+}
+
+// Done reports whether every queued image has been handled.
+func (l *AsyncLoader) Done() bool {
+	return l.uploaded >= len(l.jobs)
+}
+
+// Image returns the loaded image for key, or nil if not ready or never
+// queued. An image that failed to load is nil too, or the MissingTexture
+// with Placeholders set.
+func (l *AsyncLoader) Image(key string) *ebiten.Image {
+	return l.images[key]
+}
+
+// Errors returns load errors keyed by image key.
+func (l *AsyncLoader) Errors() map[string]error {
+	return l.errs
+}
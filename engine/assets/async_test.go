@@ -0,0 +1,66 @@
+package assets
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"testing/fstest"
+)
+
+func encodePNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(0, 0, c)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestAsyncLoader(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.png":   {Data: encodePNG(t, color.White)},
+		"b.png":   {Data: encodePNG(t, color.Black)},
+		"bad.png": {Data: []byte("not an image")},
+	}
+
+	processed := make(chan string, 3)
+
+	l := NewAsyncLoader(fsys, 2)
+	l.Add("a", "a.png", func(img image.Image) image.Image {
+		processed <- "a"
+
+		return img
+	})
+	l.Add("b", "b.png", nil)
+	l.Add("bad", "bad.png", nil)
+	l.Add("missing", "missing.png", nil)
+
+	if l.Poll(0) != 0 || l.Progress() != 0 {
+		t.Error("nothing should be ready before Start")
+	}
+
+	l.Wait()
+
+	if !l.Done() || l.Progress() != 1 {
+		t.Errorf("Done = %v, Progress = %v", l.Done(), l.Progress())
+	}
+
+	if l.Image("a") == nil || l.Image("b") == nil {
+		t.Error("expected decoded images")
+	}
+
+	if <-processed != "a" {
+		t.Error("processor not run")
+	}
+
+	if len(l.Errors()) != 2 || l.Image("bad") != nil {
+		t.Errorf("errors = %v", l.Errors())
+	}
+}
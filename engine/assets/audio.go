@@ -20,6 +20,9 @@ import (
 const (
 	// DefaultSampleRate is the standard sample rate for audio.
 	DefaultSampleRate = 44100
+
+	// DuckVolume is the share of its volume music keeps while ducked.
+	DuckVolume = 0.3
 )
 
 // ReadSeekerLength combines ReadSeeker with Length method.
@@ -30,16 +33,25 @@ type ReadSeekerLength interface {
 
 // AudioManager handles loading and playing sounds and music.
 type AudioManager struct {
-	context *audio.Context
-	sounds  map[string]*audio.Player
-	music   map[string]*audio.Player
-	pools   map[string]*SoundPool
-	fs      fs.FS
+	// Placeholders, when set, stand in for sound and music files that fail
+	// to load: the name plays silence and the load returns no error.
+	Placeholders *Placeholders
+
+	context  *audio.Context
+	sounds   map[string]*audio.Player
+	music    map[string]*audio.Player
+	pools    map[string]*SoundPool
+	variants map[string]*soundVariants
+	ambient  map[string]*audio.Player
+	fs       fs.FS
 
 	// Volume controls (0.0 to 1.0)
 	masterVolume float64
 	sfxVolume    float64
 	musicVolume  float64
+
+	// Ducked while the game is paused: music quieter, ambient loops silent
+	ducked bool
 }
 
 // SoundPool manages multiple players for concurrent playback of the same sound.
@@ -50,12 +62,21 @@ type SoundPool struct {
 
 // NewAudioManager creates an audio manager.
 // filesystem can be nil if you only plan to load from bytes.
+// Ebitengine allows one audio context per process, so an existing context is
+// reused; a recreated game then shares it instead of panicking.
 func NewAudioManager(filesystem fs.FS) *AudioManager {
+	ctx := audio.CurrentContext()
+	if ctx == nil {
+		ctx = audio.NewContext(DefaultSampleRate)
+	}
+
 	return &AudioManager{
-		context:      audio.NewContext(DefaultSampleRate),
+		context:      ctx,
 		sounds:       make(map[string]*audio.Player),
 		music:        make(map[string]*audio.Player),
 		pools:        make(map[string]*SoundPool),
+		variants:     make(map[string]*soundVariants),
+		ambient:      make(map[string]*audio.Player),
 		fs:           filesystem,
 		masterVolume: 1.0,
 		sfxVolume:    1.0,
@@ -107,7 +128,7 @@ func (m *AudioManager) SetMusicTrackVolume(name string, volume float64) {
 		// So let's just set it relative to master * global music?
 		// If the user calls this, they likely want a specific level.
 		val := m.clampVolume(volume)
-		player.SetVolume(val * m.masterVolume * m.musicVolume)
+		player.SetVolume(val * m.musicLevel())
 	}
 }
 
@@ -123,8 +144,43 @@ func (m *AudioManager) clampVolume(v float64) float64 {
 	return v
 }
 
-func (m *AudioManager) updateMusicVolume() {
+// SetDucked ducks the audio while a game is paused: music drops to
+// DuckVolume of its volume and ambient loops pause until the next
+// ApplyAmbience after it is restored. Sound effects, such as menu clicks,
+// are left alone.
+func (m *AudioManager) SetDucked(ducked bool) {
+	if ducked == m.ducked {
+		return
+	}
+
+	m.ducked = ducked
+	m.updateMusicVolume()
+
+	if ducked {
+		for _, p := range m.ambient {
+			p.Pause()
+		}
+	}
+}
+
+// Ducked reports whether the audio is ducked.
+func (m *AudioManager) Ducked() bool {
+	return m.ducked
+}
+
+// musicLevel returns the volume music plays at: the master and music
+// volumes, ducked while paused.
+func (m *AudioManager) musicLevel() float64 {
 	vol := m.masterVolume * m.musicVolume
+	if m.ducked {
+		vol *= DuckVolume
+	}
+
+	return vol
+}
+
+func (m *AudioManager) updateMusicVolume() {
+	vol := m.musicLevel()
 	for _, p := range m.music {
 		if p.IsPlaying() {
 			p.SetVolume(vol)
@@ -134,18 +190,42 @@ func (m *AudioManager) updateMusicVolume() {
 
 // LoadSound loads a sound effect from file.
 func (m *AudioManager) LoadSound(name, path string) error {
+	data, err := m.readFile("audio", path)
+	if err == nil {
+		err = m.LoadSoundFromBytes(name, data, filepath.Ext(path))
+	}
+
+	if m.placeholder(KindSound, path, err) {
+		return m.LoadSoundFromBytes(name, SilentWAV(), ".wav")
+	}
+
+	return err
+}
+
+// readFile reads an audio file, naming what it is in the error.
+func (m *AudioManager) readFile(what, path string) ([]byte, error) {
 	if m.fs == nil {
-		return errors.New("filesystem is nil")
+		return nil, errors.New("filesystem is nil")
 	}
 
 	data, err := fs.ReadFile(m.fs, path)
 	if err != nil {
-		return fmt.Errorf("failed to read audio %s: %w", path, err)
+		return nil, fmt.Errorf("failed to read %s %s: %w", what, path, err)
 	}
 
-	ext := strings.ToLower(filepath.Ext(path))
+	return data, nil
+}
+
+// placeholder records a failed load and reports whether silence should
+// stand in for it.
+func (m *AudioManager) placeholder(kind AssetKind, path string, err error) bool {
+	if err == nil || m.Placeholders == nil {
+		return false
+	}
 
-	return m.LoadSoundFromBytes(name, data, ext)
+	m.Placeholders.Add(kind, path, err)
+
+	return true
 }
 
 // LoadSoundFromBytes loads a sound effect from raw data.
@@ -168,18 +248,16 @@ func (m *AudioManager) LoadSoundFromBytes(name string, data []byte, format strin
 
 // LoadMusic loads a music track from file.
 func (m *AudioManager) LoadMusic(name, path string) error {
-	if m.fs == nil {
-		return errors.New("filesystem is nil")
+	data, err := m.readFile("music", path)
+	if err == nil {
+		err = m.LoadMusicFromBytes(name, data, filepath.Ext(path))
 	}
 
-	data, err := fs.ReadFile(m.fs, path)
-	if err != nil {
-		return fmt.Errorf("failed to read music %s: %w", path, err)
+	if m.placeholder(KindMusic, path, err) {
+		return m.LoadMusicFromBytes(name, SilentWAV(), ".wav")
 	}
 
-	ext := strings.ToLower(filepath.Ext(path))
-
-	return m.LoadMusicFromBytes(name, data, ext)
+	return err
 }
 
 // LoadMusicFromBytes loads a music track from raw data.
@@ -203,18 +281,16 @@ func (m *AudioManager) LoadMusicFromBytes(name string, data []byte, format strin
 
 // CreatePool creates a sound pool from file.
 func (m *AudioManager) CreatePool(name, path string, size int) error {
-	if m.fs == nil {
-		return errors.New("filesystem is nil")
+	data, err := m.readFile("audio", path)
+	if err == nil {
+		err = m.CreatePoolFromBytes(name, data, size, filepath.Ext(path))
 	}
 
-	data, err := fs.ReadFile(m.fs, path)
-	if err != nil {
-		return fmt.Errorf("failed to read audio %s: %w", path, err)
+	if m.placeholder(KindSound, path, err) {
+		return m.CreatePoolFromBytes(name, SilentWAV(), size, ".wav")
 	}
 
-	ext := strings.ToLower(filepath.Ext(path))
-
-	return m.CreatePoolFromBytes(name, data, size, ext)
+	return err
 }
 
 // CreatePoolFromBytes creates a sound pool from raw data.
@@ -289,6 +365,11 @@ func (m *AudioManager) PlaySoundWithVolume(name string, volume float64) {
 
 // PlayPooled plays a sound from a pool.
 func (m *AudioManager) PlayPooled(name string) {
+	m.PlayPooledWithVolume(name, 1.0)
+}
+
+// PlayPooledWithVolume plays a sound from a pool at a specific volume (0.0 to 1.0).
+func (m *AudioManager) PlayPooledWithVolume(name string, volume float64) {
 	pool, ok := m.pools[name]
 	if !ok {
 		return
@@ -298,14 +379,14 @@ func (m *AudioManager) PlayPooled(name string) {
 	pool.current = (pool.current + 1) % len(pool.players)
 
 	player.Rewind()
-	player.SetVolume(m.masterVolume * m.sfxVolume)
+	player.SetVolume(volume * m.masterVolume * m.sfxVolume)
 	player.Play()
 }
 
 // PlayMusic starts playing a music track.
 func (m *AudioManager) PlayMusic(name string) {
 	if player, ok := m.music[name]; ok {
-		player.SetVolume(m.masterVolume * m.musicVolume)
+		player.SetVolume(m.musicLevel())
 
 		if !player.IsPlaying() {
 			player.Rewind()
@@ -342,7 +423,7 @@ func (m *AudioManager) FadeMusic(name string, targetVolume float64, duration tim
 		steps := 20
 		stepDuration := duration / time.Duration(steps)
 		// Global volume scaling
-		finalTarget := targetVolume * m.masterVolume * m.musicVolume
+		finalTarget := targetVolume * m.musicLevel()
 
 		volumeStep := (finalTarget - startVolume) / float64(steps)
 
@@ -0,0 +1,184 @@
+package assets
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// SoundEvent is a point in an entity's life that can make a sound.
+type SoundEvent int
+
+const (
+	SoundSpawn SoundEvent = iota
+	SoundHit
+	SoundDeath
+)
+
+// SoundEmitter declares the sounds an entity type makes, so giving a new kind
+// of entity audio is a data change rather than PlaySound calls in gameplay
+// code. Names refer to pools, variant groups, or single sounds; empty names
+// are silent.
+type SoundEmitter struct {
+	Spawn, Hit, Death string
+	Ambient           string  // Looped while an entity of this type is in earshot
+	Volume            float64 // Scales every sound; 0 plays at full volume
+}
+
+// Sound returns the sound played on a lifecycle event.
+func (e SoundEmitter) Sound(event SoundEvent) string {
+	switch event {
+	case SoundSpawn:
+		return e.Spawn
+	case SoundHit:
+		return e.Hit
+	case SoundDeath:
+		return e.Death
+	}
+
+	return ""
+}
+
+func (e SoundEmitter) gain() float64 {
+	if e.Volume <= 0 {
+		return 1
+	}
+
+	return e.Volume
+}
+
+// Listener is where positional sounds are heard from.
+type Listener struct {
+	X, Y  float64
+	Range float64 // Distance at which sounds fade to silence; 0 hears everything at full volume
+}
+
+// Volume returns how loud a sound at (x, y) is to the listener, falling
+// linearly from 1 at the listener to 0 at Range.
+func (l Listener) Volume(x, y float64) float64 {
+	if l.Range <= 0 {
+		return 1
+	}
+
+	return max(0, 1-math.Hypot(x-l.X, y-l.Y)/l.Range)
+}
+
+// Emit plays an emitter's sound for a lifecycle event at (x, y), attenuated
+// by the listener's distance.
+func (m *AudioManager) Emit(e SoundEmitter, event SoundEvent, x, y float64, l Listener) {
+	name := e.Sound(event)
+
+	volume := l.Volume(x, y) * e.gain()
+	if name == "" || volume <= 0 {
+		return
+	}
+
+	m.playEffect(name, m.clampVolume(volume))
+}
+
+// playEffect plays a pool, variant group, or single sound by name.
+func (m *AudioManager) playEffect(name string, volume float64) {
+	switch {
+	case m.pools[name] != nil:
+		m.PlayPooledWithVolume(name, volume)
+	case m.variants[name] != nil:
+		m.playVariantWithVolume(name, volume)
+	default:
+		m.PlaySoundWithVolume(name, volume)
+	}
+}
+
+// LoadAmbientFromBytes loads a looping ambient sound for emitters. It stays
+// paused until ApplyAmbience gives it a volume.
+func (m *AudioManager) LoadAmbientFromBytes(name string, data []byte, format string) error {
+	stream, err := m.decodeStream(format, data)
+	if err != nil {
+		return err
+	}
+
+	player, err := m.context.NewPlayer(audio.NewInfiniteLoop(stream, stream.Length()))
+	if err != nil {
+		return fmt.Errorf("failed to create ambient player: %w", err)
+	}
+
+	m.ambient[name] = player
+
+	return nil
+}
+
+// SetAmbientVolume sets an ambient loop's volume (0.0 to 1.0), pausing it
+// at 0 or while ducked and resuming it where it left off otherwise.
+func (m *AudioManager) SetAmbientVolume(name string, volume float64) {
+	player, ok := m.ambient[name]
+	if !ok {
+		return
+	}
+
+	volume = m.clampVolume(volume)
+	if m.ducked {
+		volume = 0
+	}
+	player.SetVolume(volume * m.masterVolume * m.sfxVolume)
+
+	switch {
+	case volume > 0 && !player.IsPlaying():
+		player.Play()
+	case volume <= 0 && player.IsPlaying():
+		player.Pause()
+	}
+}
+
+// ApplyAmbience sets every ambient loop to its level in a; a nil Ambience
+// silences them all.
+func (m *AudioManager) ApplyAmbience(a *Ambience) {
+	for name := range m.ambient {
+		m.SetAmbientVolume(name, a.Level(name))
+	}
+}
+
+// Ambience mixes the ambient loops of many emitters. Each frame, Begin it at
+// the listener, Add every live emitter, and pass it to ApplyAmbience; each
+// loop plays as loud as its nearest source, so a crowd of one monster type
+// sounds like one loop rather than a wall of copies.
+type Ambience struct {
+	Listener Listener
+
+	levels map[string]float64
+}
+
+// Begin clears the mix for a new frame heard from l.
+func (a *Ambience) Begin(l Listener) {
+	a.Listener = l
+
+	if a.levels == nil {
+		a.levels = make(map[string]float64)
+	}
+
+	clear(a.levels)
+}
+
+// Add mixes in an emitter at (x, y).
+func (a *Ambience) Add(e SoundEmitter, x, y float64) {
+	if e.Ambient == "" {
+		return
+	}
+
+	if a.levels == nil {
+		a.levels = make(map[string]float64)
+	}
+
+	volume := a.Listener.Volume(x, y) * e.gain()
+	if volume > a.levels[e.Ambient] {
+		a.levels[e.Ambient] = volume
+	}
+}
+
+// Level returns the volume of an ambient loop in the mix.
+func (a *Ambience) Level(name string) float64 {
+	if a == nil {
+		return 0
+	}
+
+	return a.levels[name]
+}
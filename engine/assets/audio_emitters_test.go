@@ -0,0 +1,101 @@
+package assets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSoundEmitterEvents(t *testing.T) {
+	e := SoundEmitter{Spawn: "roar", Death: "splat"}
+
+	for event, want := range map[SoundEvent]string{SoundSpawn: "roar", SoundHit: "", SoundDeath: "splat"} {
+		if got := e.Sound(event); got != want {
+			t.Errorf("Sound(%d) = %q, want %q", event, got, want)
+		}
+	}
+}
+
+func TestListenerVolumeFallsOffWithDistance(t *testing.T) {
+	l := Listener{X: 100, Y: 100, Range: 200}
+
+	tests := []struct {
+		x, y, want float64
+	}{
+		{100, 100, 1},
+		{200, 100, 0.5},
+		{100, 300, 0},
+		{500, 500, 0},
+	}
+
+	for _, tt := range tests {
+		if got := l.Volume(tt.x, tt.y); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Volume(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	if got := (Listener{}).Volume(1e6, 1e6); got != 1 {
+		t.Errorf("Volume with no range = %v, want 1", got)
+	}
+}
+
+func TestAmbienceTakesNearestSource(t *testing.T) {
+	var a Ambience
+
+	hum := SoundEmitter{Ambient: "hum"}
+	quiet := SoundEmitter{Ambient: "hum", Volume: 0.25}
+
+	a.Begin(Listener{Range: 100})
+	a.Add(hum, 75, 0)
+	a.Add(hum, 50, 0)
+	a.Add(quiet, 0, 0)
+	a.Add(SoundEmitter{Hit: "hit"}, 0, 0)
+
+	if got := a.Level("hum"); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("hum level = %v, want the nearest full-volume source's 0.5", got)
+	}
+
+	a.Begin(Listener{Range: 100})
+
+	if got := a.Level("hum"); got != 0 {
+		t.Errorf("hum level after Begin = %v, want 0", got)
+	}
+
+	if got := (*Ambience)(nil).Level("hum"); got != 0 {
+		t.Errorf("nil ambience level = %v, want 0", got)
+	}
+}
+
+func TestDuckingQuietensMusicAndSilencesAmbience(t *testing.T) {
+	m := NewAudioManager(nil)
+
+	if err := m.LoadMusicFromBytes("bgm", SilentWAV(), ".wav"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.LoadAmbientFromBytes("hum", SilentWAV(), ".wav"); err != nil {
+		t.Fatal(err)
+	}
+
+	m.SetMusicVolume(0.5)
+	m.PlayMusic("bgm")
+	m.SetAmbientVolume("hum", 1)
+
+	m.SetDucked(true)
+
+	if got := m.music["bgm"].Volume(); math.Abs(got-0.5*DuckVolume) > 1e-9 {
+		t.Errorf("ducked music volume = %v, want %v", got, 0.5*DuckVolume)
+	}
+
+	m.SetAmbientVolume("hum", 1)
+
+	if m.ambient["hum"].IsPlaying() {
+		t.Error("ambient loops should stay paused while ducked")
+	}
+
+	m.SetDucked(false)
+	m.SetAmbientVolume("hum", 1)
+
+	if got := m.music["bgm"].Volume(); math.Abs(got-0.5) > 1e-9 || !m.ambient["hum"].IsPlaying() {
+		t.Errorf("music volume %v, ambience playing %v after ducking ended", got, m.ambient["hum"].IsPlaying())
+	}
+}
@@ -0,0 +1,73 @@
+package assets
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// soundVariants groups several pooled takes of one sound effect.
+type soundVariants struct {
+	pools        []string
+	last         int
+	volumeJitter float64
+}
+
+// CreateVariantsFromBytes registers a group of alternative recordings for one
+// sound effect. PlayVariant picks a different take each time and randomizes
+// the volume by up to ±volumeJitter, so rapid repeats (pickups, footsteps)
+// don't sound mechanical.
+func (m *AudioManager) CreateVariantsFromBytes(
+	name string,
+	variants [][]byte,
+	size int,
+	format string,
+	volumeJitter float64,
+) error {
+	group := &soundVariants{last: -1, volumeJitter: volumeJitter}
+
+	for i, data := range variants {
+		poolName := fmt.Sprintf("%s#%d", name, i)
+		if err := m.CreatePoolFromBytes(poolName, data, size, format); err != nil {
+			return fmt.Errorf("failed to create variant %d of %s: %w", i, name, err)
+		}
+
+		group.pools = append(group.pools, poolName)
+	}
+
+	m.variants[name] = group
+
+	return nil
+}
+
+// PlayVariant plays a random take of a variant group, avoiding the previous one.
+func (m *AudioManager) PlayVariant(name string) {
+	m.playVariantWithVolume(name, 1)
+}
+
+// playVariantWithVolume plays a random take of a variant group, scaled by volume.
+func (m *AudioManager) playVariantWithVolume(name string, volume float64) {
+	group, ok := m.variants[name]
+	if !ok || len(group.pools) == 0 {
+		return
+	}
+
+	idx := pickVariant(len(group.pools), group.last, rand.Intn)
+	group.last = idx
+
+	volume *= 1.0 + (rand.Float64()*2-1)*group.volumeJitter
+	m.PlayPooledWithVolume(group.pools[idx], m.clampVolume(volume))
+}
+
+// pickVariant chooses a variant index in [0, n) that differs from last when possible.
+func pickVariant(n, last int, intn func(int) int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	idx := intn(n - 1)
+	if idx >= last && last >= 0 {
+		idx++
+	}
+
+	return idx
+}
@@ -0,0 +1,22 @@
+package assets
+
+import "testing"
+
+func TestPickVariantAvoidsRepeat(t *testing.T) {
+	for last := range 4 {
+		for roll := range 3 {
+			idx := pickVariant(4, last, func(int) int { return roll })
+			if idx == last || idx < 0 || idx >= 4 {
+				t.Errorf("pickVariant(4, %d) with roll %d = %d", last, roll, idx)
+			}
+		}
+	}
+
+	if idx := pickVariant(1, 0, func(int) int { return 0 }); idx != 0 {
+		t.Errorf("single variant should always be 0, got %d", idx)
+	}
+
+	if idx := pickVariant(3, -1, func(int) int { return 1 }); idx != 1 {
+		t.Errorf("first play should use the roll directly, got %d", idx)
+	}
+}
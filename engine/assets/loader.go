@@ -14,6 +14,9 @@ import (
 
 // Loader handles loading and caching game assets.
 type Loader struct {
+	// Placeholders, when set, stand in for images that fail to load.
+	Placeholders *Placeholders
+
 	images map[string]*ebiten.Image
 	fs     fs.FS
 }
@@ -37,13 +40,32 @@ func NewEmbedLoader(efs embed.FS, root string) (*Loader, error) {
 	return NewLoader(subFS), nil
 }
 
-// LoadImage loads an image from the filesystem and caches it.
+// LoadImage loads an image from the filesystem and caches it. With
+// Placeholders set, an image that fails to load is recorded and cached as
+// the MissingTexture instead of returning an error.
 func (l *Loader) LoadImage(path string) (*ebiten.Image, error) {
 	// Check cache first
 	if img, ok := l.images[path]; ok {
 		return img, nil
 	}
 
+	img, err := l.loadImage(path)
+	if err != nil && l.Placeholders != nil {
+		l.Placeholders.Add(KindImage, path, err)
+		img, err = MissingTexture(), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	l.images[path] = img
+
+	return img, nil
+}
+
+// loadImage reads and decodes an image.
+func (l *Loader) loadImage(path string) (*ebiten.Image, error) {
 	// Read file
 	data, err := fs.ReadFile(l.fs, path)
 	if err != nil {
@@ -57,10 +79,7 @@ func (l *Loader) LoadImage(path string) (*ebiten.Image, error) {
 	}
 
 	// Convert to Ebitengine image
-	eImg := ebiten.NewImageFromImage(img)
-	l.images[path] = eImg
-
-	return eImg, nil
+	return ebiten.NewImageFromImage(img), nil
 }
 
 // LoadAllImages loads all PNG images from a directory.
@@ -0,0 +1,162 @@
+package assets
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AssetKind is the sort of asset a placeholder stands in for.
+type AssetKind string
+
+const (
+	KindImage AssetKind = "image"
+	KindSound AssetKind = "sound"
+	KindMusic AssetKind = "music"
+)
+
+// MissingAsset is an asset that failed to load and was replaced.
+type MissingAsset struct {
+	Kind AssetKind
+	Path string
+	Err  error
+}
+
+// Placeholders stands in for assets that fail to load, so a game with a
+// missing or corrupt file still runs: images become a magenta and black
+// "missing texture" checkerboard that is easy to spot, and sounds and music
+// become silence. Each replacement is recorded for a startup Report.
+//
+// Give one to a Loader, AsyncLoader, or AudioManager through its
+// Placeholders field; without one they return the errors as before.
+type Placeholders struct {
+	mu      sync.Mutex
+	missing []MissingAsset
+}
+
+// NewPlaceholders returns placeholders with nothing missing yet.
+func NewPlaceholders() *Placeholders {
+	return &Placeholders{}
+}
+
+// Add records that path could not be loaded as kind.
+func (p *Placeholders) Add(kind AssetKind, path string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.missing = append(p.missing, MissingAsset{Kind: kind, Path: path, Err: err})
+}
+
+// Missing returns every replaced asset, by kind and then path, so reports
+// read the same however the loaders' workers were scheduled.
+func (p *Placeholders) Missing() []MissingAsset {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	missing := slices.Clone(p.missing)
+	slices.SortFunc(missing, func(a, b MissingAsset) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Path, b.Path))
+	})
+
+	return missing
+}
+
+// Report lists the missing assets one per line under a count, or returns ""
+// when nothing is missing.
+func (p *Placeholders) Report() string {
+	missing := p.Missing()
+	if len(missing) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d missing assets replaced by placeholders:", len(missing))
+
+	for _, m := range missing {
+		fmt.Fprintf(&b, "\n  %-5s %s: %v", m.Kind, m.Path, m.Err)
+	}
+
+	return b.String()
+}
+
+// Missing texture layout.
+const (
+	missingTextureSize = 32
+	missingCheckSize   = 8
+)
+
+var (
+	missingMagenta = color.RGBA{R: 255, G: 0, B: 255, A: 255}
+	missingBlack   = color.RGBA{A: 255}
+
+	missingTexture *ebiten.Image // Created on first use
+)
+
+// MissingImage returns a new magenta and black checkerboard, the classic
+// look of a texture that failed to load.
+func MissingImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, missingTextureSize, missingTextureSize))
+
+	for y := range missingTextureSize {
+		for x := range missingTextureSize {
+			c := missingBlack
+			if (x/missingCheckSize+y/missingCheckSize)%2 == 0 {
+				c = missingMagenta
+			}
+
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+// MissingTexture returns the shared MissingImage texture. Call it from the
+// game thread; it is created on the first call.
+func MissingTexture() *ebiten.Image {
+	if missingTexture == nil {
+		missingTexture = ebiten.NewImageFromImage(MissingImage())
+	}
+
+	return missingTexture
+}
+
+// silenceSeconds is the length of the silent stand-in for a sound.
+const silenceSeconds = 0.1
+
+// SilentWAV returns a short silent WAV file at DefaultSampleRate, 16-bit
+// stereo, which every audio loader accepts.
+func SilentWAV() []byte {
+	const (
+		channels   = 2
+		bytesPer   = 2
+		headerSize = 44
+	)
+
+	size := int(silenceSeconds*DefaultSampleRate) * channels * bytesPer
+	wav := make([]byte, headerSize+size)
+
+	le := binary.LittleEndian
+	copy(wav[0:], "RIFF")
+	le.PutUint32(wav[4:], uint32(headerSize-8+size))
+	copy(wav[8:], "WAVEfmt ")
+	le.PutUint32(wav[16:], 16) // fmt chunk size
+	le.PutUint16(wav[20:], 1)  // PCM
+	le.PutUint16(wav[22:], channels)
+	le.PutUint32(wav[24:], DefaultSampleRate)
+	le.PutUint32(wav[28:], DefaultSampleRate*channels*bytesPer)
+	le.PutUint16(wav[32:], channels*bytesPer)
+	le.PutUint16(wav[34:], bytesPer*8)
+	copy(wav[36:], "data")
+	le.PutUint32(wav[40:], uint32(size))
+
+	return wav
+}
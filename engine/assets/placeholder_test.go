@@ -0,0 +1,99 @@
+package assets
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+func TestAsyncLoaderPlaceholders(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.png":   {Data: encodePNG(t, color.White)},
+		"bad.png": {Data: []byte("not an image")},
+	}
+
+	p := NewPlaceholders()
+
+	l := NewAsyncLoader(fsys, 2)
+	l.Placeholders = p
+	l.Add("a", "a.png", nil)
+	l.Add("bad", "bad.png", nil)
+	l.Add("missing", "chars/missing.png", nil)
+	l.Wait()
+
+	if l.Image("bad") != MissingTexture() || l.Image("missing") != MissingTexture() {
+		t.Error("failed images should resolve to the missing texture")
+	}
+
+	if l.Image("a") == MissingTexture() || len(l.Errors()) != 2 {
+		t.Errorf("loaded image replaced, or errors = %v", l.Errors())
+	}
+
+	missing := p.Missing()
+	if len(missing) != 2 || missing[0].Path != "bad.png" || missing[1].Path != "chars/missing.png" {
+		t.Fatalf("Missing() = %v, want bad.png then chars/missing.png", missing)
+	}
+
+	report := p.Report()
+	if !strings.HasPrefix(report, "2 missing assets") || !strings.Contains(report, "image chars/missing.png") {
+		t.Errorf("Report() = %q", report)
+	}
+}
+
+func TestLoaderPlaceholders(t *testing.T) {
+	l := NewLoader(fstest.MapFS{})
+
+	if _, err := l.LoadImage("gone.png"); err == nil {
+		t.Error("without placeholders a missing image is an error")
+	}
+
+	l.Placeholders = NewPlaceholders()
+
+	img, err := l.LoadImage("gone.png")
+	if err != nil || img != MissingTexture() || l.GetImage("gone.png") != img {
+		t.Errorf("LoadImage = %v, %v; want the cached missing texture", img, err)
+	}
+}
+
+func TestPlaceholdersReportEmpty(t *testing.T) {
+	p := NewPlaceholders()
+	if r := p.Report(); r != "" {
+		t.Errorf("Report() with nothing missing = %q", r)
+	}
+
+	p.Add(KindSound, "sfx/hit.wav", errors.New("gone"))
+
+	if r := p.Report(); !strings.Contains(r, "sound sfx/hit.wav: gone") {
+		t.Errorf("Report() = %q", r)
+	}
+}
+
+func TestMissingImageIsCheckerboard(t *testing.T) {
+	img := MissingImage()
+
+	if img.RGBAAt(0, 0) != missingMagenta || img.RGBAAt(missingCheckSize, 0) != missingBlack ||
+		img.RGBAAt(missingCheckSize, missingCheckSize) != missingMagenta {
+		t.Error("missing image is not a magenta and black checkerboard")
+	}
+}
+
+func TestSilentWAVDecodes(t *testing.T) {
+	stream, err := wav.DecodeWithSampleRate(DefaultSampleRate, bytes.NewReader(SilentWAV()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stream.Length() == 0 {
+		t.Error("silence has no samples")
+	}
+
+	buf := make([]byte, 64)
+	if n, _ := stream.Read(buf); n == 0 || bytes.Count(buf[:n], []byte{0}) != n {
+		t.Errorf("silence reads %v", buf[:n])
+	}
+}
@@ -0,0 +1,218 @@
+// Package chunks streams per-chunk world state in and out of memory.
+//
+// Chunks are generated on first visit, kept resident in an LRU cache, and
+// serialized when evicted so changes (destroyed crates, opened chests, ...)
+// are restored if the player returns.
+package chunks
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Key identifies a chunk by its grid coordinates.
+type Key struct {
+	X, Y int
+}
+
+// KeyAt returns the key of the chunk containing world position (x, y).
+func KeyAt(x, y, size float64) Key {
+	return Key{X: int(math.Floor(x / size)), Y: int(math.Floor(y / size))}
+}
+
+// Around returns the keys within radius chunks of center, center first.
+func Around(center Key, radius int) []Key {
+	keys := make([]Key, 0, (2*radius+1)*(2*radius+1))
+	keys = append(keys, center)
+
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			keys = append(keys, Key{X: center.X + dx, Y: center.Y + dy})
+		}
+	}
+
+	return keys
+}
+
+// GenerateFunc creates the initial state of a chunk that has never been visited.
+// It should be deterministic so unmodified chunks can be dropped and regenerated.
+type GenerateFunc[T any] func(k Key) T
+
+// Stats reports store activity, useful for debug overlays.
+type Stats struct {
+	Resident  int
+	Saved     int
+	SavedSize int // Bytes of serialized chunk state
+	Generated int
+	Restored  int
+	Evicted   int
+}
+
+type entry[T any] struct {
+	key   Key
+	state *T
+	dirty bool
+}
+
+// Store keeps up to Capacity chunks in memory. Evicted chunks that were marked
+// dirty are serialized to JSON; clean chunks are simply regenerated on return.
+type Store[T any] struct {
+	Capacity int
+
+	generate GenerateFunc[T]
+	resident map[Key]*list.Element
+	lru      *list.List // Front is most recently used
+	saved    map[Key][]byte
+	stats    Stats
+}
+
+// NewStore creates a chunk store holding at most capacity resident chunks.
+func NewStore[T any](capacity int, generate GenerateFunc[T]) *Store[T] {
+	return &Store[T]{
+		Capacity: capacity,
+		generate: generate,
+		resident: make(map[Key]*list.Element),
+		lru:      list.New(),
+		saved:    make(map[Key][]byte),
+	}
+}
+
+// Get returns the chunk state for k, loading or generating it if needed.
+// The returned pointer is valid until the chunk is evicted.
+func (s *Store[T]) Get(k Key) (*T, error) {
+	if el, ok := s.resident[k]; ok {
+		s.lru.MoveToFront(el)
+
+		return el.Value.(*entry[T]).state, nil
+	}
+
+	e := &entry[T]{key: k}
+
+	if data, ok := s.saved[k]; ok {
+		var state T
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to restore chunk %v: %w", k, err)
+		}
+
+		e.state = &state
+		// Keep it dirty so the restored changes are saved again on eviction
+		e.dirty = true
+		s.stats.Restored++
+	} else {
+		state := s.generate(k)
+		e.state = &state
+		s.stats.Generated++
+	}
+
+	s.resident[k] = s.lru.PushFront(e)
+
+	if err := s.trim(); err != nil {
+		return nil, err
+	}
+
+	return e.state, nil
+}
+
+// Loaded reports whether the chunk is currently resident.
+func (s *Store[T]) Loaded(k Key) bool {
+	_, ok := s.resident[k]
+
+	return ok
+}
+
+// MarkDirty flags a resident chunk as modified so it is saved on eviction.
+func (s *Store[T]) MarkDirty(k Key) {
+	if el, ok := s.resident[k]; ok {
+		el.Value.(*entry[T]).dirty = true
+	}
+}
+
+// Each calls fn for every resident chunk, most recently used first.
+func (s *Store[T]) Each(fn func(k Key, state *T)) {
+	for el := s.lru.Front(); el != nil; el = el.Next() {
+		e := el.Value.(*entry[T])
+		fn(e.key, e.state)
+	}
+}
+
+// Evict serializes (if dirty) and unloads a resident chunk.
+func (s *Store[T]) Evict(k Key) error {
+	el, ok := s.resident[k]
+	if !ok {
+		return nil
+	}
+
+	e := el.Value.(*entry[T])
+	if e.dirty {
+		data, err := json.Marshal(e.state)
+		if err != nil {
+			return fmt.Errorf("failed to save chunk %v: %w", k, err)
+		}
+
+		s.saved[k] = data
+	}
+
+	s.lru.Remove(el)
+	delete(s.resident, k)
+	s.stats.Evicted++
+
+	return nil
+}
+
+// Flush serializes all dirty resident chunks without unloading them.
+func (s *Store[T]) Flush() error {
+	for el := s.lru.Front(); el != nil; el = el.Next() {
+		e := el.Value.(*entry[T])
+		if !e.dirty {
+			continue
+		}
+
+		data, err := json.Marshal(e.state)
+		if err != nil {
+			return fmt.Errorf("failed to save chunk %v: %w", e.key, err)
+		}
+
+		s.saved[e.key] = data
+	}
+
+	return nil
+}
+
+// Reset drops all resident and saved chunks, e.g. when starting a new run.
+func (s *Store[T]) Reset() {
+	s.resident = make(map[Key]*list.Element)
+	s.lru.Init()
+	s.saved = make(map[Key][]byte)
+	s.stats = Stats{}
+}
+
+// Stats returns current cache statistics.
+func (s *Store[T]) Stats() Stats {
+	st := s.stats
+	st.Resident = len(s.resident)
+	st.Saved = len(s.saved)
+
+	for _, data := range s.saved {
+		st.SavedSize += len(data)
+	}
+
+	return st
+}
+
+// trim evicts least recently used chunks until the store is within capacity.
+func (s *Store[T]) trim() error {
+	for s.Capacity > 0 && s.lru.Len() > s.Capacity {
+		back := s.lru.Back().Value.(*entry[T])
+		if err := s.Evict(back.key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
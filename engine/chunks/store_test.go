@@ -0,0 +1,106 @@
+package chunks
+
+import "testing"
+
+type crateChunk struct {
+	Crates []bool `json:"crates"` // true = destroyed
+}
+
+func newTestStore(capacity int) (*Store[crateChunk], *int) {
+	generated := 0
+	s := NewStore(capacity, func(Key) crateChunk {
+		generated++
+
+		return crateChunk{Crates: make([]bool, 3)}
+	})
+
+	return s, &generated
+}
+
+func TestStoreRestoresDirtyChunks(t *testing.T) {
+	s, generated := newTestStore(2)
+
+	home := Key{0, 0}
+
+	c, err := s.Get(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Crates[1] = true
+	s.MarkDirty(home)
+
+	// Roam away far enough to evict the home chunk
+	for x := 1; x <= 3; x++ {
+		if _, err := s.Get(Key{x, 0}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s.Loaded(home) {
+		t.Fatal("home chunk should have been evicted")
+	}
+
+	c, err = s.Get(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !c.Crates[1] {
+		t.Error("destroyed crate was not restored")
+	}
+
+	if *generated != 4 {
+		t.Errorf("generated = %d, want 4 (home restored, not regenerated)", *generated)
+	}
+
+	st := s.Stats()
+	if st.Resident != 2 || st.Restored != 1 || st.Saved != 1 {
+		t.Errorf("stats = %+v", st)
+	}
+}
+
+func TestStoreDropsCleanChunks(t *testing.T) {
+	s, generated := newTestStore(1)
+
+	if _, err := s.Get(Key{0, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get(Key{1, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get(Key{0, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if st := s.Stats(); st.Saved != 0 || *generated != 3 {
+		t.Errorf("clean chunks should regenerate, stats = %+v generated = %d", st, *generated)
+	}
+}
+
+func TestStoreLRUOrder(t *testing.T) {
+	s, _ := newTestStore(2)
+
+	for _, k := range []Key{{0, 0}, {1, 0}, {0, 0}, {2, 0}} {
+		if _, err := s.Get(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// {0,0} was touched more recently than {1,0}, so {1,0} is evicted
+	if !s.Loaded(Key{0, 0}) || s.Loaded(Key{1, 0}) {
+		t.Error("least recently used chunk should be evicted first")
+	}
+}
+
+func TestKeyAt(t *testing.T) {
+	if k := KeyAt(-1, 250, 100); k != (Key{-1, 2}) {
+		t.Errorf("KeyAt = %v, want {-1 2}", k)
+	}
+
+	if keys := Around(Key{0, 0}, 1); len(keys) != 9 || keys[0] != (Key{0, 0}) {
+		t.Errorf("Around = %v", keys)
+	}
+}
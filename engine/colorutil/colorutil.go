@@ -0,0 +1,213 @@
+// Package colorutil converts and blends colors for drawing: HSV, lerps,
+// brightening, alpha fades, and palette ramps.
+//
+// Colors are color.RGBA in, as game data stores them. Ebiten treats
+// color.RGBA as alpha-premultiplied, so lowering only the A channel of an
+// opaque color draws it brighter than intended; fade with ScaleAlpha, which
+// scales every channel, or WithAlpha, which returns straight-alpha NRGBA.
+package colorutil
+
+import (
+	"image/color"
+	"math"
+)
+
+// ToHSV returns hue in degrees [0, 360) and saturation and value in [0, 1].
+// Alpha is ignored.
+func ToHSV(c color.RGBA) (h, s, v float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	hi, lo := max(r, g, b), min(r, g, b)
+	d := hi - lo
+
+	switch {
+	case d == 0:
+	case hi == r:
+		h = 60 * math.Mod((g-b)/d, 6)
+	case hi == g:
+		h = 60 * ((b-r)/d + 2)
+	default:
+		h = 60 * ((r-g)/d + 4)
+	}
+
+	if h < 0 {
+		h += 360
+	}
+
+	if hi > 0 {
+		s = d / hi
+	}
+
+	return h, s, hi
+}
+
+// FromHSV returns the opaque color for hue in degrees, wrapped into
+// [0, 360), and saturation and value clamped to [0, 1].
+func FromHSV(h, s, v float64) color.RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	s, v = clamp01(s), clamp01(v)
+
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+
+	switch {
+	case h < 60:
+		r, g = c, x
+	case h < 120:
+		r, g = x, c
+	case h < 180:
+		g, b = c, x
+	case h < 240:
+		g, b = x, c
+	case h < 300:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+
+	return color.RGBA{R: channel(r + m), G: channel(g + m), B: channel(b + m), A: 255}
+}
+
+// RotateHue turns c around the color wheel by degrees, keeping its
+// saturation, value, and alpha.
+func RotateHue(c color.RGBA, degrees float64) color.RGBA {
+	h, s, v := ToHSV(c)
+	out := FromHSV(h+degrees, s, v)
+	out.A = c.A
+
+	return out
+}
+
+// Lerp blends a toward b, channel by channel, with t clamped to [0, 1].
+func Lerp(a, b color.RGBA, t float64) color.RGBA {
+	t = clamp01(t)
+
+	return color.RGBA{
+		R: lerp8(a.R, b.R, t),
+		G: lerp8(a.G, b.G, t),
+		B: lerp8(a.B, b.B, t),
+		A: lerp8(a.A, b.A, t),
+	}
+}
+
+// Brighten moves c toward white by amount in [0, 1], keeping its alpha:
+// 0 leaves it unchanged and 1 is white. Unlike adding a constant to each
+// channel, bright channels never clip while dim ones catch up.
+func Brighten(c color.RGBA, amount float64) color.RGBA {
+	out := Lerp(c, color.RGBA{R: 255, G: 255, B: 255, A: c.A}, amount)
+	out.A = c.A
+
+	return out
+}
+
+// Darken moves c toward black by amount in [0, 1], keeping its alpha.
+func Darken(c color.RGBA, amount float64) color.RGBA {
+	out := Lerp(c, color.RGBA{A: c.A}, amount)
+	out.A = c.A
+
+	return out
+}
+
+// WithAlpha returns c as a straight-alpha color with alpha a, for drawing
+// an opaque color translucently.
+func WithAlpha(c color.RGBA, a uint8) color.NRGBA {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: a}
+}
+
+// ScaleAlpha fades the premultiplied color c by f in [0, 1], scaling every
+// channel so the color keeps its hue as it fades out.
+func ScaleAlpha(c color.RGBA, f float64) color.RGBA {
+	f = clamp01(f)
+
+	return color.RGBA{
+		R: channel(float64(c.R) / 255 * f),
+		G: channel(float64(c.G) / 255 * f),
+		B: channel(float64(c.B) / 255 * f),
+		A: channel(float64(c.A) / 255 * f),
+	}
+}
+
+// Premultiply converts a straight-alpha color to premultiplied color.RGBA.
+func Premultiply(c color.NRGBA) color.RGBA {
+	a := float64(c.A) / 255
+
+	return color.RGBA{
+		R: channel(float64(c.R) / 255 * a),
+		G: channel(float64(c.G) / 255 * a),
+		B: channel(float64(c.B) / 255 * a),
+		A: c.A,
+	}
+}
+
+// Unpremultiply converts a premultiplied color to straight alpha. Fully
+// transparent colors come back as transparent black.
+func Unpremultiply(c color.RGBA) color.NRGBA {
+	if c.A == 0 {
+		return color.NRGBA{}
+	}
+
+	a := float64(c.A) / 255
+
+	return color.NRGBA{
+		R: channel(float64(c.R) / 255 / a),
+		G: channel(float64(c.G) / 255 / a),
+		B: channel(float64(c.B) / 255 / a),
+		A: c.A,
+	}
+}
+
+// RampAt samples a gradient through evenly spaced stops at t in [0, 1].
+// It returns the zero color when stops is empty.
+func RampAt(stops []color.RGBA, t float64) color.RGBA {
+	switch len(stops) {
+	case 0:
+		return color.RGBA{}
+	case 1:
+		return stops[0]
+	}
+
+	pos := clamp01(t) * float64(len(stops)-1)
+	i := min(int(pos), len(stops)-2)
+
+	return Lerp(stops[i], stops[i+1], pos-float64(i))
+}
+
+// Ramp returns n colors evenly spaced along the gradient through stops,
+// from the first stop to the last, for heat maps, health tints, and
+// palette swatches.
+func Ramp(stops []color.RGBA, n int) []color.RGBA {
+	if n <= 0 {
+		return nil
+	}
+
+	ramp := make([]color.RGBA, n)
+	for i := range ramp {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+
+		ramp[i] = RampAt(stops, t)
+	}
+
+	return ramp
+}
+
+func lerp8(a, b uint8, t float64) uint8 {
+	return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+}
+
+// channel converts a [0, 1] intensity to an 8-bit channel, clamping.
+func channel(f float64) uint8 {
+	return uint8(math.Round(clamp01(f) * 255))
+}
+
+func clamp01(f float64) float64 {
+	return min(max(f, 0), 1)
+}
@@ -0,0 +1,129 @@
+package colorutil
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestHSVRoundTrip(t *testing.T) {
+	for _, c := range []color.RGBA{
+		{R: 255, A: 255},
+		{G: 255, A: 255},
+		{B: 255, A: 255},
+		{R: 255, G: 200, B: 50, A: 255},
+		{R: 12, G: 80, B: 140, A: 255},
+		{R: 128, G: 128, B: 128, A: 255},
+		{A: 255},
+	} {
+		h, s, v := ToHSV(c)
+		if got := FromHSV(h, s, v); got != c {
+			t.Errorf("FromHSV(ToHSV(%v)) = %v", c, got)
+		}
+	}
+}
+
+func TestFromHSVWrapsHue(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+
+	for _, h := range []float64{0, 360, -360, 720} {
+		if got := FromHSV(h, 1, 1); got != red {
+			t.Errorf("FromHSV(%v, 1, 1) = %v, want red", h, got)
+		}
+	}
+
+	if got := RotateHue(color.RGBA{R: 255, A: 128}, 120); got != (color.RGBA{G: 255, A: 128}) {
+		t.Errorf("RotateHue(red, 120) = %v, want green keeping alpha", got)
+	}
+}
+
+func TestLerpClampsT(t *testing.T) {
+	a := color.RGBA{A: 255}
+	b := color.RGBA{R: 200, G: 100, B: 50, A: 255}
+
+	if got := Lerp(a, b, 0.5); got != (color.RGBA{R: 100, G: 50, B: 25, A: 255}) {
+		t.Errorf("Lerp at 0.5 = %v", got)
+	}
+
+	if Lerp(a, b, -1) != a || Lerp(a, b, 2) != b {
+		t.Error("Lerp did not clamp t to [0, 1]")
+	}
+}
+
+func TestBrightenAndDarkenKeepAlpha(t *testing.T) {
+	c := color.RGBA{R: 255, G: 100, B: 0, A: 100}
+
+	if got := Brighten(c, 0.5); got != (color.RGBA{R: 255, G: 178, B: 128, A: 100}) {
+		t.Errorf("Brighten = %v", got)
+	}
+
+	if got := Darken(c, 0.5); got != (color.RGBA{R: 128, G: 50, B: 0, A: 100}) {
+		t.Errorf("Darken = %v", got)
+	}
+}
+
+func TestScaleAlphaScalesEveryChannel(t *testing.T) {
+	c := color.RGBA{R: 200, G: 100, B: 0, A: 255}
+
+	got := ScaleAlpha(c, 0.5)
+	if got != (color.RGBA{R: 100, G: 50, B: 0, A: 128}) {
+		t.Errorf("ScaleAlpha = %v", got)
+	}
+
+	if got.R > got.A || got.G > got.A {
+		t.Errorf("ScaleAlpha(%v) = %v is not a valid premultiplied color", c, got)
+	}
+}
+
+func TestPremultiplyRoundTrip(t *testing.T) {
+	n := color.NRGBA{R: 200, G: 100, B: 40, A: 128}
+
+	p := Premultiply(n)
+	if p != (color.RGBA{R: 100, G: 50, B: 20, A: 128}) {
+		t.Errorf("Premultiply = %v", p)
+	}
+
+	// 8-bit premultiplied channels lose precision, so allow off-by-one
+	got := Unpremultiply(p)
+	if diff(got.R, n.R) > 1 || diff(got.G, n.G) > 1 || diff(got.B, n.B) > 1 || got.A != n.A {
+		t.Errorf("Unpremultiply(Premultiply(%v)) = %v", n, got)
+	}
+
+	if got := Unpremultiply(color.RGBA{}); got != (color.NRGBA{}) {
+		t.Errorf("Unpremultiply(transparent) = %v", got)
+	}
+
+	// The standard library agrees on the premultiplied form
+	r, g, b, a := n.RGBA()
+	if want := (color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}); p != want {
+		t.Errorf("Premultiply = %v, image/color gives %v", p, want)
+	}
+}
+
+func TestRampHitsStops(t *testing.T) {
+	stops := []color.RGBA{
+		{R: 255, A: 255},
+		{R: 255, G: 255, A: 255},
+		{G: 255, A: 255},
+	}
+
+	ramp := Ramp(stops, 5)
+	if len(ramp) != 5 {
+		t.Fatalf("len(Ramp) = %d, want 5", len(ramp))
+	}
+
+	if ramp[0] != stops[0] || ramp[2] != stops[1] || ramp[4] != stops[2] {
+		t.Errorf("ramp %v does not pass through stops %v", ramp, stops)
+	}
+
+	if ramp[1] != (color.RGBA{R: 255, G: 128, A: 255}) {
+		t.Errorf("ramp[1] = %v, want halfway red to yellow", ramp[1])
+	}
+
+	if Ramp(stops, 1)[0] != stops[0] || Ramp(nil, 3)[0] != (color.RGBA{}) || Ramp(stops, 0) != nil {
+		t.Error("Ramp mishandled a degenerate size or empty stops")
+	}
+}
+
+func diff(a, b uint8) uint8 {
+	return max(a, b) - min(a, b)
+}
@@ -0,0 +1,271 @@
+// Package combatlog records recent combat events (damage, kills, level-ups,
+// drops) and shows them in a toggleable, filterable overlay.
+//
+// Games publish Entry values on an events.Bus; any Log subscribed to that bus
+// records them, so systems never need a reference to the log itself.
+package combatlog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Category groups entries for filtering.
+type Category int
+
+const (
+	DamageDealt Category = iota
+	DamageTaken
+	Heal
+	Kill
+	LevelUp
+	Drop
+	CategoryCount
+)
+
+var categoryNames = [CategoryCount]string{"Dealt", "Taken", "Heal", "Kill", "Level", "Drop"}
+
+func (c Category) String() string {
+	if c < 0 || c >= CategoryCount {
+		return "Other"
+	}
+
+	return categoryNames[c]
+}
+
+// Entry is a single combat event.
+type Entry struct {
+	Time     float64 // Seconds since the log started; 0 = stamp on arrival
+	Category Category
+	Source   string
+	Target   string
+	Amount   int
+	Detail   string // e.g. weapon or skill name
+}
+
+// String formats the entry as a single log line.
+func (e Entry) String() string {
+	stamp := fmt.Sprintf("[%02d:%02d]", int(e.Time)/60, int(e.Time)%60)
+
+	var line string
+
+	switch e.Category {
+	case DamageDealt, DamageTaken:
+		line = fmt.Sprintf("%s hit %s for %d", e.Source, e.Target, e.Amount)
+	case Heal:
+		line = fmt.Sprintf("%s healed %s for %d", e.Source, e.Target, e.Amount)
+	case Kill:
+		line = fmt.Sprintf("%s killed %s", e.Source, e.Target)
+	case LevelUp:
+		line = fmt.Sprintf("%s reached level %d", e.Source, e.Amount)
+	case Drop:
+		line = fmt.Sprintf("%s dropped %s", e.Source, e.Target)
+	default:
+		line = e.Source
+	}
+
+	if e.Detail != "" {
+		line += " (" + e.Detail + ")"
+	}
+
+	return stamp + " " + line
+}
+
+// Log keeps the most recent entries and renders them as an overlay.
+type Log struct {
+	Capacity  int
+	Visible   bool
+	ToggleKey ebiten.Key
+	ExportKey ebiten.Key
+	ExportDir string
+
+	entries []Entry
+	hidden  [CategoryCount]bool
+	clock   float64
+	status  string
+	unsub   func()
+}
+
+// NewLog creates a log holding up to capacity entries. If bus is non-nil,
+// Entry events published on it are recorded.
+func NewLog(bus *events.Bus, capacity int) *Log {
+	l := &Log{
+		Capacity:  capacity,
+		ToggleKey: ebiten.KeyL,
+		ExportKey: ebiten.KeyF8,
+		ExportDir: ".",
+	}
+
+	if bus != nil {
+		l.unsub = events.Subscribe(bus, l.Add)
+	}
+
+	return l
+}
+
+// Close detaches the log from its event bus.
+func (l *Log) Close() {
+	if l.unsub != nil {
+		l.unsub()
+		l.unsub = nil
+	}
+}
+
+// Add records an entry, dropping the oldest once the log is full.
+func (l *Log) Add(e Entry) {
+	if e.Time == 0 {
+		e.Time = l.clock
+	}
+
+	l.entries = append(l.entries, e)
+	if l.Capacity > 0 && len(l.entries) > l.Capacity {
+		l.entries = append(l.entries[:0], l.entries[len(l.entries)-l.Capacity:]...)
+	}
+}
+
+// Clear removes all entries.
+func (l *Log) Clear() {
+	l.entries = l.entries[:0]
+	l.clock = 0
+}
+
+// Len returns the number of recorded entries, ignoring filters.
+func (l *Log) Len() int {
+	return len(l.entries)
+}
+
+// ToggleCategory shows or hides a category.
+func (l *Log) ToggleCategory(c Category) {
+	if c >= 0 && c < CategoryCount {
+		l.hidden[c] = !l.hidden[c]
+	}
+}
+
+// Shows returns true if the category is not filtered out.
+func (l *Log) Shows(c Category) bool {
+	return c < 0 || c >= CategoryCount || !l.hidden[c]
+}
+
+// Entries returns the visible entries, oldest first.
+func (l *Log) Entries() []Entry {
+	result := make([]Entry, 0, len(l.entries))
+
+	for _, e := range l.entries {
+		if l.Shows(e.Category) {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// Export writes the visible entries as text lines.
+func (l *Log) Export(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, e := range l.Entries() {
+		if _, err := fmt.Fprintln(bw, e.String()); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ExportFile writes the visible entries to a timestamped file in ExportDir
+// and returns its path.
+func (l *Log) ExportFile() (string, error) {
+	path := filepath.Join(l.ExportDir, "combat_log_"+time.Now().Format("20060102_150405")+".txt")
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create combat log: %w", err)
+	}
+	defer f.Close()
+
+	if err := l.Export(f); err != nil {
+		return "", fmt.Errorf("failed to write combat log: %w", err)
+	}
+
+	return path, nil
+}
+
+// Update advances the log clock and handles the toggle, filter (F1-F6), and export keys.
+func (l *Log) Update(dt float64) {
+	l.clock += dt
+
+	if inpututil.IsKeyJustPressed(l.ToggleKey) {
+		l.Visible = !l.Visible
+	}
+
+	if !l.Visible {
+		return
+	}
+
+	for c := range CategoryCount {
+		if inpututil.IsKeyJustPressed(ebiten.KeyF1 + ebiten.Key(c)) {
+			l.ToggleCategory(c)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(l.ExportKey) {
+		path, err := l.ExportFile()
+		if err != nil {
+			l.status = err.Error()
+		} else {
+			l.status = "Saved " + path
+		}
+	}
+}
+
+// Draw renders the most recent visible entries that fit in the given box.
+func (l *Log) Draw(screen *ebiten.Image, x, y, w, h int) {
+	if !l.Visible {
+		return
+	}
+
+	const lineH = 16
+
+	ui.CurrentTheme().Skin().Panel.Draw(screen, float64(x), float64(y), float64(w), float64(h))
+
+	// Filter legend
+	legend := ""
+	for c := range CategoryCount {
+		mark := "+"
+		if !l.Shows(c) {
+			mark = "-"
+		}
+
+		legend += fmt.Sprintf("F%d%s%s ", c+1, mark, c)
+	}
+
+	ebitenutil.DebugPrintAt(screen, legend, x+8, y+6)
+
+	footer := "F8 export | " + l.ToggleKey.String() + " close"
+	if l.status != "" {
+		footer = l.status
+	}
+
+	ebitenutil.DebugPrintAt(screen, footer, x+8, y+h-lineH-4)
+
+	entries := l.Entries()
+	rows := (h - 2*lineH - 12) / lineH
+
+	if len(entries) > rows {
+		entries = entries[len(entries)-max(rows, 0):]
+	}
+
+	for i, e := range entries {
+		ebitenutil.DebugPrintAt(screen, e.String(), x+8, y+lineH+10+i*lineH)
+	}
+}
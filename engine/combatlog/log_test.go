@@ -0,0 +1,75 @@
+package combatlog
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+func TestLogFromBusWithFilters(t *testing.T) {
+	bus := events.NewBus()
+	l := NewLog(bus, 3)
+
+	events.Publish(bus, Entry{Time: 1, Category: DamageDealt, Source: "Player", Target: "Bug", Amount: 12})
+	events.Publish(bus, Entry{Time: 2, Category: Kill, Source: "Player", Target: "Bug"})
+	events.Publish(bus, Entry{Time: 3, Category: DamageTaken, Source: "Orc", Target: "Player", Amount: 7})
+	events.Publish(bus, Entry{Time: 64, Category: LevelUp, Source: "Player", Amount: 2})
+
+	if l.Len() != 3 {
+		t.Fatalf("len = %d, want capacity 3", l.Len())
+	}
+
+	if first := l.Entries()[0]; first.Category != Kill {
+		t.Errorf("oldest entry should have been dropped, first = %+v", first)
+	}
+
+	l.ToggleCategory(Kill)
+
+	entries := l.Entries()
+	if len(entries) != 2 || entries[0].Category != DamageTaken {
+		t.Errorf("filtered entries = %+v", entries)
+	}
+
+	if got := entries[1].String(); got != "[01:04] Player reached level 2" {
+		t.Errorf("String = %q", got)
+	}
+
+	l.Close()
+	events.Publish(bus, Entry{Category: Drop})
+
+	if l.Len() != 3 {
+		t.Error("closed log should ignore bus events")
+	}
+}
+
+func TestLogExport(t *testing.T) {
+	l := NewLog(nil, 10)
+	l.clock = 5
+	l.Add(Entry{Category: DamageTaken, Source: "Deadline", Target: "Player", Amount: 30, Detail: "slam"})
+	l.Add(Entry{Category: Drop, Source: "Deadline", Target: "Rare Keyboard"})
+
+	var buf bytes.Buffer
+	if err := l.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[00:05] Deadline hit Player for 30 (slam)\n[00:05] Deadline dropped Rare Keyboard\n"
+	if buf.String() != want {
+		t.Errorf("export = %q, want %q", buf.String(), want)
+	}
+
+	l.ExportDir = t.TempDir()
+
+	path, err := l.ExportFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "Rare Keyboard") {
+		t.Errorf("exported file = %q, err %v", data, err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package combo provides a kill-streak meter: kills in quick succession build
+// a multiplier that decays once the streak lapses, with tier thresholds a
+// game can key small buffs off.
+package combo
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// tierFlash is how long the meter flashes after reaching a new tier, in seconds.
+const tierFlash = 0.4
+
+// Tier is a combo threshold. Games apply their own buffs by tier index.
+type Tier struct {
+	Kills      int     // Combo count that reaches the tier
+	Multiplier float64 // Applied to score or gold while in the tier
+	Name       string  // Shown on the meter, e.g. "Rampage"
+}
+
+// Config tunes how a game's combo builds and decays.
+type Config struct {
+	Window float64 // Seconds a kill holds the combo before it starts decaying
+	Decay  float64 // Combo lost per second once the window lapses; 0 ends it at once
+	Tiers  []Tier  // Ascending by Kills
+}
+
+// Meter counts a kill streak. Call Kill on every kill, Update every tick, and
+// scale rewards with Apply.
+type Meter struct {
+	Config Config
+
+	count float64 // Fractional while decaying
+	timer float64 // Seconds of the window left
+	lapse float64 // Count when the window last lapsed
+	best  int
+	flash float64
+}
+
+// NewMeter creates an empty meter.
+func NewMeter(cfg Config) *Meter {
+	return &Meter{Config: cfg}
+}
+
+// Kill adds one kill to the streak and restarts the window.
+func (m *Meter) Kill() {
+	m.Add(1)
+}
+
+// Add adds kills to the streak and restarts the window.
+func (m *Meter) Add(kills int) {
+	prev := m.Tier()
+
+	m.count += float64(kills)
+	m.timer = m.Config.Window
+	m.best = max(m.best, m.Count())
+
+	if m.Tier() > prev {
+		m.flash = tierFlash
+	}
+}
+
+// Update runs down the window, then decays the streak.
+func (m *Meter) Update(dt float64) {
+	m.flash = max(0, m.flash-dt)
+
+	if m.timer > 0 {
+		m.timer -= dt
+		if m.timer > 0 {
+			return
+		}
+
+		// Decay only for the part of the tick past the window
+		dt = -m.timer
+		m.timer = 0
+		m.lapse = m.count
+	}
+
+	if m.Config.Decay <= 0 {
+		m.count = 0
+
+		return
+	}
+
+	m.count = max(0, m.count-m.Config.Decay*dt)
+}
+
+// Break ends the streak at once, e.g. when the player is hit.
+func (m *Meter) Break() {
+	m.count, m.timer, m.lapse, m.flash = 0, 0, 0, 0
+}
+
+// Reset ends the streak and forgets the best one, for a new run.
+func (m *Meter) Reset() {
+	m.Break()
+	m.best = 0
+}
+
+// Count returns the current streak.
+func (m *Meter) Count() int {
+	return int(m.count)
+}
+
+// Best returns the longest streak since the last Reset.
+func (m *Meter) Best() int {
+	return m.best
+}
+
+// Tier returns the index of the highest tier reached, or -1 below the first.
+func (m *Meter) Tier() int {
+	tier := -1
+
+	for i, t := range m.Config.Tiers {
+		if m.Count() >= t.Kills {
+			tier = i
+		}
+	}
+
+	return tier
+}
+
+// Multiplier returns the current tier's multiplier, 1 below the first tier.
+func (m *Meter) Multiplier() float64 {
+	tier := m.Tier()
+	if tier < 0 {
+		return 1
+	}
+
+	return m.Config.Tiers[tier].Multiplier
+}
+
+// Apply scales a reward by the multiplier, rounded to the nearest whole unit.
+func (m *Meter) Apply(amount int) int {
+	return int(math.Round(float64(amount) * m.Multiplier()))
+}
+
+// Window returns the fraction of the window left before the streak decays.
+func (m *Meter) Window() float64 {
+	if m.Config.Window <= 0 {
+		return 0
+	}
+
+	return m.timer / m.Config.Window
+}
+
+// Draw renders the meter in a width-wide panel at (x, y), colored by tier
+// with the theme's rarity colors. Nothing is drawn without a streak.
+func (m *Meter) Draw(screen *ebiten.Image, x, y, width float64) {
+	if m.Count() <= 0 {
+		return
+	}
+
+	palette := ui.CurrentTheme().Palette
+	tier := m.Tier()
+
+	accent := palette.Text
+	if tier >= 0 {
+		accent = palette.RarityColor(tier)
+	}
+
+	border := palette.PanelBorder
+	if m.flash > 0 {
+		border = accent
+	}
+
+	px, py, w := float32(x), float32(y), float32(width)
+	panel := color.NRGBA{R: palette.Panel.R, G: palette.Panel.G, B: palette.Panel.B, A: 200}
+
+	vector.FillRect(screen, px, py, w, 36, panel, false)
+	vector.StrokeRect(screen, px, py, w, 36, 1, border, false)
+
+	label := fmt.Sprintf("COMBO %d", m.Count())
+	if tier >= 0 {
+		label += fmt.Sprintf("  x%.4g", m.Multiplier())
+	}
+
+	ebitenutil.DebugPrintAt(screen, label, int(px)+6, int(py)+3)
+
+	if tier >= 0 {
+		ebitenutil.DebugPrintAt(screen, m.Config.Tiers[tier].Name, int(px)+6, int(py)+17)
+	}
+
+	// Window left, or the streak draining once it lapses
+	fill := m.Window()
+	if fill <= 0 && m.lapse > 0 {
+		fill = m.count / m.lapse
+		accent = palette.Danger
+	}
+
+	vector.FillRect(screen, px+2, py+32, (w-4)*float32(fill), 2, accent, false)
+}
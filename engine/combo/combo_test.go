@@ -0,0 +1,116 @@
+package combo
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var testConfig = Config{
+	Window: 1,
+	Decay:  4,
+	Tiers: []Tier{
+		{Kills: 3, Multiplier: 1.5, Name: "Nice"},
+		{Kills: 6, Multiplier: 2, Name: "Great"},
+	},
+}
+
+func TestMeterTiers(t *testing.T) {
+	m := NewMeter(testConfig)
+
+	tests := []struct {
+		kills int
+		tier  int
+		mult  float64
+	}{
+		{1, -1, 1},
+		{3, 0, 1.5},
+		{5, 0, 1.5},
+		{6, 1, 2},
+		{20, 1, 2},
+	}
+
+	for _, tt := range tests {
+		m.Reset()
+		m.Add(tt.kills)
+
+		if m.Tier() != tt.tier || m.Multiplier() != tt.mult {
+			t.Errorf("%d kills: tier %d x%v, want tier %d x%v", tt.kills, m.Tier(), m.Multiplier(), tt.tier, tt.mult)
+		}
+	}
+
+	m.Reset()
+	m.Add(3)
+
+	if got := m.Apply(101); got != 152 {
+		t.Errorf("Apply(101) at x1.5 = %d, want 152", got)
+	}
+}
+
+func TestMeterDecaysAfterWindow(t *testing.T) {
+	m := NewMeter(testConfig)
+	m.Add(6)
+
+	// Kills inside the window keep it alive
+	m.Update(0.75)
+	m.Kill()
+	m.Update(0.75)
+
+	if m.Count() != 7 || m.Window() <= 0 {
+		t.Fatalf("count %d window %v, want 7 with the window still open", m.Count(), m.Window())
+	}
+
+	// 0.25s of window left, then 0.5s of decay at 4 per second
+	m.Update(0.75)
+
+	if m.Count() != 5 || m.Tier() != 0 {
+		t.Errorf("count %d tier %d after decaying, want 5 in the first tier", m.Count(), m.Tier())
+	}
+
+	m.Update(10)
+
+	if m.Count() != 0 || m.Best() != 7 {
+		t.Errorf("count %d best %d, want 0 with best 7", m.Count(), m.Best())
+	}
+}
+
+func TestMeterWithoutDecayEndsAtOnce(t *testing.T) {
+	cfg := testConfig
+	cfg.Decay = 0
+
+	m := NewMeter(cfg)
+	m.Add(4)
+	m.Update(1.01)
+
+	if m.Count() != 0 {
+		t.Errorf("count %d after the window, want the streak ended", m.Count())
+	}
+}
+
+func TestMeterBreak(t *testing.T) {
+	m := NewMeter(testConfig)
+	m.Add(8)
+	m.Break()
+
+	if m.Count() != 0 || m.Tier() != -1 || m.Best() != 8 {
+		t.Errorf("count %d tier %d best %d after Break, want 0, -1, 8", m.Count(), m.Tier(), m.Best())
+	}
+
+	m.Reset()
+
+	if m.Best() != 0 {
+		t.Errorf("best %d after Reset, want 0", m.Best())
+	}
+}
+
+func TestMeterDraw(t *testing.T) {
+	screen := ebiten.NewImage(200, 50)
+	m := NewMeter(testConfig)
+
+	// Empty, in a tier, and draining
+	m.Draw(screen, 0, 0, 160)
+	m.Add(6)
+	m.Draw(screen, 0, 0, 160)
+	m.Update(1.5)
+	m.Draw(screen, 0, 0, 160)
+}
@@ -0,0 +1,111 @@
+package components
+
+// Ability is an active skill gated by a cooldown. Activating it starts the
+// cooldown and keeps the ability "active" for Duration seconds so systems can
+// apply timed effects (dashes, buffs, slows).
+type Ability struct {
+	ID       string
+	Name     string
+	Cooldown Cooldown
+	Duration float64 // Seconds the effect lasts after activation (0 = instant)
+	Active   float64 // Seconds of effect remaining
+}
+
+// NewAbility creates an ability with the given cooldown and effect duration.
+func NewAbility(id, name string, cooldown, duration float64) Ability {
+	return Ability{
+		ID:       id,
+		Name:     name,
+		Cooldown: NewCooldown(cooldown),
+		Duration: duration,
+	}
+}
+
+// IsReady returns true if the ability is off cooldown.
+func (a *Ability) IsReady() bool {
+	return a.Cooldown.IsReady()
+}
+
+// IsActive returns true while the ability's effect is running.
+func (a *Ability) IsActive() bool {
+	return a.Active > 0
+}
+
+// Activate uses the ability if it is ready, returns true if successful.
+func (a *Ability) Activate() bool {
+	if !a.Cooldown.Use() {
+		return false
+	}
+
+	a.Active = a.Duration
+
+	return true
+}
+
+// Update advances the cooldown and effect timers, returns true if the effect just ended.
+func (a *Ability) Update(dt float64) bool {
+	a.Cooldown.Tick(dt)
+
+	if a.Active <= 0 {
+		return false
+	}
+
+	a.Active -= dt
+	if a.Active <= 0 {
+		a.Active = 0
+
+		return true
+	}
+
+	return false
+}
+
+// Abilities holds an entity's ability slots, e.g. bound to hotkeys or buttons.
+type Abilities struct {
+	Slots []Ability
+}
+
+// NewAbilities creates an ability set from the given slots.
+func NewAbilities(slots ...Ability) Abilities {
+	return Abilities{Slots: slots}
+}
+
+// Get returns the ability with the given ID, or nil.
+func (a *Abilities) Get(id string) *Ability {
+	for i := range a.Slots {
+		if a.Slots[i].ID == id {
+			return &a.Slots[i]
+		}
+	}
+
+	return nil
+}
+
+// Activate uses the ability in a slot, returns true if successful.
+func (a *Abilities) Activate(slot int) bool {
+	if slot < 0 || slot >= len(a.Slots) {
+		return false
+	}
+
+	return a.Slots[slot].Activate()
+}
+
+// IsActive returns true if the ability with the given ID is running.
+func (a *Abilities) IsActive(id string) bool {
+	ability := a.Get(id)
+
+	return ability != nil && ability.IsActive()
+}
+
+// Update advances all abilities and returns the IDs whose effects just ended.
+func (a *Abilities) Update(dt float64) []string {
+	var ended []string
+
+	for i := range a.Slots {
+		if a.Slots[i].Update(dt) {
+			ended = append(ended, a.Slots[i].ID)
+		}
+	}
+
+	return ended
+}
@@ -0,0 +1,41 @@
+package components
+
+import "testing"
+
+func TestAbilityLifecycle(t *testing.T) {
+	set := NewAbilities(
+		NewAbility("dash", "Dash", 2, 0.25),
+		NewAbility("slow", "Time Slow", 10, 3),
+	)
+
+	if !set.Activate(0) {
+		t.Fatal("dash should activate when ready")
+	}
+
+	if set.Activate(0) {
+		t.Error("dash should be on cooldown")
+	}
+
+	if !set.IsActive("dash") || set.IsActive("slow") {
+		t.Error("only dash should be active")
+	}
+
+	if got := set.Get("dash").Cooldown.Progress(); got != 0 {
+		t.Errorf("progress right after use = %v, want 0", got)
+	}
+
+	ended := set.Update(0.3)
+	if len(ended) != 1 || ended[0] != "dash" {
+		t.Errorf("ended = %v, want [dash]", ended)
+	}
+
+	set.Update(1.7)
+
+	if !set.Get("dash").IsReady() {
+		t.Error("dash should be ready after its cooldown")
+	}
+
+	if set.Activate(5) || set.Get("missing") != nil {
+		t.Error("unknown slots and IDs should be rejected")
+	}
+}
@@ -7,6 +7,14 @@ type Position struct {
 	X, Y float64
 }
 
+// PrevPosition is an entity's Position at the start of the current
+// simulation tick. Entities that carry it are drawn interpolated between
+// the two, so motion stays smooth when the display refreshes faster than
+// the game ticks. Set it equal to Position when spawning or teleporting.
+type PrevPosition struct {
+	X, Y float64
+}
+
 // Velocity represents the 2D velocity of an entity.
 type Velocity struct {
 	X, Y float64
@@ -57,3 +65,9 @@ func NewHealth(maxVal int) Health {
 type Tag struct {
 	Name string
 }
+
+// Name is an optional human-readable label used in logs, the inspector, and
+// debug console commands (e.g. "Boss Manager #2").
+type Name struct {
+	Value string
+}
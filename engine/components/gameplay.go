@@ -11,6 +11,28 @@ type Combat struct {
 	LastAttackAt float64 // Time of last attack
 }
 
+// Boss holds the phase thresholds and enrage timer shown on the boss bar for
+// an entity tagged "boss".
+type Boss struct {
+	Phases     []float64 // HP fractions where a new phase starts, e.g. 0.66, 0.33
+	EnrageTime float64   // Seconds after spawn the boss enrages; 0 = never
+	Elapsed    float64   // Seconds since spawn
+}
+
+// EnrageIn returns the seconds until enrage, or -1 if the boss never enrages.
+func (b *Boss) EnrageIn() float64 {
+	if b.EnrageTime <= 0 {
+		return -1
+	}
+
+	return max(0, b.EnrageTime-b.Elapsed)
+}
+
+// Enraged reports whether the enrage timer has run out.
+func (b *Boss) Enraged() bool {
+	return b.EnrageTime > 0 && b.Elapsed >= b.EnrageTime
+}
+
 // DamageType represents the type of damage dealt.
 type DamageType string
 
@@ -89,6 +111,45 @@ func (c *Cooldown) Use() bool {
 	return true
 }
 
+// Tick advances the cooldown timer and charge regeneration by dt seconds.
+func (c *Cooldown) Tick(dt float64) {
+	if c.Remaining > 0 {
+		c.Remaining -= dt
+		if c.Remaining < 0 {
+			c.Remaining = 0
+		}
+	}
+
+	if c.MaxCharges > 1 && c.Charges < c.MaxCharges {
+		c.ChargeTimer += dt
+		if c.ChargeTimer >= c.ChargeTime {
+			c.ChargeTimer -= c.ChargeTime
+			c.Charges++
+		}
+	}
+}
+
+// Progress returns how far the cooldown has recovered (0.0 = just used, 1.0 = ready).
+func (c *Cooldown) Progress() float64 {
+	if c.IsReady() {
+		return 1.0
+	}
+
+	if c.MaxCharges > 1 {
+		if c.ChargeTime == 0 {
+			return 1.0
+		}
+
+		return c.ChargeTimer / c.ChargeTime
+	}
+
+	if c.Duration == 0 {
+		return 1.0
+	}
+
+	return 1.0 - c.Remaining/c.Duration
+}
+
 // Combo tracks combo attacks for an entity.
 type Combo struct {
 	Count       int     // Current combo count
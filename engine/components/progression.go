@@ -1,5 +1,10 @@
 package components
 
+import (
+	"sort"
+	"time"
+)
+
 // Experience tracks XP and level requirements.
 type Experience struct {
 	Current    int64   // Current XP
@@ -167,6 +172,13 @@ type Achievement struct {
 	Target      int    // Target to unlock
 	Hidden      bool   // Hidden until unlocked
 	Reward      string // Optional reward ID
+	Stat        string // Persistent counter that drives progress; see AchievementTracker.Sync
+	Trigger     string // Event rule that drives progress; see package triggers
+}
+
+// StatSource supplies persistent counter values, e.g. a *stats.Store.
+type StatSource interface {
+	CounterValue(name string) int64
 }
 
 // NewAchievement creates an achievement.
@@ -221,6 +233,28 @@ func (at *AchievementTracker) Progress(id string, amount int) bool {
 	return false
 }
 
+// Sync sets the progress of every stat-backed achievement from its counter and
+// returns the IDs unlocked by this call, sorted. Progress then survives across
+// sessions because it lives in the stats store rather than the tracker.
+func (at *AchievementTracker) Sync(src StatSource) []string {
+	var unlocked []string
+
+	for id, a := range at.Achievements {
+		if a.Stat == "" || a.Unlocked {
+			continue
+		}
+
+		if a.AddProgress(int(src.CounterValue(a.Stat)) - a.Progress) {
+			a.UnlockedAt = time.Now().Unix()
+			unlocked = append(unlocked, id)
+		}
+	}
+
+	sort.Strings(unlocked)
+
+	return unlocked
+}
+
 // GetUnlockedCount returns the number of unlocked achievements.
 func (at *AchievementTracker) GetUnlockedCount() int {
 	count := 0
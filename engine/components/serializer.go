@@ -0,0 +1,395 @@
+package components
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unsafe"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mlange-42/ark/ecs"
+)
+
+// snapshotMagic starts every world snapshot.
+const snapshotMagic = "ECSW"
+
+// snapshotFormat is the version of the binary layout written by Marshal.
+const snapshotFormat uint16 = 1
+
+// ErrSnapshotVersion is returned when a snapshot was written by a newer
+// format or schema than the Serializer reading it.
+var ErrSnapshotVersion = errors.New("components: unsupported snapshot version")
+
+// Serializer writes whole ECS worlds to a versioned binary snapshot and reads
+// them back, for save games and rollback. Every component type a world uses
+// must be registered, with Register or RegisterAs, under a stable name.
+//
+// A snapshot holds the entity pool, so entity IDs and generations survive a
+// round trip, followed by one section per component type. Component values
+// are gob-encoded, so fields may be added or removed between schema
+// versions; fields missing from a snapshot load as zero.
+type Serializer struct {
+	// Version is the schema version stamped on snapshots. Bump it when
+	// components change incompatibly; Unmarshal rejects newer snapshots.
+	Version uint32
+
+	byName map[string]*componentCodec
+	byType map[reflect.Type]*componentCodec
+}
+
+// componentCodec encodes one component type.
+type componentCodec struct {
+	name   string
+	typ    reflect.Type
+	tag    bool // Zero-sized; only presence is stored
+	encode func(enc *gob.Encoder, ptr unsafe.Pointer) error
+	decode func(dec *gob.Decoder) (func(ptr unsafe.Pointer), error)
+}
+
+// NewSerializer returns a serializer for the given schema version with no
+// component types registered.
+func NewSerializer(version uint32) *Serializer {
+	return &Serializer{
+		Version: version,
+		byName:  make(map[string]*componentCodec),
+		byType:  make(map[reflect.Type]*componentCodec),
+	}
+}
+
+// Register adds component type T under name, encoding its exported fields.
+func Register[T any](s *Serializer, name string) {
+	RegisterAs(s, name, func(c *T) T { return *c }, func(d T, c *T) { *c = d })
+}
+
+// RegisterAs adds component type T under name, stored as a D built by to and
+// turned back into a T by from. Use it for components holding values that do
+// not serialize, as RegisterSprite does for images.
+func RegisterAs[T, D any](s *Serializer, name string, to func(c *T) D, from func(d D, c *T)) {
+	typ := reflect.TypeFor[T]()
+	if _, ok := s.byName[name]; ok {
+		panic(fmt.Sprintf("components: serializer name %q registered twice", name))
+	}
+
+	if _, ok := s.byType[typ]; ok {
+		panic(fmt.Sprintf("components: serializer type %v registered twice", typ))
+	}
+
+	codec := &componentCodec{
+		name: name,
+		typ:  typ,
+		tag:  reflect.TypeFor[D]().Size() == 0,
+		encode: func(enc *gob.Encoder, ptr unsafe.Pointer) error {
+			d := to((*T)(ptr))
+
+			return enc.Encode(&d)
+		},
+		decode: func(dec *gob.Decoder) (func(ptr unsafe.Pointer), error) {
+			var d D
+			if err := dec.Decode(&d); err != nil {
+				return nil, err
+			}
+
+			return func(ptr unsafe.Pointer) { from(d, (*T)(ptr)) }, nil
+		},
+	}
+	s.byName[name] = codec
+	s.byType[typ] = codec
+}
+
+// sectionEntry is one component of one entity within a section.
+type sectionEntry struct {
+	entity uint32
+	ptr    unsafe.Pointer
+}
+
+// Marshal writes every alive entity of world and its components. It fails if
+// an entity has a component type that is not registered, or a relation.
+func (s *Serializer) Marshal(world *ecs.World) ([]byte, error) {
+	dump := world.Unsafe().DumpEntities()
+
+	// Group component values by type, in entity order
+	sections := make(map[*componentCodec][]sectionEntry)
+
+	for _, idx := range dump.Alive {
+		entity := dump.Entities[idx]
+		ids := world.Unsafe().IDs(entity)
+
+		for i := range ids.Len() {
+			id := ids.Get(i)
+
+			info, _ := ecs.ComponentInfo(world, id)
+			if info.IsRelation {
+				return nil, fmt.Errorf("components: relation %v is not serializable", info.Type)
+			}
+
+			codec, ok := s.byType[info.Type]
+			if !ok {
+				return nil, fmt.Errorf("components: unregistered component type %v", info.Type)
+			}
+
+			ptr := world.Unsafe().Get(entity, id)
+			sections[codec] = append(sections[codec], sectionEntry{entity: idx, ptr: ptr})
+		}
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(snapshotMagic)
+	write(&buf, snapshotFormat, s.Version)
+
+	write(&buf, uint32(len(dump.Entities)))
+
+	for _, e := range dump.Entities {
+		write(&buf, e.ID(), e.Gen())
+	}
+
+	write(&buf, uint32(len(dump.Alive)), dump.Alive, dump.Next, dump.Available)
+
+	codecs := slices.SortedFunc(maps.Keys(sections), func(a, b *componentCodec) int {
+		return strings.Compare(a.name, b.name)
+	})
+	write(&buf, uint16(len(codecs)))
+
+	for _, codec := range codecs {
+		entries := sections[codec]
+
+		var payload bytes.Buffer
+
+		enc := gob.NewEncoder(&payload)
+
+		entities := make([]uint32, len(entries))
+		for i, e := range entries {
+			entities[i] = e.entity
+
+			if codec.tag {
+				continue
+			}
+
+			if err := codec.encode(enc, e.ptr); err != nil {
+				return nil, fmt.Errorf("components: encoding %s: %w", codec.name, err)
+			}
+		}
+
+		write(&buf, uint16(len(codec.name)))
+		buf.WriteString(codec.name)
+		write(&buf, uint32(len(entities)), entities, uint32(payload.Len()))
+		buf.Write(payload.Bytes())
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal resets world and fills it from a snapshot written by Marshal.
+// Entities keep the IDs and generations they were saved with. Resources are
+// cleared by the reset and are not part of the snapshot.
+func (s *Serializer) Unmarshal(data []byte, world *ecs.World) error {
+	r := bytes.NewReader(data)
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != snapshotMagic {
+		return errors.New("components: not a world snapshot")
+	}
+
+	var (
+		format  uint16
+		version uint32
+	)
+	if err := read(r, &format, &version); err != nil {
+		return err
+	}
+
+	if format != snapshotFormat || version > s.Version {
+		return fmt.Errorf("%w: format %d schema %d, want format %d schema <= %d",
+			ErrSnapshotVersion, format, version, snapshotFormat, s.Version)
+	}
+
+	var dump ecs.EntityDump
+
+	var count uint32
+	if err := read(r, &count); err != nil {
+		return err
+	}
+
+	dump.Entities = make([]ecs.Entity, count)
+
+	for i := range dump.Entities {
+		var id, gen uint32
+		if err := read(r, &id, &gen); err != nil {
+			return err
+		}
+
+		if err := dump.Entities[i].UnmarshalJSON(fmt.Appendf(nil, "[%d,%d]", id, gen)); err != nil {
+			return fmt.Errorf("components: %w", err)
+		}
+	}
+
+	if err := read(r, &count); err != nil {
+		return err
+	}
+
+	dump.Alive = make([]uint32, count)
+	if err := read(r, dump.Alive, &dump.Next, &dump.Available); err != nil {
+		return err
+	}
+
+	for _, idx := range dump.Alive {
+		if idx >= uint32(len(dump.Entities)) {
+			return fmt.Errorf("components: alive entity %d out of range", idx)
+		}
+	}
+
+	// Decode every section before touching the world, so a bad snapshot
+	// leaves it as it was
+	type pending struct {
+		id  ecs.ID
+		set func(ptr unsafe.Pointer)
+	}
+
+	comps := make(map[uint32][]pending)
+
+	var sections uint16
+	if err := read(r, &sections); err != nil {
+		return err
+	}
+
+	for range sections {
+		var nameLen uint16
+		if err := read(r, &nameLen); err != nil {
+			return err
+		}
+
+		name := make([]byte, nameLen)
+		if _, err := io.ReadFull(r, name); err != nil {
+			return fmt.Errorf("components: %w", err)
+		}
+
+		codec, ok := s.byName[string(name)]
+		if !ok {
+			return fmt.Errorf("components: unknown component %q in snapshot", name)
+		}
+
+		if err := read(r, &count); err != nil {
+			return err
+		}
+
+		entities := make([]uint32, count)
+
+		var payloadLen uint32
+		if err := read(r, entities, &payloadLen); err != nil {
+			return err
+		}
+
+		payload := make([]byte, payloadLen)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return fmt.Errorf("components: %w", err)
+		}
+
+		id := ecs.TypeID(world, codec.typ)
+		dec := gob.NewDecoder(bytes.NewReader(payload))
+
+		for _, e := range entities {
+			if e >= uint32(len(dump.Entities)) {
+				return fmt.Errorf("components: %s on entity %d out of range", codec.name, e)
+			}
+
+			set := func(unsafe.Pointer) {}
+
+			if !codec.tag {
+				var err error
+				if set, err = codec.decode(dec); err != nil {
+					return fmt.Errorf("components: decoding %s: %w", codec.name, err)
+				}
+			}
+
+			comps[e] = append(comps[e], pending{id: id, set: set})
+		}
+	}
+
+	world.Reset()
+	world.Unsafe().LoadEntities(&dump)
+
+	ids := make([]ecs.ID, 0, 8)
+
+	for _, idx := range dump.Alive {
+		entity := dump.Entities[idx]
+
+		ids = ids[:0]
+		for _, c := range comps[idx] {
+			ids = append(ids, c.id)
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		world.Unsafe().Add(entity, ids...)
+
+		for _, c := range comps[idx] {
+			c.set(world.Unsafe().Get(entity, c.id))
+		}
+	}
+
+	return nil
+}
+
+// write appends values to buf in little-endian order.
+func write(buf *bytes.Buffer, values ...any) {
+	for _, v := range values {
+		// Writes to a bytes.Buffer of fixed-size values cannot fail
+		_ = binary.Write(buf, binary.LittleEndian, v)
+	}
+}
+
+// read fills values from r in little-endian order.
+func read(r io.Reader, values ...any) error {
+	for _, v := range values {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return fmt.Errorf("components: truncated snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SpriteData is the part of a Sprite a snapshot stores: everything but the
+// image, plus the image's size so a loader can rebuild or look it up.
+type SpriteData struct {
+	Width, Height    int
+	OffsetX, OffsetY float64
+	ScaleX, ScaleY   float64
+	Visible          bool
+}
+
+// RegisterSprite adds Sprite under name. Images are not stored; image is
+// called on load to supply one and may return nil, e.g. in headless games.
+func RegisterSprite(s *Serializer, name string, image func(d SpriteData) *ebiten.Image) {
+	RegisterAs(s, name,
+		func(c *Sprite) SpriteData {
+			d := SpriteData{
+				OffsetX: c.OffsetX, OffsetY: c.OffsetY,
+				ScaleX: c.ScaleX, ScaleY: c.ScaleY,
+				Visible: c.Visible,
+			}
+			if c.Image != nil {
+				d.Width, d.Height = c.Image.Bounds().Dx(), c.Image.Bounds().Dy()
+			}
+
+			return d
+		},
+		func(d SpriteData, c *Sprite) {
+			*c = Sprite{
+				Image:   image(d),
+				OffsetX: d.OffsetX, OffsetY: d.OffsetY,
+				ScaleX: d.ScaleX, ScaleY: d.ScaleY,
+				Visible: d.Visible,
+			}
+		},
+	)
+}
@@ -0,0 +1,112 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mlange-42/ark/ecs"
+)
+
+type marker struct{}
+
+func newTestSerializer(version uint32) *Serializer {
+	s := NewSerializer(version)
+	Register[Position](s, "position")
+	Register[Health](s, "health")
+	Register[marker](s, "marker")
+	RegisterSprite(s, "sprite", func(SpriteData) *ebiten.Image { return nil })
+
+	return s
+}
+
+func TestSerializerRoundTrip(t *testing.T) {
+	w := ecs.NewWorld()
+
+	posMap := ecs.NewMap2[Position, Health](&w)
+	a := posMap.NewEntity(&Position{X: 1, Y: 2}, &Health{Current: 3, Max: 10})
+	dead := posMap.NewEntity(&Position{}, &Health{})
+	b := ecs.NewMap3[Position, Sprite, marker](&w).NewEntity(
+		&Position{X: 5}, &Sprite{OffsetX: -8, ScaleX: 2, Visible: true}, &marker{},
+	)
+	w.RemoveEntity(dead)
+
+	s := newTestSerializer(1)
+
+	data, err := s.Marshal(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Load over a world with other contents
+	w2 := ecs.NewWorld()
+	ecs.NewMap1[Position](&w2).NewEntity(&Position{X: 99})
+
+	if err := s.Unmarshal(data, &w2); err != nil {
+		t.Fatal(err)
+	}
+
+	if !w2.Alive(a) || !w2.Alive(b) || w2.Alive(dead) {
+		t.Fatalf("alive a=%v b=%v dead=%v", w2.Alive(a), w2.Alive(b), w2.Alive(dead))
+	}
+
+	pos, health := ecs.NewMap2[Position, Health](&w2).Get(a)
+	if *pos != (Position{X: 1, Y: 2}) || *health != (Health{Current: 3, Max: 10}) {
+		t.Errorf("a = %+v %+v", pos, health)
+	}
+
+	sprite := ecs.NewMap[Sprite](&w2).Get(b)
+	if sprite.OffsetX != -8 || sprite.ScaleX != 2 || !sprite.Visible {
+		t.Errorf("b sprite = %+v", sprite)
+	}
+
+	if !ecs.NewMap[marker](&w2).Has(b) || ecs.NewMap[Sprite](&w2).Has(a) {
+		t.Error("component sets changed")
+	}
+
+	// New entities don't reuse a live ID
+	c := w2.NewEntity()
+	if c == a || c == b {
+		t.Errorf("new entity %v collides", c)
+	}
+}
+
+func TestSerializerRejects(t *testing.T) {
+	w := ecs.NewWorld()
+	ecs.NewMap2[Position, Velocity](&w).NewEntity(&Position{}, &Velocity{})
+
+	s := newTestSerializer(2)
+	if _, err := s.Marshal(&w); err == nil {
+		t.Error("marshaled an unregistered component")
+	}
+
+	w.Reset()
+	ecs.NewMap1[Health](&w).NewEntity(&Health{Current: 1})
+
+	data, err := s.Marshal(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newTestSerializer(1).Unmarshal(data, &w); !errors.Is(err, ErrSnapshotVersion) {
+		t.Errorf("older serializer err = %v", err)
+	}
+
+	bare := NewSerializer(2)
+	if err := bare.Unmarshal(data, &w); err == nil {
+		t.Error("loaded an unknown component")
+	}
+
+	if err := s.Unmarshal(data[:len(data)-3], &w); err == nil {
+		t.Error("loaded a truncated snapshot")
+	}
+
+	// Failed loads leave the world alone
+	q := ecs.NewFilter1[Health](&w).Query()
+	n := q.Count()
+	q.Close()
+
+	if n != 1 {
+		t.Errorf("world has %d entities after failed loads", n)
+	}
+}
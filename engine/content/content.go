@@ -0,0 +1,155 @@
+// Package content checks a game's content tables (weapons, monsters, trees,
+// loot) for broken references and bad values before they reach players. A
+// game writes a check function that records issues in a Report; Run prints
+// them with their source so a contributor can find the entry to fix.
+package content
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Flag is the command-line flag that makes a game validate its content and
+// exit instead of starting. cmd/validate passes it to each game.
+const Flag = "-validate"
+
+// Severity is how bad an issue is.
+type Severity int
+
+const (
+	// SeverityError is content that is broken: a dangling reference or a
+	// value the game cannot use. Any error fails validation.
+	SeverityError Severity = iota
+	// SeverityWarning is content the game tolerates but probably should not
+	// ship, e.g. a missing image drawn with a fallback.
+	SeverityWarning
+)
+
+// Issue is one problem found in the content.
+type Issue struct {
+	Severity Severity
+	Source   string // Where the entry lives, e.g. "evolutions[3]"
+	Message  string
+}
+
+func (i Issue) String() string {
+	label := "error"
+	if i.Severity == SeverityWarning {
+		label = "warning"
+	}
+
+	return fmt.Sprintf("%-7s %s: %s", label, i.Source, i.Message)
+}
+
+// Report collects the issues a check finds.
+type Report struct {
+	issues []Issue
+}
+
+// Errorf records broken content.
+func (r *Report) Errorf(source, format string, args ...any) {
+	r.issues = append(r.issues, Issue{SeverityError, source, fmt.Sprintf(format, args...)})
+}
+
+// Warnf records content the game tolerates.
+func (r *Report) Warnf(source, format string, args ...any) {
+	r.issues = append(r.issues, Issue{SeverityWarning, source, fmt.Sprintf(format, args...)})
+}
+
+// Issues returns the issues in the order they were found.
+func (r *Report) Issues() []Issue {
+	return r.issues
+}
+
+// Errors returns how many issues are errors.
+func (r *Report) Errors() int {
+	n := 0
+
+	for _, i := range r.issues {
+		if i.Severity == SeverityError {
+			n++
+		}
+	}
+
+	return n
+}
+
+// CheckWeights reports a weighted table that cannot be rolled: a weight that
+// is negative or not a number, or no positive weight at all.
+func (r *Report) CheckWeights(source string, weights []float64) {
+	total := 0.0
+
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			r.Errorf(fmt.Sprintf("%s[%d]", source, i), "weight %v must be a non-negative number", w)
+
+			continue
+		}
+
+		total += w
+	}
+
+	if total <= 0 {
+		r.Errorf(source, "weights sum to %v; nothing can be rolled", total)
+	}
+}
+
+// CheckGraph reports bad links in a graph of node IDs to their neighbors, as
+// used by skill trees: links to unknown nodes, to the node itself, repeated
+// links, and one-way links, which make a node reachable from only one side.
+func (r *Report) CheckGraph(source string, links map[int][]int) {
+	for _, id := range slices.Sorted(maps.Keys(links)) {
+		node := fmt.Sprintf("%s[%d]", source, id)
+		seen := make(map[int]bool)
+
+		for _, to := range links[id] {
+			switch back, ok := links[to]; {
+			case to == id:
+				r.Errorf(node, "links to itself")
+			case !ok:
+				r.Errorf(node, "links to unknown node %d", to)
+			case seen[to]:
+				r.Errorf(node, "links to %d more than once", to)
+			case !slices.Contains(back, id):
+				r.Errorf(node, "links to %d, which does not link back; add %d to its links", to, id)
+			}
+
+			seen[to] = true
+		}
+	}
+}
+
+// Run checks a game's content, prints every issue, errors first and then by
+// source, with a summary line, and returns the process exit code: 1 if any error was found.
+func Run(w io.Writer, game string, check func(*Report)) int {
+	var r Report
+
+	check(&r)
+
+	issues := slices.Clone(r.issues)
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return cmp.Or(cmp.Compare(a.Severity, b.Severity), cmp.Compare(a.Source, b.Source))
+	})
+
+	for _, i := range issues {
+		fmt.Fprintf(w, "%s: %s\n", game, i)
+	}
+
+	errs := r.Errors()
+	fmt.Fprintf(w, "%s: %d errors, %d warnings\n", game, errs, len(issues)-errs)
+
+	if errs > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// Requested reports whether the command-line arguments ask for validation.
+func Requested(args []string) bool {
+	return slices.Contains(args, Flag)
+}
@@ -0,0 +1,82 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckWeights(t *testing.T) {
+	var r Report
+
+	r.CheckWeights("ok", []float64{1, 0, 3})
+	r.CheckWeights("empty", nil)
+	r.CheckWeights("zero", []float64{0, 0})
+	r.CheckWeights("negative", []float64{5, -1})
+
+	var sources []string
+	for _, i := range r.Issues() {
+		sources = append(sources, i.Source)
+	}
+
+	if got := strings.Join(sources, " "); got != "empty zero negative[1]" {
+		t.Errorf("issues at %q, want empty, zero, and negative[1]", got)
+	}
+}
+
+func TestCheckGraph(t *testing.T) {
+	var r Report
+
+	r.CheckGraph("tree", map[int][]int{
+		0: {1, 2},
+		1: {0, 1},
+		2: {3},
+		3: {2, 2},
+	})
+
+	want := []string{
+		"tree[0]: links to 2, which does not link back; add 0 to its links",
+		"tree[1]: links to itself",
+		"tree[3]: links to 2 more than once",
+	}
+
+	if len(r.Issues()) != len(want) {
+		t.Fatalf("got %v, want %d issues", r.Issues(), len(want))
+	}
+
+	for i, issue := range r.Issues() {
+		if got := issue.Source + ": " + issue.Message; got != want[i] {
+			t.Errorf("issue %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	r = Report{}
+	r.CheckGraph("tree", map[int][]int{0: {7}})
+
+	if r.Errors() != 1 || !strings.Contains(r.Issues()[0].Message, "unknown node 7") {
+		t.Errorf("a link to a missing node gave %v", r.Issues())
+	}
+}
+
+func TestRunPrintsErrorsFirstAndFailsOnErrors(t *testing.T) {
+	var out strings.Builder
+
+	code := Run(&out, "demo", func(r *Report) {
+		r.Warnf("weapons[2]", "no image")
+		r.Errorf("recipes[1]", "unknown weapon %d", 9)
+	})
+
+	want := "demo: error   recipes[1]: unknown weapon 9\n" +
+		"demo: warning weapons[2]: no image\n" +
+		"demo: 1 errors, 1 warnings\n"
+	if code != 1 || out.String() != want {
+		t.Errorf("Run returned %d and printed\n%s\nwant 1 and\n%s", code, out.String(), want)
+	}
+
+	if code := Run(&out, "demo", func(r *Report) { r.Warnf("x", "y") }); code != 0 {
+		t.Error("warnings alone should not fail validation")
+	}
+
+	if !Requested([]string{"-x", Flag}) || Requested(nil) {
+		t.Error("Requested should look for the validate flag")
+	}
+}
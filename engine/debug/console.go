@@ -0,0 +1,274 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+)
+
+// maxConsoleLines is the number of output lines kept in the console history.
+const maxConsoleLines = 12
+
+// CommandFunc handles a console command and returns text to print.
+type CommandFunc func(args []string) (string, error)
+
+type consoleCommand struct {
+	help string
+	fn   CommandFunc
+}
+
+// Console is a minimal developer console for running commands against the ECS world.
+// Toggle with the backquote key. Built-in commands accept entity selectors
+// of the form `tag:<tag>` or `name:<name>`, e.g. `kill tag:boss`.
+type Console struct {
+	world    *ecs.World
+	tags     *systems.TagQuery
+	commands map[string]consoleCommand
+	enabled  bool
+	input    string
+	output   []string
+
+	// OnKill is called for each entity before the kill command removes it,
+	// letting games run their own death logic instead of a raw removal.
+	OnKill func(entity ecs.Entity)
+}
+
+// ErrUnknownCommand is returned by Exec for unregistered commands.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// NewConsole creates a debug console with the built-in entity commands. Games
+// that do not use the ECS may pass a nil world to get only help and the
+// commands they register.
+func NewConsole(world *ecs.World) *Console {
+	c := &Console{
+		world:    world,
+		commands: make(map[string]consoleCommand),
+	}
+
+	c.Register("help", "list commands", c.cmdHelp)
+
+	if world == nil {
+		return c
+	}
+
+	c.tags = systems.NewTagQuery(world)
+	c.Register("list", "list <selector>: print matching entities", c.cmdList)
+	c.Register("count", "count <selector>: count matching entities", c.cmdCount)
+	c.Register("kill", "kill <selector>: remove matching entities", c.cmdKill)
+
+	return c
+}
+
+// Register adds or replaces a console command.
+func (c *Console) Register(name, help string, fn CommandFunc) {
+	c.commands[name] = consoleCommand{help: help, fn: fn}
+}
+
+// Toggle opens or closes the console.
+func (c *Console) Toggle() {
+	c.enabled = !c.enabled
+}
+
+// Enabled returns whether the console is open.
+func (c *Console) Enabled() bool {
+	return c.enabled
+}
+
+// Output returns the console history, oldest first.
+func (c *Console) Output() []string {
+	return c.output
+}
+
+// Exec parses and runs a single command line, appending the result to the history.
+func (c *Console) Exec(line string) (string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	c.print("> " + line)
+
+	cmd, ok := c.commands[fields[0]]
+	if !ok {
+		err := fmt.Errorf("%w: %s", ErrUnknownCommand, fields[0])
+		c.print(err.Error())
+
+		return "", err
+	}
+
+	result, err := cmd.fn(fields[1:])
+	if err != nil {
+		c.print("error: " + err.Error())
+
+		return "", err
+	}
+
+	if result != "" {
+		c.print(result)
+	}
+
+	return result, nil
+}
+
+// Select resolves an entity selector (`tag:<tag>` or `name:<name>`) to entities.
+func (c *Console) Select(selector string) ([]ecs.Entity, error) {
+	kind, value, ok := strings.Cut(selector, ":")
+	if !ok || value == "" {
+		return nil, fmt.Errorf("invalid selector %q, want tag:<tag> or name:<name>", selector)
+	}
+
+	switch kind {
+	case "tag":
+		return c.tags.WithTag(value), nil
+	case "name":
+		return c.tags.WithName(value), nil
+	default:
+		return nil, fmt.Errorf("unknown selector kind %q", kind)
+	}
+}
+
+func (c *Console) selectArgs(args []string) ([]ecs.Entity, error) {
+	if len(args) != 1 {
+		return nil, errors.New("expected exactly one selector")
+	}
+
+	return c.Select(args[0])
+}
+
+func (c *Console) cmdHelp([]string) (string, error) {
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = name + " - " + c.commands[name].help
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (c *Console) cmdList(args []string) (string, error) {
+	entities, err := c.selectArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entities) == 0 {
+		return "no matching entities", nil
+	}
+
+	lines := make([]string, len(entities))
+	for i, e := range entities {
+		lines[i] = c.tags.Describe(e)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (c *Console) cmdCount(args []string) (string, error) {
+	entities, err := c.selectArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d entities", len(entities)), nil
+}
+
+func (c *Console) cmdKill(args []string) (string, error) {
+	entities, err := c.selectArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	killed := make([]string, 0, len(entities))
+
+	for _, e := range entities {
+		if !c.world.Alive(e) {
+			continue
+		}
+
+		killed = append(killed, c.tags.Describe(e))
+
+		if c.OnKill != nil {
+			c.OnKill(e)
+		}
+
+		if c.world.Alive(e) {
+			c.world.RemoveEntity(e)
+		}
+	}
+
+	if len(killed) == 0 {
+		return "no matching entities", nil
+	}
+
+	return "killed " + strings.Join(killed, ", "), nil
+}
+
+func (c *Console) print(text string) {
+	c.output = append(c.output, strings.Split(text, "\n")...)
+	if len(c.output) > maxConsoleLines {
+		c.output = c.output[len(c.output)-maxConsoleLines:]
+	}
+}
+
+// Update handles the toggle key and text entry while the console is open.
+func (c *Console) Update() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackquote) {
+		c.Toggle()
+
+		return
+	}
+
+	if !c.enabled {
+		return
+	}
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r != '`' {
+			c.input += string(r)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && c.input != "" {
+		c.input = c.input[:len(c.input)-1]
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		_, _ = c.Exec(c.input)
+		c.input = ""
+	}
+}
+
+// Draw renders the console at the bottom of the screen.
+func (c *Console) Draw(screen *ebiten.Image) {
+	if !c.enabled {
+		return
+	}
+
+	lineHeight := 16
+	w := screen.Bounds().Dx()
+	h := (maxConsoleLines + 1) * lineHeight
+	y := screen.Bounds().Dy() - h - 4
+
+	vector.FillRect(screen, 0, float32(y), float32(w), float32(h+4), color.RGBA{R: 0, G: 0, B: 0, A: 200}, false)
+
+	for i, line := range c.output {
+		ebitenutil.DebugPrintAt(screen, line, 6, y+i*lineHeight)
+	}
+
+	ebitenutil.DebugPrintAt(screen, "] "+c.input+"_", 6, y+maxConsoleLines*lineHeight)
+}
@@ -0,0 +1,85 @@
+package debug
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+func TestConsoleKillByTag(t *testing.T) {
+	world := ecs.NewWorld()
+	tagMap := ecs.NewMap1[components.Tag](&world)
+
+	boss := tagMap.NewEntity(&components.Tag{Name: "boss"})
+	minion := tagMap.NewEntity(&components.Tag{Name: "enemy"})
+
+	console := NewConsole(&world)
+
+	var hooked []ecs.Entity
+
+	console.OnKill = func(e ecs.Entity) { hooked = append(hooked, e) }
+
+	if _, err := console.Exec("kill tag:boss"); err != nil {
+		t.Fatalf("kill failed: %v", err)
+	}
+
+	if world.Alive(boss) {
+		t.Error("boss should be removed")
+	}
+
+	if !world.Alive(minion) {
+		t.Error("minion should survive")
+	}
+
+	if len(hooked) != 1 || hooked[0] != boss {
+		t.Errorf("OnKill called with %v", hooked)
+	}
+}
+
+func TestConsoleSelectorsAndErrors(t *testing.T) {
+	world := ecs.NewWorld()
+	nameMap := ecs.NewMap1[components.Name](&world)
+	nameMap.NewEntity(&components.Name{Value: "stuck"})
+
+	console := NewConsole(&world)
+
+	out, err := console.Exec("count name:stuck")
+	if err != nil || out != "1 entities" {
+		t.Errorf("count = %q, %v", out, err)
+	}
+
+	if _, err := console.Exec("kill boss"); err == nil {
+		t.Error("expected error for malformed selector")
+	}
+
+	if _, err := console.Exec("explode"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("err = %v, want ErrUnknownCommand", err)
+	}
+
+	console.Register("ping", "reply pong", func([]string) (string, error) { return "pong", nil })
+
+	if out, _ := console.Exec("ping"); out != "pong" {
+		t.Errorf("ping = %q", out)
+	}
+
+	if len(console.Output()) == 0 {
+		t.Error("expected console history")
+	}
+}
+
+func TestConsoleWithoutWorld(t *testing.T) {
+	console := NewConsole(nil)
+	console.Register("echo", "echo <text>", func(args []string) (string, error) {
+		return args[0], nil
+	})
+
+	if out, err := console.Exec("echo hi"); err != nil || out != "hi" {
+		t.Errorf("echo = %q, %v", out, err)
+	}
+
+	if _, err := console.Exec("kill tag:boss"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("kill without a world: err = %v, want ErrUnknownCommand", err)
+	}
+}
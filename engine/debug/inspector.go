@@ -4,6 +4,7 @@ package debug
 import (
 	"fmt"
 	"image/color"
+	"sort"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -11,6 +12,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/mlange-42/ark/ecs"
 	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
 )
 
 // Inspector provides an on-screen debug overlay for inspecting ECS entities.
@@ -26,6 +29,8 @@ type Inspector struct {
 	healthFilter   *ecs.Filter1[components.Health]
 	colliderFilter *ecs.Filter1[components.Collider]
 	tilemapFilter  *ecs.Filter1[components.Tilemap]
+	tags           *systems.TagQuery
+	scheduler      *engine.Scheduler
 
 	// Camera offset for world-to-screen conversion
 	cameraX, cameraY float64
@@ -38,6 +43,7 @@ type Inspector struct {
 	healthCount   int
 	colliderCount int
 	tilemapCount  int
+	tagCounts     map[string]int
 }
 
 // NewInspector creates a new debug inspector for the given ECS world.
@@ -51,6 +57,7 @@ func NewInspector(world *ecs.World) *Inspector {
 		healthFilter:   ecs.NewFilter1[components.Health](world),
 		colliderFilter: ecs.NewFilter1[components.Collider](world),
 		tilemapFilter:  ecs.NewFilter1[components.Tilemap](world),
+		tags:           systems.NewTagQuery(world),
 	}
 }
 
@@ -60,6 +67,11 @@ func (i *Inspector) SetCamera(x, y float64) {
 	i.cameraY = y
 }
 
+// SetScheduler shows the scheduler's resolved system order and timings in the overlay.
+func (i *Inspector) SetScheduler(s *engine.Scheduler) {
+	i.scheduler = s
+}
+
 // Toggle enables or disables the debug overlay.
 func (i *Inspector) Toggle() {
 	i.enabled = !i.enabled
@@ -129,6 +141,8 @@ func (i *Inspector) updateStats() {
 		i.tilemapCount++
 	}
 
+	i.tagCounts = i.tags.TagCounts()
+
 	// Total is max of position count (most common component)
 	i.totalEntities = max(i.spriteCount, i.positionCount)
 }
@@ -139,8 +153,15 @@ func (i *Inspector) Draw(screen *ebiten.Image) {
 		return
 	}
 
-	// Draw background panel
-	panelW, panelH := float32(200), float32(160)
+	tagNames := make([]string, 0, len(i.tagCounts))
+	for name := range i.tagCounts {
+		tagNames = append(tagNames, name)
+	}
+
+	sort.Strings(tagNames)
+
+	// Draw background panel, growing to fit the tag breakdown
+	panelW, panelH := float32(200), float32(160+16*len(tagNames))
 	panelX, panelY := float32(screen.Bounds().Dx())-panelW-10, float32(10)
 
 	// Semi-transparent background
@@ -183,11 +204,50 @@ func (i *Inspector) Draw(screen *ebiten.Image) {
 
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  Tilemap: %d", i.tilemapCount), int(panelX)+10, y)
 
+	for _, name := range tagNames {
+		y += lineHeight
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  #%s: %d", name, i.tagCounts[name]), int(panelX)+10, y)
+	}
+
 	// Footer
 	ebitenutil.DebugPrintAt(screen, "Press F12 to close", int(panelX)+10, int(panelY)+int(panelH)-18)
 
+	i.drawSchedule(screen, panelX, panelY+panelH+10, panelW)
+
 	// Draw entity position markers
 	i.drawPositionMarkers(screen)
+	i.drawLabels(screen)
+}
+
+// drawSchedule lists the scheduled systems in run order with their last update time.
+func (i *Inspector) drawSchedule(screen *ebiten.Image, x, y, w float32) {
+	if i.scheduler == nil || i.scheduler.Len() == 0 {
+		return
+	}
+
+	timings := i.scheduler.Timings()
+	lineHeight := 16
+
+	h := float32(30 + lineHeight*max(len(timings), 1))
+	vector.FillRect(screen, x, y, w, h, color.RGBA{R: 0, G: 0, B: 0, A: 200}, false)
+	vector.StrokeRect(screen, x, y, w, h, 1, color.RGBA{R: 100, G: 255, B: 100, A: 255}, false)
+
+	ebitenutil.DebugPrintAt(screen, "=== SYSTEM ORDER ===", int(x)+10, int(y)+5)
+
+	ty := int(y) + 25
+
+	if timings == nil {
+		// Scheduling failed; the error is returned from Update
+		ebitenutil.DebugPrintAt(screen, "  unresolved (cycle?)", int(x)+10, ty)
+
+		return
+	}
+
+	for n, st := range timings {
+		ms := float64(st.Duration.Microseconds()) / 1000
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d. %-14s %5.2fms", n+1, st.Name, ms), int(x)+10, ty)
+		ty += lineHeight
+	}
 }
 
 // drawPositionMarkers draws small dots at each entity position.
@@ -212,3 +272,25 @@ func (i *Inspector) drawPositionMarkers(screen *ebiten.Image) {
 		vector.FillCircle(screen, screenX, screenY, 3, markerColor, false)
 	}
 }
+
+// drawLabels prints the Name/Tag label next to each labelled on-screen entity.
+func (i *Inspector) drawLabels(screen *ebiten.Image) {
+	query := i.posFilter.Query()
+	for query.Next() {
+		pos := query.Get()
+		entity := query.Entity()
+
+		if !i.tags.IsLabelled(entity) {
+			continue
+		}
+
+		screenX := int(pos.X - i.cameraX)
+		screenY := int(pos.Y - i.cameraY)
+
+		if screenX < 0 || screenX > screen.Bounds().Dx() || screenY < 0 || screenY > screen.Bounds().Dy() {
+			continue
+		}
+
+		ebitenutil.DebugPrintAt(screen, i.tags.Describe(entity), screenX+5, screenY-16)
+	}
+}
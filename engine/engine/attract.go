@@ -0,0 +1,247 @@
+package engine
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DefaultAttractDelay is the seconds idle on a menu before the demo starts
+// when no delay is given.
+const DefaultAttractDelay = 30
+
+// attractBanner is drawn over a running demo.
+const attractBanner = "DEMO - PRESS ANY KEY"
+
+// IdleDetector measures how long the player has gone without input.
+type IdleDetector struct {
+	Timeout float64 // Seconds; 0 never times out
+
+	idle             float64
+	cursorX, cursorY int
+}
+
+// Update adds dt seconds of idle time, or starts over if active, and reports
+// whether the timeout has been reached.
+func (d *IdleDetector) Update(dt float64, active bool) bool {
+	if active {
+		d.idle = 0
+	} else {
+		d.idle += dt
+	}
+
+	return d.TimedOut()
+}
+
+// TimedOut reports whether the player has been idle for Timeout seconds.
+func (d *IdleDetector) TimedOut() bool {
+	return d.Timeout > 0 && d.idle >= d.Timeout
+}
+
+// Idle returns the seconds since the last input.
+func (d *IdleDetector) Idle() float64 {
+	return d.idle
+}
+
+// Reset starts the idle time over.
+func (d *IdleDetector) Reset() {
+	d.idle = 0
+}
+
+// Poll reports whether any key, mouse button, wheel, touch, or gamepad button
+// is down this frame, or the cursor moved since the last poll.
+func (d *IdleDetector) Poll() bool {
+	active := len(inpututil.AppendPressedKeys(nil)) > 0 ||
+		ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) ||
+		ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) ||
+		len(ebiten.AppendTouchIDs(nil)) > 0
+
+	if wx, wy := ebiten.Wheel(); wx != 0 || wy != 0 {
+		active = true
+	}
+
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if len(inpututil.AppendPressedGamepadButtons(id, nil)) > 0 {
+			active = true
+		}
+	}
+
+	x, y := ebiten.CursorPosition()
+	if x != d.cursorX || y != d.cursorY {
+		d.cursorX, d.cursorY = x, y
+		active = true
+	}
+
+	return active
+}
+
+// Demo is an attract-mode demo, e.g. an AI-played run or a recorded replay.
+// Start is called each time the demo begins and should start it from the
+// top. Update returning ebiten.Termination ends the demo early.
+type Demo interface {
+	Start()
+	Update() error
+	Draw(screen *ebiten.Image)
+}
+
+// Attract plays a Demo once the player has been idle on a menu for a while,
+// and stops it on any input. Hosts call Update every tick and skip their own
+// update and draw while it reports the demo running.
+type Attract struct {
+	Demo Demo
+	Idle IdleDetector
+
+	running bool
+	ticks   int
+}
+
+// NewAttract returns an attract mode that starts demo after delay seconds
+// idle on a menu; 0 uses DefaultAttractDelay.
+func NewAttract(demo Demo, delay float64) *Attract {
+	if delay <= 0 {
+		delay = DefaultAttractDelay
+	}
+
+	return &Attract{Demo: demo, Idle: IdleDetector{Timeout: delay}}
+}
+
+// Running reports whether the demo is playing.
+func (a *Attract) Running() bool {
+	return a.running
+}
+
+// Update advances the idle time by dt seconds. atMenu reports whether the
+// host is on a menu screen, where idling starts the demo, and active whether
+// there was any input this tick. It returns true while the demo is running;
+// the tick that input stops the demo also returns true, so the key press
+// that woke the menu is not handled as a menu action.
+func (a *Attract) Update(dt float64, atMenu, active bool) (bool, error) {
+	if a.running {
+		if active {
+			a.Stop()
+
+			return true, nil
+		}
+
+		a.ticks++
+
+		if err := a.Demo.Update(); err != nil {
+			a.Stop()
+
+			if errors.Is(err, ebiten.Termination) {
+				return true, nil
+			}
+
+			return true, err
+		}
+
+		return true, nil
+	}
+
+	if !atMenu {
+		a.Idle.Reset()
+
+		return false, nil
+	}
+
+	if a.Idle.Update(dt, active) && a.Demo != nil {
+		a.running = true
+		a.ticks = 0
+		a.Demo.Start()
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Stop ends the demo, if one is running, and starts the idle time over.
+func (a *Attract) Stop() {
+	a.running = false
+	a.Idle.Reset()
+}
+
+// Draw draws the running demo with a blinking banner along the bottom.
+func (a *Attract) Draw(screen *ebiten.Image) {
+	a.Demo.Draw(screen)
+
+	b := screen.Bounds()
+	w := len(attractBanner)*6 + 16
+	x := b.Min.X + (b.Dx()-w)/2
+	y := b.Max.Y - 32
+
+	vector.FillRect(screen, float32(x), float32(y), float32(w), 20, color.NRGBA{A: 170}, false)
+
+	if a.ticks/30%2 == 0 {
+		ebitenutil.DebugPrintAt(screen, attractBanner, x+8, y+2)
+	}
+}
+
+// AttractConfig configures WithAttract.
+type AttractConfig struct {
+	Demo  Demo
+	Delay float64 // Seconds idle on a menu before the demo; 0 uses DefaultAttractDelay
+
+	// AtMenu reports whether the game is on a menu screen. Nil counts every
+	// screen as a menu.
+	AtMenu func() bool
+}
+
+// AttractGame wraps a game with an attract mode: the demo replaces the game
+// after the player idles on a menu and hands it back on any input.
+type AttractGame struct {
+	ebiten.Game
+	*Attract
+
+	atMenu func() bool
+	poll   func() bool // Replaced in tests
+	tps    func() int
+}
+
+// WithAttract wraps game with an attract-mode demo.
+func WithAttract(game ebiten.Game, cfg AttractConfig) *AttractGame {
+	a := &AttractGame{
+		Game:    game,
+		Attract: NewAttract(cfg.Demo, cfg.Delay),
+		atMenu:  cfg.AtMenu,
+		tps:     ebiten.TPS,
+	}
+	a.poll = a.Idle.Poll
+
+	return a
+}
+
+// Update runs the demo while it plays and the wrapped game otherwise.
+func (a *AttractGame) Update() error {
+	atMenu := a.atMenu == nil || a.atMenu()
+
+	running, err := a.Attract.Update(1/float64(a.tps()), atMenu, a.poll())
+	if running || err != nil {
+		return err
+	}
+
+	return a.Game.Update()
+}
+
+// Pause forwards to the wrapped game, so FocusConfig.AutoPause reaches a
+// Pauser inside an AttractGame.
+func (a *AttractGame) Pause() {
+	if p, ok := a.Game.(Pauser); ok {
+		p.Pause()
+	}
+}
+
+// Draw draws the demo while it plays and the wrapped game otherwise.
+func (a *AttractGame) Draw(screen *ebiten.Image) {
+	if a.Running() {
+		a.Attract.Draw(screen)
+
+		return
+	}
+
+	a.Game.Draw(screen)
+}
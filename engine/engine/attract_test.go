@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// scriptedDemo counts starts and updates and ends itself after end updates.
+type scriptedDemo struct {
+	starts, updates, end int
+}
+
+func (d *scriptedDemo) Start()             { d.starts++; d.updates = 0 }
+func (d *scriptedDemo) Draw(*ebiten.Image) {}
+
+func (d *scriptedDemo) Update() error {
+	d.updates++
+	if d.end > 0 && d.updates >= d.end {
+		return ebiten.Termination
+	}
+
+	return nil
+}
+
+func TestAttractStartsWhenIdleOnMenuAndStopsOnInput(t *testing.T) {
+	game := &countingGame{}
+	demo := &scriptedDemo{}
+	atMenu, input := true, false
+
+	a := WithAttract(game, AttractConfig{Demo: demo, Delay: 1, AtMenu: func() bool { return atMenu }})
+	a.poll = func() bool { return input }
+	a.tps = func() int { return 8 }
+
+	tick := func(n int) {
+		for range n {
+			if err := a.Update(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Idling in a run never starts the demo
+	atMenu = false
+	tick(30)
+
+	if a.Running() {
+		t.Fatal("demo started outside a menu")
+	}
+
+	atMenu = true
+	tick(7)
+
+	if a.Running() || game.updates != 37 {
+		t.Fatalf("running %v after 7/8s on the menu, %d game updates", a.Running(), game.updates)
+	}
+
+	tick(1)
+
+	if !a.Running() || demo.starts != 1 {
+		t.Fatalf("running %v, starts %d after 1s idle", a.Running(), demo.starts)
+	}
+
+	tick(5)
+
+	if demo.updates != 5 || game.updates != 37 {
+		t.Errorf("demo updates %d, game updates %d while the demo plays", demo.updates, game.updates)
+	}
+
+	// The key that stops the demo does not reach the menu
+	input = true
+	tick(1)
+
+	if a.Running() || game.updates != 37 {
+		t.Errorf("running %v, game updates %d after input", a.Running(), game.updates)
+	}
+
+	input = false
+	tick(1)
+
+	if game.updates != 38 || a.Idle.Idle() == 0 {
+		t.Errorf("game updates %d, idle %v after returning to the menu", game.updates, a.Idle.Idle())
+	}
+}
+
+func TestAttractDemoEndingReturnsToMenu(t *testing.T) {
+	demo := &scriptedDemo{end: 3}
+	a := NewAttract(demo, 0.625)
+
+	for range 5 {
+		if _, err := a.Update(0.125, true, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !a.Running() {
+		t.Fatal("demo did not start after 0.625s")
+	}
+
+	for range 3 {
+		if _, err := a.Update(0.125, true, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if a.Running() || a.Idle.Idle() != 0 {
+		t.Errorf("running %v, idle %v after the demo ended", a.Running(), a.Idle.Idle())
+	}
+
+	// Idling again replays it from the top
+	for range 5 {
+		if _, err := a.Update(0.125, true, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if demo.starts != 2 {
+		t.Errorf("starts = %d, want 2", demo.starts)
+	}
+}
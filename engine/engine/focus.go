@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// FocusPolicy selects what a game does while its window is unfocused.
+type FocusPolicy int
+
+const (
+	FocusIgnore   FocusPolicy = iota // Keep simulating at full speed
+	FocusPause                       // Stop updating until focus returns
+	FocusThrottle                    // Keep updating at a reduced TPS
+)
+
+// DefaultIdleTPS is the tick rate used while unfocused when FocusConfig.IdleTPS is unset.
+const DefaultIdleTPS = 10
+
+// resumeGap is the shortest stall between updates reported to a Resumer;
+// anything shorter is an ordinary hitch rather than time away.
+const resumeGap = time.Second
+
+// FocusConfig configures focus-aware throttling.
+type FocusConfig struct {
+	Policy  FocusPolicy
+	IdleTPS int // Ticks per second while paused or throttled; 0 uses DefaultIdleTPS
+
+	// AutoPause calls Pause on a game implementing Pauser when the window
+	// loses focus or the loop was suspended, so an action game waits in its
+	// pause menu instead of carrying on the moment the player comes back.
+	AutoPause bool
+}
+
+// Resumer is implemented by games that catch up on time they did not
+// simulate, e.g. an idle game paying out offline income. Resume is called
+// before the first update after a pause or after the loop was suspended
+// (minimized window, hidden browser tab).
+type Resumer interface {
+	Resume(away time.Duration)
+}
+
+// Pauser is implemented by games with a pause menu of their own. Pause
+// must do nothing when the game is already paused or has nothing to pause,
+// e.g. on a title screen.
+type Pauser interface {
+	Pause()
+}
+
+// FocusGame wraps a game with focus-aware throttling so unfocused windows
+// stop burning CPU. Games whose Update advances by a fixed 1/60 s should read
+// ebiten.TPS instead when using FocusThrottle.
+type FocusGame struct {
+	ebiten.Game
+	Config FocusConfig
+
+	focused     bool
+	activeTPS   int
+	last        time.Time
+	pausedSince time.Time
+
+	// Platform hooks, replaced in tests
+	isFocused func() bool
+	now       func() time.Time
+	setTPS    func(int)
+	tps       func() int
+}
+
+// WithFocus wraps game with the given focus policy. With FocusPause the
+// desktop loop is also stopped entirely while unfocused.
+func WithFocus(game ebiten.Game, cfg FocusConfig) *FocusGame {
+	if cfg.Policy == FocusPause {
+		ebiten.SetRunnableOnUnfocused(false)
+	}
+
+	return newFocusGame(game, cfg, ebiten.IsFocused, time.Now, ebiten.SetTPS, ebiten.TPS)
+}
+
+func newFocusGame(
+	game ebiten.Game,
+	cfg FocusConfig,
+	isFocused func() bool,
+	now func() time.Time,
+	setTPS func(int),
+	tps func() int,
+) *FocusGame {
+	return &FocusGame{
+		Game:      game,
+		Config:    cfg,
+		focused:   true,
+		isFocused: isFocused,
+		now:       now,
+		setTPS:    setTPS,
+		tps:       tps,
+	}
+}
+
+// Paused reports whether updates are currently being skipped.
+func (f *FocusGame) Paused() bool {
+	return !f.pausedSince.IsZero()
+}
+
+// Update applies the focus policy, then updates the wrapped game unless it is paused.
+func (f *FocusGame) Update() error {
+	now := f.now()
+
+	var away time.Duration
+
+	// A long stall while not paused means the loop itself was suspended
+	if !f.last.IsZero() && !f.Paused() {
+		if gap := now.Sub(f.last); gap >= resumeGap {
+			away = gap
+		}
+	}
+
+	f.last = now
+
+	lost := false
+
+	if focused := f.isFocused(); focused != f.focused {
+		f.focused = focused
+
+		if focused {
+			away += f.regainFocus(now)
+		} else {
+			f.loseFocus(now)
+			lost = true
+		}
+	}
+
+	if f.Config.AutoPause && (lost || away > 0) {
+		if p, ok := f.Game.(Pauser); ok {
+			p.Pause()
+		}
+	}
+
+	if away > 0 {
+		if r, ok := f.Game.(Resumer); ok {
+			r.Resume(away)
+		}
+	}
+
+	if f.Paused() {
+		return nil
+	}
+
+	return f.Game.Update()
+}
+
+func (f *FocusGame) loseFocus(now time.Time) {
+	if f.Config.Policy == FocusIgnore {
+		return
+	}
+
+	idle := f.Config.IdleTPS
+	if idle <= 0 {
+		idle = DefaultIdleTPS
+	}
+
+	f.activeTPS = f.tps()
+	f.setTPS(idle)
+
+	if f.Config.Policy == FocusPause {
+		f.pausedSince = now
+	}
+}
+
+// regainFocus restores the tick rate and returns how long updates were paused.
+func (f *FocusGame) regainFocus(now time.Time) time.Duration {
+	if f.activeTPS > 0 {
+		f.setTPS(f.activeTPS)
+		f.activeTPS = 0
+	}
+
+	if !f.Paused() {
+		return 0
+	}
+
+	away := now.Sub(f.pausedSince)
+	f.pausedSince = time.Time{}
+
+	return away
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// focusHarness drives a FocusGame with a fake clock, focus state, and TPS.
+type focusHarness struct {
+	focused bool
+	clock   time.Time
+	tps     int
+}
+
+func (h *focusHarness) wrap(game ebiten.Game, cfg FocusConfig) *FocusGame {
+	h.focused, h.clock, h.tps = true, time.Unix(1000, 0), 60
+
+	return newFocusGame(
+		game,
+		cfg,
+		func() bool { return h.focused },
+		func() time.Time { return h.clock },
+		func(tps int) { h.tps = tps },
+		func() int { return h.tps },
+	)
+}
+
+// tick advances the clock by one frame at the current TPS and updates f.
+func (h *focusHarness) tick(t *testing.T, f *FocusGame) {
+	t.Helper()
+
+	h.clock = h.clock.Add(time.Second / time.Duration(h.tps))
+
+	if err := f.Update(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// countingGame counts updates and records Resume calls.
+type countingGame struct {
+	updates int
+	away    []time.Duration
+}
+
+func (c *countingGame) Update() error              { c.updates++; return nil }
+func (c *countingGame) Draw(*ebiten.Image)         {}
+func (c *countingGame) Layout(w, h int) (int, int) { return w, h }
+func (c *countingGame) Resume(away time.Duration)  { c.away = append(c.away, away) }
+
+func TestFocusPauseSkipsUpdatesAndReportsAway(t *testing.T) {
+	var h focusHarness
+
+	game := &countingGame{}
+	f := h.wrap(game, FocusConfig{Policy: FocusPause})
+
+	h.tick(t, f)
+
+	h.focused = false
+	for range 50 {
+		h.tick(t, f)
+	}
+
+	if game.updates != 1 || !f.Paused() {
+		t.Errorf("updates = %d, paused = %v; want 1 update while paused", game.updates, f.Paused())
+	}
+
+	if h.tps != DefaultIdleTPS {
+		t.Errorf("TPS while paused = %d, want %d", h.tps, DefaultIdleTPS)
+	}
+
+	h.focused = true
+	h.tick(t, f)
+
+	if game.updates != 2 || h.tps != 60 {
+		t.Errorf("after refocus: updates = %d, TPS = %d", game.updates, h.tps)
+	}
+
+	if len(game.away) != 1 || game.away[0] < 5*time.Second {
+		t.Errorf("Resume calls = %v, want one of about 5s", game.away)
+	}
+}
+
+func TestFocusThrottleKeepsUpdating(t *testing.T) {
+	var h focusHarness
+
+	game := &countingGame{}
+	f := h.wrap(game, FocusConfig{Policy: FocusThrottle, IdleTPS: 5})
+
+	h.focused = false
+	for range 10 {
+		h.tick(t, f)
+	}
+
+	if game.updates != 10 || h.tps != 5 {
+		t.Errorf("updates = %d at TPS %d, want 10 at 5", game.updates, h.tps)
+	}
+
+	h.focused = true
+	h.tick(t, f)
+
+	if h.tps != 60 || len(game.away) != 0 {
+		t.Errorf("after refocus: TPS = %d, Resume calls = %v", h.tps, game.away)
+	}
+}
+
+func TestFocusReportsSuspendedLoop(t *testing.T) {
+	var h focusHarness
+
+	game := &countingGame{}
+	f := h.wrap(game, FocusConfig{Policy: FocusIgnore})
+
+	h.tick(t, f)
+	h.clock = h.clock.Add(time.Minute)
+	h.tick(t, f)
+
+	if len(game.away) != 1 || game.away[0] < time.Minute {
+		t.Errorf("Resume calls = %v, want one of about 1m", game.away)
+	}
+
+	h.focused = false
+	h.tick(t, f)
+
+	if h.tps != 60 || game.updates != 3 {
+		t.Errorf("FocusIgnore changed TPS to %d or skipped updates (%d)", h.tps, game.updates)
+	}
+}
+
+// pausingGame is a countingGame with a pause menu.
+type pausingGame struct {
+	countingGame
+
+	pauses int
+}
+
+func (p *pausingGame) Pause() { p.pauses++ }
+
+func TestFocusAutoPause(t *testing.T) {
+	var h focusHarness
+
+	game := &pausingGame{}
+	f := h.wrap(game, FocusConfig{Policy: FocusThrottle, AutoPause: true})
+
+	h.tick(t, f)
+	h.focused = false
+	h.tick(t, f)
+	h.tick(t, f)
+
+	if game.pauses != 1 {
+		t.Fatalf("Pause called %d times on losing focus, want 1", game.pauses)
+	}
+
+	// A suspended loop never sees the focus change, only the gap
+	h.focused = true
+	h.tick(t, f)
+	h.clock = h.clock.Add(time.Minute)
+	h.tick(t, f)
+
+	if game.pauses != 2 {
+		t.Errorf("Pause called %d times after a suspended loop, want 2", game.pauses)
+	}
+
+	// Off by default
+	off := &pausingGame{}
+	f = h.wrap(off, FocusConfig{Policy: FocusPause})
+
+	h.focused = false
+	h.tick(t, f)
+
+	if off.pauses != 0 {
+		t.Errorf("Pause called %d times without AutoPause", off.pauses)
+	}
+}
@@ -15,6 +15,8 @@ type Game struct {
 	// Systems to run each frame
 	updateSystems []System
 	drawSystems   []DrawSystem
+	scheduler     *Scheduler
+	clock         *TickClock
 }
 
 // System is an interface for ECS systems that run during Update.
@@ -22,11 +24,27 @@ type System interface {
 	Update(world *ecs.World)
 }
 
+// SystemFunc adapts a function to System, for small systems that need no
+// state of their own.
+type SystemFunc func(world *ecs.World)
+
+// Update calls f.
+func (f SystemFunc) Update(world *ecs.World) {
+	f(world)
+}
+
 // DrawSystem is an interface for ECS systems that run during Draw.
 type DrawSystem interface {
 	Draw(world *ecs.World, screen *ebiten.Image)
 }
 
+// Resetter is implemented by systems and games that hold per-run state
+// (pools, timers, caches) which must be cleared on a soft restart while
+// loaded assets are kept.
+type Resetter interface {
+	Reset()
+}
+
 // NewGame creates a new game instance with given dimensions.
 func NewGame(width, height int, title string) *Game {
 	return &Game{
@@ -36,6 +54,8 @@ func NewGame(width, height int, title string) *Game {
 		title:         title,
 		updateSystems: make([]System, 0),
 		drawSystems:   make([]DrawSystem, 0),
+		scheduler:     NewScheduler(),
+		clock:         NewTickClock(),
 	}
 }
 
@@ -44,18 +64,46 @@ func (g *Game) AddSystem(s System) {
 	g.updateSystems = append(g.updateSystems, s)
 }
 
+// RegisterSystem adds an update system ordered by its declared dependencies.
+// Scheduled systems run after those added with AddSystem.
+func (g *Game) RegisterSystem(spec SystemSpec) error {
+	return g.scheduler.Register(spec)
+}
+
+// Scheduler returns the dependency scheduler, e.g. for the debug overlay.
+func (g *Game) Scheduler() *Scheduler {
+	return g.scheduler
+}
+
 // AddDrawSystem adds a draw system to the game loop.
 func (g *Game) AddDrawSystem(s DrawSystem) {
 	g.drawSystems = append(g.drawSystems, s)
 }
 
+// SetTPS sets the simulation rate independently of the display refresh
+// rate; Draw keeps running at the refresh rate. Pair it with an
+// InterpolationSystem and a RenderSystem interpolating by Alpha so high
+// refresh displays show smooth motion between ticks.
+func (g *Game) SetTPS(tps int) {
+	ebiten.SetTPS(tps)
+}
+
+// Alpha returns how far the current frame is between the last two ticks,
+// for interpolating entity transforms in Draw.
+func (g *Game) Alpha() float64 {
+	return g.clock.Alpha()
+}
+
 // Update implements ebiten.Game interface.
 func (g *Game) Update() error {
 	for _, s := range g.updateSystems {
 		s.Update(&g.World)
 	}
 
-	return nil
+	err := g.scheduler.Update(&g.World)
+	g.clock.Tick()
+
+	return err
 }
 
 // Draw implements ebiten.Game interface.
@@ -65,15 +113,41 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	}
 }
 
+// Reset soft-restarts the game: it removes every entity from the world and
+// resets each registered system that implements Resetter. The world is reset
+// in place, so filters and maps held by systems stay valid and its storage is
+// reused instead of reallocated.
+func (g *Game) Reset() {
+	g.World.Reset()
+
+	for _, s := range g.updateSystems {
+		resetSystem(s)
+	}
+
+	for _, s := range g.drawSystems {
+		resetSystem(s)
+	}
+
+	g.scheduler.Reset()
+}
+
+// resetSystem resets s if it holds per-run state.
+func resetSystem(s any) {
+	if r, ok := s.(Resetter); ok {
+		r.Reset()
+	}
+}
+
 // Layout implements ebiten.Game interface.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return g.width, g.height
 }
 
-// Run starts the game loop.
+// Run starts the game loop, with Alt+Enter toggling fullscreen. Wrap the game
+// with WithWindow and an App to also remember the window between sessions.
 func (g *Game) Run() error {
 	ebiten.SetWindowSize(g.width, g.height)
 	ebiten.SetWindowTitle(g.title)
 
-	return ebiten.RunGame(g)
+	return ebiten.RunGame(WithWindow(g, WindowConfig{}))
 }
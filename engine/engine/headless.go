@@ -99,8 +99,14 @@ func (g *HeadlessGame) RunWithCallback(n int, callback func(*ecs.World, int64))
 	}
 }
 
-// Reset clears the world and resets tick counter.
+// Reset clears the world in place, resets systems that implement Resetter,
+// and resets the tick counter.
 func (g *HeadlessGame) Reset() {
-	g.World = ecs.NewWorld()
+	g.World.Reset()
+
+	for _, s := range g.updateSystems {
+		resetSystem(s)
+	}
+
 	g.currentTick = 0
 }
@@ -121,3 +121,33 @@ func TestHeadlessGameReset(t *testing.T) {
 		t.Errorf("Tick should be 0 after reset, got %d", game.CurrentTick())
 	}
 }
+
+// poolSystem counts updates and clears the count when reset.
+type poolSystem struct {
+	live int
+}
+
+func (s *poolSystem) Update(world *ecs.World) { s.live++ }
+func (s *poolSystem) Reset()                  { s.live = 0 }
+
+func TestHeadlessGameResetClearsWorldAndSystems(t *testing.T) {
+	game := NewHeadlessGame()
+	pool := &poolSystem{}
+	game.AddSystem(pool)
+
+	game.World.NewEntity()
+	game.StepN(5)
+	game.Reset()
+
+	if pool.live != 0 {
+		t.Errorf("system state = %d after reset, want 0", pool.live)
+	}
+
+	query := ecs.NewFilter0(&game.World).Query()
+	n := query.Count()
+	query.Close()
+
+	if n != 0 {
+		t.Errorf("entities after reset = %d, want 0", n)
+	}
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TickClock measures how far the display is between two simulation ticks.
+// Ebitengine calls Draw at the display refresh rate and Update at the TPS,
+// so on a 144Hz monitor running a 60 TPS game several frames are drawn per
+// tick; rendering at Alpha of the way from the previous to the current
+// state keeps motion smooth instead of stepping at 60Hz.
+type TickClock struct {
+	last time.Time
+
+	// Platform hooks, replaced in tests
+	now func() time.Time
+	tps func() int
+}
+
+// NewTickClock creates a clock that reads the current TPS each frame, so it
+// follows SetTPS and focus throttling.
+func NewTickClock() *TickClock {
+	return newTickClock(time.Now, ebiten.TPS)
+}
+
+func newTickClock(now func() time.Time, tps func() int) *TickClock {
+	return &TickClock{now: now, tps: tps}
+}
+
+// Tick records that a simulation step just finished. Call it at the end of Update.
+func (c *TickClock) Tick() {
+	c.last = c.now()
+}
+
+// Alpha returns the fraction of a tick elapsed since the last Tick, in
+// [0, 1]. It is 1 before the first tick and when TPS is synced to the
+// display, where every frame shows a fresh state.
+func (c *TickClock) Alpha() float64 {
+	tps := c.tps()
+	if c.last.IsZero() || tps <= 0 {
+		return 1
+	}
+
+	alpha := c.now().Sub(c.last).Seconds() * float64(tps)
+
+	return min(max(alpha, 0), 1)
+}
@@ -0,0 +1,33 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickClockAlpha(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	tps := 60
+	c := newTickClock(func() time.Time { return clock }, func() int { return tps })
+
+	if a := c.Alpha(); a != 1 {
+		t.Errorf("alpha before the first tick = %v, want 1", a)
+	}
+
+	c.Tick()
+
+	clock = clock.Add(time.Second / 240)
+	if a := c.Alpha(); a < 0.24 || a > 0.26 {
+		t.Errorf("alpha a quarter tick in = %v, want 0.25", a)
+	}
+
+	clock = clock.Add(time.Second)
+	if a := c.Alpha(); a != 1 {
+		t.Errorf("alpha after a stall = %v, want clamped to 1", a)
+	}
+
+	tps = -1 // ebiten.SyncWithFPS
+	if a := c.Alpha(); a != 1 {
+		t.Errorf("alpha with TPS synced to FPS = %v, want 1", a)
+	}
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"image"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// PixelArtToggleKey switches pixel art rendering on and off when
+// PixelArtConfig.Toggle is set.
+const PixelArtToggleKey = ebiten.KeyF4
+
+// PixelArtConfig configures low-resolution rendering.
+type PixelArtConfig struct {
+	// Internal resolution the world is rendered at, e.g. 320x180; 0 uses
+	// half the game's layout size.
+	Width, Height int
+
+	CRT    bool // Scanlines, an aperture mask, and a vignette over the upscaled image
+	Toggle bool // PixelArtToggleKey switches between pixel art and native rendering
+}
+
+// LayeredGame is a game that draws its world and UI separately. Draw should
+// draw both; PixelArtGame draws only the world at low resolution and the UI
+// over it at native resolution, so text stays crisp.
+type LayeredGame interface {
+	DrawWorld(screen *ebiten.Image)
+	DrawUI(screen *ebiten.Image)
+}
+
+// PixelArtGame renders a game at a low internal resolution and scales it up
+// with nearest-neighbor filtering for a retro look. The world is drawn at
+// native size and averaged down, so thin lines and small sprites blend into
+// the low-resolution pixels instead of flickering in and out.
+type PixelArtGame struct {
+	ebiten.Game
+	Config PixelArtConfig
+
+	disabled bool
+
+	// Native-size world, low-resolution copy, and upscaled copy for the CRT pass
+	world, low, upscaled *ebiten.Image
+
+	// Platform hook, replaced in tests
+	toggleKey func() bool
+}
+
+// WithPixelArt wraps game with low-resolution rendering.
+func WithPixelArt(game ebiten.Game, cfg PixelArtConfig) *PixelArtGame {
+	return &PixelArtGame{Game: game, Config: cfg, toggleKey: func() bool {
+		return input.IsKeyJustPressed(PixelArtToggleKey)
+	}}
+}
+
+// Enabled reports whether the world is rendered at low resolution.
+func (p *PixelArtGame) Enabled() bool {
+	return !p.disabled
+}
+
+// SetEnabled switches low-resolution rendering on or off.
+func (p *PixelArtGame) SetEnabled(enabled bool) {
+	p.disabled = !enabled
+}
+
+// Update handles the toggle key and updates the game.
+func (p *PixelArtGame) Update() error {
+	if p.Config.Toggle && p.toggleKey() {
+		p.disabled = !p.disabled
+	}
+
+	return p.Game.Update()
+}
+
+// Resume forwards to the wrapped game, so a FocusGame around a PixelArtGame
+// still reaches a Resumer.
+func (p *PixelArtGame) Resume(away time.Duration) {
+	if r, ok := p.Game.(Resumer); ok {
+		r.Resume(away)
+	}
+}
+
+// Pause forwards to the wrapped game, so FocusConfig.AutoPause reaches a
+// Pauser inside a PixelArtGame.
+func (p *PixelArtGame) Pause() {
+	if pauser, ok := p.Game.(Pauser); ok {
+		pauser.Pause()
+	}
+}
+
+// Resolution returns the internal resolution for a screen of the given size.
+func (p *PixelArtGame) Resolution(screen image.Point) image.Point {
+	w, h := p.Config.Width, p.Config.Height
+	if w <= 0 || h <= 0 {
+		w, h = screen.X/2, screen.Y/2
+	}
+
+	return image.Pt(max(w, 1), max(h, 1))
+}
+
+// Draw draws the world at low resolution, scales it up to the screen, and
+// draws the UI of a LayeredGame over it at native resolution.
+func (p *PixelArtGame) Draw(screen *ebiten.Image) {
+	if p.disabled {
+		p.Game.Draw(screen)
+
+		return
+	}
+
+	size := screen.Bounds().Size()
+	low := p.Resolution(size)
+	layered, isLayered := p.Game.(LayeredGame)
+
+	p.world = sizedImage(p.world, size)
+	if isLayered {
+		layered.DrawWorld(p.world)
+	} else {
+		p.Game.Draw(p.world)
+	}
+
+	// Filter linearly going down, so each low pixel averages the world under
+	// it, and nearest-neighbor going up, so the result keeps hard edges
+	p.low = sizedImage(p.low, low)
+	down := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	down.GeoM.Scale(float64(low.X)/float64(size.X), float64(low.Y)/float64(size.Y))
+	p.low.DrawImage(p.world, down)
+
+	up := &ebiten.DrawImageOptions{}
+	up.GeoM.Scale(float64(size.X)/float64(low.X), float64(size.Y)/float64(low.Y))
+
+	if p.Config.CRT {
+		p.upscaled = sizedImage(p.upscaled, size)
+		p.upscaled.DrawImage(p.low, up)
+
+		op := &ebiten.DrawRectShaderOptions{Uniforms: map[string]any{"Rows": float32(low.Y)}}
+		op.Images[0] = p.upscaled
+		op.GeoM.Translate(float64(screen.Bounds().Min.X), float64(screen.Bounds().Min.Y))
+		screen.DrawRectShader(size.X, size.Y, crtShader(), op)
+	} else {
+		up.GeoM.Translate(float64(screen.Bounds().Min.X), float64(screen.Bounds().Min.Y))
+		screen.DrawImage(p.low, up)
+	}
+
+	if isLayered {
+		layered.DrawUI(screen)
+	}
+}
+
+// sizedImage returns a clear image of the given size, reusing img when it
+// already has that size.
+func sizedImage(img *ebiten.Image, size image.Point) *ebiten.Image {
+	if img != nil && img.Bounds().Size() == size {
+		img.Clear()
+
+		return img
+	}
+
+	if img != nil {
+		img.Deallocate()
+	}
+
+	return ebiten.NewImage(size.X, size.Y)
+}
+
+// crtSource darkens the gap between low-resolution rows, tints columns
+// through an RGB aperture mask, and darkens the corners.
+const crtSource = `//kage:unit pixels
+package main
+
+var Rows float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	size := imageSrc0Size()
+	local := srcPos - imageSrc0Origin()
+	c := imageSrc0At(srcPos)
+
+	row := fract(local.y / size.y * Rows)
+	scan := 0.75 + 0.25*sin(row*3.14159)
+
+	mask := vec3(1, 0.9, 0.9)
+	column := mod(floor(local.x), 3)
+	if column >= 2 {
+		mask = vec3(0.9, 0.9, 1)
+	} else if column >= 1 {
+		mask = vec3(0.9, 1, 0.9)
+	}
+
+	uv := local/size*2 - 1
+	vignette := 1 - 0.2*dot(uv, uv)
+
+	return vec4(c.rgb*mask*scan*vignette, c.a)
+}
+`
+
+var crtShader = sync.OnceValue(func() *ebiten.Shader { return mustShader(crtSource) })
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// layeredGame records the images its layers were drawn on.
+type layeredGame struct {
+	countingGame
+
+	draws      []*ebiten.Image
+	world, ui  *ebiten.Image
+	worldDraws int
+}
+
+func (l *layeredGame) Draw(screen *ebiten.Image)      { l.draws = append(l.draws, screen) }
+func (l *layeredGame) DrawWorld(screen *ebiten.Image) { l.world = screen; l.worldDraws++ }
+func (l *layeredGame) DrawUI(screen *ebiten.Image)    { l.ui = screen }
+
+func TestPixelArtResolutionDefaultsToHalf(t *testing.T) {
+	p := WithPixelArt(&countingGame{}, PixelArtConfig{})
+
+	if got := p.Resolution(image.Pt(640, 480)); got != image.Pt(320, 240) {
+		t.Errorf("default resolution = %v, want half the screen", got)
+	}
+
+	p.Config.Width, p.Config.Height = 320, 180
+	if got := p.Resolution(image.Pt(1280, 720)); got != image.Pt(320, 180) {
+		t.Errorf("resolution = %v, want the configured 320x180", got)
+	}
+}
+
+func TestPixelArtDrawsUIAtNativeResolution(t *testing.T) {
+	game := &layeredGame{}
+	screen := ebiten.NewImage(64, 48)
+
+	for _, crt := range []bool{false, true} {
+		p := WithPixelArt(game, PixelArtConfig{Width: 16, Height: 12, CRT: crt})
+		p.Draw(screen)
+
+		if game.ui != screen {
+			t.Error("UI was not drawn on the screen")
+		}
+
+		if game.world == screen || game.world.Bounds().Size() != image.Pt(64, 48) {
+			t.Errorf("world drawn on %v, want a native-size offscreen image", game.world.Bounds())
+		}
+	}
+
+	if len(game.draws) != 0 || game.worldDraws != 2 {
+		t.Errorf("Draw called %d times and DrawWorld %d, want only the layers", len(game.draws), game.worldDraws)
+	}
+}
+
+func TestPixelArtToggle(t *testing.T) {
+	game := &layeredGame{}
+	screen := ebiten.NewImage(64, 48)
+
+	pressed := false
+	p := WithPixelArt(game, PixelArtConfig{Toggle: true})
+	p.toggleKey = func() bool { return pressed }
+
+	pressed = true
+	if err := p.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Native rendering draws the whole game straight to the screen
+	if p.Draw(screen); p.Enabled() || len(game.draws) != 1 || game.draws[0] != screen {
+		t.Fatalf("enabled = %v after toggling, draws = %d", p.Enabled(), len(game.draws))
+	}
+
+	p.Config.Toggle = false
+	if err := p.Update(); err != nil || p.Enabled() {
+		t.Error("toggle key handled while Toggle is off")
+	}
+}
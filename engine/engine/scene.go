@@ -1,9 +1,10 @@
 package engine
 
 import (
-	"image/color"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
 )
 
 // Scene represents a game scene (menu, gameplay, pause, etc.)
@@ -18,12 +19,40 @@ type Scene interface {
 	Draw(screen *ebiten.Image)
 }
 
-// SceneManager handles scene transitions.
+// SceneManager is a stack of scenes. The top scene is updated and drawn;
+// scenes under it stay loaded, e.g. gameplay under a pause menu.
 type SceneManager struct {
-	current      Scene
-	next         Scene
-	transition   Transition
-	inTransition bool
+	stack []Scene
+
+	// Playing transition and what to do when it finishes
+	transition Transition
+	done       func()
+
+	// Played by Push, Pop, and TransitionTo when given none
+	defaultTransition Transition
+
+	// Pause key, the pause menu it opened, and whether audio is ducked
+	pause     PauseConfig
+	pauseKey  func() bool
+	pauseMenu Scene
+	ducked    bool
+}
+
+// Ducker lowers a game's audio while it is paused; *assets.AudioManager is
+// one.
+type Ducker interface {
+	SetDucked(ducked bool)
+}
+
+// PauseConfig configures the pause key of a SceneManager.
+type PauseConfig struct {
+	Controls *input.Map // Its Pause action opens and closes the menu
+
+	// Menu returns the pause menu to push over scene, or nil where there is
+	// nothing to pause, e.g. on a title screen.
+	Menu func(scene Scene) Scene
+
+	Audio Ducker // Ducked while the menu is open; nil leaves audio alone
 }
 
 // Transition defines how scenes switch.
@@ -41,137 +70,240 @@ func NewSceneManager() *SceneManager {
 	return &SceneManager{}
 }
 
-// SetScene immediately switches to a new scene.
-func (m *SceneManager) SetScene(scene Scene) error {
-	if m.current != nil {
-		m.current.Unload()
+// SetDefaultTransition sets the transition played by every scene change
+// that does not name its own; nil switches instantly.
+func (m *SceneManager) SetDefaultTransition(transition Transition) {
+	m.defaultTransition = transition
+}
+
+// SetPause makes the Pause action a universal pause key: pressed on any
+// scene it pushes the pause menu, and pressed on the pause menu it pops it.
+// Scenes pushed over the pause menu, such as an options screen, get the key
+// themselves.
+func (m *SceneManager) SetPause(cfg PauseConfig) {
+	m.pause = cfg
+	m.pauseKey = func() bool { return cfg.Controls.JustPressed(input.Pause) }
+}
+
+// Pause pushes the pause menu over the current scene unless already paused
+// or the scene has nothing to pause. With FocusConfig.AutoPause it is called
+// when the window loses focus.
+func (m *SceneManager) Pause() {
+	if m.pause.Menu == nil || m.Paused() {
+		return
 	}
 
-	m.current = scene
-	if m.current != nil {
-		return m.current.Load()
+	current := m.Current()
+	if current == nil {
+		return
 	}
 
-	return nil
+	menu := m.pause.Menu(current)
+	if menu == nil {
+		return
+	}
+
+	if err := m.Push(menu); err != nil {
+		return
+	}
+
+	m.pauseMenu = menu
+	m.duck()
+}
+
+// Unpause pops the pause menu when it is the current scene.
+func (m *SceneManager) Unpause() {
+	if m.pauseMenu == nil || m.Current() != m.pauseMenu {
+		return
+	}
+
+	m.Pop()
+	m.pauseMenu = nil
+	m.duck()
+}
+
+// Paused reports whether the pause menu is on the stack, under other
+// scenes or not.
+func (m *SceneManager) Paused() bool {
+	return m.pauseMenu != nil && slices.Contains(m.stack, m.pauseMenu)
 }
 
-// TransitionTo starts a transition to a new scene.
-func (m *SceneManager) TransitionTo(scene Scene, transition Transition) {
-	m.next = scene
-	m.transition = transition
+// duck ducks the audio while paused and restores it otherwise, telling the
+// Ducker only when that changes.
+func (m *SceneManager) duck() {
+	if m.pauseMenu != nil && !m.Paused() {
+		m.pauseMenu = nil // Popped by a scene, e.g. a Resume button
+	}
 
-	m.inTransition = true
-	if transition != nil {
-		transition.Start(m.current, m.next)
+	if paused := m.Paused(); paused != m.ducked && m.pause.Audio != nil {
+		m.ducked = paused
+		m.pause.Audio.SetDucked(paused)
 	}
 }
 
-// Update updates the current scene or transition.
-func (m *SceneManager) Update() error {
-	if m.inTransition && m.transition != nil {
-		if m.transition.Update() {
-			// Transition complete
-			if m.current != nil {
-				m.current.Unload()
-			}
+// SetScene immediately replaces the current scene.
+func (m *SceneManager) SetScene(scene Scene) error {
+	m.finishTransition()
 
-			m.current = m.next
+	if current := m.Current(); current != nil {
+		current.Unload()
+		m.stack = m.stack[:len(m.stack)-1]
+	}
 
-			m.next = nil
-			if m.current != nil {
-				if err := m.current.Load(); err != nil {
-					return err
-				}
-			}
+	if scene == nil {
+		return nil
+	}
 
-			m.inTransition = false
-			m.transition = nil
-		}
-	} else if m.current != nil {
-		return m.current.Update()
+	m.stack = append(m.stack, scene)
+
+	return scene.Load()
+}
+
+// TransitionTo replaces the current scene with scene, playing transition,
+// or the default transition when nil. The new scene is loaded first so the
+// transition can draw it; the old one is unloaded when it ends.
+func (m *SceneManager) TransitionTo(scene Scene, transition Transition) error {
+	if err := scene.Load(); err != nil {
+		return err
 	}
 
+	from := m.Current()
+	if from != nil {
+		m.stack = m.stack[:len(m.stack)-1]
+	}
+
+	m.stack = append(m.stack, scene)
+	m.play(from, scene, transition, func() {
+		if from != nil {
+			from.Unload()
+		}
+	})
+
 	return nil
 }
 
-// Draw renders the current scene or transition.
-func (m *SceneManager) Draw(screen *ebiten.Image) {
-	if m.inTransition && m.transition != nil {
-		m.transition.Draw(screen)
-	} else if m.current != nil {
-		m.current.Draw(screen)
+// Push loads scene on top of the current one, which stays loaded.
+func (m *SceneManager) Push(scene Scene) error {
+	if err := scene.Load(); err != nil {
+		return err
 	}
+
+	from := m.Current()
+	m.stack = append(m.stack, scene)
+	m.play(from, scene, nil, nil)
+
+	return nil
 }
 
-// Current returns the current scene.
-func (m *SceneManager) Current() Scene {
-	return m.current
+// Pop returns to the scene under the current one, unloading the current
+// scene once the transition ends.
+func (m *SceneManager) Pop() {
+	top := m.Current()
+	if top == nil {
+		return
+	}
+
+	m.stack = m.stack[:len(m.stack)-1]
+	m.play(top, m.Current(), nil, top.Unload)
 }
 
-// FadeTransition fades between scenes.
-type FadeTransition struct {
-	from      Scene
-	to        Scene
-	duration  float64
-	elapsed   float64
-	fadeOut   bool
-	alpha     float64
-	fadeColor *ebiten.Image
+// play starts transition from one scene to another, running done when it
+// finishes. A transition still playing is finished first.
+func (m *SceneManager) play(from, to Scene, transition Transition, done func()) {
+	m.finishTransition()
+
+	if transition == nil {
+		transition = m.defaultTransition
+	}
+
+	if transition == nil {
+		if done != nil {
+			done()
+		}
+
+		return
+	}
+
+	transition.Start(from, to)
+	m.transition, m.done = transition, done
 }
 
-// NewFadeTransition creates a fade transition.
-func NewFadeTransition(duration float64) *FadeTransition {
-	return &FadeTransition{
-		duration: duration,
+// finishTransition ends the playing transition, if any.
+func (m *SceneManager) finishTransition() {
+	if m.transition == nil {
+		return
 	}
+
+	if done := m.done; done != nil {
+		m.done = nil
+		done()
+	}
+
+	m.transition = nil
 }
 
-// Start initializes the fade transition.
-func (t *FadeTransition) Start(from, to Scene) {
-	t.from = from
-	t.to = to
-	t.elapsed = 0
-	t.fadeOut = true
-	t.alpha = 0
+// Transitioning reports whether a transition is playing.
+func (m *SceneManager) Transitioning() bool {
+	return m.transition != nil
 }
 
-// Update advances the fade.
-func (t *FadeTransition) Update() bool {
-	t.elapsed += 1.0 / 60.0 // Assume 60 FPS
+// Update advances the playing transition, or handles the pause key and
+// updates the current scene. Scenes are not updated while a transition
+// plays, nor on the tick the pause key opens or closes the pause menu.
+func (m *SceneManager) Update() error {
+	defer m.duck()
+
+	if m.transition != nil {
+		if m.transition.Update() {
+			m.finishTransition()
+		}
+
+		return nil
+	}
+
+	if m.pauseKey != nil && m.pauseKey() {
+		switch {
+		case m.pauseMenu != nil && m.Current() == m.pauseMenu:
+			m.Unpause()
 
-	halfDuration := t.duration / 2
+			return nil
+		case !m.Paused():
+			m.Pause()
 
-	if t.elapsed < halfDuration {
-		// Fading out
-		t.alpha = t.elapsed / halfDuration
-	} else if t.elapsed < t.duration {
-		// Fading in
-		t.fadeOut = false
-		t.alpha = 1.0 - (t.elapsed-halfDuration)/halfDuration
-	} else {
-		return true // Complete
+			if m.Paused() {
+				return nil
+			}
+		}
 	}
 
-	return false
+	if current := m.Current(); current != nil {
+		return current.Update()
+	}
+
+	return nil
 }
 
-// Draw renders the fade effect.
-func (t *FadeTransition) Draw(screen *ebiten.Image) {
-	// Draw the appropriate scene
-	if t.fadeOut && t.from != nil {
-		t.from.Draw(screen)
-	} else if !t.fadeOut && t.to != nil {
-		t.to.Draw(screen)
+// Draw renders the current scene or transition.
+func (m *SceneManager) Draw(screen *ebiten.Image) {
+	if m.transition != nil {
+		m.transition.Draw(screen)
+	} else if current := m.Current(); current != nil {
+		current.Draw(screen)
 	}
+}
 
-	// Draw fade overlay
-	if t.fadeColor == nil {
-		bounds := screen.Bounds()
-		t.fadeColor = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+// Current returns the current scene, or nil when the stack is empty.
+func (m *SceneManager) Current() Scene {
+	if len(m.stack) == 0 {
+		return nil
 	}
 
-	t.fadeColor.Fill(color.RGBA{R: 0, G: 0, B: 0, A: uint8(t.alpha * 255)})
-	screen.DrawImage(t.fadeColor, nil)
+	return m.stack[len(m.stack)-1]
+}
+
+// Depth returns the number of scenes on the stack.
+func (m *SceneManager) Depth() int {
+	return len(m.stack)
 }
 
 // BaseScene provides a basic scene implementation.
@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/ark/ecs"
+)
+
+// ErrSystemCycle is returned when system dependencies form a cycle.
+var ErrSystemCycle = errors.New("system dependency cycle")
+
+// SystemSpec registers a system with a Scheduler under a unique name and
+// declares which systems it must run after or before.
+type SystemSpec struct {
+	Name   string
+	System System
+	After  []string // Systems that must run before this one
+	Before []string // Systems that must run after this one
+
+	// Optional skips After and Before names that are not registered, so a
+	// system can state its usual place among systems a game may leave out.
+	Optional bool
+}
+
+// SystemTiming reports a system's place in the resolved order and how long its
+// last update took.
+type SystemTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Scheduler runs systems in an order derived from their declared dependencies
+// instead of registration order. Systems with no constraint between them keep
+// their registration order, so the result is deterministic.
+type Scheduler struct {
+	specs    []SystemSpec
+	index    map[string]int
+	order    []int
+	timings  []time.Duration
+	resolved bool
+}
+
+// NewScheduler creates an empty scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{index: make(map[string]int)}
+}
+
+// Register adds a system. Names must be unique and non-empty; dependencies may
+// name systems that are registered later.
+func (s *Scheduler) Register(spec SystemSpec) error {
+	if spec.Name == "" {
+		return errors.New("system name is empty")
+	}
+
+	if _, ok := s.index[spec.Name]; ok {
+		return fmt.Errorf("system %q already registered", spec.Name)
+	}
+
+	s.index[spec.Name] = len(s.specs)
+	s.specs = append(s.specs, spec)
+	s.resolved = false
+
+	return nil
+}
+
+// Len returns the number of registered systems.
+func (s *Scheduler) Len() int {
+	return len(s.specs)
+}
+
+// Resolve topologically sorts the registered systems. It fails if a dependency
+// names an unregistered system or if the dependencies form a cycle.
+func (s *Scheduler) Resolve() error {
+	n := len(s.specs)
+	edges := make([][]int, n)
+	inDegree := make([]int, n)
+
+	link := func(from, to int) {
+		edges[from] = append(edges[from], to)
+		inDegree[to]++
+	}
+
+	for i, spec := range s.specs {
+		for _, dep := range spec.After {
+			j, ok := s.index[dep]
+			if !ok {
+				if spec.Optional {
+					continue
+				}
+
+				return fmt.Errorf("system %q runs after unknown system %q", spec.Name, dep)
+			}
+
+			link(j, i)
+		}
+
+		for _, dep := range spec.Before {
+			j, ok := s.index[dep]
+			if !ok {
+				if spec.Optional {
+					continue
+				}
+
+				return fmt.Errorf("system %q runs before unknown system %q", spec.Name, dep)
+			}
+
+			link(i, j)
+		}
+	}
+
+	// Kahn's algorithm, always taking the earliest registered ready system
+	order := make([]int, 0, n)
+	done := make([]bool, n)
+
+	for len(order) < n {
+		next := -1
+
+		for i := range n {
+			if !done[i] && inDegree[i] == 0 {
+				next = i
+
+				break
+			}
+		}
+
+		if next < 0 {
+			var stuck []string
+
+			for i := range n {
+				if !done[i] {
+					stuck = append(stuck, s.specs[i].Name)
+				}
+			}
+
+			return fmt.Errorf("%w: %s", ErrSystemCycle, strings.Join(stuck, ", "))
+		}
+
+		done[next] = true
+		order = append(order, next)
+
+		for _, to := range edges[next] {
+			inDegree[to]--
+		}
+	}
+
+	s.order = order
+	s.timings = make([]time.Duration, n)
+	s.resolved = true
+
+	return nil
+}
+
+// Order returns the system names in resolved order, resolving if needed.
+func (s *Scheduler) Order() ([]string, error) {
+	if err := s.ensureResolved(); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(s.order))
+	for i, idx := range s.order {
+		names[i] = s.specs[idx].Name
+	}
+
+	return names, nil
+}
+
+// Update runs every system once in resolved order and records its duration.
+func (s *Scheduler) Update(world *ecs.World) error {
+	if err := s.ensureResolved(); err != nil {
+		return err
+	}
+
+	for _, idx := range s.order {
+		start := time.Now()
+
+		s.specs[idx].System.Update(world)
+		s.timings[idx] = time.Since(start)
+	}
+
+	return nil
+}
+
+// Timings returns the resolved order with the duration of each system's last
+// update. It is empty until the scheduler has resolved successfully.
+func (s *Scheduler) Timings() []SystemTiming {
+	if !s.resolved {
+		return nil
+	}
+
+	out := make([]SystemTiming, len(s.order))
+	for i, idx := range s.order {
+		out[i] = SystemTiming{Name: s.specs[idx].Name, Duration: s.timings[idx]}
+	}
+
+	return out
+}
+
+// Reset resets every scheduled system that implements Resetter and clears
+// the recorded timings.
+func (s *Scheduler) Reset() {
+	for _, spec := range s.specs {
+		resetSystem(spec.System)
+	}
+
+	clear(s.timings)
+}
+
+func (s *Scheduler) ensureResolved() error {
+	if s.resolved {
+		return nil
+	}
+
+	return s.Resolve()
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+)
+
+// recordSystem appends its name to a shared log when updated.
+type recordSystem struct {
+	name string
+	log  *[]string
+}
+
+func (s *recordSystem) Update(world *ecs.World) {
+	*s.log = append(*s.log, s.name)
+}
+
+func TestSchedulerOrdersByDependencies(t *testing.T) {
+	var log []string
+
+	sched := NewScheduler()
+	rec := func(name string) System { return &recordSystem{name: name, log: &log} }
+
+	// Registered backwards; dependencies decide the order
+	specs := []SystemSpec{
+		{Name: "damage", System: rec("damage"), After: []string{"collision"}},
+		{Name: "render-prep", System: rec("render-prep")},
+		{Name: "collision", System: rec("collision"), After: []string{"movement"}},
+		{Name: "movement", System: rec("movement"), Before: []string{"render-prep"}},
+	}
+	for _, spec := range specs {
+		if err := sched.Register(spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	world := ecs.NewWorld()
+	if err := sched.Update(&world); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"movement", "render-prep", "collision", "damage"}
+	if !slices.Equal(log, want) {
+		t.Errorf("ran %v, want %v", log, want)
+	}
+
+	timings := sched.Timings()
+	if len(timings) != len(want) || timings[0].Name != "movement" {
+		t.Errorf("timings = %+v, want one per system in resolved order", timings)
+	}
+}
+
+func TestSchedulerErrors(t *testing.T) {
+	noop := &recordSystem{log: new([]string)}
+
+	cyclic := NewScheduler()
+	_ = cyclic.Register(SystemSpec{Name: "a", System: noop, After: []string{"c"}})
+	_ = cyclic.Register(SystemSpec{Name: "b", System: noop, After: []string{"a"}})
+	_ = cyclic.Register(SystemSpec{Name: "c", System: noop, After: []string{"b"}})
+
+	world := ecs.NewWorld()
+	if err := cyclic.Update(&world); !errors.Is(err, ErrSystemCycle) {
+		t.Errorf("Update with a cycle = %v, want ErrSystemCycle", err)
+	}
+
+	if cyclic.Timings() != nil {
+		t.Error("an unresolved scheduler should report no timings")
+	}
+
+	unknown := NewScheduler()
+	_ = unknown.Register(SystemSpec{Name: "a", System: noop, After: []string{"missing"}})
+
+	if err := unknown.Resolve(); err == nil || errors.Is(err, ErrSystemCycle) {
+		t.Errorf("Resolve with an unknown dependency = %v, want a non-cycle error", err)
+	}
+
+	if err := unknown.Register(SystemSpec{Name: "a", System: noop}); err == nil {
+		t.Error("registering a duplicate name should fail")
+	}
+
+	optional := NewScheduler()
+	_ = optional.Register(SystemSpec{Name: "a", System: noop, After: []string{"missing"}, Optional: true})
+
+	if err := optional.Resolve(); err != nil {
+		t.Errorf("Resolve with an optional unknown dependency = %v, want nil", err)
+	}
+}
+
+func TestGameRunsScheduledSystemsAfterAdded(t *testing.T) {
+	var log []string
+
+	game := NewGame(100, 100, "test")
+	game.AddSystem(&recordSystem{name: "legacy", log: &log})
+	_ = game.RegisterSystem(SystemSpec{
+		Name: "collision", System: &recordSystem{name: "collision", log: &log}, After: []string{"movement"},
+	})
+	_ = game.RegisterSystem(SystemSpec{Name: "movement", System: &recordSystem{name: "movement", log: &log}})
+
+	if err := game.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"legacy", "movement", "collision"}; !slices.Equal(log, want) {
+		t.Errorf("ran %v, want %v", log, want)
+	}
+}
@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"image/color"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// DefaultSpeeds are the substeps per frame offered when SpeedConfig.Speeds is unset.
+var DefaultSpeeds = []int{1, 2, 4}
+
+// Speed button layout, in layout pixels.
+const (
+	speedButtonW   = 28
+	speedButtonH   = 18
+	speedButtonGap = 4
+)
+
+// Stepper is a game whose simulation can advance one fixed tick without
+// reading input. Update handles input and then steps once, as usual; Step
+// is the simulation part alone, so fast-forward never sees a click or key
+// press twice. Games step by a fixed dt (1/ebiten.TPS), never wall-clock
+// time, so each substep moves timers and cooldowns by a full tick.
+type Stepper interface {
+	ebiten.Game
+	Step() error
+}
+
+// SpeedConfig configures fast-forward.
+type SpeedConfig struct {
+	Speeds      []int // Selectable substeps per frame, slowest first; nil uses DefaultSpeeds
+	X, Y        int   // Top-left of the speed buttons
+	HideButtons bool  // Leave speed changes to the game, via SetSpeed and Cycle
+}
+
+// SpeedGame wraps a game with a simulation speed control: at speed n each
+// frame runs the game's Update once and Step n-1 more times, so 2x is
+// exactly two normal ticks rather than one tick with a doubled dt that
+// would let fast projectiles tunnel and skip cooldown boundaries.
+type SpeedGame struct {
+	Stepper
+	Config SpeedConfig
+
+	index int
+
+	// Platform hooks, replaced in tests
+	click func() (x, y int, ok bool)
+	poll  func()
+}
+
+// WithSpeed wraps game with a speed control starting at the slowest speed.
+// Draw polls input.Default, so games reading their hotkeys and clicks from
+// it see every press exactly once at any speed.
+func WithSpeed(game Stepper, cfg SpeedConfig) *SpeedGame {
+	s := newSpeedGame(game, cfg, func() (int, int, bool) {
+		return input.Default.Click(ebiten.MouseButtonLeft)
+	})
+	s.poll = input.Default.Poll
+
+	return s
+}
+
+func newSpeedGame(game Stepper, cfg SpeedConfig, click func() (int, int, bool)) *SpeedGame {
+	if len(cfg.Speeds) == 0 {
+		cfg.Speeds = DefaultSpeeds
+	}
+
+	return &SpeedGame{Stepper: game, Config: cfg, click: click}
+}
+
+// Speed returns the current substeps per frame.
+func (s *SpeedGame) Speed() int {
+	return s.Config.Speeds[s.index]
+}
+
+// SetSpeed selects the fastest configured speed not above speed.
+func (s *SpeedGame) SetSpeed(speed int) {
+	s.index = 0
+
+	for i, v := range s.Config.Speeds {
+		if v <= speed {
+			s.index = i
+		}
+	}
+}
+
+// Cycle moves to the next speed, wrapping from the fastest to the slowest.
+func (s *SpeedGame) Cycle() {
+	s.index = (s.index + 1) % len(s.Config.Speeds)
+}
+
+// Update handles the speed buttons, then updates the game once and steps it
+// for the remaining substeps.
+func (s *SpeedGame) Update() error {
+	if x, y, ok := s.click(); ok && !s.Config.HideButtons {
+		if i := s.buttonAt(x, y); i >= 0 {
+			s.index = i
+		}
+	}
+
+	if err := s.Stepper.Update(); err != nil {
+		return err
+	}
+
+	for range s.Speed() - 1 {
+		if err := s.Step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Resume forwards to the wrapped game, so a FocusGame around a SpeedGame
+// still reaches a Resumer.
+func (s *SpeedGame) Resume(away time.Duration) {
+	if r, ok := s.Stepper.(Resumer); ok {
+		r.Resume(away)
+	}
+}
+
+// Pause forwards to the wrapped game, so FocusConfig.AutoPause reaches a
+// Pauser inside a SpeedGame.
+func (s *SpeedGame) Pause() {
+	if p, ok := s.Stepper.(Pauser); ok {
+		p.Pause()
+	}
+}
+
+// Draw polls input between ticks, then draws the game and the speed
+// buttons over it.
+func (s *SpeedGame) Draw(screen *ebiten.Image) {
+	if s.poll != nil {
+		s.poll()
+	}
+
+	s.Stepper.Draw(screen)
+
+	if s.Config.HideButtons {
+		return
+	}
+
+	for i, speed := range s.Config.Speeds {
+		x, y := s.buttonPos(i)
+
+		bg := color.NRGBA{R: 40, G: 40, B: 50, A: 200}
+		if i == s.index {
+			bg = color.NRGBA{R: 60, G: 140, B: 220, A: 230}
+		}
+
+		vector.FillRect(screen, float32(x), float32(y), speedButtonW, speedButtonH, bg, false)
+		vector.StrokeRect(screen, float32(x), float32(y), speedButtonW, speedButtonH, 1,
+			color.RGBA{R: 200, G: 200, B: 210, A: 255}, false)
+		ebitenutil.DebugPrintAt(screen, strconv.Itoa(speed)+"x", x+6, y+1)
+	}
+}
+
+// buttonPos returns the top-left of the i-th speed button.
+func (s *SpeedGame) buttonPos(i int) (x, y int) {
+	return s.Config.X + i*(speedButtonW+speedButtonGap), s.Config.Y
+}
+
+// buttonAt returns the index of the speed button under (x, y), or -1.
+func (s *SpeedGame) buttonAt(x, y int) int {
+	for i := range s.Config.Speeds {
+		bx, by := s.buttonPos(i)
+		if x >= bx && x < bx+speedButtonW && y >= by && y < by+speedButtonH {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// steppingGame counts updates and steps separately.
+type steppingGame struct {
+	countingGame
+
+	steps int
+}
+
+func (s *steppingGame) Step() error { s.steps++; return nil }
+
+// clickAt returns a click hook that reports one click at (x, y), then none.
+func clickAt(x, y int) func() (int, int, bool) {
+	clicked := false
+
+	return func() (int, int, bool) {
+		if clicked {
+			return 0, 0, false
+		}
+
+		clicked = true
+
+		return x, y, true
+	}
+}
+
+func noClick() (int, int, bool) { return 0, 0, false }
+
+func TestSpeedRunsExtraSubstepsAsSteps(t *testing.T) {
+	game := &steppingGame{}
+	s := newSpeedGame(game, SpeedConfig{}, noClick)
+
+	for _, speed := range []int{1, 2, 4} {
+		game.updates, game.steps = 0, 0
+		s.SetSpeed(speed)
+
+		if err := s.Update(); err != nil {
+			t.Fatal(err)
+		}
+
+		if game.updates != 1 || game.steps != speed-1 {
+			t.Errorf("at %dx: %d updates and %d steps, want 1 and %d", speed, game.updates, game.steps, speed-1)
+		}
+	}
+}
+
+func TestSetSpeedPicksFastestNotAbove(t *testing.T) {
+	s := newSpeedGame(&steppingGame{}, SpeedConfig{Speeds: []int{1, 2, 8}}, noClick)
+
+	for speed, want := range map[int]int{0: 1, 1: 1, 3: 2, 7: 2, 8: 8, 100: 8} {
+		if s.SetSpeed(speed); s.Speed() != want {
+			t.Errorf("SetSpeed(%d) = %d, want %d", speed, s.Speed(), want)
+		}
+	}
+}
+
+func TestCycleWraps(t *testing.T) {
+	s := newSpeedGame(&steppingGame{}, SpeedConfig{}, noClick)
+
+	var got []int
+
+	for range 4 {
+		s.Cycle()
+		got = append(got, s.Speed())
+	}
+
+	if want := []int{2, 4, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("cycle = %v, want %v", got, want)
+	}
+}
+
+func TestSpeedButtonClickSelectsSpeed(t *testing.T) {
+	game := &steppingGame{}
+	cfg := SpeedConfig{X: 100, Y: 10}
+
+	// Third button: 100 + 2*(28+4) = 164
+	s := newSpeedGame(game, cfg, clickAt(170, 15))
+	if err := s.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Speed() != 4 || game.steps != 3 {
+		t.Errorf("speed %d with %d steps after clicking 4x, want 4 and 3", s.Speed(), game.steps)
+	}
+
+	cfg.HideButtons = true
+	hidden := newSpeedGame(&steppingGame{}, cfg, clickAt(170, 15))
+
+	if err := hidden.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if hidden.Speed() != 1 {
+		t.Errorf("hidden buttons were clickable: speed %d", hidden.Speed())
+	}
+}
+
+func TestSpeedForwardsResume(t *testing.T) {
+	var h focusHarness
+
+	game := &steppingGame{}
+	f := h.wrap(newSpeedGame(game, SpeedConfig{}, noClick), FocusConfig{Policy: FocusPause})
+
+	h.tick(t, f)
+	h.focused = false
+	h.tick(t, f)
+	h.clock = h.clock.Add(time.Minute)
+	h.focused = true
+	h.tick(t, f)
+
+	if len(game.away) != 1 {
+		t.Errorf("Resume reached the game %d times, want 1", len(game.away))
+	}
+}
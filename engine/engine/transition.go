@@ -0,0 +1,398 @@
+package engine
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DefaultPixelateBlock is the largest pixel block a PixelateTransition
+// reaches at its midpoint when MaxBlock is unset.
+const DefaultPixelateBlock = 32
+
+// transitionClock times a transition and renders its scenes offscreen.
+type transitionClock struct {
+	Duration float64 // Seconds
+
+	from, to       Scene
+	elapsed        float64
+	fromImg, toImg *ebiten.Image
+}
+
+// Start resets the clock for a transition between two scenes.
+func (c *transitionClock) Start(from, to Scene) {
+	c.from, c.to = from, to
+	c.elapsed = 0
+}
+
+// Update advances one tick and reports whether the transition is done.
+func (c *transitionClock) Update() bool {
+	c.elapsed += 1 / float64(ebiten.TPS())
+
+	// Allow for float drift so a half second takes exactly 30 ticks at 60 TPS
+	return c.elapsed >= c.Duration-1e-9
+}
+
+// Progress returns how far the transition has played, from 0 to 1.
+func (c *transitionClock) Progress() float64 {
+	if c.Duration <= 0 {
+		return 1
+	}
+
+	return min(c.elapsed/c.Duration, 1)
+}
+
+// render draws both scenes into offscreen images the size of screen.
+func (c *transitionClock) render(screen *ebiten.Image) (from, to *ebiten.Image) {
+	c.fromImg = drawScene(c.fromImg, screen.Bounds().Size(), c.from)
+	c.toImg = drawScene(c.toImg, screen.Bounds().Size(), c.to)
+
+	return c.fromImg, c.toImg
+}
+
+// drawScene draws scene into img, reallocating it when the size changed.
+// A nil scene leaves the image clear.
+func drawScene(img *ebiten.Image, size image.Point, scene Scene) *ebiten.Image {
+	img = sizedImage(img, size)
+	if scene != nil {
+		scene.Draw(img)
+	}
+
+	return img
+}
+
+// FadeTransition fades the old scene out to a color, then the new one in.
+type FadeTransition struct {
+	transitionClock
+
+	Color color.Color // Black when nil
+}
+
+// NewFadeTransition creates a fade-to-black transition.
+func NewFadeTransition(duration float64) *FadeTransition {
+	return &FadeTransition{transitionClock: transitionClock{Duration: duration}}
+}
+
+// Draw renders the fade effect.
+func (t *FadeTransition) Draw(screen *ebiten.Image) {
+	scene, alpha := t.from, t.Progress()*2
+	if alpha > 1 {
+		scene, alpha = t.to, 2-alpha
+	}
+
+	if scene != nil {
+		scene.Draw(screen)
+	}
+
+	c := t.Color
+	if c == nil {
+		c = color.Black
+	}
+
+	// Scale every premultiplied channel to fade the overlay
+	r, g, b, a := c.RGBA()
+	overlay := color.RGBA64{
+		R: uint16(float64(r) * alpha),
+		G: uint16(float64(g) * alpha),
+		B: uint16(float64(b) * alpha),
+		A: uint16(float64(a) * alpha),
+	}
+
+	bounds := screen.Bounds()
+	vector.FillRect(screen, float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()),
+		float32(bounds.Dy()), overlay, false)
+}
+
+// WipeDirection is the way a wipe's leading edge travels.
+type WipeDirection int
+
+const (
+	WipeLeft WipeDirection = iota
+	WipeRight
+	WipeUp
+	WipeDown
+)
+
+// WipeTransition slides an edge across the screen, uncovering the new scene.
+type WipeTransition struct {
+	transitionClock
+
+	Direction WipeDirection
+}
+
+// NewWipeTransition creates a wipe whose edge travels in dir.
+func NewWipeTransition(duration float64, dir WipeDirection) *WipeTransition {
+	return &WipeTransition{transitionClock: transitionClock{Duration: duration}, Direction: dir}
+}
+
+// Draw renders the wipe.
+func (t *WipeTransition) Draw(screen *ebiten.Image) {
+	from, to := t.render(screen)
+	screen.DrawImage(from, nil)
+
+	// Ease out so the edge settles instead of stopping dead
+	p := 1 - (1-t.Progress())*(1-t.Progress())
+	revealed := wipeRect(to.Bounds(), t.Direction, p)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(revealed.Min.X), float64(revealed.Min.Y))
+	screen.DrawImage(to.SubImage(revealed).(*ebiten.Image), op)
+}
+
+// wipeRect returns the part of bounds uncovered by a wipe in dir at progress p.
+func wipeRect(bounds image.Rectangle, dir WipeDirection, p float64) image.Rectangle {
+	w := int(math.Round(float64(bounds.Dx()) * p))
+	h := int(math.Round(float64(bounds.Dy()) * p))
+	r := bounds
+
+	switch dir {
+	case WipeLeft:
+		r.Min.X = r.Max.X - w
+	case WipeRight:
+		r.Max.X = r.Min.X + w
+	case WipeUp:
+		r.Min.Y = r.Max.Y - h
+	case WipeDown:
+		r.Max.Y = r.Min.Y + h
+	}
+
+	return r
+}
+
+// PixelateTransition coarsens the old scene into ever larger blocks, swaps
+// to the new scene at the midpoint, then sharpens it back.
+type PixelateTransition struct {
+	transitionClock
+
+	MaxBlock int // Block size in pixels at the midpoint; 0 uses DefaultPixelateBlock
+}
+
+// NewPixelateTransition creates a pixelate transition.
+func NewPixelateTransition(duration float64) *PixelateTransition {
+	return &PixelateTransition{transitionClock: transitionClock{Duration: duration}}
+}
+
+// Block returns the current block size in pixels.
+func (t *PixelateTransition) Block() float64 {
+	maxBlock := t.MaxBlock
+	if maxBlock <= 0 {
+		maxBlock = DefaultPixelateBlock
+	}
+
+	peak := 1 - math.Abs(2*t.Progress()-1)
+
+	return 1 + float64(maxBlock-1)*peak
+}
+
+// Draw renders the pixelated scene.
+func (t *PixelateTransition) Draw(screen *ebiten.Image) {
+	from, to := t.render(screen)
+
+	swap := float32(0)
+	if t.Progress() >= 0.5 {
+		swap = 1
+	}
+
+	drawTransitionShader(screen, pixelateShader(), from, to, map[string]any{
+		"Block": float32(t.Block()),
+		"Swap":  swap,
+	})
+}
+
+// DissolveTransition replaces the old scene with the new one in randomly
+// ordered blocks.
+type DissolveTransition struct {
+	transitionClock
+
+	Grain int // Block size in pixels; 0 dissolves single pixels
+}
+
+// NewDissolveTransition creates a dissolve transition.
+func NewDissolveTransition(duration float64) *DissolveTransition {
+	return &DissolveTransition{transitionClock: transitionClock{Duration: duration}}
+}
+
+// Draw renders the dissolve.
+func (t *DissolveTransition) Draw(screen *ebiten.Image) {
+	from, to := t.render(screen)
+
+	drawTransitionShader(screen, dissolveShader(), from, to, map[string]any{
+		"Grain":    float32(max(t.Grain, 1)),
+		"Progress": float32(t.Progress()),
+	})
+}
+
+// drawTransitionShader draws shader over screen with from and to as its
+// first two source images.
+func drawTransitionShader(
+	screen *ebiten.Image,
+	shader *ebiten.Shader,
+	from, to *ebiten.Image,
+	uniforms map[string]any,
+) {
+	bounds := screen.Bounds()
+	op := &ebiten.DrawRectShaderOptions{Uniforms: uniforms}
+	op.Images[0], op.Images[1] = from, to
+	op.GeoM.Translate(float64(bounds.Min.X), float64(bounds.Min.Y))
+	screen.DrawRectShader(bounds.Dx(), bounds.Dy(), shader, op)
+}
+
+// pixelateSource samples both scenes at the center of the block a pixel
+// falls in, showing the old scene until Swap.
+const pixelateSource = `//kage:unit pixels
+package main
+
+var Block float
+var Swap float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	local := srcPos - imageSrc0Origin()
+	block := floor(local/Block)*Block + Block/2
+	block = clamp(block, vec2(0), imageSrc0Size()-vec2(1))
+
+	if Swap > 0 {
+		return imageSrc1At(block + imageSrc1Origin())
+	}
+
+	return imageSrc0At(block + imageSrc0Origin())
+}
+`
+
+// dissolveSource shows the new scene in blocks whose hashed threshold is
+// under Progress.
+const dissolveSource = `//kage:unit pixels
+package main
+
+var Grain float
+var Progress float
+
+func hash(p vec2) float {
+	return fract(sin(dot(p, vec2(12.9898, 78.233))) * 43758.5453)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	local := srcPos - imageSrc0Origin()
+
+	if hash(floor(local/Grain)) < Progress {
+		return imageSrc1At(local + imageSrc1Origin())
+	}
+
+	return imageSrc0At(srcPos)
+}
+`
+
+var (
+	pixelateShader = sync.OnceValue(func() *ebiten.Shader { return mustShader(pixelateSource) })
+	dissolveShader = sync.OnceValue(func() *ebiten.Shader { return mustShader(dissolveSource) })
+)
+
+// mustShader compiles a built-in shader, which is a programming error to get wrong.
+func mustShader(src string) *ebiten.Shader {
+	s, err := ebiten.NewShader([]byte(src))
+	if err != nil {
+		panic("engine: compiling transition shader: " + err.Error())
+	}
+
+	return s
+}
+
+// TransitionGame plays a transition whenever a game's scene changes, for
+// games that switch screens with a state field instead of a SceneManager.
+// The old scene is the last frame drawn before the change; the new scene is
+// drawn live, and the game keeps updating while the transition plays.
+type TransitionGame struct {
+	ebiten.Game
+	Transition Transition
+
+	scene   func() any
+	current any
+	playing bool
+
+	frame, snapshot *ebiten.Image
+}
+
+// WithTransitions wraps game so transition plays each time the value
+// returned by scene changes, e.g. func() any { return g.state }. The value
+// must be comparable.
+func WithTransitions(game ebiten.Game, scene func() any, transition Transition) *TransitionGame {
+	return &TransitionGame{Game: game, Transition: transition, scene: scene, current: scene()}
+}
+
+// Update updates the game, then starts the transition if its scene changed.
+func (t *TransitionGame) Update() error {
+	if err := t.Game.Update(); err != nil {
+		return err
+	}
+
+	if t.playing && t.Transition.Update() {
+		t.playing = false
+	}
+
+	if next := t.scene(); next != t.current {
+		t.current = next
+
+		// Nothing drawn yet means there is no old frame to leave
+		if t.frame != nil {
+			t.snapshot = drawScene(t.snapshot, t.frame.Bounds().Size(), &imageScene{img: t.frame})
+			t.Transition.Start(&imageScene{img: t.snapshot}, &imageScene{img: t.frame})
+			t.playing = true
+		}
+	}
+
+	return nil
+}
+
+// Playing reports whether a transition is playing.
+func (t *TransitionGame) Playing() bool {
+	return t.playing
+}
+
+// Resume forwards to the wrapped game, so a FocusGame around a
+// TransitionGame still reaches a Resumer.
+func (t *TransitionGame) Resume(away time.Duration) {
+	if r, ok := t.Game.(Resumer); ok {
+		r.Resume(away)
+	}
+}
+
+// Pause forwards to the wrapped game, so FocusConfig.AutoPause reaches a
+// Pauser inside a TransitionGame.
+func (t *TransitionGame) Pause() {
+	if p, ok := t.Game.(Pauser); ok {
+		p.Pause()
+	}
+}
+
+// Draw draws the game, through the transition while one plays.
+func (t *TransitionGame) Draw(screen *ebiten.Image) {
+	t.frame = drawScene(t.frame, screen.Bounds().Size(), &gameScene{game: t.Game})
+
+	if t.playing {
+		t.Transition.Draw(screen)
+	} else {
+		screen.DrawImage(t.frame, nil)
+	}
+}
+
+// imageScene is a scene that draws a fixed image.
+type imageScene struct {
+	BaseScene
+
+	img *ebiten.Image
+}
+
+func (s *imageScene) Draw(screen *ebiten.Image) { screen.DrawImage(s.img, nil) }
+
+// gameScene is a scene that draws a game.
+type gameScene struct {
+	BaseScene
+
+	game ebiten.Game
+}
+
+func (s *gameScene) Draw(screen *ebiten.Image) { s.game.Draw(screen) }
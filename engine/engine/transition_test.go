@@ -0,0 +1,328 @@
+package engine
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// loggingScene records its lifecycle calls in a shared log.
+type loggingScene struct {
+	name string
+	log  *[]string
+}
+
+func (s *loggingScene) Load() error        { *s.log = append(*s.log, "load "+s.name); return nil }
+func (s *loggingScene) Unload()            { *s.log = append(*s.log, "unload "+s.name) }
+func (s *loggingScene) Update() error      { *s.log = append(*s.log, "update "+s.name); return nil }
+func (s *loggingScene) Draw(*ebiten.Image) {}
+
+// playOut updates m until its transition ends, failing after limit ticks.
+func playOut(t *testing.T, m *SceneManager, limit int) int {
+	t.Helper()
+
+	for ticks := 1; ticks <= limit; ticks++ {
+		if err := m.Update(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !m.Transitioning() {
+			return ticks
+		}
+	}
+
+	t.Fatalf("transition still playing after %d ticks", limit)
+
+	return 0
+}
+
+func TestScenePushPopKeepsSceneUnderneathLoaded(t *testing.T) {
+	var log []string
+
+	game := &loggingScene{name: "game", log: &log}
+	pause := &loggingScene{name: "pause", log: &log}
+
+	m := NewSceneManager()
+	if err := m.SetScene(game); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Push(pause); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Current() != Scene(pause) || m.Depth() != 2 {
+		t.Fatalf("current = %v at depth %d, want pause on game", m.Current(), m.Depth())
+	}
+
+	m.Pop()
+
+	if m.Current() != Scene(game) || m.Depth() != 1 {
+		t.Fatalf("current = %v at depth %d after pop, want game", m.Current(), m.Depth())
+	}
+
+	want := []string{"load game", "load pause", "update pause", "unload pause"}
+	if !slices.Equal(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+}
+
+func TestSceneDefaultTransitionDefersUnload(t *testing.T) {
+	var log []string
+
+	menu := &loggingScene{name: "menu", log: &log}
+	game := &loggingScene{name: "game", log: &log}
+
+	m := NewSceneManager()
+	m.SetDefaultTransition(NewFadeTransition(0.5))
+
+	if err := m.SetScene(menu); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.TransitionTo(game, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The new scene is loaded up front so the transition can draw it
+	if want := []string{"load menu", "load game"}; !slices.Equal(log, want) {
+		t.Fatalf("log = %v, want %v", log, want)
+	}
+
+	log = log[:0]
+
+	if ticks := playOut(t, m, 60); ticks != 30 {
+		t.Errorf("half second transition took %d ticks, want 30", ticks)
+	}
+
+	// Neither scene updates during the transition
+	if want := []string{"unload menu"}; !slices.Equal(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+
+	if m.Current() != Scene(game) || m.Depth() != 1 {
+		t.Errorf("current = %v at depth %d, want game alone", m.Current(), m.Depth())
+	}
+
+	// A new change finishes the playing transition first
+	if err := m.Push(menu); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Pop()
+	playOut(t, m, 60)
+
+	if want := []string{"load menu", "unload menu"}; !slices.Equal(log[1:], want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+}
+
+func TestWipeRectUncoversFromEdge(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 50)
+
+	tests := []struct {
+		dir  WipeDirection
+		want image.Rectangle
+	}{
+		{WipeRight, image.Rect(0, 0, 25, 50)},
+		{WipeLeft, image.Rect(75, 0, 100, 50)},
+		{WipeDown, image.Rect(0, 0, 100, 13)},
+		{WipeUp, image.Rect(0, 37, 100, 50)},
+	}
+
+	for _, tt := range tests {
+		if got := wipeRect(bounds, tt.dir, 0.25); got != tt.want {
+			t.Errorf("wipe %d at 25%% = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+
+	if got := wipeRect(bounds, WipeLeft, 1); got != bounds {
+		t.Errorf("finished wipe = %v, want the whole screen", got)
+	}
+}
+
+func TestPixelateBlockPeaksAtMidpoint(t *testing.T) {
+	p := NewPixelateTransition(1)
+	p.MaxBlock = 16
+	p.Start(nil, nil)
+
+	if p.Block() != 1 {
+		t.Errorf("block at start = %v, want 1", p.Block())
+	}
+
+	for range 30 {
+		p.Update()
+	}
+
+	if math.Abs(p.Block()-16) > 1e-9 {
+		t.Errorf("block at midpoint = %v, want 16", p.Block())
+	}
+
+	for !p.Update() {
+	}
+
+	if math.Abs(p.Block()-1) > 1e-9 {
+		t.Errorf("block at end = %v, want 1", p.Block())
+	}
+}
+
+func TestTransitionShadersCompileAndDraw(t *testing.T) {
+	screen := ebiten.NewImage(64, 48)
+
+	for _, tr := range []Transition{
+		NewFadeTransition(0.2),
+		NewWipeTransition(0.2, WipeLeft),
+		NewPixelateTransition(0.2),
+		NewDissolveTransition(0.2),
+	} {
+		tr.Start(&BaseScene{}, nil)
+		tr.Update()
+		tr.Draw(screen)
+	}
+}
+
+// stateGame is a game that switches screens with a state field.
+type stateGame struct {
+	countingGame
+
+	state int
+}
+
+func TestWithTransitionsPlaysOnStateChange(t *testing.T) {
+	game := &stateGame{}
+	tg := WithTransitions(game, func() any { return game.state }, NewFadeTransition(0.1))
+	screen := ebiten.NewImage(32, 32)
+
+	// A change before anything was drawn has no old frame to fade from
+	game.state = 1
+
+	if err := tg.Update(); err != nil || tg.Playing() {
+		t.Fatalf("playing = %v (err %v) before the first frame", tg.Playing(), err)
+	}
+
+	tg.Draw(screen)
+
+	game.state = 2
+
+	for range 3 {
+		if err := tg.Update(); err != nil {
+			t.Fatal(err)
+		}
+
+		tg.Draw(screen)
+	}
+
+	if !tg.Playing() {
+		t.Error("state change did not start a transition")
+	}
+
+	for range 6 {
+		if err := tg.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The game keeps updating underneath the transition
+	if tg.Playing() || game.updates != 10 {
+		t.Errorf("playing = %v after %d updates, want done after 10", tg.Playing(), game.updates)
+	}
+}
+
+// fakeDucker records the ducked state it was last given.
+type fakeDucker struct{ ducked bool }
+
+func (d *fakeDucker) SetDucked(ducked bool) { d.ducked = ducked }
+
+func TestScenePauseKeyTogglesPauseMenu(t *testing.T) {
+	var log []string
+
+	title := &loggingScene{name: "title", log: &log}
+	game := &loggingScene{name: "game", log: &log}
+	pause := &loggingScene{name: "pause", log: &log}
+	audio := &fakeDucker{}
+
+	m := NewSceneManager()
+	m.SetPause(PauseConfig{
+		Menu: func(scene Scene) Scene {
+			if scene == Scene(title) {
+				return nil // Nothing to pause on the title screen
+			}
+
+			return pause
+		},
+		Audio: audio,
+	})
+
+	pressed := false
+	m.pauseKey = func() bool { return pressed }
+
+	// press updates m with the pause key down for one tick
+	press := func() {
+		t.Helper()
+
+		pressed = true
+		defer func() { pressed = false }()
+
+		if err := m.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := m.SetScene(title); err != nil {
+		t.Fatal(err)
+	}
+
+	press()
+
+	if m.Paused() || m.Current() != Scene(title) {
+		t.Fatal("the pause key should do nothing where there is nothing to pause")
+	}
+
+	if err := m.SetScene(game); err != nil {
+		t.Fatal(err)
+	}
+
+	log = log[:0]
+	press()
+
+	if !m.Paused() || m.Current() != Scene(pause) || !audio.ducked {
+		t.Fatalf("paused %v, current %v, ducked %v; want the pause menu and ducked audio",
+			m.Paused(), m.Current(), audio.ducked)
+	}
+
+	// The key press that paused is not seen by either scene
+	if want := []string{"load pause"}; !slices.Equal(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+
+	m.Pause()
+
+	if m.Depth() != 2 {
+		t.Errorf("pausing twice stacked %d scenes", m.Depth())
+	}
+
+	press()
+
+	if m.Paused() || m.Current() != Scene(game) || audio.ducked {
+		t.Errorf("paused %v, current %v, ducked %v; want the game back", m.Paused(), m.Current(), audio.ducked)
+	}
+
+	// A pause menu that pops itself, e.g. from a Resume button, unducks too
+	m.Pause()
+	m.Pop()
+
+	if err := m.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Paused() || audio.ducked {
+		t.Error("popping the pause menu should restore the audio")
+	}
+}
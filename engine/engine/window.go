@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// DefaultWindowFile is the file in the app's config directory that window
+// state is kept in when WindowConfig.File is unset and the game keeps no
+// settings of its own.
+const DefaultWindowFile = "window.json"
+
+// windowSettleTicks is how many updates the window must hold still before
+// its state is saved, so dragging or resizing writes the file once.
+const windowSettleTicks = 30
+
+// WindowState is the window placement remembered between sessions.
+// Position is relative to the monitor's top-left corner, as ebiten reports it.
+type WindowState struct {
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	X          int    `json:"x"`
+	Y          int    `json:"y"`
+	Fullscreen bool   `json:"fullscreen"`
+	Monitor    string `json:"monitor,omitempty"`
+}
+
+// WindowConfig configures window persistence.
+type WindowConfig struct {
+	App  paths.App // Whose config directory keeps the state; a zero App only handles Alt+Enter
+	File string    // File name; "" uses DefaultWindowFile
+
+	// Load and Save keep the state with the game's own settings instead of
+	// in File; set both. Load reports false before anything was saved.
+	Load func() (WindowState, bool)
+	Save func(WindowState)
+}
+
+// WindowGame wraps a game with window-state persistence: the size, position,
+// fullscreen mode, and monitor saved last session are restored when it is
+// created and saved again whenever they change. Alt+Enter toggles
+// fullscreen; the game does not update that tick, so it never sees the
+// Enter as a confirm.
+type WindowGame struct {
+	ebiten.Game
+	Config WindowConfig
+
+	store   paths.FS
+	saved   WindowState
+	pending WindowState
+	stable  int
+
+	// Platform hooks, replaced in tests
+	read          func() WindowState
+	setFullscreen func(bool)
+	toggleKey     func() bool
+}
+
+// WithWindow wraps game and restores the saved window state. Call it before
+// ebiten.RunGame and after setting the default window size, which applies
+// on first launch.
+func WithWindow(game ebiten.Game, cfg WindowConfig) *WindowGame {
+	var store paths.FS
+
+	if cfg.App.Name != "" && cfg.Load == nil {
+		s, err := cfg.App.Open(paths.Config)
+		if err != nil {
+			log.Printf("window state: %v", err)
+		} else {
+			store = s
+		}
+	}
+
+	return newWindowGame(game, cfg, store, readWindow, applyWindow, ebiten.SetFullscreen, func() bool {
+		return input.IsKeyJustPressed(ebiten.KeyEnter) && ebiten.IsKeyPressed(ebiten.KeyAlt)
+	})
+}
+
+func newWindowGame(
+	game ebiten.Game,
+	cfg WindowConfig,
+	store paths.FS,
+	read func() WindowState,
+	apply func(WindowState),
+	setFullscreen func(bool),
+	toggleKey func() bool,
+) *WindowGame {
+	if cfg.File == "" {
+		cfg.File = DefaultWindowFile
+	}
+
+	w := &WindowGame{
+		Game:          game,
+		Config:        cfg,
+		store:         store,
+		read:          read,
+		setFullscreen: setFullscreen,
+		toggleKey:     toggleKey,
+	}
+
+	if state, ok := w.load(); ok {
+		apply(state)
+		w.saved, w.pending = state, state
+	}
+
+	return w
+}
+
+// Update toggles fullscreen on Alt+Enter, saves the window state once it
+// has settled after a change, and updates the game, skipping the tick
+// Alt+Enter was pressed on.
+func (w *WindowGame) Update() error {
+	toggled := w.toggleKey()
+	if toggled {
+		w.setFullscreen(!w.read().Fullscreen)
+	}
+
+	w.track()
+
+	if toggled {
+		return nil
+	}
+
+	return w.Game.Update()
+}
+
+// Resume forwards to the wrapped game, so a FocusGame around a WindowGame
+// still reaches a Resumer.
+func (w *WindowGame) Resume(away time.Duration) {
+	if r, ok := w.Game.(Resumer); ok {
+		r.Resume(away)
+	}
+}
+
+// Pause forwards to the wrapped game, so FocusConfig.AutoPause reaches a
+// Pauser inside a WindowGame.
+func (w *WindowGame) Pause() {
+	if p, ok := w.Game.(Pauser); ok {
+		p.Pause()
+	}
+}
+
+// track saves the window state after it has differed from the saved state
+// and held still for windowSettleTicks updates.
+func (w *WindowGame) track() {
+	state := w.read()
+	if state != w.pending {
+		w.pending, w.stable = state, 0
+
+		return
+	}
+
+	if state == w.saved {
+		return
+	}
+
+	if w.stable++; w.stable < windowSettleTicks {
+		return
+	}
+
+	w.saved = state
+	w.save(state)
+}
+
+func (w *WindowGame) load() (WindowState, bool) {
+	if w.Config.Load != nil {
+		return w.Config.Load()
+	}
+
+	if w.store == nil || !w.store.Exists(w.Config.File) {
+		return WindowState{}, false
+	}
+
+	data, err := w.store.ReadFile(w.Config.File)
+	if err != nil {
+		log.Printf("window state: %v", err)
+
+		return WindowState{}, false
+	}
+
+	var state WindowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("window state: %s: %v", w.Config.File, err)
+
+		return WindowState{}, false
+	}
+
+	return state, true
+}
+
+func (w *WindowGame) save(state WindowState) {
+	if w.Config.Save != nil {
+		w.Config.Save(state)
+
+		return
+	}
+
+	if w.store == nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err == nil {
+		err = w.store.WriteFile(w.Config.File, data)
+	}
+
+	if err != nil {
+		log.Printf("window state: %v", err)
+	}
+}
+
+// readWindow returns the current window state. In fullscreen ebiten still
+// reports the windowed size and position, so leaving fullscreen next session
+// returns to them.
+func readWindow() WindowState {
+	width, height := ebiten.WindowSize()
+	x, y := ebiten.WindowPosition()
+
+	state := WindowState{Width: width, Height: height, X: x, Y: y, Fullscreen: ebiten.IsFullscreen()}
+	if m := ebiten.Monitor(); m != nil {
+		state.Monitor = m.Name()
+	}
+
+	return state
+}
+
+// applyWindow restores state. The position is only restored on the monitor
+// it was saved on, and is clamped so the window stays on screen if that
+// monitor's resolution shrank; on a missing monitor the OS places it.
+func applyWindow(state WindowState) {
+	if state.Width > 0 && state.Height > 0 {
+		ebiten.SetWindowSize(state.Width, state.Height)
+	}
+
+	for _, m := range ebiten.AppendMonitors(nil) {
+		if m.Name() != state.Monitor {
+			continue
+		}
+
+		ebiten.SetMonitor(m)
+
+		x, y := state.X, state.Y
+		if mw, mh := m.Size(); mw > 0 && mh > 0 {
+			x = min(max(x, 0), max(mw-state.Width, 0))
+			y = min(max(y, 0), max(mh-state.Height, 0))
+		}
+
+		ebiten.SetWindowPosition(x, y)
+
+		break
+	}
+
+	ebiten.SetFullscreen(state.Fullscreen)
+}
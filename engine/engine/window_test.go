@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// windowHarness drives a WindowGame with a fake window and keyboard.
+type windowHarness struct {
+	window  WindowState
+	applied []WindowState
+	pressed bool
+	game    countingGame
+}
+
+func (h *windowHarness) wrap(store paths.FS) *WindowGame {
+	return h.wrapConfig(WindowConfig{}, store)
+}
+
+func (h *windowHarness) wrapConfig(cfg WindowConfig, store paths.FS) *WindowGame {
+	return newWindowGame(
+		&h.game,
+		cfg,
+		store,
+		func() WindowState { return h.window },
+		func(s WindowState) { h.applied = append(h.applied, s); h.window = s },
+		func(on bool) { h.window.Fullscreen = on },
+		func() bool { pressed := h.pressed; h.pressed = false; return pressed },
+	)
+}
+
+func (h *windowHarness) tick(t *testing.T, w *WindowGame, n int) {
+	t.Helper()
+
+	for range n {
+		if err := w.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readState(t *testing.T, store paths.FS) (WindowState, bool) {
+	t.Helper()
+
+	if !store.Exists(DefaultWindowFile) {
+		return WindowState{}, false
+	}
+
+	data, err := store.ReadFile(DefaultWindowFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var state WindowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	return state, true
+}
+
+func TestWindowStateSavedOnceSettled(t *testing.T) {
+	store := paths.MemFS()
+	h := windowHarness{window: WindowState{Width: 800, Height: 600}}
+	w := h.wrap(store)
+
+	// Dragging: the state changes every frame, so nothing is written
+	for i := range windowSettleTicks {
+		h.window.X = i * 10
+		h.tick(t, w, 1)
+	}
+
+	if _, ok := readState(t, store); ok {
+		t.Fatal("saved while the window was still moving")
+	}
+
+	h.tick(t, w, windowSettleTicks)
+
+	got, ok := readState(t, store)
+	if !ok || got != h.window {
+		t.Errorf("saved %+v (%v), want %+v", got, ok, h.window)
+	}
+}
+
+func TestWindowStateRestoredOnStart(t *testing.T) {
+	store := paths.MemFS()
+	want := WindowState{Width: 1280, Height: 720, X: 40, Y: 30, Fullscreen: true, Monitor: "DP-1"}
+
+	first := windowHarness{window: want}
+	first.tick(t, first.wrap(store), windowSettleTicks+1)
+
+	var second windowHarness
+
+	w := second.wrap(store)
+	if len(second.applied) != 1 || second.applied[0] != want {
+		t.Fatalf("applied %+v, want %+v", second.applied, want)
+	}
+
+	// An unchanged window is not written again
+	if err := store.Remove(DefaultWindowFile); err != nil {
+		t.Fatal(err)
+	}
+
+	second.tick(t, w, 2*windowSettleTicks)
+
+	if _, ok := readState(t, store); ok {
+		t.Error("rewrote an unchanged window state")
+	}
+}
+
+func TestAltEnterTogglesFullscreen(t *testing.T) {
+	store := paths.MemFS()
+	h := windowHarness{window: WindowState{Width: 800, Height: 600}}
+	w := h.wrap(store)
+
+	h.pressed = true
+	h.tick(t, w, windowSettleTicks+1)
+
+	if got, _ := readState(t, store); !h.window.Fullscreen || !got.Fullscreen {
+		t.Fatalf("fullscreen %v, saved %+v, want on", h.window.Fullscreen, got)
+	}
+
+	h.pressed = true
+	h.tick(t, w, 1)
+
+	if h.window.Fullscreen {
+		t.Error("second Alt+Enter left fullscreen on")
+	}
+}
+
+func TestAltEnterNeverReachesTheGame(t *testing.T) {
+	h := windowHarness{window: WindowState{Width: 800, Height: 600}}
+	w := h.wrap(nil)
+
+	h.tick(t, w, 1)
+	h.pressed = true
+	h.tick(t, w, 1)
+
+	if h.game.updates != 1 {
+		t.Errorf("game updated %d times, want once: the Alt+Enter tick is swallowed", h.game.updates)
+	}
+}
+
+func TestWindowStateKeptWithGameSettings(t *testing.T) {
+	store := paths.MemFS()
+	settings := WindowState{Width: 1024, Height: 768, Monitor: "HDMI-1"}
+
+	cfg := WindowConfig{
+		Load: func() (WindowState, bool) { return settings, true },
+		Save: func(s WindowState) { settings = s },
+	}
+
+	h := windowHarness{}
+	w := h.wrapConfig(cfg, store)
+
+	if len(h.applied) != 1 || h.applied[0].Width != 1024 {
+		t.Fatalf("applied %+v, want the state from the settings", h.applied)
+	}
+
+	h.window.X = 50
+	h.tick(t, w, windowSettleTicks+1)
+
+	if settings != h.window {
+		t.Errorf("settings hold %+v, want %+v", settings, h.window)
+	}
+
+	if _, ok := readState(t, store); ok {
+		t.Errorf("wrote %s alongside the game's settings", DefaultWindowFile)
+	}
+}
+
+func TestWindowWithoutStoreStillToggles(t *testing.T) {
+	h := windowHarness{window: WindowState{Width: 800, Height: 600}}
+	w := h.wrap(nil)
+
+	h.pressed = true
+	h.tick(t, w, windowSettleTicks+1)
+
+	if !h.window.Fullscreen || len(h.applied) != 0 {
+		t.Errorf("fullscreen %v, applied %v, want toggled with nothing restored", h.window.Fullscreen, h.applied)
+	}
+}
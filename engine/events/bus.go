@@ -0,0 +1,112 @@
+// Package events provides a typed publish/subscribe event bus so systems can
+// communicate (achievements, drops, network status, ...) without direct references.
+package events
+
+import (
+	"reflect"
+	"sync"
+)
+
+type subscriber struct {
+	id int
+	fn func(any)
+}
+
+// Bus dispatches events to subscribers by event type.
+// It is safe for concurrent use; handlers run on the publishing goroutine
+// (Publish) or the goroutine calling Flush (Enqueue).
+type Bus struct {
+	mu       sync.Mutex
+	handlers map[reflect.Type][]subscriber
+	queue    []any
+	nextID   int
+}
+
+// NewBus creates an empty event bus.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[reflect.Type][]subscriber)}
+}
+
+// Default is a process-wide bus for games that don't need more than one.
+var Default = NewBus()
+
+// Subscribe registers fn for events of type T and returns a function that removes it.
+func Subscribe[T any](b *Bus, fn func(T)) (unsubscribe func()) {
+	key := reflect.TypeFor[T]()
+
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	b.handlers[key] = append(b.handlers[key], subscriber{id: id, fn: func(ev any) { fn(ev.(T)) }})
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subs := b.handlers[key]
+		for i, s := range subs {
+			if s.id == id {
+				b.handlers[key] = append(subs[:i:i], subs[i+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers an event to all current subscribers of its type immediately.
+func Publish[T any](b *Bus, ev T) {
+	b.dispatch(reflect.TypeFor[T](), ev)
+}
+
+// Enqueue queues an event for delivery on the next Flush. Use it from worker
+// goroutines or mid-iteration code that must not run handlers re-entrantly.
+func Enqueue[T any](b *Bus, ev T) {
+	b.mu.Lock()
+	b.queue = append(b.queue, queued[T]{ev})
+	b.mu.Unlock()
+}
+
+// queued wraps an enqueued event so its static type survives the []any queue.
+type queued[T any] struct {
+	ev T
+}
+
+func (q queued[T]) deliver(b *Bus) {
+	Publish(b, q.ev)
+}
+
+type deliverer interface {
+	deliver(b *Bus)
+}
+
+// Flush delivers all queued events in order. Call it once per frame from Update.
+func (b *Bus) Flush() {
+	b.mu.Lock()
+	pending := b.queue
+	b.queue = nil
+	b.mu.Unlock()
+
+	for _, ev := range pending {
+		ev.(deliverer).deliver(b)
+	}
+}
+
+// Pending returns the number of queued events awaiting Flush.
+func (b *Bus) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.queue)
+}
+
+func (b *Bus) dispatch(key reflect.Type, ev any) {
+	b.mu.Lock()
+	subs := append([]subscriber(nil), b.handlers[key]...)
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.fn(ev)
+	}
+}
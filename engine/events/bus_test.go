@@ -0,0 +1,67 @@
+package events
+
+import "testing"
+
+type scored struct{ Points int }
+
+type died struct{ Name string }
+
+func TestBusPublishSubscribe(t *testing.T) {
+	bus := NewBus()
+	total := 0
+
+	unsub := Subscribe(bus, func(e scored) { total += e.Points })
+
+	var names []string
+
+	Subscribe(bus, func(e died) { names = append(names, e.Name) })
+
+	Publish(bus, scored{Points: 5})
+	Publish(bus, died{Name: "boss"})
+	Publish(bus, scored{Points: 3})
+
+	if total != 8 || len(names) != 1 {
+		t.Errorf("total = %d, names = %v", total, names)
+	}
+
+	unsub()
+	Publish(bus, scored{Points: 100})
+
+	if total != 8 {
+		t.Errorf("handler still called after unsubscribe, total = %d", total)
+	}
+}
+
+func TestBusEnqueueFlush(t *testing.T) {
+	bus := NewBus()
+
+	var got []int
+
+	Subscribe(bus, func(e scored) {
+		got = append(got, e.Points)
+
+		// Events raised while flushing wait for the next flush
+		if e.Points == 1 {
+			Enqueue(bus, scored{Points: 99})
+		}
+	})
+
+	Enqueue(bus, scored{Points: 1})
+	Enqueue(bus, scored{Points: 2})
+
+	if len(got) != 0 || bus.Pending() != 2 {
+		t.Fatalf("enqueued events delivered early: %v", got)
+	}
+
+	bus.Flush()
+
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 || bus.Pending() != 1 {
+		t.Errorf("after flush: got = %v, pending = %d", got, bus.Pending())
+	}
+
+	bus.Flush()
+
+	if len(got) != 3 || got[2] != 99 {
+		t.Errorf("second flush: got = %v", got)
+	}
+}
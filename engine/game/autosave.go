@@ -0,0 +1,338 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"sync"
+	"time"
+)
+
+// lastGoodName returns the file keeping the last save of slot that passed
+// verification.
+func lastGoodName(slot string) string {
+	return slot + ".last.bak"
+}
+
+// intact reports whether raw is a save file that parses and passes its checksum.
+func (sm *SaveManager) intact(raw []byte) bool {
+	var save SaveData
+	if err := json.Unmarshal(raw, &save); err != nil {
+		return false
+	}
+
+	return sm.verifyChecksum(&save)
+}
+
+// SaveSafe saves like Save, but first keeps the slot's current file, if it
+// is intact, as the last good backup. The primary file itself is replaced
+// through a temporary file and a rename, so one of the two copies is always
+// whole even if the game dies mid-write.
+func (sm *SaveManager) SaveSafe(slot string, save *SaveData) error {
+	if err := sm.writeSafe(slot, save); err != nil {
+		return err
+	}
+
+	sm.CurrentSave = save
+
+	return nil
+}
+
+// writeSafe is SaveSafe without touching CurrentSave, so AutoSaver can call
+// it off the main goroutine.
+func (sm *SaveManager) writeSafe(slot string, save *SaveData) error {
+	if raw, err := sm.FS.ReadFile(slot + ".json"); err == nil && sm.intact(raw) {
+		if err := sm.FS.WriteFile(lastGoodName(slot), raw); err != nil {
+			return fmt.Errorf("failed to keep last good save: %w", err)
+		}
+	}
+
+	data, err := sm.encode(save)
+	if err != nil {
+		return err
+	}
+
+	if err := sm.FS.WriteFile(slot+".json", data); err != nil {
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSafe loads slot, falling back to the last good backup kept by
+// SaveSafe when the primary file is missing or corrupt. recovered reports
+// the fallback, in which case the backup is also written back as the
+// primary file. Saves from a newer version are never replaced by a backup.
+func (sm *SaveManager) LoadSafe(slot string) (save *SaveData, recovered bool, err error) {
+	save, err = sm.Load(slot)
+	if err == nil || errors.Is(err, ErrSaveFromFuture) || !sm.FS.Exists(lastGoodName(slot)) {
+		return save, false, err
+	}
+
+	backup, berr := sm.loadFile(slot, lastGoodName(slot))
+	if berr != nil {
+		return nil, false, fmt.Errorf("%w; backup: %w", err, berr)
+	}
+
+	if err := sm.Save(slot, backup); err != nil {
+		return nil, false, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	return backup, true, nil
+}
+
+// HasSafeSave reports whether slot has a primary file or a backup LoadSafe
+// can fall back to.
+func (sm *SaveManager) HasSafeSave(slot string) bool {
+	return sm.Exists(slot) || sm.FS.Exists(lastGoodName(slot))
+}
+
+// DeleteSafe removes slot and the last good backup SaveSafe kept of it, so
+// LoadSafe cannot bring the slot back.
+func (sm *SaveManager) DeleteSafe(slot string) error {
+	if err := sm.Delete(slot); err != nil {
+		return err
+	}
+
+	return sm.FS.Remove(lastGoodName(slot))
+}
+
+// AutoSaver saves a slot periodically and on request without blocking the
+// game: the snapshot is taken on the calling goroutine and written with
+// SaveSafe on a background one. Requests made while a write is in flight
+// are coalesced, so only the newest snapshot is written next.
+type AutoSaver struct {
+	Slot     string
+	Interval time.Duration // Time between periodic saves; 0 saves only on request
+
+	sm       *SaveManager
+	snapshot func() *SaveData
+	elapsed  time.Duration
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending *SaveData
+	busy    bool
+	closed  bool
+	saves   int
+	err     error
+	done    chan struct{}
+}
+
+// NewAutoSaver starts an auto-saver writing sm's AutoSaveSlot. snapshot
+// captures the game state; it must return data the game no longer modifies,
+// since it is encoded on another goroutine.
+func NewAutoSaver(sm *SaveManager, interval time.Duration, snapshot func() *SaveData) *AutoSaver {
+	a := &AutoSaver{
+		Slot:     sm.AutoSaveSlot,
+		Interval: interval,
+		sm:       sm,
+		snapshot: snapshot,
+		done:     make(chan struct{}),
+	}
+	a.cond = sync.NewCond(&a.mu)
+
+	go a.run()
+
+	return a
+}
+
+// Update advances the periodic timer by dt seconds and requests a save when
+// the interval has passed.
+func (a *AutoSaver) Update(dt float64) {
+	if a.Interval <= 0 {
+		return
+	}
+
+	a.elapsed += time.Duration(dt * float64(time.Second))
+	if a.elapsed >= a.Interval {
+		a.Request()
+	}
+}
+
+// Request snapshots the game now and queues the write, for key events like
+// finishing a wave. It also restarts the periodic timer.
+func (a *AutoSaver) Request() {
+	a.elapsed = 0
+	save := a.snapshot()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return
+	}
+
+	a.pending = save
+	a.cond.Broadcast()
+}
+
+// Flush waits until every requested save has been written and returns the
+// last write error, if any.
+func (a *AutoSaver) Flush() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for a.pending != nil || a.busy {
+		a.cond.Wait()
+	}
+
+	return a.err
+}
+
+// Close writes any pending save and stops the background goroutine.
+func (a *AutoSaver) Close() error {
+	a.mu.Lock()
+	a.closed = true
+	a.cond.Broadcast()
+	a.mu.Unlock()
+
+	<-a.done
+
+	return a.Err()
+}
+
+// Saves returns how many saves have been written successfully.
+func (a *AutoSaver) Saves() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.saves
+}
+
+// Err returns the error from the most recent write, or nil if it succeeded.
+func (a *AutoSaver) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.err
+}
+
+func (a *AutoSaver) run() {
+	defer close(a.done)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for {
+		for a.pending == nil && !a.closed {
+			a.cond.Wait()
+		}
+
+		if a.pending == nil {
+			return
+		}
+
+		save := a.pending
+		a.pending, a.busy = nil, true
+		a.mu.Unlock()
+
+		err := a.sm.writeSafe(a.Slot, save)
+
+		a.mu.Lock()
+		a.busy, a.err = false, err
+		if err == nil {
+			a.saves++
+		}
+
+		a.cond.Broadcast()
+	}
+}
+
+// EnableAutoSave restores progress from sm's autosave, if there is one, and
+// starts saving it every interval and whenever a wave ends or a card is
+// picked. Progress is checkpointed at wave boundaries: a restored game
+// resumes at the start of the saved wave with the saved lives, gold, score,
+// and hero, card upgrades included. Losing or winning deletes the save.
+func (g *TDGame) EnableAutoSave(sm *SaveManager, interval time.Duration) error {
+	g.AutoSave = NewAutoSaver(sm, interval, g.progressSave)
+
+	save, recovered, err := sm.LoadSafe(g.AutoSave.Slot)
+	if isNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if recovered {
+		log.Printf("autosave was corrupt; restored the last good backup")
+	}
+
+	g.restoreProgress(save)
+
+	return nil
+}
+
+// progressSave snapshots the run's progress.
+func (g *TDGame) progressSave() *SaveData {
+	save := NewSaveData("autosave")
+	save.Set("wave", g.CurrentWave)
+	save.Set("lives", g.Lives)
+	save.Set("gold", g.Gold)
+	save.Set("score", g.Score)
+
+	// The hero's stats carry every card picked so far
+	save.Set("hero_damage", g.Hero.AttackDamage)
+	save.Set("hero_range", g.Hero.AttackRange)
+	save.Set("hero_speed", g.Hero.AttackSpeed)
+	save.Set("hero_level", g.Hero.Level)
+	save.Set("hero_exp", g.Hero.Experience)
+	save.Set("hero_exp_to_level", g.Hero.ExpToLevel)
+
+	return save
+}
+
+// restoreProgress resumes at the start of the saved wave.
+func (g *TDGame) restoreProgress(save *SaveData) {
+	wave := min(max(save.GetInt("wave", 0), 0), len(g.WaveManager.Waves)-1)
+	g.CurrentWave = wave
+	g.WaveManager.CurrentWave = wave
+	g.WaveManager.WaveActive = false
+	g.WaveManager.WaveTimer = 0
+	g.Lives = save.GetInt("lives", g.Lives)
+	g.Gold = save.GetInt("gold", g.Gold)
+	g.Score = save.GetInt("score", g.Score)
+
+	h := g.Hero
+	h.AttackDamage = save.GetInt("hero_damage", h.AttackDamage)
+	h.AttackRange = save.GetFloat("hero_range", h.AttackRange)
+	h.AttackSpeed = save.GetFloat("hero_speed", h.AttackSpeed)
+	h.Level = save.GetInt("hero_level", h.Level)
+	h.Experience = save.GetInt("hero_exp", h.Experience)
+	h.ExpToLevel = save.GetInt("hero_exp_to_level", h.ExpToLevel)
+}
+
+// endRun ends the run in state, StateGameOver or StateVictory, and deletes
+// the autosave so the finished run does not resume next launch.
+func (g *TDGame) endRun(state GameState) {
+	g.State = state
+
+	if g.AutoSave == nil {
+		return
+	}
+
+	if err := g.AutoSave.Flush(); err != nil {
+		log.Printf("autosave: %v", err)
+	}
+
+	if err := g.AutoSave.sm.DeleteSafe(g.AutoSave.Slot); err != nil {
+		log.Printf("autosave: %v", err)
+	}
+}
+
+// requestAutoSave saves progress now if auto-save is enabled.
+func (g *TDGame) requestAutoSave() {
+	if g.AutoSave != nil {
+		g.AutoSave.Request()
+	}
+}
+
+// isNotExist reports whether err means the save does not exist yet, as
+// opposed to being unreadable.
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestLoadSafeFallsBackToLastGoodSave(t *testing.T) {
+	fsys := paths.MemFS()
+	sm := NewSaveManagerFS(fsys)
+
+	for _, coins := range []int{1, 2} {
+		save := NewSaveData("hero")
+		save.Set("coins", coins)
+
+		if err := sm.SaveSafe("slot1", save); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Simulate a torn or bit-flipped primary file
+	if err := fsys.WriteFile("slot1.json", []byte(`{"data": {"coins": 2`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sm.Load("slot1"); !errors.Is(err, ErrSaveCorrupted) {
+		t.Fatalf("Load err = %v, want ErrSaveCorrupted", err)
+	}
+
+	save, recovered, err := sm.LoadSafe("slot1")
+	if err != nil || !recovered || save.GetInt("coins", 0) != 1 {
+		t.Fatalf("LoadSafe = %v, recovered %v, %v; want the coins=1 backup", save, recovered, err)
+	}
+
+	// The backup was restored as the primary file
+	if save, err := sm.Load("slot1"); err != nil || save.GetInt("coins", 0) != 1 {
+		t.Errorf("primary after recovery = %v, %v", save, err)
+	}
+}
+
+func TestSaveSafeNeverBacksUpCorruptFile(t *testing.T) {
+	fsys := paths.MemFS()
+	sm := NewSaveManagerFS(fsys)
+
+	if err := sm.SaveSafe("slot1", NewSaveData("hero")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sm.SaveSafe("slot1", NewSaveData("hero")); err != nil {
+		t.Fatal(err)
+	}
+
+	good, _ := fsys.ReadFile(lastGoodName("slot1"))
+
+	if err := fsys.WriteFile("slot1.json", []byte("garbage")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sm.SaveSafe("slot1", NewSaveData("hero")); err != nil {
+		t.Fatal(err)
+	}
+
+	if kept, _ := fsys.ReadFile(lastGoodName("slot1")); string(kept) != string(good) {
+		t.Errorf("corrupt primary replaced the last good backup: %q", kept)
+	}
+}
+
+func TestDeleteSafeRemovesBackup(t *testing.T) {
+	sm := NewSaveManagerFS(paths.MemFS())
+
+	for range 2 {
+		if err := sm.SaveSafe("slot1", NewSaveData("hero")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := sm.DeleteSafe("slot1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if sm.HasSafeSave("slot1") {
+		t.Error("DeleteSafe left a backup LoadSafe could restore")
+	}
+}
+
+func TestAutoSaverSavesOnIntervalAndRequest(t *testing.T) {
+	sm := NewSaveManagerFS(paths.MemFS())
+
+	coins := 0
+	a := NewAutoSaver(sm, 10*time.Second, func() *SaveData {
+		save := NewSaveData("hero")
+		save.Set("coins", coins)
+
+		return save
+	})
+
+	for range 9 {
+		a.Update(1)
+	}
+
+	if err := a.Flush(); err != nil || a.Saves() != 0 {
+		t.Fatalf("saved %d times before the interval (err %v)", a.Saves(), err)
+	}
+
+	coins = 5
+	a.Update(1)
+
+	if err := a.Flush(); err != nil || a.Saves() != 1 {
+		t.Fatalf("saves = %d, err = %v after the interval", a.Saves(), err)
+	}
+
+	coins = 9
+	a.Request()
+
+	if err := a.Close(); err != nil || a.Saves() != 2 {
+		t.Fatalf("saves = %d, err = %v after Close", a.Saves(), err)
+	}
+
+	save, _, err := sm.LoadSafe(sm.AutoSaveSlot)
+	if err != nil || save.GetInt("coins", 0) != 9 {
+		t.Errorf("autosave = %v, %v; want coins 9", save, err)
+	}
+}
+
+func TestTDGameAutoSaveRestoresProgress(t *testing.T) {
+	sm := NewSaveManagerFS(paths.MemFS())
+
+	g := NewTDGame(800, 480)
+	if err := g.EnableAutoSave(sm, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	g.CurrentWave, g.Gold, g.Lives, g.Score = 2, 340, 12, 900
+	g.chooseCard(&Card{Name: "Sharp Blade", Effect: CardEffectDamage, Value: 5})
+	g.Unload()
+
+	resumed := NewTDGame(800, 480)
+	if err := resumed.EnableAutoSave(sm, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.Unload()
+
+	if resumed.CurrentWave != 2 || resumed.WaveManager.CurrentWave != 2 ||
+		resumed.Gold != 340 || resumed.Lives != 12 || resumed.Score != 900 {
+		t.Errorf("resumed at wave %d with %d gold, %d lives, score %d",
+			resumed.CurrentWave, resumed.Gold, resumed.Lives, resumed.Score)
+	}
+
+	if want := NewHero("").AttackDamage + 5; resumed.Hero.AttackDamage != want {
+		t.Errorf("resumed hero damage %d, want %d with the card picked before saving",
+			resumed.Hero.AttackDamage, want)
+	}
+}
+
+func TestTDGameEndDeletesAutoSave(t *testing.T) {
+	for _, state := range []GameState{StateGameOver, StateVictory} {
+		sm := NewSaveManagerFS(paths.MemFS())
+
+		g := NewTDGame(800, 480)
+		if err := g.EnableAutoSave(sm, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		g.requestAutoSave()
+		g.requestAutoSave()
+		g.endRun(state)
+		g.Unload()
+
+		if sm.HasSafeSave(sm.AutoSaveSlot) {
+			t.Errorf("state %d: autosave kept after the run ended", state)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"image/color"
+	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -149,6 +150,8 @@ type CardSelector struct {
 	CardWidth   int
 	CardHeight  int
 	CardSpacing int
+
+	rng *rand.Rand // Draws the cards when set; nil picks by wave number
 }
 
 // NewCardSelector creates a card selector.
@@ -203,6 +206,10 @@ func (s *CardSelector) pickRandomCard(waveNumber int) *Card {
 		return &CardPool[0]
 	}
 
+	if s.rng != nil {
+		return &eligible[s.rng.Intn(len(eligible))]
+	}
+
 	// Pick random (simple implementation - in real game use proper random)
 	idx := waveNumber % len(eligible)
 
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// ControlsFile is the file EnableControls keeps the player's bindings in.
+const ControlsFile = "controls.json"
+
+// ActionCard1 and the two after it take the first, second, and third card
+// on the card selection screen.
+const ActionCard1 = input.FirstCustom
+
+// TDControls are the controls offered on the rebinding screen.
+var TDControls = []input.Control{
+	{Name: "Pause", Action: input.Pause},
+	{Name: "Card 1", Action: ActionCard1},
+	{Name: "Card 2", Action: ActionCard1 + 1},
+	{Name: "Card 3", Action: ActionCard1 + 2},
+}
+
+// newTDControls returns the default bindings plus the number keys and face
+// buttons for the cards, from left to right.
+func newTDControls() *input.Map {
+	m := input.NewMap()
+
+	cards := []struct {
+		key ebiten.Key
+		btn ebiten.StandardGamepadButton
+	}{
+		{ebiten.KeyDigit1, ebiten.StandardGamepadButtonRightLeft},
+		{ebiten.KeyDigit2, ebiten.StandardGamepadButtonRightBottom},
+		{ebiten.KeyDigit3, ebiten.StandardGamepadButtonRightRight},
+	}
+	for i, c := range cards {
+		m.Bind(ActionCard1+input.Action(i), input.Binding{
+			Keys:    []ebiten.Key{c.key},
+			Buttons: []ebiten.StandardGamepadButton{c.btn},
+		})
+	}
+
+	m.SetDefaults()
+
+	return m
+}
+
+// EnableControls loads the player's bindings from fsys and saves them there
+// whenever they are changed on the rebinding screen.
+func (g *TDGame) EnableControls(fsys paths.FS) error {
+	g.controlStore = fsys
+
+	return g.Controls.LoadControls(fsys, ControlsFile, TDControls)
+}
+
+// OpenControls shows the rebinding screen; it is open while paused.
+func (g *TDGame) OpenControls() {
+	g.rebinder = ui.NewRebinder(g.Controls, TDControls)
+	g.rebinder.OnChange = func() {
+		if g.controlStore == nil {
+			return
+		}
+
+		if err := g.Controls.SaveControls(g.controlStore, ControlsFile, TDControls); err != nil {
+			log.Printf("controls: %v", err)
+		}
+	}
+	g.State = StatePaused
+}
+
+// updatePaused resumes on Pause, opens the rebinding screen on K, or passes
+// input to the open rebinding screen.
+func (g *TDGame) updatePaused() {
+	if g.rebinder != nil {
+		if g.rebinder.Update() {
+			g.rebinder = nil
+		}
+
+		return
+	}
+
+	switch {
+	case g.Controls.JustPressed(input.Pause):
+		g.State = StatePlaying
+	case g.Input.IsKeyJustPressed(ebiten.KeyK):
+		g.OpenControls()
+	}
+}
+
+// drawPaused draws the rebinding screen, or how to resume and rebind.
+func (g *TDGame) drawPaused(screen *ebiten.Image) {
+	if g.rebinder != nil {
+		ebitenutil.DebugPrintAt(screen, "CONTROLS", 40, 60)
+		g.rebinder.X, g.rebinder.Y = 40, 90
+		g.rebinder.Draw(screen)
+
+		return
+	}
+
+	hint := fmt.Sprintf("%s: resume | K: controls", g.Controls.Binding(input.Pause))
+	ebitenutil.DebugPrintAt(screen, hint, (g.Width-len(hint)*6)/2, g.Height/2+40)
+}
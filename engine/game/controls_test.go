@@ -0,0 +1,40 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestRebindingFromPauseIsKept(t *testing.T) {
+	fsys := paths.MemFS()
+
+	g := NewHeadlessTDGame()
+	if err := g.EnableControls(fsys); err != nil {
+		t.Fatalf("no saved controls: %v", err)
+	}
+
+	g.OpenControls()
+
+	if g.State != StatePaused || g.rebinder == nil {
+		t.Fatalf("state %v, rebinder %v; want the rebinding screen over the pause", g.State, g.rebinder)
+	}
+
+	g.Controls.Bind(input.Pause, input.Binding{Keys: []ebiten.Key{ebiten.KeyTab}})
+	g.rebinder.OnChange()
+
+	resumed := NewHeadlessTDGame()
+	if err := resumed.EnableControls(fsys); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := resumed.Controls.Binding(input.Pause).String(); got != "Tab" {
+		t.Errorf("loaded Pause = %q, want Tab", got)
+	}
+
+	if got := resumed.Controls.Binding(ActionCard1 + 1).String(); got != "Digit2, Pad A" {
+		t.Errorf("Card 2 = %q, want its default", got)
+	}
+}
@@ -1,14 +1,21 @@
 package game
 
 import (
+	"cmp"
 	"image/color"
-	"math"
+	"log"
+	"maps"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/mlange-42/ark/ecs"
 	"github.com/skyrocket-qy/NeuralWay/engine/components"
 	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
 	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+	"github.com/skyrocket-qy/NeuralWay/engine/targeting"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 // GameState represents the current game state.
@@ -42,7 +49,12 @@ type TDGame struct {
 	ActiveMonsters    map[ecs.Entity]*Monster
 
 	// Systems
-	Input *systems.InputManager
+	Input         *systems.InputManager
+	Controls      *input.Map // Pause and card picks; see TDControls
+	Interpolation *systems.InterpolationSystem
+	BossBar       *systems.BossBarSystem
+	Clock         *engine.TickClock
+	AutoSave      *AutoSaver // Nil until EnableAutoSave
 
 	// Game state
 	State       GameState
@@ -50,15 +62,27 @@ type TDGame struct {
 	Gold        int
 	Score       int
 	CurrentWave int
-	DeltaTime   *engine.DeltaTime
 
 	// Screen dimensions
 	Width  int
 	Height int
+
+	// headless games create no images and tick at HeadlessTPS
+	headless bool
+
+	serializer *components.Serializer // World snapshots; see worldSerializer
+
+	// Rebinding screen open over the pause screen, and where bindings are kept
+	rebinder     *ui.Rebinder
+	controlStore paths.FS
 }
 
 // NewTDGame creates a new tower defense game.
 func NewTDGame(width, height int) *TDGame {
+	return newTDGame(width, height, false)
+}
+
+func newTDGame(width, height int, headless bool) *TDGame {
 	world := ecs.NewWorld()
 
 	game := &TDGame{
@@ -68,12 +92,16 @@ func NewTDGame(width, height int) *TDGame {
 		CardSelector:   NewCardSelector(),
 		ActiveMonsters: make(map[ecs.Entity]*Monster),
 		Input:          systems.NewInputManager(),
+		Controls:       newTDControls(),
+		Interpolation:  systems.NewInterpolationSystem(&world),
+		BossBar:        systems.NewBossBarSystem(&world, ui.NewBossBar(float64(width)/4, 52, float64(width)/2, 14)),
+		Clock:          engine.NewTickClock(),
 		State:          StatePlaying,
 		Lives:          20,
 		Gold:           100,
-		DeltaTime:      engine.NewDeltaTime(60),
 		Width:          width,
 		Height:         height,
+		headless:       headless,
 	}
 
 	// Create monster movement system
@@ -82,11 +110,7 @@ func NewTDGame(width, height int) *TDGame {
 	// Create hero
 	spawnX, spawnY := game.TDMap.TileToWorld(12, 7)
 	game.Hero = NewHero("Guardian")
-	game.HeroEntity = CreateHeroEntity(&world, game.Hero, spawnX, spawnY)
-
-	// Setup input bindings
-	game.Input.BindAction("pause", ebiten.KeyEscape)
-	game.Input.BindAction("select", ebiten.Key1, ebiten.Key2, ebiten.Key3)
+	game.HeroEntity = createHeroEntity(&world, game.Hero, spawnX, spawnY, !headless)
 
 	return game
 }
@@ -98,36 +122,70 @@ func (g *TDGame) Load() error {
 
 // Unload implements Scene.
 func (g *TDGame) Unload() {
-	// Cleanup
+	if g.AutoSave != nil {
+		if err := g.AutoSave.Close(); err != nil {
+			log.Printf("autosave: %v", err)
+		}
+	}
 }
 
-// Update implements Scene.
+// Update implements Scene. It handles input, then advances the simulation
+// one tick with Step.
 func (g *TDGame) Update() error {
-	dt := g.DeltaTime.Update()
 	g.Input.Update()
+	g.Interpolation.Update(g.World)
 
 	switch g.State {
 	case StatePlaying:
-		g.updatePlaying(dt)
+		if g.Controls.JustPressed(input.Pause) {
+			g.State = StatePaused
+		}
 	case StateCardSelect:
 		g.updateCardSelect()
 	case StatePaused:
-		if g.Input.IsActionJustPressed("pause") {
-			g.State = StatePlaying
-		}
+		g.updatePaused()
+	}
+
+	err := g.Step()
+
+	g.Clock.Tick()
+
+	return err
+}
+
+// Step advances the simulation one fixed tick without reading input, for
+// fast-forward substeps (see engine.WithSpeed).
+func (g *TDGame) Step() error {
+	if g.State != StatePlaying {
+		return nil
+	}
+
+	dt := g.tickDelta()
+	g.updatePlaying(dt)
+
+	if g.AutoSave != nil && g.State == StatePlaying {
+		g.AutoSave.Update(dt)
 	}
 
 	return nil
 }
 
-func (g *TDGame) updatePlaying(dt float64) {
-	// Handle pause
-	if g.Input.IsActionJustPressed("pause") {
-		g.State = StatePaused
+// tickDelta returns the simulated seconds per tick. Steps always advance a
+// whole tick, never wall-clock time, so substeps move timers and cooldowns
+// exactly as far as normal ticks do. Headless games ignore ebiten's TPS.
+func (g *TDGame) tickDelta() float64 {
+	if g.headless {
+		return 1.0 / HeadlessTPS
+	}
 
-		return
+	if tps := ebiten.TPS(); tps > 0 {
+		return 1 / float64(tps)
 	}
 
+	return 1.0 / ebiten.DefaultTPS
+}
+
+func (g *TDGame) updatePlaying(dt float64) {
 	// Update wave spawning
 	monsterType := g.WaveManager.Update(dt)
 	if monsterType != "" {
@@ -139,11 +197,14 @@ func (g *TDGame) updatePlaying(dt float64) {
 
 	// Update hero attacks
 	g.updateHeroAttacks(dt)
+	g.BossBar.Update(g.World, dt)
 
 	// Check for wave completion -> card selection
 	if len(g.ActiveMonsters) == 0 && !g.WaveManager.WaveActive &&
 		g.WaveManager.CurrentWave > g.CurrentWave {
 		g.CurrentWave = g.WaveManager.CurrentWave
+		g.requestAutoSave()
+
 		if !g.WaveManager.AllComplete {
 			g.CardSelector.GenerateChoices(g.CurrentWave)
 			g.State = StateCardSelect
@@ -152,11 +213,11 @@ func (g *TDGame) updatePlaying(dt float64) {
 
 	// Check win/lose conditions
 	if g.Lives <= 0 {
-		g.State = StateGameOver
+		g.endRun(StateGameOver)
 	}
 
 	if g.WaveManager.AllComplete && len(g.ActiveMonsters) == 0 {
-		g.State = StateVictory
+		g.endRun(StateVictory)
 	}
 }
 
@@ -165,15 +226,29 @@ func (g *TDGame) updateCardSelect() {
 	clicked := g.Input.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
 
 	card := g.CardSelector.HandleInput(mx, my, clicked, g.Width, g.Height)
+
+	for i, c := range g.CardSelector.Cards {
+		if c != nil && g.Controls.JustPressed(ActionCard1+input.Action(i)) {
+			card = c
+		}
+	}
+
 	if card != nil {
-		g.CardSelector.ApplyCard(card, g.Hero)
-		g.State = StatePlaying
+		g.chooseCard(card)
 	}
 }
 
+// chooseCard applies the picked card and resumes play.
+func (g *TDGame) chooseCard(card *Card) {
+	g.CardSelector.ApplyCard(card, g.Hero)
+	g.CardSelector.Active = false
+	g.State = StatePlaying
+	g.requestAutoSave()
+}
+
 func (g *TDGame) spawnMonster(monsterType string) {
 	spawnX, spawnY := g.TDMap.TileToWorld(g.TDMap.SpawnPoint.X, g.TDMap.SpawnPoint.Y)
-	entity, monster := CreateMonsterEntity(g.World, monsterType, spawnX, spawnY)
+	entity, monster := createMonsterEntity(g.World, monsterType, spawnX, spawnY, !g.headless)
 	g.ActiveMonsters[entity] = monster
 	g.MonsterMoveSystem.AddMonster(entity, monster)
 }
@@ -200,56 +275,64 @@ func (g *TDGame) updateHeroAttacks(dt float64) {
 		return
 	}
 
-	// Find closest monster in range
 	posMapper := ecs.NewMap1[components.Position](g.World)
+	healthMapper := ecs.NewMap1[components.Health](g.World)
 
 	heroPos := posMapper.Get(g.HeroEntity)
 	if heroPos == nil {
 		return
 	}
 
-	var (
-		closestEntity  ecs.Entity
-		closestDist    = math.MaxFloat64
-		closestMonster *Monster
-	)
+	// Pick a monster in range by the hero's targeting policy
+	candidates := make([]targeting.Target, 0, len(g.ActiveMonsters))
+	entities := make([]ecs.Entity, 0, len(g.ActiveMonsters))
 
-	for entity, monster := range g.ActiveMonsters {
-		pos := posMapper.Get(entity)
-		if pos == nil {
+	// In spawn order, so ties resolve the same way every run
+	for _, entity := range g.monstersInOrder() {
+		monster := g.ActiveMonsters[entity]
+		pos, health := posMapper.Get(entity), healthMapper.Get(entity)
+		if pos == nil || health == nil {
 			continue
 		}
 
-		dx := pos.X - heroPos.X
-		dy := pos.Y - heroPos.Y
-		dist := math.Sqrt(dx*dx + dy*dy)
+		candidates = append(candidates, targeting.Target{
+			ID:       len(entities),
+			X:        pos.X,
+			Y:        pos.Y,
+			HP:       float64(health.Current),
+			Progress: float64(monster.PathIndex),
+		})
+		entities = append(entities, entity)
+	}
 
-		if dist <= g.Hero.AttackRange && dist < closestDist {
-			closestDist = dist
-			closestEntity = entity
-			closestMonster = monster
-		}
+	target, ok := targeting.Select(heroPos.X, heroPos.Y, g.Hero.AttackRange, g.Hero.Targeting, candidates)
+	if !ok {
+		return
 	}
 
-	// Attack the closest monster
-	if closestMonster != nil {
-		healthMapper := ecs.NewMap1[components.Health](g.World)
-		health := healthMapper.Get(closestEntity)
+	entity := entities[target.ID]
+	monster := g.ActiveMonsters[entity]
 
-		if health != nil {
-			health.Current -= g.Hero.AttackDamage
-			if health.Current <= 0 {
-				g.Gold += closestMonster.Experience / 2
+	health := healthMapper.Get(entity)
+	health.Current -= g.Hero.AttackDamage
 
-				g.Score += closestMonster.Experience
-				g.Hero.GainExp(closestMonster.Experience) // Level ups handled automatically
+	if health.Current <= 0 {
+		g.Gold += monster.Experience / 2
 
-				g.removeMonster(closestEntity)
-			}
-		}
+		g.Score += monster.Experience
+		g.Hero.GainExp(monster.Experience) // Level ups handled automatically
+
+		g.removeMonster(entity)
 	}
 }
 
+// monstersInOrder returns the active monsters sorted by entity ID.
+func (g *TDGame) monstersInOrder() []ecs.Entity {
+	return slices.SortedFunc(maps.Keys(g.ActiveMonsters), func(a, b ecs.Entity) int {
+		return cmp.Compare(a.ID(), b.ID())
+	})
+}
+
 func (g *TDGame) removeMonster(entity ecs.Entity) {
 	delete(g.ActiveMonsters, entity)
 	g.MonsterMoveSystem.RemoveMonster(entity)
@@ -268,6 +351,7 @@ func (g *TDGame) Draw(screen *ebiten.Image) {
 	g.drawEntities(screen)
 
 	// Draw UI
+	g.BossBar.Draw(g.World, screen)
 	g.drawUI(screen)
 
 	// Draw card selector if active
@@ -275,6 +359,8 @@ func (g *TDGame) Draw(screen *ebiten.Image) {
 }
 
 func (g *TDGame) drawEntities(screen *ebiten.Image) {
+	alpha := g.Clock.Alpha()
+	prevMapper := ecs.NewMap[components.PrevPosition](g.World)
 	spriteFilter := ecs.NewFilter2[components.Position, components.Sprite](g.World)
 	query := spriteFilter.Query()
 
@@ -284,9 +370,14 @@ func (g *TDGame) drawEntities(screen *ebiten.Image) {
 			continue
 		}
 
+		x, y := pos.X, pos.Y
+		if prevMapper.Has(query.Entity()) {
+			x, y = systems.Lerp(*prevMapper.Get(query.Entity()), *pos, alpha)
+		}
+
 		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Scale(sprite.ScaleX, sprite.ScaleY)
-		op.GeoM.Translate(pos.X+sprite.OffsetX, pos.Y+sprite.OffsetY)
+		op.GeoM.Translate(x+sprite.OffsetX, y+sprite.OffsetY)
 		screen.DrawImage(sprite.Image, op)
 	}
 
@@ -300,13 +391,18 @@ func (g *TDGame) drawEntities(screen *ebiten.Image) {
 			continue
 		}
 
+		x, y := pos.X, pos.Y
+		if prevMapper.Has(hQuery.Entity()) {
+			x, y = systems.Lerp(*prevMapper.Get(hQuery.Entity()), *pos, alpha)
+		}
+
 		// Health bar background
 		bgWidth := 20
 		bg := ebiten.NewImage(bgWidth, 4)
 		bg.Fill(color.RGBA{R: 100, G: 0, B: 0, A: 255})
 
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(pos.X-float64(bgWidth)/2, pos.Y-15)
+		op.GeoM.Translate(x-float64(bgWidth)/2, y-15)
 		screen.DrawImage(bg, op)
 
 		// Health bar foreground
@@ -328,11 +424,8 @@ func (g *TDGame) drawUI(screen *ebiten.Image) {
 	// Draw state-specific overlays
 	switch g.State {
 	case StatePaused:
-		g.drawCenteredOverlay(
-			screen,
-			"PAUSED - Press ESC to resume",
-			color.RGBA{R: 255, G: 255, B: 255, A: 255},
-		)
+		g.drawCenteredOverlay(screen, "PAUSED", color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		g.drawPaused(screen)
 	case StateGameOver:
 		g.drawCenteredOverlay(screen, "GAME OVER", color.RGBA{R: 255, G: 50, B: 50, A: 255})
 	case StateVictory:
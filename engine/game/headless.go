@@ -0,0 +1,68 @@
+package game
+
+import "github.com/skyrocket-qy/NeuralWay/engine/rng"
+
+// HeadlessTPS is the fixed tick rate of headless games: each tick advances
+// the simulation 1/HeadlessTPS seconds, however fast the ticks really run.
+const HeadlessTPS = 60
+
+// Summary is the state a headless run ends in.
+type Summary struct {
+	Seed      int64
+	Ticks     int       // Ticks simulated, at most the budget
+	State     GameState // StateVictory, StateGameOver, or StatePlaying if the budget ran out
+	Wave      int       // Waves completed
+	Lives     int
+	Gold      int
+	Score     int
+	HeroLevel int
+	Cards     []string // Names of the cards picked, in order
+	Monsters  int      // Monsters still on the map
+}
+
+// NewHeadlessTDGame creates a tower defense game that never draws: sprites
+// have no images and Step always advances 1/HeadlessTPS seconds, so it runs
+// without a window, e.g. for AI training, balance tests in CI, or a server.
+func NewHeadlessTDGame() *TDGame {
+	return newTDGame(0, 0, true)
+}
+
+// RunHeadless plays a new headless game for up to ticks ticks and returns
+// where it ended. See TDGame.RunHeadless.
+func RunHeadless(ticks int, seed int64) Summary {
+	return NewHeadlessTDGame().RunHeadless(ticks, seed)
+}
+
+// RunHeadless steps the game without input until it is won or lost or ticks
+// ticks have passed. The cards offered and the one picked from each choice
+// are drawn from seed, so the same seed always plays out the same way.
+func (g *TDGame) RunHeadless(ticks int, seed int64) Summary {
+	streams := rng.New(seed)
+	g.CardSelector.rng = streams.Stream("cards")
+	picks := streams.Stream("card picks")
+	sum := Summary{Seed: seed}
+
+	for sum.Ticks < ticks && g.State != StateVictory && g.State != StateGameOver {
+		if g.State == StateCardSelect {
+			card := g.CardSelector.Cards[picks.Intn(len(g.CardSelector.Cards))]
+			g.chooseCard(card)
+			sum.Cards = append(sum.Cards, card.Name)
+		}
+
+		if err := g.Step(); err != nil {
+			break
+		}
+
+		sum.Ticks++
+	}
+
+	sum.State = g.State
+	sum.Wave = g.CurrentWave
+	sum.Lives = g.Lives
+	sum.Gold = g.Gold
+	sum.Score = g.Score
+	sum.HeroLevel = g.Hero.Level
+	sum.Monsters = len(g.ActiveMonsters)
+
+	return sum
+}
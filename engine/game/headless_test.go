@@ -0,0 +1,75 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+const headlessBudget = 60 * HeadlessTPS // A minute of game time
+
+// strongHeadlessGame returns a headless game whose hero clears every wave.
+func strongHeadlessGame() *TDGame {
+	g := NewHeadlessTDGame()
+	g.Hero.AttackDamage = 1000
+	g.Hero.AttackRange = 1000
+	g.Hero.AttackSpeed = 10
+
+	return g
+}
+
+func TestRunHeadlessIsDeterministic(t *testing.T) {
+	a := strongHeadlessGame().RunHeadless(headlessBudget, 7)
+	b := strongHeadlessGame().RunHeadless(headlessBudget, 7)
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed played out differently:\n%+v\n%+v", a, b)
+	}
+}
+
+func TestRunHeadlessPlaysToVictory(t *testing.T) {
+	sum := strongHeadlessGame().RunHeadless(headlessBudget, 1)
+
+	if sum.State != StateVictory || sum.Monsters != 0 || sum.Lives != 20 {
+		t.Fatalf("summary = %+v, want a flawless victory", sum)
+	}
+
+	if sum.Ticks >= headlessBudget {
+		t.Errorf("ran all %d ticks; the run should stop at victory", sum.Ticks)
+	}
+
+	// A card after every wave but the last
+	if len(sum.Cards) != sum.Wave-1 {
+		t.Errorf("picked %v after %d waves", sum.Cards, sum.Wave)
+	}
+}
+
+func TestRunHeadlessStopsAtGameOver(t *testing.T) {
+	sum := RunHeadless(headlessBudget, 1)
+
+	if sum.State != StateGameOver || sum.Lives > 0 || sum.Ticks >= headlessBudget {
+		t.Errorf("summary = %+v, want a game over before the budget runs out", sum)
+	}
+}
+
+func TestRunHeadlessStopsAtBudget(t *testing.T) {
+	sum := RunHeadless(10, 1)
+
+	if sum.Ticks != 10 || sum.State != StatePlaying {
+		t.Errorf("summary = %+v, want 10 ticks still playing", sum)
+	}
+}
+
+func TestHeadlessGameCreatesNoImages(t *testing.T) {
+	g := NewHeadlessTDGame()
+	g.RunHeadless(10*HeadlessTPS, 1)
+
+	sprites := ecs.NewFilter1[components.Sprite](g.World).Query()
+	for sprites.Next() {
+		if sprites.Get().Image != nil {
+			t.Fatal("headless game created a sprite image")
+		}
+	}
+}
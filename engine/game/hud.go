@@ -6,6 +6,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // HUDElement represents a UI element type.
@@ -30,6 +31,10 @@ const (
 )
 
 // HUDBar represents a progress bar.
+//
+// When animated (SmoothRate > 0), the drawn fill eases toward Current and a
+// lost segment stays visible in FlashColor for TrailDelay seconds before
+// draining, so damage spikes are easy to read. Call Update every frame.
 type HUDBar struct {
 	X, Y          float64
 	Width, Height float64
@@ -40,6 +45,19 @@ type HUDBar struct {
 	ShowText      bool
 	TextFormat    string // e.g., "%d/%d" or "%.0f%%"
 	Visible       bool
+
+	// Animation
+	Display      float64    // Smoothed fill that is actually drawn
+	Trail        float64    // End of the recent-loss segment (>= Display)
+	SmoothRate   float64    // How fast Display catches up, per second (0 = snap)
+	TrailDelay   float64    // Seconds the loss segment holds before draining
+	TrailRate    float64    // Fill fraction per second the loss segment drains
+	FlashColor   color.RGBA // Color of the loss segment
+	LowThreshold float64    // Pulse when Current is at or below this (0 = off)
+	PulseRate    float64    // Low-value pulses per second
+
+	trailHold float64
+	pulseTime float64
 }
 
 // NewHUDBar creates a new bar.
@@ -56,22 +74,82 @@ func NewHUDBar(x, y, width, height float64) *HUDBar {
 	}
 }
 
+// NewAnimatedHUDBar creates a bar with smoothed fill and a white loss flash.
+func NewAnimatedHUDBar(x, y, width, height float64) *HUDBar {
+	b := NewHUDBar(x, y, width, height)
+	b.SmoothRate = 12
+	b.TrailDelay = 0.4
+	b.TrailRate = 0.8
+	b.FlashColor = color.RGBA{220, 220, 220, 220}
+	b.PulseRate = 1.5
+
+	return b
+}
+
 // SetValue sets the bar value (clamped 0-1).
 func (b *HUDBar) SetValue(current, maxVal float64) {
+	prev := b.Current
+
 	if maxVal <= 0 {
 		b.Current = 0
+	} else {
+		b.Current = Clamp(current/maxVal, 0, 1)
+	}
 
-		return
+	// A loss (re)starts the hold on the flash segment
+	if b.Current < prev {
+		b.Trail = math.Max(b.Trail, b.Display)
+		b.trailHold = b.TrailDelay
 	}
+}
 
-	b.Current = current / maxVal
-	if b.Current < 0 {
-		b.Current = 0
+// Snap jumps the bar to a value without animating, e.g. when an XP bar wraps on level-up.
+func (b *HUDBar) Snap(current, maxVal float64) {
+	b.SetValue(current, maxVal)
+	b.Display = b.Current
+	b.Trail = b.Current
+	b.trailHold = 0
+}
+
+// Update advances the fill animation and the low-value pulse.
+func (b *HUDBar) Update(dt float64) {
+	b.pulseTime += dt
+
+	if b.SmoothRate <= 0 {
+		b.Display = b.Current
+	} else {
+		b.Display += (b.Current - b.Display) * math.Min(1, b.SmoothRate*dt)
+		if math.Abs(b.Current-b.Display) < 0.001 {
+			b.Display = b.Current
+		}
+	}
+
+	switch {
+	case b.Trail <= b.Display:
+		b.Trail = b.Display
+	case b.trailHold > 0:
+		b.trailHold -= dt
+	default:
+		b.Trail = math.Max(b.Display, b.Trail-b.TrailRate*dt)
 	}
+}
+
+// IsLow returns true if the bar is at or below its low threshold.
+func (b *HUDBar) IsLow() bool {
+	return b.LowThreshold > 0 && b.Current <= b.LowThreshold
+}
 
-	if b.Current > 1 {
-		b.Current = 1
+// LowPulse returns a 0-1 pulse while the bar is low, stronger the emptier it is.
+// Games can feed it to a screen vignette (see DrawVignette).
+func (b *HUDBar) LowPulse() float64 {
+	if !b.IsLow() {
+		return 0
 	}
+
+	depth := 0.4 + 0.6*(1-b.Current/b.LowThreshold)
+	wave := 0.5 + 0.5*math.Sin(b.pulseTime*b.PulseRate*2*math.Pi)
+
+	return depth * wave
 }
 
 // Draw renders the bar.
@@ -80,19 +158,54 @@ func (b *HUDBar) Draw(screen *ebiten.Image) {
 		return
 	}
 
+	fill := b.Current
+	if b.SmoothRate > 0 || b.Trail > 0 {
+		fill = b.Display
+	}
+
 	// Background
 	drawRect(screen, b.X, b.Y, b.Width, b.Height, b.BackColor)
 
-	// Fill
-	fillWidth := b.Width * b.Current
+	// Recent loss
+	if b.Trail > fill {
+		drawRect(screen, b.X+b.Width*fill, b.Y, b.Width*(b.Trail-fill), b.Height, b.FlashColor)
+	}
+
+	// Fill, brightened in time with the low-value pulse
+	fillWidth := b.Width * fill
 	if fillWidth > 0 {
-		drawRect(screen, b.X, b.Y, fillWidth, b.Height, b.FillColor)
+		drawRect(screen, b.X, b.Y, fillWidth, b.Height, brighten(b.FillColor, b.LowPulse()*0.5))
 	}
 
 	// Border
 	drawRectOutline(screen, b.X, b.Y, b.Width, b.Height, b.BorderColor)
 }
 
+// DrawVignette darkens the screen edges with a tinted border, e.g. driven by
+// HUDBar.LowPulse for a low-health warning. intensity is 0-1.
+func DrawVignette(screen *ebiten.Image, intensity float64, c color.RGBA) {
+	if intensity <= 0 {
+		return
+	}
+
+	bounds := screen.Bounds()
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+
+	const bands = 8
+
+	thickness := math.Min(w, h) * 0.12 / bands
+
+	for i := range bands {
+		inset := float64(i) * thickness
+		band := scaleAlpha(c, intensity*(1-float64(i)/bands))
+
+		drawRect(screen, inset, inset, w-2*inset, thickness, band)
+		drawRect(screen, inset, h-inset-thickness, w-2*inset, thickness, band)
+		drawRect(screen, inset, inset+thickness, thickness, h-2*inset-2*thickness, band)
+		drawRect(screen, w-inset-thickness, inset+thickness, thickness, h-2*inset-2*thickness, band)
+	}
+}
+
 // HUDText represents a text label.
 type HUDText struct {
 	X, Y    float64
@@ -299,7 +412,9 @@ func (h *HUD) SetMinimap(minimap *Minimap) {
 
 // Update updates all HUD elements.
 func (h *HUD) Update(dt float64) {
-	// Animation updates could go here
+	for _, bar := range h.Bars {
+		bar.Update(dt)
+	}
 }
 
 // Draw renders the entire HUD.
@@ -328,12 +443,29 @@ func (h *HUD) Draw(screen *ebiten.Image) {
 // Helper functions
 
 func drawRect(screen *ebiten.Image, x, y, w, h float64, c color.RGBA) {
-	img := ebiten.NewImage(int(w), int(h))
-	img.Fill(c)
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	vector.FillRect(screen, float32(x), float32(y), float32(w), float32(h), c, false)
+}
+
+// scaleAlpha fades a premultiplied color by factor (0-1).
+func scaleAlpha(c color.RGBA, factor float64) color.RGBA {
+	scale := func(v uint8) uint8 { return uint8(float64(v) * factor) }
+
+	return color.RGBA{scale(c.R), scale(c.G), scale(c.B), scale(c.A)}
+}
+
+// brighten blends a (premultiplied) color toward white by amount (0-1).
+func brighten(c color.RGBA, amount float64) color.RGBA {
+	if amount <= 0 {
+		return c
+	}
+
+	lift := func(v uint8) uint8 { return uint8(float64(v) + (float64(c.A)-float64(v))*amount) }
 
-	opts := &ebiten.DrawImageOptions{}
-	opts.GeoM.Translate(x, y)
-	screen.DrawImage(img, opts)
+	return color.RGBA{lift(c.R), lift(c.G), lift(c.B), c.A}
 }
 
 func drawRectOutline(screen *ebiten.Image, x, y, w, h float64, c color.RGBA) {
@@ -0,0 +1,101 @@
+package game
+
+import "testing"
+
+func TestAnimatedHUDBarTrail(t *testing.T) {
+	b := NewAnimatedHUDBar(0, 0, 100, 10)
+	b.Snap(100, 100)
+
+	b.SetValue(40, 100)
+
+	if b.Display != 1 || b.Trail != 1 {
+		t.Fatalf("loss should not snap the fill: display=%v trail=%v", b.Display, b.Trail)
+	}
+
+	// The fill eases down while the loss segment holds
+	b.Update(1.0 / 60)
+
+	if b.Display >= 1 || b.Display <= 0.4 {
+		t.Errorf("display after one frame = %v, want between 0.4 and 1", b.Display)
+	}
+
+	if b.Trail != 1 {
+		t.Errorf("trail should hold during TrailDelay, got %v", b.Trail)
+	}
+
+	for range 120 {
+		b.Update(1.0 / 60)
+	}
+
+	if b.Display != 0.4 || b.Trail != 0.4 {
+		t.Errorf("after 2s display=%v trail=%v, want both 0.4", b.Display, b.Trail)
+	}
+
+	// Gains never show a loss segment
+	b.SetValue(80, 100)
+	b.Update(1.0 / 60)
+
+	if b.Trail != b.Display {
+		t.Errorf("gain produced a trail: display=%v trail=%v", b.Display, b.Trail)
+	}
+}
+
+func TestHUDBarSnapAndLowPulse(t *testing.T) {
+	b := NewAnimatedHUDBar(0, 0, 100, 10)
+	b.LowThreshold = 0.25
+
+	b.Snap(90, 100)
+	b.Snap(5, 100) // e.g. XP bar wrapping on level-up
+
+	if b.Display != 0.05 || b.Trail != 0.05 {
+		t.Errorf("snap should skip animation: display=%v trail=%v", b.Display, b.Trail)
+	}
+
+	if !b.IsLow() {
+		t.Fatal("5% should be low")
+	}
+
+	peak := 0.0
+	for range 60 {
+		b.Update(1.0 / 60)
+		peak = max(peak, b.LowPulse())
+	}
+
+	if peak <= 0.5 || peak > 1 {
+		t.Errorf("low pulse peak = %v, want in (0.5, 1]", peak)
+	}
+
+	b.Snap(100, 100)
+
+	if b.LowPulse() != 0 {
+		t.Error("full bar should not pulse")
+	}
+}
+
+func TestPlainHUDBarSnaps(t *testing.T) {
+	b := NewHUDBar(0, 0, 100, 10)
+	b.SetValue(50, 100)
+	b.Update(1.0 / 60)
+
+	if b.Display != 0.5 {
+		t.Errorf("non-animated bar display = %v, want 0.5", b.Display)
+	}
+}
+
+func TestBossBarFollowsBossMonsters(t *testing.T) {
+	g := NewHeadlessTDGame()
+
+	g.spawnMonster("goblin")
+	g.BossBar.Update(g.World, 0.1)
+
+	if g.BossBar.Bar.Visible() {
+		t.Fatal("boss bar shown for a goblin")
+	}
+
+	g.spawnMonster("boss")
+	g.BossBar.Update(g.World, 0.1)
+
+	if st := g.BossBar.Bar.Status(); !g.BossBar.Bar.Visible() || st.Name != "Boss" || st.Max != 500 {
+		t.Fatalf("boss bar = %+v, want the boss shown", st)
+	}
+}
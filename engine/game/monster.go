@@ -7,6 +7,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/mlange-42/ark/ecs"
 	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
 )
 
 // Monster represents an enemy that follows the path.
@@ -47,6 +48,7 @@ type MonsterType struct {
 	Speed  float64
 	Exp    int
 	Size   int
+	Boss   *components.Boss // Set for bosses, which are tagged for the boss bar
 }
 
 // Predefined monster types.
@@ -82,11 +84,20 @@ var MonsterTypes = map[string]MonsterType{
 		Speed:  15,
 		Exp:    200,
 		Size:   32,
+		Boss:   &components.Boss{},
 	},
 }
 
 // CreateMonsterEntity creates an ECS entity for a monster.
 func CreateMonsterEntity(world *ecs.World, monsterType string, x, y float64) (ecs.Entity, *Monster) {
+	return createMonsterEntity(world, monsterType, x, y, true)
+}
+
+// createMonsterEntity creates a monster, leaving its sprite without an image
+// when draw is false, as in headless games.
+func createMonsterEntity(
+	world *ecs.World, monsterType string, x, y float64, draw bool,
+) (ecs.Entity, *Monster) {
 	mt := MonsterTypes[monsterType]
 	if mt.Name == "" {
 		mt = MonsterTypes["goblin"] // Default
@@ -94,14 +105,18 @@ func CreateMonsterEntity(world *ecs.World, monsterType string, x, y float64) (ec
 
 	monster := NewMonster(mt.Name, mt.Health, mt.Speed, mt.Exp)
 
-	img := ebiten.NewImage(mt.Size, mt.Size)
-	img.Fill(mt.Color)
+	var img *ebiten.Image
+	if draw {
+		img = ebiten.NewImage(mt.Size, mt.Size)
+		img.Fill(mt.Color)
+	}
 
-	mapper := ecs.NewMap4[components.Position, components.Velocity, components.Sprite, components.Health](
-		world,
-	)
+	mapper := ecs.NewMap5[
+		components.Position, components.PrevPosition, components.Velocity, components.Sprite, components.Health,
+	](world)
 	entity := mapper.NewEntity(
 		&components.Position{X: x, Y: y},
+		&components.PrevPosition{X: x, Y: y},
 		&components.Velocity{X: 0, Y: 0},
 		&components.Sprite{
 			Image:   img,
@@ -117,6 +132,12 @@ func CreateMonsterEntity(world *ecs.World, monsterType string, x, y float64) (ec
 		},
 	)
 
+	if mt.Boss != nil {
+		boss := *mt.Boss
+		ecs.NewMap3[components.Tag, components.Name, components.Boss](world).Add(entity,
+			&components.Tag{Name: systems.BossTag}, &components.Name{Value: mt.Name}, &boss)
+	}
+
 	return entity, monster
 }
 
@@ -6,10 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
 )
 
 // SaveData represents a game save.
@@ -106,20 +106,37 @@ func (s *SaveData) GetBool(key string, defaultVal bool) bool {
 	return defaultVal
 }
 
+// ErrSaveCorrupted is returned when a save file cannot be parsed or fails its
+// checksum.
+var ErrSaveCorrupted = errors.New("save file corrupted")
+
 // SaveManager handles save/load operations.
 type SaveManager struct {
-	SaveDir      string
-	CurrentSave  *SaveData
-	AutoSaveSlot string
-	useChecksum  bool
+	SaveDir       string   // Directory behind FS; empty when FS is not a directory
+	FS            paths.FS // Where slots are stored
+	CurrentSave   *SaveData
+	AutoSaveSlot  string
+	SchemaVersion int // Current save format version, raised by RegisterMigration
+	useChecksum   bool
+	migrations    map[int]Migration
 }
 
-// NewSaveManager creates a save manager.
+// NewSaveManager creates a save manager storing slots in saveDir.
 func NewSaveManager(saveDir string) *SaveManager {
+	sm := NewSaveManagerFS(paths.DirFS(saveDir))
+	sm.SaveDir = saveDir
+
+	return sm
+}
+
+// NewSaveManagerFS creates a save manager storing slots in fsys, such as the
+// store from paths.App.Open, which uses localStorage in the browser.
+func NewSaveManagerFS(fsys paths.FS) *SaveManager {
 	return &SaveManager{
-		SaveDir:      saveDir,
-		AutoSaveSlot: "autosave",
-		useChecksum:  true,
+		FS:            fsys,
+		AutoSaveSlot:  "autosave",
+		SchemaVersion: 1,
+		useChecksum:   true,
 	}
 }
 
@@ -148,24 +165,12 @@ func (sm *SaveManager) verifyChecksum(save *SaveData) bool {
 
 // Save saves data to a slot.
 func (sm *SaveManager) Save(slot string, save *SaveData) error {
-	// Ensure save directory exists
-	if err := os.MkdirAll(sm.SaveDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create save directory: %w", err)
-	}
-
-	// Update metadata
-	save.Timestamp = time.Now().Unix()
-	save.Checksum = sm.calculateChecksum(save.Data)
-
-	// Marshal to JSON
-	jsonData, err := json.MarshalIndent(save, "", "  ")
+	data, err := sm.encode(save)
 	if err != nil {
-		return fmt.Errorf("failed to marshal save data: %w", err)
+		return err
 	}
 
-	// Write to file
-	path := filepath.Join(sm.SaveDir, slot+".json")
-	if err := os.WriteFile(path, jsonData, 0o644); err != nil {
+	if err := sm.FS.WriteFile(slot+".json", data); err != nil {
 		return fmt.Errorf("failed to write save file: %w", err)
 	}
 
@@ -174,67 +179,87 @@ func (sm *SaveManager) Save(slot string, save *SaveData) error {
 	return nil
 }
 
+// encode stamps save with the schema version, time, and checksum and
+// marshals it.
+func (sm *SaveManager) encode(save *SaveData) ([]byte, error) {
+	save.Version = sm.SchemaVersion
+	save.Timestamp = time.Now().Unix()
+	save.Checksum = sm.calculateChecksum(save.Data)
+
+	jsonData, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal save data: %w", err)
+	}
+
+	return jsonData, nil
+}
+
 // Load loads data from a slot.
 func (sm *SaveManager) Load(slot string) (*SaveData, error) {
-	path := filepath.Join(sm.SaveDir, slot+".json")
-
-	file, err := os.Open(path)
+	save, err := sm.loadFile(slot, slot+".json")
 	if err != nil {
-		return nil, fmt.Errorf("failed to open save file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	data, err := io.ReadAll(file)
+	sm.CurrentSave = save
+
+	return save, nil
+}
+
+// loadFile reads, verifies, and if needed migrates the named file holding
+// slot's data.
+func (sm *SaveManager) loadFile(slot, name string) (*SaveData, error) {
+	data, err := sm.FS.ReadFile(name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read save file: %w", err)
 	}
 
 	var save SaveData
 	if err := json.Unmarshal(data, &save); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal save data: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrSaveCorrupted, err)
 	}
 
 	// Verify checksum
 	if !sm.verifyChecksum(&save) {
-		return nil, errors.New("save file corrupted: checksum mismatch")
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrSaveCorrupted)
 	}
 
-	sm.CurrentSave = &save
+	// Upgrade older saves, keeping a backup of the original file
+	if save.Version > sm.SchemaVersion {
+		return nil, fmt.Errorf("%w: save v%d, supported v%d", ErrSaveFromFuture, save.Version, sm.SchemaVersion)
+	}
+
+	if sm.NeedsMigration(&save) {
+		if err := sm.migrateSlot(slot, data, &save); err != nil {
+			return nil, err
+		}
+	}
 
 	return &save, nil
 }
 
 // Exists checks if a save slot exists.
 func (sm *SaveManager) Exists(slot string) bool {
-	path := filepath.Join(sm.SaveDir, slot+".json")
-	_, err := os.Stat(path)
-
-	return err == nil
+	return sm.FS.Exists(slot + ".json")
 }
 
 // Delete removes a save slot.
 func (sm *SaveManager) Delete(slot string) error {
-	path := filepath.Join(sm.SaveDir, slot+".json")
-
-	return os.Remove(path)
+	return sm.FS.Remove(slot + ".json")
 }
 
 // ListSaves returns all available save slots.
 func (sm *SaveManager) ListSaves() ([]string, error) {
-	entries, err := os.ReadDir(sm.SaveDir)
+	names, err := sm.FS.List()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
-		}
-
 		return nil, err
 	}
 
-	var slots []string
+	slots := []string{}
 
-	for _, e := range entries {
-		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
-			slots = append(slots, e.Name()[:len(e.Name())-5])
+	for _, name := range names {
+		if slot, ok := strings.CutSuffix(name, ".json"); ok {
+			slots = append(slots, slot)
 		}
 	}
 
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MigrationFunc upgrades save data in place from one schema version to the next.
+type MigrationFunc func(data map[string]any) error
+
+// Migration describes a single schema upgrade step (From -> From+1).
+type Migration struct {
+	From        int
+	Description string
+	Apply       MigrationFunc
+}
+
+// ErrSaveFromFuture is returned when a save was written by a newer schema version.
+var ErrSaveFromFuture = errors.New("save was written by a newer version")
+
+// RegisterMigration registers a migration from the given version to the next one.
+// The manager's SchemaVersion is raised to cover the newest registered step.
+func (sm *SaveManager) RegisterMigration(from int, description string, apply MigrationFunc) {
+	if sm.migrations == nil {
+		sm.migrations = make(map[int]Migration)
+	}
+
+	sm.migrations[from] = Migration{From: from, Description: description, Apply: apply}
+
+	if from+1 > sm.SchemaVersion {
+		sm.SchemaVersion = from + 1
+	}
+}
+
+// Migrations returns the registered migrations ordered by source version.
+func (sm *SaveManager) Migrations() []Migration {
+	list := make([]Migration, 0, len(sm.migrations))
+	for _, m := range sm.migrations {
+		list = append(list, m)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].From < list[j].From })
+
+	return list
+}
+
+// NeedsMigration reports whether the save is older than the current schema.
+func (sm *SaveManager) NeedsMigration(save *SaveData) bool {
+	return save.Version < sm.SchemaVersion
+}
+
+// Migrate applies all migrations needed to bring the save to the current schema.
+// Steps run sequentially; the save is left untouched if any step is missing or fails.
+func (sm *SaveManager) Migrate(save *SaveData) error {
+	if save.Version > sm.SchemaVersion {
+		return fmt.Errorf("%w: save v%d, supported v%d", ErrSaveFromFuture, save.Version, sm.SchemaVersion)
+	}
+
+	// Work on a shallow copy so a failed step does not leave half-migrated data behind
+	data := make(map[string]any, len(save.Data))
+	for k, v := range save.Data {
+		data[k] = v
+	}
+
+	version := save.Version
+	for version < sm.SchemaVersion {
+		m, ok := sm.migrations[version]
+		if !ok {
+			return fmt.Errorf("no migration registered from save v%d", version)
+		}
+
+		if err := m.Apply(data); err != nil {
+			return fmt.Errorf("migration v%d->v%d (%s) failed: %w", version, version+1, m.Description, err)
+		}
+
+		version++
+	}
+
+	save.Data = data
+	save.Version = version
+
+	return nil
+}
+
+// backupName returns the backup file name for a slot at the given version.
+func backupName(slot string, version int) string {
+	return fmt.Sprintf("%s.v%d.bak", slot, version)
+}
+
+// HasBackup returns true if a pre-migration backup exists for the slot and version.
+func (sm *SaveManager) HasBackup(slot string, version int) bool {
+	return sm.FS.Exists(backupName(slot, version))
+}
+
+// migrateSlot backs up the original file and rewrites the slot at the current schema.
+func (sm *SaveManager) migrateSlot(slot string, raw []byte, save *SaveData) error {
+	original := save.Version
+
+	if err := sm.Migrate(save); err != nil {
+		return err
+	}
+
+	if err := sm.FS.WriteFile(backupName(slot, original), raw); err != nil {
+		return fmt.Errorf("failed to back up save before migration: %w", err)
+	}
+
+	return sm.Save(slot, save)
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// registerTestMigrations installs a v1->v2->v3 chain:
+// v2 renames "gold" to "coins", v3 wraps equipment into a slot map.
+func registerTestMigrations(sm *SaveManager) {
+	sm.RegisterMigration(1, "rename gold to coins", func(data map[string]any) error {
+		data["coins"] = data["gold"]
+		delete(data, "gold")
+
+		return nil
+	})
+	sm.RegisterMigration(2, "equipment by slot", func(data map[string]any) error {
+		data["equipment"] = map[string]any{"weapon": data["weapon"]}
+		delete(data, "weapon")
+
+		return nil
+	})
+}
+
+func writeV1Save(t *testing.T, dir string) {
+	t.Helper()
+
+	legacy := NewSaveManager(dir)
+	save := NewSaveData("hero")
+	save.Set("gold", 42)
+	save.Set("weapon", "sword")
+
+	if err := legacy.Save("slot1", save); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+}
+
+func TestSaveMigrationChain(t *testing.T) {
+	dir := t.TempDir()
+	writeV1Save(t, dir)
+
+	sm := NewSaveManager(dir)
+	registerTestMigrations(sm)
+
+	if sm.SchemaVersion != 3 {
+		t.Fatalf("SchemaVersion = %d, want 3", sm.SchemaVersion)
+	}
+
+	save, err := sm.Load("slot1")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if save.Version != 3 {
+		t.Errorf("Version = %d, want 3", save.Version)
+	}
+
+	if got := save.GetInt("coins", 0); got != 42 {
+		t.Errorf("coins = %d, want 42", got)
+	}
+
+	if _, ok := save.Get("gold"); ok {
+		t.Error("gold should have been removed by v1->v2 migration")
+	}
+
+	equip, ok := save.Data["equipment"].(map[string]any)
+	if !ok || equip["weapon"] != "sword" {
+		t.Errorf("equipment = %v, want weapon=sword", save.Data["equipment"])
+	}
+
+	if !sm.HasBackup("slot1", 1) {
+		t.Error("expected v1 backup to be written before migrating")
+	}
+
+	// The migrated file is rewritten, so a second load needs no migration
+	reloaded, err := sm.Load("slot1")
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if reloaded.Version != 3 || reloaded.GetInt("coins", 0) != 42 {
+		t.Errorf("reloaded save = v%d %v", reloaded.Version, reloaded.Data)
+	}
+}
+
+func TestSaveMigrationMissingStep(t *testing.T) {
+	dir := t.TempDir()
+	writeV1Save(t, dir)
+
+	sm := NewSaveManager(dir)
+	sm.RegisterMigration(2, "only second step", func(map[string]any) error { return nil })
+
+	if _, err := sm.Load("slot1"); err == nil {
+		t.Fatal("expected error for missing v1->v2 migration")
+	}
+
+	if sm.HasBackup("slot1", 1) {
+		t.Error("backup should not be written when migration cannot run")
+	}
+}
+
+func TestSaveMigrationFailureLeavesDataIntact(t *testing.T) {
+	sm := NewSaveManager(t.TempDir())
+	registerTestMigrations(sm)
+	sm.RegisterMigration(3, "broken", func(data map[string]any) error {
+		data["coins"] = 0
+
+		return errors.New("boom")
+	})
+
+	save := &SaveData{Version: 1, Data: map[string]any{"gold": 7, "weapon": "axe"}}
+
+	if err := sm.Migrate(save); err == nil {
+		t.Fatal("expected migration error")
+	}
+
+	if save.Version != 1 || save.Data["gold"] != 7 {
+		t.Errorf("save mutated after failed migration: v%d %v", save.Version, save.Data)
+	}
+}
+
+func TestSaveFromFutureRejected(t *testing.T) {
+	dir := t.TempDir()
+
+	newer := NewSaveManager(dir)
+	registerTestMigrations(newer)
+
+	if err := newer.Save("slot1", NewSaveData("hero")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	older := NewSaveManager(dir)
+
+	_, err := older.Load("slot1")
+	if !errors.Is(err, ErrSaveFromFuture) {
+		t.Errorf("err = %v, want ErrSaveFromFuture", err)
+	}
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestSaveManagerFS(t *testing.T) {
+	fsys := paths.MemFS()
+	sm := NewSaveManagerFS(fsys)
+
+	save := NewSaveData("hero")
+	save.Set("coins", 7)
+
+	for _, slot := range []string{"slot1", sm.AutoSaveSlot} {
+		if err := sm.Save(slot, save); err != nil {
+			t.Fatalf("Save(%s): %v", slot, err)
+		}
+	}
+
+	if slots, err := sm.ListSaves(); err != nil || !slices.Equal(slots, []string{"autosave", "slot1"}) {
+		t.Errorf("ListSaves = %v, %v", slots, err)
+	}
+
+	loaded, err := sm.Load("slot1")
+	if err != nil || loaded.GetInt("coins", 0) != 7 {
+		t.Fatalf("Load = %v, %v", loaded, err)
+	}
+
+	if err := sm.Delete("slot1"); err != nil || sm.Exists("slot1") || fsys.Exists("slot1.json") {
+		t.Errorf("Delete: err = %v, still exists = %v", err, sm.Exists("slot1"))
+	}
+}
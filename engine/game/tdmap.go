@@ -6,6 +6,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/mlange-42/ark/ecs"
 	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/targeting"
 )
 
 // TDMap represents the tower defense map.
@@ -216,6 +217,7 @@ type Hero struct {
 	AttackRange  float64
 	AttackSpeed  float64 // Attacks per second
 	AttackTimer  float64
+	Targeting    targeting.Policy // Which monster in range to attack
 	Level        int
 	Experience   int
 	ExpToLevel   int
@@ -267,8 +269,17 @@ func (h *Hero) CanAttack(dt float64) bool {
 
 // CreateHeroEntity creates an ECS entity for a hero.
 func CreateHeroEntity(world *ecs.World, hero *Hero, x, y float64) ecs.Entity {
-	img := ebiten.NewImage(24, 24)
-	img.Fill(color.RGBA{R: 0, G: 100, B: 255, A: 255}) // Blue hero
+	return createHeroEntity(world, hero, x, y, true)
+}
+
+// createHeroEntity creates a hero, leaving its sprite without an image when
+// draw is false, as in headless games.
+func createHeroEntity(world *ecs.World, hero *Hero, x, y float64, draw bool) ecs.Entity {
+	var img *ebiten.Image
+	if draw {
+		img = ebiten.NewImage(24, 24)
+		img.Fill(heroColor)
+	}
 
 	mapper := ecs.NewMap3[components.Position, components.Sprite, components.Collider](world)
 
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// WorldSchemaVersion is the component schema of TD world snapshots. Bump it
+// when a component a TDGame world uses changes incompatibly.
+const WorldSchemaVersion = 1
+
+// heroColor fills the hero's sprite.
+var heroColor = color.RGBA{R: 0, G: 100, B: 255, A: 255}
+
+// NewWorldSerializer returns a serializer for the components of a TDGame
+// world. When draw is true, loaded sprites get a solid image of their saved
+// size in the colour of the monster type of that size, or the hero's; images
+// of one size are shared. Otherwise sprites load without images.
+func NewWorldSerializer(draw bool) *components.Serializer {
+	s := components.NewSerializer(WorldSchemaVersion)
+	components.Register[components.Position](s, "position")
+	components.Register[components.PrevPosition](s, "prev_position")
+	components.Register[components.Velocity](s, "velocity")
+	components.Register[components.Health](s, "health")
+	components.Register[components.Collider](s, "collider")
+	components.Register[components.Tag](s, "tag")
+	components.Register[components.Name](s, "name")
+	components.Register[components.Boss](s, "boss")
+
+	images := make(map[[2]int]*ebiten.Image)
+
+	components.RegisterSprite(s, "sprite", func(d components.SpriteData) *ebiten.Image {
+		if !draw || d.Width == 0 || d.Height == 0 {
+			return nil
+		}
+
+		size := [2]int{d.Width, d.Height}
+		if img, ok := images[size]; ok {
+			return img
+		}
+
+		clr := heroColor
+
+		for _, mt := range MonsterTypes {
+			if mt.Size == d.Width {
+				clr = mt.Color
+			}
+		}
+
+		img := ebiten.NewImage(d.Width, d.Height)
+		img.Fill(clr)
+		images[size] = img
+
+		return img
+	})
+
+	return s
+}
+
+// SnapshotWorld encodes the game's ECS world, e.g. for a save game or a
+// rollback point.
+func (g *TDGame) SnapshotWorld() ([]byte, error) {
+	return g.worldSerializer().Marshal(g.World)
+}
+
+// RestoreWorld replaces the game's ECS world with a snapshot from
+// SnapshotWorld. Monster state kept outside the world is not part of the
+// snapshot; monsters whose entities are gone are dropped, and entities of
+// monsters the game no longer tracks are removed.
+func (g *TDGame) RestoreWorld(data []byte) error {
+	if err := g.worldSerializer().Unmarshal(data, g.World); err != nil {
+		return err
+	}
+
+	// World.Alive can't be asked about entities created after the snapshot,
+	// whose IDs may be past the end of the restored entity pool
+	dump := g.World.Unsafe().DumpEntities()
+	alive := make(map[ecs.Entity]bool, len(dump.Alive))
+
+	for _, idx := range dump.Alive {
+		alive[dump.Entities[idx]] = true
+	}
+
+	for _, entity := range g.monstersInOrder() {
+		if !alive[entity] {
+			delete(g.ActiveMonsters, entity)
+			g.MonsterMoveSystem.RemoveMonster(entity)
+		}
+	}
+
+	var orphans []ecs.Entity
+
+	query := ecs.NewFilter1[components.Health](g.World).Query()
+	for query.Next() {
+		if entity := query.Entity(); g.ActiveMonsters[entity] == nil {
+			orphans = append(orphans, entity)
+		}
+	}
+
+	for _, entity := range orphans {
+		g.World.RemoveEntity(entity)
+	}
+
+	return nil
+}
+
+// worldSerializer returns the game's serializer, creating it on first use.
+func (g *TDGame) worldSerializer() *components.Serializer {
+	if g.serializer == nil {
+		g.serializer = NewWorldSerializer(!g.headless)
+	}
+
+	return g.serializer
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+)
+
+func TestWorldSnapshotRollsBack(t *testing.T) {
+	g := NewHeadlessTDGame()
+
+	for len(g.ActiveMonsters) < 2 {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := g.SnapshotWorld()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	positions := ecs.NewMap[components.Position](g.World)
+	want := make(map[ecs.Entity]components.Position)
+
+	for _, e := range append(g.monstersInOrder(), g.HeroEntity) {
+		want[e] = *positions.Get(e)
+	}
+
+	for range HeadlessTPS {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := g.RestoreWorld(data); err != nil {
+		t.Fatal(err)
+	}
+
+	positions = ecs.NewMap[components.Position](g.World)
+
+	// All the snapshot's entities existed then, so Alive is safe to ask
+	for e, pos := range want {
+		if !g.World.Alive(e) {
+			t.Errorf("entity %v missing after restore", e)
+
+			continue
+		}
+
+		if got := *positions.Get(e); got != pos {
+			t.Errorf("entity %v at %+v, want %+v", e, got, pos)
+		}
+	}
+
+	// Every monster the world holds is one the game tracks, and vice versa
+	query := ecs.NewFilter1[components.Health](g.World).Query()
+	n := 0
+
+	for query.Next() {
+		n++
+
+		if g.ActiveMonsters[query.Entity()] == nil {
+			t.Errorf("untracked monster %v", query.Entity())
+		}
+	}
+
+	if n != len(g.ActiveMonsters) {
+		t.Errorf("world has %d monsters, game tracks %d", n, len(g.ActiveMonsters))
+	}
+}
+
+func TestWorldSnapshotKeepsLiveBoss(t *testing.T) {
+	g := NewHeadlessTDGame()
+	g.spawnMonster("boss")
+
+	data, err := g.SnapshotWorld()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.Step(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RestoreWorld(data); err != nil {
+		t.Fatal(err)
+	}
+
+	bosses := ecs.NewFilter3[components.Tag, components.Name, components.Boss](g.World).Query()
+	n := 0
+
+	for bosses.Next() {
+		tag, name, _ := bosses.Get()
+		if tag.Name != systems.BossTag || name.Value != "Boss" || g.ActiveMonsters[bosses.Entity()] == nil {
+			t.Errorf("restored boss tag %q name %q, tracked %v", tag.Name, name.Value, g.ActiveMonsters[bosses.Entity()] != nil)
+		}
+
+		n++
+	}
+
+	if n != 1 {
+		t.Errorf("%d bosses after restore, want 1", n)
+	}
+}
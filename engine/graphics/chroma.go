@@ -3,54 +3,163 @@ package graphics
 import (
 	"image"
 	"image/color"
-	"image/draw"
 )
 
+// Common chroma key colors.
+var (
+	KeyMagenta = color.RGBA{R: 255, G: 0, B: 255, A: 255}
+	KeyGreen   = color.RGBA{R: 0, G: 255, B: 0, A: 255}
+)
+
+// ChromaKeyOptions configures background removal.
+type ChromaKeyOptions struct {
+	// Keys are the background colors to remove. If empty, the top-left pixel is used.
+	Keys []color.Color
+	// Tolerance is the max per-channel difference (0-255) at which a pixel is fully removed.
+	Tolerance float64
+	// Feather is the extra per-channel difference (0-255) over which alpha ramps back
+	// up to opaque, giving anti-aliased edges instead of a hard cut-out. 0 disables it.
+	Feather float64
+	// FloodFill only removes key-colored pixels connected to the image corners,
+	// preserving key-colored details inside the sprite.
+	FloodFill bool
+}
+
+// DefaultChromaKeyOptions returns the options used by RemoveBackground:
+// top-left pixel key, ~12% tolerance, hard edges, global replacement.
+func DefaultChromaKeyOptions() ChromaKeyOptions {
+	return ChromaKeyOptions{Tolerance: 32}
+}
+
 // RemoveBackground removes the background color from an image using Chroma Keying.
 // Supports traditional Magenta (#FF00FF) and Green (#00FF00) keys.
 // Also performs a safety check to preserve existing transparency (PNG-32).
 func RemoveBackground(src image.Image) image.Image {
+	return RemoveBackgroundWithOptions(src, DefaultChromaKeyOptions())
+}
+
+// RemoveBackgroundWithOptions removes background colors using the given options.
+// When no explicit keys are set and the top-left pixel is already transparent,
+// the image is assumed to be PNG-32 and returned unchanged.
+func RemoveBackgroundWithOptions(src image.Image, opts ChromaKeyOptions) image.Image {
 	bounds := src.Bounds()
+	if bounds.Empty() {
+		return src
+	}
 
-	// 1. Safety Check: If top-left pixel is already transparent, assume PNG-32 and skip
-	c := src.At(bounds.Min.X, bounds.Min.Y)
+	keys := opts.Keys
+	if len(keys) == 0 {
+		// Safety Check: If top-left pixel is already transparent, assume PNG-32 and skip
+		c := src.At(bounds.Min.X, bounds.Min.Y)
 
-	//nolint:dogsled // We only need alpha here to check transparency
-	_, _, _, a := c.RGBA()
-	if a < 1000 {
-		return src
+		//nolint:dogsled // We only need alpha here to check transparency
+		_, _, _, a := c.RGBA()
+		if a < 1000 {
+			return src
+		}
+
+		keys = []color.Color{c}
 	}
 
-	// 2. Use Top-Left pixel as Key Color
-	keyR, keyG, keyB, _ := c.RGBA()
+	dst := image.NewNRGBA(bounds)
+	w, h := bounds.Dx(), bounds.Dy()
 
-	const keyThreshold = 8000 // Tolerance (approx 12% or 30/255)
+	// Per-pixel alpha factor: 0 = removed, 1 = untouched
+	factors := make([]float64, w*h)
 
-	// 3. Apply Chroma Key Removal
-	dst := image.NewRGBA(bounds)
-	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+	for y := range h {
+		for x := range w {
+			c := color.NRGBAModel.Convert(src.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			dst.SetNRGBA(bounds.Min.X+x, bounds.Min.Y+y, c)
+			factors[y*w+x] = keyAlpha(c, keys, opts.Tolerance, opts.Feather)
+		}
+	}
 
-	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-		for x := bounds.Min.X; x < bounds.Max.X; x++ {
-			original := dst.At(x, y)
-			r, g, b, _ := original.RGBA()
+	if opts.FloodFill {
+		keepUnreachable(factors, w, h)
+	}
 
-			// Check if color matches key within threshold
-			if intAbs(int(r)-int(keyR)) < int(keyThreshold) &&
-				intAbs(int(g)-int(keyG)) < int(keyThreshold) &&
-				intAbs(int(b)-int(keyB)) < int(keyThreshold) {
-				dst.Set(x, y, color.Transparent)
+	for y := range h {
+		for x := range w {
+			f := factors[y*w+x]
+			if f >= 1 {
+				continue
 			}
+
+			i := dst.PixOffset(bounds.Min.X+x, bounds.Min.Y+y)
+			dst.Pix[i+3] = uint8(float64(dst.Pix[i+3]) * f)
 		}
 	}
 
 	return dst
 }
 
-func intAbs(x int) int {
-	if x < 0 {
-		return -x
+// keyAlpha returns the alpha factor for a pixel given its distance to the nearest key.
+func keyAlpha(c color.NRGBA, keys []color.Color, tolerance, feather float64) float64 {
+	dist := 256.0
+
+	for _, key := range keys {
+		k := color.NRGBAModel.Convert(key).(color.NRGBA)
+		d := max(
+			absDiff(c.R, k.R),
+			absDiff(c.G, k.G),
+			absDiff(c.B, k.B),
+		)
+		dist = min(dist, d)
+	}
+
+	switch {
+	case dist < tolerance:
+		return 0
+	case feather > 0 && dist < tolerance+feather:
+		return (dist - tolerance) / feather
+	default:
+		return 1
+	}
+}
+
+// keepUnreachable restores pixels that are not connected to a corner through
+// keyed (factor < 1) pixels, so only the outer background is removed.
+func keepUnreachable(factors []float64, w, h int) {
+	reached := make([]bool, w*h)
+	stack := make([]int, 0, 4)
+
+	for _, i := range []int{0, w - 1, (h - 1) * w, h*w - 1} {
+		if factors[i] < 1 && !reached[i] {
+			reached[i] = true
+			stack = append(stack, i)
+		}
+	}
+
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		x, y := i%w, i/w
+
+		for _, n := range [4][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+			if n[0] < 0 || n[0] >= w || n[1] < 0 || n[1] >= h {
+				continue
+			}
+
+			j := n[1]*w + n[0]
+			if !reached[j] && factors[j] < 1 {
+				reached[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+
+	for i := range factors {
+		if !reached[i] {
+			factors[i] = 1
+		}
+	}
+}
+
+func absDiff(a, b uint8) float64 {
+	if a > b {
+		return float64(a - b)
 	}
 
-	return x
+	return float64(b - a)
 }
@@ -0,0 +1,130 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+var spriteRed = color.RGBA{R: 200, G: 30, B: 30, A: 255}
+
+// newSprite returns a 10x10 sprite: bg border, a 6x6 red body, and a 2x2
+// bg-colored "eye" inside the body.
+func newSprite(bg color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+
+	for y := range 10 {
+		for x := range 10 {
+			img.Set(x, y, bg)
+
+			if x >= 2 && x < 8 && y >= 2 && y < 8 {
+				img.Set(x, y, spriteRed)
+			}
+		}
+	}
+
+	img.Set(4, 4, bg)
+	img.Set(5, 4, bg)
+
+	return img
+}
+
+func alphaAt(img image.Image, x, y int) uint8 {
+	return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA).A
+}
+
+func TestRemoveBackgroundDefault(t *testing.T) {
+	out := RemoveBackground(newSprite(KeyMagenta))
+
+	if a := alphaAt(out, 0, 0); a != 0 {
+		t.Errorf("corner alpha = %d, want 0", a)
+	}
+
+	if a := alphaAt(out, 3, 3); a != 255 {
+		t.Errorf("body alpha = %d, want 255", a)
+	}
+
+	// Default mode removes every key-colored pixel, including the eye
+	if a := alphaAt(out, 4, 4); a != 0 {
+		t.Errorf("eye alpha = %d, want 0", a)
+	}
+}
+
+func TestRemoveBackgroundSkipsTransparentImages(t *testing.T) {
+	img := newSprite(color.Transparent)
+
+	if out := RemoveBackground(img); out != image.Image(img) {
+		t.Error("already transparent image should be returned unchanged")
+	}
+}
+
+func TestRemoveBackgroundExplicitKeys(t *testing.T) {
+	img := newSprite(KeyGreen)
+	img.Set(0, 0, KeyMagenta) // Mixed keys, e.g. an atlas with two backgrounds
+
+	out := RemoveBackgroundWithOptions(img, ChromaKeyOptions{
+		Keys:      []color.Color{KeyMagenta, KeyGreen},
+		Tolerance: 10,
+	})
+
+	if alphaAt(out, 0, 0) != 0 || alphaAt(out, 9, 9) != 0 {
+		t.Error("both key colors should be removed")
+	}
+
+	if alphaAt(out, 3, 3) != 255 {
+		t.Error("sprite body should be opaque")
+	}
+}
+
+func TestRemoveBackgroundTolerance(t *testing.T) {
+	img := newSprite(KeyMagenta)
+	img.Set(9, 0, color.RGBA{R: 235, G: 20, B: 235, A: 255}) // Off-key by 20
+
+	strict := RemoveBackgroundWithOptions(img, ChromaKeyOptions{Keys: []color.Color{KeyMagenta}, Tolerance: 10})
+	loose := RemoveBackgroundWithOptions(img, ChromaKeyOptions{Keys: []color.Color{KeyMagenta}, Tolerance: 30})
+
+	if alphaAt(strict, 9, 0) != 255 {
+		t.Error("off-key pixel should survive a strict tolerance")
+	}
+
+	if alphaAt(loose, 9, 0) != 0 {
+		t.Error("off-key pixel should be removed with a loose tolerance")
+	}
+}
+
+func TestRemoveBackgroundFeather(t *testing.T) {
+	img := newSprite(KeyMagenta)
+	// Anti-aliased edge pixel halfway between key and sprite
+	img.Set(1, 5, color.RGBA{R: 228, G: 15, B: 143, A: 255})
+
+	out := RemoveBackgroundWithOptions(img, ChromaKeyOptions{
+		Keys:      []color.Color{KeyMagenta},
+		Tolerance: 20,
+		Feather:   200,
+	})
+
+	a := alphaAt(out, 1, 5)
+	if a == 0 || a == 255 {
+		t.Errorf("edge alpha = %d, want partial", a)
+	}
+
+	if alphaAt(out, 0, 5) != 0 || alphaAt(out, 3, 3) != 255 {
+		t.Error("feathering should not affect pure key or far colors")
+	}
+}
+
+func TestRemoveBackgroundFloodFill(t *testing.T) {
+	out := RemoveBackgroundWithOptions(newSprite(KeyMagenta), ChromaKeyOptions{
+		Keys:      []color.Color{KeyMagenta},
+		Tolerance: 10,
+		FloodFill: true,
+	})
+
+	if alphaAt(out, 0, 0) != 0 || alphaAt(out, 9, 5) != 0 {
+		t.Error("outer background should be removed")
+	}
+
+	if a := alphaAt(out, 4, 4); a != 255 {
+		t.Errorf("enclosed key-colored eye alpha = %d, want 255", a)
+	}
+}
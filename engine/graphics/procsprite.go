@@ -0,0 +1,329 @@
+package graphics
+
+import (
+	"hash/fnv"
+	"image"
+	"image/color"
+	"math"
+	"math/rand/v2"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/colorutil"
+)
+
+// spriteGrid is the resolution of the generated pixel art; the grid is
+// scaled up to the requested sprite size.
+const spriteGrid = 16
+
+// DefaultSpriteSize is the edge length used when SpriteOptions.Size is unset.
+const DefaultSpriteSize = 32
+
+// SpritePalette colors the layers of a generated sprite.
+type SpritePalette struct {
+	Outline color.RGBA
+	Body    color.RGBA
+	Shade   color.RGBA // Lower part of the body
+	Accent  color.RGBA // Horns, antennae, hats, and spikes
+	Eye     color.RGBA
+	Pupil   color.RGBA
+}
+
+// TintPalette is a light grey palette for sprites that are colored at draw
+// time with ColorScale, like survivor monsters.
+var TintPalette = SpritePalette{
+	Outline: color.RGBA{R: 40, G: 40, B: 40, A: 255},
+	Body:    color.RGBA{R: 235, G: 235, B: 235, A: 255},
+	Shade:   color.RGBA{R: 170, G: 170, B: 170, A: 255},
+	Accent:  color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	Eye:     color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	Pupil:   color.RGBA{R: 20, G: 20, B: 20, A: 255},
+}
+
+// PaletteFromColor builds a palette around base: a darker shade and outline
+// and an accent on the opposite side of the color wheel.
+func PaletteFromColor(base color.RGBA) SpritePalette {
+	h, s, v := colorutil.ToHSV(base)
+
+	return SpritePalette{
+		Outline: colorutil.FromHSV(h, s, v*0.25),
+		Body:    base,
+		Shade:   colorutil.FromHSV(h, s, v*0.7),
+		Accent:  colorutil.FromHSV(h+180, max(s, 0.5), max(v, 0.8)),
+		Eye:     color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Pupil:   color.RGBA{R: 20, G: 20, B: 30, A: 255},
+	}
+}
+
+// PaletteFromSeed picks a saturated body color from seed.
+func PaletteFromSeed(seed int64) SpritePalette {
+	rng := spriteRand(seed)
+
+	h, s, v := rng.Float64()*360, 0.45+rng.Float64()*0.35, 0.75+rng.Float64()*0.2
+
+	return PaletteFromColor(colorutil.FromHSV(h, s, v))
+}
+
+// SeedFromName derives a stable seed from a name, so data-defined monsters
+// and characters keep the same sprite across runs.
+func SeedFromName(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return int64(h.Sum64())
+}
+
+// SpriteOptions configures GenerateSprite.
+type SpriteOptions struct {
+	Size    int            // Edge length in pixels; 0 uses DefaultSpriteSize
+	Palette *SpritePalette // Nil derives a palette from the seed
+}
+
+// bodyKind is the silhouette a sprite is built around.
+type bodyKind int
+
+const (
+	bodyBlob bodyKind = iota
+	bodyHumanoid
+	bodyWide
+	bodyTall
+	bodyKindCount
+)
+
+// Sprite layer cell values, drawn back to front.
+const (
+	cellEmpty = iota
+	cellOutline
+	cellBody
+	cellShade
+	cellAccent
+	cellEye
+	cellPupil
+)
+
+// GenerateSprite draws a left-right symmetric creature deterministically
+// from seed: a silhouette (blob, humanoid, wide, or tall) with jittered
+// edges, shading, one to three eyes, and up to two accessories. The same
+// seed and options always produce the same image.
+func GenerateSprite(seed int64, opts SpriteOptions) *image.RGBA {
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultSpriteSize
+	}
+
+	rng := spriteRand(seed)
+
+	palette := PaletteFromSeed(seed)
+	if opts.Palette != nil {
+		palette = *opts.Palette
+	}
+
+	var grid [spriteGrid][spriteGrid]uint8
+
+	kind := bodyKind(rng.IntN(int(bodyKindCount)))
+	top, bottom := drawBody(&grid, rng, kind)
+	drawAccessories(&grid, rng, top)
+	drawEyes(&grid, rng, top, bottom)
+	outline(&grid)
+
+	colors := [...]color.RGBA{
+		cellOutline: palette.Outline,
+		cellBody:    palette.Body,
+		cellShade:   palette.Shade,
+		cellAccent:  palette.Accent,
+		cellEye:     palette.Eye,
+		cellPupil:   palette.Pupil,
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for py := range size {
+		for px := range size {
+			if c := grid[py*spriteGrid/size][px*spriteGrid/size]; c != cellEmpty {
+				img.SetRGBA(px, py, colors[c])
+			}
+		}
+	}
+
+	return img
+}
+
+func spriteRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), 0x9e3779b97f4a7c15))
+}
+
+// setMirrored fills cell (x, y) and its mirror across the vertical axis.
+func setMirrored(grid *[spriteGrid][spriteGrid]uint8, x, y int, c uint8) {
+	if x < 0 || x >= spriteGrid || y < 0 || y >= spriteGrid {
+		return
+	}
+
+	grid[y][x] = c
+	grid[y][spriteGrid-1-x] = c
+}
+
+// drawBody fills the silhouette and returns its first and last rows.
+func drawBody(grid *[spriteGrid][spriteGrid]uint8, rng *rand.Rand, kind bodyKind) (top, bottom int) {
+	const center = (spriteGrid - 1) / 2.0
+
+	rx, ry, cy := 5.0, 5.0, 8.5
+
+	switch kind {
+	case bodyBlob:
+	case bodyHumanoid:
+		rx, ry, cy = 4, 4.5, 7
+	case bodyWide:
+		rx, ry, cy = 6.5, 4, 9
+	case bodyTall:
+		rx, ry, cy = 3.5, 6, 8
+	case bodyKindCount:
+	}
+
+	rx += rng.Float64() - 0.5
+	ry += rng.Float64() - 0.5
+
+	top, bottom = spriteGrid, -1
+	jitter := 0.0
+
+	for y := range spriteGrid {
+		dy := (float64(y) + 0.5 - cy) / ry
+		if math.Abs(dy) > 1 {
+			continue
+		}
+
+		// Random walk keeps neighbouring rows similar so edges look organic
+		jitter = min(max(jitter+rng.Float64()-0.5, -1), 1)
+		half := rx*math.Sqrt(1-dy*dy) + jitter
+
+		for x := range spriteGrid / 2 {
+			if center-float64(x) <= half {
+				setMirrored(grid, x, y, cellBody)
+			}
+		}
+
+		top, bottom = min(top, y), max(bottom, y)
+	}
+
+	// Shade the lower third
+	for y := bottom - (bottom-top)/3; y <= bottom; y++ {
+		for x := range spriteGrid {
+			if grid[y][x] == cellBody {
+				grid[y][x] = cellShade
+			}
+		}
+	}
+
+	if kind == bodyHumanoid {
+		legX := 5 + rng.IntN(2)
+		for y := bottom + 1; y < min(bottom+4, spriteGrid-1); y++ {
+			setMirrored(grid, legX, y, cellShade)
+		}
+
+		bottom = min(bottom+3, spriteGrid-2)
+	}
+
+	return top, bottom
+}
+
+// drawAccessories adds up to two of horns, an antenna, a hat, and side spikes.
+func drawAccessories(grid *[spriteGrid][spriteGrid]uint8, rng *rand.Rand, top int) {
+	const accessoryCount = 4
+
+	first := rng.IntN(accessoryCount + 1) // accessoryCount means none
+	second := rng.IntN(accessoryCount + 1)
+
+	for i := range accessoryCount {
+		if i != first && i != second {
+			continue
+		}
+
+		switch i {
+		case 0: // Horns
+			for d := range 3 {
+				setMirrored(grid, 4-d, top-d, cellAccent)
+			}
+		case 1: // Antenna with a bulb
+			for y := top - 3; y < top; y++ {
+				setMirrored(grid, 7, y, cellAccent)
+			}
+
+			setMirrored(grid, 6, top-4, cellAccent)
+		case 2: // Hat band and crown
+			for x := 4; x < 8; x++ {
+				setMirrored(grid, x, top-1, cellAccent)
+			}
+
+			setMirrored(grid, 5, top-2, cellAccent)
+		case 3: // Side spikes
+			for y := top + 2; y < min(top+8, spriteGrid); y += 2 {
+				for x := range spriteGrid / 2 {
+					if grid[y][x] != cellEmpty {
+						setMirrored(grid, x-1, y, cellAccent)
+
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// drawEyes places one, two, or three eyes in the upper half of the body.
+func drawEyes(grid *[spriteGrid][spriteGrid]uint8, rng *rand.Rand, top, bottom int) {
+	y := top + max((bottom-top)/3, 1)
+	if y+1 >= spriteGrid {
+		return
+	}
+
+	eye := func(x int) {
+		setMirrored(grid, x, y, cellEye)
+		setMirrored(grid, x, y+1, cellPupil)
+	}
+
+	switch rng.IntN(3) {
+	case 0: // Cyclops
+		grid[y][7], grid[y][8] = cellEye, cellEye
+		grid[y+1][7], grid[y+1][8] = cellPupil, cellPupil
+	case 1:
+		eye(5 + rng.IntN(2))
+	default: // Two eyes and a third on the forehead
+		eye(5)
+
+		if y > top+1 {
+			grid[y-2][7], grid[y-2][8] = cellEye, cellEye
+		}
+	}
+
+	// Optional mouth two rows below the eyes
+	if my := y + 3; my < bottom && rng.IntN(2) == 0 {
+		for x := 6; x < 8; x++ {
+			setMirrored(grid, x, my, cellOutline)
+		}
+	}
+}
+
+// outline rings every filled cell with the outline color.
+func outline(grid *[spriteGrid][spriteGrid]uint8) {
+	var edges [spriteGrid][spriteGrid]bool
+
+	for y := range spriteGrid {
+		for x := range spriteGrid {
+			if grid[y][x] != cellEmpty {
+				continue
+			}
+
+			for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				nx, ny := x+d[0], y+d[1]
+				if nx >= 0 && nx < spriteGrid && ny >= 0 && ny < spriteGrid && grid[ny][nx] > cellOutline {
+					edges[y][x] = true
+				}
+			}
+		}
+	}
+
+	for y := range spriteGrid {
+		for x := range spriteGrid {
+			if edges[y][x] {
+				grid[y][x] = cellOutline
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package graphics
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+)
+
+func TestGenerateSpriteDeterministic(t *testing.T) {
+	a := GenerateSprite(42, SpriteOptions{})
+	b := GenerateSprite(42, SpriteOptions{})
+
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Error("same seed produced different sprites")
+	}
+
+	if a.Bounds().Dx() != DefaultSpriteSize || a.Bounds().Dy() != DefaultSpriteSize {
+		t.Errorf("size = %v, want %dx%d", a.Bounds(), DefaultSpriteSize, DefaultSpriteSize)
+	}
+}
+
+func TestGenerateSpriteDistinguishable(t *testing.T) {
+	palette := TintPalette
+	seen := map[string]int64{}
+
+	for seed := range int64(64) {
+		img := GenerateSprite(seed, SpriteOptions{Palette: &palette})
+
+		key := string(img.Pix)
+		if prev, ok := seen[key]; ok {
+			t.Fatalf("seeds %d and %d produced the same sprite", prev, seed)
+		}
+
+		seen[key] = seed
+
+		filled := 0
+
+		for y := range DefaultSpriteSize {
+			for x := range DefaultSpriteSize {
+				if img.RGBAAt(x, y) != img.RGBAAt(DefaultSpriteSize-1-x, y) {
+					t.Fatalf("seed %d is not symmetric at (%d, %d)", seed, x, y)
+				}
+
+				if img.RGBAAt(x, y).A > 0 {
+					filled++
+				}
+			}
+		}
+
+		if total := DefaultSpriteSize * DefaultSpriteSize; filled < total/6 || filled > total*3/4 {
+			t.Errorf("seed %d fills %d of %d pixels", seed, filled, total)
+		}
+	}
+}
+
+func TestPaletteFromColorKeepsBody(t *testing.T) {
+	base := color.RGBA{R: 200, G: 60, B: 40, A: 255}
+	p := PaletteFromColor(base)
+
+	if p.Body != base {
+		t.Errorf("body = %v, want %v", p.Body, base)
+	}
+
+	if p.Shade.R >= base.R || p.Outline.R >= p.Shade.R {
+		t.Errorf("shade %v and outline %v should darken the body", p.Shade, p.Outline)
+	}
+
+	if SeedFromName("Goblin") != SeedFromName("Goblin") || SeedFromName("Goblin") == SeedFromName("Orc") {
+		t.Error("SeedFromName is not a stable per-name seed")
+	}
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Codec encodes command logs as JSON. Each command is stored under the name
+// its type was registered with, so a log survives renaming the Go types.
+type Codec[S any] struct {
+	names map[reflect.Type]string
+	types map[string]reflect.Type
+}
+
+// entry is one encoded command.
+type entry struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// NewCodec returns a codec with no command types registered.
+func NewCodec[S any]() *Codec[S] {
+	return &Codec[S]{names: make(map[reflect.Type]string), types: make(map[string]reflect.Type)}
+}
+
+// Register names the type of cmd, a value or pointer, for encoding. Commands
+// decode as the same kind, value or pointer, as the one registered.
+func (c *Codec[S]) Register(name string, cmd Command[S]) {
+	t := reflect.TypeOf(cmd)
+	c.names[t] = name
+	c.types[name] = t
+}
+
+// Marshal encodes cmds. It fails on a command whose type is not registered.
+func (c *Codec[S]) Marshal(cmds []Command[S]) ([]byte, error) {
+	entries := make([]entry, 0, len(cmds))
+
+	for _, cmd := range cmds {
+		name, ok := c.names[reflect.TypeOf(cmd)]
+		if !ok {
+			return nil, fmt.Errorf("history: unregistered command type %T", cmd)
+		}
+
+		data, err := json.Marshal(cmd)
+		if err != nil {
+			return nil, fmt.Errorf("history: encoding %s: %w", name, err)
+		}
+
+		entries = append(entries, entry{Type: name, Data: data})
+	}
+
+	return json.Marshal(entries)
+}
+
+// Unmarshal decodes a log written by Marshal.
+func (c *Codec[S]) Unmarshal(data []byte) ([]Command[S], error) {
+	var entries []entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+
+	cmds := make([]Command[S], 0, len(entries))
+
+	for _, e := range entries {
+		t, ok := c.types[e.Type]
+		if !ok {
+			return nil, fmt.Errorf("history: unknown command type %q", e.Type)
+		}
+
+		// Decode into a new value of the registered type, through a pointer
+		ptr := t
+		if t.Kind() == reflect.Pointer {
+			ptr = t.Elem()
+		}
+
+		v := reflect.New(ptr)
+		if err := json.Unmarshal(e.Data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("history: decoding %s: %w", e.Type, err)
+		}
+
+		if t.Kind() != reflect.Pointer {
+			v = v.Elem()
+		}
+
+		cmds = append(cmds, v.Interface().(Command[S]))
+	}
+
+	return cmds, nil
+}
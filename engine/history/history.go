@@ -0,0 +1,124 @@
+// Package history keeps a game's moves as a log of reversible commands, so
+// puzzle games get undo, redo, and a replayable move history without
+// snapshotting their whole state on every move.
+//
+// A command changes a state S and knows how to change it back. History
+// applies commands, keeps the most recent ones up to a limit, and walks back
+// and forth through them; Codec turns the log into JSON for save files and
+// replays.
+package history
+
+// Command is a reversible change to a state S. Revert must exactly undo
+// Apply, so that applying and reverting any run of commands in order leaves
+// the state as it was.
+type Command[S any] interface {
+	Apply(state S)
+	Revert(state S)
+}
+
+// History is a bounded undo/redo log of the commands applied to one state.
+// It is not safe for concurrent use.
+type History[S any] struct {
+	// Limit is the most commands kept for undo; the oldest are dropped
+	// beyond it. 0 keeps every command.
+	Limit int
+
+	state  S
+	done   []Command[S] // Applied, oldest first
+	undone []Command[S] // Reverted, most recently reverted last
+}
+
+// New returns an empty history of the commands applied to state, keeping up
+// to limit of them for undo.
+func New[S any](state S, limit int) *History[S] {
+	return &History[S]{Limit: limit, state: state}
+}
+
+// Do applies c to the state and records it. Anything undone is no longer
+// redoable.
+func (h *History[S]) Do(c Command[S]) {
+	c.Apply(h.state)
+	h.Push(c)
+}
+
+// Push records c as already applied, for moves the game resolves itself,
+// e.g. with random tile spawns, and then describes as a command.
+func (h *History[S]) Push(c Command[S]) {
+	h.done = append(h.done, c)
+	h.undone = h.undone[:0]
+
+	if h.Limit > 0 && len(h.done) > h.Limit {
+		drop := len(h.done) - h.Limit
+		clear(h.done[:drop])
+		h.done = h.done[drop:]
+	}
+}
+
+// Undo reverts the last applied command. It returns false if there is none.
+func (h *History[S]) Undo() bool {
+	if len(h.done) == 0 {
+		return false
+	}
+
+	c := h.done[len(h.done)-1]
+	h.done[len(h.done)-1] = nil
+	h.done = h.done[:len(h.done)-1]
+
+	c.Revert(h.state)
+	h.undone = append(h.undone, c)
+
+	return true
+}
+
+// Redo applies the last undone command again. It returns false if there is
+// none.
+func (h *History[S]) Redo() bool {
+	if len(h.undone) == 0 {
+		return false
+	}
+
+	c := h.undone[len(h.undone)-1]
+	h.undone[len(h.undone)-1] = nil
+	h.undone = h.undone[:len(h.undone)-1]
+
+	c.Apply(h.state)
+	h.done = append(h.done, c)
+
+	return true
+}
+
+// CanUndo reports whether Undo has a command to revert.
+func (h *History[S]) CanUndo() bool {
+	return len(h.done) > 0
+}
+
+// CanRedo reports whether Redo has a command to apply.
+func (h *History[S]) CanRedo() bool {
+	return len(h.undone) > 0
+}
+
+// Len returns the number of commands that can be undone.
+func (h *History[S]) Len() int {
+	return len(h.done)
+}
+
+// Done returns the applied commands, oldest first. The slice is the
+// history's own and must not be modified.
+func (h *History[S]) Done() []Command[S] {
+	return h.done
+}
+
+// Clear forgets every command, e.g. when a new game starts.
+func (h *History[S]) Clear() {
+	clear(h.done)
+	clear(h.undone)
+	h.done, h.undone = h.done[:0], h.undone[:0]
+}
+
+// Replay applies cmds to the state in order and records them, e.g. to
+// rebuild a game from its starting state and a saved move log.
+func (h *History[S]) Replay(cmds []Command[S]) {
+	for _, c := range cmds {
+		h.Do(c)
+	}
+}
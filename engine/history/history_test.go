@@ -0,0 +1,118 @@
+package history
+
+import (
+	"strings"
+	"testing"
+)
+
+type counter struct{ n int }
+
+type add struct{ By int }
+
+func (a add) Apply(c *counter)  { c.n += a.By }
+func (a add) Revert(c *counter) { c.n -= a.By }
+
+type double struct{}
+
+func (*double) Apply(c *counter)  { c.n *= 2 }
+func (*double) Revert(c *counter) { c.n /= 2 }
+
+func TestUndoRedo(t *testing.T) {
+	c := &counter{}
+	h := New(c, 0)
+
+	h.Do(add{By: 3})
+	h.Do(&double{})
+	h.Do(add{By: 1})
+
+	if c.n != 7 || h.Len() != 3 {
+		t.Fatalf("n = %d, len = %d", c.n, h.Len())
+	}
+
+	h.Undo()
+	h.Undo()
+
+	if c.n != 3 || !h.CanRedo() {
+		t.Fatalf("after two undos n = %d", c.n)
+	}
+
+	h.Redo()
+
+	if c.n != 6 {
+		t.Errorf("after redo n = %d, want 6", c.n)
+	}
+
+	// A new command drops the redo branch
+	h.Do(add{By: 10})
+
+	if h.CanRedo() || h.Redo() {
+		t.Error("redo should be gone after a new command")
+	}
+
+	for h.Undo() {
+	}
+
+	if c.n != 0 || h.Undo() {
+		t.Errorf("undoing everything left n = %d", c.n)
+	}
+}
+
+func TestLimitDropsOldest(t *testing.T) {
+	c := &counter{}
+	h := New(c, 2)
+
+	for i := 1; i <= 4; i++ {
+		h.Do(add{By: i})
+	}
+
+	if h.Len() != 2 {
+		t.Fatalf("len = %d, want 2", h.Len())
+	}
+
+	h.Undo()
+	h.Undo()
+
+	// Only the last two can be undone
+	if c.n != 3 || h.Undo() {
+		t.Errorf("n = %d, want 3 with nothing left to undo", c.n)
+	}
+}
+
+func TestCodecReplays(t *testing.T) {
+	codec := NewCodec[*counter]()
+	codec.Register("add", add{})
+	codec.Register("double", &double{})
+
+	c := &counter{}
+	h := New(c, 0)
+	h.Do(add{By: 2})
+	h.Do(&double{})
+	h.Do(add{By: 5})
+
+	data, err := codec.Marshal(h.Done())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmds, err := codec.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := &counter{}
+	New(replayed, 0).Replay(cmds)
+
+	if replayed.n != c.n {
+		t.Errorf("replayed n = %d, want %d from %s", replayed.n, c.n, data)
+	}
+
+	if _, err := codec.Unmarshal([]byte(`[{"type":"nope","data":{}}]`)); err == nil ||
+		!strings.Contains(err.Error(), "nope") {
+		t.Errorf("unknown type err = %v", err)
+	}
+
+	type unregistered struct{ add }
+	if _, err := codec.Marshal([]Command[*counter]{unregistered{}}); err == nil {
+		t.Error("marshaled an unregistered command")
+	}
+}
@@ -0,0 +1,331 @@
+package input
+
+import (
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// DefaultDeadzone is how far a stick must be pushed before it counts, when
+// Map.Deadzone is unset.
+const DefaultDeadzone = 0.25
+
+// stepThreshold is how far a stick must be pushed to count as one step for
+// JustMoved.
+const stepThreshold = 0.5
+
+// Action is a button-like game input: pressed or not.
+type Action int
+
+const (
+	Confirm Action = iota // Accept a menu choice
+	Cancel                // Back out of a menu
+	Pause                 // Open or close the pause menu
+
+	// FirstCustom is the first action free for a game's own buttons, e.g.
+	// const Jump = input.FirstCustom.
+	FirstCustom
+)
+
+// Axis is a direction input from -1 to 1.
+type Axis int
+
+const (
+	MoveX Axis = iota // Negative is left
+	MoveY             // Negative is up, as on screen
+)
+
+// Binding lists the keys, mouse buttons, and standard-layout gamepad buttons
+// that trigger an action. Any one of them is enough.
+type Binding struct {
+	Keys    []ebiten.Key                   `json:"keys,omitempty"`
+	Mouse   []ebiten.MouseButton           `json:"mouse,omitempty"`
+	Buttons []ebiten.StandardGamepadButton `json:"buttons,omitempty"`
+}
+
+// AxisBinding lists what drives an axis: buttons for each direction, which
+// push it fully, and analog sticks, which push it by their deflection.
+type AxisBinding struct {
+	Negative, Positive Binding
+	Sticks             []ebiten.StandardGamepadAxis
+}
+
+// DefaultBindings returns the bindings a new Map starts with: WASD, the
+// arrows, the d-pad, and the left stick move; Enter, Space, and the bottom
+// face button confirm; Escape, Backspace, and the right face button cancel;
+// Escape, P, and Start pause.
+func DefaultBindings() (map[Action]Binding, map[Axis]AxisBinding) {
+	actions := map[Action]Binding{
+		Confirm: {
+			Keys:    []ebiten.Key{ebiten.KeyEnter, ebiten.KeySpace},
+			Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+		},
+		Cancel: {
+			Keys:    []ebiten.Key{ebiten.KeyEscape, ebiten.KeyBackspace},
+			Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightRight},
+		},
+		Pause: {
+			Keys:    []ebiten.Key{ebiten.KeyEscape, ebiten.KeyP},
+			Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight},
+		},
+	}
+
+	axes := map[Axis]AxisBinding{
+		MoveX: {
+			Negative: Binding{
+				Keys:    []ebiten.Key{ebiten.KeyA, ebiten.KeyLeft},
+				Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft},
+			},
+			Positive: Binding{
+				Keys:    []ebiten.Key{ebiten.KeyD, ebiten.KeyRight},
+				Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight},
+			},
+			Sticks: []ebiten.StandardGamepadAxis{ebiten.StandardGamepadAxisLeftStickHorizontal},
+		},
+		MoveY: {
+			Negative: Binding{
+				Keys:    []ebiten.Key{ebiten.KeyW, ebiten.KeyUp},
+				Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop},
+			},
+			Positive: Binding{
+				Keys:    []ebiten.Key{ebiten.KeyS, ebiten.KeyDown},
+				Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftBottom},
+			},
+			Sticks: []ebiten.StandardGamepadAxis{ebiten.StandardGamepadAxisLeftStickVertical},
+		},
+	}
+
+	return actions, axes
+}
+
+// Map turns keyboard, mouse, and gamepad input into game actions and axes,
+// so games ask "did the player confirm" instead of checking each key and
+// controller. Every connected gamepad with a standard layout drives the
+// same actions. Key and mouse presses are read through the Default queue.
+type Map struct {
+	Deadzone float64 // Stick deflection ignored as drift; 0 uses DefaultDeadzone
+
+	actions map[Action]Binding
+	axes    map[Axis]AxisBinding
+
+	// What Reset restores; see SetDefaults
+	defaultActions map[Action]Binding
+	defaultAxes    map[Axis]AxisBinding
+
+	// Stick step directions for JustMoved, at the current and previous tick
+	tick           int64
+	steps, prevDir map[Axis]int
+
+	// Platform hooks, replaced in tests
+	keyDown   func(ebiten.Key) bool
+	keyJust   func(ebiten.Key) bool
+	mouseDown func(ebiten.MouseButton) bool
+	mouseJust func(ebiten.MouseButton) bool
+	gamepads  func([]ebiten.GamepadID) []ebiten.GamepadID
+	padDown   func(ebiten.GamepadID, ebiten.StandardGamepadButton) bool
+	padJust   func(ebiten.GamepadID, ebiten.StandardGamepadButton) bool
+	padAxis   func(ebiten.GamepadID, ebiten.StandardGamepadAxis) float64
+	now       func() int64
+}
+
+// NewMap returns a map with the DefaultBindings.
+func NewMap() *Map {
+	m := &Map{
+		keyDown:   ebiten.IsKeyPressed,
+		keyJust:   IsKeyJustPressed,
+		mouseDown: ebiten.IsMouseButtonPressed,
+		mouseJust: IsMouseButtonJustPressed,
+		gamepads:  ebiten.AppendGamepadIDs,
+		padDown:   ebiten.IsStandardGamepadButtonPressed,
+		padJust:   inpututil.IsStandardGamepadButtonJustPressed,
+		padAxis:   ebiten.StandardGamepadAxisValue,
+		now:       ebiten.Tick,
+	}
+	m.defaultActions, m.defaultAxes = DefaultBindings()
+	m.Reset()
+
+	return m
+}
+
+// Reset restores the default bindings: the DefaultBindings, or those
+// captured by the last SetDefaults.
+func (m *Map) Reset() {
+	m.actions, m.axes = maps.Clone(m.defaultActions), maps.Clone(m.defaultAxes)
+	m.tick = -1
+	m.steps = make(map[Axis]int)
+	m.prevDir = make(map[Axis]int)
+}
+
+// SetDefaults makes the current bindings the ones Reset restores. Games call
+// it after binding their own actions, before loading the player's changes.
+func (m *Map) SetDefaults() {
+	m.defaultActions, m.defaultAxes = maps.Clone(m.actions), maps.Clone(m.axes)
+}
+
+// Bind sets what triggers a, replacing its previous binding.
+func (m *Map) Bind(a Action, b Binding) {
+	m.actions[a] = b
+}
+
+// BindAxis sets what drives ax, replacing its previous binding.
+func (m *Map) BindAxis(ax Axis, b AxisBinding) {
+	m.axes[ax] = b
+}
+
+// Binding returns what triggers a, e.g. to extend it before calling Bind.
+func (m *Map) Binding(a Action) Binding {
+	return m.actions[a]
+}
+
+// Pressed reports whether anything bound to a is held.
+func (m *Map) Pressed(a Action) bool {
+	return m.down(m.actions[a])
+}
+
+// JustPressed reports whether anything bound to a went down this tick.
+func (m *Map) JustPressed(a Action) bool {
+	return m.just(m.actions[a])
+}
+
+// Axis returns ax from -1 to 1. Held buttons push it fully; otherwise the
+// most deflected stick past the deadzone sets it, rescaled so the deadzone
+// edge reads 0.
+func (m *Map) Axis(ax Axis) float64 {
+	b := m.axes[ax]
+
+	v := 0.0
+	if m.down(b.Negative) {
+		v--
+	}
+
+	if m.down(b.Positive) {
+		v++
+	}
+
+	if v != 0 {
+		return v
+	}
+
+	return m.stick(b)
+}
+
+// Move returns MoveX and MoveY, scaled down to length 1 when pushed
+// diagonally so diagonal movement is no faster.
+func (m *Map) Move() (x, y float64) {
+	x, y = m.Axis(MoveX), m.Axis(MoveY)
+
+	if l := math.Hypot(x, y); l > 1 {
+		x, y = x/l, y/l
+	}
+
+	return x, y
+}
+
+// JustMoved returns -1 or 1 on the tick ax is first pushed that way, by a
+// bound button or by a stick crossing halfway, and 0 otherwise. Use it for
+// grid steps and menu cursors.
+func (m *Map) JustMoved(ax Axis) int {
+	b := m.axes[ax]
+
+	switch {
+	case m.just(b.Negative):
+		return -1
+	case m.just(b.Positive):
+		return 1
+	}
+
+	m.sync()
+
+	if dir := m.steps[ax]; dir != m.prevDir[ax] {
+		return dir
+	}
+
+	return 0
+}
+
+// sync samples stick steps once per tick, keeping the previous tick's.
+func (m *Map) sync() {
+	if t := m.now(); t != m.tick {
+		for ax, b := range m.axes {
+			v := m.stick(b)
+
+			dir := 0
+			if v <= -stepThreshold {
+				dir = -1
+			} else if v >= stepThreshold {
+				dir = 1
+			}
+
+			if t == m.tick+1 {
+				m.prevDir[ax] = m.steps[ax]
+			} else {
+				m.prevDir[ax] = dir // Not read last tick; don't report a held stick as new
+			}
+
+			m.steps[ax] = dir
+		}
+
+		m.tick = t
+	}
+}
+
+// stick returns the most deflected bound stick past the deadzone.
+func (m *Map) stick(b AxisBinding) float64 {
+	dead := m.Deadzone
+	if dead <= 0 {
+		dead = DefaultDeadzone
+	}
+
+	v := 0.0
+
+	for _, id := range m.gamepads(nil) {
+		for _, s := range b.Sticks {
+			if a := m.padAxis(id, s); math.Abs(a) > math.Abs(v) {
+				v = a
+			}
+		}
+	}
+
+	if math.Abs(v) < dead {
+		return 0
+	}
+
+	return math.Copysign((math.Abs(v)-dead)/(1-dead), v)
+}
+
+// down reports whether anything in b is held.
+func (m *Map) down(b Binding) bool {
+	if slices.ContainsFunc(b.Keys, m.keyDown) || slices.ContainsFunc(b.Mouse, m.mouseDown) {
+		return true
+	}
+
+	for _, id := range m.gamepads(nil) {
+		for _, btn := range b.Buttons {
+			if m.padDown(id, btn) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// just reports whether anything in b went down this tick.
+func (m *Map) just(b Binding) bool {
+	if slices.ContainsFunc(b.Keys, m.keyJust) || slices.ContainsFunc(b.Mouse, m.mouseJust) {
+		return true
+	}
+
+	for _, id := range m.gamepads(nil) {
+		for _, btn := range b.Buttons {
+			if m.padJust(id, btn) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,157 @@
+package input
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// fakePad is one standard-layout gamepad driven by the test.
+type fakePad struct {
+	down, just map[ebiten.StandardGamepadButton]bool
+	axes       map[ebiten.StandardGamepadAxis]float64
+}
+
+// actionMap returns a map reading dev's keyboard and mouse and pad, if any.
+func (f *fakeDevices) actionMap(pad *fakePad) *Map {
+	q := f.queue()
+	m := NewMap()
+	m.keyDown = func(k ebiten.Key) bool { return slices.Contains(f.keys, k) }
+	m.keyJust = q.IsKeyJustPressed
+	m.mouseDown = func(b ebiten.MouseButton) bool { return f.buttons[b] }
+	m.mouseJust = q.IsMouseButtonJustPressed
+	m.now = func() int64 { return f.tick }
+	m.gamepads = func(ids []ebiten.GamepadID) []ebiten.GamepadID {
+		if pad == nil {
+			return ids
+		}
+
+		return append(ids, 0)
+	}
+	m.padDown = func(_ ebiten.GamepadID, b ebiten.StandardGamepadButton) bool { return pad.down[b] }
+	m.padJust = func(_ ebiten.GamepadID, b ebiten.StandardGamepadButton) bool { return pad.just[b] }
+	m.padAxis = func(_ ebiten.GamepadID, a ebiten.StandardGamepadAxis) float64 { return pad.axes[a] }
+
+	return m
+}
+
+func newFakePad() *fakePad {
+	return &fakePad{
+		down: make(map[ebiten.StandardGamepadButton]bool),
+		just: make(map[ebiten.StandardGamepadButton]bool),
+		axes: make(map[ebiten.StandardGamepadAxis]float64),
+	}
+}
+
+func TestMapActionsFromKeysAndPad(t *testing.T) {
+	var dev fakeDevices
+
+	pad := newFakePad()
+	m := dev.actionMap(pad)
+
+	dev.keys = []ebiten.Key{ebiten.KeyEnter}
+	dev.tick++
+
+	if !m.Pressed(Confirm) || !m.JustPressed(Confirm) || m.Pressed(Cancel) {
+		t.Error("Enter should confirm")
+	}
+
+	dev.tick++
+
+	if !m.Pressed(Confirm) || m.JustPressed(Confirm) {
+		t.Error("a held Enter is pressed but not just pressed")
+	}
+
+	dev.keys = nil
+	pad.down[ebiten.StandardGamepadButtonCenterRight] = true
+	pad.just[ebiten.StandardGamepadButtonCenterRight] = true
+	dev.tick++
+
+	if !m.JustPressed(Pause) || m.Pressed(Confirm) {
+		t.Error("Start should pause")
+	}
+
+	// Custom actions, and rebinding
+	const jump = FirstCustom
+	m.Bind(jump, Binding{Mouse: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+
+	dev.buttons[ebiten.MouseButtonLeft] = true
+	dev.tick++
+
+	if !m.JustPressed(jump) {
+		t.Error("click should jump")
+	}
+}
+
+func TestMapAxes(t *testing.T) {
+	var dev fakeDevices
+
+	pad := newFakePad()
+	m := dev.actionMap(pad)
+
+	dev.keys = []ebiten.Key{ebiten.KeyA, ebiten.KeyDown}
+	if x, y := m.Move(); x > -0.7 || x < -0.71 || y < 0.7 || y > 0.71 {
+		t.Errorf("A+Down move = (%v, %v), want a unit diagonal", x, y)
+	}
+
+	dev.keys = nil
+
+	// Drift inside the deadzone reads as nothing; past it, rescaled
+	pad.axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = 0.2
+	if v := m.Axis(MoveX); v != 0 {
+		t.Errorf("drift reads %v", v)
+	}
+
+	pad.axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = 1
+	if v := m.Axis(MoveX); v != 1 {
+		t.Errorf("full stick reads %v", v)
+	}
+
+	// Held buttons win over the stick
+	pad.down[ebiten.StandardGamepadButtonLeftLeft] = true
+	if v := m.Axis(MoveX); v != -1 {
+		t.Errorf("d-pad left with stick right reads %v", v)
+	}
+}
+
+func TestMapJustMovedSteps(t *testing.T) {
+	var dev fakeDevices
+
+	pad := newFakePad()
+	m := dev.actionMap(pad)
+	stick := ebiten.StandardGamepadAxisLeftStickVertical
+
+	steps := func(v float64) int {
+		dev.tick++
+		pad.axes[stick] = v
+
+		return m.JustMoved(MoveY)
+	}
+
+	if s := steps(0); s != 0 {
+		t.Errorf("resting stick stepped %d", s)
+	}
+
+	// One step per push, however long it is held
+	if s := steps(0.9); s != 1 {
+		t.Errorf("push down stepped %d", s)
+	}
+
+	if s := steps(0.9); s != 0 {
+		t.Errorf("held stick stepped again: %d", s)
+	}
+
+	if s := steps(-0.8); s != -1 {
+		t.Errorf("flick up stepped %d", s)
+	}
+
+	// Keys step on their press
+	pad.axes[stick] = 0
+	dev.keys = []ebiten.Key{ebiten.KeyS}
+	dev.tick++
+
+	if s := m.JustMoved(MoveY); s != 1 {
+		t.Errorf("S stepped %d", s)
+	}
+}
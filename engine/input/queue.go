@@ -0,0 +1,187 @@
+package input
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// EventKind is whether an input event is a press or a release.
+type EventKind int
+
+const (
+	Press EventKind = iota
+	Release
+)
+
+// Event is a key or mouse button edge.
+type Event struct {
+	Kind   EventKind
+	Key    ebiten.Key
+	Button ebiten.MouseButton
+	Mouse  bool // Button is set instead of Key
+	X, Y   int  // Cursor position when the edge was seen
+}
+
+// queuedButtons are the mouse buttons a Queue tracks.
+var queuedButtons = []ebiten.MouseButton{
+	ebiten.MouseButtonLeft,
+	ebiten.MouseButtonRight,
+	ebiten.MouseButtonMiddle,
+}
+
+// Queue captures key and mouse button edges whenever it is polled, not only
+// on Update ticks, and delivers each to exactly one tick. Polling from Draw
+// as well catches taps that start and end between two ticks at low TPS, and
+// a press is never reported on more than one tick however often Update runs.
+type Queue struct {
+	keys    []ebiten.Key // Held at the last poll
+	buttons map[ebiten.MouseButton]bool
+	pending []Event // Seen since the last tick
+	current []Event // Delivered to this tick
+	tick    int64
+
+	// Platform hooks, replaced in tests
+	pressedKeys func([]ebiten.Key) []ebiten.Key
+	buttonDown  func(ebiten.MouseButton) bool
+	cursor      func() (int, int)
+	now         func() int64
+}
+
+// Default is the queue read by the package-level helpers.
+var Default = NewQueue()
+
+// NewQueue creates a queue reading the live keyboard and mouse.
+func NewQueue() *Queue {
+	return newQueue(inpututil.AppendPressedKeys, ebiten.IsMouseButtonPressed, ebiten.CursorPosition, ebiten.Tick)
+}
+
+func newQueue(
+	pressedKeys func([]ebiten.Key) []ebiten.Key,
+	buttonDown func(ebiten.MouseButton) bool,
+	cursor func() (int, int),
+	now func() int64,
+) *Queue {
+	return &Queue{
+		buttons:     make(map[ebiten.MouseButton]bool),
+		tick:        -1,
+		pressedKeys: pressedKeys,
+		buttonDown:  buttonDown,
+		cursor:      cursor,
+		now:         now,
+	}
+}
+
+// Poll samples the keyboard and mouse and queues any edges since the last
+// poll for the next tick. Call it from Draw; ticks poll on their own.
+func (q *Queue) Poll() {
+	x, y := q.cursor()
+	keys := q.pressedKeys(nil)
+
+	for _, k := range keys {
+		if !slices.Contains(q.keys, k) {
+			q.pending = append(q.pending, Event{Kind: Press, Key: k, X: x, Y: y})
+		}
+	}
+
+	for _, k := range q.keys {
+		if !slices.Contains(keys, k) {
+			q.pending = append(q.pending, Event{Kind: Release, Key: k, X: x, Y: y})
+		}
+	}
+
+	q.keys = keys
+
+	for _, b := range queuedButtons {
+		down := q.buttonDown(b)
+		if down == q.buttons[b] {
+			continue
+		}
+
+		kind := Press
+		if !down {
+			kind = Release
+		}
+
+		q.buttons[b] = down
+		q.pending = append(q.pending, Event{Kind: kind, Button: b, Mouse: true, X: x, Y: y})
+	}
+}
+
+// sync delivers the pending events on the first read of a new tick.
+func (q *Queue) sync() {
+	if t := q.now(); t != q.tick {
+		q.Poll()
+		q.current = append(q.current[:0], q.pending...)
+		q.pending = q.pending[:0]
+		q.tick = t
+	}
+}
+
+// Events returns the edges delivered to the current tick, oldest first.
+func (q *Queue) Events() []Event {
+	q.sync()
+
+	return q.current
+}
+
+// has reports whether the current tick has an event matching ok.
+func (q *Queue) has(ok func(Event) bool) bool {
+	return slices.ContainsFunc(q.Events(), ok)
+}
+
+// IsKeyJustPressed reports whether key went down since the previous tick,
+// even if it was released again before this one.
+func (q *Queue) IsKeyJustPressed(key ebiten.Key) bool {
+	return q.has(func(e Event) bool { return !e.Mouse && e.Kind == Press && e.Key == key })
+}
+
+// IsKeyJustReleased reports whether key went up since the previous tick.
+func (q *Queue) IsKeyJustReleased(key ebiten.Key) bool {
+	return q.has(func(e Event) bool { return !e.Mouse && e.Kind == Release && e.Key == key })
+}
+
+// IsMouseButtonJustPressed reports whether button went down since the
+// previous tick.
+func (q *Queue) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return q.has(func(e Event) bool { return e.Mouse && e.Kind == Press && e.Button == button })
+}
+
+// IsMouseButtonJustReleased reports whether button went up since the
+// previous tick.
+func (q *Queue) IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	return q.has(func(e Event) bool { return e.Mouse && e.Kind == Release && e.Button == button })
+}
+
+// Click returns where button was last pressed since the previous tick.
+func (q *Queue) Click(button ebiten.MouseButton) (x, y int, ok bool) {
+	events := q.Events()
+	for i := len(events) - 1; i >= 0; i-- {
+		if e := events[i]; e.Mouse && e.Kind == Press && e.Button == button {
+			return e.X, e.Y, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// IsKeyJustPressed reports whether key went down since the previous tick on
+// the Default queue.
+func IsKeyJustPressed(key ebiten.Key) bool { return Default.IsKeyJustPressed(key) }
+
+// IsKeyJustReleased reports whether key went up since the previous tick on
+// the Default queue.
+func IsKeyJustReleased(key ebiten.Key) bool { return Default.IsKeyJustReleased(key) }
+
+// IsMouseButtonJustPressed reports whether button went down since the
+// previous tick on the Default queue.
+func IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return Default.IsMouseButtonJustPressed(button)
+}
+
+// IsMouseButtonJustReleased reports whether button went up since the
+// previous tick on the Default queue.
+func IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	return Default.IsMouseButtonJustReleased(button)
+}
@@ -0,0 +1,127 @@
+package input
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// fakeDevices is a keyboard, mouse, and tick counter driven by the test.
+type fakeDevices struct {
+	keys    []ebiten.Key
+	buttons map[ebiten.MouseButton]bool
+	x, y    int
+	tick    int64
+}
+
+func (f *fakeDevices) queue() *Queue {
+	f.buttons = make(map[ebiten.MouseButton]bool)
+
+	return newQueue(
+		func(keys []ebiten.Key) []ebiten.Key { return append(keys, f.keys...) },
+		func(b ebiten.MouseButton) bool { return f.buttons[b] },
+		func() (int, int) { return f.x, f.y },
+		func() int64 { return f.tick },
+	)
+}
+
+func TestQueueCatchesTapBetweenTicks(t *testing.T) {
+	var dev fakeDevices
+
+	q := dev.queue()
+	q.Events()
+
+	// Pressed and released between two ticks, seen only by Draw polls
+	dev.keys = []ebiten.Key{ebiten.KeySpace}
+	q.Poll()
+
+	dev.keys = nil
+	q.Poll()
+
+	dev.tick++
+
+	if !q.IsKeyJustPressed(ebiten.KeySpace) || !q.IsKeyJustReleased(ebiten.KeySpace) {
+		t.Errorf("events %v, want the tap pressed and released", q.Events())
+	}
+
+	// Reads within a tick agree; the next tick has nothing new
+	if !q.IsKeyJustPressed(ebiten.KeySpace) {
+		t.Error("second read in the same tick lost the press")
+	}
+
+	dev.tick++
+
+	if q.IsKeyJustPressed(ebiten.KeySpace) {
+		t.Error("press delivered on two ticks")
+	}
+}
+
+func TestQueueHeldKeyPressesOnce(t *testing.T) {
+	var dev fakeDevices
+
+	q := dev.queue()
+	dev.keys = []ebiten.Key{ebiten.KeyEscape}
+
+	presses := 0
+
+	// Several polls per tick, as at a low TPS, then several ticks with no poll
+	// between, as when ticks outpace frames
+	for range 4 {
+		q.Poll()
+		q.Poll()
+		dev.tick++
+
+		if q.IsKeyJustPressed(ebiten.KeyEscape) {
+			presses++
+		}
+	}
+
+	for range 4 {
+		dev.tick++
+
+		if q.IsKeyJustPressed(ebiten.KeyEscape) {
+			presses++
+		}
+	}
+
+	if presses != 1 {
+		t.Errorf("held key reported %d presses, want 1", presses)
+	}
+}
+
+func TestQueueClickKeepsPressPosition(t *testing.T) {
+	var dev fakeDevices
+
+	q := dev.queue()
+	q.Events()
+
+	dev.x, dev.y = 40, 30
+	dev.buttons[ebiten.MouseButtonLeft] = true
+	q.Poll()
+
+	// The cursor moves on before the tick reads the click
+	dev.x, dev.y = 90, 80
+	dev.tick++
+
+	x, y, ok := q.Click(ebiten.MouseButtonLeft)
+	if !ok || x != 40 || y != 30 {
+		t.Errorf("click at (%d, %d) ok=%v, want (40, 30)", x, y, ok)
+	}
+
+	if _, _, ok := q.Click(ebiten.MouseButtonRight); ok {
+		t.Error("right button clicked")
+	}
+
+	dev.buttons[ebiten.MouseButtonLeft] = false
+	dev.tick++
+
+	kinds := make([]EventKind, 0, 1)
+	for _, e := range q.Events() {
+		kinds = append(kinds, e.Kind)
+	}
+
+	if !slices.Equal(kinds, []EventKind{Release}) || !q.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		t.Errorf("events after letting go = %v, want one release", q.Events())
+	}
+}
@@ -0,0 +1,177 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// Control is one input as players rebind it: an action, or one direction of
+// an axis, e.g. "Move Left" is the negative direction of MoveX.
+type Control struct {
+	Name   string // Shown on rebinding screens and used as the save key
+	Action Action
+	Axis   Axis
+	Dir    int // -1 or 1 binds that direction of Axis; 0 binds Action
+}
+
+// Controls for the default movement axes.
+var (
+	MoveUp    = Control{Name: "Move Up", Axis: MoveY, Dir: -1}
+	MoveDown  = Control{Name: "Move Down", Axis: MoveY, Dir: 1}
+	MoveLeft  = Control{Name: "Move Left", Axis: MoveX, Dir: -1}
+	MoveRight = Control{Name: "Move Right", Axis: MoveX, Dir: 1}
+)
+
+// ControlBinding returns what triggers c.
+func (m *Map) ControlBinding(c Control) Binding {
+	return controlBinding(m.actions, m.axes, c)
+}
+
+// DefaultBinding returns what triggers c after a Reset.
+func (m *Map) DefaultBinding(c Control) Binding {
+	return controlBinding(m.defaultActions, m.defaultAxes, c)
+}
+
+func controlBinding(actions map[Action]Binding, axes map[Axis]AxisBinding, c Control) Binding {
+	switch {
+	case c.Dir < 0:
+		return axes[c.Axis].Negative
+	case c.Dir > 0:
+		return axes[c.Axis].Positive
+	}
+
+	return actions[c.Action]
+}
+
+// BindControl sets what triggers c, replacing its previous binding. Sticks
+// driving an axis are kept.
+func (m *Map) BindControl(c Control, b Binding) {
+	if c.Dir == 0 {
+		m.Bind(c.Action, b)
+
+		return
+	}
+
+	ab := m.axes[c.Axis]
+	if c.Dir < 0 {
+		ab.Negative = b
+	} else {
+		ab.Positive = b
+	}
+
+	m.axes[c.Axis] = ab
+}
+
+// Captured returns the first key or gamepad button that went down this tick
+// as a binding of just that input, for rebinding screens. Modifier keys are
+// reported by side, e.g. ShiftLeft rather than Shift.
+func (m *Map) Captured() (Binding, bool) {
+	// The modifier keys that cover both sides come last, from KeyAlt on
+	for k := ebiten.Key(0); k < ebiten.KeyAlt; k++ {
+		if m.keyJust(k) {
+			return Binding{Keys: []ebiten.Key{k}}, true
+		}
+	}
+
+	for _, id := range m.gamepads(nil) {
+		for btn := ebiten.StandardGamepadButton(0); btn <= ebiten.StandardGamepadButtonMax; btn++ {
+			if m.padJust(id, btn) {
+				return Binding{Buttons: []ebiten.StandardGamepadButton{btn}}, true
+			}
+		}
+	}
+
+	return Binding{}, false
+}
+
+// SaveControls writes the bindings of controls to the named file in fsys as
+// JSON keyed by control name.
+func (m *Map) SaveControls(fsys paths.FS, name string, controls []Control) error {
+	saved := make(map[string]Binding, len(controls))
+	for _, c := range controls {
+		saved[c.Name] = m.ControlBinding(c)
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fsys.WriteFile(name, append(data, '\n'))
+}
+
+// LoadControls binds controls as saved by SaveControls in the named file of
+// fsys. A missing file is not an error, and controls the file does not
+// mention keep their bindings, so a game can add controls between versions.
+func (m *Map) LoadControls(fsys paths.FS, name string, controls []Control) error {
+	data, err := fsys.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var saved map[string]Binding
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("input: %s: %w", name, err)
+	}
+
+	for _, c := range controls {
+		if b, ok := saved[c.Name]; ok {
+			m.BindControl(c, b)
+		}
+	}
+
+	return nil
+}
+
+// buttonNames are short labels for the standard gamepad buttons, named
+// after the common Xbox layout.
+var buttonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "Pad A",
+	ebiten.StandardGamepadButtonRightRight:       "Pad B",
+	ebiten.StandardGamepadButtonRightLeft:        "Pad X",
+	ebiten.StandardGamepadButtonRightTop:         "Pad Y",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "LB",
+	ebiten.StandardGamepadButtonFrontTopRight:    "RB",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "LT",
+	ebiten.StandardGamepadButtonFrontBottomRight: "RT",
+	ebiten.StandardGamepadButtonCenterLeft:       "Back",
+	ebiten.StandardGamepadButtonCenterRight:      "Start",
+	ebiten.StandardGamepadButtonLeftStick:        "L3",
+	ebiten.StandardGamepadButtonRightStick:       "R3",
+	ebiten.StandardGamepadButtonLeftTop:          "D-Up",
+	ebiten.StandardGamepadButtonLeftBottom:       "D-Down",
+	ebiten.StandardGamepadButtonLeftLeft:         "D-Left",
+	ebiten.StandardGamepadButtonLeftRight:        "D-Right",
+	ebiten.StandardGamepadButtonCenterCenter:     "Home",
+}
+
+// String lists the inputs in b for display, e.g. "W, Up, D-Up", or "none".
+func (b Binding) String() string {
+	var names []string
+
+	for _, k := range b.Keys {
+		names = append(names, strings.TrimPrefix(k.String(), "Arrow"))
+	}
+
+	for _, btn := range b.Mouse {
+		names = append(names, fmt.Sprintf("Mouse %d", btn+1))
+	}
+
+	for _, btn := range b.Buttons {
+		names = append(names, buttonNames[btn])
+	}
+
+	if len(names) == 0 {
+		return "none"
+	}
+
+	return strings.Join(names, ", ")
+}
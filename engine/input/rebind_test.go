@@ -0,0 +1,142 @@
+package input
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestBindControlKeepsSticks(t *testing.T) {
+	m := NewMap()
+	m.BindControl(MoveUp, Binding{Keys: []ebiten.Key{ebiten.KeyZ}})
+
+	if got := m.ControlBinding(MoveUp).Keys; !slices.Equal(got, []ebiten.Key{ebiten.KeyZ}) {
+		t.Errorf("Move Up keys = %v, want Z", got)
+	}
+
+	if got := m.axes[MoveY]; len(got.Sticks) == 0 || len(got.Positive.Keys) != 2 {
+		t.Errorf("rebinding up changed the rest of MoveY: %+v", got)
+	}
+
+	if got := m.DefaultBinding(MoveUp).Keys; !slices.Contains(got, ebiten.KeyW) {
+		t.Errorf("default Move Up keys = %v, want W among them", got)
+	}
+
+	m.Reset()
+
+	if got := m.ControlBinding(MoveUp).Keys; slices.Contains(got, ebiten.KeyZ) {
+		t.Errorf("Reset kept Z on Move Up: %v", got)
+	}
+}
+
+func TestSetDefaults(t *testing.T) {
+	const jump = FirstCustom
+
+	jumpControl := Control{Name: "Jump", Action: jump}
+	space := Binding{Keys: []ebiten.Key{ebiten.KeySpace}}
+
+	m := NewMap()
+	m.Bind(jump, space)
+	m.SetDefaults()
+	m.Bind(jump, Binding{Keys: []ebiten.Key{ebiten.KeyJ}})
+	m.Reset()
+
+	if got := m.ControlBinding(jumpControl); !slices.Equal(got.Keys, space.Keys) {
+		t.Errorf("after Reset jump = %v, want Space", got)
+	}
+}
+
+func TestSaveAndLoadControls(t *testing.T) {
+	const dodge = FirstCustom
+
+	dodgeControl := Control{Name: "Dodge", Action: dodge}
+	controls := []Control{MoveLeft, dodgeControl}
+
+	fsys := paths.MemFS()
+
+	m := NewMap()
+	m.BindControl(MoveLeft, Binding{
+		Keys:    []ebiten.Key{ebiten.KeyQ},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft},
+	})
+	m.Bind(dodge, Binding{Keys: []ebiten.Key{ebiten.KeyShiftLeft}})
+
+	if err := m.SaveControls(fsys, "controls.json", controls); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewMap()
+	if err := loaded.LoadControls(fsys, "controls.json", append(controls, MoveRight)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range controls {
+		if got, want := loaded.ControlBinding(c).String(), m.ControlBinding(c).String(); got != want {
+			t.Errorf("%s loaded as %q, want %q", c.Name, got, want)
+		}
+	}
+
+	if got := loaded.ControlBinding(MoveRight).String(); got != "D, Right, D-Right" {
+		t.Errorf("Move Right, missing from the file, = %q, want the default", got)
+	}
+}
+
+func TestLoadControlsMissingFile(t *testing.T) {
+	m := NewMap()
+	if err := m.LoadControls(paths.MemFS(), "controls.json", []Control{MoveUp}); err != nil {
+		t.Errorf("missing file: %v", err)
+	}
+
+	fsys := paths.MemFS()
+	_ = fsys.WriteFile("controls.json", []byte("{"))
+
+	if err := m.LoadControls(fsys, "controls.json", []Control{MoveUp}); err == nil {
+		t.Error("a corrupt file should be an error")
+	}
+}
+
+func TestCaptured(t *testing.T) {
+	var dev fakeDevices
+
+	pad := newFakePad()
+	m := dev.actionMap(pad)
+
+	dev.tick++
+
+	if _, ok := m.Captured(); ok {
+		t.Error("captured an input with nothing pressed")
+	}
+
+	dev.keys = []ebiten.Key{ebiten.KeyShiftLeft, ebiten.KeyShift}
+	dev.tick++
+
+	if b, ok := m.Captured(); !ok || !slices.Equal(b.Keys, []ebiten.Key{ebiten.KeyShiftLeft}) {
+		t.Errorf("Captured() = %v, %v; want ShiftLeft", b, ok)
+	}
+
+	dev.keys = nil
+	pad.just[ebiten.StandardGamepadButtonFrontTopRight] = true
+	dev.tick++
+
+	if b, ok := m.Captured(); !ok || b.String() != "RB" {
+		t.Errorf("Captured() = %v, %v; want RB", b, ok)
+	}
+}
+
+func TestBindingString(t *testing.T) {
+	b := Binding{
+		Keys:    []ebiten.Key{ebiten.KeyW, ebiten.KeyArrowUp},
+		Mouse:   []ebiten.MouseButton{ebiten.MouseButtonLeft},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+	}
+
+	if got, want := b.String(), "W, Up, Mouse 1, Pad A"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if got := (Binding{}).String(); got != "none" {
+		t.Errorf("empty binding = %q, want none", got)
+	}
+}
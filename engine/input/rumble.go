@@ -0,0 +1,216 @@
+package input
+
+import (
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Rumble tuning.
+const (
+	// rumbleSlice is how long each vibration command lasts; Update renews it
+	// while an effect plays, so a stopped effect never outlives it by much.
+	rumbleSlice = 0.1
+
+	// rumbleEpsilon is the smallest motor change worth a new command.
+	rumbleEpsilon = 0.02
+)
+
+// Step is one stretch of a rumble effect: the low-frequency (Strong) and
+// high-frequency (Weak) motor strengths, from 0 to 1, for Duration seconds.
+type Step struct {
+	Duration     float64
+	Strong, Weak float64
+}
+
+// Effect is a rumble shape over time: its steps play in order, each held
+// for its duration, or with Ramp set, sliding toward the next step's
+// strengths.
+type Effect struct {
+	Steps []Step
+	Ramp  bool
+}
+
+// Pulse is a steady rumble of both motors for seconds.
+func Pulse(strength, seconds float64) Effect {
+	return Effect{Steps: []Step{{Duration: seconds, Strong: strength, Weak: strength}}}
+}
+
+// Ramp slides both motors from one strength to another over seconds,
+// e.g. a rumble that builds as a boss arrives.
+func Ramp(from, to, seconds float64) Effect {
+	return Effect{
+		Steps: []Step{{Duration: seconds, Strong: from, Weak: from}, {Strong: to, Weak: to}},
+		Ramp:  true,
+	}
+}
+
+// Pattern plays steps one after another, e.g. a heartbeat of two pulses
+// with a gap between them.
+func Pattern(steps ...Step) Effect {
+	return Effect{Steps: steps}
+}
+
+// Length returns the effect's duration in seconds.
+func (e Effect) Length() float64 {
+	total := 0.0
+	for _, s := range e.Steps {
+		total += s.Duration
+	}
+
+	return total
+}
+
+// At returns the motor strengths t seconds into the effect; zero before it
+// starts and after it ends.
+func (e Effect) At(t float64) (strong, weak float64) {
+	if t < 0 {
+		return 0, 0
+	}
+
+	for i, s := range e.Steps {
+		if t >= s.Duration {
+			t -= s.Duration
+
+			continue
+		}
+
+		if !e.Ramp || i+1 == len(e.Steps) {
+			return s.Strong, s.Weak
+		}
+
+		next, f := e.Steps[i+1], t/s.Duration
+
+		return s.Strong + (next.Strong-s.Strong)*f, s.Weak + (next.Weak-s.Weak)*f
+	}
+
+	return 0, 0
+}
+
+type playingEffect struct {
+	effect Effect
+	t      float64
+}
+
+// Rumble plays force-feedback effects on every connected gamepad. Games
+// Play effects on events (the player is hit, a boss spawns) and call Update
+// once per tick; effects that overlap mix by taking the strongest value of
+// each motor.
+//
+// Ebitengine can only vibrate gamepads in browsers and on Nintendo Switch.
+// Elsewhere, or with no gamepad connected, the rumble is inactive and Play
+// does nothing, so games can trigger it unconditionally.
+type Rumble struct {
+	Intensity float64 // Scales every effect, from 0 (off) to 1
+
+	playing    []playingEffect
+	sentStrong float64 // Strengths of the last command
+	sentWeak   float64
+	sentLeft   float64 // Seconds before the last command runs out
+
+	// Platform hooks, replaced in tests
+	supported bool
+	gamepads  func([]ebiten.GamepadID) []ebiten.GamepadID
+	vibrate   func(ebiten.GamepadID, *ebiten.VibrateGamepadOptions)
+}
+
+// NewRumble returns a rumble at full intensity.
+func NewRumble() *Rumble {
+	return &Rumble{
+		Intensity: 1,
+		supported: runtime.GOOS == "js",
+		gamepads:  ebiten.AppendGamepadIDs,
+		vibrate:   ebiten.VibrateGamepad,
+	}
+}
+
+// Supported reports whether this platform can vibrate a gamepad at all.
+func (r *Rumble) Supported() bool {
+	return r.supported
+}
+
+// Active reports whether effects play: the platform supports rumble, a
+// gamepad is connected, and the intensity is above zero.
+func (r *Rumble) Active() bool {
+	return r.supported && r.Intensity > 0 && len(r.gamepads(nil)) > 0
+}
+
+// Play starts an effect, unless the rumble is inactive.
+func (r *Rumble) Play(e Effect) {
+	if !r.Active() || e.Length() <= 0 {
+		return
+	}
+
+	r.playing = append(r.playing, playingEffect{effect: e})
+}
+
+// Playing reports whether any effect is still running.
+func (r *Rumble) Playing() bool {
+	return len(r.playing) > 0
+}
+
+// Stop ends every effect and stills the motors, e.g. when the game pauses.
+func (r *Rumble) Stop() {
+	r.playing = r.playing[:0]
+	r.send(0, 0)
+}
+
+// Update advances the effects by dt seconds and sends the mixed strengths to
+// the gamepads when they change or the last command is about to run out.
+func (r *Rumble) Update(dt float64) {
+	if len(r.playing) == 0 && r.sentStrong == 0 && r.sentWeak == 0 {
+		return
+	}
+
+	if !r.Active() {
+		r.Stop()
+
+		return
+	}
+
+	strong, weak := 0.0, 0.0
+	kept := r.playing[:0]
+
+	for _, p := range r.playing {
+		s, w := p.effect.At(p.t)
+		strong, weak = max(strong, s), max(weak, w)
+
+		if p.t += dt; p.t < p.effect.Length() {
+			kept = append(kept, p)
+		}
+	}
+
+	r.playing = kept
+	strong, weak = clampUnit(strong*r.Intensity), clampUnit(weak*r.Intensity)
+	r.sentLeft -= dt
+
+	changed := math.Abs(strong-r.sentStrong) > rumbleEpsilon || math.Abs(weak-r.sentWeak) > rumbleEpsilon
+	if changed || (r.sentLeft <= dt && strong+weak > 0) {
+		r.send(strong, weak)
+	}
+}
+
+// send vibrates every gamepad at the given strengths for a rumbleSlice.
+func (r *Rumble) send(strong, weak float64) {
+	r.sentStrong, r.sentWeak, r.sentLeft = strong, weak, rumbleSlice
+
+	if !r.supported {
+		return
+	}
+
+	opts := &ebiten.VibrateGamepadOptions{
+		Duration:        time.Duration(rumbleSlice * float64(time.Second)),
+		StrongMagnitude: strong,
+		WeakMagnitude:   weak,
+	}
+
+	for _, id := range r.gamepads(nil) {
+		r.vibrate(id, opts)
+	}
+}
+
+func clampUnit(v float64) float64 {
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,142 @@
+package input
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// fakeRumble returns a supported rumble with pads gamepads that records
+// each command sent to them.
+func fakeRumble(pads int) (*Rumble, *[]ebiten.VibrateGamepadOptions) {
+	var sent []ebiten.VibrateGamepadOptions
+
+	r := NewRumble()
+	r.supported = true
+	r.gamepads = func(ids []ebiten.GamepadID) []ebiten.GamepadID {
+		for i := range pads {
+			ids = append(ids, ebiten.GamepadID(i))
+		}
+
+		return ids
+	}
+	r.vibrate = func(id ebiten.GamepadID, o *ebiten.VibrateGamepadOptions) {
+		if id == 0 {
+			sent = append(sent, *o)
+		}
+	}
+
+	return r, &sent
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEffectShapes(t *testing.T) {
+	if s, w := Pulse(0.8, 0.2).At(0.1); s != 0.8 || w != 0.8 {
+		t.Errorf("pulse mid-way = %v, %v", s, w)
+	}
+
+	if s, _ := Pulse(0.8, 0.2).At(0.2); s != 0 {
+		t.Errorf("pulse after its end = %v", s)
+	}
+
+	ramp := Ramp(0, 1, 2)
+	if s, w := ramp.At(0.5); !near(s, 0.25) || !near(w, 0.25) {
+		t.Errorf("ramp at a quarter = %v, %v", s, w)
+	}
+
+	beat := Pattern(
+		Step{Duration: 0.1, Strong: 1},
+		Step{Duration: 0.1},
+		Step{Duration: 0.1, Weak: 0.5},
+	)
+	if !near(beat.Length(), 0.3) {
+		t.Errorf("pattern length = %v", beat.Length())
+	}
+
+	if s, w := beat.At(0.15); s != 0 || w != 0 {
+		t.Errorf("pattern gap = %v, %v", s, w)
+	}
+
+	if s, w := beat.At(0.25); s != 0 || w != 0.5 {
+		t.Errorf("pattern second beat = %v, %v", s, w)
+	}
+}
+
+func TestRumbleMixesScalesAndStops(t *testing.T) {
+	r, sent := fakeRumble(1)
+	r.Intensity = 0.5
+
+	r.Play(Pulse(0.4, 0.5))
+	r.Play(Pattern(Step{Duration: 0.1, Strong: 1, Weak: 0.2}))
+	r.Update(1.0 / 60)
+
+	if len(*sent) != 1 || !near((*sent)[0].StrongMagnitude, 0.5) || !near((*sent)[0].WeakMagnitude, 0.2) {
+		t.Fatalf("sent %+v, want the strongest of each motor at half intensity", *sent)
+	}
+
+	for range 60 {
+		r.Update(1.0 / 60)
+	}
+
+	last := (*sent)[len(*sent)-1]
+	if r.Playing() || last.StrongMagnitude != 0 || last.WeakMagnitude != 0 {
+		t.Errorf("after the effects end: playing=%v, last command %+v", r.Playing(), last)
+	}
+
+	// A steady effect renews its command before it runs out, not every tick
+	if n := len(*sent); n < 4 || n > 12 {
+		t.Errorf("%d commands for half a second of rumble", n)
+	}
+}
+
+func TestRumbleDisablesItself(t *testing.T) {
+	r, sent := fakeRumble(0)
+	r.Play(Pulse(1, 1))
+	r.Update(1.0 / 60)
+
+	if r.Active() || r.Playing() || len(*sent) != 0 {
+		t.Error("rumble without a gamepad should do nothing")
+	}
+
+	off, _ := fakeRumble(1)
+	off.Intensity = 0
+
+	if off.Play(Pulse(1, 1)); off.Playing() {
+		t.Error("zero intensity should turn rumble off")
+	}
+
+	unsupported := NewRumble()
+	unsupported.supported = false
+	unsupported.gamepads = func(ids []ebiten.GamepadID) []ebiten.GamepadID { return append(ids, 0) }
+
+	if unsupported.Play(Pulse(1, 1)); unsupported.Active() || unsupported.Playing() {
+		t.Error("rumble should be off where the platform cannot vibrate")
+	}
+}
+
+func TestRumbleStopsWhenThePadGoesAway(t *testing.T) {
+	pads := 1
+
+	r, sent := fakeRumble(0)
+	r.gamepads = func(ids []ebiten.GamepadID) []ebiten.GamepadID {
+		for i := range pads {
+			ids = append(ids, ebiten.GamepadID(i))
+		}
+
+		return ids
+	}
+
+	r.Play(Pulse(1, 1))
+	r.Update(1.0 / 60)
+
+	pads = 0
+	r.Update(1.0 / 60)
+
+	if r.Playing() || len(*sent) != 1 {
+		t.Errorf("playing=%v after the pad left, commands %+v", r.Playing(), *sent)
+	}
+}
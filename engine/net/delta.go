@@ -0,0 +1,144 @@
+package net
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// deltaGap is the longest run of unchanged bytes folded into a changed run;
+// restarting a run costs about as much as copying them.
+const deltaGap = 2
+
+// MaxDeltaState is the largest state ApplyDelta rebuilds, so a corrupt or
+// hostile length cannot make it allocate without bound.
+const MaxDeltaState = 1 << 20
+
+// EncodeDelta compresses state against a baseline the receiver already holds.
+// Only the bytes that differ are kept, as runs of (skip, length, bytes) after
+// the state length, so a mostly unchanged snapshot encodes to a few bytes.
+// Bytes past the end of baseline count as zero.
+func EncodeDelta(baseline, state []byte) []byte {
+	out := binary.AppendUvarint(nil, uint64(len(state)))
+
+	at := func(i int) byte {
+		if i < len(baseline) {
+			return baseline[i]
+		}
+
+		return 0
+	}
+
+	last := 0 // End of the previous run
+
+	for i := 0; i < len(state); {
+		if state[i] == at(i) {
+			i++
+
+			continue
+		}
+
+		end, same := i+1, 0
+		for j := i + 1; j < len(state) && same <= deltaGap; j++ {
+			if state[j] == at(j) {
+				same++
+
+				continue
+			}
+
+			end, same = j+1, 0
+		}
+
+		out = binary.AppendUvarint(out, uint64(i-last))
+		out = binary.AppendUvarint(out, uint64(end-i))
+		out = append(out, state[i:end]...)
+		last, i = end, end
+	}
+
+	return out
+}
+
+// ApplyDelta rebuilds a state from the baseline it was encoded against.
+func ApplyDelta(baseline, delta []byte) ([]byte, error) {
+	size, n := binary.Uvarint(delta)
+	if n <= 0 {
+		return nil, errors.New("invalid delta length")
+	}
+
+	if size > MaxDeltaState {
+		return nil, errors.New("delta state too large")
+	}
+
+	delta = delta[n:]
+	state := make([]byte, size)
+	copy(state, baseline)
+
+	pos := uint64(0)
+
+	for len(delta) > 0 {
+		skip, n := binary.Uvarint(delta)
+		if n <= 0 {
+			return nil, errors.New("invalid delta skip")
+		}
+
+		delta = delta[n:]
+
+		count, n := binary.Uvarint(delta)
+		if n <= 0 {
+			return nil, errors.New("invalid delta run")
+		}
+
+		delta = delta[n:]
+
+		// Compare against what is left rather than adding, which can overflow
+		if skip > size-pos || count > size-pos-skip || count > uint64(len(delta)) {
+			return nil, errors.New("delta run out of range")
+		}
+
+		pos += skip
+
+		copy(state[pos:], delta[:count])
+		delta = delta[count:]
+		pos += count
+	}
+
+	return state, nil
+}
+
+// StateHistory keeps the last few encoded states by tick, so a sender can
+// delta against whichever one the peer last acknowledged and the peer can
+// find it again to decode.
+type StateHistory struct {
+	ticks  []int64
+	states [][]byte
+	next   int
+}
+
+// NewStateHistory creates a history holding up to size states.
+func NewStateHistory(size int) *StateHistory {
+	return &StateHistory{ticks: make([]int64, size), states: make([][]byte, size)}
+}
+
+// Add records the state for a tick, replacing the oldest.
+func (h *StateHistory) Add(tick int64, state []byte) {
+	h.ticks[h.next] = tick
+	h.states[h.next] = state
+	h.next = (h.next + 1) % len(h.states)
+}
+
+// Get returns the state recorded for a tick, if it is still held.
+func (h *StateHistory) Get(tick int64) ([]byte, bool) {
+	for i, t := range h.ticks {
+		if t == tick && h.states[i] != nil {
+			return h.states[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Reset forgets every state.
+func (h *StateHistory) Reset() {
+	clear(h.ticks)
+	clear(h.states)
+	h.next = 0
+}
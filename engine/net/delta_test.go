@@ -0,0 +1,100 @@
+package net
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestDeltaRoundTrip(t *testing.T) {
+	base := bytes.Repeat([]byte{1, 2, 3, 4}, 100)
+
+	cases := map[string][]byte{
+		"unchanged": base,
+		"shrunk":    base[:50],
+		"empty":     {},
+	}
+
+	changed := bytes.Clone(base)
+	changed[10], changed[12], changed[300] = 9, 9, 9
+	cases["changed"] = changed
+
+	grown := append(bytes.Clone(base), 7, 0, 0, 8)
+	cases["grown"] = grown
+
+	for name, state := range cases {
+		delta := EncodeDelta(base, state)
+
+		got, err := ApplyDelta(base, delta)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if !bytes.Equal(got, state) {
+			t.Errorf("%s: decoded %d bytes that differ from the state", name, len(got))
+		}
+	}
+
+	if n := len(EncodeDelta(base, changed)); n > 12 {
+		t.Errorf("three changed bytes encode to %d bytes", n)
+	}
+
+	if n := len(EncodeDelta(nil, base)); n > len(base)+6 {
+		t.Errorf("a full state against no baseline encodes to %d bytes", n)
+	}
+}
+
+func TestApplyDeltaRejectsCorruptData(t *testing.T) {
+	delta := EncodeDelta(nil, []byte{1, 2, 3})
+
+	huge := binary.AppendUvarint(nil, MaxDeltaState+1)
+	overflow := binary.AppendUvarint([]byte{3}, math.MaxUint64)
+	overflow = append(overflow, 1, 9)
+
+	for _, bad := range [][]byte{nil, delta[:len(delta)-1], {2, 1, 5, 0}, huge, overflow} {
+		if _, err := ApplyDelta(nil, bad); err == nil {
+			t.Errorf("ApplyDelta(%v) succeeded", bad)
+		}
+	}
+}
+
+func FuzzApplyDelta(f *testing.F) {
+	base := bytes.Repeat([]byte{1, 2, 3, 4}, 16)
+	f.Add(base, EncodeDelta(base, append(bytes.Clone(base), 5)))
+	f.Add([]byte{}, []byte{2, 1, 5, 0})
+
+	f.Fuzz(func(t *testing.T, baseline, delta []byte) {
+		state, err := ApplyDelta(baseline, delta)
+		if err != nil {
+			return
+		}
+
+		// Whatever decodes must survive a round trip
+		got, err := ApplyDelta(baseline, EncodeDelta(baseline, state))
+		if err != nil || !bytes.Equal(got, state) {
+			t.Fatalf("re-encoding %d bytes: %v", len(state), err)
+		}
+	})
+}
+
+func TestStateHistory(t *testing.T) {
+	h := NewStateHistory(2)
+	h.Add(1, []byte{1})
+	h.Add(2, []byte{2})
+	h.Add(3, []byte{3})
+
+	if _, ok := h.Get(1); ok {
+		t.Error("the oldest state should be replaced")
+	}
+
+	if s, ok := h.Get(3); !ok || s[0] != 3 {
+		t.Errorf("Get(3) = %v, %v", s, ok)
+	}
+
+	h.Reset()
+
+	if _, ok := h.Get(0); ok {
+		t.Error("a reset history returned a state")
+	}
+}
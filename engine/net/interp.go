@@ -0,0 +1,187 @@
+package net
+
+import (
+	"maps"
+	"math"
+	"slices"
+)
+
+// InterpConfig tunes how remote entities are smoothed between snapshots.
+type InterpConfig struct {
+	// Delay is how far behind the estimated server clock entities are drawn,
+	// in seconds. It should cover the snapshot interval plus typical jitter so
+	// there is usually a snapshot on either side of the render time.
+	Delay float64
+
+	// MaxExtrapolate is how long, in seconds, an entity keeps moving along
+	// its last velocity once the render time passes its newest snapshot.
+	MaxExtrapolate float64
+
+	// SnapDistance is the jump between consecutive snapshots, in world
+	// units, treated as a teleport: the entity snaps instead of sliding.
+	SnapDistance float64
+
+	// ClockSmoothing is the weight (0-1) of each snapshot's arrival time in
+	// the server clock estimate. Lower values ride out more jitter.
+	ClockSmoothing float64
+
+	// ResyncAfter is the clock error, in seconds, beyond which the estimate
+	// resets instead of drifting toward the new timing.
+	ResyncAfter float64
+
+	// BufferSize caps the snapshots kept per entity.
+	BufferSize int
+}
+
+// DefaultInterpConfig suits snapshots sent around 10-20 times a second.
+func DefaultInterpConfig() InterpConfig {
+	return InterpConfig{
+		Delay:          0.1,
+		MaxExtrapolate: 0.25,
+		SnapDistance:   200,
+		ClockSmoothing: 0.1,
+		ResyncAfter:    1,
+		BufferSize:     32,
+	}
+}
+
+// Snapshot is an entity's position at a server time, in seconds.
+type Snapshot struct {
+	Time float64
+	X, Y float64
+}
+
+// Interpolation smooths remote entity positions. Snapshots are pushed as
+// they arrive; positions are sampled at a render time held Delay behind a
+// jitter-smoothed estimate of the server clock, interpolating between the
+// snapshots on either side and extrapolating briefly when none are newer.
+type Interpolation struct {
+	InterpConfig
+
+	offset   float64 // Estimated server time minus local time
+	synced   bool
+	entities map[uint64][]Snapshot
+}
+
+// NewInterpolation creates an empty interpolation.
+func NewInterpolation(cfg InterpConfig) *Interpolation {
+	return &Interpolation{InterpConfig: cfg, entities: make(map[uint64][]Snapshot)}
+}
+
+// Receive updates the server clock estimate with a snapshot message stamped
+// serverTime that arrived at localTime. Call it once per message, before
+// pushing the message's entities.
+func (in *Interpolation) Receive(serverTime, localTime float64) {
+	sample := serverTime - localTime
+
+	if !in.synced || math.Abs(sample-in.offset) > in.ResyncAfter {
+		in.offset = sample
+		in.synced = true
+
+		return
+	}
+
+	in.offset += (sample - in.offset) * in.ClockSmoothing
+}
+
+// ServerTime estimates the server clock at localTime.
+func (in *Interpolation) ServerTime(localTime float64) float64 {
+	return localTime + in.offset
+}
+
+// RenderTime is the server time entities are drawn at for localTime.
+func (in *Interpolation) RenderTime(localTime float64) float64 {
+	return in.ServerTime(localTime) - in.Delay
+}
+
+// Push adds an entity snapshot. Snapshots older than the entity's newest are
+// dropped, and a jump beyond SnapDistance discards the older ones so the
+// entity snaps.
+func (in *Interpolation) Push(id uint64, s Snapshot) {
+	buf := in.entities[id]
+
+	if n := len(buf); n > 0 {
+		last := buf[n-1]
+		if s.Time <= last.Time {
+			return
+		}
+
+		if math.Hypot(s.X-last.X, s.Y-last.Y) > in.SnapDistance {
+			buf = buf[:0]
+		}
+	}
+
+	buf = append(buf, s)
+	if in.BufferSize > 0 && len(buf) > in.BufferSize {
+		buf = append(buf[:0], buf[len(buf)-in.BufferSize:]...)
+	}
+
+	in.entities[id] = buf
+}
+
+// Position returns an entity's smoothed position at localTime, or false if
+// it has no snapshots.
+func (in *Interpolation) Position(id uint64, localTime float64) (x, y float64, ok bool) {
+	buf := in.entities[id]
+	if len(buf) == 0 {
+		return 0, 0, false
+	}
+
+	t := in.RenderTime(localTime)
+
+	if t <= buf[0].Time {
+		return buf[0].X, buf[0].Y, true
+	}
+
+	// Between two snapshots
+	for i := len(buf) - 1; i > 0; i-- {
+		a, b := buf[i-1], buf[i]
+		if t >= a.Time && t < b.Time {
+			f := (t - a.Time) / (b.Time - a.Time)
+
+			return a.X + (b.X-a.X)*f, a.Y + (b.Y-a.Y)*f, true
+		}
+	}
+
+	// Past the newest: carry on along the last velocity for a while
+	last := buf[len(buf)-1]
+	if len(buf) < 2 {
+		return last.X, last.Y, true
+	}
+
+	prev := buf[len(buf)-2]
+	dt := min(t-last.Time, in.MaxExtrapolate) / (last.Time - prev.Time)
+
+	return last.X + (last.X-prev.X)*dt, last.Y + (last.Y-prev.Y)*dt, true
+}
+
+// Latest returns an entity's newest raw snapshot.
+func (in *Interpolation) Latest(id uint64) (Snapshot, bool) {
+	buf := in.entities[id]
+	if len(buf) == 0 {
+		return Snapshot{}, false
+	}
+
+	return buf[len(buf)-1], true
+}
+
+// Buffered returns how many snapshots are held for an entity.
+func (in *Interpolation) Buffered(id uint64) int {
+	return len(in.entities[id])
+}
+
+// Remove forgets an entity, e.g. when it leaves the game.
+func (in *Interpolation) Remove(id uint64) {
+	delete(in.entities, id)
+}
+
+// IDs lists the tracked entities in ascending order.
+func (in *Interpolation) IDs() []uint64 {
+	return slices.Sorted(maps.Keys(in.entities))
+}
+
+// Reset forgets every entity and the clock estimate.
+func (in *Interpolation) Reset() {
+	clear(in.entities)
+	in.offset, in.synced = 0, false
+}
@@ -0,0 +1,49 @@
+package net
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	debugRawColor    = color.NRGBA{R: 230, G: 60, B: 60, A: 200}
+	debugSmoothColor = color.NRGBA{R: 40, G: 200, B: 90, A: 230}
+)
+
+// DrawDebug visualizes the smoothing: each entity's newest raw snapshot is a
+// red ring and its smoothed position a green dot, joined by a line, with a
+// summary of the clock in the bottom-left corner. toScreen maps world
+// coordinates to the screen, e.g. a camera's WorldToScreen.
+func (in *Interpolation) DrawDebug(
+	screen *ebiten.Image,
+	localTime float64,
+	toScreen func(x, y float64) (float64, float64),
+) {
+	extrapolating := 0
+
+	for _, id := range in.IDs() {
+		raw, _ := in.Latest(id)
+		x, y, _ := in.Position(id, localTime)
+
+		if in.RenderTime(localTime) > raw.Time {
+			extrapolating++
+		}
+
+		rx, ry := toScreen(raw.X, raw.Y)
+		sx, sy := toScreen(x, y)
+
+		vector.StrokeLine(screen, float32(rx), float32(ry), float32(sx), float32(sy), 1, debugRawColor, false)
+		vector.StrokeCircle(screen, float32(rx), float32(ry), 6, 2, debugRawColor, false)
+		vector.FillCircle(screen, float32(sx), float32(sy), 4, debugSmoothColor, false)
+	}
+
+	summary := fmt.Sprintf(
+		"interp: %d entities, delay %.0fms, clock offset %.0fms, %d extrapolating",
+		len(in.entities), in.Delay*1000, in.offset*1000, extrapolating,
+	)
+	ebitenutil.DebugPrintAt(screen, summary, 10, screen.Bounds().Dy()-20)
+}
@@ -0,0 +1,104 @@
+package net
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestInterpolationBetweenSnapshots(t *testing.T) {
+	in := NewInterpolation(DefaultInterpConfig())
+
+	// Snapshots every 0.1s arriving 50ms after they were sent
+	for i := range 4 {
+		sent := float64(i) * 0.1
+		in.Receive(sent, sent+0.05)
+		in.Push(1, Snapshot{Time: sent, X: float64(i) * 10})
+	}
+
+	// The server clock runs 50ms behind local time and rendering 0.1s behind that
+	now := 0.4
+	if got := in.RenderTime(now); !near(got, 0.25) {
+		t.Fatalf("RenderTime = %v, want 0.25", got)
+	}
+
+	if x, _, ok := in.Position(1, now); !ok || !near(x, 25) {
+		t.Errorf("x = %v, want 25 halfway between the snapshots at 0.2 and 0.3", x)
+	}
+
+	if _, _, ok := in.Position(2, now); ok {
+		t.Error("unknown entity reported a position")
+	}
+}
+
+func TestInterpolationExtrapolatesThenHolds(t *testing.T) {
+	in := NewInterpolation(DefaultInterpConfig())
+
+	in.Receive(0, 0)
+	in.Push(1, Snapshot{Time: 0, X: 0})
+	in.Push(1, Snapshot{Time: 0.1, X: 10})
+
+	// 0.1s past the newest snapshot: keeps moving at 100 units/s
+	if x, _, _ := in.Position(1, 0.3); !near(x, 20) {
+		t.Errorf("extrapolated x = %v, want 20", x)
+	}
+
+	// Capped at MaxExtrapolate
+	if x, _, _ := in.Position(1, 5); !near(x, 10+100*in.MaxExtrapolate) {
+		t.Errorf("x = %v long after the last snapshot, want it held at the cap", x)
+	}
+}
+
+func TestInterpolationSnapsOnTeleport(t *testing.T) {
+	in := NewInterpolation(DefaultInterpConfig())
+
+	in.Receive(0, 0)
+	in.Push(1, Snapshot{Time: 0, X: 0})
+	in.Push(1, Snapshot{Time: 0.1, X: 1000})
+
+	if in.Buffered(1) != 1 {
+		t.Fatalf("%d snapshots buffered after a teleport, want 1", in.Buffered(1))
+	}
+
+	if x, _, _ := in.Position(1, 0.15); x != 1000 {
+		t.Errorf("x = %v mid-teleport, want a snap to 1000", x)
+	}
+
+	// Late and duplicate snapshots are dropped
+	in.Push(1, Snapshot{Time: 0.05, X: 500})
+	in.Push(1, Snapshot{Time: 0.1, X: 500})
+
+	if s, _ := in.Latest(1); s.X != 1000 || in.Buffered(1) != 1 {
+		t.Errorf("latest %+v with %d buffered after stale snapshots", s, in.Buffered(1))
+	}
+}
+
+func TestInterpolationClockRidesOutJitter(t *testing.T) {
+	in := NewInterpolation(DefaultInterpConfig())
+
+	in.Receive(0, 0.1)
+
+	// One snapshot arrives 60ms late; the estimate moves only a little
+	in.Receive(0.1, 0.26)
+
+	if got := in.ServerTime(1); got <= 0.9-0.01 || got >= 0.9 {
+		t.Errorf("ServerTime(1) = %v, want slightly under 0.9", got)
+	}
+
+	// A large jump resyncs at once
+	in.Receive(10, 0.3)
+
+	if got := in.ServerTime(0.3); !near(got, 10) {
+		t.Errorf("ServerTime after resync = %v, want 10", got)
+	}
+
+	in.Push(1, Snapshot{Time: 10})
+	in.Reset()
+
+	if len(in.IDs()) != 0 || in.ServerTime(0) != 0 {
+		t.Error("Reset kept state")
+	}
+}
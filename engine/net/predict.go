@@ -0,0 +1,55 @@
+package net
+
+// PendingInput is an input the client has applied locally but the server has
+// not yet acknowledged.
+type PendingInput[I any] struct {
+	Seq   uint32
+	Input I
+}
+
+// Prediction tracks a client's unacknowledged inputs for reconciliation. The
+// client applies each input to its own entity as it sends it; when a server
+// state arrives acknowledging input Seq, the client resets the entity to that
+// state and replays the inputs still pending.
+type Prediction[I any] struct {
+	seq     uint32
+	pending []PendingInput[I]
+}
+
+// Add records an input about to be sent and returns its sequence number,
+// starting at 1 so that 0 acknowledges nothing.
+func (p *Prediction[I]) Add(input I) uint32 {
+	p.seq++
+	p.pending = append(p.pending, PendingInput[I]{Seq: p.seq, Input: input})
+
+	return p.seq
+}
+
+// Ack drops the inputs up to and including seq, which the server has applied,
+// and returns the rest in order for replay. The slice is reused by later
+// calls.
+func (p *Prediction[I]) Ack(seq uint32) []PendingInput[I] {
+	i := 0
+	for i < len(p.pending) && p.pending[i].Seq <= seq {
+		i++
+	}
+
+	p.pending = append(p.pending[:0], p.pending[i:]...)
+
+	return p.pending
+}
+
+// Pending returns the inputs awaiting acknowledgement, oldest first.
+func (p *Prediction[I]) Pending() []PendingInput[I] {
+	return p.pending
+}
+
+// Len returns how many inputs are awaiting acknowledgement.
+func (p *Prediction[I]) Len() int {
+	return len(p.pending)
+}
+
+// Reset drops every pending input; sequence numbers keep counting up.
+func (p *Prediction[I]) Reset() {
+	p.pending = p.pending[:0]
+}
@@ -0,0 +1,27 @@
+package net
+
+import "testing"
+
+func TestPredictionReplaysUnacknowledgedInputs(t *testing.T) {
+	var p Prediction[int]
+
+	for in := range 5 {
+		p.Add(in * 10)
+	}
+
+	rest := p.Ack(3)
+	if len(rest) != 2 || rest[0].Seq != 4 || rest[1].Input != 40 {
+		t.Fatalf("Ack(3) left %+v, want inputs 4 and 5", rest)
+	}
+
+	// A stale ack changes nothing
+	if p.Ack(1); p.Len() != 2 {
+		t.Errorf("%d pending after a stale ack, want 2", p.Len())
+	}
+
+	p.Reset()
+
+	if seq := p.Add(0); seq != 6 || p.Len() != 1 {
+		t.Errorf("Add after Reset gave seq %d with %d pending", seq, p.Len())
+	}
+}
@@ -0,0 +1,254 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FS is a flat store of named files, backed by a directory on desktop and by
+// localStorage in the browser. Names are plain file names without
+// separators.
+type FS interface {
+	// ReadFile returns the file's contents; missing files return an error
+	// matching fs.ErrNotExist.
+	ReadFile(name string) ([]byte, error)
+	// WriteFile replaces the file's contents, creating it if needed.
+	WriteFile(name string, data []byte) error
+	// Remove deletes the file; removing a missing file is not an error.
+	Remove(name string) error
+	// Exists reports whether the file exists.
+	Exists(name string) bool
+	// List returns the names of all files, sorted.
+	List() ([]string, error)
+}
+
+// checkName rejects names that would escape the store.
+func checkName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("paths: invalid file name %q", name)
+	}
+
+	return nil
+}
+
+// DirFS returns an FS over the files in dir, which is created on the first
+// write. Writes go through a temporary file and a rename, so a crash never
+// leaves a half-written file behind.
+func DirFS(dir string) FS {
+	return dirFS(dir)
+}
+
+type dirFS string
+
+func (d dirFS) ReadFile(name string) ([]byte, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(filepath.Join(string(d), name))
+}
+
+func (d dirFS) WriteFile(name string, data []byte) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(string(d), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(string(d), name+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(string(d), name)); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return nil
+}
+
+func (d dirFS) Remove(name string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	err := os.Remove(filepath.Join(string(d), name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (d dirFS) Exists(name string) bool {
+	if checkName(name) != nil {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join(string(d), name))
+
+	return err == nil && !info.IsDir()
+}
+
+func (d dirFS) List() ([]string, error) {
+	entries, err := os.ReadDir(string(d))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// MemFS returns an empty FS held in memory, for tests and for platforms
+// where nothing persists.
+func MemFS() FS {
+	return &memFS{files: make(map[string][]byte)}
+}
+
+type memFS struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (m *memFS) ReadFile(name string) ([]byte, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+func (m *memFS) WriteFile(name string, data []byte) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.files[name] = append([]byte(nil), data...)
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *memFS) Remove(name string) error {
+	m.mu.Lock()
+	delete(m.files, name)
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *memFS) Exists(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.files[name]
+
+	return ok
+}
+
+func (m *memFS) List() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// MigrateLegacy moves the named files from legacyDir, where older builds
+// wrote them, into fsys. legacyDir must be a directory the game itself
+// created, never the working directory or another shared location. A file
+// is only copied when valid accepts its contents (nil accepts any) and fsys
+// does not already have it, so newer data is never overwritten; the legacy
+// copy is removed once fsys holds the file, and left alone when it does not
+// parse. It returns the names that were copied.
+func MigrateLegacy(fsys FS, legacyDir string, valid func(data []byte) error, names ...string) ([]string, error) {
+	legacy := DirFS(legacyDir)
+
+	var (
+		moved []string
+		errs  []error
+	)
+
+	for _, name := range names {
+		if !legacy.Exists(name) {
+			continue
+		}
+
+		data, err := legacy.ReadFile(name)
+		if err == nil && valid != nil {
+			err = valid(data)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("paths: migrate %s: %w", name, err))
+
+			continue
+		}
+
+		if !fsys.Exists(name) {
+			if err := fsys.WriteFile(name, data); err != nil {
+				errs = append(errs, fmt.Errorf("paths: migrate %s: %w", name, err))
+
+				continue
+			}
+
+			moved = append(moved, name)
+		}
+
+		if err := legacy.Remove(name); err != nil {
+			errs = append(errs, fmt.Errorf("paths: migrate %s: %w", name, err))
+		}
+	}
+
+	return moved, errors.Join(errs...)
+}
@@ -0,0 +1,13 @@
+//go:build !js || !wasm
+
+package paths
+
+// Open returns the store for files of the given kind: the directory from Dir.
+func (a App) Open(kind Kind) (FS, error) {
+	dir, err := a.Dir(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return DirFS(dir), nil
+}
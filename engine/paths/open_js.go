@@ -0,0 +1,112 @@
+//go:build js && wasm
+
+package paths
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"syscall/js"
+)
+
+// Open returns the store for files of the given kind. In the browser files
+// are kept in localStorage under "<vendor>/<name>/<kind>/<file>"; where
+// localStorage is unavailable (private browsing, Node) it falls back to
+// memory. A Root still selects a plain directory, which works under Node.
+func (a App) Open(kind Kind) (FS, error) {
+	if a.Root != "" {
+		dir, err := a.Dir(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		return DirFS(dir), nil
+	}
+
+	storage := localStorage()
+	if !storage.Truthy() {
+		return MemFS(), nil
+	}
+
+	return &webFS{storage: storage, prefix: a.Vendor + "/" + a.Name + "/" + kind.String() + "/"}, nil
+}
+
+// localStorage returns window.localStorage, or undefined when reading it
+// fails (it throws when storage is disabled).
+func localStorage() (v js.Value) {
+	defer func() {
+		if recover() != nil {
+			v = js.Undefined()
+		}
+	}()
+
+	return js.Global().Get("localStorage")
+}
+
+// webFS stores each file as one base64 string, since localStorage only
+// holds text.
+type webFS struct {
+	storage js.Value
+	prefix  string
+}
+
+func (w *webFS) ReadFile(name string) ([]byte, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	v := w.storage.Call("getItem", w.prefix+name)
+	if v.IsNull() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return base64.StdEncoding.DecodeString(v.String())
+}
+
+func (w *webFS) WriteFile(name string, data []byte) (err error) {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	// setItem throws when the quota is exceeded
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("paths: write %s: %v", name, r)
+		}
+	}()
+
+	w.storage.Call("setItem", w.prefix+name, base64.StdEncoding.EncodeToString(data))
+
+	return nil
+}
+
+func (w *webFS) Remove(name string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	w.storage.Call("removeItem", w.prefix+name)
+
+	return nil
+}
+
+func (w *webFS) Exists(name string) bool {
+	return checkName(name) == nil && !w.storage.Call("getItem", w.prefix+name).IsNull()
+}
+
+func (w *webFS) List() ([]string, error) {
+	var names []string
+
+	for i := range w.storage.Get("length").Int() {
+		key := w.storage.Call("key", i)
+		if !key.IsNull() && strings.HasPrefix(key.String(), w.prefix) {
+			names = append(names, strings.TrimPrefix(key.String(), w.prefix))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
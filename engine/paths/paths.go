@@ -0,0 +1,125 @@
+// Package paths resolves where a game keeps its files on each platform:
+// settings in the config directory, saves and stats in the data directory,
+// and disposable files in the cache directory.
+//
+//	           Config                 Data                   Cache
+//	Windows    %AppData%              %AppData%              %LocalAppData%
+//	macOS      ~/Library/Application Support (all but cache) ~/Library/Caches
+//	Linux/BSD  $XDG_CONFIG_HOME       $XDG_DATA_HOME         $XDG_CACHE_HOME
+//	           (~/.config)            (~/.local/share)       (~/.cache)
+//
+// Each app gets <base>/<Vendor>/<Name>. The browser build has no file
+// system, so Open returns a store backed by localStorage instead.
+package paths
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// ErrNoFilesystem is returned by Dir on platforms without a user file system
+// (the browser build); use Open there.
+var ErrNoFilesystem = errors.New("paths: no user directories on this platform")
+
+// Kind selects which of an app's directories a file belongs in.
+type Kind int
+
+const (
+	Config Kind = iota // Settings and key bindings
+	Data               // Saves, stats, and other progress
+	Cache              // Files that can be regenerated
+)
+
+// String returns the kind's name, also used in localStorage keys.
+func (k Kind) String() string {
+	switch k {
+	case Config:
+		return "config"
+	case Data:
+		return "data"
+	case Cache:
+		return "cache"
+	}
+
+	return "unknown"
+}
+
+// App identifies an application's directories.
+type App struct {
+	Vendor string
+	Name   string
+
+	// Root, when set, replaces the platform directories with Root/<kind>,
+	// e.g. for portable installs or tests.
+	Root string
+}
+
+// Dir returns the directory for files of the given kind, without creating it.
+func (a App) Dir(kind Kind) (string, error) {
+	if a.Root != "" {
+		return filepath.Join(a.Root, kind.String()), nil
+	}
+
+	home, _ := os.UserHomeDir()
+
+	base, err := baseDir(runtime.GOOS, kind, os.Getenv, home)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(base, a.Vendor, a.Name), nil
+}
+
+// baseDir returns the platform directory for kind before the app's
+// subdirectories are added.
+func baseDir(goos string, kind Kind, getenv func(string) string, home string) (string, error) {
+	inHome := func(rel string) (string, error) {
+		if home == "" {
+			return "", errors.New("paths: the home directory is not set")
+		}
+
+		return filepath.Join(home, rel), nil
+	}
+
+	// XDG variables must be absolute; relative ones are ignored per the spec
+	fromEnv := func(key, rel string) (string, error) {
+		if dir := getenv(key); filepath.IsAbs(dir) {
+			return dir, nil
+		}
+
+		return inHome(rel)
+	}
+
+	switch goos {
+	case "js", "wasip1":
+		return "", ErrNoFilesystem
+	case "windows":
+		key := "AppData"
+		if kind == Cache {
+			key = "LocalAppData"
+		}
+
+		if dir := getenv(key); dir != "" {
+			return dir, nil
+		}
+
+		return "", errors.New("paths: %" + key + "% is not set")
+	case "darwin", "ios":
+		if kind == Cache {
+			return inHome("Library/Caches")
+		}
+
+		return inHome("Library/Application Support")
+	}
+
+	switch kind {
+	case Cache:
+		return fromEnv("XDG_CACHE_HOME", ".cache")
+	case Data:
+		return fromEnv("XDG_DATA_HOME", ".local/share")
+	}
+
+	return fromEnv("XDG_CONFIG_HOME", ".config")
+}
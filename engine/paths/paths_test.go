@@ -0,0 +1,187 @@
+package paths
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBaseDirPerPlatform(t *testing.T) {
+	env := map[string]string{
+		"AppData":         `C:\Users\ada\AppData\Roaming`,
+		"LocalAppData":    `C:\Users\ada\AppData\Local`,
+		"XDG_DATA_HOME":   "/xdg/data",
+		"XDG_CONFIG_HOME": "relative/ignored",
+	}
+	getenv := func(k string) string { return env[k] }
+
+	tests := []struct {
+		goos string
+		kind Kind
+		want string
+	}{
+		{"windows", Config, env["AppData"]},
+		{"windows", Data, env["AppData"]},
+		{"windows", Cache, env["LocalAppData"]},
+		{"darwin", Config, "/home/ada/Library/Application Support"},
+		{"darwin", Cache, "/home/ada/Library/Caches"},
+		{"linux", Config, "/home/ada/.config"},
+		{"linux", Data, "/xdg/data"},
+		{"freebsd", Cache, "/home/ada/.cache"},
+	}
+
+	for _, tt := range tests {
+		got, err := baseDir(tt.goos, tt.kind, getenv, "/home/ada")
+		if err != nil || got != filepath.FromSlash(tt.want) {
+			t.Errorf("baseDir(%s, %v) = %q, %v; want %q", tt.goos, tt.kind, got, err, tt.want)
+		}
+	}
+
+	if _, err := baseDir("js", Data, getenv, ""); !errors.Is(err, ErrNoFilesystem) {
+		t.Errorf("js: err = %v, want ErrNoFilesystem", err)
+	}
+
+	if _, err := baseDir("linux", Data, func(string) string { return "" }, ""); err == nil {
+		t.Error("linux without $HOME or XDG vars should fail")
+	}
+}
+
+func TestAppRootOverridesPlatform(t *testing.T) {
+	root := t.TempDir()
+	app := App{Vendor: "acme", Name: "game", Root: root}
+
+	dir, err := app.Dir(Data)
+	if err != nil || dir != filepath.Join(root, "data") {
+		t.Fatalf("Dir = %q, %v", dir, err)
+	}
+
+	store, err := app.Open(Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.WriteFile("settings.json", []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "config", "settings.json")); err != nil {
+		t.Errorf("settings not written under Root: %v", err)
+	}
+}
+
+func testFS(t *testing.T, fsys FS) {
+	t.Helper()
+
+	if fsys.Exists("a.json") {
+		t.Fatal("new store is not empty")
+	}
+
+	if _, err := fsys.ReadFile("a.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile missing: err = %v, want fs.ErrNotExist", err)
+	}
+
+	for _, name := range []string{"b.json", "a.json"} {
+		if err := fsys.WriteFile(name, []byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if data, err := fsys.ReadFile("a.json"); err != nil || string(data) != "a.json" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+
+	if names, _ := fsys.List(); !slices.Equal(names, []string{"a.json", "b.json"}) {
+		t.Errorf("List = %v", names)
+	}
+
+	if err := fsys.Remove("a.json"); err != nil || fsys.Exists("a.json") {
+		t.Errorf("Remove: err = %v, exists = %v", err, fsys.Exists("a.json"))
+	}
+
+	if err := fsys.Remove("a.json"); err != nil {
+		t.Errorf("removing a missing file: %v", err)
+	}
+
+	if err := fsys.WriteFile("../escape", nil); err == nil {
+		t.Error("WriteFile accepted a name with a separator")
+	}
+}
+
+func TestDirFS(t *testing.T) {
+	testFS(t, DirFS(filepath.Join(t.TempDir(), "created", "on", "write")))
+}
+
+func TestMemFS(t *testing.T) {
+	testFS(t, MemFS())
+}
+
+func TestMigrateLegacyMovesOnlyMissingFiles(t *testing.T) {
+	legacy := t.TempDir()
+	for _, name := range []string{"settings.json", "stats.json", "other.txt"} {
+		if err := os.WriteFile(filepath.Join(legacy, name), []byte("old "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fsys := MemFS()
+	if err := fsys.WriteFile("stats.json", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := MigrateLegacy(fsys, legacy, nil, "settings.json", "stats.json", "absent.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(moved, []string{"settings.json"}) {
+		t.Errorf("moved = %v, want [settings.json]", moved)
+	}
+
+	if data, _ := fsys.ReadFile("settings.json"); string(data) != "old settings.json" {
+		t.Errorf("migrated settings = %q", data)
+	}
+
+	if data, _ := fsys.ReadFile("stats.json"); string(data) != "new" {
+		t.Errorf("existing stats overwritten with %q", data)
+	}
+
+	for name, want := range map[string]bool{"settings.json": false, "stats.json": false, "other.txt": true} {
+		if _, err := os.Stat(filepath.Join(legacy, name)); (err == nil) != want {
+			t.Errorf("legacy %s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+}
+
+func TestMigrateLegacyKeepsInvalidFiles(t *testing.T) {
+	legacy := t.TempDir()
+	if err := os.WriteFile(filepath.Join(legacy, "stats.json"), []byte("not ours"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	errInvalid := errors.New("invalid")
+	valid := func(data []byte) error {
+		if string(data) != "ours" {
+			return errInvalid
+		}
+
+		return nil
+	}
+
+	fsys := MemFS()
+
+	moved, err := MigrateLegacy(fsys, legacy, valid, "stats.json")
+	if !errors.Is(err, errInvalid) || len(moved) != 0 {
+		t.Fatalf("moved %v, err %v; want nothing moved and the validity error", moved, err)
+	}
+
+	if fsys.Exists("stats.json") {
+		t.Error("invalid file was copied")
+	}
+
+	if _, err := os.Stat(filepath.Join(legacy, "stats.json")); err != nil {
+		t.Errorf("invalid legacy file was removed: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package profile
+
+import (
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/colorutil"
+	"github.com/skyrocket-qy/NeuralWay/engine/graphics"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Kind is the slot a cosmetic is worn in; one item of each kind at a time.
+type Kind string
+
+const (
+	Palette    Kind = "palette" // Recolors the UI accent and highlight
+	Pet        Kind = "pet"     // A companion on title screens
+	TitleTheme Kind = "title"   // A title-screen backdrop
+)
+
+// Item is a cosmetic sold in the token shop.
+type Item struct {
+	ID    string // Stable key kept in saves
+	Name  string
+	Kind  Kind
+	Price int // Tokens
+
+	// Color is the palette's accent, the pet's body, or the top of the title
+	// backdrop; Alt is the palette's highlight or the backdrop's bottom.
+	Color, Alt color.RGBA
+}
+
+// Catalog is everything the token shop sells, cheapest kinds first.
+var Catalog = []Item{
+	{ID: "palette-ember", Name: "Ember", Kind: Palette, Price: 5,
+		Color: color.RGBA{R: 255, G: 120, B: 60, A: 255}, Alt: color.RGBA{R: 255, G: 200, B: 90, A: 255}},
+	{ID: "palette-mint", Name: "Mint", Kind: Palette, Price: 5,
+		Color: color.RGBA{R: 90, G: 220, B: 170, A: 255}, Alt: color.RGBA{R: 200, G: 255, B: 140, A: 255}},
+	{ID: "palette-violet", Name: "Violet", Kind: Palette, Price: 5,
+		Color: color.RGBA{R: 170, G: 120, B: 255, A: 255}, Alt: color.RGBA{R: 255, G: 140, B: 220, A: 255}},
+	{ID: "pet-blip", Name: "Blip", Kind: Pet, Price: 10, Color: color.RGBA{R: 120, G: 200, B: 255, A: 255}},
+	{ID: "pet-mochi", Name: "Mochi", Kind: Pet, Price: 10, Color: color.RGBA{R: 255, G: 180, B: 200, A: 255}},
+	{ID: "pet-glitch", Name: "Glitch", Kind: Pet, Price: 10, Color: color.RGBA{R: 140, G: 255, B: 120, A: 255}},
+	{ID: "title-sunset", Name: "Sunset", Kind: TitleTheme, Price: 15,
+		Color: color.RGBA{R: 60, G: 30, B: 90, A: 255}, Alt: color.RGBA{R: 230, G: 110, B: 70, A: 255}},
+	{ID: "title-ocean", Name: "Deep Sea", Kind: TitleTheme, Price: 15,
+		Color: color.RGBA{R: 10, G: 40, B: 80, A: 255}, Alt: color.RGBA{R: 20, G: 140, B: 150, A: 255}},
+	{ID: "title-terminal", Name: "Terminal", Kind: TitleTheme, Price: 15,
+		Color: color.RGBA{R: 0, G: 20, B: 0, A: 255}, Alt: color.RGBA{R: 0, G: 90, B: 30, A: 255}},
+}
+
+// Lookup returns the catalog item with the given ID.
+func Lookup(id string) (Item, bool) {
+	if i := catalogIndex(id); i < len(Catalog) {
+		return Catalog[i], true
+	}
+
+	return Item{}, false
+}
+
+// catalogIndex returns the position of id in the Catalog, or len(Catalog)
+// when it is not there, e.g. an item from a newer build.
+func catalogIndex(id string) int {
+	if i := slices.IndexFunc(Catalog, func(it Item) bool { return it.ID == id }); i >= 0 {
+		return i
+	}
+
+	return len(Catalog)
+}
+
+// Theme returns base recolored by the palette p wears, or base itself when
+// it wears none. Register the result with ui.RegisterTheme to switch to it.
+func (p *Profile) Theme(base *ui.Theme) *ui.Theme {
+	it, ok := p.Wearing(Palette)
+	if !ok {
+		return base
+	}
+
+	t := base.Clone(base.Name + " " + it.Name)
+	t.Palette.Accent = it.Color
+	t.Palette.Highlight = it.Alt
+
+	return t
+}
+
+// DrawTitleBackdrop fills screen with the title theme p wears, a vertical
+// gradient, and reports whether it drew one. Title screens draw their own
+// background when it returns false.
+func (p *Profile) DrawTitleBackdrop(screen *ebiten.Image) bool {
+	it, ok := p.Wearing(TitleTheme)
+	if !ok {
+		return false
+	}
+
+	drawGradient(screen, it)
+
+	return true
+}
+
+// drawGradient fills screen from it.Color at the top to it.Alt at the bottom.
+func drawGradient(screen *ebiten.Image, it Item) {
+	const band = 4
+
+	b := screen.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y += band {
+		c := colorutil.Lerp(it.Color, it.Alt, float64(y-b.Min.Y)/float64(b.Dy()))
+		vector.DrawFilledRect(screen, float32(b.Min.X), float32(y), float32(b.Dx()), band, c, false)
+	}
+}
+
+// petImages caches generated pet sprites by item ID.
+var petImages = map[string]*ebiten.Image{}
+
+// DrawPet draws the pet p wears with its feet at (x, y), bobbing gently, and
+// reports whether it has one.
+func (p *Profile) DrawPet(screen *ebiten.Image, x, y float64) bool {
+	it, ok := p.Wearing(Pet)
+	if !ok {
+		return false
+	}
+
+	drawPet(screen, it, x, y)
+
+	return true
+}
+
+// drawPet draws pet it with its feet at (x, y).
+func drawPet(screen *ebiten.Image, it Item, x, y float64) {
+	img, ok := petImages[it.ID]
+	if !ok {
+		pal := graphics.PaletteFromColor(it.Color)
+		img = ebiten.NewImageFromImage(graphics.GenerateSprite(graphics.SeedFromName(it.ID),
+			graphics.SpriteOptions{Size: graphics.DefaultSpriteSize, Palette: &pal}))
+		petImages[it.ID] = img
+	}
+
+	bob := math.Sin(float64(ebiten.Tick())/20) * 2
+	size := float64(img.Bounds().Dx())
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x-size/2, y-size+bob)
+	screen.DrawImage(img, op)
+}
@@ -0,0 +1,229 @@
+// Package profile keeps the player's framework tokens and cosmetic unlocks.
+// Every example shares one profile: playing any of them earns tokens, and
+// the token shop spends them on palettes, pets, and title-screen themes that
+// the games can show.
+//
+// The profile is a save slot in its own app directory. Each change reloads
+// the slot before writing it back, so two games open at once do not drop
+// each other's tokens.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// App is where the shared profile is kept.
+var App = paths.App{Vendor: "neuralway", Name: "profile"}
+
+// slot is the save slot holding the profile.
+const slot = "profile"
+
+// Save data keys.
+const (
+	keyTokens   = "tokens"
+	prefixEarn  = "earned:"
+	prefixOwn   = "owned:"
+	prefixEquip = "equipped:"
+)
+
+var (
+	// ErrOwned is returned when buying an item the profile already has.
+	ErrOwned = errors.New("profile: already owned")
+	// ErrTooPoor is returned when buying an item costs more than the balance.
+	ErrTooPoor = errors.New("profile: not enough tokens")
+	// ErrNotOwned is returned when equipping an item that was never bought.
+	ErrNotOwned = errors.New("profile: not owned")
+)
+
+// Profile is the player's token balance and cosmetics.
+type Profile struct {
+	Tokens   int
+	Earned   map[string]int  // Lifetime tokens by the game that earned them
+	Owned    []string        // IDs of the items bought
+	Equipped map[Kind]string // Item ID worn for each kind
+
+	store *game.SaveManager // Nil keeps the profile in memory
+}
+
+// New returns an empty profile saved to store, or kept in memory if store
+// is nil.
+func New(store *game.SaveManager) *Profile {
+	return &Profile{
+		Earned:   make(map[string]int),
+		Equipped: make(map[Kind]string),
+		store:    store,
+	}
+}
+
+// Load reads the profile from store, starting empty when none was saved.
+func Load(store *game.SaveManager) (*Profile, error) {
+	p := New(store)
+
+	return p, p.reload()
+}
+
+// Open loads the shared profile from the user data directory. When that
+// cannot be read it logs why and returns an empty profile in memory, so a
+// game never fails to start over its cosmetics.
+func Open() *Profile {
+	fsys, err := App.Open(paths.Data)
+	if err != nil {
+		log.Printf("profile: %v", err)
+
+		return New(nil)
+	}
+
+	p, err := Load(game.NewSaveManagerFS(fsys))
+	if err != nil {
+		log.Printf("profile: %v", err)
+	}
+
+	return p
+}
+
+// Earn adds n tokens earned by the named game and saves the profile.
+func (p *Profile) Earn(gameName string, n int) error {
+	return p.change(func() error {
+		p.Tokens += n
+		p.Earned[gameName] += n
+
+		return nil
+	})
+}
+
+// Owns reports whether the item with the given ID was bought.
+func (p *Profile) Owns(id string) bool {
+	return slices.Contains(p.Owned, id)
+}
+
+// Buy spends it.Price tokens on it and saves the profile.
+func (p *Profile) Buy(it Item) error {
+	return p.change(func() error {
+		switch {
+		case p.Owns(it.ID):
+			return fmt.Errorf("%w: %s", ErrOwned, it.Name)
+		case p.Tokens < it.Price:
+			return fmt.Errorf("%w: %s costs %d, have %d", ErrTooPoor, it.Name, it.Price, p.Tokens)
+		}
+
+		p.Tokens -= it.Price
+		p.Owned = append(p.Owned, it.ID)
+
+		return nil
+	})
+}
+
+// Equip wears an owned item in place of any other of its kind and saves the
+// profile.
+func (p *Profile) Equip(it Item) error {
+	return p.change(func() error {
+		if !p.Owns(it.ID) {
+			return fmt.Errorf("%w: %s", ErrNotOwned, it.Name)
+		}
+
+		p.Equipped[it.Kind] = it.ID
+
+		return nil
+	})
+}
+
+// Unequip takes off the item worn for kind and saves the profile.
+func (p *Profile) Unequip(kind Kind) error {
+	return p.change(func() error {
+		delete(p.Equipped, kind)
+
+		return nil
+	})
+}
+
+// Wearing returns the item worn for kind, if any.
+func (p *Profile) Wearing(kind Kind) (Item, bool) {
+	if p == nil {
+		return Item{}, false
+	}
+
+	id, ok := p.Equipped[kind]
+	if !ok {
+		return Item{}, false
+	}
+
+	return Lookup(id)
+}
+
+// change reloads the saved profile, applies fn, and saves the result. The
+// profile is left as reloaded when fn fails.
+func (p *Profile) change(fn func() error) error {
+	if err := p.reload(); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return p.save()
+}
+
+// reload replaces p with the saved profile, if there is one.
+func (p *Profile) reload() error {
+	if p.store == nil || !p.store.Exists(slot) {
+		return nil
+	}
+
+	save, err := p.store.Load(slot)
+	if err != nil {
+		return err
+	}
+
+	p.Tokens = save.GetInt(keyTokens, 0)
+	p.Earned = make(map[string]int)
+	p.Owned = p.Owned[:0]
+	p.Equipped = make(map[Kind]string)
+
+	for key := range save.Data {
+		switch {
+		case strings.HasPrefix(key, prefixEarn):
+			p.Earned[strings.TrimPrefix(key, prefixEarn)] = save.GetInt(key, 0)
+		case strings.HasPrefix(key, prefixOwn):
+			p.Owned = append(p.Owned, strings.TrimPrefix(key, prefixOwn))
+		case strings.HasPrefix(key, prefixEquip):
+			p.Equipped[Kind(strings.TrimPrefix(key, prefixEquip))] = save.GetString(key, "")
+		}
+	}
+
+	// Save data is a map; keep purchases in catalog order
+	slices.SortFunc(p.Owned, func(a, b string) int { return catalogIndex(a) - catalogIndex(b) })
+
+	return nil
+}
+
+// save writes p to its store.
+func (p *Profile) save() error {
+	if p.store == nil {
+		return nil
+	}
+
+	save := game.NewSaveData(slot)
+	save.Set(keyTokens, p.Tokens)
+
+	for name, n := range p.Earned {
+		save.Set(prefixEarn+name, n)
+	}
+
+	for _, id := range p.Owned {
+		save.Set(prefixOwn+id, true)
+	}
+
+	for kind, id := range p.Equipped {
+		save.Set(prefixEquip+string(kind), id)
+	}
+
+	return p.store.Save(slot, save)
+}
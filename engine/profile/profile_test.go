@@ -0,0 +1,86 @@
+package profile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestBuyAndEquipPersist(t *testing.T) {
+	store := game.NewSaveManagerFS(paths.MemFS())
+	p := New(store)
+
+	pet, _ := Lookup("pet-mochi")
+	if err := p.Buy(pet); !errors.Is(err, ErrTooPoor) {
+		t.Errorf("buying with no tokens: %v", err)
+	}
+
+	if err := p.Equip(pet); !errors.Is(err, ErrNotOwned) {
+		t.Errorf("equipping before buying: %v", err)
+	}
+
+	if err := p.Earn("snake", 12); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Buy(pet); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Buy(pet); !errors.Is(err, ErrOwned) {
+		t.Errorf("buying twice: %v", err)
+	}
+
+	if err := p.Equip(pet); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worn, ok := loaded.Wearing(Pet)
+	if !ok || worn.ID != pet.ID || loaded.Tokens != 2 || loaded.Earned["snake"] != 12 {
+		t.Errorf("reloaded: wearing %v %v, %d tokens, earned %v", worn.ID, ok, loaded.Tokens, loaded.Earned)
+	}
+}
+
+func TestTwoGamesShareTheBalance(t *testing.T) {
+	store := game.NewSaveManagerFS(paths.MemFS())
+	snake, pong := New(store), New(store)
+
+	// Each game holds its own copy; changes reload before writing
+	for range 3 {
+		if err := snake.Earn("snake", 1); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := pong.Earn("pong", 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if pong.Tokens != 9 || pong.Earned["snake"] != 3 || pong.Earned["pong"] != 6 {
+		t.Errorf("balance %d, earned %v", pong.Tokens, pong.Earned)
+	}
+}
+
+func TestInMemoryProfileAndNilWearsNothing(t *testing.T) {
+	var none *Profile
+	if _, ok := none.Wearing(TitleTheme); ok {
+		t.Error("a nil profile wears a title theme")
+	}
+
+	p := New(nil)
+	if err := p.Earn("flappy", 20); err != nil {
+		t.Fatal(err)
+	}
+
+	sunset, _ := Lookup("title-sunset")
+	if err := p.Buy(sunset); err != nil || p.Tokens != 5 {
+		t.Errorf("buy: %v, %d left", err, p.Tokens)
+	}
+}
@@ -0,0 +1,184 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"maps"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Shop layout, in pixels.
+const (
+	shopRowH    = 22
+	shopTop     = 70
+	shopListX   = 30
+	shopListW   = 360
+	shopPreview = 420
+
+	shopMessageTime = 2.5 // Seconds a purchase message stays up
+)
+
+// Shop is the token shop scene: browse the Catalog, buy items with tokens,
+// and equip or take off what is owned. Up/down move, Confirm buys or toggles
+// the focused item, and Cancel leaves.
+type Shop struct {
+	Profile *Profile
+	OnExit  func() // Called on Cancel; nil ignores it
+
+	focus   int
+	message string
+	timer   float64
+
+	controls *input.Map
+	tps      func() int
+}
+
+// NewShop returns a shop spending p's tokens.
+func NewShop(p *Profile) *Shop {
+	return &Shop{Profile: p, controls: input.NewMap(), tps: ebiten.TPS}
+}
+
+// Load reloads the profile, picking up tokens earned in other games.
+func (s *Shop) Load() error {
+	return s.Profile.reload()
+}
+
+// Unload does nothing; every change is saved as it is made.
+func (s *Shop) Unload() {}
+
+// Update moves the focus and buys or equips the focused item.
+func (s *Shop) Update() error {
+	s.timer = max(0, s.timer-1/float64(s.tps()))
+
+	n := len(Catalog)
+	s.focus = (s.focus + s.controls.JustMoved(input.MoveY) + n) % n
+
+	if s.controls.JustPressed(input.Confirm) {
+		s.choose(Catalog[s.focus])
+	}
+
+	if s.controls.JustPressed(input.Cancel) && s.OnExit != nil {
+		s.OnExit()
+	}
+
+	return nil
+}
+
+// choose buys it, or toggles it on or off when it is already owned.
+func (s *Shop) choose(it Item) {
+	p := s.Profile
+
+	var err error
+
+	switch {
+	case !p.Owns(it.ID):
+		if err = p.Buy(it); err == nil {
+			err = p.Equip(it)
+			s.say(fmt.Sprintf("Bought %s", it.Name))
+		}
+	case p.Equipped[it.Kind] == it.ID:
+		err = p.Unequip(it.Kind)
+		s.say(fmt.Sprintf("Took off %s", it.Name))
+	default:
+		err = p.Equip(it)
+		s.say(fmt.Sprintf("Equipped %s", it.Name))
+	}
+
+	switch {
+	case errors.Is(err, ErrTooPoor):
+		s.say(fmt.Sprintf("%s needs %d more tokens", it.Name, it.Price-p.Tokens))
+	case err != nil:
+		s.say(err.Error())
+	}
+}
+
+// say shows a message under the list.
+func (s *Shop) say(msg string) {
+	s.message, s.timer = msg, shopMessageTime
+}
+
+// Draw draws the item list, the balance, and a preview of the focused item.
+func (s *Shop) Draw(screen *ebiten.Image) {
+	pal := ui.CurrentTheme().Palette
+	p := s.Profile
+
+	screen.Fill(pal.Background)
+	ebitenutil.DebugPrintAt(screen, "FRAMEWORK TOKEN SHOP", shopListX, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Tokens: %d", p.Tokens), shopListX, 40)
+
+	for i, it := range Catalog {
+		y := shopTop + i*shopRowH
+
+		if i == s.focus {
+			vector.DrawFilledRect(screen, shopListX-6, float32(y-3), shopListW, shopRowH-2, pal.ButtonHover, false)
+		}
+
+		vector.DrawFilledRect(screen, shopListX, float32(y+2), 10, 10, it.Color, false)
+
+		status := fmt.Sprintf("%d tokens", it.Price)
+		if p.Owns(it.ID) {
+			status = "owned"
+		}
+
+		if p.Equipped[it.Kind] == it.ID {
+			status = "equipped"
+		}
+
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%-6s %-10s %s", it.Kind, it.Name, status), shopListX+18, y)
+	}
+
+	bottom := shopTop + len(Catalog)*shopRowH + 10
+	if s.timer > 0 {
+		ebitenutil.DebugPrintAt(screen, s.message, shopListX, bottom)
+	}
+
+	ebitenutil.DebugPrintAt(screen, "UP/DOWN choose | ENTER buy or equip | ESC back", shopListX, bottom+20)
+	s.drawEarnings(screen, bottom+50)
+	s.drawPreview(screen, Catalog[s.focus])
+}
+
+// drawEarnings lists lifetime tokens by game, most first.
+func (s *Shop) drawEarnings(screen *ebiten.Image, y int) {
+	earned := s.Profile.Earned
+	if len(earned) == 0 {
+		ebitenutil.DebugPrintAt(screen, "Play any example to earn tokens: 1 per active minute", shopListX, y)
+
+		return
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Earned in:", shopListX, y)
+
+	games := slices.SortedFunc(maps.Keys(earned), func(a, b string) int { return earned[b] - earned[a] })
+	for i, name := range games[:min(len(games), 6)] {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  %-18s %d", name, earned[name]), shopListX, y+16*(i+1))
+	}
+}
+
+// drawPreview shows the focused item as it looks in a game.
+func (s *Shop) drawPreview(screen *ebiten.Image, it Item) {
+	const w, h = 200, 150
+
+	frame := screen.SubImage(image.Rect(shopPreview, shopTop, shopPreview+w, shopTop+h)).(*ebiten.Image)
+	frame.Fill(color.RGBA{R: 10, G: 10, B: 16, A: 255})
+
+	switch it.Kind {
+	case TitleTheme:
+		drawGradient(frame, it)
+		ebitenutil.DebugPrintAt(frame, "TITLE", shopPreview+w/2-15, shopTop+h/2-8)
+	case Pet:
+		drawPet(frame, it, shopPreview+w/2, shopTop+h/2+30)
+	case Palette:
+		vector.DrawFilledRect(frame, shopPreview+30, shopTop+40, w-60, 30, it.Color, false)
+		vector.DrawFilledRect(frame, shopPreview+30, shopTop+80, w-60, 30, it.Alt, false)
+	}
+
+	ebitenutil.DebugPrintAt(screen, it.Name, shopPreview, shopTop+h+8)
+}
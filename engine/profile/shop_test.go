@@ -0,0 +1,37 @@
+package profile
+
+import "testing"
+
+func TestShopBuysThenTogglesEquip(t *testing.T) {
+	p := New(nil)
+	s := NewShop(p)
+	mint, _ := Lookup("palette-mint")
+
+	s.choose(mint)
+
+	if p.Owns(mint.ID) || s.message != "Mint needs 5 more tokens" {
+		t.Errorf("too poor: owns %v, message %q", p.Owns(mint.ID), s.message)
+	}
+
+	if err := p.Earn("pong", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	s.choose(mint)
+
+	if worn, ok := p.Wearing(Palette); !ok || worn.ID != mint.ID || p.Tokens != 0 {
+		t.Errorf("bought: wearing %v, %d tokens", worn.ID, p.Tokens)
+	}
+
+	s.choose(mint)
+
+	if _, ok := p.Wearing(Palette); ok {
+		t.Error("choosing a worn item should take it off")
+	}
+
+	s.choose(mint)
+
+	if _, ok := p.Wearing(Palette); !ok || p.Tokens != 0 {
+		t.Errorf("re-equipping should be free: %d tokens", p.Tokens)
+	}
+}
@@ -0,0 +1,100 @@
+package profile
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+)
+
+const (
+	// EarnInterval is the seconds of play that earn one token.
+	EarnInterval = 60.0
+	// EarnIdle is the seconds without input after which play stops counting,
+	// so a game left open does not farm tokens.
+	EarnIdle = 30.0
+
+	earnToast = 2.0 // Seconds the "+1 token" note stays up
+)
+
+// TokenGame wraps a game so that playing it earns framework tokens.
+type TokenGame struct {
+	ebiten.Game
+
+	Name    string   // Game the tokens are credited to
+	Profile *Profile // Opened on the first token when nil
+
+	played float64 // Seconds of play toward the next token
+	toast  float64 // Seconds the earn note has left
+	idle   engine.IdleDetector
+
+	// Platform hooks, replaced in tests
+	poll    func() bool
+	focused func() bool
+	tps     func() int
+}
+
+// WithTokens wraps game so that every EarnInterval seconds of active play
+// credit one token to name in the shared profile. Time stops counting while
+// the window is unfocused or after EarnIdle seconds without input. Wrap the
+// outermost game, so interfaces the engine wrappers look for stay visible.
+func WithTokens(game ebiten.Game, name string) *TokenGame {
+	t := &TokenGame{
+		Game:    game,
+		Name:    name,
+		idle:    engine.IdleDetector{Timeout: EarnIdle},
+		focused: ebiten.IsFocused,
+		tps:     ebiten.TPS,
+	}
+	t.poll = t.idle.Poll
+
+	return t
+}
+
+// Update runs the game and counts the tick toward the next token.
+func (t *TokenGame) Update() error {
+	if err := t.Game.Update(); err != nil {
+		return err
+	}
+
+	dt := 1 / float64(t.tps())
+	t.toast = max(0, t.toast-dt)
+
+	if t.idle.Update(dt, t.poll()) || !t.focused() {
+		return nil
+	}
+
+	t.played += dt
+	if t.played < EarnInterval {
+		return nil
+	}
+
+	t.played -= EarnInterval
+
+	if t.Profile == nil {
+		t.Profile = Open()
+	}
+
+	if err := t.Profile.Earn(t.Name, 1); err != nil {
+		log.Printf("profile: %v", err)
+
+		return nil
+	}
+
+	t.toast = earnToast
+
+	return nil
+}
+
+// Draw draws the game and, briefly after a token is earned, a note with the
+// new balance in the bottom-left corner.
+func (t *TokenGame) Draw(screen *ebiten.Image) {
+	t.Game.Draw(screen)
+
+	if t.toast > 0 {
+		msg := fmt.Sprintf("+1 token (%d)", t.Profile.Tokens)
+		ebitenutil.DebugPrintAt(screen, msg, 8, screen.Bounds().Dy()-20)
+	}
+}
@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// idleGame counts updates.
+type idleGame struct{ updates int }
+
+func (g *idleGame) Update() error              { g.updates++; return nil }
+func (g *idleGame) Draw(*ebiten.Image)         {}
+func (g *idleGame) Layout(w, h int) (int, int) { return w, h }
+
+func TestTokensEarnedOnlyForActivePlay(t *testing.T) {
+	inner := &idleGame{}
+	active, focused := true, true
+
+	tg := WithTokens(inner, "snake")
+	tg.Profile = New(nil)
+	tg.poll = func() bool { return active }
+	tg.focused = func() bool { return focused }
+	tg.tps = func() int { return 1 }
+
+	tick := func(n int) {
+		for range n {
+			if err := tg.Update(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tick(EarnInterval)
+
+	if tg.Profile.Tokens != 1 || tg.Profile.Earned["snake"] != 1 || tg.toast == 0 {
+		t.Fatalf("after a minute of play: %d tokens, earned %v", tg.Profile.Tokens, tg.Profile.Earned)
+	}
+
+	// Unfocused time and idle time past the cutoff do not count
+	focused = false
+	tick(EarnInterval)
+
+	focused, active = true, false
+	tick(EarnInterval * 2)
+
+	if tg.Profile.Tokens != 1 {
+		t.Errorf("%d tokens after unfocused and idle minutes", tg.Profile.Tokens)
+	}
+
+	if inner.updates != EarnInterval*4 {
+		t.Errorf("game updated %d times", inner.updates)
+	}
+}
@@ -0,0 +1,71 @@
+package rng
+
+import "testing"
+
+func draw(r *Rand, name string, n int) []int64 {
+	out := make([]int64, n)
+	for i := range out {
+		out[i] = r.Stream(name).Int63()
+	}
+
+	return out
+}
+
+func TestSameSeedReplaysStreams(t *testing.T) {
+	a, b := draw(New(42), "loot", 5), draw(New(42), "loot", 5)
+
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same seed drew %v and %v", a, b)
+		}
+	}
+}
+
+func TestStreamsAreIndependent(t *testing.T) {
+	quiet, busy := New(7), New(7)
+
+	// Extra rolls in one stream must not shift another
+	draw(busy, "combat", 100)
+
+	a, b := draw(quiet, "loot", 5), draw(busy, "loot", 5)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("combat rolls shifted loot: %v vs %v", a, b)
+		}
+	}
+}
+
+func TestStreamSeedsDiffer(t *testing.T) {
+	seeds := map[int64]string{}
+
+	for _, seed := range []int64{0, 1, 2} {
+		for _, name := range []string{"spawn", "spawns", "loot", ""} {
+			s := StreamSeed(seed, name)
+			if prev, ok := seeds[s]; ok {
+				t.Errorf("seed %d %q collides with %s", seed, name, prev)
+			}
+
+			seeds[s] = name
+		}
+	}
+}
+
+func TestForkIsDeterministic(t *testing.T) {
+	a, b := New(3).Fork("level 1"), New(3).Fork("level 1")
+
+	if a.Seed() != b.Seed() || a.Seed() == New(3).Fork("level 2").Seed() {
+		t.Errorf("fork seeds = %d, %d", a.Seed(), b.Seed())
+	}
+}
+
+func TestNilRandIsUnseeded(t *testing.T) {
+	var r *Rand
+
+	if r.Stream("loot") != Unseeded() || r.Fork("x") != nil {
+		t.Error("nil Rand should hand out the unseeded stream")
+	}
+
+	if f := r.Stream("loot").Float64(); f < 0 || f >= 1 {
+		t.Errorf("Float64 = %v", f)
+	}
+}
@@ -0,0 +1,104 @@
+package spectator
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// pipMargin is the gap between the picture-in-picture view and the screen edge.
+const pipMargin = 10
+
+// DrawWorld renders the game world onto dst as seen by cam. cam's screen
+// size is dst's size.
+type DrawWorld func(dst *ebiten.Image, cam *game.Camera)
+
+// Draw renders the main view with labels and the stat popup, then the
+// picture-in-picture view in the top-right corner and a status line.
+func (o *Observer) Draw(screen *ebiten.Image, world DrawWorld) {
+	world(screen, o.Camera)
+
+	if o.Labels {
+		for _, e := range o.entities {
+			if o.Camera.IsVisible(e.X, e.Y) {
+				o.drawLabel(screen, e)
+			}
+		}
+	}
+
+	if e, ok := o.Find(o.Inspect); ok {
+		o.drawPopup(screen, e)
+	}
+
+	if o.PiPOn {
+		o.drawPiP(screen, world)
+	}
+
+	status := "SPECTATING  " + o.Mode.String()
+	if e, ok := o.Find(o.Target); ok && o.Mode == Follow {
+		status += ": " + e.Name
+	}
+
+	ebitenutil.DebugPrintAt(screen, status, pipMargin, int(o.Camera.ScreenHeight)-20)
+}
+
+// drawLabel writes e's name centered above it.
+func (o *Observer) drawLabel(screen *ebiten.Image, e Entity) {
+	x, y := o.Camera.WorldToScreen(e.X, e.Y-e.Radius)
+	ebitenutil.DebugPrintAt(screen, e.Name, int(x)-len(e.Name)*3, int(y)-18)
+}
+
+// drawPopup draws e's stats in a panel beside it.
+func (o *Observer) drawPopup(screen *ebiten.Image, e Entity) {
+	const lineH = 16
+
+	palette := ui.CurrentTheme().Palette
+
+	width := len(e.Name)
+	for _, s := range e.Stats {
+		width = max(width, len(s.Label)+len(s.Value)+2)
+	}
+
+	w, h := float32(width*6+16), float32((len(e.Stats)+1)*lineH+10)
+
+	sx, sy := o.Camera.WorldToScreen(e.X+e.Radius, e.Y-e.Radius)
+	x := min(float32(sx)+8, float32(o.Camera.ScreenWidth)-w)
+	y := max(float32(sy)-h, 0)
+
+	vector.FillRect(screen, x, y, w, h, color.NRGBA{R: 0, G: 0, B: 0, A: 190}, false)
+	vector.StrokeRect(screen, x, y, w, h, 1, palette.Highlight, false)
+	ebitenutil.DebugPrintAt(screen, e.Name, int(x)+8, int(y)+4)
+
+	for i, s := range e.Stats {
+		ebitenutil.DebugPrintAt(screen, s.Label+": "+s.Value, int(x)+8, int(y)+4+(i+1)*lineH)
+	}
+}
+
+// drawPiP renders the world through the picture-in-picture camera into an
+// offscreen image and draws it framed in the top-right corner.
+func (o *Observer) drawPiP(screen *ebiten.Image, world DrawWorld) {
+	w, h := int(o.PiP.ScreenWidth), int(o.PiP.ScreenHeight)
+	if o.pipView == nil || o.pipView.Bounds().Dx() != w || o.pipView.Bounds().Dy() != h {
+		o.pipView = ebiten.NewImage(w, h)
+	}
+
+	o.pipView.Clear()
+	world(o.pipView, o.PiP)
+
+	x := o.Camera.ScreenWidth - float64(w) - pipMargin
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, pipMargin)
+	screen.DrawImage(o.pipView, op)
+
+	border := ui.CurrentTheme().Palette.Highlight
+	vector.StrokeRect(screen, float32(x), pipMargin, float32(w), float32(h), 2, border, false)
+
+	if e, ok := o.Find(o.PiPTarget); ok {
+		ebitenutil.DebugPrintAt(screen, e.Name, int(x)+4, pipMargin+h+2)
+	}
+}
@@ -0,0 +1,57 @@
+package spectator
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ReadInput reads the default spectator controls: WASD or the arrow keys
+// to fly, the mouse wheel or +/- to zoom, Tab and Shift+Tab to cycle the
+// followed entity, F to fly free, P for picture-in-picture, and a left
+// click to inspect and follow an entity.
+func ReadInput() Input {
+	var in Input
+
+	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyLeft) {
+		in.PanX--
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyRight) {
+		in.PanX++
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyUp) {
+		in.PanY--
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyDown) {
+		in.PanY++
+	}
+
+	_, in.Zoom = ebiten.Wheel()
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadAdd) {
+		in.Zoom++
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadSubtract) {
+		in.Zoom--
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			in.Prev = true
+		} else {
+			in.Next = true
+		}
+	}
+
+	in.FreeFly = inpututil.IsKeyJustPressed(ebiten.KeyF)
+	in.TogglePiP = inpututil.IsKeyJustPressed(ebiten.KeyP)
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		in.Click, in.ClickX, in.ClickY = true, float64(x), float64(y)
+	}
+
+	return in
+}
@@ -0,0 +1,265 @@
+// Package spectator provides an observer camera for watching a game without
+// playing it, such as after the player is out of a local or networked round.
+// The observer follows any entity or flies freely, labels entities, pops up
+// the stats of the one being inspected, and can follow a second entity in a
+// picture-in-picture view.
+//
+// The package knows nothing about the game's entities: each frame the game
+// passes a snapshot of them to Update, and Draw calls back into the game to
+// render the world through the observer's cameras.
+package spectator
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+)
+
+// Default observer settings.
+const (
+	DefaultPanSpeed = 600.0 // World units per second at zoom 1
+	DefaultPiPScale = 0.28  // Picture-in-picture size as a fraction of the screen
+	zoomStep        = 0.1   // Zoom change per Input.Zoom unit
+)
+
+// Stat is one line of an entity's stat popup.
+type Stat struct {
+	Label string
+	Value string
+}
+
+// Entity is what the observer needs to know about something it can watch.
+// IDs must stay the same across frames for following to work.
+type Entity struct {
+	ID     uint64
+	Name   string
+	X, Y   float64
+	Radius float64 // Used for picking by click and to place the label
+	Stats  []Stat
+}
+
+// Mode is how the main camera moves.
+type Mode int
+
+const (
+	FreeFly Mode = iota // Panned by the spectator
+	Follow              // Tracks the Target entity
+)
+
+// String returns the mode's display name.
+func (m Mode) String() string {
+	if m == Follow {
+		return "Follow"
+	}
+
+	return "Free"
+}
+
+// Input is one frame of spectator controls. ReadInput fills it from the
+// keyboard and mouse; games and tests can also build it directly.
+type Input struct {
+	PanX, PanY float64 // Free-fly direction, each in [-1, 1]; panning leaves Follow
+	Zoom       float64 // Zoom steps, positive to zoom in
+	Next, Prev bool    // Follow the next or previous entity by ID
+	FreeFly    bool    // Stop following
+	TogglePiP  bool    // Show or hide the picture-in-picture view
+
+	Click          bool // Inspect and follow the entity under the cursor
+	ClickX, ClickY float64
+}
+
+// Observer is a spectator's view of the game.
+type Observer struct {
+	Camera *game.Camera
+	Mode   Mode
+	Target uint64 // Entity followed in Follow mode
+
+	// Picture-in-picture follow of a second entity
+	PiP       *game.Camera
+	PiPOn     bool
+	PiPTarget uint64
+
+	Inspect  uint64 // Entity whose stat popup is shown; 0 shows none
+	Labels   bool   // Draw entity names
+	PanSpeed float64
+
+	entities []Entity // Latest snapshot, sorted by ID
+	pipView  *ebiten.Image
+}
+
+// New creates a free-flying observer for a screen of the given size,
+// centered on (x, y).
+func New(screenWidth, screenHeight, x, y float64) *Observer {
+	cam := game.NewCamera(screenWidth, screenHeight)
+	cam.LookAt(x, y)
+
+	pip := game.NewCamera(math.Round(screenWidth*DefaultPiPScale), math.Round(screenHeight*DefaultPiPScale))
+	pip.LookAt(x, y)
+
+	return &Observer{
+		Camera:   cam,
+		PiP:      pip,
+		Labels:   true,
+		PanSpeed: DefaultPanSpeed,
+	}
+}
+
+// Entities returns the snapshot passed to the last Update, sorted by ID.
+func (o *Observer) Entities() []Entity {
+	return o.entities
+}
+
+// Find returns the entity with id from the latest snapshot.
+func (o *Observer) Find(id uint64) (Entity, bool) {
+	i, ok := o.search(id)
+	if !ok {
+		return Entity{}, false
+	}
+
+	return o.entities[i], true
+}
+
+// FollowEntity switches to Follow mode on id.
+func (o *Observer) FollowEntity(id uint64) {
+	o.Mode, o.Target = Follow, id
+}
+
+// SetPiPTarget shows id in the picture-in-picture view.
+func (o *Observer) SetPiPTarget(id uint64) {
+	o.PiPOn, o.PiPTarget = true, id
+
+	if e, ok := o.Find(id); ok {
+		o.PiP.LookAt(e.X, e.Y)
+	}
+}
+
+// Update takes this frame's entities, applies in, and moves the cameras.
+// Following an entity that has gone away (it died, or its player left the
+// session) drops back to free-fly where it was last seen, and the
+// picture-in-picture view closes when its entity goes away.
+func (o *Observer) Update(dt float64, entities []Entity, in Input) {
+	o.entities = append(o.entities[:0], entities...)
+	slices.SortFunc(o.entities, func(a, b Entity) int { return cmp.Compare(a.ID, b.ID) })
+
+	o.handleInput(dt, in)
+
+	if o.Mode == Follow {
+		if e, ok := o.Find(o.Target); ok {
+			o.Camera.SetTarget(e.X, e.Y)
+		} else {
+			o.Mode = FreeFly
+			o.Camera.SetTarget(o.Camera.X, o.Camera.Y)
+		}
+	}
+
+	if _, ok := o.Find(o.Inspect); !ok {
+		o.Inspect = 0
+	}
+
+	o.Camera.Update(dt)
+
+	if !o.PiPOn {
+		return
+	}
+
+	if e, ok := o.Find(o.PiPTarget); ok {
+		o.PiP.SetTarget(e.X, e.Y)
+		o.PiP.Update(dt)
+	} else {
+		o.PiPOn = false
+	}
+}
+
+func (o *Observer) handleInput(dt float64, in Input) {
+	if in.Zoom != 0 {
+		o.Camera.SetZoom(o.Camera.Zoom * (1 + zoomStep*in.Zoom))
+	}
+
+	if in.PanX != 0 || in.PanY != 0 || in.FreeFly {
+		o.Mode = FreeFly
+		step := o.PanSpeed * dt / o.Camera.Zoom
+		o.Camera.SetTarget(o.Camera.TargetX+in.PanX*step, o.Camera.TargetY+in.PanY*step)
+	}
+
+	if in.Next {
+		o.cycle(1)
+	}
+
+	if in.Prev {
+		o.cycle(-1)
+	}
+
+	if in.Click {
+		o.Inspect = 0
+		if e, ok := o.EntityAt(in.ClickX, in.ClickY); ok {
+			o.Inspect = e.ID
+			o.FollowEntity(e.ID)
+		}
+	}
+
+	if in.TogglePiP {
+		if o.PiPOn {
+			o.PiPOn = false
+		} else if id, ok := o.neighbor(o.Target, 1); ok {
+			o.SetPiPTarget(id)
+		}
+	}
+}
+
+// cycle follows the entity dir places from the current target in ID order.
+func (o *Observer) cycle(dir int) {
+	if id, ok := o.neighbor(o.Target, dir); ok {
+		o.FollowEntity(id)
+	}
+}
+
+// neighbor returns the ID dir places from id in ID order, wrapping around.
+// An id not in the snapshot starts from the first or last entity.
+func (o *Observer) neighbor(id uint64, dir int) (uint64, bool) {
+	n := len(o.entities)
+	if n == 0 {
+		return 0, false
+	}
+
+	// Without id, i is where it would go: the next entity is at i itself
+	i, ok := o.search(id)
+	if !ok && dir > 0 {
+		dir--
+	}
+
+	return o.entities[((i+dir)%n+n)%n].ID, true
+}
+
+// EntityAt returns the entity under screen point (x, y) in the main view,
+// the smallest one when several overlap.
+func (o *Observer) EntityAt(x, y float64) (Entity, bool) {
+	wx, wy := o.Camera.ScreenToWorld(x, y)
+
+	var (
+		best  Entity
+		found bool
+	)
+
+	for _, e := range o.entities {
+		r := max(e.Radius, 6/o.Camera.Zoom) // Keep tiny entities clickable
+		if math.Hypot(e.X-wx, e.Y-wy) > r {
+			continue
+		}
+
+		if !found || e.Radius < best.Radius {
+			best, found = e, true
+		}
+	}
+
+	return best, found
+}
+
+// search finds id in the sorted snapshot, like slices.BinarySearch.
+func (o *Observer) search(id uint64) (int, bool) {
+	return slices.BinarySearchFunc(o.entities, id, func(e Entity, id uint64) int {
+		return cmp.Compare(e.ID, id)
+	})
+}
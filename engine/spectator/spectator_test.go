@@ -0,0 +1,142 @@
+package spectator
+
+import (
+	"testing"
+)
+
+const frame = 1.0 / 60
+
+func testEntities() []Entity {
+	return []Entity{
+		{ID: 3, Name: "c", X: 300, Y: 0, Radius: 10},
+		{ID: 1, Name: "a", X: 100, Y: 0, Radius: 10},
+		{ID: 2, Name: "b", X: 200, Y: 0, Radius: 30},
+	}
+}
+
+func TestCycleFollowsEntitiesInIDOrder(t *testing.T) {
+	o := New(800, 600, 0, 0)
+	o.Update(frame, testEntities(), Input{})
+
+	var got []uint64
+
+	for range 4 {
+		o.Update(frame, testEntities(), Input{Next: true})
+		got = append(got, o.Target)
+	}
+
+	want := []uint64{1, 2, 3, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Next order = %v, want %v", got, want)
+		}
+	}
+
+	o.Update(frame, testEntities(), Input{Prev: true})
+
+	if o.Target != 3 || o.Mode != Follow {
+		t.Errorf("Prev = %d in %v, want 3 in Follow", o.Target, o.Mode)
+	}
+}
+
+func TestFollowMovesCameraToTarget(t *testing.T) {
+	o := New(800, 600, 0, 0)
+	o.Camera.Smoothing = 0
+	o.Update(frame, testEntities(), Input{})
+	o.FollowEntity(2)
+	o.Update(frame, testEntities(), Input{})
+
+	if o.Camera.X != 200 || o.Camera.Y != 0 {
+		t.Errorf("camera at (%v, %v), want (200, 0)", o.Camera.X, o.Camera.Y)
+	}
+}
+
+func TestPanningLeavesFollow(t *testing.T) {
+	o := New(800, 600, 0, 0)
+	o.Camera.Smoothing = 0
+	o.Update(frame, testEntities(), Input{})
+	o.FollowEntity(1)
+	o.Update(frame, testEntities(), Input{})
+	o.Update(1, testEntities(), Input{PanX: 1})
+
+	if o.Mode != FreeFly {
+		t.Fatalf("mode = %v after panning, want Free", o.Mode)
+	}
+
+	if want := 100 + DefaultPanSpeed; o.Camera.X != want {
+		t.Errorf("camera x = %v, want %v", o.Camera.X, want)
+	}
+}
+
+func TestLostTargetFallsBackToFreeFly(t *testing.T) {
+	o := New(800, 600, 0, 0)
+	o.Camera.Smoothing = 0
+	o.Update(frame, testEntities(), Input{})
+	o.FollowEntity(3)
+	o.SetPiPTarget(3)
+	o.Inspect = 3
+	o.Update(frame, testEntities(), Input{})
+
+	o.Update(frame, testEntities()[1:], Input{})
+
+	if o.Mode != FreeFly || o.PiPOn || o.Inspect != 0 {
+		t.Errorf("after target left: mode %v, pip %v, inspect %d", o.Mode, o.PiPOn, o.Inspect)
+	}
+
+	if o.Camera.X != 300 {
+		t.Errorf("camera x = %v, want to stay at 300", o.Camera.X)
+	}
+}
+
+func TestClickInspectsSmallestEntityUnderCursor(t *testing.T) {
+	o := New(800, 600, 200, 0)
+	o.Update(frame, []Entity{
+		{ID: 1, Name: "big", X: 200, Y: 0, Radius: 100},
+		{ID: 2, Name: "small", X: 210, Y: 0, Radius: 20},
+	}, Input{})
+
+	// Screen center is world (200, 0)
+	o.Update(frame, o.Entities(), Input{Click: true, ClickX: 405, ClickY: 300})
+
+	if o.Inspect != 2 || o.Target != 2 || o.Mode != Follow {
+		t.Errorf("click picked inspect %d target %d in %v, want 2, 2, Follow", o.Inspect, o.Target, o.Mode)
+	}
+
+	o.Update(frame, o.Entities(), Input{Click: true, ClickX: 0, ClickY: 0})
+
+	if o.Inspect != 0 {
+		t.Errorf("clicking empty space kept inspect %d", o.Inspect)
+	}
+}
+
+func TestTogglePiPFollowsNextEntity(t *testing.T) {
+	o := New(800, 600, 0, 0)
+	o.Update(frame, testEntities(), Input{})
+	o.FollowEntity(3)
+	o.Update(frame, testEntities(), Input{TogglePiP: true})
+
+	if !o.PiPOn || o.PiPTarget != 1 {
+		t.Fatalf("pip on %v target %d, want on following 1", o.PiPOn, o.PiPTarget)
+	}
+
+	if o.PiP.X != 100 {
+		t.Errorf("pip camera x = %v, want 100", o.PiP.X)
+	}
+
+	o.Update(frame, testEntities(), Input{TogglePiP: true})
+
+	if o.PiPOn {
+		t.Error("second toggle left the pip on")
+	}
+}
+
+func TestZoomScalesPanSpeed(t *testing.T) {
+	o := New(800, 600, 0, 0)
+	o.Camera.Smoothing = 0
+	o.Camera.SetZoom(2)
+	o.Update(1, nil, Input{PanY: 1})
+
+	if want := DefaultPanSpeed / 2.0; o.Camera.Y != want {
+		t.Errorf("camera y = %v at zoom 2, want %v", o.Camera.Y, want)
+	}
+}
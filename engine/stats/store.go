@@ -0,0 +1,351 @@
+// Package stats provides a persistent key-value store of counters and gauges
+// for achievements, unlocks, and analytics.
+//
+// Updates are lock-free atomics that only touch memory, so any goroutine can
+// record a stat every frame. Flush writes the whole store in one batch, and
+// only when something changed, by writing a temp file and renaming it over
+// the old one so a crash never leaves a half-written file.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Counter is a monotonically adjusted integer stat, e.g. total kills.
+type Counter struct {
+	v     atomic.Int64
+	dirty *atomic.Bool
+}
+
+// Add adds n and returns the new value.
+func (c *Counter) Add(n int64) int64 {
+	c.dirty.Store(true)
+
+	return c.v.Add(n)
+}
+
+// Inc adds one and returns the new value.
+func (c *Counter) Inc() int64 {
+	return c.Add(1)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() int64 {
+	return c.v.Load()
+}
+
+// Gauge is a float stat that is set rather than accumulated, e.g. best time.
+type Gauge struct {
+	bits  atomic.Uint64
+	dirty *atomic.Bool
+}
+
+// Set stores v.
+func (g *Gauge) Set(v float64) {
+	g.bits.Store(math.Float64bits(v))
+	g.dirty.Store(true)
+}
+
+// SetMax stores v if it is greater than the current value and reports whether
+// it did, for personal bests.
+func (g *Gauge) SetMax(v float64) bool {
+	for {
+		old := g.bits.Load()
+		if v <= math.Float64frombits(old) {
+			return false
+		}
+
+		if g.bits.CompareAndSwap(old, math.Float64bits(v)) {
+			g.dirty.Store(true)
+
+			return true
+		}
+	}
+}
+
+// Value returns the current value.
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(g.bits.Load())
+}
+
+// snapshot is the on-disk format.
+type snapshot struct {
+	Counters map[string]int64   `json:"counters"`
+	Gauges   map[string]float64 `json:"gauges"`
+}
+
+// Store holds the stats of one namespace, usually one game.
+type Store struct {
+	path string // Empty for an in-memory store
+
+	mu       sync.RWMutex
+	counters map[string]*Counter
+	gauges   map[string]*Gauge
+	dirty    atomic.Bool
+
+	flushMu sync.Mutex // Serializes writes to path
+}
+
+// NewMemory creates a store that is never written to disk.
+func NewMemory() *Store {
+	return &Store{
+		counters: make(map[string]*Counter),
+		gauges:   make(map[string]*Gauge),
+	}
+}
+
+// Open loads the stats for namespace from dir, creating an empty store if none
+// were saved yet. Each namespace is kept in its own file.
+func Open(dir, namespace string) (*Store, error) {
+	if namespace == "" || filepath.Base(namespace) != namespace {
+		return nil, fmt.Errorf("stats: invalid namespace %q", namespace)
+	}
+
+	s := NewMemory()
+	s.path = filepath.Join(dir, namespace+".stats.json")
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("stats: %s: %w", s.path, err)
+	}
+
+	for name, v := range snap.Counters {
+		s.Counter(name).v.Store(v)
+	}
+
+	for name, v := range snap.Gauges {
+		s.Gauge(name).bits.Store(math.Float64bits(v))
+	}
+
+	return s, nil
+}
+
+// Validate reports whether data is a stats file Open can load.
+func Validate(data []byte) error {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the named counter, creating it at zero. Keep the returned
+// pointer to avoid the map lookup on hot paths.
+func (s *Store) Counter(name string) *Counter {
+	s.mu.RLock()
+	c, ok := s.counters[name]
+	s.mu.RUnlock()
+
+	if ok {
+		return c
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.counters[name]; ok {
+		return c
+	}
+
+	c = &Counter{dirty: &s.dirty}
+	s.counters[name] = c
+
+	return c
+}
+
+// Gauge returns the named gauge, creating it at zero.
+func (s *Store) Gauge(name string) *Gauge {
+	s.mu.RLock()
+	g, ok := s.gauges[name]
+	s.mu.RUnlock()
+
+	if ok {
+		return g
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g, ok := s.gauges[name]; ok {
+		return g
+	}
+
+	g = &Gauge{dirty: &s.dirty}
+	s.gauges[name] = g
+
+	return g
+}
+
+// CounterValue returns the named counter's value, or zero if it does not exist.
+func (s *Store) CounterValue(name string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if c, ok := s.counters[name]; ok {
+		return c.Value()
+	}
+
+	return 0
+}
+
+// Names returns the counter and gauge names, sorted.
+func (s *Store) Names() (counters, gauges []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for name := range s.counters {
+		counters = append(counters, name)
+	}
+
+	for name := range s.gauges {
+		gauges = append(gauges, name)
+	}
+
+	sort.Strings(counters)
+	sort.Strings(gauges)
+
+	return counters, gauges
+}
+
+// Dirty reports whether any stat changed since the last flush.
+func (s *Store) Dirty() bool {
+	return s.dirty.Load()
+}
+
+// Flush writes every stat to disk in one batch if anything changed. It is a
+// no-op for in-memory stores.
+func (s *Store) Flush() error {
+	if s.path == "" || !s.dirty.Swap(false) {
+		return nil
+	}
+
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	if err := s.write(s.snapshot()); err != nil {
+		// Keep the changes pending so the next flush retries them
+		s.dirty.Store(true)
+
+		return err
+	}
+
+	return nil
+}
+
+// FlushEvery flushes in the background at the given interval until the
+// returned stop function is called, which performs a final flush.
+func (s *Store) FlushEvery(interval time.Duration, onError func(error)) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+
+	report := func(err error) {
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	go func() {
+		defer close(finished)
+
+		for {
+			select {
+			case <-ticker.C:
+				report(s.Flush())
+			case <-done:
+				ticker.Stop()
+				report(s.Flush())
+
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-finished
+		})
+	}
+}
+
+func (s *Store) snapshot() snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := snapshot{
+		Counters: make(map[string]int64, len(s.counters)),
+		Gauges:   make(map[string]float64, len(s.gauges)),
+	}
+
+	for name, c := range s.counters {
+		snap.Counters[name] = c.Value()
+	}
+
+	for name, g := range s.gauges {
+		snap.Gauges[name] = g.Value()
+	}
+
+	return snap
+}
+
+// write replaces the stats file atomically via a temp file and rename.
+func (s *Store) write(snap snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	return nil
+}
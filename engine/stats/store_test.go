@@ -0,0 +1,180 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := Open(dir, "survivor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Counter("kills").Add(42)
+	s.Gauge("best_time").SetMax(95.5)
+
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Dirty() {
+		t.Error("store should be clean after a flush")
+	}
+
+	loaded, err := Open(dir, "survivor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := loaded.CounterValue("kills"); got != 42 {
+		t.Errorf("kills = %d after reload, want 42", got)
+	}
+
+	if got := loaded.Gauge("best_time").Value(); got != 95.5 {
+		t.Errorf("best_time = %v after reload, want 95.5", got)
+	}
+
+	other, err := Open(dir, "snake")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := other.CounterValue("kills"); got != 0 {
+		t.Errorf("namespaces leaked: snake kills = %d", got)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "survivor.stats.json" {
+		t.Errorf("stats dir holds %v, want only the survivor file and no temp files", entries)
+	}
+}
+
+func TestStoreConcurrentCounters(t *testing.T) {
+	s := NewMemory()
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			for range 1000 {
+				s.Counter("hits").Inc()
+				s.Gauge("peak").SetMax(float64(s.CounterValue("hits")))
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := s.CounterValue("hits"); got != 8000 {
+		t.Errorf("hits = %d, want 8000", got)
+	}
+
+	if got := s.Gauge("peak").Value(); got != 8000 {
+		t.Errorf("peak = %v, want 8000", got)
+	}
+}
+
+func TestGaugeSetMax(t *testing.T) {
+	g := NewMemory().Gauge("best")
+
+	if !g.SetMax(10) || g.SetMax(5) || g.Value() != 10 {
+		t.Errorf("SetMax should keep the larger value, got %v", g.Value())
+	}
+}
+
+func TestFlushSkipsCleanAndMemoryStores(t *testing.T) {
+	if err := NewMemory().Flush(); err != nil {
+		t.Errorf("memory flush = %v, want nil", err)
+	}
+
+	dir := t.TempDir()
+
+	s, err := Open(dir, "pong")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "pong.stats.json")); !os.IsNotExist(err) {
+		t.Error("a store with no changes should not write a file")
+	}
+
+	if _, err := Open(dir, "../escape"); err == nil {
+		t.Error("namespaces containing path separators should be rejected")
+	}
+}
+
+func TestFlushEveryFinalFlush(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := Open(dir, "agar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := s.FlushEvery(time.Hour, func(err error) { t.Error(err) })
+	s.Counter("eaten").Add(3)
+	stop()
+
+	loaded, err := Open(dir, "agar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := loaded.CounterValue("eaten"); got != 3 {
+		t.Errorf("eaten = %d after stop, want 3 from the final flush", got)
+	}
+}
+
+func TestAchievementsBackedByStore(t *testing.T) {
+	s := NewMemory()
+	tracker := components.NewAchievementTracker()
+	tracker.Add(components.Achievement{ID: "hunter", Target: 10, Stat: "kills"})
+	tracker.Add(components.NewAchievement("manual", "Manual", "", 1))
+
+	s.Counter("kills").Add(4)
+
+	if unlocked := tracker.Sync(s); len(unlocked) != 0 {
+		t.Fatalf("unlocked %v at 4 kills", unlocked)
+	}
+
+	if p := tracker.Achievements["hunter"].Progress; p != 4 {
+		t.Errorf("progress = %d, want 4 from the counter", p)
+	}
+
+	s.Counter("kills").Add(6)
+
+	unlocked := tracker.Sync(s)
+	if len(unlocked) != 1 || unlocked[0] != "hunter" {
+		t.Errorf("unlocked %v, want [hunter]", unlocked)
+	}
+
+	if tracker.Achievements["manual"].Progress != 0 {
+		t.Error("achievements without a stat should not be synced")
+	}
+
+	if again := tracker.Sync(s); len(again) != 0 {
+		t.Errorf("Sync re-announced %v", again)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate([]byte(`{"counters":{"runs":3},"gauges":{}}`)); err != nil {
+		t.Errorf("valid stats rejected: %v", err)
+	}
+
+	if err := Validate([]byte("[1, 2]")); err == nil {
+		t.Error("non-stats JSON accepted")
+	}
+}
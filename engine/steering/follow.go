@@ -0,0 +1,31 @@
+package steering
+
+// Arrive returns the velocity that moves pos toward target at up to maxSpeed,
+// slowing linearly inside slowRadius and stopping inside stopRadius so a
+// follower settles instead of overshooting and jittering around the target.
+func Arrive(pos, target Vec2, maxSpeed, slowRadius, stopRadius float64) Vec2 {
+	offset := target.Sub(pos)
+	dist := offset.Len()
+
+	if dist <= stopRadius {
+		return Vec2{}
+	}
+
+	speed := maxSpeed
+	if dist < slowRadius {
+		speed = maxSpeed * (dist - stopRadius) / (slowRadius - stopRadius)
+	}
+
+	return offset.Scale(speed / dist)
+}
+
+// Behind returns the point distance behind a leader facing heading, where a
+// follower trails it. A zero heading counts as facing +X.
+func Behind(leader, heading Vec2, distance float64) Vec2 {
+	dir := heading.Normalize()
+	if dir == (Vec2{}) {
+		dir = Vec2{1, 0}
+	}
+
+	return leader.Sub(dir.Scale(distance))
+}
@@ -0,0 +1,44 @@
+package steering
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArriveSlowsAndStops(t *testing.T) {
+	target := Vec2{100, 0}
+
+	if v := Arrive(Vec2{-500, 0}, target, 50, 80, 10); math.Abs(v.Len()-50) > 1e-9 || v.X <= 0 {
+		t.Errorf("far away: velocity = %v, want full speed toward target", v)
+	}
+
+	if v := Arrive(Vec2{55, 0}, target, 50, 80, 10); math.Abs(v.Len()-25) > 1e-9 {
+		t.Errorf("inside slow radius: speed = %v, want 25", v.Len())
+	}
+
+	if v := Arrive(Vec2{95, 0}, target, 50, 80, 10); v != (Vec2{}) {
+		t.Errorf("inside stop radius: velocity = %v, want zero", v)
+	}
+}
+
+func TestArriveConverges(t *testing.T) {
+	pos, target := Vec2{0, 0}, Vec2{30, 40}
+
+	for range 600 {
+		pos = pos.Add(Arrive(pos, target, 120, 60, 2).Scale(1.0 / 60))
+	}
+
+	if d := target.Sub(pos).Len(); d > 2.5 {
+		t.Errorf("distance after 10s = %v, want within stop radius", d)
+	}
+}
+
+func TestBehind(t *testing.T) {
+	if p := Behind(Vec2{10, 10}, Vec2{0, 5}, 20); p != (Vec2{10, -10}) {
+		t.Errorf("Behind facing +Y = %v, want {10 -10}", p)
+	}
+
+	if p := Behind(Vec2{10, 10}, Vec2{}, 20); p != (Vec2{-10, 10}) {
+		t.Errorf("Behind with zero heading = %v, want {-10 10}", p)
+	}
+}
@@ -0,0 +1,205 @@
+package steering
+
+import "math"
+
+const orcaEpsilon = 1e-5
+
+// orcaLine is a half-plane constraint on an agent's velocity: permitted
+// velocities lie to the left of Direction through Point.
+type orcaLine struct {
+	point     Vec2
+	direction Vec2
+}
+
+// orcaLineFor builds the ORCA half-plane an agent must respect to avoid another body.
+// share is the fraction of the avoidance effort this agent takes on (0.5 is reciprocal,
+// 1 means the other body does not react, e.g. a static obstacle).
+func orcaLineFor(
+	pos, vel, otherPos, otherVel Vec2,
+	combinedRadius, share, timeHorizon, dt float64,
+) orcaLine {
+	relPos := otherPos.Sub(pos)
+	relVel := vel.Sub(otherVel)
+	distSq := relPos.LenSq()
+	combinedRadiusSq := combinedRadius * combinedRadius
+
+	var (
+		line orcaLine
+		u    Vec2
+	)
+
+	if distSq > combinedRadiusSq {
+		// No collision yet: project onto the truncated velocity obstacle cone
+		invTimeHorizon := 1 / timeHorizon
+		w := relVel.Sub(relPos.Scale(invTimeHorizon))
+		wLenSq := w.LenSq()
+		dot1 := w.Dot(relPos)
+
+		if dot1 < 0 && dot1*dot1 > combinedRadiusSq*wLenSq {
+			// Project on the cut-off circle
+			wLen := math.Sqrt(wLenSq)
+			unitW := w.Scale(1 / wLen)
+			line.direction = Vec2{unitW.Y, -unitW.X}
+			u = unitW.Scale(combinedRadius*invTimeHorizon - wLen)
+		} else {
+			// Project on the nearest leg of the cone
+			leg := math.Sqrt(distSq - combinedRadiusSq)
+			if relPos.Det(w) > 0 {
+				line.direction = Vec2{
+					relPos.X*leg - relPos.Y*combinedRadius,
+					relPos.X*combinedRadius + relPos.Y*leg,
+				}.Scale(1 / distSq)
+			} else {
+				line.direction = Vec2{
+					relPos.X*leg + relPos.Y*combinedRadius,
+					-relPos.X*combinedRadius + relPos.Y*leg,
+				}.Scale(-1 / distSq)
+			}
+
+			u = line.direction.Scale(relVel.Dot(line.direction)).Sub(relVel)
+		}
+	} else {
+		// Already overlapping: push apart within a single time step
+		invDt := 1 / dt
+		w := relVel.Sub(relPos.Scale(invDt))
+		wLen := w.Len()
+
+		unitW := Vec2{1, 0}
+		if wLen > orcaEpsilon {
+			unitW = w.Scale(1 / wLen)
+		}
+
+		line.direction = Vec2{unitW.Y, -unitW.X}
+		u = unitW.Scale(combinedRadius*invDt - wLen)
+	}
+
+	line.point = vel.Add(u.Scale(share))
+
+	return line
+}
+
+// linearProgram1 solves a one-dimensional linear program on the given line,
+// subject to the previous lines and the max-speed circle.
+func linearProgram1(lines []orcaLine, lineNo int, radius float64, opt Vec2, directionOpt bool, result *Vec2) bool {
+	ln := lines[lineNo]
+	dot := ln.point.Dot(ln.direction)
+
+	discriminant := dot*dot + radius*radius - ln.point.LenSq()
+	if discriminant < 0 {
+		// Max speed circle fully invalidates this line
+		return false
+	}
+
+	sqrtDisc := math.Sqrt(discriminant)
+	tLeft := -dot - sqrtDisc
+	tRight := -dot + sqrtDisc
+
+	for i := range lineNo {
+		denominator := ln.direction.Det(lines[i].direction)
+		numerator := lines[i].direction.Det(ln.point.Sub(lines[i].point))
+
+		if math.Abs(denominator) <= orcaEpsilon {
+			// Lines are (almost) parallel
+			if numerator < 0 {
+				return false
+			}
+
+			continue
+		}
+
+		t := numerator / denominator
+		if denominator >= 0 {
+			tRight = math.Min(tRight, t)
+		} else {
+			tLeft = math.Max(tLeft, t)
+		}
+
+		if tLeft > tRight {
+			return false
+		}
+	}
+
+	switch {
+	case directionOpt:
+		if opt.Dot(ln.direction) > 0 {
+			*result = ln.point.Add(ln.direction.Scale(tRight))
+		} else {
+			*result = ln.point.Add(ln.direction.Scale(tLeft))
+		}
+	default:
+		t := ln.direction.Dot(opt.Sub(ln.point))
+		t = math.Max(tLeft, math.Min(tRight, t))
+		*result = ln.point.Add(ln.direction.Scale(t))
+	}
+
+	return true
+}
+
+// linearProgram2 finds the velocity closest to opt satisfying all lines.
+// It returns the index of the first line that could not be satisfied, or len(lines).
+func linearProgram2(lines []orcaLine, radius float64, opt Vec2, directionOpt bool, result *Vec2) int {
+	switch {
+	case directionOpt:
+		*result = opt.Scale(radius)
+	case opt.LenSq() > radius*radius:
+		*result = opt.Normalize().Scale(radius)
+	default:
+		*result = opt
+	}
+
+	for i := range lines {
+		if lines[i].direction.Det(lines[i].point.Sub(*result)) > 0 {
+			prev := *result
+			if !linearProgram1(lines, i, radius, opt, directionOpt, result) {
+				*result = prev
+
+				return i
+			}
+		}
+	}
+
+	return len(lines)
+}
+
+// linearProgram3 minimises the maximum violation of the agent lines when the
+// problem is infeasible. The first numFixed lines (obstacles) are never relaxed.
+func linearProgram3(lines []orcaLine, numFixed, beginLine int, radius float64, result *Vec2) {
+	distance := 0.0
+
+	for i := beginLine; i < len(lines); i++ {
+		if lines[i].direction.Det(lines[i].point.Sub(*result)) <= distance {
+			continue
+		}
+
+		projLines := append([]orcaLine(nil), lines[:numFixed]...)
+
+		for j := numFixed; j < i; j++ {
+			var line orcaLine
+
+			determinant := lines[i].direction.Det(lines[j].direction)
+			if math.Abs(determinant) <= orcaEpsilon {
+				if lines[i].direction.Dot(lines[j].direction) > 0 {
+					// Same direction: already covered
+					continue
+				}
+
+				line.point = lines[i].point.Add(lines[j].point).Scale(0.5)
+			} else {
+				t := lines[j].direction.Det(lines[i].point.Sub(lines[j].point)) / determinant
+				line.point = lines[i].point.Add(lines[i].direction.Scale(t))
+			}
+
+			line.direction = lines[j].direction.Sub(lines[i].direction).Normalize()
+			projLines = append(projLines, line)
+		}
+
+		prev := *result
+		if linearProgram2(projLines, radius, Vec2{-lines[i].direction.Y, lines[i].direction.X}, true, result) <
+			len(projLines) {
+			// Should not happen in theory; keep the previous result on numerical failure
+			*result = prev
+		}
+
+		distance = lines[i].direction.Det(lines[i].point.Sub(*result))
+	}
+}
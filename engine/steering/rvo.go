@@ -0,0 +1,217 @@
+package steering
+
+import (
+	"math"
+	"sort"
+)
+
+// Agent is a body steered by the RVO solver.
+// Set PreferredVelocity each frame (e.g. toward the agent's goal); after Solve,
+// Velocity holds the collision-free velocity closest to it.
+type Agent struct {
+	Position          Vec2
+	Velocity          Vec2
+	PreferredVelocity Vec2
+	Radius            float64
+	MaxSpeed          float64
+
+	// Priority controls how avoidance effort is shared between two agents:
+	// an agent with higher priority yields less. Equal priorities split it evenly.
+	Priority float64
+
+	// Group restricts avoidance to agents in the same group (e.g. team), so
+	// friendly units flow around each other while still engaging enemies.
+	Group int
+
+	newVelocity Vec2
+}
+
+// Obstacle is a static circular obstacle agents steer around.
+type Obstacle struct {
+	Center Vec2
+	Radius float64
+}
+
+// RVOSolver computes reciprocal (ORCA) avoidance velocities for a set of agents.
+type RVOSolver struct {
+	// NeighborDist is the radius within which other agents are considered.
+	NeighborDist float64
+	// MaxNeighbors caps the neighbors considered per agent, nearest first.
+	MaxNeighbors int
+	// TimeHorizon is how far ahead (seconds) agent collisions are anticipated.
+	TimeHorizon float64
+	// ObstacleTimeHorizon is how far ahead obstacle collisions are anticipated.
+	ObstacleTimeHorizon float64
+
+	agents    []*Agent
+	obstacles []Obstacle
+
+	// Scratch buffers reused between solves
+	grid      map[[2]int][]int
+	neighbors []neighbor
+	lines     []orcaLine
+}
+
+type neighbor struct {
+	agent  *Agent
+	distSq float64
+}
+
+// NewRVOSolver creates a solver with defaults suited to pixel-space units.
+func NewRVOSolver() *RVOSolver {
+	return &RVOSolver{
+		NeighborDist:        80,
+		MaxNeighbors:        10,
+		TimeHorizon:         1.5,
+		ObstacleTimeHorizon: 0.5,
+		grid:                make(map[[2]int][]int),
+	}
+}
+
+// AddAgent registers an agent with the solver.
+func (s *RVOSolver) AddAgent(a *Agent) {
+	s.agents = append(s.agents, a)
+}
+
+// RemoveAgent unregisters an agent.
+func (s *RVOSolver) RemoveAgent(a *Agent) {
+	for i, other := range s.agents {
+		if other == a {
+			s.agents = append(s.agents[:i], s.agents[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// AddObstacle registers a static circular obstacle.
+func (s *RVOSolver) AddObstacle(o Obstacle) {
+	s.obstacles = append(s.obstacles, o)
+}
+
+// Clear removes all agents and obstacles.
+func (s *RVOSolver) Clear() {
+	s.agents = s.agents[:0]
+	s.obstacles = s.obstacles[:0]
+}
+
+// Agents returns the registered agents.
+func (s *RVOSolver) Agents() []*Agent {
+	return s.agents
+}
+
+// Solve computes new velocities for all agents for a step of dt seconds.
+// Positions are not changed; use Step to also integrate them.
+func (s *RVOSolver) Solve(dt float64) {
+	if dt <= 0 {
+		return
+	}
+
+	s.buildGrid()
+
+	for _, a := range s.agents {
+		a.newVelocity = s.computeVelocity(a, dt)
+	}
+
+	for _, a := range s.agents {
+		a.Velocity = a.newVelocity
+	}
+}
+
+// Step solves and then advances agent positions by their new velocities.
+func (s *RVOSolver) Step(dt float64) {
+	s.Solve(dt)
+
+	for _, a := range s.agents {
+		a.Position = a.Position.Add(a.Velocity.Scale(dt))
+	}
+}
+
+func (s *RVOSolver) cellOf(p Vec2) [2]int {
+	size := math.Max(s.NeighborDist, 1)
+
+	return [2]int{int(math.Floor(p.X / size)), int(math.Floor(p.Y / size))}
+}
+
+// buildGrid buckets agents into NeighborDist-sized cells for neighbor lookup.
+func (s *RVOSolver) buildGrid() {
+	for k, v := range s.grid {
+		s.grid[k] = v[:0]
+	}
+
+	for i, a := range s.agents {
+		c := s.cellOf(a.Position)
+		s.grid[c] = append(s.grid[c], i)
+	}
+}
+
+// findNeighbors collects the nearest same-group agents within NeighborDist.
+func (s *RVOSolver) findNeighbors(a *Agent) []neighbor {
+	s.neighbors = s.neighbors[:0]
+	rangeSq := s.NeighborDist * s.NeighborDist
+	c := s.cellOf(a.Position)
+
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for _, idx := range s.grid[[2]int{c[0] + dx, c[1] + dy}] {
+				other := s.agents[idx]
+				if other == a || other.Group != a.Group {
+					continue
+				}
+
+				distSq := other.Position.Sub(a.Position).LenSq()
+				if distSq < rangeSq {
+					s.neighbors = append(s.neighbors, neighbor{agent: other, distSq: distSq})
+				}
+			}
+		}
+	}
+
+	if s.MaxNeighbors > 0 && len(s.neighbors) > s.MaxNeighbors {
+		sort.Slice(s.neighbors, func(i, j int) bool { return s.neighbors[i].distSq < s.neighbors[j].distSq })
+		s.neighbors = s.neighbors[:s.MaxNeighbors]
+	}
+
+	return s.neighbors
+}
+
+// share returns the fraction of avoidance effort agent a takes against other.
+func share(a, other *Agent) float64 {
+	total := a.Priority + other.Priority
+	if total <= 0 {
+		return 0.5
+	}
+
+	return other.Priority / total
+}
+
+func (s *RVOSolver) computeVelocity(a *Agent, dt float64) Vec2 {
+	s.lines = s.lines[:0]
+
+	// Obstacle lines come first and are never relaxed by linearProgram3
+	for _, o := range s.obstacles {
+		reach := s.ObstacleTimeHorizon*a.MaxSpeed + a.Radius + o.Radius
+		if o.Center.Sub(a.Position).LenSq() > reach*reach {
+			continue
+		}
+
+		s.lines = append(s.lines,
+			orcaLineFor(a.Position, a.Velocity, o.Center, Vec2{}, a.Radius+o.Radius, 1, s.ObstacleTimeHorizon, dt))
+	}
+
+	numFixed := len(s.lines)
+
+	for _, n := range s.findNeighbors(a) {
+		other := n.agent
+		s.lines = append(s.lines, orcaLineFor(a.Position, a.Velocity, other.Position, other.Velocity,
+			a.Radius+other.Radius, share(a, other), s.TimeHorizon, dt))
+	}
+
+	var result Vec2
+
+	if fail := linearProgram2(s.lines, a.MaxSpeed, a.PreferredVelocity, false, &result); fail < len(s.lines) {
+		linearProgram3(s.lines, numFixed, fail, a.MaxSpeed, &result)
+	}
+
+	return result
+}
@@ -0,0 +1,159 @@
+package steering
+
+import (
+	"math"
+	"testing"
+)
+
+func steerToGoal(a *Agent, goal Vec2) {
+	toGoal := goal.Sub(a.Position)
+	if toGoal.Len() > a.MaxSpeed {
+		toGoal = toGoal.Normalize().Scale(a.MaxSpeed)
+	}
+
+	a.PreferredVelocity = toGoal
+}
+
+func minSeparation(agents []*Agent) float64 {
+	best := math.Inf(1)
+
+	for i := range agents {
+		for j := i + 1; j < len(agents); j++ {
+			d := agents[i].Position.Sub(agents[j].Position).Len() - agents[i].Radius - agents[j].Radius
+			best = math.Min(best, d)
+		}
+	}
+
+	return best
+}
+
+func TestRVOHeadOnAgentsPass(t *testing.T) {
+	s := NewRVOSolver()
+	a := &Agent{Position: Vec2{0, 0}, Radius: 10, MaxSpeed: 100, Priority: 1}
+	b := &Agent{Position: Vec2{200, 0}, Radius: 10, MaxSpeed: 100, Priority: 1}
+	s.AddAgent(a)
+	s.AddAgent(b)
+
+	worst := math.Inf(1)
+
+	for range 300 {
+		steerToGoal(a, Vec2{200, 0})
+		steerToGoal(b, Vec2{0, 0})
+		s.Step(1.0 / 60)
+
+		worst = math.Min(worst, minSeparation(s.Agents()))
+	}
+
+	if worst < -1 {
+		t.Errorf("agents overlapped by %.2f", -worst)
+	}
+
+	if a.Position.Sub(Vec2{200, 0}).Len() > 5 || b.Position.Len() > 5 {
+		t.Errorf("agents did not reach goals: a=%v b=%v", a.Position, b.Position)
+	}
+}
+
+func TestRVOCrowdsCrossing(t *testing.T) {
+	s := NewRVOSolver()
+	goals := make(map[*Agent]Vec2)
+
+	// Two 3x4 squads swap sides through each other
+	for row := range 4 {
+		for col := range 3 {
+			y := float64(row)*40 + float64(col)*3
+			left := &Agent{Position: Vec2{float64(col) * 40, y}, Radius: 8, MaxSpeed: 80, Priority: 1}
+			right := &Agent{Position: Vec2{300 - float64(col)*40, y + 5}, Radius: 8, MaxSpeed: 80, Priority: 1}
+
+			s.AddAgent(left)
+			s.AddAgent(right)
+
+			goals[left] = Vec2{300 - float64(col)*40, y}
+			goals[right] = Vec2{float64(col) * 40, y + 5}
+		}
+	}
+
+	worst := math.Inf(1)
+
+	for range 1200 {
+		for _, a := range s.Agents() {
+			steerToGoal(a, goals[a])
+		}
+
+		s.Step(1.0 / 60)
+
+		worst = math.Min(worst, minSeparation(s.Agents()))
+	}
+
+	if worst < -2 {
+		t.Errorf("crowd overlapped by %.2f", -worst)
+	}
+
+	for _, a := range s.Agents() {
+		if d := a.Position.Sub(goals[a]).Len(); d > 20 {
+			t.Errorf("agent stuck %.1f from goal", d)
+		}
+	}
+}
+
+func TestRVOObstacleAndGroups(t *testing.T) {
+	s := NewRVOSolver()
+	s.AddObstacle(Obstacle{Center: Vec2{100, 0}, Radius: 20})
+
+	a := &Agent{Position: Vec2{0, 1}, Radius: 8, MaxSpeed: 60, Priority: 1}
+	s.AddAgent(a)
+
+	for range 400 {
+		steerToGoal(a, Vec2{200, 0})
+		s.Step(1.0 / 60)
+
+		if d := a.Position.Sub(Vec2{100, 0}).Len(); d < 28-1 {
+			t.Fatalf("agent entered obstacle (dist %.2f)", d)
+		}
+	}
+
+	// Agents in different groups ignore each other
+	s2 := NewRVOSolver()
+	x := &Agent{Position: Vec2{0, 0}, Radius: 10, MaxSpeed: 50, Group: 0}
+	y := &Agent{Position: Vec2{15, 0}, Radius: 10, MaxSpeed: 50, Group: 1}
+	x.PreferredVelocity = Vec2{10, 0}
+	s2.AddAgent(x)
+	s2.AddAgent(y)
+	s2.Solve(1.0 / 60)
+
+	if x.Velocity != x.PreferredVelocity {
+		t.Errorf("cross-group agent should keep preferred velocity, got %v", x.Velocity)
+	}
+}
+
+func TestRVOPriorityAndNeighborCap(t *testing.T) {
+	if got := share(&Agent{Priority: 3}, &Agent{Priority: 1}); got != 0.25 {
+		t.Errorf("high priority share = %v, want 0.25", got)
+	}
+
+	if got := share(&Agent{}, &Agent{}); got != 0.5 {
+		t.Errorf("zero priority share = %v, want 0.5", got)
+	}
+
+	s := NewRVOSolver()
+	s.MaxNeighbors = 3
+
+	center := &Agent{Radius: 5, MaxSpeed: 10}
+	s.AddAgent(center)
+
+	for i := range 8 {
+		s.AddAgent(&Agent{Position: Vec2{float64(i+1) * 5, 0}, Radius: 1})
+	}
+
+	s.buildGrid()
+
+	got := s.findNeighbors(center)
+	if len(got) != 3 {
+		t.Fatalf("neighbors = %d, want 3", len(got))
+	}
+
+	for _, n := range got {
+		if n.distSq > 15*15 {
+			t.Errorf("neighbor at dist %.1f is not among the nearest", math.Sqrt(n.distSq))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Package steering provides local collision avoidance for groups of moving agents.
+package steering
+
+import "math"
+
+// Vec2 is a 2D vector used by the steering solvers.
+type Vec2 struct {
+	X, Y float64
+}
+
+// Add returns v + o.
+func (v Vec2) Add(o Vec2) Vec2 { return Vec2{v.X + o.X, v.Y + o.Y} }
+
+// Sub returns v - o.
+func (v Vec2) Sub(o Vec2) Vec2 { return Vec2{v.X - o.X, v.Y - o.Y} }
+
+// Scale returns v * s.
+func (v Vec2) Scale(s float64) Vec2 { return Vec2{v.X * s, v.Y * s} }
+
+// Dot returns the dot product of v and o.
+func (v Vec2) Dot(o Vec2) float64 { return v.X*o.X + v.Y*o.Y }
+
+// Det returns the 2D cross product (determinant) of v and o.
+func (v Vec2) Det(o Vec2) float64 { return v.X*o.Y - v.Y*o.X }
+
+// LenSq returns the squared length of v.
+func (v Vec2) LenSq() float64 { return v.X*v.X + v.Y*v.Y }
+
+// Len returns the length of v.
+func (v Vec2) Len() float64 { return math.Sqrt(v.LenSq()) }
+
+// Normalize returns v scaled to unit length, or the zero vector.
+func (v Vec2) Normalize() Vec2 {
+	l := v.Len()
+	if l == 0 {
+		return Vec2{}
+	}
+
+	return v.Scale(1 / l)
+}
@@ -0,0 +1,60 @@
+package systems
+
+import (
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// AbilityEndedEvent represents an ability's timed effect running out.
+type AbilityEndedEvent struct {
+	Entity    ecs.Entity
+	AbilityID string
+}
+
+// AbilitySystem ticks ability cooldowns and effect timers.
+type AbilitySystem struct {
+	filter  *ecs.Filter1[components.Abilities]
+	onEnded func(AbilityEndedEvent)
+}
+
+// NewAbilitySystem creates an ability system.
+func NewAbilitySystem(world *ecs.World) *AbilitySystem {
+	return &AbilitySystem{
+		filter: ecs.NewFilter1[components.Abilities](world),
+	}
+}
+
+// SetOnEnded sets the callback for when an ability's effect ends.
+func (s *AbilitySystem) SetOnEnded(fn func(AbilityEndedEvent)) {
+	s.onEnded = fn
+}
+
+// Update advances all abilities.
+func (s *AbilitySystem) Update(world *ecs.World, dt float64) {
+	query := s.filter.Query()
+	for query.Next() {
+		abilities := query.Get()
+		entity := query.Entity()
+
+		for _, id := range abilities.Update(dt) {
+			if s.onEnded != nil {
+				s.onEnded(AbilityEndedEvent{Entity: entity, AbilityID: id})
+			}
+		}
+	}
+}
+
+// Activate uses the ability in the given slot of an entity, returns true if successful.
+func (s *AbilitySystem) Activate(world *ecs.World, entity ecs.Entity, slot int) bool {
+	query := s.filter.Query()
+	for query.Next() {
+		if query.Entity() == entity {
+			abilities := query.Get()
+			query.Close()
+
+			return abilities.Activate(slot)
+		}
+	}
+
+	return false
+}
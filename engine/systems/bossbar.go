@@ -0,0 +1,83 @@
+package systems
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// BossTag is the Tag that makes an entity show on the boss bar.
+const BossTag = "boss"
+
+// BossBarSystem shows a ui.BossBar for the first living entity tagged
+// BossTag. The entity needs Health; an optional Name labels the bar and an
+// optional components.Boss adds phase markers and an enrage timer, which this
+// system advances.
+type BossBarSystem struct {
+	Bar *ui.BossBar
+
+	tags   *TagQuery
+	health *ecs.Map[components.Health]
+	names  *ecs.Map[components.Name]
+	bosses *ecs.Map[components.Boss]
+}
+
+// NewBossBarSystem creates a boss bar system drawing into bar.
+func NewBossBarSystem(world *ecs.World, bar *ui.BossBar) *BossBarSystem {
+	return &BossBarSystem{
+		Bar:    bar,
+		tags:   NewTagQuery(world),
+		health: ecs.NewMap[components.Health](world),
+		names:  ecs.NewMap[components.Name](world),
+		bosses: ecs.NewMap[components.Boss](world),
+	}
+}
+
+// Update advances boss timers and points the bar at the current boss.
+func (s *BossBarSystem) Update(world *ecs.World, dt float64) {
+	shown := false
+
+	for _, e := range s.tags.WithTag(BossTag) {
+		var boss *components.Boss
+		if s.bosses.Has(e) {
+			boss = s.bosses.Get(e)
+			boss.Elapsed += dt
+		}
+
+		if shown || !s.health.Has(e) {
+			continue
+		}
+
+		hp := s.health.Get(e)
+		if hp.Current <= 0 {
+			continue
+		}
+
+		status := ui.BossStatus{Name: "Boss", HP: float64(hp.Current), Max: float64(hp.Max), EnrageIn: -1}
+		if s.names.Has(e) {
+			status.Name = s.names.Get(e).Value
+		}
+
+		if boss != nil {
+			status.Phases = boss.Phases
+			status.EnrageIn = boss.EnrageIn()
+			status.Enraged = boss.Enraged()
+		}
+
+		s.Bar.Show(status)
+
+		shown = true
+	}
+
+	if !shown {
+		s.Bar.Hide()
+	}
+
+	s.Bar.Update(dt)
+}
+
+// Draw renders the boss bar.
+func (s *BossBarSystem) Draw(world *ecs.World, screen *ebiten.Image) {
+	s.Bar.Draw(screen)
+}
@@ -0,0 +1,58 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+func TestBossBarSystemTracksTaggedBoss(t *testing.T) {
+	world := ecs.NewWorld()
+	bosses := ecs.NewMap4[components.Tag, components.Name, components.Health, components.Boss](&world)
+	minions := ecs.NewMap2[components.Tag, components.Health](&world)
+
+	minions.NewEntity(&components.Tag{Name: "enemy"}, &components.Health{Current: 5, Max: 5})
+	boss := bosses.NewEntity(
+		&components.Tag{Name: BossTag},
+		&components.Name{Value: "Hard Deadline"},
+		&components.Health{Current: 1000, Max: 1000},
+		&components.Boss{Phases: []float64{0.66, 0.33}, EnrageTime: 10},
+	)
+
+	s := NewBossBarSystem(&world, ui.NewBossBar(100, 560, 600, 16))
+	s.Update(&world, 1)
+
+	status := s.Bar.Status()
+	if !s.Bar.Visible() || status.Name != "Hard Deadline" || len(status.Phases) != 2 {
+		t.Fatalf("bar = %+v (visible %v), want the tagged boss", status, s.Bar.Visible())
+	}
+
+	if status.EnrageIn != 9 {
+		t.Errorf("EnrageIn = %v after 1s, want 9", status.EnrageIn)
+	}
+
+	health := ecs.NewMap[components.Health](&world)
+	health.Get(boss).Current = 400
+	s.Update(&world, 0.1)
+
+	if ghost := s.Bar.Ghost(); ghost != 1 {
+		t.Errorf("ghost = %v right after the hit, want it held at 1", ghost)
+	}
+
+	for range 20 {
+		s.Update(&world, 1)
+	}
+
+	if !s.Bar.Status().Enraged {
+		t.Error("boss should be enraged past its enrage time")
+	}
+
+	health.Get(boss).Current = 0
+	s.Update(&world, 1)
+
+	if s.Bar.Visible() {
+		t.Error("bar should fade out once no boss is alive")
+	}
+}
@@ -0,0 +1,102 @@
+package systems
+
+import (
+	"math"
+	"sort"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// ChainConfig describes how a hit arcs to additional nearby targets.
+type ChainConfig struct {
+	Jumps   int     // Additional targets after the first hit
+	Range   float64 // Maximum length of a single arc
+	Falloff float64 // Damage multiplier per arc (0.7 = 30% less each jump)
+	Fork    bool    // Arc from the first target to every jump at once instead of hopping
+}
+
+// ChainArc is one arc between two targets, as indexes into the candidate slice.
+type ChainArc struct {
+	From, To int
+	Damage   float64
+}
+
+// ChainArcs plans the arcs of a hit of the given damage on positions[first].
+// A chain hops from each new target to the nearest unvisited one; a fork arcs
+// from the first target to its nearest neighbors. No target is arced to twice,
+// and skip (which may be nil) excludes candidates such as dead enemies.
+func ChainArcs(
+	cfg ChainConfig,
+	positions []components.Position,
+	first int,
+	damage float64,
+	skip func(i int) bool,
+) []ChainArc {
+	if cfg.Jumps <= 0 || first < 0 || first >= len(positions) {
+		return nil
+	}
+
+	visited := map[int]bool{first: true}
+	eligible := func(from, i int) bool {
+		if visited[i] || (skip != nil && skip(i)) {
+			return false
+		}
+
+		return chainDist(positions[from], positions[i]) <= cfg.Range
+	}
+
+	if cfg.Fork {
+		candidates := make([]int, 0, len(positions))
+
+		for i := range positions {
+			if eligible(first, i) {
+				candidates = append(candidates, i)
+			}
+		}
+
+		origin := positions[first]
+		sort.Slice(candidates, func(a, b int) bool {
+			return chainDist(origin, positions[candidates[a]]) < chainDist(origin, positions[candidates[b]])
+		})
+
+		arcs := make([]ChainArc, 0, min(cfg.Jumps, len(candidates)))
+		for _, i := range candidates[:min(cfg.Jumps, len(candidates))] {
+			arcs = append(arcs, ChainArc{From: first, To: i, Damage: damage * cfg.Falloff})
+		}
+
+		return arcs
+	}
+
+	arcs := make([]ChainArc, 0, cfg.Jumps)
+	current := first
+
+	for range cfg.Jumps {
+		next := -1
+		best := math.Inf(1)
+
+		for i := range positions {
+			if !eligible(current, i) {
+				continue
+			}
+
+			if d := chainDist(positions[current], positions[i]); d < best {
+				next, best = i, d
+			}
+		}
+
+		if next < 0 {
+			break
+		}
+
+		damage *= cfg.Falloff
+		arcs = append(arcs, ChainArc{From: current, To: next, Damage: damage})
+		visited[next] = true
+		current = next
+	}
+
+	return arcs
+}
+
+func chainDist(a, b components.Position) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
@@ -0,0 +1,68 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// line places targets along the x axis at the given coordinates.
+func line(xs ...float64) []components.Position {
+	out := make([]components.Position, len(xs))
+	for i, x := range xs {
+		out[i] = components.Position{X: x}
+	}
+
+	return out
+}
+
+func TestChainArcsHopWithFalloff(t *testing.T) {
+	positions := line(0, 50, 100, 150, 500)
+	cfg := ChainConfig{Jumps: 5, Range: 60, Falloff: 0.5}
+
+	arcs := ChainArcs(cfg, positions, 0, 100, nil)
+	if len(arcs) != 3 {
+		t.Fatalf("arcs = %v, want 3 hops stopping before the out-of-range target", arcs)
+	}
+
+	want := []ChainArc{{0, 1, 50}, {1, 2, 25}, {2, 3, 12.5}}
+	for i, a := range arcs {
+		if a != want[i] {
+			t.Errorf("arc %d = %+v, want %+v", i, a, want[i])
+		}
+	}
+}
+
+func TestChainArcsForkFromFirstTarget(t *testing.T) {
+	positions := line(0, -40, 30, 80, 20)
+	cfg := ChainConfig{Jumps: 2, Range: 50, Falloff: 0.5, Fork: true}
+
+	arcs := ChainArcs(cfg, positions, 0, 100, nil)
+	if len(arcs) != 2 {
+		t.Fatalf("arcs = %v, want 2 forks", arcs)
+	}
+
+	if arcs[0].To != 4 || arcs[1].To != 2 {
+		t.Errorf("forks went to %d and %d, want the two nearest (4, 2)", arcs[0].To, arcs[1].To)
+	}
+
+	for _, a := range arcs {
+		if a.From != 0 || a.Damage != 50 {
+			t.Errorf("fork %+v should start at the first target with one falloff step", a)
+		}
+	}
+}
+
+func TestChainArcsSkipAndBounds(t *testing.T) {
+	positions := line(0, 10, 20)
+	cfg := ChainConfig{Jumps: 2, Range: 15, Falloff: 1}
+
+	arcs := ChainArcs(cfg, positions, 0, 10, func(i int) bool { return i == 1 })
+	if len(arcs) != 0 {
+		t.Errorf("skipped target should break the chain, got %v", arcs)
+	}
+
+	if ChainArcs(cfg, positions, 7, 10, nil) != nil {
+		t.Error("out-of-range first index should produce no arcs")
+	}
+}
@@ -209,20 +209,12 @@ func (s *CombatSystem) ApplyBuff(world *ecs.World, target ecs.Entity, buff compo
 	return false
 }
 
-// CalculateDamage calculates final damage with defense.
+// CalculateDamage calculates final damage with defense using DefaultMitigation.
 func CalculateDamage(baseDamage, defense float64) float64 {
-	// Simple damage reduction formula
-	reduction := defense / (defense + 100)
-
-	return baseDamage * (1 - reduction)
+	return DefaultMitigation.Apply(baseDamage, defense, Penetration{})
 }
 
-// CalculateDamageWithPenetration calculates damage with defense penetration.
+// CalculateDamageWithPenetration calculates damage with a fraction of defense ignored.
 func CalculateDamageWithPenetration(baseDamage, defense, penetration float64) float64 {
-	effectiveDefense := defense * (1 - penetration)
-	if effectiveDefense < 0 {
-		effectiveDefense = 0
-	}
-
-	return CalculateDamage(baseDamage, effectiveDefense)
+	return DefaultMitigation.Apply(baseDamage, defense, Penetration{Percent: penetration})
 }
@@ -45,22 +45,7 @@ func (s *CooldownSystem) Update(world *ecs.World, dt float64) {
 
 		wasOnCooldown := !cd.IsReady()
 
-		// Update main cooldown
-		if cd.Remaining > 0 {
-			cd.Remaining -= dt
-			if cd.Remaining < 0 {
-				cd.Remaining = 0
-			}
-		}
-
-		// Update charge regeneration
-		if cd.MaxCharges > 1 && cd.Charges < cd.MaxCharges {
-			cd.ChargeTimer += dt
-			if cd.ChargeTimer >= cd.ChargeTime {
-				cd.ChargeTimer -= cd.ChargeTime
-				cd.Charges++
-			}
-		}
+		cd.Tick(dt)
 
 		// Check if just became ready
 		if wasOnCooldown && cd.IsReady() {
@@ -3,6 +3,7 @@ package systems
 import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
 )
 
 // InputState represents the current state of all inputs.
@@ -44,6 +45,7 @@ type InputManager struct {
 	prevMX   int
 	prevMY   int
 	bindings map[string][]ebiten.Key
+	queue    *input.Queue // Source of just-pressed and just-released edges
 }
 
 // NewInputManager creates a new input manager.
@@ -60,6 +62,7 @@ func NewInputManager() *InputManager {
 			GamepadAxes:       make(map[ebiten.GamepadID]map[int]float64),
 		},
 		bindings: make(map[string][]ebiten.Key),
+		queue:    input.Default,
 	}
 }
 
@@ -120,18 +123,9 @@ func (m *InputManager) Update() {
 	}
 
 	// Update keyboard
-	for k := range m.state.KeysPressed {
-		if !ebiten.IsKeyPressed(k) {
-			m.state.KeysJustUp[k] = true
-			delete(m.state.KeysPressed, k)
-		}
-	}
+	clear(m.state.KeysPressed)
 
 	for _, k := range inpututil.AppendPressedKeys(nil) {
-		if !m.state.KeysPressed[k] {
-			m.state.KeysJustDown[k] = true
-		}
-
 		m.state.KeysPressed[k] = true
 	}
 
@@ -148,24 +142,27 @@ func (m *InputManager) Update() {
 	m.state.MouseWheelY = wy
 
 	// Update mouse buttons
-	buttons := []ebiten.MouseButton{
+	for _, b := range []ebiten.MouseButton{
 		ebiten.MouseButtonLeft,
 		ebiten.MouseButtonRight,
 		ebiten.MouseButtonMiddle,
+	} {
+		m.state.MouseButtons[b] = ebiten.IsMouseButtonPressed(b)
 	}
-	for _, b := range buttons {
-		pressed := ebiten.IsMouseButtonPressed(b)
-		wasPressed := m.state.MouseButtons[b]
 
-		if pressed && !wasPressed {
-			m.state.MouseJustPressed[b] = true
+	// Edges come from the queue, which also catches taps between ticks and
+	// never reports a press on two ticks
+	for _, e := range m.queue.Events() {
+		switch {
+		case e.Mouse && e.Kind == input.Press:
+			m.state.MouseJustPressed[e.Button] = true
+		case e.Mouse:
+			m.state.MouseJustReleased[e.Button] = true
+		case e.Kind == input.Press:
+			m.state.KeysJustDown[e.Key] = true
+		default:
+			m.state.KeysJustUp[e.Key] = true
 		}
-
-		if !pressed && wasPressed {
-			m.state.MouseJustReleased[b] = true
-		}
-
-		m.state.MouseButtons[b] = pressed
 	}
 
 	// Update touches
@@ -0,0 +1,35 @@
+package systems
+
+import (
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// InterpolationSystem records each entity's Position into its PrevPosition.
+// Add it before any system that moves entities so that, once the tick
+// finishes, PrevPosition and Position hold the previous and current states.
+type InterpolationSystem struct {
+	filter *ecs.Filter2[components.Position, components.PrevPosition]
+}
+
+// NewInterpolationSystem creates a new interpolation system.
+func NewInterpolationSystem(world *ecs.World) *InterpolationSystem {
+	return &InterpolationSystem{
+		filter: ecs.NewFilter2[components.Position, components.PrevPosition](world),
+	}
+}
+
+// Update snapshots the current positions.
+func (s *InterpolationSystem) Update(world *ecs.World) {
+	query := s.filter.Query()
+	for query.Next() {
+		pos, prev := query.Get()
+		prev.X, prev.Y = pos.X, pos.Y
+	}
+}
+
+// Lerp returns the position alpha of the way from prev to pos, where alpha
+// is the fraction of a tick elapsed since the last update.
+func Lerp(prev components.PrevPosition, pos components.Position, alpha float64) (float64, float64) {
+	return prev.X + (pos.X-prev.X)*alpha, prev.Y + (pos.Y-prev.Y)*alpha
+}
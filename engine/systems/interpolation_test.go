@@ -0,0 +1,36 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+func TestInterpolationSystemSnapshotsBeforeMovement(t *testing.T) {
+	world := ecs.NewWorld()
+	interp := NewInterpolationSystem(&world)
+	movement := NewMovementSystem(&world)
+
+	mapper := ecs.NewMap3[components.Position, components.PrevPosition, components.Velocity](&world)
+	entity := mapper.NewEntity(
+		&components.Position{X: 10, Y: 20},
+		&components.PrevPosition{X: 10, Y: 20},
+		&components.Velocity{X: 4, Y: -2},
+	)
+
+	for range 2 {
+		interp.Update(&world)
+		movement.Update(&world)
+	}
+
+	pos, prev, _ := mapper.Get(entity)
+	if *prev != (components.PrevPosition{X: 14, Y: 18}) || *pos != (components.Position{X: 18, Y: 16}) {
+		t.Fatalf("prev = %+v, pos = %+v; want one tick apart", *prev, *pos)
+	}
+
+	x, y := Lerp(*prev, *pos, 0.25)
+	if x != 15 || y != 17.5 {
+		t.Errorf("Lerp at 0.25 = (%v, %v), want (15, 17.5)", x, y)
+	}
+}
@@ -0,0 +1,59 @@
+package systems
+
+import "math"
+
+// Mitigation is a diminishing-returns armor formula:
+//
+//	reduction = armor / (armor + K)
+//
+// K is the armor that halves incoming damage. Each extra point of armor is
+// worth less than the last, so stacking armor never reaches immunity the way
+// flat subtraction does. The result is then clamped so at least Floor of the
+// raw damage (and MinDamage) always lands, and no hit exceeds Cap.
+type Mitigation struct {
+	K         float64
+	Floor     float64 // Minimum fraction of raw damage that always lands
+	MinDamage float64 // Minimum damage of any hit with positive raw damage
+	Cap       float64 // Maximum damage of a single hit; 0 disables the cap
+}
+
+// Penetration lowers the defender's armor before mitigation is applied.
+type Penetration struct {
+	Percent float64 // Fraction of armor ignored, applied first
+	Flat    float64 // Armor ignored after the percent reduction
+}
+
+// DefaultMitigation halves damage at 100 armor with no floors or caps.
+var DefaultMitigation = Mitigation{K: 100}
+
+// EffectiveArmor returns the armor left after penetration, never below zero.
+func (m Mitigation) EffectiveArmor(armor float64, pen Penetration) float64 {
+	return math.Max(0, armor*(1-pen.Percent)-pen.Flat)
+}
+
+// Reduction returns the fraction of damage blocked by the given armor.
+func (m Mitigation) Reduction(armor float64) float64 {
+	if armor <= 0 || m.K <= 0 {
+		return 0
+	}
+
+	return armor / (armor + m.K)
+}
+
+// Apply returns the damage dealt by a raw hit against armor after penetration,
+// reduction, and the floor and cap.
+func (m Mitigation) Apply(damage, armor float64, pen Penetration) float64 {
+	if damage <= 0 {
+		return 0
+	}
+
+	dealt := damage * (1 - m.Reduction(m.EffectiveArmor(armor, pen)))
+	dealt = math.Max(dealt, damage*m.Floor)
+	dealt = math.Max(dealt, m.MinDamage)
+
+	if m.Cap > 0 {
+		dealt = math.Min(dealt, m.Cap)
+	}
+
+	return dealt
+}
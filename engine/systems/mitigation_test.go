@@ -0,0 +1,81 @@
+package systems
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMitigationApply(t *testing.T) {
+	survivor := Mitigation{K: 50, Floor: 0.2, MinDamage: 1, Cap: 60}
+
+	tests := []struct {
+		name   string
+		m      Mitigation
+		damage float64
+		armor  float64
+		pen    Penetration
+		want   float64
+	}{
+		{"no armor", DefaultMitigation, 100, 0, Penetration{}, 100},
+		{"armor equal to K halves", DefaultMitigation, 100, 100, Penetration{}, 50},
+		{"diminishing returns", DefaultMitigation, 100, 300, Penetration{}, 25},
+		{"percent penetration", DefaultMitigation, 100, 200, Penetration{Percent: 0.5}, 50},
+		{"flat penetration", DefaultMitigation, 100, 150, Penetration{Flat: 50}, 50},
+		{"percent before flat", DefaultMitigation, 100, 300, Penetration{Percent: 0.5, Flat: 50}, 50},
+		{"penetration past zero armor", DefaultMitigation, 100, 20, Penetration{Flat: 50}, 100},
+		{"floor keeps a fraction", survivor, 10, 1000, Penetration{}, 2},
+		{"minimum damage", survivor, 2, 1000, Penetration{}, 1},
+		{"cap limits big hits", survivor, 500, 0, Penetration{}, 60},
+		{"zero damage stays zero", survivor, 0, 0, Penetration{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.Apply(tt.damage, tt.armor, tt.pen)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Apply(%v, %v, %+v) = %v, want %v", tt.damage, tt.armor, tt.pen, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMitigationMonotonic(t *testing.T) {
+	m := Mitigation{K: 50}
+	prev := m.Reduction(0)
+
+	for armor := 10.0; armor <= 1000; armor += 10 {
+		r := m.Reduction(armor)
+		if r <= prev || r >= 1 {
+			t.Fatalf("Reduction(%v) = %v, want increasing and below 1 (prev %v)", armor, r, prev)
+		}
+
+		if gain, lastGain := r-prev, prev-m.Reduction(armor-20); armor > 10 && gain >= lastGain {
+			t.Fatalf("armor %v gained %v, not less than previous step %v", armor, gain, lastGain)
+		}
+
+		prev = r
+	}
+}
+
+func TestCalculateDamageWrappers(t *testing.T) {
+	tests := []struct {
+		base, defense, pen, want float64
+	}{
+		{100, 0, 0, 100},
+		{100, 100, 0, 50},
+		{100, 200, 0.5, 50},
+		{100, 100, 1.5, 100},
+	}
+
+	for _, tt := range tests {
+		if got := CalculateDamageWithPenetration(tt.base, tt.defense, tt.pen); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CalculateDamageWithPenetration(%v, %v, %v) = %v, want %v",
+				tt.base, tt.defense, tt.pen, got, tt.want)
+		}
+
+		if tt.pen == 0 && CalculateDamage(tt.base, tt.defense) != tt.want {
+			t.Errorf("CalculateDamage(%v, %v) = %v, want %v", tt.base, tt.defense,
+				CalculateDamage(tt.base, tt.defense), tt.want)
+		}
+	}
+}
@@ -7,19 +7,35 @@ import (
 )
 
 // RenderSystem draws all entities with Position and Sprite components.
+// With Interpolate set, entities that also carry PrevPosition are drawn
+// between their previous and current positions.
 type RenderSystem struct {
 	filter *ecs.Filter2[components.Position, components.Sprite]
+	prev   *ecs.Map[components.PrevPosition]
+	alpha  func() float64
 }
 
 // NewRenderSystem creates a new render system.
 func NewRenderSystem(world *ecs.World) *RenderSystem {
 	return &RenderSystem{
 		filter: ecs.NewFilter2[components.Position, components.Sprite](world),
+		prev:   ecs.NewMap[components.PrevPosition](world),
 	}
 }
 
+// Interpolate makes Draw blend positions by alpha, typically
+// engine.Game.Alpha or a TickClock's Alpha. Nil turns interpolation off.
+func (s *RenderSystem) Interpolate(alpha func() float64) {
+	s.alpha = alpha
+}
+
 // Draw renders all visible sprites to the screen.
 func (s *RenderSystem) Draw(world *ecs.World, screen *ebiten.Image) {
+	alpha := 1.0
+	if s.alpha != nil {
+		alpha = s.alpha()
+	}
+
 	query := s.filter.Query()
 	for query.Next() {
 		pos, sprite := query.Get()
@@ -28,9 +44,14 @@ func (s *RenderSystem) Draw(world *ecs.World, screen *ebiten.Image) {
 			continue
 		}
 
+		x, y := pos.X, pos.Y
+		if alpha < 1 && s.prev.Has(query.Entity()) {
+			x, y = Lerp(*s.prev.Get(query.Entity()), *pos, alpha)
+		}
+
 		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Scale(sprite.ScaleX, sprite.ScaleY)
-		op.GeoM.Translate(pos.X+sprite.OffsetX, pos.Y+sprite.OffsetY)
+		op.GeoM.Translate(x+sprite.OffsetX, y+sprite.OffsetY)
 
 		screen.DrawImage(sprite.Image, op)
 	}
@@ -0,0 +1,58 @@
+package systems
+
+import "github.com/skyrocket-qy/NeuralWay/engine/engine"
+
+// Scheduler names of the built-in systems. Their specs order them
+// interpolation, movement, collision, then health, which applies the damage
+// collisions queue; each constraint is dropped when the other system is not
+// registered.
+const (
+	InterpolationSystemName = "interpolation"
+	MovementSystemName      = "movement"
+	CollisionSystemName     = "collision"
+	HealthSystemName        = "health"
+)
+
+// Spec registers the interpolation system to snapshot positions before
+// anything moves.
+func (s *InterpolationSystem) Spec() engine.SystemSpec {
+	return engine.SystemSpec{
+		Name:     InterpolationSystemName,
+		System:   s,
+		Before:   []string{MovementSystemName},
+		Optional: true,
+	}
+}
+
+// Spec registers the movement system to run before collision.
+func (s *MovementSystem) Spec() engine.SystemSpec {
+	return engine.SystemSpec{
+		Name:     MovementSystemName,
+		System:   s,
+		After:    []string{InterpolationSystemName},
+		Before:   []string{CollisionSystemName},
+		Optional: true,
+	}
+}
+
+// Spec registers the collision system to test positions after movement and
+// before health applies the damage it leads to.
+func (s *CollisionSystem) Spec() engine.SystemSpec {
+	return engine.SystemSpec{
+		Name:     CollisionSystemName,
+		System:   s,
+		After:    []string{MovementSystemName},
+		Before:   []string{HealthSystemName},
+		Optional: true,
+	}
+}
+
+// Spec registers the health system to apply damage after collision.
+func (s *HealthSystem) Spec() engine.SystemSpec {
+	return engine.SystemSpec{
+		Name:     HealthSystemName,
+		System:   s,
+		After:    []string{CollisionSystemName, MovementSystemName},
+		Optional: true,
+	}
+}
@@ -0,0 +1,52 @@
+package systems
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+)
+
+func TestBuiltinSystemsScheduleInOrder(t *testing.T) {
+	world := ecs.NewWorld()
+	sched := engine.NewScheduler()
+
+	// Registered backwards; the specs put them in pipeline order
+	for _, spec := range []engine.SystemSpec{
+		NewHealthSystem(&world).Spec(),
+		NewCollisionSystem(&world, 32).Spec(),
+		NewMovementSystem(&world).Spec(),
+		NewInterpolationSystem(&world).Spec(),
+	} {
+		if err := sched.Register(spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	order, err := sched.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{InterpolationSystemName, MovementSystemName, CollisionSystemName, HealthSystemName}
+	if !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestBuiltinSystemsScheduleWithoutCollision(t *testing.T) {
+	world := ecs.NewWorld()
+	sched := engine.NewScheduler()
+	_ = sched.Register(NewHealthSystem(&world).Spec())
+	_ = sched.Register(NewMovementSystem(&world).Spec())
+
+	order, err := sched.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{MovementSystemName, HealthSystemName}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
@@ -0,0 +1,125 @@
+package systems
+
+import (
+	"fmt"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// TagQuery looks up entities by their Tag and Name components.
+// It is intended for scripting triggers and debug tooling rather than
+// per-frame hot paths, so results are collected into slices.
+type TagQuery struct {
+	world      *ecs.World
+	tagFilter  *ecs.Filter1[components.Tag]
+	nameFilter *ecs.Filter1[components.Name]
+	tagMap     *ecs.Map[components.Tag]
+	nameMap    *ecs.Map[components.Name]
+}
+
+// NewTagQuery creates a tag query helper for the given world.
+func NewTagQuery(world *ecs.World) *TagQuery {
+	return &TagQuery{
+		world:      world,
+		tagFilter:  ecs.NewFilter1[components.Tag](world),
+		nameFilter: ecs.NewFilter1[components.Name](world),
+		tagMap:     ecs.NewMap[components.Tag](world),
+		nameMap:    ecs.NewMap[components.Name](world),
+	}
+}
+
+// WithTag returns all entities whose Tag matches the given name.
+func (q *TagQuery) WithTag(tag string) []ecs.Entity {
+	var result []ecs.Entity
+
+	query := q.tagFilter.Query()
+	for query.Next() {
+		if query.Get().Name == tag {
+			result = append(result, query.Entity())
+		}
+	}
+
+	return result
+}
+
+// WithName returns all entities whose Name matches the given value.
+func (q *TagQuery) WithName(name string) []ecs.Entity {
+	var result []ecs.Entity
+
+	query := q.nameFilter.Query()
+	for query.Next() {
+		if query.Get().Value == name {
+			result = append(result, query.Entity())
+		}
+	}
+
+	return result
+}
+
+// First returns the first entity with the given tag, if any.
+func (q *TagQuery) First(tag string) (ecs.Entity, bool) {
+	query := q.tagFilter.Query()
+	for query.Next() {
+		if query.Get().Name == tag {
+			entity := query.Entity()
+			query.Close()
+
+			return entity, true
+		}
+	}
+
+	return ecs.Entity{}, false
+}
+
+// Count returns the number of entities with the given tag.
+func (q *TagQuery) Count(tag string) int {
+	count := 0
+
+	query := q.tagFilter.Query()
+	for query.Next() {
+		if query.Get().Name == tag {
+			count++
+		}
+	}
+
+	return count
+}
+
+// TagCounts returns the number of entities per tag.
+func (q *TagQuery) TagCounts() map[string]int {
+	counts := make(map[string]int)
+
+	query := q.tagFilter.Query()
+	for query.Next() {
+		counts[query.Get().Name]++
+	}
+
+	return counts
+}
+
+// IsLabelled reports whether the entity has a Name or Tag component.
+func (q *TagQuery) IsLabelled(entity ecs.Entity) bool {
+	return q.nameMap.Has(entity) || q.tagMap.Has(entity)
+}
+
+// Describe returns a log-friendly label such as `Boss Manager#12 [boss]`.
+// Anonymous entities fall back to `entity#12`.
+func (q *TagQuery) Describe(entity ecs.Entity) string {
+	if !q.world.Alive(entity) {
+		return fmt.Sprintf("entity#%d (dead)", entity.ID())
+	}
+
+	label := "entity"
+	if q.nameMap.Has(entity) {
+		label = q.nameMap.Get(entity).Value
+	}
+
+	label = fmt.Sprintf("%s#%d", label, entity.ID())
+
+	if q.tagMap.Has(entity) {
+		label += " [" + q.tagMap.Get(entity).Name + "]"
+	}
+
+	return label
+}
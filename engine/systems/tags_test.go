@@ -0,0 +1,53 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+func TestTagQuery(t *testing.T) {
+	world := ecs.NewWorld()
+	tagged := ecs.NewMap2[components.Name, components.Tag](&world)
+	tagOnly := ecs.NewMap1[components.Tag](&world)
+
+	boss := tagged.NewEntity(&components.Name{Value: "Deadline"}, &components.Tag{Name: "boss"})
+	tagOnly.NewEntity(&components.Tag{Name: "enemy"})
+	tagOnly.NewEntity(&components.Tag{Name: "enemy"})
+
+	anon := world.NewEntity()
+	q := NewTagQuery(&world)
+
+	if got := q.Count("enemy"); got != 2 {
+		t.Errorf("Count(enemy) = %d, want 2", got)
+	}
+
+	if got := q.WithTag("boss"); len(got) != 1 || got[0] != boss {
+		t.Errorf("WithTag(boss) = %v, want [%v]", got, boss)
+	}
+
+	if got := q.WithName("Deadline"); len(got) != 1 || got[0] != boss {
+		t.Errorf("WithName(Deadline) = %v", got)
+	}
+
+	if e, ok := q.First("boss"); !ok || e != boss {
+		t.Errorf("First(boss) = %v, %v", e, ok)
+	}
+
+	if _, ok := q.First("missing"); ok {
+		t.Error("First(missing) should not find an entity")
+	}
+
+	if counts := q.TagCounts(); counts["enemy"] != 2 || counts["boss"] != 1 {
+		t.Errorf("TagCounts = %v", counts)
+	}
+
+	if got, want := q.Describe(boss), "Deadline#"; len(got) < len(want) || got[:len(want)] != want {
+		t.Errorf("Describe(boss) = %q", got)
+	}
+
+	if q.IsLabelled(anon) {
+		t.Error("anonymous entity should not be labelled")
+	}
+}
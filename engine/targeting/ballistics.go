@@ -0,0 +1,199 @@
+package targeting
+
+import "math"
+
+// Flight is how a projectile travels to its target.
+type Flight int
+
+const (
+	Instant Flight = iota // Hits on the first update, like a hitscan laser
+	Linear                // Flies straight at the aim point
+	Arcing                // Lobbed over obstacles, landing on the aim point
+	Homing                // Steers toward the target's current position
+)
+
+// homingLifetime is how long a homing projectile chases before giving up.
+const homingLifetime = 5.0
+
+// Intercept returns where a shot of the given speed fired from (sx, sy)
+// meets a target at (tx, ty) moving at (vx, vy), and the flight time. When
+// the target outruns the shot, ok is false and the target's current
+// position is returned.
+func Intercept(sx, sy, tx, ty, vx, vy, speed float64) (x, y, t float64, ok bool) {
+	dx, dy := tx-sx, ty-sy
+
+	// |d + v t| = speed t  =>  a t^2 + b t + c = 0
+	a := vx*vx + vy*vy - speed*speed
+	b := 2 * (dx*vx + dy*vy)
+	c := dx*dx + dy*dy
+
+	switch {
+	case math.Abs(a) < 1e-9:
+		if b >= 0 {
+			return tx, ty, 0, false
+		}
+
+		t = -c / b
+	default:
+		disc := b*b - 4*a*c
+		if disc < 0 {
+			return tx, ty, 0, false
+		}
+
+		root := math.Sqrt(disc)
+		t1, t2 := (-b-root)/(2*a), (-b+root)/(2*a)
+
+		t = min(t1, t2)
+		if t < 0 {
+			t = max(t1, t2)
+		}
+
+		if t < 0 {
+			return tx, ty, 0, false
+		}
+	}
+
+	return tx + vx*t, ty + vy*t, t, true
+}
+
+// Shot configures the projectiles an attacker fires.
+type Shot struct {
+	Flight    Flight
+	Speed     float64 // Units per second along the ground; ignored by Instant
+	Lead      bool    // Aim where a moving target will be (Linear and Arcing)
+	ArcHeight float64 // Apex height of an Arcing shot
+	TurnRate  float64 // Radians per second a Homing shot can turn; 0 turns instantly
+	HitRadius float64 // Distance at which a Homing shot reaches its target
+}
+
+// Projectile is one shot in flight.
+type Projectile struct {
+	Flight Flight
+	X, Y   float64 // Ground position
+	Z      float64 // Height above the ground, for drawing Arcing shots
+	AimX   float64 // Where Linear and Arcing shots land
+	AimY   float64
+	Done   bool // Arrived or gave up; remove it
+
+	vx, vy         float64
+	speed          float64
+	turnRate       float64
+	hitRadius      float64
+	fromX, fromY   float64
+	arcHeight      float64
+	elapsed, total float64
+}
+
+// Fire launches a projectile from (x, y) at t.
+func (s Shot) Fire(x, y float64, t Target) *Projectile {
+	aimX, aimY := t.X, t.Y
+	if s.Lead && (s.Flight == Linear || s.Flight == Arcing) {
+		aimX, aimY, _, _ = Intercept(x, y, t.X, t.Y, t.VX, t.VY, s.Speed)
+	}
+
+	p := &Projectile{
+		Flight:    s.Flight,
+		X:         x,
+		Y:         y,
+		AimX:      aimX,
+		AimY:      aimY,
+		speed:     s.Speed,
+		turnRate:  s.TurnRate,
+		hitRadius: max(s.HitRadius, 1),
+		fromX:     x,
+		fromY:     y,
+		arcHeight: s.ArcHeight,
+	}
+
+	dist := math.Hypot(aimX-x, aimY-y)
+	if dist > 0 {
+		p.vx, p.vy = (aimX-x)/dist*s.Speed, (aimY-y)/dist*s.Speed
+	}
+
+	switch s.Flight {
+	case Linear, Arcing:
+		if s.Speed > 0 {
+			p.total = dist / s.Speed
+		}
+	case Homing:
+		p.total = homingLifetime
+	case Instant:
+	}
+
+	return p
+}
+
+// Update advances the projectile by dt seconds toward a target now at
+// (tx, ty), which only Homing shots follow. It returns true on the update
+// the shot arrives: Instant immediately, Linear and Arcing at the aim point,
+// Homing within its hit radius. Linear and Arcing shots land whether or not
+// the target is still there, so the caller decides what they hit.
+func (p *Projectile) Update(dt, tx, ty float64) bool {
+	if p.Done {
+		return false
+	}
+
+	p.elapsed += dt
+
+	switch p.Flight {
+	case Instant:
+		p.X, p.Y = tx, ty
+	case Linear, Arcing:
+		frac := 1.0
+		if p.total > 0 {
+			frac = min(p.elapsed/p.total, 1)
+		}
+
+		p.X = p.fromX + (p.AimX-p.fromX)*frac
+		p.Y = p.fromY + (p.AimY-p.fromY)*frac
+
+		if p.Flight == Arcing {
+			p.Z = 4 * p.arcHeight * frac * (1 - frac)
+		}
+
+		if frac < 1 {
+			return false
+		}
+	case Homing:
+		// Reaching the target this step counts even if the step would overshoot
+		if math.Hypot(tx-p.X, ty-p.Y) <= p.hitRadius+p.speed*dt {
+			p.X, p.Y = tx, ty
+
+			break
+		}
+
+		p.steer(dt, tx, ty)
+		p.X += p.vx * dt
+		p.Y += p.vy * dt
+		p.Done = p.elapsed >= p.total
+
+		return false
+	}
+
+	p.Done = true
+
+	return true
+}
+
+// steer turns a homing projectile's velocity toward (tx, ty), limited by its
+// turn rate.
+func (p *Projectile) steer(dt, tx, ty float64) {
+	want := math.Atan2(ty-p.Y, tx-p.X)
+	if p.turnRate <= 0 || p.vx == 0 && p.vy == 0 {
+		p.vx, p.vy = math.Cos(want)*p.speed, math.Sin(want)*p.speed
+
+		return
+	}
+
+	heading := math.Atan2(p.vy, p.vx)
+	turn := math.Remainder(want-heading, 2*math.Pi)
+	limit := p.turnRate * dt
+	heading += min(max(turn, -limit), limit)
+	p.vx, p.vy = math.Cos(heading)*p.speed, math.Sin(heading)*p.speed
+}
+
+// Hits reports whether the projectile is within radius of (x, y), to check
+// what a landed Linear or Arcing shot struck.
+func (p *Projectile) Hits(x, y, radius float64) bool {
+	return math.Hypot(x-p.X, y-p.Y) <= radius
+}
@@ -0,0 +1,83 @@
+// Package targeting picks targets for towers, archers, and other ranged
+// attackers, and flies the projectiles they fire. It is plain math with no
+// ECS or rendering dependencies, so tower defense systems and the RTS
+// examples share the same rules.
+package targeting
+
+import "math"
+
+// Target is a candidate the caller describes for one selection.
+type Target struct {
+	ID       int // Caller's handle, such as an index or entity ID
+	X, Y     float64
+	VX, VY   float64 // Velocity in units per second, for leading shots
+	HP       float64 // Current health, for Strongest and Weakest
+	Progress float64 // Distance travelled along a path, for First and Last
+}
+
+// Policy decides which target in range an attacker prefers.
+type Policy int
+
+const (
+	Nearest   Policy = iota // Closest to the attacker
+	Farthest                // Farthest still in range
+	First                   // Furthest along the path, closest to leaking
+	Last                    // Least far along the path
+	Strongest               // Most HP
+	Weakest                 // Least HP, to finish kills
+	policyCount
+)
+
+var policyNames = [...]string{"Nearest", "Farthest", "First", "Last", "Strongest", "Weakest"}
+
+// String returns the policy's display name.
+func (p Policy) String() string {
+	if p < 0 || p >= policyCount {
+		return "Unknown"
+	}
+
+	return policyNames[p]
+}
+
+// Next cycles to the following policy, for a UI toggle.
+func (p Policy) Next() Policy {
+	return (p + 1) % policyCount
+}
+
+// score ranks t under p; higher is preferred.
+func (p Policy) score(t Target, dist float64) float64 {
+	switch p {
+	case Farthest:
+		return dist
+	case First:
+		return t.Progress
+	case Last:
+		return -t.Progress
+	case Strongest:
+		return t.HP
+	case Weakest:
+		return -t.HP
+	}
+
+	return -dist
+}
+
+// Select returns the target within rng of (x, y) that policy prefers, with
+// ties going to the nearer one. ok is false when nothing is in range.
+func Select(x, y, rng float64, policy Policy, targets []Target) (best Target, ok bool) {
+	bestScore, bestDist := math.Inf(-1), math.Inf(1)
+
+	for _, t := range targets {
+		dist := math.Hypot(t.X-x, t.Y-y)
+		if dist > rng {
+			continue
+		}
+
+		score := policy.score(t, dist)
+		if score > bestScore || score == bestScore && dist < bestDist {
+			best, bestScore, bestDist, ok = t, score, dist, true
+		}
+	}
+
+	return best, ok
+}
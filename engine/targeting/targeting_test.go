@@ -0,0 +1,136 @@
+package targeting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSelectPolicies(t *testing.T) {
+	targets := []Target{
+		{ID: 1, X: 10, HP: 50, Progress: 100},
+		{ID: 2, X: 40, HP: 10, Progress: 300},
+		{ID: 3, X: 80, HP: 90, Progress: 200},
+		{ID: 4, X: 500, HP: 1, Progress: 900}, // Out of range
+	}
+
+	want := map[Policy]int{
+		Nearest:   1,
+		Farthest:  3,
+		First:     2,
+		Last:      1,
+		Strongest: 3,
+		Weakest:   2,
+	}
+
+	for policy, id := range want {
+		got, ok := Select(0, 0, 100, policy, targets)
+		if !ok || got.ID != id {
+			t.Errorf("%v picked %d (ok %v), want %d", policy, got.ID, ok, id)
+		}
+	}
+
+	if _, ok := Select(0, 0, 5, Nearest, targets); ok {
+		t.Error("nothing is within range 5")
+	}
+}
+
+func TestSelectBreaksTiesByDistance(t *testing.T) {
+	targets := []Target{{ID: 1, X: 50, HP: 10}, {ID: 2, X: 20, HP: 10}}
+
+	if got, _ := Select(0, 0, 100, Weakest, targets); got.ID != 2 {
+		t.Errorf("tie went to %d, want the nearer 2", got.ID)
+	}
+}
+
+func TestInterceptLeadsMovingTarget(t *testing.T) {
+	// Target crossing at 50/s, 100 units away, shot speed 100
+	x, y, tt, ok := Intercept(0, 0, 100, 0, 0, 50, 100)
+	if !ok {
+		t.Fatal("no intercept")
+	}
+
+	if shot, target := math.Hypot(x, y), 100*tt; math.Abs(shot-target) > 1e-6 {
+		t.Errorf("shot travels %v but flies %v in %vs", shot, target, tt)
+	}
+
+	if math.Abs(y-50*tt) > 1e-6 {
+		t.Errorf("aim point %v,%v is not where the target will be", x, y)
+	}
+
+	if _, _, _, ok := Intercept(0, 0, 100, 0, 200, 0, 100); ok {
+		t.Error("a target fleeing faster than the shot cannot be intercepted")
+	}
+}
+
+// fly updates p against a target moving at (vx, vy) until it arrives or
+// gives up, and returns whether it arrived and where the target ended.
+func fly(p *Projectile, tx, ty, vx, vy float64) (arrived bool, x, y float64) {
+	const dt = 1.0 / 60
+
+	for range 10 * 60 {
+		tx += vx * dt
+		ty += vy * dt
+
+		if p.Update(dt, tx, ty) {
+			return true, tx, ty
+		}
+
+		if p.Done {
+			break
+		}
+	}
+
+	return false, tx, ty
+}
+
+func TestFlightModels(t *testing.T) {
+	target := Target{X: 200, Y: 0, VY: 40}
+
+	t.Run("Instant", func(t *testing.T) {
+		p := Shot{Flight: Instant}.Fire(0, 0, target)
+		if !p.Update(1.0/60, target.X, target.Y) || !p.Done {
+			t.Error("instant shot should land on the first update")
+		}
+	})
+
+	t.Run("LinearLead", func(t *testing.T) {
+		p := Shot{Flight: Linear, Speed: 300, Lead: true}.Fire(0, 0, target)
+		if arrived, x, y := fly(p, target.X, target.Y, target.VX, target.VY); !arrived || !p.Hits(x, y, 4) {
+			t.Errorf("led linear shot landed at %.1f,%.1f, target at %.1f,%.1f", p.X, p.Y, x, y)
+		}
+	})
+
+	t.Run("LinearNoLeadMisses", func(t *testing.T) {
+		p := Shot{Flight: Linear, Speed: 300}.Fire(0, 0, target)
+		if _, x, y := fly(p, target.X, target.Y, target.VX, target.VY); p.Hits(x, y, 4) {
+			t.Error("unled shot should miss a crossing target")
+		}
+	})
+
+	t.Run("Arcing", func(t *testing.T) {
+		p := Shot{Flight: Arcing, Speed: 200, ArcHeight: 50}.Fire(0, 0, Target{X: 200})
+
+		peak := 0.0
+		for !p.Update(1.0/60, 200, 0) {
+			peak = max(peak, p.Z)
+		}
+
+		if math.Abs(peak-50) > 1 || p.Z != 0 || p.X != 200 {
+			t.Errorf("arc peaked at %.1f and landed at %.1f (z %.1f)", peak, p.X, p.Z)
+		}
+	})
+
+	t.Run("Homing", func(t *testing.T) {
+		p := Shot{Flight: Homing, Speed: 300, TurnRate: 6, HitRadius: 5}.Fire(0, 0, target)
+		if arrived, _, _ := fly(p, target.X, target.Y, 0, 120); !arrived {
+			t.Error("homing shot should catch a slower target")
+		}
+	})
+
+	t.Run("HomingGivesUp", func(t *testing.T) {
+		p := Shot{Flight: Homing, Speed: 50, HitRadius: 5}.Fire(0, 0, target)
+		if arrived, _, _ := fly(p, target.X, target.Y, 100, 0); arrived || !p.Done {
+			t.Error("homing shot should give up on a faster target")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package wavegame
+
+import "math"
+
+// DirectorConfig paces a run's waves.
+type DirectorConfig struct {
+	WaveDuration float64 // Seconds each wave spawns for
+	BaseBudget   float64 // Spawn points earned per second in wave 1
+	BudgetGrowth float64 // Budget multiplier per wave, e.g. 1.25; 0 or 1 keeps it flat
+	MaxAlive     int     // Spawning pauses while this many enemies are alive; 0 is unlimited
+	ClearToEnd   bool    // The wave lasts until its enemies are dead, not just the timer
+}
+
+// DefaultDirectorConfig suits a short arena run: 30 second waves that grow a
+// quarter harder each time.
+func DefaultDirectorConfig() DirectorConfig {
+	return DirectorConfig{
+		WaveDuration: 30,
+		BaseBudget:   2,
+		BudgetGrowth: 1.25,
+		MaxAlive:     150,
+	}
+}
+
+// SpawnFunc spawns one enemy the budget can afford and returns its cost, or 0
+// if nothing is affordable yet.
+type SpawnFunc func(wave int, budget float64) float64
+
+// Director earns a spawn budget over each wave and spends it through a
+// SpawnFunc, so later waves field more or stronger enemies without the game
+// scripting them.
+type Director struct {
+	Config DirectorConfig
+
+	wave    int
+	elapsed float64
+	budget  float64
+}
+
+// NewDirector creates a director before the first wave.
+func NewDirector(cfg DirectorConfig) *Director {
+	return &Director{Config: cfg}
+}
+
+// Start begins a wave, 1 being the first.
+func (d *Director) Start(wave int) {
+	d.wave, d.elapsed, d.budget = wave, 0, 0
+}
+
+// Wave returns the current wave.
+func (d *Director) Wave() int {
+	return d.wave
+}
+
+// Rate returns the spawn points earned per second in the current wave.
+func (d *Director) Rate() float64 {
+	growth := d.Config.BudgetGrowth
+	if growth <= 0 {
+		growth = 1
+	}
+
+	return d.Config.BaseBudget * math.Pow(growth, float64(max(d.wave-1, 0)))
+}
+
+// Update earns budget for dt and spends it on spawns while the wave's timer
+// runs. alive is how many enemies are on the field.
+func (d *Director) Update(dt float64, alive int, spawn SpawnFunc) {
+	if d.elapsed >= d.Config.WaveDuration {
+		return
+	}
+
+	d.elapsed += min(dt, d.Config.WaveDuration-d.elapsed)
+	d.budget += d.Rate() * dt
+
+	for d.Config.MaxAlive <= 0 || alive < d.Config.MaxAlive {
+		cost := spawn(d.wave, d.budget)
+		if cost <= 0 {
+			break
+		}
+
+		d.budget -= cost
+		alive++
+	}
+}
+
+// Remaining returns the seconds left on the wave's timer.
+func (d *Director) Remaining() float64 {
+	return max(d.Config.WaveDuration-d.elapsed, 0)
+}
+
+// Done reports whether the wave is over: its timer has run out and, with
+// ClearToEnd, no enemies are left.
+func (d *Director) Done(alive int) bool {
+	return d.Remaining() == 0 && (!d.Config.ClearToEnd || alive == 0)
+}
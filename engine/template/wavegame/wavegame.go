@@ -0,0 +1,331 @@
+// Package wavegame is a scaffold for wave survival games like the survivor,
+// space shooter, and mini RTS examples: waves spawn, the player survives, and
+// power grows between waves. It runs the loop, the spawn director, the score,
+// the upgrade pick, and the game-over screen; a game supplies only Rules.
+package wavegame
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Rules is the game-specific part of a wave game.
+type Rules interface {
+	// Reset starts a new run.
+	Reset()
+
+	// Spawn spawns one enemy the budget can afford and returns its cost, or 0
+	// if nothing is affordable yet.
+	Spawn(wave int, budget float64) float64
+
+	// Update simulates one frame of play. It reports kills with Game.AddScore
+	// and the player's death with Game.End.
+	Update(g *Game, dt float64)
+
+	// Alive returns how many enemies are on the field.
+	Alive() int
+
+	// Upgrades returns the choices offered after a wave; none skips the pick.
+	Upgrades(wave int) []Upgrade
+
+	// Draw draws the playfield; the game draws its HUD and screens on top.
+	Draw(screen *ebiten.Image)
+}
+
+// Upgrade is a choice offered between waves.
+type Upgrade struct {
+	Name        string
+	Description string
+	Apply       func()
+}
+
+// State is where a run is.
+type State int
+
+const (
+	StatePlaying State = iota
+	StateUpgrade       // Picking an upgrade between waves
+	StateGameOver
+)
+
+// Config sets up a wave game.
+type Config struct {
+	Title         string
+	Width, Height int
+	Director      DirectorConfig
+}
+
+// Game runs Rules as an ebiten.Game.
+type Game struct {
+	Config   Config
+	Director *Director
+	Rules    Rules
+
+	state   State
+	score   int
+	best    int
+	choices []Upgrade
+	focus   int
+	paused  bool
+}
+
+// New creates a game and starts its first run.
+func New(rules Rules, cfg Config) *Game {
+	g := &Game{Config: cfg, Director: NewDirector(cfg.Director), Rules: rules}
+	g.Restart()
+
+	return g
+}
+
+// Restart begins a new run at wave 1.
+func (g *Game) Restart() {
+	g.score = 0
+	g.state = StatePlaying
+	g.choices = nil
+	g.paused = false
+	g.Rules.Reset()
+	g.Director.Start(1)
+}
+
+// State returns where the run is.
+func (g *Game) State() State {
+	return g.state
+}
+
+// Wave returns the current wave.
+func (g *Game) Wave() int {
+	return g.Director.Wave()
+}
+
+// Score returns the run's score.
+func (g *Game) Score() int {
+	return g.score
+}
+
+// Best returns the highest score since the game started.
+func (g *Game) Best() int {
+	return g.best
+}
+
+// AddScore adds to the run's score.
+func (g *Game) AddScore(n int) {
+	g.score += n
+	g.best = max(g.best, g.score)
+}
+
+// Pause pauses a run in progress, for engine.FocusConfig.AutoPause. Escape
+// or P resumes it.
+func (g *Game) Pause() {
+	if g.state == StatePlaying {
+		g.paused = true
+	}
+}
+
+// Paused reports whether the run is paused.
+func (g *Game) Paused() bool {
+	return g.paused
+}
+
+// End ends the run; the player died.
+func (g *Game) End() {
+	g.state = StateGameOver
+}
+
+// Choices returns the upgrades on offer between waves.
+func (g *Game) Choices() []Upgrade {
+	return g.choices
+}
+
+// Choose applies the i-th upgrade on offer and starts the next wave.
+func (g *Game) Choose(i int) {
+	if g.state != StateUpgrade || i < 0 || i >= len(g.choices) {
+		return
+	}
+
+	if apply := g.choices[i].Apply; apply != nil {
+		apply()
+	}
+
+	g.nextWave()
+}
+
+// Step advances play by dt seconds without reading input: the rules
+// simulate, the director spawns, and a finished wave moves to the upgrade
+// pick.
+func (g *Game) Step(dt float64) {
+	if g.state != StatePlaying {
+		return
+	}
+
+	g.Rules.Update(g, dt)
+
+	if g.state != StatePlaying {
+		return
+	}
+
+	g.Director.Update(dt, g.Rules.Alive(), g.Rules.Spawn)
+
+	if !g.Director.Done(g.Rules.Alive()) {
+		return
+	}
+
+	g.choices = g.Rules.Upgrades(g.Wave())
+	g.focus = 0
+
+	if len(g.choices) == 0 {
+		g.nextWave()
+
+		return
+	}
+
+	g.state = StateUpgrade
+}
+
+func (g *Game) nextWave() {
+	g.state = StatePlaying
+	g.choices = nil
+	g.Director.Start(g.Wave() + 1)
+}
+
+// Update reads input for the current state and steps play by one tick.
+func (g *Game) Update() error {
+	switch g.state {
+	case StatePlaying:
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyP) {
+			g.paused = !g.paused
+		}
+
+		if !g.paused {
+			g.Step(1.0 / float64(ebiten.TPS()))
+		}
+	case StateUpgrade:
+		g.updateUpgrade()
+	case StateGameOver:
+		if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			g.Restart()
+		}
+	}
+
+	return nil
+}
+
+// Upgrade pick layout.
+const (
+	choiceW   = 360
+	choiceH   = 44
+	choiceGap = 8
+)
+
+// choiceRect returns the screen rectangle of the i-th upgrade.
+func (g *Game) choiceRect(i int) (x, y float32) {
+	total := len(g.choices)*(choiceH+choiceGap) - choiceGap
+	x = float32(g.Config.Width-choiceW) / 2
+	y = float32(g.Config.Height-total)/2 + float32(i*(choiceH+choiceGap))
+
+	return x, y
+}
+
+// updateUpgrade picks with number keys, arrows and Enter, or a click.
+func (g *Game) updateUpgrade() {
+	for i := range min(len(g.choices), 9) {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			g.Choose(i)
+
+			return
+		}
+	}
+
+	n := len(g.choices)
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		g.focus = (g.focus + n - 1) % n
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		g.focus = (g.focus + 1) % n
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		g.Choose(g.focus)
+
+		return
+	}
+
+	mx, my := ebiten.CursorPosition()
+
+	for i := range g.choices {
+		x, y := g.choiceRect(i)
+		if float32(mx) < x || float32(mx) >= x+choiceW || float32(my) < y || float32(my) >= y+choiceH {
+			continue
+		}
+
+		g.focus = i
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			g.Choose(i)
+		}
+	}
+}
+
+// Draw draws the rules' playfield, the HUD, and the upgrade or game-over
+// screen.
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.Rules.Draw(screen)
+
+	status := fmt.Sprintf("Wave %d  %2.0fs  Score %d  Best %d",
+		g.Wave(), g.Director.Remaining(), g.score, g.best)
+	if g.Director.Remaining() == 0 && g.state == StatePlaying {
+		status = fmt.Sprintf("Wave %d  Clear the field!  Score %d  Best %d", g.Wave(), g.score, g.best)
+	}
+
+	ebitenutil.DebugPrintAt(screen, status, 10, 10)
+
+	palette := ui.CurrentTheme().Palette
+	w, h := float32(g.Config.Width), float32(g.Config.Height)
+
+	switch g.state {
+	case StateUpgrade:
+		vector.FillRect(screen, 0, 0, w, h, palette.Overlay, false)
+
+		title := fmt.Sprintf("Wave %d cleared - choose an upgrade", g.Wave())
+		_, top := g.choiceRect(0)
+		ebitenutil.DebugPrintAt(screen, title, (g.Config.Width-len(title)*6)/2, int(top)-24)
+
+		for i, c := range g.choices {
+			x, y := g.choiceRect(i)
+			panel := color.NRGBA{R: palette.Panel.R, G: palette.Panel.G, B: palette.Panel.B, A: 230}
+
+			border := palette.PanelBorder
+			if i == g.focus {
+				border = palette.Highlight
+			}
+
+			vector.FillRect(screen, x, y, choiceW, choiceH, panel, false)
+			vector.StrokeRect(screen, x, y, choiceW, choiceH, 2, border, false)
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d. %s", i+1, c.Name), int(x)+10, int(y)+6)
+			ebitenutil.DebugPrintAt(screen, c.Description, int(x)+10, int(y)+22)
+		}
+	case StateGameOver:
+		vector.FillRect(screen, 0, 0, w, h, palette.Overlay, false)
+
+		lines := []string{
+			g.Config.Title,
+			fmt.Sprintf("Fell on wave %d with %d points", g.Wave(), g.score),
+			"Press SPACE to try again",
+		}
+		for i, line := range lines {
+			ebitenutil.DebugPrintAt(screen, line, (g.Config.Width-len(line)*6)/2, g.Config.Height/2-30+i*20)
+		}
+	case StatePlaying:
+		if g.paused {
+			ui.DrawPaused(screen, "ESC or P to resume")
+		}
+	}
+}
+
+// Layout returns the configured screen size.
+func (g *Game) Layout(_, _ int) (int, int) {
+	return g.Config.Width, g.Config.Height
+}
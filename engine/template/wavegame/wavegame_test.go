@@ -0,0 +1,155 @@
+package wavegame
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// arena spawns enemies costing one point each and kills one per frame when
+// killing is set.
+type arena struct {
+	alive, resets int
+	killing       bool
+	dies          bool
+	power         int
+}
+
+func (a *arena) Reset() {
+	*a = arena{resets: a.resets + 1, killing: a.killing}
+}
+
+func (a *arena) Spawn(_ int, budget float64) float64 {
+	if budget < 1 {
+		return 0
+	}
+
+	a.alive++
+
+	return 1
+}
+
+func (a *arena) Update(g *Game, _ float64) {
+	if a.dies {
+		g.End()
+	}
+
+	if a.killing && a.alive > 0 {
+		a.alive--
+		g.AddScore(10)
+	}
+}
+
+func (a *arena) Alive() int {
+	return a.alive
+}
+
+func (a *arena) Upgrades(int) []Upgrade {
+	return []Upgrade{
+		{Name: "Power", Apply: func() { a.power++ }},
+		{Name: "Nothing"},
+	}
+}
+
+func (*arena) Draw(*ebiten.Image) {}
+
+func TestDirectorSpendsAGrowingBudget(t *testing.T) {
+	d := NewDirector(DirectorConfig{WaveDuration: 10, BaseBudget: 2, BudgetGrowth: 2, MaxAlive: 30})
+
+	spawned := 0
+	spawn := func(_ int, budget float64) float64 {
+		if budget < 1 {
+			return 0
+		}
+
+		spawned++
+
+		return 1
+	}
+
+	d.Start(1)
+
+	for range 20 {
+		d.Update(1, spawned, spawn)
+	}
+
+	if spawned != 20 || !d.Done(spawned) {
+		t.Fatalf("wave 1 spawned %d, want 20 over its 10 seconds", spawned)
+	}
+
+	spawned = 0
+	d.Start(2)
+	d.Update(10, 0, spawn)
+
+	if spawned != 30 {
+		t.Errorf("wave 2 spawned %d, want 40 capped at MaxAlive 30", spawned)
+	}
+
+	d.Config.ClearToEnd = true
+	if d.Done(1) || !d.Done(0) {
+		t.Error("a ClearToEnd wave should end only once the field is clear")
+	}
+}
+
+func TestGameRunsWavesUpgradesAndGameOver(t *testing.T) {
+	rules := &arena{killing: true}
+	g := New(rules, Config{
+		Width:    640,
+		Height:   480,
+		Director: DirectorConfig{WaveDuration: 1, BaseBudget: 5, ClearToEnd: true},
+	})
+
+	for range 100 {
+		g.Step(0.1)
+	}
+
+	if g.State() != StateUpgrade || g.Wave() != 1 || g.Score() != 50 {
+		t.Fatalf("state %d wave %d score %d, want the wave 1 upgrade pick with 50 points",
+			g.State(), g.Wave(), g.Score())
+	}
+
+	g.Choose(0)
+
+	if rules.power != 1 || g.State() != StatePlaying || g.Wave() != 2 {
+		t.Fatalf("power %d state %d wave %d after choosing", rules.power, g.State(), g.Wave())
+	}
+
+	rules.dies = true
+	g.Step(0.1)
+
+	if g.State() != StateGameOver {
+		t.Fatal("the run should end when the rules call End")
+	}
+
+	g.Restart()
+
+	if rules.resets != 2 || g.Wave() != 1 || g.Score() != 0 || g.Best() != 50 {
+		t.Errorf("resets %d wave %d score %d best %d after restarting", rules.resets, g.Wave(), g.Score(), g.Best())
+	}
+}
+
+func TestPauseOnlyHoldsARunInProgress(t *testing.T) {
+	rules := &arena{}
+	g := New(rules, Config{Director: DirectorConfig{WaveDuration: 1, BaseBudget: 5}})
+
+	rules.dies = true
+	g.Step(0.1)
+	g.Pause()
+
+	if g.Paused() {
+		t.Fatal("a finished run should not pause")
+	}
+
+	g.Restart()
+	g.Pause()
+
+	if !g.Paused() {
+		t.Fatal("a run in progress should pause")
+	}
+
+	g.Restart()
+
+	if g.Paused() {
+		t.Error("restarting should clear the pause")
+	}
+}
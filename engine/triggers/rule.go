@@ -0,0 +1,267 @@
+// Package triggers unlocks achievements from event-bus events picked out by
+// declarative rules, so a game that publishes its events can add
+// achievements as data without writing tracking code:
+//
+//	EnemyKilled where type=Boss and damageTaken=0 within run
+//
+// A rule names an event type, optionally narrows it with conditions on the
+// event's fields, and optionally scopes its progress to a stretch of play
+// that the game begins with Tracker.Begin.
+package triggers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Rule keywords.
+const (
+	keywordWhere  = "where"
+	keywordAnd    = "and"
+	keywordWithin = "within"
+)
+
+// Op compares an event field with a condition's value.
+type Op string
+
+// Comparison operators, longest first so "<=" is not read as "<".
+const (
+	OpNotEqual  Op = "!="
+	OpLessEq    Op = "<="
+	OpGreaterEq Op = ">="
+	OpEqual     Op = "="
+	OpLess      Op = "<"
+	OpGreater   Op = ">"
+)
+
+var ops = []Op{OpNotEqual, OpLessEq, OpGreaterEq, OpEqual, OpLess, OpGreater}
+
+// Cond is one condition on an event field, e.g. damageTaken=0.
+type Cond struct {
+	Field string // Matched against the event's exported fields ignoring case
+	Op    Op
+	Value string
+}
+
+// Rule is a parsed trigger.
+type Rule struct {
+	Event string // Event type name, e.g. "EnemyKilled"
+	Conds []Cond
+	Scope string // Progress resets when this scope begins; "" counts forever
+}
+
+// Parse parses a rule of the form
+//
+//	Event [where field op value {and field op value}] [within scope]
+//
+// where op is one of = != < <= > >=. Keywords are case-insensitive and
+// values may contain spaces, e.g. "monster=Null Pointer".
+func Parse(src string) (Rule, error) {
+	words := strings.Fields(src)
+	if len(words) == 0 {
+		return Rule{}, errors.New("triggers: empty rule")
+	}
+
+	r := Rule{Event: words[0]}
+	words = words[1:]
+
+	if n := len(words); n >= 2 && strings.EqualFold(words[n-2], keywordWithin) {
+		r.Scope = words[n-1]
+		words = words[:n-2]
+	}
+
+	if len(words) == 0 {
+		return r, nil
+	}
+
+	if !strings.EqualFold(words[0], keywordWhere) || len(words) == 1 {
+		return Rule{}, fmt.Errorf("triggers: %q: want \"where\" conditions or a \"within\" scope", src)
+	}
+
+	var cond []string
+
+	for _, w := range append(words[1:], keywordAnd) {
+		if !strings.EqualFold(w, keywordAnd) {
+			cond = append(cond, w)
+
+			continue
+		}
+
+		c, err := parseCond(strings.Join(cond, " "))
+		if err != nil {
+			return Rule{}, fmt.Errorf("triggers: %q: %w", src, err)
+		}
+
+		r.Conds = append(r.Conds, c)
+		cond = cond[:0]
+	}
+
+	return r, nil
+}
+
+func parseCond(s string) (Cond, error) {
+	for _, op := range ops {
+		i := strings.Index(s, string(op))
+		if i < 0 {
+			continue
+		}
+
+		c := Cond{
+			Field: strings.TrimSpace(s[:i]),
+			Op:    op,
+			Value: strings.TrimSpace(s[i+len(op):]),
+		}
+		if c.Field == "" || c.Value == "" {
+			return Cond{}, fmt.Errorf("incomplete condition %q", s)
+		}
+
+		return c, nil
+	}
+
+	return Cond{}, fmt.Errorf("condition %q has no operator", s)
+}
+
+// String formats the rule back into its source form.
+func (r Rule) String() string {
+	var b strings.Builder
+
+	b.WriteString(r.Event)
+
+	for i, c := range r.Conds {
+		if i == 0 {
+			b.WriteString(" " + keywordWhere + " ")
+		} else {
+			b.WriteString(" " + keywordAnd + " ")
+		}
+
+		b.WriteString(c.Field + string(c.Op) + c.Value)
+	}
+
+	if r.Scope != "" {
+		b.WriteString(" " + keywordWithin + " " + r.Scope)
+	}
+
+	return b.String()
+}
+
+// Check reports whether the rule can be matched against events of type t:
+// every condition must name a field of t and compare it in a way its kind
+// supports.
+func (r Rule) Check(t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("triggers: event %v is not a struct", t)
+	}
+
+	for _, c := range r.Conds {
+		f, ok := field(t, c.Field)
+		if !ok {
+			return fmt.Errorf("triggers: %q: %s has no field %q", r, t.Name(), c.Field)
+		}
+
+		if _, err := c.compare(reflect.Zero(f.Type)); err != nil {
+			return fmt.Errorf("triggers: %q: %w", r, err)
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether ev is the rule's event and meets every condition.
+func (r Rule) Match(ev any) bool {
+	v := reflect.ValueOf(ev)
+	if v.Kind() != reflect.Struct || v.Type().Name() != r.Event {
+		return false
+	}
+
+	for _, c := range r.Conds {
+		f, ok := field(v.Type(), c.Field)
+		if !ok {
+			return false
+		}
+
+		if ok, err := c.compare(v.FieldByIndex(f.Index)); err != nil || !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// field finds an exported field of t by name, ignoring case.
+func field(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		if f := t.Field(i); f.IsExported() && strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// compare applies the condition to a field value. Numbers compare
+// numerically, booleans and strings only for (in)equality, and other values
+// with a String method by their string form.
+func (c Cond) compare(v reflect.Value) (bool, error) {
+	if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() != reflect.String {
+		return c.compareString(s.String())
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.compareNumber(float64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.compareNumber(float64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return c.compareNumber(v.Float())
+	case reflect.Bool:
+		want, err := strconv.ParseBool(c.Value)
+		if err != nil {
+			return false, fmt.Errorf("%s wants true or false, got %q", c.Field, c.Value)
+		}
+
+		return c.equality(v.Bool() == want)
+	case reflect.String:
+		return c.compareString(v.String())
+	default:
+		return false, fmt.Errorf("%s is a %v, which rules cannot compare", c.Field, v.Type())
+	}
+}
+
+func (c Cond) compareNumber(got float64) (bool, error) {
+	want, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return false, fmt.Errorf("%s wants a number, got %q", c.Field, c.Value)
+	}
+
+	switch c.Op {
+	case OpLess:
+		return got < want, nil
+	case OpLessEq:
+		return got <= want, nil
+	case OpGreater:
+		return got > want, nil
+	case OpGreaterEq:
+		return got >= want, nil
+	default:
+		return c.equality(got == want)
+	}
+}
+
+func (c Cond) compareString(got string) (bool, error) {
+	return c.equality(got == c.Value)
+}
+
+// equality applies = or != to whether the field equals the value.
+func (c Cond) equality(equal bool) (bool, error) {
+	switch c.Op {
+	case OpEqual:
+		return equal, nil
+	case OpNotEqual:
+		return !equal, nil
+	default:
+		return false, fmt.Errorf("%s cannot be compared with %s", c.Field, c.Op)
+	}
+}
@@ -0,0 +1,122 @@
+package triggers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type kind int
+
+func (k kind) String() string {
+	if k == 1 {
+		return "Boss"
+	}
+
+	return "Mob"
+}
+
+type EnemyKilled struct {
+	Type        kind
+	Name        string
+	DamageTaken int
+	Time        float64
+	Elite       bool
+}
+
+func TestParse(t *testing.T) {
+	r, err := Parse("EnemyKilled where type=Boss and damageTaken = 0 and name!=Null Pointer within run")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Rule{
+		Event: "EnemyKilled",
+		Conds: []Cond{
+			{Field: "type", Op: OpEqual, Value: "Boss"},
+			{Field: "damageTaken", Op: OpEqual, Value: "0"},
+			{Field: "name", Op: OpNotEqual, Value: "Null Pointer"},
+		},
+		Scope: "run",
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("rule = %+v, want %+v", r, want)
+	}
+
+	if s := r.String(); s != "EnemyKilled where type=Boss and damageTaken=0 and name!=Null Pointer within run" {
+		t.Errorf("String() = %q", s)
+	}
+
+	if r, err := Parse("RunEnded"); err != nil || r.Event != "RunEnded" || r.Conds != nil || r.Scope != "" {
+		t.Errorf("bare event = %+v, %v", r, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"EnemyKilled where",
+		"EnemyKilled if type=Boss",
+		"EnemyKilled where type",
+		"EnemyKilled where type= and time<5",
+		"EnemyKilled where >=3",
+	} {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Parse(%q) should fail", src)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	boss := EnemyKilled{Type: 1, Name: "Manager", Time: 90}
+
+	for src, want := range map[string]bool{
+		"EnemyKilled":                                   true,
+		"EnemyKilled where type=Boss":                   true,
+		"EnemyKilled where type=Mob":                    false,
+		"EnemyKilled where type=Boss and damageTaken=0": true,
+		"EnemyKilled where damageTaken>0":               false,
+		"EnemyKilled where time>=90 and time<120":       true,
+		"EnemyKilled where TIME<=60":                    false,
+		"EnemyKilled where elite=false":                 true,
+		"EnemyKilled where name!=Manager":               false,
+		"RunEnded":                                      false,
+		"EnemyKilled where missing=1":                   false,
+		"EnemyKilled where name<Manager":                false,
+	} {
+		if got := mustParse(t, src).Match(boss); got != want {
+			t.Errorf("%q matched = %v, want %v", src, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	typ := reflect.TypeFor[EnemyKilled]()
+
+	if err := mustParse(t, "EnemyKilled where type=Boss and time<60 and elite=true").Check(typ); err != nil {
+		t.Errorf("valid rule: %v", err)
+	}
+
+	for src, problem := range map[string]string{
+		"EnemyKilled where weapon=Coffee": "no field",
+		"EnemyKilled where time=soon":     "number",
+		"EnemyKilled where elite=maybe":   "true or false",
+		"EnemyKilled where name>B":        "cannot be compared",
+	} {
+		err := mustParse(t, src).Check(typ)
+		if err == nil || !strings.Contains(err.Error(), problem) {
+			t.Errorf("Check(%q) = %v, want an error about %q", src, err, problem)
+		}
+	}
+}
+
+func mustParse(t *testing.T, src string) Rule {
+	t.Helper()
+
+	r, err := Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
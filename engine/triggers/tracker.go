@@ -0,0 +1,109 @@
+package triggers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+// Tracker advances the achievements that have a Trigger rule: each event
+// matching an achievement's rule adds one to its progress toward Target.
+type Tracker struct {
+	achievements *components.AchievementTracker
+	rules        map[string]Rule // By achievement ID
+	ids          []string        // Sorted, so unlocks come in a stable order
+
+	// OnUnlock, if set, is called for each achievement a trigger unlocks.
+	OnUnlock func(a *components.Achievement)
+}
+
+// New parses the Trigger of every achievement in at that has one. Bad rules
+// are reported together; the achievements with good ones still track.
+func New(at *components.AchievementTracker) (*Tracker, error) {
+	t := &Tracker{achievements: at, rules: make(map[string]Rule)}
+
+	var errs []error
+
+	for id, a := range at.Achievements {
+		if a.Trigger == "" {
+			continue
+		}
+
+		r, err := Parse(a.Trigger)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("achievement %s: %w", id, err))
+
+			continue
+		}
+
+		t.rules[id] = r
+		t.ids = append(t.ids, id)
+	}
+
+	sort.Strings(t.ids)
+
+	return t, errors.Join(errs...)
+}
+
+// Rule returns the parsed trigger of an achievement.
+func (t *Tracker) Rule(id string) (Rule, bool) {
+	r, ok := t.rules[id]
+
+	return r, ok
+}
+
+// Watch feeds events of type T published on b to the tracker and returns a
+// function that stops it. It reports rules on T that name a missing field
+// or compare one in a way its kind does not support.
+func Watch[T any](t *Tracker, b *events.Bus) (unwatch func(), err error) {
+	typ := reflect.TypeFor[T]()
+
+	var errs []error
+
+	for _, id := range t.ids {
+		if r := t.rules[id]; r.Event == typ.Name() {
+			errs = append(errs, r.Check(typ))
+		}
+	}
+
+	return events.Subscribe(b, func(ev T) { t.Handle(ev) }), errors.Join(errs...)
+}
+
+// Handle advances every locked achievement whose rule matches ev and
+// returns the IDs it unlocked.
+func (t *Tracker) Handle(ev any) []string {
+	var unlocked []string
+
+	for _, id := range t.ids {
+		a := t.achievements.Achievements[id]
+		if a == nil || a.Unlocked || !t.rules[id].Match(ev) {
+			continue
+		}
+
+		if a.AddProgress(1) {
+			a.UnlockedAt = time.Now().Unix()
+			unlocked = append(unlocked, id)
+
+			if t.OnUnlock != nil {
+				t.OnUnlock(a)
+			}
+		}
+	}
+
+	return unlocked
+}
+
+// Begin starts a new stretch of a scope, e.g. "run", resetting the progress
+// of the locked achievements scoped to it.
+func (t *Tracker) Begin(scope string) {
+	for _, id := range t.ids {
+		if a := t.achievements.Achievements[id]; a != nil && !a.Unlocked && t.rules[id].Scope == scope {
+			a.Progress = 0
+		}
+	}
+}
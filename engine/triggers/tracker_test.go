@@ -0,0 +1,125 @@
+package triggers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+func newTestTracker(
+	t *testing.T, achievements ...components.Achievement,
+) (*Tracker, *components.AchievementTracker) {
+	t.Helper()
+
+	at := components.NewAchievementTracker()
+	for _, a := range achievements {
+		at.Add(a)
+	}
+
+	tr, err := New(&at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tr, &at
+}
+
+func TestTrackerUnlocksFromBusEvents(t *testing.T) {
+	tr, at := newTestTracker(t,
+		components.Achievement{ID: "flawless", Target: 1, Trigger: "EnemyKilled where type=Boss and damageTaken=0"},
+		components.Achievement{ID: "hunter", Target: 3, Trigger: "EnemyKilled"},
+		components.Achievement{ID: "counted", Target: 1, Stat: "kills"},
+	)
+
+	var announced []string
+
+	tr.OnUnlock = func(a *components.Achievement) { announced = append(announced, a.ID) }
+
+	bus := events.NewBus()
+
+	unwatch, err := Watch[EnemyKilled](tr, bus)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events.Publish(bus, EnemyKilled{Type: 1, DamageTaken: 5})
+	events.Publish(bus, EnemyKilled{})
+
+	if len(announced) != 0 || at.Achievements["hunter"].Progress != 2 {
+		t.Fatalf("announced %v, hunter at %d; want nothing yet and 2", announced, at.Achievements["hunter"].Progress)
+	}
+
+	events.Publish(bus, EnemyKilled{Type: 1})
+
+	if !reflect.DeepEqual(announced, []string{"flawless", "hunter"}) {
+		t.Errorf("announced %v, want flawless and hunter in ID order", announced)
+	}
+
+	if a := at.Achievements["flawless"]; !a.Unlocked || a.UnlockedAt == 0 {
+		t.Errorf("flawless = %+v, want unlocked with a time", a)
+	}
+
+	if at.Achievements["counted"].Progress != 0 {
+		t.Error("stat-driven achievements are not the tracker's to advance")
+	}
+
+	unwatch()
+	events.Publish(bus, EnemyKilled{Type: 1})
+
+	if len(announced) != 2 {
+		t.Errorf("events reached the tracker after unwatch: %v", announced)
+	}
+}
+
+func TestTrackerScopesProgress(t *testing.T) {
+	tr, at := newTestTracker(t,
+		components.Achievement{ID: "spree", Target: 2, Trigger: "EnemyKilled within run"},
+		components.Achievement{ID: "career", Target: 3, Trigger: "EnemyKilled"},
+	)
+
+	tr.Begin("run")
+	tr.Handle(EnemyKilled{})
+	tr.Begin("run")
+	tr.Handle(EnemyKilled{})
+
+	if at.Achievements["spree"].Unlocked || at.Achievements["spree"].Progress != 1 {
+		t.Errorf("spree = %+v, want one kill into the new run", at.Achievements["spree"])
+	}
+
+	if at.Achievements["career"].Progress != 2 {
+		t.Errorf("career = %+v, want unscoped progress kept", at.Achievements["career"])
+	}
+
+	if got := tr.Handle(EnemyKilled{}); !reflect.DeepEqual(got, []string{"career", "spree"}) {
+		t.Errorf("unlocked %v, want both", got)
+	}
+
+	tr.Begin("run")
+
+	if !at.Achievements["spree"].Unlocked || at.Achievements["spree"].Progress != 2 {
+		t.Error("a new scope must not take back an unlocked achievement")
+	}
+}
+
+func TestTrackerReportsBadRules(t *testing.T) {
+	at := components.NewAchievementTracker()
+	at.Add(components.Achievement{ID: "broken", Target: 1, Trigger: "EnemyKilled where"})
+	at.Add(components.Achievement{ID: "typo", Target: 1, Trigger: "EnemyKilled where weapon=Coffee"})
+	at.Add(components.Achievement{ID: "fine", Target: 1, Trigger: "EnemyKilled"})
+
+	tr, err := New(&at)
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("New error = %v, want one naming the broken rule", err)
+	}
+
+	if _, ok := tr.Rule("fine"); !ok {
+		t.Error("good rules should still track")
+	}
+
+	if _, err := Watch[EnemyKilled](tr, events.NewBus()); err == nil || !strings.Contains(err.Error(), "weapon") {
+		t.Errorf("Watch error = %v, want one naming the missing field", err)
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Boss bar timing.
+const (
+	bossGhostDelay = 0.6 // Seconds recent damage holds before draining
+	bossGhostRate  = 0.5 // Fill fraction per second the ghost drains
+	bossFadeRate   = 4.0 // Show/hide fade, per second
+)
+
+// BossStatus is the state a BossBar shows for one frame.
+type BossStatus struct {
+	Name    string
+	HP, Max float64
+	// Phases are HP fractions where the boss changes phase, e.g. 0.66 and 0.33.
+	Phases []float64
+	// EnrageIn is the seconds left before the boss enrages; negative hides the timer.
+	EnrageIn float64
+	Enraged  bool
+}
+
+// BossBar is a large screen-wide health bar for the current boss, with phase
+// markers, a ghost of recent damage, and an enrage countdown. Call Show each
+// frame a boss is present and Hide when none is, then Update and Draw.
+type BossBar struct {
+	X, Y, Width, Height float64
+
+	status  BossStatus
+	fill    float64 // Current HP fraction
+	ghost   float64 // End of the recent-damage segment (>= fill)
+	hold    float64
+	alpha   float64
+	visible bool
+}
+
+// NewBossBar creates a boss bar spanning the given rectangle.
+func NewBossBar(x, y, width, height float64) *BossBar {
+	return &BossBar{X: x, Y: y, Width: width, Height: height}
+}
+
+// Show sets the boss to display. Switching to a different boss snaps the bar
+// instead of animating from the previous one.
+func (b *BossBar) Show(s BossStatus) {
+	frac := 0.0
+	if s.Max > 0 {
+		frac = math.Max(0, math.Min(1, s.HP/s.Max))
+	}
+
+	switch {
+	case !b.visible || s.Name != b.status.Name:
+		b.ghost, b.hold = frac, 0
+	case frac < b.fill:
+		b.ghost = math.Max(b.ghost, b.fill)
+		b.hold = bossGhostDelay
+	}
+
+	b.status = s
+	b.fill = frac
+	b.visible = true
+}
+
+// Hide fades the bar out.
+func (b *BossBar) Hide() {
+	b.visible = false
+}
+
+// Visible reports whether the bar is shown or still fading out.
+func (b *BossBar) Visible() bool {
+	return b.alpha > 0
+}
+
+// Status returns the boss last passed to Show.
+func (b *BossBar) Status() BossStatus {
+	return b.status
+}
+
+// Ghost returns the end of the recent-damage segment as an HP fraction.
+func (b *BossBar) Ghost() float64 {
+	return b.ghost
+}
+
+// Update advances the fade and drains the damage ghost.
+func (b *BossBar) Update(dt float64) {
+	if b.visible {
+		b.alpha = math.Min(1, b.alpha+bossFadeRate*dt)
+	} else {
+		b.alpha = math.Max(0, b.alpha-bossFadeRate*dt)
+	}
+
+	switch {
+	case b.ghost <= b.fill:
+		b.ghost = b.fill
+	case b.hold > 0:
+		b.hold -= dt
+	default:
+		b.ghost = math.Max(b.fill, b.ghost-bossGhostRate*dt)
+	}
+}
+
+// Draw renders the bar with the current theme.
+func (b *BossBar) Draw(screen *ebiten.Image) {
+	if b.alpha <= 0 {
+		return
+	}
+
+	palette := CurrentTheme().Palette
+	fade := func(c color.RGBA) color.NRGBA {
+		return color.NRGBA{R: c.R, G: c.G, B: c.B, A: uint8(float64(c.A) * b.alpha)}
+	}
+
+	x, y, w, h := float32(b.X), float32(b.Y), float32(b.Width), float32(b.Height)
+
+	fillCol := palette.Danger
+	if b.status.Enraged {
+		// Pulse while enraged
+		pulse := 0.5 + 0.5*math.Sin(float64(ebiten.Tick())*0.2)
+		fillCol = lerpRGBA(palette.Danger, palette.Warning, pulse)
+	}
+
+	vector.FillRect(screen, x-2, y-2, w+4, h+4, fade(palette.Panel), false)
+	vector.FillRect(screen, x, y, w*float32(b.ghost), h, fade(palette.Text), false)
+	vector.FillRect(screen, x, y, w*float32(b.fill), h, fade(fillCol), false)
+
+	for _, p := range b.status.Phases {
+		if p <= 0 || p >= 1 {
+			continue
+		}
+
+		mx := x + w*float32(p)
+		vector.StrokeLine(screen, mx, y-4, mx, y+h+4, 2, fade(palette.PanelBorder), false)
+	}
+
+	vector.StrokeRect(screen, x, y, w, h, 1, fade(palette.PanelBorder), false)
+
+	if b.alpha < 1 {
+		return
+	}
+
+	ebitenutil.DebugPrintAt(screen, b.status.Name, int(x), int(y)-18)
+
+	hp := fmt.Sprintf("%.0f / %.0f", math.Max(0, b.status.HP), b.status.Max)
+	ebitenutil.DebugPrintAt(screen, hp, int(x+w/2)-len(hp)*3, int(y+h/2)-8)
+
+	switch {
+	case b.status.Enraged:
+		ebitenutil.DebugPrintAt(screen, "ENRAGED", int(x+w)-42, int(y)-18)
+	case b.status.EnrageIn >= 0:
+		label := fmt.Sprintf("Enrage %d:%02d", int(b.status.EnrageIn)/60, int(b.status.EnrageIn)%60)
+		ebitenutil.DebugPrintAt(screen, label, int(x+w)-len(label)*6, int(y)-18)
+	}
+}
+
+// lerpRGBA blends a toward b by t in [0, 1].
+func lerpRGBA(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 { return uint8(float64(x) + (float64(y)-float64(x))*t) }
+
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// Button is a labeled button drawn with the skin's button art. On its own
+// it handles the mouse; put buttons in a ButtonGroup for keyboard and
+// gamepad focus.
+type Button struct {
+	Rect
+
+	Label    string
+	Tooltip  string // Shown while the cursor is over the button; "" for none
+	Disabled bool
+	Focused  bool  // Drawn highlighted; set by ButtonGroup
+	Skin     *Skin // Nil uses the current theme's skin
+
+	// OnClick runs when the button is pressed.
+	OnClick func()
+
+	hovered          bool
+	cursorX, cursorY int
+}
+
+// NewButton creates a button.
+func NewButton(x, y, w, h float64, label string) *Button {
+	return &Button{Rect: Rect{X: x, Y: y, W: w, H: h}, Label: label}
+}
+
+// Update handles the mouse and reports whether the button was clicked.
+func (b *Button) Update() bool {
+	return b.update(readPointer())
+}
+
+func (b *Button) update(p Pointer) bool {
+	b.hovered = b.Contains(p.X, p.Y)
+	b.cursorX, b.cursorY = p.X, p.Y
+
+	if !b.hovered || !p.Clicked || b.Disabled {
+		return false
+	}
+
+	b.press()
+
+	return true
+}
+
+func (b *Button) press() {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+// Draw draws the button: pressed art while disabled, hover art while
+// focused or under the cursor.
+func (b *Button) Draw(screen *ebiten.Image) {
+	state := ButtonStateNormal
+
+	switch {
+	case b.Disabled:
+		state = ButtonStatePressed
+	case b.Focused || b.hovered:
+		state = ButtonStateHover
+	}
+
+	skinOr(b.Skin).ButtonSlice(state).Draw(screen, b.X, b.Y, b.W, b.H)
+	drawCentered(screen, b.Label, b.Rect)
+}
+
+// DrawTooltip draws the button's tooltip at the cursor while it is over the
+// button. Draw it after everything it may overlap.
+func (b *Button) DrawTooltip(screen *ebiten.Image) {
+	if b.hovered && b.Tooltip != "" {
+		(&Tooltip{Text: b.Tooltip, Skin: b.Skin}).Draw(screen, float64(b.cursorX), float64(b.cursorY))
+	}
+}
+
+// ButtonGroup gives a row or column of buttons keyboard and gamepad focus:
+// the group's axis steps the focus, skipping disabled buttons, and Confirm
+// presses the focused one. Moving or clicking the mouse focuses the button
+// under it, and a resting cursor leaves the keyboard's focus alone.
+type ButtonGroup struct {
+	Buttons []*Button
+	Axis    input.Axis // Steps the focus: MoveX for a row, MoveY for a column
+
+	focus int
+	hover hover
+}
+
+// NewButtonGroup groups buttons laid out in a row.
+func NewButtonGroup(buttons ...*Button) *ButtonGroup {
+	g := &ButtonGroup{Buttons: buttons}
+	g.SetFocus(0)
+
+	return g
+}
+
+// Focus returns the index of the focused button.
+func (g *ButtonGroup) Focus() int {
+	return g.focus
+}
+
+// SetFocus focuses button i, or the next enabled button after it.
+func (g *ButtonGroup) SetFocus(i int) {
+	if len(g.Buttons) == 0 {
+		return
+	}
+
+	g.focus = min(max(i, 0), len(g.Buttons)-1)
+	if g.Buttons[g.focus].Disabled {
+		g.move(1)
+	}
+
+	g.markFocus()
+}
+
+// Update handles this tick's input from m and the mouse, and returns the
+// index of the pressed button, or -1.
+func (g *ButtonGroup) Update(m *input.Map) int {
+	return g.update(readPointer(), m.JustMoved(g.Axis), m.JustPressed(input.Confirm))
+}
+
+func (g *ButtonGroup) update(p Pointer, step int, confirm bool) int {
+	if len(g.Buttons) == 0 {
+		return -1
+	}
+
+	moved := g.hover.moved(p)
+	if step != 0 {
+		g.move(step)
+	}
+
+	pressed := -1
+
+	for i, b := range g.Buttons {
+		if moved && !b.Disabled && b.Contains(p.X, p.Y) {
+			g.focus = i
+		}
+
+		if b.update(p) {
+			pressed = i
+		}
+	}
+
+	if focused := g.Buttons[g.focus]; pressed < 0 && confirm && !focused.Disabled {
+		focused.press()
+		pressed = g.focus
+	}
+
+	g.markFocus()
+
+	return pressed
+}
+
+// move steps the focus, wrapping around and skipping disabled buttons.
+func (g *ButtonGroup) move(step int) {
+	n := len(g.Buttons)
+	dir := 1
+
+	if step < 0 {
+		dir = -1
+	}
+
+	for range n {
+		g.focus = ((g.focus+dir)%n + n) % n
+		if !g.Buttons[g.focus].Disabled {
+			return
+		}
+	}
+}
+
+func (g *ButtonGroup) markFocus() {
+	for i, b := range g.Buttons {
+		b.Focused = i == g.focus
+	}
+}
+
+// Draw draws the buttons, then the tooltip of the one under the cursor.
+func (g *ButtonGroup) Draw(screen *ebiten.Image) {
+	for _, b := range g.Buttons {
+		b.Draw(screen)
+	}
+
+	for _, b := range g.Buttons {
+		b.DrawTooltip(screen)
+	}
+}
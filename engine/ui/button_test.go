@@ -0,0 +1,67 @@
+package ui
+
+import "testing"
+
+func TestButtonClick(t *testing.T) {
+	b := NewButton(10, 10, 80, 24, "Deal")
+
+	clicks := 0
+	b.OnClick = func() { clicks++ }
+
+	if b.update(Pointer{X: 5, Y: 5, Clicked: true}) {
+		t.Error("a click outside the button pressed it")
+	}
+
+	if !b.update(Pointer{X: 20, Y: 20, Clicked: true}) || clicks != 1 {
+		t.Errorf("a click on the button ran OnClick %d times, want 1", clicks)
+	}
+
+	b.Disabled = true
+
+	if b.update(Pointer{X: 20, Y: 20, Clicked: true}) || clicks != 1 {
+		t.Error("a disabled button was pressed")
+	}
+}
+
+func TestButtonGroupFocus(t *testing.T) {
+	hit := NewButton(0, 0, 50, 20, "Hit")
+	stand := NewButton(60, 0, 50, 20, "Stand")
+	split := NewButton(120, 0, 50, 20, "Split")
+	split.Disabled = true
+
+	g := NewButtonGroup(hit, stand, split)
+	rest := Pointer{X: 300, Y: 300}
+
+	// The keyboard skips the disabled button and wraps around
+	g.update(rest, 1, false)
+	g.update(rest, 1, false)
+
+	if g.Focus() != 0 || !hit.Focused || stand.Focused {
+		t.Errorf("focus %d after two steps right, want Hit", g.Focus())
+	}
+
+	if got := g.update(rest, 0, true); got != 0 {
+		t.Errorf("Confirm pressed %d, want Hit", got)
+	}
+
+	// Moving over a button focuses it
+	g.update(Pointer{X: 70, Y: 5}, 0, false)
+
+	if g.Focus() != 1 {
+		t.Fatalf("moving over Stand focused %d", g.Focus())
+	}
+
+	// A cursor resting on a button does not take focus from the keyboard
+	g.update(Pointer{X: 70, Y: 5}, -1, false)
+	g.update(Pointer{X: 70, Y: 5}, 0, false)
+
+	if g.Focus() != 0 {
+		t.Errorf("resting cursor took focus back: %d", g.Focus())
+	}
+
+	// Clicking presses the button under the cursor
+
+	if got := g.update(Pointer{X: 75, Y: 6, Clicked: true}, 0, false); got != 1 {
+		t.Errorf("clicking Stand pressed %d", got)
+	}
+}
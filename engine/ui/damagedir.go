@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+// Damage indicator defaults.
+const (
+	DefaultDamageArcDuration = 1.0         // Seconds an arc takes to fade out
+	DefaultDamageArcSpread   = math.Pi / 5 // Half-width of an arc, in radians
+	DefaultDamageArcDepth    = 28.0        // Thickness at the arc's center, in pixels
+	DefaultDamageArcMax      = 8           // Arcs shown at once
+
+	damageArcMinAlpha = 0.35 // Opacity of the weakest hit, so every hit is seen
+	damageArcSegments = 16
+)
+
+// DamageFrom is the event published on an events.Bus when something hits the
+// player, so a DamageIndicator can point at the attacker.
+type DamageFrom struct {
+	X, Y             float64 // Attacker's world position
+	TargetX, TargetY float64 // Victim's world position
+	// Strength is 0-1, e.g. the hit as a fraction of max HP; it sets how
+	// bright the arc starts.
+	Strength float64
+}
+
+// DamageArc is one direction the player was recently hit from.
+type DamageArc struct {
+	Angle    float64 // Screen direction toward the attacker; 0 is right, Pi/2 is down
+	Strength float64
+	Age      float64 // Seconds since the last hit from this direction
+}
+
+// DamageIndicator draws a red arc on the screen edge toward each recent
+// attacker, fading out over Duration. Hits from nearly the same direction
+// refresh one arc instead of stacking.
+type DamageIndicator struct {
+	Duration float64 // 0 uses DefaultDamageArcDuration
+	Spread   float64 // 0 uses DefaultDamageArcSpread
+	Depth    float64 // 0 uses DefaultDamageArcDepth
+	MaxArcs  int     // 0 uses DefaultDamageArcMax; the oldest arc goes first
+	Color    color.RGBA
+
+	arcs  []DamageArc
+	unsub func()
+}
+
+// NewDamageIndicator creates an indicator in the theme's danger color. If
+// bus is non-nil, DamageFrom events published on it are shown automatically.
+func NewDamageIndicator(bus *events.Bus) *DamageIndicator {
+	d := &DamageIndicator{Color: CurrentTheme().Palette.Danger}
+
+	if bus != nil {
+		d.unsub = events.Subscribe(bus, d.Add)
+	}
+
+	return d
+}
+
+// Close detaches the indicator from its event bus.
+func (d *DamageIndicator) Close() {
+	if d.unsub != nil {
+		d.unsub()
+		d.unsub = nil
+	}
+}
+
+// Add shows a hit. Hits with the attacker on top of the target, like
+// hazards underfoot, have no direction and are ignored.
+func (d *DamageIndicator) Add(e DamageFrom) {
+	dx, dy := e.X-e.TargetX, e.Y-e.TargetY
+	if math.Hypot(dx, dy) < 1e-6 {
+		return
+	}
+
+	d.Hit(math.Atan2(dy, dx), e.Strength)
+}
+
+// Hit shows a hit from angle, measured like math.Atan2 in screen space.
+func (d *DamageIndicator) Hit(angle, strength float64) {
+	strength = math.Max(0, math.Min(1, strength))
+
+	for i := range d.arcs {
+		a := &d.arcs[i]
+		if angleBetween(a.Angle, angle) < d.spread()/2 {
+			a.Angle, a.Age = angle, 0
+			a.Strength = math.Max(a.Strength*d.fade(*a), strength)
+
+			return
+		}
+	}
+
+	maxArcs := d.MaxArcs
+	if maxArcs <= 0 {
+		maxArcs = DefaultDamageArcMax
+	}
+
+	if len(d.arcs) >= maxArcs {
+		// Arcs are kept oldest first
+		d.arcs = append(d.arcs[:0], d.arcs[1:]...)
+	}
+
+	d.arcs = append(d.arcs, DamageArc{Angle: angle, Strength: strength})
+}
+
+// Arcs returns the arcs still showing.
+func (d *DamageIndicator) Arcs() []DamageArc {
+	return d.arcs
+}
+
+// Clear removes every arc, e.g. when a run restarts.
+func (d *DamageIndicator) Clear() {
+	d.arcs = d.arcs[:0]
+}
+
+// Update ages the arcs and drops the ones that have faded out.
+func (d *DamageIndicator) Update(dt float64) {
+	kept := d.arcs[:0]
+
+	for _, a := range d.arcs {
+		a.Age += dt
+		if a.Age < d.duration() {
+			kept = append(kept, a)
+		}
+	}
+
+	d.arcs = kept
+}
+
+// Alpha returns how opaque an arc is drawn, 0-1.
+func (d *DamageIndicator) Alpha(a DamageArc) float64 {
+	return (damageArcMinAlpha + (1-damageArcMinAlpha)*a.Strength) * d.fade(a)
+}
+
+// Draw renders the arcs along the edge of the screen, each thickest where it
+// points at its attacker and tapering to nothing at its ends.
+func (d *DamageIndicator) Draw(screen *ebiten.Image) {
+	if len(d.arcs) == 0 {
+		return
+	}
+
+	bounds := screen.Bounds()
+	rx, ry := float64(bounds.Dx())/2, float64(bounds.Dy())/2
+	cx, cy := float64(bounds.Min.X)+rx, float64(bounds.Min.Y)+ry
+
+	depth := d.Depth
+	if depth <= 0 {
+		depth = DefaultDamageArcDepth
+	}
+
+	// edge finds where a ray from the center leaves the screen, or inset
+	// pixels inside that point.
+	edge := func(angle, inset float64) (float32, float32) {
+		cos, sin := math.Cos(angle), math.Sin(angle)
+		t := math.Min(rx/math.Max(math.Abs(cos), 1e-9), ry/math.Max(math.Abs(sin), 1e-9)) - inset
+
+		return float32(cx + cos*t), float32(cy + sin*t)
+	}
+
+	spread := d.spread()
+	step := 2 * spread / damageArcSegments
+
+	for _, a := range d.arcs {
+		alpha := d.Alpha(a)
+
+		for i := range damageArcSegments {
+			a0 := a.Angle - spread + float64(i)*step
+			a1 := a0 + step
+
+			// Taper from the center of the arc to its ends
+			mid := math.Abs(a0+step/2-a.Angle) / spread
+			taper := math.Cos(mid * math.Pi / 2)
+
+			var path vector.Path
+
+			x, y := edge(a0, 0)
+			path.MoveTo(x, y)
+			x, y = edge(a1, 0)
+			path.LineTo(x, y)
+			x, y = edge(a1, depth*taper)
+			path.LineTo(x, y)
+			x, y = edge(a0, depth*taper)
+			path.LineTo(x, y)
+			path.Close()
+
+			var cs ebiten.ColorScale
+
+			cs.ScaleWithColor(d.Color)
+			cs.ScaleAlpha(float32(alpha * taper))
+			vector.FillPath(screen, &path, nil, &vector.DrawPathOptions{ColorScale: cs})
+		}
+	}
+}
+
+func (d *DamageIndicator) duration() float64 {
+	if d.Duration <= 0 {
+		return DefaultDamageArcDuration
+	}
+
+	return d.Duration
+}
+
+func (d *DamageIndicator) spread() float64 {
+	if d.Spread <= 0 {
+		return DefaultDamageArcSpread
+	}
+
+	return d.Spread
+}
+
+// fade is what is left of an arc's brightness as it ages, 1 to 0.
+func (d *DamageIndicator) fade(a DamageArc) float64 {
+	return math.Max(0, 1-a.Age/d.duration())
+}
+
+// angleBetween returns the absolute difference between two angles, 0-Pi.
+func angleBetween(a, b float64) float64 {
+	diff := math.Mod(math.Abs(a-b), 2*math.Pi)
+
+	return math.Min(diff, 2*math.Pi-diff)
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+func TestDamageIndicatorViaBus(t *testing.T) {
+	bus := events.NewBus()
+	d := NewDamageIndicator(bus)
+
+	// An attacker straight above the player, then one underfoot
+	events.Publish(bus, DamageFrom{X: 100, Y: -50, TargetX: 100, TargetY: 200, Strength: 0.5})
+	events.Publish(bus, DamageFrom{X: 100, Y: 200, TargetX: 100, TargetY: 200, Strength: 1})
+
+	arcs := d.Arcs()
+	if len(arcs) != 1 || math.Abs(arcs[0].Angle+math.Pi/2) > 1e-9 {
+		t.Fatalf("arcs = %+v, want one pointing up", arcs)
+	}
+
+	d.Close()
+	events.Publish(bus, DamageFrom{X: 500, Y: 200, TargetX: 100, TargetY: 200})
+
+	if len(d.Arcs()) != 1 {
+		t.Error("closed indicator should ignore bus events")
+	}
+}
+
+func TestDamageIndicatorMergesNearbyHits(t *testing.T) {
+	d := NewDamageIndicator(nil)
+
+	d.Hit(math.Pi-0.05, 0.2)
+	d.Update(0.5)
+	d.Hit(-math.Pi+0.05, 0.8) // The same direction across the wrap
+	d.Hit(0, 0.1)
+
+	arcs := d.Arcs()
+	if len(arcs) != 2 {
+		t.Fatalf("arcs = %+v, want the left hits merged", arcs)
+	}
+
+	if arcs[0].Age != 0 || arcs[0].Strength != 0.8 {
+		t.Errorf("merged arc = %+v, want refreshed at the stronger hit", arcs[0])
+	}
+
+	d.MaxArcs = 2
+	d.Hit(math.Pi/2, 1)
+
+	if arcs := d.Arcs(); len(arcs) != 2 || arcs[0].Angle != 0 {
+		t.Errorf("arcs over the limit = %+v, want the oldest dropped", arcs)
+	}
+}
+
+func TestDamageIndicatorFades(t *testing.T) {
+	d := NewDamageIndicator(nil)
+	d.Duration = 2
+
+	d.Hit(0, 1)
+	full := d.Alpha(d.Arcs()[0])
+
+	d.Update(1)
+
+	if half := d.Alpha(d.Arcs()[0]); math.Abs(half-full/2) > 1e-9 {
+		t.Errorf("alpha halfway = %v, want %v", half, full/2)
+	}
+
+	d.Hit(math.Pi, 0)
+	if weak := d.Alpha(d.Arcs()[1]); weak <= 0 {
+		t.Error("even a weak hit should show")
+	}
+
+	d.Update(1.5)
+
+	if arcs := d.Arcs(); len(arcs) != 1 || arcs[0].Angle != math.Pi {
+		t.Errorf("arcs = %+v, want only the later hit left", arcs)
+	}
+
+	d.Draw(ebiten.NewImage(320, 240))
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"image"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// debugScratch holds white debug text before it is tinted onto the target.
+var debugScratch *ebiten.Image
+
+// DebugPrintAt draws s like ebitenutil.DebugPrintAt, tinted with the
+// theme's text color so it stays legible on light panels. Text drawn
+// straight over the game world, with no panel behind it, should keep using
+// ebitenutil.
+func DebugPrintAt(dst *ebiten.Image, s string, x, y int) {
+	if s == "" {
+		return
+	}
+
+	w, h := debugTextSize(s)
+
+	if debugScratch == nil || debugScratch.Bounds().Dx() < w || debugScratch.Bounds().Dy() < h {
+		bw, bh := w, h
+		if debugScratch != nil {
+			bw, bh = max(bw, debugScratch.Bounds().Dx()), max(bh, debugScratch.Bounds().Dy())
+		}
+
+		debugScratch = ebiten.NewImage(bw, bh)
+	}
+
+	area := debugScratch.SubImage(image.Rect(0, 0, w, h)).(*ebiten.Image)
+	area.Clear()
+	ebitenutil.DebugPrintAt(area, s, 0, 0)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(CurrentTheme().Palette.Text)
+	dst.DrawImage(area, op)
+}
+
+// debugTextSize returns the pixels s covers in the debug font.
+func debugTextSize(s string) (w, h int) {
+	lines := strings.Split(s, "\n")
+	for _, line := range lines {
+		w = max(w, len([]rune(line)))
+	}
+
+	// One extra column: DebugPrintAt draws a pixel right of x
+	return w*textCharWidth + 1, len(lines) * lineHeight
+}
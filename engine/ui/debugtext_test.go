@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDebugPrintAtUsesThemeText(t *testing.T) {
+	original := CurrentTheme().Name
+	defer func() { _ = SetTheme(original) }()
+
+	if err := SetTheme("Light"); err != nil {
+		t.Fatal(err)
+	}
+
+	img := ebiten.NewImage(40, 20)
+	DebugPrintAt(img, "##", 0, 0)
+
+	want := CurrentTheme().Palette.Text
+	found := false
+
+	for y := range 20 {
+		for x := range 40 {
+			r, g, b, a := img.At(x, y).RGBA()
+			if a == 0 {
+				continue
+			}
+
+			if r>>8 > uint32(want.R)+1 || g>>8 > uint32(want.G)+1 || b>>8 > uint32(want.B)+1 {
+				t.Fatalf("pixel (%d, %d) = %v, brighter than the theme text %v", x, y, img.At(x, y), want)
+			}
+
+			found = true
+		}
+	}
+
+	if !found {
+		t.Error("nothing drawn")
+	}
+}
+
+func TestDebugTextSize(t *testing.T) {
+	if w, h := debugTextSize("ab\nabcd"); w != 4*textCharWidth+1 || h != 2*lineHeight {
+		t.Errorf("size = %dx%d", w, h)
+	}
+}
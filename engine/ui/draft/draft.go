@@ -0,0 +1,179 @@
+// Package draft is a pick-one screen for choices made as a run starts, such
+// as a survivor's starting mutation or a roguelike's starting boon: a title
+// over a row of cards, each with a name and a description.
+//
+// Deal draws the cards from a seeded stream, so replaying a run's seed
+// offers the same choices.
+package draft
+
+import (
+	"image/color"
+	"math/rand"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// Card layout, in pixels.
+const (
+	cardMaxW   = 200
+	cardH      = 160
+	cardGap    = 16
+	cardMargin = 20
+	cardPad    = 10
+)
+
+// Option is one card of a draft.
+type Option struct {
+	Name  string
+	Desc  string
+	Color color.Color // Name color; nil uses the theme's highlight
+}
+
+// Deal returns n distinct entries of pool in random order, drawn from r; all
+// of pool when it has n or fewer.
+func Deal[T any](r *rand.Rand, pool []T, n int) []T {
+	n = min(n, len(pool))
+	dealt := make([]T, 0, n)
+
+	for _, i := range r.Perm(len(pool))[:n] {
+		dealt = append(dealt, pool[i])
+	}
+
+	return dealt
+}
+
+// Screen shows the options as cards, centered in its area over a dimmed
+// backdrop. Left and Right move the focus and Confirm picks the focused
+// card; the number keys and clicking pick a card directly.
+type Screen struct {
+	X, Y, W, H float64 // Area the screen is centered in, usually the whole screen
+	Title      string
+	Options    []Option
+
+	// Skin draws the cards; nil uses the current theme's skin.
+	Skin *ui.Skin
+
+	focus int
+}
+
+// New creates a draft screen filling a w by h screen.
+func New(title string, options []Option, w, h float64) *Screen {
+	return &Screen{W: w, H: h, Title: title, Options: options}
+}
+
+// Focus returns the index of the focused card.
+func (s *Screen) Focus() int {
+	return s.focus
+}
+
+// Update handles this tick's input from m, the number keys, and the mouse,
+// and returns the index of the picked option, or -1 while undecided.
+func (s *Screen) Update(m *input.Map) int {
+	if len(s.Options) == 0 {
+		return -1
+	}
+
+	s.move(m.JustMoved(input.MoveX))
+
+	for i := range min(len(s.Options), 9) {
+		if inpututil.IsKeyJustPressed(ebiten.KeyDigit1 + ebiten.Key(i)) {
+			return i
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if i := s.CardAt(ebiten.CursorPosition()); i >= 0 {
+			return i
+		}
+	}
+
+	if m.JustPressed(input.Confirm) {
+		return s.focus
+	}
+
+	return -1
+}
+
+// move shifts the focus by step cards, wrapping around.
+func (s *Screen) move(step int) {
+	n := len(s.Options)
+	if n == 0 {
+		return
+	}
+
+	s.focus = ((s.focus+step)%n + n) % n
+}
+
+// CardRect returns where card i is drawn.
+func (s *Screen) CardRect(i int) (x, y, w, h float64) {
+	n := float64(max(len(s.Options), 1))
+	w = min(cardMaxW, (s.W-2*cardMargin-(n-1)*cardGap)/n)
+	row := n*w + (n-1)*cardGap
+
+	return s.X + (s.W-row)/2 + float64(i)*(w+cardGap), s.Y + (s.H-cardH)/2, w, cardH
+}
+
+// CardAt returns the card under a screen point, or -1.
+func (s *Screen) CardAt(px, py int) int {
+	for i := range s.Options {
+		x, y, w, h := s.CardRect(i)
+		if float64(px) >= x && float64(px) < x+w && float64(py) >= y && float64(py) < y+h {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Draw draws the backdrop, title, cards, and controls hint.
+func (s *Screen) Draw(screen *ebiten.Image) {
+	theme := ui.CurrentTheme()
+	pal := theme.Palette
+
+	skin := s.Skin
+	if skin == nil {
+		skin = theme.Skin()
+	}
+
+	vector.FillRect(screen, float32(s.X), float32(s.Y), float32(s.W), float32(s.H), pal.Overlay, false)
+
+	_, cardY, _, _ := s.CardRect(0)
+	title := text.Options{Font: text.Bold(), Size: theme.Fonts.Heading, Align: text.AlignCenter, Shadow: true}
+	text.Draw(screen, s.Title, s.X+s.W/2, cardY-2*theme.Fonts.Heading-cardPad, title)
+
+	for i, opt := range s.Options {
+		x, y, w, h := s.CardRect(i)
+
+		state := ui.ButtonStateNormal
+		if i == s.focus {
+			state = ui.ButtonStateHover
+		}
+
+		skin.ButtonSlice(state).Draw(screen, x, y, w, h)
+
+		key := text.Options{Size: theme.Fonts.Small, Color: pal.TextMuted}
+		text.Draw(screen, "["+strconv.Itoa(i+1)+"]", x+cardPad, y+cardPad, key)
+
+		name := text.Options{Font: text.Bold(), Color: opt.Color, Align: text.AlignCenter, Width: w - 2*cardPad}
+		if name.Color == nil {
+			name.Color = pal.Highlight
+		}
+
+		nameY := y + 2*cardPad + theme.Fonts.Small
+		nameH := text.Draw(screen, opt.Name, x+w/2, nameY, name)
+
+		desc := text.Options{Size: theme.Fonts.Small, Align: text.AlignCenter, Width: w - 2*cardPad}
+		text.Draw(screen, opt.Desc, x+w/2, nameY+nameH+cardPad, desc)
+	}
+
+	keys := "1-" + strconv.Itoa(min(len(s.Options), 9))
+	hint := text.Options{Size: theme.Fonts.Small, Color: pal.TextMuted, Align: text.AlignCenter, Shadow: true}
+	hintY := cardY + cardH + 2*cardPad
+	text.Draw(screen, "LEFT/RIGHT choose | ENTER or "+keys+" pick | PAD: A pick", s.X+s.W/2, hintY, hint)
+}
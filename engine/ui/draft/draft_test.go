@@ -0,0 +1,63 @@
+package draft
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDealIsSeededAndDistinct(t *testing.T) {
+	pool := []string{"a", "b", "c", "d", "e", "f"}
+
+	first := Deal(rand.New(rand.NewSource(7)), pool, 3)
+	again := Deal(rand.New(rand.NewSource(7)), pool, 3)
+
+	if len(first) != 3 || !slices.Equal(first, again) {
+		t.Errorf("Deal() = %q then %q, want the same three for the same seed", first, again)
+	}
+
+	if c := slices.Compact(slices.Sorted(slices.Values(first))); len(c) != 3 {
+		t.Errorf("Deal() = %q, want distinct entries", first)
+	}
+
+	if all := Deal(rand.New(rand.NewSource(7)), pool[:2], 3); len(all) != 2 {
+		t.Errorf("Deal() from a short pool = %q, want all of it", all)
+	}
+}
+
+func TestFocusWraps(t *testing.T) {
+	s := New("Pick", []Option{{Name: "A"}, {Name: "B"}, {Name: "C"}}, 640, 480)
+
+	s.move(-1)
+
+	if s.Focus() != 2 {
+		t.Errorf("Left from the first card focused %d, want the last", s.Focus())
+	}
+
+	s.move(1)
+
+	if s.Focus() != 0 {
+		t.Errorf("Right from the last card focused %d, want the first", s.Focus())
+	}
+}
+
+func TestCardAt(t *testing.T) {
+	s := New("Pick", []Option{{Name: "A"}, {Name: "B"}, {Name: "C"}}, 640, 480)
+
+	for i := range s.Options {
+		x, y, w, h := s.CardRect(i)
+		if got := s.CardAt(int(x+w/2), int(y+h/2)); got != i {
+			t.Errorf("CardAt(center of %d) = %d", i, got)
+		}
+	}
+
+	if x, y, _, _ := s.CardRect(0); s.CardAt(int(x)-1, int(y)) != -1 {
+		t.Error("a point left of the cards should hit nothing")
+	}
+
+	// Cards shrink to fit a narrow screen
+	narrow := New("Pick", s.Options, 300, 480)
+	if x, _, w, _ := narrow.CardRect(2); x+w > 300 {
+		t.Errorf("last card ends at %.0f, past the 300px screen", x+w)
+	}
+}
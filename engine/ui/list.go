@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// DefaultListRowH is the row height of a List without one set.
+const DefaultListRowH = 20
+
+// List is a column of selectable rows, scrolled to keep the selection in
+// view. Up and Down move the selection, wrapping around, and Confirm picks
+// it; moving the mouse over a row selects it and clicking picks it.
+type List struct {
+	X, Y, W  float64
+	RowH     float64 // 0 uses DefaultListRowH
+	Rows     int     // Rows shown at once; 0 shows every item
+	Items    []string
+	Selected int
+
+	top   int // First row shown
+	hover hover
+}
+
+// NewList creates a list showing every item.
+func NewList(x, y, w float64, items []string) *List {
+	return &List{X: x, Y: y, W: w, Items: items}
+}
+
+func (l *List) rowH() float64 {
+	if l.RowH > 0 {
+		return l.RowH
+	}
+
+	return DefaultListRowH
+}
+
+// visible returns how many rows are shown.
+func (l *List) visible() int {
+	if l.Rows > 0 {
+		return min(l.Rows, len(l.Items))
+	}
+
+	return len(l.Items)
+}
+
+// Height returns the height of the shown rows.
+func (l *List) Height() float64 {
+	return float64(l.visible()) * l.rowH()
+}
+
+// Update handles this tick's input from m and the mouse, and returns the
+// index of the picked item, or -1.
+func (l *List) Update(m *input.Map) int {
+	return l.update(readPointer(), m.JustMoved(input.MoveY), m.JustPressed(input.Confirm))
+}
+
+func (l *List) update(p Pointer, step int, confirm bool) int {
+	n := len(l.Items)
+	if n == 0 {
+		return -1
+	}
+
+	if step != 0 {
+		l.Select(((l.Selected+step)%n + n) % n)
+	}
+
+	if l.hover.moved(p) {
+		if i := l.rowAt(p.X, p.Y); i >= 0 {
+			l.Selected = i
+
+			if p.Clicked {
+				return i
+			}
+		}
+	}
+
+	if confirm {
+		return l.Selected
+	}
+
+	return -1
+}
+
+// Select selects item i and scrolls it into view.
+func (l *List) Select(i int) {
+	l.Selected = min(max(i, 0), len(l.Items)-1)
+
+	switch rows := l.visible(); {
+	case l.Selected < l.top:
+		l.top = l.Selected
+	case l.Selected >= l.top+rows:
+		l.top = l.Selected - rows + 1
+	}
+}
+
+// rowAt returns the item of the shown row under a screen point, or -1.
+func (l *List) rowAt(x, y int) int {
+	if !(Rect{X: l.X, Y: l.Y, W: l.W, H: l.Height()}).Contains(x, y) {
+		return -1
+	}
+
+	return l.top + int((float64(y)-l.Y)/l.rowH())
+}
+
+// Draw draws the shown rows with the selection highlighted, and arrows
+// where more rows are scrolled out of view.
+func (l *List) Draw(screen *ebiten.Image) {
+	pal := CurrentTheme().Palette
+	rowH := l.rowH()
+
+	for row := range l.visible() {
+		i := l.top + row
+		y := l.Y + float64(row)*rowH
+
+		if i == l.Selected {
+			vector.FillRect(screen, float32(l.X), float32(y), float32(l.W), float32(rowH-2), pal.ButtonHover, false)
+		}
+
+		DebugPrintAt(screen, l.Items[i], int(l.X)+6, int(y+(rowH-lineHeight)/2))
+	}
+
+	right := int(l.X+l.W) - 2*textCharWidth
+
+	if l.top > 0 {
+		DebugPrintAt(screen, "^", right, int(l.Y))
+	}
+
+	if l.top+l.visible() < len(l.Items) {
+		DebugPrintAt(screen, "v", right, int(l.Y+l.Height()-rowH))
+	}
+}
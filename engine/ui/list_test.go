@@ -0,0 +1,50 @@
+package ui
+
+import "testing"
+
+func TestListKeyboardScrollsSelection(t *testing.T) {
+	l := NewList(0, 0, 100, []string{"a", "b", "c", "d", "e"})
+	l.Rows = 3
+	rest := Pointer{X: 500, Y: 500}
+
+	for range 4 {
+		l.update(rest, 1, false)
+	}
+
+	if l.Selected != 4 || l.top != 2 {
+		t.Errorf("selected %d top %d, want the last row scrolled into view", l.Selected, l.top)
+	}
+
+	l.update(rest, 1, false)
+
+	if l.Selected != 0 || l.top != 0 {
+		t.Errorf("selected %d top %d, want a wrap to the top", l.Selected, l.top)
+	}
+
+	if got := l.update(rest, 0, true); got != 0 {
+		t.Errorf("Confirm picked %d, want 0", got)
+	}
+
+	if l.Height() != 3*DefaultListRowH {
+		t.Errorf("Height() = %.0f, want three rows", l.Height())
+	}
+}
+
+func TestListMouse(t *testing.T) {
+	l := NewList(10, 10, 100, []string{"Resume", "Save", "Quit"})
+
+	l.update(Pointer{X: 20, Y: 15}, 0, false)
+	l.update(Pointer{X: 20, Y: 35}, 0, false)
+
+	if l.Selected != 1 {
+		t.Errorf("moving over row 1 selected %d", l.Selected)
+	}
+
+	if got := l.update(Pointer{X: 20, Y: 55, Clicked: true}, 0, false); got != 2 {
+		t.Errorf("clicking row 2 picked %d", got)
+	}
+
+	if got := l.update(Pointer{X: 200, Y: 55, Clicked: true}, 0, false); got != -1 {
+		t.Errorf("clicking beside the list picked %d", got)
+	}
+}
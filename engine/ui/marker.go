@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DefaultMarkerMargin is the inset from the screen edge that off-screen
+// markers are clamped to when MarkerLayer.Margin is unset.
+const DefaultMarkerMargin = 24
+
+// MarkerKind is the purpose of a marker, which picks its theme style.
+type MarkerKind int
+
+const (
+	MarkerObjective MarkerKind = iota // Goals to reach: level exits, stairs
+	MarkerWaypoint                    // Neutral points of interest
+	MarkerTarget                      // What the player's units are attacking
+	MarkerThreat                      // Bosses and other dangers
+)
+
+// MarkerShape is the icon a marker is drawn with.
+type MarkerShape int
+
+const (
+	ShapeDiamond MarkerShape = iota
+	ShapeRing
+	ShapeCrosshair
+	ShapeTriangle
+)
+
+// MarkerStyle is how one kind of marker is drawn.
+type MarkerStyle struct {
+	Color color.RGBA
+	Shape MarkerShape
+	Size  float64 // Icon radius in pixels
+}
+
+// Marker is a world-space point of interest.
+type Marker struct {
+	Kind  MarkerKind
+	X, Y  float64 // World position
+	Label string  // Drawn under the icon; may be empty
+}
+
+// MarkerStyle returns the theme's style for kind: an entry in Markers if the
+// theme overrides it, otherwise one derived from the palette.
+func (t *Theme) MarkerStyle(kind MarkerKind) MarkerStyle {
+	if s, ok := t.Markers[kind]; ok {
+		return s
+	}
+
+	switch kind {
+	case MarkerWaypoint:
+		return MarkerStyle{Color: t.Palette.Accent, Shape: ShapeRing, Size: 7}
+	case MarkerTarget:
+		return MarkerStyle{Color: t.Palette.Warning, Shape: ShapeCrosshair, Size: 9}
+	case MarkerThreat:
+		return MarkerStyle{Color: t.Palette.Danger, Shape: ShapeTriangle, Size: 9}
+	}
+
+	return MarkerStyle{Color: t.Palette.Highlight, Shape: ShapeDiamond, Size: 8}
+}
+
+// MarkerPlacement is where a marker lands on screen.
+type MarkerPlacement struct {
+	X, Y     float64 // Screen position, pulled inside the margin when off screen
+	OnScreen bool
+	Angle    float64 // Direction from the screen center, for the edge arrow
+	Distance float64 // World distance from the viewer
+}
+
+// MarkerLayer draws markers over a world view, pinning off-screen ones to
+// the screen edge with an arrow pointing at them.
+type MarkerLayer struct {
+	Width, Height float64 // Screen size
+	Margin        float64 // Edge inset for off-screen markers; 0 uses DefaultMarkerMargin
+
+	// OffScreenOnly skips markers that are on screen, for games that already
+	// highlight what the player can see.
+	OffScreenOnly bool
+
+	// ToScreen converts world to screen coordinates; nil means they are the same.
+	ToScreen func(x, y float64) (sx, sy float64)
+
+	// DistanceScale is world units per displayed distance unit, e.g. 32 for
+	// 32-pixel tiles; 0 hides the distance text.
+	DistanceScale float64
+	DistanceUnit  string // Appended to the distance, e.g. "m"
+
+	Theme *Theme // Nil uses the current theme
+}
+
+// Place projects m onto the screen as seen by a viewer at (fromX, fromY).
+func (l *MarkerLayer) Place(m Marker, fromX, fromY float64) MarkerPlacement {
+	sx, sy := m.X, m.Y
+	if l.ToScreen != nil {
+		sx, sy = l.ToScreen(m.X, m.Y)
+	}
+
+	margin := l.Margin
+	if margin <= 0 {
+		margin = DefaultMarkerMargin
+	}
+
+	cx, cy := l.Width/2, l.Height/2
+	dx, dy := sx-cx, sy-cy
+
+	p := MarkerPlacement{
+		X:        sx,
+		Y:        sy,
+		Angle:    math.Atan2(dy, dx),
+		Distance: math.Hypot(m.X-fromX, m.Y-fromY),
+	}
+
+	halfW, halfH := max(cx-margin, 0), max(cy-margin, 0)
+	if math.Abs(dx) <= halfW && math.Abs(dy) <= halfH {
+		p.OnScreen = true
+
+		return p
+	}
+
+	// Walk from the center toward the marker until the inset edge
+	t := math.Inf(1)
+	if dx != 0 {
+		t = halfW / math.Abs(dx)
+	}
+
+	if dy != 0 {
+		t = min(t, halfH/math.Abs(dy))
+	}
+
+	p.X, p.Y = cx+dx*t, cy+dy*t
+
+	return p
+}
+
+// Draw draws markers as seen by a viewer at (fromX, fromY).
+func (l *MarkerLayer) Draw(screen *ebiten.Image, markers []Marker, fromX, fromY float64) {
+	theme := l.Theme
+	if theme == nil {
+		theme = CurrentTheme()
+	}
+
+	for _, m := range markers {
+		p := l.Place(m, fromX, fromY)
+		if p.OnScreen && l.OffScreenOnly {
+			continue
+		}
+
+		style := theme.MarkerStyle(m.Kind)
+		x, y := float32(p.X), float32(p.Y)
+		size := float32(style.Size)
+
+		if !p.OnScreen {
+			drawMarkerArrow(screen, x, y, size, p.Angle, style.Color)
+		}
+
+		drawMarkerShape(screen, x, y, size, style)
+
+		text := m.Label
+		if l.DistanceScale > 0 {
+			dist := strconv.Itoa(int(math.Round(p.Distance/l.DistanceScale))) + l.DistanceUnit
+			if text != "" {
+				text += " "
+			}
+
+			text += dist
+		}
+
+		if text != "" {
+			// Keep the text on screen for markers clamped to the bottom or sides
+			tx := min(max(int(p.X)-len(text)*3, 0), int(l.Width)-len(text)*6)
+			ty := int(p.Y + style.Size + 2)
+
+			if ty > int(l.Height)-16 {
+				ty = int(p.Y-style.Size) - 16
+			}
+
+			ebitenutil.DebugPrintAt(screen, text, tx, ty)
+		}
+	}
+}
+
+// drawMarkerArrow draws a triangle just outside the icon, pointing at angle.
+func drawMarkerArrow(screen *ebiten.Image, x, y, size float32, angle float64, c color.RGBA) {
+	cos, sin := float32(math.Cos(angle)), float32(math.Sin(angle))
+	tip := size + 10
+	base := size + 3
+
+	var path vector.Path
+
+	path.MoveTo(x+cos*tip, y+sin*tip)
+	path.LineTo(x+cos*base-sin*5, y+sin*base+cos*5)
+	path.LineTo(x+cos*base+sin*5, y+sin*base-cos*5)
+	path.Close()
+
+	vector.FillPath(screen, &path, nil, &vector.DrawPathOptions{ColorScale: colorScale(c)})
+}
+
+// drawMarkerShape draws the marker icon centered on (x, y).
+func drawMarkerShape(screen *ebiten.Image, x, y, size float32, s MarkerStyle) {
+	switch s.Shape {
+	case ShapeRing:
+		vector.StrokeCircle(screen, x, y, size, 2, s.Color, false)
+		vector.FillCircle(screen, x, y, 2, s.Color, false)
+	case ShapeCrosshair:
+		vector.StrokeCircle(screen, x, y, size*0.7, 2, s.Color, false)
+		vector.StrokeLine(screen, x-size, y, x+size, y, 2, s.Color, false)
+		vector.StrokeLine(screen, x, y-size, x, y+size, 2, s.Color, false)
+	default:
+		var path vector.Path
+
+		if s.Shape == ShapeTriangle {
+			path.MoveTo(x, y-size)
+			path.LineTo(x+size, y+size*0.8)
+			path.LineTo(x-size, y+size*0.8)
+		} else {
+			path.MoveTo(x, y-size)
+			path.LineTo(x+size, y)
+			path.LineTo(x, y+size)
+			path.LineTo(x-size, y)
+		}
+
+		path.Close()
+		vector.FillPath(screen, &path, nil, &vector.DrawPathOptions{ColorScale: colorScale(s.Color)})
+	}
+}
+
+func colorScale(c color.RGBA) ebiten.ColorScale {
+	var cs ebiten.ColorScale
+
+	cs.ScaleWithColor(c)
+
+	return cs
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestMarkerPlaceOnScreen(t *testing.T) {
+	l := &MarkerLayer{Width: 800, Height: 600}
+
+	p := l.Place(Marker{X: 500, Y: 200}, 400, 300)
+	if !p.OnScreen || p.X != 500 || p.Y != 200 {
+		t.Errorf("placement %+v, want on screen at the marker", p)
+	}
+
+	if math.Abs(p.Distance-math.Hypot(100, 100)) > 1e-9 {
+		t.Errorf("distance = %v, want %v", p.Distance, math.Hypot(100, 100))
+	}
+}
+
+func TestMarkerPlaceClampsToEdge(t *testing.T) {
+	// A camera scrolled so the world origin is the screen's top left
+	camX, camY := 1000.0, 0.0
+	l := &MarkerLayer{
+		Width:  800,
+		Height: 600,
+		Margin: 20,
+		ToScreen: func(x, y float64) (float64, float64) {
+			return x - camX, y - camY
+		},
+	}
+
+	tests := []struct {
+		name         string
+		x, y         float64
+		wantX, wantY float64
+	}{
+		{"right", 3000, 300, 780, 300},
+		{"left", 0, 300, 20, 300},
+		{"below", 1400, 5000, 400, 580},
+		{"corner", 1400 + 3800, 300 + 2800, 780, 580},
+		{"margin", 1000 + 790, 300, 780, 300},
+	}
+
+	for _, tt := range tests {
+		p := l.Place(Marker{X: tt.x, Y: tt.y}, 0, 0)
+		if p.OnScreen {
+			t.Errorf("%s: marker reported on screen", tt.name)
+		}
+
+		if math.Abs(p.X-tt.wantX) > 1e-9 || math.Abs(p.Y-tt.wantY) > 1e-9 {
+			t.Errorf("%s: placed at (%v, %v), want (%v, %v)", tt.name, p.X, p.Y, tt.wantX, tt.wantY)
+		}
+	}
+
+	// The edge position keeps the direction to the marker
+	p := l.Place(Marker{X: 1400 + 800, Y: 300 + 100}, 0, 0)
+	if want := math.Atan2(100, 800); math.Abs(p.Angle-want) > 1e-9 || math.Abs(p.Y-(300+380.0/8)) > 1e-9 {
+		t.Errorf("angle %v at y %v, want %v along the ray", p.Angle, p.Y, want)
+	}
+}
+
+func TestThemeMarkerStyle(t *testing.T) {
+	base := DarkTheme()
+
+	if got := base.MarkerStyle(MarkerThreat); got.Color != base.Palette.Danger {
+		t.Errorf("threat marker color = %v, want the danger color", got.Color)
+	}
+
+	if base.MarkerStyle(MarkerObjective).Shape == base.MarkerStyle(MarkerTarget).Shape {
+		t.Error("objective and target markers should be told apart by shape")
+	}
+
+	custom := base.Clone("Markers")
+	custom.Markers = map[MarkerKind]MarkerStyle{
+		MarkerThreat: {Color: base.Palette.Text, Shape: ShapeRing, Size: 4},
+	}
+
+	if got := custom.MarkerStyle(MarkerThreat); got.Shape != ShapeRing || got.Size != 4 {
+		t.Errorf("overridden threat style = %+v", got)
+	}
+
+	custom.Clone("Copy").Markers[MarkerThreat] = MarkerStyle{}
+
+	if custom.MarkerStyle(MarkerThreat).Size != 4 {
+		t.Error("clone should not share the marker overrides with its base")
+	}
+}
+
+func TestMarkerLayerDraw(t *testing.T) {
+	screen := ebiten.NewImage(200, 150)
+	l := &MarkerLayer{Width: 200, Height: 150, DistanceScale: 10, DistanceUnit: "m"}
+
+	// Every shape, on and off screen, including a label pushed off the bottom
+	l.Draw(screen, []Marker{
+		{Kind: MarkerObjective, X: 50, Y: 50, Label: "Exit"},
+		{Kind: MarkerWaypoint, X: -500, Y: 75},
+		{Kind: MarkerTarget, X: 100, Y: 900},
+		{Kind: MarkerThreat, X: 900, Y: -900, Label: "Boss"},
+	}, 100, 75)
+}
@@ -0,0 +1,148 @@
+// Package ui provides UI building blocks such as nine-slice panels and skins.
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NineSlice draws an image stretched to any size while keeping its corners
+// intact: corners are drawn unscaled, edges stretch along one axis, and the
+// center stretches along both.
+type NineSlice struct {
+	Image *ebiten.Image
+	// Insets in source pixels marking the fixed border on each side.
+	Left, Top, Right, Bottom int
+}
+
+// NewNineSlice creates a nine-slice from an image and its border insets.
+func NewNineSlice(img *ebiten.Image, left, top, right, bottom int) *NineSlice {
+	return &NineSlice{Image: img, Left: left, Top: top, Right: right, Bottom: bottom}
+}
+
+// Draw renders the nine-slice into the rectangle (x, y, w, h).
+func (n *NineSlice) Draw(dst *ebiten.Image, x, y, w, h float64) {
+	n.DrawTinted(dst, x, y, w, h, nil)
+}
+
+// DrawTinted renders the nine-slice multiplied by tint (nil for no tint),
+// e.g. to highlight a hovered button with the same art.
+func (n *NineSlice) DrawTinted(dst *ebiten.Image, x, y, w, h float64, tint color.Color) {
+	if n == nil || n.Image == nil || w <= 0 || h <= 0 {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+
+	for _, p := range n.patches(x, y, w, h) {
+		sub := n.Image.SubImage(p.src).(*ebiten.Image)
+
+		op.GeoM.Reset()
+		op.GeoM.Scale(p.w/float64(p.src.Dx()), p.h/float64(p.src.Dy()))
+		op.GeoM.Translate(p.x, p.y)
+		op.ColorScale.Reset()
+
+		if tint != nil {
+			op.ColorScale.ScaleWithColor(tint)
+		}
+
+		dst.DrawImage(sub, op)
+	}
+}
+
+// patch maps a source region of the nine-slice image to a destination rectangle.
+type patch struct {
+	src        image.Rectangle
+	x, y, w, h float64
+}
+
+// patches computes the (up to nine) source/destination pairs for a target rectangle.
+func (n *NineSlice) patches(x, y, w, h float64) []patch {
+	b := n.Image.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+
+	// Shrink the borders proportionally if the target is smaller than them
+	left, right := float64(n.Left), float64(n.Right)
+	if left+right > w {
+		s := w / (left + right)
+		left, right = left*s, right*s
+	}
+
+	top, bottom := float64(n.Top), float64(n.Bottom)
+	if top+bottom > h {
+		s := h / (top + bottom)
+		top, bottom = top*s, bottom*s
+	}
+
+	srcX := [4]int{0, n.Left, sw - n.Right, sw}
+	srcY := [4]int{0, n.Top, sh - n.Bottom, sh}
+	dstX := [4]float64{x, x + left, x + w - right, x + w}
+	dstY := [4]float64{y, y + top, y + h - bottom, y + h}
+
+	result := make([]patch, 0, 9)
+
+	for row := range 3 {
+		for col := range 3 {
+			sx0, sx1 := srcX[col], srcX[col+1]
+			sy0, sy1 := srcY[row], srcY[row+1]
+			dw, dh := dstX[col+1]-dstX[col], dstY[row+1]-dstY[row]
+
+			if sx1 <= sx0 || sy1 <= sy0 || dw <= 0 || dh <= 0 {
+				continue
+			}
+
+			result = append(result, patch{
+				src: image.Rect(b.Min.X+sx0, b.Min.Y+sy0, b.Min.X+sx1, b.Min.Y+sy1),
+				x:   dstX[col], y: dstY[row], w: dw, h: dh,
+			})
+		}
+	}
+
+	return result
+}
+
+// GenerateNineSlice builds a programmatic nine-slice with a solid fill, a border
+// of borderWidth pixels, and corners rounded by radius pixels. It is used as the
+// default skin when no art is provided.
+func GenerateNineSlice(fill, border color.Color, borderWidth, radius int) *NineSlice {
+	img, inset := nineSlicePixels(fill, border, borderWidth, radius)
+
+	return NewNineSlice(ebiten.NewImageFromImage(img), inset, inset, inset, inset)
+}
+
+// nineSlicePixels rasterises a rounded, bordered box and returns it with its inset.
+func nineSlicePixels(fill, border color.Color, borderWidth, radius int) (*image.RGBA, int) {
+	inset := max(borderWidth, radius, 1)
+	size := inset*2 + 1
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	r := float64(radius)
+
+	for y := range size {
+		for x := range size {
+			// Distance from the nearest corner's arc center, if inside a corner square
+			cx, cy := float64(x)+0.5, float64(y)+0.5
+			ax := min(max(cx, r), float64(size)-r)
+			ay := min(max(cy, r), float64(size)-r)
+			dx, dy := cx-ax, cy-ay
+			dist := dx*dx + dy*dy
+
+			if radius > 0 && dist > r*r {
+				continue // Outside the rounded corner
+			}
+
+			c := fill
+
+			edge := min(x, y, size-1-x, size-1-y)
+			if edge < borderWidth || (radius > 0 && dist > (r-float64(borderWidth))*(r-float64(borderWidth))) {
+				c = border
+			}
+
+			img.Set(x, y, c)
+		}
+	}
+
+	return img, inset
+}
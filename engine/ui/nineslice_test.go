@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNineSlicePatches(t *testing.T) {
+	n := NewNineSlice(ebiten.NewImage(12, 12), 4, 4, 4, 4)
+
+	patches := n.patches(10, 20, 100, 50)
+	if len(patches) != 9 {
+		t.Fatalf("patches = %d, want 9", len(patches))
+	}
+
+	// Corners keep their source size
+	tl, br := patches[0], patches[8]
+	if tl.x != 10 || tl.y != 20 || tl.w != 4 || tl.h != 4 {
+		t.Errorf("top-left = %+v", tl)
+	}
+
+	if br.x != 106 || br.y != 66 || br.w != 4 || br.h != 4 {
+		t.Errorf("bottom-right = %+v", br)
+	}
+
+	// Center stretches to fill the rest
+	if c := patches[4]; c.w != 92 || c.h != 42 || c.src.Dx() != 4 {
+		t.Errorf("center = %+v", c)
+	}
+}
+
+func TestNineSliceSmallTarget(t *testing.T) {
+	n := NewNineSlice(ebiten.NewImage(12, 12), 4, 4, 4, 4)
+
+	// Narrower than both borders: borders shrink and the center disappears
+	total := 0.0
+	for _, p := range n.patches(0, 0, 6, 20) {
+		if p.y == 0 {
+			total += p.w
+		}
+	}
+
+	if total != 6 {
+		t.Errorf("top row width = %v, want 6", total)
+	}
+}
+
+func TestGeneratedSkinPixels(t *testing.T) {
+	fill := color.RGBA{R: 10, G: 20, B: 30, A: 255}
+	border := color.RGBA{R: 200, G: 200, B: 200, A: 255}
+
+	img, inset := nineSlicePixels(fill, border, 2, 6)
+	if inset != 6 || img.Bounds().Dx() != 13 {
+		t.Fatalf("inset = %d, size = %d", inset, img.Bounds().Dx())
+	}
+
+	if _, _, _, a := img.At(0, 0).RGBA(); a != 0 {
+		t.Error("rounded corner pixel should be transparent")
+	}
+
+	if img.At(6, 0) != color.Color(border) {
+		t.Errorf("edge pixel = %v, want border", img.At(6, 0))
+	}
+
+	if img.At(6, 6) != color.Color(fill) {
+		t.Errorf("center pixel = %v, want fill", img.At(6, 6))
+	}
+
+	if DefaultSkin().ButtonSlice(ButtonStatePressed) == nil {
+		t.Error("default skin should provide every button state")
+	}
+}
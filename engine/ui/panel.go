@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// panelTitleH is the height of a panel's title strip.
+const panelTitleH = 28
+
+// Panel is a box drawn with the skin's panel art, with an optional title
+// centered along its top, for dialogs, score boxes, and menus.
+type Panel struct {
+	Rect
+
+	Title string
+	Skin  *Skin // Nil uses the current theme's skin
+}
+
+// NewPanel creates a panel.
+func NewPanel(x, y, w, h float64, title string) *Panel {
+	return &Panel{Rect: Rect{X: x, Y: y, W: w, H: h}, Title: title}
+}
+
+// CenteredPanel creates a w by h panel centered on a screenW by screenH
+// screen.
+func CenteredPanel(screenW, screenH, w, h float64, title string) *Panel {
+	return NewPanel((screenW-w)/2, (screenH-h)/2, w, h, title)
+}
+
+// Content returns the area below the title, inset by the theme's padding.
+func (p *Panel) Content() Rect {
+	pad := CurrentTheme().Spacing.Padding
+	top := pad
+
+	if p.Title != "" {
+		top = panelTitleH
+	}
+
+	return Rect{X: p.X + pad, Y: p.Y + top, W: p.W - 2*pad, H: p.H - top - pad}
+}
+
+// Draw draws the panel and its title.
+func (p *Panel) Draw(screen *ebiten.Image) {
+	skinOr(p.Skin).Panel.Draw(screen, p.X, p.Y, p.W, p.H)
+
+	if p.Title != "" {
+		drawCentered(screen, p.Title, Rect{X: p.X, Y: p.Y + 4, W: p.W, H: panelTitleH - 4})
+	}
+}
+
+// DrawPaused dims screen and draws a centered "PAUSED" panel with hint
+// under the title, for games whose pause screen only waits to resume.
+func DrawPaused(screen *ebiten.Image, hint string) {
+	b := screen.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+
+	vector.FillRect(screen, 0, 0, float32(w), float32(h), color.RGBA{A: 150}, false)
+
+	p := CenteredPanel(w, h, max(220, float64(len(hint)*textCharWidth+40)), 76, "PAUSED")
+	p.Draw(screen)
+	drawCentered(screen, hint, p.Content())
+}
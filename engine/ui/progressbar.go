@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// ProgressBar shows Value out of Max as a filled bar, with an optional
+// label centered on it, for loading, health, and timers outside the HUD.
+type ProgressBar struct {
+	Rect
+
+	Value, Max float64
+	Label      string
+	Fill       color.Color // Nil uses the theme's accent
+	Back       color.Color // Nil uses the theme's panel color
+}
+
+// NewProgressBar creates an empty bar.
+func NewProgressBar(x, y, w, h float64) *ProgressBar {
+	return &ProgressBar{Rect: Rect{X: x, Y: y, W: w, H: h}}
+}
+
+// Set sets the value and maximum.
+func (b *ProgressBar) Set(value, maximum float64) {
+	b.Value, b.Max = value, maximum
+}
+
+// Fraction returns how full the bar is, from 0 to 1; 0 without a maximum.
+func (b *ProgressBar) Fraction() float64 {
+	if b.Max <= 0 {
+		return 0
+	}
+
+	return min(max(b.Value/b.Max, 0), 1)
+}
+
+// Draw draws the bar and its label.
+func (b *ProgressBar) Draw(screen *ebiten.Image) {
+	pal := CurrentTheme().Palette
+
+	fill, back := b.Fill, b.Back
+	if fill == nil {
+		fill = pal.Accent
+	}
+
+	if back == nil {
+		back = pal.Panel
+	}
+
+	x, y, w, h := float32(b.X), float32(b.Y), float32(b.W), float32(b.H)
+	vector.FillRect(screen, x, y, w, h, back, false)
+	vector.FillRect(screen, x, y, w*float32(b.Fraction()), h, fill, false)
+	vector.StrokeRect(screen, x, y, w, h, 1, pal.PanelBorder, false)
+
+	if b.Label != "" {
+		drawCentered(screen, b.Label, b.Rect)
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// Rebinder layout, in pixels.
+const (
+	rebindRowH   = 20
+	rebindLabelW = 120
+)
+
+// Rebinder is a key-rebinding screen: the listed controls with what each is
+// bound to. Up and Down pick a control; Enter listens for the next key or
+// gamepad button, which replaces the control's keys or buttons; Backspace
+// restores its default; Escape closes. The gamepad uses the d-pad, A, Y,
+// and B. These keys and buttons are fixed, so a bad binding can always be
+// undone, and Escape or B cancels listening rather than binding itself.
+//
+// Inputs bound to more than one listed control are shown as conflicts.
+type Rebinder struct {
+	X, Y     float64 // Top-left of the list
+	Map      *input.Map
+	Controls []input.Control
+
+	// OnChange runs after every change, e.g. to save the bindings.
+	OnChange func()
+
+	focus     int
+	listening bool
+}
+
+// NewRebinder creates a rebinding screen for controls of m.
+func NewRebinder(m *input.Map, controls []input.Control) *Rebinder {
+	return &Rebinder{X: 40, Y: 60, Map: m, Controls: controls}
+}
+
+// Listening reports whether the focused control is waiting for an input.
+func (r *Rebinder) Listening() bool {
+	return r.listening
+}
+
+// Update handles this tick's input and reports whether the player closed
+// the screen.
+func (r *Rebinder) Update() bool {
+	b, ok := r.Map.Captured()
+	if !ok {
+		return false
+	}
+
+	return r.press(b)
+}
+
+// press handles one captured key or button.
+func (r *Rebinder) press(b input.Binding) bool {
+	if len(r.Controls) == 0 {
+		return true
+	}
+
+	c := r.Controls[r.focus]
+
+	if r.listening {
+		r.listening = false
+
+		if !isInput(b, ebiten.KeyEscape, ebiten.StandardGamepadButtonRightRight) {
+			r.bind(c, b)
+		}
+
+		return false
+	}
+
+	switch {
+	case isInput(b, ebiten.KeyArrowUp, ebiten.StandardGamepadButtonLeftTop):
+		r.focus = (r.focus + len(r.Controls) - 1) % len(r.Controls)
+	case isInput(b, ebiten.KeyArrowDown, ebiten.StandardGamepadButtonLeftBottom):
+		r.focus = (r.focus + 1) % len(r.Controls)
+	case isInput(b, ebiten.KeyEnter, ebiten.StandardGamepadButtonRightBottom):
+		r.listening = true
+	case isInput(b, ebiten.KeyBackspace, ebiten.StandardGamepadButtonRightTop):
+		r.Map.BindControl(c, r.Map.DefaultBinding(c))
+		r.changed()
+	case isInput(b, ebiten.KeyEscape, ebiten.StandardGamepadButtonRightRight):
+		return true
+	}
+
+	return false
+}
+
+// bind replaces c's keys with the captured key, or its gamepad buttons with
+// the captured button, keeping the other kind.
+func (r *Rebinder) bind(c input.Control, captured input.Binding) {
+	b := r.Map.ControlBinding(c)
+	if len(captured.Keys) > 0 {
+		b.Keys = captured.Keys
+	} else {
+		b.Buttons = captured.Buttons
+	}
+
+	r.Map.BindControl(c, b)
+	r.changed()
+}
+
+func (r *Rebinder) changed() {
+	if r.OnChange != nil {
+		r.OnChange()
+	}
+}
+
+// isInput reports whether captured is key or btn.
+func isInput(captured input.Binding, key ebiten.Key, btn ebiten.StandardGamepadButton) bool {
+	return slices.Contains(captured.Keys, key) || slices.Contains(captured.Buttons, btn)
+}
+
+// Conflicts returns the names of listed controls sharing a key or gamepad
+// button with another listed control.
+func (r *Rebinder) Conflicts() map[string]bool {
+	keys := make(map[ebiten.Key][]string)
+	buttons := make(map[ebiten.StandardGamepadButton][]string)
+
+	for _, c := range r.Controls {
+		b := r.Map.ControlBinding(c)
+		for _, k := range b.Keys {
+			keys[k] = append(keys[k], c.Name)
+		}
+
+		for _, btn := range b.Buttons {
+			buttons[btn] = append(buttons[btn], c.Name)
+		}
+	}
+
+	conflicts := make(map[string]bool)
+	mark := func(names []string) {
+		if len(names) > 1 {
+			for _, name := range names {
+				conflicts[name] = true
+			}
+		}
+	}
+
+	for _, names := range keys {
+		mark(names)
+	}
+
+	for _, names := range buttons {
+		mark(names)
+	}
+
+	return conflicts
+}
+
+// Draw draws the control list, with the focused row highlighted and
+// conflicting bindings marked in the theme's warning color.
+func (r *Rebinder) Draw(screen *ebiten.Image) {
+	pal := CurrentTheme().Palette
+	conflicts := r.Conflicts()
+	x, y := int(r.X), int(r.Y)
+
+	for i, c := range r.Controls {
+		rowY := y + i*rebindRowH
+
+		if i == r.focus {
+			vector.DrawFilledRect(screen, float32(x-6), float32(rowY-3), 380, rebindRowH-2, pal.ButtonHover, false)
+		}
+
+		value := r.Map.ControlBinding(c).String()
+		if i == r.focus && r.listening {
+			value = "press a key or button..."
+		}
+
+		if conflicts[c.Name] {
+			vector.DrawFilledRect(screen, float32(x-6), float32(rowY-3), 3, rebindRowH-2, pal.Warning, false)
+			value += "  (conflict)"
+		}
+
+		DebugPrintAt(screen, c.Name, x, rowY)
+		DebugPrintAt(screen, value, x+rebindLabelW, rowY)
+	}
+
+	hint := "UP/DOWN choose | ENTER rebind | BACKSPACE default | ESC done"
+	if r.listening {
+		hint = "Press the new key or pad button | ESC cancel"
+	}
+
+	bottom := y + len(r.Controls)*rebindRowH
+	DebugPrintAt(screen, hint, x, bottom+10)
+	DebugPrintAt(screen, "PAD: D-PAD choose | A rebind | Y default | B done", x, bottom+26)
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+func key(k ebiten.Key) input.Binding {
+	return input.Binding{Keys: []ebiten.Key{k}}
+}
+
+func button(b ebiten.StandardGamepadButton) input.Binding {
+	return input.Binding{Buttons: []ebiten.StandardGamepadButton{b}}
+}
+
+func TestRebinderRebindsFocusedControl(t *testing.T) {
+	m := input.NewMap()
+	r := NewRebinder(m, []input.Control{input.MoveUp, input.MoveDown})
+
+	changes := 0
+	r.OnChange = func() { changes++ }
+
+	// AZERTY: move up with Z instead of W
+	r.press(key(ebiten.KeyEnter))
+
+	if !r.Listening() {
+		t.Fatal("Enter should start listening")
+	}
+
+	r.press(key(ebiten.KeyZ))
+
+	if got := m.ControlBinding(input.MoveUp).String(); got != "Z, D-Up" {
+		t.Errorf("Move Up = %q, want Z and the d-pad kept", got)
+	}
+
+	// A pad button replaces only the buttons
+	r.press(button(ebiten.StandardGamepadButtonRightBottom))
+	r.press(button(ebiten.StandardGamepadButtonRightTop))
+
+	if got := m.ControlBinding(input.MoveUp).String(); got != "Z, Pad Y" {
+		t.Errorf("Move Up = %q, want Z and Pad Y", got)
+	}
+
+	// Escape cancels listening without binding
+	r.press(key(ebiten.KeyEnter))
+	r.press(key(ebiten.KeyEscape))
+
+	if r.Listening() || m.ControlBinding(input.MoveUp).String() != "Z, Pad Y" {
+		t.Errorf("Escape should cancel, got %q", m.ControlBinding(input.MoveUp))
+	}
+
+	// So does the pad's B
+	r.press(key(ebiten.KeyEnter))
+	r.press(button(ebiten.StandardGamepadButtonRightRight))
+
+	if r.Listening() || m.ControlBinding(input.MoveUp).String() != "Z, Pad Y" {
+		t.Errorf("B should cancel, got %q", m.ControlBinding(input.MoveUp))
+	}
+
+	// Backspace restores the default
+	r.press(key(ebiten.KeyBackspace))
+
+	if got := m.ControlBinding(input.MoveUp).String(); got != "W, Up, D-Up" {
+		t.Errorf("after reset Move Up = %q", got)
+	}
+
+	if changes != 3 {
+		t.Errorf("OnChange ran %d times, want 3", changes)
+	}
+
+	if !r.press(key(ebiten.KeyEscape)) {
+		t.Error("Escape should close the screen")
+	}
+}
+
+func TestRebinderConflicts(t *testing.T) {
+	m := input.NewMap()
+	r := NewRebinder(m, []input.Control{input.MoveUp, input.MoveDown, input.MoveLeft})
+
+	if c := r.Conflicts(); len(c) != 0 {
+		t.Errorf("defaults conflict: %v", c)
+	}
+
+	r.press(key(ebiten.KeyArrowDown))
+	r.press(key(ebiten.KeyEnter))
+	r.press(key(ebiten.KeyW))
+
+	c := r.Conflicts()
+	if !c[input.MoveUp.Name] || !c[input.MoveDown.Name] || c[input.MoveLeft.Name] {
+		t.Errorf("Conflicts() = %v, want up and down", c)
+	}
+}
@@ -0,0 +1,56 @@
+package ui
+
+// Skin is a set of nine-slice graphics for the standard UI elements.
+// Themes can swap skins to restyle every panel, button, and tooltip at once.
+type Skin struct {
+	Panel         *NineSlice
+	Button        *NineSlice
+	ButtonHover   *NineSlice
+	ButtonPressed *NineSlice
+	Tooltip       *NineSlice
+}
+
+// NewSkin generates a programmatic skin from a theme's palette and border style.
+func NewSkin(t *Theme) *Skin {
+	p, border := t.Palette, t.Border
+
+	return &Skin{
+		Panel:         GenerateNineSlice(p.Panel, p.PanelBorder, border.Width, border.Radius),
+		Button:        GenerateNineSlice(p.Button, p.TextMuted, max(border.Width-1, 1), border.Radius*2/3),
+		ButtonHover:   GenerateNineSlice(p.ButtonHover, p.Text, max(border.Width-1, 1), border.Radius*2/3),
+		ButtonPressed: GenerateNineSlice(p.Panel, p.TextMuted, max(border.Width-1, 1), border.Radius*2/3),
+		Tooltip:       GenerateNineSlice(p.Background, p.Highlight, max(border.Width-1, 1), border.Radius/2),
+	}
+}
+
+// DefaultSkin returns the current theme's skin, used when no art is provided.
+func DefaultSkin() *Skin {
+	return CurrentTheme().Skin()
+}
+
+// ButtonState selects which button graphic to draw.
+type ButtonState int
+
+const (
+	ButtonStateNormal ButtonState = iota
+	ButtonStateHover
+	ButtonStatePressed
+)
+
+// ButtonSlice returns the nine-slice for a button state, falling back to the
+// normal button graphic when the skin has no art for that state.
+func (s *Skin) ButtonSlice(state ButtonState) *NineSlice {
+	switch state {
+	case ButtonStateHover:
+		if s.ButtonHover != nil {
+			return s.ButtonHover
+		}
+	case ButtonStatePressed:
+		if s.ButtonPressed != nil {
+			return s.ButtonPressed
+		}
+	case ButtonStateNormal:
+	}
+
+	return s.Button
+}
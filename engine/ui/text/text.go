@@ -0,0 +1,220 @@
+// Package text draws UI text with real fonts through ebiten's text/v2, in
+// place of ebitenutil.DebugPrintAt's fixed-size white bitmap font: any
+// TrueType or OpenType font, sizes from the theme, colors, alignment, drop
+// shadows, and word wrapping.
+//
+// Coordinates are the top-left of the first line, as with DebugPrintAt, so
+// a call can be swapped for the other without moving anything.
+package text
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	etext "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// DefaultLineHeight is the line height as a multiple of the size, when
+// Options.LineHeight is unset.
+const DefaultLineHeight = 1.25
+
+// shadowColor is drawn one pixel down and right of shadowed text.
+var shadowColor = color.RGBA{A: 200}
+
+// Align places text horizontally relative to the x it is drawn at.
+type Align int
+
+const (
+	AlignLeft   Align = iota // x is the left edge
+	AlignCenter              // x is the center
+	AlignRight               // x is the right edge
+)
+
+// Font is a loaded font, drawn at any size.
+type Font struct {
+	source *etext.GoTextFaceSource
+	faces  map[float64]*etext.GoTextFace
+}
+
+// LoadFont parses a TrueType or OpenType font.
+func LoadFont(data []byte) (*Font, error) {
+	source, err := etext.NewGoTextFaceSource(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Font{source: source, faces: make(map[float64]*etext.GoTextFace)}, nil
+}
+
+// mustLoad parses a font embedded in the binary.
+func mustLoad(data []byte) *Font {
+	f, err := LoadFont(data)
+	if err != nil {
+		panic("text: embedded font: " + err.Error())
+	}
+
+	return f
+}
+
+// The Go fonts, parsed on first use.
+var regular, bold *Font
+
+// Regular returns Go Regular, the default font.
+func Regular() *Font {
+	if regular == nil {
+		regular = mustLoad(goregular.TTF)
+	}
+
+	return regular
+}
+
+// Bold returns Go Bold, for headings and emphasis.
+func Bold() *Font {
+	if bold == nil {
+		bold = mustLoad(gobold.TTF)
+	}
+
+	return bold
+}
+
+// Face returns the font at size pixels, cached so repeated draws reuse the
+// glyph cache.
+func (f *Font) Face(size float64) *etext.GoTextFace {
+	face, ok := f.faces[size]
+	if !ok {
+		face = &etext.GoTextFace{Source: f.source, Size: size}
+		f.faces[size] = face
+	}
+
+	return face
+}
+
+// Options style a piece of text. The zero value is the theme's body size
+// and text color in the Regular font, left-aligned and unwrapped.
+type Options struct {
+	Font       *Font       // Nil uses Regular
+	Size       float64     // Pixels; 0 uses the theme's body size
+	Color      color.Color // Nil uses the theme's text color
+	Align      Align
+	Width      float64 // Wraps lines at word boundaries to fit; 0 does not wrap
+	LineHeight float64 // Multiple of Size between line tops; 0 uses DefaultLineHeight
+	Shadow     bool    // A dark drop shadow, for text over busy backgrounds
+}
+
+// face returns the font face o draws with.
+func (o Options) face() *etext.GoTextFace {
+	f := o.Font
+	if f == nil {
+		f = Regular()
+	}
+
+	size := o.Size
+	if size <= 0 {
+		size = ui.CurrentTheme().Fonts.Body
+	}
+
+	return f.Face(size)
+}
+
+// lineSpacing returns the pixels between line tops.
+func (o Options) lineSpacing(face *etext.GoTextFace) float64 {
+	h := o.LineHeight
+	if h <= 0 {
+		h = DefaultLineHeight
+	}
+
+	return face.Size * h
+}
+
+// Draw draws s with its first line's top at y, aligned on x, and returns
+// the height of the lines drawn, so callers can stack text.
+func Draw(dst *ebiten.Image, s string, x, y float64, o Options) float64 {
+	face := o.face()
+	lines := Wrap(s, o)
+
+	op := &etext.DrawOptions{}
+	op.LineSpacing = o.lineSpacing(face)
+	op.PrimaryAlign = etext.Align(o.Align) // Start, Center, and End match ours
+
+	joined := strings.Join(lines, "\n")
+
+	if o.Shadow {
+		op.GeoM.Translate(x+1, y+1)
+		op.ColorScale.ScaleWithColor(shadowColor)
+		etext.Draw(dst, joined, face, op)
+		op.GeoM.Reset()
+		op.ColorScale.Reset()
+	}
+
+	c := o.Color
+	if c == nil {
+		c = ui.CurrentTheme().Palette.Text
+	}
+
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(c)
+	etext.Draw(dst, joined, face, op)
+
+	return float64(len(lines)) * op.LineSpacing
+}
+
+// Measure returns the size s takes when drawn with o: the widest line, and
+// the line spacing times the number of lines.
+func Measure(s string, o Options) (width, height float64) {
+	face := o.face()
+	lines := Wrap(s, o)
+
+	for _, line := range lines {
+		width = max(width, etext.Advance(line, face))
+	}
+
+	return width, float64(len(lines)) * o.lineSpacing(face)
+}
+
+// Wrap splits s into the lines Draw draws: at each newline, and between
+// words wherever a line would grow past o.Width. A word wider than the
+// width gets a line to itself. Without a width only newlines split.
+func Wrap(s string, o Options) []string {
+	paragraphs := strings.Split(s, "\n")
+	if o.Width <= 0 {
+		return paragraphs
+	}
+
+	face := o.face()
+	space := etext.Advance(" ", face)
+
+	var lines []string
+
+	for _, p := range paragraphs {
+		words := strings.Fields(p)
+		if len(words) == 0 {
+			lines = append(lines, "")
+
+			continue
+		}
+
+		line, w := words[0], etext.Advance(words[0], face)
+
+		for _, word := range words[1:] {
+			ww := etext.Advance(word, face)
+			if w+space+ww > o.Width {
+				lines = append(lines, line)
+				line, w = word, ww
+
+				continue
+			}
+
+			line += " " + word
+			w += space + ww
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,72 @@
+package text
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWrapBreaksBetweenWords(t *testing.T) {
+	o := Options{Size: 13}
+	wordW, _ := Measure("word", o)
+	spaceW, _ := Measure("word word", o)
+
+	// Room for two words but not three
+	o.Width = spaceW + wordW/2
+
+	got := Wrap("word word word word word", o)
+	want := []string{"word word", "word word", "word"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+
+	for _, line := range got {
+		if w, _ := Measure(line, Options{Size: 13}); w > o.Width {
+			t.Errorf("line %q is %.1f wide, over %.1f", line, w, o.Width)
+		}
+	}
+}
+
+func TestWrapKeepsNewlinesAndLongWords(t *testing.T) {
+	o := Options{Size: 13, Width: 30}
+
+	got := Wrap("a\n\nextraordinarily b", o)
+	want := []string{"a", "", "extraordinarily", "b"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+
+	if got := Wrap("one two\nthree", Options{}); !slices.Equal(got, []string{"one two", "three"}) {
+		t.Errorf("unwrapped Wrap() = %q, want only newline splits", got)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	o := Options{Size: 20}
+
+	short, h := Measure("ab", o)
+	long, _ := Measure("abab", o)
+
+	if short <= 0 || long <= short {
+		t.Errorf("widths ab=%.1f abab=%.1f, want growing with length", short, long)
+	}
+
+	if h != 20*DefaultLineHeight {
+		t.Errorf("one line height = %.1f, want %.1f", h, 20*DefaultLineHeight)
+	}
+
+	if _, h := Measure("a\nb\nc", Options{Size: 10, LineHeight: 2}); h != 60 {
+		t.Errorf("three line height = %.1f, want 60", h)
+	}
+
+	bold, _ := Measure("abab", Options{Size: 20, Font: Bold()})
+	if bold <= long {
+		t.Errorf("bold width %.1f should exceed regular %.1f", bold, long)
+	}
+
+	// The zero size is the theme's body size
+	if a, _ := Measure("abab", Options{}); a == long {
+		t.Error("default size should differ from 20px")
+	}
+}
@@ -0,0 +1,387 @@
+package ui
+
+import (
+	"image"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Character sets for TextInput.Charset.
+const (
+	CharsetInitials     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	CharsetAlphanumeric = CharsetInitials + "0123456789"
+)
+
+// Grid keys after the characters of an on-screen character grid.
+const (
+	GridBackspace = "DEL"
+	GridDone      = "OK"
+)
+
+// Text input layout, in pixels; the debug font is 6x16.
+const (
+	textInputHeight = 24
+	textCharWidth   = 6
+	gridCellSize    = 24
+	gridCellGap     = 2
+)
+
+// TextInput is a one-line text field for initials, seed codes, and profile
+// names that needs no OS dialog. Players type on a keyboard or pick from an
+// on-screen character grid with a gamepad or mouse.
+//
+// Typing goes through ebiten's exp/textinput, so on desktops and browsers
+// with an input method only committed text reaches the field, and Enter or
+// Backspace pressed to finish an IME composition never submits or edits.
+type TextInput struct {
+	X, Y, Width float64
+	Label       string
+	MaxLen      int    // Characters; 0 is unlimited
+	Charset     string // Characters accepted and offered on the grid; "" accepts any printable
+	Upper       bool   // Fold typed letters to upper case
+	GridColumns int    // Cells per grid row
+	ShowGrid    bool   // Draw the grid even without a gamepad
+
+	// Validate checks the text on submit; an error keeps the field open and
+	// is shown under it.
+	Validate func(text string) error
+	// OnSubmit receives the validated text.
+	OnSubmit func(text string)
+	// OnCancel runs when the player backs out with Escape or the gamepad's B.
+	OnCancel func()
+
+	field textinput.Field
+	err   string
+	grid  int // Selected grid cell
+	ticks int // For the cursor blink
+}
+
+// NewTextInput creates a focused text input of up to maxLen characters from
+// charset; an empty charset accepts any printable character.
+func NewTextInput(label, charset string, maxLen int) *TextInput {
+	t := &TextInput{
+		Width:       float64(max(maxLen, 16)*textCharWidth + 16),
+		Label:       label,
+		MaxLen:      maxLen,
+		Charset:     charset,
+		GridColumns: 10,
+	}
+	t.Focus()
+
+	return t
+}
+
+// Focus starts taking keyboard text, taking it from any other text input.
+func (t *TextInput) Focus() {
+	t.field.Focus()
+}
+
+// Blur stops taking keyboard text.
+func (t *TextInput) Blur() {
+	t.field.Blur()
+}
+
+// Text returns the committed text.
+func (t *TextInput) Text() string {
+	return t.field.Text()
+}
+
+// SetText replaces the text, dropping characters the input does not accept.
+func (t *TextInput) SetText(s string) {
+	t.setText(t.clean(s))
+}
+
+// Err returns the last validation error, or "" if there is none.
+func (t *TextInput) Err() string {
+	return t.err
+}
+
+func (t *TextInput) setText(s string) {
+	t.field.SetTextAndSelection(s, len(s), len(s))
+	t.err = ""
+}
+
+// clean folds case, drops characters outside the charset and control
+// characters, and truncates to MaxLen.
+func (t *TextInput) clean(s string) string {
+	var b strings.Builder
+
+	n := 0
+
+	for _, r := range s {
+		if t.Upper {
+			r = unicode.ToUpper(r)
+		}
+
+		if !t.accepts(r) {
+			continue
+		}
+
+		if t.MaxLen > 0 && n >= t.MaxLen {
+			break
+		}
+
+		b.WriteRune(r)
+		n++
+	}
+
+	return b.String()
+}
+
+func (t *TextInput) accepts(r rune) bool {
+	if t.Charset == "" {
+		return unicode.IsPrint(r)
+	}
+
+	return strings.ContainsRune(t.Charset, r)
+}
+
+// Type adds characters at the end, as if typed.
+func (t *TextInput) Type(s string) {
+	t.SetText(t.Text() + s)
+}
+
+// Backspace removes the last character.
+func (t *TextInput) Backspace() {
+	text := t.Text()
+	if text == "" {
+		return
+	}
+
+	_, size := utf8.DecodeLastRuneInString(text)
+	t.setText(text[:len(text)-size])
+}
+
+// Submit validates the text and hands it to OnSubmit. It returns false,
+// keeping the error for Err and Draw, if validation fails.
+func (t *TextInput) Submit() bool {
+	text := t.Text()
+
+	if t.Validate != nil {
+		if err := t.Validate(text); err != nil {
+			t.err = err.Error()
+
+			return false
+		}
+	}
+
+	t.err = ""
+
+	if t.OnSubmit != nil {
+		t.OnSubmit(text)
+	}
+
+	return true
+}
+
+// Cancel runs OnCancel.
+func (t *TextInput) Cancel() {
+	if t.OnCancel != nil {
+		t.OnCancel()
+	}
+}
+
+// GridKeys returns the on-screen grid's cells: each character of the
+// charset, then GridBackspace and GridDone.
+func (t *TextInput) GridKeys() []string {
+	charset := t.Charset
+	if charset == "" {
+		charset = CharsetAlphanumeric
+	}
+
+	keys := make([]string, 0, utf8.RuneCountInString(charset)+2)
+	for _, r := range charset {
+		keys = append(keys, string(r))
+	}
+
+	return append(keys, GridBackspace, GridDone)
+}
+
+// GridSelected returns the grid cell the gamepad is on.
+func (t *TextInput) GridSelected() string {
+	return t.GridKeys()[t.grid]
+}
+
+// MoveGrid moves the grid selection by columns and rows, wrapping around.
+func (t *TextInput) MoveGrid(dx, dy int) {
+	n := len(t.GridKeys())
+	t.grid = ((t.grid+dx+dy*t.GridColumns)%n + n) % n
+}
+
+// PressGrid types the selected grid cell, or erases or submits for the
+// special cells.
+func (t *TextInput) PressGrid() {
+	t.pressKey(t.GridSelected())
+}
+
+func (t *TextInput) pressKey(key string) {
+	switch key {
+	case GridBackspace:
+		t.Backspace()
+	case GridDone:
+		t.Submit()
+	default:
+		t.Type(key)
+	}
+}
+
+// gridCell returns the top-left corner of grid cell i.
+func (t *TextInput) gridCell(i int) (x, y float64) {
+	col, row := i%t.GridColumns, i/t.GridColumns
+
+	return t.X + float64(col*(gridCellSize+gridCellGap)),
+		t.Y + textInputHeight + 28 + float64(row*(gridCellSize+gridCellGap))
+}
+
+// HandleClick presses the grid cell under the point. It returns true if one
+// was hit, so callers can swallow the click.
+func (t *TextInput) HandleClick(x, y float64) bool {
+	if !t.gridVisible() {
+		return false
+	}
+
+	for i, key := range t.GridKeys() {
+		cx, cy := t.gridCell(i)
+		if x >= cx && x < cx+gridCellSize && y >= cy && y < cy+gridCellSize {
+			t.grid = i
+			t.pressKey(key)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// gridVisible reports whether the grid is drawn and clickable: when asked
+// for or while a gamepad is connected.
+func (t *TextInput) gridVisible() bool {
+	return t.ShowGrid || len(ebiten.AppendGamepadIDs(nil)) > 0
+}
+
+// Update reads the keyboard, gamepads, and mouse.
+func (t *TextInput) Update() error {
+	t.ticks++
+
+	before := t.Text()
+
+	bounds := image.Rect(int(t.X)+8, int(t.Y)+4, int(t.X+t.Width)-8, int(t.Y)+20)
+
+	handled, err := t.field.HandleInputWithBounds(bounds)
+	if err != nil {
+		return err
+	}
+
+	if text := t.Text(); text != before {
+		t.SetText(text)
+	}
+
+	// An IME composition has the keys this tick
+	if handled {
+		return nil
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		t.Submit()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		t.Cancel()
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace) || repeating(ebiten.KeyBackspace):
+		t.Backspace()
+	}
+
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		t.updateGamepad(id)
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		t.HandleClick(float64(mx), float64(my))
+	}
+
+	return nil
+}
+
+// repeating reports a key held long enough to auto-repeat.
+func repeating(key ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(key)
+
+	return d > 30 && d%4 == 0
+}
+
+func (t *TextInput) updateGamepad(id ebiten.GamepadID) {
+	pressed := func(b ebiten.StandardGamepadButton) bool {
+		return inpututil.IsStandardGamepadButtonJustPressed(id, b)
+	}
+
+	switch {
+	case pressed(ebiten.StandardGamepadButtonLeftLeft):
+		t.MoveGrid(-1, 0)
+	case pressed(ebiten.StandardGamepadButtonLeftRight):
+		t.MoveGrid(1, 0)
+	case pressed(ebiten.StandardGamepadButtonLeftTop):
+		t.MoveGrid(0, -1)
+	case pressed(ebiten.StandardGamepadButtonLeftBottom):
+		t.MoveGrid(0, 1)
+	case pressed(ebiten.StandardGamepadButtonRightBottom):
+		t.PressGrid()
+	case pressed(ebiten.StandardGamepadButtonRightLeft):
+		t.Backspace()
+	case pressed(ebiten.StandardGamepadButtonCenterRight):
+		t.Submit()
+	case pressed(ebiten.StandardGamepadButtonRightRight):
+		t.Cancel()
+	}
+}
+
+// Draw renders the label, the field with any IME composition and a blinking
+// cursor, the validation error, and the character grid when it is visible.
+func (t *TextInput) Draw(screen *ebiten.Image) {
+	theme := CurrentTheme()
+	p := theme.Palette
+
+	DebugPrintAt(screen, t.Label, int(t.X), int(t.Y)-18)
+	theme.Skin().Panel.Draw(screen, t.X, t.Y, t.Width, textInputHeight)
+
+	text := t.field.TextForRendering()
+	DebugPrintAt(screen, text, int(t.X)+8, int(t.Y)+4)
+
+	if t.ticks/30%2 == 0 {
+		cx := float32(t.X) + 8 + float32(utf8.RuneCountInString(text)*textCharWidth)
+		vector.StrokeLine(screen, cx, float32(t.Y)+5, cx, float32(t.Y)+19, 1, p.Highlight, false)
+	}
+
+	if t.err != "" {
+		vector.FillRect(screen, float32(t.X), float32(t.Y)+textInputHeight+3, 4, 14, p.Danger, false)
+		DebugPrintAt(screen, t.err, int(t.X)+8, int(t.Y)+textInputHeight+2)
+	}
+
+	if !t.gridVisible() {
+		return
+	}
+
+	keys := t.GridKeys()
+	rows := (len(keys) + t.GridColumns - 1) / t.GridColumns
+	gx, gy := t.gridCell(0)
+	theme.Skin().Panel.Draw(screen, gx-4, gy-4,
+		float64(t.GridColumns*(gridCellSize+gridCellGap)-gridCellGap+8),
+		float64(rows*(gridCellSize+gridCellGap)-gridCellGap+8))
+
+	for i, key := range keys {
+		x, y := t.gridCell(i)
+
+		fill := p.Button
+		if i == t.grid {
+			fill = p.Highlight
+		}
+
+		vector.FillRect(screen, float32(x), float32(y), gridCellSize, gridCellSize, fill, false)
+		DebugPrintAt(screen, key, int(x)+(gridCellSize-len(key)*textCharWidth)/2, int(y)+4)
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTextInputFiltersAndTruncates(t *testing.T) {
+	in := NewTextInput("Initials", CharsetInitials, 3)
+	in.Upper = true
+
+	in.Type("a1b-")
+	in.Type("cd")
+
+	if in.Text() != "ABC" {
+		t.Errorf("text = %q, want ABC", in.Text())
+	}
+
+	in.Backspace()
+	in.Backspace()
+	in.Backspace()
+	in.Backspace()
+
+	if in.Text() != "" {
+		t.Errorf("text after backspaces = %q", in.Text())
+	}
+
+	name := NewTextInput("Name", "", 0)
+	name.SetText("Zoë\n\tok")
+
+	if name.Text() != "Zoëok" {
+		t.Errorf("any printable = %q", name.Text())
+	}
+}
+
+func TestTextInputValidateAndSubmit(t *testing.T) {
+	var submitted string
+
+	in := NewTextInput("Seed", CharsetAlphanumeric, 6)
+	in.Validate = func(s string) error {
+		if len(s) < 6 {
+			return errors.New("too short")
+		}
+
+		return nil
+	}
+	in.OnSubmit = func(s string) { submitted = s }
+
+	in.Type("ABC")
+
+	if in.Submit() || in.Err() != "too short" || submitted != "" {
+		t.Fatalf("short submit: err = %q, submitted = %q", in.Err(), submitted)
+	}
+
+	// Editing clears the error
+	in.Type("123")
+
+	if in.Err() != "" {
+		t.Errorf("err after typing = %q", in.Err())
+	}
+
+	if !in.Submit() || submitted != "ABC123" {
+		t.Errorf("submitted = %q", submitted)
+	}
+
+	cancelled := false
+	in.OnCancel = func() { cancelled = true }
+	in.Cancel()
+
+	if !cancelled {
+		t.Error("OnCancel not called")
+	}
+}
+
+func TestTextInputGrid(t *testing.T) {
+	var submitted string
+
+	in := NewTextInput("Initials", CharsetInitials, 3)
+	in.OnSubmit = func(s string) { submitted = s }
+
+	keys := in.GridKeys()
+	if len(keys) != 28 || keys[26] != GridBackspace || keys[27] != GridDone {
+		t.Fatalf("grid keys = %v", keys)
+	}
+
+	// Down a row from A is K; left from A wraps to OK
+	in.MoveGrid(0, 1)
+	in.PressGrid()
+	in.MoveGrid(0, -1)
+	in.MoveGrid(-1, 0)
+
+	if in.Text() != "K" || in.GridSelected() != GridDone {
+		t.Fatalf("text = %q, selected = %q", in.Text(), in.GridSelected())
+	}
+
+	in.MoveGrid(-1, 0)
+	in.PressGrid()
+
+	if in.Text() != "" {
+		t.Errorf("DEL left %q", in.Text())
+	}
+
+	// Mouse clicks press cells when the grid shows
+	in.ShowGrid = true
+	x, y := in.gridCell(2)
+
+	if !in.HandleClick(x+1, y+1) || in.Text() != "C" {
+		t.Errorf("click: text = %q", in.Text())
+	}
+
+	x, y = in.gridCell(27)
+	in.HandleClick(x+1, y+1)
+
+	if submitted != "C" {
+		t.Errorf("submitted = %q", submitted)
+	}
+
+	if in.HandleClick(in.X-10, in.Y) {
+		t.Error("click outside the grid was handled")
+	}
+}
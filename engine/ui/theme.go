@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"maps"
+)
+
+// Palette holds the colors UI widgets draw with.
+type Palette struct {
+	Background  color.RGBA // Screen clear color behind menus
+	Overlay     color.RGBA // Dim layer drawn behind modal dialogs
+	Panel       color.RGBA
+	PanelBorder color.RGBA
+	Button      color.RGBA
+	ButtonHover color.RGBA
+	Text        color.RGBA
+	TextMuted   color.RGBA
+	Highlight   color.RGBA // Selected or focused items
+	Accent      color.RGBA
+	Success     color.RGBA
+	Warning     color.RGBA
+	Danger      color.RGBA
+	// Rarity colors, lowest tier first (e.g. common, magic, rare, legendary).
+	Rarity []color.RGBA
+}
+
+// RarityColor returns the color for a rarity tier, clamped to the palette.
+func (p Palette) RarityColor(tier int) color.RGBA {
+	if len(p.Rarity) == 0 {
+		return p.Text
+	}
+
+	return p.Rarity[min(max(tier, 0), len(p.Rarity)-1)]
+}
+
+// FontSizes holds text sizes in points.
+type FontSizes struct {
+	Small   float64
+	Body    float64
+	Heading float64
+	Title   float64
+}
+
+// Spacing holds layout distances in pixels.
+type Spacing struct {
+	Padding float64 // Inside panels and buttons
+	Gap     float64 // Between stacked widgets
+}
+
+// BorderStyle describes panel and button borders.
+type BorderStyle struct {
+	Width  int
+	Radius int
+}
+
+// Theme bundles everything widgets need to style themselves.
+type Theme struct {
+	Name    string
+	Palette Palette
+	Fonts   FontSizes
+	Spacing Spacing
+	Border  BorderStyle
+
+	// Markers overrides the palette-derived MarkerStyle per kind.
+	Markers map[MarkerKind]MarkerStyle
+
+	skin *Skin
+}
+
+// Skin returns the nine-slice skin generated from the theme, building it on first use.
+func (t *Theme) Skin() *Skin {
+	if t.skin == nil {
+		t.skin = NewSkin(t)
+	}
+
+	return t.skin
+}
+
+// Clone returns a copy of the theme under a new name, for per-game overrides.
+func (t *Theme) Clone(name string) *Theme {
+	c := *t
+	c.Name = name
+	c.Palette.Rarity = append([]color.RGBA(nil), t.Palette.Rarity...)
+	c.Markers = maps.Clone(t.Markers)
+	c.skin = nil
+
+	return &c
+}
+
+var defaultRarity = []color.RGBA{
+	{R: 200, G: 200, B: 200, A: 255},
+	{R: 100, G: 150, B: 255, A: 255},
+	{R: 255, G: 255, B: 100, A: 255},
+	{R: 255, G: 150, B: 50, A: 255},
+}
+
+var defaultFonts = FontSizes{Small: 10, Body: 13, Heading: 18, Title: 28}
+
+// DarkTheme returns the built-in dark theme.
+func DarkTheme() *Theme {
+	return &Theme{
+		Name: "Dark",
+		Palette: Palette{
+			Background:  color.RGBA{R: 20, G: 25, B: 35, A: 255},
+			Overlay:     color.RGBA{R: 0, G: 0, B: 0, A: 180},
+			Panel:       color.RGBA{R: 30, G: 35, B: 50, A: 240},
+			PanelBorder: color.RGBA{R: 120, G: 130, B: 160, A: 255},
+			Button:      color.RGBA{R: 60, G: 65, B: 85, A: 255},
+			ButtonHover: color.RGBA{R: 80, G: 90, B: 120, A: 255},
+			Text:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			TextMuted:   color.RGBA{R: 150, G: 150, B: 150, A: 255},
+			Highlight:   color.RGBA{R: 255, G: 215, B: 0, A: 255},
+			Accent:      color.RGBA{R: 100, G: 200, B: 255, A: 255},
+			Success:     color.RGBA{R: 100, G: 220, B: 100, A: 255},
+			Warning:     color.RGBA{R: 255, G: 170, B: 50, A: 255},
+			Danger:      color.RGBA{R: 200, G: 50, B: 50, A: 255},
+			Rarity:      append([]color.RGBA(nil), defaultRarity...),
+		},
+		Fonts:   defaultFonts,
+		Spacing: Spacing{Padding: 10, Gap: 6},
+		Border:  BorderStyle{Width: 2, Radius: 6},
+	}
+}
+
+// LightTheme returns the built-in light theme.
+func LightTheme() *Theme {
+	return &Theme{
+		Name: "Light",
+		Palette: Palette{
+			Background:  color.RGBA{R: 230, G: 232, B: 238, A: 255},
+			Overlay:     color.RGBA{R: 255, G: 255, B: 255, A: 140},
+			Panel:       color.RGBA{R: 245, G: 246, B: 250, A: 245},
+			PanelBorder: color.RGBA{R: 90, G: 100, B: 130, A: 255},
+			Button:      color.RGBA{R: 210, G: 215, B: 230, A: 255},
+			ButtonHover: color.RGBA{R: 180, G: 195, B: 230, A: 255},
+			Text:        color.RGBA{R: 20, G: 20, B: 30, A: 255},
+			TextMuted:   color.RGBA{R: 100, G: 105, B: 120, A: 255},
+			Highlight:   color.RGBA{R: 200, G: 120, B: 0, A: 255},
+			Accent:      color.RGBA{R: 30, G: 110, B: 200, A: 255},
+			Success:     color.RGBA{R: 30, G: 140, B: 60, A: 255},
+			Warning:     color.RGBA{R: 200, G: 110, B: 0, A: 255},
+			Danger:      color.RGBA{R: 190, G: 30, B: 30, A: 255},
+			Rarity: []color.RGBA{
+				{R: 90, G: 90, B: 90, A: 255},
+				{R: 40, G: 90, B: 220, A: 255},
+				{R: 190, G: 150, B: 0, A: 255},
+				{R: 220, G: 100, B: 0, A: 255},
+			},
+		},
+		Fonts:   defaultFonts,
+		Spacing: Spacing{Padding: 10, Gap: 6},
+		Border:  BorderStyle{Width: 2, Radius: 6},
+	}
+}
+
+// HighContrastTheme returns the built-in high-contrast theme: pure black and
+// white surfaces, thick borders, saturated accents, and larger text.
+func HighContrastTheme() *Theme {
+	return &Theme{
+		Name: "High Contrast",
+		Palette: Palette{
+			Background:  color.RGBA{A: 255},
+			Overlay:     color.RGBA{A: 230},
+			Panel:       color.RGBA{A: 255},
+			PanelBorder: color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			Button:      color.RGBA{A: 255},
+			ButtonHover: color.RGBA{R: 0, G: 0, B: 160, A: 255},
+			Text:        color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			TextMuted:   color.RGBA{R: 220, G: 220, B: 220, A: 255},
+			Highlight:   color.RGBA{R: 255, G: 255, B: 0, A: 255},
+			Accent:      color.RGBA{R: 0, G: 255, B: 255, A: 255},
+			Success:     color.RGBA{R: 0, G: 255, B: 0, A: 255},
+			Warning:     color.RGBA{R: 255, G: 160, B: 0, A: 255},
+			Danger:      color.RGBA{R: 255, G: 0, B: 0, A: 255},
+			Rarity: []color.RGBA{
+				{R: 255, G: 255, B: 255, A: 255},
+				{R: 0, G: 160, B: 255, A: 255},
+				{R: 255, G: 255, B: 0, A: 255},
+				{R: 255, G: 100, B: 0, A: 255},
+			},
+		},
+		Fonts:   FontSizes{Small: 13, Body: 16, Heading: 22, Title: 32},
+		Spacing: Spacing{Padding: 12, Gap: 8},
+		Border:  BorderStyle{Width: 3, Radius: 0},
+	}
+}
+
+var (
+	themes       = map[string]*Theme{}
+	themeOrder   []string
+	currentTheme *Theme
+	themeHooks   []func(*Theme)
+)
+
+func init() {
+	RegisterTheme(DarkTheme())
+	RegisterTheme(LightTheme())
+	RegisterTheme(HighContrastTheme())
+
+	currentTheme = themes["Dark"]
+}
+
+// RegisterTheme adds or replaces a theme, making it selectable by name.
+func RegisterTheme(t *Theme) {
+	if _, exists := themes[t.Name]; !exists {
+		themeOrder = append(themeOrder, t.Name)
+	}
+
+	themes[t.Name] = t
+
+	if currentTheme != nil && currentTheme.Name == t.Name {
+		setCurrentTheme(t)
+	}
+}
+
+// LookupTheme returns a registered theme by name.
+func LookupTheme(name string) (*Theme, bool) {
+	t, ok := themes[name]
+
+	return t, ok
+}
+
+// ThemeNames returns registered theme names in registration order.
+func ThemeNames() []string {
+	return append([]string(nil), themeOrder...)
+}
+
+// CurrentTheme returns the active theme.
+func CurrentTheme() *Theme {
+	return currentTheme
+}
+
+// SetTheme switches the active theme at runtime and notifies listeners.
+func SetTheme(name string) error {
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+
+	setCurrentTheme(t)
+
+	return nil
+}
+
+// CycleTheme switches to the next (dir > 0) or previous registered theme.
+func CycleTheme(dir int) *Theme {
+	idx := 0
+
+	for i, name := range themeOrder {
+		if name == currentTheme.Name {
+			idx = i
+		}
+	}
+
+	n := len(themeOrder)
+	setCurrentTheme(themes[themeOrder[((idx+dir)%n+n)%n]])
+
+	return currentTheme
+}
+
+// OnThemeChange registers a callback invoked whenever the active theme changes,
+// e.g. to rebuild cached skins.
+func OnThemeChange(fn func(*Theme)) {
+	themeHooks = append(themeHooks, fn)
+}
+
+func setCurrentTheme(t *Theme) {
+	currentTheme = t
+
+	for _, fn := range themeHooks {
+		fn(t)
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import "testing"
+
+func TestThemeRegistry(t *testing.T) {
+	original := CurrentTheme().Name
+	defer func() { _ = SetTheme(original) }()
+
+	for _, name := range []string{"Dark", "Light", "High Contrast"} {
+		if _, ok := LookupTheme(name); !ok {
+			t.Errorf("built-in theme %q not registered", name)
+		}
+	}
+
+	var notified *Theme
+
+	OnThemeChange(func(th *Theme) { notified = th })
+
+	if err := SetTheme("Light"); err != nil {
+		t.Fatal(err)
+	}
+
+	if CurrentTheme().Name != "Light" || notified == nil || notified.Name != "Light" {
+		t.Errorf("current = %q, notified = %v", CurrentTheme().Name, notified)
+	}
+
+	if err := SetTheme("Nope"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+
+	before := CurrentTheme().Name
+	CycleTheme(1)
+	CycleTheme(-1)
+
+	if CurrentTheme().Name != before {
+		t.Errorf("cycling forward and back landed on %q, want %q", CurrentTheme().Name, before)
+	}
+}
+
+func TestThemeCloneOverride(t *testing.T) {
+	base := DarkTheme()
+	custom := base.Clone("Custom")
+	custom.Palette.Rarity[0] = base.Palette.Highlight
+
+	if base.Palette.Rarity[0] == base.Palette.Highlight {
+		t.Error("clone should not share the rarity slice with its base")
+	}
+
+	if got := custom.Palette.RarityColor(99); got != custom.Palette.Rarity[3] {
+		t.Errorf("RarityColor should clamp to the highest tier, got %v", got)
+	}
+
+	if custom.Skin() != custom.Skin() {
+		t.Error("skin should be cached per theme")
+	}
+
+	RegisterTheme(custom)
+
+	if _, ok := LookupTheme("Custom"); !ok {
+		t.Error("custom theme not registered")
+	}
+}
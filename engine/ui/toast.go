@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+// ToastPriority orders notifications when more are queued than fit on screen.
+type ToastPriority int
+
+const (
+	ToastLow ToastPriority = iota
+	ToastNormal
+	ToastHigh
+	ToastCritical // e.g. disconnects; pushes lower priority toasts off screen
+)
+
+// DefaultToastDuration is used when a notification has no duration.
+const DefaultToastDuration = 3.0
+
+// Notification is the event published on an events.Bus to show a toast.
+type Notification struct {
+	Title    string
+	Message  string
+	Icon     *ebiten.Image // Optional, drawn at 24x24
+	Color    color.Color   // Accent stripe; nil uses the theme highlight
+	Duration float64       // Seconds on screen; 0 uses DefaultToastDuration
+	Priority ToastPriority
+}
+
+// toast is a notification being tracked by the queue.
+type toast struct {
+	Notification
+
+	id  int
+	seq int     // Arrival order, for FIFO within a priority
+	age float64 // Seconds spent visible
+}
+
+// ToastQueue shows stacking, self-dismissing notifications above gameplay.
+// Toasts beyond MaxVisible wait in a priority queue; clicking a toast dismisses it.
+type ToastQueue struct {
+	X, Y       float64 // Top-right anchor of the stack
+	Width      float64
+	Height     float64 // Height of a single toast
+	MaxVisible int
+
+	visible []*toast
+	pending []*toast
+	nextID  int
+	unsub   func()
+}
+
+// NewToastQueue creates a toast queue anchored to the top-right corner of a
+// screen of the given width. If bus is non-nil, Notification events published
+// on it are shown automatically.
+func NewToastQueue(bus *events.Bus, screenWidth float64) *ToastQueue {
+	q := &ToastQueue{
+		X:          screenWidth - 10,
+		Y:          10,
+		Width:      260,
+		Height:     48,
+		MaxVisible: 4,
+	}
+
+	if bus != nil {
+		q.unsub = events.Subscribe(bus, func(n Notification) { q.Push(n) })
+	}
+
+	return q
+}
+
+// Close detaches the queue from its event bus.
+func (q *ToastQueue) Close() {
+	if q.unsub != nil {
+		q.unsub()
+		q.unsub = nil
+	}
+}
+
+// Push adds a notification and returns its id.
+func (q *ToastQueue) Push(n Notification) int {
+	if n.Duration <= 0 {
+		n.Duration = DefaultToastDuration
+	}
+
+	q.nextID++
+	t := &toast{Notification: n, id: q.nextID, seq: q.nextID}
+
+	if len(q.visible) < q.MaxVisible {
+		q.visible = append(q.visible, t)
+
+		return t.id
+	}
+
+	// A more important toast bumps the least important visible one back to pending
+	lowest := 0
+	for i, v := range q.visible {
+		if v.Priority < q.visible[lowest].Priority {
+			lowest = i
+		}
+	}
+
+	if n.Priority > q.visible[lowest].Priority {
+		q.pending = append(q.pending, q.visible[lowest])
+		q.visible[lowest] = t
+	} else {
+		q.pending = append(q.pending, t)
+	}
+
+	q.sortPending()
+
+	return t.id
+}
+
+// Dismiss removes a toast by id, visible or pending.
+func (q *ToastQueue) Dismiss(id int) {
+	for i, t := range q.visible {
+		if t.id == id {
+			q.visible = append(q.visible[:i], q.visible[i+1:]...)
+			q.promote()
+
+			return
+		}
+	}
+
+	for i, t := range q.pending {
+		if t.id == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Clear removes all toasts.
+func (q *ToastQueue) Clear() {
+	q.visible = q.visible[:0]
+	q.pending = q.pending[:0]
+}
+
+// Visible returns the notifications currently on screen, top first.
+func (q *ToastQueue) Visible() []Notification {
+	result := make([]Notification, len(q.visible))
+	for i, t := range q.visible {
+		result[i] = t.Notification
+	}
+
+	return result
+}
+
+// Len returns the number of visible and pending toasts.
+func (q *ToastQueue) Len() int {
+	return len(q.visible) + len(q.pending)
+}
+
+// Update ages toasts, expires finished ones, and handles click-to-dismiss.
+func (q *ToastQueue) Update(dt float64) {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		q.HandleClick(float64(mx), float64(my))
+	}
+
+	kept := q.visible[:0]
+
+	for _, t := range q.visible {
+		t.age += dt
+		if t.age < t.Duration {
+			kept = append(kept, t)
+		}
+	}
+
+	q.visible = kept
+	q.promote()
+}
+
+// HandleClick dismisses the toast under the point. It returns true if one was hit,
+// so callers can swallow the click.
+func (q *ToastQueue) HandleClick(x, y float64) bool {
+	for i, t := range q.visible {
+		tx, ty := q.toastRect(i)
+		if x >= tx && x <= tx+q.Width && y >= ty && y <= ty+q.Height {
+			q.Dismiss(t.id)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// promote moves pending toasts into free visible slots, most important first.
+func (q *ToastQueue) promote() {
+	for len(q.visible) < q.MaxVisible && len(q.pending) > 0 {
+		q.visible = append(q.visible, q.pending[0])
+		q.pending = q.pending[1:]
+	}
+}
+
+func (q *ToastQueue) sortPending() {
+	sort.SliceStable(q.pending, func(i, j int) bool {
+		if q.pending[i].Priority != q.pending[j].Priority {
+			return q.pending[i].Priority > q.pending[j].Priority
+		}
+
+		return q.pending[i].seq < q.pending[j].seq
+	})
+}
+
+func (q *ToastQueue) toastRect(i int) (x, y float64) {
+	return q.X - q.Width, q.Y + float64(i)*(q.Height+6)
+}
+
+// Draw renders the visible toasts using the current theme.
+func (q *ToastQueue) Draw(screen *ebiten.Image) {
+	theme := CurrentTheme()
+	skin := theme.Skin()
+
+	for i, t := range q.visible {
+		x, y := q.toastRect(i)
+
+		// Slide in from the right during the first 0.2s
+		if t.age < 0.2 {
+			x += (1 - t.age/0.2) * (q.Width + 10)
+		}
+
+		skin.Tooltip.Draw(screen, x, y, q.Width, q.Height)
+
+		accent := t.Color
+		if accent == nil {
+			accent = theme.Palette.Highlight
+		}
+
+		vector.FillRect(screen, float32(x)+3, float32(y)+3, 4, float32(q.Height)-6, accent, false)
+
+		textX := int(x) + 14
+		if t.Icon != nil {
+			op := &ebiten.DrawImageOptions{}
+			b := t.Icon.Bounds()
+			op.GeoM.Scale(24/float64(b.Dx()), 24/float64(b.Dy()))
+			op.GeoM.Translate(x+14, y+(q.Height-24)/2)
+			screen.DrawImage(t.Icon, op)
+
+			textX += 30
+		}
+
+		DebugPrintAt(screen, t.Title, textX, int(y)+8)
+		DebugPrintAt(screen, t.Message, textX, int(y)+26)
+
+		// Remaining time bar
+		remaining := 1 - t.age/t.Duration
+		vector.FillRect(screen, float32(x)+10, float32(y+q.Height)-4,
+			float32((q.Width-20)*remaining), 2, accent, false)
+	}
+}
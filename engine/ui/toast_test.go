@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+)
+
+func TestToastQueueViaBus(t *testing.T) {
+	bus := events.NewBus()
+	q := NewToastQueue(bus, 800)
+	q.MaxVisible = 2
+
+	events.Publish(bus, Notification{Title: "a"})
+	events.Publish(bus, Notification{Title: "b", Duration: 10})
+	events.Publish(bus, Notification{Title: "c", Priority: ToastLow})
+
+	if q.Len() != 3 || len(q.Visible()) != 2 {
+		t.Fatalf("len = %d, visible = %d", q.Len(), len(q.Visible()))
+	}
+
+	// "a" expires after the default duration, making room for "c"
+	q.Update(DefaultToastDuration + 0.1)
+
+	visible := q.Visible()
+	if len(visible) != 2 || visible[0].Title != "b" || visible[1].Title != "c" {
+		t.Errorf("visible after expiry = %+v", visible)
+	}
+
+	q.Close()
+	events.Publish(bus, Notification{Title: "ignored"})
+
+	if q.Len() != 2 {
+		t.Error("closed queue should ignore bus events")
+	}
+}
+
+func TestToastPriorityAndDismiss(t *testing.T) {
+	q := NewToastQueue(nil, 800)
+	q.MaxVisible = 1
+
+	q.Push(Notification{Title: "low", Priority: ToastLow})
+	q.Push(Notification{Title: "normal", Priority: ToastNormal})
+	q.Push(Notification{Title: "critical", Priority: ToastCritical})
+
+	if v := q.Visible(); v[0].Title != "critical" {
+		t.Fatalf("critical toast should preempt, visible = %+v", v)
+	}
+
+	// Clicking the toast dismisses it and promotes the next most important
+	x, y := q.toastRect(0)
+	if !q.HandleClick(x+5, y+5) {
+		t.Fatal("click on toast not handled")
+	}
+
+	if v := q.Visible(); v[0].Title != "normal" {
+		t.Errorf("after dismiss, visible = %+v, want normal", v)
+	}
+
+	if q.HandleClick(0, 500) {
+		t.Error("click outside toasts should not be handled")
+	}
+}
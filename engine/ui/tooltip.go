@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tooltip layout, in pixels.
+const (
+	tooltipPad    = 6
+	tooltipOffset = 14 // From the anchor, clear of the cursor
+)
+
+// Tooltip is a small box of text shown next to the cursor or a widget,
+// flipped to the other side of the anchor where it would leave the screen.
+type Tooltip struct {
+	Text string // Lines are split at newlines
+	Skin *Skin  // Nil uses the current theme's skin
+}
+
+// Size returns the tooltip's width and height.
+func (t *Tooltip) Size() (w, h float64) {
+	lines := strings.Split(t.Text, "\n")
+
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, len(line))
+	}
+
+	return float64(longest*textCharWidth + 2*tooltipPad), float64(len(lines)*lineHeight + 2*tooltipPad)
+}
+
+// Place returns the top-left of the tooltip shown below and right of an
+// anchor, flipped above or left of it to fit a screenW by screenH screen.
+func (t *Tooltip) Place(anchorX, anchorY, screenW, screenH float64) (x, y float64) {
+	w, h := t.Size()
+	x, y = anchorX+tooltipOffset, anchorY+tooltipOffset
+
+	if x+w > screenW {
+		x = anchorX - tooltipOffset - w
+	}
+
+	if y+h > screenH {
+		y = anchorY - tooltipOffset - h
+	}
+
+	return max(x, 0), max(y, 0)
+}
+
+// Draw draws the tooltip next to an anchor such as the cursor.
+func (t *Tooltip) Draw(screen *ebiten.Image, anchorX, anchorY float64) {
+	if t.Text == "" {
+		return
+	}
+
+	bounds := screen.Bounds()
+	x, y := t.Place(anchorX, anchorY, float64(bounds.Dx()), float64(bounds.Dy()))
+	w, h := t.Size()
+
+	skinOr(t.Skin).Tooltip.Draw(screen, x, y, w, h)
+	DebugPrintAt(screen, t.Text, int(x)+tooltipPad, int(y)+tooltipPad)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Debug font cell, in pixels.
+const lineHeight = 16
+
+// Rect is a widget's area on screen.
+type Rect struct {
+	X, Y, W, H float64
+}
+
+// Contains reports whether a screen point is inside r.
+func (r Rect) Contains(x, y int) bool {
+	px, py := float64(x), float64(y)
+
+	return px >= r.X && px < r.X+r.W && py >= r.Y && py < r.Y+r.H
+}
+
+// Pointer is the mouse as widgets see it for one tick.
+type Pointer struct {
+	X, Y    int
+	Clicked bool // The left button went down this tick
+}
+
+// readPointer reads the mouse; tests replace it.
+var readPointer = func() Pointer {
+	x, y := ebiten.CursorPosition()
+
+	return Pointer{X: x, Y: y, Clicked: inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)}
+}
+
+// hover tracks the cursor between ticks so that a cursor resting over a
+// widget does not take focus back from the keyboard: only moving or
+// clicking the mouse focuses what is under it.
+type hover struct {
+	x, y int
+	seen bool
+}
+
+// moved reports whether p differs from the pointer of the previous tick.
+func (h *hover) moved(p Pointer) bool {
+	moved := h.seen && (p.X != h.x || p.Y != h.y)
+	h.x, h.y, h.seen = p.X, p.Y, true
+
+	return moved || p.Clicked
+}
+
+// skinOr returns s, or the current theme's skin when s is nil.
+func skinOr(s *Skin) *Skin {
+	if s == nil {
+		return CurrentTheme().Skin()
+	}
+
+	return s
+}
+
+// drawCentered draws a line of debug text centered in r.
+func drawCentered(screen *ebiten.Image, s string, r Rect) {
+	x := r.X + (r.W-float64(len(s)*textCharWidth))/2
+	y := r.Y + (r.H-lineHeight)/2
+
+	DebugPrintAt(screen, s, int(x), int(y))
+}
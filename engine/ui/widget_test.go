@@ -0,0 +1,51 @@
+package ui
+
+import "testing"
+
+func TestProgressBarFraction(t *testing.T) {
+	b := NewProgressBar(0, 0, 100, 10)
+
+	for _, c := range []struct{ value, maximum, want float64 }{
+		{5, 10, 0.5},
+		{15, 10, 1},
+		{-3, 10, 0},
+		{5, 0, 0},
+	} {
+		b.Set(c.value, c.maximum)
+
+		if got := b.Fraction(); got != c.want {
+			t.Errorf("Fraction() of %v/%v = %v, want %v", c.value, c.maximum, got, c.want)
+		}
+	}
+}
+
+func TestTooltipStaysOnScreen(t *testing.T) {
+	tip := &Tooltip{Text: "Double your bet\nand take one card"}
+	w, h := tip.Size()
+
+	if w != 17*textCharWidth+2*tooltipPad || h != 2*lineHeight+2*tooltipPad {
+		t.Errorf("Size() = %v x %v", w, h)
+	}
+
+	if x, y := tip.Place(10, 10, 640, 480); x != 10+tooltipOffset || y != 10+tooltipOffset {
+		t.Errorf("Place() near the top-left = %v, %v, want below and right", x, y)
+	}
+
+	if x, y := tip.Place(630, 470, 640, 480); x+w > 630 || y+h > 470 {
+		t.Errorf("Place() near the bottom-right = %v, %v, want flipped above and left", x, y)
+	}
+}
+
+func TestPanelContent(t *testing.T) {
+	p := CenteredPanel(640, 480, 200, 100, "PAUSED")
+	pad := CurrentTheme().Spacing.Padding
+
+	if p.X != 220 || p.Y != 190 {
+		t.Errorf("panel at %v, %v, want centered", p.X, p.Y)
+	}
+
+	c := p.Content()
+	if c.X != p.X+pad || c.Y != p.Y+panelTitleH || c.Y+c.H != p.Y+p.H-pad {
+		t.Errorf("Content() = %+v, want below the title inside the padding", c)
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"image/color"
 	"log"
 	"math"
-	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/spectator"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -19,6 +25,7 @@ const (
 
 // Cell represents a player or AI cell.
 type Cell struct {
+	ID     uint64
 	X, Y   float64
 	Radius float64
 	Color  color.RGBA
@@ -35,308 +42,164 @@ type Food struct {
 
 // Game represents the agar.io clone.
 type Game struct {
-	player    *Cell
-	aiCells   []*Cell
-	foods     []*Food
-	cameraX   float64
-	cameraY   float64
-	score     int
+	*World // Local, or the client's copy of the server's while networked
+
+	camera    *game.Camera
 	highscore int
-	gameOver  bool
+	paused    bool
+
+	// Watches the bots after the player is eaten, nil while playing
+	observer *spectator.Observer
+
+	// Plays through a server-authoritative world over a simulated network
+	netView *netView
 }
 
 // NewGame creates a new game.
 func NewGame() *Game {
 	g := &Game{
-		foods:   make([]*Food, 0),
-		aiCells: make([]*Cell, 0),
+		World:   newWorld(),
+		camera:  game.NewCamera(screenWidth, screenHeight),
+		netView: newNetView(),
 	}
-	g.reset()
+	g.camera.Smoothing = 0
 
 	return g
 }
 
-func (g *Game) reset() {
-	g.player = &Cell{
-		X:      float64(worldSize) / 2,
-		Y:      float64(worldSize) / 2,
-		Radius: 20,
-		Color:  color.RGBA{R: 50, G: 150, B: 255, A: 255},
-		Name:   "Player",
-	}
+// Reset starts a new round, asking the server for one while networked.
+func (g *Game) Reset() {
+	g.observer = nil
+	g.paused = false
 
-	g.foods = make([]*Food, 0)
-	g.aiCells = make([]*Cell, 0)
-	g.score = 0
-	g.gameOver = false
+	if g.netView.active() {
+		g.netView.respawn()
 
-	// Spawn initial food
-	for range 200 {
-		g.spawnFood()
+		return
 	}
 
-	// Spawn AI cells
-	for range 10 {
-		g.spawnAI()
-	}
-}
-
-func (g *Game) spawnFood() {
-	colors := []color.RGBA{
-		{R: 255, G: 100, B: 100, A: 255},
-		{R: 100, G: 255, B: 100, A: 255},
-		{R: 100, G: 100, B: 255, A: 255},
-		{R: 255, G: 255, B: 100, A: 255},
-		{R: 255, G: 100, B: 255, A: 255},
-		{R: 100, G: 255, B: 255, A: 255},
-	}
-
-	g.foods = append(g.foods, &Food{
-		X:     rand.Float64() * worldSize,
-		Y:     rand.Float64() * worldSize,
-		Color: colors[rand.Intn(len(colors))],
-	})
+	g.reset()
 }
 
-func (g *Game) spawnAI() {
-	colors := []color.RGBA{
-		{R: 200, G: 50, B: 50, A: 255},
-		{R: 50, G: 200, B: 50, A: 255},
-		{R: 200, G: 200, B: 50, A: 255},
-		{R: 200, G: 50, B: 200, A: 255},
-		{R: 50, G: 200, B: 200, A: 255},
+// Pause pauses a local round, for engine.FocusConfig.AutoPause. Networked
+// rounds never pause: the server's world keeps running.
+func (g *Game) Pause() {
+	if !g.gameOver && !g.netView.active() {
+		g.paused = true
 	}
-	names := []string{"Bot1", "Bot2", "Bot3", "Bot4", "Bot5", "Bot6", "Bot7", "Bot8"}
-
-	g.aiCells = append(g.aiCells, &Cell{
-		X:      rand.Float64() * worldSize,
-		Y:      rand.Float64() * worldSize,
-		Radius: 15 + rand.Float64()*30,
-		Color:  colors[rand.Intn(len(colors))],
-		IsAI:   true,
-		Name:   names[rand.Intn(len(names))],
-	})
 }
 
 func (g *Game) Update() error {
-	if g.gameOver {
-		if ebiten.IsKeyPressed(ebiten.KeySpace) {
-			if g.score > g.highscore {
-				g.highscore = g.score
-			}
-
-			g.reset()
-		}
-
-		return nil
+	if !g.gameOver && !g.netView.active() &&
+		(inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyP)) {
+		g.paused = !g.paused
 	}
 
-	// Get mouse position relative to center
-	mx, my := ebiten.CursorPosition()
-	dx := float64(mx) - float64(screenWidth)/2
-	dy := float64(my) - float64(screenHeight)/2
-
-	// Normalize and apply speed (smaller = faster)
-	dist := math.Sqrt(dx*dx + dy*dy)
-	if dist > 0 {
-		speed := 5.0 / (1 + g.player.Radius/50)
-		g.player.VX = (dx / dist) * speed
-		g.player.VY = (dy / dist) * speed
+	if g.paused {
+		return nil
 	}
 
-	// Move player
-	g.player.X += g.player.VX
-	g.player.Y += g.player.VY
-
-	// Keep in world bounds
-	g.player.X = clamp(g.player.X, g.player.Radius, worldSize-g.player.Radius)
-	g.player.Y = clamp(g.player.Y, g.player.Radius, worldSize-g.player.Radius)
-
-	// Update camera
-	g.cameraX = g.player.X - float64(screenWidth)/2
-	g.cameraY = g.player.Y - float64(screenHeight)/2
-
-	// Eat food
-	for i := len(g.foods) - 1; i >= 0; i-- {
-		food := g.foods[i]
+	g.netView.handleInput(g)
 
-		dist := math.Sqrt((g.player.X-food.X)*(g.player.X-food.X) + (g.player.Y-food.Y)*(g.player.Y-food.Y))
-		if dist < g.player.Radius {
-			g.player.Radius += 0.5
-			g.score += 10
-			g.foods = append(g.foods[:i], g.foods[i+1:]...)
-			g.spawnFood()
-		}
+	var in Input
+	if !g.gameOver {
+		in = readInput()
 	}
 
-	// Update AI
-	for _, ai := range g.aiCells {
-		// Find nearest food or smaller cell
-		var targetX, targetY float64
-
-		minDist := math.MaxFloat64
+	if g.netView.active() {
+		g.netView.update(g, in)
+	}
 
-		for _, food := range g.foods {
-			dist := math.Sqrt((ai.X-food.X)*(ai.X-food.X) + (ai.Y-food.Y)*(ai.Y-food.Y))
-			if dist < minDist {
-				minDist = dist
-				targetX, targetY = food.X, food.Y
+	if g.gameOver {
+		if ebiten.IsKeyPressed(ebiten.KeySpace) {
+			if g.score > g.highscore {
+				g.highscore = g.score
 			}
-		}
 
-		// Move toward target
-		if minDist < math.MaxFloat64 {
-			dx := targetX - ai.X
-			dy := targetY - ai.Y
+			g.Reset()
 
-			dist := math.Sqrt(dx*dx + dy*dy)
-			if dist > 0 {
-				speed := 3.0 / (1 + ai.Radius/50)
-				ai.X += (dx / dist) * speed
-				ai.Y += (dy / dist) * speed
-			}
+			return nil
 		}
 
-		// Keep in bounds
-		ai.X = clamp(ai.X, ai.Radius, worldSize-ai.Radius)
-		ai.Y = clamp(ai.Y, ai.Radius, worldSize-ai.Radius)
-
-		// AI eats food
-		for i := len(g.foods) - 1; i >= 0; i-- {
-			food := g.foods[i]
-
-			dist := math.Sqrt((ai.X-food.X)*(ai.X-food.X) + (ai.Y-food.Y)*(ai.Y-food.Y))
-			if dist < ai.Radius {
-				ai.Radius += 0.3
+		if g.observer == nil && ebiten.IsKeyPressed(ebiten.KeyV) {
+			g.observer = spectator.New(screenWidth, screenHeight, g.player.X, g.player.Y)
+		}
 
-				g.foods = append(g.foods[:i], g.foods[i+1:]...)
-				g.spawnFood()
+		if g.observer != nil {
+			if !g.netView.active() {
+				g.updateAI()
 			}
+
+			g.observer.Update(1.0/60, g.spectatorEntities(), spectator.ReadInput())
 		}
+
+		return nil
 	}
 
-	// Player eats AI or gets eaten
-	for i := len(g.aiCells) - 1; i >= 0; i-- {
-		ai := g.aiCells[i]
-		dist := math.Sqrt((g.player.X-ai.X)*(g.player.X-ai.X) + (g.player.Y-ai.Y)*(g.player.Y-ai.Y))
-
-		if g.player.Radius > ai.Radius*1.1 && dist < g.player.Radius {
-			// Player eats AI
-			g.player.Radius += ai.Radius * 0.3
-			g.score += int(ai.Radius * 10)
-			g.aiCells = append(g.aiCells[:i], g.aiCells[i+1:]...)
-			g.spawnAI()
-		} else if ai.Radius > g.player.Radius*1.1 && dist < ai.Radius {
-			// AI eats player
-			g.gameOver = true
-		}
+	if !g.netView.active() {
+		g.steer(in)
+		g.step()
 	}
 
+	g.camera.LookAt(g.player.X, g.player.Y)
+
 	return nil
 }
 
-func (g *Game) Draw(screen *ebiten.Image) {
-	// Background
-	screen.Fill(color.RGBA{R: 240, G: 240, B: 245, A: 255})
+// readInput steers toward the cursor.
+func readInput() Input {
+	mx, my := ebiten.CursorPosition()
 
-	// Grid lines
-	gridSpacing := 50.0
-	for x := 0.0; x < worldSize; x += gridSpacing {
-		screenX := x - g.cameraX
-		if screenX >= -10 && screenX <= screenWidth+10 {
-			vector.FillRect(
-				screen,
-				float32(screenX),
-				0,
-				1,
-				screenHeight,
-				color.RGBA{R: 220, G: 220, B: 225, A: 255},
-				false,
-			)
-		}
-	}
+	return Input{DX: float64(mx) - screenWidth/2, DY: float64(my) - screenHeight/2}
+}
 
-	for y := 0.0; y < worldSize; y += gridSpacing {
-		screenY := y - g.cameraY
-		if screenY >= -10 && screenY <= screenHeight+10 {
-			vector.FillRect(
-				screen,
-				0,
-				float32(screenY),
-				screenWidth,
-				1,
-				color.RGBA{R: 220, G: 220, B: 225, A: 255},
-				false,
-			)
+// spectatorEntities lists the bots for the observer where the client draws
+// them, so while networked the labels and follow camera track the
+// interpolated bots rather than the newest snapshot.
+func (g *Game) spectatorEntities() []spectator.Entity {
+	entities := make([]spectator.Entity, 0, len(g.aiCells))
+	for _, ai := range g.aiCells {
+		seen, ok := g.netView.view(ai)
+		if !ok {
+			continue
 		}
-	}
-
-	// Draw food
-	for _, food := range g.foods {
-		screenX := food.X - g.cameraX
 
-		screenY := food.Y - g.cameraY
-		if screenX >= -10 && screenX <= screenWidth+10 && screenY >= -10 && screenY <= screenHeight+10 {
-			vector.FillCircle(screen, float32(screenX), float32(screenY), 5, food.Color, false)
-		}
+		entities = append(entities, spectator.Entity{
+			ID:     ai.ID,
+			Name:   ai.Name,
+			X:      seen.X,
+			Y:      seen.Y,
+			Radius: ai.Radius,
+			Stats: []spectator.Stat{
+				{Label: "Mass", Value: formatInt(int(ai.Radius))},
+				{Label: "Speed", Value: formatInt(int(3.0 / (1 + ai.Radius/50) * 60))},
+			},
+		})
 	}
 
-	// Draw AI cells
-	for _, ai := range g.aiCells {
-		screenX := ai.X - g.cameraX
-
-		screenY := ai.Y - g.cameraY
-		if screenX >= -float64(ai.Radius) && screenX <= float64(screenWidth)+ai.Radius &&
-			screenY >= -float64(ai.Radius) && screenY <= float64(screenHeight)+ai.Radius {
-			vector.FillCircle(
-				screen,
-				float32(screenX),
-				float32(screenY),
-				float32(ai.Radius),
-				ai.Color,
-				false,
-			)
-			vector.StrokeCircle(
-				screen,
-				float32(screenX),
-				float32(screenY),
-				float32(ai.Radius),
-				2,
-				color.RGBA{R: 0, G: 0, B: 0, A: 50},
-				false,
-			)
-			ebitenutil.DebugPrintAt(screen, ai.Name, int(screenX)-15, int(screenY)-5)
-		}
-	}
+	return entities
+}
 
-	// Draw player
-	if !g.gameOver {
-		screenX := g.player.X - g.cameraX
-		screenY := g.player.Y - g.cameraY
-		vector.FillCircle(
-			screen,
-			float32(screenX),
-			float32(screenY),
-			float32(g.player.Radius),
-			g.player.Color,
-			false,
-		)
-		vector.StrokeCircle(
+func (g *Game) Draw(screen *ebiten.Image) {
+	if g.observer != nil {
+		g.observer.Draw(screen, g.drawWorld)
+		ebitenutil.DebugPrintAt(
 			screen,
-			float32(screenX),
-			float32(screenY),
-			float32(g.player.Radius),
-			3,
-			color.RGBA{R: 255, G: 255, B: 255, A: 150},
-			false,
+			"TAB follow | F free | WASD fly | wheel zoom | P picture-in-picture | SPACE restart",
+			10,
+			10,
 		)
-		ebitenutil.DebugPrintAt(screen, g.player.Name, int(screenX)-20, int(screenY)-5)
+
+		return
 	}
 
+	g.drawWorld(screen, g.camera)
+
 	// UI
 	ebitenutil.DebugPrintAt(screen, "Score: "+formatInt(g.score), 10, 10)
 	ebitenutil.DebugPrintAt(screen, "Mass: "+formatInt(int(g.player.Radius)), 10, 30)
 	ebitenutil.DebugPrintAt(screen, "Best: "+formatInt(g.highscore), 10, 50)
+	g.netView.draw(screen, g.camera)
 
 	if g.gameOver {
 		vector.FillRect(
@@ -351,19 +214,81 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, "GAME OVER - You were eaten!", screenWidth/2-100, screenHeight/2-20)
 		ebitenutil.DebugPrintAt(screen, "Score: "+formatInt(g.score), screenWidth/2-40, screenHeight/2)
 		ebitenutil.DebugPrintAt(screen, "Press SPACE to restart", screenWidth/2-80, screenHeight/2+30)
+		ebitenutil.DebugPrintAt(screen, "Press V to spectate", screenWidth/2-70, screenHeight/2+50)
+	}
+
+	if g.paused {
+		ui.DrawPaused(screen, "ESC or P to resume")
 	}
 }
 
-func clamp(v, minVal, maxVal float64) float64 {
-	if v < minVal {
-		return minVal
+// drawWorld draws the grid and cells onto dst as seen by cam. Names are
+// left to the observer's labels while spectating.
+func (g *Game) drawWorld(dst *ebiten.Image, cam *game.Camera) {
+	// Background
+	dst.Fill(color.RGBA{R: 240, G: 240, B: 245, A: 255})
+
+	// Grid lines
+	gridSpacing := 50.0
+	gridColor := color.RGBA{R: 220, G: 220, B: 225, A: 255}
+	w, h := float32(cam.ScreenWidth), float32(cam.ScreenHeight)
+	minX, minY, maxX, maxY := cam.GetViewBounds()
+
+	startX := math.Max(math.Ceil(minX/gridSpacing)*gridSpacing, 0)
+	startY := math.Max(math.Ceil(minY/gridSpacing)*gridSpacing, 0)
+
+	for x := startX; x < math.Min(maxX, worldSize); x += gridSpacing {
+		sx, _ := cam.WorldToScreen(x, 0)
+		vector.FillRect(dst, float32(sx), 0, 1, h, gridColor, false)
 	}
 
-	if v > maxVal {
-		return maxVal
+	for y := startY; y < math.Min(maxY, worldSize); y += gridSpacing {
+		_, sy := cam.WorldToScreen(0, y)
+		vector.FillRect(dst, 0, float32(sy), w, 1, gridColor, false)
 	}
 
-	return v
+	// Draw food
+	for _, food := range g.foods {
+		if cam.IsVisible(food.X, food.Y) {
+			sx, sy := cam.WorldToScreen(food.X, food.Y)
+			vector.FillCircle(dst, float32(sx), float32(sy), float32(5*cam.Zoom), food.Color, false)
+		}
+	}
+
+	// Draw AI cells
+	for _, ai := range g.aiCells {
+		if seen, ok := g.netView.view(ai); ok {
+			g.drawCell(dst, cam, &seen, 2, color.RGBA{R: 0, G: 0, B: 0, A: 50})
+		}
+	}
+
+	// Draw player
+	if !g.gameOver {
+		g.drawCell(dst, cam, g.player, 3, color.RGBA{R: 255, G: 255, B: 255, A: 150})
+	}
+}
+
+// drawCell draws c with an outline if any of it is in cam's view.
+func (g *Game) drawCell(
+	dst *ebiten.Image,
+	cam *game.Camera,
+	c *Cell,
+	outline float32,
+	outlineColor color.RGBA,
+) {
+	if !cam.IsRectVisible(c.X-c.Radius, c.Y-c.Radius, 2*c.Radius, 2*c.Radius) {
+		return
+	}
+
+	sx, sy := cam.WorldToScreen(c.X, c.Y)
+	r := float32(c.Radius * cam.Zoom)
+
+	vector.FillCircle(dst, float32(sx), float32(sy), r, c.Color, false)
+	vector.StrokeCircle(dst, float32(sx), float32(sy), r, outline, outlineColor, false)
+
+	if g.observer == nil {
+		ebitenutil.DebugPrintAt(dst, c.Name, int(sx)-len(c.Name)*3, int(sy)-5)
+	}
 }
 
 func formatInt(n int) string {
@@ -389,7 +314,10 @@ func main() {
 	ebiten.SetWindowTitle("Agar.io Clone")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "agar"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+	windowed := engine.WithWindow(engine.WithFocus(NewGame(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/net"
+)
+
+// netPresets are the link conditions N cycles through. Off plays locally;
+// the others hand the world to an in-process server and play as its client.
+var netPresets = []struct {
+	Name  string
+	Apply func(*net.NetworkDebug)
+}{
+	{"Off", nil},
+	{"Good", (*net.NetworkDebug).PresetGood},
+	{"Average", (*net.NetworkDebug).PresetAverage},
+	{"Poor", (*net.NetworkDebug).PresetPoor},
+	{"Terrible", (*net.NetworkDebug).PresetTerrible},
+}
+
+// authorityColor rings the server's position for the player in the debug
+// overlay.
+var authorityColor = color.NRGBA{R: 240, G: 200, B: 40, A: 220}
+
+// respawnRetry is how long, in seconds, a respawn request waits for the new
+// round before it is sent again, in case the link dropped it.
+const respawnRetry = 1.0
+
+// packet is an encoded message in flight.
+type packet struct {
+	arrive float64
+	data   []byte
+}
+
+// link carries messages one way with the latency, jitter, and loss of a
+// preset.
+type link struct {
+	cond     *net.NetworkDebug
+	inflight []packet
+}
+
+// send queues msg unless the link drops it and returns its encoded size.
+func (l *link) send(now float64, msg *net.Message) int {
+	data := net.Encode(msg)
+	if rand.Float64() < l.cond.PacketLoss {
+		return len(data)
+	}
+
+	latency := l.cond.LatencyMs
+	if l.cond.LatencyJitterMs > 0 {
+		latency += rand.Intn(l.cond.LatencyJitterMs)
+	}
+
+	l.inflight = append(l.inflight, packet{arrive: now + float64(latency)/1000, data: data})
+
+	return len(data)
+}
+
+// receive returns the messages that have arrived by now, in send order.
+func (l *link) receive(now float64) []*net.Message {
+	var arrived []*net.Message
+
+	kept := l.inflight[:0]
+
+	for _, p := range l.inflight {
+		if p.arrive > now {
+			kept = append(kept, p)
+
+			continue
+		}
+
+		if msg, err := net.Decode(p.data); err == nil {
+			arrived = append(arrived, msg)
+		}
+	}
+
+	l.inflight = kept
+
+	return arrived
+}
+
+// netView plays the game as a client of a server-authoritative world. The
+// server runs in process behind a simulated link in each direction; the
+// client sends only inputs, predicts its own cell, reconciles with each
+// snapshot, and draws the bots from an interpolation of the snapshots.
+type netView struct {
+	preset int
+	cond   net.NetworkDebug
+	up     link // Client to server
+	down   link // Server to client
+	server *server
+	clock  float64
+
+	lastTick    int64 // Newest snapshot applied
+	history     *net.StateHistory
+	predict     net.Prediction[Input]
+	interp      *net.Interpolation
+	authority   Cell    // The player as of the newest snapshot
+	respawnSent float64 // When the pending respawn request was sent, or 0
+
+	stats      *net.Stats
+	statsSince float64
+	upBps      float64
+	downBps    float64
+
+	debug bool // Draws raw against smoothed positions
+}
+
+// newNetView creates a view with the link off.
+func newNetView() *netView {
+	cfg := net.DefaultInterpConfig()
+	cfg.Delay = 0.15 // One snapshot interval plus room for jitter
+
+	v := &netView{
+		interp:  net.NewInterpolation(cfg),
+		history: net.NewStateHistory(32),
+		stats:   net.NewStats(),
+	}
+	v.up.cond, v.down.cond = &v.cond, &v.cond
+
+	return v
+}
+
+// active reports whether the game is played through the server.
+func (v *netView) active() bool {
+	return v.server != nil
+}
+
+// handleInput cycles the link preset with N, connecting to or leaving the
+// server as needed, and toggles the debug overlay with F3.
+func (v *netView) handleInput(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		v.preset = (v.preset + 1) % len(netPresets)
+
+		switch apply := netPresets[v.preset].Apply; {
+		case apply == nil:
+			v.disconnect(g)
+		case !v.active():
+			apply(&v.cond)
+			v.connect(g)
+		default:
+			apply(&v.cond)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		v.debug = !v.debug
+	}
+}
+
+// connect hands the game's world to a new server and joins it with a full
+// state, as a real client would on connecting.
+func (v *netView) connect(g *Game) {
+	v.server = newServer(g.World)
+	v.up.inflight = v.up.inflight[:0]
+	v.down.inflight = v.down.inflight[:0]
+	v.lastTick = 0
+	v.history.Reset()
+	v.predict.Reset()
+	v.interp.Reset()
+	v.respawnSent = 0
+
+	g.World, _ = decodeState(g.World.encodeState())
+	v.authority = *g.player
+}
+
+// disconnect takes the authoritative world back for local play.
+func (v *netView) disconnect(g *Game) {
+	if v.server != nil {
+		g.World = v.server.world
+		v.server = nil
+	}
+}
+
+// update runs one frame of the client and the server: the client sends this
+// frame's input and predicts its own movement, the server ticks, and
+// whatever has arrived at either end is handled.
+func (v *netView) update(g *Game, in Input) {
+	v.clock += 1.0 / serverTickRate
+
+	if !g.gameOver {
+		v.predict.Add(in)
+		g.steer(in)
+	}
+
+	v.sendInputs()
+
+	for _, msg := range v.up.receive(v.clock) {
+		v.server.receive(msg)
+	}
+
+	if snap := v.server.update(); snap != nil {
+		v.down.send(v.clock, snap)
+	}
+
+	for _, msg := range v.down.receive(v.clock) {
+		v.stats.RecordReceive(len(net.Encode(msg)))
+		v.apply(g, msg)
+	}
+
+	if elapsed := v.clock - v.statsSince; elapsed >= 1 {
+		v.upBps, v.downBps = v.stats.GetBandwidth(elapsed)
+		v.stats = net.NewStats()
+		v.statsSince = v.clock
+	}
+}
+
+// sendInputs sends the newest unacknowledged inputs along with the newest
+// snapshot tick, which the server deltas against. While the player is dead
+// it carries no inputs, only the acknowledgement.
+func (v *netView) sendInputs() {
+	pending := v.predict.Pending()
+	pending = pending[max(0, len(pending)-maxResentInputs):]
+
+	inputs := make([]Input, len(pending))
+	for i, p := range pending {
+		inputs[i] = p.Input
+	}
+
+	var seq uint32
+	if len(pending) > 0 {
+		seq = pending[len(pending)-1].Seq
+	}
+
+	msg := net.NewInputMessage(v.lastTick, 0, encodeInputs(inputs))
+	msg.Sequence = seq
+	v.stats.RecordSend(v.up.send(v.clock, msg))
+}
+
+// respawn asks the server for a new round once the player has been eaten,
+// asking again if no new round has arrived after respawnRetry seconds.
+func (v *netView) respawn() {
+	if v.respawnSent > 0 && v.clock-v.respawnSent < respawnRetry {
+		return
+	}
+
+	v.respawnSent = v.clock
+	v.stats.RecordSend(v.up.send(v.clock, net.NewRPCMessage("respawn", nil)))
+}
+
+// apply decodes a snapshot into the game's world, then reconciles the
+// predicted player: it starts from the server's position and replays the
+// inputs the server had not yet applied.
+func (v *netView) apply(g *Game, msg *net.Message) {
+	if msg.Tick <= v.lastTick {
+		return // Overtaken by a newer snapshot
+	}
+
+	state := msg.Payload
+
+	if msg.Type == net.MsgStateDelta {
+		if len(msg.Payload) < 8 {
+			return
+		}
+
+		base, ok := v.history.Get(int64(binary.LittleEndian.Uint64(msg.Payload)))
+		if !ok {
+			return
+		}
+
+		var err error
+		if state, err = net.ApplyDelta(base, msg.Payload[8:]); err != nil {
+			return
+		}
+	}
+
+	w, err := decodeState(state)
+	if err != nil {
+		return
+	}
+
+	v.history.Add(msg.Tick, state)
+	v.lastTick = msg.Tick
+
+	serverTime := float64(msg.Tick) / serverTickRate
+	v.interp.Receive(serverTime, v.clock)
+
+	seen := make(map[uint64]bool, len(w.aiCells))
+	for _, c := range w.aiCells {
+		seen[c.ID] = true
+		v.interp.Push(c.ID, net.Snapshot{Time: serverTime, X: c.X, Y: c.Y})
+	}
+
+	for _, id := range v.interp.IDs() {
+		if !seen[id] {
+			v.interp.Remove(id)
+		}
+	}
+
+	v.authority = *w.player
+	w.player.VX, w.player.VY = g.player.VX, g.player.VY
+
+	pending := v.predict.Ack(msg.Sequence)
+	if !w.gameOver {
+		for _, p := range pending {
+			w.steer(p.Input)
+		}
+
+		v.respawnSent = 0
+	}
+
+	g.World = w
+}
+
+// view returns the bot as the client sees it, or false if no snapshot of it
+// has arrived yet.
+func (v *netView) view(c *Cell) (Cell, bool) {
+	if !v.active() {
+		return *c, true
+	}
+
+	x, y, ok := v.interp.Position(c.ID, v.clock)
+	if !ok {
+		return Cell{}, false
+	}
+
+	seen := *c
+	seen.X, seen.Y = x, y
+
+	return seen, true
+}
+
+// draw shows the link status and, if enabled, the debug overlay: the
+// interpolation's raw and smoothed bots, and the server's position for the
+// player as a yellow ring around the predicted one.
+func (v *netView) draw(screen *ebiten.Image, cam *game.Camera) {
+	if !v.active() {
+		ebitenutil.DebugPrintAt(screen, "Net: Off (N to play through a server)", 10, 70)
+
+		return
+	}
+
+	link := fmt.Sprintf("Net: %s %dms +%dms jitter %.0f%% loss (N cycle, F3 debug)",
+		netPresets[v.preset].Name, v.cond.LatencyMs, v.cond.LatencyJitterMs, v.cond.PacketLoss*100)
+	traffic := fmt.Sprintf("Server-authoritative: %.1f KB/s down, %.1f KB/s up, %d unacked inputs",
+		v.downBps/1024, v.upBps/1024, v.predict.Len())
+
+	ebitenutil.DebugPrintAt(screen, link, 10, 70)
+	ebitenutil.DebugPrintAt(screen, traffic, 10, 90)
+
+	if !v.debug {
+		return
+	}
+
+	v.interp.DrawDebug(screen, v.clock, cam.WorldToScreen)
+
+	sx, sy := cam.WorldToScreen(v.authority.X, v.authority.Y)
+	r := float32(v.authority.Radius * cam.Zoom)
+	vector.StrokeCircle(screen, float32(sx), float32(sy), r, 2, authorityColor, false)
+}
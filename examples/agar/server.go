@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/binary"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/net"
+)
+
+const (
+	serverTickRate = 60 // Simulation steps per second
+	snapshotEvery  = 6  // Ticks between snapshots: 10 a second
+)
+
+// server owns the authoritative world for one client and runs it headlessly.
+// The client sends only inputs; each tick the server applies those that have
+// arrived, steps the world, and every few ticks sends a snapshot
+// delta-compressed against the newest one the client has acknowledged.
+type server struct {
+	world    *World
+	tick     int64
+	inputs   []Input // Arrived since the last tick, oldest first
+	ackInput uint32  // Sequence of the newest input applied
+	ackState int64   // Tick of the newest snapshot the client holds
+	history  *net.StateHistory
+}
+
+// newServer takes over w as the authoritative world.
+func newServer(w *World) *server {
+	return &server{world: w, history: net.NewStateHistory(32)}
+}
+
+// receive handles a message from the client.
+func (s *server) receive(msg *net.Message) {
+	switch msg.Type {
+	case net.MsgInput:
+		s.ackState = max(s.ackState, msg.Tick)
+
+		// Sequences start at 1, so a message cannot carry more inputs than
+		// its sequence; one that does is corrupt and would wrap first below
+		inputs, err := decodeInputs(msg.Payload)
+		if err != nil || uint32(len(inputs)) > msg.Sequence {
+			return
+		}
+
+		// The message repeats recent inputs; skip those already applied
+		first := msg.Sequence - uint32(len(inputs)) + 1
+		for i, in := range inputs {
+			if first+uint32(i) > s.ackInput {
+				s.inputs = append(s.inputs, in)
+			}
+		}
+
+		s.ackInput = max(s.ackInput, msg.Sequence)
+	case net.MsgRPC:
+		if method, _, err := net.ParseRPC(msg); err == nil && method == "respawn" && s.world.gameOver {
+			s.world.reset()
+		}
+	default:
+	}
+}
+
+// update runs one tick and returns a snapshot if one is due, else nil.
+func (s *server) update() *net.Message {
+	s.tick++
+
+	if s.world.gameOver {
+		s.inputs = s.inputs[:0]
+		s.world.updateAI()
+	} else {
+		for _, in := range s.inputs {
+			s.world.steer(in)
+		}
+
+		s.inputs = s.inputs[:0]
+		s.world.step()
+	}
+
+	if s.tick%snapshotEvery != 0 {
+		return nil
+	}
+
+	return s.snapshot()
+}
+
+// snapshot encodes the world, as a delta if the client's acknowledged
+// snapshot is still in the history and in full otherwise. Its Sequence tells
+// the client which of its inputs the state includes.
+func (s *server) snapshot() *net.Message {
+	state := s.world.encodeState()
+	msg := &net.Message{Type: net.MsgStateUpdate, Tick: s.tick, Sequence: s.ackInput, Payload: state}
+
+	if base, ok := s.history.Get(s.ackState); ok {
+		msg.Type = net.MsgStateDelta
+		msg.Payload = binary.LittleEndian.AppendUint64(nil, uint64(s.ackState))
+		msg.Payload = append(msg.Payload, net.EncodeDelta(base, state)...)
+	}
+
+	s.history.Add(s.tick, state)
+
+	return msg
+}
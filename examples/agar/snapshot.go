@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"image/color"
+	"math"
+)
+
+// Wire formats for the server-authoritative mode. Everything is little
+// endian; positions are float32, which is plenty for a 2000 unit world.
+
+// maxResentInputs is how many of the newest unacknowledged inputs each input
+// message carries, so one lost packet does not lose a frame of movement.
+const maxResentInputs = 3
+
+var errTruncated = errors.New("truncated message")
+
+// encodeState serializes everything a client draws: the score, the player,
+// the bots, and the food.
+func (w *World) encodeState() []byte {
+	b := make([]byte, 0, 64+len(w.aiCells)*32+len(w.foods)*11)
+	b = binary.LittleEndian.AppendUint32(b, uint32(w.score))
+
+	if w.gameOver {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+
+	b = appendCell(b, w.player)
+
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(w.aiCells)))
+	for _, c := range w.aiCells {
+		b = appendCell(b, c)
+	}
+
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(w.foods)))
+	for _, f := range w.foods {
+		b = appendFloat(b, f.X)
+		b = appendFloat(b, f.Y)
+		b = append(b, f.Color.R, f.Color.G, f.Color.B)
+	}
+
+	return b
+}
+
+func appendCell(b []byte, c *Cell) []byte {
+	b = binary.LittleEndian.AppendUint64(b, c.ID)
+	b = appendFloat(b, c.X)
+	b = appendFloat(b, c.Y)
+	b = appendFloat(b, c.Radius)
+	b = append(b, c.Color.R, c.Color.G, c.Color.B, byte(len(c.Name)))
+
+	return append(b, c.Name...)
+}
+
+func appendFloat(b []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v)))
+}
+
+// decodeState builds a client's copy of the world from a state.
+func decodeState(data []byte) (*World, error) {
+	r := reader{b: data}
+	w := &World{score: int(r.uint32()), gameOver: r.byte() == 1}
+	w.player = r.cell()
+
+	w.aiCells = make([]*Cell, r.uint16())
+	for i := range w.aiCells {
+		w.aiCells[i] = r.cell()
+		w.aiCells[i].IsAI = true
+	}
+
+	w.foods = make([]*Food, r.uint16())
+	for i := range w.foods {
+		w.foods[i] = &Food{X: r.float(), Y: r.float(), Color: r.color()}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return w, nil
+}
+
+// encodeInputs packs inputs, oldest first, for an input message whose
+// Sequence is that of the newest.
+func encodeInputs(inputs []Input) []byte {
+	b := make([]byte, 0, 1+len(inputs)*8)
+	b = append(b, byte(len(inputs)))
+
+	for _, in := range inputs {
+		b = appendFloat(b, in.DX)
+		b = appendFloat(b, in.DY)
+	}
+
+	return b
+}
+
+// decodeInputs unpacks an input message's payload.
+func decodeInputs(data []byte) ([]Input, error) {
+	r := reader{b: data}
+	inputs := make([]Input, r.byte())
+
+	for i := range inputs {
+		inputs[i] = Input{DX: r.float(), DY: r.float()}
+	}
+
+	return inputs, r.err
+}
+
+// reader reads the wire format, remembering the first error so callers can
+// check once at the end.
+type reader struct {
+	b   []byte
+	err error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil || len(r.b) < n {
+		r.err = errTruncated
+
+		return make([]byte, n)
+	}
+
+	b := r.b[:n]
+	r.b = r.b[n:]
+
+	return b
+}
+
+func (r *reader) byte() byte {
+	return r.next(1)[0]
+}
+
+func (r *reader) uint16() uint16 {
+	return binary.LittleEndian.Uint16(r.next(2))
+}
+
+func (r *reader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.next(4))
+}
+
+func (r *reader) float() float64 {
+	return float64(math.Float32frombits(r.uint32()))
+}
+
+func (r *reader) color() color.RGBA {
+	b := r.next(3)
+
+	return color.RGBA{R: b[0], G: b[1], B: b[2], A: 255}
+}
+
+func (r *reader) cell() *Cell {
+	c := &Cell{ID: binary.LittleEndian.Uint64(r.next(8))}
+	c.X, c.Y, c.Radius = r.float(), r.float(), r.float()
+	c.Color = r.color()
+	c.Name = string(r.next(int(r.byte())))
+
+	return c
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// World is the agar simulation: the player, the bots, and the food. It has no
+// rendering or input of its own, so the demo server can run it headlessly.
+type World struct {
+	player   *Cell
+	aiCells  []*Cell
+	foods    []*Food
+	score    int
+	gameOver bool
+	nextID   uint64
+}
+
+// Input steers the player: the cursor's offset from the screen center.
+type Input struct {
+	DX, DY float64
+}
+
+// newWorld creates a world with a fresh round.
+func newWorld() *World {
+	w := &World{
+		foods:   make([]*Food, 0),
+		aiCells: make([]*Cell, 0),
+	}
+	w.reset()
+
+	return w
+}
+
+// reset starts a new round in place, reusing the food and cell slices.
+func (w *World) reset() {
+	w.nextID++
+	w.player = &Cell{
+		ID:     w.nextID,
+		X:      float64(worldSize) / 2,
+		Y:      float64(worldSize) / 2,
+		Radius: 20,
+		Color:  color.RGBA{R: 50, G: 150, B: 255, A: 255},
+		Name:   "Player",
+	}
+
+	clear(w.foods)
+	w.foods = w.foods[:0]
+	clear(w.aiCells)
+	w.aiCells = w.aiCells[:0]
+	w.score = 0
+	w.gameOver = false
+
+	// Spawn initial food
+	for range 200 {
+		w.foods = append(w.foods, randomFood())
+	}
+
+	// Spawn AI cells
+	for range 10 {
+		w.spawnAI()
+	}
+}
+
+func randomFood() *Food {
+	colors := []color.RGBA{
+		{R: 255, G: 100, B: 100, A: 255},
+		{R: 100, G: 255, B: 100, A: 255},
+		{R: 100, G: 100, B: 255, A: 255},
+		{R: 255, G: 255, B: 100, A: 255},
+		{R: 255, G: 100, B: 255, A: 255},
+		{R: 100, G: 255, B: 255, A: 255},
+	}
+
+	return &Food{
+		X:     rand.Float64() * worldSize,
+		Y:     rand.Float64() * worldSize,
+		Color: colors[rand.Intn(len(colors))],
+	}
+}
+
+func (w *World) spawnAI() {
+	colors := []color.RGBA{
+		{R: 200, G: 50, B: 50, A: 255},
+		{R: 50, G: 200, B: 50, A: 255},
+		{R: 200, G: 200, B: 50, A: 255},
+		{R: 200, G: 50, B: 200, A: 255},
+		{R: 50, G: 200, B: 200, A: 255},
+	}
+	names := []string{"Bot1", "Bot2", "Bot3", "Bot4", "Bot5", "Bot6", "Bot7", "Bot8"}
+
+	w.nextID++
+	w.aiCells = append(w.aiCells, &Cell{
+		ID:     w.nextID,
+		X:      rand.Float64() * worldSize,
+		Y:      rand.Float64() * worldSize,
+		Radius: 15 + rand.Float64()*30,
+		Color:  colors[rand.Intn(len(colors))],
+		IsAI:   true,
+		Name:   names[rand.Intn(len(names))],
+	})
+}
+
+// steer moves the player one frame toward the input direction. It is the only
+// part of the simulation a networked client predicts.
+func (w *World) steer(in Input) {
+	// Normalize and apply speed (smaller = faster)
+	dist := math.Sqrt(in.DX*in.DX + in.DY*in.DY)
+	if dist > 0 {
+		speed := 5.0 / (1 + w.player.Radius/50)
+		w.player.VX = (in.DX / dist) * speed
+		w.player.VY = (in.DY / dist) * speed
+	}
+
+	// Move player
+	w.player.X += w.player.VX
+	w.player.Y += w.player.VY
+
+	// Keep in world bounds
+	w.player.X = clamp(w.player.X, w.player.Radius, worldSize-w.player.Radius)
+	w.player.Y = clamp(w.player.Y, w.player.Radius, worldSize-w.player.Radius)
+}
+
+// step runs one frame of everything but the player's movement: eating, the
+// bots, and whether the player was eaten.
+func (w *World) step() {
+	// Eat food; eaten food respawns in the same slot, which keeps snapshot
+	// deltas small
+	for i, food := range w.foods {
+		dist := math.Sqrt((w.player.X-food.X)*(w.player.X-food.X) + (w.player.Y-food.Y)*(w.player.Y-food.Y))
+		if dist < w.player.Radius {
+			w.player.Radius += 0.5
+			w.score += 10
+			w.foods[i] = randomFood()
+		}
+	}
+
+	w.updateAI()
+
+	// Player eats AI or gets eaten
+	for i := len(w.aiCells) - 1; i >= 0; i-- {
+		ai := w.aiCells[i]
+		dist := math.Sqrt((w.player.X-ai.X)*(w.player.X-ai.X) + (w.player.Y-ai.Y)*(w.player.Y-ai.Y))
+
+		if w.player.Radius > ai.Radius*1.1 && dist < w.player.Radius {
+			// Player eats AI
+			w.player.Radius += ai.Radius * 0.3
+			w.score += int(ai.Radius * 10)
+			w.aiCells = append(w.aiCells[:i], w.aiCells[i+1:]...)
+			w.spawnAI()
+		} else if ai.Radius > w.player.Radius*1.1 && dist < ai.Radius {
+			// AI eats player
+			w.gameOver = true
+		}
+	}
+}
+
+// updateAI moves the bots toward the nearest food and lets them eat it.
+func (w *World) updateAI() {
+	for _, ai := range w.aiCells {
+		// Find nearest food or smaller cell
+		var targetX, targetY float64
+
+		minDist := math.MaxFloat64
+
+		for _, food := range w.foods {
+			dist := math.Sqrt((ai.X-food.X)*(ai.X-food.X) + (ai.Y-food.Y)*(ai.Y-food.Y))
+			if dist < minDist {
+				minDist = dist
+				targetX, targetY = food.X, food.Y
+			}
+		}
+
+		// Move toward target
+		if minDist < math.MaxFloat64 {
+			dx := targetX - ai.X
+			dy := targetY - ai.Y
+
+			dist := math.Sqrt(dx*dx + dy*dy)
+			if dist > 0 {
+				speed := 3.0 / (1 + ai.Radius/50)
+				ai.X += (dx / dist) * speed
+				ai.Y += (dy / dist) * speed
+			}
+		}
+
+		// Keep in bounds
+		ai.X = clamp(ai.X, ai.Radius, worldSize-ai.Radius)
+		ai.Y = clamp(ai.Y, ai.Radius, worldSize-ai.Radius)
+
+		// AI eats food
+		for i, food := range w.foods {
+			dist := math.Sqrt((ai.X-food.X)*(ai.X-food.X) + (ai.Y-food.Y)*(ai.Y-food.Y))
+			if dist < ai.Radius {
+				ai.Radius += 0.3
+				w.foods[i] = randomFood()
+			}
+		}
+	}
+}
+
+func clamp(v, minVal, maxVal float64) float64 {
+	if v < minVal {
+		return minVal
+	}
+
+	if v > maxVal {
+		return maxVal
+	}
+
+	return v
+}
@@ -9,11 +9,18 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
 	screenWidth  = 600
 	screenHeight = 500
+	panelHeight  = 80
+	betStep      = 50
 )
 
 // Card represents a playing card.
@@ -86,18 +93,56 @@ type Game struct {
 	message    string
 	wins       int
 	losses     int
+
+	// Bottom panel and the buttons of the betting, playing, and result states
+	panel         *ui.Panel
+	betButtons    *ui.ButtonGroup
+	playButtons   *ui.ButtonGroup
+	resultButtons *ui.ButtonGroup
+	controls      *input.Map
 }
 
 // NewGame creates a new game.
 func NewGame() *Game {
 	g := &Game{
-		chips: 1000,
-		bet:   100,
+		chips:    1000,
+		bet:      100,
+		panel:    ui.NewPanel(0, screenHeight-panelHeight, screenWidth, panelHeight, ""),
+		controls: input.NewMap(),
 	}
 
+	g.betButtons = ui.NewButtonGroup(
+		actionButton(0, "- $50", "Lower the bet (DOWN)", g.lowerBet),
+		actionButton(1, "+ $50", "Raise the bet (UP)", g.raiseBet),
+		actionButton(2, "Deal", "Place the bet and deal", g.startRound),
+	)
+	g.betButtons.SetFocus(2)
+	g.playButtons = ui.NewButtonGroup(
+		actionButton(0, "Hit (H)", "Take another card", g.hit),
+		actionButton(1, "Stand (S)", "Keep your hand;\nthe dealer draws to 17", g.stand),
+	)
+	g.resultButtons = ui.NewButtonGroup(actionButton(2, "Continue", "", g.nextRound))
+
 	return g
 }
 
+// actionButton creates a button in one of the panel's three button slots.
+func actionButton(slot int, label, tooltip string, onClick func()) *ui.Button {
+	b := ui.NewButton(float64(280+slot*100), screenHeight-45, 90, 28, label)
+	b.Tooltip = tooltip
+	b.OnClick = onClick
+
+	return b
+}
+
+func (g *Game) raiseBet() {
+	g.bet = min(g.bet+betStep, max(g.chips, betStep))
+}
+
+func (g *Game) lowerBet() {
+	g.bet = max(g.bet-betStep, betStep)
+}
+
 func (g *Game) shuffleDeck() {
 	g.deck = make([]Card, 0, 52)
 
@@ -202,45 +247,60 @@ func (g *Game) endRound(msg string) {
 	g.gameState = 3
 }
 
+// nextRound returns to betting, with fresh chips after going broke.
+func (g *Game) nextRound() {
+	if g.chips <= 0 {
+		g.chips = 1000
+		g.wins = 0
+		g.losses = 0
+	}
+
+	g.bet = min(g.bet, g.chips)
+	g.message = ""
+	g.gameState = 0
+}
+
+// buttons returns the buttons of the current state.
+func (g *Game) buttons() *ui.ButtonGroup {
+	switch g.gameState {
+	case 0:
+		return g.betButtons
+	case 1:
+		return g.playButtons
+	case 3:
+		return g.resultButtons
+	}
+
+	return nil
+}
+
 func (g *Game) Update() error {
 	switch g.gameState {
 	case 0: // Betting
 		if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
-			g.bet += 50
-			if g.bet > g.chips {
-				g.bet = g.chips
-			}
+			g.raiseBet()
 		}
 
 		if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
-			g.bet -= 50
-			if g.bet < 50 {
-				g.bet = 50
-			}
-		}
-
-		if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-			g.startRound()
+			g.lowerBet()
 		}
 	case 1: // Playing
 		if inpututil.IsKeyJustPressed(ebiten.KeyH) {
 			g.hit()
+
+			return nil
 		}
 
 		if inpututil.IsKeyJustPressed(ebiten.KeyS) {
 			g.stand()
+
+			return nil
 		}
-	case 3: // Result
-		if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-			if g.chips > 0 {
-				g.gameState = 0
-			} else {
-				g.chips = 1000
-				g.wins = 0
-				g.losses = 0
-				g.gameState = 0
-			}
-		}
+	}
+
+	// Enter, Space, or pad A press the focused button; LEFT/RIGHT move focus
+	if buttons := g.buttons(); buttons != nil {
+		buttons.Update(g.controls)
 	}
 
 	return nil
@@ -291,29 +351,19 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	}
 
 	// UI panel
-	vector.FillRect(
-		screen,
-		0,
-		screenHeight-80,
-		screenWidth,
-		80,
-		color.RGBA{R: 40, G: 30, B: 20, A: 255},
-		false,
-	)
+	g.panel.Draw(screen)
 
 	// Chips and bet
-	ebitenutil.DebugPrintAt(screen, "Chips: $"+formatInt(g.chips), 20, screenHeight-65)
-	ebitenutil.DebugPrintAt(screen, "Bet: $"+formatInt(g.bet), 20, screenHeight-45)
-	ebitenutil.DebugPrintAt(screen, "W: "+formatInt(g.wins)+" L: "+formatInt(g.losses), 20, screenHeight-25)
+	ebitenutil.DebugPrintAt(screen, "Chips: $"+formatInt(g.chips), 20, screenHeight-70)
+	ebitenutil.DebugPrintAt(screen, "Bet: $"+formatInt(g.bet), 20, screenHeight-50)
+	ebitenutil.DebugPrintAt(screen, "W: "+formatInt(g.wins)+" L: "+formatInt(g.losses), 20, screenHeight-30)
 
-	// Controls
-	switch g.gameState {
-	case 0:
-		ebitenutil.DebugPrintAt(screen, "UP/DOWN = Adjust Bet | SPACE = Deal", 200, screenHeight-45)
-	case 1:
-		ebitenutil.DebugPrintAt(screen, "H = Hit | S = Stand", 250, screenHeight-45)
-	case 3:
-		ebitenutil.DebugPrintAt(screen, g.message+" | SPACE = Continue", 180, screenHeight-45)
+	if g.message != "" {
+		ebitenutil.DebugPrintAt(screen, g.message, (screenWidth-len(g.message)*6)/2, screenHeight-panelHeight-30)
+	}
+
+	if buttons := g.buttons(); buttons != nil {
+		buttons.Draw(screen)
 	}
 }
 
@@ -401,7 +451,10 @@ func main() {
 	ebiten.SetWindowTitle("Blackjack")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "blackjack"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+	windowed := engine.WithWindow(engine.WithFocus(NewGame(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
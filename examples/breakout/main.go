@@ -11,6 +11,11 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combo"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -28,6 +33,21 @@ const (
 	brickOffsetY = 60
 )
 
+// comboConfig builds the brick-breaking combo: each tier also widens the
+// paddle by comboPaddleBonus.
+var comboConfig = combo.Config{
+	Window: 2,
+	Decay:  0,
+	Tiers: []combo.Tier{
+		{Kills: 3, Multiplier: 1.5, Name: "Nice"},
+		{Kills: 6, Multiplier: 2, Name: "Great"},
+		{Kills: 10, Multiplier: 3, Name: "Blazing"},
+		{Kills: 15, Multiplier: 4, Name: "Unstoppable"},
+	},
+}
+
+const comboPaddleBonus = 8
+
 type GameState int
 
 const (
@@ -84,8 +104,8 @@ type Breakout struct {
 	lives      int
 	state      GameState
 	launched   bool
-	combo      int
-	comboTimer float64
+	paused     bool
+	combo      *combo.Meter
 	level      int
 	titlePulse float64
 	hitFlash   float64
@@ -101,6 +121,7 @@ func NewBreakout() *Breakout {
 			Height: paddleHeight,
 		},
 		ball:  &Ball{Size: ballSize},
+		combo: combo.NewMeter(comboConfig),
 		lives: 3,
 		level: 1,
 		state: StateTitle,
@@ -113,12 +134,20 @@ func (b *Breakout) startGame() {
 	b.score = 0
 	b.lives = 3
 	b.level = 1
-	b.combo = 0
+	b.combo.Reset()
 	b.createBricks()
 	b.resetBall()
+	b.paused = false
 	b.state = StatePlaying
 }
 
+// Pause pauses a game in progress, for engine.FocusConfig.AutoPause.
+func (b *Breakout) Pause() {
+	if b.state == StatePlaying {
+		b.paused = true
+	}
+}
+
 func (b *Breakout) resetBall() {
 	b.ball.X = float64(screenWidth)/2 - ballSize/2
 	b.ball.Y = b.paddle.Y - ballSize - 5
@@ -181,13 +210,19 @@ func (b *Breakout) addPopup(x, y float64, text string, clr color.RGBA) {
 }
 
 func (b *Breakout) Update() error {
+	if b.state == StatePlaying && (inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyP)) {
+		b.paused = !b.paused
+	}
+
+	if b.paused {
+		return nil
+	}
+
 	dt := 1.0 / 60.0
 	b.titlePulse += dt * 2
 
-	b.comboTimer -= dt
-	if b.comboTimer < 0 {
-		b.combo = 0
-	}
+	b.combo.Update(dt)
 
 	if b.hitFlash > 0 {
 		b.hitFlash -= dt * 5
@@ -233,6 +268,7 @@ func (b *Breakout) Update() error {
 
 	case StatePlaying:
 		mx, _ := ebiten.CursorPosition()
+		b.paddle.Width = paddleWidth + comboPaddleBonus*float64(b.combo.Tier()+1)
 		b.paddle.X = clamp(float64(mx)-b.paddle.Width/2, 0, float64(screenWidth)-b.paddle.Width)
 
 		if !b.launched {
@@ -274,7 +310,7 @@ func (b *Breakout) Update() error {
 		if b.ball.Y > float64(screenHeight) {
 			b.lives--
 
-			b.combo = 0
+			b.combo.Break()
 			if b.lives <= 0 {
 				if b.score > b.highscore {
 					b.highscore = b.score
@@ -309,15 +345,14 @@ func (b *Breakout) Update() error {
 			if b.ball.X+b.ball.Size >= brick.X && b.ball.X <= brick.X+brick.Width &&
 				b.ball.Y+b.ball.Size >= brick.Y && b.ball.Y <= brick.Y+brick.Height {
 				brick.Alive = false
-				b.combo++
-				b.comboTimer = 2.0
-				points := brick.Points * b.combo
+				b.combo.Kill()
+				points := b.combo.Apply(brick.Points)
 				b.score += points
 				b.spawnBrickParticles(brick)
 
 				popText := fmt.Sprintf("+%d", points)
-				if b.combo > 1 {
-					popText = fmt.Sprintf("+%d x%d", points, b.combo)
+				if b.combo.Tier() >= 0 {
+					popText = fmt.Sprintf("+%d x%.4g", points, b.combo.Multiplier())
 				}
 
 				b.addPopup(brick.X+brick.Width/2, brick.Y, popText, brick.Color)
@@ -384,6 +419,10 @@ func (b *Breakout) Draw(screen *ebiten.Image) {
 		b.drawTitle(screen)
 	case StatePlaying:
 		b.drawGame(screen)
+
+		if b.paused {
+			ui.DrawPaused(screen, "ESC or P to resume")
+		}
 	case StateGameOver:
 		b.drawGame(screen)
 		b.drawOverlay(screen, "GAME OVER", color.RGBA{R: 255, G: 80, B: 80, A: 255})
@@ -551,19 +590,7 @@ func (b *Breakout) drawGame(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("LVL %d", b.level), screenWidth-60, 12)
 
 	// Combo
-	if b.combo > 1 && b.comboTimer > 0 {
-		comboAlpha := uint8(min(int(b.comboTimer*127+128), 255))
-		vector.FillRect(
-			screen,
-			screenWidth/2-50,
-			45,
-			100,
-			25,
-			color.RGBA{R: 255, G: 200, B: 50, A: comboAlpha / 2},
-			false,
-		)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("COMBO x%d!", b.combo), screenWidth/2-35, 50)
-	}
+	b.combo.Draw(screen, screenWidth/2-70, 44, 140)
 
 	// Popups
 	for _, pop := range b.popups {
@@ -615,7 +642,15 @@ func main() {
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetCursorMode(ebiten.CursorModeHidden)
 
-	if err := ebiten.RunGame(NewBreakout()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "breakout"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	g := NewBreakout()
+	onTitle := func() any { return g.state == StateTitle }
+	scenes := engine.WithTransitions(g, onTitle, engine.NewPixelateTransition(0.5))
+
+	windowed := engine.WithWindow(engine.WithFocus(scenes, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
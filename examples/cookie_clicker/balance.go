@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// BalanceConfig describes the simulated player for SimulateEconomy.
+type BalanceConfig struct {
+	ClickRate  float64 // Cookie clicks per second
+	Multiplier float64 // Production multiplier, such as a prestige bonus; 0 means 1
+	Horizon    float64 // Seconds of play to simulate
+	MaxStall   float64 // Longest acceptable wait between purchases, in seconds
+}
+
+// Purchase is one upgrade bought during a simulation.
+type Purchase struct {
+	Time    float64 // Seconds into the run
+	Upgrade string
+	Owned   int // Copies owned after the purchase
+	Cost    float64
+	Wait    float64 // Seconds since the previous purchase
+	CPS     float64 // Passive income after the purchase, multiplier included
+
+	// Seconds until the next copy of each upgrade is affordable, in shop
+	// order, with the cookies left after the purchase
+	TimeToAfford []float64
+}
+
+// BalanceReport is the result of SimulateEconomy.
+type BalanceReport struct {
+	Config    BalanceConfig
+	Upgrades  []string // Shop order
+	Purchases []Purchase
+}
+
+// SimulateEconomy plays the shop with a player who clicks at a steady rate
+// and always saves for the upgrade that pays for itself soonest: the least
+// time to afford it plus the time its production takes to repay its cost.
+// Time jumps from purchase to purchase, so hours of play simulate exactly
+// and instantly. The run ends with the first purchase past the horizon, so
+// the wait for it is checked too.
+func SimulateEconomy(cfg BalanceConfig) *BalanceReport {
+	mult := cfg.Multiplier
+	if mult == 0 {
+		mult = 1
+	}
+
+	upgrades := newUpgrades()
+	report := &BalanceReport{Config: cfg}
+
+	for _, u := range upgrades {
+		report.Upgrades = append(report.Upgrades, u.Name)
+	}
+
+	var now, last, cookies, cps float64
+
+	for now <= cfg.Horizon {
+		income := cps + cfg.ClickRate
+
+		best, bestScore := -1, math.Inf(1)
+
+		for i, u := range upgrades {
+			score := timeToAfford(u.Cost(), cookies, income) + u.Cost()/(u.CPS*mult)
+			if score < bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		if best < 0 || income <= 0 {
+			break
+		}
+
+		u := upgrades[best]
+		cost := u.Cost()
+		wait := timeToAfford(cost, cookies, income)
+
+		now += wait
+		cookies += wait*income - cost
+		cps += u.CPS * mult
+		u.Owned++
+
+		p := Purchase{
+			Time:    now,
+			Upgrade: u.Name,
+			Owned:   u.Owned,
+			Cost:    cost,
+			Wait:    now - last,
+			CPS:     cps,
+		}
+
+		for _, other := range upgrades {
+			p.TimeToAfford = append(p.TimeToAfford, timeToAfford(other.Cost(), cookies, cps+cfg.ClickRate))
+		}
+
+		report.Purchases = append(report.Purchases, p)
+		last = now
+	}
+
+	return report
+}
+
+// timeToAfford returns the seconds until cookies reaches cost at income per second.
+func timeToAfford(cost, cookies, income float64) float64 {
+	if cookies >= cost {
+		return 0
+	}
+
+	return (cost - cookies) / income
+}
+
+// Stalls returns the purchases that took longer than the configured
+// MaxStall to save for.
+func (r *BalanceReport) Stalls() []Purchase {
+	var stalls []Purchase
+
+	for _, p := range r.Purchases {
+		if p.Wait > r.Config.MaxStall {
+			stalls = append(stalls, p)
+		}
+	}
+
+	return stalls
+}
+
+// LongestWait returns the longest time spent saving for one purchase.
+func (r *BalanceReport) LongestWait() float64 {
+	longest := 0.0
+	for _, p := range r.Purchases {
+		longest = max(longest, p.Wait)
+	}
+
+	return longest
+}
+
+// WriteCSV writes one row per purchase: when it happened, what was bought,
+// the wait for it, the income after it, and the time-to-afford curve of
+// every upgrade as one column each.
+func (r *BalanceReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"time_s", "upgrade", "owned", "cost", "wait_s", "cps"}
+	for _, name := range r.Upgrades {
+		header = append(header, "afford_"+name+"_s")
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, p := range r.Purchases {
+		row := []string{
+			formatFloat(p.Time),
+			p.Upgrade,
+			strconv.Itoa(p.Owned),
+			formatFloat(p.Cost),
+			formatFloat(p.Wait),
+			formatFloat(p.CPS),
+		}
+
+		for _, t := range p.TimeToAfford {
+			row = append(row, formatFloat(t))
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 1, 64)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"testing"
+)
+
+// balanceConfig is the pacing the shop is tuned for: a casual two clicks a
+// second, and never more than fifteen minutes of saving for the next purchase
+// during the first four hours.
+var balanceConfig = BalanceConfig{
+	ClickRate: 2,
+	Horizon:   4 * 60 * 60,
+	MaxStall:  15 * 60,
+}
+
+// TestEconomyNeverStalls simulates the shop and fails if any purchase takes
+// longer than the configured stall threshold. The CSV balance report is
+// written to the file named by $BALANCE_REPORT, or logged with -v.
+func TestEconomyNeverStalls(t *testing.T) {
+	report := SimulateEconomy(balanceConfig)
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if path := os.Getenv("BALANCE_REPORT"); path != "" {
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		t.Log("\n" + buf.String())
+	}
+
+	for _, p := range report.Stalls() {
+		t.Errorf("%s #%d at %.0fs took %.0fs to afford, over the %.0fs limit",
+			p.Upgrade, p.Owned, p.Time, p.Wait, balanceConfig.MaxStall)
+	}
+
+	t.Logf("%d purchases, longest wait %.0fs", len(report.Purchases), report.LongestWait())
+}
+
+func TestEconomyBuysEveryUpgrade(t *testing.T) {
+	report := SimulateEconomy(balanceConfig)
+
+	owned := map[string]int{}
+	for _, p := range report.Purchases {
+		owned[p.Upgrade] = p.Owned
+	}
+
+	for _, name := range report.Upgrades {
+		if owned[name] == 0 {
+			t.Errorf("%s never bought within %.0fs", name, balanceConfig.Horizon)
+		}
+	}
+}
+
+func TestEconomyMultiplierSpeedsProgress(t *testing.T) {
+	boosted := balanceConfig
+	boosted.Multiplier = 2
+
+	base, fast := SimulateEconomy(balanceConfig), SimulateEconomy(boosted)
+	if len(fast.Purchases) <= len(base.Purchases) {
+		t.Errorf("2x production made %d purchases, base %d", len(fast.Purchases), len(base.Purchases))
+	}
+}
+
+func TestBalanceReportCSV(t *testing.T) {
+	report := SimulateEconomy(BalanceConfig{ClickRate: 1, Horizon: 60, MaxStall: 60})
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != len(report.Purchases)+1 {
+		t.Fatalf("%d rows, want a header and %d purchases", len(rows), len(report.Purchases))
+	}
+
+	if want := 6 + len(report.Upgrades); len(rows[0]) != want {
+		t.Errorf("header has %d columns, want %d", len(rows[0]), want)
+	}
+
+	// The first purchase is a Cursor: 15 cookies at one click a second
+	if first := rows[1]; first[1] != "Cursor" || first[0] != "15.0" {
+		t.Errorf("first purchase = %v, want a Cursor at 15s", first)
+	}
+}
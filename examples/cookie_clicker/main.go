@@ -5,11 +5,15 @@ import (
 	"image/color"
 	"log"
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
@@ -37,6 +41,10 @@ type Game struct {
 	// Animation
 	cookieScale  float64
 	clickEffects []ClickEffect
+
+	// Offline earnings banner
+	awayEarned float64
+	awayTimer  float64
 }
 
 // ClickEffect represents a floating +1 effect.
@@ -53,14 +61,19 @@ func NewGame() *Game {
 		cookies:     0,
 		clickPower:  1,
 		cookieScale: 1.0,
-		upgrades: []*Upgrade{
-			{Name: "Cursor", BaseCost: 15, CPS: 0.1},
-			{Name: "Grandma", BaseCost: 100, CPS: 1},
-			{Name: "Farm", BaseCost: 1100, CPS: 8},
-			{Name: "Mine", BaseCost: 12000, CPS: 47},
-			{Name: "Factory", BaseCost: 130000, CPS: 260},
-			{Name: "Bank", BaseCost: 1400000, CPS: 1400},
-		},
+		upgrades:    newUpgrades(),
+	}
+}
+
+// newUpgrades returns the upgrade shop with nothing owned.
+func newUpgrades() []*Upgrade {
+	return []*Upgrade{
+		{Name: "Cursor", BaseCost: 15, CPS: 0.1},
+		{Name: "Grandma", BaseCost: 100, CPS: 1},
+		{Name: "Farm", BaseCost: 1100, CPS: 8},
+		{Name: "Mine", BaseCost: 12000, CPS: 47},
+		{Name: "Factory", BaseCost: 130000, CPS: 260},
+		{Name: "Bank", BaseCost: 1400000, CPS: 1400},
 	}
 }
 
@@ -68,17 +81,33 @@ func (u *Upgrade) Cost() float64 {
 	return math.Floor(u.BaseCost * math.Pow(1.15, float64(u.Owned)))
 }
 
+// Resume pays out the cookies produced while the game was not updating.
+func (g *Game) Resume(away time.Duration) {
+	earned := g.cps * away.Seconds()
+	if earned <= 0 {
+		return
+	}
+
+	g.cookies += earned
+	g.totalCookies += earned
+	g.awayEarned = earned
+	g.awayTimer = 4
+}
+
 func (g *Game) Update() error {
-	dt := 1.0 / 60.0
+	// Real time per tick, so throttling while unfocused keeps the same income
+	dt := 1.0 / float64(ebiten.TPS())
 
-	// Passive cookie generation
-	g.cookies += g.cps * dt
-	g.totalCookies += g.cps * dt
+	if g.awayTimer > 0 {
+		g.awayTimer -= dt
+	}
 
-	// Cookie click
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
+	if err := g.Step(); err != nil {
+		return err
+	}
 
+	// Cookie click
+	if mx, my, ok := input.Default.Click(ebiten.MouseButtonLeft); ok {
 		// Check cookie click (center area)
 		cookieX, cookieY := 150, 250
 		dx := float64(mx - cookieX)
@@ -137,6 +166,15 @@ func (g *Game) Update() error {
 	return nil
 }
 
+// Step produces one tick of passive income, for fast-forward substeps.
+func (g *Game) Step() error {
+	dt := 1.0 / float64(ebiten.TPS())
+	g.cookies += g.cps * dt
+	g.totalCookies += g.cps * dt
+
+	return nil
+}
+
 func (g *Game) Draw(screen *ebiten.Image) {
 	// Background
 	screen.Fill(color.RGBA{R: 40, G: 30, B: 50, A: 255})
@@ -148,6 +186,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, formatBigNumber(g.cookies)+" cookies", 60, 30)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("per second: %.1f", g.cps), 80, 50)
 
+	if g.awayTimer > 0 {
+		ebitenutil.DebugPrintAt(screen, "While you were away: +"+formatBigNumber(g.awayEarned), 40, 70)
+	}
+
 	// Big cookie
 	g.drawCookie(screen, 150, 250, g.cookieScale)
 
@@ -295,7 +337,13 @@ func main() {
 	ebiten.SetWindowTitle("Cookie Clicker")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	// Speed buttons sit in the stats bar, clear of the cookie and the shop
+	speed := engine.SpeedConfig{X: screenWidth - 100, Y: screenHeight - 29}
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "cookie_clicker"}}
+	focus := engine.FocusConfig{Policy: engine.FocusThrottle}
+
+	game := engine.WithWindow(engine.WithFocus(engine.WithSpeed(NewGame(), speed), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(game, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
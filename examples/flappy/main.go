@@ -12,6 +12,10 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -67,6 +71,7 @@ type Game struct {
 	highscore  int
 	state      GameState
 	pipeTimer  float64
+	paused     bool
 	titlePulse float64
 	deathTimer float64
 	groundX    float64 // For scrolling ground
@@ -89,9 +94,17 @@ func (g *Game) startGame() {
 	g.pipeTimer = 0
 	g.deathTimer = 0
 	g.bird.VelocityY = jumpForce
+	g.paused = false
 	g.state = StatePlaying
 }
 
+// Pause pauses a flight in progress, for engine.FocusConfig.AutoPause.
+func (g *Game) Pause() {
+	if g.state == StatePlaying {
+		g.paused = true
+	}
+}
+
 func (g *Game) spawnPipe() {
 	minGap := float64(pipeGap/2 + 50)
 	maxGap := float64(screenHeight - pipeGap/2 - 100)
@@ -117,6 +130,15 @@ func (g *Game) addScorePopup() {
 }
 
 func (g *Game) Update() error {
+	if g.state == StatePlaying && (inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyP)) {
+		g.paused = !g.paused
+	}
+
+	if g.paused {
+		return nil
+	}
+
 	dt := 1.0 / 60.0
 	g.titlePulse += dt * 2
 
@@ -338,6 +360,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawTitle(screen)
 	case StatePlaying:
 		g.drawHUD(screen)
+
+		if g.paused {
+			ui.DrawPaused(screen, "ESC or P to resume")
+		}
 	case StateGameOver:
 		g.drawHUD(screen)
 		g.drawGameOver(screen)
@@ -527,7 +553,15 @@ func main() {
 	ebiten.SetWindowTitle("Flappy Bird")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "flappy"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	g := NewGame()
+	onTitle := func() any { return g.state == StateTitle }
+	scenes := engine.WithTransitions(g, onTitle, engine.NewWipeTransition(0.4, engine.WipeLeft))
+
+	windowed := engine.WithWindow(engine.WithFocus(scenes, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
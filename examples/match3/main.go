@@ -11,6 +11,10 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/history"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
@@ -21,6 +25,8 @@ const (
 	cellSize     = 50
 	gridOffsetX  = 25
 	gridOffsetY  = 80
+
+	undoBoosters = 3 // "Undo last swap" boosters per game
 )
 
 type GameState int
@@ -92,14 +98,75 @@ type Game struct {
 	titlePulse     float64
 	particles      []Particle
 	popups         []ScorePopup
+	swaps          *history.History[*Game]
+	pendingSwap    *swapCommand // Swap whose cascade is still resolving
+	undosLeft      int
+}
+
+// board is the part of the game a swap and its cascade change.
+type board struct {
+	Gems     [gridRows][gridCols]GemType
+	Score    int
+	Moves    int
+	MaxCombo int
+}
+
+// swapCommand is a swap and its whole cascade, as the boards before and
+// after it.
+type swapCommand struct {
+	Before, After board
+}
+
+func (c *swapCommand) Apply(g *Game)  { g.setBoard(c.After) }
+func (c *swapCommand) Revert(g *Game) { g.setBoard(c.Before) }
+
+func (g *Game) board() board {
+	b := board{Score: g.score, Moves: g.moves, MaxCombo: g.maxCombo}
+
+	for y := range gridRows {
+		for x := range gridCols {
+			b.Gems[y][x] = g.grid[y][x].Type
+		}
+	}
+
+	return b
+}
+
+// setBoard lays out a board from the swap history with every gem at rest.
+func (g *Game) setBoard(b board) {
+	for y := range gridRows {
+		for x := range gridCols {
+			g.grid[y][x] = &Gem{
+				Type: b.Gems[y][x], X: float64(x), Y: float64(y), TargetY: float64(y), Scale: 1.0,
+			}
+		}
+	}
+
+	g.score, g.moves, g.maxCombo = b.Score, b.Moves, b.MaxCombo
+	g.combo = 0
+	g.selected = false
+}
+
+// undoSwap spends a booster to take back the last swap and its cascade.
+func (g *Game) undoSwap() bool {
+	if g.undosLeft <= 0 || g.pendingSwap != nil || !g.swaps.Undo() {
+		return false
+	}
+
+	g.undosLeft--
+
+	return true
 }
 
 func NewGame() *Game {
-	return &Game{
+	g := &Game{
 		selectedX: -1,
 		selectedY: -1,
 		state:     StateTitle,
 	}
+	g.swaps = history.New(g, undoBoosters)
+
+	return g
 }
 
 func (g *Game) startGame() {
@@ -109,6 +176,9 @@ func (g *Game) startGame() {
 	g.moves = 0
 	g.particles = nil
 	g.popups = nil
+	g.swaps.Clear()
+	g.pendingSwap = nil
+	g.undosLeft = undoBoosters
 	g.initGrid()
 	g.state = StatePlaying
 }
@@ -234,6 +304,7 @@ func (g *Game) updateGameplay(dt float64) {
 			g.swapProgress = 0
 			if !g.checkAndMarkMatches() {
 				g.grid[g.swapY1][g.swapX1], g.grid[g.swapY2][g.swapX2] = g.grid[g.swapY2][g.swapX2], g.grid[g.swapY1][g.swapX1]
+				g.pendingSwap = nil
 			} else {
 				g.combo = 1
 				g.moves++
@@ -258,6 +329,17 @@ func (g *Game) updateGameplay(dt float64) {
 		return
 	}
 
+	// The cascade has settled: the swap can be undone
+	if g.pendingSwap != nil {
+		g.pendingSwap.After = g.board()
+		g.swaps.Push(g.pendingSwap)
+		g.pendingSwap = nil
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyU) {
+		g.undoSwap()
+	}
+
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		mx, my := ebiten.CursorPosition()
 		gridX := (mx - gridOffsetX) / cellSize
@@ -298,6 +380,7 @@ func (g *Game) startSwap(x1, y1, x2, y2 int) {
 	g.swapX1, g.swapY1 = x1, y1
 	g.swapX2, g.swapY2 = x2, y2
 	g.swapProgress = 0
+	g.pendingSwap = &swapCommand{Before: g.board()}
 	g.grid[y1][x1], g.grid[y2][x2] = g.grid[y2][x2], g.grid[y1][x1]
 }
 
@@ -499,6 +582,7 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Best Combo: %d", g.maxCombo), 280, 10)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("High: %d", g.highscore), 280, 30)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Undo (U): %d left", g.undosLeft), 280, 50)
 
 	// Grid background
 	vector.FillRect(screen, float32(gridOffsetX), float32(gridOffsetY),
@@ -584,7 +668,7 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, text, int(pop.X)-15, int(pop.Y))
 	}
 
-	ebitenutil.DebugPrintAt(screen, "Click gems to swap | ESC: Menu", 85, screenHeight-25)
+	ebitenutil.DebugPrintAt(screen, "Click gems to swap | U: Undo swap | ESC: Menu", 40, screenHeight-25)
 }
 
 func abs(x int) int {
@@ -604,7 +688,15 @@ func main() {
 	ebiten.SetWindowTitle("Match 3")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "match3"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+
+	g := NewGame()
+	onTitle := func() any { return g.state == StateTitle }
+	scenes := engine.WithTransitions(g, onTitle, engine.NewDissolveTransition(0.5))
+
+	windowed := engine.WithWindow(engine.WithFocus(scenes, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
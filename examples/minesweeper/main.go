@@ -10,6 +10,9 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
@@ -62,7 +65,11 @@ func NewGame() *Game {
 func (g *Game) initGrid() {
 	for i := range gridSize {
 		for j := range gridSize {
-			g.grid[i][j] = &Cell{}
+			if g.grid[i][j] == nil {
+				g.grid[i][j] = &Cell{}
+			} else {
+				*g.grid[i][j] = Cell{}
+			}
 		}
 	}
 }
@@ -107,7 +114,8 @@ func (g *Game) placeMines(excludeRow, excludeCol int) {
 	}
 }
 
-func (g *Game) reset() {
+// Reset clears the board in place for a new game.
+func (g *Game) Reset() {
 	g.initGrid()
 	g.gameOver = false
 	g.won = false
@@ -124,7 +132,7 @@ func (g *Game) Update() error {
 	if g.gameOver || g.won {
 		if inpututil.IsKeyJustPressed(ebiten.KeyR) ||
 			inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-			g.reset()
+			g.Reset()
 		}
 
 		return nil
@@ -429,7 +437,10 @@ func main() {
 	ebiten.SetWindowTitle("Minesweeper")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "minesweeper"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+	windowed := engine.WithWindow(engine.WithFocus(NewGame(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
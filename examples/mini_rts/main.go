@@ -8,8 +8,14 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/steering"
+	"github.com/skyrocket-qy/NeuralWay/engine/targeting"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -41,6 +47,17 @@ type Unit struct {
 	Selected  bool
 	AttackCD  float64
 	Moving    bool
+	Agent     *steering.Agent // Collision-avoidance body, grouped by team
+
+	Targeting targeting.Policy // Which enemy in range to attack
+	Shot      targeting.Shot   // Melee units hit instantly; archers lob arrows
+}
+
+// Arrow is an archer's projectile in flight.
+type Arrow struct {
+	*targeting.Projectile
+	Target *Unit
+	Damage int
 }
 
 // Game represents the mini RTS.
@@ -55,6 +72,10 @@ type Game struct {
 	wave          int
 	message       string
 	messageTimer  float64
+	paused        bool
+	avoidance     *steering.RVOSolver
+	arrows        []*Arrow
+	markers       *ui.MarkerLayer
 }
 
 // NewGame creates a new game.
@@ -63,11 +84,13 @@ func NewGame() *Game {
 		units:     make([]*Unit, 0),
 		resources: 500,
 		wave:      1,
+		avoidance: steering.NewRVOSolver(),
+		markers:   &ui.MarkerLayer{Width: screenWidth, Height: screenHeight},
 	}
 
 	// Spawn starting units
 	for i := range 5 {
-		g.units = append(g.units, g.createUnit(100+float64(i*30), 300, 0, UnitSoldier))
+		g.addUnit(g.createUnit(100+float64(i*30), 300, 0, UnitSoldier))
 	}
 
 	return g
@@ -83,6 +106,9 @@ func (g *Game) createUnit(x, y float64, team int, uType UnitType) *Unit {
 		Type:    uType,
 	}
 
+	// Tanks get right of way; lighter units step around them
+	radius, priority := 7.0, 1.0
+
 	switch uType {
 	case UnitSoldier:
 		u.Health, u.MaxHealth = 100, 100
@@ -94,17 +120,48 @@ func (g *Game) createUnit(x, y float64, team int, uType UnitType) *Unit {
 		u.Attack = 20
 		u.Range = 120
 		u.Speed = 1.5
+		u.Targeting = targeting.Weakest // Finish off wounded units
+		u.Shot = targeting.Shot{Flight: targeting.Arcing, Speed: 220, Lead: true, ArcHeight: 25}
+		radius = 6
 	case UnitTank:
 		u.Health, u.MaxHealth = 200, 200
 		u.Attack = 25
 		u.Range = 20
 		u.Speed = 1
+		radius, priority = 10, 3
+	}
+
+	u.Agent = &steering.Agent{
+		Position: steering.Vec2{X: x, Y: y},
+		Radius:   radius,
+		MaxSpeed: u.Speed * 60,
+		Priority: priority,
+		Group:    team,
 	}
 
 	return u
 }
 
+// addUnit adds a unit to the game and the avoidance solver.
+func (g *Game) addUnit(u *Unit) {
+	g.units = append(g.units, u)
+	g.avoidance.AddAgent(u.Agent)
+}
+
+// Pause pauses the battle, for engine.FocusConfig.AutoPause.
+func (g *Game) Pause() {
+	g.paused = true
+}
+
 func (g *Game) Update() error {
+	if input.IsKeyJustPressed(ebiten.KeyEscape) || input.IsKeyJustPressed(ebiten.KeyP) {
+		g.paused = !g.paused
+	}
+
+	if g.paused {
+		return nil
+	}
+
 	dt := 1.0 / 60.0
 
 	// Message timer
@@ -112,42 +169,34 @@ func (g *Game) Update() error {
 		g.messageTimer -= dt
 	}
 
-	// Enemy spawn
-	g.enemySpawnCD -= dt
-	if g.enemySpawnCD <= 0 {
-		g.spawnEnemyWave()
-		g.enemySpawnCD = 15.0
-		g.wave++
-	}
-
 	// Unit buying
-	if inpututil.IsKeyJustPressed(ebiten.Key1) && g.resources >= 50 {
+	if input.IsKeyJustPressed(ebiten.Key1) && g.resources >= 50 {
 		g.resources -= 50
-		g.units = append(g.units, g.createUnit(50+rand.Float64()*80, 250+rand.Float64()*100, 0, UnitSoldier))
+		g.addUnit(g.createUnit(50+rand.Float64()*80, 250+rand.Float64()*100, 0, UnitSoldier))
 		g.showMessage("Soldier purchased!")
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.Key2) && g.resources >= 80 {
+	if input.IsKeyJustPressed(ebiten.Key2) && g.resources >= 80 {
 		g.resources -= 80
-		g.units = append(g.units, g.createUnit(50+rand.Float64()*80, 250+rand.Float64()*100, 0, UnitArcher))
+		g.addUnit(g.createUnit(50+rand.Float64()*80, 250+rand.Float64()*100, 0, UnitArcher))
 		g.showMessage("Archer purchased!")
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.Key3) && g.resources >= 150 {
+	if input.IsKeyJustPressed(ebiten.Key3) && g.resources >= 150 {
 		g.resources -= 150
-		g.units = append(g.units, g.createUnit(50+rand.Float64()*80, 250+rand.Float64()*100, 0, UnitTank))
+		g.addUnit(g.createUnit(50+rand.Float64()*80, 250+rand.Float64()*100, 0, UnitTank))
 		g.showMessage("Tank purchased!")
 	}
 
 	// Selection box
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		g.selectStartX, g.selectStartY = ebiten.CursorPosition()
+	if x, y, ok := input.Default.Click(ebiten.MouseButtonLeft); ok {
+		g.selectStartX, g.selectStartY = x, y
 		g.selecting = true
 	}
 
 	// Selection box is drawn while selecting in Draw()
 
-	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+	if input.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 		mx, my := ebiten.CursorPosition()
 		if g.selecting {
 			// Select units in box
@@ -173,8 +222,7 @@ func (g *Game) Update() error {
 	}
 
 	// Move command (right click)
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		mx, my := ebiten.CursorPosition()
+	if mx, my, ok := input.Default.Click(ebiten.MouseButtonRight); ok {
 		for _, u := range g.selectedUnits {
 			u.TargetX = float64(mx)
 			u.TargetY = float64(my)
@@ -182,42 +230,39 @@ func (g *Game) Update() error {
 		}
 	}
 
+	return g.Step()
+}
+
+// Step advances the battle one tick without reading input, for
+// fast-forward substeps.
+func (g *Game) Step() error {
+	if g.paused {
+		return nil
+	}
+
+	dt := 1.0 / 60.0
+
+	// Enemy spawn
+	g.enemySpawnCD -= dt
+	if g.enemySpawnCD <= 0 {
+		g.spawnEnemyWave()
+		g.enemySpawnCD = 15.0
+		g.wave++
+	}
+
+	g.moveUnits(dt)
+
 	// Update units
 	for _, u := range g.units {
-		// Movement
-		if u.Moving {
-			dx := u.TargetX - u.X
-			dy := u.TargetY - u.Y
-
-			dist := math.Sqrt(dx*dx + dy*dy)
-			if dist > 5 {
-				u.X += (dx / dist) * u.Speed
-				u.Y += (dy / dist) * u.Speed
-			} else {
-				u.Moving = false
-			}
-		}
-
 		// Attack cooldown
 		u.AttackCD -= dt
 
 		// Find enemy to attack
-		var target *Unit
-
-		minDist := u.Range
-		for _, other := range g.units {
-			if other.Team != u.Team && other.Health > 0 {
-				dist := math.Sqrt((u.X-other.X)*(u.X-other.X) + (u.Y-other.Y)*(u.Y-other.Y))
-				if dist < minDist {
-					minDist = dist
-					target = other
-				}
-			}
-		}
+		target := g.pickTarget(u)
 
 		// Attack
 		if target != nil && u.AttackCD <= 0 {
-			target.Health -= u.Attack
+			g.fire(u, target)
 			u.AttackCD = 1.0
 
 			// Enemy AI: move toward player units
@@ -239,6 +284,8 @@ func (g *Game) Update() error {
 		}
 	}
 
+	g.updateArrows(dt)
+
 	// Remove dead units
 	for i := len(g.units) - 1; i >= 0; i-- {
 		if g.units[i].Health <= 0 {
@@ -246,6 +293,7 @@ func (g *Game) Update() error {
 				g.resources += 20
 			}
 
+			g.avoidance.RemoveAgent(g.units[i].Agent)
 			g.units = append(g.units[:i], g.units[i+1:]...)
 		}
 	}
@@ -253,6 +301,122 @@ func (g *Game) Update() error {
 	return nil
 }
 
+// moveUnits steers units toward their targets, letting squadmates flow
+// around each other instead of stacking on the same point.
+func (g *Game) moveUnits(dt float64) {
+	for _, u := range g.units {
+		u.Agent.Position = steering.Vec2{X: u.X, Y: u.Y}
+		u.Agent.PreferredVelocity = steering.Vec2{}
+
+		if !u.Moving {
+			continue
+		}
+
+		dx := u.TargetX - u.X
+		dy := u.TargetY - u.Y
+
+		dist := math.Sqrt(dx*dx + dy*dy)
+		if dist > 5 {
+			u.Agent.PreferredVelocity = steering.Vec2{X: dx / dist, Y: dy / dist}.Scale(u.Agent.MaxSpeed)
+		} else {
+			u.Moving = false
+		}
+	}
+
+	g.avoidance.Solve(dt)
+
+	for _, u := range g.units {
+		u.X += u.Agent.Velocity.X * dt
+		u.Y += u.Agent.Velocity.Y * dt
+	}
+}
+
+// pickTarget returns the living enemy in u's range that its targeting
+// policy prefers, or nil.
+func (g *Game) pickTarget(u *Unit) *Unit {
+	var candidates []targeting.Target
+
+	for i, other := range g.units {
+		if other.Team != u.Team && other.Health > 0 {
+			candidates = append(candidates, targeting.Target{
+				ID: i,
+				X:  other.X, Y: other.Y,
+				VX: other.Agent.Velocity.X, VY: other.Agent.Velocity.Y,
+				HP: float64(other.Health),
+			})
+		}
+	}
+
+	t, ok := targeting.Select(u.X, u.Y, u.Range, u.Targeting, candidates)
+	if !ok {
+		return nil
+	}
+
+	return g.units[t.ID]
+}
+
+// fire attacks target with u's shot: instant hits land now, arrows later.
+func (g *Game) fire(u, target *Unit) {
+	if u.Shot.Flight == targeting.Instant {
+		target.Health -= u.Attack
+
+		return
+	}
+
+	g.arrows = append(g.arrows, &Arrow{
+		Projectile: u.Shot.Fire(u.X, u.Y, targeting.Target{
+			X: target.X, Y: target.Y,
+			VX: target.Agent.Velocity.X, VY: target.Agent.Velocity.Y,
+		}),
+		Target: target,
+		Damage: u.Attack,
+	})
+}
+
+// updateArrows flies arrows and damages targets still under them when they land.
+func (g *Game) updateArrows(dt float64) {
+	for i := len(g.arrows) - 1; i >= 0; i-- {
+		a := g.arrows[i]
+		t := a.Target
+
+		if a.Update(dt, t.X, t.Y) && t.Health > 0 && a.Hits(t.X, t.Y, t.Agent.Radius+4) {
+			t.Health -= a.Damage
+		}
+
+		if a.Done {
+			g.arrows = append(g.arrows[:i], g.arrows[i+1:]...)
+		}
+	}
+}
+
+// orderMarkers marks what the selected units are doing: the enemies they
+// are attacking and where they were ordered to move.
+func (g *Game) orderMarkers() []ui.Marker {
+	var markers []ui.Marker
+
+	seen := make(map[[2]float64]bool)
+	add := func(kind ui.MarkerKind, x, y float64) {
+		if !seen[[2]float64{x, y}] {
+			seen[[2]float64{x, y}] = true
+			markers = append(markers, ui.Marker{Kind: kind, X: x, Y: y})
+		}
+	}
+
+	for _, u := range g.selectedUnits {
+		if u.Health <= 0 {
+			continue
+		}
+
+		if target := g.pickTarget(u); target != nil {
+			add(ui.MarkerTarget, target.X, target.Y)
+		} else if u.Moving {
+			add(ui.MarkerWaypoint, u.TargetX, u.TargetY)
+		}
+	}
+
+	return markers
+}
+
 func (g *Game) spawnEnemyWave() {
 	count := 3 + g.wave
 	for range count {
@@ -265,7 +429,7 @@ func (g *Game) spawnEnemyWave() {
 			uType = UnitTank
 		}
 
-		g.units = append(g.units, g.createUnit(
+		g.addUnit(g.createUnit(
 			float64(screenWidth)-50,
 			150+rand.Float64()*300,
 			1, uType))
@@ -288,6 +452,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawUnit(screen, u)
 	}
 
+	// Arrows with their ground shadows
+	for _, a := range g.arrows {
+		vector.FillCircle(screen, float32(a.X), float32(a.Y), 2, color.RGBA{R: 20, G: 30, B: 20, A: 120}, false)
+		vector.FillCircle(screen, float32(a.X), float32(a.Y-a.Z), 2, color.RGBA{R: 240, G: 230, B: 200, A: 255}, false)
+	}
+
+	g.markers.Draw(screen, g.orderMarkers(), 0, 0)
+
 	// Selection box
 	if g.selecting {
 		mx, my := ebiten.CursorPosition()
@@ -322,6 +494,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		)
 		ebitenutil.DebugPrintAt(screen, g.message, screenWidth/2-len(g.message)*3, screenHeight/2-7)
 	}
+
+	if g.paused {
+		ui.DrawPaused(screen, "ESC or P to resume")
+	}
 }
 
 func (g *Game) drawUnit(screen *ebiten.Image, u *Unit) {
@@ -413,7 +589,12 @@ func main() {
 	ebiten.SetWindowTitle("Mini RTS")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	speed := engine.SpeedConfig{X: screenWidth - 100, Y: 28}
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "mini_rts"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	game := engine.WithWindow(engine.WithFocus(engine.WithSpeed(NewGame(), speed), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(game, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
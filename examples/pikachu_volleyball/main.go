@@ -11,6 +11,9 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
@@ -66,26 +69,18 @@ type VolleyballGame struct {
 func NewVolleyballGame() *VolleyballGame {
 	g := &VolleyballGame{
 		player1: &Player{
-			X:            150,
-			Y:            groundY - playerSize,
 			Size:         playerSize,
 			Color:        color.RGBA{R: 255, G: 220, B: 0, A: 255}, // Yellow for Pikachu
 			ControlLeft:  ebiten.KeyA,
 			ControlRight: ebiten.KeyD,
 			ControlJump:  ebiten.KeyW,
-			OnGround:     true,
-			JumpsLeft:    maxJumps,
 		},
 		player2: &Player{
-			X:            screenWidth - 150 - playerSize,
-			Y:            groundY - playerSize,
 			Size:         playerSize,
 			Color:        color.RGBA{R: 255, G: 100, B: 100, A: 255}, // Red opponent
 			ControlLeft:  ebiten.KeyLeft,
 			ControlRight: ebiten.KeyRight,
 			ControlJump:  ebiten.KeyUp,
-			OnGround:     true,
-			JumpsLeft:    maxJumps,
 		},
 		ball: &Ball{
 			Radius: ballRadius,
@@ -93,11 +88,29 @@ func NewVolleyballGame() *VolleyballGame {
 		winScore: winScore,
 		groundY:  groundY,
 	}
-	g.resetBall()
+	g.Reset()
 
 	return g
 }
 
+// Reset starts a new match in place, keeping the players' controls and colors.
+func (g *VolleyballGame) Reset() {
+	g.player1.X = 150
+	g.player2.X = screenWidth - 150 - playerSize
+
+	for _, p := range []*Player{g.player1, g.player2} {
+		p.Y = groundY - playerSize
+		p.VX, p.VY = 0, 0
+		p.Score = 0
+		p.OnGround = true
+		p.JumpsLeft = maxJumps
+	}
+
+	g.paused = false
+	g.gameOver = false
+	g.resetBall()
+}
+
 func (g *VolleyballGame) resetBall() {
 	g.ball.X = screenWidth / 2
 	g.ball.Y = 100
@@ -106,10 +119,17 @@ func (g *VolleyballGame) resetBall() {
 	g.ball.LastTouched = 0
 }
 
+// Pause pauses a rally in progress, for engine.FocusConfig.AutoPause.
+func (g *VolleyballGame) Pause() {
+	if !g.gameOver {
+		g.paused = true
+	}
+}
+
 func (g *VolleyballGame) Update() error {
 	if g.gameOver {
 		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-			*g = *NewVolleyballGame()
+			g.Reset()
 		}
 
 		return nil
@@ -554,7 +574,10 @@ func main() {
 	ebiten.SetWindowTitle("Pikachu Volleyball - Framework Example")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewVolleyballGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "pikachu_volleyball"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+	game := engine.WithWindow(engine.WithFocus(NewVolleyballGame(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(game, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -7,8 +7,12 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -20,6 +24,12 @@ const (
 	tileSize     = 32
 )
 
+// Platformer actions beyond the standard input ones.
+const (
+	actionJump = input.FirstCustom + iota
+	actionRestart
+)
+
 // Player represents the player character.
 type Player struct {
 	X, Y       float64
@@ -44,6 +54,10 @@ type Game struct {
 	score      int
 	levelWidth int
 	won        bool
+	paused     bool
+	goals      []ui.Marker
+	markers    *ui.MarkerLayer
+	controls   *input.Map
 }
 
 // Level tiles: 0=empty, 1=ground, 2=platform, 3=coin, 4=goal.
@@ -72,24 +86,52 @@ func NewGame() *Game {
 		level:      levelData,
 		levelWidth: len(levelData[0]) * tileSize,
 		coins:      make([]*Coin, 0),
+		controls:   input.NewMap(),
 	}
 
-	// Find coins in level
+	g.controls.Bind(actionJump, input.Binding{
+		Keys:    []ebiten.Key{ebiten.KeySpace, ebiten.KeyUp, ebiten.KeyW},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+	})
+	g.controls.Bind(actionRestart, input.Binding{
+		Keys: []ebiten.Key{ebiten.KeyR},
+		Buttons: []ebiten.StandardGamepadButton{
+			ebiten.StandardGamepadButtonRightBottom, ebiten.StandardGamepadButtonCenterRight,
+		},
+	})
+
+	// Find coins and goals in level
 	for y, row := range levelData {
 		for x, tile := range row {
-			if tile == 3 {
-				g.coins = append(g.coins, &Coin{
-					X: float64(x*tileSize) + tileSize/2,
-					Y: float64(y*tileSize) + tileSize/2,
-				})
+			cx, cy := float64(x*tileSize)+tileSize/2, float64(y*tileSize)+tileSize/2
+
+			switch tile {
+			case 3:
+				g.coins = append(g.coins, &Coin{X: cx, Y: cy})
+			case 4:
+				g.goals = append(g.goals, ui.Marker{Kind: ui.MarkerObjective, X: cx, Y: cy, Label: "GOAL"})
 			}
 		}
 	}
 
+	// Point at the goal while it is scrolled off screen
+	g.markers = &ui.MarkerLayer{
+		Width:         screenWidth,
+		Height:        screenHeight,
+		Margin:        tileSize / 2,
+		OffScreenOnly: true,
+		ToScreen: func(x, y float64) (float64, float64) {
+			return x - g.cameraX, y
+		},
+		DistanceScale: tileSize,
+		DistanceUnit:  "m",
+	}
+
 	return g
 }
 
-func (g *Game) reset() {
+// Reset restarts the level in place, restoring collected coins.
+func (g *Game) Reset() {
 	g.player.X = 50
 	g.player.Y = float64(len(levelData)-2)*tileSize - 32
 	g.player.VX = 0
@@ -98,6 +140,7 @@ func (g *Game) reset() {
 	g.player.JumpCount = 0
 	g.score = 0
 	g.won = false
+	g.paused = false
 
 	g.cameraX = 0
 	for _, c := range g.coins {
@@ -105,30 +148,40 @@ func (g *Game) reset() {
 	}
 }
 
+// Pause pauses the level in progress, for engine.FocusConfig.AutoPause.
+func (g *Game) Pause() {
+	if !g.won {
+		g.paused = true
+	}
+}
+
 func (g *Game) Update() error {
 	if g.won {
-		if inpututil.IsKeyJustPressed(ebiten.KeyR) {
-			g.reset()
+		if g.controls.JustPressed(actionRestart) {
+			g.Reset()
 		}
 
 		return nil
 	}
 
-	// Horizontal movement
-	g.player.VX = 0
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
-		g.player.VX = -moveSpeed
-		g.player.FacingLeft = true
+	if g.controls.JustPressed(input.Pause) {
+		g.paused = !g.paused
+	}
+
+	if g.paused {
+		return nil
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
-		g.player.VX = moveSpeed
-		g.player.FacingLeft = false
+	// Horizontal movement; a half-pushed stick walks
+	move := g.controls.Axis(input.MoveX)
+	g.player.VX = move * moveSpeed
+
+	if move != 0 {
+		g.player.FacingLeft = move < 0
 	}
 
 	// Jump (double jump allowed)
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyUp) ||
-		inpututil.IsKeyJustPressed(ebiten.KeyW) {
+	if g.controls.JustPressed(actionJump) {
 		if g.player.JumpCount < 2 {
 			g.player.VY = jumpForce
 			g.player.JumpCount++
@@ -161,7 +214,7 @@ func (g *Game) Update() error {
 
 	// Fall death
 	if g.player.Y > float64(len(g.level)*tileSize) {
-		g.reset()
+		g.Reset()
 	}
 
 	// Collect coins
@@ -276,6 +329,13 @@ func (g *Game) checkTileCollision(tx, ty int) bool {
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
+	g.DrawWorld(screen)
+	g.DrawUI(screen)
+}
+
+// DrawWorld draws the sky, level, coins, and player; the pixel art mode
+// renders it at low resolution.
+func (g *Game) DrawWorld(screen *ebiten.Image) {
 	// Sky gradient
 	for y := range screenHeight {
 		t := float64(y) / float64(screenHeight)
@@ -375,11 +435,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	// Draw player
 	g.drawPlayer(screen)
+}
 
-	// UI
+// DrawUI draws the score bar, goal marker, and win screen at native resolution.
+func (g *Game) DrawUI(screen *ebiten.Image) {
 	vector.FillRect(screen, 0, 0, screenWidth, 35, color.RGBA{R: 0, G: 0, B: 0, A: 150}, false)
+	g.markers.Draw(screen, g.goals, g.player.X, g.player.Y)
 	ebitenutil.DebugPrintAt(screen, "Score: "+formatInt(g.score), 10, 10)
-	ebitenutil.DebugPrintAt(screen, "WASD/Arrows = Move | Space = Jump (x2)", 200, 10)
+	ebitenutil.DebugPrintAt(screen, "WASD/Arrows/Stick = Move | Space/A = Jump (x2)", 200, 10)
 
 	if g.won {
 		vector.FillRect(
@@ -397,7 +460,11 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			screenWidth/2-60,
 			screenHeight/2-10,
 		)
-		ebitenutil.DebugPrintAt(screen, "Press R to restart", screenWidth/2-60, screenHeight/2+20)
+		ebitenutil.DebugPrintAt(screen, "Press R or A to restart", screenWidth/2-60, screenHeight/2+20)
+	}
+
+	if g.paused {
+		ui.DrawPaused(screen, "ESC or P to resume")
 	}
 }
 
@@ -465,7 +532,13 @@ func main() {
 	ebiten.SetWindowTitle("Platformer")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	// Chunky pixels for the world, crisp score text; F4 switches to native
+	pixels := engine.PixelArtConfig{Width: 320, Height: 240, Toggle: true}
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "platformer"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	game := engine.WithWindow(engine.WithFocus(engine.WithPixelArt(NewGame(), pixels), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(game, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
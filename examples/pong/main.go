@@ -12,6 +12,9 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
@@ -186,6 +189,13 @@ func (p *Pong) updateAI(dt float64) {
 	}
 }
 
+// Pause pauses a match in progress, for engine.FocusConfig.AutoPause.
+func (p *Pong) Pause() {
+	if p.state == StatePlaying {
+		p.state = StatePaused
+	}
+}
+
 func (p *Pong) Update() error {
 	dt := 1.0 / 60.0
 	p.titlePulse += dt * 2
@@ -679,7 +689,15 @@ func main() {
 	ebiten.SetWindowTitle("Pong")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewPong()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "pong"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	g := NewPong()
+	onTitle := func() any { return g.state == StateTitle }
+	scenes := engine.WithTransitions(g, onTitle, engine.NewFadeTransition(0.4))
+
+	windowed := engine.WithWindow(engine.WithFocus(scenes, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -12,6 +12,12 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/history"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -33,6 +39,18 @@ const (
 	StateWin
 )
 
+// undoLimit is how many moves can be taken back.
+const undoLimit = 64
+
+// End-of-game dialog layout, in pixels.
+const (
+	overlayW       = 360
+	overlayH       = 150
+	overlayButtonW = 80
+	overlayButtonH = 28
+	overlayGap     = 8
+)
+
 var TileColors = map[int]color.RGBA{
 	0:    {R: 205, G: 193, B: 180, A: 255},
 	2:    {R: 238, G: 228, B: 218, A: 255},
@@ -81,10 +99,89 @@ type Game struct {
 	moveCount    int
 	bestTile     int
 	continuePlay bool // Continue after winning
+	moves        *history.History[*Game]
+
+	// End-of-game dialog and its buttons after losing and after winning
+	overlay     *ui.Panel
+	overButtons *ui.ButtonGroup
+	winButtons  *ui.ButtonGroup
+	controls    *input.Map
+}
+
+// board is the part of the game a move changes.
+type board struct {
+	Grid  [gridSize][gridSize]int
+	Score int
+	Moves int
+}
+
+// moveCommand is one move, with its merges and the tile it spawned, as the
+// boards before and after it.
+type moveCommand struct {
+	Before, After board
 }
 
+func (c moveCommand) Apply(g *Game)  { g.setBoard(c.After) }
+func (c moveCommand) Revert(g *Game) { g.setBoard(c.Before) }
+
 func NewGame() *Game {
-	return &Game{state: StateTitle}
+	g := &Game{
+		state:    StateTitle,
+		overlay:  ui.CenteredPanel(screenWidth, screenHeight, overlayW, overlayH, ""),
+		controls: input.NewMap(),
+	}
+	g.moves = history.New(g, undoLimit)
+
+	g.overButtons = g.overlayButtons(
+		overlayButton("New Game", "Start a new board (SPACE)", g.newGame),
+		overlayButton("Menu", "Back to the title (ESC)", g.toMenu),
+		overlayButton("Undo (U)", "Take back the last move", g.undo),
+	)
+	g.winButtons = g.overlayButtons(
+		overlayButton("Continue (C)", "Keep playing past 2048", g.continuePlaying),
+		overlayButton("New Game", "Start a new board (SPACE)", g.newGame),
+		overlayButton("Menu", "Back to the title (ESC)", g.toMenu),
+		overlayButton("Undo (U)", "Take back the last move", g.undo),
+	)
+	g.winButtons.SetFocus(1)
+
+	return g
+}
+
+// overlayButton creates an end-of-game dialog button; overlayButtons places
+// it.
+func overlayButton(label, tooltip string, onClick func()) *ui.Button {
+	b := ui.NewButton(0, 0, overlayButtonW, overlayButtonH, label)
+	b.Tooltip = tooltip
+	b.OnClick = onClick
+
+	return b
+}
+
+// overlayButtons lays buttons out in a row centered along the bottom of the
+// end-of-game dialog, with New Game focused so SPACE still starts over.
+func (g *Game) overlayButtons(buttons ...*ui.Button) *ui.ButtonGroup {
+	n := float64(len(buttons))
+	x := g.overlay.X + (g.overlay.W-n*overlayButtonW-(n-1)*overlayGap)/2
+	y := g.overlay.Y + g.overlay.H - overlayButtonH - 16
+
+	for i, b := range buttons {
+		b.X, b.Y = x+float64(i)*(overlayButtonW+overlayGap), y
+	}
+
+	return ui.NewButtonGroup(buttons...)
+}
+
+func (g *Game) board() board {
+	return board{Grid: g.grid, Score: g.score, Moves: g.moveCount}
+}
+
+// setBoard puts a board from the move history back in play, so a move that
+// ended the game can be taken back.
+func (g *Game) setBoard(b board) {
+	g.grid, g.score, g.moveCount = b.Grid, b.Score, b.Moves
+	g.animations = g.animations[:0]
+	g.state = StatePlaying
 }
 
 func (g *Game) startGame() {
@@ -99,6 +196,7 @@ func (g *Game) startGame() {
 	g.bestTile = 0
 	g.state = StatePlaying
 	g.continuePlay = false
+	g.moves.Clear()
 	g.spawnTile()
 	g.spawnTile()
 }
@@ -155,6 +253,32 @@ func (g *Game) addPopup(row, col, value int) {
 	g.popups = append(g.popups, ScorePopup{X: x, Y: y, Value: value, Timer: 1.0})
 }
 
+// keepHighscore records the score if it beats the best.
+func (g *Game) keepHighscore() {
+	if g.score > g.highscore {
+		g.highscore = g.score
+	}
+}
+
+func (g *Game) newGame() {
+	g.keepHighscore()
+	g.startGame()
+}
+
+func (g *Game) toMenu() {
+	g.keepHighscore()
+	g.state = StateTitle
+}
+
+func (g *Game) undo() {
+	g.moves.Undo()
+}
+
+func (g *Game) continuePlaying() {
+	g.continuePlay = true
+	g.state = StatePlaying
+}
+
 func (g *Game) Update() error {
 	dt := 1.0 / 60.0
 	g.titlePulse += dt * 2
@@ -199,6 +323,19 @@ func (g *Game) Update() error {
 		}
 
 	case StatePlaying:
+		if inpututil.IsKeyJustPressed(ebiten.KeyU) || inpututil.IsKeyJustPressed(ebiten.KeyZ) {
+			g.moves.Undo()
+
+			break
+		}
+
+		if inpututil.IsKeyJustPressed(ebiten.KeyY) {
+			g.moves.Redo()
+
+			break
+		}
+
+		before := g.board()
 		g.moved = false
 		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyA) {
 			g.moveLeft()
@@ -219,30 +356,23 @@ func (g *Game) Update() error {
 		if g.moved {
 			g.moveCount++
 			g.spawnTile()
+			g.moves.Push(moveCommand{Before: before, After: g.board()})
 			g.checkGameOver()
 			g.updateBestTile()
 		}
 
 	case StateGameOver, StateWin:
-		if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-			if g.score > g.highscore {
-				g.highscore = g.score
-			}
-
-			g.startGame()
-		}
-
-		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-			if g.score > g.highscore {
-				g.highscore = g.score
-			}
-
-			g.state = StateTitle
-		}
-
-		if g.state == StateWin && inpututil.IsKeyJustPressed(ebiten.KeyC) {
-			g.continuePlay = true
-			g.state = StatePlaying
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyU) || inpututil.IsKeyJustPressed(ebiten.KeyZ):
+			g.moves.Undo()
+		case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+			g.toMenu()
+		case g.state == StateWin && inpututil.IsKeyJustPressed(ebiten.KeyC):
+			g.continuePlaying()
+		case g.state == StateWin:
+			g.winButtons.Update(g.controls)
+		default:
+			g.overButtons.Update(g.controls)
 		}
 	}
 
@@ -467,10 +597,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawGame(screen)
 	case StateGameOver:
 		g.drawGame(screen)
-		g.drawOverlay(screen, "Game Over!", color.RGBA{R: 119, G: 110, B: 101, A: 220}, false)
+		g.drawOverlay(screen, "Game Over!", color.RGBA{R: 119, G: 110, B: 101, A: 220}, g.overButtons)
 	case StateWin:
 		g.drawGame(screen)
-		g.drawOverlay(screen, "You Win!", color.RGBA{R: 237, G: 194, B: 46, A: 220}, true)
+		g.drawOverlay(screen, "You Win!", color.RGBA{R: 237, G: 194, B: 46, A: 220}, g.winButtons)
 	}
 }
 
@@ -556,21 +686,15 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("+%d", pop.Value), int(pop.X)-15, int(pop.Y))
 	}
 
-	ebitenutil.DebugPrintAt(screen, "Arrow Keys / WASD | R to restart", 80, screenHeight-25)
+	ebitenutil.DebugPrintAt(screen, "Arrow Keys / WASD | U undo | Y redo", 75, screenHeight-25)
 }
 
 func (g *Game) drawScoreBox(screen *ebiten.Image, x, y int, label string, value int) {
-	vector.FillRect(
-		screen,
-		float32(x),
-		float32(y),
-		100,
-		60,
-		color.RGBA{R: 143, G: 122, B: 102, A: 255},
-		false,
-	)
-	ebitenutil.DebugPrintAt(screen, label, x+32, y+8)
-	ebitenutil.DebugPrintAt(screen, strconv.Itoa(value), x+30, y+30)
+	box := ui.NewPanel(float64(x), float64(y), 100, 60, label)
+	box.Draw(screen)
+
+	v := strconv.Itoa(value)
+	ebitenutil.DebugPrintAt(screen, v, x+(100-len(v)*6)/2, y+32)
 }
 
 func (g *Game) drawTile(screen *ebiten.Image, row, col int) {
@@ -608,24 +732,16 @@ func (g *Game) drawTile(screen *ebiten.Image, row, col int) {
 	}
 }
 
-func (g *Game) drawOverlay(screen *ebiten.Image, msg string, bgColor color.RGBA, showContinue bool) {
+func (g *Game) drawOverlay(screen *ebiten.Image, msg string, bgColor color.RGBA, buttons *ui.ButtonGroup) {
 	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, bgColor, false)
 
-	boxW, boxH := float32(280), float32(150)
-	boxX, boxY := float32(screenWidth-280)/2, float32(screenHeight-150)/2
-	vector.FillRect(screen, boxX, boxY, boxW, boxH, color.RGBA{R: 255, G: 255, B: 255, A: 255}, false)
-	vector.StrokeRect(screen, boxX, boxY, boxW, boxH, 2, color.RGBA{R: 119, G: 110, B: 101, A: 255}, false)
+	g.overlay.Title = msg
+	g.overlay.Draw(screen)
 
-	ebitenutil.DebugPrintAt(screen, msg, int(boxX)+95, int(boxY)+25)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Score: %d", g.score), int(boxX)+105, int(boxY)+55)
+	score := fmt.Sprintf("Score: %d", g.score)
+	ebitenutil.DebugPrintAt(screen, score, int(g.overlay.X)+(overlayW-len(score)*6)/2, int(g.overlay.Y)+50)
 
-	if showContinue {
-		ebitenutil.DebugPrintAt(screen, "C: Continue  SPACE: New", int(boxX)+50, int(boxY)+90)
-		ebitenutil.DebugPrintAt(screen, "ESC: Menu", int(boxX)+100, int(boxY)+115)
-	} else {
-		ebitenutil.DebugPrintAt(screen, "SPACE: New Game", int(boxX)+80, int(boxY)+95)
-		ebitenutil.DebugPrintAt(screen, "ESC: Menu", int(boxX)+100, int(boxY)+120)
-	}
+	buttons.Draw(screen)
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -637,7 +753,15 @@ func main() {
 	ebiten.SetWindowTitle("2048")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "puzzle_2048"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+
+	g := NewGame()
+	onTitle := func() any { return g.state == StateTitle }
+	scenes := engine.WithTransitions(g, onTitle, engine.NewWipeTransition(0.4, engine.WipeUp))
+
+	windowed := engine.WithWindow(engine.WithFocus(scenes, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
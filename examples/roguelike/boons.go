@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/rng"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/draft"
+)
+
+// boonDraftSize is how many boons are offered at the start of a run.
+const boonDraftSize = 3
+
+// Boon is a blessing drafted at the start of a run.
+type Boon struct {
+	Name  string
+	Desc  string
+	Apply func(p *Player)
+}
+
+// Boons lists every boon the draft deals from.
+var Boons = []Boon{
+	{Name: "Vigor", Desc: "+30 max HP", Apply: func(p *Player) {
+		p.MaxHP += 30
+		p.HP = p.MaxHP
+	}},
+	{Name: "Sharp Blade", Desc: "+4 attack", Apply: func(p *Player) { p.Attack += 4 }},
+	{Name: "Iron Skin", Desc: "+4 defense", Apply: func(p *Player) { p.Defense += 4 }},
+	{Name: "Inheritance", Desc: "Start with 60 gold", Apply: func(p *Player) { p.Gold += 60 }},
+	{Name: "Veteran", Desc: "+2 attack and defense, -10 max HP", Apply: func(p *Player) {
+		p.Attack += 2
+		p.Defense += 2
+		p.MaxHP -= 10
+		p.HP = p.MaxHP
+	}},
+	{Name: "Head Start", Desc: "Half way to level 2", Apply: func(p *Player) { p.XP += p.Level * 25 }},
+}
+
+// openBoonDraft deals the starting boons.
+func (g *Game) openBoonDraft() {
+	g.boonOffer = draft.Deal(rng.Unseeded(), Boons, boonDraftSize)
+	options := make([]draft.Option, len(g.boonOffer))
+
+	for i, b := range g.boonOffer {
+		options[i] = draft.Option{Name: b.Name, Desc: b.Desc}
+	}
+
+	g.boonDraft = draft.New("Choose a starting boon", options, screenWidth, screenHeight)
+}
+
+// updateBoonDraft grants the picked boon and starts the descent.
+func (g *Game) updateBoonDraft() {
+	i := g.boonDraft.Update(g.controls)
+	if i < 0 {
+		return
+	}
+
+	b := g.boonOffer[i]
+	b.Apply(g.player)
+	g.addMessage("Blessed with " + b.Name)
+
+	g.boonDraft, g.boonOffer = nil, nil
+}
+
+// drawBoonDraft draws the draft over the first floor.
+func (g *Game) drawBoonDraft(screen *ebiten.Image) {
+	if g.boonDraft != nil {
+		g.boonDraft.Draw(screen)
+	}
+}
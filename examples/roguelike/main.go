@@ -7,8 +7,14 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/draft"
 )
 
 const (
@@ -77,25 +83,60 @@ type Game struct {
 	message  string
 	messages []string
 	gameOver bool
+	stairs   []ui.Marker
+	markers  *ui.MarkerLayer
+	controls *input.Map
+
+	// Click-to-move: the walk under way and the grid its paths are found on
+	walk     *walk
+	pathGrid *game.PathGrid
+
+	// Starting boon draft and the boons it offers
+	boonDraft *draft.Screen
+	boonOffer []Boon
 }
 
 // NewGame creates a new game.
 func NewGame() *Game {
 	g := &Game{
-		player: &Player{
-			HP: 100, MaxHP: 100,
-			Attack: 10, Defense: 5,
-			Level: 1,
-		},
-		floor:    1,
+		player:   &Player{},
 		messages: make([]string, 0),
+		controls: input.NewMap(),
+		pathGrid: game.NewPathGrid(mapWidth, mapHeight),
+		// Markers float above their tile; distance is in tiles
+		markers: &ui.MarkerLayer{
+			Width:  screenWidth,
+			Height: screenHeight,
+			ToScreen: func(x, y float64) (float64, float64) {
+				return x, y - tileSize*0.75
+			},
+			DistanceScale: tileSize,
+		},
 	}
-	g.generateLevel()
+	g.Reset()
 
 	return g
 }
 
+// Reset starts a new run on floor 1, reusing the player and entity slices,
+// and offers the starting boons.
+func (g *Game) Reset() {
+	*g.player = Player{
+		HP: 100, MaxHP: 100,
+		Attack: 10, Defense: 5,
+		Level: 1,
+	}
+	g.floor = 1
+	g.gameOver = false
+	g.message = ""
+	g.messages = g.messages[:0]
+	g.generateLevel()
+	g.openBoonDraft()
+}
+
 func (g *Game) generateLevel() {
+	g.stopWalking()
+
 	// Fill with walls
 	for y := range mapHeight {
 		for x := range mapWidth {
@@ -151,13 +192,18 @@ func (g *Game) generateLevel() {
 	}
 
 	// Place stairs in last room
+	g.stairs = g.stairs[:0]
+
 	if len(rooms) > 1 {
 		stairRoom := rooms[len(rooms)-1]
-		g.tiles[stairRoom[1]+1][stairRoom[0]+stairRoom[2]-2] = TileStairs
+		sx, sy := stairRoom[0]+stairRoom[2]-2, stairRoom[1]+1
+		g.tiles[sy][sx] = TileStairs
+		g.stairs = append(g.stairs, ui.Marker{Kind: ui.MarkerObjective, X: tileCenter(sx), Y: tileCenter(sy)})
 	}
 
 	// Spawn enemies
-	g.enemies = make([]*Enemy, 0)
+	clear(g.enemies)
+	g.enemies = g.enemies[:0]
 	enemyNames := []string{"Goblin", "Rat", "Skeleton", "Orc", "Spider"}
 
 	for i := 0; i < 5+g.floor*2; i++ {
@@ -180,7 +226,8 @@ func (g *Game) generateLevel() {
 	}
 
 	// Spawn items
-	g.items = make([]*Item, 0)
+	clear(g.items)
+	g.items = g.items[:0]
 
 	for i := 0; i < 3+rand.Intn(3); i++ {
 		for range 50 {
@@ -208,136 +255,129 @@ func (g *Game) addMessage(msg string) {
 }
 
 func (g *Game) Update() error {
+	if g.boonDraft != nil {
+		g.updateBoonDraft()
+
+		return nil
+	}
+
 	if g.gameOver {
-		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-			// Restart
-			g.player = &Player{HP: 100, MaxHP: 100, Attack: 10, Defense: 5, Level: 1}
-			g.floor = 1
-			g.gameOver = false
-			g.messages = make([]string, 0)
-			g.generateLevel()
+		if g.controls.JustPressed(input.Confirm) {
+			g.Reset()
 		}
 
 		return nil
 	}
 
-	dx, dy := 0, 0
-	moved := false
+	// One step per press or stick flick; the keyboard overrides a walk
+	dx := g.controls.JustMoved(input.MoveX)
+	dy := g.controls.JustMoved(input.MoveY)
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
-		dy = -1
-		moved = true
-	}
+	if dx != 0 || dy != 0 {
+		g.stopWalking()
+		g.takeTurn(dx, dy)
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
-		dy = 1
-		moved = true
+		return nil
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyA) {
-		dx = -1
-		moved = true
-	}
+	g.updateMouse()
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyD) {
-		dx = 1
-		moved = true
-	}
+	return nil
+}
 
-	if moved {
-		newX := g.player.X + dx
-		newY := g.player.Y + dy
-
-		// Check bounds and walls
-		if newX >= 0 && newX < mapWidth && newY >= 0 && newY < mapHeight {
-			tile := g.tiles[newY][newX]
-
-			// Check for enemy
-			enemy := g.getEnemyAt(newX, newY)
-			if enemy != nil {
-				// Attack
-				damage := max(g.player.Attack-rand.Intn(5), 1)
-
-				enemy.HP -= damage
-				g.addMessage("Hit " + enemy.Name + " for " + formatInt(damage))
-
-				if enemy.HP <= 0 {
-					enemy.Dead = true
-					xp := 10 + g.floor*5
-					g.player.XP += xp
-					g.addMessage(enemy.Name + " defeated! +" + formatInt(xp) + " XP")
-					g.checkLevelUp()
-				}
-			} else if tile != TileWall {
-				g.player.X = newX
-				g.player.Y = newY
-
-				// Check stairs
-				if tile == TileStairs {
-					g.floor++
-					g.generateLevel()
-				}
+// takeTurn moves the player by (dx, dy), attacking an enemy in the way,
+// and then lets the enemies act.
+func (g *Game) takeTurn(dx, dy int) {
+	newX := g.player.X + dx
+	newY := g.player.Y + dy
+
+	// Check bounds and walls
+	if newX >= 0 && newX < mapWidth && newY >= 0 && newY < mapHeight {
+		tile := g.tiles[newY][newX]
+
+		// Check for enemy
+		enemy := g.getEnemyAt(newX, newY)
+		if enemy != nil {
+			// Attack
+			damage := max(g.player.Attack-rand.Intn(5), 1)
+
+			enemy.HP -= damage
+			g.addMessage("Hit " + enemy.Name + " for " + formatInt(damage))
+
+			if enemy.HP <= 0 {
+				enemy.Dead = true
+				xp := 10 + g.floor*5
+				g.player.XP += xp
+				g.addMessage(enemy.Name + " defeated! +" + formatInt(xp) + " XP")
+				g.checkLevelUp()
+			}
+		} else if tile != TileWall {
+			g.player.X = newX
+			g.player.Y = newY
+
+			// Check stairs
+			if tile == TileStairs {
+				g.floor++
+				g.generateLevel()
+			}
 
-				// Check items
-				for i := len(g.items) - 1; i >= 0; i-- {
-					item := g.items[i]
-					if item.X == g.player.X && item.Y == g.player.Y {
-						g.pickupItem(item)
-						g.items = append(g.items[:i], g.items[i+1:]...)
-					}
+			// Check items
+			for i := len(g.items) - 1; i >= 0; i-- {
+				item := g.items[i]
+				if item.X == g.player.X && item.Y == g.player.Y {
+					g.pickupItem(item)
+					g.items = append(g.items[:i], g.items[i+1:]...)
 				}
 			}
 		}
+	}
 
-		// Enemy turns
-		for _, e := range g.enemies {
-			if e.Dead {
-				continue
+	// Enemy turns
+	for _, e := range g.enemies {
+		if e.Dead {
+			continue
+		}
+		// Simple AI: move toward player if close
+		edx := 0
+		edy := 0
+
+		if abs(e.X-g.player.X)+abs(e.Y-g.player.Y) <= 5 {
+			if e.X < g.player.X {
+				edx = 1
+			} else if e.X > g.player.X {
+				edx = -1
 			}
-			// Simple AI: move toward player if close
-			edx := 0
-			edy := 0
-
-			if abs(e.X-g.player.X)+abs(e.Y-g.player.Y) <= 5 {
-				if e.X < g.player.X {
-					edx = 1
-				} else if e.X > g.player.X {
-					edx = -1
-				}
 
-				if e.Y < g.player.Y {
-					edy = 1
-				} else if e.Y > g.player.Y {
-					edy = -1
-				}
+			if e.Y < g.player.Y {
+				edy = 1
+			} else if e.Y > g.player.Y {
+				edy = -1
 			}
+		}
 
-			// Attack if adjacent
-			if abs(e.X-g.player.X) <= 1 && abs(e.Y-g.player.Y) <= 1 {
-				damage := max(e.Attack-g.player.Defense/2, 1)
+		// Attack if adjacent
+		if abs(e.X-g.player.X) <= 1 && abs(e.Y-g.player.Y) <= 1 {
+			damage := max(e.Attack-g.player.Defense/2, 1)
 
-				g.player.HP -= damage
-				g.addMessage(e.Name + " hits you for " + formatInt(damage))
+			g.player.HP -= damage
+			g.addMessage(e.Name + " hits you for " + formatInt(damage))
 
-				if g.player.HP <= 0 {
-					g.gameOver = true
-					g.addMessage("You died!")
-				}
-			} else if edx != 0 || edy != 0 {
-				// Move
-				newX := e.X + edx
-
-				newY := e.Y + edy
-				if newX >= 0 && newX < mapWidth && newY >= 0 && newY < mapHeight &&
-					g.tiles[newY][newX] == TileFloor && g.getEnemyAt(newX, newY) == nil {
-					e.X = newX
-					e.Y = newY
-				}
+			if g.player.HP <= 0 {
+				g.gameOver = true
+				g.addMessage("You died!")
+			}
+		} else if edx != 0 || edy != 0 {
+			// Move
+			newX := e.X + edx
+
+			newY := e.Y + edy
+			if newX >= 0 && newX < mapWidth && newY >= 0 && newY < mapHeight &&
+				g.tiles[newY][newX] == TileFloor && g.getEnemyAt(newX, newY) == nil {
+				e.X = newX
+				e.Y = newY
 			}
 		}
 	}
-
-	return nil
 }
 
 func (g *Game) getEnemyAt(x, y int) *Enemy {
@@ -386,6 +426,11 @@ func (g *Game) checkLevelUp() {
 	}
 }
 
+// tileCenter returns the pixel center of a tile coordinate.
+func tileCenter(t int) float64 {
+	return float64(t*tileSize) + tileSize/2
+}
+
 func (g *Game) Draw(screen *ebiten.Image) {
 	// Background
 	screen.Fill(color.RGBA{R: 20, G: 20, B: 30, A: 255})
@@ -480,6 +525,8 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	playerY := float32(g.player.Y*tileSize) + tileSize/2
 	vector.FillCircle(screen, playerX, playerY, 12, color.RGBA{R: 50, G: 150, B: 255, A: 255}, false)
 
+	g.markers.Draw(screen, g.stairs, float64(playerX), float64(playerY))
+
 	// UI - Stats
 	vector.FillRect(
 		screen,
@@ -539,8 +586,11 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			screenWidth/2-60,
 			screenHeight/2,
 		)
-		ebitenutil.DebugPrintAt(screen, "Press SPACE to restart", screenWidth/2-80, screenHeight/2+30)
+		ebitenutil.DebugPrintAt(screen, "Press SPACE or A to restart", screenWidth/2-80, screenHeight/2+30)
 	}
+
+	g.drawMouse(screen)
+	g.drawBoonDraft(screen)
 }
 
 func abs(x int) int {
@@ -574,7 +624,10 @@ func main() {
 	ebiten.SetWindowTitle("Roguelike Dungeon")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "roguelike"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+	windowed := engine.WithWindow(engine.WithFocus(NewGame(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Mouse play tuning.
+const (
+	sightRadius = 7 // Tiles the player sees enemies within, walls permitting
+	walkDelay   = 6 // Ticks between the steps of a click-to-move walk
+	hudTop      = screenHeight - 100
+)
+
+// walk is a click-to-move order the player carries out a step at a time.
+type walk struct {
+	path   []game.Point // Tiles still to step onto
+	target *Enemy       // Walk up to this enemy and attack it instead
+	seen   []*Enemy     // Enemies in view when the walk began
+	timer  int
+}
+
+// updateMouse starts a walk or an attack on a left click and advances the
+// walk in progress.
+func (g *Game) updateMouse() {
+	if mx, my, ok := input.Default.Click(ebiten.MouseButtonLeft); ok && my < hudTop {
+		g.click(mx/tileSize, my/tileSize)
+	}
+
+	g.updateWalk()
+}
+
+// click orders the player to the tile at (x, y): a walk there along the
+// shortest path, or to the enemy on it to attack.
+func (g *Game) click(x, y int) {
+	if !inMap(x, y) || (x == g.player.X && y == g.player.Y) {
+		return
+	}
+
+	if e := g.getEnemyAt(x, y); e != nil {
+		if !g.canSee(x, y) {
+			g.addMessage("You can't see that far")
+
+			return
+		}
+
+		g.walk = &walk{target: e, seen: g.visibleEnemies(), timer: walkDelay}
+
+		return
+	}
+
+	if g.tiles[y][x] == TileWall {
+		return
+	}
+
+	path := g.findPath(x, y, nil)
+	if len(path) == 0 {
+		g.addMessage("No way there")
+
+		return
+	}
+
+	g.walk = &walk{path: path, seen: g.visibleEnemies(), timer: walkDelay}
+}
+
+// updateWalk takes the walk's next step when it is due. The walk stops when
+// an enemy comes into view, when the player is hurt or blocked, and after
+// the attack a walk to an enemy ends with.
+func (g *Game) updateWalk() {
+	w := g.walk
+	if w == nil {
+		return
+	}
+
+	if w.timer++; w.timer < walkDelay {
+		return
+	}
+
+	w.timer = 0
+
+	if e := g.newlySeen(w.seen); e != nil {
+		g.addMessage(e.Name + " comes into view")
+		g.walk = nil
+
+		return
+	}
+
+	next, ok := g.nextStep(w)
+	if !ok {
+		g.walk = nil
+
+		return
+	}
+
+	hp := g.player.HP
+	attack := g.getEnemyAt(next.X, next.Y) != nil
+	g.takeTurn(next.X-g.player.X, next.Y-g.player.Y)
+
+	if attack || g.player.HP < hp || (w.target == nil && len(w.path) == 0) {
+		g.walk = nil
+	}
+}
+
+// nextStep returns the tile the walk steps onto next, and false when it
+// cannot go on.
+func (g *Game) nextStep(w *walk) (game.Point, bool) {
+	if w.target != nil {
+		if w.target.Dead {
+			return game.Point{}, false
+		}
+
+		path := g.findPath(w.target.X, w.target.Y, w.target)
+		if len(path) == 0 {
+			g.addMessage("No way to reach the " + w.target.Name)
+
+			return game.Point{}, false
+		}
+
+		return path[0], true
+	}
+
+	if len(w.path) == 0 {
+		return game.Point{}, false
+	}
+
+	next := w.path[0]
+	if g.getEnemyAt(next.X, next.Y) != nil {
+		g.addMessage("The way is blocked")
+
+		return game.Point{}, false
+	}
+
+	w.path = w.path[1:]
+
+	return next, true
+}
+
+// stopWalking cancels any click-to-move walk.
+func (g *Game) stopWalking() {
+	g.walk = nil
+}
+
+// findPath returns the tiles from the player to (x, y), not counting the
+// one the player stands on. Walls and living enemies other than target
+// block the way.
+func (g *Game) findPath(x, y int, target *Enemy) []game.Point {
+	for ty := range mapHeight {
+		for tx := range mapWidth {
+			g.pathGrid.SetWalkable(tx, ty, g.tiles[ty][tx] != TileWall)
+		}
+	}
+
+	for _, e := range g.enemies {
+		if !e.Dead && e != target {
+			g.pathGrid.SetWalkable(e.X, e.Y, false)
+		}
+	}
+
+	path := g.pathGrid.FindPath(g.player.X, g.player.Y, x, y)
+	if len(path) < 2 {
+		return nil
+	}
+
+	return path[1:]
+}
+
+func inMap(x, y int) bool {
+	return x >= 0 && x < mapWidth && y >= 0 && y < mapHeight
+}
+
+// canSee reports whether the player can see the tile at (x, y): within
+// sightRadius with no wall on the line between them.
+func (g *Game) canSee(x, y int) bool {
+	px, py := g.player.X, g.player.Y
+	if (x-px)*(x-px)+(y-py)*(y-py) > sightRadius*sightRadius {
+		return false
+	}
+
+	// Bresenham's line, checking the tiles strictly between the two
+	dx, dy := abs(x-px), -abs(y-py)
+	sx, sy := sign(x-px), sign(y-py)
+	err := dx + dy
+
+	for cx, cy := px, py; ; {
+		if cx == x && cy == y {
+			return true
+		}
+
+		if (cx != px || cy != py) && g.tiles[cy][cx] == TileWall {
+			return false
+		}
+
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			cx += sx
+		} else {
+			err += dx
+			cy += sy
+		}
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+
+	return 0
+}
+
+// visibleEnemies returns the living enemies the player can see.
+func (g *Game) visibleEnemies() []*Enemy {
+	var seen []*Enemy
+
+	for _, e := range g.enemies {
+		if !e.Dead && g.canSee(e.X, e.Y) {
+			seen = append(seen, e)
+		}
+	}
+
+	return seen
+}
+
+// newlySeen returns an enemy in view that is not in seen, or nil.
+func (g *Game) newlySeen(seen []*Enemy) *Enemy {
+	for _, e := range g.visibleEnemies() {
+		if !slices.Contains(seen, e) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// hoveredTile returns the map tile under the cursor, and false when the
+// cursor is off the map or over the HUD.
+func hoveredTile() (x, y int, ok bool) {
+	mx, my := ebiten.CursorPosition()
+	if mx < 0 || my < 0 || my >= hudTop {
+		return 0, 0, false
+	}
+
+	x, y = mx/tileSize, my/tileSize
+
+	return x, y, inMap(x, y)
+}
+
+// drawMouse outlines the hovered tile, marks the path a click there would
+// take or the walk under way, and shows what is on the tile in a tooltip.
+func (g *Game) drawMouse(screen *ebiten.Image) {
+	if g.boonDraft != nil || g.gameOver {
+		return
+	}
+
+	var path []game.Point
+	if g.walk != nil {
+		path = g.walk.path
+	}
+
+	x, y, ok := hoveredTile()
+	if ok {
+		if g.walk == nil && g.tiles[y][x] != TileWall && g.getEnemyAt(x, y) == nil {
+			path = g.findPath(x, y, nil)
+		}
+
+		vector.StrokeRect(screen, float32(x*tileSize), float32(y*tileSize), tileSize-1, tileSize-1, 1,
+			color.RGBA{R: 220, G: 220, B: 120, A: 255}, false)
+	}
+
+	for _, p := range path {
+		vector.FillCircle(screen, float32(tileCenter(p.X)), float32(tileCenter(p.Y)), 3,
+			color.RGBA{R: 220, G: 220, B: 120, A: 160}, false)
+	}
+
+	if ok {
+		mx, my := ebiten.CursorPosition()
+		tip := ui.Tooltip{Text: g.describeTile(x, y)}
+		tip.Draw(screen, float64(mx), float64(my))
+	}
+}
+
+// describeTile is the hover tooltip for the tile at (x, y): the player,
+// enemy, or item on it, or else the tile itself.
+func (g *Game) describeTile(x, y int) string {
+	if x == g.player.X && y == g.player.Y {
+		return "You"
+	}
+
+	if e := g.getEnemyAt(x, y); e != nil {
+		desc := e.Name + "\nHP " + formatInt(e.HP) + "/" + formatInt(e.MaxHP) + "  Attack " + formatInt(e.Attack)
+
+		switch {
+		case !g.canSee(x, y):
+			return desc + "\nOut of sight"
+		case abs(x-g.player.X) <= 1 && abs(y-g.player.Y) <= 1:
+			return desc + "\nClick to attack"
+		default:
+			return desc + "\nClick to close in and attack"
+		}
+	}
+
+	for _, item := range g.items {
+		if item.X == x && item.Y == y {
+			return describeItem(item)
+		}
+	}
+
+	switch g.tiles[y][x] {
+	case TileWall:
+		return "Wall"
+	case TileStairs:
+		return "Stairs down to floor " + formatInt(g.floor+1)
+	case TileFloor:
+	}
+
+	return "Floor"
+}
+
+// describeItem names an item and what picking it up does.
+func describeItem(item *Item) string {
+	switch item.Type {
+	case ItemPotion:
+		return "Potion\n+" + formatInt(item.Value) + " HP"
+	case ItemWeapon:
+		return "Weapon\nAttack +" + formatInt(item.Value/5)
+	case ItemArmor:
+		return "Armor\nDefense +" + formatInt(item.Value/5)
+	case ItemGold:
+		return formatInt(item.Value) + " gold"
+	}
+
+	return "Item"
+}
@@ -10,6 +10,11 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 const (
@@ -66,16 +71,40 @@ type Game struct {
 	message        string
 	animTimer      float64
 	battleCount    int
+
+	bus       *events.Bus
+	combatLog *combatlog.Log
 }
 
 // NewGame creates a new game.
 func NewGame() *Game {
-	g := &Game{}
+	g := &Game{bus: events.NewBus()}
+	g.combatLog = combatlog.NewLog(g.bus, 100)
 	g.initBattle()
 
 	return g
 }
 
+// logHit records damage (and a kill, if it was lethal) in the combat log.
+func (g *Game) logHit(attacker, target *Character, damage int, detail string) {
+	category := combatlog.DamageDealt
+	if attacker.IsEnemy {
+		category = combatlog.DamageTaken
+	}
+
+	events.Publish(g.bus, combatlog.Entry{
+		Category: category,
+		Source:   attacker.Name,
+		Target:   target.Name,
+		Amount:   damage,
+		Detail:   detail,
+	})
+
+	if target.HP <= 0 {
+		events.Publish(g.bus, combatlog.Entry{Category: combatlog.Kill, Source: attacker.Name, Target: target.Name})
+	}
+}
+
 func (g *Game) initBattle() {
 	// Create party
 	g.party = []*Character{
@@ -162,6 +191,8 @@ func (g *Game) currentChar() *Character {
 func (g *Game) Update() error {
 	dt := 1.0 / 60.0
 
+	g.combatLog.Update(dt)
+
 	// Animation timer
 	if g.state == StateAnimation {
 		g.animTimer -= dt
@@ -217,6 +248,7 @@ func (g *Game) Update() error {
 			}
 
 			g.message = current.Name + " attacks " + target.Name + " for " + formatInt(damage) + " damage!"
+			g.logHit(current, target, damage, "Attack")
 		}
 
 		g.state = StateAnimation
@@ -298,6 +330,7 @@ func (g *Game) Update() error {
 
 						g.enemies[i].HP -= damage
 						g.message = current.Name + " attacks for " + formatInt(damage) + "!"
+						g.logHit(current, g.enemies[i], damage, "Attack")
 					} else {
 						g.executeSkill(current, g.enemies[i], current.Skills[g.selectedSkill])
 					}
@@ -327,6 +360,13 @@ func (g *Game) executeSkill(user, target *Character, skill Skill) {
 		}
 
 		g.message = user.Name + " heals " + target.Name + " for " + formatInt(skill.Damage) + "!"
+		events.Publish(g.bus, combatlog.Entry{
+			Category: combatlog.Heal,
+			Source:   user.Name,
+			Target:   target.Name,
+			Amount:   skill.Damage,
+			Detail:   skill.Name,
+		})
 	} else {
 		target.HP -= skill.Damage
 		if target.HP < 0 {
@@ -334,6 +374,7 @@ func (g *Game) executeSkill(user, target *Character, skill Skill) {
 		}
 
 		g.message = user.Name + " uses " + skill.Name + " for " + formatInt(skill.Damage) + "!"
+		g.logHit(user, target, skill.Damage, skill.Name)
 	}
 }
 
@@ -413,7 +454,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	// Actions
 	if g.state == StateSelectAction && g.currentChar() != nil && !g.currentChar().IsEnemy {
-		ebitenutil.DebugPrintAt(screen, "[1] Attack  [2] Skills  [3] Defend", 20, 440)
+		ebitenutil.DebugPrintAt(screen, "[1] Attack  [2] Skills  [3] Defend  [L] Combat log", 20, 440)
 	} else if g.state == StateSelectSkill {
 		current := g.currentChar()
 
@@ -473,6 +514,9 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			495,
 		)
 	}
+
+	// Combat log overlay
+	g.combatLog.Draw(screen, 150, 20, 400, 360)
 }
 
 func (g *Game) drawCharacter(screen *ebiten.Image, c *Character, idx int, isEnemy bool) {
@@ -569,7 +613,10 @@ func main() {
 	ebiten.SetWindowTitle("Turn-Based RPG Battle")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "rpg_battle"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+	windowed := engine.WithWindow(engine.WithFocus(NewGame(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
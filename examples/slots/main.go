@@ -18,6 +18,9 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
 )
 
 //go:embed assets/*.png
@@ -831,7 +834,10 @@ func main() {
 	ebiten.SetWindowTitle("财神到 - Fortune Arrives")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewSlotMachine()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "slots"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+	windowed := engine.WithWindow(engine.WithFocus(NewSlotMachine(), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -11,6 +11,10 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -65,6 +69,7 @@ type Snake struct {
 	state      GameState
 	moveTimer  float64
 	moveDelay  float64
+	paused     bool
 	particles  []Particle
 	foodPulse  float64 // For food animation
 	titlePulse float64 // For title animation
@@ -92,10 +97,18 @@ func (s *Snake) startGame() {
 	s.moveTimer = 0
 	s.particles = nil
 	s.deathTimer = 0
+	s.paused = false
 	s.state = StatePlaying
 	s.spawnFood()
 }
 
+// Pause pauses a game in progress, for engine.FocusConfig.AutoPause.
+func (s *Snake) Pause() {
+	if s.state == StatePlaying {
+		s.paused = true
+	}
+}
+
 func (s *Snake) spawnFood() {
 	for {
 		s.food = Point{
@@ -162,6 +175,15 @@ func (s *Snake) spawnDeathParticles() {
 }
 
 func (s *Snake) Update() error {
+	if s.state == StatePlaying && (inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyP)) {
+		s.paused = !s.paused
+	}
+
+	if s.paused {
+		return nil
+	}
+
 	dt := 1.0 / 60.0
 	s.foodPulse += dt * 3
 	s.titlePulse += dt * 2
@@ -346,6 +368,10 @@ func (s *Snake) Draw(screen *ebiten.Image) {
 		s.drawTitle(screen)
 	case StatePlaying:
 		s.drawGame(screen)
+
+		if s.paused {
+			ui.DrawPaused(screen, "ESC or P to resume")
+		}
 	case StateGameOver:
 		s.drawGame(screen)
 		s.drawGameOver(screen)
@@ -524,7 +550,15 @@ func main() {
 	ebiten.SetWindowTitle("Snake")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewSnake()); err != nil {
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "snake"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	g := NewSnake()
+	onTitle := func() any { return g.state == StateTitle }
+	scenes := engine.WithTransitions(g, onTitle, engine.NewPixelateTransition(0.5))
+
+	windowed := engine.WithWindow(engine.WithFocus(scenes, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
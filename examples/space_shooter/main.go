@@ -8,8 +8,13 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combo"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
 )
 
 const (
@@ -18,8 +23,27 @@ const (
 	playerSpeed  = 5
 	bulletSpeed  = 8
 	enemySpeed   = 2
+
+	fireInterval  = 0.15 // Seconds between shots
+	comboFireRate = 0.02 // Shot cooldown shaved off per combo tier
+	killsPerLevel = 5
+	startLives    = 3
 )
 
+// actionFire shoots while held: Space, or A or the right trigger on a gamepad.
+const actionFire = input.FirstCustom
+
+// comboConfig builds the kill streak combo; each tier also fires faster.
+var comboConfig = combo.Config{
+	Window: 1.5,
+	Decay:  3,
+	Tiers: []combo.Tier{
+		{Kills: 3, Multiplier: 1.5, Name: "Hot Streak"},
+		{Kills: 8, Multiplier: 2, Name: "Ace"},
+		{Kills: 15, Multiplier: 3, Name: "Top Gun"},
+	},
+}
+
 // Entity represents a game entity.
 type Entity struct {
 	X, Y   float64
@@ -36,12 +60,17 @@ type Game struct {
 	enemies       []*Entity
 	particles     []*Particle
 	score         int
+	kills         int
+	combo         *combo.Meter
+	damageDir     *ui.DamageIndicator // Edge arcs toward the ships that rammed the player
 	highscore     int
 	lives         int
 	gameOver      bool
+	paused        bool
 	spawnTimer    float64
 	shootCooldown float64
 	level         int
+	controls      *input.Map
 }
 
 // Particle for explosions.
@@ -54,6 +83,14 @@ type Particle struct {
 
 // NewGame creates a new game.
 func NewGame() *Game {
+	controls := input.NewMap()
+	controls.Bind(actionFire, input.Binding{
+		Keys: []ebiten.Key{ebiten.KeySpace},
+		Buttons: []ebiten.StandardGamepadButton{
+			ebiten.StandardGamepadButtonRightBottom, ebiten.StandardGamepadButtonFrontBottomRight,
+		},
+	})
+
 	return &Game{
 		player: &Entity{
 			X:      float64(screenWidth) / 2,
@@ -66,55 +103,71 @@ func NewGame() *Game {
 		bullets:   make([]*Entity, 0),
 		enemies:   make([]*Entity, 0),
 		particles: make([]*Particle, 0),
-		lives:     3,
+		combo:     combo.NewMeter(comboConfig),
+		damageDir: ui.NewDamageIndicator(nil),
+		lives:     startLives,
 		level:     1,
+		controls:  controls,
 	}
 }
 
-func (g *Game) reset() {
+// Reset starts a new run in place, reusing the entity slices.
+func (g *Game) Reset() {
 	g.player.X = float64(screenWidth) / 2
 	g.player.Y = float64(screenHeight) - 80
 	g.player.Active = true
-	g.bullets = make([]*Entity, 0)
-	g.enemies = make([]*Entity, 0)
-	g.particles = make([]*Particle, 0)
+	clear(g.bullets)
+	g.bullets = g.bullets[:0]
+	clear(g.enemies)
+	g.enemies = g.enemies[:0]
+	clear(g.particles)
+	g.particles = g.particles[:0]
 	g.score = 0
-	g.lives = 3
+	g.kills = 0
+	g.combo.Reset()
+	g.damageDir.Clear()
+	g.lives = startLives
 	g.gameOver = false
+	g.paused = false
 	g.level = 1
 }
 
+// Pause pauses a run in progress, for engine.FocusConfig.AutoPause.
+func (g *Game) Pause() {
+	if !g.gameOver {
+		g.paused = true
+	}
+}
+
 func (g *Game) Update() error {
 	if g.gameOver {
-		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		if g.controls.JustPressed(input.Confirm) {
 			if g.score > g.highscore {
 				g.highscore = g.score
 			}
 
-			g.reset()
+			g.Reset()
 		}
 
 		return nil
 	}
 
-	dt := 1.0 / 60.0
-
-	// Player movement
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
-		g.player.X -= playerSpeed
+	if g.controls.JustPressed(input.Pause) {
+		g.paused = !g.paused
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
-		g.player.X += playerSpeed
+	if g.paused {
+		return nil
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
-		g.player.Y -= playerSpeed
-	}
+	dt := 1.0 / 60.0
+	g.combo.Update(dt)
+	g.damageDir.Update(dt)
 
-	if ebiten.IsKeyPressed(ebiten.KeyDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
-		g.player.Y += playerSpeed
-	}
+	// Player movement
+	dx, dy := g.controls.Move()
+	g.player.X += dx * playerSpeed
+	g.player.Y += dy * playerSpeed
 
 	// Clamp player position
 	g.player.X = clamp(g.player.X, g.player.W/2, float64(screenWidth)-g.player.W/2)
@@ -122,9 +175,9 @@ func (g *Game) Update() error {
 
 	// Shooting
 	g.shootCooldown -= dt
-	if ebiten.IsKeyPressed(ebiten.KeySpace) && g.shootCooldown <= 0 {
+	if g.controls.Pressed(actionFire) && g.shootCooldown <= 0 {
 		g.shoot()
-		g.shootCooldown = 0.15
+		g.shootCooldown = fireInterval - comboFireRate*float64(g.combo.Tier()+1)
 	}
 
 	// Update bullets
@@ -165,6 +218,12 @@ func (g *Game) Update() error {
 		// Collision with player
 		if g.checkCollision(g.player, e) {
 			g.lives--
+			g.combo.Break()
+			g.damageDir.Add(ui.DamageFrom{
+				X: e.X, Y: e.Y,
+				TargetX: g.player.X, TargetY: g.player.Y,
+				Strength: 1.0 / startLives,
+			})
 			g.enemies = append(g.enemies[:i], g.enemies[i+1:]...)
 			g.spawnExplosion(g.player.X, g.player.Y)
 
@@ -179,13 +238,15 @@ func (g *Game) Update() error {
 		for j := len(g.bullets) - 1; j >= 0; j-- {
 			b := g.bullets[j]
 			if g.checkCollision(b, e) {
-				g.score += 100
+				g.kills++
+				g.combo.Kill()
+				g.score += g.combo.Apply(100)
 				g.bullets = append(g.bullets[:j], g.bullets[j+1:]...)
 				g.enemies = append(g.enemies[:i], g.enemies[i+1:]...)
 				g.spawnExplosion(e.X, e.Y)
 
-				// Level up every 500 points
-				if g.score > 0 && g.score%500 == 0 {
+				// Level up every few kills; combo bonuses don't speed it up
+				if g.kills%killsPerLevel == 0 {
 					g.level++
 				}
 
@@ -257,6 +318,13 @@ func (g *Game) checkCollision(a, b *Entity) bool {
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
+	g.DrawWorld(screen)
+	g.DrawUI(screen)
+}
+
+// DrawWorld draws the starfield, particles, bullets, and ships; the pixel
+// art mode renders it at low resolution.
+func (g *Game) DrawWorld(screen *ebiten.Image) {
 	// Starfield background
 	screen.Fill(color.RGBA{R: 5, G: 5, B: 20, A: 255})
 	g.drawStars(screen)
@@ -290,13 +358,20 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	if g.player.Active && !g.gameOver {
 		g.drawPlayer(screen)
 	}
+}
 
-	// UI
+// DrawUI draws the HUD and game over screen at native resolution.
+func (g *Game) DrawUI(screen *ebiten.Image) {
+	g.damageDir.Draw(screen)
 	g.drawUI(screen)
 
 	if g.gameOver {
 		g.drawGameOver(screen)
 	}
+
+	if g.paused {
+		ui.DrawPaused(screen, "ESC or P to resume")
+	}
 }
 
 func (g *Game) drawStars(screen *ebiten.Image) {
@@ -348,6 +423,8 @@ func (g *Game) drawUI(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Score: "+formatInt(g.score), 10, 12)
 	ebitenutil.DebugPrintAt(screen, "Level: "+formatInt(g.level), screenWidth/2-30, 12)
 	ebitenutil.DebugPrintAt(screen, "Lives: "+formatInt(g.lives), screenWidth-80, 12)
+
+	g.combo.Draw(screen, 10, 48, 150)
 }
 
 func (g *Game) drawGameOver(screen *ebiten.Image) {
@@ -371,7 +448,7 @@ func (g *Game) drawGameOver(screen *ebiten.Image) {
 
 	ebitenutil.DebugPrintAt(screen, "GAME OVER", int(boxX)+80, int(boxY)+25)
 	ebitenutil.DebugPrintAt(screen, "Score: "+formatInt(g.score), int(boxX)+80, int(boxY)+50)
-	ebitenutil.DebugPrintAt(screen, "Press SPACE to restart", int(boxX)+35, int(boxY)+80)
+	ebitenutil.DebugPrintAt(screen, "Press SPACE or A to restart", int(boxX)+20, int(boxY)+80)
 }
 
 func formatInt(n int) string {
@@ -410,7 +487,13 @@ func main() {
 	ebiten.SetWindowTitle("Space Shooter")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	// Arcade monitor look for the playfield; F4 switches to native
+	pixels := engine.PixelArtConfig{Width: 240, Height: 320, CRT: true, Toggle: true}
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "space_shooter"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	game := engine.WithWindow(engine.WithFocus(engine.WithPixelArt(NewGame(), pixels), focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(game, window.App.Name)); err != nil {
 		log.Fatal(err)
 	}
 }
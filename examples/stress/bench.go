@@ -0,0 +1,155 @@
+package main
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	benchWindow = 120 // Frames each row averages over
+	benchWarmup = 10  // Frames skipped after a change, while caches and buffers settle
+	benchRows   = 12  // Rows kept; the oldest goes first
+)
+
+// Row is the recent frame times of one configuration.
+type Row struct {
+	Options Options
+	Counts  Counts
+
+	update, draw [benchWindow]time.Duration
+	n, next      int
+}
+
+// Frames returns how many frames the averages cover.
+func (r *Row) Frames() int {
+	return r.n
+}
+
+// Update returns the average simulation time per frame.
+func (r *Row) Update() time.Duration {
+	return r.average(&r.update)
+}
+
+// Draw returns the average time per frame spent issuing draw calls. The
+// GPU runs behind that, so it is the CPU's share of drawing.
+func (r *Row) Draw() time.Duration {
+	return r.average(&r.draw)
+}
+
+// Frame returns the average update and draw time together.
+func (r *Row) Frame() time.Duration {
+	return r.Update() + r.Draw()
+}
+
+func (r *Row) average(ring *[benchWindow]time.Duration) time.Duration {
+	if r.n == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, d := range ring[:r.n] {
+		total += d
+	}
+
+	return total / time.Duration(r.n)
+}
+
+// Bench keeps a table of frame times, one row per combination of options
+// and counts that has been run, so switching an optimization on and off
+// compares them side by side.
+type Bench struct {
+	rows    []*Row
+	current *Row
+	warmup  int
+	pending time.Duration // Update time waiting for its frame's draw
+	updated bool
+}
+
+// Select makes the row for o and c current, adding it if it is new, and
+// restarts the warmup.
+func (b *Bench) Select(o Options, c Counts) {
+	b.warmup, b.updated = benchWarmup, false
+
+	i := slices.IndexFunc(b.rows, func(r *Row) bool { return r.Options == o && r.Counts == c })
+	if i >= 0 {
+		b.current = b.rows[i]
+
+		return
+	}
+
+	if len(b.rows) >= benchRows {
+		b.rows = slices.Delete(b.rows, 0, 1)
+	}
+
+	b.current = &Row{Options: o, Counts: c}
+	b.rows = append(b.rows, b.current)
+}
+
+// Current returns the row being measured.
+func (b *Bench) Current() *Row {
+	return b.current
+}
+
+// AddUpdate records the time one update took.
+func (b *Bench) AddUpdate(d time.Duration) {
+	b.pending, b.updated = d, true
+}
+
+// AddDraw records the time one draw took and completes the frame with the
+// last update. Draws without an update since the last, as when the game
+// runs more frames than ticks, are not recorded.
+func (b *Bench) AddDraw(d time.Duration) {
+	r := b.current
+	if r == nil || !b.updated {
+		return
+	}
+
+	b.updated = false
+
+	if b.warmup > 0 {
+		b.warmup--
+
+		return
+	}
+
+	r.update[r.next], r.draw[r.next] = b.pending, d
+	r.next = (r.next + 1) % benchWindow
+	r.n = min(r.n+1, benchWindow)
+}
+
+// Rows returns the table, slowest frames first, leaving out rows that
+// have not recorded a frame yet.
+func (b *Bench) Rows() []*Row {
+	var rows []*Row
+
+	for _, r := range b.rows {
+		if r.n > 0 {
+			rows = append(rows, r)
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b *Row) int { return int(b.Frame() - a.Frame()) })
+
+	return rows
+}
+
+// Baseline returns the row for the same counts with every optimization
+// off, or nil if it has not been measured.
+func (b *Bench) Baseline(r *Row) *Row {
+	for _, base := range b.rows {
+		if base.Counts == r.Counts && base.Options == (Options{}) && base.n > 0 {
+			return base
+		}
+	}
+
+	return nil
+}
+
+// Reset clears the table, keeping only the current configuration.
+func (b *Bench) Reset() {
+	b.rows = nil
+
+	if r := b.current; r != nil {
+		b.Select(r.Options, r.Counts)
+	}
+}
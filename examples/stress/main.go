@@ -0,0 +1,281 @@
+// Stress is the engine's benchmark playground: a scene of enemies,
+// projectiles, and particles at counts you choose, with each optimization
+// switched on and off while a table compares the frame times. The scene
+// runs on the engine's ECS world, MovementSystem, and SpatialHash, so
+// changes to them move its numbers.
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/debug"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+const (
+	screenWidth  = 960
+	screenHeight = 720
+
+	maxCount = 1 << 16 // Per kind of entity
+	minCount = 64      // Doubling from zero starts here
+	dotSize  = 16      // Side of the dot texture the batched path draws
+)
+
+var (
+	enemyColor      = color.NRGBA{R: 230, G: 70, B: 70, A: 255}
+	projectileColor = color.NRGBA{R: 255, G: 230, B: 90, A: 255}
+	particleColor   = color.NRGBA{R: 255, G: 150, B: 60, A: 255}
+)
+
+// defaultCounts is a crowd a little past what survivor reaches late in a run.
+var defaultCounts = Counts{Enemies: 1000, Projectiles: 500, Particles: 2000}
+
+// countKeys double and halve each entity count.
+var countKeys = []struct {
+	up, down ebiten.Key
+	count    func(*Counts) *int
+}{
+	{ebiten.KeyQ, ebiten.KeyA, func(c *Counts) *int { return &c.Enemies }},
+	{ebiten.KeyW, ebiten.KeyS, func(c *Counts) *int { return &c.Projectiles }},
+	{ebiten.KeyE, ebiten.KeyD, func(c *Counts) *int { return &c.Particles }},
+}
+
+// Game runs the scene and times it.
+type Game struct {
+	sim       *Sim
+	bench     Bench
+	paused    bool
+	inspector *debug.Inspector // F12: entity counts and the system order with timings
+
+	dot      *ebiten.Image
+	vertices []ebiten.Vertex
+	indices  []uint32
+}
+
+// NewGame creates the scene at the default counts with every optimization off.
+func NewGame() *Game {
+	dot := ebiten.NewImage(dotSize, dotSize)
+	vector.FillCircle(dot, dotSize/2, dotSize/2, dotSize/2, color.White, true)
+
+	g := &Game{sim: NewSim(screenWidth, screenHeight, 1, defaultCounts, Options{}), dot: dot}
+	g.bench.Select(g.sim.Options, g.sim.Counts)
+	g.inspector = debug.NewInspector(&g.sim.World)
+	g.inspector.SetScheduler(g.sim.Schedule())
+
+	return g
+}
+
+// Update handles the controls and steps the scene, timing the step.
+func (g *Game) Update() error {
+	opts, counts := g.sim.Options, g.sim.Counts
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		opts.SpatialHash = !opts.SpatialHash
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+		opts.Batching = !opts.Batching
+	}
+
+	for _, k := range countKeys {
+		n := k.count(&counts)
+
+		switch {
+		case inpututil.IsKeyJustPressed(k.up):
+			*n = min(max(*n*2, minCount), maxCount)
+		case inpututil.IsKeyJustPressed(k.down):
+			*n /= 2
+			if *n < minCount {
+				*n = 0
+			}
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.bench.Reset()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.paused = !g.paused
+	}
+
+	g.apply(opts, counts)
+	g.inspector.Update()
+
+	if g.paused {
+		return nil
+	}
+
+	start := time.Now()
+	g.sim.Step()
+	g.bench.AddUpdate(time.Since(start))
+
+	return nil
+}
+
+// apply switches the scene to new options and counts and starts a row for
+// them.
+func (g *Game) apply(o Options, c Counts) {
+	if o == g.sim.Options && c == g.sim.Counts {
+		return
+	}
+
+	g.sim.SetOptions(o)
+	g.sim.SetCounts(c)
+	g.bench.Select(o, c)
+}
+
+// Draw draws the scene, timing it, and then the HUD, which is not timed.
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{R: 12, G: 12, B: 20, A: 255})
+
+	start := time.Now()
+
+	if g.sim.Options.Batching {
+		g.drawBatched(screen)
+	} else {
+		g.drawEach(screen)
+	}
+
+	g.bench.AddDraw(time.Since(start))
+
+	g.drawHUD(screen)
+	g.inspector.Draw(screen)
+}
+
+// drawEach draws every entity with its own vector.FillCircle call, the way
+// most examples draw.
+func (g *Game) drawEach(screen *ebiten.Image) {
+	s := g.sim
+
+	s.particles.each(func(x, y, left float64) {
+		vector.FillCircle(screen, float32(x), float32(y), particleRadius, fade(particleColor, left), true)
+	})
+	s.enemies.each(func(x, y, _ float64) {
+		vector.FillCircle(screen, float32(x), float32(y), enemyRadius, enemyColor, true)
+	})
+	s.projectiles.each(func(x, y, _ float64) {
+		vector.FillCircle(screen, float32(x), float32(y), projectileRadius, projectileColor, true)
+	})
+}
+
+// drawBatched writes every entity as a quad of the dot texture and draws
+// them all in one call.
+func (g *Game) drawBatched(screen *ebiten.Image) {
+	s := g.sim
+	g.vertices, g.indices = g.vertices[:0], g.indices[:0]
+
+	s.particles.each(func(x, y, left float64) { g.quad(x, y, particleRadius, fade(particleColor, left)) })
+	s.enemies.each(func(x, y, _ float64) { g.quad(x, y, enemyRadius, enemyColor) })
+	s.projectiles.each(func(x, y, _ float64) { g.quad(x, y, projectileRadius, projectileColor) })
+
+	opts := &ebiten.DrawTrianglesOptions{Filter: ebiten.FilterLinear}
+	screen.DrawTriangles32(g.vertices, g.indices, g.dot, opts)
+}
+
+// quad appends a dot of radius r centered on (x, y).
+func (g *Game) quad(x, y, r float64, c color.NRGBA) {
+	base := uint32(len(g.vertices))
+	cr, cg, cb, ca := float32(c.R)/255, float32(c.G)/255, float32(c.B)/255, float32(c.A)/255
+
+	for _, corner := range [4][2]float32{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		g.vertices = append(g.vertices, ebiten.Vertex{
+			DstX: float32(x-r) + corner[0]*float32(2*r), DstY: float32(y-r) + corner[1]*float32(2*r),
+			SrcX: corner[0] * dotSize, SrcY: corner[1] * dotSize,
+			ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca,
+		})
+	}
+
+	g.indices = append(g.indices, base, base+1, base+2, base+1, base+3, base+2)
+}
+
+// fade dims a particle's color as its life runs out.
+func fade(c color.NRGBA, life float64) color.NRGBA {
+	c.A = uint8(255 * min(max(life/particleLife, 0), 1))
+
+	return c
+}
+
+func (g *Game) drawHUD(screen *ebiten.Image) {
+	s := g.sim
+	vector.FillRect(screen, 0, 0, screenWidth, 44, color.RGBA{A: 200}, false)
+
+	status := fmt.Sprintf("FPS %.0f  TPS %.0f   %s   enemies %d  projectiles %d  particles %d",
+		ebiten.ActualFPS(), ebiten.ActualTPS(), s.Options.Label(),
+		s.Counts.Enemies, s.Counts.Projectiles, s.Counts.Particles)
+	status += fmt.Sprintf("   hits %d  checks %d", s.Hits, s.Checks)
+	if g.paused {
+		status += "   PAUSED"
+	}
+
+	ebitenutil.DebugPrintAt(screen, status, 8, 4)
+	ebitenutil.DebugPrintAt(screen, "H hash  B batching   Q/A enemies  W/S projectiles  "+
+		"E/D particles (x2 / /2)   R reset table  SPACE pause  F12 systems", 8, 22)
+
+	g.drawTable(screen)
+}
+
+// drawTable draws the comparison table in the bottom-left corner.
+func (g *Game) drawTable(screen *ebiten.Image) {
+	rows := g.bench.Rows()
+	if len(rows) == 0 {
+		return
+	}
+
+	const lineHeight = 16
+
+	h := float32((len(rows) + 1) * lineHeight)
+	y := float32(screenHeight) - h - 12
+	vector.FillRect(screen, 0, y-6, 560, h+18, color.RGBA{A: 200}, false)
+
+	header := fmt.Sprintf("  %-16s %-17s %8s %8s %8s %8s",
+		"options", "enemy/proj/part", "update", "draw", "frame", "speedup")
+	ebitenutil.DebugPrintAt(screen, header, 8, int(y))
+
+	for i, r := range rows {
+		mark := " "
+		if r == g.bench.Current() {
+			mark = ">"
+		}
+
+		speedup := "-"
+		if base := g.bench.Baseline(r); base != nil && r.Frame() > 0 {
+			speedup = fmt.Sprintf("x%.2f", float64(base.Frame())/float64(r.Frame()))
+		}
+
+		line := fmt.Sprintf("%s %-16s %-17s %8s %8s %8s %8s", mark, r.Options.Label(), r.Counts,
+			millis(r.Update()), millis(r.Draw()), millis(r.Frame()), speedup)
+		ebitenutil.DebugPrintAt(screen, line, 8, int(y)+(i+1)*lineHeight)
+	}
+}
+
+func millis(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", d.Seconds()*1000)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func main() {
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Stress Test")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+
+	// Times taken in the background mean nothing, so pause then. Running
+	// the benchmark earns no framework tokens.
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "stress"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause}
+
+	if err := ebiten.RunGame(engine.WithWindow(engine.WithFocus(NewGame(), focus), window)); err != nil {
+		log.Fatal(err)
+	}
+}
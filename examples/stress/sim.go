@@ -0,0 +1,459 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/mlange-42/ark/ecs"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/rng"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+)
+
+// Scene tuning. Enemies walk in from the edges toward the center, where the
+// player would stand; projectiles fan out from it.
+const (
+	enemyRadius      = 6
+	projectileRadius = 2
+	particleRadius   = 2
+	hitDist          = enemyRadius + projectileRadius
+
+	enemySpeed      = 40  // Pixels per second
+	projectileSpeed = 320 // Pixels per second
+	projectileLife  = 2.5 // Seconds before a miss is recycled
+	particleSpeed   = 90  // Top launch speed, pixels per second
+	particleLife    = 0.8 // Top lifetime in seconds
+	particleDrag    = 2.5 // Share of particle speed lost per second
+	coreRadius      = 12  // Enemies that reach the center start over
+
+	hashCell = 32       // Spatial hash cell size; at least hitDist
+	tick     = 1.0 / 60 // Seconds per step
+
+	// goldenAngle spreads successive shots evenly around the center.
+	goldenAngle = math.Pi * (3 - 2.23606797749979)
+)
+
+// Counts is how many of each entity the scene keeps alive.
+type Counts struct {
+	Enemies, Projectiles, Particles int
+}
+
+func (c Counts) String() string {
+	return strconv.Itoa(c.Enemies) + "/" + strconv.Itoa(c.Projectiles) + "/" + strconv.Itoa(c.Particles)
+}
+
+// Options are the optimizations being compared. The scene does the same
+// work either way; only how it does it changes.
+type Options struct {
+	SpatialHash bool // Projectiles query the engine's SpatialHash, not every enemy
+	Batching    bool // Everything draws in one DrawTriangles32 call, not one call per entity
+}
+
+// Label names the enabled optimizations, e.g. "hash+batch", or "none".
+func (o Options) Label() string {
+	var on []string
+
+	for _, opt := range []struct {
+		on   bool
+		name string
+	}{{o.SpatialHash, "hash"}, {o.Batching, "batch"}} {
+		if opt.on {
+			on = append(on, opt.name)
+		}
+	}
+
+	if len(on) == 0 {
+		return "none"
+	}
+
+	return strings.Join(on, "+")
+}
+
+// body is one entity's state, as spawned and as tests compare it. Velocity
+// is in pixels per tick, the way the engine's MovementSystem applies it.
+// Life counts down for projectiles and particles; enemies ignore it.
+type body struct {
+	X, Y, VX, VY, Life float64
+}
+
+// life is the seconds a projectile or particle has left.
+type life struct {
+	Left float64
+}
+
+// Kind tags, so each pool queries only its own entities.
+type (
+	enemyTag      struct{}
+	projectileTag struct{}
+	particleTag   struct{}
+)
+
+// pool is one kind of entity in the ECS world. Entities are never removed
+// while the count holds, only respawned in place, so each keeps its slot:
+// the order hit tests prefer enemies in.
+type pool struct {
+	world    *ecs.World
+	entities []ecs.Entity // By slot
+	slots    map[ecs.Entity]int
+	bodies   *ecs.Map3[components.Position, components.Velocity, life]
+	filter   *ecs.Filter3[components.Position, components.Velocity, life]
+	create   func() ecs.Entity
+}
+
+// newPool creates the pool of entities tagged K.
+func newPool[K any](world *ecs.World) pool {
+	create := ecs.NewMap4[components.Position, components.Velocity, life, K](world)
+
+	return pool{
+		world:  world,
+		slots:  make(map[ecs.Entity]int),
+		bodies: ecs.NewMap3[components.Position, components.Velocity, life](world),
+		filter: ecs.NewFilter3[components.Position, components.Velocity, life](world).With(ecs.C[K]()),
+		create: func() ecs.Entity {
+			return create.NewEntity(&components.Position{}, &components.Velocity{}, &life{}, new(K))
+		},
+	}
+}
+
+// Len returns the number of entities.
+func (p *pool) Len() int {
+	return len(p.entities)
+}
+
+func (p *pool) at(i int) body {
+	pos, vel, l := p.bodies.Get(p.entities[i])
+
+	return body{X: pos.X, Y: pos.Y, VX: vel.X, VY: vel.Y, Life: l.Left}
+}
+
+func (p *pool) set(i int, b body) {
+	pos, vel, l := p.bodies.Get(p.entities[i])
+	pos.X, pos.Y, vel.X, vel.Y, l.Left = b.X, b.Y, b.VX, b.VY, b.Life
+}
+
+func (p *pool) pos(i int) (float64, float64) {
+	pos, _, _ := p.bodies.Get(p.entities[i])
+
+	return pos.X, pos.Y
+}
+
+func (p *pool) push(b body) {
+	e := p.create()
+	p.slots[e] = len(p.entities)
+	p.entities = append(p.entities, e)
+	p.set(len(p.entities)-1, b)
+}
+
+// resize grows the pool with spawned entities or removes the newest ones.
+func (p *pool) resize(n int, spawn func() body) {
+	for p.Len() < n {
+		p.push(spawn())
+	}
+
+	for _, e := range p.entities[n:] {
+		delete(p.slots, e)
+		p.world.RemoveEntity(e)
+	}
+
+	p.entities = p.entities[:n]
+}
+
+// each calls fn with every entity's position and life, in query order.
+func (p *pool) each(fn func(x, y, left float64)) {
+	query := p.filter.Query()
+	for query.Next() {
+		pos, _, l := query.Get()
+		fn(pos.X, pos.Y, l.Left)
+	}
+}
+
+// age slows every entity by drag and counts its life down by a tick.
+func (p *pool) age(drag float64) {
+	keep := math.Max(0, 1-drag*tick)
+
+	query := p.filter.Query()
+	for query.Next() {
+		_, vel, l := query.Get()
+		vel.X *= keep
+		vel.Y *= keep
+		l.Left -= tick
+	}
+}
+
+// seek points every entity at (x, y) at speed pixels per second.
+func (p *pool) seek(x, y, speed float64) {
+	query := p.filter.Query()
+	for query.Next() {
+		pos, vel, _ := query.Get()
+		vel.X, vel.Y = toward(pos.X, pos.Y, x, y, speed*tick)
+	}
+}
+
+func toward(fromX, fromY, toX, toY, speed float64) (float64, float64) {
+	dx, dy := toX-fromX, toY-fromY
+
+	d := math.Hypot(dx, dy)
+	if d == 0 {
+		return 0, 0
+	}
+
+	return dx / d * speed, dy / d * speed
+}
+
+// Sim is the scene's simulation: enemies, projectiles, and particles at
+// fixed counts, recycled as they die so the load stays steady. They live
+// in an ark ECS world and each step runs through the engine's scheduler
+// and MovementSystem, so work on any of them shows up in the table.
+type Sim struct {
+	Width, Height float64
+	Counts        Counts
+	Options       Options
+
+	// Per-step work, for the HUD and to check the optimizations change
+	// nothing: projectile hits and enemy distance tests.
+	Hits, Checks int
+
+	World                           ecs.World
+	enemies, projectiles, particles pool
+
+	schedule *engine.Scheduler
+	hash     *systems.SpatialHash
+	hit      []bool // Enemies already hit this step
+	rng      *rand.Rand
+	shots    int     // Projectiles fired so far, for the spread
+	lastX    float64 // Where the last hit landed, for new particles
+	lastY    float64
+	haveLast bool
+}
+
+// NewSim creates a scene of the given size. The same seed, counts, and
+// steps always play out the same, whatever the options.
+func NewSim(width, height int, seed int64, c Counts, o Options) *Sim {
+	s := &Sim{
+		Width:   float64(width),
+		Height:  float64(height),
+		World:   ecs.NewWorld(),
+		Options: o,
+		hash:    systems.NewSpatialHash(hashCell),
+		rng:     rng.New(seed).Stream("stress"),
+	}
+	s.enemies = newPool[enemyTag](&s.World)
+	s.projectiles = newPool[projectileTag](&s.World)
+	s.particles = newPool[particleTag](&s.World)
+	s.schedule = s.newSchedule()
+	s.SetCounts(c)
+
+	return s
+}
+
+// SetCounts spawns or drops entities to match c.
+func (s *Sim) SetCounts(c Counts) {
+	c.Enemies, c.Projectiles, c.Particles = max(c.Enemies, 0), max(c.Projectiles, 0), max(c.Particles, 0)
+	s.Counts = c
+	s.enemies.resize(c.Enemies, s.spawnEnemy)
+	s.projectiles.resize(c.Projectiles, s.spawnProjectile)
+	s.particles.resize(c.Particles, s.spawnParticle)
+}
+
+// SetOptions switches optimizations.
+func (s *Sim) SetOptions(o Options) {
+	s.Options = o
+}
+
+// Step advances the scene by one tick, running its systems in the order
+// the scheduler resolved.
+func (s *Sim) Step() {
+	if err := s.schedule.Update(&s.World); err != nil {
+		panic("stress: " + err.Error())
+	}
+}
+
+// Schedule returns the scheduler running the scene's systems, for the debug
+// overlay.
+func (s *Sim) Schedule() *engine.Scheduler {
+	return s.schedule
+}
+
+// newSchedule registers the scene's systems around the engine's
+// MovementSystem: enemies turn toward the center before moving, and hits
+// and recycling follow.
+func (s *Sim) newSchedule() *engine.Scheduler {
+	sched := engine.NewScheduler()
+	specs := []engine.SystemSpec{
+		{Name: "seek", System: engine.SystemFunc(s.seek), Before: []string{systems.MovementSystemName}},
+		systems.NewMovementSystem(&s.World).Spec(),
+		{Name: "age", System: engine.SystemFunc(s.age), After: []string{systems.MovementSystemName}},
+		{Name: "hits", System: engine.SystemFunc(s.collide), After: []string{"age"}},
+		{Name: "recycle", System: engine.SystemFunc(s.recycle), After: []string{"hits"}},
+	}
+
+	for _, spec := range specs {
+		if err := sched.Register(spec); err != nil {
+			panic("stress: " + err.Error())
+		}
+	}
+
+	if err := sched.Resolve(); err != nil {
+		panic("stress: " + err.Error())
+	}
+
+	return sched
+}
+
+// seek turns every enemy toward the center.
+func (s *Sim) seek(*ecs.World) {
+	s.enemies.seek(s.Width/2, s.Height/2, enemySpeed)
+}
+
+// age slows particles and runs down projectile and particle lives.
+func (s *Sim) age(*ecs.World) {
+	s.projectiles.age(0)
+	s.particles.age(particleDrag)
+}
+
+// recycle respawns enemies that reached the center and projectiles and
+// particles that ran out of life.
+func (s *Sim) recycle(*ecs.World) {
+	cx, cy := s.Width/2, s.Height/2
+
+	for i := range s.enemies.Len() {
+		if x, y := s.enemies.pos(i); math.Hypot(x-cx, y-cy) < coreRadius {
+			s.enemies.set(i, s.spawnEnemy())
+		}
+	}
+
+	for i := range s.projectiles.Len() {
+		if s.projectiles.at(i).Life <= 0 {
+			s.projectiles.set(i, s.spawnProjectile())
+		}
+	}
+
+	for i := range s.particles.Len() {
+		if s.particles.at(i).Life <= 0 {
+			s.particles.set(i, s.spawnParticle())
+		}
+	}
+}
+
+// collide lets each projectile, in order, hit the lowest-numbered enemy in
+// reach that nothing else hit this step. Both the brute-force and hashed
+// paths pick the same enemy, so only Checks differs between them.
+func (s *Sim) collide(*ecs.World) {
+	n := s.enemies.Len()
+	s.hit = append(s.hit[:0], make([]bool, n)...)
+	s.Hits, s.Checks = 0, 0
+
+	if s.Options.SpatialHash {
+		s.hash.Clear()
+
+		for i, e := range s.enemies.entities {
+			x, y := s.enemies.pos(i)
+			s.hash.Insert(e, x, y, 0, 0)
+		}
+	}
+
+	for i := range s.projectiles.Len() {
+		px, py := s.projectiles.pos(i)
+
+		target := -1
+		if s.Options.SpatialHash {
+			target = s.nearbyTarget(px, py)
+		} else {
+			for j := range n {
+				if s.reaches(j, px, py) {
+					target = j
+
+					break
+				}
+			}
+		}
+
+		if target < 0 {
+			continue
+		}
+
+		s.hit[target] = true
+		s.Hits++
+		s.lastX, s.lastY, s.haveLast = px, py, true
+		s.projectiles.set(i, s.spawnProjectile())
+		s.enemies.set(target, s.spawnEnemy())
+	}
+}
+
+// nearbyTarget asks the spatial hash for the enemies near (x, y) and
+// returns the lowest-numbered one in reach.
+func (s *Sim) nearbyTarget(x, y float64) int {
+	target := -1
+
+	for _, e := range s.hash.Query(x-hitDist, y-hitDist, 2*hitDist, 2*hitDist) {
+		j := s.enemies.slots[e]
+		if (target < 0 || j < target) && s.reaches(j, x, y) {
+			target = j
+		}
+	}
+
+	return target
+}
+
+// reaches reports whether enemy j is unhit and within hitDist of (x, y).
+func (s *Sim) reaches(j int, x, y float64) bool {
+	s.Checks++
+
+	if s.hit[j] {
+		return false
+	}
+
+	ex, ey := s.enemies.pos(j)
+	dx, dy := ex-x, ey-y
+
+	return dx*dx+dy*dy < hitDist*hitDist
+}
+
+// spawnEnemy places an enemy just outside a random screen edge.
+func (s *Sim) spawnEnemy() body {
+	t := s.rng.Float64()
+
+	switch s.rng.Intn(4) {
+	case 0:
+		return body{X: t * s.Width, Y: -enemyRadius}
+	case 1:
+		return body{X: t * s.Width, Y: s.Height + enemyRadius}
+	case 2:
+		return body{X: -enemyRadius, Y: t * s.Height}
+	}
+
+	return body{X: s.Width + enemyRadius, Y: t * s.Height}
+}
+
+// spawnProjectile fires from the center, each shot turned a golden angle
+// from the last.
+func (s *Sim) spawnProjectile() body {
+	angle := float64(s.shots) * goldenAngle
+	s.shots++
+
+	return body{
+		X: s.Width / 2, Y: s.Height / 2,
+		VX: math.Cos(angle) * projectileSpeed * tick, VY: math.Sin(angle) * projectileSpeed * tick,
+		Life: projectileLife,
+	}
+}
+
+// spawnParticle bursts from the last hit, or anywhere before the first.
+func (s *Sim) spawnParticle() body {
+	x, y := s.lastX, s.lastY
+	if !s.haveLast {
+		x, y = s.rng.Float64()*s.Width, s.rng.Float64()*s.Height
+	}
+
+	angle := s.rng.Float64() * 2 * math.Pi
+	speed := s.rng.Float64() * particleSpeed
+
+	return body{
+		X: x, Y: y,
+		VX: math.Cos(angle) * speed * tick, VY: math.Sin(angle) * speed * tick,
+		Life: particleLife * (0.5 + 0.5*s.rng.Float64()),
+	}
+}
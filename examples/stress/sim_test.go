@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+)
+
+var testCounts = Counts{Enemies: 400, Projectiles: 300, Particles: 200}
+
+// runSim steps a scene and returns its entities and total hits.
+func runSim(o Options, steps int) ([3][]body, int) {
+	s := NewSim(screenWidth, screenHeight, 7, testCounts, o)
+	hits := 0
+
+	for range steps {
+		s.Step()
+		hits += s.Hits
+	}
+
+	var out [3][]body
+
+	for k, p := range []*pool{&s.enemies, &s.projectiles, &s.particles} {
+		for i := range p.Len() {
+			out[k] = append(out[k], p.at(i))
+		}
+	}
+
+	return out, hits
+}
+
+func TestOptimizationsChangeNothing(t *testing.T) {
+	want, wantHits := runSim(Options{}, 240)
+	if wantHits == 0 {
+		t.Fatal("the scene should land hits for the comparison to mean anything")
+	}
+
+	all := Options{SpatialHash: true, Batching: true}
+	for _, o := range []Options{{SpatialHash: true}, all} {
+		got, hits := runSim(o, 240)
+		if hits != wantHits || fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: %d hits, want %d, and the same entities", o.Label(), hits, wantHits)
+		}
+	}
+}
+
+func TestSpatialHashChecksFewerEnemies(t *testing.T) {
+	brute := NewSim(screenWidth, screenHeight, 7, testCounts, Options{})
+	hashed := NewSim(screenWidth, screenHeight, 7, testCounts, Options{SpatialHash: true})
+
+	brute.Step()
+	hashed.Step()
+
+	if hashed.Checks*10 > brute.Checks {
+		t.Errorf("hashed checks = %d, brute force = %d; want a tenth or fewer", hashed.Checks, brute.Checks)
+	}
+}
+
+func TestResizingKeepsEntities(t *testing.T) {
+	s := NewSim(screenWidth, screenHeight, 3, testCounts, Options{})
+	s.Step()
+
+	before := s.enemies.at(7)
+	s.SetCounts(Counts{Enemies: 10, Projectiles: 900})
+
+	if s.enemies.Len() != 10 || s.projectiles.Len() != 900 || s.particles.Len() != 0 {
+		t.Errorf("counts = %d/%d/%d, want 10/900/0", s.enemies.Len(), s.projectiles.Len(), s.particles.Len())
+	}
+
+	if s.enemies.at(7) != before {
+		t.Errorf("enemy #7 = %+v, want %+v", s.enemies.at(7), before)
+	}
+
+	if n := s.World.Stats().Entities.Used; n != 910 {
+		t.Errorf("world holds %d entities, want 910", n)
+	}
+}
+
+func TestHashFindsEnemiesOffScreen(t *testing.T) {
+	s := NewSim(screenWidth, screenHeight, 1, Counts{}, Options{SpatialHash: true})
+	s.enemies.push(body{X: -5, Y: 300})
+	s.projectiles.push(body{X: 1, Y: 300, Life: 1})
+	s.Counts = Counts{Enemies: 1, Projectiles: 1}
+
+	s.collide(&s.World)
+
+	if s.Hits != 1 {
+		t.Error("a projectile at the edge should hit an enemy just off screen")
+	}
+}
+
+func TestStepRunsSystemsInDependencyOrder(t *testing.T) {
+	s := NewSim(screenWidth, screenHeight, 1, testCounts, Options{})
+
+	order, err := s.Schedule().Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"seek", systems.MovementSystemName, "age", "hits", "recycle"}
+	if !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestBenchComparesConfigurations(t *testing.T) {
+	var b Bench
+
+	record := func(o Options, update time.Duration, frames int) {
+		b.Select(o, testCounts)
+
+		for range benchWarmup + frames {
+			b.AddUpdate(update)
+			b.AddDraw(time.Millisecond)
+			b.AddDraw(time.Hour) // A second draw in the same tick is not a frame
+		}
+	}
+
+	record(Options{}, 9*time.Millisecond, 20)
+	record(Options{SpatialHash: true}, 3*time.Millisecond, benchWindow+30)
+
+	rows := b.Rows()
+	if len(rows) != 2 || rows[0].Options != (Options{}) {
+		t.Fatalf("rows = %+v, want the slower baseline first", rows)
+	}
+
+	fast := rows[1]
+	if fast.Frames() != benchWindow || fast.Update() != 3*time.Millisecond || fast.Frame() != 4*time.Millisecond {
+		t.Errorf("hash row: %d frames, update %v, frame %v", fast.Frames(), fast.Update(), fast.Frame())
+	}
+
+	if base := b.Baseline(fast); base != rows[0] || base.Frame() != 10*time.Millisecond {
+		t.Errorf("baseline = %+v", base)
+	}
+
+	b.Reset()
+
+	if len(b.Rows()) != 0 || b.Current().Options != (Options{SpatialHash: true}) {
+		t.Error("reset should empty the table and keep measuring the current configuration")
+	}
+}
+
+// BenchmarkStep times one simulation step with and without the spatial
+// hash: go test -bench Step ./examples/stress
+func BenchmarkStep(b *testing.B) {
+	counts := Counts{Enemies: 4000, Projectiles: 2000, Particles: 8000}
+
+	for _, o := range []Options{{}, {SpatialHash: true}} {
+		b.Run(o.Label(), func(b *testing.B) {
+			s := NewSim(screenWidth, screenHeight, 1, counts, o)
+
+			for b.Loop() {
+				s.Step()
+			}
+		})
+	}
+}
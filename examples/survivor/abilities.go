@@ -0,0 +1,184 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// Active ability IDs.
+const (
+	AbilityDash  = "dash"
+	AbilityTaunt = "taunt"
+	AbilitySlow  = "slow"
+)
+
+const (
+	dashSpeedMult  = 4.0
+	tauntRadius    = 220.0
+	timeSlowFactor = 0.3 // Enemy speed multiplier while time is slowed
+)
+
+// abilityKeys and abilityButtons bind ability slots to keyboard and gamepad.
+var (
+	abilityKeys    = []ebiten.Key{ebiten.KeySpace, ebiten.KeyE}
+	abilityButtons = []ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonRightBottom,
+		ebiten.StandardGamepadButtonRightRight,
+	}
+)
+
+// newAbility returns a fresh instance of an active ability.
+func newAbility(id string) components.Ability {
+	switch id {
+	case AbilityDash:
+		return components.NewAbility(AbilityDash, "Hotfix Sprint", 3, 0.2)
+	case AbilityTaunt:
+		return components.NewAbility(AbilityTaunt, "Stand-up Meeting", 12, 3)
+	default:
+		return components.NewAbility(AbilitySlow, "Code Freeze", 20, 4)
+	}
+}
+
+// CharacterAbilities lists the two active abilities each character starts with.
+var CharacterAbilities = map[CharacterType][2]string{
+	CharJunior:   {AbilityDash, AbilityTaunt},
+	CharSenior:   {AbilitySlow, AbilityTaunt},
+	CharTechLead: {AbilityTaunt, AbilitySlow},
+	Char10x:      {AbilityDash, AbilitySlow},
+}
+
+// newPlayerAbilities creates the ability set for a character.
+func newPlayerAbilities(charType CharacterType) components.Abilities {
+	ids := CharacterAbilities[charType]
+
+	return components.NewAbilities(newAbility(ids[0]), newAbility(ids[1]))
+}
+
+// abilityPressed reports whether the slot's key or gamepad button was just pressed.
+func abilityPressed(slot int) bool {
+	return controls.JustPressed(actionAbility1 + input.Action(slot))
+}
+
+// updateAbilities ticks cooldowns, reads ability input, and moves the player while dashing.
+func (g *Game) updateAbilities(dt float64) {
+	p := g.player
+	p.Abilities.Update(dt)
+
+	for slot := range p.Abilities.Slots {
+		if abilityPressed(slot) {
+			g.activateAbility(slot)
+		}
+	}
+
+	if p.Abilities.IsActive(AbilityDash) {
+		p.X += p.FaceX * p.Speed * dashSpeedMult
+		p.Y += p.FaceY * p.Speed * dashSpeedMult
+	}
+}
+
+// activateAbility triggers the ability in a slot and applies its start effect.
+func (g *Game) activateAbility(slot int) bool {
+	p := g.player
+	if !p.Abilities.Activate(slot) {
+		return false
+	}
+
+	a := p.Abilities.Slots[slot]
+
+	switch a.ID {
+	case AbilityDash:
+		// Invulnerable for the whole dash
+		p.HitTimer = math.Max(p.HitTimer, a.Duration)
+
+		if p.FaceX == 0 && p.FaceY == 0 {
+			p.FaceX = 1
+		}
+
+		g.spawnParticle(p.X, p.Y, 10, Characters[p.CharType].Color)
+	case AbilityTaunt:
+		g.tauntX, g.tauntY = p.X, p.Y
+		g.spawnParticle(p.X, p.Y, 20, color.RGBA{R: 255, G: 120, B: 80, A: 255})
+	case AbilitySlow:
+		g.spawnParticle(p.X, p.Y, 20, color.RGBA{R: 120, G: 200, B: 255, A: 255})
+	}
+
+	return true
+}
+
+// enemyTarget returns where an enemy should walk to, honoring an active taunt.
+func (g *Game) enemyTarget(e *Enemy) (x, y float64) {
+	if g.player.Abilities.IsActive(AbilityTaunt) && !e.IsBoss &&
+		math.Hypot(e.X-g.tauntX, e.Y-g.tauntY) < tauntRadius {
+		return g.tauntX, g.tauntY
+	}
+
+	return g.player.X, g.player.Y
+}
+
+// enemySpeedScale returns the enemy movement multiplier from active abilities.
+func (g *Game) enemySpeedScale() float64 {
+	if g.player.Abilities.IsActive(AbilitySlow) {
+		return timeSlowFactor
+	}
+
+	return 1
+}
+
+// drawAbilityEffects draws world-space ability visuals such as the taunt area.
+func (g *Game) drawAbilityEffects(screen *ebiten.Image) {
+	if g.player.Abilities.IsActive(AbilityTaunt) {
+		sx, sy := float32(g.tauntX-g.cameraX), float32(g.tauntY-g.cameraY)
+		vector.StrokeCircle(screen, sx, sy, tauntRadius, 2, color.RGBA{R: 255, G: 120, B: 80, A: 160}, false)
+		vector.FillCircle(screen, sx, sy, 10, color.RGBA{R: 255, G: 120, B: 80, A: 255}, false)
+	}
+
+	if g.player.Abilities.IsActive(AbilitySlow) {
+		vector.FillRect(screen, 0, 0, screenWidth, screenHeight, color.RGBA{R: 20, G: 40, B: 80, A: 60}, false)
+	}
+}
+
+// drawAbilityHUD draws the ability slots with cooldown sweeps and key hints.
+func (g *Game) drawAbilityHUD(screen *ebiten.Image) {
+	const size = 50
+
+	for slot, a := range g.player.Abilities.Slots {
+		x := float32(screenWidth/2 - size - 5 + slot*(size+10))
+		y := float32(screenHeight - size - 10)
+
+		fill := color.RGBA{R: 60, G: 60, B: 80, A: 230}
+		if a.IsActive() {
+			fill = color.RGBA{R: 90, G: 140, B: 90, A: 230}
+		}
+
+		vector.FillRect(screen, x, y, size, size, fill, false)
+
+		// Darken the unrecovered part of the cooldown from the top down
+		if !a.IsReady() {
+			remaining := float32(1 - a.Cooldown.Progress())
+			vector.FillRect(screen, x, y, size, size*remaining, color.RGBA{R: 0, G: 0, B: 0, A: 170}, false)
+			ebitenutil.DebugPrintAt(screen, formatInt(int(math.Ceil(a.Cooldown.Remaining))), int(x)+20, int(y)+18)
+		}
+
+		vector.StrokeRect(screen, x, y, size, size, 2, color.RGBA{R: 255, G: 255, B: 255, A: 150}, false)
+		ebitenutil.DebugPrintAt(screen, abilityKeyName(slot), int(x)+3, int(y)+2)
+		ebitenutil.DebugPrintAt(screen, abilityShortName(a), int(x)+3, int(y)+size-16)
+	}
+}
+
+// abilityShortName abbreviates an ability name to fit in its HUD slot.
+func abilityShortName(a components.Ability) string {
+	switch a.ID {
+	case AbilityDash:
+		return "Dash"
+	case AbilityTaunt:
+		return "Taunt"
+	default:
+		return "Slow"
+	}
+}
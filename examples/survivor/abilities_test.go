@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+func TestDashGrantsInvulnerability(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.FaceX = 1
+
+	if !g.activateAbility(0) {
+		t.Fatal("dash should activate on a fresh run")
+	}
+
+	if g.activateAbility(0) {
+		t.Error("dash should be on cooldown after use")
+	}
+
+	startX := g.player.X
+	g.enemies = append(g.enemies, &Enemy{X: startX, Y: 0, HP: 10, MaxHP: 10, Damage: 50, Radius: 10})
+	hp := g.player.HP
+
+	g.updateAbilities(1.0 / 60)
+	g.updateEnemies(1.0 / 60)
+
+	if g.player.X <= startX {
+		t.Error("dash should move the player along the facing direction")
+	}
+
+	if g.player.HP != hp {
+		t.Errorf("player took damage during dash i-frames: %d -> %d", hp, g.player.HP)
+	}
+}
+
+func TestTauntAndTimeSlow(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharTechLead)
+
+	near := &Enemy{X: 100, Y: 0, Speed: 2, Radius: 10}
+	far := &Enemy{X: 1000, Y: 0, Speed: 2, Radius: 10}
+	g.enemies = append(g.enemies, near, far)
+
+	g.activateAbility(0) // Taunt at the origin
+	g.player.X = -300    // Player walks away from the decoy
+
+	if x, _ := g.enemyTarget(near); x != 0 {
+		t.Errorf("taunted enemy should chase the decoy, target x = %v", x)
+	}
+
+	if x, _ := g.enemyTarget(far); x != g.player.X {
+		t.Errorf("enemy outside the taunt should chase the player, target x = %v", x)
+	}
+
+	g.activateAbility(1)
+
+	if s := g.enemySpeedScale(); s != timeSlowFactor {
+		t.Errorf("speed scale = %v, want %v", s, timeSlowFactor)
+	}
+
+	for range 5 * 60 {
+		g.player.Abilities.Update(1.0 / 60)
+	}
+
+	if g.enemySpeedScale() != 1 || g.player.Abilities.IsActive(AbilityTaunt) {
+		t.Error("ability effects should expire")
+	}
+}
@@ -170,16 +170,7 @@ func (a *SurvivorAdapter) Step() error {
 	}
 
 	// Update weapons
-	for _, w := range a.game.player.Weapons {
-		def := WeaponDefs[w.Type]
-		cooldown := def.Cooldown * a.game.player.CooldownMult
-
-		w.Timer += dt
-		if w.Timer >= cooldown {
-			a.game.fireWeapon(w)
-			w.Timer = 0
-		}
-	}
+	a.game.updateWeapons(dt)
 
 	// Update game components
 	a.game.updateProjectiles(dt)
@@ -214,6 +205,16 @@ func (a *SurvivorAdapter) Reset() error {
 	return nil
 }
 
+// qaWorldExtent is the half-size of the square the QA detector treats as in
+// bounds. The streamed world has no edge, so this only catches runaway positions.
+const qaWorldExtent = 100000
+
+// WorldBounds reports a square centered on the spawn point, since the player
+// starts at the origin and may walk in any direction.
+func (a *SurvivorAdapter) WorldBounds() (x, y, width, height float64) {
+	return -qaWorldExtent, -qaWorldExtent, 2 * qaWorldExtent, 2 * qaWorldExtent
+}
+
 // GetGameTime returns the current in-game time.
 func (a *SurvivorAdapter) GetGameTime() float64 {
 	return a.game.gameTime
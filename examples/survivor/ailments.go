@@ -0,0 +1,272 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// AilmentDef tunes a damage-over-time or crowd-control effect. Stacking
+// follows the engine's StatusComponent: a burn, slow, or stun replaces a
+// weaker one of its kind and refreshes an equal one, while poison stacks as
+// independent doses up to MaxStacks, the oldest giving way to a new one.
+type AilmentDef struct {
+	Name       string
+	Status     StatusID // Shown in the status tray
+	Duration   float64  // Seconds
+	Interval   float64  // Seconds between damage ticks; 0 deals no damage
+	DamageMult float64  // Tick damage over the damage of the hit that applied it
+	Slow       float64  // Fraction of movement speed taken away
+	MaxStacks  int      // Doses that stack; 0 for one effect of this kind
+	Color      color.RGBA
+}
+
+// AilmentDefs are the ailments weapons and monsters can inflict.
+var AilmentDefs = map[components.StatusType]AilmentDef{
+	components.StatusIgnite: {
+		Name: "Burn", Status: StatusBurning, Duration: 3, Interval: 0.5, DamageMult: 0.15,
+		Color: color.RGBA{R: 255, G: 130, B: 40, A: 255},
+	},
+	components.StatusPoison: {
+		Name: "Poison", Status: StatusPoisoned, Duration: 4, Interval: 1, DamageMult: 0.5, MaxStacks: 5,
+		Color: color.RGBA{R: 140, G: 220, B: 60, A: 255},
+	},
+	components.StatusChill: {
+		Name: "Slow", Status: StatusHindered, Duration: 2, Slow: 0.4,
+		Color: color.RGBA{R: 120, G: 200, B: 255, A: 255},
+	},
+	components.StatusFreeze: {
+		Name: "Stun", Status: StatusStunned, Duration: 0.6,
+		Color: color.RGBA{R: 255, G: 230, B: 90, A: 255},
+	},
+}
+
+// ailmentOrder lists the ailments in tray and indicator order.
+var ailmentOrder = []components.StatusType{
+	components.StatusIgnite, components.StatusPoison, components.StatusChill, components.StatusFreeze,
+}
+
+// Ailment indicator layout, in pixels.
+const (
+	ailmentPipRadius = 2.5
+	ailmentPipGap    = 7
+)
+
+// inflict applies an ailment to sc from a hit that dealt damage.
+func inflict(sc *components.StatusComponent, st components.StatusType, damage int) {
+	def, ok := AilmentDefs[st]
+	if !ok {
+		return
+	}
+
+	effect := &components.StatusEffect{
+		Type:        st,
+		Duration:    def.Duration,
+		MaxDuration: def.Duration,
+		Interval:    def.Interval,
+		Magnitude:   def.Slow,
+		Stackable:   def.MaxStacks > 0,
+	}
+
+	if def.Interval > 0 {
+		effect.DamagePerTick = max(1, int(float64(damage)*def.DamageMult))
+		effect.Magnitude = float64(effect.DamagePerTick) // A stronger burn wins
+	}
+
+	if effect.Stackable && ailmentStacks(sc, st) >= def.MaxStacks {
+		dropOldest(sc, st)
+	}
+
+	sc.AddEffect(effect)
+}
+
+// ailmentStacks counts the effects of one kind on sc.
+func ailmentStacks(sc *components.StatusComponent, st components.StatusType) int {
+	n := 0
+
+	for _, e := range sc.Effects {
+		if e.Type == st {
+			n++
+		}
+	}
+
+	return n
+}
+
+// dropOldest removes the effect of one kind with the least time left.
+func dropOldest(sc *components.StatusComponent, st components.StatusType) {
+	oldest := -1
+
+	for i, e := range sc.Effects {
+		if e.Type == st && (oldest < 0 || e.Duration < sc.Effects[oldest].Duration) {
+			oldest = i
+		}
+	}
+
+	if oldest >= 0 {
+		sc.Effects = append(sc.Effects[:oldest], sc.Effects[oldest+1:]...)
+	}
+}
+
+// clearAilment removes every effect of one kind from sc.
+func clearAilment(sc *components.StatusComponent, st components.StatusType) {
+	kept := sc.Effects[:0]
+
+	for _, e := range sc.Effects {
+		if e.Type != st {
+			kept = append(kept, e)
+		}
+	}
+
+	sc.Effects = kept
+}
+
+// ailmentSpeed returns the movement speed multiplier of sc's ailments:
+// nothing while stunned, else less the strongest slow.
+func ailmentSpeed(sc *components.StatusComponent) float64 {
+	if sc.HasStatus(components.StatusFreeze) {
+		return 0
+	}
+
+	return 1 - sc.GetStatusMagnitude(components.StatusChill)
+}
+
+// tickAilments advances sc's ailments by dt, calling hurt for each damage
+// tick that falls due, then drops the expired ones. It stops early once hurt
+// returns false.
+func tickAilments(sc *components.StatusComponent, dt float64, hurt func(damage int, def AilmentDef) bool) {
+	for _, e := range sc.Effects {
+		if e.UpdateTick(dt) && e.DamagePerTick > 0 && !hurt(e.DamagePerTick, AilmentDefs[e.Type]) {
+			return
+		}
+	}
+
+	sc.RemoveExpired(dt)
+}
+
+// inflictEnemy applies a weapon's ailment to an enemy it hit for damage.
+// Bosses shrug off stuns.
+func (g *Game) inflictEnemy(e *Enemy, wt WeaponType, damage int) {
+	st := WeaponDefs[wt].Ailment
+	if st == "" || e.Dead || (e.IsBoss && st == components.StatusFreeze) {
+		return
+	}
+
+	inflict(&e.Ailments, st, damage)
+}
+
+// inflictPlayer applies a monster's ailment to the player after its hit
+// landed for damage.
+func (g *Game) inflictPlayer(e *Enemy, damage int) {
+	if e == nil {
+		return
+	}
+
+	if st := MonsterDefs[e.Type].Inflicts; st != "" {
+		inflict(&g.player.Ailments, st, damage)
+	}
+}
+
+// updateEnemyAilments ticks the ailments of every living enemy.
+func (g *Game) updateEnemyAilments(dt float64) {
+	for _, e := range g.enemies {
+		if e.Dead {
+			continue
+		}
+
+		tickAilments(&e.Ailments, dt, func(damage int, def AilmentDef) bool {
+			return !g.ailmentDamageEnemy(e, damage, def)
+		})
+	}
+}
+
+// updatePlayerAilments ticks the player's ailments.
+func (g *Game) updatePlayerAilments(dt float64) {
+	tickAilments(&g.player.Ailments, dt, func(damage int, def AilmentDef) bool {
+		g.losePlayerHP(damage, def.Name)
+
+		return g.state == StatePlaying
+	})
+}
+
+// ailmentDamageEnemy deals an ailment tick to an enemy. Ticks skip crits,
+// life steal, and the hit sound, and resistances already scaled the hit that
+// inflicted the ailment. It returns true if the enemy died.
+func (g *Game) ailmentDamageEnemy(e *Enemy, damage int, def AilmentDef) bool {
+	e.HP -= damage
+
+	g.spawnParticle(e.X, e.Y, 2, def.Color)
+	g.addDamageNumber(e.X, e.Y, damage, false)
+	g.logCombat(combatlog.Entry{
+		Category: combatlog.DamageDealt,
+		Source:   "Player",
+		Target:   MonsterDefs[e.Type].Name,
+		Amount:   damage,
+		Detail:   def.Name,
+	})
+
+	if e.HP <= 0 {
+		g.killEnemy(e)
+
+		return true
+	}
+
+	return false
+}
+
+// ailmentStatuses lists sc's ailments for a status tray: poison as one
+// status counting its doses, with the longest one's time left.
+func ailmentStatuses(sc *components.StatusComponent) []Status {
+	var list []Status
+
+	for _, st := range ailmentOrder {
+		var longest *components.StatusEffect
+
+		for _, e := range sc.Effects {
+			if e.Type == st && (longest == nil || e.Duration > longest.Duration) {
+				longest = e
+			}
+		}
+
+		if longest == nil {
+			continue
+		}
+
+		s := newStatus(AilmentDefs[st].Status, longest.Duration, longest.MaxDuration)
+		s.Stacks = ailmentStacks(sc, st)
+		list = append(list, s)
+	}
+
+	return list
+}
+
+// drawAilmentPips draws a colored pip under an enemy at screen point (sx, sy)
+// for each kind of ailment on it, and rings a stunned one.
+func drawAilmentPips(screen *ebiten.Image, e *Enemy, sx, sy float32) {
+	if len(e.Ailments.Effects) == 0 {
+		return
+	}
+
+	var kinds []AilmentDef
+
+	for _, st := range ailmentOrder {
+		if e.Ailments.HasStatus(st) {
+			kinds = append(kinds, AilmentDefs[st])
+		}
+	}
+
+	y := sy + float32(e.Radius) + 5
+	x := sx - float32(len(kinds)-1)*ailmentPipGap/2
+
+	for i, def := range kinds {
+		vector.FillCircle(screen, x+float32(i)*ailmentPipGap, y, ailmentPipRadius, def.Color, false)
+	}
+
+	if e.Ailments.HasStatus(components.StatusFreeze) {
+		stun := AilmentDefs[components.StatusFreeze].Color
+		vector.StrokeCircle(screen, sx, sy, float32(e.Radius)+3, 2, stun, false)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+func TestBurnKeepsTheStrongestAndRefreshes(t *testing.T) {
+	var sc components.StatusComponent
+
+	inflict(&sc, components.StatusIgnite, 100)
+	inflict(&sc, components.StatusIgnite, 20) // Weaker: ignored
+
+	if len(sc.Effects) != 1 || sc.Effects[0].DamagePerTick != 15 {
+		t.Fatalf("burns = %+v, want one ticking for 15", sc.Effects)
+	}
+
+	tickAilments(&sc, 1, func(int, AilmentDef) bool { return true })
+	inflict(&sc, components.StatusIgnite, 100)
+
+	burn := AilmentDefs[components.StatusIgnite]
+	if len(sc.Effects) != 1 || sc.Effects[0].Duration != burn.Duration {
+		t.Errorf("an equal burn should refresh the duration, got %+v", sc.Effects)
+	}
+}
+
+func TestPoisonStacksUpToTheCap(t *testing.T) {
+	var sc components.StatusComponent
+
+	poison := AilmentDefs[components.StatusPoison]
+
+	for i := range poison.MaxStacks + 1 {
+		inflict(&sc, components.StatusPoison, 10*(i+1))
+		tickAilments(&sc, 0.1, func(int, AilmentDef) bool { return true })
+	}
+
+	if n := ailmentStacks(&sc, components.StatusPoison); n != poison.MaxStacks {
+		t.Fatalf("%d doses, want the cap of %d", n, poison.MaxStacks)
+	}
+
+	// The first dose, the oldest, gave way to the newest
+	for _, e := range sc.Effects {
+		if e.DamagePerTick == 5 {
+			t.Errorf("oldest dose %+v kept past the cap", e)
+		}
+	}
+
+	list := ailmentStatuses(&sc)
+	if len(list) != 1 || list[0].ID != StatusPoisoned || list[0].Stacks != poison.MaxStacks {
+		t.Errorf("statuses = %+v, want one poisoned with %d stacks", list, poison.MaxStacks)
+	}
+}
+
+func TestAilmentSpeed(t *testing.T) {
+	var sc components.StatusComponent
+
+	if s := ailmentSpeed(&sc); s != 1 {
+		t.Fatalf("speed without ailments = %v", s)
+	}
+
+	inflict(&sc, components.StatusChill, 0)
+
+	if s := ailmentSpeed(&sc); s != 1-AilmentDefs[components.StatusChill].Slow {
+		t.Errorf("slowed speed = %v", s)
+	}
+
+	inflict(&sc, components.StatusFreeze, 0)
+
+	if s := ailmentSpeed(&sc); s != 0 {
+		t.Errorf("stunned speed = %v, want 0", s)
+	}
+}
+
+func TestFirewallBurnsOverTime(t *testing.T) {
+	g, dummy := newWeaponTestGame(WeaponFirewall)
+	disableCrits(g)
+
+	g.hitEnemy(dummy, 100, WeaponDefs[WeaponFirewall].Color, WeaponFirewall)
+	hp := dummy.HP
+
+	if !dummy.Ailments.HasStatus(components.StatusIgnite) {
+		t.Fatal("a Firewall hit should burn")
+	}
+
+	burn := AilmentDefs[components.StatusIgnite]
+	for range int(burn.Duration*60) + 5 {
+		g.updateEnemyAilments(1.0 / 60)
+	}
+
+	ticks := int(burn.Duration / burn.Interval)
+	if lost := hp - dummy.HP; lost != ticks*15 {
+		t.Errorf("burn dealt %d, want %d ticks of 15", lost, ticks)
+	}
+
+	if len(dummy.Ailments.Effects) != 0 {
+		t.Errorf("burn outlived its duration: %+v", dummy.Ailments.Effects)
+	}
+}
+
+func TestPoisonTicksCanKill(t *testing.T) {
+	g, dummy := newWeaponTestGame(WeaponMemoryLeak)
+	dummy.HP = 3
+
+	inflict(&dummy.Ailments, components.StatusPoison, 10)
+
+	for range 60 {
+		g.updateEnemyAilments(1.0 / 60)
+	}
+
+	if !dummy.Dead || g.killCount != 1 {
+		t.Errorf("poison tick should kill, dead=%v kills=%d", dummy.Dead, g.killCount)
+	}
+}
+
+func TestMemoryLeakPoisons(t *testing.T) {
+	g, dummy := newWeaponTestGame(WeaponMemoryLeak)
+	dummy.X, dummy.Y = g.player.X+60, g.player.Y
+
+	for range 120 {
+		g.updateWeapons(1.0 / 60)
+		g.updateProjectiles(1.0 / 60)
+	}
+
+	if !dummy.Ailments.HasStatus(components.StatusPoison) {
+		t.Error("Memory Leak should poison what it hits")
+	}
+}
+
+func TestBossesShrugOffStuns(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	def := WeaponDefs[WeaponPrint]
+	defer func() { WeaponDefs[WeaponPrint] = def }()
+
+	stunning := def
+	stunning.Ailment = components.StatusFreeze
+	WeaponDefs[WeaponPrint] = stunning
+
+	boss := &Enemy{IsBoss: true, HP: 100, MaxHP: 100}
+	mob := &Enemy{HP: 100, MaxHP: 100}
+
+	g.inflictEnemy(boss, WeaponPrint, 10)
+	g.inflictEnemy(mob, WeaponPrint, 10)
+
+	if boss.Ailments.HasStatus(components.StatusFreeze) || !mob.Ailments.HasStatus(components.StatusFreeze) {
+		t.Errorf("boss stunned=%v, mob stunned=%v; only the mob should be",
+			boss.Ailments.HasStatus(components.StatusFreeze), mob.Ailments.HasStatus(components.StatusFreeze))
+	}
+}
+
+func TestSpaghettiContactSlowsThePlayer(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	spaghetti := g.spawnMonster(MonsterSpaghetti, g.player.X+5, g.player.Y)
+	spaghetti.Speed = 0
+	g.updateEnemies(1.0 / 60)
+
+	if !g.player.Ailments.HasStatus(components.StatusChill) {
+		t.Fatal("touching Spaghetti Code should slow the player")
+	}
+
+	list := g.playerStatuses()
+	if last := list[len(list)-1]; last.ID != StatusHindered || !last.Debuff {
+		t.Errorf("statuses = %v, want the slow last as a debuff", statusIDs(list))
+	}
+}
+
+func TestDodgeBreaksAStun(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	inflict(&g.player.Ailments, components.StatusFreeze, 0)
+
+	if !g.dodge() || g.player.Ailments.HasStatus(components.StatusFreeze) {
+		t.Error("a dodge should break free of a stun")
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+const (
+	manualAimCone       = math.Pi / 18 // Manual aim snaps to enemies within 10 degrees of facing
+	assistAimCone       = math.Pi / 4  // 45 degrees with aim assist
+	gemMagnetBoost      = 1.75
+	maxDamageReduction  = 0.5
+	damageReductionStep = 0.1
+)
+
+// Help screen rows: audio, theme, graphics, and rumble first, then the assist
+// options, then the key rebinding screen.
+const (
+	helpRowSFX = iota
+	helpRowMusic
+	helpRowTheme
+	helpRowGraphics
+	helpRowRumble
+	helpRowAimMode
+	helpRowAimAssist
+	helpRowToggleMove
+	helpRowGemMagnet
+	helpRowDamageReduction
+	helpRowGameSpeed
+	helpRowControls
+	helpRowCount
+)
+
+// aimTarget picks the enemy an aimed weapon fires at. Auto-aim takes the
+// nearest enemy; manual aim only snaps to enemies inside the facing cone.
+func (g *Game) aimTarget(maxDist float64) *Enemy {
+	if g.settings.AimMode == AimAuto {
+		return g.findNearestEnemy(maxDist)
+	}
+
+	cone := manualAimCone
+	if g.settings.AimAssist {
+		cone = assistAimCone
+	}
+
+	facing := g.facingAngle()
+
+	var target *Enemy
+
+	best := maxDist
+
+	for _, e := range g.enemies {
+		if e.Dead {
+			continue
+		}
+
+		dist := math.Hypot(e.X-g.player.X, e.Y-g.player.Y)
+		if dist >= best {
+			continue
+		}
+
+		off := math.Abs(math.Remainder(math.Atan2(e.Y-g.player.Y, e.X-g.player.X)-facing, 2*math.Pi))
+		if off <= cone {
+			target, best = e, dist
+		}
+	}
+
+	return target
+}
+
+// aimDirection returns the unit vector an aimed weapon fires along. Without a
+// target, manual aim fires straight ahead and auto-aim reports ok=false.
+func (g *Game) aimDirection(maxDist float64) (dx, dy float64, ok bool) {
+	if target := g.aimTarget(maxDist); target != nil {
+		dx, dy = target.X-g.player.X, target.Y-g.player.Y
+		if dist := math.Hypot(dx, dy); dist > 0 {
+			return dx / dist, dy / dist, true
+		}
+	}
+
+	if g.settings.AimMode == AimManual {
+		facing := g.facingAngle()
+
+		return math.Cos(facing), math.Sin(facing), true
+	}
+
+	return 0, 0, false
+}
+
+// facingAngle returns the player's last movement heading.
+func (g *Game) facingAngle() float64 {
+	if g.player.FaceX == 0 && g.player.FaceY == 0 {
+		return 0
+	}
+
+	return math.Atan2(g.player.FaceY, g.player.FaceX)
+}
+
+// moveInput reads the movement direction. With toggle-to-move, tapping a
+// direction latches it until it is tapped again, and X holds position. Demo
+// runs steer with the autopilot instead.
+func (g *Game) moveInput() (dx, dy float64) {
+	if g.autopilot {
+		return g.autopilotMove()
+	}
+
+	if !g.settings.ToggleMove {
+		return controls.Axis(input.MoveX), controls.Axis(input.MoveY)
+	}
+
+	if dir := controls.JustMoved(input.MoveY); dir != 0 {
+		g.moveLatchY = toggleLatch(g.moveLatchY, float64(dir))
+	}
+
+	if dir := controls.JustMoved(input.MoveX); dir != 0 {
+		g.moveLatchX = toggleLatch(g.moveLatchX, float64(dir))
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyX) {
+		g.moveLatchX, g.moveLatchY = 0, 0
+	}
+
+	return g.moveLatchX, g.moveLatchY
+}
+
+// noteHoldKey tells toggle-to-move players once per session that X holds
+// position, as C did before it opened the character sheet.
+func (g *Game) noteHoldKey() {
+	if !g.settings.ToggleMove || g.holdKeyNoted {
+		return
+	}
+
+	g.holdKeyNoted = true
+	g.notify(ui.Notification{
+		Title:    "Hold position moved to X",
+		Message:  "C now opens the character sheet",
+		Color:    ui.CurrentTheme().Palette.Warning,
+		Duration: 6,
+	})
+}
+
+// toggleLatch stops a latched axis when its direction is tapped again and
+// otherwise switches it to the tapped direction.
+func toggleLatch(current, dir float64) float64 {
+	if current == dir {
+		return 0
+	}
+
+	return dir
+}
+
+// magnetRange returns the pickup radius including the combo bonus and the
+// gem magnet assist.
+func (g *Game) magnetRange() float64 {
+	base := g.player.MagnetRange + g.comboMagnet()
+	if g.settings.GemMagnet {
+		return base * gemMagnetBoost
+	}
+
+	return base
+}
+
+// assistDamage applies the assist damage reduction to damage taken.
+func (g *Game) assistDamage(taken int) int {
+	if g.settings.DamageReduction <= 0 {
+		return taken
+	}
+
+	return max(1, int(math.Round(float64(taken)*(1-g.settings.DamageReduction))))
+}
+
+// adjustSetting changes the setting on a help screen row by one step.
+func (g *Game) adjustSetting(row, dir int) {
+	s := g.settings
+
+	switch row {
+	case helpRowSFX:
+		g.audio.SetSFXVolume(g.audio.SFXVolume() + 0.1*float64(dir))
+		g.audio.PlaySound("select")
+
+		return
+	case helpRowMusic:
+		g.audio.SetMusicVolume(g.audio.MusicVolume() + 0.1*float64(dir))
+
+		return
+	case helpRowTheme:
+		s.Theme = ui.CycleTheme(dir).Name
+		g.setSettings(s)
+		g.audio.PlaySound("select")
+
+		return
+	case helpRowControls:
+		g.openRebinder()
+		g.audio.PlaySound("select")
+
+		return
+	case helpRowGraphics:
+		// Right raises quality, which counts down from Low to High
+		s.Graphics = clampQuality(s.Graphics - GraphicsQuality(dir))
+	case helpRowRumble:
+		// Right strengthens the rumble, which counts steps down from full
+		s.RumbleLevel = clampRumbleLevel(s.RumbleLevel - dir)
+		g.setSettings(s)
+		g.playRumble(rumbleSample)
+		g.audio.PlaySound("select")
+
+		return
+	case helpRowAimMode:
+		s.AimMode = 1 - s.AimMode
+	case helpRowAimAssist:
+		s.AimAssist = !s.AimAssist
+	case helpRowToggleMove:
+		s.ToggleMove = !s.ToggleMove
+		g.moveLatchX, g.moveLatchY = 0, 0
+	case helpRowGemMagnet:
+		s.GemMagnet = !s.GemMagnet
+	case helpRowDamageReduction:
+		steps := math.Round(s.DamageReduction/damageReductionStep) + float64(dir)
+		s.DamageReduction = min(max(steps*damageReductionStep, 0), maxDamageReduction)
+	case helpRowGameSpeed:
+		s.GameSpeed = clampGameSpeed(s.gameSpeed() + gameSpeedStep*float64(dir))
+	}
+
+	g.setSettings(s)
+	g.audio.PlaySound("select")
+}
+
+// drawAssistSettings draws the assist mode and game speed rows of the help
+// screen starting at y and returns the y below them.
+func (g *Game) drawAssistSettings(screen *ebiten.Image, x, y int) int {
+	palette := ui.CurrentTheme().Palette
+	s := g.settings
+
+	aim := "Auto"
+	if s.AimMode == AimManual {
+		aim = "Manual"
+	}
+
+	rows := []struct {
+		row   int
+		label string
+		value string
+	}{
+		{helpRowAimMode, "Aim Mode:", aim},
+		{helpRowAimAssist, "Aim Assist:", onOff(s.AimAssist)},
+		{helpRowToggleMove, "Toggle Move:", onOff(s.ToggleMove) + " (X holds position)"},
+		{helpRowGemMagnet, "Gem Magnet+:", onOff(s.GemMagnet)},
+	}
+
+	for _, r := range rows {
+		prefix := "  "
+		if g.helpSelection == r.row {
+			prefix = "> "
+		}
+
+		ui.DebugPrintAt(screen, prefix+r.label, x, y)
+		ui.DebugPrintAt(screen, "< "+r.value+" >", x+130, y)
+		y += 20
+	}
+
+	barCol := palette.TextMuted
+	if g.helpSelection == helpRowDamageReduction {
+		barCol = palette.Highlight
+	}
+
+	ui.DebugPrintAt(screen, "  Damage Taken:", x, y)
+	vector.FillRect(screen, float32(x+130), float32(y+2), 100, 10, color.RGBA{R: 50, G: 50, B: 50, A: 255}, false)
+	vector.FillRect(
+		screen,
+		float32(x+130),
+		float32(y+2),
+		float32(100*s.DamageReduction/maxDamageReduction),
+		10,
+		barCol,
+		false,
+	)
+	ui.DebugPrintAt(screen, "-"+formatInt(int(math.Round(s.DamageReduction*100)))+"%", x+240, y)
+	y += 20
+
+	prefix := "  "
+	if g.helpSelection == helpRowGameSpeed {
+		prefix = "> "
+	}
+
+	ui.DebugPrintAt(screen, prefix+"Game Speed:", x, y)
+	ui.DebugPrintAt(screen, "< "+gameSpeedPercent(s.gameSpeed())+" >", x+130, y)
+
+	return y + 20
+}
+
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+
+	return "Off"
+}
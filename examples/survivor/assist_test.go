@@ -0,0 +1,172 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+func TestManualAimConeWidensWithAssist(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.FaceX = 1
+
+	// 30 degrees off facing: outside the manual cone, inside the assist cone
+	offAxis := &Enemy{X: 86.6, Y: 50, HP: 10, MaxHP: 10}
+	behind := &Enemy{X: -20, HP: 10, MaxHP: 10}
+	g.enemies = []*Enemy{offAxis, behind}
+
+	if got := g.aimTarget(500); got != behind {
+		t.Fatalf("auto aim should take the nearest enemy, got %+v", got)
+	}
+
+	g.settings.AimMode = AimManual
+
+	if got := g.aimTarget(500); got != nil {
+		t.Errorf("manual aim snapped to %+v outside its cone", got)
+	}
+
+	if dx, dy, ok := g.aimDirection(500); !ok || dx != 1 || dy != 0 {
+		t.Errorf("manual aim without a target = (%v, %v, %v), want straight ahead", dx, dy, ok)
+	}
+
+	g.settings.AimAssist = true
+
+	if got := g.aimTarget(500); got != offAxis {
+		t.Errorf("aim assist should snap to the off-axis enemy, got %+v", got)
+	}
+}
+
+func TestToggleLatch(t *testing.T) {
+	tests := []struct {
+		current, dir, want float64
+	}{
+		{0, 1, 1},
+		{1, 1, 0},
+		{-1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		if got := toggleLatch(tt.current, tt.dir); got != tt.want {
+			t.Errorf("toggleLatch(%v, %v) = %v, want %v", tt.current, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestAssistDamageReductionAndMagnet(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.MaxHP, g.player.HP = 1000, 1000
+
+	g.settings.DamageReduction = 0.5
+	g.hurtPlayer(40, 0, "test")
+
+	if taken := 1000 - g.player.HP; taken != 20 {
+		t.Errorf("took %d with 50%% assist reduction, want 20", taken)
+	}
+
+	base := g.magnetRange()
+	g.settings.GemMagnet = true
+
+	if got := g.magnetRange(); got != base*gemMagnetBoost {
+		t.Errorf("magnetRange = %v with gem magnet, want %v", got, base*gemMagnetBoost)
+	}
+}
+
+func TestAssistsMarkRun(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	if g.runAssisted {
+		t.Fatal("default settings should not mark the run as assisted")
+	}
+
+	g.adjustSetting(helpRowDamageReduction, 1)
+
+	if g.settings.DamageReduction != damageReductionStep || !g.runAssisted {
+		t.Errorf("reduction = %v, assisted = %v after one step", g.settings.DamageReduction, g.runAssisted)
+	}
+
+	for range 10 {
+		g.adjustSetting(helpRowDamageReduction, 1)
+	}
+
+	if g.settings.DamageReduction != maxDamageReduction {
+		t.Errorf("reduction = %v, want clamped to %v", g.settings.DamageReduction, maxDamageReduction)
+	}
+
+	// Turning assists off keeps the mark for the rest of the run
+	g.setSettings(Settings{})
+
+	if !g.runAssisted {
+		t.Error("run should stay marked after assists are turned off")
+	}
+
+	g.startGame(CharJunior)
+
+	if g.runAssisted {
+		t.Error("a new run without assists should not be marked")
+	}
+}
+
+func TestSettingsRoundTrip(t *testing.T) {
+	sm := game.NewSaveManager(t.TempDir())
+
+	if got := loadSettings(sm); got != (Settings{}) {
+		t.Fatalf("missing settings file = %+v, want defaults", got)
+	}
+
+	want := Settings{
+		AimMode: AimManual, AimAssist: true, ToggleMove: true, GemMagnet: true, DamageReduction: 0.3,
+		Theme:  "Light",
+		Window: engine.WindowState{Width: 1280, Height: 720, X: 40, Y: 30, Fullscreen: true, Monitor: "DP-1"},
+	}
+	saveSettings(sm, want)
+
+	if got := loadSettings(sm); got != want {
+		t.Errorf("loaded %+v, want %+v", got, want)
+	}
+}
+
+func TestThemeChoiceIsSaved(t *testing.T) {
+	name := ui.CurrentTheme().Name
+	defer func() { _ = ui.SetTheme(name) }()
+
+	g := &Game{settingsStore: game.NewSaveManager(t.TempDir())}
+	g.startGame(CharJunior)
+	g.adjustSetting(helpRowTheme, 1)
+
+	saved := loadSettings(g.settingsStore).Theme
+	if saved == "" || saved != ui.CurrentTheme().Name {
+		t.Fatalf("saved theme = %q, current %q", saved, ui.CurrentTheme().Name)
+	}
+
+	_ = ui.SetTheme(name)
+	g.settings.Theme = saved
+	g.applyTheme()
+
+	if ui.CurrentTheme().Name != saved {
+		t.Errorf("applied theme = %q, want %q", ui.CurrentTheme().Name, saved)
+	}
+}
+
+func TestHoldKeyNotedOnce(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.noteHoldKey()
+
+	if g.toasts.Len() != 0 {
+		t.Fatal("noted the hold key without toggle-to-move")
+	}
+
+	g.settings.ToggleMove = true
+	g.noteHoldKey()
+	g.noteHoldKey()
+
+	if g.toasts.Len() != 1 {
+		t.Errorf("%d toasts, want the hold key noted once", g.toasts.Len())
+	}
+}
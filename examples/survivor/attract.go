@@ -0,0 +1,131 @@
+package main
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	attractDelay = 30 // Seconds idle on the title screen before the demo
+	demoLength   = 90 // Seconds of game time before the demo hands back
+
+	autopilotDanger = 220.0 // Enemies closer than this push the autopilot away
+)
+
+// survivorDemo plays autopiloted runs on the title screen. Each run is a
+// separate Game sharing the host's images, with no saves, lifetime stats,
+// meta shop, or audio attached, so the demo never touches the player's
+// progress. Characters take turns from one demo to the next.
+type survivorDemo struct {
+	host *Game
+	run  *Game
+	next CharacterType
+}
+
+// atTitle reports whether g is on the title screen, where idling starts the
+// demo.
+func (g *Game) atTitle() bool {
+	return g.state == StateCharSelect && g.seedEntry == nil && g.tokenShop == nil
+}
+
+// Start begins a new demo run with the next character.
+func (d *survivorDemo) Start() {
+	h := d.host
+	g := &Game{
+		charImages:    h.charImages,
+		monsterImages: h.monsterImages,
+		weaponImages:  h.weaponImages,
+		passiveImages: h.passiveImages,
+		skin:          h.skin,
+		settings:      h.settings,
+		autopilot:     true,
+	}
+	g.settings.GraphicsProbed = true // Keep the frame probe's hint out of the demo
+
+	g.startGame(d.next)
+	d.next = (d.next + 1) % CharacterType(len(Characters))
+	d.run = g
+}
+
+// Update plays one tick of the demo run, ending the demo when the run is over
+// or has gone on for demoLength.
+func (d *survivorDemo) Update() error {
+	g := d.run
+
+	switch {
+	case g.gameTime >= demoLength:
+		return ebiten.Termination
+	case g.state == StatePlaying:
+		return g.updatePlaying()
+	case g.state == StateLevelUp:
+		g.autopilotLevelUp()
+
+		return nil
+	}
+
+	return ebiten.Termination
+}
+
+// Draw draws the demo run.
+func (d *survivorDemo) Draw(screen *ebiten.Image) {
+	d.run.Draw(screen)
+}
+
+// autopilotMove steers away from nearby enemies, weighted by closeness, and
+// otherwise toward the nearest XP gem, or in a slow circle when there is
+// none. Directions are snapped to the eight the keyboard can give.
+func (g *Game) autopilotMove() (dx, dy float64) {
+	p := g.player
+
+	var vx, vy float64
+
+	for _, e := range g.enemies {
+		ex, ey := p.X-e.X, p.Y-e.Y
+
+		dist := math.Hypot(ex, ey)
+		if e.Dead || dist >= autopilotDanger || dist == 0 {
+			continue
+		}
+
+		w := (autopilotDanger - dist) / autopilotDanger / dist
+		vx += ex * w
+		vy += ey * w
+	}
+
+	if vx == 0 && vy == 0 {
+		nearest := math.Inf(1)
+
+		for _, gem := range g.xpGems {
+			if dist := math.Hypot(gem.X-p.X, gem.Y-p.Y); dist < nearest {
+				nearest, vx, vy = dist, gem.X-p.X, gem.Y-p.Y
+			}
+		}
+	}
+
+	if vx == 0 && vy == 0 {
+		vx, vy = math.Cos(g.gameTime*0.5), math.Sin(g.gameTime*0.5)
+	}
+
+	return snapAxis(vx, vy), snapAxis(vy, vx)
+}
+
+// snapAxis returns the sign of v, or 0 when v is small next to the other
+// axis, so a mostly horizontal heading moves straight along it.
+func snapAxis(v, other float64) float64 {
+	if math.Abs(v) < math.Abs(other)*0.4 {
+		return 0
+	}
+
+	return math.Copysign(1, v)
+}
+
+// autopilotLevelUp takes the first upgrade offered.
+func (g *Game) autopilotLevelUp() {
+	if len(g.upgradeOptions) > 0 {
+		g.upgradeOptions[0].Apply(g)
+		g.discoverLoadout()
+	}
+
+	g.nextLevelUp()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDemoPlaysApartFromTheHost(t *testing.T) {
+	host := &Game{state: StateCharSelect}
+	if !host.atTitle() {
+		t.Fatal("the character select screen should count as the title")
+	}
+
+	d := &survivorDemo{host: host}
+	d.Start()
+
+	run := d.run
+	if run == host || !run.autopilot || run.state != StatePlaying {
+		t.Fatalf("demo run = %p (host %p), autopilot %v, state %v", run, host, run.autopilot, run.state)
+	}
+
+	var err error
+	for range 20 * 60 {
+		if err = d.Update(); err != nil {
+			break
+		}
+	}
+
+	if err != nil && !errors.Is(err, ebiten.Termination) {
+		t.Fatal(err)
+	}
+
+	if run.gameTime == 0 || run.player.X == 0 && run.player.Y == 0 {
+		t.Errorf("demo run did not play: time %v at (%v, %v)", run.gameTime, run.player.X, run.player.Y)
+	}
+
+	if host.state != StateCharSelect || host.player != nil {
+		t.Errorf("host changed: state %v, player %v", host.state, host.player)
+	}
+
+	// The next demo plays the next character
+	d.Start()
+
+	if d.run == run || d.run.player.CharType != CharacterType(1) {
+		t.Errorf("second demo plays %v", d.run.player.CharType)
+	}
+
+	d.run.gameTime = demoLength
+	if err := d.Update(); !errors.Is(err, ebiten.Termination) {
+		t.Errorf("demo past its length: err = %v", err)
+	}
+}
+
+func TestAutopilotFleesEnemies(t *testing.T) {
+	g := &Game{autopilot: true}
+	g.startGame(CharJunior)
+	g.enemies = append(g.enemies, &Enemy{X: 50, Y: 5, HP: 10})
+
+	if dx, dy := g.moveInput(); dx != -1 || dy != 0 {
+		t.Errorf("enemy to the right: move (%v, %v), want (-1, 0)", dx, dy)
+	}
+
+	g.enemies = nil
+	g.xpGems = append(g.xpGems, &XPGem{X: -30, Y: 40})
+
+	if dx, dy := g.moveInput(); dx != -1 || dy != 1 {
+		t.Errorf("gem down-left: move (%v, %v), want (-1, 1)", dx, dy)
+	}
+}
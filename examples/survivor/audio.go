@@ -34,6 +34,42 @@ func (ap *AudioPlayer) PlaySound(name string) {
 	ap.manager.PlayPooled(name)
 }
 
+// PlayVariant plays a random take of a sound with several variants.
+func (ap *AudioPlayer) PlayVariant(name string) {
+	if ap == nil || ap.manager == nil {
+		return
+	}
+
+	ap.manager.PlayVariant(name)
+}
+
+// Emit plays an emitter's sound for a lifecycle event at a world position.
+func (ap *AudioPlayer) Emit(e assets.SoundEmitter, event assets.SoundEvent, x, y float64, l assets.Listener) {
+	if ap == nil || ap.manager == nil {
+		return
+	}
+
+	ap.manager.Emit(e, event, x, y, l)
+}
+
+// ApplyAmbience sets the ambient loops to this frame's mix.
+func (ap *AudioPlayer) ApplyAmbience(a *assets.Ambience) {
+	if ap == nil || ap.manager == nil {
+		return
+	}
+
+	ap.manager.ApplyAmbience(a)
+}
+
+// SetDucked ducks the music and ambience while the game is paused.
+func (ap *AudioPlayer) SetDucked(ducked bool) {
+	if ap == nil || ap.manager == nil {
+		return
+	}
+
+	ap.manager.SetDucked(ducked)
+}
+
 func (ap *AudioPlayer) PlayBGM() {
 	if ap.manager != nil {
 		ap.manager.PlayMusic("bgm")
@@ -75,9 +111,26 @@ func (ap *AudioPlayer) GenerateSounds() {
 	// We need to pass valid WAV data.
 	// Since gen... returns WAV bytes, we pass "wav" as format.
 	ap.manager.CreatePoolFromBytes("shoot", genShootSound(), 8, "wav")
-	ap.manager.CreatePoolFromBytes("hit", genHitSound(), 8, "wav")
 	ap.manager.CreatePoolFromBytes("levelup", genLevelUpSound(), 4, "wav")
 	ap.manager.CreatePoolFromBytes("select", genSelectSound(), 4, "wav")
+	ap.manager.CreatePoolFromBytes("shield", genShieldSound(), 4, "wav")
+
+	// Coin pickups alternate between slightly detuned takes
+	coinTakes := make([][]byte, 0, len(coinPitches))
+	for _, pitch := range coinPitches {
+		coinTakes = append(coinTakes, genCoinSound(pitch))
+	}
+
+	ap.manager.CreateVariantsFromBytes("coin", coinTakes, 4, "wav", 0.15)
+
+	// Monster sounds, named by the emitters on MonsterDefs
+	for name, gen := range monsterSounds {
+		ap.manager.CreatePoolFromBytes(name, gen(), 8, "wav")
+	}
+
+	for name, gen := range ambientSounds {
+		ap.manager.LoadAmbientFromBytes(name, gen(), "wav")
+	}
 
 	// BGM
 	// Load as Music (streaming/looping)
@@ -125,6 +178,100 @@ func genHitSound() []byte {
 	})
 }
 
+// monsterSounds generates the one-shot sounds MonsterDef emitters can name.
+var monsterSounds = map[string]func() []byte{
+	"hit":   genHitSound,
+	"splat": genSplatSound,
+	"blast": genBlastSound,
+	"roar":  genRoarSound,
+}
+
+// ambientSounds generates the loops MonsterDef emitters can name as Ambient.
+var ambientSounds = map[string]func() []byte{
+	"hum":   genHumSound,
+	"drone": genDroneSound,
+}
+
+func genSplatSound() []byte {
+	seconds := 0.18
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		// Noise over a falling thump
+		freq := 300.0 - t*1200.0
+		thump := math.Sin(2 * math.Pi * freq * t)
+		noise := rand.Float64()*2 - 1
+		env := 1.0 - t/seconds
+
+		return (thump*0.6 + noise*0.4) * env * env * 0.3
+	})
+}
+
+func genBlastSound() []byte {
+	seconds := 0.6
+
+	// Low-passed noise with a long tail
+	var smoothed float64
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		noise := rand.Float64()*2 - 1
+		smoothed += (noise - smoothed) * 0.08
+		env := math.Exp(-t * 6)
+
+		return smoothed * env * 1.5
+	})
+}
+
+func genRoarSound() []byte {
+	seconds := 0.9
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		// Growling sawtooth with a wobble, swelling then dying away
+		freq := 70.0 + 10*math.Sin(2*math.Pi*7*t)
+		saw := 2*(t*freq-math.Floor(t*freq)) - 1
+		env := math.Sin(math.Pi * t / seconds)
+
+		return saw * env * 0.3
+	})
+}
+
+func genHumSound() []byte {
+	// Whole cycles of every component over 2s, so the loop is seamless
+	seconds := 2.0
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		tremolo := 0.6 + 0.4*math.Sin(2*math.Pi*t)
+		val := math.Sin(2*math.Pi*220*t) + 0.5*math.Sin(2*math.Pi*331*t)
+
+		return val * tremolo * 0.08
+	})
+}
+
+func genDroneSound() []byte {
+	seconds := 2.0
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		// Fifth-stacked sawtooth drone pulsing once a second
+		root := 2*(t*55-math.Floor(t*55)) - 1
+		fifth := 2*(t*82.5-math.Floor(t*82.5)) - 1
+		pulse := 0.7 + 0.3*math.Cos(2*math.Pi*t)
+
+		return (root + fifth*0.5) * pulse * 0.08
+	})
+}
+
+// genShieldSound is a bright, quickly fading ping, distinct from the noisy
+// HP hit.
+func genShieldSound() []byte {
+	seconds := 0.18
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		freq := 1400.0 + t*2000.0
+		env := math.Exp(-t * 25)
+
+		return (math.Sin(2*math.Pi*freq*t) + 0.4*math.Sin(2*math.Pi*freq*1.5*t)) * env * 0.25
+	})
+}
+
 func genSelectSound() []byte {
 	seconds := 0.1
 
@@ -136,6 +283,25 @@ func genSelectSound() []byte {
 	})
 }
 
+// coinPitches are the base frequencies of the coin pickup variants.
+var coinPitches = []float64{988, 1047, 1109, 1175}
+
+func genCoinSound(pitch float64) []byte {
+	seconds := 0.12
+
+	return genWavHeaderAndData(seconds, func(t float64) float64 {
+		// Two-note "ching": jump up a fifth halfway through
+		freq := pitch
+		if t > seconds/3 {
+			freq *= 1.5
+		}
+
+		env := 1.0 - t/seconds
+
+		return math.Sin(2*math.Pi*freq*t) * env * 0.25
+	})
+}
+
 func genLevelUpSound() []byte {
 	seconds := 1.0
 	// Major arpeggio: C4, E4, G4, C5
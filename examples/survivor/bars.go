@@ -0,0 +1,54 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+)
+
+// lowHPThreshold is the HP fraction below which the HP bar pulses and the screen vignettes.
+const lowHPThreshold = 0.3
+
+// initBars creates the animated HP and XP bars for a run.
+func (g *Game) initBars() {
+	g.hpBar = game.NewAnimatedHUDBar(60, 8, 200, 18)
+	g.hpBar.FillColor = color.RGBA{R: 200, G: 50, B: 50, A: 255}
+	g.hpBar.BackColor = color.RGBA{R: 40, G: 40, B: 40, A: 255}
+	g.hpBar.BorderColor = color.RGBA{}
+	g.hpBar.LowThreshold = lowHPThreshold
+	g.hpBar.Snap(float64(g.player.HP), float64(g.player.MaxHP))
+
+	g.xpBar = game.NewAnimatedHUDBar(60, 32, 200, 12)
+	g.xpBar.FillColor = color.RGBA{R: 100, G: 200, B: 255, A: 255}
+	g.xpBar.BackColor = color.RGBA{R: 40, G: 40, B: 40, A: 255}
+	g.xpBar.BorderColor = color.RGBA{}
+	g.xpBar.Snap(float64(g.player.XP), float64(g.player.XPToNext()))
+	g.xpBarLevel = g.player.Level
+}
+
+// updateBars feeds the current HP and XP into the bars and animates them.
+func (g *Game) updateBars(dt float64) {
+	g.hpBar.SetValue(float64(g.player.HP), float64(g.player.MaxHP))
+
+	xp, xpNeeded := float64(g.player.XP), float64(g.player.XPToNext())
+	if g.player.Level != g.xpBarLevel {
+		// The XP bar wraps on level-up; don't animate that as a loss
+		g.xpBar.Snap(xp, xpNeeded)
+		g.xpBarLevel = g.player.Level
+	} else {
+		g.xpBar.SetValue(xp, xpNeeded)
+	}
+
+	g.hpBar.Update(dt)
+	g.xpBar.Update(dt)
+}
+
+// drawLowHPVignette tints the screen edges in time with the low-HP pulse.
+func (g *Game) drawLowHPVignette(screen *ebiten.Image) {
+	if !g.graphics().Vignette {
+		return
+	}
+
+	game.DrawVignette(screen, g.hpBar.LowPulse(), color.RGBA{R: 160, A: 160})
+}
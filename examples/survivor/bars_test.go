@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestXPBarSnapsOnLevelUp(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.player.XP = 20
+	g.updateBars(1.0 / 60)
+
+	g.player.Level++
+	g.player.XP = 2
+	g.updateBars(1.0 / 60)
+
+	if g.xpBar.Trail != g.xpBar.Display || g.xpBar.Display != g.xpBar.Current {
+		t.Errorf("XP wrap animated as a loss: display=%v trail=%v current=%v",
+			g.xpBar.Display, g.xpBar.Trail, g.xpBar.Current)
+	}
+
+	g.player.HP = g.player.MaxHP / 10
+	g.updateBars(1.0 / 60)
+
+	if !g.hpBar.IsLow() || g.hpBar.Trail <= g.hpBar.Display {
+		t.Errorf("HP drop should flash and pulse: low=%v display=%v trail=%v",
+			g.hpBar.IsLow(), g.hpBar.Display, g.hpBar.Trail)
+	}
+}
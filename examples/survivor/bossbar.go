@@ -0,0 +1,116 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+const (
+	enrageSpeedMult  = 1.5
+	enrageDamageMult = 1.5
+	bossMarkerScale  = 40 // World pixels per meter shown on boss markers
+)
+
+// trackedBoss returns the boss shown on the boss bar: the living boss with
+// the most max HP, so a late Hard Deadline takes over from a Micro Manager.
+func (g *Game) trackedBoss() *Enemy {
+	var boss *Enemy
+
+	for _, e := range g.enemies {
+		if e.IsBoss && !e.Dead && (boss == nil || e.MaxHP > boss.MaxHP) {
+			boss = e
+		}
+	}
+
+	return boss
+}
+
+// updateBosses ages bosses, runs their behaviors, and points the boss bar
+// at the tracked boss.
+func (g *Game) updateBosses(dt float64) {
+	for _, e := range g.enemies {
+		if !e.IsBoss || e.Dead {
+			continue
+		}
+
+		e.Age += dt
+		g.updateBossBehavior(e, dt)
+	}
+
+	if g.bossBar == nil {
+		g.bossBar = ui.NewBossBar(200, 78, 500, 14)
+	}
+
+	if boss := g.trackedBoss(); boss != nil {
+		status := ui.BossStatus{
+			Name:     MonsterDefs[boss.Type].Name,
+			HP:       float64(boss.HP),
+			Max:      float64(boss.MaxHP),
+			EnrageIn: -1,
+			Enraged:  boss.Enraged,
+		}
+
+		if b := BossBehaviors[boss.Type]; b != nil {
+			status.Phases = b.markers()
+
+			if b.EnrageTime > 0 {
+				status.EnrageIn = max(0, b.EnrageTime-boss.Age)
+			}
+		}
+
+		g.bossBar.Show(status)
+	} else {
+		g.bossBar.Hide()
+	}
+
+	g.bossBar.Update(dt)
+}
+
+// enrage makes a boss faster and harder hitting for the rest of the fight.
+func (g *Game) enrage(e *Enemy) {
+	e.Enraged = true
+	e.Speed *= enrageSpeedMult
+	e.Damage = int(float64(e.Damage) * enrageDamageMult)
+
+	g.notify(ui.Notification{
+		Title:    MonsterDefs[e.Type].Name + " is enraged!",
+		Message:  "Faster and hits harder",
+		Color:    ui.CurrentTheme().Palette.Danger,
+		Priority: ui.ToastCritical,
+	})
+}
+
+// drawBossBar draws the boss bar under the top HUD row.
+func (g *Game) drawBossBar(screen *ebiten.Image) {
+	if g.bossBar != nil {
+		g.bossBar.Draw(screen)
+	}
+}
+
+// drawBossMarkers points at bosses off screen, with their distance.
+func (g *Game) drawBossMarkers(screen *ebiten.Image) {
+	var markers []ui.Marker
+
+	for _, e := range g.enemies {
+		if e.IsBoss && !e.Dead {
+			label := MonsterDefs[e.Type].Name
+			markers = append(markers, ui.Marker{Kind: ui.MarkerThreat, X: e.X, Y: e.Y, Label: label})
+		}
+	}
+
+	if len(markers) == 0 {
+		return
+	}
+
+	layer := ui.MarkerLayer{
+		Width:         screenWidth,
+		Height:        screenHeight,
+		OffScreenOnly: true,
+		ToScreen: func(x, y float64) (float64, float64) {
+			return x - g.cameraX, y - g.cameraY
+		},
+		DistanceScale: bossMarkerScale,
+		DistanceUnit:  "m",
+	}
+	layer.Draw(screen, markers, g.player.X, g.player.Y)
+}
@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestBossBarTracksBiggestBossAndEnrages(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.spawnBoss() // Micro Manager at run start
+
+	manager := g.enemies[len(g.enemies)-1]
+	speed, damage := manager.Speed, manager.Damage
+
+	g.updateBosses(1.0 / 60)
+
+	if got := g.bossBar.Status().Name; got != "Micro Manager" {
+		t.Fatalf("boss bar shows %q, want Micro Manager", got)
+	}
+
+	g.gameTime = 400
+	g.spawnBoss()
+	g.updateBosses(1.0 / 60)
+
+	if got := g.bossBar.Status(); got.Name != "Hard Deadline" || len(got.Phases) != 2 {
+		t.Errorf("boss bar = %+v, want Hard Deadline with two phase markers", got)
+	}
+
+	g.updateBosses(BossBehaviors[MonsterBossManager].EnrageTime)
+
+	wantDamage := int(float64(damage) * enrageDamageMult)
+	if !manager.Enraged || manager.Speed != speed*enrageSpeedMult || manager.Damage != wantDamage {
+		t.Errorf("manager after enrage: enraged %v, speed %v, damage %d", manager.Enraged, manager.Speed, manager.Damage)
+	}
+
+	for _, e := range g.enemies {
+		e.Dead = true
+	}
+
+	for range 60 {
+		g.updateBosses(1.0 / 60)
+	}
+
+	if g.bossBar.Visible() {
+		t.Error("boss bar should fade out when no boss is alive")
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// summonRingGap is how far outside a boss its adds appear.
+const summonRingGap = 40.0
+
+// BossBehavior scripts a boss fight around its attack pattern: phases
+// entered as its HP falls, the adds it calls in, and when it enrages.
+type BossBehavior struct {
+	Phases     []BossPhase // In the order they are entered, by falling At
+	EnrageTime float64     // Seconds until the boss enrages; 0 never
+}
+
+// BossPhase is a stage of a boss fight, entered when the boss falls to At
+// of its max HP. An opening phase at 1 is entered on spawn, unannounced.
+type BossPhase struct {
+	At     float64     // HP fraction, above 0 and at most 1
+	Name   string      // Announced as the phase starts
+	Tempo  float64     // Attack pattern speed from this phase on; 0 keeps the last
+	Nova   *BossNova   // Telegraphed blast around the boss as the phase starts
+	Summon *BossSummon // Adds called in from this phase on; nil keeps the last
+}
+
+// BossNova is a telegraphed blast centered on the boss: a windup circle is
+// drawn around where it stands before the damage lands.
+type BossNova struct {
+	Radius float64
+	Windup float64 // Seconds of warning
+	Damage float64 // Multiplier of the boss's contact damage
+}
+
+// BossSummon calls adds in around a boss.
+type BossSummon struct {
+	Type  MonsterType
+	Count int     // Adds per summon
+	Every float64 // Seconds between summons; the first comes as the phase starts
+	Max   int     // The boss does not summon while this many of its adds live
+}
+
+// BossBehaviors lists the scripted boss fights.
+var BossBehaviors = map[MonsterType]*BossBehavior{
+	// The Micro Manager keeps a few interns around and, at half HP, starts
+	// hovering: its pattern speeds up and the interns come faster.
+	MonsterBossManager: {
+		Phases: []BossPhase{
+			{At: 1, Summon: &BossSummon{Type: MonsterBug, Count: 3, Every: 12, Max: 6}},
+			{
+				At:     0.5,
+				Name:   "Micromanaging",
+				Tempo:  1.3,
+				Nova:   &BossNova{Radius: 160, Windup: 1.2, Damage: 1},
+				Summon: &BossSummon{Type: MonsterBug, Count: 4, Every: 8, Max: 8},
+			},
+		},
+		EnrageTime: 90,
+	},
+	// The Hard Deadline fights alone until scope creep brings in spaghetti
+	// code, then crunch time races it to the finish.
+	MonsterBossDeadline: {
+		Phases: []BossPhase{
+			{
+				At:     0.66,
+				Name:   "Scope creep",
+				Nova:   &BossNova{Radius: 180, Windup: 1.2, Damage: 1.5},
+				Summon: &BossSummon{Type: MonsterSpaghetti, Count: 2, Every: 10, Max: 4},
+			},
+			{
+				At:     0.33,
+				Name:   "Crunch time",
+				Tempo:  1.5,
+				Nova:   &BossNova{Radius: 220, Windup: 1, Damage: 2},
+				Summon: &BossSummon{Type: MonsterRaceCond, Count: 3, Every: 8, Max: 6},
+			},
+		},
+		EnrageTime: 120,
+	},
+}
+
+// markers returns the HP fractions the boss bar marks: the phases after
+// the opening.
+func (b *BossBehavior) markers() []float64 {
+	var at []float64
+
+	for _, p := range b.Phases {
+		if p.At < 1 {
+			at = append(at, p.At)
+		}
+	}
+
+	return at
+}
+
+// summon returns the summon of the latest of the first n phases to set
+// one, or nil.
+func (b *BossBehavior) summon(n int) *BossSummon {
+	for i := n - 1; i >= 0; i-- {
+		if s := b.Phases[i].Summon; s != nil {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// tempo returns the pattern speed set by the latest of the first n phases
+// to set one, or 1.
+func (b *BossBehavior) tempo(n int) float64 {
+	for i := n - 1; i >= 0; i-- {
+		if t := b.Phases[i].Tempo; t > 0 {
+			return t
+		}
+	}
+
+	return 1
+}
+
+// bossDetails describes a boss's fight for the compendium: each phase and
+// when it enrages.
+func bossDetails(mt MonsterType) []string {
+	b := BossBehaviors[mt]
+	if b == nil {
+		return nil
+	}
+
+	var details []string
+
+	for _, p := range b.Phases {
+		if p.At < 1 {
+			details = append(details, fmt.Sprintf("%s below %.0f%% HP", p.Name, p.At*100))
+		}
+	}
+
+	if b.EnrageTime > 0 {
+		details = append(details, "Enrages after "+formatTime(b.EnrageTime))
+	}
+
+	return details
+}
+
+// updateBossBehavior enters the phases a boss's HP has fallen to, enrages it
+// when its timer runs out, and calls in its adds.
+func (g *Game) updateBossBehavior(e *Enemy, dt float64) {
+	b := BossBehaviors[e.Type]
+	if b == nil {
+		return
+	}
+
+	for e.Phase < len(b.Phases) && float64(e.HP) <= b.Phases[e.Phase].At*float64(e.MaxHP) {
+		g.enterPhase(e, b.Phases[e.Phase])
+		e.Phase++
+
+		if e.Pattern != nil {
+			e.Pattern.tempo = b.tempo(e.Phase)
+		}
+	}
+
+	if !e.Enraged && b.EnrageTime > 0 && e.Age >= b.EnrageTime {
+		g.enrage(e)
+	}
+
+	if s := b.summon(e.Phase); s != nil {
+		if e.SummonTimer -= dt; e.SummonTimer <= 0 {
+			e.SummonTimer = s.Every
+			g.summonAdds(e, s)
+		}
+	}
+}
+
+// enterPhase starts phase p of a boss fight: it is announced, its nova is
+// telegraphed, and a new summon comes at once.
+func (g *Game) enterPhase(e *Enemy, p BossPhase) {
+	if p.Summon != nil {
+		e.SummonTimer = 0
+	}
+
+	if n := p.Nova; n != nil {
+		g.telegraphs = append(g.telegraphs, &Telegraph{
+			X: e.X, Y: e.Y,
+			Radius: n.Radius,
+			Windup: n.Windup,
+			Timer:  n.Windup,
+			Damage: int(math.Round(float64(e.Damage) * n.Damage)),
+			Source: e,
+		})
+	}
+
+	if p.At >= 1 {
+		return
+	}
+
+	g.notify(ui.Notification{
+		Title:    MonsterDefs[e.Type].Name + ": " + p.Name,
+		Message:  fmt.Sprintf("Below %.0f%% HP", p.At*100),
+		Color:    ui.CurrentTheme().Palette.Danger,
+		Priority: ui.ToastCritical,
+	})
+	g.playRumble(rumbleBoss)
+}
+
+// bossAdds counts the living adds boss has called in.
+func (g *Game) bossAdds(boss *Enemy) int {
+	n := 0
+
+	for _, e := range g.enemies {
+		if e.Summoner == boss && !e.Dead {
+			n++
+		}
+	}
+
+	return n
+}
+
+// summonAdds calls in up to s.Count adds, evenly spaced in a ring around the
+// boss, without going over s.Max alive at once.
+func (g *Game) summonAdds(boss *Enemy, s *BossSummon) {
+	n := min(s.Count, s.Max-g.bossAdds(boss))
+	dist := boss.Radius + summonRingGap
+
+	for i := range n {
+		angle := boss.Age + 2*math.Pi*float64(i)/float64(n)
+		x, y := boss.X+math.Cos(angle)*dist, boss.Y+math.Sin(angle)*dist
+
+		add := g.spawnMonster(s.Type, x, y)
+		add.Summoner = boss
+		g.spawnParticle(x, y, 8, color.RGBA{R: 200, G: 80, B: 255, A: 255})
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/content"
+)
+
+// spawnTestBoss spawns the boss of type mt beside the player.
+func spawnTestBoss(g *Game, mt MonsterType) *Enemy {
+	if mt == MonsterBossDeadline {
+		g.gameTime = 400
+	}
+
+	g.spawnBoss()
+
+	boss := g.enemies[len(g.enemies)-1]
+	boss.X, boss.Y = g.player.X+300, g.player.Y
+
+	return boss
+}
+
+func TestBossPhasesFollowHP(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	boss := spawnTestBoss(g, MonsterBossDeadline)
+	g.updateBosses(1.0 / 60)
+
+	if boss.Phase != 0 || len(g.telegraphs) != 0 {
+		t.Fatalf("phase %d with %d telegraphs at full HP", boss.Phase, len(g.telegraphs))
+	}
+
+	// One big hit can skip straight through both phases
+	boss.HP = boss.MaxHP / 4
+	g.updateBosses(1.0 / 60)
+
+	b := BossBehaviors[MonsterBossDeadline]
+	if boss.Phase != 2 || boss.Pattern.rate() != b.Phases[1].Tempo {
+		t.Fatalf("phase %d at tempo %v, want crunch time", boss.Phase, boss.Pattern.rate())
+	}
+
+	if len(g.telegraphs) != 2 {
+		t.Fatalf("%d telegraphs, want a nova for each phase", len(g.telegraphs))
+	}
+
+	if nova := g.telegraphs[1]; nova.X != boss.X || nova.Radius != b.Phases[1].Nova.Radius {
+		t.Errorf("nova = %+v, want crunch time's around the boss", nova)
+	}
+}
+
+func TestBossSummonsAddsUpToMax(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	boss := spawnTestBoss(g, MonsterBossManager)
+	s := BossBehaviors[MonsterBossManager].Phases[0].Summon
+
+	g.updateBosses(1.0 / 60)
+
+	if n := g.bossAdds(boss); n != s.Count {
+		t.Fatalf("%d adds on spawn, want %d", n, s.Count)
+	}
+
+	for range 3 {
+		g.updateBosses(s.Every)
+	}
+
+	if n := g.bossAdds(boss); n != s.Max {
+		t.Errorf("%d adds after several summons, want the max %d", n, s.Max)
+	}
+
+	for _, e := range g.enemies {
+		if e.Summoner == boss && e.Type != s.Type {
+			t.Errorf("summoned a %s, want %s", MonsterDefs[e.Type].Name, MonsterDefs[s.Type].Name)
+		}
+	}
+
+	// Killing adds makes room for more
+	for _, e := range g.enemies {
+		if e.Summoner == boss {
+			e.Dead = true
+		}
+	}
+
+	g.updateBosses(s.Every)
+
+	if n := g.bossAdds(boss); n != s.Count {
+		t.Errorf("%d adds after the old ones died, want %d", n, s.Count)
+	}
+}
+
+func TestBossEnragesOnItsTimer(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	boss := spawnTestBoss(g, MonsterBossManager)
+	enrage := BossBehaviors[MonsterBossManager].EnrageTime
+
+	g.updateBosses(enrage - 1)
+
+	if boss.Enraged || g.bossBar.Status().EnrageIn <= 0 {
+		t.Fatalf("enraged %v, enrage in %v before the timer ran out", boss.Enraged, g.bossBar.Status().EnrageIn)
+	}
+
+	g.updateBosses(1)
+
+	if !boss.Enraged {
+		t.Error("boss should enrage when its timer runs out")
+	}
+}
+
+func TestBossBarMarksPhases(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	spawnTestBoss(g, MonsterBossManager)
+	g.updateBosses(1.0 / 60)
+
+	if got := g.bossBar.Status().Phases; !slices.Equal(got, []float64{0.5}) {
+		t.Errorf("phase markers = %v, want the half-HP phase only", got)
+	}
+}
+
+func TestContentValidationCatchesBrokenBossBehaviors(t *testing.T) {
+	saved := BossBehaviors[MonsterBossDeadline]
+	defer func() { BossBehaviors[MonsterBossDeadline] = saved }()
+
+	BossBehaviors[MonsterBossDeadline] = &BossBehavior{
+		Phases: []BossPhase{
+			{At: 0.3, Name: "Out of order"},
+			{
+				At:     0.6,
+				Name:   "Summons a boss",
+				Summon: &BossSummon{Type: MonsterBossManager, Count: 1, Every: 5, Max: 1},
+			},
+		},
+	}
+
+	var r content.Report
+
+	validateContent(&r)
+
+	if r.Errors() != 2 {
+		t.Errorf("%d errors, want 2: %v", r.Errors(), r.Issues())
+	}
+}
@@ -0,0 +1,482 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combo"
+)
+
+// devFlag enables developer tools such as the boss editor.
+const devFlag = "-dev"
+
+// Boss editor layout and tuning.
+const (
+	editorSnap      = 0.25 // Seconds the cursor and steps move by
+	editorArenaTop  = 40
+	editorArenaBot  = 480
+	editorBossDist  = 260.0 // Boss spawn distance from the dummy
+	editorDummyHP   = 1_000_000_000
+	editorTimelineX = 40
+	editorTimelineY = 520
+	editorTimelineW = screenWidth - 2*editorTimelineX
+	editorTimelineH = 40
+)
+
+// patternStepColors tell the step kinds apart on the timeline.
+var patternStepColors = map[PatternStepKind]color.RGBA{
+	StepRing:   {R: 255, G: 170, B: 60, A: 255},
+	StepCharge: {R: 230, G: 70, B: 60, A: 255},
+	StepSlam:   {R: 170, G: 90, B: 230, A: 255},
+}
+
+// bossEditor is the dev-only boss pattern editor: attack steps are placed on
+// a looping timeline while a preview sim plays the pattern against a dummy
+// player, and the result is exported as the JSON the boss system loads.
+type bossEditor struct {
+	boss     MonsterType
+	pattern  *BossPattern
+	cursor   float64 // Timeline time new steps are placed at
+	selected int     // Index into pattern.Steps, or -1
+	paused   bool
+	status   string // Result of the last load or export
+
+	sim      *Game
+	runner   *patternRunner // The preview boss's pattern
+	taken    int            // Damage the dummy took this loop
+	lastLoop int            // Damage over the last full loop, or -1 before one finishes
+}
+
+// openBossEditor shows the boss editor on the first boss.
+func (g *Game) openBossEditor() {
+	g.bossEditor = g.newBossEditor(MonsterBossManager)
+	g.state = StateBossEditor
+}
+
+// newBossEditor edits the pattern the boss would run now, or an empty one.
+func (g *Game) newBossEditor(mt MonsterType) *bossEditor {
+	ed := &bossEditor{boss: mt, selected: -1}
+
+	p, err := g.bossPattern(mt)
+
+	switch {
+	case err != nil:
+		ed.status = err.Error()
+	case p == nil:
+		ed.status = "No pattern yet; starting an empty one"
+	default:
+		ed.status = "Loaded " + patternFile(mt)
+	}
+
+	if p == nil {
+		p = &BossPattern{Boss: MonsterDefs[mt].Name, Length: 8}
+	}
+
+	ed.pattern = p
+	ed.restart(g)
+
+	return ed
+}
+
+// restart rebuilds the preview: the dummy stands still at the origin and the
+// boss starts the pattern from the beginning, off to its right.
+func (ed *bossEditor) restart(g *Game) {
+	def := MonsterDefs[ed.boss]
+	sim := &Game{
+		state: StatePlaying,
+		player: &Player{
+			HP: editorDummyHP, MaxHP: editorDummyHP,
+			CharType:   CharJunior,
+			Abilities:  newPlayerAbilities(CharJunior),
+			Stamina:    baseMaxStamina,
+			MaxStamina: baseMaxStamina,
+		},
+		combo:         combo.NewMeter(comboConfig),
+		monsterImages: g.monsterImages,
+	}
+
+	sim.enemies = append(sim.enemies, &Enemy{
+		X:  editorBossDist,
+		HP: def.HP, MaxHP: def.HP,
+		Speed:   def.Speed,
+		Damage:  def.Damage,
+		Radius:  def.Radius,
+		Type:    ed.boss,
+		Color:   def.Color,
+		IsBoss:  true,
+		Pattern: &patternRunner{pattern: ed.pattern},
+	})
+
+	ed.sim = sim
+	ed.runner = sim.enemies[0].Pattern
+	ed.taken = 0
+	ed.lastLoop = -1
+}
+
+// step advances the preview one tick, restarting it at the end of each loop
+// so every loop plays out the same way.
+func (ed *bossEditor) step(g *Game, dt float64) {
+	sim := ed.sim
+	sim.gameTime += dt
+	sim.player.HitTimer -= dt
+
+	hp := sim.player.HP
+
+	sim.updateEnemies(dt)
+	sim.updateBossPatterns(dt)
+	sim.updateTelegraphs(dt)
+	sim.updateParticles(dt)
+
+	ed.taken += hp - sim.player.HP
+
+	if sim.gameTime >= ed.pattern.Length {
+		taken := ed.taken
+		ed.restart(g)
+		ed.lastLoop = taken
+	}
+}
+
+// edited re-sorts the steps, keeping the selection on the same step, and
+// restarts the preview.
+func (ed *bossEditor) edited(g *Game) {
+	var keep *PatternStep
+	if ed.selected >= 0 {
+		s := ed.pattern.Steps[ed.selected]
+		keep = &s
+	}
+
+	ed.pattern.sortSteps()
+
+	if keep != nil {
+		for i, s := range ed.pattern.Steps {
+			if s == *keep {
+				ed.selected = i
+			}
+		}
+	}
+
+	ed.restart(g)
+}
+
+// addStep places a default step of the given kind at the cursor and selects it.
+func (ed *bossEditor) addStep(g *Game, kind PatternStepKind) {
+	s := patternStepDefaults[kind]
+	s.At = ed.cursor
+	ed.pattern.Steps = append(ed.pattern.Steps, s)
+	ed.selected = len(ed.pattern.Steps) - 1
+	ed.edited(g)
+}
+
+// deleteSelected removes the selected step.
+func (ed *bossEditor) deleteSelected(g *Game) {
+	if ed.selected < 0 {
+		return
+	}
+
+	steps := ed.pattern.Steps
+	ed.pattern.Steps = append(steps[:ed.selected], steps[ed.selected+1:]...)
+	ed.selected = -1
+	ed.edited(g)
+}
+
+// moveSelected shifts the selected step along the timeline, within the loop.
+func (ed *bossEditor) moveSelected(g *Game, d float64) {
+	if ed.selected < 0 {
+		return
+	}
+
+	s := &ed.pattern.Steps[ed.selected]
+	s.At = min(max(s.At+d, 0), ed.pattern.Length-editorSnap)
+	ed.cursor = s.At
+	ed.edited(g)
+}
+
+// adjustSelected changes the selected step's main value: ring shot count,
+// charge speed, or slam radius.
+func (ed *bossEditor) adjustSelected(g *Game, dir int) {
+	if ed.selected < 0 {
+		return
+	}
+
+	s := &ed.pattern.Steps[ed.selected]
+
+	switch s.Kind {
+	case StepRing:
+		s.Count = max(s.Count+2*dir, 2)
+	case StepCharge:
+		s.Speed = max(s.Speed+40*float64(dir), 40)
+	case StepSlam:
+		s.Radius = max(s.Radius+10*float64(dir), 20)
+	}
+
+	ed.edited(g)
+}
+
+// resize changes the loop length, never cutting off a step.
+func (ed *bossEditor) resize(g *Game, d float64) {
+	shortest := editorSnap
+	for _, s := range ed.pattern.Steps {
+		shortest = max(shortest, s.At+editorSnap)
+	}
+
+	ed.pattern.Length = max(ed.pattern.Length+d, shortest)
+	ed.cursor = min(ed.cursor, ed.pattern.Length-editorSnap)
+	ed.edited(g)
+}
+
+// selectStep selects step i, wrapping around, and moves the cursor to it.
+func (ed *bossEditor) selectStep(i int) {
+	n := len(ed.pattern.Steps)
+	if n == 0 {
+		ed.selected = -1
+
+		return
+	}
+
+	ed.selected = (i%n + n) % n
+	ed.cursor = ed.pattern.Steps[ed.selected].At
+}
+
+// exportPattern writes the pattern to the data store, where the next boss of
+// its type picks it up. Without a store the JSON goes to the log to copy
+// into bosspatterns/ by hand.
+func (g *Game) exportPattern(ed *bossEditor) error {
+	if err := ed.pattern.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(ed.pattern, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if g.patternStore == nil {
+		log.Printf("boss patterns: %s\n%s", patternFile(ed.boss), data)
+		ed.status = "Nowhere to save; the JSON was written to the log"
+
+		return nil
+	}
+
+	if err := g.patternStore.WriteFile(patternSlot(ed.boss), append(data, '\n')); err != nil {
+		return err
+	}
+
+	ed.status = "Exported " + patternSlot(ed.boss)
+
+	return nil
+}
+
+// timelineTime returns the snapped timeline time at screen x.
+func (ed *bossEditor) timelineTime(x int) float64 {
+	frac := float64(x-editorTimelineX) / editorTimelineW
+	t := math.Round(frac*ed.pattern.Length/editorSnap) * editorSnap
+
+	return min(max(t, 0), ed.pattern.Length-editorSnap)
+}
+
+// timelineX returns the screen x of timeline time t.
+func (ed *bossEditor) timelineX(t float64) float32 {
+	return editorTimelineX + float32(t/ed.pattern.Length)*editorTimelineW
+}
+
+// clickTimeline moves the cursor to a click on the timeline and selects the
+// step under it, if any.
+func (ed *bossEditor) clickTimeline(mx, my int) {
+	if !image.Pt(mx, my).In(image.Rect(editorTimelineX, editorTimelineY,
+		editorTimelineX+editorTimelineW, editorTimelineY+editorTimelineH)) {
+		return
+	}
+
+	ed.cursor = ed.timelineTime(mx)
+	ed.selected = -1
+
+	for i, s := range ed.pattern.Steps {
+		if math.Abs(float64(ed.timelineX(s.At))-float64(mx)) <= 6 {
+			ed.selected = i
+		}
+	}
+}
+
+func (g *Game) updateBossEditor() error {
+	ed := g.bossEditor
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.bossEditor = nil
+		g.state = StateCharSelect
+
+		return nil
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		next := MonsterBossManager
+		if ed.boss == MonsterBossManager {
+			next = MonsterBossDeadline
+		}
+
+		g.bossEditor = g.newBossEditor(next)
+
+		return nil
+	}
+
+	for i, kind := range patternStepKinds {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			ed.addStep(g, kind)
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		ed.cursor = max(ed.cursor-editorSnap, 0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		ed.cursor = min(ed.cursor+editorSnap, ed.pattern.Length-editorSnap)
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		ed.selectStep(ed.selected - 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		ed.selectStep(ed.selected + 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyA):
+		ed.moveSelected(g, -editorSnap)
+	case inpututil.IsKeyJustPressed(ebiten.KeyD):
+		ed.moveSelected(g, editorSnap)
+	case inpututil.IsKeyJustPressed(ebiten.KeyW):
+		ed.adjustSelected(g, 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		ed.adjustSelected(g, -1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete), inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		ed.deleteSelected(g)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEqual):
+		ed.resize(g, 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyMinus):
+		ed.resize(g, -1)
+	case inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		ed.paused = !ed.paused
+	case inpututil.IsKeyJustPressed(ebiten.KeyR):
+		ed.restart(g)
+	case inpututil.IsKeyJustPressed(ebiten.KeyX):
+		if err := g.exportPattern(ed); err != nil {
+			ed.status = "Export failed: " + err.Error()
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyL):
+		g.bossEditor = g.newBossEditor(ed.boss)
+
+		return nil
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		ed.clickTimeline(ebiten.CursorPosition())
+	}
+
+	if !ed.paused {
+		ed.step(g, 1.0/60.0)
+	}
+
+	return nil
+}
+
+func (g *Game) drawBossEditor(screen *ebiten.Image) {
+	ed := g.bossEditor
+
+	screen.Fill(color.RGBA{R: 20, G: 25, B: 35, A: 255})
+	ebitenutil.DebugPrintAt(screen, "BOSS EDITOR - "+MonsterDefs[ed.boss].Name+" (TAB next boss)", 20, 12)
+	ebitenutil.DebugPrintAt(screen, ed.status, screenWidth/2, 12)
+
+	// Preview, clipped to the arena and centered on the dummy
+	arena := screen.SubImage(image.Rect(0, editorArenaTop, screenWidth, editorArenaBot)).(*ebiten.Image)
+	arena.Fill(color.RGBA{R: 25, G: 30, B: 40, A: 255})
+
+	sim := ed.sim
+	sim.cameraX, sim.cameraY = -screenWidth/2, -(editorArenaTop+editorArenaBot)/2
+	sim.drawTelegraphs(arena)
+	sim.drawEnemies(arena)
+	sim.drawBossPatterns(arena)
+	vector.FillCircle(arena, float32(-sim.cameraX), float32(-sim.cameraY), 14,
+		color.RGBA{R: 120, G: 120, B: 130, A: 255}, false)
+	sim.drawParticles(arena)
+
+	taken := "Dummy took " + formatInt(ed.taken) + " this loop"
+	if ed.lastLoop >= 0 {
+		taken += ", " + formatInt(ed.lastLoop) + " last loop"
+	}
+
+	ebitenutil.DebugPrintAt(screen, taken, 20, editorArenaTop+8)
+
+	if ed.paused {
+		ebitenutil.DebugPrintAt(screen, "PAUSED", screenWidth-70, editorArenaTop+8)
+	}
+
+	g.drawTimeline(screen, ed)
+
+	y := editorTimelineY + editorTimelineH + 24
+	if ed.selected >= 0 {
+		ebitenutil.DebugPrintAt(screen, describeStep(ed.pattern.Steps[ed.selected]), editorTimelineX, y)
+	} else {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Cursor %.2fs", ed.cursor), editorTimelineX, y)
+	}
+
+	help := []string{
+		"1 ring | 2 charge | 3 slam: add at cursor    LEFT/RIGHT cursor    UP/DOWN select    click timeline",
+		"A/D move step | W/S count, speed, or radius | DEL remove    -/= loop length    SPACE pause | R restart",
+		"X export JSON | L reload    ESC back",
+	}
+	for i, line := range help {
+		ebitenutil.DebugPrintAt(screen, line, editorTimelineX, screenHeight-66+i*18)
+	}
+}
+
+// drawTimeline draws the loop with second ticks, the steps, the cursor, and
+// the preview's playhead.
+func (g *Game) drawTimeline(screen *ebiten.Image, ed *bossEditor) {
+	x0, y0 := float32(editorTimelineX), float32(editorTimelineY)
+	track := color.RGBA{R: 40, G: 45, B: 55, A: 255}
+	vector.FillRect(screen, x0, y0, editorTimelineW, editorTimelineH, track, false)
+
+	for s := 0; float64(s) <= ed.pattern.Length; s++ {
+		x := ed.timelineX(float64(s))
+		vector.StrokeLine(screen, x, y0+editorTimelineH-8, x, y0+editorTimelineH, 1,
+			color.RGBA{R: 120, G: 120, B: 130, A: 255}, false)
+		ebitenutil.DebugPrintAt(screen, formatInt(s), int(x)-3, editorTimelineY+editorTimelineH+2)
+	}
+
+	for i, s := range ed.pattern.Steps {
+		x := ed.timelineX(s.At)
+		vector.FillRect(screen, x-4, y0+6, 8, editorTimelineH-16, patternStepColors[s.Kind], false)
+
+		if i == ed.selected {
+			gold := color.RGBA{R: 255, G: 215, B: 0, A: 255}
+			vector.StrokeRect(screen, x-6, y0+4, 12, editorTimelineH-12, 2, gold, false)
+		}
+	}
+
+	cx := ed.timelineX(ed.cursor)
+	vector.StrokeLine(screen, cx, y0-6, cx, y0+editorTimelineH, 1, color.White, false)
+
+	px := ed.timelineX(ed.runner.t)
+	playhead := color.NRGBA{R: 120, G: 220, B: 120, A: 200}
+	vector.StrokeLine(screen, px, y0, px, y0+editorTimelineH, 2, playhead, false)
+
+	loop := fmt.Sprintf("%.2fs loop", ed.pattern.Length)
+	ebitenutil.DebugPrintAt(screen, loop, editorTimelineX, editorTimelineY-18)
+}
+
+// describeStep summarizes a step's settings for the editor.
+func describeStep(s PatternStep) string {
+	line := fmt.Sprintf("%s at %.2fs", s.Kind, s.At)
+
+	switch s.Kind {
+	case StepRing:
+		line += fmt.Sprintf("  %d shots at %.0f px/s, %.1fx damage", s.Count, s.Speed, s.Damage)
+	case StepCharge:
+		line += fmt.Sprintf("  %.1fs windup, %.0f px/s dash", s.Windup, s.Speed)
+	case StepSlam:
+		line += fmt.Sprintf("  radius %.0f, %.1fs windup, %.1fx damage", s.Radius, s.Windup, s.Damage)
+	}
+
+	return line
+}
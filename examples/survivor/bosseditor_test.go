@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestBossEditorBuildsAndExportsAPattern(t *testing.T) {
+	g := &Game{patternStore: paths.MemFS()}
+	ed := g.newBossEditor(MonsterBossManager)
+	ed.pattern.Steps = nil
+	ed.edited(g)
+
+	// Ring at 2s, charge at 5s, then nudge the charge later
+	ed.cursor = 2
+	ed.addStep(g, StepRing)
+	ed.cursor = 5
+	ed.addStep(g, StepCharge)
+	ed.moveSelected(g, editorSnap)
+	ed.selectStep(0)
+	ed.adjustSelected(g, 1)
+
+	steps := ed.pattern.Steps
+	if len(steps) != 2 || steps[0].Kind != StepRing || steps[1].At != 5+editorSnap {
+		t.Fatalf("steps = %+v, want a ring at 2s and a charge at %vs", steps, 5+editorSnap)
+	}
+
+	if want := patternStepDefaults[StepRing].Count + 2; steps[0].Count != want {
+		t.Errorf("ring count = %d after raising it, want %d", steps[0].Count, want)
+	}
+
+	if err := g.exportPattern(ed); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next boss runs the exported pattern
+	loaded, err := g.bossPattern(MonsterBossManager)
+	if err != nil || len(loaded.Steps) != 2 || loaded.Steps[1].Kind != StepCharge {
+		t.Errorf("reloaded %+v, %v; want the exported pattern", loaded, err)
+	}
+}
+
+func TestBossEditorPreviewHitsTheDummy(t *testing.T) {
+	g := &Game{}
+	ed := g.newBossEditor(MonsterBossDeadline)
+
+	for range int(ed.pattern.Length*60) + 1 {
+		ed.step(g, 1.0/60)
+	}
+
+	if ed.lastLoop <= 0 {
+		t.Errorf("dummy took %d damage over a loop of the default pattern, want some", ed.lastLoop)
+	}
+
+	// Steps cannot be pushed out of the loop, and the loop cannot be cut short of one
+	ed.selectStep(-1)
+	ed.moveSelected(g, 100)
+
+	if last := ed.pattern.Steps[len(ed.pattern.Steps)-1]; last.At >= ed.pattern.Length {
+		t.Errorf("step moved to %vs, past the %vs loop", last.At, ed.pattern.Length)
+	}
+
+	ed.resize(g, -100)
+
+	if err := ed.pattern.Validate(); err != nil {
+		t.Errorf("shrinking the loop broke the pattern: %v", err)
+	}
+}
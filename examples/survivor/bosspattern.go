@@ -0,0 +1,394 @@
+package main
+
+import (
+	"cmp"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"log"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// Default boss patterns, one JSON file per boss. A pattern exported from the
+// boss editor to the data store takes precedence, so a pattern can be tuned
+// without recompiling.
+//
+//go:embed bosspatterns/*.json
+var bossPatternFS embed.FS
+
+// Boss pattern tuning.
+const (
+	bossShotRadius  = 6.0
+	bossShotLife    = 4.0  // Seconds before a shot fizzles
+	bossShotHitDist = 26.0 // Shot to player center distance that counts as a hit
+	chargeOvershoot = 80.0 // Pixels a charge carries on past the locked target
+)
+
+// PatternStepKind is an attack a boss pattern can schedule.
+type PatternStepKind string
+
+const (
+	StepRing   PatternStepKind = "ring"   // Burst of shots in every direction, one aimed at the player
+	StepCharge PatternStepKind = "charge" // Wind up, then dash through the player's position
+	StepSlam   PatternStepKind = "slam"   // Telegraphed strike under the player
+)
+
+// patternStepKinds lists the kinds in the order the editor offers them.
+var patternStepKinds = []PatternStepKind{StepRing, StepCharge, StepSlam}
+
+// PatternStep is one attack on a boss pattern's timeline. Fields a kind does
+// not use are left out of the JSON.
+type PatternStep struct {
+	At     float64         `json:"at"` // Seconds into the loop
+	Kind   PatternStepKind `json:"kind"`
+	Count  int             `json:"count,omitempty"`  // Ring shots
+	Speed  float64         `json:"speed,omitempty"`  // Ring shot or charge speed, pixels per second
+	Radius float64         `json:"radius,omitempty"` // Slam area
+	Windup float64         `json:"windup,omitempty"` // Seconds of warning before a charge or slam
+	Damage float64         `json:"damage,omitempty"` // Multiplier of the boss's contact damage
+}
+
+// patternStepDefaults are the steps the editor adds for each kind.
+var patternStepDefaults = map[PatternStepKind]PatternStep{
+	StepRing:   {Kind: StepRing, Count: 12, Speed: 160, Damage: 0.5},
+	StepCharge: {Kind: StepCharge, Speed: 520, Windup: 0.8},
+	StepSlam:   {Kind: StepSlam, Radius: 110, Windup: 1.2, Damage: 1.5},
+}
+
+// BossPattern is a boss's looping timeline of attacks.
+type BossPattern struct {
+	Boss   string        `json:"boss"`   // MonsterDefs name of the boss it is for
+	Length float64       `json:"length"` // Seconds before the timeline loops
+	Steps  []PatternStep `json:"steps"`  // Sorted by At
+}
+
+// Validate reports the first step or value the boss system cannot run.
+func (p *BossPattern) Validate() error {
+	if p.Length <= 0 {
+		return fmt.Errorf("length %v must be positive", p.Length)
+	}
+
+	for i, s := range p.Steps {
+		var err error
+
+		switch {
+		case s.At < 0 || s.At >= p.Length:
+			err = fmt.Errorf("starts at %v, outside the %vs loop", s.At, p.Length)
+		case s.Windup < 0 || s.Damage < 0:
+			err = errors.New("windup and damage must not be negative")
+		case s.Kind == StepRing && (s.Count <= 0 || s.Speed <= 0):
+			err = errors.New("ring needs a positive count and speed")
+		case s.Kind == StepCharge && s.Speed <= 0:
+			err = errors.New("charge needs a positive speed")
+		case s.Kind == StepSlam && s.Radius <= 0:
+			err = errors.New("slam needs a positive radius")
+		case !slices.Contains(patternStepKinds, s.Kind):
+			err = fmt.Errorf("unknown kind %q", s.Kind)
+		}
+
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// sortSteps orders the steps by start time, keeping ties in place.
+func (p *BossPattern) sortSteps() {
+	slices.SortStableFunc(p.Steps, func(a, b PatternStep) int {
+		return cmp.Compare(a.At, b.At)
+	})
+}
+
+// parseBossPattern decodes and validates a pattern, sorting its steps.
+func parseBossPattern(data []byte) (*BossPattern, error) {
+	var p BossPattern
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	p.sortSteps()
+
+	return &p, nil
+}
+
+// patternFile returns the file name of a boss's pattern, e.g.
+// micro_manager.json.
+func patternFile(mt MonsterType) string {
+	return strings.ToLower(strings.ReplaceAll(MonsterDefs[mt].Name, " ", "_")) + ".json"
+}
+
+// patternSlot returns the name a boss's exported pattern is kept under in the
+// data store.
+func patternSlot(mt MonsterType) string {
+	return "bosspattern_" + patternFile(mt)
+}
+
+// bossPatternStore returns the store exported patterns are kept in, beside
+// the lifetime stats, or nil when there is nowhere to keep them.
+func bossPatternStore() paths.FS {
+	store, err := survivorApp.Open(paths.Data)
+	if err != nil {
+		log.Printf("boss patterns: %v", err)
+
+		return nil
+	}
+
+	return store
+}
+
+// defaultBossPattern returns the embedded pattern for a boss, or nil if it has
+// none.
+func defaultBossPattern(mt MonsterType) (*BossPattern, error) {
+	data, err := bossPatternFS.ReadFile("bosspatterns/" + patternFile(mt))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return checkedPattern(mt, data)
+}
+
+// checkedPattern parses a pattern and checks that it is for the boss mt.
+func checkedPattern(mt MonsterType, data []byte) (*BossPattern, error) {
+	p, err := parseBossPattern(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", patternFile(mt), err)
+	}
+
+	if name := MonsterDefs[mt].Name; p.Boss != name {
+		return nil, fmt.Errorf("%s: pattern is for %q, not %q", patternFile(mt), p.Boss, name)
+	}
+
+	return p, nil
+}
+
+// bossPattern returns the pattern a boss runs: the one exported from the
+// editor if there is a valid one, otherwise the embedded default. It is read
+// on every boss spawn, so an export shows up on the next boss.
+func (g *Game) bossPattern(mt MonsterType) (*BossPattern, error) {
+	if g.patternStore != nil && g.patternStore.Exists(patternSlot(mt)) {
+		data, err := g.patternStore.ReadFile(patternSlot(mt))
+		if err == nil {
+			var p *BossPattern
+			if p, err = checkedPattern(mt, data); err == nil {
+				return p, nil
+			}
+		}
+
+		log.Printf("boss patterns: ignoring exported pattern: %v", err)
+	}
+
+	return defaultBossPattern(mt)
+}
+
+// attachPattern gives a boss its attack pattern, if it has one. Bosses with
+// a pattern do not use their TelegraphAttacks entry.
+func (g *Game) attachPattern(e *Enemy) {
+	p, err := g.bossPattern(e.Type)
+	if err != nil {
+		log.Printf("boss patterns: %v", err)
+	}
+
+	if p != nil {
+		e.Pattern = &patternRunner{pattern: p}
+	}
+}
+
+// patternRunner plays a pattern's timeline for one boss.
+type patternRunner struct {
+	pattern *BossPattern
+	t       float64 // Seconds into the current loop
+	next    int     // First step not yet fired this loop
+	tempo   float64 // Timeline speed set by the boss's phase; 0 is normal
+}
+
+// rate returns how many seconds of the timeline play per second.
+func (r *patternRunner) rate() float64 {
+	if r.tempo <= 0 {
+		return 1
+	}
+
+	return r.tempo
+}
+
+// advance moves the timeline on by dt and calls fire for every step that
+// starts in that time, wrapping around at the end of the loop.
+func (r *patternRunner) advance(dt float64, fire func(PatternStep)) {
+	steps := r.pattern.Steps
+	end := r.t + dt
+
+	for {
+		for r.next < len(steps) && steps[r.next].At < end {
+			fire(steps[r.next])
+			r.next++
+		}
+
+		if end < r.pattern.Length {
+			break
+		}
+
+		end -= r.pattern.Length
+		r.next = 0
+	}
+
+	r.t = end
+}
+
+// bossCharge is a charge step in progress: winding up in place, then dashing
+// along a direction locked when the windup ends.
+type bossCharge struct {
+	Windup     float64
+	Speed      float64
+	DirX, DirY float64
+	Left       float64 // Pixels of dash remaining
+	Dashing    bool
+}
+
+// BossShot is a shot fired by a boss pattern; it hurts the player.
+type BossShot struct {
+	X, Y, VX, VY float64
+	Life         float64
+	Damage       int
+	Source       *Enemy
+}
+
+// updateBossPatterns runs the boss patterns and moves their shots and charges.
+func (g *Game) updateBossPatterns(dt float64) {
+	for _, e := range g.enemies {
+		if e.Pattern == nil || e.Dead {
+			continue
+		}
+
+		g.updateCharge(e, dt)
+		e.Pattern.advance(dt*e.Pattern.rate(), func(s PatternStep) { g.firePatternStep(e, s) })
+	}
+
+	g.updateBossShots(dt)
+}
+
+// firePatternStep starts one attack of a boss's pattern.
+func (g *Game) firePatternStep(e *Enemy, s PatternStep) {
+	p := g.player
+	damage := int(math.Round(float64(e.Damage) * s.Damage))
+
+	switch s.Kind {
+	case StepRing:
+		aim := math.Atan2(p.Y-e.Y, p.X-e.X)
+		for i := range s.Count {
+			angle := aim + 2*math.Pi*float64(i)/float64(s.Count)
+			g.bossShots = append(g.bossShots, &BossShot{
+				X: e.X, Y: e.Y,
+				VX: math.Cos(angle) * s.Speed, VY: math.Sin(angle) * s.Speed,
+				Life:   bossShotLife,
+				Damage: damage,
+				Source: e,
+			})
+		}
+	case StepCharge:
+		e.Charge = &bossCharge{Windup: s.Windup, Speed: s.Speed}
+	case StepSlam:
+		g.telegraphs = append(g.telegraphs, &Telegraph{
+			X: p.X, Y: p.Y,
+			Radius: s.Radius,
+			Windup: s.Windup,
+			Timer:  s.Windup,
+			Damage: damage,
+			Source: e,
+		})
+	}
+}
+
+// updateCharge winds up or moves a charging boss. The boss does not chase
+// the player until the dash ends.
+func (g *Game) updateCharge(e *Enemy, dt float64) {
+	c := e.Charge
+	if c == nil {
+		return
+	}
+
+	if !c.Dashing {
+		if c.Windup -= dt; c.Windup > 0 {
+			return
+		}
+
+		dx, dy := g.player.X-e.X, g.player.Y-e.Y
+		dist := math.Hypot(dx, dy)
+
+		if dist == 0 {
+			e.Charge = nil
+
+			return
+		}
+
+		c.DirX, c.DirY, c.Left = dx/dist, dy/dist, dist+chargeOvershoot
+		c.Dashing = true
+	}
+
+	step := math.Min(c.Speed*dt, c.Left)
+	e.X += c.DirX * step
+	e.Y += c.DirY * step
+
+	if c.Left -= step; c.Left <= 0 {
+		e.Charge = nil
+	}
+}
+
+// updateBossShots moves boss shots and resolves the ones that reach the player.
+func (g *Game) updateBossShots(dt float64) {
+	p := g.player
+	kept := g.bossShots[:0]
+
+	for _, s := range g.bossShots {
+		s.X += s.VX * dt
+		s.Y += s.VY * dt
+		s.Life -= dt
+
+		if p.HitTimer <= 0 && math.Hypot(p.X-s.X, p.Y-s.Y) < bossShotHitDist {
+			def := MonsterDefs[s.Source.Type]
+			g.hurtPlayerFrom(s.Source.X, s.Source.Y, s.Damage, def.ArmorPen, def.Name+" shot")
+
+			continue
+		}
+
+		if s.Life > 0 {
+			kept = append(kept, s)
+		}
+	}
+
+	clear(g.bossShots[len(kept):])
+	g.bossShots = kept
+}
+
+// drawBossPatterns draws boss shots, and the line a winding-up charge will
+// dash along.
+func (g *Game) drawBossPatterns(screen *ebiten.Image) {
+	for _, e := range g.enemies {
+		if c := e.Charge; c != nil && !c.Dashing && !e.Dead {
+			vector.StrokeLine(screen, float32(e.X-g.cameraX), float32(e.Y-g.cameraY),
+				float32(g.player.X-g.cameraX), float32(g.player.Y-g.cameraY), 3,
+				color.NRGBA{R: 255, G: 60, B: 40, A: 110}, false)
+		}
+	}
+
+	for _, s := range g.bossShots {
+		sx, sy := float32(s.X-g.cameraX), float32(s.Y-g.cameraY)
+		vector.FillCircle(screen, sx, sy, bossShotRadius+3, color.NRGBA{R: 255, G: 90, B: 40, A: 90}, false)
+		vector.FillCircle(screen, sx, sy, bossShotRadius, color.RGBA{R: 255, G: 170, B: 60, A: 255}, false)
+	}
+}
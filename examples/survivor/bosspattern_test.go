@@ -0,0 +1,152 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestPatternRunnerFiresStepsOnTimeAndLoops(t *testing.T) {
+	r := &patternRunner{pattern: &BossPattern{Length: 4, Steps: []PatternStep{
+		{At: 0, Kind: StepSlam},
+		{At: 2, Kind: StepRing},
+	}}}
+
+	var fired []float64
+
+	for tick := range 10 * 60 {
+		r.advance(1.0/60, func(s PatternStep) {
+			fired = append(fired, float64(tick+1)/60)
+		})
+	}
+
+	// Steps at 0 and 2 seconds, then again each 4 second loop
+	want := []float64{0, 2, 4, 6, 8}
+	if len(fired) != len(want) {
+		t.Fatalf("fired at %v, want %v", fired, want)
+	}
+
+	for i, at := range fired {
+		if at < want[i] || at > want[i]+1.0/60+1e-9 {
+			t.Errorf("step %d fired at %.3fs, want %vs", i, at, want[i])
+		}
+	}
+
+	// A long frame still fires every step it skips over: 0, 2, 4, 6, and 8
+	r = &patternRunner{pattern: r.pattern}
+	n := 0
+	r.advance(9, func(PatternStep) { n++ })
+
+	if n != 5 || r.t != 1 {
+		t.Errorf("a 9s frame fired %d steps and ended %vs into the loop, want 5 and 1s", n, r.t)
+	}
+}
+
+func TestBossPatternValidation(t *testing.T) {
+	for _, tc := range []struct{ json, want string }{
+		{`{"length": 0}`, "length"},
+		{`{"length": 5, "steps": [{"at": 5, "kind": "ring", "count": 8, "speed": 100}]}`, "outside"},
+		{`{"length": 5, "steps": [{"at": 1, "kind": "ring", "speed": 100}]}`, "count"},
+		{`{"length": 5, "steps": [{"at": 1, "kind": "slam"}]}`, "radius"},
+		{`{"length": 5, "steps": [{"at": 1, "kind": "laser"}]}`, "unknown kind"},
+		{`{"length": 5, "steps": [`, "unexpected end"},
+	} {
+		if _, err := parseBossPattern([]byte(tc.json)); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("parsing %s gave %v, want an error about %q", tc.json, err, tc.want)
+		}
+	}
+
+	p, err := parseBossPattern([]byte(`{"length": 5, "steps": [
+		{"at": 3, "kind": "charge", "speed": 400}, {"at": 1, "kind": "slam", "radius": 50}]}`))
+	if err != nil || p.Steps[0].Kind != StepSlam {
+		t.Errorf("a valid pattern gave %v, %v; want its steps sorted by time", p, err)
+	}
+}
+
+func TestEveryBossHasAPattern(t *testing.T) {
+	for mt, def := range MonsterDefs {
+		p, err := defaultBossPattern(mt)
+		if err != nil {
+			t.Errorf("%s: %v", def.Name, err)
+		}
+
+		if def.IsBoss != (p != nil) {
+			t.Errorf("%s: boss %v, has pattern %v", def.Name, def.IsBoss, p != nil)
+		}
+	}
+}
+
+func TestExportedPatternOverridesDefault(t *testing.T) {
+	g := &Game{patternStore: paths.MemFS()}
+	g.startGame(CharJunior)
+
+	slot := patternSlot(MonsterBossManager)
+	exported := `{"boss": "Micro Manager", "length": 3, "steps": [{"at": 1, "kind": "ring", "count": 4, "speed": 90}]}`
+
+	if err := g.patternStore.WriteFile(slot, []byte(exported)); err != nil {
+		t.Fatal(err)
+	}
+
+	g.spawnBoss()
+
+	boss := g.enemies[len(g.enemies)-1]
+	if boss.Pattern == nil || boss.Pattern.pattern.Length != 3 {
+		t.Fatalf("spawned boss runs %+v, want the exported pattern", boss.Pattern)
+	}
+
+	// A broken export falls back to the embedded default
+	if err := g.patternStore.WriteFile(slot, []byte(`{"boss": "Micro Manager"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := g.bossPattern(MonsterBossManager)
+	if want, _ := defaultBossPattern(MonsterBossManager); err != nil || p.Length != want.Length {
+		t.Errorf("broken export gave %+v, %v; want the default", p, err)
+	}
+}
+
+func TestBossPatternAttacksHurtThePlayer(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	boss := &Enemy{X: 200, HP: 1000, MaxHP: 1000, Damage: 20, Radius: 30, Type: MonsterBossManager, IsBoss: true}
+	boss.Pattern = &patternRunner{pattern: &BossPattern{Boss: "Micro Manager", Length: 10, Steps: []PatternStep{
+		{At: 0, Kind: StepRing, Count: 8, Speed: 200, Damage: 1},
+		{At: 3, Kind: StepCharge, Speed: 600, Windup: 0.5},
+	}}}
+	g.enemies = append(g.enemies, boss)
+
+	g.updateBossPatterns(1.0 / 60)
+
+	if len(g.bossShots) != 8 || g.bossShots[0].VX >= 0 {
+		t.Fatalf("ring fired %d shots, want 8 with the first aimed at the player", len(g.bossShots))
+	}
+
+	hp := g.player.HP
+	for range 2 * 60 {
+		g.updateBossPatterns(1.0 / 60)
+	}
+
+	if g.player.HP >= hp {
+		t.Error("a ring shot aimed at the player should hurt")
+	}
+
+	// The charge roots the boss for its windup, then dashes past the player
+	for range 60 {
+		g.updateBossPatterns(1.0 / 60)
+	}
+
+	if boss.Charge == nil || boss.Charge.Dashing || boss.X != 200 {
+		t.Fatalf("boss should be winding up a charge in place, charge %+v at x=%v", boss.Charge, boss.X)
+	}
+
+	for range 60 {
+		g.updateBossPatterns(1.0 / 60)
+	}
+
+	if boss.Charge != nil || boss.X > -chargeOvershoot+1 {
+		t.Errorf("boss should have dashed through the player to x=%v, got charge %+v at x=%v",
+			-chargeOvershoot, boss.Charge, boss.X)
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Budgets caps how many objects of each kind a run keeps alive at once, so
+// long runs stay within memory on low-end machines.
+type Budgets struct {
+	Enemies       int
+	Projectiles   int
+	Particles     int
+	Gems          int
+	DamageNumbers int
+}
+
+// Budget presets; Settings.LowMemory selects LowBudgets.
+var (
+	StandardBudgets = Budgets{Enemies: 800, Projectiles: 1200, Particles: 2000, Gems: 1500, DamageNumbers: 300}
+	LowBudgets      = Budgets{Enemies: 300, Projectiles: 400, Particles: 500, Gems: 400, DamageNumbers: 80}
+)
+
+// particlePressure is the gameplay budget usage above which cosmetic
+// particles are thinned out, so they are the first thing to degrade.
+const particlePressure = 0.75
+
+// budgets returns the active budget preset.
+func (g *Game) budgets() Budgets {
+	if g.settings.LowMemory {
+		return LowBudgets
+	}
+
+	return StandardBudgets
+}
+
+// budgetPressure returns the highest usage fraction among the gameplay
+// budgets: enemies, projectiles, and gems.
+func (g *Game) budgetPressure() float64 {
+	b := g.budgets()
+
+	return max(
+		float64(len(g.enemies))/float64(b.Enemies),
+		float64(len(g.projectiles))/float64(b.Projectiles),
+		float64(len(g.xpGems))/float64(b.Gems),
+	)
+}
+
+// particleAllowance returns how many of count burst particles may spawn.
+// Bursts are halved once particles pass half their budget or gameplay
+// objects come under pressure, and stop entirely at the cap.
+func (g *Game) particleAllowance(count int) int {
+	b := g.budgets()
+	count = int(math.Ceil(float64(count) * g.graphics().ParticleMult))
+
+	free := b.Particles - len(g.particles)
+	if free <= 0 {
+		g.culled.Particles += count
+
+		return 0
+	}
+
+	allowed := count
+	if len(g.particles) >= b.Particles/2 || g.budgetPressure() >= particlePressure {
+		allowed = (count + 1) / 2
+	}
+
+	allowed = min(allowed, free)
+	g.culled.Particles += count - allowed
+
+	return allowed
+}
+
+// trailAllowed reports whether projectile trails may spawn. Trails are the
+// least important effect and are dropped as soon as anything degrades.
+func (g *Game) trailAllowed() bool {
+	if len(g.particles) < g.budgets().Particles/2 && g.budgetPressure() < particlePressure {
+		return true
+	}
+
+	g.culled.Particles++
+
+	return false
+}
+
+// projectileRoom reports whether another projectile fits in the budget.
+// Weapons fire fewer projectiles at the cap rather than evicting live ones.
+func (g *Game) projectileRoom() bool {
+	if len(g.projectiles) < g.budgets().Projectiles {
+		return true
+	}
+
+	g.culled.Projectiles++
+
+	return false
+}
+
+// enemyRoom reports whether the ambient spawner may add an enemy. Bosses and
+// scripted spawn events are bounded on their own and ignore the budget.
+func (g *Game) enemyRoom() bool {
+	if len(g.enemies) < g.budgets().Enemies {
+		return true
+	}
+
+	g.culled.Enemies++
+
+	return false
+}
+
+// dropGem adds an XP gem. Once the budget is full the value is merged into
+// the nearest gem instead, so no XP is lost.
+func (g *Game) dropGem(x, y float64, value int) {
+	if len(g.xpGems) < g.budgets().Gems || len(g.xpGems) == 0 {
+		g.xpGems = append(g.xpGems, &XPGem{X: x, Y: y, Value: value})
+
+		return
+	}
+
+	nearest, best := g.xpGems[0], math.Inf(1)
+	for _, gem := range g.xpGems {
+		if d := math.Hypot(gem.X-x, gem.Y-y); d < best {
+			nearest, best = gem, d
+		}
+	}
+
+	nearest.Value += value
+	g.culled.Gems++
+}
+
+// evictDamageNumber frees the oldest damage number when the budget is full,
+// making room for a new one.
+func (g *Game) evictDamageNumber() {
+	if len(g.damageNumbers) < g.budgets().DamageNumbers {
+		return
+	}
+
+	g.freeDamageNumber(g.damageNumbers[0])
+	g.damageNumbers = append(g.damageNumbers[:0], g.damageNumbers[1:]...)
+	g.culled.DamageNumbers++
+}
+
+// drawMemorySetting shows the budget preset on the character screen.
+func (g *Game) drawMemorySetting(screen *ebiten.Image) {
+	label := "Memory: Standard"
+	if g.settings.LowMemory {
+		label = "Memory: Low (fewer enemies and effects)"
+	}
+
+	ui.DebugPrintAt(screen, label, screenWidth/2-160, screenHeight-75)
+}
+
+// drawBudgets draws the debug readout of current usage against each budget
+// and how many objects were culled this run.
+func (g *Game) drawBudgets(screen *ebiten.Image) {
+	if !g.showBudgets {
+		return
+	}
+
+	b, c := g.budgets(), g.culled
+	rows := []struct {
+		name        string
+		used, limit int
+		culled      int
+	}{
+		{"Enemies", len(g.enemies), b.Enemies, c.Enemies},
+		{"Projectiles", len(g.projectiles), b.Projectiles, c.Projectiles},
+		{"Particles", len(g.particles), b.Particles, c.Particles},
+		{"Gems", len(g.xpGems), b.Gems, c.Gems},
+		{"Dmg numbers", len(g.damageNumbers), b.DamageNumbers, c.DamageNumbers},
+	}
+
+	preset := "standard"
+	if g.settings.LowMemory {
+		preset = "low"
+	}
+
+	const (
+		lineHeight = 16
+		width      = 230
+	)
+
+	x, y := screenWidth-width-6, 110
+	vector.FillRect(
+		screen,
+		float32(x-4),
+		float32(y-4),
+		width,
+		float32((len(rows)+2)*lineHeight+8),
+		color.NRGBA{R: 0, G: 0, B: 0, A: 160},
+		false,
+	)
+	ebitenutil.DebugPrintAt(screen, "MEMORY BUDGET ("+preset+")", x, y)
+	y += lineHeight
+
+	for _, r := range rows {
+		frac := min(float64(r.used)/float64(r.limit), 1)
+
+		barCol := color.NRGBA{R: 80, G: 200, B: 80, A: 200}
+		if frac >= particlePressure {
+			barCol = color.NRGBA{R: 230, G: 80, B: 60, A: 200}
+		}
+
+		vector.FillRect(screen, float32(x), float32(y+12), float32(frac*(width-8)), 2, barCol, false)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%-11s %4d/%-4d -%d", r.name, r.used, r.limit, r.culled), x, y)
+		y += lineHeight
+	}
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Pressure %3.0f%%", g.budgetPressure()*100), x, y)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+)
+
+func newBudgetTestGame() *Game {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.settings.LowMemory = true
+
+	return g
+}
+
+func TestBudgetThinsParticlesFirst(t *testing.T) {
+	g := newBudgetTestGame()
+
+	g.spawnParticle(0, 0, 10, color.RGBA{A: 255})
+
+	if len(g.particles) != 10 {
+		t.Fatalf("particles = %d, want the full burst while under budget", len(g.particles))
+	}
+
+	// Gameplay pressure halves bursts and drops trails before anything else
+	for range LowBudgets.Enemies * 3 / 4 {
+		g.spawnMonster(MonsterBug, 5000, 5000)
+	}
+
+	g.spawnParticle(0, 0, 10, color.RGBA{A: 255})
+	g.spawnTrailParticle(0, 0, 4, color.RGBA{A: 255})
+
+	if len(g.particles) != 15 {
+		t.Errorf("particles = %d, want a halved burst and no trail under pressure", len(g.particles))
+	}
+
+	for len(g.particles) < LowBudgets.Particles {
+		g.spawnParticle(0, 0, 100, color.RGBA{A: 255})
+	}
+
+	g.spawnParticle(0, 0, 10, color.RGBA{A: 255})
+
+	if len(g.particles) != LowBudgets.Particles || g.culled.Particles == 0 {
+		t.Errorf("particles = %d (culled %d), want capped at %d",
+			len(g.particles), g.culled.Particles, LowBudgets.Particles)
+	}
+}
+
+func TestBudgetMergesGemsAtCap(t *testing.T) {
+	g := newBudgetTestGame()
+
+	for i := range LowBudgets.Gems {
+		g.dropGem(float64(i)*100, 0, 1)
+	}
+
+	g.dropGem(1010, 0, 7)
+
+	if len(g.xpGems) != LowBudgets.Gems {
+		t.Fatalf("gems = %d, want capped at %d", len(g.xpGems), LowBudgets.Gems)
+	}
+
+	if g.xpGems[10].Value != 8 {
+		t.Errorf("nearest gem value = %d, want 8 with the merged XP", g.xpGems[10].Value)
+	}
+}
+
+func TestBudgetEvictsOldestDamageNumber(t *testing.T) {
+	g := newBudgetTestGame()
+
+	for i := range LowBudgets.DamageNumbers + 5 {
+		g.addDamageNumber(0, 0, i, false)
+	}
+
+	if len(g.damageNumbers) != LowBudgets.DamageNumbers {
+		t.Fatalf("damage numbers = %d, want %d", len(g.damageNumbers), LowBudgets.DamageNumbers)
+	}
+
+	if g.damageNumbers[0].Value != 5 {
+		t.Errorf("oldest damage number = %d, want 5 after evicting the first five", g.damageNumbers[0].Value)
+	}
+}
+
+func TestBudgetStopsAmbientSpawns(t *testing.T) {
+	g := newBudgetTestGame()
+
+	for range LowBudgets.Enemies {
+		g.spawnMonster(MonsterBug, 5000, 5000)
+	}
+
+	g.spawnEvents = nil // Scripted events ignore the budget
+	g.gameTime = 100
+	g.updateSpawning(1)
+
+	if len(g.enemies) != LowBudgets.Enemies || g.culled.Enemies == 0 {
+		t.Errorf("enemies = %d (culled %d), want capped at %d",
+			len(g.enemies), g.culled.Enemies, LowBudgets.Enemies)
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// buildCodePrefix starts every build code and names its format version, so
+// a code from a future format is refused instead of misread.
+const buildCodePrefix = "NWB1."
+
+// buildCodeLineLen is how many characters of a build code the game over
+// screen shows per line.
+const buildCodeLineLen = 140
+
+// buildCodeMaxJSON bounds a decoded build, so a crafted code cannot inflate
+// into an enormous allocation.
+const buildCodeMaxJSON = 64 << 10
+
+// buildCodeFile is where the last exported build code is saved in the data
+// directory, to copy out or load in the sandbox in a later session.
+const buildCodeFile = "build_code.txt"
+
+var errBuildCodeVersion = errors.New("not a build code, or from another version")
+
+// Build is a character's loadout at the end of a run: enough to rebuild it
+// in the sandbox, but none of the run itself (time, seed, or kills).
+type Build struct {
+	Char      CharacterType       `json:"c"`
+	Level     int                 `json:"l"`
+	Weapons   []BuildWeapon       `json:"w"`
+	Passives  map[PassiveType]int `json:"p,omitempty"`
+	Nodes     []int               `json:"t,omitempty"` // Allocated passive tree node IDs
+	Equipment []*Equipment        `json:"e,omitempty"` // Equipped items; each knows its slot
+	Mutation  string              `json:"m,omitempty"`
+}
+
+// BuildWeapon is a weapon in a build.
+type BuildWeapon struct {
+	Type  WeaponType `json:"t"`
+	Level int        `json:"l"`
+}
+
+// exportBuild captures the player's current build.
+func (g *Game) exportBuild() Build {
+	p := g.player
+	b := Build{Char: p.CharType, Level: p.Level, Passives: p.Passives, Mutation: p.Mutation}
+
+	for _, w := range p.Weapons {
+		b.Weapons = append(b.Weapons, BuildWeapon{Type: w.Type, Level: w.Level})
+	}
+
+	for id, ok := range p.AllocatedNodes {
+		if ok {
+			b.Nodes = append(b.Nodes, id)
+		}
+	}
+
+	slices.Sort(b.Nodes)
+
+	for slot := range SlotCount {
+		if equip := p.Equipment[slot]; equip != nil {
+			b.Equipment = append(b.Equipment, equip)
+		}
+	}
+
+	return b
+}
+
+// Code encodes the build as compact JSON, deflated and written in URL-safe
+// base64 after buildCodePrefix.
+func (b Build) Code() (string, error) {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := w.Write(raw); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return buildCodePrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// ParseBuildCode decodes a build code and checks that everything it names
+// exists in this version of the game. Surrounding spaces are ignored.
+func ParseBuildCode(code string) (Build, error) {
+	var b Build
+
+	payload, ok := strings.CutPrefix(strings.TrimSpace(code), buildCodePrefix)
+	if !ok {
+		return b, errBuildCodeVersion
+	}
+
+	deflated, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return b, fmt.Errorf("build code: %w", err)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(deflated)), buildCodeMaxJSON+1))
+	if err != nil {
+		return b, fmt.Errorf("build code: %w", err)
+	}
+
+	if len(raw) > buildCodeMaxJSON {
+		return b, errors.New("build code is too large")
+	}
+
+	if err := json.Unmarshal(raw, &b); err != nil {
+		return b, fmt.Errorf("build code: %w", err)
+	}
+
+	return b, b.validate()
+}
+
+// validate reports the first thing in the build this game does not have.
+func (b Build) validate() error {
+	if int(b.Char) < 0 || int(b.Char) >= len(Characters) {
+		return fmt.Errorf("build names unknown character %d", b.Char)
+	}
+
+	for _, w := range b.Weapons {
+		if _, ok := WeaponDefs[w.Type]; !ok || w.Level < 1 {
+			return fmt.Errorf("build has unknown weapon %d at level %d", w.Type, w.Level)
+		}
+	}
+
+	for pt, lvl := range b.Passives {
+		if _, ok := PassiveDefs[pt]; !ok || lvl < 0 {
+			return fmt.Errorf("build has unknown passive %d at level %d", pt, lvl)
+		}
+	}
+
+	nodes := make(map[int]bool)
+	for _, node := range passiveTreeNodes() {
+		nodes[node.ID] = true
+	}
+
+	for _, id := range b.Nodes {
+		if !nodes[id] {
+			return fmt.Errorf("build allocates unknown tree node %d", id)
+		}
+	}
+
+	for _, e := range b.Equipment {
+		if e == nil || e.Slot < 0 || e.Slot >= SlotCount || e.Rarity < RarityCommon || e.Rarity > RarityLegendary {
+			return errors.New("build has an item with an unknown slot or rarity")
+		}
+
+		for _, m := range e.Modifiers {
+			if _, ok := ModTypeNames[m.Type]; !ok {
+				return fmt.Errorf("%s has unknown modifier %d", e.Name, m.Type)
+			}
+		}
+	}
+
+	if _, ok := mutationByName(b.Mutation); b.Mutation != "" && !ok {
+		return fmt.Errorf("build has unknown mutation %q", b.Mutation)
+	}
+
+	return nil
+}
+
+// buildCodeStore returns the store build codes are saved in, beside the
+// lifetime stats, or nil when there is nowhere to keep them.
+func buildCodeStore() paths.FS {
+	store, err := survivorApp.Open(paths.Data)
+	if err != nil {
+		log.Printf("build export: %v", err)
+
+		return nil
+	}
+
+	return store
+}
+
+// shareBuild encodes the build the run ended with for the game over screen
+// and saves it to buildCodeFile, so it can be copied and imported in another
+// sandbox.
+func (g *Game) shareBuild() {
+	code, err := g.exportBuild().Code()
+	if err != nil {
+		log.Printf("build export: %v", err)
+
+		return
+	}
+
+	g.buildCode, g.buildShared = code, true
+
+	if g.buildStore == nil {
+		return
+	}
+
+	if err := g.buildStore.WriteFile(buildCodeFile, []byte(code+"\n")); err != nil {
+		log.Printf("build export: %v", err)
+	}
+}
+
+// savedBuildCode returns the code last saved to buildCodeFile, or "" if
+// there is none.
+func (g *Game) savedBuildCode() string {
+	if g.buildStore == nil || !g.buildStore.Exists(buildCodeFile) {
+		return ""
+	}
+
+	data, err := g.buildStore.ReadFile(buildCodeFile)
+	if err != nil {
+		log.Printf("build import: %v", err)
+
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// drawBuildCode draws the exported build code centered on the screen from y
+// down, split into lines that fit it.
+func (g *Game) drawBuildCode(screen *ebiten.Image, y int) {
+	for code := g.buildCode; code != ""; y += 16 {
+		line := code[:min(len(code), buildCodeLineLen)]
+		code = code[len(line):]
+
+		ui.DebugPrintAt(screen, line, (screenWidth-len(line)*6)/2, y)
+	}
+}
+
+// loadBuild restarts the sandbox as the build's character with its
+// weapons, passives, tree, gear, and mutation, at full health.
+func (g *Game) loadBuild(b Build) {
+	g.startSandbox(b.Char)
+
+	p := g.player
+	p.Level = max(b.Level, 1)
+	p.Mutation = b.Mutation
+
+	p.Weapons = p.Weapons[:0]
+	for _, w := range b.Weapons {
+		p.Weapons = append(p.Weapons, &Weapon{Type: w.Type, Level: w.Level})
+	}
+
+	p.Passives = make(map[PassiveType]int, len(b.Passives))
+	for pt, lvl := range b.Passives {
+		p.Passives[pt] = lvl
+	}
+
+	p.AllocatedNodes = make(map[int]bool, len(b.Nodes))
+	for _, id := range b.Nodes {
+		p.AllocatedNodes[id] = true
+	}
+
+	p.Equipment = make(map[EquipSlot]*Equipment, len(b.Equipment))
+	for _, e := range b.Equipment {
+		p.Equipment[e.Slot] = e
+	}
+
+	p.HasRevival = p.Passives[PassiveRevival] > 0
+
+	g.recalculateStats()
+	p.HP, p.Shield = p.MaxHP, p.MaxShield
+}
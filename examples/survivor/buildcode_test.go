@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// newBuildTestGame ends a run with a build that touches every part of one.
+func newBuildTestGame() *Game {
+	g := &Game{}
+	g.startGame(CharSenior)
+
+	p := g.player
+	p.Level = 14
+	p.Weapons = append(p.Weapons, &Weapon{Type: WeaponDocker, Level: 4})
+	p.Passives[PassiveMight] = 3
+	p.Passives[PassiveArmor] = 1
+	p.AllocatedNodes[1] = true
+	p.AllocatedNodes[4] = true
+	p.Equipment[SlotKeyboard] = &Equipment{
+		Slot: SlotKeyboard, Name: "Quality Mech Keyboard", Base: "Mech Keyboard", Rarity: RarityMagic, ItemLevel: 9,
+		Modifiers: []Modifier{{Type: ModFlatDamage, Value: 7, Tier: 2}, {Type: ModCritChance, Value: 4, Tier: 1}},
+	}
+	p.Equipment[SlotChair] = &Equipment{
+		Slot: SlotChair, Name: "Office Chair", Rarity: RarityCommon,
+		Modifiers: []Modifier{{Type: ModArmor, Value: 12}},
+	}
+	p.Mutation = Mutations[0].Name
+	g.recalculateStats()
+
+	return g
+}
+
+func TestBuildCodeRoundTrip(t *testing.T) {
+	g := newBuildTestGame()
+	want := g.exportBuild()
+
+	code, err := want.Code()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(code, buildCodePrefix) || strings.ContainsAny(code, " +/=") {
+		t.Errorf("code %q should be prefixed and safe to paste in a URL or console", code)
+	}
+
+	got, err := ParseBuildCode("  " + code + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadBuildRebuildsStatsInSandbox(t *testing.T) {
+	g := newBuildTestGame()
+	p := *g.player
+	b := g.exportBuild()
+
+	loaded := &Game{}
+	loaded.loadBuild(b)
+	q := loaded.player
+
+	if loaded.sandbox == nil {
+		t.Fatal("imported builds load into the sandbox")
+	}
+
+	if q.CharType != CharSenior || q.Level != 14 || len(q.Weapons) != 2 || q.Mutation != p.Mutation {
+		t.Errorf("loaded %v level %d with %d weapons, mutation %q", q.CharType, q.Level, len(q.Weapons), q.Mutation)
+	}
+
+	if q.MaxHP != p.MaxHP || q.Armor != p.Armor || q.FlatDamage != p.FlatDamage ||
+		q.DamageMult != p.DamageMult || q.AreaMult != p.AreaMult || q.Speed != p.Speed {
+		t.Errorf("loaded stats differ: got %+v\nwant %+v", q, p)
+	}
+
+	if q.HP != q.MaxHP {
+		t.Errorf("HP = %d, want a full %d", q.HP, q.MaxHP)
+	}
+
+	// Exporting the loaded build gives the same code back
+	if again := loaded.exportBuild(); !reflect.DeepEqual(again, b) {
+		t.Errorf("re-export = %+v, want %+v", again, b)
+	}
+}
+
+func TestParseBuildCodeRejectsBadCodes(t *testing.T) {
+	encode := func(json string) string {
+		var buf bytes.Buffer
+
+		w, _ := flate.NewWriter(&buf, flate.BestSpeed)
+		w.Write([]byte(json))
+		w.Close()
+
+		return buildCodePrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	}
+
+	if _, err := ParseBuildCode("0H4Q-7ZK2-M1B8"); !errors.Is(err, errBuildCodeVersion) {
+		t.Errorf("a seed code: err = %v, want errBuildCodeVersion", err)
+	}
+
+	for name, code := range map[string]string{
+		"bad base64":       buildCodePrefix + "not base64!",
+		"not deflated":     buildCodePrefix + "aGVsbG8",
+		"bad json":         encode("{"),
+		"unknown char":     encode(`{"c":42}`),
+		"unknown weapon":   encode(`{"c":0,"w":[{"t":999,"l":1}]}`),
+		"unknown node":     encode(`{"c":0,"t":[4242]}`),
+		"unknown slot":     encode(`{"c":0,"e":[{"Slot":99}]}`),
+		"unknown modifier": encode(`{"c":0,"e":[{"Slot":0,"Modifiers":[{"Type":99}]}]}`),
+		"unknown mutation": encode(`{"c":0,"m":"Wings"}`),
+		"too large":        encode(`{"m":"` + strings.Repeat("a", buildCodeMaxJSON) + `"}`),
+	} {
+		if _, err := ParseBuildCode(code); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSandboxBuildCommandLoadsExportedBuild(t *testing.T) {
+	g := newBuildTestGame()
+	g.state = StateGameOver
+	g.shareBuild()
+
+	if !g.buildShared || g.buildCode == "" {
+		t.Fatal("shareBuild did not keep the code")
+	}
+
+	code := g.buildCode
+
+	g.startSandbox(CharJunior)
+
+	if g.buildShared || g.buildCode != code {
+		t.Error("a new run should keep the last code for the sandbox but not show it as its own")
+	}
+
+	if _, err := g.sandbox.console.Exec("build"); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.player.CharType != CharSenior || g.player.Level != 14 {
+		t.Errorf("build loaded %v level %d, want the exported Senior at 14", g.player.CharType, g.player.Level)
+	}
+
+	if _, err := g.sandbox.console.Exec("build NWB1.garbage"); err == nil {
+		t.Error("a bad code should report an error")
+	}
+}
+
+func TestExportedBuildIsSavedForLaterSessions(t *testing.T) {
+	g := newBuildTestGame()
+	g.buildStore = paths.MemFS()
+	g.shareBuild()
+
+	data, err := g.buildStore.ReadFile(buildCodeFile)
+	if err != nil || strings.TrimSpace(string(data)) != g.buildCode {
+		t.Fatalf("saved %q (%v), want the exported code", data, err)
+	}
+
+	// A later session loads it in the sandbox without the code in memory
+	later := &Game{buildStore: g.buildStore}
+	later.startSandbox(CharJunior)
+
+	if _, err := later.sandbox.console.Exec("build"); err != nil {
+		t.Fatal(err)
+	}
+
+	if later.player.CharType != CharSenior {
+		t.Errorf("loaded %v, want the saved Senior build", later.player.CharType)
+	}
+}
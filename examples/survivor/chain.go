@@ -0,0 +1,137 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+)
+
+// Forked Lightning, the legendary weapon-slot special: an on-hit proc that
+// forks to ForkCount nearby enemies at reduced damage.
+const forkLightningChance = 0.2
+
+var forkLightning = systems.ChainConfig{Range: 140, Falloff: 0.5, Fork: true}
+
+// lightningColor tints Forked Lightning arcs regardless of the source weapon.
+var lightningColor = color.RGBA{R: 180, G: 220, B: 255, A: 255}
+
+const (
+	arcLifetime = 0.15
+	arcSegments = 6
+	arcJitter   = 8.0
+)
+
+// LightningArc is a short-lived jagged segment drawn between chained targets.
+type LightningArc struct {
+	Points []components.Position
+	Timer  float64
+	Color  color.RGBA
+}
+
+// chainHit arcs a projectile hit on e to nearby enemies if the weapon chains.
+// Lightning weapons that do not chain still arc from targets in water.
+func (g *Game) chainHit(p *Projectile, e *Enemy) {
+	def := WeaponDefs[p.WeaponType]
+
+	cfg := def.Chain
+	if cfg.Jumps == 0 {
+		if def.DamageType != components.DamageLightning {
+			return
+		}
+
+		cfg = waterArc
+	}
+
+	g.chainFrom(e, cfg, p.Damage, p.Color, p.WeaponType)
+}
+
+// chainFrom plans arcs from e with the engine chain helper and damages each
+// target. Chains starting in water conduct further.
+func (g *Game) chainFrom(e *Enemy, cfg systems.ChainConfig, damage int, c color.RGBA, wt WeaponType) {
+	cfg = g.conduct(e, cfg)
+	if cfg.Jumps == 0 {
+		return
+	}
+
+	positions := make([]components.Position, len(g.enemies))
+	first := -1
+
+	for i, other := range g.enemies {
+		positions[i] = components.Position{X: other.X, Y: other.Y}
+		if other == e {
+			first = i
+		}
+	}
+
+	arcs := systems.ChainArcs(cfg, positions, first, float64(damage), func(i int) bool {
+		return g.enemies[i].Dead
+	})
+
+	for _, a := range arcs {
+		g.addLightningArc(positions[a.From], positions[a.To], c)
+		g.hitEnemy(g.enemies[a.To], max(1, int(a.Damage)), c, wt)
+	}
+}
+
+// addLightningArc builds a jagged path between two points with random
+// perpendicular offsets on the inner vertices.
+func (g *Game) addLightningArc(from, to components.Position, c color.RGBA) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+
+	nx, ny := -dy/length, dx/length
+	points := make([]components.Position, arcSegments+1)
+
+	for i := range points {
+		t := float64(i) / arcSegments
+
+		offset := 0.0
+		if i > 0 && i < arcSegments {
+			offset = (rand.Float64()*2 - 1) * arcJitter
+		}
+
+		points[i] = components.Position{X: from.X + dx*t + nx*offset, Y: from.Y + dy*t + ny*offset}
+	}
+
+	g.arcs = append(g.arcs, &LightningArc{Points: points, Timer: arcLifetime, Color: c})
+}
+
+// updateArcs fades out lightning arcs.
+func (g *Game) updateArcs(dt float64) {
+	kept := g.arcs[:0]
+
+	for _, a := range g.arcs {
+		a.Timer -= dt
+		if a.Timer > 0 {
+			kept = append(kept, a)
+		}
+	}
+
+	g.arcs = kept
+}
+
+// drawArcs renders each arc as a translucent glow with a bright core.
+func (g *Game) drawArcs(screen *ebiten.Image) {
+	for _, a := range g.arcs {
+		fade := a.Timer / arcLifetime
+		glow := color.NRGBA{R: a.Color.R, G: a.Color.G, B: a.Color.B, A: uint8(120 * fade)}
+		core := color.NRGBA{R: 255, G: 255, B: 255, A: uint8(255 * fade)}
+
+		for i := 1; i < len(a.Points); i++ {
+			x0, y0 := float32(a.Points[i-1].X-g.cameraX), float32(a.Points[i-1].Y-g.cameraY)
+			x1, y1 := float32(a.Points[i].X-g.cameraX), float32(a.Points[i].Y-g.cameraY)
+
+			vector.StrokeLine(screen, x0, y0, x1, y1, 5, glow, false)
+			vector.StrokeLine(screen, x0, y0, x1, y1, 1.5, core, false)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import "testing"
+
+// copilotStrike drops a Copilot projectile onto the first enemy.
+func copilotStrike(g *Game, damage int) {
+	p := g.newProjectile()
+	p.X, p.Y = g.enemies[0].X, g.enemies[0].Y
+	p.Damage, p.Lifetime, p.Radius, p.Piercing = damage, 0.2, 5, 1
+	p.WeaponType = WeaponCopilot
+	p.Color = WeaponDefs[WeaponCopilot].Color
+	g.projectiles = append(g.projectiles, p)
+
+	g.updateProjectiles(0)
+}
+
+func TestCopilotChainsWithFalloff(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	disableCrits(g)
+
+	for i := range 5 {
+		g.enemies = append(g.enemies, &Enemy{X: 300 + float64(i)*100, HP: 1000, MaxHP: 1000, Radius: 10})
+	}
+
+	copilotStrike(g, 100)
+
+	cfg := WeaponDefs[WeaponCopilot].Chain
+	want := []int{900, 930, 951, 966, 1000}
+
+	for i, e := range g.enemies {
+		if e.HP != want[i] {
+			t.Errorf("enemy %d HP = %d, want %d", i, e.HP, want[i])
+		}
+	}
+
+	if len(g.arcs) != cfg.Jumps {
+		t.Errorf("arcs = %d, want %d rendered segments", len(g.arcs), cfg.Jumps)
+	}
+
+	for range 20 {
+		g.updateArcs(1.0 / 60)
+	}
+
+	if len(g.arcs) != 0 {
+		t.Errorf("arcs should fade out, %d left", len(g.arcs))
+	}
+}
+
+func TestLegendaryForkLightning(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	item := g.generateEquipment(SlotKeyboard, 10, RarityLegendary)
+
+	g.player.Equipment[SlotKeyboard] = item
+	g.recalculateStats()
+
+	if g.player.ForkCount != 2 {
+		t.Fatalf("ForkCount = %d with legendary keyboard, want 2", g.player.ForkCount)
+	}
+
+	if len(g.player.Procs) != 1 || g.player.Procs[0].Name != "Forked Lightning" {
+		t.Errorf("procs = %v, want Forked Lightning registered", g.player.Procs)
+	}
+
+	g.player.Equipment[SlotKeyboard] = nil
+	g.recalculateStats()
+
+	if g.player.ForkCount != 0 || len(g.player.Procs) != 0 {
+		t.Errorf("ForkCount = %d, procs = %d after unequip, want none", g.player.ForkCount, len(g.player.Procs))
+	}
+
+	if rare := g.generateEquipment(SlotKeyboard, 10, RarityRare); hasMod(rare, ModForkLightning) {
+		t.Error("only legendary gear should roll Forked Lightning")
+	}
+}
+
+func TestForkLightningArcsToNeighbors(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	disableCrits(g)
+	g.player.Procs = []OnHitProc{forkLightningProc(2)}
+
+	g.enemies = []*Enemy{
+		{X: 300, HP: 1000, MaxHP: 1000, Radius: 10},
+		{X: 300, Y: 60, HP: 1000, MaxHP: 1000, Radius: 10},
+		{X: 300, Y: -60, HP: 1000, MaxHP: 1000, Radius: 10},
+	}
+
+	// The fork is a proc; strike until it fires
+	for range 200 {
+		g.rollProcs(&Projectile{Damage: 40, WeaponType: WeaponPrint}, g.enemies[0])
+
+		if g.enemies[1].HP < 1000 {
+			break
+		}
+	}
+
+	if g.enemies[1].HP != 980 || g.enemies[2].HP != 980 {
+		t.Errorf("fork damage = %d/%d, want both neighbors at 980", g.enemies[1].HP, g.enemies[2].HP)
+	}
+}
+
+func hasMod(item *Equipment, mt ModType) bool {
+	for _, m := range item.Modifiers {
+		if m.Type == mt {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// CoinTier is the denomination of a dropped coin.
+type CoinTier int
+
+const (
+	CoinCopper CoinTier = iota
+	CoinSilver
+	CoinGold
+)
+
+// CoinDef describes a coin denomination.
+type CoinDef struct {
+	Value  int
+	Radius float32
+	Color  color.RGBA
+}
+
+// CoinDefs lists coin denominations.
+var CoinDefs = map[CoinTier]CoinDef{
+	CoinCopper: {Value: 1, Radius: 4, Color: color.RGBA{R: 205, G: 127, B: 50, A: 255}},
+	CoinSilver: {Value: 5, Radius: 5, Color: color.RGBA{R: 200, G: 200, B: 215, A: 255}},
+	CoinGold:   {Value: 25, Radius: 7, Color: color.RGBA{R: 255, G: 215, B: 0, A: 255}},
+}
+
+// Coin is a physical currency drop collected by walking over it.
+type Coin struct {
+	X, Y   float64
+	VX, VY float64 // Initial scatter, damped to rest
+	Tier   CoinTier
+	Magnet bool
+}
+
+// MetaBonuses are permanent upgrades bought in the meta-progression shop.
+type MetaBonuses struct {
+	CoinValueMult float64 // Multiplies the value of every coin picked up
+	MagnetRange   float64 // Added to the starting pickup radius
+	StartingGold  int
+}
+
+// MetaShop connects runs to the persistent meta-progression shop.
+type MetaShop interface {
+	// RunBonuses returns the upgrades applied when a run starts.
+	RunBonuses() MetaBonuses
+	// DepositRunGold banks the gold collected during a run when it ends.
+	DepositRunGold(gold int)
+}
+
+// applyMetaBonuses applies shop upgrades to a freshly started run.
+func (g *Game) applyMetaBonuses() {
+	g.metaBonus = MetaBonuses{CoinValueMult: 1}
+
+	if g.meta == nil {
+		return
+	}
+
+	g.metaBonus = g.meta.RunBonuses()
+	if g.metaBonus.CoinValueMult <= 0 {
+		g.metaBonus.CoinValueMult = 1
+	}
+
+	g.player.MagnetRange += g.metaBonus.MagnetRange
+	g.player.Gold += g.metaBonus.StartingGold
+}
+
+// endRun ends the current run and banks its gold with the meta shop, less
+// the starting gold the shop paid out, so dying at once earns nothing.
+func (g *Game) endRun() {
+	g.state = StateGameOver
+	g.recordRunEnd()
+
+	if g.meta != nil {
+		g.meta.DepositRunGold(max(g.player.Gold-g.metaBonus.StartingGold, 0))
+	}
+}
+
+// dropCoins scatters coins worth roughly value around a point using the largest tiers first.
+func (g *Game) dropCoins(x, y float64, value int) {
+	r := g.rng.Stream(streamLoot)
+
+	for _, tier := range []CoinTier{CoinGold, CoinSilver, CoinCopper} {
+		for value >= CoinDefs[tier].Value {
+			value -= CoinDefs[tier].Value
+
+			angle := r.Float64() * math.Pi * 2
+			speed := 1 + r.Float64()*2
+			g.coins = append(g.coins, &Coin{
+				X: x, Y: y,
+				VX: math.Cos(angle) * speed, VY: math.Sin(angle) * speed,
+				Tier: tier,
+			})
+		}
+	}
+}
+
+// dropEnemyCoins rolls the coin drop for a killed enemy.
+func (g *Game) dropEnemyCoins(e *Enemy) {
+	r := g.rng.Stream(streamLoot)
+
+	switch {
+	case e.IsBoss:
+		g.dropCoins(e.X, e.Y, 100+r.Intn(51))
+	case e.XP >= 5:
+		if r.Float64() < 0.5 {
+			g.dropCoins(e.X, e.Y, 5+r.Intn(6))
+		}
+	default:
+		if r.Float64() < 0.2 {
+			g.dropCoins(e.X, e.Y, 1)
+		}
+	}
+}
+
+// magnetPull moves a pickup toward the player once it is within the magnet range,
+// and returns its distance to the player. Shared by XP gems and coins.
+func (g *Game) magnetPull(x, y *float64, magnet *bool) float64 {
+	dx, dy := g.player.X-*x, g.player.Y-*y
+	dist := math.Sqrt(dx*dx + dy*dy)
+
+	if dist < g.magnetRange() || *magnet {
+		*magnet = true
+
+		speed := 10.0
+		if dist > 0 {
+			*x += (dx / dist) * speed
+			*y += (dy / dist) * speed
+		}
+	}
+
+	return dist
+}
+
+// collectCoins settles scattered coins, pulls them in, and banks picked up gold.
+func (g *Game) collectCoins() {
+	for i := len(g.coins) - 1; i >= 0; i-- {
+		c := g.coins[i]
+
+		c.X += c.VX
+		c.Y += c.VY
+		c.VX *= 0.9
+		c.VY *= 0.9
+
+		if g.magnetPull(&c.X, &c.Y, &c.Magnet) >= 25 {
+			continue
+		}
+
+		value := float64(CoinDefs[c.Tier].Value) * g.metaBonus.CoinValueMult * g.combo.Multiplier()
+		g.player.Gold += int(math.Round(value))
+		g.audio.PlayVariant("coin")
+		g.coins = append(g.coins[:i], g.coins[i+1:]...)
+	}
+}
+
+// drawCoins renders coins on the ground.
+func (g *Game) drawCoins(screen *ebiten.Image) {
+	for _, c := range g.coins {
+		sx, sy := float32(c.X-g.cameraX), float32(c.Y-g.cameraY)
+		if sx < -10 || sx > screenWidth+10 || sy < -10 || sy > screenHeight+10 {
+			continue
+		}
+
+		def := CoinDefs[c.Tier]
+		vector.FillCircle(screen, sx, sy, def.Radius, def.Color, false)
+		vector.StrokeCircle(screen, sx, sy, def.Radius, 1, color.RGBA{R: 90, G: 60, B: 20, A: 255}, false)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+type fakeMetaShop struct {
+	bonus     MetaBonuses
+	deposited int
+}
+
+func (f *fakeMetaShop) RunBonuses() MetaBonuses { return f.bonus }
+
+func (f *fakeMetaShop) DepositRunGold(gold int) { f.deposited += gold }
+
+func coinTotal(coins []*Coin) int {
+	total := 0
+	for _, c := range coins {
+		total += CoinDefs[c.Tier].Value
+	}
+
+	return total
+}
+
+func TestDropCoinsUsesLargestTiers(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.dropCoins(500, 500, 37)
+
+	if total := coinTotal(g.coins); total != 37 {
+		t.Errorf("dropped %d gold, want 37", total)
+	}
+
+	// 25 + 5 + 5 + 1 + 1
+	if len(g.coins) != 5 || g.coins[0].Tier != CoinGold {
+		t.Errorf("got %d coins (first tier %d), want 5 starting with gold", len(g.coins), g.coins[0].Tier)
+	}
+}
+
+func TestCoinsMagnetAndMetaShop(t *testing.T) {
+	shop := &fakeMetaShop{bonus: MetaBonuses{CoinValueMult: 2, MagnetRange: 40, StartingGold: 10}}
+	g := &Game{meta: shop}
+	g.startGame(CharJunior)
+
+	if g.player.Gold != 10 || g.player.MagnetRange != 120 {
+		t.Fatalf("meta bonuses not applied: gold=%d magnet=%v", g.player.Gold, g.player.MagnetRange)
+	}
+
+	g.recalculateStats()
+
+	if g.player.MagnetRange != 120 {
+		t.Errorf("magnet bonus lost on stat recalculation: %v", g.player.MagnetRange)
+	}
+
+	// A coin just inside the boosted magnet range is pulled in and doubled
+	g.coins = []*Coin{{X: 110, Tier: CoinSilver}, {X: 400, Tier: CoinGold}}
+	for range 30 {
+		g.collectCoins()
+	}
+
+	if g.player.Gold != 20 || len(g.coins) != 1 {
+		t.Errorf("gold = %d with %d coins left, want 20 and 1", g.player.Gold, len(g.coins))
+	}
+
+	g.player.HP = 1
+	g.hurtPlayer(100, 0, "test")
+
+	// The starting gold is not banked again
+	if g.state != StateGameOver || shop.deposited != 10 {
+		t.Errorf("run end: state=%d deposited=%d, want game over and 10", g.state, shop.deposited)
+	}
+}
+
+func TestCoinShopBanksGoldAndSellsUpgrades(t *testing.T) {
+	sm := game.NewSaveManagerFS(paths.MemFS())
+	shop := loadCoinShop(sm)
+
+	if b := shop.RunBonuses(); b.CoinValueMult != 1 || b.MagnetRange != 0 || b.StartingGold != 0 {
+		t.Fatalf("empty shop bonuses = %+v, want none", b)
+	}
+
+	shop.DepositRunGold(200)
+
+	if shop.Buy(UpgradeCoinValue) || shop.Bank != 200 {
+		t.Fatalf("bought a 150 gold upgrade twice over: bank=%d", shop.Bank)
+	}
+
+	if !shop.Buy(UpgradeMagnet) || !shop.Buy(UpgradeStartingGold) || shop.Bank != 20 {
+		t.Fatalf("bank after buying magnet and starting gold = %d, want 20", shop.Bank)
+	}
+
+	shop = loadCoinShop(sm)
+	if shop.Bank != 20 || shop.Level(UpgradeMagnet) != 1 || shop.Level(UpgradeStartingGold) != 1 {
+		t.Fatalf("reloaded shop: bank=%d magnet=%d gold=%d", shop.Bank,
+			shop.Level(UpgradeMagnet), shop.Level(UpgradeStartingGold))
+	}
+
+	g := &Game{meta: shop}
+	g.startGame(CharJunior)
+
+	if g.player.Gold != 25 || g.player.MagnetRange != 95 {
+		t.Errorf("run start: gold=%d magnet=%v, want 25 and 95", g.player.Gold, g.player.MagnetRange)
+	}
+}
+
+func TestCoinShopMaxedUpgradeIsNotSold(t *testing.T) {
+	shop := loadCoinShop(nil)
+	shop.DepositRunGold(100000)
+
+	def := CoinUpgradeDefs[UpgradeMagnet]
+	for range def.MaxLevel {
+		shop.Buy(UpgradeMagnet)
+	}
+
+	if shop.Cost(UpgradeMagnet) != 0 || shop.Buy(UpgradeMagnet) || shop.Level(UpgradeMagnet) != def.MaxLevel {
+		t.Errorf("maxed magnet: cost=%d level=%d", shop.Cost(UpgradeMagnet), shop.Level(UpgradeMagnet))
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/combo"
+)
+
+// comboMagnetBonus is pickup range added per combo tier.
+const comboMagnetBonus = 15.0
+
+// comboConfig builds the kill streak: kills come fast, so the tiers are deep
+// and a lapsed streak drains rather than ending. The multiplier applies to
+// gold picked up.
+var comboConfig = combo.Config{
+	Window: 2.5,
+	Decay:  15,
+	Tiers: []combo.Tier{
+		{Kills: 15, Multiplier: 1.1, Name: "Streak"},
+		{Kills: 40, Multiplier: 1.25, Name: "Rampage"},
+		{Kills: 80, Multiplier: 1.5, Name: "Massacre"},
+		{Kills: 150, Multiplier: 2, Name: "Legendary"},
+	},
+}
+
+// comboMagnet returns the pickup range the current combo tier adds.
+func (g *Game) comboMagnet() float64 {
+	if g.combo == nil {
+		return 0
+	}
+
+	return comboMagnetBonus * float64(g.combo.Tier()+1)
+}
+
+// drawCombo shows the combo meter under the top bar.
+func (g *Game) drawCombo(screen *ebiten.Image) {
+	if g.combo != nil {
+		g.combo.Draw(screen, 10, 70, 170)
+	}
+}
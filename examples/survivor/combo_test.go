@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestComboMultipliesGoldAndMagnet(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	base := g.magnetRange()
+
+	// Enough quick kills to reach the second tier
+	for range comboConfig.Tiers[1].Kills {
+		g.killEnemy(&Enemy{Type: MonsterBug, HP: 0, MaxHP: 10})
+	}
+
+	if g.combo.Tier() != 1 {
+		t.Fatalf("combo tier = %d after %d kills, want 1", g.combo.Tier(), g.combo.Count())
+	}
+
+	if got, want := g.magnetRange(), base+2*comboMagnetBonus; got != want {
+		t.Errorf("magnet range = %v, want %v", got, want)
+	}
+
+	gold := g.player.Gold
+	g.coins = []*Coin{{X: g.player.X, Y: g.player.Y, Tier: CoinGold}}
+	g.collectCoins()
+
+	if got, want := g.player.Gold-gold, 31; got != want {
+		t.Errorf("gold coin worth %d at x%v, want %d", got, g.combo.Multiplier(), want)
+	}
+
+	// A new run starts without a streak
+	g.startGame(CharJunior)
+
+	if g.combo.Count() != 0 || g.magnetRange() != base {
+		t.Errorf("combo %d and magnet %v after restart, want 0 and %v", g.combo.Count(), g.magnetRange(), base)
+	}
+}
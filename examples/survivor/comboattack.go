@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// ComboAttack identifies a joint attack two base weapons make together.
+// Unlike an evolution it needs no passive and leaves both weapons in place:
+// owning the pair is enough, so it gives a build direction early in a run.
+type ComboAttack int
+
+const (
+	ComboTestStorm ComboAttack = iota
+	ComboCopyPaste
+	ComboSandbox
+)
+
+// ComboPattern is the shape a combo attack's projectiles take.
+type ComboPattern int
+
+const (
+	ComboBurst   ComboPattern = iota // A ring flying outward from the player
+	ComboBarrage                     // Bolts dropped on the nearest enemies
+	ComboRing                        // A still ring around the player
+)
+
+// ComboAttackDef describes a combo attack: the two weapons that make it and
+// the attack they fire together every Cooldown seconds.
+type ComboAttackDef struct {
+	Name    string
+	Desc    string
+	Weapons [2]WeaponType
+	// Source is the weapon whose damage type, ailment, and look the
+	// projectiles take
+	Source     WeaponType
+	Pattern    ComboPattern
+	Cooldown   float64
+	DamageMult float64 // Of both weapons' hits added together
+	Count      int
+	Radius     float64 // Of each projectile
+	Range      float64 // Ring radius, or how far a barrage looks for enemies
+	Speed      float64
+	Duration   float64
+	Color      color.RGBA
+}
+
+// ComboAttacks is the combo registry.
+var ComboAttacks = map[ComboAttack]ComboAttackDef{
+	ComboTestStorm: {
+		Name:    "Caffeinated Test Storm",
+		Desc:    "Jittery unit tests burst out in every direction",
+		Weapons: [2]WeaponType{WeaponCoffee, WeaponUnitTests},
+		Source:  WeaponUnitTests, Pattern: ComboBurst,
+		Cooldown: 8, DamageMult: 0.5,
+		Count: 12, Radius: 12, Speed: 6, Duration: 1.2,
+		Color: color.RGBA{R: 180, G: 255, B: 120, A: 255},
+	},
+	ComboCopyPaste: {
+		Name:    "Copy-Paste Barrage",
+		Desc:    "Every nearby enemy gets the accepted answer, printed",
+		Weapons: [2]WeaponType{WeaponPrint, WeaponStackOverflow},
+		Source:  WeaponStackOverflow, Pattern: ComboBarrage,
+		Cooldown: 10, DamageMult: 0.6,
+		Count: 8, Radius: 26, Range: 350, Speed: 20, Duration: 0.3,
+		Color: color.RGBA{R: 255, G: 170, B: 60, A: 255},
+	},
+	ComboSandbox: {
+		Name:    "Sandboxed Firewall",
+		Desc:    "Containers of fire ring the player for a few seconds",
+		Weapons: [2]WeaponType{WeaponFirewall, WeaponDocker},
+		Source:  WeaponFirewall, Pattern: ComboRing,
+		Cooldown: 12, DamageMult: 0.4,
+		Count: 8, Radius: 16, Range: 110, Duration: 3,
+		Color: color.RGBA{R: 255, G: 110, B: 60, A: 255},
+	},
+}
+
+// comboAttackKey is the compendium key of a combo attack.
+func comboAttackKey(ca ComboAttack) string { return "combo:" + ComboAttacks[ca].Name }
+
+// evolutionBase returns the base weapon an evolved weapon came from, or wt
+// itself. Combos count an evolved weapon as its base.
+func evolutionBase(wt WeaponType) WeaponType {
+	for _, r := range Evolutions {
+		if r.Result == wt {
+			return r.BaseWeapon
+		}
+	}
+
+	return wt
+}
+
+// comboWeapons returns the player's weapons filling def's two slots, or
+// nil when either is missing.
+func (g *Game) comboWeapons(def ComboAttackDef) []*Weapon {
+	ws := make([]*Weapon, 0, len(def.Weapons))
+
+	for _, wt := range def.Weapons {
+		i := slices.IndexFunc(g.player.Weapons, func(w *Weapon) bool { return evolutionBase(w.Type) == wt })
+		if i < 0 {
+			return nil
+		}
+
+		ws = append(ws, g.player.Weapons[i])
+	}
+
+	return ws
+}
+
+// comboCompletedBy returns the combo attacks taking wt would complete with
+// the weapons the player already has.
+func (g *Game) comboCompletedBy(wt WeaponType) []ComboAttack {
+	var done []ComboAttack
+
+	for _, ca := range slices.Sorted(maps.Keys(ComboAttacks)) {
+		def := ComboAttacks[ca]
+		if !slices.Contains(def.Weapons[:], wt) || g.comboWeapons(def) != nil {
+			continue
+		}
+
+		partner := def.Weapons[0]
+		if partner == wt {
+			partner = def.Weapons[1]
+		}
+
+		if slices.ContainsFunc(g.player.Weapons, func(w *Weapon) bool { return evolutionBase(w.Type) == partner }) {
+			done = append(done, ca)
+		}
+	}
+
+	return done
+}
+
+// updateComboAttacks fires each combo attack the player's weapons make when
+// its cooldown is up. A combo is announced and discovered the first time it
+// comes together in a run.
+func (g *Game) updateComboAttacks(dt float64) {
+	for _, ca := range slices.Sorted(maps.Keys(ComboAttacks)) {
+		def := ComboAttacks[ca]
+
+		ws := g.comboWeapons(def)
+		if ws == nil {
+			continue
+		}
+
+		timer, active := g.comboTimers[ca]
+		if !active {
+			g.notifyCombo(def)
+			g.discover(comboAttackKey(ca))
+		}
+
+		timer += dt
+		if timer >= def.Cooldown*g.player.EffectiveCooldownMult() {
+			g.fireCombo(def, ws)
+			timer = 0
+		}
+
+		g.comboTimers[ca] = timer
+	}
+}
+
+// comboDamage is the damage of one combo projectile: a share of what both
+// weapons hit for at their levels.
+func (g *Game) comboDamage(def ComboAttackDef, ws []*Weapon) int {
+	total := 0
+	for _, w := range ws {
+		total += g.damageCalc(w.Type, w.Level).Hit()
+	}
+
+	return int(float64(total) * def.DamageMult)
+}
+
+// fireCombo fires a combo attack from the weapons ws.
+func (g *Game) fireCombo(def ComboAttackDef, ws []*Weapon) {
+	damage := g.comboDamage(def, ws)
+	px, py := g.player.X, g.player.Y
+
+	spawn := func(x, y, vx, vy float64, piercing int) {
+		if !g.projectileRoom() {
+			return
+		}
+
+		p := g.newProjectile()
+		p.X, p.Y = x, y
+		p.VX, p.VY = vx, vy
+		p.Damage = damage
+		p.Lifetime = def.Duration * g.player.DurationMult
+		p.Radius = def.Radius
+		p.Piercing = piercing
+		p.Color = def.Color
+		p.WeaponType = def.Source
+		p.Child, p.Combo = false, true
+		g.projectiles = append(g.projectiles, p)
+	}
+
+	switch def.Pattern {
+	case ComboBurst:
+		for i := range def.Count {
+			angle := float64(i) * 2 * math.Pi / float64(def.Count)
+			cos, sin := math.Cos(angle), math.Sin(angle)
+			spawn(px+cos*20, py+sin*20, cos*def.Speed, sin*def.Speed, 3)
+		}
+	case ComboBarrage:
+		for _, e := range g.findNearestEnemies(def.Count, def.Range) {
+			spawn(e.X, e.Y-50, 0, def.Speed, 1)
+		}
+	case ComboRing:
+		r := def.Range * g.player.AreaMult
+		for i := range def.Count {
+			angle := float64(i) * 2 * math.Pi / float64(def.Count)
+			spawn(px+math.Cos(angle)*r, py+math.Sin(angle)*r, 0, 0, 999)
+		}
+	}
+
+	g.audio.PlaySound("shoot")
+	g.spawnParticle(px, py, 12, def.Color)
+}
+
+// notifyCombo announces a combo attack coming together.
+func (g *Game) notifyCombo(def ComboAttackDef) {
+	g.notify(ui.Notification{
+		Title:   "Combo: " + def.Name,
+		Message: WeaponDefs[def.Weapons[0]].Name + " + " + WeaponDefs[def.Weapons[1]].Name,
+		Color:   def.Color,
+	})
+}
+
+// comboDetails describes a combo attack for the compendium. Ingredients not
+// yet discovered are hidden.
+func (g *Game) comboDetails(def ComboAttackDef) []string {
+	names := make([]string, len(def.Weapons))
+
+	for i, wt := range def.Weapons {
+		names[i] = "???"
+		if _, ok := g.discoveries().Seen(weaponKey(wt)); ok {
+			names[i] = WeaponDefs[wt].Name
+		}
+	}
+
+	return []string{
+		def.Desc,
+		"Combo of " + names[0] + " + " + names[1],
+		fmt.Sprintf("Every %.0fs, %.0f%% of both weapons' damage", def.Cooldown, def.DamageMult*100),
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/content"
+)
+
+// comboProjectiles counts the live projectiles fired by combo attacks.
+func comboProjectiles(g *Game) int {
+	n := 0
+
+	for _, p := range g.projectiles {
+		if p.Combo {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestComboAttackFiresWithBothWeapons(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	def := ComboAttacks[ComboTestStorm]
+	g.player.Weapons = []*Weapon{{Type: WeaponCoffee, Level: 1}}
+	g.updateComboAttacks(def.Cooldown)
+
+	if comboProjectiles(g) != 0 {
+		t.Fatal("one weapon of the pair should not fire the combo")
+	}
+
+	g.player.Weapons = append(g.player.Weapons, &Weapon{Type: WeaponUnitTests, Level: 3})
+
+	if _, ok := g.discoveries().Seen(comboAttackKey(ComboTestStorm)); ok {
+		t.Fatal("combo discovered before it came together")
+	}
+
+	g.updateComboAttacks(1.0 / 60)
+
+	if _, ok := g.discoveries().Seen(comboAttackKey(ComboTestStorm)); !ok || comboProjectiles(g) != 0 {
+		t.Fatal("the combo should be discovered when it comes together and fire after its cooldown")
+	}
+
+	g.updateComboAttacks(def.Cooldown)
+
+	if n := comboProjectiles(g); n != def.Count {
+		t.Fatalf("%d combo projectiles, want %d", n, def.Count)
+	}
+
+	both := g.damageCalc(WeaponCoffee, 1).Hit() + g.damageCalc(WeaponUnitTests, 3).Hit()
+	want := int(float64(both) * def.DamageMult)
+	if p := g.projectiles[0]; p.Damage != want || p.WeaponType != def.Source {
+		t.Errorf("damage %d from weapon %d, want %d from %d", p.Damage, p.WeaponType, want, def.Source)
+	}
+
+	// Unit Tests refreshes its own cast without ending the storm
+	g.expireInstances(WeaponUnitTests)
+
+	if comboProjectiles(g) != def.Count || g.liveInstances(WeaponUnitTests) != 0 {
+		t.Error("combo projectiles should not count as casts of their source weapon")
+	}
+}
+
+func TestComboAttackOutlastsEvolution(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.Weapons = []*Weapon{{Type: WeaponEspresso, Level: 1}, {Type: WeaponUnitTests, Level: 1}}
+
+	if g.comboWeapons(ComboAttacks[ComboTestStorm]) == nil {
+		t.Error("an evolved weapon should still count as its base")
+	}
+}
+
+func TestLevelUpPointsAtCombos(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.Weapons = []*Weapon{{Type: WeaponCoffee, Level: 1}}
+
+	if got := g.comboCompletedBy(WeaponUnitTests); !slices.Equal(got, []ComboAttack{ComboTestStorm}) {
+		t.Errorf("Unit Tests completes %v, want the test storm", got)
+	}
+
+	if got := g.comboCompletedBy(WeaponDocker); len(got) != 0 {
+		t.Errorf("Docker completes %v without Firewall", got)
+	}
+
+	g.player.Weapons = append(g.player.Weapons, &Weapon{Type: WeaponUnitTests, Level: 1})
+
+	if got := g.comboCompletedBy(WeaponUnitTests); len(got) != 0 {
+		t.Errorf("a combo already made should not be offered again: %v", got)
+	}
+}
+
+func TestCompendiumListsCombos(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	def := ComboAttacks[ComboCopyPaste]
+	details := strings.Join(findEntry(t, g, TabWeapons, WeaponDefs[WeaponPrint].Name).Details, "\n")
+
+	if !strings.Contains(details, "Combos with ??? into ???") {
+		t.Errorf("undiscovered combo should be hidden in:\n%s", details)
+	}
+
+	g.player.Weapons = []*Weapon{{Type: WeaponPrint, Level: 1}, {Type: WeaponStackOverflow, Level: 1}}
+	g.discoverLoadout()
+	g.updateComboAttacks(1.0 / 60)
+
+	entry := findEntry(t, g, TabCombos, def.Name)
+	if !entry.Known || entry.Details[1] != "Combo of Print Debug + StackOverflow" {
+		t.Errorf("combo entry = %+v", entry)
+	}
+}
+
+func TestContentValidationCatchesBrokenCombos(t *testing.T) {
+	saved := ComboAttacks[ComboSandbox]
+	defer func() { ComboAttacks[ComboSandbox] = saved }()
+
+	broken := ComboAttacks[ComboTestStorm]
+	broken.Weapons = [2]WeaponType{WeaponUnitTests, WeaponEspresso} // Espresso is evolved
+	broken.Source = WeaponDocker                                    // Not one of the pair
+	ComboAttacks[ComboSandbox] = broken
+
+	var r content.Report
+
+	validateContent(&r)
+
+	if r.Errors() != 2 {
+		t.Errorf("%d errors, want 2: %v", r.Errors(), r.Issues())
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+const compendiumSlot = "compendium"
+
+// compendiumRows is how many entries the compendium list shows at once.
+const compendiumRows = 24
+
+// CompendiumTab is a page of the compendium.
+type CompendiumTab int
+
+const (
+	TabWeapons CompendiumTab = iota
+	TabPassives
+	TabMonsters
+	TabEquipment
+	TabCombos
+	compendiumTabCount
+)
+
+var compendiumTabNames = map[CompendiumTab]string{
+	TabWeapons:   "Weapons",
+	TabPassives:  "Passives",
+	TabMonsters:  "Monsters",
+	TabEquipment: "Equipment",
+	TabCombos:    "Combos",
+}
+
+// Compendium records which weapons, passives, monsters, equipment bases, and
+// combo attacks the player has encountered, keyed by name so entries survive reordering
+// of the definitions. Each key maps to the run time it was first seen at.
+type Compendium struct {
+	seen  map[string]float64
+	store *game.SaveManager // Nil keeps discoveries in memory only
+}
+
+// Compendium keys for each kind of entry.
+func weaponKey(wt WeaponType) string      { return "weapon:" + WeaponDefs[wt].Name }
+func passiveKey(pt PassiveType) string    { return "passive:" + PassiveDefs[pt].Name }
+func monsterKey(mt MonsterType) string    { return "monster:" + MonsterDefs[mt].Name }
+func equipmentBaseKey(base string) string { return "base:" + base }
+
+// compendiumManager returns the save manager for discovery state, kept with
+// the lifetime stats, or nil when there is nowhere to keep it.
+func compendiumManager() *game.SaveManager {
+	store, err := survivorApp.Open(paths.Data)
+	if err != nil {
+		log.Printf("compendium: %v", err)
+
+		return nil
+	}
+
+	return game.NewSaveManagerFS(store)
+}
+
+// loadCompendium reads saved discoveries from sm, starting empty when there
+// are none or they cannot be read.
+func loadCompendium(sm *game.SaveManager) *Compendium {
+	c := &Compendium{seen: make(map[string]float64), store: sm}
+	if sm == nil || !sm.Exists(compendiumSlot) {
+		return c
+	}
+
+	save, err := sm.Load(compendiumSlot)
+	if err != nil {
+		log.Printf("compendium: %v", err)
+
+		return c
+	}
+
+	for key := range save.Data {
+		c.seen[key] = save.GetFloat(key, 0)
+	}
+
+	return c
+}
+
+// Seen reports whether key has been discovered and the run time it first was.
+func (c *Compendium) Seen(key string) (float64, bool) {
+	at, ok := c.seen[key]
+
+	return at, ok
+}
+
+// Discover records key as seen at run time at and saves the compendium.
+// It reports whether the entry is new.
+func (c *Compendium) Discover(key string, at float64) bool {
+	if _, ok := c.seen[key]; ok {
+		return false
+	}
+
+	c.seen[key] = at
+	c.save()
+
+	return true
+}
+
+// Count returns how many entries have been discovered.
+func (c *Compendium) Count() int {
+	return len(c.seen)
+}
+
+func (c *Compendium) save() {
+	if c.store == nil {
+		return
+	}
+
+	save := game.NewSaveData(compendiumSlot)
+	for key, at := range c.seen {
+		save.Set(key, at)
+	}
+
+	if err := c.store.Save(compendiumSlot, save); err != nil {
+		log.Printf("compendium: %v", err)
+	}
+}
+
+// discoveries returns the compendium, in memory only if none was loaded.
+func (g *Game) discoveries() *Compendium {
+	if g.compendium == nil {
+		g.compendium = loadCompendium(nil)
+	}
+
+	return g.compendium
+}
+
+// discover records an encounter in the compendium. The sandbox spawns
+// everything on demand, so it discovers nothing.
+func (g *Game) discover(key string) {
+	if g.sandbox != nil {
+		return
+	}
+
+	g.discoveries().Discover(key, g.gameTime)
+}
+
+// discoverLoadout records the player's weapons and passives.
+func (g *Game) discoverLoadout() {
+	for _, w := range g.player.Weapons {
+		g.discover(weaponKey(w.Type))
+	}
+
+	for pt := range g.player.Passives {
+		g.discover(passiveKey(pt))
+	}
+}
+
+// discoverMonster records a monster the first time one spawns.
+func (g *Game) discoverMonster(mt MonsterType) {
+	g.discover(monsterKey(mt))
+}
+
+// discoverItem records the base of a looted item.
+func (g *Game) discoverItem(item *Equipment) {
+	g.discover(equipmentBaseKey(item.Base))
+}
+
+// compendiumEntry is one row of a compendium tab.
+type compendiumEntry struct {
+	Name    string
+	Known   bool
+	Details []string
+	Monster *MonsterDef // Set on monster entries, for the resistance badges
+}
+
+// compendiumEntries lists a tab's entries in definition order. Undiscovered
+// entries are listed with their details hidden.
+func (g *Game) compendiumEntries(tab CompendiumTab) []compendiumEntry {
+	c := g.discoveries()
+
+	var entries []compendiumEntry
+
+	switch tab {
+	case TabWeapons:
+		for _, wt := range slices.Sorted(maps.Keys(WeaponDefs)) {
+			_, known := c.Seen(weaponKey(wt))
+			entries = append(entries, compendiumEntry{
+				Name:    WeaponDefs[wt].Name,
+				Known:   known,
+				Details: g.weaponDetails(wt),
+			})
+		}
+	case TabPassives:
+		for _, pt := range slices.Sorted(maps.Keys(PassiveDefs)) {
+			def := PassiveDefs[pt]
+			_, known := c.Seen(passiveKey(pt))
+			entries = append(entries, compendiumEntry{
+				Name:    def.Name,
+				Known:   known,
+				Details: []string{def.Desc, fmt.Sprintf("Max level %d", def.MaxLvl)},
+			})
+		}
+	case TabMonsters:
+		for _, mt := range slices.Sorted(maps.Keys(MonsterDefs)) {
+			def := MonsterDefs[mt]
+			at, known := c.Seen(monsterKey(mt))
+
+			details := []string{
+				fmt.Sprintf("HP %d  Speed %.1f  Damage %d  XP %d", def.HP, def.Speed, def.Damage, def.XP),
+				"First encountered at " + formatTime(at),
+			}
+			if def.IsBoss {
+				details = append(details, "Boss")
+				details = append(details, bossDetails(mt)...)
+			}
+
+			entries = append(entries, compendiumEntry{Name: def.Name, Known: known, Details: details, Monster: def})
+		}
+	case TabEquipment:
+		for slot := range SlotCount {
+			mods := make([]string, 0, len(SlotMods[slot]))
+			for _, m := range SlotMods[slot] {
+				mods = append(mods, ModTypeNames[m])
+			}
+
+			for _, base := range EquipmentBases[slot] {
+				_, known := c.Seen(equipmentBaseKey(base))
+				entries = append(entries, compendiumEntry{
+					Name:    base,
+					Known:   known,
+					Details: []string{EquipSlotNames[slot], "Rolls " + strings.Join(mods, ", ")},
+				})
+			}
+		}
+	case TabCombos:
+		for _, ca := range slices.Sorted(maps.Keys(ComboAttacks)) {
+			def := ComboAttacks[ca]
+			_, known := c.Seen(comboAttackKey(ca))
+			entries = append(entries, compendiumEntry{Name: def.Name, Known: known, Details: g.comboDetails(def)})
+		}
+	case compendiumTabCount:
+	}
+
+	return entries
+}
+
+// weaponDetails describes a weapon's stats, the evolution recipes and combo
+// attacks it is part of, and its damage tags. Ingredients not yet discovered
+// are hidden.
+func (g *Game) weaponDetails(wt WeaponType) []string {
+	def := WeaponDefs[wt]
+	details := []string{
+		fmt.Sprintf("Damage %d  Cooldown %.2fs  Range %.0f  Count %d",
+			def.Damage, def.Cooldown, def.Range, def.Count),
+	}
+
+	name := func(key, name string) string {
+		if _, ok := g.discoveries().Seen(key); ok {
+			return name
+		}
+
+		return "???"
+	}
+
+	for _, r := range Evolutions {
+		base := name(weaponKey(r.BaseWeapon), WeaponDefs[r.BaseWeapon].Name)
+		passive := name(passiveKey(r.Passive), PassiveDefs[r.Passive].Name)
+
+		switch wt {
+		case r.Result:
+			details = append(details, "Evolves from "+base+" + "+passive)
+		case r.BaseWeapon:
+			result := name(weaponKey(r.Result), WeaponDefs[r.Result].Name)
+			details = append(details, "Evolves into "+result+" with "+passive)
+		}
+	}
+
+	for _, ca := range slices.Sorted(maps.Keys(ComboAttacks)) {
+		def := ComboAttacks[ca]
+		if i := slices.Index(def.Weapons[:], wt); i >= 0 {
+			partner := def.Weapons[1-i]
+			combo := name(comboAttackKey(ca), def.Name)
+			details = append(details, "Combos with "+name(weaponKey(partner), WeaponDefs[partner].Name)+" into "+combo)
+		}
+	}
+
+	return append(details, "Deals "+tagNames(weaponTags(wt))+" damage")
+}
+
+// openCompendium shows the compendium from the character screen.
+func (g *Game) openCompendium() {
+	g.compendiumTab, g.compendiumSel = TabWeapons, 0
+	g.state = StateCompendium
+}
+
+func (g *Game) updateCompendium() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.state = StateCharSelect
+
+		return nil
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		g.compendiumTab = (g.compendiumTab + compendiumTabCount - 1) % compendiumTabCount
+		g.compendiumSel = 0
+		g.audio.PlaySound("select")
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		g.compendiumTab = (g.compendiumTab + 1) % compendiumTabCount
+		g.compendiumSel = 0
+		g.audio.PlaySound("select")
+	}
+
+	n := len(g.compendiumEntries(g.compendiumTab))
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
+		g.compendiumSel = (g.compendiumSel + n - 1) % n
+		g.audio.PlaySound("select")
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.compendiumSel = (g.compendiumSel + 1) % n
+		g.audio.PlaySound("select")
+	}
+
+	return nil
+}
+
+func (g *Game) drawCompendium(screen *ebiten.Image) {
+	screen.Fill(ui.CurrentTheme().Palette.Background)
+
+	palette := ui.CurrentTheme().Palette
+
+	total := 0
+	for tab := range compendiumTabCount {
+		total += len(g.compendiumEntries(tab))
+	}
+
+	title := fmt.Sprintf("COMPENDIUM  (%d/%d discovered)", g.discoveries().Count(), total)
+	ui.DebugPrintAt(screen, title, screenWidth/2-len(title)*3, 30)
+
+	// Tabs
+	for tab := range compendiumTabCount {
+		x := float32(150 + int(tab)*150)
+		if tab == g.compendiumTab {
+			vector.FillRect(screen, x, 58, 140, 20, palette.ButtonHover, false)
+		}
+
+		ui.DebugPrintAt(screen, compendiumTabNames[tab], int(x)+10, 61)
+	}
+
+	entries := g.compendiumEntries(g.compendiumTab)
+	first := min(max(g.compendiumSel-compendiumRows/2, 0), max(len(entries)-compendiumRows, 0))
+
+	// Entry list
+	vector.FillRect(screen, 40, 95, 260, 560, color.NRGBA{R: 0, G: 0, B: 0, A: 100}, false)
+
+	for i := first; i < min(first+compendiumRows, len(entries)); i++ {
+		y := 100 + (i-first)*22
+		if i == g.compendiumSel {
+			vector.FillRect(screen, 44, float32(y-2), 252, 20, palette.ButtonHover, false)
+		}
+
+		name := "???"
+		if entries[i].Known {
+			name = entries[i].Name
+		}
+
+		ui.DebugPrintAt(screen, name, 52, y)
+	}
+
+	// Details of the selected entry
+	vector.FillRect(screen, 320, 95, 540, 560, color.NRGBA{R: 0, G: 0, B: 0, A: 100}, false)
+
+	if len(entries) > 0 {
+		e := entries[g.compendiumSel]
+		if !e.Known {
+			ui.DebugPrintAt(screen, "Not yet discovered.", 340, 110)
+		} else {
+			ui.DebugPrintAt(screen, e.Name, 340, 110)
+
+			for i, line := range e.Details {
+				ui.DebugPrintAt(screen, line, 340, 145+i*22)
+			}
+
+			if e.Monster != nil {
+				drawTagBadges(screen, e.Monster, 340, 145+len(e.Details)*22+6)
+			}
+		}
+	}
+
+	ui.DebugPrintAt(
+		screen,
+		"LEFT/RIGHT tab | UP/DOWN entry | ESC back",
+		screenWidth/2-123,
+		screenHeight-30,
+	)
+}
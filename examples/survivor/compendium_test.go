@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// findEntry returns the compendium entry named name on tab.
+func findEntry(t *testing.T, g *Game, tab CompendiumTab, name string) compendiumEntry {
+	t.Helper()
+
+	for _, e := range g.compendiumEntries(tab) {
+		if e.Name == name {
+			return e
+		}
+	}
+
+	t.Fatalf("no %s entry %q", compendiumTabNames[tab], name)
+
+	return compendiumEntry{}
+}
+
+func TestCompendiumDiscoversStartingLoadout(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	start := WeaponDefs[Characters[CharJunior].StartWeapon].Name
+	if !findEntry(t, g, TabWeapons, start).Known {
+		t.Errorf("starting weapon %s not discovered", start)
+	}
+
+	evolved := WeaponDefs[WeaponLogStream].Name
+	if findEntry(t, g, TabWeapons, evolved).Known {
+		t.Errorf("%s discovered without being seen", evolved)
+	}
+}
+
+func TestCompendiumRecordsFirstEncounter(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.gameTime = 75
+	g.spawnMonster(MonsterLegacy, 100, 0)
+	g.gameTime = 200
+	g.spawnMonster(MonsterLegacy, 100, 0)
+
+	at, ok := g.discoveries().Seen(monsterKey(MonsterLegacy))
+	if !ok || at != 75 {
+		t.Fatalf("Legacy first seen = %v, %v; want 75, true", at, ok)
+	}
+
+	want := "First encountered at 1:15"
+	if got := findEntry(t, g, TabMonsters, MonsterDefs[MonsterLegacy].Name).Details[1]; got != want {
+		t.Errorf("details = %q, want %q", got, want)
+	}
+}
+
+func TestCompendiumDiscoversLootBases(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	for range 100 {
+		g.rollLootTable(bossLoot, "test", 0, 0)
+	}
+
+	for _, d := range g.itemDrops {
+		if !findEntry(t, g, TabEquipment, d.Item.Base).Known {
+			t.Errorf("looted base %s not discovered", d.Item.Base)
+		}
+	}
+}
+
+func TestCompendiumHidesUndiscoveredRecipeIngredients(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	recipe := Evolutions[0]
+	result := WeaponDefs[recipe.Result].Name
+	g.discoveries().Discover(weaponKey(recipe.Result), 0)
+	g.discoveries().Discover(weaponKey(recipe.BaseWeapon), 0)
+
+	want := "Evolves from " + WeaponDefs[recipe.BaseWeapon].Name + " + ???"
+	if got := findEntry(t, g, TabWeapons, result).Details[1]; got != want {
+		t.Errorf("recipe = %q, want %q", got, want)
+	}
+}
+
+func TestCompendiumSandboxDiscoversNothing(t *testing.T) {
+	g := &Game{}
+	g.startSandbox(CharJunior)
+
+	g.spawnMonster(MonsterBossDeadline, 100, 0)
+
+	if _, ok := g.discoveries().Seen(monsterKey(MonsterBossDeadline)); ok {
+		t.Error("sandbox spawn was discovered")
+	}
+}
+
+func TestCompendiumPersists(t *testing.T) {
+	sm := game.NewSaveManagerFS(paths.MemFS())
+
+	c := loadCompendium(sm)
+	c.Discover(monsterKey(MonsterBug), 12)
+	c.Discover(equipmentBaseKey("Thermos"), 0)
+
+	loaded := loadCompendium(sm)
+	if loaded.Count() != 2 {
+		t.Fatalf("loaded %d entries, want 2", loaded.Count())
+	}
+
+	if at, ok := loaded.Seen(monsterKey(MonsterBug)); !ok || at != 12 {
+		t.Errorf("Bug first seen = %v, %v; want 12, true", at, ok)
+	}
+}
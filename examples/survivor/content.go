@@ -0,0 +1,442 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/content"
+)
+
+// validateContent checks the survivor's content tables for references to
+// weapons, passives, monsters, or tree nodes that do not exist, and for
+// values the game cannot use. Run it with -validate or go run ./cmd/validate.
+func validateContent(r *content.Report) {
+	checkCharacters(r)
+	checkWeapons(r)
+	checkEvolutions(r)
+	checkComboAttacks(r)
+	checkPassives(r)
+	checkMonsters(r)
+	checkSpawns(r)
+	checkPassiveTree(r)
+	checkBossPatterns(r)
+	checkBossBehaviors(r)
+}
+
+// checkImage warns about an image missing from the embedded assets; the game
+// draws a fallback shape instead.
+func checkImage(r *content.Report, source, file string) {
+	if file == "" {
+		return
+	}
+
+	if _, err := fs.Stat(assetsFS, file); err != nil {
+		r.Warnf(source, "image %s is not in the embedded assets", file)
+	}
+}
+
+func checkCharacters(r *content.Report) {
+	for i, c := range Characters {
+		source := fmt.Sprintf("characters[%d] %s", i, c.Name)
+		ct := CharacterType(i)
+
+		if def, ok := WeaponDefs[c.StartWeapon]; !ok {
+			r.Errorf(source, "starts with unknown weapon %d", c.StartWeapon)
+		} else if def.IsEvolved {
+			r.Errorf(source, "starts with evolved weapon %s", def.Name)
+		}
+
+		if c.HP <= 0 || c.Speed <= 0 {
+			r.Errorf(source, "HP %d and speed %v must be positive", c.HP, c.Speed)
+		}
+
+		abilities, ok := CharacterAbilities[ct]
+		if !ok {
+			r.Errorf(source, "has no CharacterAbilities entry")
+		}
+
+		for _, id := range abilities {
+			if id != AbilityDash && id != AbilityTaunt && id != AbilitySlow {
+				r.Errorf(source, "has unknown ability %q", id)
+			}
+		}
+
+		if _, ok := CharacterDodges[ct]; !ok {
+			r.Errorf(source, "has no CharacterDodges entry")
+		}
+
+		if _, ok := GearProfiles[ct]; !ok {
+			r.Errorf(source, "has no GearProfiles entry")
+		}
+
+		checkImage(r, source, c.ImageFile)
+	}
+}
+
+func checkWeapons(r *content.Report) {
+	for wt := WeaponPrint; wt < weaponTypeCount; wt++ {
+		def, ok := WeaponDefs[wt]
+		if !ok {
+			r.Errorf(fmt.Sprintf("weapons[%d]", wt), "has no WeaponDefs entry")
+
+			continue
+		}
+
+		source := fmt.Sprintf("weapons[%d] %s", wt, def.Name)
+
+		if def.Name == "" {
+			r.Errorf(source, "has no name")
+		}
+
+		if def.Damage < 0 {
+			r.Errorf(source, "damage %d must not be negative", def.Damage)
+		}
+
+		if def.Cooldown <= 0 {
+			r.Errorf(source, "cooldown %v must be positive", def.Cooldown)
+		}
+
+		if def.Duration <= 0 {
+			r.Errorf(source, "duration %v must be positive", def.Duration)
+		}
+
+		checkImage(r, source, def.ImageFile)
+	}
+}
+
+// checkEvolutions checks that each recipe turns a base weapon and a passive
+// into an evolved weapon, and that every evolved weapon can be reached.
+func checkEvolutions(r *content.Report) {
+	bases := make(map[WeaponType]int)
+	results := make(map[WeaponType]int)
+
+	for i, e := range Evolutions {
+		source := fmt.Sprintf("evolutions[%d]", i)
+
+		if def, ok := WeaponDefs[e.BaseWeapon]; !ok {
+			r.Errorf(source, "base is unknown weapon %d", e.BaseWeapon)
+		} else if def.IsEvolved {
+			r.Errorf(source, "base %s is itself evolved", def.Name)
+		}
+
+		if _, ok := PassiveDefs[e.Passive]; !ok {
+			r.Errorf(source, "needs unknown passive %d", e.Passive)
+		}
+
+		if def, ok := WeaponDefs[e.Result]; !ok {
+			r.Errorf(source, "makes unknown weapon %d", e.Result)
+		} else if !def.IsEvolved {
+			r.Errorf(source, "makes %s, which is not marked IsEvolved", def.Name)
+		}
+
+		if prev, ok := bases[e.BaseWeapon]; ok {
+			r.Errorf(source, "evolves the same base as evolutions[%d]; only the first is used", prev)
+		}
+
+		if prev, ok := results[e.Result]; ok {
+			r.Errorf(source, "makes the same weapon as evolutions[%d]", prev)
+		}
+
+		bases[e.BaseWeapon], results[e.Result] = i, i
+	}
+
+	for wt, def := range WeaponDefs {
+		if _, ok := results[wt]; def.IsEvolved && !ok {
+			r.Errorf(fmt.Sprintf("weapons[%d] %s", wt, def.Name), "is evolved but no recipe makes it")
+		}
+	}
+}
+
+// checkComboAttacks checks that each combo pairs two different base weapons,
+// that no two combos share a pair, and that its numbers are usable.
+func checkComboAttacks(r *content.Report) {
+	pairs := make(map[[2]WeaponType]ComboAttack)
+
+	for _, ca := range slices.Sorted(maps.Keys(ComboAttacks)) {
+		def := ComboAttacks[ca]
+		source := fmt.Sprintf("combos[%d] %s", ca, def.Name)
+
+		for _, wt := range def.Weapons {
+			if w, ok := WeaponDefs[wt]; !ok {
+				r.Errorf(source, "needs unknown weapon %d", wt)
+			} else if w.IsEvolved {
+				r.Errorf(source, "needs %s, which is evolved; combos pair base weapons", w.Name)
+			}
+		}
+
+		pair := def.Weapons
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+
+		if pair[0] == pair[1] {
+			r.Errorf(source, "pairs a weapon with itself")
+		}
+
+		if prev, ok := pairs[pair]; ok {
+			r.Errorf(source, "pairs the same weapons as combos[%d]", prev)
+		}
+
+		pairs[pair] = ca
+
+		if !slices.Contains(def.Weapons[:], def.Source) {
+			r.Errorf(source, "source weapon %d is not one of its pair", def.Source)
+		}
+
+		if def.Cooldown <= 0 || def.Count <= 0 || def.DamageMult <= 0 {
+			r.Errorf(source, "cooldown %v, count %d, and damage %v must be positive",
+				def.Cooldown, def.Count, def.DamageMult)
+		}
+	}
+}
+
+func checkPassives(r *content.Report) {
+	for pt := PassiveMight; pt <= PassiveShield; pt++ {
+		def, ok := PassiveDefs[pt]
+		if !ok {
+			r.Errorf(fmt.Sprintf("passives[%d]", pt), "has no PassiveDefs entry")
+
+			continue
+		}
+
+		source := fmt.Sprintf("passives[%d] %s", pt, def.Name)
+
+		if def.MaxLvl <= 0 {
+			r.Errorf(source, "max level %d must be positive", def.MaxLvl)
+		}
+
+		checkImage(r, source, def.ImageFile)
+	}
+}
+
+func checkMonsters(r *content.Report) {
+	for mt := MonsterBug; mt <= MonsterBossDeadline; mt++ {
+		def, ok := MonsterDefs[mt]
+		if !ok {
+			r.Errorf(fmt.Sprintf("monsters[%d]", mt), "has no MonsterDefs entry")
+
+			continue
+		}
+
+		source := fmt.Sprintf("monsters[%d] %s", mt, def.Name)
+
+		if def.HP <= 0 || def.Radius <= 0 {
+			r.Errorf(source, "HP %d and radius %v must be positive", def.HP, def.Radius)
+		}
+
+		if def.Immune&def.Resists != 0 {
+			r.Warnf(source, "both resists and is immune to %v", tagNames(def.Immune&def.Resists))
+		}
+
+		for _, name := range []string{def.Sounds.Spawn, def.Sounds.Hit, def.Sounds.Death} {
+			if _, ok := monsterSounds[name]; name != "" && !ok {
+				r.Errorf(source, "names unknown sound %q", name)
+			}
+		}
+
+		if _, ok := ambientSounds[def.Sounds.Ambient]; def.Sounds.Ambient != "" && !ok {
+			r.Errorf(source, "names unknown ambient sound %q", def.Sounds.Ambient)
+		}
+
+		checkImage(r, source, def.ImageFile)
+	}
+
+	for mt, table := range LootTables {
+		source := fmt.Sprintf("loot[%d] %s", mt, table.Name)
+
+		if _, ok := MonsterDefs[mt]; !ok {
+			r.Errorf(source, "is for unknown monster %d", mt)
+		}
+
+		if table.Chance < 0 || table.Chance > 1 {
+			r.Errorf(source, "drop chance %v must be between 0 and 1", table.Chance)
+		}
+
+		if table.LevelMin > table.LevelMax {
+			r.Errorf(source, "item level range %d..%d is reversed", table.LevelMin, table.LevelMax)
+		}
+
+		weights := make([]float64, len(table.Rarities))
+		for i, w := range table.Rarities {
+			weights[i] = w.Weight
+		}
+
+		r.CheckWeights(source+" rarities", weights)
+	}
+
+	for mt := range TelegraphAttacks {
+		if _, ok := MonsterDefs[mt]; !ok {
+			r.Errorf(fmt.Sprintf("telegraphs[%d]", mt), "is for unknown monster %d", mt)
+		}
+	}
+}
+
+func checkSpawns(r *content.Report) {
+	for i, p := range SpawnPhases {
+		if i == 0 && p.Start != 0 {
+			r.Errorf("spawn phases[0] "+p.Name, "starts at %v; the first phase must start at 0", p.Start)
+		}
+
+		if i > 0 && p.Start <= SpawnPhases[i-1].Start {
+			r.Errorf(fmt.Sprintf("spawn phases[%d] %s", i, p.Name), "starts before the phase it follows")
+		}
+	}
+
+	for i, e := range SpawnEvents {
+		source := fmt.Sprintf("spawn events[%d] %s", i, e.Name)
+
+		if _, ok := MonsterDefs[e.Monster]; !ok {
+			r.Errorf(source, "spawns unknown monster %d", e.Monster)
+		}
+
+		if e.Count <= 0 {
+			r.Errorf(source, "count %d must be positive", e.Count)
+		}
+
+		if e.Until != 0 && e.Until < e.Start {
+			r.Errorf(source, "ends at %v, before its first occurrence at %v", e.Until, e.Start)
+		}
+	}
+
+	weights := make([]float64, len(WorldEvents))
+
+	for i, e := range WorldEvents {
+		weights[i] = e.Weight
+
+		if e.MegaElite != nil {
+			if _, ok := MonsterDefs[e.MegaElite.Monster]; !ok {
+				r.Errorf(fmt.Sprintf("world events[%d] %s", i, e.Name), "spawns unknown monster %d", e.MegaElite.Monster)
+			}
+		}
+	}
+
+	r.CheckWeights("world events", weights)
+}
+
+// checkPassiveTree checks that the embedded tree parses, its node IDs and
+// links, and that every character has a start node.
+func checkPassiveTree(r *content.Report) {
+	if _, err := defaultPassiveTree(); err != nil {
+		r.Errorf("passive tree", "%s: %v", passiveTreeSlot, err)
+
+		return
+	}
+
+	nodes := passiveTreeNodes()
+	links := make(map[int][]int, len(nodes))
+
+	for i, n := range nodes {
+		if _, ok := links[n.ID]; ok {
+			r.Errorf(fmt.Sprintf("passive tree[%d] %s", i, n.Name), "reuses node ID %d", n.ID)
+		}
+
+		links[n.ID] = n.Connections
+	}
+
+	r.CheckGraph("passive tree", links)
+
+	for i, c := range Characters {
+		found := false
+
+		for _, n := range nodes {
+			found = found || n.StartClass == CharacterType(i)
+		}
+
+		if !found {
+			r.Errorf("passive tree", "has no start node for %s", c.Name)
+		}
+	}
+}
+
+// checkBossPatterns checks the embedded boss patterns, which bosses otherwise
+// skip with only a log line.
+func checkBossPatterns(r *content.Report) {
+	for mt, def := range MonsterDefs {
+		p, err := defaultBossPattern(mt)
+
+		switch {
+		case err != nil:
+			r.Errorf("boss patterns", "%v", err)
+		case p != nil && !def.IsBoss:
+			r.Errorf("boss patterns", "%s is for %s, which is not a boss", patternFile(mt), def.Name)
+		case p == nil && def.IsBoss:
+			r.Warnf("boss patterns", "%s has no pattern and falls back to its telegraph attack", def.Name)
+		}
+	}
+
+	entries, err := fs.ReadDir(bossPatternFS, "bosspatterns")
+	if err != nil {
+		r.Errorf("boss patterns", "%v", err)
+
+		return
+	}
+
+	for _, entry := range entries {
+		found := false
+		for mt := range MonsterDefs {
+			found = found || patternFile(mt) == entry.Name()
+		}
+
+		if !found {
+			r.Errorf("boss patterns", "%s does not match any monster name", entry.Name())
+		}
+	}
+}
+
+// checkBossBehaviors checks that every boss has a behavior whose phases come
+// in order and summon monsters that exist.
+func checkBossBehaviors(r *content.Report) {
+	for mt, def := range MonsterDefs {
+		if _, ok := BossBehaviors[mt]; def.IsBoss && !ok {
+			r.Warnf(fmt.Sprintf("monsters[%d] %s", mt, def.Name), "is a boss with no BossBehavior")
+		}
+	}
+
+	for mt, b := range BossBehaviors {
+		def, ok := MonsterDefs[mt]
+		if !ok || !def.IsBoss {
+			r.Errorf(fmt.Sprintf("boss behaviors[%d]", mt), "is for a monster that is not a boss")
+
+			continue
+		}
+
+		if b.EnrageTime < 0 {
+			r.Errorf(def.Name, "enrage time %v must not be negative", b.EnrageTime)
+		}
+
+		last := 1.0
+
+		for i, p := range b.Phases {
+			source := fmt.Sprintf("%s phase %d", def.Name, i)
+
+			if p.At <= 0 || p.At > 1 || (i > 0 && p.At >= last) {
+				r.Errorf(source, "starts at %v; phases must fall from at most 1 toward 0", p.At)
+			}
+
+			last = p.At
+
+			if p.Tempo < 0 {
+				r.Errorf(source, "tempo %v must not be negative", p.Tempo)
+			}
+
+			if n := p.Nova; n != nil && (n.Radius <= 0 || n.Windup <= 0 || n.Damage < 0) {
+				r.Errorf(source, "nova needs a positive radius and windup")
+			}
+
+			if s := p.Summon; s != nil {
+				add, ok := MonsterDefs[s.Type]
+
+				switch {
+				case !ok:
+					r.Errorf(source, "summons unknown monster %d", s.Type)
+				case add.IsBoss:
+					r.Errorf(source, "summons the boss %s", add.Name)
+				case s.Count <= 0 || s.Every <= 0 || s.Max < s.Count:
+					r.Errorf(source, "summon needs a positive count and interval, and a max of at least the count")
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/content"
+)
+
+func TestContentIsValid(t *testing.T) {
+	if content.Run(os.Stdout, "survivor", validateContent) != 0 {
+		t.Error("content has errors")
+	}
+}
+
+func TestContentValidationCatchesBrokenReferences(t *testing.T) {
+	saved := Evolutions
+	defer func() { Evolutions = saved }()
+
+	Evolutions = append([]EvolutionRecipe{
+		{WeaponPrint, PassiveAmount, WeaponRefactor}, // Makes a base weapon
+		{WeaponCoffee, PassiveType(99), WeaponLogStream},
+	}, saved...)
+
+	var r content.Report
+
+	validateContent(&r)
+
+	// Both bad recipes, plus the real ones they shadow
+	if r.Errors() != 5 {
+		t.Errorf("%d errors, want 5: %v", r.Errors(), r.Issues())
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Survivor's own actions, after the engine's Confirm, Cancel, and Pause.
+const (
+	actionDodge = input.FirstCustom + iota
+	actionPick  // Take the focused level-up option
+	actionLoot  // Pick up the nearest item drop
+	actionAbility1
+)
+
+// controlsFile keeps the player's bindings in the config directory.
+const controlsFile = "controls.json"
+
+// controls maps keyboard and gamepad input to survivor's actions.
+var controls = newControls()
+
+// rebindableControls are the controls offered on the rebinding screen.
+var rebindableControls = newRebindableControls()
+
+// newControls returns the default bindings plus survivor's own. Pause drops
+// P, which opens the passive tree, level-up picks leave out Space so a held
+// ability key never takes an upgrade by accident, and loot is on T, clear of
+// the sandbox's F freeze.
+func newControls() *input.Map {
+	m := input.NewMap()
+	m.Bind(input.Pause, input.Binding{
+		Keys:    []ebiten.Key{ebiten.KeyEscape},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight},
+	})
+	m.Bind(actionDodge, input.Binding{
+		Keys:    []ebiten.Key{ebiten.KeyShiftLeft, ebiten.KeyShiftRight},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontTopRight},
+	})
+	m.Bind(actionPick, input.Binding{
+		Keys:    []ebiten.Key{ebiten.KeyEnter},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+	})
+	m.Bind(actionLoot, input.Binding{
+		Keys:    []ebiten.Key{ebiten.KeyT},
+		Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightLeft},
+	})
+
+	for slot, key := range abilityKeys {
+		m.Bind(actionAbility1+input.Action(slot), input.Binding{
+			Keys:    []ebiten.Key{key},
+			Buttons: []ebiten.StandardGamepadButton{abilityButtons[slot]},
+		})
+	}
+
+	m.SetDefaults()
+
+	return m
+}
+
+// newRebindableControls lists movement, dodge, each ability slot, loot, and
+// pause.
+// Menu keys stay fixed so a bad binding can never lock the player out.
+func newRebindableControls() []input.Control {
+	cs := []input.Control{
+		input.MoveUp, input.MoveDown, input.MoveLeft, input.MoveRight,
+		{Name: "Dodge", Action: actionDodge},
+	}
+
+	for slot := range abilityKeys {
+		name := fmt.Sprintf("Ability %d", slot+1)
+		cs = append(cs, input.Control{Name: name, Action: actionAbility1 + input.Action(slot)})
+	}
+
+	return append(cs,
+		input.Control{Name: "Pick Up", Action: actionLoot},
+		input.Control{Name: "Pause", Action: input.Pause})
+}
+
+// openControls loads the player's saved bindings into controls and returns
+// where to save changes, or nil when there is nowhere to keep them.
+func openControls() paths.FS {
+	store, err := survivorApp.Open(paths.Config)
+	if err != nil {
+		log.Printf("controls: %v", err)
+
+		return nil
+	}
+
+	if err := controls.LoadControls(store, controlsFile, rebindableControls); err != nil {
+		log.Printf("controls: %v", err)
+	}
+
+	return store
+}
+
+// openRebinder shows the key rebinding screen over the help screen.
+func (g *Game) openRebinder() {
+	g.rebinder = ui.NewRebinder(controls, rebindableControls)
+	g.rebinder.OnChange = g.saveControls
+}
+
+// saveControls writes the bindings; failures are logged and otherwise ignored.
+func (g *Game) saveControls() {
+	if g.controlStore == nil {
+		return
+	}
+
+	if err := controls.SaveControls(g.controlStore, controlsFile, rebindableControls); err != nil {
+		log.Printf("controls: %v", err)
+	}
+}
+
+// firstKey names the first key of b, or "-" when it has none.
+func firstKey(b input.Binding) string {
+	if len(b.Keys) == 0 {
+		return "-"
+	}
+
+	return input.Binding{Keys: b.Keys[:1]}.String()
+}
+
+// shortBinding names the first key of b and its first gamepad button, e.g.
+// "ShiftLeft (RB)".
+func shortBinding(b input.Binding) string {
+	name := firstKey(b)
+	if len(b.Buttons) > 0 {
+		name += " (" + input.Binding{Buttons: b.Buttons[:1]}.String() + ")"
+	}
+
+	return name
+}
+
+// abilityKeyName labels an ability's HUD slot with its first bound key,
+// cut to fit.
+func abilityKeyName(slot int) string {
+	name := firstKey(controls.Binding(actionAbility1 + input.Action(slot)))
+
+	return name[:min(len(name), 7)]
+}
+
+// drawRebindRow draws the settings page's rebinding row at y and returns
+// the y below it.
+func (g *Game) drawRebindRow(screen *ebiten.Image, x, y int) int {
+	prefix := "  "
+	if g.helpSelection == helpRowControls {
+		prefix = "> "
+	}
+
+	ui.DebugPrintAt(screen, prefix+"Rebind Keys:", x, y)
+	ui.DebugPrintAt(screen, "< ENTER >", x+130, y)
+
+	return y + 20
+}
+
+// drawControlsHelp draws the current gameplay bindings of the help page
+// starting at y and returns the y below them.
+func (g *Game) drawControlsHelp(screen *ebiten.Image, x, y int) int {
+	move := ""
+	for _, c := range []input.Control{input.MoveUp, input.MoveLeft, input.MoveDown, input.MoveRight} {
+		move += firstKey(controls.ControlBinding(c))
+	}
+
+	abilities := ""
+	for slot := range abilityKeys {
+		if slot > 0 {
+			abilities += " / "
+		}
+
+		abilities += shortBinding(controls.Binding(actionAbility1 + input.Action(slot)))
+	}
+
+	lines := [][2]string{
+		{move + " / Stick", "Move character"},
+		{abilities, "Active abilities"},
+		{shortBinding(controls.Binding(actionDodge)), "Dodge (uses stamina)"},
+		{shortBinding(controls.Binding(actionLoot)), "Pick up nearby loot"},
+		{shortBinding(controls.Binding(input.Pause)), "Pause game"},
+	}
+
+	for _, l := range lines {
+		ui.DebugPrintAt(screen, fmt.Sprintf("%-20s %s", l[0], l[1]), x, y)
+		y += 20
+	}
+
+	return y
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestRebindingFromHelpIsSaved(t *testing.T) {
+	t.Cleanup(controls.Reset)
+
+	g := &Game{controlStore: paths.MemFS()}
+	g.startGame(CharJunior)
+	g.adjustSetting(helpRowControls, 1)
+
+	if g.rebinder == nil {
+		t.Fatal("the controls row should open the rebinding screen")
+	}
+
+	// AZERTY players move up with Z and fire the first ability with Q
+	controls.BindControl(input.MoveUp, input.Binding{Keys: []ebiten.Key{ebiten.KeyZ}})
+	controls.Bind(actionAbility1, input.Binding{Keys: []ebiten.Key{ebiten.KeyQ}})
+	g.rebinder.OnChange()
+
+	if got := abilityKeyName(0); got != "Q" {
+		t.Errorf("HUD label = %q, want Q", got)
+	}
+
+	controls.Reset()
+
+	if err := controls.LoadControls(g.controlStore, controlsFile, rebindableControls); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := controls.ControlBinding(input.MoveUp).String(); got != "Z" {
+		t.Errorf("loaded Move Up = %q, want Z", got)
+	}
+
+	if got := controls.ControlBinding(input.MoveDown).String(); got != "S, Down, D-Down" {
+		t.Errorf("loaded Move Down = %q, want the default", got)
+	}
+}
+
+func TestSurvivorControlsStartWithoutConflicts(t *testing.T) {
+	g := &Game{}
+	g.openRebinder()
+
+	if c := g.rebinder.Conflicts(); len(c) > 0 {
+		t.Errorf("default bindings conflict: %v", c)
+	}
+}
+
+func TestLootKeyAvoidsSandboxShortcuts(t *testing.T) {
+	for _, k := range controls.Binding(actionLoot).Keys {
+		if slices.Contains([]ebiten.Key{ebiten.KeyF, ebiten.KeyG, ebiten.KeyN, ebiten.KeyK, ebiten.KeyR}, k) {
+			t.Errorf("loot is bound to %v, a sandbox shortcut", k)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"errors"
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// streamCrafting rolls crafted modifiers, apart from the loot stream so
+// crafting never changes what drops.
+const streamCrafting = "crafting"
+
+// Crafting limits.
+const (
+	maxModTier    = 5
+	magicModLimit = 2 // Magic items roll one or two modifiers
+)
+
+// CraftAction is one way to spend scrap on an item.
+type CraftAction int
+
+const (
+	CraftReroll  CraftAction = iota // Reroll one modifier's type and tier
+	CraftAugment                    // Add a modifier to a Magic item
+	CraftUpgrade                    // Raise one modifier a tier
+	craftActionCount
+)
+
+var craftActionNames = map[CraftAction]string{
+	CraftReroll:  "Reroll modifier",
+	CraftAugment: "Add modifier",
+	CraftUpgrade: "Upgrade tier",
+}
+
+// Reasons a craft is refused.
+var (
+	errNoScrap      = errors.New("not enough scrap")
+	errNoModifier   = errors.New("no modifier selected")
+	errSpecialMod   = errors.New("special modifiers cannot be crafted")
+	errNotMagic     = errors.New("only Magic items take an added modifier")
+	errModsFull     = errors.New("the item has no room for another modifier")
+	errMaxTier      = errors.New("the modifier is already at the top tier")
+	errNothingToAdd = errors.New("nothing rolls on this slot")
+)
+
+// modValue returns the value of a modifier of type mt at tier.
+func modValue(mt ModType, tier int) float64 {
+	return float64(tier) * modTierValue[mt]
+}
+
+// maxRollTier returns the highest tier a modifier rolls at on an item of
+// itemLevel: one more per ten levels, up to maxModTier.
+func maxRollTier(itemLevel int) int {
+	return min(maxModTier, itemLevel/10+1)
+}
+
+// rollModifier rolls a modifier for an item in slot at itemLevel.
+func rollModifier(r *rand.Rand, slot EquipSlot, itemLevel int) Modifier {
+	mods := SlotMods[slot]
+	mt := mods[r.Intn(len(mods))]
+	tier := 1 + r.Intn(maxRollTier(itemLevel))
+
+	return Modifier{Type: mt, Value: modValue(mt, tier), Tier: tier}
+}
+
+// craftable reports whether modifier m can be rerolled or upgraded: it is
+// one the item's slot rolls, not a special like Forked Lightning.
+func craftable(slot EquipSlot, m Modifier) bool {
+	for _, mt := range SlotMods[slot] {
+		if mt == m.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+// craftCost returns the scrap action costs on modifier i of item. Upgrades
+// cost more the higher the tier.
+func craftCost(action CraftAction, item *Equipment, i int) int {
+	switch action {
+	case CraftReroll:
+		return 5
+	case CraftAugment:
+		return 12
+	case CraftUpgrade:
+		if i >= 0 && i < len(item.Modifiers) {
+			return 4 * item.Modifiers[i].Tier
+		}
+	case craftActionCount:
+	}
+
+	return 0
+}
+
+// checkCraft returns why action cannot be done on modifier i of item with
+// scrap to spend, or nil.
+func checkCraft(action CraftAction, item *Equipment, i, scrap int) error {
+	hasMod := i >= 0 && i < len(item.Modifiers)
+
+	switch action {
+	case CraftReroll, CraftUpgrade:
+		if !hasMod {
+			return errNoModifier
+		}
+
+		if !craftable(item.Slot, item.Modifiers[i]) {
+			return errSpecialMod
+		}
+
+		if action == CraftUpgrade && item.Modifiers[i].Tier >= maxModTier {
+			return errMaxTier
+		}
+	case CraftAugment:
+		if item.Rarity != RarityMagic {
+			return errNotMagic
+		}
+
+		if len(item.Modifiers) >= magicModLimit {
+			return errModsFull
+		}
+
+		if len(SlotMods[item.Slot]) == 0 {
+			return errNothingToAdd
+		}
+	case craftActionCount:
+	}
+
+	if scrap < craftCost(action, item, i) {
+		return errNoScrap
+	}
+
+	return nil
+}
+
+// craft does action on modifier i of item, rolling with r, and returns the
+// scrap it cost. The same item, rolls, and scrap always craft the same
+// result. An added modifier goes on the end of the list.
+func craft(r *rand.Rand, action CraftAction, item *Equipment, i, scrap int) (int, error) {
+	if err := checkCraft(action, item, i, scrap); err != nil {
+		return 0, err
+	}
+
+	cost := craftCost(action, item, i)
+
+	switch action {
+	case CraftReroll:
+		item.Modifiers[i] = rollModifier(r, item.Slot, item.ItemLevel)
+	case CraftAugment:
+		item.Modifiers = append(item.Modifiers, rollModifier(r, item.Slot, item.ItemLevel))
+	case CraftUpgrade:
+		m := &item.Modifiers[i]
+		m.Tier++
+		m.Value = modValue(m.Type, m.Tier)
+	case craftActionCount:
+	}
+
+	return cost, nil
+}
+
+// openCrafting opens the crafting screen on the selected inventory item,
+// or on the item equipped in the selected slot when the inventory is empty.
+func (g *Game) openCrafting() {
+	item := g.player.Equipment[g.selectedSlot]
+	if i := g.selectedInvIndex; i < len(g.player.Inventory) {
+		item = g.player.Inventory[i]
+	}
+
+	if item == nil {
+		return
+	}
+
+	g.craftItem, g.craftMod, g.craftMsg = item, 0, ""
+	g.state = StateCrafting
+}
+
+// isEquipped reports whether item is worn, so crafting it changes stats.
+func (g *Game) isEquipped(item *Equipment) bool {
+	return item != nil && g.player.Equipment[item.Slot] == item
+}
+
+// doCraft crafts the selected modifier and reports the outcome.
+func (g *Game) doCraft(action CraftAction) {
+	item := g.craftItem
+	before := ""
+
+	if g.craftMod < len(item.Modifiers) {
+		before = item.Modifiers[g.craftMod].String()
+	}
+
+	cost, err := craft(g.rng.Stream(streamCrafting), action, item, g.craftMod, g.player.Scrap)
+	if err != nil {
+		g.craftMsg = err.Error()
+
+		return
+	}
+
+	g.player.Scrap -= cost
+	g.audio.PlaySound("select")
+
+	if action == CraftAugment {
+		g.craftMod = len(item.Modifiers) - 1
+		g.craftMsg = "Added " + item.Modifiers[g.craftMod].String()
+	} else {
+		g.craftMsg = before + " -> " + item.Modifiers[g.craftMod].String()
+	}
+
+	if g.isEquipped(item) {
+		g.recalculateStats()
+	}
+}
+
+func (g *Game) updateCrafting() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.state = StateEquipment
+
+		return nil
+	}
+
+	if n := len(g.craftItem.Modifiers); n > 0 {
+		if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+			g.craftMod = (g.craftMod + n - 1) % n
+		}
+
+		if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+			g.craftMod = (g.craftMod + 1) % n
+		}
+	}
+
+	for action := range craftActionCount {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(action)) {
+			g.doCraft(action)
+		}
+	}
+
+	return nil
+}
+
+func (g *Game) drawCrafting(screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, color.RGBA{A: 180}, false)
+
+	palette := ui.CurrentTheme().Palette
+	panelW, panelH := float32(560), float32(420)
+	panelX, panelY := (screenWidth-panelW)/2, (screenHeight-panelH)/2
+
+	vector.FillRect(screen, panelX, panelY, panelW, panelH, color.RGBA{R: 30, G: 30, B: 40, A: 255}, false)
+	vector.StrokeRect(screen, panelX, panelY, panelW, panelH, 3, palette.PanelBorder, false)
+	drawText(screen, "CRAFTING", int(panelX+panelW/2), int(panelY)+8, headingText())
+
+	scrap := smallText()
+	scrap.Align = text.AlignRight
+	drawText(screen, "Scrap "+formatInt(g.player.Scrap), int(panelX+panelW)-12, int(panelY)+12, scrap)
+
+	item := g.craftItem
+	x, y := int(panelX)+20, int(panelY)+50
+
+	heading := "Crafting"
+	if g.isEquipped(item) {
+		heading = "Crafting (equipped)"
+	}
+
+	// The item's header lines, then its modifiers with the selected one marked
+	lines := itemCardLines(item, heading)
+	header := lines[:min(3, len(lines))]
+	drawCardLines(screen, header, x, y)
+	y += linesHeight(header) + 6
+
+	for i, m := range item.Modifiers {
+		opts := smallText()
+		if i == g.craftMod {
+			opts.Color = palette.Highlight
+			drawText(screen, ">", x, y, opts)
+		}
+
+		y += drawText(screen, m.String()+"  (tier "+formatInt(m.Tier)+")", x+14, y, opts)
+	}
+
+	if len(item.Modifiers) == 0 {
+		y += drawText(screen, "No modifiers", x+14, y, smallText())
+	}
+
+	// Actions, greyed out with the reason when they cannot be done
+	y += 16
+
+	for action := range craftActionCount {
+		opts := text.Options{Size: smallText().Size}
+		label := formatInt(int(action)+1) + ": " + craftActionNames[action]
+
+		if cost := craftCost(action, item, g.craftMod); cost > 0 {
+			label += " (" + formatInt(cost) + " scrap)"
+		}
+
+		if err := checkCraft(action, item, g.craftMod, g.player.Scrap); err != nil {
+			opts = smallText()
+			label += " - " + err.Error()
+		}
+
+		y += drawText(screen, label, x, y, opts)
+	}
+
+	if g.craftMsg != "" {
+		msg := text.Options{Color: palette.Highlight, Width: float64(panelW) - 40}
+		drawText(screen, g.craftMsg, x, y+12, msg)
+	}
+
+	hint := smallText()
+	hint.Align = text.AlignCenter
+	drawText(screen, "UP/DOWN: Select Modifier | 1-3: Craft | C/ESC: Back",
+		int(panelX+panelW/2), int(panelY+panelH)-25, hint)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func magicKeyboard() *Equipment {
+	return &Equipment{
+		Name:      "Fine Keyboard",
+		Slot:      SlotKeyboard,
+		Rarity:    RarityMagic,
+		ItemLevel: 30,
+		Modifiers: []Modifier{{Type: ModFlatDamage, Value: modValue(ModFlatDamage, 2), Tier: 2}},
+	}
+}
+
+func TestCraftIsDeterministic(t *testing.T) {
+	for action := range craftActionCount {
+		a, b := magicKeyboard(), magicKeyboard()
+
+		costA, errA := craft(rand.New(rand.NewSource(7)), action, a, 0, 100)
+		costB, errB := craft(rand.New(rand.NewSource(7)), action, b, 0, 100)
+
+		if errA != nil || errB != nil {
+			t.Fatalf("%s: %v, %v", craftActionNames[action], errA, errB)
+		}
+
+		if costA != costB || !slices.Equal(a.Modifiers, b.Modifiers) {
+			t.Errorf("%s differs with the same seed: %v vs %v", craftActionNames[action], a.Modifiers, b.Modifiers)
+		}
+	}
+}
+
+func TestCraftActions(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	item := magicKeyboard()
+	if cost, err := craft(r, CraftUpgrade, item, 0, 100); err != nil || cost != 8 {
+		t.Fatalf("upgrade cost %d, err %v; want 8 for tier 2", cost, err)
+	}
+
+	if m := item.Modifiers[0]; m.Tier != 3 || m.Value != modValue(ModFlatDamage, 3) {
+		t.Errorf("upgraded to %+v, want tier 3", m)
+	}
+
+	if _, err := craft(r, CraftAugment, item, 0, 100); err != nil || len(item.Modifiers) != 2 {
+		t.Fatalf("augment: %v, %d modifiers", err, len(item.Modifiers))
+	}
+
+	added := item.Modifiers[1]
+	if !craftable(SlotKeyboard, added) || added.Tier < 1 || added.Tier > maxRollTier(item.ItemLevel) {
+		t.Errorf("added %+v is not a keyboard roll at item level %d", added, item.ItemLevel)
+	}
+
+	if _, err := craft(r, CraftReroll, item, 1, 100); err != nil || !craftable(SlotKeyboard, item.Modifiers[1]) {
+		t.Errorf("reroll: %v, got %+v", err, item.Modifiers[1])
+	}
+}
+
+func TestCraftRefusals(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	topTier := magicKeyboard()
+	topTier.Modifiers[0].Tier = maxModTier
+
+	full := magicKeyboard()
+	full.Modifiers = append(full.Modifiers, full.Modifiers[0])
+
+	rare := magicKeyboard()
+	rare.Rarity = RarityRare
+
+	special := magicKeyboard()
+	special.Modifiers[0] = Modifier{Type: ModForkLightning, Value: 2, Tier: 1}
+
+	tests := []struct {
+		name   string
+		action CraftAction
+		item   *Equipment
+		mod    int
+		scrap  int
+		want   error
+	}{
+		{"too poor", CraftReroll, magicKeyboard(), 0, 4, errNoScrap},
+		{"no modifier", CraftReroll, magicKeyboard(), 3, 100, errNoModifier},
+		{"top tier", CraftUpgrade, topTier, 0, 100, errMaxTier},
+		{"two modifiers", CraftAugment, full, 0, 100, errModsFull},
+		{"not magic", CraftAugment, rare, 0, 100, errNotMagic},
+		{"special", CraftReroll, special, 0, 100, errSpecialMod},
+	}
+
+	for _, tt := range tests {
+		before := slices.Clone(tt.item.Modifiers)
+
+		cost, err := craft(r, tt.action, tt.item, tt.mod, tt.scrap)
+		if !errors.Is(err, tt.want) || cost != 0 {
+			t.Errorf("%s: cost %d, err %v; want %v", tt.name, cost, err, tt.want)
+		}
+
+		if !slices.Equal(before, tt.item.Modifiers) {
+			t.Errorf("%s: a refused craft changed the item", tt.name)
+		}
+	}
+}
+
+func TestCraftingEquippedItemSpendsScrapAndUpdatesStats(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	item := magicKeyboard()
+	g.player.Equipment[SlotKeyboard] = item
+	g.player.Scrap = 10
+	g.recalculateStats()
+	damage := g.player.FlatDamage
+
+	g.selectedSlot = SlotKeyboard
+	g.openCrafting()
+
+	if g.state != StateCrafting || g.craftItem != item {
+		t.Fatalf("state %d, crafting %v; want the equipped keyboard", g.state, g.craftItem)
+	}
+
+	g.doCraft(CraftUpgrade)
+
+	if g.player.Scrap != 2 || g.player.FlatDamage <= damage {
+		t.Errorf("scrap %d, damage %v from %v after an upgrade", g.player.Scrap, g.player.FlatDamage, damage)
+	}
+
+	g.doCraft(CraftUpgrade)
+
+	if g.player.Scrap != 2 || g.craftMsg != errNoScrap.Error() {
+		t.Errorf("scrap %d, message %q when too poor", g.player.Scrap, g.craftMsg)
+	}
+
+	g.drawCrafting(ebiten.NewImage(screenWidth, screenHeight))
+}
@@ -0,0 +1,36 @@
+package main
+
+// Crit tuning. Chance past 100% is not wasted: each point of overflow adds
+// critOverflowRate points of crit multiplier instead.
+const (
+	baseCritMultiplier = 1.5
+	critOverflowRate   = 1.0
+)
+
+// critChance returns the player's total crit chance with the given weapon,
+// before overflow conversion.
+func (g *Game) critChance(wt WeaponType) float64 {
+	return g.player.CritChance + WeaponDefs[wt].CritChance
+}
+
+// critMultiplier returns the damage multiplier of a crit with the given weapon,
+// including bonus multiplier converted from crit chance overflow.
+func (g *Game) critMultiplier(wt WeaponType) float64 {
+	return g.player.CritMultiplier + max(0, g.critChance(wt)-1)*critOverflowRate
+}
+
+// rollCrit decides whether a hit crits and returns the damage multiplier to apply.
+// A pending guaranteed crit is consumed first.
+func (g *Game) rollCrit(wt WeaponType) (bool, float64) {
+	if g.player.GuaranteedCrits > 0 {
+		g.player.GuaranteedCrits--
+
+		return true, g.critMultiplier(wt)
+	}
+
+	if g.rng.Stream(streamCombat).Float64() < g.critChance(wt) {
+		return true, g.critMultiplier(wt)
+	}
+
+	return false, 1
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// disableCrits cancels player and per-weapon crit chance so damage is exact.
+func disableCrits(g *Game) {
+	g.player.CritChance = -1
+}
+
+// hitDummy strikes a fresh dummy once and returns the HP lost and the damage number shown.
+func hitDummy(g *Game, damage int, wt WeaponType) (lost, shown int, crit bool) {
+	e := &Enemy{HP: 100_000, MaxHP: 100_000, Radius: 10}
+	g.damageNumbers = nil
+
+	g.hitEnemy(e, damage, WeaponDefs[wt].Color, wt)
+	d := g.damageNumbers[len(g.damageNumbers)-1]
+
+	return e.MaxHP - e.HP, d.Value, d.Crit
+}
+
+func TestCritAppliedOnce(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.CritChance = 1
+
+	lost, shown, crit := hitDummy(g, 100, WeaponPrint)
+	if !crit {
+		t.Fatal("100% crit chance should crit")
+	}
+
+	want := int(100 * baseCritMultiplier)
+	if lost != want || shown != want {
+		t.Errorf("crit dealt %d and showed %d, want %d for both", lost, shown, want)
+	}
+
+	disableCrits(g)
+
+	if lost, shown, crit := hitDummy(g, 100, WeaponPrint); crit || lost != 100 || shown != 100 {
+		t.Errorf("non-crit dealt %d, showed %d (crit=%v), want 100", lost, shown, crit)
+	}
+}
+
+func TestCritMultiplierStatAndOverflow(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.player.Equipment[SlotMouse] = &Equipment{Modifiers: []Modifier{{Type: ModCritMultiplier, Value: 50}}}
+	g.recalculateStats()
+
+	if math.Abs(g.player.CritMultiplier-2.0) > 1e-9 {
+		t.Fatalf("CritMultiplier = %v, want 2.0 with +50%%", g.player.CritMultiplier)
+	}
+
+	// 120% total with the weapon's base: 20 points overflow into the multiplier
+	g.player.CritChance = 1.2 - WeaponDefs[WeaponStackOverflow].CritChance
+
+	if got := g.critMultiplier(WeaponStackOverflow); math.Abs(got-2.2) > 1e-9 {
+		t.Errorf("overflowed multiplier = %v, want 2.2", got)
+	}
+
+	if lost, _, _ := hitDummy(g, 100, WeaponStackOverflow); lost != 220 {
+		t.Errorf("overflow crit dealt %d, want 220", lost)
+	}
+}
+
+func TestWeaponBaseCritAndGuarantee(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	if got := g.critChance(WeaponCopilot); got != WeaponDefs[WeaponCopilot].CritChance {
+		t.Errorf("Copilot crit chance = %v, want weapon base %v", got, WeaponDefs[WeaponCopilot].CritChance)
+	}
+
+	disableCrits(g)
+	g.player.GuaranteedCrits = 1
+
+	if _, _, crit := hitDummy(g, 10, WeaponPrint); !crit {
+		t.Error("guaranteed crit was not consumed on the next hit")
+	}
+
+	if g.player.GuaranteedCrits != 0 {
+		t.Errorf("GuaranteedCrits = %d, want 0 after use", g.player.GuaranteedCrits)
+	}
+
+	if _, _, crit := hitDummy(g, 10, WeaponPrint); crit {
+		t.Error("only one hit should be guaranteed")
+	}
+}
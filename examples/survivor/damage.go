@@ -0,0 +1,63 @@
+package main
+
+import "fmt"
+
+// damagePerLevel is the base damage a weapon gains per level.
+const damagePerLevel = 3
+
+// DamageCalc breaks a weapon hit down into the parts that make it: the
+// weapon's base damage, flat damage added by gear and the passive tree, the
+// player's damage multiplier, and what a crit does on top.
+type DamageCalc struct {
+	Base       int     // Weapon damage plus its level bonus
+	Flat       int     // Added damage, e.g. "+9 Damage" on a keyboard
+	Mult       float64 // Percent increases and run modifiers, as one multiplier
+	CritChance float64 // May exceed 1; the overflow is already in CritMult
+	CritMult   float64
+}
+
+// damageCalc works out the damage of a weapon at a level with the player's
+// current stats.
+func (g *Game) damageCalc(wt WeaponType, level int) DamageCalc {
+	return DamageCalc{
+		Base:       WeaponDefs[wt].Damage + level*damagePerLevel,
+		Flat:       g.player.FlatDamage,
+		Mult:       g.player.DamageMult,
+		CritChance: g.critChance(wt),
+		CritMult:   g.critMultiplier(wt),
+	}
+}
+
+// Hit returns the damage of a hit before crits and resistances.
+func (d DamageCalc) Hit() int {
+	return int(float64(d.Base+d.Flat) * d.Mult)
+}
+
+// Crit returns the damage of a crit before resistances.
+func (d DamageCalc) Crit() int {
+	return int(float64(d.Hit()) * d.CritMult)
+}
+
+// Average returns the expected damage of a hit, counting crits by chance.
+func (d DamageCalc) Average() float64 {
+	chance := min(max(d.CritChance, 0), 1)
+
+	return float64(d.Hit()) * (1 + chance*(d.CritMult-1))
+}
+
+// Breakdown lists each step of the calculation for tooltips.
+func (d DamageCalc) Breakdown() []string {
+	lines := []string{fmt.Sprintf("Base     %d", d.Base)}
+
+	if d.Flat != 0 {
+		lines = append(lines, fmt.Sprintf("Flat     %+d", d.Flat))
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("Mult     x%.2f", d.Mult),
+		fmt.Sprintf("Hit      %d", d.Hit()),
+		fmt.Sprintf("Crit     %d (%.0f%%, x%.2f)", d.Crit(), min(d.CritChance, 1)*100, d.CritMult),
+	)
+
+	return lines
+}
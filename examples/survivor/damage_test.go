@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDamageCalc(t *testing.T) {
+	d := DamageCalc{Base: 18, Flat: 9, Mult: 1.5, CritChance: 0.2, CritMult: 2}
+
+	if d.Hit() != 40 || d.Crit() != 80 {
+		t.Errorf("hit %d, crit %d; want 40 and 80", d.Hit(), d.Crit())
+	}
+
+	if avg := d.Average(); math.Abs(avg-48) > 1e-9 {
+		t.Errorf("average = %v, want 48", avg)
+	}
+
+	lines := strings.Join(d.Breakdown(), "\n")
+	for _, want := range []string{
+		"Base     18", "Flat     +9", "Mult     x1.50", "Hit      40", "Crit     80 (20%, x2.00)",
+	} {
+		if !strings.Contains(lines, want) {
+			t.Errorf("breakdown missing %q:\n%s", want, lines)
+		}
+	}
+
+	plain := DamageCalc{Base: 5, Mult: 1, CritMult: 1.5}
+	if lines := plain.Breakdown(); strings.Contains(strings.Join(lines, ""), "Flat") {
+		t.Errorf("breakdown without flat damage lists it: %v", lines)
+	}
+}
+
+func TestFlatDamageModRaisesWeaponDamage(t *testing.T) {
+	g, dummy := newWeaponTestGame(WeaponGitPush)
+	dummy.X = 100
+
+	g.recalculateStats()
+	before := g.damageCalc(WeaponGitPush, 1)
+
+	g.player.Equipment[SlotKeyboard] = &Equipment{
+		Slot: SlotKeyboard, Modifiers: []Modifier{{Type: ModFlatDamage, Value: 9}},
+	}
+	g.recalculateStats()
+
+	calc := g.damageCalc(WeaponGitPush, 1)
+	if want := int(float64(before.Base+9) * before.Mult); calc.Flat != 9 || calc.Hit() != want {
+		t.Fatalf("calc = %+v, hit %d; want %d with +9 flat", calc, calc.Hit(), want)
+	}
+
+	g.fireWeapon(g.player.Weapons[0])
+
+	if len(g.projectiles) == 0 || g.projectiles[0].Damage != calc.Hit() {
+		t.Errorf("fired projectiles %v, want damage %d", g.projectiles, calc.Hit())
+	}
+
+	if stats := g.weaponStats(WeaponGitPush, 1); stats.Damage != calc.Hit() {
+		t.Errorf("preview damage %d, want %d", stats.Damage, calc.Hit())
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// DodgeKind is how a character evades.
+type DodgeKind int
+
+const (
+	DodgeRoll     DodgeKind = iota // Quick roll with i-frames along the facing direction
+	DodgeTeleport                  // Instant blink; shorter i-frames
+)
+
+// DodgeDef configures a character's dodge.
+type DodgeDef struct {
+	Kind     DodgeKind
+	Cost     float64 // Stamina per dodge
+	Distance float64 // Pixels travelled
+	Duration float64 // Seconds the roll lasts (ignored for teleports)
+	IFrames  float64 // Seconds of invulnerability
+}
+
+// CharacterDodges configures the dodge per character; the Tech Lead blinks instead of rolling.
+var CharacterDodges = map[CharacterType]DodgeDef{
+	CharJunior:   {Kind: DodgeRoll, Cost: 30, Distance: 120, Duration: 0.25, IFrames: 0.3},
+	CharSenior:   {Kind: DodgeRoll, Cost: 35, Distance: 100, Duration: 0.25, IFrames: 0.35},
+	CharTechLead: {Kind: DodgeTeleport, Cost: 50, Distance: 170, IFrames: 0.15},
+	Char10x:      {Kind: DodgeRoll, Cost: 25, Distance: 140, Duration: 0.2, IFrames: 0.25},
+}
+
+const (
+	baseMaxStamina     = 100.0
+	staminaRegen       = 30.0 // Per second
+	staminaRegenDelay  = 0.6  // Seconds after a dodge before regen resumes
+	perfectDodgeWindow = 0.3  // Dodging this close to a telegraph landing counts as perfect
+)
+
+// TelegraphDef describes a wind-up attack an enemy type can perform.
+type TelegraphDef struct {
+	Range    float64 // Enemy starts the attack when the player is this close
+	Radius   float64 // Area of the strike
+	Windup   float64 // Seconds of warning before it lands
+	Cooldown float64 // Seconds between attacks
+	DamageX  float64 // Multiplier of the enemy's contact damage
+}
+
+// TelegraphAttacks lists enemies that telegraph heavy attacks.
+var TelegraphAttacks = map[MonsterType]TelegraphDef{
+	MonsterLegacy:       {Range: 160, Radius: 70, Windup: 1.0, Cooldown: 5, DamageX: 2},
+	MonsterBossManager:  {Range: 260, Radius: 110, Windup: 1.2, Cooldown: 4, DamageX: 1.5},
+	MonsterBossDeadline: {Range: 300, Radius: 140, Windup: 1.0, Cooldown: 3, DamageX: 1.5},
+}
+
+// Telegraph is a pending enemy strike shown on the ground before it lands.
+type Telegraph struct {
+	X, Y    float64
+	Radius  float64
+	Windup  float64
+	Timer   float64 // Seconds until it lands
+	Damage  int
+	Source  *Enemy
+	Perfect bool // Player dodged in the area just before it landed
+}
+
+// sourceName names the enemy behind a telegraph for the combat log.
+func (t *Telegraph) sourceName() string {
+	if t.Source == nil {
+		return "Unknown"
+	}
+
+	return MonsterDefs[t.Source.Type].Name + " slam"
+}
+
+// armorPen returns the armor penetration of the telegraphing enemy.
+func (t *Telegraph) armorPen() float64 {
+	if t.Source == nil {
+		return 0
+	}
+
+	return MonsterDefs[t.Source.Type].ArmorPen
+}
+
+// origin returns where the strike came from: the telegraphing enemy, or the
+// center of the area if it has none.
+func (t *Telegraph) origin() (float64, float64) {
+	if t.Source == nil {
+		return t.X, t.Y
+	}
+
+	return t.Source.X, t.Source.Y
+}
+
+// dodgeDef returns the player's dodge configuration.
+func (g *Game) dodgeDef() DodgeDef {
+	return CharacterDodges[g.player.CharType]
+}
+
+// dodgePressed reports whether the dodge key or gamepad shoulder was just pressed.
+func dodgePressed() bool {
+	return controls.JustPressed(actionDodge)
+}
+
+// updateDodge regenerates stamina, reads dodge input, and moves the player mid-roll.
+func (g *Game) updateDodge(dt float64) {
+	p := g.player
+
+	if p.StaminaDelay > 0 {
+		p.StaminaDelay -= dt
+	} else {
+		p.Stamina = math.Min(p.Stamina+staminaRegen*dt, p.MaxStamina)
+	}
+
+	if dodgePressed() {
+		g.dodge()
+	}
+
+	if p.DodgeTimer > 0 {
+		def := g.dodgeDef()
+		step := def.Distance / def.Duration * math.Min(dt, p.DodgeTimer)
+		p.X += p.FaceX * step
+		p.Y += p.FaceY * step
+		p.DodgeTimer -= dt
+	}
+}
+
+// dodge performs the character's dodge if there is enough stamina.
+func (g *Game) dodge() bool {
+	p := g.player
+	def := g.dodgeDef()
+
+	if p.Stamina < def.Cost || p.DodgeTimer > 0 {
+		return false
+	}
+
+	// Dodging breaks free of a stun
+	clearAilment(&p.Ailments, components.StatusFreeze)
+
+	p.Stamina -= def.Cost
+	p.StaminaDelay = staminaRegenDelay
+	p.HitTimer = math.Max(p.HitTimer, def.IFrames)
+
+	if p.FaceX == 0 && p.FaceY == 0 {
+		p.FaceX = 1
+	}
+
+	// Dodging out of (or through) a strike about to land is a perfect dodge
+	for _, t := range g.telegraphs {
+		if t.Timer <= perfectDodgeWindow && math.Hypot(p.X-t.X, p.Y-t.Y) < t.Radius+20 {
+			t.Perfect = true
+		}
+	}
+
+	charColor := Characters[p.CharType].Color
+
+	switch def.Kind {
+	case DodgeTeleport:
+		g.spawnParticle(p.X, p.Y, 12, charColor)
+		p.X += p.FaceX * def.Distance
+		p.Y += p.FaceY * def.Distance
+		g.spawnParticle(p.X, p.Y, 12, charColor)
+	default:
+		p.DodgeTimer = def.Duration
+		g.spawnTrailParticle(p.X, p.Y, 6, charColor)
+	}
+
+	return true
+}
+
+// updateTelegraphs starts enemy wind-up attacks and resolves the ones that land.
+func (g *Game) updateTelegraphs(dt float64) {
+	p := g.player
+
+	for _, e := range g.enemies {
+		def, ok := TelegraphAttacks[e.Type]
+		if !ok || e.Dead || e.Pattern != nil || e.Ailments.HasStatus(components.StatusFreeze) {
+			continue
+		}
+
+		e.AttackTimer -= dt
+		if e.AttackTimer > 0 || math.Hypot(p.X-e.X, p.Y-e.Y) > def.Range {
+			continue
+		}
+
+		e.AttackTimer = def.Cooldown
+		e.Windup = def.Windup
+		g.telegraphs = append(g.telegraphs, &Telegraph{
+			X: p.X, Y: p.Y,
+			Radius: def.Radius,
+			Windup: def.Windup,
+			Timer:  def.Windup,
+			Damage: int(float64(e.Damage) * def.DamageX),
+			Source: e,
+		})
+	}
+
+	kept := g.telegraphs[:0]
+
+	for _, t := range g.telegraphs {
+		t.Timer -= dt
+		if t.Source != nil {
+			t.Source.Windup = math.Max(t.Timer, 0)
+		}
+
+		if t.Timer > 0 {
+			kept = append(kept, t)
+
+			continue
+		}
+
+		// The strike lands; a cancelled attack (dead source) does nothing
+		if t.Source != nil && t.Source.Dead {
+			continue
+		}
+
+		inside := math.Hypot(p.X-t.X, p.Y-t.Y) < t.Radius
+		g.spawnParticle(t.X, t.Y, 15, color.RGBA{R: 255, G: 80, B: 60, A: 255})
+
+		switch {
+		case t.Perfect:
+			// Reward: refund the dodge, briefly extend the i-frames, and line up a crit
+			g.perfectDodges++
+			p.GuaranteedCrits++
+			p.Stamina = math.Min(p.Stamina+g.dodgeDef().Cost, p.MaxStamina)
+			p.HitTimer = math.Max(p.HitTimer, 0.5)
+			g.spawnParticle(p.X, p.Y, 20, color.RGBA{R: 255, G: 255, B: 120, A: 255})
+		case inside && p.HitTimer <= 0:
+			ox, oy := t.origin()
+			if g.hurtPlayerFrom(ox, oy, t.Damage, t.armorPen(), t.sourceName()) {
+				g.inflictPlayer(t.Source, t.Damage)
+			}
+		}
+	}
+
+	g.telegraphs = kept
+}
+
+// drawTelegraphs renders pending strikes as filling warning circles.
+func (g *Game) drawTelegraphs(screen *ebiten.Image) {
+	for _, t := range g.telegraphs {
+		sx, sy := float32(t.X-g.cameraX), float32(t.Y-g.cameraY)
+		progress := float32(1 - t.Timer/t.Windup)
+
+		vector.FillCircle(screen, sx, sy, float32(t.Radius)*progress, color.NRGBA{R: 255, G: 60, B: 40, A: 70}, false)
+		vector.StrokeCircle(screen, sx, sy, float32(t.Radius), 2, color.NRGBA{R: 255, G: 60, B: 40, A: 200}, false)
+	}
+}
+
+// drawStaminaBar draws the stamina meter under the XP bar.
+func (g *Game) drawStaminaBar(screen *ebiten.Image) {
+	p := g.player
+	ratio := float32(p.Stamina / p.MaxStamina)
+
+	fill := color.RGBA{R: 120, G: 220, B: 120, A: 255}
+	if p.Stamina < g.dodgeDef().Cost {
+		fill = color.RGBA{R: 120, G: 120, B: 120, A: 255}
+	}
+
+	vector.FillRect(screen, 60, 48, 200, 6, color.RGBA{R: 40, G: 40, B: 40, A: 255}, false)
+	vector.FillRect(screen, 60, 48, 200*ratio, 6, fill, false)
+}
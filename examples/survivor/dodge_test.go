@@ -0,0 +1,105 @@
+package main
+
+import "testing"
+
+func TestDodgeStamina(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.FaceX = 1
+	def := g.dodgeDef()
+
+	dodges := 0
+	for g.dodge() {
+		dodges++
+		g.player.DodgeTimer = 0
+	}
+
+	if want := int(baseMaxStamina / def.Cost); dodges != want {
+		t.Errorf("dodges on a full bar = %d, want %d", dodges, want)
+	}
+
+	// No regen during the delay, then regen back to full
+	before := g.player.Stamina
+	g.updateDodge(staminaRegenDelay / 2)
+
+	if g.player.Stamina != before {
+		t.Error("stamina regenerated during the regen delay")
+	}
+
+	for range 10 * 60 {
+		g.updateDodge(1.0 / 60)
+	}
+
+	if g.player.Stamina != g.player.MaxStamina {
+		t.Errorf("stamina = %v after regen, want %v", g.player.Stamina, g.player.MaxStamina)
+	}
+}
+
+func TestTechLeadTeleports(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharTechLead)
+	g.player.FaceX = 1
+
+	if !g.dodge() {
+		t.Fatal("dodge failed")
+	}
+
+	if want := CharacterDodges[CharTechLead].Distance; g.player.X != want || g.player.DodgeTimer != 0 {
+		t.Errorf("teleport moved to x=%v (roll timer %v), want instant move to %v",
+			g.player.X, g.player.DodgeTimer, want)
+	}
+}
+
+func TestTelegraphRewardsDodging(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	legacy := &Enemy{X: 100, Y: 0, HP: 100, MaxHP: 100, Damage: 20, Radius: 10, Type: MonsterLegacy}
+	g.enemies = append(g.enemies, legacy)
+
+	g.updateTelegraphs(1.0 / 60)
+
+	if len(g.telegraphs) != 1 || legacy.Windup <= 0 {
+		t.Fatalf("legacy code should start a telegraphed attack, got %d", len(g.telegraphs))
+	}
+
+	// Standing still: the strike lands
+	hp := g.player.HP
+	for range 2 * 60 {
+		g.updateTelegraphs(1.0 / 60)
+	}
+
+	if g.player.HP >= hp {
+		t.Error("standing in a telegraph should hurt")
+	}
+
+	if legacy.Windup != 0 {
+		t.Errorf("enemy still rooted after the strike, windup = %v", legacy.Windup)
+	}
+
+	// Dodging just before the next strike lands is perfect
+	g.player.HitTimer = 0
+	legacy.AttackTimer = 0
+	g.updateTelegraphs(1.0 / 60)
+
+	for g.telegraphs[0].Timer > perfectDodgeWindow/2 {
+		g.updateTelegraphs(1.0 / 60)
+	}
+
+	g.dodge()
+	stamina := g.player.Stamina
+	hp = g.player.HP
+
+	for len(g.telegraphs) > 0 {
+		g.updateTelegraphs(1.0 / 60)
+	}
+
+	if g.perfectDodges != 1 || g.player.HP != hp || g.player.Stamina <= stamina {
+		t.Errorf("perfect dodge: count=%d hp %d->%d stamina %v->%v",
+			g.perfectDodges, hp, g.player.HP, stamina, g.player.Stamina)
+	}
+
+	if g.player.GuaranteedCrits != 1 {
+		t.Errorf("perfect dodge should line up a guaranteed crit, got %d", g.player.GuaranteedCrits)
+	}
+}
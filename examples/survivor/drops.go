@@ -0,0 +1,168 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// Item drop tuning.
+const (
+	itemDropPickupDist = 24  // Walking this close picks a drop up
+	itemDropReach      = 90  // The loot key picks up the nearest drop this close
+	itemDropBlink      = 5.0 // Seconds before despawning that a drop blinks
+	itemDropScatter    = 18  // Distance drops from one kill spread apart
+)
+
+// itemDropLife is how long a drop of each rarity lies on the ground.
+var itemDropLife = map[Rarity]float64{
+	RarityCommon:    30,
+	RarityMagic:     45,
+	RarityRare:      60,
+	RarityLegendary: 90,
+}
+
+// ItemDrop is a piece of equipment lying where it dropped, waiting to be
+// picked up.
+type ItemDrop struct {
+	Item *Equipment
+	X, Y float64
+	Life float64 // Seconds until it despawns
+	Age  float64 // Seconds on the ground, for the glow's pulse
+}
+
+// dropItem leaves item on the ground near (x, y). Drops from the same spot
+// fan out so they do not stack.
+func (g *Game) dropItem(item *Equipment, x, y float64) {
+	angle := float64(len(g.itemDrops)) * 2.399963 // Golden angle
+	if len(g.itemDrops) > 0 {
+		x += math.Cos(angle) * itemDropScatter
+		y += math.Sin(angle) * itemDropScatter
+	}
+
+	g.itemDrops = append(g.itemDrops, &ItemDrop{Item: item, X: x, Y: y, Life: itemDropLife[item.Rarity]})
+}
+
+// updateItemDrops ages drops, despawns expired ones, and picks up those the
+// player walks over or reaches for with the loot key. Drops stay on the
+// ground while the inventory is full.
+func (g *Game) updateItemDrops(dt float64) {
+	if controls.JustPressed(actionLoot) {
+		switch i := g.nearestItemDrop(itemDropReach); {
+		case i < 0:
+		case g.inventoryFull():
+			g.invFullWarned = false // The key always gets an answer
+			g.warnInventoryFull()
+		default:
+			g.pickUpItem(i)
+		}
+	}
+
+	kept := g.itemDrops[:0]
+
+	for _, d := range g.itemDrops {
+		d.Age += dt
+		d.Life -= dt
+
+		near := math.Hypot(g.player.X-d.X, g.player.Y-d.Y) < itemDropPickupDist
+
+		switch {
+		case near && !g.inventoryFull():
+			g.collectItem(d.Item)
+		case d.Life > 0:
+			if near {
+				g.warnInventoryFull()
+			}
+
+			kept = append(kept, d)
+		}
+	}
+
+	clear(g.itemDrops[len(kept):])
+	g.itemDrops = kept
+}
+
+// nearestItemDrop returns the index of the closest drop within reach of the
+// player, or -1.
+func (g *Game) nearestItemDrop(reach float64) int {
+	best := -1
+
+	for i, d := range g.itemDrops {
+		if dist := math.Hypot(g.player.X-d.X, g.player.Y-d.Y); dist < reach {
+			reach, best = dist, i
+		}
+	}
+
+	return best
+}
+
+// pickUpItem takes drop i off the ground into the inventory.
+func (g *Game) pickUpItem(i int) {
+	g.collectItem(g.itemDrops[i].Item)
+	g.itemDrops = append(g.itemDrops[:i], g.itemDrops[i+1:]...)
+}
+
+// collectItem adds a picked up item to the inventory.
+func (g *Game) collectItem(item *Equipment) {
+	g.player.Inventory = append(g.player.Inventory, item)
+	g.audio.PlaySound("select")
+}
+
+// drawItemDrops draws each drop on screen as a box under a beam of light in
+// its rarity color, taller for rarer items, blinking as it is about to
+// vanish. The drop the loot key would take is labeled.
+func (g *Game) drawItemDrops(screen *ebiten.Image) {
+	near := g.nearestItemDrop(itemDropReach)
+
+	for i, d := range g.itemDrops {
+		sx, sy := float32(d.X-g.cameraX), float32(d.Y-g.cameraY)
+		if sx < -20 || sx > screenWidth+20 || sy < -20 || sy > screenHeight+200 {
+			continue
+		}
+
+		if d.Life < itemDropBlink && int(d.Life*6)%2 == 0 {
+			continue
+		}
+
+		c := RarityColor(d.Item.Rarity)
+		pulse := float32(0.75 + 0.25*math.Sin(d.Age*4))
+
+		drawLootBeam(screen, sx, sy, 40+30*float32(d.Item.Rarity), c, pulse)
+		vector.FillCircle(screen, sx, sy, 12*pulse, color.RGBA{R: c.R / 3, G: c.G / 3, B: c.B / 3, A: 80}, false)
+		vector.FillRect(screen, sx-5, sy-5, 10, 10, c, false)
+		vector.StrokeRect(screen, sx-5, sy-5, 10, 10, 1, color.RGBA{R: 20, G: 20, B: 20, A: 255}, false)
+
+		if i == near {
+			label := hudText
+			label.Color, label.Size, label.Align = c, smallText().Size, text.AlignCenter
+			msg := firstKey(controls.Binding(actionLoot)) + ": " + d.Item.Name
+
+			if g.inventoryFull() {
+				label.Color, msg = ui.CurrentTheme().Palette.Danger, "Inventory full"
+			}
+
+			drawText(screen, msg, int(sx), int(sy)+10, label)
+		}
+	}
+}
+
+// drawLootBeam draws a column of light rising from (x, y), brightest at
+// its base.
+func drawLootBeam(screen *ebiten.Image, x, y, height float32, c color.RGBA, strength float32) {
+	const bands = 8
+
+	step := height / bands
+
+	for i := range bands {
+		fade := strength * (1 - float32(i)/bands) * 0.6
+		band := color.RGBA{
+			R: uint8(float32(c.R) * fade), G: uint8(float32(c.G) * fade), B: uint8(float32(c.B) * fade),
+			A: uint8(255 * fade),
+		}
+		vector.FillRect(screen, x-3, y-step*float32(i+1), 6, step, band, false)
+	}
+}
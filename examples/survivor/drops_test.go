@@ -0,0 +1,108 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestWalkingOverADropPicksItUp(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	item := &Equipment{Name: "Mouse", Rarity: RarityMagic}
+	g.dropItem(item, g.player.X+100, g.player.Y)
+	g.updateItemDrops(1.0 / 60)
+
+	if len(g.itemDrops) != 1 || len(g.player.Inventory) != 0 {
+		t.Fatal("a drop out of reach should stay on the ground")
+	}
+
+	g.player.X += 90
+	g.updateItemDrops(1.0 / 60)
+
+	if len(g.itemDrops) != 0 || len(g.player.Inventory) != 1 || g.player.Inventory[0] != item {
+		t.Errorf("drops %d, inventory %v; want the drop picked up", len(g.itemDrops), g.player.Inventory)
+	}
+}
+
+func TestLootKeyPicksUpNearestDrop(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	far := &Equipment{Name: "Far", Rarity: RarityCommon}
+	near := &Equipment{Name: "Near", Rarity: RarityCommon}
+	g.itemDrops = []*ItemDrop{
+		{Item: far, X: g.player.X + 80, Y: g.player.Y, Life: 10},
+		{Item: near, X: g.player.X, Y: g.player.Y + 50, Life: 10},
+		{Item: near, X: g.player.X + 500, Y: g.player.Y, Life: 10},
+	}
+
+	i := g.nearestItemDrop(itemDropReach)
+	if i != 1 {
+		t.Fatalf("nearest drop = %d, want 1", i)
+	}
+
+	g.pickUpItem(i)
+
+	if len(g.itemDrops) != 2 || g.itemDrops[0].Item != far || len(g.player.Inventory) != 1 {
+		t.Errorf("drops %d, inventory %v; want the near drop taken", len(g.itemDrops), g.player.Inventory)
+	}
+
+	g.itemDrops = g.itemDrops[1:]
+	if g.nearestItemDrop(itemDropReach) != -1 {
+		t.Error("no drop should be in reach")
+	}
+}
+
+func TestDropsDespawnByRarity(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.dropItem(&Equipment{Rarity: RarityCommon}, g.player.X+300, g.player.Y)
+	g.dropItem(&Equipment{Rarity: RarityLegendary}, g.player.X+300, g.player.Y)
+
+	if d := g.itemDrops[1]; d.X == g.player.X+300 && d.Y == g.player.Y {
+		t.Error("a second drop at the same spot should be scattered")
+	}
+
+	g.updateItemDrops(itemDropLife[RarityCommon])
+
+	if len(g.itemDrops) != 1 || g.itemDrops[0].Item.Rarity != RarityLegendary {
+		t.Fatalf("after %vs, %d drops left; want only the legendary", itemDropLife[RarityCommon], len(g.itemDrops))
+	}
+
+	g.updateItemDrops(itemDropLife[RarityLegendary])
+
+	if len(g.itemDrops) != 0 || len(g.player.Inventory) != 0 {
+		t.Error("every drop should despawn unclaimed")
+	}
+}
+
+func TestRunSaveKeepsGroundItems(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	held := &Equipment{Name: "Held"}
+	g.player.Inventory = []*Equipment{held}
+	g.dropItem(&Equipment{Name: "Ground"}, 0, 0)
+
+	s := g.snapshotRun()
+
+	if len(s.Inventory) != 2 || s.Inventory[0] != held || s.Inventory[1].Name != "Ground" {
+		t.Errorf("saved inventory = %v, want the held item then the ground one", s.Inventory)
+	}
+
+	if len(g.player.Inventory) != 1 {
+		t.Error("saving should not pick up the drop")
+	}
+}
+
+func TestDrawItemDrops(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.dropItem(&Equipment{Name: "Keyboard", Rarity: RarityRare}, g.player.X+40, g.player.Y)
+	g.dropItem(&Equipment{Name: "Mug", Rarity: RarityCommon}, g.player.X+5000, g.player.Y)
+	g.drawItemDrops(ebiten.NewImage(screenWidth, screenHeight))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Game speed accessibility option. Slow mode lowers the tick rate while
+// playing, so every timer, cooldown, and per-tick movement slows alike, and
+// leaves menus at full rate.
+const (
+	baseTPS       = 60
+	minGameSpeed  = 0.7
+	gameSpeedStep = 0.1
+)
+
+// clampGameSpeed snaps speed to a gameSpeedStep between minGameSpeed and
+// full speed. Zero, the unset default, is kept.
+func clampGameSpeed(speed float64) float64 {
+	if speed == 0 {
+		return 0
+	}
+
+	// Divide rather than multiply by the step so 0.7 stays exactly 0.7
+	steps := math.Round(speed / gameSpeedStep)
+
+	return min(max(steps/math.Round(1/gameSpeedStep), minGameSpeed), 1)
+}
+
+// gameSpeed returns the simulation speed from the settings.
+func (s Settings) gameSpeed() float64 {
+	if s.GameSpeed == 0 {
+		return 1
+	}
+
+	return clampGameSpeed(s.GameSpeed)
+}
+
+// targetTPS returns the tick rate for the current state: slowed while
+// playing, full everywhere else.
+func (g *Game) targetTPS() int {
+	if g.state != StatePlaying {
+		return baseTPS
+	}
+
+	return int(math.Round(baseTPS * g.settings.gameSpeed()))
+}
+
+// syncGameSpeed applies targetTPS when it changes.
+func (g *Game) syncGameSpeed() {
+	if tps := g.targetTPS(); tps != g.tps {
+		g.tps = tps
+		ebiten.SetTPS(tps)
+	}
+}
+
+// noteGameSpeed records the slowest game speed used this run.
+func (g *Game) noteGameSpeed() {
+	g.runGameSpeed = min(g.runGameSpeed, g.settings.gameSpeed())
+}
+
+// gameSpeedPercent formats a game speed for display.
+func gameSpeedPercent(speed float64) string {
+	return formatInt(int(math.Round(speed*100))) + "%"
+}
+
+// runNote summarizes the options that shaped this run, for the game over
+// screen: assists and the slowest game speed played at.
+func (g *Game) runNote() string {
+	var notes []string
+
+	if g.runAssisted {
+		notes = append(notes, "Assists enabled")
+	}
+
+	if g.runGameSpeed > 0 && g.runGameSpeed < 1 {
+		notes = append(notes, "Speed "+gameSpeedPercent(g.runGameSpeed))
+	}
+
+	return strings.Join(notes, " | ")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+)
+
+func TestClampGameSpeed(t *testing.T) {
+	for in, want := range map[float64]float64{0: 0, 0.5: 0.7, 0.84: 0.8, 0.9: 0.9, 1: 1, 2: 1} {
+		if got := clampGameSpeed(in); gameSpeedPercent(got) != gameSpeedPercent(want) {
+			t.Errorf("clampGameSpeed(%v) = %v, want %v", in, got, want)
+		}
+	}
+
+	if got := (Settings{}).gameSpeed(); got != 1 {
+		t.Errorf("default game speed = %v, want full speed", got)
+	}
+}
+
+func TestGameSpeedSlowsOnlyPlaying(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	if got := g.targetTPS(); got != baseTPS {
+		t.Errorf("full speed TPS = %d, want %d", got, baseTPS)
+	}
+
+	for range 10 {
+		g.adjustSetting(helpRowGameSpeed, -1)
+	}
+
+	if got := g.targetTPS(); got != 42 {
+		t.Errorf("TPS at %s = %d, want 42", gameSpeedPercent(g.settings.gameSpeed()), got)
+	}
+
+	// Menus keep full speed so navigation is not slowed
+	for _, state := range []GameState{StatePaused, StateHelp, StateLevelUp, StateCharSelect} {
+		g.state = state
+		if got := g.targetTPS(); got != baseTPS {
+			t.Errorf("TPS in state %v = %d, want %d", state, got, baseTPS)
+		}
+	}
+}
+
+func TestGameSpeedRecordedForRun(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	if note := g.runNote(); note != "" {
+		t.Fatalf("full speed run note = %q, want none", note)
+	}
+
+	g.adjustSetting(helpRowGameSpeed, -1)
+	g.adjustSetting(helpRowGameSpeed, -1)
+	g.adjustSetting(helpRowGameSpeed, 1)
+
+	// The slowest speed played counts, and it is not an assist
+	if g.runAssisted || g.runNote() != "Speed 80%" {
+		t.Errorf("run note = %q, assisted = %v, want the slowest speed only", g.runNote(), g.runAssisted)
+	}
+
+	g.startGame(CharJunior)
+
+	if note := g.runNote(); note != "Speed 90%" {
+		t.Errorf("new run at 90%% has note %q", note)
+	}
+}
+
+func TestGameSpeedSettingRoundTrip(t *testing.T) {
+	sm := game.NewSaveManager(t.TempDir())
+
+	want := Settings{GameSpeed: 0.7}
+	saveSettings(sm, want)
+
+	if got := loadSettings(sm); got != want {
+		t.Errorf("loaded %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// StatWeights says how much a character values each modifier. Modifiers
+// without an entry are worth 1.
+type StatWeights map[ModType]float64
+
+// GearProfiles are the stat weights auto-equip uses for each character.
+var GearProfiles = map[CharacterType]StatWeights{
+	CharJunior: {}, // Balanced
+	CharSenior: {
+		ModXPGain:   1.5,
+		ModArea:     1.2,
+		ModDuration: 1.2,
+	},
+	CharTechLead: {
+		ModArea:     1.5,
+		ModDuration: 1.3,
+		ModCooldown: 1.2,
+	},
+	Char10x: {
+		ModFlatDamage:    1.5,
+		ModPercentDamage: 1.5,
+		ModCooldown:      1.5,
+		ModCritChance:    1.2,
+		ModLifesteal:     1.2,
+		ModFlatHP:        0.5,
+		ModPercentHP:     0.5,
+		ModArmor:         0.5,
+	},
+}
+
+// modTierValue is a modifier's value per tier, used to put modifiers with
+// very different scales (+15 HP vs +2% crit) on the same footing.
+var modTierValue = map[ModType]float64{
+	ModFlatDamage:     3,
+	ModPercentDamage:  5,
+	ModFlatHP:         15,
+	ModPercentHP:      5,
+	ModArmor:          3,
+	ModSpeed:          3,
+	ModCritChance:     2,
+	ModCritMultiplier: 10,
+	ModCooldown:       3,
+	ModArea:           5,
+	ModDuration:       5,
+	ModMagnet:         10,
+	ModXPGain:         5,
+	ModRecovery:       0.5,
+	ModProjectiles:    1,
+	ModLifesteal:      1,
+	ModThorns:         10,
+	ModForkLightning:  2,
+	ModShield:         10,
+}
+
+// weight returns the weight of mod, defaulting to 1.
+func (w StatWeights) weight(mod ModType) float64 {
+	if v, ok := w[mod]; ok {
+		return v
+	}
+
+	return 1
+}
+
+// gearScore rates item for the current character: the sum of its modifiers
+// in tiers, each scaled by the character's stat weight. Nil scores 0.
+func (g *Game) gearScore(item *Equipment) float64 {
+	if item == nil {
+		return 0
+	}
+
+	weights := GearProfiles[g.player.CharType]
+	score := 0.0
+
+	for _, m := range item.Modifiers {
+		if per := modTierValue[m.Type]; per > 0 {
+			score += weights.weight(m.Type) * m.Value / per
+		}
+	}
+
+	return score
+}
+
+// isUpgrade reports whether item scores higher than what is equipped in its slot.
+func (g *Game) isUpgrade(item *Equipment) bool {
+	return g.gearScore(item) > g.gearScore(g.player.Equipment[item.Slot])
+}
+
+// bestUpgrade returns the inventory index of the best upgrade for slot, or -1.
+func (g *Game) bestUpgrade(slot EquipSlot) int {
+	best, bestScore := -1, g.gearScore(g.player.Equipment[slot])
+
+	for i, item := range g.player.Inventory {
+		if item.Slot != slot {
+			continue
+		}
+
+		if s := g.gearScore(item); s > bestScore {
+			best, bestScore = i, s
+		}
+	}
+
+	return best
+}
+
+// equipFromInventory equips the inventory item at index i, moving whatever
+// was in its slot back to the inventory. Stats are not recalculated.
+func (g *Game) equipFromInventory(i int) {
+	item := g.player.Inventory[i]
+	old := g.player.Equipment[item.Slot]
+	g.player.Equipment[item.Slot] = item
+
+	g.player.Inventory = append(g.player.Inventory[:i], g.player.Inventory[i+1:]...)
+	if old != nil {
+		g.player.Inventory = append(g.player.Inventory, old)
+	}
+}
+
+// autoEquip equips the best upgrade for every slot and returns how many
+// items it equipped.
+func (g *Game) autoEquip() int {
+	equipped := 0
+
+	for slot := range SlotCount {
+		if i := g.bestUpgrade(slot); i >= 0 {
+			g.equipFromInventory(i)
+			equipped++
+		}
+	}
+
+	if equipped > 0 {
+		g.recalculateStats()
+		g.audio.PlaySound("select")
+	}
+
+	g.selectedInvIndex = min(g.selectedInvIndex, max(len(g.player.Inventory)-1, 0))
+
+	return equipped
+}
+
+// autoEquipWithToast runs autoEquip from gameplay and reports the result.
+func (g *Game) autoEquipWithToast() {
+	msg := "No upgrades in your inventory"
+	if n := g.autoEquip(); n == 1 {
+		msg = "Equipped 1 upgrade"
+	} else if n > 1 {
+		msg = "Equipped " + formatInt(n) + " upgrades"
+	}
+
+	g.notify(ui.Notification{
+		Title:    "Auto-equip",
+		Message:  msg,
+		Color:    ui.CurrentTheme().Palette.Highlight,
+		Duration: 2,
+	})
+}
+
+// drawUpgradeArrow draws a green up arrow marking an upgrade, with its tip at (x, y).
+func drawUpgradeArrow(screen *ebiten.Image, x, y float32) {
+	c := color.RGBA{R: 80, G: 220, B: 100, A: 255}
+
+	for i := range 6 {
+		w := float32(i)
+		vector.FillRect(screen, x-w, y+w, 2*w+1, 1, c, false)
+	}
+
+	vector.FillRect(screen, x-2, y+6, 5, 7, c, false)
+}
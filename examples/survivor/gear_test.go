@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func gearItem(slot EquipSlot, mods ...Modifier) *Equipment {
+	return &Equipment{Slot: slot, Name: "Test Gear", Rarity: RarityMagic, Modifiers: mods}
+}
+
+func TestGearScoreUsesCharacterWeights(t *testing.T) {
+	g := &Game{}
+	g.startGame(Char10x)
+
+	damage := gearItem(SlotKeyboard, Modifier{Type: ModPercentDamage, Value: 10, Tier: 2})
+	health := gearItem(SlotKeyboard, Modifier{Type: ModFlatHP, Value: 30, Tier: 2})
+
+	if g.gearScore(damage) <= g.gearScore(health) {
+		t.Errorf("10x Eng scores damage %.2f <= health %.2f", g.gearScore(damage), g.gearScore(health))
+	}
+
+	g.startGame(CharJunior)
+
+	if g.gearScore(damage) != g.gearScore(health) {
+		t.Errorf("balanced profile scores equal tiers differently: %.2f vs %.2f",
+			g.gearScore(damage), g.gearScore(health))
+	}
+}
+
+func TestAutoEquipPicksBestPerSlot(t *testing.T) {
+	g := &Game{}
+	g.startGame(Char10x)
+
+	worn := gearItem(SlotMouse, Modifier{Type: ModCritChance, Value: 2, Tier: 1})
+	g.player.Equipment[SlotMouse] = worn
+
+	weak := gearItem(SlotMouse, Modifier{Type: ModArea, Value: 5, Tier: 1})
+	strong := gearItem(SlotMouse, Modifier{Type: ModPercentDamage, Value: 15, Tier: 3})
+	chair := gearItem(SlotChair, Modifier{Type: ModArmor, Value: 3, Tier: 1})
+	g.player.Inventory = []*Equipment{weak, strong, chair}
+
+	if g.isUpgrade(weak) || !g.isUpgrade(strong) || !g.isUpgrade(chair) {
+		t.Fatal("upgrade markers do not match the scores")
+	}
+
+	if n := g.autoEquip(); n != 2 {
+		t.Fatalf("autoEquip equipped %d items, want 2", n)
+	}
+
+	if g.player.Equipment[SlotMouse] != strong || g.player.Equipment[SlotChair] != chair {
+		t.Error("autoEquip did not equip the best item for each slot")
+	}
+
+	if len(g.player.Inventory) != 2 || g.bestUpgrade(SlotMouse) != -1 {
+		t.Errorf("inventory = %d items, want the replaced and the weak item kept", len(g.player.Inventory))
+	}
+
+	if g.player.DamageMult <= 1 {
+		t.Errorf("DamageMult = %.2f, want stats recalculated after equipping", g.player.DamageMult)
+	}
+}
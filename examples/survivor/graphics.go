@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// GraphicsQuality trades visual effects for frame time on low-end hardware.
+type GraphicsQuality int
+
+const (
+	QualityHigh GraphicsQuality = iota // Every effect; the default
+	QualityMedium
+	QualityLow
+)
+
+// graphicsProfile is what a quality level draws.
+type graphicsProfile struct {
+	Name         string
+	ParticleMult float64 // Scales the particles each effect spawns
+	TrailChance  float64 // Per-frame chance a fast projectile leaves a trail
+	Glow         bool    // Projectile glow layers
+	Vignette     bool    // Low-HP screen lighting
+	HPBarRange   float64 // Enemy HP bars beyond this distance are skipped; 0 draws all
+}
+
+var graphicsProfiles = [...]graphicsProfile{
+	QualityHigh:   {Name: "High", ParticleMult: 1, TrailChance: 0.3, Glow: true, Vignette: true},
+	QualityMedium: {Name: "Medium", ParticleMult: 0.6, TrailChance: 0.15, Glow: true, Vignette: true, HPBarRange: 400},
+	QualityLow:    {Name: "Low", ParticleMult: 0.3, HPBarRange: 200},
+}
+
+// clampQuality keeps a saved or adjusted quality within the known levels.
+func clampQuality(q GraphicsQuality) GraphicsQuality {
+	return min(max(q, QualityHigh), QualityLow)
+}
+
+// graphics returns the profile for the current quality setting.
+func (g *Game) graphics() *graphicsProfile {
+	return &graphicsProfiles[clampQuality(g.settings.Graphics)]
+}
+
+// hpBarVisible reports whether an enemy is close enough to the player for its
+// HP bar to be drawn.
+func (g *Game) hpBarVisible(e *Enemy) bool {
+	r := g.graphics().HPBarRange
+
+	return r == 0 || math.Hypot(e.X-g.player.X, e.Y-g.player.Y) <= r
+}
+
+// Frame time probe: on the first run, the average frame time over the first
+// probeFrames frames of play picks a suggested quality.
+const (
+	probeFrames   = 300
+	probeMaxGap   = 250 * time.Millisecond // Longer gaps are pauses, not slow frames
+	probeMediumAt = 18 * time.Millisecond  // Roughly 55 FPS
+	probeLowAt    = 25 * time.Millisecond  // 40 FPS
+)
+
+// frameProbe averages the time between drawn frames.
+type frameProbe struct {
+	last   time.Time
+	total  time.Duration
+	frames int
+}
+
+// suggestedQuality returns the quality a measured average frame time calls for.
+func suggestedQuality(avg time.Duration) GraphicsQuality {
+	switch {
+	case avg >= probeLowAt:
+		return QualityLow
+	case avg >= probeMediumAt:
+		return QualityMedium
+	default:
+		return QualityHigh
+	}
+}
+
+// clock returns the current time; tests replace g.now.
+func (g *Game) clock() time.Time {
+	if g.now != nil {
+		return g.now()
+	}
+
+	return time.Now()
+}
+
+// probeFrame times one drawn frame of play until the probe has enough
+// samples, then suggests a lower quality if frames are slow. It runs once per
+// install: the result is saved in the settings.
+func (g *Game) probeFrame() {
+	if g.settings.GraphicsProbed {
+		return
+	}
+
+	p := &g.probe
+	now := g.clock()
+
+	if gap := now.Sub(p.last); !p.last.IsZero() && gap <= probeMaxGap {
+		p.total += gap
+		p.frames++
+	}
+
+	p.last = now
+
+	if p.frames < probeFrames {
+		return
+	}
+
+	avg := p.total / time.Duration(p.frames)
+	g.probe = frameProbe{}
+
+	s := g.settings
+	s.GraphicsProbed = true
+	g.setSettings(s)
+
+	if q := suggestedQuality(avg); q > s.Graphics {
+		g.notify(ui.Notification{
+			Title:    "Try Graphics: " + graphicsProfiles[q].Name,
+			Message:  fmt.Sprintf("Frames average %d ms. Change it in Help (H).", avg.Milliseconds()),
+			Color:    ui.CurrentTheme().Palette.Warning,
+			Duration: 6,
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGraphicsQualityCutsEffects(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	far := &Enemy{X: 300}
+	if g.particleAllowance(10) != 10 || !g.hpBarVisible(far) || !g.graphics().Glow {
+		t.Fatal("high quality should draw everything")
+	}
+
+	g.adjustSetting(helpRowGraphics, -1)
+	g.adjustSetting(helpRowGraphics, -1)
+	g.adjustSetting(helpRowGraphics, -1)
+
+	if g.settings.Graphics != QualityLow {
+		t.Fatalf("quality %d after stepping down three times, want Low", g.settings.Graphics)
+	}
+
+	if got := g.particleAllowance(10); got != 3 {
+		t.Errorf("low quality allows %d of 10 particles, want 3", got)
+	}
+
+	if g.hpBarVisible(far) || !g.hpBarVisible(&Enemy{X: 100}) {
+		t.Error("low quality should only draw nearby HP bars")
+	}
+
+	if p := g.graphics(); p.Glow || p.Vignette || p.TrailChance != 0 {
+		t.Errorf("low quality profile %+v keeps effects", *p)
+	}
+
+	g.adjustSetting(helpRowGraphics, 1)
+
+	if g.settings.Graphics != QualityMedium {
+		t.Errorf("quality %d after stepping up, want Medium", g.settings.Graphics)
+	}
+}
+
+func TestFrameProbeSuggestsQuality(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	clock := time.Unix(0, 0)
+	g.now = func() time.Time { return clock }
+
+	frame := func(d time.Duration) {
+		clock = clock.Add(d)
+		g.probeFrame()
+	}
+
+	// A pause in the middle is not a slow frame
+	for i := range probeFrames {
+		if i == probeFrames/2 {
+			frame(5 * time.Second)
+		}
+
+		frame(30 * time.Millisecond)
+	}
+
+	if g.settings.GraphicsProbed {
+		t.Fatal("probe finished early")
+	}
+
+	frame(30 * time.Millisecond)
+
+	if !g.settings.GraphicsProbed || g.toasts.Len() != 1 {
+		t.Fatalf("probed %v with %d toasts, want a suggestion", g.settings.GraphicsProbed, g.toasts.Len())
+	}
+
+	// The probe only runs once, and never changes the setting itself
+	for range probeFrames + 1 {
+		frame(30 * time.Millisecond)
+	}
+
+	if g.toasts.Len() != 1 || g.settings.Graphics != QualityHigh {
+		t.Errorf("%d toasts and quality %d after probing again", g.toasts.Len(), g.settings.Graphics)
+	}
+
+	if suggestedQuality(10*time.Millisecond) != QualityHigh || suggestedQuality(20*time.Millisecond) != QualityMedium {
+		t.Error("fast frames should not suggest Low")
+	}
+}
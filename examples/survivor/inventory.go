@@ -0,0 +1,148 @@
+package main
+
+import (
+	"cmp"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// inventoryLimit is how many unequipped items the player can carry; the
+// equipment screen shows six rows of four.
+const inventoryLimit = 24
+
+// salvageScrap is the scrap an item salvages for by rarity, before its item
+// level adds to it.
+var salvageScrap = map[Rarity]int{
+	RarityCommon:    1,
+	RarityMagic:     3,
+	RarityRare:      8,
+	RarityLegendary: 20,
+}
+
+// salvageValue returns the scrap salvaging item yields: its rarity's share
+// plus one per ten item levels.
+func salvageValue(item *Equipment) int {
+	return salvageScrap[item.Rarity] + item.ItemLevel/10
+}
+
+// inventoryFull reports whether the inventory has no room for another item.
+func (g *Game) inventoryFull() bool {
+	return len(g.player.Inventory) >= inventoryLimit
+}
+
+// warnInventoryFull tells the player once that an item was left behind, and
+// again only after room was made and the inventory filled back up.
+func (g *Game) warnInventoryFull() {
+	if g.invFullWarned {
+		return
+	}
+
+	g.invFullWarned = true
+	g.notify(ui.Notification{
+		Title:    "Inventory full",
+		Message:  "Salvage items on the equipment screen (I) to pick up more",
+		Color:    ui.CurrentTheme().Palette.Danger,
+		Duration: 3,
+	})
+}
+
+// salvageItem breaks up inventory item i for scrap.
+func (g *Game) salvageItem(i int) {
+	g.player.Scrap += salvageValue(g.player.Inventory[i])
+	g.player.Inventory = slices.Delete(g.player.Inventory, i, i+1)
+	g.invFullWarned = false
+	g.selectedInvIndex = min(g.selectedInvIndex, max(len(g.player.Inventory)-1, 0))
+}
+
+// salvageJunk salvages every item marked as junk and returns how many.
+func (g *Game) salvageJunk() int {
+	n := 0
+
+	for i := len(g.player.Inventory) - 1; i >= 0; i-- {
+		if g.player.Inventory[i].Junk {
+			g.salvageItem(i)
+			n++
+		}
+	}
+
+	return n
+}
+
+// sortInventory orders the inventory by slot, then rarest and highest item
+// level first, keeping the selected item selected.
+func (g *Game) sortInventory() {
+	inv := g.player.Inventory
+
+	var selected *Equipment
+	if g.selectedInvIndex < len(inv) {
+		selected = inv[g.selectedInvIndex]
+	}
+
+	slices.SortStableFunc(inv, func(a, b *Equipment) int {
+		return cmp.Or(
+			cmp.Compare(a.Slot, b.Slot),
+			cmp.Compare(b.Rarity, a.Rarity),
+			cmp.Compare(b.ItemLevel, a.ItemLevel),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	if i := slices.Index(inv, selected); i >= 0 {
+		g.selectedInvIndex = i
+	}
+}
+
+// updateInventoryKeys handles sorting, junk marking, and salvage on the
+// equipment screen.
+func (g *Game) updateInventoryKeys() {
+	i := g.selectedInvIndex
+	selected := i < len(g.player.Inventory)
+	salvage := inpututil.IsKeyJustPressed(ebiten.KeyX)
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		g.sortInventory()
+	case salvage && ebiten.IsKeyPressed(ebiten.KeyShift):
+		if g.salvageJunk() == 0 {
+			return
+		}
+	case salvage && selected:
+		g.salvageItem(i)
+	case inpututil.IsKeyJustPressed(ebiten.KeyJ) && selected:
+		g.player.Inventory[i].Junk = !g.player.Inventory[i].Junk
+	default:
+		return
+	}
+
+	g.audio.PlaySound("select")
+}
+
+// drawInventoryCount shows how full the inventory is, in the danger color
+// once full, and the scrap on hand, right-aligned at (x, y).
+func (g *Game) drawInventoryCount(screen *ebiten.Image, x, y int) {
+	opts := smallText()
+	opts.Align = text.AlignRight
+
+	if g.inventoryFull() {
+		opts.Color = ui.CurrentTheme().Palette.Danger
+	}
+
+	count := formatInt(len(g.player.Inventory)) + "/" + formatInt(inventoryLimit)
+	drawText(screen, count+"   Scrap "+formatInt(g.player.Scrap), x, y, opts)
+}
+
+// drawJunkMark dims an inventory tile marked as junk and labels it.
+func drawJunkMark(screen *ebiten.Image, x, y, w, h float32) {
+	vector.FillRect(screen, x, y, w, h, color.RGBA{A: 140}, false)
+
+	label := smallText()
+	label.Color = ui.CurrentTheme().Palette.Danger
+	label.Align = text.AlignRight
+	drawText(screen, "JUNK", int(x+w)-3, int(y)+3, label)
+}
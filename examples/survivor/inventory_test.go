@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestSalvageTurnsItemsIntoScrap(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	rare := &Equipment{Name: "Rare", Rarity: RarityRare, ItemLevel: 25}
+	junk := &Equipment{Name: "Junk", Rarity: RarityCommon, Junk: true}
+	keep := &Equipment{Name: "Keep", Rarity: RarityMagic}
+	g.player.Inventory = []*Equipment{junk, rare, keep, {Name: "More junk", Rarity: RarityMagic, Junk: true}}
+	g.selectedInvIndex = 3
+
+	g.salvageItem(1)
+
+	if g.player.Scrap != salvageScrap[RarityRare]+2 || len(g.player.Inventory) != 3 {
+		t.Fatalf("scrap %d, %d items after salvaging a rare", g.player.Scrap, len(g.player.Inventory))
+	}
+
+	if g.selectedInvIndex != 2 {
+		t.Errorf("selection %d should move back onto the last item", g.selectedInvIndex)
+	}
+
+	if n := g.salvageJunk(); n != 2 || len(g.player.Inventory) != 1 || g.player.Inventory[0] != keep {
+		t.Errorf("salvaged %d junk items, left %v", n, g.player.Inventory)
+	}
+
+	want := salvageScrap[RarityRare] + 2 + salvageScrap[RarityCommon] + salvageScrap[RarityMagic]
+	if g.player.Scrap != want {
+		t.Errorf("scrap = %d, want %d", g.player.Scrap, want)
+	}
+}
+
+func TestSortInventoryBySlotThenRarity(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	a := &Equipment{Name: "a", Slot: EquipSlot(1), Rarity: RarityCommon}
+	b := &Equipment{Name: "b", Slot: EquipSlot(0), Rarity: RarityCommon, ItemLevel: 9}
+	c := &Equipment{Name: "c", Slot: EquipSlot(1), Rarity: RarityLegendary}
+	d := &Equipment{Name: "d", Slot: EquipSlot(0), Rarity: RarityCommon, ItemLevel: 20}
+	g.player.Inventory = []*Equipment{a, b, c, d}
+	g.selectedInvIndex = 0
+
+	g.sortInventory()
+
+	want := []*Equipment{d, b, c, a}
+	for i, item := range g.player.Inventory {
+		if item != want[i] {
+			t.Fatalf("sorted %d = %s, want %s", i, item.Name, want[i].Name)
+		}
+	}
+
+	if g.selectedInvIndex != 3 {
+		t.Errorf("selection = %d, want it to follow item a to 3", g.selectedInvIndex)
+	}
+}
+
+func TestFullInventoryLeavesDropsOnGround(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	for range inventoryLimit {
+		g.player.Inventory = append(g.player.Inventory, &Equipment{Name: "Filler"})
+	}
+
+	g.dropItem(&Equipment{Name: "Loot", Rarity: RarityRare}, g.player.X, g.player.Y)
+	g.updateItemDrops(1.0 / 60)
+
+	if len(g.itemDrops) != 1 || len(g.player.Inventory) != inventoryLimit || !g.invFullWarned {
+		t.Fatalf("drops %d, items %d, warned %v; want the drop left and a warning",
+			len(g.itemDrops), len(g.player.Inventory), g.invFullWarned)
+	}
+
+	g.salvageItem(0)
+	g.updateItemDrops(1.0 / 60)
+
+	if len(g.itemDrops) != 0 || g.player.Inventory[inventoryLimit-1].Name != "Loot" || g.invFullWarned {
+		t.Error("making room should let the drop be picked up and rearm the warning")
+	}
+}
+
+func TestRunSaveKeepsScrapAndJunk(t *testing.T) {
+	g := &Game{runSaves: game.NewSaveManagerFS(paths.MemFS())}
+	g.startGame(CharJunior)
+
+	g.player.Scrap = 42
+	g.player.Inventory = []*Equipment{{Name: "Old", Junk: true}}
+
+	if err := g.saveRun("run_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _, err := g.loadRunSave("run_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g2 := &Game{}
+	g2.restoreRun(s)
+
+	if g2.player.Scrap != 42 || !g2.player.Inventory[0].Junk {
+		t.Errorf("restored scrap %d, junk %v", g2.player.Scrap, g2.player.Inventory[0].Junk)
+	}
+}
+
+func TestDrawEquipmentWithFullInventory(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	for i := range inventoryLimit {
+		g.player.Inventory = append(g.player.Inventory, &Equipment{Name: "Filler", Junk: i%2 == 0})
+	}
+
+	g.state = StateEquipment
+	g.drawEquipment(ebiten.NewImage(screenWidth, screenHeight))
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"image/color"
+	"slices"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// Item card layout, in pixels.
+const (
+	itemCardColW = 190
+	itemCardPad  = 10
+	itemCardGap  = 8 // Between the card and the item it describes
+)
+
+// cardLine is one styled line, or wrapped paragraph, of an item card.
+type cardLine struct {
+	Text string
+	Opts text.Options
+}
+
+func (l cardLine) height() int {
+	_, h := text.Measure(l.Text, l.Opts)
+
+	return int(h)
+}
+
+// modDiff returns how equipping item in place of equipped changes each
+// modifier type either has, in ModType order, leaving out types that come
+// out even. Either item may be nil.
+func modDiff(item, equipped *Equipment) []Modifier {
+	totals := make(map[ModType]float64)
+	add := func(e *Equipment, sign float64) {
+		if e == nil {
+			return
+		}
+
+		for _, m := range e.Modifiers {
+			totals[m.Type] += sign * m.Value
+		}
+	}
+
+	add(item, 1)
+	add(equipped, -1)
+
+	var diff []Modifier
+
+	for mt, v := range totals {
+		if v != 0 {
+			diff = append(diff, Modifier{Type: mt, Value: v})
+		}
+	}
+
+	slices.SortFunc(diff, func(a, b Modifier) int { return int(a.Type) - int(b.Type) })
+
+	return diff
+}
+
+// itemCardLines describes an item: its name in its rarity color, rarity,
+// slot, and item level, then each modifier. A nil item is an empty slot.
+func itemCardLines(item *Equipment, heading string) []cardLine {
+	muted := smallText()
+	muted.Width = itemCardColW
+
+	lines := []cardLine{{heading, muted}}
+	if item == nil {
+		return append(lines, cardLine{"(empty)", muted})
+	}
+
+	name := text.Options{Font: text.Bold(), Color: RarityColor(item.Rarity), Width: itemCardColW}
+	kind := RarityNames[item.Rarity] + " " + EquipSlotNames[item.Slot]
+
+	if item.ItemLevel > 0 {
+		kind += ", item level " + strconv.Itoa(item.ItemLevel)
+	}
+
+	lines = append(lines, cardLine{item.Name, name}, cardLine{kind, muted})
+
+	mod := text.Options{Size: muted.Size, Width: itemCardColW}
+	for _, m := range item.Modifiers {
+		lines = append(lines, cardLine{m.String(), mod})
+	}
+
+	if len(item.Modifiers) == 0 {
+		lines = append(lines, cardLine{"No modifiers", muted})
+	}
+
+	return lines
+}
+
+// itemDiffLines lists what equipping item would change, gains in the
+// theme's success color and losses in its danger color.
+func (g *Game) itemDiffLines(item *Equipment) []cardLine {
+	equipped := g.player.Equipment[item.Slot]
+	if equipped == item {
+		return nil
+	}
+
+	palette := ui.CurrentTheme().Palette
+	muted := smallText()
+
+	lines := []cardLine{{"If equipped:", muted}}
+
+	diff := modDiff(item, equipped)
+	for _, m := range diff {
+		c := palette.Success
+		if m.Value < 0 {
+			c = palette.Danger
+		}
+
+		lines = append(lines, cardLine{m.String(), text.Options{Size: muted.Size, Color: c}})
+	}
+
+	if len(diff) == 0 {
+		lines = append(lines, cardLine{"No change", muted})
+	}
+
+	return lines
+}
+
+func linesHeight(lines []cardLine) int {
+	h := 0
+	for _, l := range lines {
+		h += l.height()
+	}
+
+	return h
+}
+
+func drawCardLines(screen *ebiten.Image, lines []cardLine, x, y int) {
+	for _, l := range lines {
+		y += drawText(screen, l.Text, x, y, l.Opts)
+	}
+}
+
+// drawItemCard draws item next to the rectangle it is shown in: its
+// modifiers beside those of the item equipped in its slot, and what
+// swapping them would change. The card sits right of the rectangle, or
+// left of it where it would leave the screen.
+func (g *Game) drawItemCard(screen *ebiten.Image, item *Equipment, x, y, w float32) {
+	equipped := g.player.Equipment[item.Slot]
+	left := itemCardLines(item, "Selected")
+	right := itemCardLines(equipped, "Equipped")
+	diff := g.itemDiffLines(item)
+
+	top := max(linesHeight(left), linesHeight(right))
+	cardW := float32(2*itemCardColW + 3*itemCardPad)
+	cardH := float32(top + 2*itemCardPad)
+
+	if len(diff) > 0 {
+		cardH += float32(linesHeight(diff) + itemCardPad)
+	}
+
+	cardX := x + w + itemCardGap
+	if cardX+cardW > screenWidth {
+		cardX = x - itemCardGap - cardW
+	}
+
+	cardX = max(cardX, 0)
+	cardY := max(min(y, screenHeight-cardH), 0)
+
+	palette := ui.CurrentTheme().Palette
+	vector.FillRect(screen, cardX, cardY, cardW, cardH, color.RGBA{R: 20, G: 20, B: 28, A: 245}, false)
+	vector.StrokeRect(screen, cardX, cardY, cardW, cardH, 2, RarityColor(item.Rarity), false)
+
+	cx, cy := int(cardX)+itemCardPad, int(cardY)+itemCardPad
+	drawCardLines(screen, left, cx, cy)
+	drawCardLines(screen, right, cx+itemCardColW+itemCardPad, cy)
+
+	if len(diff) == 0 {
+		return
+	}
+
+	sepY, sepX := float32(cy+top+itemCardPad/2), cardX+itemCardPad
+	vector.StrokeLine(screen, sepX, sepY, cardX+cardW-itemCardPad, sepY, 1, palette.PanelBorder, false)
+	drawCardLines(screen, diff, cx, cy+top+itemCardPad)
+}
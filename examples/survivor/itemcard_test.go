@@ -0,0 +1,69 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestModDiff(t *testing.T) {
+	item := &Equipment{Modifiers: []Modifier{
+		{Type: ModArmor, Value: 5}, {Type: ModCritChance, Value: 4}, {Type: ModArmor, Value: 5},
+	}}
+	equipped := &Equipment{Modifiers: []Modifier{
+		{Type: ModFlatHP, Value: 20}, {Type: ModCritChance, Value: 4}, {Type: ModFlatDamage, Value: 3},
+	}}
+
+	got := modDiff(item, equipped)
+	want := []Modifier{
+		{Type: ModFlatDamage, Value: -3}, {Type: ModFlatHP, Value: -20}, {Type: ModArmor, Value: 10},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("diff = %v, want %v", got, want)
+	}
+
+	if got := modDiff(item, nil); len(got) != 2 || got[0] != (Modifier{Type: ModArmor, Value: 10}) ||
+		len(modDiff(nil, nil)) != 0 {
+		t.Errorf("diff against an empty slot = %v", got)
+	}
+}
+
+func TestItemCardComparesWithEquipped(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.player.Equipment[SlotChair] = &Equipment{
+		Slot: SlotChair, Name: "Office Chair", Rarity: RarityCommon,
+		Modifiers: []Modifier{{Type: ModArmor, Value: 12}},
+	}
+	item := &Equipment{
+		Slot: SlotChair, Name: "Swivel Throne", Rarity: RarityMagic, ItemLevel: 7,
+		Modifiers: []Modifier{{Type: ModArmor, Value: 4}, {Type: ModSpeed, Value: 6}},
+	}
+
+	var card []string
+	for _, l := range itemCardLines(item, "Selected") {
+		card = append(card, l.Text)
+	}
+
+	want := []string{
+		"Selected", "Swivel Throne", "Magic Gaming Chair, item level 7", "+4 Armor", "+6% Movement Speed",
+	}
+	if !slices.Equal(card, want) {
+		t.Errorf("card = %q, want %q", card, want)
+	}
+
+	lines := g.itemDiffLines(item)
+	if len(lines) != 3 || lines[1].Text != "-8 Armor" || lines[2].Text != "+6% Movement Speed" {
+		t.Fatalf("diff lines = %+v", lines)
+	}
+
+	if lines[1].Opts.Color == lines[2].Opts.Color {
+		t.Error("losses and gains should be colored apart")
+	}
+
+	if empty := itemCardLines(nil, "Equipped"); !strings.Contains(empty[1].Text, "empty") {
+		t.Errorf("empty slot card = %+v", empty)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// skipGoldBonus is the gold granted for skipping a level-up.
+const skipGoldBonus = 25
+
+// Leveling tuning.
+const (
+	xpPerLevel      = 25  // XP to the next level per current level
+	levelBurstShown = 2.5 // Seconds the "+N levels" indicator stays up
+)
+
+// levelBurstColor tints the "+N levels" indicator.
+var levelBurstColor = color.RGBA{R: 120, G: 220, B: 255, A: 255}
+
+// XPToNext returns the XP needed to reach the next level.
+func (p *Player) XPToNext() int {
+	return p.Level * xpPerLevel
+}
+
+// gainLevels spends banked XP on as many levels as it covers, keeping the
+// overflow toward the next, and queues a choice screen for each. A pickup
+// worth several levels, like a boss gem, shows a "+N levels" indicator.
+func (g *Game) gainLevels() {
+	p := g.player
+	gained := 0
+
+	for p.XP >= p.XPToNext() {
+		p.XP -= p.XPToNext()
+		p.Level++
+		p.PassivePoints++ // Grant passive point on level-up
+		gained++
+
+		g.logCombat(combatlog.Entry{Category: combatlog.LevelUp, Source: "Player", Amount: p.Level})
+		g.recordLevel(p.Level)
+	}
+
+	if gained == 0 {
+		return
+	}
+
+	if gained > 1 {
+		g.levelBurst, g.levelBurstAt = gained, levelBurstShown
+	}
+
+	p.PendingLevels += gained
+	g.nextLevelUp()
+}
+
+// nextLevelUp opens the next queued choice screen, or returns to play once
+// the queue is empty.
+func (g *Game) nextLevelUp() {
+	if g.player.PendingLevels <= 0 {
+		g.state = StatePlaying
+
+		return
+	}
+
+	g.player.PendingLevels--
+	g.showLevelUp()
+}
+
+// drawLevelBurst draws the "+N levels" indicator beside the level while it
+// is up.
+func (g *Game) drawLevelBurst(screen *ebiten.Image) {
+	if g.levelBurstAt <= 0 {
+		return
+	}
+
+	o := hudText
+	o.Font = text.Bold()
+	o.Color = levelBurstColor
+	drawText(screen, "+"+formatInt(g.levelBurst)+" levels", 320, 20, o)
+}
+
+// LevelUpTokens tracks level-up reroll/banish/skip usage for the current run.
+// Charges are derived from the Luck passive, so only usage is stored.
+type LevelUpTokens struct {
+	RerollsUsed  int
+	BanishesUsed int
+	SkipsUsed    int
+	Banished     map[string]bool // Option keys that never appear again this run
+	Banishing    bool            // Next option pick banishes instead of taking it
+}
+
+// NewLevelUpTokens creates an empty token state for a new run.
+func NewLevelUpTokens() LevelUpTokens {
+	return LevelUpTokens{Banished: make(map[string]bool)}
+}
+
+// Key identifies what an option offers, so banishing a weapon also removes
+// its level-ups and evolutions into it.
+func (o UpgradeOption) Key() string {
+	if o.IsWeapon {
+		return "weapon:" + WeaponDefs[o.WeaponType].Name
+	}
+
+	return "passive:" + PassiveDefs[o.PassiveType].Name
+}
+
+// MaxRerolls returns the reroll charges for the run: one plus one per Luck level.
+func (p *Player) MaxRerolls() int {
+	return 1 + p.Passives[PassiveLuck]
+}
+
+// MaxBanishes returns the banish charges: one plus one per two Luck levels.
+func (p *Player) MaxBanishes() int {
+	return 1 + p.Passives[PassiveLuck]/2
+}
+
+// MaxSkips returns the skip charges: one plus one per two Luck levels.
+func (p *Player) MaxSkips() int {
+	return 1 + p.Passives[PassiveLuck]/2
+}
+
+// RerollsLeft returns the remaining reroll charges.
+func (p *Player) RerollsLeft() int {
+	return max(p.MaxRerolls()-p.Tokens.RerollsUsed, 0)
+}
+
+// BanishesLeft returns the remaining banish charges.
+func (p *Player) BanishesLeft() int {
+	return max(p.MaxBanishes()-p.Tokens.BanishesUsed, 0)
+}
+
+// SkipsLeft returns the remaining skip charges.
+func (p *Player) SkipsLeft() int {
+	return max(p.MaxSkips()-p.Tokens.SkipsUsed, 0)
+}
+
+// filterBanished drops options the player banished earlier in the run.
+func (g *Game) filterBanished(options []UpgradeOption) []UpgradeOption {
+	kept := options[:0]
+
+	for _, opt := range options {
+		if !g.player.Tokens.Banished[opt.Key()] {
+			kept = append(kept, opt)
+		}
+	}
+
+	return kept
+}
+
+// rerollUpgrades spends a reroll charge to draw a fresh set of options.
+func (g *Game) rerollUpgrades() bool {
+	if g.player.RerollsLeft() == 0 {
+		return false
+	}
+
+	g.player.Tokens.RerollsUsed++
+	g.player.Tokens.Banishing = false
+	g.upgradeOptions = g.generateUpgrades()
+	g.audio.PlaySound("select")
+
+	return true
+}
+
+// banishUpgrade spends a banish charge to remove an option for the rest of the run.
+// The remaining options stay on screen.
+func (g *Game) banishUpgrade(index int) bool {
+	if index < 0 || index >= len(g.upgradeOptions) || g.player.BanishesLeft() == 0 {
+		return false
+	}
+
+	g.player.Tokens.BanishesUsed++
+	g.player.Tokens.Banishing = false
+	g.player.Tokens.Banished[g.upgradeOptions[index].Key()] = true
+	g.upgradeOptions = append(g.upgradeOptions[:index], g.upgradeOptions[index+1:]...)
+	g.audio.PlaySound("select")
+
+	// Nothing left to pick: treat as a free skip
+	if len(g.upgradeOptions) == 0 {
+		g.nextLevelUp()
+	}
+
+	return true
+}
+
+// skipLevelUp spends a skip charge, taking a small gold bonus instead of an upgrade.
+func (g *Game) skipLevelUp() bool {
+	if g.player.SkipsLeft() == 0 {
+		return false
+	}
+
+	g.player.Tokens.SkipsUsed++
+	g.player.Tokens.Banishing = false
+	g.player.Gold += skipGoldBonus
+	g.nextLevelUp()
+	g.audio.PlaySound("select")
+
+	return true
+}
+
+// levelUpButton is a clickable token button on the level-up screen. Its key
+// stays clear of the movement keys, which may still be held when the screen
+// opens.
+type levelUpButton struct {
+	label  string
+	key    ebiten.Key
+	charge func(*Player) int
+	action func(*Game)
+}
+
+var levelUpButtons = []levelUpButton{
+	{"Reroll", ebiten.KeyR, (*Player).RerollsLeft, func(g *Game) { g.rerollUpgrades() }},
+	{"Banish", ebiten.KeyB, (*Player).BanishesLeft, func(g *Game) {
+		if g.player.BanishesLeft() > 0 {
+			g.player.Tokens.Banishing = !g.player.Tokens.Banishing
+		}
+	}},
+	{"Skip", ebiten.KeyK, (*Player).SkipsLeft, func(g *Game) { g.skipLevelUp() }},
+}
+
+// levelUpButtonRect returns the screen rectangle of the i-th token button.
+func levelUpButtonRect(i int) (x, y, w, h float32) {
+	boxX, boxY := float32(screenWidth-500)/2, float32(screenHeight-levelUpBoxH)/2
+	w, h = 140, 32
+
+	return boxX + 25 + float32(i)*(w+15), boxY + levelUpBoxH - h - 15, w, h
+}
+
+// updateLevelUpTokens handles the token buttons. It returns true if input was consumed.
+func (g *Game) updateLevelUpTokens() bool {
+	clicked := -1
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+
+		for i := range levelUpButtons {
+			x, y, w, h := levelUpButtonRect(i)
+			if float32(mx) >= x && float32(mx) <= x+w && float32(my) >= y && float32(my) <= y+h {
+				clicked = i
+			}
+		}
+	}
+
+	for i, b := range levelUpButtons {
+		if clicked == i || inpututil.IsKeyJustPressed(b.key) {
+			b.action(g)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// drawLevelUpTokens draws the token buttons with their remaining charges.
+func (g *Game) drawLevelUpTokens(screen *ebiten.Image) {
+	for i, b := range levelUpButtons {
+		x, y, w, h := levelUpButtonRect(i)
+		left := b.charge(g.player)
+
+		skin := g.uiSkin()
+
+		switch {
+		case left == 0:
+			skin.ButtonSlice(ui.ButtonStatePressed).Draw(screen, float64(x), float64(y), float64(w), float64(h))
+		case b.label == "Banish" && g.player.Tokens.Banishing:
+			skin.Button.DrawTinted(screen, float64(x), float64(y), float64(w), float64(h),
+				color.RGBA{R: 255, G: 110, B: 110, A: 255})
+		default:
+			skin.Button.Draw(screen, float64(x), float64(y), float64(w), float64(h))
+		}
+
+		label := "[" + b.key.String() + "] " + b.label + " x" + formatInt(left)
+		drawText(screen, label, int(x+w/2), int(y)+8, text.Options{Align: text.AlignCenter})
+	}
+}
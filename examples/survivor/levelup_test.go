@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+func TestLevelUpTokens(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.showLevelUp()
+
+	if g.player.RerollsLeft() != 1 || g.player.BanishesLeft() != 1 || g.player.SkipsLeft() != 1 {
+		t.Fatalf("unexpected starting charges: %d/%d/%d",
+			g.player.RerollsLeft(), g.player.BanishesLeft(), g.player.SkipsLeft())
+	}
+
+	// Luck grants extra charges
+	g.player.Passives[PassiveLuck] = 2
+	if g.player.RerollsLeft() != 3 || g.player.BanishesLeft() != 2 {
+		t.Errorf("luck 2 charges: rerolls=%d banishes=%d", g.player.RerollsLeft(), g.player.BanishesLeft())
+	}
+
+	banished := g.upgradeOptions[0].Key()
+	if !g.banishUpgrade(0) {
+		t.Fatal("banish failed")
+	}
+
+	for range 20 {
+		for _, opt := range g.generateUpgrades() {
+			if opt.Key() == banished {
+				t.Fatalf("banished option %q offered again", banished)
+			}
+		}
+	}
+
+	if !g.rerollUpgrades() || g.player.Tokens.RerollsUsed != 1 {
+		t.Error("reroll should spend a charge")
+	}
+
+	if !g.skipLevelUp() || g.player.Gold != skipGoldBonus || g.state != StatePlaying {
+		t.Errorf("skip: gold=%d state=%v", g.player.Gold, g.state)
+	}
+
+	g.player.Passives[PassiveLuck] = 0
+	if g.skipLevelUp() {
+		t.Error("skip should fail without charges")
+	}
+}
+
+func TestXPOverflowQueuesLevelUps(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	// Both gems land this frame: 25 + 50 + 75 XP for three levels, 10 over
+	p := g.player
+	g.xpGems = append(g.xpGems, &XPGem{X: p.X, Y: p.Y, Value: 100}, &XPGem{X: p.X, Y: p.Y, Value: 60})
+	g.collectXP(1.0 / 60)
+
+	if len(g.xpGems) != 0 || p.Level != 4 || p.XP != 10 {
+		t.Fatalf("gems left %d, level %d, xp %d; want none, 4, and 10 banked", len(g.xpGems), p.Level, p.XP)
+	}
+
+	if g.state != StateLevelUp || p.PendingLevels != 2 || g.levelBurst != 3 {
+		t.Fatalf("state %v, pending %d, burst %d; want a choice open, 2 queued, +3 shown",
+			g.state, p.PendingLevels, g.levelBurst)
+	}
+
+	// Each pick opens the next queued choice; the last returns to play
+	for want := 1; want >= 0; want-- {
+		g.upgradeOptions[0].Apply(g)
+		g.nextLevelUp()
+
+		if g.state != StateLevelUp || p.PendingLevels != want {
+			t.Fatalf("after a pick: state %v, pending %d, want %d queued", g.state, p.PendingLevels, want)
+		}
+	}
+
+	if !g.skipLevelUp() || g.state != StatePlaying {
+		t.Errorf("skipping the last choice should return to play, state %v", g.state)
+	}
+}
+
+func TestSingleLevelShowsNoBurst(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.player.XP = g.player.XPToNext()
+	g.gainLevels()
+
+	if g.player.Level != 2 || g.player.PendingLevels != 0 || g.levelBurstAt > 0 {
+		t.Errorf("level %d, pending %d, burst shown %v", g.player.Level, g.player.PendingLevels, g.levelBurstAt)
+	}
+}
+
+func TestLevelUpKeysAvoidMovement(t *testing.T) {
+	for _, c := range []input.Control{input.MoveUp, input.MoveDown, input.MoveLeft, input.MoveRight} {
+		for _, k := range controls.ControlBinding(c).Keys {
+			for _, b := range levelUpButtons {
+				if b.key == k {
+					t.Errorf("%s shares %v with %s", b.label, k, c.Name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/stats"
+	"github.com/skyrocket-qy/NeuralWay/engine/triggers"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Lifetime stat names in the survivor namespace.
+const (
+	statsNamespace = "survivor"
+
+	statRunsStarted = "runs_started"
+	statKills       = "enemies_killed"
+	statBossKills   = "bosses_killed"
+	statDeaths      = "deaths"
+	statBestTime    = "best_survival_seconds"
+	statWorldEvents = "world_events"
+
+	// statUnlockedPrefix + an achievement ID is set once a trigger unlocks it
+	statUnlockedPrefix = "unlocked_"
+
+	// scopeRun resets trigger progress scoped "within run"
+	scopeRun = "run"
+)
+
+// lifetimeAchievements are unlocked from lifetime counters, so progress
+// carries over between runs and sessions.
+var lifetimeAchievements = []components.Achievement{
+	{ID: "first_blood", Name: "Hello, World", Description: "Defeat your first bug", Target: 1, Stat: statKills},
+	{ID: "centurion", Name: "Code Review", Description: "Defeat 1,000 bugs", Target: 1000, Stat: statKills},
+	{ID: "exterminator", Name: "Zero Defects", Description: "Defeat 10,000 bugs", Target: 10000, Stat: statKills},
+	{ID: "boss_slayer", Name: "Skip-Level", Description: "Defeat a boss", Target: 1, Stat: statBossKills},
+	{ID: "on_call", Name: "On Call", Description: "Live through 10 world events", Target: 10, Stat: statWorldEvents},
+	{ID: "persistent", Name: "Ship It Again", Description: "Start 25 runs", Target: 25, Stat: statRunsStarted},
+}
+
+// triggerAchievements are unlocked by run events matching their Trigger
+// rules; see package triggers for the rule syntax.
+var triggerAchievements = []components.Achievement{
+	{
+		ID: "flawless_deploy", Name: "Flawless Deploy", Description: "Defeat a boss without taking damage",
+		Target: 1, Trigger: "EnemyKilled where type=Boss and damageTaken=0 within run",
+	},
+	{
+		ID: "reorg", Name: "Reorg", Description: "Defeat 3 bosses in one run",
+		Target: 3, Trigger: "EnemyKilled where type=Boss within run",
+	},
+	{
+		ID: "headhunter", Name: "Headhunter", Description: "Defeat 10 elites in one run",
+		Target: 10, Trigger: "EnemyKilled where type=Elite within run",
+	},
+	{
+		ID: "fast_track", Name: "Fast Track", Description: "Reach level 20 in under 5 minutes",
+		Target: 1, Trigger: "LevelReached where level>=20 and time<300",
+	},
+	{
+		ID: "crunch_time", Name: "Crunch Time", Description: "Survive for 15 minutes",
+		Target: 1, Trigger: "RunEnded where time>=900",
+	},
+}
+
+// EnemyKilled is published on the run's bus when the player kills an enemy.
+type EnemyKilled struct {
+	Monster     string  // MonsterDefs name
+	Type        string  // "Boss", "Elite", or "Normal"
+	DamageTaken int     // HP the player has lost so far this run
+	Time        float64 // Run time in seconds
+}
+
+// LevelReached is published on the run's bus for each level the player gains.
+type LevelReached struct {
+	Level int
+	Time  float64 // Run time in seconds
+}
+
+// RunEnded is published on the run's bus when the player dies.
+type RunEnded struct {
+	Time  float64 // Seconds survived
+	Kills int
+	Level int
+}
+
+// openLifetimeStats loads the survivor's lifetime stats from the user data
+// directory, falling back to an in-memory store (e.g. the web build). Stats
+// that older builds kept in the config directory are moved over first.
+func openLifetimeStats() *stats.Store {
+	dir, err := survivorApp.Dir(paths.Data)
+	if err != nil {
+		return stats.NewMemory()
+	}
+
+	migrateLifetimeStats(dir)
+
+	s, err := stats.Open(dir, statsNamespace)
+	if err != nil {
+		log.Printf("lifetime stats: %v", err)
+
+		return stats.NewMemory()
+	}
+
+	return s
+}
+
+// migrateLifetimeStats moves a stats file from the config directory, where
+// older builds wrote it, into dir.
+func migrateLifetimeStats(dir string) {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return
+	}
+
+	from := filepath.Join(config, "neuralway")
+	file := statsNamespace + ".stats.json"
+
+	if moved, err := paths.MigrateLegacy(paths.DirFS(dir), from, stats.Validate, file); err != nil {
+		log.Printf("lifetime stats: %v", err)
+	} else if len(moved) > 0 {
+		log.Printf("lifetime stats: moved %s from %s", file, from)
+	}
+}
+
+// initLifetime attaches a stats store and syncs the achievements with it
+// without announcing ones unlocked in earlier sessions.
+func (g *Game) initLifetime(s *stats.Store) {
+	g.lifetime = s
+	g.achievements = components.NewAchievementTracker()
+
+	for _, a := range lifetimeAchievements {
+		g.achievements.Add(a)
+	}
+
+	for _, a := range triggerAchievements {
+		a.Unlocked = s.CounterValue(statUnlockedPrefix+a.ID) > 0
+		g.achievements.Add(a)
+	}
+
+	g.achievements.Sync(s)
+
+	t, err := triggers.New(&g.achievements)
+	if err != nil {
+		log.Printf("achievements: %v", err)
+	}
+
+	t.OnUnlock = g.unlockTriggered
+	g.triggers = t
+}
+
+// watchTriggers starts the run's trigger progress and feeds the run's
+// events to it.
+func (g *Game) watchTriggers() {
+	if g.triggers == nil || g.bus == nil {
+		return
+	}
+
+	g.triggers.Begin(scopeRun)
+
+	for _, watch := range []func(*triggers.Tracker, *events.Bus) (func(), error){
+		triggers.Watch[EnemyKilled],
+		triggers.Watch[LevelReached],
+		triggers.Watch[RunEnded],
+	} {
+		if _, err := watch(g.triggers, g.bus); err != nil {
+			log.Printf("achievements: %v", err)
+		}
+	}
+}
+
+// unlockTriggered remembers an achievement a trigger unlocked and announces it.
+func (g *Game) unlockTriggered(a *components.Achievement) {
+	g.lifetime.Counter(statUnlockedPrefix + a.ID).Inc()
+	g.announceAchievement(a)
+}
+
+// publish publishes a run event, outside the sandbox.
+func publish[T any](g *Game, ev T) {
+	if g.bus == nil || g.sandbox != nil {
+		return
+	}
+
+	events.Publish(g.bus, ev)
+}
+
+// recordRunStart counts a started run and starts watching its events.
+// Sandbox runs are not recorded.
+func (g *Game) recordRunStart() {
+	if g.lifetime == nil || g.sandbox != nil {
+		return
+	}
+
+	g.lifetime.Counter(statRunsStarted).Inc()
+	g.syncAchievements()
+	g.watchTriggers()
+}
+
+// recordKill counts a kill toward the lifetime totals and publishes it,
+// outside the sandbox.
+func (g *Game) recordKill(e *Enemy) {
+	if g.lifetime == nil || g.sandbox != nil {
+		return
+	}
+
+	def := MonsterDefs[e.Type]
+	kind := "Normal"
+
+	g.lifetime.Counter(statKills).Inc()
+
+	switch {
+	case def.IsBoss:
+		kind = "Boss"
+
+		g.lifetime.Counter(statBossKills).Inc()
+	case e.Elite || e.MegaElite != nil:
+		kind = "Elite"
+	}
+
+	g.syncAchievements()
+	publish(g, EnemyKilled{Monster: def.Name, Type: kind, DamageTaken: g.runDamageTaken, Time: g.gameTime})
+}
+
+// recordLevel publishes a level the player reached.
+func (g *Game) recordLevel(level int) {
+	publish(g, LevelReached{Level: level, Time: g.gameTime})
+}
+
+// recordWorldEvent counts a world event toward the lifetime totals, outside
+// the sandbox.
+func (g *Game) recordWorldEvent() {
+	if g.lifetime == nil || g.sandbox != nil {
+		return
+	}
+
+	g.lifetime.Counter(statWorldEvents).Inc()
+	g.syncAchievements()
+}
+
+// recordRunEnd records the death and best survival time, then writes the
+// batch to disk.
+func (g *Game) recordRunEnd() {
+	if g.lifetime == nil {
+		return
+	}
+
+	g.lifetime.Counter(statDeaths).Inc()
+	g.lifetime.Gauge(statBestTime).SetMax(g.gameTime)
+	publish(g, RunEnded{Time: g.gameTime, Kills: g.killCount, Level: g.player.Level})
+	g.flushLifetime()
+}
+
+// flushLifetime writes the counters gathered since the last flush, for runs
+// that end without a death: quitting to the menu or closing the window.
+func (g *Game) flushLifetime() {
+	if g.lifetime == nil {
+		return
+	}
+
+	if err := g.lifetime.Flush(); err != nil {
+		log.Printf("lifetime stats: %v", err)
+	}
+}
+
+// syncAchievements announces achievements newly unlocked by the counters.
+func (g *Game) syncAchievements() {
+	for _, id := range g.achievements.Sync(g.lifetime) {
+		g.announceAchievement(g.achievements.Achievements[id])
+	}
+}
+
+// announceAchievement raises a toast for a newly unlocked achievement.
+func (g *Game) announceAchievement(a *components.Achievement) {
+	g.notify(ui.Notification{
+		Title:    "Achievement: " + a.Name,
+		Message:  a.Description,
+		Color:    ui.CurrentTheme().Palette.Highlight,
+		Priority: ui.ToastHigh,
+	})
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/stats"
+	"github.com/skyrocket-qy/NeuralWay/engine/triggers"
+)
+
+func TestLifetimeStatsAcrossRuns(t *testing.T) {
+	store := stats.NewMemory()
+
+	g := &Game{}
+	g.initLifetime(store)
+	g.startGame(CharJunior)
+
+	g.killEnemy(&Enemy{Type: MonsterBug, HP: 0, MaxHP: 10})
+	g.killEnemy(&Enemy{Type: MonsterBossManager, HP: 0, MaxHP: 10})
+	g.gameTime = 75
+	g.endRun()
+
+	g.startGame(CharJunior)
+	g.gameTime = 30
+	g.endRun()
+
+	want := map[string]int64{statRunsStarted: 2, statKills: 2, statBossKills: 1, statDeaths: 2}
+	for name, v := range want {
+		if got := store.CounterValue(name); got != v {
+			t.Errorf("%s = %d, want %d", name, got, v)
+		}
+	}
+
+	if best := store.Gauge(statBestTime).Value(); best != 75 {
+		t.Errorf("best time = %v, want the longer run's 75", best)
+	}
+
+	for _, id := range []string{"first_blood", "boss_slayer"} {
+		if !g.achievements.Achievements[id].Unlocked {
+			t.Errorf("%s should unlock from the kill counters", id)
+		}
+	}
+
+	// A fresh session picks up progress from the store without re-announcing
+	g2 := &Game{}
+	g2.initLifetime(store)
+
+	if !g2.achievements.Achievements["first_blood"].Unlocked {
+		t.Error("unlocked achievements should restore from the store")
+	}
+}
+
+func TestTriggerAchievementsFromRunEvents(t *testing.T) {
+	store := stats.NewMemory()
+
+	g := &Game{}
+	g.initLifetime(store)
+	g.startGame(CharJunior)
+
+	g.losePlayerHP(1, "test")
+	g.killEnemy(&Enemy{Type: MonsterBossManager, MaxHP: 10})
+
+	if g.achievements.Achievements["flawless_deploy"].Unlocked {
+		t.Fatal("a boss killed after taking damage is not flawless")
+	}
+
+	g.startGame(CharJunior)
+	g.killEnemy(&Enemy{Type: MonsterBossManager, MaxHP: 10})
+
+	if !g.achievements.Achievements["flawless_deploy"].Unlocked {
+		t.Fatal("an untouched boss kill should unlock Flawless Deploy")
+	}
+
+	if reorg := g.achievements.Achievements["reorg"]; reorg.Progress != 1 {
+		t.Errorf("reorg progress = %d, want only this run's boss", reorg.Progress)
+	}
+
+	// Unlocks persist through the store
+	g2 := &Game{}
+	g2.initLifetime(store)
+
+	if !g2.achievements.Achievements["flawless_deploy"].Unlocked {
+		t.Error("trigger unlocks should restore from the store")
+	}
+}
+
+func TestTriggerAchievementRulesFitTheirEvents(t *testing.T) {
+	g := &Game{}
+	g.initLifetime(stats.NewMemory())
+
+	for _, a := range triggerAchievements {
+		if _, ok := g.triggers.Rule(a.ID); !ok {
+			t.Errorf("%s: rule %q did not parse", a.ID, a.Trigger)
+		}
+	}
+
+	bus := events.NewBus()
+
+	if _, err := triggers.Watch[EnemyKilled](g.triggers, bus); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := triggers.Watch[LevelReached](g.triggers, bus); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := triggers.Watch[RunEnded](g.triggers, bus); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLifetimeStatsFlushedWithoutADeath(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, leave := range []func(g *Game){(*Game).quitRun, (*Game).saveOnClose} {
+		store, err := stats.Open(dir, statsNamespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g := &Game{}
+		g.initLifetime(store)
+		g.startGame(CharJunior)
+		g.killEnemy(&Enemy{Type: MonsterBug, HP: 0, MaxHP: 10})
+		leave(g)
+	}
+
+	store, err := stats.Open(dir, statsNamespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := store.CounterValue(statKills); got != 2 {
+		t.Errorf("kills on disk = %d, want both runs' kills kept after quitting and closing", got)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/assets"
+	"github.com/skyrocket-qy/NeuralWay/engine/graphics"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// iconSize is the pixel size of weapon and passive icons.
+const iconSize = 64
+
+// iconKey is the loader key for an image scaled down to an icon.
+func iconKey(imageFile string) string {
+	return "icon:" + imageFile
+}
+
+// scaleToIcon resamples an image to iconSize x iconSize (nearest neighbour).
+func scaleToIcon(img image.Image) image.Image {
+	scaled := image.NewRGBA(image.Rect(0, 0, iconSize, iconSize))
+	srcBounds := img.Bounds()
+	sx := float64(srcBounds.Dx()) / float64(iconSize)
+	sy := float64(srcBounds.Dy()) / float64(iconSize)
+
+	for y := range iconSize {
+		for x := range iconSize {
+			srcX := int(float64(x) * sx)
+			srcY := int(float64(y) * sy)
+			scaled.Set(x, y, img.At(srcBounds.Min.X+srcX, srcBounds.Min.Y+srcY))
+		}
+	}
+
+	return scaled
+}
+
+// startLoading queues all sprites and icons for background decoding.
+func (g *Game) startLoading() {
+	g.loader = assets.NewAsyncLoader(assetsFS, 0)
+	g.loader.Placeholders = assets.NewPlaceholders()
+
+	for _, char := range Characters {
+		if char.ImageFile != "" {
+			g.loader.Add(char.ImageFile, char.ImageFile, graphics.RemoveBackground)
+		}
+	}
+
+	for _, def := range MonsterDefs {
+		if def.ImageFile != "" {
+			g.loader.Add(def.ImageFile, def.ImageFile, graphics.RemoveBackground)
+		}
+	}
+
+	for _, def := range WeaponDefs {
+		if def.ImageFile != "" {
+			g.loader.Add(iconKey(def.ImageFile), def.ImageFile, scaleToIcon)
+		}
+	}
+
+	for _, def := range PassiveDefs {
+		if def.ImageFile != "" {
+			g.loader.Add(iconKey(def.ImageFile), def.ImageFile, scaleToIcon)
+		}
+	}
+
+	g.loader.Start()
+}
+
+// updateLoading uploads decoded images a few per frame and moves on once done.
+func (g *Game) updateLoading() error {
+	// Limit GPU uploads per frame so the progress bar keeps animating
+	const uploadsPerFrame = 8
+
+	g.loader.Poll(uploadsPerFrame)
+
+	if g.loader.Done() {
+		g.finishLoading()
+	}
+
+	return nil
+}
+
+// finishLoading assigns the loaded textures, builds sprites and icons for
+// definitions without an image file, and logs any file that failed to load
+// and shows as the missing texture.
+func (g *Game) finishLoading() {
+	if report := g.loader.Placeholders.Report(); report != "" {
+		log.Print(report)
+	}
+
+	for i, char := range Characters {
+		g.charImages[i] = g.loader.Image(char.ImageFile)
+		if g.charImages[i] == nil {
+			palette := graphics.PaletteFromColor(char.Color)
+			g.charImages[i] = generatedSprite(char.Name, &palette)
+		}
+	}
+
+	for t, def := range MonsterDefs {
+		img := g.loader.Image(def.ImageFile)
+		if img == nil {
+			// Monsters are tinted by their color when drawn
+			img = generatedSprite(def.Name, &graphics.TintPalette)
+		}
+
+		g.monsterImages[t] = img
+	}
+
+	g.generateIcons()
+	g.state = StateCharSelect
+}
+
+// charImage returns the sprite of character c, or the missing texture when
+// there is none, e.g. before loading finishes.
+func (g *Game) charImage(c CharacterType) *ebiten.Image {
+	if int(c) >= 0 && int(c) < len(g.charImages) && g.charImages[c] != nil {
+		return g.charImages[c]
+	}
+
+	return assets.MissingTexture()
+}
+
+// monsterImage returns the sprite of monster type t, or the missing texture
+// when there is none.
+func (g *Game) monsterImage(t MonsterType) *ebiten.Image {
+	if img := g.monsterImages[t]; img != nil {
+		return img
+	}
+
+	return assets.MissingTexture()
+}
+
+// generatedSprite builds a procedural sprite for a definition with no image
+// file, seeded by its name so it stays stable.
+func generatedSprite(name string, palette *graphics.SpritePalette) *ebiten.Image {
+	return ebiten.NewImageFromImage(graphics.GenerateSprite(graphics.SeedFromName(name), graphics.SpriteOptions{
+		Palette: palette,
+	}))
+}
+
+// drawLoading renders the loading screen with a progress bar.
+func (g *Game) drawLoading(screen *ebiten.Image) {
+	screen.Fill(ui.CurrentTheme().Palette.Background)
+
+	barW, barH := float32(400), float32(20)
+	barX, barY := float32(screenWidth-400)/2, float32(screenHeight)/2
+
+	vector.FillRect(screen, barX, barY, barW, barH, color.RGBA{R: 50, G: 50, B: 60, A: 255}, false)
+	vector.FillRect(screen, barX, barY, barW*float32(g.loader.Progress()), barH,
+		color.RGBA{R: 100, G: 200, B: 255, A: 255}, false)
+	vector.StrokeRect(screen, barX, barY, barW, barH, 2, color.RGBA{R: 200, G: 200, B: 200, A: 255}, false)
+
+	ui.DebugPrintAt(screen, "Loading... "+formatInt(int(g.loader.Progress()*100))+"%",
+		int(barX)+150, int(barY)-25)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/assets"
+	"github.com/skyrocket-qy/NeuralWay/engine/graphics"
+)
+
+func TestBackgroundLoading(t *testing.T) {
+	g := &Game{
+		state:         StateLoading,
+		charImages:    make([]*ebiten.Image, len(Characters)),
+		monsterImages: make(map[MonsterType]*ebiten.Image),
+	}
+
+	g.startLoading()
+	g.loader.Wait()
+
+	if err := g.updateLoading(); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.state != StateCharSelect {
+		t.Fatalf("state = %v, want StateCharSelect after loading", g.state)
+	}
+
+	for i, img := range g.charImages {
+		if img == nil {
+			t.Errorf("character %d image not loaded", i)
+		}
+	}
+
+	for wt := range WeaponDefs {
+		if g.weaponImages[wt] == nil {
+			t.Errorf("weapon %d has no icon", wt)
+		}
+	}
+}
+
+func TestLoadingGeneratesSpritesWithoutImageFile(t *testing.T) {
+	def := MonsterDefs[MonsterBug]
+	file := def.ImageFile
+	def.ImageFile = ""
+
+	t.Cleanup(func() { def.ImageFile = file })
+
+	g := &Game{
+		state:         StateLoading,
+		charImages:    make([]*ebiten.Image, len(Characters)),
+		monsterImages: make(map[MonsterType]*ebiten.Image),
+	}
+
+	g.startLoading()
+	g.loader.Wait()
+	g.finishLoading()
+
+	img := g.monsterImages[MonsterBug]
+	if img == nil || img.Bounds().Dx() != graphics.DefaultSpriteSize {
+		t.Fatalf("monster without an image file got %v, want a generated %dpx sprite",
+			img, graphics.DefaultSpriteSize)
+	}
+}
+
+func TestMissingImageFileShowsMissingTexture(t *testing.T) {
+	def := MonsterDefs[MonsterBug]
+	file := def.ImageFile
+	def.ImageFile = "monsters/does-not-exist.png"
+
+	t.Cleanup(func() { def.ImageFile = file })
+
+	g := &Game{
+		state:         StateLoading,
+		charImages:    make([]*ebiten.Image, len(Characters)),
+		monsterImages: make(map[MonsterType]*ebiten.Image),
+	}
+
+	g.startLoading()
+	g.loader.Wait()
+	g.finishLoading()
+
+	if g.monsterImage(MonsterBug) != assets.MissingTexture() {
+		t.Error("a monster whose image file is missing should show the missing texture")
+	}
+
+	missing := g.loader.Placeholders.Missing()
+	if len(missing) != 1 || missing[0].Path != def.ImageFile {
+		t.Errorf("missing assets = %v, want only %s", missing, def.ImageFile)
+	}
+}
+
+func TestCharImageOutOfRange(t *testing.T) {
+	g := &Game{}
+
+	if g.charImage(CharJunior) != assets.MissingTexture() || g.charImage(CharacterType(len(Characters))) == nil {
+		t.Error("characters without a sprite should get the missing texture")
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Loot simulation sizes for the sandbox console's loot command.
+const (
+	lootSimKills    = 10_000
+	lootSimMaxKills = 1_000_000
+)
+
+// LootWeight is one rarity's relative share of a loot table's drops.
+type LootWeight struct {
+	Rarity Rarity
+	Weight float64
+}
+
+// LootTable describes the equipment a monster can drop on death.
+type LootTable struct {
+	Name     string
+	Chance   float64      // Chance per kill that an item drops at all
+	Rarities []LootWeight // Rarity of a dropped item, by relative weight
+	LevelMin int          // Item level range, relative to the player's level
+	LevelMax int
+	Pity     int // A Rare or better is guaranteed within this many kills; 0 disables
+}
+
+// Shared loot tables.
+var (
+	eliteLoot = &LootTable{
+		Name:     "Elite",
+		Chance:   0.05,
+		Rarities: []LootWeight{{RarityMagic, 80}, {RarityRare, 20}},
+		Pity:     150,
+	}
+	bossLoot = &LootTable{
+		Name:     "Boss",
+		Chance:   1,
+		Rarities: []LootWeight{{RarityRare, 70}, {RarityLegendary, 30}},
+		LevelMax: 2,
+	}
+)
+
+// LootTables maps monsters to the table rolled when they die. Monsters
+// without an entry drop no equipment.
+var LootTables = map[MonsterType]*LootTable{
+	MonsterSpaghetti:    eliteLoot,
+	MonsterLegacy:       eliteLoot,
+	MonsterRaceCond:     eliteLoot,
+	MonsterBossManager:  bossLoot,
+	MonsterBossDeadline: bossLoot,
+}
+
+// LootDrop is the item a successful roll produces.
+type LootDrop struct {
+	Rarity    Rarity
+	ItemLevel int
+}
+
+// Roll rolls the table for one kill using roll for randomness. pity counts
+// kills since the table last dropped a Rare or better and is updated; when
+// it reaches Pity the kill drops a Rare or better regardless of Chance.
+func (t *LootTable) Roll(roll func() float64, pity *int, playerLevel int) (LootDrop, bool) {
+	guaranteed := t.Pity > 0 && *pity+1 >= t.Pity
+	if !guaranteed && roll() >= t.Chance {
+		*pity++
+
+		return LootDrop{}, false
+	}
+
+	rarity := t.pickRarity(roll)
+	if guaranteed {
+		rarity = max(rarity, RarityRare)
+	}
+
+	if rarity >= RarityRare {
+		*pity = 0
+	} else {
+		*pity++
+	}
+
+	level := playerLevel + t.LevelMin + int(roll()*float64(t.LevelMax-t.LevelMin+1))
+
+	return LootDrop{Rarity: rarity, ItemLevel: max(level, 1)}, true
+}
+
+// pickRarity chooses a rarity by weight, defaulting to Common for an empty table.
+func (t *LootTable) pickRarity(roll func() float64) Rarity {
+	total := 0.0
+	for _, w := range t.Rarities {
+		total += w.Weight
+	}
+
+	r := roll() * total
+	for _, w := range t.Rarities {
+		if r < w.Weight {
+			return w.Rarity
+		}
+
+		r -= w.Weight
+	}
+
+	if len(t.Rarities) > 0 {
+		return t.Rarities[len(t.Rarities)-1].Rarity
+	}
+
+	return RarityCommon
+}
+
+// rollLoot rolls e's loot table and drops any item where e died.
+func (g *Game) rollLoot(e *Enemy) {
+	if t := LootTables[e.Type]; t != nil {
+		g.rollLootTable(t, MonsterDefs[e.Type].Name, e.X, e.Y)
+	}
+}
+
+// rollLootTable rolls t once and drops any item at (x, y), crediting source
+// in the drop notification.
+func (g *Game) rollLootTable(t *LootTable, source string, x, y float64) {
+	if g.lootPity == nil {
+		g.lootPity = make(map[*LootTable]int)
+	}
+
+	r := g.rng.Stream(streamLoot)
+	pity := g.lootPity[t]
+	drop, ok := t.Roll(r.Float64, &pity, g.player.Level)
+	g.lootPity[t] = pity
+
+	if !ok {
+		return
+	}
+
+	slot := EquipSlot(r.Intn(int(SlotCount)))
+	item := g.generateEquipment(slot, drop.ItemLevel, drop.Rarity)
+	g.dropItem(item, x, y)
+	g.notifyItemDrop(source, item)
+	g.discoverItem(item)
+}
+
+// lootHelp describes t for the help screen: the monsters that roll it and
+// its drop chance.
+func lootHelp(t *LootTable) string {
+	var names []string
+
+	for _, mt := range slices.Sorted(maps.Keys(LootTables)) {
+		if LootTables[mt] == t {
+			names = append(names, MonsterDefs[mt].Name)
+		}
+	}
+
+	return fmt.Sprintf("%s: %.0f%% drop chance", strings.Join(names, ", "), t.Chance*100)
+}
+
+// simulateLoot rolls t for n kills by a player of the given level and
+// reports the drop rate, rarity distribution, item levels, and the longest
+// run of kills without a Rare or better.
+func simulateLoot(t *LootTable, n, playerLevel int, seed int64) string {
+	rng := rand.New(rand.NewSource(seed))
+
+	var (
+		counts       [RarityLegendary + 1]int
+		drops        int
+		pity         int
+		dry, longest int
+	)
+
+	minLevel, maxLevel := 0, 0
+
+	for range n {
+		drop, ok := t.Roll(rng.Float64, &pity, playerLevel)
+		if ok && drop.Rarity >= RarityRare {
+			dry = 0
+		} else {
+			dry++
+			longest = max(longest, dry)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if drops == 0 {
+			minLevel, maxLevel = drop.ItemLevel, drop.ItemLevel
+		}
+
+		drops++
+		counts[drop.Rarity]++
+		minLevel, maxLevel = min(minLevel, drop.ItemLevel), max(maxLevel, drop.ItemLevel)
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s loot, %d kills: %d drops (%.1f%%)", t.Name, n, drops, percent(drops, n))
+
+	for r, c := range counts {
+		if c > 0 {
+			fmt.Fprintf(&b, "\n  %-9s %6d (%.1f%%)", RarityNames[Rarity(r)], c, percent(c, drops))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n  item level %d-%d, longest dry streak %d kills", minLevel, maxLevel, longest)
+
+	return b.String()
+}
+
+func percent(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+
+	return 100 * float64(part) / float64(whole)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLootPityGuaranteesRare(t *testing.T) {
+	unlucky := func() float64 { return 0.99 }
+	pity := 0
+
+	for kill := 1; kill < eliteLoot.Pity; kill++ {
+		if _, ok := eliteLoot.Roll(unlucky, &pity, 10); ok {
+			t.Fatalf("kill %d dropped with a failing roll", kill)
+		}
+	}
+
+	drop, ok := eliteLoot.Roll(unlucky, &pity, 10)
+	if !ok || drop.Rarity < RarityRare {
+		t.Fatalf("kill %d: drop = %+v, %v; want a guaranteed Rare", eliteLoot.Pity, drop, ok)
+	}
+
+	if pity != 0 {
+		t.Errorf("pity = %d after a Rare, want reset to 0", pity)
+	}
+}
+
+func TestLootRollWeightsAndLevels(t *testing.T) {
+	pity := 0
+	lowest := func() float64 { return 0 }
+
+	drop, ok := bossLoot.Roll(lowest, &pity, 7)
+	if !ok || drop.Rarity != RarityRare || drop.ItemLevel != 7 {
+		t.Errorf("lowest roll = %+v, %v; want Rare at level 7", drop, ok)
+	}
+
+	highest := func() float64 { return 0.999 }
+
+	drop, ok = bossLoot.Roll(highest, &pity, 7)
+	if !ok || drop.Rarity != RarityLegendary || drop.ItemLevel != 7+bossLoot.LevelMax {
+		t.Errorf("highest roll = %+v, %v; want Legendary at level %d", drop, ok, 7+bossLoot.LevelMax)
+	}
+}
+
+func TestLootHelpFollowsTheTable(t *testing.T) {
+	help := lootHelp(eliteLoot)
+
+	if want := fmt.Sprintf("%.0f%% drop chance", eliteLoot.Chance*100); !strings.HasSuffix(help, want) {
+		t.Errorf("help %q does not end with %q", help, want)
+	}
+
+	for mt, table := range LootTables {
+		if table == eliteLoot && !strings.Contains(help, MonsterDefs[mt].Name) {
+			t.Errorf("help %q omits %s", help, MonsterDefs[mt].Name)
+		}
+	}
+}
+
+func TestSimulateLootDistribution(t *testing.T) {
+	out := simulateLoot(eliteLoot, lootSimKills, 5, 1)
+
+	for _, want := range []string{"Elite loot, 10000 kills", "Magic", "Rare", "longest dry streak"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("simulation output missing %q:\n%s", want, out)
+		}
+	}
+
+	var longest int
+	if i := strings.LastIndex(out, "streak "); i >= 0 {
+		fmt.Sscanf(out[i:], "streak %d", &longest)
+	}
+
+	if longest == 0 || longest >= eliteLoot.Pity {
+		t.Errorf("longest dry streak = %d, want below the pity limit %d", longest, eliteLoot.Pity)
+	}
+}
+
+func TestBossKillDropsFromLootTable(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	boss := g.spawnMonster(MonsterBossManager, 100, 0)
+	g.killEnemy(boss)
+
+	if len(g.itemDrops) != 1 || g.itemDrops[0].Item.Rarity < RarityRare {
+		t.Fatalf("drops = %v, want one Rare or better boss drop", g.itemDrops)
+	}
+
+	if d := g.itemDrops[0]; d.X != boss.X || d.Y != boss.Y || len(g.player.Inventory) != 0 {
+		t.Errorf("drop at (%v, %v) should lie where the boss died until picked up", d.X, d.Y)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"embed"
 	"image"
 	"image/color"
@@ -10,12 +9,35 @@ import (
 	"log"
 	"math"
 	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
-	"github.com/skyrocket-qy/NeuralWay/engine/graphics"
+	"github.com/skyrocket-qy/NeuralWay/engine/assets"
+	"github.com/skyrocket-qy/NeuralWay/engine/chunks"
+	"github.com/skyrocket-qy/NeuralWay/engine/colorutil"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/combo"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/content"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/rng"
+	"github.com/skyrocket-qy/NeuralWay/engine/stats"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+	"github.com/skyrocket-qy/NeuralWay/engine/triggers"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/draft"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
 )
 
 //go:embed assets/*.png
@@ -24,6 +46,8 @@ var assetsFS embed.FS
 const (
 	screenWidth  = 900
 	screenHeight = 700
+
+	levelUpBoxH = 360 // Level-up panel height, including the token buttons
 )
 
 // CharacterType represents playable characters.
@@ -85,7 +109,7 @@ var Characters = []CharacterDef{
 		Speed:       3.5,
 		StartWeapon: WeaponGitPush,
 		Trait:       "Hyper",
-		TraitDesc:   "+50% Cooldown",
+		TraitDesc:   "+50% Cooldown, 5% Life Steal",
 		Color:       color.RGBA{R: 255, G: 100, B: 0, A: 255},
 		ImageFile:   "assets/hero_10x.png",
 	},
@@ -112,6 +136,7 @@ const (
 	WeaponCopilot
 	WeaponK8s
 	WeaponCI_CD
+	WeaponMemoryLeak
 )
 
 // Weapon definition.
@@ -121,9 +146,25 @@ type WeaponDef struct {
 	Cooldown  float64
 	Range     float64
 	Count     int
+	Duration  float64 // Seconds a projectile lives, before the player's DurationMult
 	Color     color.RGBA
 	IsEvolved bool
 	ImageFile string
+	// InstanceRule decides how a new cast interacts with live ones (default: stack)
+	InstanceRule InstanceRule
+	// OnDeath turns projectiles into explosions, splits, or zones when they die
+	OnDeath ProjectileDeathEffect
+	// Chain arcs each hit to nearby enemies
+	Chain systems.ChainConfig
+	// CritChance is added to the player's crit chance for this weapon's hits
+	CritChance float64
+	// DamageType decides how hits interact with stage surfaces and monster
+	// resistances; "" is physical
+	DamageType components.DamageType
+	// Area tags the weapon's hits as area damage for monster resistances
+	Area bool
+	// Ailment is inflicted by every hit that lands; "" for none
+	Ailment components.StatusType
 }
 
 var WeaponDefs = map[WeaponType]WeaponDef{
@@ -133,6 +174,7 @@ var WeaponDefs = map[WeaponType]WeaponDef{
 		Cooldown:  0.6,
 		Range:     90,
 		Count:     1,
+		Duration:  0.3,
 		Color:     color.RGBA{R: 200, G: 200, B: 200, A: 255},
 		ImageFile: "assets/weapon_print.png",
 	},
@@ -142,44 +184,56 @@ var WeaponDefs = map[WeaponType]WeaponDef{
 		Cooldown:  1.0,
 		Range:     110,
 		Count:     2,
+		Duration:  0.2,
 		Color:     color.RGBA{R: 100, G: 150, B: 255, A: 255},
 		ImageFile: "assets/weapon_refactor.png",
 	},
 	WeaponGitPush: {
-		Name:      "Git Push",
-		Damage:    12,
-		Cooldown:  0.5,
-		Range:     300,
-		Count:     1,
-		Color:     color.RGBA{R: 50, G: 200, B: 50, A: 255},
-		ImageFile: "assets/weapon_gitpush.png",
+		Name:       "Git Push",
+		Damage:     12,
+		Cooldown:   0.5,
+		Range:      300,
+		Count:      1,
+		Duration:   2.0,
+		Color:      color.RGBA{R: 50, G: 200, B: 50, A: 255},
+		ImageFile:  "assets/weapon_gitpush.png",
+		CritChance: 0.1,
 	},
 	WeaponCoffee: {
-		Name:      "Coffee",
-		Damage:    5,
-		Cooldown:  0.3,
-		Range:     60,
-		Count:     1,
-		Color:     color.RGBA{R: 100, G: 50, B: 0, A: 255},
-		ImageFile: "assets/weapon_coffee.png",
+		Name:         "Coffee",
+		Damage:       5,
+		Cooldown:     0.3,
+		Range:        60,
+		Count:        1,
+		Duration:     0.4,
+		Color:        color.RGBA{R: 100, G: 50, B: 0, A: 255},
+		ImageFile:    "assets/weapon_coffee.png",
+		InstanceRule: InstanceRefresh,
 	},
 	WeaponFirewall: {
-		Name:      "Firewall",
-		Damage:    25,
-		Cooldown:  1.2,
-		Range:     130,
-		Count:     1,
-		Color:     color.RGBA{R: 255, G: 100, B: 50, A: 255},
-		ImageFile: "assets/weapon_firewall.png",
+		Name:       "Firewall",
+		Damage:     25,
+		Cooldown:   1.2,
+		Range:      130,
+		Count:      1,
+		Duration:   0.5,
+		Color:      color.RGBA{R: 255, G: 100, B: 50, A: 255},
+		ImageFile:  "assets/weapon_firewall.png",
+		DamageType: components.DamageFire,
+		Area:       true,
+		Ailment:    components.StatusIgnite,
 	},
 	WeaponStackOverflow: {
-		Name:      "StackOverflow",
-		Damage:    40,
-		Cooldown:  1.5,
-		Range:     250,
-		Count:     2,
-		Color:     color.RGBA{R: 255, G: 200, B: 0, A: 255},
-		ImageFile: "assets/weapon_stackoverflow.png",
+		Name:       "StackOverflow",
+		Damage:     40,
+		Cooldown:   1.5,
+		Range:      250,
+		Count:      2,
+		Duration:   0.2,
+		Color:      color.RGBA{R: 255, G: 200, B: 0, A: 255},
+		ImageFile:  "assets/weapon_stackoverflow.png",
+		CritChance: 0.15,
+		DamageType: components.DamageLightning,
 	},
 	WeaponDocker: {
 		Name:      "Docker Container",
@@ -187,17 +241,23 @@ var WeaponDefs = map[WeaponType]WeaponDef{
 		Cooldown:  1.2,
 		Range:     100,
 		Count:     1,
+		Duration:  2.0,
 		Color:     color.RGBA{R: 0, G: 100, B: 255, A: 255},
 		ImageFile: "assets/weapon_docker.png",
+		OnDeath: ProjectileDeathEffect{
+			Kind: DeathZone, Trigger: TriggerExpire, Radius: 45, DamageMult: 0.2, Duration: 2,
+		},
 	},
 	WeaponUnitTests: {
-		Name:      "Unit Tests",
-		Damage:    8,
-		Cooldown:  0.8,
-		Range:     80,
-		Count:     1,
-		Color:     color.RGBA{R: 100, G: 255, B: 100, A: 255},
-		ImageFile: "assets/weapon_unittests.png",
+		Name:         "Unit Tests",
+		Damage:       8,
+		Cooldown:     0.8,
+		Range:        80,
+		Count:        1,
+		Duration:     0.2,
+		Color:        color.RGBA{R: 100, G: 255, B: 100, A: 255},
+		ImageFile:    "assets/weapon_unittests.png",
+		InstanceRule: InstanceRefresh,
 	},
 
 	// Evolved weapons (no image assets yet, will use programmatic fallback)
@@ -207,6 +267,7 @@ var WeaponDefs = map[WeaponType]WeaponDef{
 		Cooldown:  0.2,
 		Range:     150,
 		Count:     1,
+		Duration:  0.3,
 		Color:     color.RGBA{R: 255, G: 255, B: 255, A: 255},
 		IsEvolved: true,
 	},
@@ -216,6 +277,7 @@ var WeaponDefs = map[WeaponType]WeaponDef{
 		Cooldown:  0.8,
 		Range:     150,
 		Count:     4,
+		Duration:  0.2,
 		Color:     color.RGBA{R: 150, G: 200, B: 255, A: 255},
 		IsEvolved: true,
 	},
@@ -225,53 +287,87 @@ var WeaponDefs = map[WeaponType]WeaponDef{
 		Cooldown:  0.1,
 		Range:     500,
 		Count:     1,
+		Duration:  2.0,
 		Color:     color.RGBA{R: 0, G: 255, B: 0, A: 255},
 		IsEvolved: true,
+		OnDeath: ProjectileDeathEffect{
+			Kind: DeathExplode, Trigger: TriggerKill, Radius: 50, DamageMult: 0.6,
+		},
+		CritChance: 0.1,
 	},
 	WeaponEspresso: {
-		Name:      "Double Espresso",
-		Damage:    10,
-		Cooldown:  0.1,
-		Range:     100,
-		Count:     1,
-		Color:     color.RGBA{R: 150, G: 100, B: 50, A: 255},
-		IsEvolved: true,
+		Name:         "Double Espresso",
+		Damage:       10,
+		Cooldown:     0.1,
+		Range:        100,
+		Count:        1,
+		Duration:     0.4,
+		Color:        color.RGBA{R: 150, G: 100, B: 50, A: 255},
+		IsEvolved:    true,
+		InstanceRule: InstanceRefresh,
 	},
 	WeaponZeroTrust: {
-		Name:      "Zero Trust",
-		Damage:    50,
-		Cooldown:  1.0,
-		Range:     180,
-		Count:     1,
-		Color:     color.RGBA{R: 255, G: 50, B: 0, A: 255},
-		IsEvolved: true,
+		Name:       "Zero Trust",
+		Damage:     50,
+		Cooldown:   1.0,
+		Range:      180,
+		Count:      1,
+		Duration:   0.5,
+		Color:      color.RGBA{R: 255, G: 50, B: 0, A: 255},
+		IsEvolved:  true,
+		DamageType: components.DamageFire,
+		Area:       true,
+		Ailment:    components.StatusIgnite,
 	},
 	WeaponCopilot: {
-		Name:      "AI Copilot",
-		Damage:    60,
-		Cooldown:  1.0,
-		Range:     300,
-		Count:     6,
-		Color:     color.RGBA{R: 255, G: 255, B: 100, A: 255},
-		IsEvolved: true,
+		Name:       "AI Copilot",
+		Damage:     60,
+		Cooldown:   1.0,
+		Range:      300,
+		Count:      6,
+		Duration:   0.2,
+		Color:      color.RGBA{R: 255, G: 255, B: 100, A: 255},
+		IsEvolved:  true,
+		Chain:      systems.ChainConfig{Jumps: 3, Range: 160, Falloff: 0.7},
+		CritChance: 0.15,
+		DamageType: components.DamageLightning,
 	},
 	WeaponK8s: {
-		Name:      "Kubernetes",
-		Damage:    80,
-		Cooldown:  2.0,
-		Range:     300,
-		Count:     1,
-		Color:     color.RGBA{R: 50, G: 50, B: 255, A: 255},
-		IsEvolved: true,
+		Name:         "Kubernetes",
+		Damage:       80,
+		Cooldown:     2.0,
+		Range:        300,
+		Count:        1,
+		Duration:     2.0,
+		Color:        color.RGBA{R: 50, G: 50, B: 255, A: 255},
+		IsEvolved:    true,
+		InstanceRule: InstanceQueue,
+		OnDeath: ProjectileDeathEffect{
+			Kind: DeathSplit, Trigger: TriggerExpire, Radius: 7, DamageMult: 0.35,
+			Count: 6, Duration: 0.8, Speed: 6,
+		},
 	},
 	WeaponCI_CD: {
-		Name:      "CI/CD Pipeline",
-		Damage:    12,
-		Cooldown:  0.5,
-		Range:     120,
-		Count:     1,
-		Color:     color.RGBA{R: 100, G: 255, B: 255, A: 255},
-		IsEvolved: true,
+		Name:         "CI/CD Pipeline",
+		Damage:       12,
+		Cooldown:     0.5,
+		Range:        120,
+		Count:        1,
+		Duration:     0.2,
+		Color:        color.RGBA{R: 100, G: 255, B: 255, A: 255},
+		IsEvolved:    true,
+		InstanceRule: InstanceRefresh,
+	},
+	WeaponMemoryLeak: {
+		Name:       "Memory Leak",
+		Damage:     6,
+		Cooldown:   1.0,
+		Range:      220,
+		Count:      1,
+		Duration:   1.5,
+		Color:      color.RGBA{R: 140, G: 220, B: 60, A: 255},
+		DamageType: components.DamageChaos,
+		Ailment:    components.StatusPoison,
 	},
 }
 
@@ -317,6 +413,14 @@ type MonsterDef struct {
 	Color     color.RGBA
 	IsBoss    bool
 	ImageFile string
+	// ArmorPen is the fraction of the player's armor this monster ignores
+	ArmorPen float64
+	// Sounds played on spawn, hit, and death, and looped while nearby
+	Sounds assets.SoundEmitter
+	// Hits with an immune tag deal nothing; resisted tags deal resistMult
+	Immune, Resists DamageTag
+	// Inflicts is the ailment its landed hits give the player; "" for none
+	Inflicts components.StatusType
 }
 
 // Monster definitions.
@@ -330,6 +434,7 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Radius:    10,
 		Color:     color.RGBA{100, 100, 100, 255},
 		ImageFile: "assets/monster_bug.png",
+		Sounds:    assets.SoundEmitter{Hit: "hit", Death: "splat"},
 	}, // Bat
 	MonsterNull: {
 		Name:      "Null Pointer",
@@ -340,6 +445,7 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Radius:    12,
 		Color:     color.RGBA{200, 200, 200, 255},
 		ImageFile: "assets/monster_null.png",
+		Sounds:    assets.SoundEmitter{Hit: "hit", Death: "splat"},
 	}, // Skeleton
 	MonsterSpaghetti: {
 		Name:      "Spaghetti Code",
@@ -350,6 +456,8 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Radius:    14,
 		Color:     color.RGBA{50, 150, 50, 255},
 		ImageFile: "assets/monster_spaghetti.png",
+		Sounds:    assets.SoundEmitter{Hit: "hit", Death: "splat"},
+		Inflicts:  components.StatusChill,
 	}, // Zombie
 	MonsterDowntime: {
 		Name:      "Downtime",
@@ -360,6 +468,8 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Radius:    10,
 		Color:     color.RGBA{200, 200, 255, 150},
 		ImageFile: "assets/monster_downtime.png",
+		Sounds:    assets.SoundEmitter{Hit: "hit", Death: "splat", Ambient: "hum", Volume: 0.6},
+		Resists:   TagPhysical,
 	}, // Ghost
 	MonsterLegacy: {
 		Name:      "Legacy Code",
@@ -370,6 +480,10 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Radius:    20,
 		Color:     color.RGBA{200, 50, 50, 255},
 		ImageFile: "assets/monster_legacy.png",
+		ArmorPen:  0.15,
+		Sounds:    assets.SoundEmitter{Hit: "hit", Death: "blast"},
+		Resists:   TagFire,
+		Inflicts:  components.StatusFreeze,
 	}, // Demon
 	MonsterRaceCond: {
 		Name:      "Race Condition",
@@ -380,6 +494,8 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Radius:    15,
 		Color:     color.RGBA{50, 50, 200, 255},
 		ImageFile: "assets/monster_race.png",
+		Sounds:    assets.SoundEmitter{Hit: "hit", Death: "splat"},
+		Resists:   TagArea,
 	}, // Elemental
 
 	// Bosses
@@ -393,6 +509,8 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Color:     color.RGBA{50, 0, 50, 255},
 		IsBoss:    true,
 		ImageFile: "assets/monster_manager.png",
+		ArmorPen:  0.25,
+		Sounds:    assets.SoundEmitter{Spawn: "roar", Hit: "hit", Death: "blast", Ambient: "drone"},
 	}, // Boss CharJunior
 	MonsterBossDeadline: {
 		Name:      "Hard Deadline",
@@ -404,6 +522,8 @@ var MonsterDefs = map[MonsterType]*MonsterDef{
 		Color:     color.RGBA{200, 100, 0, 255},
 		IsBoss:    true,
 		ImageFile: "assets/monster_deadline.png",
+		ArmorPen:  0.5,
+		Sounds:    assets.SoundEmitter{Spawn: "roar", Hit: "hit", Death: "blast", Ambient: "drone"},
 	}, // Boss Dragon
 }
 
@@ -423,6 +543,7 @@ const (
 	PassiveDuration
 	PassiveAmount
 	PassiveRevival
+	PassiveShield
 )
 
 type PassiveDef struct {
@@ -465,6 +586,7 @@ var PassiveDefs = map[PassiveType]PassiveDef{
 	PassiveDuration: {Name: "Duration", Desc: "+10% duration", MaxLvl: 5},
 	PassiveAmount:   {Name: "Amount", Desc: "+1 projectile", MaxLvl: 3},
 	PassiveRevival:  {Name: "Revival", Desc: "Revive once", MaxLvl: 1},
+	PassiveShield:   {Name: "Energy Shield", Desc: "+20 shield that recharges", MaxLvl: 5},
 }
 
 // ============================================================================
@@ -493,6 +615,26 @@ var EquipSlotNames = map[EquipSlot]string{
 	SlotCoffeeMug:  "Coffee Mug",
 }
 
+// EquipmentBases are the item bases each slot can drop.
+var EquipmentBases = map[EquipSlot][]string{
+	SlotKeyboard:   {"Mechanical Keyboard", "Cherry MX Board", "Ergonomic Keyboard", "Gaming Keyboard"},
+	SlotMonitor:    {"4K Monitor", "Ultrawide Display", "Gaming Monitor", "Dual Screen"},
+	SlotChair:      {"Herman Miller", "Gaming Chair", "Ergonomic Seat", "Standing Desk"},
+	SlotMouse:      {"Wireless Mouse", "Gaming Mouse", "Trackball", "Precision Mouse"},
+	SlotHeadphones: {"Noise Cancelling", "Open Back Cans", "Gaming Headset", "AirPods Pro"},
+	SlotCoffeeMug:  {"Yeti Tumbler", "Pour Over Set", "Espresso Cup", "Thermos"},
+}
+
+// SlotMods are the modifiers items in each slot roll from.
+var SlotMods = map[EquipSlot][]ModType{
+	SlotKeyboard:   {ModFlatDamage, ModPercentDamage, ModCooldown, ModLifesteal},
+	SlotMonitor:    {ModFlatHP, ModPercentHP, ModXPGain, ModShield},
+	SlotChair:      {ModArmor, ModRecovery, ModFlatHP, ModThorns, ModShield},
+	SlotMouse:      {ModCritChance, ModArea, ModPercentDamage, ModCritMultiplier},
+	SlotHeadphones: {ModCooldown, ModDuration, ModArea},
+	SlotCoffeeMug:  {ModSpeed, ModMagnet, ModRecovery},
+}
+
 // Rarity represents item rarity tiers.
 type Rarity int
 
@@ -510,11 +652,9 @@ var RarityNames = map[Rarity]string{
 	RarityLegendary: "Legendary",
 }
 
-var RarityColors = map[Rarity]color.RGBA{
-	RarityCommon:    {200, 200, 200, 255}, // White
-	RarityMagic:     {100, 150, 255, 255}, // Blue
-	RarityRare:      {255, 255, 100, 255}, // Yellow
-	RarityLegendary: {255, 150, 50, 255},  // Orange
+// RarityColor returns the active theme's color for a rarity.
+func RarityColor(r Rarity) color.RGBA {
+	return ui.CurrentTheme().Palette.RarityColor(int(r))
 }
 
 // ModType represents modifier types.
@@ -528,6 +668,7 @@ const (
 	ModArmor
 	ModSpeed
 	ModCritChance
+	ModCritMultiplier
 	ModCooldown
 	ModArea
 	ModDuration
@@ -535,23 +676,32 @@ const (
 	ModXPGain
 	ModRecovery
 	ModProjectiles
+	ModLifesteal
+	ModThorns
+	ModForkLightning // Legendary special
+	ModShield
 )
 
 var ModTypeNames = map[ModType]string{
-	ModFlatDamage:    "+# Damage",
-	ModPercentDamage: "+#% Damage",
-	ModFlatHP:        "+# Max HP",
-	ModPercentHP:     "+#% Max HP",
-	ModArmor:         "+# Armor",
-	ModSpeed:         "+#% Movement Speed",
-	ModCritChance:    "+#% Critical Chance",
-	ModCooldown:      "-#% Cooldown",
-	ModArea:          "+#% Area of Effect",
-	ModDuration:      "+#% Duration",
-	ModMagnet:        "+#% Pickup Range",
-	ModXPGain:        "+#% XP Gain",
-	ModRecovery:      "+# HP/s Recovery",
-	ModProjectiles:   "+# Projectiles",
+	ModFlatDamage:     "+# Damage",
+	ModPercentDamage:  "+#% Damage",
+	ModFlatHP:         "+# Max HP",
+	ModPercentHP:      "+#% Max HP",
+	ModArmor:          "+# Armor",
+	ModSpeed:          "+#% Movement Speed",
+	ModCritChance:     "+#% Critical Chance",
+	ModCritMultiplier: "+#% Critical Damage",
+	ModCooldown:       "-#% Cooldown",
+	ModArea:           "+#% Area of Effect",
+	ModDuration:       "+#% Duration",
+	ModMagnet:         "+#% Pickup Range",
+	ModXPGain:         "+#% XP Gain",
+	ModRecovery:       "+# HP/s Recovery",
+	ModProjectiles:    "+# Projectiles",
+	ModLifesteal:      "+#% Life Steal",
+	ModThorns:         "+#% Thorns",
+	ModForkLightning:  "+# Forked Lightning Arcs",
+	ModShield:         "+# Energy Shield",
 }
 
 // Modifier represents a single stat modifier on equipment.
@@ -565,9 +715,11 @@ type Modifier struct {
 type Equipment struct {
 	Slot      EquipSlot
 	Name      string
+	Base      string // Entry of EquipmentBases the item was made from
 	Rarity    Rarity
 	Modifiers []Modifier
 	ItemLevel int
+	Junk      bool // Marked for salvage on the equipment screen
 }
 
 // ============================================================================
@@ -614,6 +766,8 @@ type Projectile struct {
 	HitList    map[*Enemy]bool
 	Color      color.RGBA
 	WeaponType WeaponType
+	Child      bool // Spawned by a death effect; never triggers one itself
+	Combo      bool // Fired by a combo attack, not a cast of WeaponType
 }
 
 // Enemy instance.
@@ -629,6 +783,34 @@ type Enemy struct {
 	HitFlash  float64
 	Color     color.RGBA
 	IsBoss    bool
+
+	AttackTimer float64 // Seconds until the next telegraphed attack
+	Windup      float64 // Seconds until the current telegraphed attack lands
+
+	// Bosses
+	Age         float64 // Seconds since spawn
+	Enraged     bool
+	Pattern     *patternRunner // Attack timeline; nil for bosses without one
+	Charge      *bossCharge    // Charge step in progress
+	Phase       int            // Phases of its BossBehavior entered
+	SummonTimer float64        // Seconds until it next calls in adds
+	Summoner    *Enemy         // Boss that called this add in
+
+	// Spawn events
+	Elite          bool
+	SweepX, SweepY float64 // Fixed heading for wall enemies (ignore the player)
+	Lifetime       float64 // Seconds until a sweeping enemy despawns
+
+	// World events; non-nil for a mega-elite
+	MegaElite *MegaEliteDef
+
+	// Dummy marks the sandbox target dummy, which never moves or attacks
+	Dummy bool
+
+	// Burns, poison, slows, and stuns
+	Ailments components.StatusComponent
+
+	resistCueAt float64 // Game time the next IMMUNE/RESIST cue may show
 }
 
 // XP Gem.
@@ -655,6 +837,7 @@ type DamageNumber struct {
 	Value  int
 	Timer  float64
 	Crit   bool
+	Text   string // Shown instead of the value, e.g. "IMMUNE"
 }
 
 // Player state.
@@ -668,24 +851,68 @@ type Player struct {
 	Weapons      []*Weapon
 	Passives     map[PassiveType]int
 	DamageMult   float64
+	FlatDamage   int // Added to every weapon's base damage; see DamageCalc
 	AreaMult     float64
+	DurationMult float64 // Scales projectile lifetimes
 	CooldownMult float64
 	MagnetRange  float64
 	Recovery     float64
 	CritChance   float64
 	XPMult       float64
-	Armor        int
-	HasRevival   bool
-	UsedRevival  bool
-	HitTimer     float64
+	// Crit damage multiplier, and hits that will crit regardless of chance
+	CritMultiplier  float64
+	GuaranteedCrits int
+	Armor           int
+	HasRevival      bool
+	UsedRevival     bool
+	HitTimer        float64
+	ForkCount       int     // Forked Lightning arcs from legendary gear
+	Lifesteal       float64 // Fraction of damage dealt healed
+	Thorns          float64 // Fraction of contact damage reflected
+	Procs           []OnHitProc
+
+	// Projectiles from gear and the tree, on top of the Amount passive
+	BonusProjectiles int
+
+	// Energy shield, spent before HP and recharged after ShieldDelay
+	Shield, MaxShield float64
+	ShieldDelay       float64
+
+	lifestealPool float64 // Fractional heal carried between hits
 
 	// Equipment system
 	Equipment map[EquipSlot]*Equipment
-	Inventory []*Equipment // Unequipped items
+	Inventory []*Equipment // Unequipped items, at most inventoryLimit
+	Scrap     int          // Crafting currency from salvaged items
 
 	// Passive tree system
 	PassivePoints  int
 	AllocatedNodes map[int]bool // Node IDs that are allocated
+	Respecs        int          // Nodes refunded this run; each costs more gold
+
+	// Run currency and level-up reroll/banish/skip usage
+	Gold   int
+	Tokens LevelUpTokens
+
+	// Level-ups earned but not yet chosen, offered back to back
+	PendingLevels int
+
+	// Active abilities and last movement direction (dash heading)
+	Abilities components.Abilities
+	FaceX     float64
+	FaceY     float64
+
+	// Dodge stamina
+	Stamina      float64
+	MaxStamina   float64
+	StaminaDelay float64 // Seconds until regen resumes
+	DodgeTimer   float64 // Seconds of roll remaining
+
+	// Name of the mutation drafted at the start of the run, if any
+	Mutation string
+
+	// Burns, poison, slows, and stuns
+	Ailments components.StatusComponent
 }
 
 // GameState enum.
@@ -700,6 +927,15 @@ const (
 	StateEquipment   // Equipment inventory screen
 	StatePassiveTree // Passive skill tree screen
 	StateHelp        // Help/controls screen
+	StateLoading     // Background asset loading screen
+	StateCompendium  // Compendium of discovered content
+	StateBossEditor  // Dev-only boss pattern editor
+	StateSaveMenu    // Save slots, from the pause menu
+	StateLoadMenu    // Load slots, from character select
+	StateDraft       // Starting mutation draft, before play begins
+	StateStats       // Character sheet of computed stats
+	StateCrafting    // Spend scrap on an item's modifiers, from the equipment screen
+	StateCoinShop    // Spend banked gold on meta upgrades, from character select
 )
 
 // Game main struct.
@@ -713,8 +949,11 @@ type Game struct {
 	particles     []*Particle // New visual effects
 	charImages    []*ebiten.Image
 	monsterImages map[MonsterType]*ebiten.Image
+	loader        *assets.AsyncLoader
+	skin          *survivorSkin
 	weaponImages  map[WeaponType]*ebiten.Image
 	passiveImages map[PassiveType]*ebiten.Image
+	statusIcons   *assets.TextureAtlas // Packed on first draw
 
 	unusedProjs []*Projectile
 	unusedParts []*Particle
@@ -725,24 +964,184 @@ type Game struct {
 	spawnTimer   float64
 	bossTimer    float64
 	killCount    int
+	spawnedXP    int // XP carried by every enemy spawned this run
 	selectedChar int
 
 	upgradeOptions []UpgradeOption
+	levelUpFocus   int            // Option shown in the weapon preview
+	levelUpCursor  image.Point    // Last cursor position on the level-up screen
+	preview        *weaponPreview // Test-fire sim of the focused weapon option
+	levelBurst     int            // Levels from the last multi-level pickup
+	levelBurstAt   float64        // Seconds left showing levelBurst
 	// Audio
 	audio         *AudioPlayer
 	hitAudioTimer float64
-	helpSelection int // 0: SFX, 1: Music
+	ambience      assets.Ambience
+	combo         *combo.Meter
+	helpSelection int // 0: SFX, 1: Music, 2: Theme
+	helpPage      int // helpPageSettings or helpPageGuide
+
+	comboTimers map[ComboAttack]float64 // Seconds since each combo attack fired
 
 	cameraX, cameraY float64
 	grid             map[GridKey][]*Enemy
 
-	// Passive tree
+	// Passive tree and its screen's pan and zoom
 	passiveTree []*PassiveNode
+	treeView    treeView
 
 	// Equipment UI state
 	selectedSlot     EquipSlot
 	selectedInvIndex int
-	itemDrops        []*Equipment // Dropped items in world
+	itemDrops        []*ItemDrop // Equipment lying on the ground
+	invFullWarned    bool        // Warned of a full inventory since room was last made
+
+	// Crafting screen: the item, its selected modifier, and the last outcome
+	craftItem *Equipment
+	craftMod  int
+	craftMsg  string
+
+	// Notifications
+	bus       *events.Bus
+	toasts    *ui.ToastQueue
+	combatLog *combatlog.Log
+	damageDir *ui.DamageIndicator // Edge arcs toward whatever last hit the player
+
+	// Streamed world props (crates, chests, hazards)
+	world     *chunks.Store[ChunkState]
+	worldSeed int64
+	stage     int // Index into Stages
+
+	// Decoy position of the active taunt ability
+	tauntX, tauntY float64
+
+	// Pending enemy strikes and perfect dodges this run
+	telegraphs    []*Telegraph
+	perfectDodges int
+
+	// Boss pattern shots, exported patterns, and the dev-only pattern editor
+	bossShots    []*BossShot
+	patternStore paths.FS
+	dev          bool
+	bossEditor   *bossEditor
+
+	// Run save slots and the save/load menu state
+	runSaves   *game.SaveManager // Nil when there is nowhere to keep runs
+	runSlots   []runSlotInfo
+	runSlotSel int
+	runSlotMsg string // Result of the last save, load, or delete
+
+	// Framework tokens shared by every example, and the shop that spends them
+	profile   *profile.Profile
+	tokenShop *profile.Shop // Open over the title screen when non-nil
+
+	// Currency drops and the meta-progression shop they feed
+	coins       []*Coin
+	meta        MetaShop
+	metaBonus   MetaBonuses
+	coinShop    *CoinShop // The meta shop's screen state; nil without one
+	coinShopSel int
+
+	// Animated HUD bars
+	hpBar, xpBar *game.HUDBar
+	xpBarLevel   int
+
+	// Scripted spawn director events
+	spawnEvents []*spawnEventState
+
+	// Rare world events rolled from the run seed
+	worldEvent    *worldEventState
+	worldEventRng *rand.Rand
+
+	// Seed, character, stage, and challenge modifiers of the current run, the
+	// modifiers chosen for the next one, and the seed code being typed
+	run       RunConfig
+	runMods   RunMod
+	seedEntry *ui.TextInput
+	spawnRng  *rand.Rand // Ambient spawns, seeded from the run
+	rng       *rng.Rand  // Gameplay random streams, seeded from the run
+
+	// Lingering areas left by projectile death effects
+	zones []*DamageZone
+
+	// Chain and fork lightning segments
+	arcs []*LightningArc
+
+	// Screen-wide health bar for the current boss
+	bossBar *ui.BossBar
+
+	// Hides the upcoming wave preview under the top bar
+	hideWavePreview bool
+
+	// Persisted preferences; runAssisted records assist use this run and
+	// runGameSpeed the slowest game speed played at
+	settings      Settings
+	settingsStore *game.SaveManager
+	runAssisted   bool
+	runGameSpeed  float64
+
+	// Key rebinding screen over the help screen, and where bindings are kept
+	rebinder     *ui.Rebinder
+	controlStore paths.FS
+
+	// Starting mutation draft and the mutations it offers
+	mutationDraft *draft.Screen
+	mutationOffer []Mutation
+
+	// Pause menu, rebuilt each time the game pauses
+	pauseMenu *ui.List
+
+	// Tick rate last set for the game speed option
+	tps int
+
+	// Controller rumble, and whether the run was live on the last tick
+	rumble     *input.Rumble
+	rumbleLive bool
+
+	// Frame time probe for the graphics quality suggestion; now replaces
+	// time.Now in tests
+	probe frameProbe
+	now   func() time.Time
+
+	// Latched movement for the toggle-to-move assist, and whether the player
+	// was told X now holds position now that C opens the character sheet
+	moveLatchX, moveLatchY float64
+	holdKeyNoted           bool
+
+	// Title screen demo runs steer themselves with autopilotMove
+	autopilot bool
+
+	// Lifetime stats across sessions and the achievements they unlock
+	lifetime     *stats.Store
+	achievements components.AchievementTracker
+	triggers     *triggers.Tracker // Advances the achievements with a Trigger rule
+
+	// HP lost this run, for no-hit achievements
+	runDamageTaken int
+
+	// Companion pet for this run, nil without one
+	pet *Pet
+
+	// Kills since each loot table last dropped a Rare or better
+	lootPity map[*LootTable]int
+
+	// Objects refused or merged by the memory budgets this run, and the F10 readout
+	culled      Budgets
+	showBudgets bool
+
+	// Training arena tools, nil outside the sandbox
+	sandbox *Sandbox
+
+	// Code of the last build exported at game over, kept across runs for the
+	// sandbox to load, whether it is this run's, and the store it is saved to
+	buildCode   string
+	buildShared bool
+	buildStore  paths.FS
+
+	// Discovered content and the compendium screen's tab and selected entry
+	compendium    *Compendium
+	compendiumTab CompendiumTab
+	compendiumSel int
 }
 
 type GridKey struct {
@@ -763,67 +1162,108 @@ type UpgradeOption struct {
 // NewGame creates new game.
 func NewGame() *Game {
 	g := &Game{
-		state:         StateCharSelect,
+		state:         StateLoading,
 		selectedChar:  0,
 		charImages:    make([]*ebiten.Image, len(Characters)),
 		monsterImages: make(map[MonsterType]*ebiten.Image),
 	}
 
-	// Load character images
-	for i, char := range Characters {
-		data, err := assetsFS.ReadFile(char.ImageFile)
-		if err != nil {
-			log.Printf("Warning: could not load %s: %v", char.ImageFile, err)
+	// Decode and chroma-key images in the background; StateLoading uploads them
+	g.startLoading()
+
+	g.settingsStore = settingsManager()
+	g.settings = loadSettings(g.settingsStore)
+	g.initRumble()
+	g.controlStore = openControls()
+	g.initLifetime(openLifetimeStats())
+	g.compendium = loadCompendium(compendiumManager())
+	g.patternStore = bossPatternStore()
+	g.buildStore = buildCodeStore()
+	loadPassiveTree(g.patternStore)
+	g.runSaves = runSaveManager()
+	g.coinShop = loadCoinShop(coinShopManager())
+	g.meta = g.coinShop
+	g.profile = profile.Open()
+	g.applyPalette()
+	g.applyTheme()
 
-			continue
-		}
-
-		img, _, err := image.Decode(bytes.NewReader(data))
-		if err != nil {
-			log.Printf("Warning: could not decode %s: %v", char.ImageFile, err)
+	// Audio
+	g.audio = NewAudioPlayer()
+	g.audio.GenerateSounds()
+	g.audio.PlayBGM()
 
-			continue
-		}
+	return g
+}
 
-		img = graphics.RemoveBackground(img)
-		g.charImages[i] = ebiten.NewImageFromImage(img)
+// Reset clears per-run state for a soft restart. Loaded images, audio,
+// settings, and the object pools are kept; live projectiles, particles, and
+// damage numbers go back to their pools and run slices keep their capacity.
+func (g *Game) Reset() {
+	for _, p := range g.projectiles {
+		g.freeProjectile(p)
 	}
 
-	// Load monster images
-	for t, def := range MonsterDefs {
-		if def.ImageFile == "" {
-			continue
-		}
+	for _, p := range g.particles {
+		g.freeParticle(p)
+	}
 
-		data, err := assetsFS.ReadFile(def.ImageFile)
-		if err != nil {
-			log.Printf("Warning: could not load %s: %v", def.ImageFile, err)
+	for _, d := range g.damageNumbers {
+		g.freeDamageNumber(d)
+	}
 
-			continue
-		}
+	g.enemies = truncate(g.enemies)
+	g.projectiles = truncate(g.projectiles)
+	g.particles = truncate(g.particles)
+	g.damageNumbers = truncate(g.damageNumbers)
+	g.xpGems = truncate(g.xpGems)
+	g.itemDrops = truncate(g.itemDrops)
+	g.invFullWarned = false
+	g.zones = truncate(g.zones)
+	g.arcs = truncate(g.arcs)
+	g.telegraphs = truncate(g.telegraphs)
+	g.bossShots = truncate(g.bossShots)
+	g.coins = truncate(g.coins)
+	clear(g.grid)
 
-		img, _, err := image.Decode(bytes.NewReader(data))
-		if err != nil {
-			log.Printf("Warning: could not decode %s: %v", def.ImageFile, err)
+	g.gameTime = 0
+	g.spawnTimer = 0
+	g.bossTimer = 0
+	g.hitAudioTimer = 0
+	g.killCount = 0
+	g.runDamageTaken = 0
+	g.buildShared = false
+	g.spawnedXP = 0
+	g.perfectDodges = 0
+	g.moveLatchX, g.moveLatchY = 0, 0
+	g.bossBar = nil
+	g.combo = combo.NewMeter(comboConfig)
+	g.comboTimers = make(map[ComboAttack]float64)
+	g.worldEvent = nil
+	g.pet = nil
+	g.culled = Budgets{}
+	clear(g.lootPity)
+}
 
-			continue
-		}
+// truncate empties s for reuse, dropping references so the old run can be collected.
+func truncate[T any](s []T) []T {
+	clear(s)
 
-		img = graphics.RemoveBackground(img)
-		g.monsterImages[t] = ebiten.NewImageFromImage(img)
-	}
+	return s[:0]
+}
 
-	g.generateIcons()
+// startGame starts a run with a fresh seed and the chosen challenge modifiers.
+func (g *Game) startGame(charType CharacterType) {
+	g.startRun(g.newRun(charType))
+}
 
-	// Audio
-	g.audio = NewAudioPlayer()
-	g.audio.GenerateSounds()
-	g.audio.PlayBGM()
+// startRun starts a run from a config, e.g. one decoded from a seed code.
+func (g *Game) startRun(cfg RunConfig) {
+	g.Reset()
 
-	return g
-}
+	g.run = cfg
+	g.stage = cfg.Stage
+	charType := cfg.Char
 
-func (g *Game) startGame(charType CharacterType) {
 	charDef := Characters[charType]
 	g.player = &Player{
 		X: 0, Y: 0,
@@ -837,13 +1277,19 @@ func (g *Game) startGame(charType CharacterType) {
 		Passives:       make(map[PassiveType]int),
 		DamageMult:     1.0,
 		AreaMult:       1.0,
+		DurationMult:   1.0,
 		CooldownMult:   1.0,
 		MagnetRange:    80,
 		XPMult:         1.0,
+		CritMultiplier: baseCritMultiplier,
 		Equipment:      make(map[EquipSlot]*Equipment),
 		Inventory:      make([]*Equipment, 0),
 		PassivePoints:  0,
 		AllocatedNodes: make(map[int]bool),
+		Tokens:         NewLevelUpTokens(),
+		Abilities:      newPlayerAbilities(charType),
+		Stamina:        baseMaxStamina,
+		MaxStamina:     baseMaxStamina,
 	}
 
 	// Apply character traits
@@ -853,26 +1299,39 @@ func (g *Game) startGame(charType CharacterType) {
 	case CharTechLead:
 		// Handled in projectile speed
 	case Char10x:
-		// Lifesteal handled in damage
+		g.player.Lifesteal = char10xLifesteal
 	}
 
-	g.enemies = make([]*Enemy, 0)
-	g.projectiles = make([]*Projectile, 0)
-	g.xpGems = make([]*XPGem, 0)
-	g.damageNumbers = make([]*DamageNumber, 0)
-	g.itemDrops = make([]*Equipment, 0)
-	g.gameTime = 0
-	g.spawnTimer = 0
-	g.bossTimer = 0
-	g.killCount = 0
+	g.applyRunMods()
+	g.player.HP = g.player.MaxHP
+
+	g.runAssisted = g.settings.AssistsEnabled()
+	g.runGameSpeed = g.settings.gameSpeed()
 	g.state = StatePlaying
+	g.initNotifications()
+	g.initWorld()
+	g.initWorldEvents()
+	g.initSpawnRng()
+	g.applyMetaBonuses()
+	g.initBars()
+	g.initSpawnEvents()
+	g.recordRunStart()
+	g.spawnPet()
+	g.discoverLoadout()
 
 	// Initialize passive tree
 	g.initPassiveTree()
 }
 
 func (g *Game) Update() error {
+	g.syncGameSpeed()
+	g.updateAmbience()
+	g.audio.SetDucked(g.paused())
+	g.updateRumble()
+
 	switch g.state {
+	case StateLoading:
+		return g.updateLoading()
 	case StateCharSelect:
 		return g.updateCharSelect()
 	case StatePlaying:
@@ -889,13 +1348,38 @@ func (g *Game) Update() error {
 		return g.updatePassiveTree()
 	case StateHelp:
 		return g.updateHelp()
+	case StateCompendium:
+		return g.updateCompendium()
+	case StateBossEditor:
+		return g.updateBossEditor()
+	case StateSaveMenu:
+		return g.updateSaveMenu()
+	case StateLoadMenu:
+		return g.updateLoadMenu()
+	case StateDraft:
+		return g.updateMutationDraft()
+	case StateStats:
+		return g.updateStats()
+	case StateCrafting:
+		return g.updateCrafting()
+	case StateCoinShop:
+		return g.updateCoinShop()
 	}
 
 	return nil
 }
 
 func (g *Game) updateCharSelect() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyA) {
+	if g.tokenShop != nil {
+		return g.tokenShop.Update()
+	}
+
+	if g.updateRunSetup() {
+		return nil
+	}
+
+	step := controls.JustMoved(input.MoveX)
+	if step < 0 {
 		g.selectedChar--
 		if g.selectedChar < 0 {
 			g.selectedChar = len(Characters) - 1
@@ -904,7 +1388,7 @@ func (g *Game) updateCharSelect() error {
 		g.audio.PlaySound("select")
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyD) {
+	if step > 0 {
 		g.selectedChar++
 		if g.selectedChar >= len(Characters) {
 			g.selectedChar = 0
@@ -913,19 +1397,69 @@ func (g *Game) updateCharSelect() error {
 		g.audio.PlaySound("select")
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		g.startGame(CharacterType(g.selectedChar))
+	if step := controls.JustMoved(input.MoveY); step != 0 {
+		g.cyclePet(step)
+		g.audio.PlaySound("select")
+	}
+
+	if controls.JustPressed(input.Confirm) {
+		g.sandbox = nil
+		g.draftRun(g.newRun(CharacterType(g.selectedChar)))
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.startSandbox(CharacterType(g.selectedChar))
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		s := g.settings
+		s.LowMemory = !s.LowMemory
+		g.setSettings(s)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.openCompendium()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.openLoadMenu()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+		g.openTokenShop()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		g.openCoinShop()
+	}
+
+	if g.dev && inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		g.openBossEditor()
 	}
 
 	return nil
 }
 
 func (g *Game) updatePlaying() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	// The sandbox console takes the keyboard while open
+	if g.sandbox != nil && g.sandbox.updateConsole() {
+		return nil
+	}
+
+	if controls.JustPressed(input.Pause) {
 		g.state = StatePaused
+		g.pauseMenu = nil
 
 		return nil
 	}
+	// Auto-equip the best gear (B key)
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+		g.autoEquipWithToast()
+	}
+	// Memory budget readout (F10 key)
+	if inpututil.IsKeyJustPressed(ebiten.KeyF10) {
+		g.showBudgets = !g.showBudgets
+	}
 	// Equipment screen (I key)
 	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
 		g.state = StateEquipment
@@ -944,11 +1478,31 @@ func (g *Game) updatePlaying() error {
 
 		return nil
 	}
+	// Character sheet (C key)
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.noteHoldKey()
+		g.state = StateStats
+
+		return nil
+	}
+	// Wave preview (V key)
+	if inpututil.IsKeyJustPressed(ebiten.KeyV) {
+		g.hideWavePreview = !g.hideWavePreview
+	}
+
+	// Level-ups still queued from one pickup open back to back
+	if g.player.PendingLevels > 0 {
+		g.nextLevelUp()
+
+		return nil
+	}
 
 	dt := 1.0 / 60.0
 	g.gameTime += dt
 	g.player.HitTimer -= dt
+	g.levelBurstAt -= dt
 	g.hitAudioTimer -= dt
+	g.combo.Update(dt)
 
 	// Recovery
 	if g.player.Recovery > 0 {
@@ -959,74 +1513,63 @@ func (g *Game) updatePlaying() error {
 	}
 
 	// Player movement
-	dx, dy := 0.0, 0.0
-	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyUp) {
-		dy = -1
+	dx, dy := g.moveInput()
+	if g.controlsInverted() {
+		dx, dy = -dx, -dy
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyDown) {
-		dy = 1
+	if l := math.Hypot(dx, dy); l > 1 {
+		dx, dy = dx/l, dy/l
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyLeft) {
-		dx = -1
-	}
-
-	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyRight) {
-		dx = 1
-	}
+	speed := g.player.Speed * ailmentSpeed(&g.player.Ailments)
+	g.player.X += dx * speed
+	g.player.Y += dy * speed
 
-	if dx != 0 && dy != 0 {
-		dx *= 0.707
-		dy *= 0.707
+	if dx != 0 || dy != 0 {
+		g.player.FaceX, g.player.FaceY = dx, dy
 	}
 
-	g.player.X += dx * g.player.Speed
-	g.player.Y += dy * g.player.Speed
+	g.updateAbilities(dt)
+	g.updateDodge(dt)
+	g.updateShield(dt)
 
 	g.cameraX = g.player.X - float64(screenWidth)/2
 	g.cameraY = g.player.Y - float64(screenHeight)/2
 
-	// Spawn enemies
-	g.spawnTimer += dt
-
-	spawnRate := 1.0 - g.gameTime*0.01 // Starts at 1s, decays faster
-	if spawnRate < 0.05 {              // Cap at 20 enemies/sec
-		spawnRate = 0.05
-	}
-	// Spawn multiple if falling behind
-	for g.spawnTimer >= spawnRate {
-		g.spawnEnemy()
-		g.spawnTimer -= spawnRate
-	}
-
-	// Boss timer (every 3 minutes)
-	g.bossTimer += dt
-	if g.bossTimer >= 180 {
-		g.spawnBoss()
-		g.bossTimer = 0
+	// Spawn enemies unless the sandbox froze spawning
+	if !g.spawnsFrozen() {
+		g.updateSpawning(dt)
 	}
 
 	// Update weapons
-	for _, w := range g.player.Weapons {
-		def := WeaponDefs[w.Type]
-		cooldown := def.Cooldown * g.player.CooldownMult
-
-		w.Timer += dt
-		if w.Timer >= cooldown {
-			g.fireWeapon(w)
-			w.Timer = 0
-		}
-	}
+	g.updateWeapons(dt)
+	g.updateComboAttacks(dt)
+	g.toasts.Update(dt)
+	g.combatLog.Update(dt)
+	g.damageDir.Update(dt)
 
 	// Update projectiles
 	g.updateProjectiles(dt)
+	g.updateZones(dt)
+	g.updateArcs(dt)
+	g.updateWorld(dt)
 
 	// Update enemies
 	g.updateEnemies(dt)
+	g.updateEnemyAilments(dt)
+	g.updatePlayerAilments(dt)
+	g.updateBosses(dt)
+	g.updateBossPatterns(dt)
+	g.updatePet(dt)
+	g.updateSandbox(dt)
+	g.updateTelegraphs(dt)
 
 	// Collect XP
 	g.collectXP(dt)
+	g.collectCoins()
+	g.updateItemDrops(dt)
+	g.updateBars(dt)
 
 	// Update damage numbers
 	for i := len(g.damageNumbers) - 1; i >= 0; i-- {
@@ -1048,14 +1591,52 @@ func (g *Game) updatePlaying() error {
 	return nil
 }
 
+// updateSpawning runs the timed enemy spawns, scripted events, and bosses.
+func (g *Game) updateSpawning(dt float64) {
+	g.spawnTimer += dt
+
+	spawnRate := (1.0 - g.gameTime*0.01) * g.spawnRateScale() // Starts at 1s, decays faster
+	if spawnRate < 0.05 {                                     // Cap at 20 enemies/sec
+		spawnRate = 0.05
+	}
+	g.updateWorldEvents()
+
+	// A code freeze holds back ambient and scripted spawns; bosses still arrive
+	if g.spawnsHalted() {
+		g.spawnTimer = 0
+	}
+
+	// Spawn multiple if falling behind
+	// Spawns over the enemy budget are dropped rather than banked
+	for g.spawnTimer >= spawnRate {
+		if g.enemyRoom() {
+			g.spawnEnemy()
+		}
+
+		g.spawnTimer -= spawnRate
+	}
+
+	// Scripted swarms, walls, and elite packs
+	if !g.spawnsHalted() {
+		g.updateSpawnEvents()
+	}
+
+	// Boss timer (every 3 minutes)
+	g.bossTimer += dt
+	if g.bossTimer >= bossInterval {
+		g.spawnBoss()
+		g.bossTimer = 0
+	}
+}
+
 func (g *Game) spawnEnemy() {
-	angle := rand.Float64() * math.Pi * 2
+	angle := g.spawnRng.Float64() * math.Pi * 2
 	dist := float64(screenWidth)/2 + 100
 
 	// Choose monster type based on time
 	var monsterType MonsterType
 
-	r := rand.Float64()
+	r := g.spawnRng.Float64()
 	if g.gameTime < 60 {
 		if r < 0.7 {
 			monsterType = MonsterBug
@@ -1086,57 +1667,76 @@ func (g *Game) spawnEnemy() {
 		}
 	}
 
+	g.spawnMonster(monsterType, g.player.X+math.Cos(angle)*dist, g.player.Y+math.Sin(angle)*dist)
+}
+
+// spawnMonster adds an enemy of the given type at a world position, scaled by run time.
+func (g *Game) spawnMonster(monsterType MonsterType, x, y float64) *Enemy {
 	def := MonsterDefs[monsterType]
 	hpScale := 1.0 + g.gameTime*0.008
 
-	g.enemies = append(g.enemies, &Enemy{
-		X:  g.player.X + math.Cos(angle)*dist,
-		Y:  g.player.Y + math.Sin(angle)*dist,
+	e := &Enemy{
+		X:  x,
+		Y:  y,
 		HP: int(float64(def.HP) * hpScale), MaxHP: int(float64(def.HP) * hpScale),
-		Speed:  def.Speed,
+		Speed:  def.Speed * g.enemySpeedMult(),
 		Damage: def.Damage,
 		XP:     int(float64(def.XP) * g.player.XPMult),
 		Radius: def.Radius,
 		Type:   monsterType,
 		Color:  def.Color,
-	})
+	}
+	g.enemies = append(g.enemies, e)
+	g.spawnedXP += e.XP
+	g.discoverMonster(monsterType)
+	g.emitSound(e, assets.SoundSpawn)
+
+	return e
 }
 
 func (g *Game) spawnBoss() {
-	angle := rand.Float64() * math.Pi * 2
+	angle := g.spawnRng.Float64() * math.Pi * 2
 	dist := float64(screenWidth)/2 + 150
 
-	bossType := MonsterBossManager
-	if g.gameTime > 360 {
-		bossType = MonsterBossDeadline
-	}
-
+	bossType := bossTypeAt(g.gameTime)
 	def := MonsterDefs[bossType]
 
-	g.enemies = append(g.enemies, &Enemy{
+	e := &Enemy{
 		X:  g.player.X + math.Cos(angle)*dist,
 		Y:  g.player.Y + math.Sin(angle)*dist,
 		HP: def.HP, MaxHP: def.HP,
-		Speed:  def.Speed,
+		Speed:  def.Speed * g.enemySpeedMult(),
 		Damage: def.Damage,
 		XP:     def.XP,
 		Radius: def.Radius,
 		Type:   bossType,
 		Color:  def.Color,
 		IsBoss: true,
-	})
+	}
+	g.attachPattern(e)
+	g.enemies = append(g.enemies, e)
+	g.discoverMonster(bossType)
+	g.emitSound(e, assets.SoundSpawn)
+
+	g.notifyBoss(bossType)
+	g.playRumble(rumbleBoss)
 }
 
 func (g *Game) fireWeapon(w *Weapon) {
 	def := WeaponDefs[w.Type]
 
 	g.audio.PlaySound("shoot")
-	damage := int(float64(def.Damage+w.Level*3) * g.player.DamageMult)
-	count := def.Count + g.player.Passives[PassiveAmount]
+	damage := g.damageCalc(w.Type, w.Level).Hit()
+	count := def.Count + g.player.projectileBonus()
 	areaRange := def.Range * g.player.AreaMult
+	lifetime := def.Duration * g.player.DurationMult
 
 	// Helper to spawn projectile using pool
-	spawnProj := func(x, y, vx, vy, lifetime, radius float64, piercing int) {
+	spawnProj := func(x, y, vx, vy, radius float64, piercing int) {
+		if !g.projectileRoom() {
+			return
+		}
+
 		p := g.newProjectile()
 		p.X, p.Y = x, y
 		p.VX, p.VY = vx, vy
@@ -1147,19 +1747,18 @@ func (g *Game) fireWeapon(w *Weapon) {
 		p.HitList = make(map[*Enemy]bool) // Each projectile needs its own hitlist
 		p.Color = def.Color
 		p.WeaponType = w.Type
+		p.Child, p.Combo = false, false
 		g.projectiles = append(g.projectiles, p)
 	}
 
 	switch w.Type {
 	case WeaponPrint, WeaponLogStream:
-		// Aim at nearest enemy
-		target := g.findNearestEnemy(120) // Melee range
-
+		// Aim at nearest enemy (or along facing with manual aim)
 		var baseAngle float64
-		if target != nil {
-			baseAngle = math.Atan2(target.Y-g.player.Y, target.X-g.player.X)
+		if aimX, aimY, ok := g.aimDirection(120); ok { // Melee range
+			baseAngle = math.Atan2(aimY, aimX)
 		} else {
-			baseAngle = (rand.Float64() - 0.5) * math.Pi / 2
+			baseAngle = (g.rng.Stream(streamCombat).Float64() - 0.5) * math.Pi / 2
 		}
 
 		for i := range count {
@@ -1180,7 +1779,7 @@ func (g *Game) fireWeapon(w *Weapon) {
 			spawnProj(
 				g.player.X+math.Cos(angle)*40, g.player.Y+math.Sin(angle)*40,
 				math.Cos(angle)*3, math.Sin(angle)*3,
-				0.3, radius, 5,
+				radius, 5,
 			)
 		}
 
@@ -1190,16 +1789,12 @@ func (g *Game) fireWeapon(w *Weapon) {
 			spawnProj(
 				g.player.X+math.Cos(angle)*areaRange, g.player.Y+math.Sin(angle)*areaRange,
 				0, 0,
-				0.2, 18, 3,
+				18, 3,
 			)
 		}
 
 	case WeaponGitPush, WeaponForcePush:
-		nearest := g.findNearestEnemy(500)
-		if nearest != nil {
-			dx, dy := nearest.X-g.player.X, nearest.Y-g.player.Y
-			dist := math.Sqrt(dx*dx + dy*dy)
-
+		if aimX, aimY, ok := g.aimDirection(500); ok {
 			speed := 10.0
 			if g.player.CharType == CharTechLead {
 				speed = 12.5
@@ -1209,15 +1804,15 @@ func (g *Game) fireWeapon(w *Weapon) {
 				spread := float64(i-count/2) * 0.15
 				spawnProj(
 					g.player.X, g.player.Y,
-					(dx/dist)*speed+spread, (dy/dist)*speed+spread,
-					2.0, 6, 1,
+					aimX*speed+spread, aimY*speed+spread,
+					6, 1,
 				)
 			}
 		}
 
 	case WeaponUnitTests, WeaponCI_CD:
 		// Area damage around player
-		spawnProj(g.player.X, g.player.Y, 0, 0, 0.2, areaRange, 999)
+		spawnProj(g.player.X, g.player.Y, 0, 0, areaRange, 999)
 
 	case WeaponFirewall, WeaponZeroTrust:
 		// Orbiting fireball
@@ -1225,32 +1820,38 @@ func (g *Game) fireWeapon(w *Weapon) {
 		spawnProj(
 			g.player.X+math.Cos(angle)*areaRange, g.player.Y+math.Sin(angle)*areaRange,
 			0, 0,
-			0.5, 15, 999,
+			15, 999,
 		)
 
 	case WeaponStackOverflow, WeaponCopilot:
 		// Random enemies
 		targets := g.findNearestEnemies(count, 300)
 		for _, e := range targets {
-			spawnProj(e.X, e.Y-50, 0, 20, 0.2, 30, 1)
+			spawnProj(e.X, e.Y-50, 0, 20, 30, 1)
 		}
 
 	case WeaponDocker, WeaponK8s:
 		// Throws toward nearest enemy then returns (boomerang-like)
-		nearest := g.findNearestEnemy(400)
-		if nearest != nil {
-			dx, dy := nearest.X-g.player.X, nearest.Y-g.player.Y
-			dist := math.Sqrt(dx*dx + dy*dy)
+		if aimX, aimY, ok := g.aimDirection(400); ok {
 			speed := 7.0
-			spawnProj(g.player.X, g.player.Y, (dx/dist)*speed, (dy/dist)*speed, 2.0, 15, 999)
+			spawnProj(g.player.X, g.player.Y, aimX*speed, aimY*speed, 15, 999)
 		} else {
 			// No enemy nearby, shoot in last movement direction or default
-			spawnProj(g.player.X, g.player.Y, 5, 0, 2.0, 15, 999)
+			spawnProj(g.player.X, g.player.Y, 5, 0, 15, 999)
 		}
 
 	case WeaponCoffee, WeaponEspresso:
 		// Permanent aura
-		spawnProj(g.player.X, g.player.Y, 0, 0, 0.4, areaRange, 999)
+		spawnProj(g.player.X, g.player.Y, 0, 0, areaRange, 999)
+
+	case WeaponMemoryLeak:
+		// Slow blobs that seep through a few enemies, poisoning each
+		if aimX, aimY, ok := g.aimDirection(areaRange); ok {
+			for i := range count {
+				spread := float64(i-count/2) * 0.3
+				spawnProj(g.player.X, g.player.Y, aimX*4+spread, aimY*4-spread, 10, 3)
+			}
+		}
 	}
 }
 
@@ -1322,33 +1923,19 @@ func (g *Game) findNearestEnemies(count int, maxDist float64) []*Enemy {
 func (g *Game) killEnemy(e *Enemy) {
 	e.Dead = true
 	g.killCount++
-	g.xpGems = append(g.xpGems, &XPGem{X: e.X, Y: e.Y, Value: e.XP})
+	g.recordKill(e)
+	g.combo.Kill()
+	g.logCombat(combatlog.Entry{Category: combatlog.Kill, Source: "Player", Target: MonsterDefs[e.Type].Name})
+	g.dropGem(e.X, e.Y, e.XP)
+	g.dropEnemyCoins(e)
 	g.spawnParticle(e.X, e.Y, 15, e.Color)
+	g.emitSound(e, assets.SoundDeath)
 
-	// Equipment drops
-	def := MonsterDefs[e.Type]
-	if def.IsBoss {
-		// Bosses drop guaranteed rare/legendary equipment
-		slot := EquipSlot(rand.Intn(int(SlotCount)))
-
-		rarity := RarityRare
-		if rand.Float64() < 0.3 {
-			rarity = RarityLegendary
-		}
+	// Equipment drops, left on the ground
+	g.rollLoot(e)
 
-		item := g.generateEquipment(slot, g.player.Level, rarity)
-		g.player.Inventory = append(g.player.Inventory, item)
-	} else if e.XP >= 5 && rand.Float64() < 0.05 {
-		// Elite enemies have 5% chance to drop magic/rare items
-		slot := EquipSlot(rand.Intn(int(SlotCount)))
-
-		rarity := RarityMagic
-		if rand.Float64() < 0.2 {
-			rarity = RarityRare
-		}
-
-		item := g.generateEquipment(slot, g.player.Level, rarity)
-		g.player.Inventory = append(g.player.Inventory, item)
+	if e.MegaElite != nil {
+		g.rewardMegaElite(e)
 	}
 }
 
@@ -1357,10 +1944,11 @@ func (g *Game) addDamageNumber(x, y float64, value int, crit bool) {
 	vy := -rand.Float64()*60 - 30
 
 	if crit {
-		value = int(float64(value) * 1.5)
 		vy -= 30
 	}
 
+	g.evictDamageNumber()
+
 	d := g.newDamageNumber()
 	d.X = x
 	d.Y = y
@@ -1369,11 +1957,12 @@ func (g *Game) addDamageNumber(x, y float64, value int, crit bool) {
 	d.Value = value
 	d.Timer = 0.8
 	d.Crit = crit
+	d.Text = ""
 	g.damageNumbers = append(g.damageNumbers, d)
 }
 
 func (g *Game) spawnParticle(x, y float64, count int, c color.RGBA) {
-	for range count {
+	for range g.particleAllowance(count) {
 		angle := rand.Float64() * math.Pi * 2
 		speed := rand.Float64()*100 + 50
 		life := 0.3 + rand.Float64()*0.4
@@ -1394,6 +1983,10 @@ func (g *Game) spawnParticle(x, y float64, count int, c color.RGBA) {
 
 // spawnTrailParticle spawns smaller, shorter-lived trail particles behind projectiles.
 func (g *Game) spawnTrailParticle(x, y float64, count int, c color.RGBA) {
+	if !g.trailAllowed() {
+		return
+	}
+
 	for range count {
 		// Trail particles spread less and fade faster
 		angle := rand.Float64() * math.Pi * 2
@@ -1438,7 +2031,7 @@ func (g *Game) updateProjectiles(dt float64) {
 
 		// Spawn trail particles for fast-moving projectiles
 		speed := math.Sqrt(p.VX*p.VX + p.VY*p.VY)
-		if speed > 3 && rand.Float64() < 0.3 {
+		if speed > 3 && rand.Float64() < g.graphics().TrailChance {
 			// Spawn 1-2 trail particles behind the projectile
 			trailCount := 1
 			if speed > 7 {
@@ -1457,30 +2050,15 @@ func (g *Game) updateProjectiles(dt float64) {
 
 			dist := math.Sqrt((p.X-e.X)*(p.X-e.X) + (p.Y-e.Y)*(p.Y-e.Y))
 			if dist < p.Radius+e.Radius {
-				crit := rand.Float64() < g.player.CritChance
-
-				damage := p.Damage
-				if crit {
-					damage = int(float64(damage) * 1.5)
-				}
-
-				e.HP -= damage
-				e.HitFlash = 0.1
 				p.HitList[e] = true
-
-				// Audio limit
-				if g.hitAudioTimer <= 0 {
-					g.audio.PlaySound("hit")
-					g.hitAudioTimer = 0.05
-				}
-
-				g.spawnParticle(e.X, e.Y, 5, p.Color)
 				p.Piercing--
 
-				g.addDamageNumber(e.X, e.Y, damage, crit)
+				killed := g.hitEnemy(e, p.Damage, p.Color, p.WeaponType)
+				g.chainHit(p, e)
+				g.rollProcs(p, e)
 
-				if e.HP <= 0 {
-					g.killEnemy(e)
+				if killed {
+					g.triggerDeathEffect(p, TriggerKill, e.X, e.Y)
 				}
 
 				if p.Piercing <= 0 {
@@ -1492,6 +2070,7 @@ func (g *Game) updateProjectiles(dt float64) {
 		}
 
 		if p.Lifetime <= 0 {
+			g.triggerDeathEffect(p, TriggerExpire, p.X, p.Y)
 			g.freeProjectile(p)
 			g.projectiles = append(g.projectiles[:i], g.projectiles[i+1:]...)
 		}
@@ -1532,6 +2111,10 @@ func (g *Game) updateEnemies(dt float64) {
 	for _, e := range g.enemies {
 		e.HitFlash -= dt
 
+		if e.Dummy {
+			continue
+		}
+
 		// Separation (Soft collision) to prevent stacking
 		gx := int(e.X) / cellSize
 
@@ -1574,42 +2157,30 @@ func (g *Game) updateEnemies(dt float64) {
 		e.X += sepX * 5.0 * dt // Strength factor
 		e.Y += sepY * 5.0 * dt
 
-		// Move towards player
-		dx, dy := g.player.X-e.X, g.player.Y-e.Y
+		// Move towards player (or a taunt decoy); wall enemies keep their heading
+		tx, ty := g.enemyTarget(e)
+		dx, dy := tx-e.X, ty-e.Y
+		speed := e.Speed * g.enemySpeedScale() * ailmentSpeed(&e.Ailments)
+		if e.Windup > 0 || e.Charge != nil {
+			speed = 0 // Rooted while winding up an attack; charges move themselves
+		}
 
 		dist := math.Sqrt(dx*dx + dy*dy)
-		if dist > 0 {
-			e.X += (dx / dist) * e.Speed
-			e.Y += (dy / dist) * e.Speed
+		if !g.sweepEnemy(e, dt) && dist > 0 {
+			e.X += (dx / dist) * speed
+			e.Y += (dy / dist) * speed
 		}
 
-		if dist < 20+e.Radius {
-			if g.player.HitTimer > 0 {
+		if math.Hypot(g.player.X-e.X, g.player.Y-e.Y) < 20+e.Radius {
+			if g.player.HitTimer > 0 || e.Ailments.HasStatus(components.StatusFreeze) {
 				continue
 			}
 
-			damage := max(e.Damage-g.player.Armor, 1)
-
-			g.player.HP -= damage
-			g.player.HitTimer = 0.5 // 0.5s invulnerability // Continuous damage per frame is brutal, need timer?
-			// Original code did per-frame damage?
-			// Line 941: g.player.HP -= damage
-			// Yes. With 60 FPS, this kills instantly.
-			// I should add invulnerability frame or reduce damage frequency.
-			// For Dev Survivor, let's add a global hit timer or lower damage?
-			// Or check if invulnerable.
-
-			// Original implementation was brutal. Let's keep it but maybe it relies on low overlap?
-			// With separation, overlap is minimized.
-
-			if g.player.HP <= 0 {
-				if g.player.HasRevival && !g.player.UsedRevival {
-					g.player.HP = g.player.MaxHP / 2
-					g.player.UsedRevival = true
-				} else {
-					g.state = StateGameOver
-				}
+			if g.hurtPlayerFrom(e.X, e.Y, e.Damage, MonsterDefs[e.Type].ArmorPen, MonsterDefs[e.Type].Name) {
+				g.inflictPlayer(e, e.Damage)
 			}
+
+			g.applyThorns(e, e.Damage)
 		}
 	}
 }
@@ -1617,39 +2188,21 @@ func (g *Game) updateEnemies(dt float64) {
 func (g *Game) collectXP(dt float64) {
 	for i := len(g.xpGems) - 1; i >= 0; i-- {
 		gem := g.xpGems[i]
-		dx, dy := g.player.X-gem.X, g.player.Y-gem.Y
-		dist := math.Sqrt(dx*dx + dy*dy)
-
-		if dist < g.player.MagnetRange || gem.Magnet {
-			gem.Magnet = true
-
-			speed := 10.0
-			if dist > 0 {
-				gem.X += (dx / dist) * speed
-				gem.Y += (dy / dist) * speed
-			}
-		}
+		dist := g.magnetPull(&gem.X, &gem.Y, &gem.Magnet)
 
 		if dist < 25 {
-			g.player.XP += gem.Value
+			g.player.XP += g.worldXP(gem.Value)
 			g.xpGems = append(g.xpGems[:i], g.xpGems[i+1:]...)
-
-			xpNeeded := g.player.Level * 25
-			if g.player.XP >= xpNeeded {
-				g.player.XP -= xpNeeded
-				g.player.Level++
-				g.player.PassivePoints++ // Grant passive point on level-up
-				g.showLevelUp()
-
-				return // Stop processing other gems this frame to avoid multiple level-ups
-			}
 		}
 	}
+
+	g.gainLevels()
 }
 
 func (g *Game) showLevelUp() {
 	g.state = StateLevelUp
 	g.upgradeOptions = g.generateUpgrades()
+	g.levelUpFocus = 0
 	g.audio.PlaySound("levelup")
 }
 
@@ -1688,6 +2241,7 @@ func (g *Game) generateUpgrades() []UpgradeOption {
 					for i, w := range g.player.Weapons {
 						if w.Type == rec.BaseWeapon {
 							g.player.Weapons[i] = &Weapon{Type: rec.Result, Level: 1}
+							g.playRumble(rumbleEvolve)
 
 							break
 						}
@@ -1731,6 +2285,7 @@ func (g *Game) generateUpgrades() []UpgradeOption {
 		WeaponStackOverflow,
 		WeaponDocker,
 		WeaponCoffee,
+		WeaponMemoryLeak,
 	}
 	for _, wt := range allWeapons {
 		has := false
@@ -1763,8 +2318,14 @@ func (g *Game) generateUpgrades() []UpgradeOption {
 		if !has && len(g.player.Weapons) < 6 {
 			def := WeaponDefs[wt]
 			wtCopy := wt
+
+			desc := "New weapon!"
+			for _, ca := range g.comboCompletedBy(wt) {
+				desc += " Combo: " + ComboAttacks[ca].Name
+			}
+
 			options = append(options, UpgradeOption{
-				Name: def.Name, Desc: "New weapon!",
+				Name: def.Name, Desc: desc,
 				IsWeapon: true, WeaponType: wt,
 				Apply: func(g *Game) {
 					g.player.Weapons = append(g.player.Weapons, &Weapon{Type: wtCopy, Level: 1})
@@ -1786,8 +2347,12 @@ func (g *Game) generateUpgrades() []UpgradeOption {
 		}
 	}
 
+	options = g.filterBanished(options)
+
 	// Shuffle and pick 4
-	rand.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+	g.rng.Stream(streamUpgrades).Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
 
 	// Prioritize Evolutions (move to front)
 	// Actually shuffling mixes them. If we want guaranteed evolution, we should not shuffle them away.
@@ -1825,45 +2390,145 @@ func (g *Game) applyPassive(pt PassiveType) {
 		g.player.CooldownMult *= 0.95
 	case PassiveArea:
 		g.player.AreaMult += 0.1
+	case PassiveDuration:
+		g.player.DurationMult += 0.1
 	case PassiveRevival:
 		g.player.HasRevival = true
+	case PassiveShield:
+		g.player.MaxShield += shieldPerPassive
 	}
 }
 
 func (g *Game) updateLevelUp() error {
+	if g.updateLevelUpTokens() {
+		return nil
+	}
+
+	g.updateWeaponPreview()
+
+	pick := -1
+	if controls.JustPressed(actionPick) && g.levelUpFocus < len(g.upgradeOptions) {
+		pick = g.levelUpFocus
+	}
+
 	for i := 0; i < len(g.upgradeOptions) && i < 4; i++ {
 		if inpututil.IsKeyJustPressed(ebiten.Key(int(ebiten.Key1) + i)) {
-			g.upgradeOptions[i].Apply(g)
-			g.state = StatePlaying
+			pick = i
 
 			break
 		}
 	}
 
+	switch {
+	case pick < 0:
+	case g.player.Tokens.Banishing:
+		g.banishUpgrade(pick)
+	default:
+		g.upgradeOptions[pick].Apply(g)
+		g.discoverLoadout()
+		g.nextLevelUp()
+	}
+
 	return nil
 }
 
+// Pause menu items.
+const (
+	pauseResume = "Resume"
+	pauseSave   = "Save Run"
+	pauseQuit   = "Quit to Menu"
+)
+
+// pauseList returns the pause menu, creating it with Resume selected, and
+// offering Save Run only during a run.
+func (g *Game) pauseList() *ui.List {
+	if g.pauseMenu != nil {
+		return g.pauseMenu
+	}
+
+	items := []string{pauseResume}
+	if g.inRun() {
+		items = append(items, pauseSave)
+	}
+
+	items = append(items, pauseQuit)
+	g.pauseMenu = ui.NewList((screenWidth-200)/2, (screenHeight-180)/2+70, 200, items)
+	g.pauseMenu.RowH = 26
+
+	return g.pauseMenu
+}
+
+// Pause opens the pause menu mid-run, for engine.FocusConfig.AutoPause, so
+// a boss fight waits for the player to come back to the window.
+func (g *Game) Pause() {
+	if g.state != StatePlaying {
+		return
+	}
+
+	g.state = StatePaused
+	g.pauseMenu = nil
+	g.audio.SetDucked(true)
+}
+
+// paused reports whether the run is on the pause menu or a screen opened
+// from it, when the audio is ducked.
+func (g *Game) paused() bool {
+	return g.state == StatePaused || g.state == StateSaveMenu
+}
+
 func (g *Game) updatePaused() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+	if controls.JustPressed(input.Pause) {
 		g.state = StatePlaying
+
+		return nil
 	}
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
-		g.state = StateCharSelect
+		g.quitRun()
+
+		return nil
+	}
+
+	menu := g.pauseList()
+	if i := menu.Update(controls); i >= 0 {
+		switch menu.Items[i] {
+		case pauseResume:
+			g.state = StatePlaying
+		case pauseSave:
+			g.openSaveMenu()
+		case pauseQuit:
+			g.quitRun()
+		}
 	}
 
 	return nil
 }
 
+// quitRun abandons the run for character select, keeping its lifetime stats.
+func (g *Game) quitRun() {
+	g.flushLifetime()
+	g.state = StateCharSelect
+}
+
 func (g *Game) updateGameOver() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		g.startGame(g.player.CharType)
+	if controls.JustPressed(input.Confirm) {
+		g.draftRun(g.newRun(g.player.CharType))
+	}
+
+	// Replay the same seed, character, and modifiers
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.draftRun(g.run)
 	}
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
 		g.state = StateCharSelect
 	}
 
+	// Share the build (E key)
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) && !g.buildShared {
+		g.shareBuild()
+	}
+
 	return nil
 }
 
@@ -1908,23 +2573,8 @@ func (g *Game) updateEquipment() error {
 	// Enter to equip selected inventory item to selected slot
 	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && len(g.player.Inventory) > 0 {
 		if g.selectedInvIndex < len(g.player.Inventory) {
-			item := g.player.Inventory[g.selectedInvIndex]
-			if item.Slot == g.selectedSlot {
-				// Swap with current equipment
-				oldEquip := g.player.Equipment[g.selectedSlot]
-				g.player.Equipment[g.selectedSlot] = item
-
-				// Remove from inventory
-				g.player.Inventory = append(
-					g.player.Inventory[:g.selectedInvIndex],
-					g.player.Inventory[g.selectedInvIndex+1:]...)
-
-				// Add old equipment to inventory if exists
-				if oldEquip != nil {
-					g.player.Inventory = append(g.player.Inventory, oldEquip)
-				}
-
-				// Recalculate stats
+			if g.player.Inventory[g.selectedInvIndex].Slot == g.selectedSlot {
+				g.equipFromInventory(g.selectedInvIndex)
 				g.recalculateStats()
 				g.audio.PlaySound("select")
 
@@ -1935,6 +2585,20 @@ func (g *Game) updateEquipment() error {
 		}
 	}
 
+	// B to equip the best item for every slot
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+		g.autoEquip()
+	}
+
+	// C to craft the selected item
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.openCrafting()
+
+		return nil
+	}
+
+	g.updateInventoryKeys()
+
 	return nil
 }
 
@@ -1943,119 +2607,8 @@ func (g *Game) updateEquipment() error {
 // ============================================================================
 
 func (g *Game) initPassiveTree() {
-	// Create a PoE-style passive tree with ~30 nodes
-	// Layout: Central start nodes, branching into offense/defense/utility paths
-	g.passiveTree = []*PassiveNode{
-		// Starting nodes for each class (center)
-		{
-			ID: 0, Name: "Junior Start", X: 0, Y: 0, Connections: []int{1, 2, 3}, NodeType: NodeSmall, StartClass: CharJunior,
-			Effects: []Modifier{{Type: ModPercentDamage, Value: 5}},
-		},
-		{
-			ID: 1, Name: "Senior Start", X: -2, Y: 0, Connections: []int{0, 4, 5}, NodeType: NodeSmall, StartClass: CharSenior,
-			Effects: []Modifier{{Type: ModArea, Value: 10}},
-		},
-		{
-			ID: 2, Name: "Lead Start", X: 2, Y: 0, Connections: []int{0, 6, 7}, NodeType: NodeSmall, StartClass: CharTechLead,
-			Effects: []Modifier{{Type: ModCooldown, Value: 5}},
-		},
-		{
-			ID: 3, Name: "10x Start", X: 0, Y: 2, Connections: []int{0, 8, 9}, NodeType: NodeSmall, StartClass: Char10x,
-			Effects: []Modifier{{Type: ModSpeed, Value: 10}},
-		},
-
-		// Offense branch (left side)
-		{
-			ID: 4, Name: "Code Fury", Desc: "+10% Damage", X: -3, Y: -1, Connections: []int{1, 10}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModPercentDamage, Value: 10}},
-		},
-		{
-			ID: 5, Name: "Sharp Focus", Desc: "+5% Crit", X: -3, Y: 1, Connections: []int{1, 11}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModCritChance, Value: 5}},
-		},
-		{
-			ID: 10, Name: "Aggressive Coding", Desc: "+15% Damage", X: -4, Y: -2, Connections: []int{4, 12}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModPercentDamage, Value: 15}},
-		},
-		{
-			ID: 11, Name: "Precision", Desc: "+10% Crit", X: -4, Y: 2, Connections: []int{5, 12}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModCritChance, Value: 10}},
-		},
-		{
-			ID: 12, Name: "10x Developer", Desc: "+100% Damage, -50% HP", X: -5, Y: 0, Connections: []int{10, 11}, NodeType: NodeKeystone,
-			Effects: []Modifier{{Type: ModPercentDamage, Value: 100}, {Type: ModPercentHP, Value: -50}},
-		},
-
-		// Defense branch (right side)
-		{
-			ID: 6, Name: "Thick Skin", Desc: "+5 Armor", X: 3, Y: -1, Connections: []int{2, 13}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModArmor, Value: 5}},
-		},
-		{
-			ID: 7, Name: "Vitality", Desc: "+20 Max HP", X: 3, Y: 1, Connections: []int{2, 14}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModFlatHP, Value: 20}},
-		},
-		{
-			ID: 13, Name: "Fortified Code", Desc: "+10 Armor", X: 4, Y: -2, Connections: []int{6, 15}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModArmor, Value: 10}},
-		},
-		{
-			ID: 14, Name: "Life Force", Desc: "+50 Max HP", X: 4, Y: 2, Connections: []int{7, 15}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModFlatHP, Value: 50}},
-		},
-		{
-			ID: 15, Name: "Defensive Programmer", Desc: "+50% Armor, -25% Damage", X: 5, Y: 0, Connections: []int{13, 14}, NodeType: NodeKeystone,
-			Effects: []Modifier{{Type: ModArmor, Value: 50}, {Type: ModPercentDamage, Value: -25}},
-		},
-
-		// Utility branch (bottom)
-		{
-			ID: 8, Name: "Quick Deploy", Desc: "+5% Speed", X: -1, Y: 3, Connections: []int{3, 16}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModSpeed, Value: 5}},
-		},
-		{
-			ID: 9, Name: "Optimization", Desc: "-5% Cooldown", X: 1, Y: 3, Connections: []int{3, 17}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModCooldown, Value: 5}},
-		},
-		{
-			ID: 16, Name: "Rapid Iteration", Desc: "+10% Speed", X: -2, Y: 4, Connections: []int{8, 18}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModSpeed, Value: 10}},
-		},
-		{
-			ID: 17, Name: "CI Master", Desc: "-10% Cooldown", X: 2, Y: 4, Connections: []int{9, 18}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModCooldown, Value: 10}},
-		},
-		{
-			ID: 18, Name: "Code Reviewer", Desc: "+2 Projectiles, -30% Attack Speed", X: 0, Y: 5, Connections: []int{16, 17}, NodeType: NodeKeystone,
-			Effects: []Modifier{{Type: ModProjectiles, Value: 2}, {Type: ModCooldown, Value: -30}},
-		},
-
-		// Extra nodes for depth
-		{
-			ID: 19, Name: "XP Boost", Desc: "+15% XP", X: 0, Y: -2, Connections: []int{0, 20}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModXPGain, Value: 15}},
-		},
-		{
-			ID: 20, Name: "Fast Learner", Desc: "+25% XP", X: 0, Y: -3, Connections: []int{19}, NodeType: NodeNotable,
-			Effects: []Modifier{{Type: ModXPGain, Value: 25}},
-		},
-		{
-			ID: 21, Name: "Wide Area", Desc: "+10% Area", X: -1, Y: -1, Connections: []int{0, 4}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModArea, Value: 10}},
-		},
-		{
-			ID: 22, Name: "Recovery", Desc: "+1 HP/s", X: 1, Y: -1, Connections: []int{0, 6}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModRecovery, Value: 1}},
-		},
-		{
-			ID: 23, Name: "Magnet", Desc: "+20% Pickup", X: -1, Y: 1, Connections: []int{0, 5}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModMagnet, Value: 20}},
-		},
-		{
-			ID: 24, Name: "Duration", Desc: "+15% Duration", X: 1, Y: 1, Connections: []int{0, 7}, NodeType: NodeSmall,
-			Effects: []Modifier{{Type: ModDuration, Value: 15}},
-		},
-	}
+	g.passiveTree = passiveTreeNodes()
+	g.treeView = treeView{}
 
 	// Allocate starting node based on character class
 	for _, node := range g.passiveTree {
@@ -2075,37 +2628,22 @@ func (g *Game) updatePassiveTree() error {
 		return nil
 	}
 
-	// Mouse click to allocate nodes
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
-
-		// Convert screen coords to tree coords
-		centerX := float64(screenWidth) / 2
-		centerY := float64(screenHeight) / 2
-		scale := 60.0 // Pixels per tree unit
-
-		for _, node := range g.passiveTree {
-			nodeScreenX := centerX + node.X*scale
-			nodeScreenY := centerY + node.Y*scale
-
-			// Check if click is on node
-			dist := math.Sqrt(math.Pow(float64(mx)-nodeScreenX, 2) + math.Pow(float64(my)-nodeScreenY, 2))
+	g.treeView.update(1.0 / 60.0)
 
-			nodeRadius := 15.0
+	// Left click allocates a node, right click refunds one
+	mx, my := ebiten.CursorPosition()
 
-			switch node.NodeType {
-			case NodeNotable:
-				nodeRadius = 20.0
-			case NodeKeystone:
-				nodeRadius = 25.0
-			}
+	node := g.treeNodeAt(float64(mx), float64(my))
+	if node == nil {
+		return nil
+	}
 
-			if dist < nodeRadius {
-				g.tryAllocateNode(node)
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.tryAllocateNode(node)
+	}
 
-				break
-			}
-		}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		g.refundNode(node)
 	}
 
 	return nil
@@ -2152,18 +2690,29 @@ func (g *Game) recalculateStats() {
 	g.player.MaxHP = charDef.HP
 	g.player.Speed = charDef.Speed
 	g.player.DamageMult = 1.0
+	g.player.FlatDamage = 0
 	g.player.AreaMult = 1.0
+	g.player.DurationMult = 1.0
 	g.player.CooldownMult = 1.0
-	g.player.MagnetRange = 80
+	g.player.MagnetRange = 80 + g.metaBonus.MagnetRange
 	g.player.Recovery = 0
 	g.player.CritChance = 0
+	g.player.CritMultiplier = baseCritMultiplier
 	g.player.XPMult = 1.0
 	g.player.Armor = 0
+	g.player.ForkCount = 0
+	g.player.Lifesteal = 0
+	g.player.Thorns = 0
+	g.player.MaxShield = 0
+	g.player.BonusProjectiles = 0
+	g.player.Procs = nil
 
 	// Apply character trait
 	switch g.player.CharType {
 	case CharSenior:
 		g.player.AreaMult = 1.5
+	case Char10x:
+		g.player.Lifesteal = char10xLifesteal
 	}
 
 	// Apply passive tree bonuses
@@ -2206,20 +2755,35 @@ func (g *Game) recalculateStats() {
 				g.player.CooldownMult *= 0.95
 			case PassiveArea:
 				g.player.AreaMult += 0.10
+			case PassiveDuration:
+				g.player.DurationMult += 0.10
+			case PassiveShield:
+				g.player.MaxShield += shieldPerPassive
 			}
 		}
 	}
 
-	// Clamp HP to max
+	g.applyMutation()
+
+	// On-hit procs granted by stats
+	if g.player.ForkCount > 0 {
+		g.player.Procs = append(g.player.Procs, forkLightningProc(g.player.ForkCount))
+	}
+
+	g.applyRunMods()
+
+	// Clamp HP and shield to max
 	if g.player.HP > g.player.MaxHP {
 		g.player.HP = g.player.MaxHP
 	}
+
+	g.player.Shield = min(g.player.Shield, g.player.MaxShield)
 }
 
 func (g *Game) applyModifier(mod Modifier) {
 	switch mod.Type {
 	case ModFlatDamage:
-		// Would need base damage tracking
+		g.player.FlatDamage += int(mod.Value)
 	case ModPercentDamage:
 		g.player.DamageMult += mod.Value / 100
 	case ModFlatHP:
@@ -2232,12 +2796,14 @@ func (g *Game) applyModifier(mod Modifier) {
 		g.player.Speed *= (1 + mod.Value/100)
 	case ModCritChance:
 		g.player.CritChance += mod.Value / 100
+	case ModCritMultiplier:
+		g.player.CritMultiplier += mod.Value / 100
 	case ModCooldown:
 		g.player.CooldownMult *= (1 - mod.Value/100)
 	case ModArea:
 		g.player.AreaMult += mod.Value / 100
 	case ModDuration:
-		// Would need duration tracking
+		g.player.DurationMult += mod.Value / 100
 	case ModMagnet:
 		g.player.MagnetRange *= (1 + mod.Value/100)
 	case ModXPGain:
@@ -2245,36 +2811,36 @@ func (g *Game) applyModifier(mod Modifier) {
 	case ModRecovery:
 		g.player.Recovery += mod.Value
 	case ModProjectiles:
-		// Apply to PassiveAmount
-		g.player.Passives[PassiveAmount] += int(mod.Value)
+		g.player.BonusProjectiles += int(mod.Value)
+	case ModLifesteal:
+		g.player.Lifesteal += mod.Value / 100
+	case ModThorns:
+		g.player.Thorns += mod.Value / 100
+	case ModForkLightning:
+		g.player.ForkCount += int(mod.Value)
+	case ModShield:
+		g.player.MaxShield += mod.Value
 	}
 }
 
 // generateEquipment creates a random equipment item.
 func (g *Game) generateEquipment(slot EquipSlot, itemLevel int, rarity Rarity) *Equipment {
-	names := map[EquipSlot][]string{
-		SlotKeyboard:   {"Mechanical Keyboard", "Cherry MX Board", "Ergonomic Keyboard", "Gaming Keyboard"},
-		SlotMonitor:    {"4K Monitor", "Ultrawide Display", "Gaming Monitor", "Dual Screen"},
-		SlotChair:      {"Herman Miller", "Gaming Chair", "Ergonomic Seat", "Standing Desk"},
-		SlotMouse:      {"Wireless Mouse", "Gaming Mouse", "Trackball", "Precision Mouse"},
-		SlotHeadphones: {"Noise Cancelling", "Open Back Cans", "Gaming Headset", "AirPods Pro"},
-		SlotCoffeeMug:  {"Yeti Tumbler", "Pour Over Set", "Espresso Cup", "Thermos"},
-	}
-
-	slotNames := names[slot]
-	name := slotNames[rand.Intn(len(slotNames))]
+	r := g.rng.Stream(streamLoot)
+	bases := EquipmentBases[slot]
+	base := bases[r.Intn(len(bases))]
+	name := base
 
 	// Prefix based on rarity
 	switch rarity {
 	case RarityMagic:
 		prefixes := []string{"Enhanced", "Quality", "Fine"}
-		name = prefixes[rand.Intn(len(prefixes))] + " " + name
+		name = prefixes[r.Intn(len(prefixes))] + " " + name
 	case RarityRare:
 		prefixes := []string{"Superior", "Exceptional", "Elite"}
-		name = prefixes[rand.Intn(len(prefixes))] + " " + name
+		name = prefixes[r.Intn(len(prefixes))] + " " + name
 	case RarityLegendary:
 		prefixes := []string{"Legendary", "Mythic", "Godly"}
-		name = prefixes[rand.Intn(len(prefixes))] + " " + name
+		name = prefixes[r.Intn(len(prefixes))] + " " + name
 	}
 
 	// Generate modifiers based on rarity
@@ -2284,68 +2850,27 @@ func (g *Game) generateEquipment(slot EquipSlot, itemLevel int, rarity Rarity) *
 	case RarityCommon:
 		modCount = 0
 	case RarityMagic:
-		modCount = 1 + rand.Intn(2)
+		modCount = 1 + r.Intn(2)
 	case RarityRare:
-		modCount = 3 + rand.Intn(2)
+		modCount = 3 + r.Intn(2)
 	case RarityLegendary:
-		modCount = 5 + rand.Intn(2)
-	}
-
-	// Preferred mods per slot
-	slotMods := map[EquipSlot][]ModType{
-		SlotKeyboard:   {ModFlatDamage, ModPercentDamage, ModCooldown},
-		SlotMonitor:    {ModFlatHP, ModPercentHP, ModXPGain},
-		SlotChair:      {ModArmor, ModRecovery, ModFlatHP},
-		SlotMouse:      {ModCritChance, ModArea, ModPercentDamage},
-		SlotHeadphones: {ModCooldown, ModDuration, ModArea},
-		SlotCoffeeMug:  {ModSpeed, ModMagnet, ModRecovery},
+		modCount = 5 + r.Intn(2)
 	}
 
-	preferredMods := slotMods[slot]
 	mods := make([]Modifier, 0, modCount)
+	for range modCount {
+		mods = append(mods, rollModifier(r, slot, itemLevel))
+	}
 
-	for i := 0; i < modCount; i++ {
-		modType := preferredMods[rand.Intn(len(preferredMods))]
-		tier := 1 + rand.Intn(min(5, itemLevel/10+1))
-
-		// Value based on mod type and tier
-		var value float64
-
-		switch modType {
-		case ModFlatDamage:
-			value = float64(tier * 3)
-		case ModPercentDamage:
-			value = float64(tier * 5)
-		case ModFlatHP:
-			value = float64(tier * 15)
-		case ModPercentHP:
-			value = float64(tier * 5)
-		case ModArmor:
-			value = float64(tier * 3)
-		case ModSpeed:
-			value = float64(tier * 3)
-		case ModCritChance:
-			value = float64(tier * 2)
-		case ModCooldown:
-			value = float64(tier * 3)
-		case ModArea:
-			value = float64(tier * 5)
-		case ModDuration:
-			value = float64(tier * 5)
-		case ModMagnet:
-			value = float64(tier * 10)
-		case ModXPGain:
-			value = float64(tier * 5)
-		case ModRecovery:
-			value = float64(tier) * 0.5
-		}
-
-		mods = append(mods, Modifier{Type: modType, Value: value, Tier: tier})
+	// Legendary weapon-slot gear carries the Forked Lightning special
+	if rarity == RarityLegendary && (slot == SlotKeyboard || slot == SlotMouse) {
+		mods = append(mods, Modifier{Type: ModForkLightning, Value: 2, Tier: 5})
 	}
 
 	return &Equipment{
 		Slot:      slot,
 		Name:      name,
+		Base:      base,
 		Rarity:    rarity,
 		Modifiers: mods,
 		ItemLevel: itemLevel,
@@ -2354,11 +2879,17 @@ func (g *Game) generateEquipment(slot EquipSlot, itemLevel int, rarity Rarity) *
 
 func (g *Game) Draw(screen *ebiten.Image) {
 	switch g.state {
+	case StateLoading:
+		g.drawLoading(screen)
 	case StateCharSelect:
 		g.drawCharSelect(screen)
 	case StatePlaying, StateLevelUp, StatePaused:
 		g.drawGame(screen)
 
+		if g.state == StatePlaying {
+			g.probeFrame()
+		}
+
 		if g.state == StateLevelUp {
 			g.drawLevelUp(screen)
 		}
@@ -2377,15 +2908,54 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	case StateHelp:
 		g.drawGame(screen)
 		g.drawHelp(screen)
+	case StateCompendium:
+		g.drawCompendium(screen)
+	case StateBossEditor:
+		g.drawBossEditor(screen)
+	case StateSaveMenu:
+		g.drawSaveMenu(screen)
+	case StateLoadMenu:
+		g.drawLoadMenu(screen)
+	case StateDraft:
+		g.drawGame(screen)
+		g.drawMutationDraft(screen)
+	case StateStats:
+		g.drawGame(screen)
+		g.drawStats(screen)
+	case StateCrafting:
+		g.drawGame(screen)
+		g.drawCrafting(screen)
+	case StateCoinShop:
+		g.drawCoinShop(screen)
+	}
+}
+
+// screen returns the full screen being shown, for scene transitions: states
+// drawn as overlays on the run all count as StatePlaying.
+func (g *Game) screen() any {
+	switch g.state {
+	case StateLoading, StateCharSelect, StatePassiveTree, StateCompendium, StateBossEditor:
+		return g.state
 	}
+
+	return StatePlaying
 }
 
 func (g *Game) drawCharSelect(screen *ebiten.Image) {
-	screen.Fill(color.RGBA{R: 20, G: 25, B: 35, A: 255})
+	if g.tokenShop != nil {
+		g.tokenShop.Draw(screen)
+
+		return
+	}
+
+	if !g.profile.DrawTitleBackdrop(screen) {
+		screen.Fill(ui.CurrentTheme().Palette.Background)
+	}
 
 	// Title
-	ebitenutil.DebugPrintAt(screen, "ENDLESS SWARM", screenWidth/2-50, 50)
-	ebitenutil.DebugPrintAt(screen, "Select Your Hero", screenWidth/2-60, 80)
+	ui.DebugPrintAt(screen, "ENDLESS SWARM", screenWidth/2-50, 50)
+	g.profile.DrawPet(screen, screenWidth/2+80, 68)
+	ui.DebugPrintAt(screen, "Select Your Hero", screenWidth/2-60, 80)
 
 	// Characters
 	for i, char := range Characters {
@@ -2413,43 +2983,48 @@ func (g *Game) drawCharSelect(screen *ebiten.Image) {
 			)
 		}
 
-		// Character image or fallback circle
-		if g.charImages[i] != nil {
-			img := g.charImages[i]
-			bounds := img.Bounds()
-
-			scale := 80.0 / float64(bounds.Dx())
-			if float64(bounds.Dy())*scale > 100 {
-				scale = 100.0 / float64(bounds.Dy())
-			}
+		// Character image
+		img := g.charImage(CharacterType(i))
+		bounds := img.Bounds()
 
-			op := &ebiten.DrawImageOptions{}
-			op.GeoM.Scale(scale, scale)
-			op.GeoM.Translate(float64(x+75)-float64(bounds.Dx())*scale/2, float64(y+10))
-			screen.DrawImage(img, op)
-		} else {
-			vector.FillCircle(screen, float32(x+75), float32(y+60), 40, char.Color, false)
+		scale := 80.0 / float64(bounds.Dx())
+		if float64(bounds.Dy())*scale > 100 {
+			scale = 100.0 / float64(bounds.Dy())
 		}
 
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(float64(x+75)-float64(bounds.Dx())*scale/2, float64(y+10))
+		screen.DrawImage(img, op)
+
 		// Name
-		ebitenutil.DebugPrintAt(screen, char.Name, x+50, y+115)
+		ui.DebugPrintAt(screen, char.Name, x+50, y+115)
 
 		// Stats
-		ebitenutil.DebugPrintAt(screen, "HP: "+formatInt(char.HP), x+20, y+145)
-		ebitenutil.DebugPrintAt(screen, "Speed: "+formatFloat(char.Speed), x+20, y+165)
-		ebitenutil.DebugPrintAt(screen, "Weapon:", x+20, y+190)
-		ebitenutil.DebugPrintAt(screen, WeaponDefs[char.StartWeapon].Name, x+20, y+205)
-		ebitenutil.DebugPrintAt(screen, char.Trait+":", x+20, y+235)
-		ebitenutil.DebugPrintAt(screen, char.TraitDesc, x+20, y+250)
+		ui.DebugPrintAt(screen, "HP: "+formatInt(char.HP), x+20, y+145)
+		ui.DebugPrintAt(screen, "Speed: "+formatFloat(char.Speed), x+20, y+165)
+		ui.DebugPrintAt(screen, "Weapon:", x+20, y+190)
+		ui.DebugPrintAt(screen, WeaponDefs[char.StartWeapon].Name, x+20, y+205)
+		ui.DebugPrintAt(screen, char.Trait+":", x+20, y+235)
+		ui.DebugPrintAt(screen, char.TraitDesc, x+20, y+250)
 	}
 
+	g.drawRunSetup(screen)
+	g.drawPetSelect(screen)
+	g.drawMemorySetting(screen)
+
 	// Controls
-	ebitenutil.DebugPrintAt(
+	ui.DebugPrintAt(
 		screen,
-		"LEFT/RIGHT to select | SPACE to start",
-		screenWidth/2-130,
+		"LEFT/RIGHT hero | UP/DOWN pet | SPACE to start | T training arena | "+
+			"M memory | C compendium | L load | K shop | G coin shop",
+		screenWidth/2-366,
 		screenHeight-50,
 	)
+
+	if g.dev {
+		ui.DebugPrintAt(screen, "DEV: F9 boss editor", screenWidth/2-60, screenHeight-30)
+	}
 }
 
 func (g *Game) drawGame(screen *ebiten.Image) {
@@ -2485,6 +3060,9 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 		)
 	}
 
+	// Crates, chests, and hazards
+	g.drawWorld(screen)
+
 	// XP Gems
 	for _, gem := range g.xpGems {
 		sx, sy := gem.X-g.cameraX, gem.Y-g.cameraY
@@ -2506,29 +3084,42 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 		}
 	}
 
+	// Lingering damage zones
+	g.drawZones(screen)
+
+	// Coins
+	g.drawCoins(screen)
+	g.drawItemDrops(screen)
+
+	// Telegraphed strikes under enemies
+	g.drawTelegraphs(screen)
+
 	// Enemies (Batched)
+	g.drawEliteMarkers(screen)
 	g.drawEnemies(screen)
 
 	// Projectiles
 	g.drawProjectiles(screen)
+	g.drawBossPatterns(screen)
+	g.drawArcs(screen)
+
+	g.drawPet(screen)
 
 	// Player
 	px, py := g.player.X-g.cameraX, g.player.Y-g.cameraY
-	pColor := Characters[g.player.CharType].Color
 
-	// Draw character image or fallback
-	if img := g.charImages[g.player.CharType]; img != nil {
-		op := &ebiten.DrawImageOptions{}
-		// Scale to fit ~40px width
-		bounds := img.Bounds()
-		scale := 50.0 / float64(bounds.Dx())
-		op.GeoM.Scale(scale, scale)
-		op.GeoM.Translate(px-float64(bounds.Dx())*scale/2, py-float64(bounds.Dy())*scale/2)
-		screen.DrawImage(img, op)
-	} else {
-		vector.FillCircle(screen, float32(px), float32(py), 20, pColor, false)
-		vector.StrokeCircle(screen, float32(px), float32(py), 20, 3, color.RGBA{R: 255, G: 255, B: 255, A: 200}, false)
-	}
+	// Draw character image, scaled to fit ~50px width
+	img := g.charImage(g.player.CharType)
+	bounds := img.Bounds()
+	scale := 50.0 / float64(bounds.Dx())
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(px-float64(bounds.Dx())*scale/2, py-float64(bounds.Dy())*scale/2)
+	screen.DrawImage(img, op)
+
+	// Buffs and debuffs over the player and bosses
+	g.drawStatusTrays(screen)
 
 	// Damage numbers
 	for _, d := range g.damageNumbers {
@@ -2539,6 +3130,10 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 			text = text + "!"
 		}
 
+		if d.Text != "" {
+			text = d.Text
+		}
+
 		ebitenutil.DebugPrintAt(screen, text, int(sx)-10, int(sy))
 	}
 
@@ -2546,7 +3141,17 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 	g.drawParticles(screen)
 
 	// HUD
+	g.drawAbilityEffects(screen)
+	g.drawSpawnWarnings(screen)
+	g.drawWorldEvents(screen)
+	g.drawBossMarkers(screen)
+	g.drawLowHPVignette(screen)
+	g.damageDir.Draw(screen)
 	g.drawHUD(screen)
+	g.drawStaminaBar(screen)
+	g.drawAbilityHUD(screen)
+	g.combatLog.Draw(screen, 10, 70, 440, 280)
+	g.toasts.Draw(screen)
 }
 
 func (g *Game) drawEnemies(screen *ebiten.Image) {
@@ -2564,38 +3169,29 @@ func (g *Game) drawEnemies(screen *ebiten.Image) {
 	}
 
 	for t, enemies := range buckets {
-		img := g.monsterImages[t]
+		img := g.monsterImage(t)
+		bounds := img.Bounds()
 
 		for _, e := range enemies {
 			sx, sy := e.X-g.cameraX, e.Y-g.cameraY
+			scale := (e.Radius * 2.5) / float64(bounds.Dx())
 
-			if img != nil {
-				bounds := img.Bounds()
-				scale := (e.Radius * 2.5) / float64(bounds.Dx())
-
-				op := &ebiten.DrawImageOptions{}
-				op.GeoM.Scale(scale, scale)
-				op.GeoM.Translate(sx-float64(bounds.Dx())*scale/2, sy-float64(bounds.Dy())*scale/2)
-
-				if e.HitFlash > 0 {
-					op.ColorScale.Scale(10, 10, 10, 1)
-				} else {
-					r := float32(e.Color.R) / 255.0
-					g := float32(e.Color.G) / 255.0
-					b := float32(e.Color.B) / 255.0
-					op.ColorScale.Scale(r, g, b, 1)
-				}
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(sx-float64(bounds.Dx())*scale/2, sy-float64(bounds.Dy())*scale/2)
 
-				screen.DrawImage(img, op)
+			if e.HitFlash > 0 {
+				op.ColorScale.Scale(10, 10, 10, 1)
 			} else {
-				c := e.Color
-				if e.HitFlash > 0 {
-					c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
-				}
-
-				vector.FillCircle(screen, float32(sx), float32(sy), float32(e.Radius), c, false)
+				r := float32(e.Color.R) / 255.0
+				g := float32(e.Color.G) / 255.0
+				b := float32(e.Color.B) / 255.0
+				op.ColorScale.Scale(r, g, b, 1)
 			}
 
+			screen.DrawImage(img, op)
+			drawAilmentPips(screen, e, float32(sx), float32(sy))
+
 			// Aux rendering (not batched, affects perf, but necessary for gameplay)
 			// Boss indicator
 			if e.IsBoss {
@@ -2610,8 +3206,8 @@ func (g *Game) drawEnemies(screen *ebiten.Image) {
 				)
 			}
 
-			// HP bar
-			if e.HP < e.MaxHP {
+			// HP bar; bosses use the boss bar instead
+			if e.HP < e.MaxHP && !e.IsBoss && g.hpBarVisible(e) {
 				barW := e.Radius * 2
 				hpRatio := float32(e.HP) / float32(e.MaxHP)
 				// Draw bg only
@@ -2641,6 +3237,8 @@ func (g *Game) drawEnemies(screen *ebiten.Image) {
 
 // drawProjectiles renders projectiles with weapon-specific visual effects.
 func (g *Game) drawProjectiles(screen *ebiten.Image) {
+	glow := g.graphics().Glow
+
 	for _, p := range g.projectiles {
 		sx, sy := p.X-g.cameraX, p.Y-g.cameraY
 
@@ -2649,13 +3247,8 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 			continue
 		}
 
-		// Create glow color (lighter version of base color)
-		glowColor := color.RGBA{
-			R: uint8(min(255, int(p.Color.R)+100)),
-			G: uint8(min(255, int(p.Color.G)+100)),
-			B: uint8(min(255, int(p.Color.B)+100)),
-			A: 100,
-		}
+		// Translucent, lighter version of the base color
+		glowColor := colorutil.WithAlpha(colorutil.Brighten(p.Color, 0.4), 100)
 
 		switch p.WeaponType {
 		case WeaponGitPush, WeaponForcePush:
@@ -2666,8 +3259,7 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 				dirX, dirY := -p.VX/speed, -p.VY/speed
 
 				for i := 1; i <= 4; i++ {
-					trailAlpha := uint8(150 - i*30)
-					trailColor := color.RGBA{p.Color.R, p.Color.G, p.Color.B, trailAlpha}
+					trailColor := colorutil.WithAlpha(p.Color, uint8(150-i*30))
 					offset := float64(i) * 6
 					vector.FillCircle(
 						screen,
@@ -2680,7 +3272,10 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 				}
 			}
 			// Main projectile with glow
-			vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius)+3, glowColor, false)
+			if glow {
+				vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius)+3, glowColor, false)
+			}
+
 			vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius), p.Color, false)
 			// Arrow tip
 			if p.VX != 0 || p.VY != 0 {
@@ -2692,7 +3287,9 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 		case WeaponFirewall, WeaponZeroTrust:
 			// Fire ring effect
 			// Outer glow
-			vector.StrokeCircle(screen, float32(sx), float32(sy), float32(p.Radius)+8, 4, glowColor, false)
+			if glow {
+				vector.StrokeCircle(screen, float32(sx), float32(sy), float32(p.Radius)+8, 4, glowColor, false)
+			}
 			// Flame particles (animated)
 			flameCount := 6
 			for i := range flameCount {
@@ -2733,7 +3330,9 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 		case WeaponStackOverflow, WeaponCopilot:
 			// Lightning bolt effect
 			// Vertical bolt with zigzag
-			vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius)+5, glowColor, false)
+			if glow {
+				vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius)+5, glowColor, false)
+			}
 			// Lightning segments
 			segments := 4
 			segHeight := p.Radius * 2 / float64(segments)
@@ -2772,7 +3371,9 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 		case WeaponRefactor, WeaponCleanCode:
 			// Orbiting circles with trail
 			// Outer glow ring
-			vector.StrokeCircle(screen, float32(sx), float32(sy), float32(p.Radius)+4, 2, glowColor, false)
+			if glow {
+				vector.StrokeCircle(screen, float32(sx), float32(sy), float32(p.Radius)+4, 2, glowColor, false)
+			}
 			// Inner spinning circles
 			orbCount := 3
 			for i := range orbCount {
@@ -2797,8 +3398,7 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 			for i := range 3 {
 				pulseOffset := math.Sin(g.gameTime*4+float64(i)*0.5) * 5
 				ringR := float64(p.Radius) + pulseOffset + float64(i)*8
-				ringAlpha := uint8(150 - i*40)
-				ringColor := color.RGBA{p.Color.R, p.Color.G, p.Color.B, ringAlpha}
+				ringColor := colorutil.WithAlpha(p.Color, uint8(150-i*40))
 				vector.StrokeCircle(screen, float32(sx), float32(sy), float32(ringR), 2, ringColor, false)
 			}
 			// Steam particles rising
@@ -2820,15 +3420,17 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 			// Container/box shape with glow
 			// Glow
 			boxSize := p.Radius * 1.5
-			vector.FillRect(
-				screen,
-				float32(sx-boxSize-2),
-				float32(sy-boxSize-2),
-				float32(boxSize*2+4),
-				float32(boxSize*2+4),
-				glowColor,
-				false,
-			)
+			if glow {
+				vector.FillRect(
+					screen,
+					float32(sx-boxSize-2),
+					float32(sy-boxSize-2),
+					float32(boxSize*2+4),
+					float32(boxSize*2+4),
+					glowColor,
+					false,
+				)
+			}
 			// Main container
 			vector.FillRect(
 				screen,
@@ -2878,8 +3480,7 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 			for i := range 3 {
 				waveOffset := math.Mod(g.gameTime*2+float64(i)*0.3, 1.0)
 				waveR := float64(p.Radius) * waveOffset
-				waveAlpha := uint8(float64(200) * (1 - waveOffset))
-				waveColor := color.RGBA{p.Color.R, p.Color.G, p.Color.B, waveAlpha}
+				waveColor := colorutil.WithAlpha(p.Color, uint8(200*(1-waveOffset)))
 				vector.StrokeCircle(screen, float32(sx), float32(sy), float32(waveR), 2, waveColor, false)
 			}
 			// Center checkmark
@@ -2909,7 +3510,9 @@ func (g *Game) drawProjectiles(screen *ebiten.Image) {
 		case WeaponPrint, WeaponLogStream:
 			// Text/console effect with glow
 			// Outer glow
-			vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius)+4, glowColor, false)
+			if glow {
+				vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius)+4, glowColor, false)
+			}
 			// Main circle
 			vector.FillCircle(screen, float32(sx), float32(sy), float32(p.Radius), p.Color, false)
 			// Console text lines (3 small rectangles)
@@ -2941,10 +3544,8 @@ func (g *Game) drawParticles(screen *ebiten.Image) {
 	for _, p := range g.particles {
 		sx, sy := p.X-g.cameraX, p.Y-g.cameraY
 		if sx >= -10 && sx <= screenWidth+10 && sy >= -10 && sy <= screenHeight+10 {
-			// Fade out alpha
-			alpha := float32(p.Lifetime / p.MaxLife)
-			c := p.Color
-			c.A = uint8(float32(c.A) * alpha)
+			// Fade out over the particle's life
+			c := colorutil.ScaleAlpha(p.Color, p.Lifetime/p.MaxLife)
 
 			vector.FillRect(
 				screen,
@@ -2967,26 +3568,34 @@ func (g *Game) drawHUD(screen *ebiten.Image) {
 	vector.FillCircle(screen, 30, 30, 22, Characters[g.player.CharType].Color, false)
 
 	// HP bar
-	vector.FillRect(screen, 60, 8, 200, 18, color.RGBA{R: 40, G: 40, B: 40, A: 255}, false)
-
-	hpRatio := float32(g.player.HP) / float32(g.player.MaxHP)
-	vector.FillRect(screen, 60, 8, 200*hpRatio, 18, color.RGBA{R: 200, G: 50, B: 50, A: 255}, false)
-	ebitenutil.DebugPrintAt(screen, formatInt(g.player.HP)+"/"+formatInt(g.player.MaxHP), 130, 10)
+	g.hpBar.Draw(screen)
+	g.drawShieldOverlay(screen)
+	drawText(screen, g.hpLabel(), 130, 10, hudText)
 
 	// XP bar
-	xpNeeded := g.player.Level * 25
-	xpRatio := float32(g.player.XP) / float32(xpNeeded)
-
-	vector.FillRect(screen, 60, 32, 200, 12, color.RGBA{R: 40, G: 40, B: 40, A: 255}, false)
-	vector.FillRect(screen, 60, 32, 200*xpRatio, 12, color.RGBA{R: 100, G: 200, B: 255, A: 255}, false)
+	g.xpBar.Draw(screen)
 
 	// Level
-	ebitenutil.DebugPrintAt(screen, "Lv "+formatInt(g.player.Level), 270, 20)
+	level := hudText
+	level.Font = text.Bold()
+	drawText(screen, "Lv "+formatInt(g.player.Level), 270, 20, level)
+	g.drawLevelBurst(screen)
 
 	// Time and kills
-	ebitenutil.DebugPrintAt(screen, "Time: "+formatTime(g.gameTime), 400, 10)
-	ebitenutil.DebugPrintAt(screen, "Kills: "+formatInt(g.killCount), 400, 30)
-	ebitenutil.DebugPrintAt(screen, "Enemies: "+formatInt(len(g.enemies)), 550, 10)
+	drawText(screen, "Time: "+formatTime(g.gameTime), 400, 10, hudText)
+	drawText(screen, "Kills: "+formatInt(g.killCount), 400, 30, hudText)
+	drawText(screen, "Enemies: "+formatInt(len(g.enemies)), 550, 10, hudText)
+
+	gold := hudText
+	gold.Color = CoinDefs[CoinGold].Color
+	drawText(screen, "Gold: "+formatInt(g.player.Gold), 550, 30, gold)
+
+	// Boss countdown, spawn phase, and upcoming waves
+	g.drawScheduleHUD(screen)
+	g.drawBossBar(screen)
+	g.drawCombo(screen)
+	g.drawSandbox(screen)
+	g.drawBudgets(screen)
 
 	// Weapon icons
 	for i, w := range g.player.Weapons {
@@ -3005,16 +3614,15 @@ func (g *Game) drawHUD(screen *ebiten.Image) {
 			vector.StrokeRect(screen, float32(x), float32(y), 50, 50, 2, color.RGBA{R: 255, G: 255, B: 255, A: 150}, false)
 		}
 
-		ebitenutil.DebugPrintAt(screen, formatInt(w.Level), x+38, y+35)
+		weaponLevel := level
+		weaponLevel.Align = text.AlignRight
+		drawText(screen, formatInt(w.Level), x+48, y+32, weaponLevel)
 	}
 
 	// Controls hint
-	ebitenutil.DebugPrintAt(
-		screen,
-		"H=Help | I=Equip | P=Passives | ESC=Pause",
-		screenWidth-320,
-		screenHeight-20,
-	)
+	hint := smallText()
+	hint.Align, hint.Shadow = text.AlignRight, true
+	drawText(screen, "H=Help | I=Equip | P=Passives | L=Log | ESC=Pause", screenWidth-10, screenHeight-18, hint)
 }
 
 func (g *Game) drawLevelUp(screen *ebiten.Image) {
@@ -3028,19 +3636,37 @@ func (g *Game) drawLevelUp(screen *ebiten.Image) {
 		false,
 	)
 
-	boxW, boxH := float32(500), float32(300)
-	boxX, boxY := float32(screenWidth-500)/2, float32(screenHeight-300)/2
+	boxW, boxH := float32(500), float32(levelUpBoxH)
+	boxX, boxY := float32(screenWidth-500)/2, float32(screenHeight-levelUpBoxH)/2
+
+	g.uiSkin().LevelUp.Draw(screen, float64(boxX), float64(boxY), float64(boxW), float64(boxH))
+
+	title := "LEVEL UP! Choose an upgrade:"
+	if g.player.Tokens.Banishing {
+		title = "BANISH: pick an option to remove"
+	}
+
+	drawText(screen, title, int(boxX+boxW/2), int(boxY)+12, headingText())
+
+	if n := g.player.PendingLevels; n > 0 {
+		drawText(screen, "+"+formatInt(n)+" queued", int(boxX)+20, int(boxY)+15, smallText())
+	}
 
-	vector.FillRect(screen, boxX, boxY, boxW, boxH, color.RGBA{R: 30, G: 35, B: 50, A: 255}, false)
-	vector.StrokeRect(screen, boxX, boxY, boxW, boxH, 3, color.RGBA{R: 255, G: 215, B: 0, A: 255}, false)
+	gold := text.Options{Color: CoinDefs[CoinGold].Color, Align: text.AlignRight}
+	drawText(screen, "Gold: "+formatInt(g.player.Gold), int(boxX+boxW)-20, int(boxY)+15, gold)
 
-	ebitenutil.DebugPrintAt(screen, "LEVEL UP! Choose an upgrade:", int(boxX)+140, int(boxY)+15)
+	desc := smallText()
+	desc.Width = float64(boxW) - 150
 
 	for i, opt := range g.upgradeOptions {
 		y := int(boxY) + 55 + i*60
 
 		// Option box
 		optColor := color.RGBA{R: 50, G: 55, B: 70, A: 255}
+		if i == g.previewFocus() {
+			optColor = color.RGBA{R: 70, G: 78, B: 100, A: 255}
+		}
+
 		vector.FillRect(screen, boxX+20, float32(y)-5, boxW-40, 55, optColor, false)
 
 		// Icon
@@ -3060,7 +3686,9 @@ func (g *Game) drawLevelUp(screen *ebiten.Image) {
 		}
 
 		// Number (far right)
-		ebitenutil.DebugPrintAt(screen, "["+formatInt(i+1)+"]", int(boxX+boxW)-40, y+20)
+		key := smallText()
+		key.Align = text.AlignRight
+		drawText(screen, "["+formatInt(i+1)+"]", int(boxX+boxW)-28, y+20, key)
 
 		// Name and level
 		lvlText := ""
@@ -3068,9 +3696,12 @@ func (g *Game) drawLevelUp(screen *ebiten.Image) {
 			lvlText = " (Lv " + formatInt(opt.CurrentLvl+1) + ")"
 		}
 
-		ebitenutil.DebugPrintAt(screen, opt.Name+lvlText, int(boxX)+80, y+5)
-		ebitenutil.DebugPrintAt(screen, opt.Desc, int(boxX)+80, y+25)
+		drawText(screen, opt.Name+lvlText, int(boxX)+80, y+3, text.Options{Font: text.Bold()})
+		drawText(screen, opt.Desc, int(boxX)+80, y+22, desc)
 	}
+
+	g.drawLevelUpTokens(screen)
+	g.drawWeaponPreview(screen)
 }
 
 func (g *Game) drawPaused(screen *ebiten.Image) {
@@ -3084,15 +3715,13 @@ func (g *Game) drawPaused(screen *ebiten.Image) {
 		false,
 	)
 
-	boxW, boxH := float32(300), float32(180)
-	boxX, boxY := float32(screenWidth-300)/2, float32(screenHeight-180)/2
+	panel := ui.CenteredPanel(screenWidth, screenHeight, 300, 180, "PAUSED")
+	panel.Skin = &g.uiSkin().Skin
+	panel.Draw(screen)
 
-	vector.FillRect(screen, boxX, boxY, boxW, boxH, color.RGBA{R: 40, G: 45, B: 60, A: 255}, false)
-	vector.StrokeRect(screen, boxX, boxY, boxW, boxH, 2, color.RGBA{R: 200, G: 200, B: 200, A: 255}, false)
-
-	ebitenutil.DebugPrintAt(screen, "PAUSED", int(boxX)+115, int(boxY)+30)
-	ebitenutil.DebugPrintAt(screen, "SPACE / ESC - Resume", int(boxX)+70, int(boxY)+80)
-	ebitenutil.DebugPrintAt(screen, "Q - Quit to Menu", int(boxX)+85, int(boxY)+110)
+	hint := "ESC resume  Q quit"
+	ui.DebugPrintAt(screen, hint, int(panel.X)+(300-len(hint)*6)/2, int(panel.Y)+40)
+	g.pauseList().Draw(screen)
 }
 
 func (g *Game) drawGameOver(screen *ebiten.Image) {
@@ -3106,26 +3735,42 @@ func (g *Game) drawGameOver(screen *ebiten.Image) {
 		false,
 	)
 
-	boxW, boxH := float32(350), float32(250)
-	boxX, boxY := float32(screenWidth-350)/2, float32(screenHeight-250)/2
+	boxW, boxH := float32(350), float32(310)
+	boxX, boxY := float32(screenWidth-350)/2, float32(screenHeight-310)/2
 
-	vector.FillRect(screen, boxX, boxY, boxW, boxH, color.RGBA{R: 50, G: 30, B: 30, A: 255}, false)
-	vector.StrokeRect(screen, boxX, boxY, boxW, boxH, 3, color.RGBA{R: 200, G: 50, B: 50, A: 255}, false)
+	g.uiSkin().GameOver.Draw(screen, float64(boxX), float64(boxY), float64(boxW), float64(boxH))
 
-	ebitenutil.DebugPrintAt(screen, "GAME OVER", int(boxX)+120, int(boxY)+25)
+	ui.DebugPrintAt(screen, "GAME OVER", int(boxX)+120, int(boxY)+25)
 
-	ebitenutil.DebugPrintAt(screen, "Survived: "+formatTime(g.gameTime), int(boxX)+100, int(boxY)+70)
-	ebitenutil.DebugPrintAt(screen, "Level: "+formatInt(g.player.Level), int(boxX)+120, int(boxY)+95)
-	ebitenutil.DebugPrintAt(screen, "Kills: "+formatInt(g.killCount), int(boxX)+120, int(boxY)+120)
-	ebitenutil.DebugPrintAt(
+	ui.DebugPrintAt(screen, "Survived: "+formatTime(g.gameTime), int(boxX)+100, int(boxY)+70)
+	ui.DebugPrintAt(screen, "Level: "+formatInt(g.player.Level), int(boxX)+120, int(boxY)+95)
+	ui.DebugPrintAt(screen, "Kills: "+formatInt(g.killCount), int(boxX)+120, int(boxY)+120)
+	ui.DebugPrintAt(
 		screen,
 		"Weapons: "+formatInt(len(g.player.Weapons)),
 		int(boxX)+110,
 		int(boxY)+145,
 	)
 
-	ebitenutil.DebugPrintAt(screen, "SPACE - Retry", int(boxX)+110, int(boxY)+185)
-	ebitenutil.DebugPrintAt(screen, "Q - Character Select", int(boxX)+85, int(boxY)+210)
+	if note := g.runNote(); note != "" {
+		ui.DebugPrintAt(screen, note, int(boxX)+(350-len(note)*6)/2, int(boxY)+162)
+	}
+
+	code := g.runCodeLine()
+	ui.DebugPrintAt(screen, code, int(boxX)+(350-len(code)*6)/2, int(boxY)+185)
+
+	ui.DebugPrintAt(screen, "SPACE - Retry", int(boxX)+110, int(boxY)+215)
+	ui.DebugPrintAt(screen, "R - Replay Seed", int(boxX)+105, int(boxY)+235)
+	ui.DebugPrintAt(screen, "Q - Character Select", int(boxX)+85, int(boxY)+255)
+
+	if !g.buildShared {
+		ui.DebugPrintAt(screen, "E - Export Build", int(boxX)+105, int(boxY)+275)
+
+		return
+	}
+
+	ui.DebugPrintAt(screen, "Build exported to "+buildCodeFile+":", int(boxX)+85, int(boxY)+275)
+	g.drawBuildCode(screen, int(boxY+boxH)+10)
 }
 
 // ============================================================================
@@ -3145,8 +3790,8 @@ func (g *Game) drawEquipment(screen *ebiten.Image) {
 	)
 
 	// Main panel
-	panelW, panelH := float32(700), float32(500)
-	panelX, panelY := float32(screenWidth-700)/2, float32(screenHeight-500)/2
+	panelW, panelH := float32(700), float32(640)
+	panelX, panelY := (screenWidth-panelW)/2, (screenHeight-panelH)/2
 
 	vector.FillRect(
 		screen,
@@ -3169,7 +3814,11 @@ func (g *Game) drawEquipment(screen *ebiten.Image) {
 	)
 
 	// Title
-	ebitenutil.DebugPrintAt(screen, "EQUIPMENT (Press I to close)", int(panelX)+250, int(panelY)+10)
+	drawText(screen, "EQUIPMENT", int(panelX+panelW/2), int(panelY)+8, headingText())
+
+	hint := smallText()
+	hint.Align = text.AlignRight
+	drawText(screen, "I: close", int(panelX+panelW)-12, int(panelY)+12, hint)
 
 	// Equipment slots on the left
 	slotStartX := panelX + 30
@@ -3199,30 +3848,33 @@ func (g *Game) drawEquipment(screen *ebiten.Image) {
 		)
 
 		// Slot name
-		ebitenutil.DebugPrintAt(screen, EquipSlotNames[slot]+":", int(slotStartX)+5, int(y)+5)
+		drawText(screen, EquipSlotNames[slot], int(slotStartX)+5, int(y)+4, smallText())
 
 		// Equipped item
 		if equip := g.player.Equipment[slot]; equip != nil {
-			itemCol := RarityColors[equip.Rarity]
-			ebitenutil.DebugPrintAt(screen, equip.Name, int(slotStartX)+5, int(y)+22)
+			itemCol := RarityColor(equip.Rarity)
+			drawText(screen, equip.Name, int(slotStartX)+5, int(y)+18, text.Options{Font: text.Bold(), Color: itemCol})
 			vector.FillRect(screen, slotStartX+slotW-30, y+5, 25, 25, itemCol, false)
 			// Show mod count
-			ebitenutil.DebugPrintAt(
-				screen,
-				formatInt(len(equip.Modifiers))+" mods",
-				int(slotStartX)+5,
-				int(y)+39,
-			)
+			drawText(screen, formatInt(len(equip.Modifiers))+" mods", int(slotStartX)+5, int(y)+37, smallText())
 		} else {
-			ebitenutil.DebugPrintAt(screen, "(empty)", int(slotStartX)+5, int(y)+25)
+			drawText(screen, "(empty)", int(slotStartX)+5, int(y)+22, smallText())
+		}
+
+		if g.bestUpgrade(slot) >= 0 {
+			drawUpgradeArrow(screen, slotStartX+slotW-45, y+10)
 		}
 	}
 
+	// Active buffs and debuffs under the slots
+	g.drawStatusList(screen, int(slotStartX), int(slotStartY+float32(SlotCount)*slotH)+10)
+
 	// Inventory on the right
 	invStartX := panelX + 320
 	invStartY := panelY + 50
 
-	ebitenutil.DebugPrintAt(screen, "Inventory:", int(invStartX), int(invStartY)-20)
+	drawText(screen, "Inventory", int(invStartX), int(invStartY)-22, text.Options{Font: text.Bold()})
+	g.drawInventoryCount(screen, int(invStartX)+335, int(invStartY)-20)
 
 	itemW, itemH := float32(80), float32(70)
 	cols := 4
@@ -3241,30 +3893,53 @@ func (g *Game) drawEquipment(screen *ebiten.Image) {
 		}
 
 		vector.FillRect(screen, x, y, itemW, itemH, bgCol, false)
-		vector.StrokeRect(screen, x, y, itemW, itemH, 1, RarityColors[item.Rarity], false)
+		vector.StrokeRect(screen, x, y, itemW, itemH, 1, RarityColor(item.Rarity), false)
+
+		// Item name wrapped to two lines, in its rarity color
+		name := smallText()
+		name.Color, name.Width = RarityColor(item.Rarity), float64(itemW)-4
+		lines := text.Wrap(item.Name, name)
+		drawText(screen, strings.Join(lines[:min(len(lines), 2)], "\n"), int(x)+2, int(y)+3, name)
 
-		// Item info (truncated)
-		name := item.Name
-		if len(name) > 10 {
-			name = name[:10] + "..."
+		drawText(screen, EquipSlotNames[item.Slot], int(x)+2, int(y)+33, smallText())
+		drawText(screen, formatInt(len(item.Modifiers))+" mods", int(x)+2, int(y)+48, smallText())
+
+		if g.isUpgrade(item) {
+			drawUpgradeArrow(screen, x+itemW-10, y+itemH-18)
 		}
 
-		ebitenutil.DebugPrintAt(screen, name, int(x)+2, int(y)+5)
-		ebitenutil.DebugPrintAt(screen, EquipSlotNames[item.Slot], int(x)+2, int(y)+22)
-		ebitenutil.DebugPrintAt(screen, formatInt(len(item.Modifiers))+" mods", int(x)+2, int(y)+39)
+		if item.Junk {
+			drawJunkMark(screen, x, y, itemW, itemH)
+		}
 	}
 
 	if len(g.player.Inventory) == 0 {
-		ebitenutil.DebugPrintAt(screen, "No items", int(invStartX)+50, int(invStartY)+50)
+		drawText(screen, "No items", int(invStartX)+50, int(invStartY)+50, smallText())
 	}
 
 	// Instructions
-	ebitenutil.DebugPrintAt(
+	hint.Align = text.AlignCenter
+	drawText(
+		screen,
+		"S: Sort | J: Mark Junk | X: Salvage | SHIFT+X: Salvage Junk | C: Craft",
+		int(panelX+panelW/2),
+		int(panelY+panelH-45),
+		hint,
+	)
+	drawText(
 		screen,
-		"UP/DOWN: Select Slot | LEFT/RIGHT: Select Item | ENTER: Equip",
-		int(panelX)+100,
+		"UP/DOWN: Select Slot | LEFT/RIGHT: Select Item | ENTER: Equip | B: Equip Best",
+		int(panelX+panelW/2),
 		int(panelY+panelH-25),
+		hint,
 	)
+
+	// The selected item's card goes over everything else
+	if i := g.selectedInvIndex; i >= 0 && i < len(g.player.Inventory) {
+		x := invStartX + float32(i%cols)*(itemW+5)
+		y := invStartY + float32(i/cols)*(itemH+5)
+		g.drawItemCard(screen, g.player.Inventory[i], x, y, itemW)
+	}
 }
 
 // ============================================================================
@@ -3272,73 +3947,63 @@ func (g *Game) drawEquipment(screen *ebiten.Image) {
 // ============================================================================
 
 func (g *Game) drawPassiveTree(screen *ebiten.Image) {
-	// Dark background
-	screen.Fill(color.RGBA{R: 15, G: 15, B: 25, A: 255})
+	screen.Fill(ui.CurrentTheme().Palette.Background)
 
-	centerX := float64(screenWidth) / 2
-	centerY := float64(screenHeight) / 2
-	scale := 60.0 // Pixels per tree grid unit
+	view := &g.treeView
+	zoom := view.scale()
+
+	byID := make(map[int]*PassiveNode, len(g.passiveTree))
+	for _, node := range g.passiveTree {
+		byID[node.ID] = node
+	}
 
-	// Draw connections first
+	// Draw connections first, each once
 	for _, node := range g.passiveTree {
-		nodeX := centerX + node.X*scale
-		nodeY := centerY + node.Y*scale
+		nodeX, nodeY := view.toScreen(node.X, node.Y)
 
 		for _, connID := range node.Connections {
-			// Find connected node
-			for _, other := range g.passiveTree {
-				if other.ID == connID {
-					otherX := centerX + other.X*scale
-					otherY := centerY + other.Y*scale
-
-					// Connection color based on allocation
-					connCol := color.RGBA{R: 50, G: 50, B: 60, A: 255}
-					if g.player.AllocatedNodes[node.ID] && g.player.AllocatedNodes[other.ID] {
-						connCol = color.RGBA{R: 100, G: 150, B: 200, A: 255}
-					} else if g.player.AllocatedNodes[node.ID] || g.player.AllocatedNodes[other.ID] {
-						connCol = color.RGBA{R: 80, G: 100, B: 120, A: 255}
-					}
+			other := byID[connID]
+			if other == nil || connID < node.ID {
+				continue
+			}
 
-					vector.StrokeLine(
-						screen,
-						float32(nodeX),
-						float32(nodeY),
-						float32(otherX),
-						float32(otherY),
-						2,
-						connCol,
-						false,
-					)
+			otherX, otherY := view.toScreen(other.X, other.Y)
 
-					break
-				}
+			// Connection color based on allocation
+			connCol := color.RGBA{R: 50, G: 50, B: 60, A: 255}
+			if g.player.AllocatedNodes[node.ID] && g.player.AllocatedNodes[other.ID] {
+				connCol = color.RGBA{R: 100, G: 150, B: 200, A: 255}
+			} else if g.player.AllocatedNodes[node.ID] || g.player.AllocatedNodes[other.ID] {
+				connCol = color.RGBA{R: 80, G: 100, B: 120, A: 255}
 			}
+
+			vector.StrokeLine(
+				screen,
+				float32(nodeX),
+				float32(nodeY),
+				float32(otherX),
+				float32(otherY),
+				float32(max(2*zoom, 1)),
+				connCol,
+				false,
+			)
 		}
 	}
 
 	// Draw nodes
 	mx, my := ebiten.CursorPosition()
-
-	var hoveredNode *PassiveNode
+	hoveredNode := g.treeNodeAt(float64(mx), float64(my))
 
 	for _, node := range g.passiveTree {
-		nodeX := centerX + node.X*scale
-		nodeY := centerY + node.Y*scale
+		nodeX, nodeY := view.toScreen(node.X, node.Y)
 
 		// Node size based on type
-		nodeRadius := 12.0
-
-		switch node.NodeType {
-		case NodeNotable:
-			nodeRadius = 18.0
-		case NodeKeystone:
-			nodeRadius = 24.0
-		}
+		nodeRadius := treeNodeRadius(node) * zoom
 
-		// Check hover
-		dist := math.Sqrt(math.Pow(float64(mx)-nodeX, 2) + math.Pow(float64(my)-nodeY, 2))
-		if dist < nodeRadius {
-			hoveredNode = node
+		// Skip nodes panned off screen
+		if nodeX < -nodeRadius || nodeY < -nodeRadius ||
+			nodeX > screenWidth+nodeRadius || nodeY > screenHeight+nodeRadius {
+			continue
 		}
 
 		// Node color
@@ -3406,10 +4071,22 @@ func (g *Game) drawPassiveTree(screen *ebiten.Image) {
 
 	// Tooltip for hovered node
 	if hoveredNode != nil {
+		refund := ""
+		if g.player.AllocatedNodes[hoveredNode.ID] {
+			refund = g.refundBlocker(hoveredNode)
+			if refund == "" {
+				refund = "Right-click: refund for " + formatInt(g.player.respecCost()) + " gold"
+			}
+		}
+
 		ttX, ttY := float32(mx+15), float32(my+15)
-		ttW := float32(200)
+		ttW := float32(240)
 		ttH := float32(60 + len(hoveredNode.Effects)*15)
 
+		if refund != "" {
+			ttH += 15
+		}
+
 		// Clamp to screen
 		if ttX+ttW > screenWidth {
 			ttX = screenWidth - ttW - 5
@@ -3422,43 +4099,72 @@ func (g *Game) drawPassiveTree(screen *ebiten.Image) {
 		vector.FillRect(screen, ttX, ttY, ttW, ttH, color.RGBA{R: 20, G: 20, B: 30, A: 240}, false)
 		vector.StrokeRect(screen, ttX, ttY, ttW, ttH, 1, color.RGBA{R: 100, G: 100, B: 150, A: 255}, false)
 
-		ebitenutil.DebugPrintAt(screen, hoveredNode.Name, int(ttX)+5, int(ttY)+5)
+		ui.DebugPrintAt(screen, hoveredNode.Name, int(ttX)+5, int(ttY)+5)
 
 		if hoveredNode.Desc != "" {
-			ebitenutil.DebugPrintAt(screen, hoveredNode.Desc, int(ttX)+5, int(ttY)+22)
+			ui.DebugPrintAt(screen, hoveredNode.Desc, int(ttX)+5, int(ttY)+22)
 		}
 
 		// Show effects
 		for i, mod := range hoveredNode.Effects {
-			modText := ModTypeNames[mod.Type]
-			modText = modText[:3] + formatFloat(mod.Value) + modText[4:]
-			ebitenutil.DebugPrintAt(screen, modText, int(ttX)+5, int(ttY)+40+i*15)
+			ui.DebugPrintAt(screen, mod.String(), int(ttX)+5, int(ttY)+40+i*15)
+		}
+
+		if refund != "" {
+			ui.DebugPrintAt(screen, refund, int(ttX)+5, int(ttY)+40+len(hoveredNode.Effects)*15)
 		}
 	}
 
 	// UI overlay
 	// Top bar
 	vector.FillRect(screen, 0, 0, screenWidth, 40, color.RGBA{R: 20, G: 20, B: 30, A: 220}, false)
-	ebitenutil.DebugPrintAt(screen, "PASSIVE TREE (Press P to close)", 10, 10)
-	ebitenutil.DebugPrintAt(screen, "Points: "+formatInt(g.player.PassivePoints), screenWidth-120, 10)
-	ebitenutil.DebugPrintAt(screen, "Click nodes to allocate", screenWidth/2-70, 10)
+	ui.DebugPrintAt(screen, "PASSIVE TREE (Press P to close)", 10, 10)
+	ui.DebugPrintAt(screen, "Points: "+formatInt(g.player.PassivePoints), screenWidth-120, 10)
+	ui.DebugPrintAt(screen, "Gold: "+formatInt(g.player.Gold), screenWidth-120, 24)
+	ui.DebugPrintAt(screen, "Click: allocate  Right-click: refund", screenWidth/2-110, 4)
+	ui.DebugPrintAt(screen, "WASD/middle-drag: pan  Wheel/+-: zoom  0: reset", screenWidth/2-145, 20)
 }
 
 // ============================================================================
 // HELP SCREEN
 // ============================================================================
 
+// Help screen pages, switched with Tab: the settings rows would not fit on
+// one panel alongside the guide.
+const (
+	helpPageSettings = iota
+	helpPageGuide
+	helpPageCount
+)
+
 func (g *Game) updateHelp() error {
+	if g.rebinder != nil {
+		if g.rebinder.Update() {
+			g.rebinder = nil
+		}
+
+		return nil
+	}
+
 	// ESC or H to close
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyH) {
 		g.state = StatePlaying
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		g.helpPage = (g.helpPage + 1) % helpPageCount
+		g.audio.PlaySound("select")
+	}
+
+	if g.helpPage != helpPageSettings {
+		return nil
+	}
+
 	// Selection
 	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
 		g.helpSelection--
 		if g.helpSelection < 0 {
-			g.helpSelection = 1
+			g.helpSelection = helpRowCount - 1
 		}
 
 		g.audio.PlaySound("select")
@@ -3466,33 +4172,24 @@ func (g *Game) updateHelp() error {
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
 		g.helpSelection++
-		if g.helpSelection > 1 {
+		if g.helpSelection >= helpRowCount {
 			g.helpSelection = 0
 		}
 
 		g.audio.PlaySound("select")
 	}
 
-	// Adjust Volume
-	switch g.helpSelection {
-	case 0: // SFX
-		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
-			g.audio.SetSFXVolume(g.audio.SFXVolume() - 0.1)
-			g.audio.PlaySound("select")
-		}
+	// Adjust the selected setting
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+		g.adjustSetting(g.helpSelection, -1)
+	}
 
-		if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
-			g.audio.SetSFXVolume(g.audio.SFXVolume() + 0.1)
-			g.audio.PlaySound("select")
-		}
-	case 1: // Music
-		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
-			g.audio.SetMusicVolume(g.audio.MusicVolume() - 0.1)
-		}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		g.adjustSetting(g.helpSelection, 1)
+	}
 
-		if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
-			g.audio.SetMusicVolume(g.audio.MusicVolume() + 0.1)
-		}
+	if g.helpSelection == helpRowControls && inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.adjustSetting(g.helpSelection, 1)
 	}
 
 	return nil
@@ -3511,45 +4208,47 @@ func (g *Game) drawHelp(screen *ebiten.Image) {
 	)
 
 	// Main panel
-	panelW, panelH := float32(500), float32(450)
-	panelX, panelY := float32(screenWidth-500)/2, float32(screenHeight-450)/2
+	panelW, panelH := float32(500), float32(680)
+	panelX, panelY := float32(screenWidth-500)/2, float32(screenHeight-680)/2
 
-	vector.FillRect(
-		screen,
-		panelX,
-		panelY,
-		panelW,
-		panelH,
-		color.RGBA{R: 25, G: 30, B: 40, A: 255},
-		false,
-	)
-	vector.StrokeRect(
-		screen,
-		panelX,
-		panelY,
-		panelW,
-		panelH,
-		3,
-		color.RGBA{R: 100, G: 200, B: 255, A: 255},
-		false,
-	)
+	g.uiSkin().Help.Draw(screen, float64(panelX), float64(panelY), float64(panelW), float64(panelH))
 
-	// Title
-	ebitenutil.DebugPrintAt(screen, "=== CONTROLS & HELP ===", int(panelX)+150, int(panelY)+15)
+	if g.rebinder != nil {
+		ui.DebugPrintAt(screen, "=== CONTROLS ===", int(panelX)+190, int(panelY)+15)
+		g.rebinder.X, g.rebinder.Y = float64(panelX)+30, float64(panelY)+60
+		g.rebinder.Draw(screen)
+
+		return
+	}
+
+	if g.helpPage == helpPageGuide {
+		ui.DebugPrintAt(screen, "=== HELP ===", int(panelX)+214, int(panelY)+15)
+		g.drawHelpGuide(screen, panelX, int(panelY)+50)
+	} else {
+		ui.DebugPrintAt(screen, "=== SETTINGS ===", int(panelX)+202, int(panelY)+15)
+		g.drawHelpSettings(screen, panelX, int(panelY)+50)
+	}
 
-	y := int(panelY) + 50
+	// Close hint
+	ui.DebugPrintAt(screen, "TAB settings / help | H or ESC to close", int(panelX)+112, int(panelY+panelH-30))
+}
+
+// drawHelpSettings draws the settings page of the help screen from y down,
+// inside the panel starting at panelX.
+func (g *Game) drawHelpSettings(screen *ebiten.Image, panelX float32, y int) {
+	palette := ui.CurrentTheme().Palette
 
 	// Volume Control Section
-	ebitenutil.DebugPrintAt(screen, "-- AUDIO SETTINGS --", int(panelX)+175, y)
+	ui.DebugPrintAt(screen, "-- AUDIO & VIDEO --", int(panelX)+178, y)
 	y += 25
 
 	// SFX Volume
-	sfxCol := color.RGBA{R: 150, G: 150, B: 150, A: 255}
-	if g.helpSelection == 0 {
-		sfxCol = color.RGBA{R: 255, G: 255, B: 100, A: 255}
+	sfxCol := palette.TextMuted
+	if g.helpSelection == helpRowSFX {
+		sfxCol = palette.Highlight
 	}
 
-	ebitenutil.DebugPrintAt(screen, "SFX Volume:", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "SFX Volume:", int(panelX)+30, y)
 	vector.FillRect(
 		screen,
 		panelX+130,
@@ -3571,12 +4270,12 @@ func (g *Game) drawHelp(screen *ebiten.Image) {
 	y += 20
 
 	// Music Volume
-	musicCol := color.RGBA{R: 150, G: 150, B: 150, A: 255}
-	if g.helpSelection == 1 {
-		musicCol = color.RGBA{R: 255, G: 255, B: 100, A: 255}
+	musicCol := palette.TextMuted
+	if g.helpSelection == helpRowMusic {
+		musicCol = palette.Highlight
 	}
 
-	ebitenutil.DebugPrintAt(screen, "Music Volume:", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "Music Volume:", int(panelX)+30, y)
 	vector.FillRect(
 		screen,
 		panelX+130,
@@ -3595,52 +4294,93 @@ func (g *Game) drawHelp(screen *ebiten.Image) {
 		musicCol,
 		false,
 	)
+	y += 20
+
+	// Theme
+	themeLabel := "Theme:        < " + ui.CurrentTheme().Name + " >"
+	if g.helpSelection == helpRowTheme {
+		themeLabel = "Theme:      > < " + ui.CurrentTheme().Name + " >"
+	}
+
+	ui.DebugPrintAt(screen, themeLabel, int(panelX)+30, y)
+	y += 20
 
+	// Graphics quality
+	quality := g.graphics().Name
+	graphicsLabel := "Graphics:     < " + quality + " >"
+	if g.helpSelection == helpRowGraphics {
+		graphicsLabel = "Graphics:   > < " + quality + " >"
+	}
+
+	ui.DebugPrintAt(screen, graphicsLabel, int(panelX)+30, y)
+	y += 20
+
+	// Controller rumble
+	rumbleLabel := "Rumble:       < " + g.rumbleLabel() + " >"
+	if g.helpSelection == helpRowRumble {
+		rumbleLabel = "Rumble:     > < " + g.rumbleLabel() + " >"
+	}
+
+	ui.DebugPrintAt(screen, rumbleLabel, int(panelX)+30, y)
 	y += 30
-	ebitenutil.DebugPrintAt(screen, "(UP/DOWN to select | LEFT/RIGHT to adjust)", int(panelX)+80, y)
-	y += 35
 
-	// Movement section
-	ebitenutil.DebugPrintAt(screen, "-- MOVEMENT --", int(panelX)+180, y)
+	// Assist and game speed options
+	ui.DebugPrintAt(screen, "-- ACCESSIBILITY --", int(panelX)+175, y)
 	y += 25
-	ebitenutil.DebugPrintAt(screen, "WASD / Arrow Keys    Move character", int(panelX)+30, y)
-	y += 20
-	ebitenutil.DebugPrintAt(screen, "ESC                  Pause game", int(panelX)+30, y)
-	y += 35
+	y = g.drawAssistSettings(screen, int(panelX)+30, y)
+
+	// Controls section
+	ui.DebugPrintAt(screen, "-- CONTROLS --", int(panelX)+180, y)
+	y += 25
+	y = g.drawRebindRow(screen, int(panelX)+30, y)
+	y += 15
+
+	ui.DebugPrintAt(screen, "(UP/DOWN to select | LEFT/RIGHT to adjust)", int(panelX)+80, y)
+}
+
+// drawHelpGuide draws the guide page of the help screen: the current
+// bindings and how the screens, gear, and passive tree work.
+func (g *Game) drawHelpGuide(screen *ebiten.Image, panelX float32, y int) {
+	// Controls section, as currently bound
+	ui.DebugPrintAt(screen, "-- CONTROLS --", int(panelX)+180, y)
+	y += 25
+	y = g.drawControlsHelp(screen, int(panelX)+30, y)
+	y += 15
 
 	// Screens section
-	ebitenutil.DebugPrintAt(screen, "-- SCREENS --", int(panelX)+185, y)
+	ui.DebugPrintAt(screen, "-- SCREENS --", int(panelX)+185, y)
 	y += 25
-	ebitenutil.DebugPrintAt(screen, "H                    Help (this screen)", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "H                    Help (this screen)", int(panelX)+30, y)
+	y += 20
+	ui.DebugPrintAt(screen, "I / B                Equipment / equip best gear", int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "I                    Equipment/Inventory", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "P / C                Passive Skill Tree / stats", int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "P                    Passive Skill Tree", int(panelX)+30, y)
-	y += 35
+	ui.DebugPrintAt(screen, "L                    Combat log (F1-F6 filter, F8 export)", int(panelX)+30, y)
+	y += 20
+	ui.DebugPrintAt(screen, "V                    Toggle upcoming wave preview", int(panelX)+30, y)
+	y += 25
 
 	// Equipment section
-	ebitenutil.DebugPrintAt(screen, "-- EQUIPMENT --", int(panelX)+175, y)
+	ui.DebugPrintAt(screen, "-- EQUIPMENT --", int(panelX)+175, y)
 	y += 25
-	ebitenutil.DebugPrintAt(screen, "Kill bosses (every 3 min) for guaranteed drops", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "Kill bosses (every 3 min) for guaranteed drops", int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "Elite enemies have 5% drop chance", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, lootHelp(eliteLoot), int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "In Equipment screen: Arrow keys to select,", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "In Equipment screen: Arrow keys to select,", int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "                     Enter to equip item", int(panelX)+30, y)
-	y += 35
+	ui.DebugPrintAt(screen, "                     Enter to equip item", int(panelX)+30, y)
+	y += 25
 
 	// Passive Tree section
-	ebitenutil.DebugPrintAt(screen, "-- PASSIVE TREE --", int(panelX)+165, y)
+	ui.DebugPrintAt(screen, "-- PASSIVE TREE --", int(panelX)+165, y)
 	y += 25
-	ebitenutil.DebugPrintAt(screen, "Gain +1 point per level", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "Gain +1 point per level", int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "Click nodes to allocate (must be connected)", int(panelX)+30, y)
+	ui.DebugPrintAt(screen, "Click nodes to allocate (must be connected)", int(panelX)+30, y)
 	y += 20
-	ebitenutil.DebugPrintAt(screen, "Hover nodes to see effects", int(panelX)+30, y)
-
-	// Close hint
-	ebitenutil.DebugPrintAt(screen, "Press H or ESC to close", int(panelX)+160, int(panelY+panelH-30))
+	ui.DebugPrintAt(screen, "Right-click to refund for gold; wheel zooms", int(panelX)+30, y)
 }
 
 func formatFloat(f float64) string {
@@ -3685,38 +4425,13 @@ func (g *Game) generateIcons() {
 	g.weaponImages = make(map[WeaponType]*ebiten.Image)
 	g.passiveImages = make(map[PassiveType]*ebiten.Image)
 
-	const iconSize = 64
-
-	// Helper function to load and scale an icon image from file
+	// Icons decoded and scaled by the background loader, if available
 	loadIconImage := func(imageFile string) *ebiten.Image {
-		if imageFile == "" {
-			return nil
-		}
-
-		data, err := assetsFS.ReadFile(imageFile)
-		if err != nil {
-			return nil
-		}
-
-		img, _, err := image.Decode(bytes.NewReader(data))
-		if err != nil {
+		if imageFile == "" || g.loader == nil {
 			return nil
 		}
-		// Scale to icon size
-		scaled := image.NewRGBA(image.Rect(0, 0, iconSize, iconSize))
-		srcBounds := img.Bounds()
-		sx := float64(srcBounds.Dx()) / float64(iconSize)
-
-		sy := float64(srcBounds.Dy()) / float64(iconSize)
-		for y := range iconSize {
-			for x := range iconSize {
-				srcX := int(float64(x) * sx)
-				srcY := int(float64(y) * sy)
-				scaled.Set(x, y, img.At(srcBounds.Min.X+srcX, srcBounds.Min.Y+srcY))
-			}
-		}
 
-		return ebiten.NewImageFromImage(scaled)
+		return g.loader.Image(iconKey(imageFile))
 	}
 
 	// Weapons - try loading from file first
@@ -3845,6 +4560,12 @@ func (g *Game) generateWeaponIcon(t WeaponType, def WeaponDef, size int) *ebiten
 		// Red aura
 		vector.FillCircle(img, cx, cy, 20, c, false)
 		vector.StrokeCircle(img, cx, cy, 25, 3, color.White, false)
+
+	case WeaponMemoryLeak:
+		// Dripping blob
+		vector.FillCircle(img, cx, cy-4, 14, c, false)
+		vector.FillCircle(img, cx-8, cy+14, 4, c, false)
+		vector.FillCircle(img, cx+6, cy+18, 3, c, false)
 	}
 
 	return img
@@ -3904,6 +4625,9 @@ func (g *Game) generatePassiveIcon(t PassiveType, size int) *ebiten.Image {
 		vector.StrokeLine(img, cx, cy-5, cx, cy+15, 4, color.RGBA{255, 200, 50, 255}, false)
 		vector.StrokeLine(img, cx-10, cy+5, cx+10, cy+5, 4, color.RGBA{255, 200, 50, 255}, false)
 		vector.StrokeCircle(img, cx, cy-10, 6, 3, color.RGBA{255, 200, 50, 255}, false)
+	case PassiveShield: // Bubble
+		vector.StrokeCircle(img, cx, cy, 15, 3, shieldColor, false)
+		vector.FillCircle(img, cx, cy, 9, color.RGBA{80, 170, 255, 120}, false)
 	}
 
 	return img
@@ -3974,12 +4698,40 @@ func (g *Game) freeDamageNumber(d *DamageNumber) {
 // falls back to edge-detection for images without a magenta background.
 
 func main() {
+	if content.Requested(os.Args[1:]) {
+		os.Exit(content.Run(os.Stdout, "survivor", validateContent))
+	}
+
+	if prefix, ok := spawnReportRequested(os.Args[1:]); ok {
+		if err := writeSpawnReport(prefix, spawnReportLength); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	ebiten.SetWindowSize(screenWidth, screenHeight)
 	ebiten.SetWindowTitle("Dev Survivor")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetTPS(60)
+	ebiten.SetWindowClosingHandled(true) // closeSave saves the run first
+
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+
+	g := NewGame()
+	window := g.windowConfig()
+	g.dev = slices.Contains(os.Args[1:], devFlag)
+	scenes := engine.WithTransitions(g, g.screen, engine.NewFadeTransition(0.4))
+
+	// Idling on the title screen plays an autopiloted demo run
+	demo := engine.AttractConfig{Demo: &survivorDemo{host: g}, Delay: attractDelay, AtMenu: g.atTitle}
+	attract := engine.WithAttract(scenes, demo)
+
+	windowed := engine.WithWindow(engine.WithFocus(attract, focus), window)
+	tokens := profile.WithTokens(windowed, window.App.Name)
+	tokens.Profile = g.profile
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	if err := ebiten.RunGame(withCloseSave(tokens, g)); err != nil {
 		log.Fatal(err)
 	}
 }
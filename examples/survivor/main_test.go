@@ -71,3 +71,26 @@ func TestGameLogic(t *testing.T) {
 		t.Errorf("Projectile not returned to pool")
 	}
 }
+
+func TestResetRecyclesRunState(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	for range 3 {
+		g.projectiles = append(g.projectiles, g.newProjectile())
+	}
+
+	g.enemies = append(g.enemies, &Enemy{HP: 10})
+	g.gameTime, g.killCount = 120, 40
+
+	g.startGame(CharSenior)
+
+	if len(g.unusedProjs) != 3 {
+		t.Errorf("pooled projectiles = %d after restart, want 3", len(g.unusedProjs))
+	}
+
+	if len(g.projectiles) != 0 || len(g.enemies) != 0 || g.gameTime != 0 || g.killCount != 0 {
+		t.Errorf("run state survived restart: %d projectiles, %d enemies, time %v, kills %d",
+			len(g.projectiles), len(g.enemies), g.gameTime, g.killCount)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+const coinShopSlot = "coin_shop"
+
+// CoinUpgrade is a permanent upgrade sold in the coin shop.
+type CoinUpgrade int
+
+const (
+	UpgradeCoinValue CoinUpgrade = iota
+	UpgradeMagnet
+	UpgradeStartingGold
+	coinUpgradeCount
+)
+
+// CoinUpgradeDef describes one coin shop upgrade; each level costs
+// BaseCost times the level being bought.
+type CoinUpgradeDef struct {
+	Name     string
+	Desc     string
+	Key      string // Save data key for the bought level
+	BaseCost int
+	MaxLevel int
+}
+
+// CoinUpgradeDefs lists the coin shop's upgrades.
+var CoinUpgradeDefs = map[CoinUpgrade]CoinUpgradeDef{
+	UpgradeCoinValue:    {Name: "Coin Value", Desc: "+10% coin value", Key: "coin_value", BaseCost: 150, MaxLevel: 5},
+	UpgradeMagnet:       {Name: "Magnet", Desc: "+15 pickup radius", Key: "magnet", BaseCost: 100, MaxLevel: 5},
+	UpgradeStartingGold: {Name: "Starting Gold", Desc: "+25 gold per run", Key: "starting_gold", BaseCost: 80, MaxLevel: 5},
+}
+
+// CoinShop is the meta-progression shop: gold banked at the end of each run
+// buys upgrades applied to every run after. It implements MetaShop.
+type CoinShop struct {
+	Bank   int
+	levels [coinUpgradeCount]int
+	store  *game.SaveManager // Nil keeps the bank in memory only
+}
+
+// coinShopManager returns the save manager for the coin shop, kept with the
+// lifetime stats, or nil when there is nowhere to keep it.
+func coinShopManager() *game.SaveManager {
+	store, err := survivorApp.Open(paths.Data)
+	if err != nil {
+		log.Printf("coin shop: %v", err)
+
+		return nil
+	}
+
+	return game.NewSaveManagerFS(store)
+}
+
+// loadCoinShop reads the bank and upgrades from sm, starting empty when there
+// are none or they cannot be read.
+func loadCoinShop(sm *game.SaveManager) *CoinShop {
+	s := &CoinShop{store: sm}
+	if sm == nil || !sm.Exists(coinShopSlot) {
+		return s
+	}
+
+	save, err := sm.Load(coinShopSlot)
+	if err != nil {
+		log.Printf("coin shop: %v", err)
+
+		return s
+	}
+
+	s.Bank = max(save.GetInt("bank", 0), 0)
+	for u, def := range CoinUpgradeDefs {
+		s.levels[u] = min(max(save.GetInt(def.Key, 0), 0), def.MaxLevel)
+	}
+
+	return s
+}
+
+// Level returns how many levels of u have been bought.
+func (s *CoinShop) Level(u CoinUpgrade) int {
+	return s.levels[u]
+}
+
+// Cost returns the price of the next level of u, or 0 once it is maxed.
+func (s *CoinShop) Cost(u CoinUpgrade) int {
+	def := CoinUpgradeDefs[u]
+	if s.levels[u] >= def.MaxLevel {
+		return 0
+	}
+
+	return def.BaseCost * (s.levels[u] + 1)
+}
+
+// Buy spends banked gold on the next level of u and saves the shop.
+// It reports whether the upgrade was bought.
+func (s *CoinShop) Buy(u CoinUpgrade) bool {
+	cost := s.Cost(u)
+	if cost == 0 || cost > s.Bank {
+		return false
+	}
+
+	s.Bank -= cost
+	s.levels[u]++
+	s.save()
+
+	return true
+}
+
+// RunBonuses returns the bought upgrades as bonuses for a new run.
+func (s *CoinShop) RunBonuses() MetaBonuses {
+	return MetaBonuses{
+		CoinValueMult: 1 + 0.1*float64(s.levels[UpgradeCoinValue]),
+		MagnetRange:   15 * float64(s.levels[UpgradeMagnet]),
+		StartingGold:  25 * s.levels[UpgradeStartingGold],
+	}
+}
+
+// DepositRunGold banks gold left over at the end of a run and saves the shop.
+func (s *CoinShop) DepositRunGold(gold int) {
+	if gold <= 0 {
+		return
+	}
+
+	s.Bank += gold
+	s.save()
+}
+
+func (s *CoinShop) save() {
+	if s.store == nil {
+		return
+	}
+
+	save := game.NewSaveData(coinShopSlot)
+	save.Set("bank", s.Bank)
+
+	for u, def := range CoinUpgradeDefs {
+		save.Set(def.Key, s.levels[u])
+	}
+
+	if err := s.store.Save(coinShopSlot, save); err != nil {
+		log.Printf("coin shop: %v", err)
+	}
+}
+
+// openCoinShop shows the coin shop over character select.
+func (g *Game) openCoinShop() {
+	if g.coinShop == nil {
+		return
+	}
+
+	g.coinShopSel = 0
+	g.state = StateCoinShop
+}
+
+func (g *Game) updateCoinShop() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		g.state = StateCharSelect
+
+		return nil
+	}
+
+	n := int(coinUpgradeCount)
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
+		g.coinShopSel = (g.coinShopSel + n - 1) % n
+		g.audio.PlaySound("select")
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.coinShopSel = (g.coinShopSel + 1) % n
+		g.audio.PlaySound("select")
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		if g.coinShop.Buy(CoinUpgrade(g.coinShopSel)) {
+			g.audio.PlayVariant("coin")
+		}
+	}
+
+	return nil
+}
+
+// drawCoinShop draws the coin shop over the dimmed character select.
+func (g *Game) drawCoinShop(screen *ebiten.Image) {
+	g.drawCharSelect(screen)
+	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, ui.CurrentTheme().Palette.Overlay, false)
+
+	boxW, boxH := 460, 130+int(coinUpgradeCount)*40
+	boxX, boxY := (screenWidth-boxW)/2, (screenHeight-boxH)/2
+
+	g.uiSkin().Panel.Draw(screen, float64(boxX), float64(boxY), float64(boxW), float64(boxH))
+
+	title := "COIN SHOP"
+	ui.DebugPrintAt(screen, title, boxX+(boxW-len(title)*6)/2, boxY+20)
+
+	bank := fmt.Sprintf("Banked gold: %d", g.coinShop.Bank)
+	ui.DebugPrintAt(screen, bank, boxX+(boxW-len(bank)*6)/2, boxY+40)
+
+	palette := ui.CurrentTheme().Palette
+
+	for i := range int(coinUpgradeCount) {
+		u := CoinUpgrade(i)
+		def := CoinUpgradeDefs[u]
+		y := boxY + 70 + i*40
+
+		if i == g.coinShopSel {
+			vector.FillRect(screen, float32(boxX+15), float32(y-4), float32(boxW-30), 36, palette.Button, false)
+			vector.FillRect(screen, float32(boxX+15), float32(y-4), 3, 36, palette.Highlight, false)
+		}
+
+		price := "MAX"
+		if cost := g.coinShop.Cost(u); cost > 0 {
+			price = fmt.Sprintf("%d gold", cost)
+		}
+
+		ui.DebugPrintAt(screen, fmt.Sprintf("%s %d/%d: %s", def.Name, g.coinShop.Level(u), def.MaxLevel, price),
+			boxX+25, y)
+		ui.DebugPrintAt(screen, def.Desc, boxX+25, y+14)
+	}
+
+	hint := "UP/DOWN upgrade | ENTER buy | ESC back"
+	ui.DebugPrintAt(screen, hint, boxX+(boxW-len(hint)*6)/2, boxY+boxH-30)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/draft"
+)
+
+// mutationDraftSize is how many mutations are offered at the start of a run.
+const mutationDraftSize = 3
+
+// streamMutations deals the run's mutation draft, so a seed always offers
+// the same three.
+const streamMutations = "mutations"
+
+// Mutation is a minor trade-off drafted at the start of a run: a bonus with
+// a smaller drawback, applied like gear modifiers.
+type Mutation struct {
+	Name    string
+	Desc    string
+	Effects []Modifier
+}
+
+// Mutations lists every mutation the draft deals from.
+var Mutations = []Mutation{
+	{Name: "Fleet", Desc: "+10% move speed, -5% area", Effects: []Modifier{
+		{Type: ModSpeed, Value: 10}, {Type: ModArea, Value: -5},
+	}},
+	{Name: "Heavy Hands", Desc: "+12% damage, -6% move speed", Effects: []Modifier{
+		{Type: ModPercentDamage, Value: 12}, {Type: ModSpeed, Value: -6},
+	}},
+	{Name: "Wide Reach", Desc: "+15% area, 5% slower cooldowns", Effects: []Modifier{
+		{Type: ModArea, Value: 15}, {Type: ModCooldown, Value: -5},
+	}},
+	{Name: "Quick Study", Desc: "+15% XP gain, -8% max HP", Effects: []Modifier{
+		{Type: ModXPGain, Value: 15}, {Type: ModPercentHP, Value: -8},
+	}},
+	{Name: "Magnetic", Desc: "+40% pickup range, -4% damage", Effects: []Modifier{
+		{Type: ModMagnet, Value: 40}, {Type: ModPercentDamage, Value: -4},
+	}},
+	{Name: "Twitchy", Desc: "6% faster cooldowns, -8% max HP", Effects: []Modifier{
+		{Type: ModCooldown, Value: 6}, {Type: ModPercentHP, Value: -8},
+	}},
+	{Name: "Keen Eye", Desc: "+8% crit chance, -5% XP gain", Effects: []Modifier{
+		{Type: ModCritChance, Value: 8}, {Type: ModXPGain, Value: -5},
+	}},
+	{Name: "Thick Hide", Desc: "+15% max HP, -5% move speed", Effects: []Modifier{
+		{Type: ModPercentHP, Value: 15}, {Type: ModSpeed, Value: -5},
+	}},
+	{Name: "Leech", Desc: "+3% life steal, -6% area", Effects: []Modifier{
+		{Type: ModLifesteal, Value: 3}, {Type: ModArea, Value: -6},
+	}},
+}
+
+// mutationByName returns the named mutation.
+func mutationByName(name string) (Mutation, bool) {
+	for _, m := range Mutations {
+		if m.Name == name {
+			return m, true
+		}
+	}
+
+	return Mutation{}, false
+}
+
+// draftRun starts cfg and offers the mutation draft before play begins.
+// Runs started for the attract mode, the sandbox, and tests skip the draft.
+func (g *Game) draftRun(cfg RunConfig) {
+	g.startRun(cfg)
+
+	g.mutationOffer = draft.Deal(g.rng.Stream(streamMutations), Mutations, mutationDraftSize)
+	options := make([]draft.Option, len(g.mutationOffer))
+
+	for i, m := range g.mutationOffer {
+		options[i] = draft.Option{Name: m.Name, Desc: m.Desc}
+	}
+
+	g.mutationDraft = draft.New("Choose a mutation", options, screenWidth, screenHeight)
+	g.mutationDraft.Skin = &g.uiSkin().Skin
+	g.state = StateDraft
+}
+
+// updateMutationDraft applies the picked mutation and starts play, or backs
+// out to character select on Cancel.
+func (g *Game) updateMutationDraft() error {
+	if controls.JustPressed(input.Cancel) {
+		g.mutationDraft, g.mutationOffer = nil, nil
+		g.state = StateCharSelect
+
+		return nil
+	}
+
+	i := g.mutationDraft.Update(controls)
+	if i < 0 {
+		return nil
+	}
+
+	g.pickMutation(g.mutationOffer[i])
+	g.audio.PlaySound("select")
+
+	return nil
+}
+
+// pickMutation gives the player a mutation at full health and starts play.
+func (g *Game) pickMutation(m Mutation) {
+	g.player.Mutation = m.Name
+	g.recalculateStats()
+	g.player.HP = g.player.MaxHP
+
+	g.mutationDraft, g.mutationOffer = nil, nil
+	g.state = StatePlaying
+}
+
+// applyMutation applies the player's mutation to freshly computed stats.
+func (g *Game) applyMutation() {
+	m, ok := mutationByName(g.player.Mutation)
+	if !ok {
+		return
+	}
+
+	for _, mod := range m.Effects {
+		g.applyModifier(mod)
+	}
+}
+
+// drawMutationDraft draws the draft over the run's starting field.
+func (g *Game) drawMutationDraft(screen *ebiten.Image) {
+	g.mutationDraft.Draw(screen)
+}
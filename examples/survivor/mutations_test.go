@@ -0,0 +1,107 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func offerNames(g *Game) []string {
+	var names []string
+	for _, m := range g.mutationOffer {
+		names = append(names, m.Name)
+	}
+
+	return names
+}
+
+func TestMutationDraftIsSeededPerRun(t *testing.T) {
+	cfg := RunConfig{Seed: 1234, Char: CharJunior}
+
+	g := &Game{}
+	g.draftRun(cfg)
+
+	if g.state != StateDraft || len(g.mutationOffer) != mutationDraftSize {
+		t.Fatalf("state %d with %d mutations, want the draft", g.state, len(g.mutationOffer))
+	}
+
+	again := &Game{}
+	again.draftRun(cfg)
+
+	if !slices.Equal(offerNames(g), offerNames(again)) {
+		t.Errorf("same seed offered %q then %q", offerNames(g), offerNames(again))
+	}
+
+	// Some other seed deals a different hand
+	for seed := range uint32(50) {
+		other := &Game{}
+		other.draftRun(RunConfig{Seed: seed, Char: CharJunior})
+
+		if !slices.Equal(offerNames(g), offerNames(other)) {
+			return
+		}
+	}
+
+	t.Error("every seed offered the same mutations")
+}
+
+func TestPickMutationAppliesTradeOff(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	speed, area := g.player.Speed, g.player.AreaMult
+
+	fleet, _ := mutationByName("Fleet")
+	g.pickMutation(fleet)
+
+	if g.state != StatePlaying || g.mutationDraft != nil {
+		t.Errorf("state %d after picking, want play to start", g.state)
+	}
+
+	if g.player.Speed <= speed || g.player.AreaMult >= area {
+		t.Errorf("speed %.2f area %.2f, want faster than %.2f and smaller than %.2f",
+			g.player.Speed, g.player.AreaMult, speed, area)
+	}
+
+	// Recalculating for gear keeps the mutation
+	g.recalculateStats()
+
+	if g.player.Speed <= speed {
+		t.Error("recalculateStats dropped the mutation")
+	}
+
+	if g.player.HP != g.player.MaxHP {
+		t.Errorf("HP %d/%d, want a full start", g.player.HP, g.player.MaxHP)
+	}
+}
+
+func TestRunSaveKeepsMutation(t *testing.T) {
+	g := &Game{runSaves: game.NewSaveManagerFS(paths.MemFS())}
+	g.startGame(CharJunior)
+
+	heavy, _ := mutationByName("Heavy Hands")
+	g.pickMutation(heavy)
+
+	if err := g.saveRun("run_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _, err := g.loadRunSave("run_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g2 := &Game{}
+	g2.restoreRun(s)
+
+	if g2.player.Mutation != "Heavy Hands" || g2.player.DamageMult != g.player.DamageMult {
+		t.Errorf("restored mutation %q damage %.2f, want Heavy Hands at %.2f",
+			g2.player.Mutation, g2.player.DamageMult, g.player.DamageMult)
+	}
+
+	if g2.state != StatePlaying {
+		t.Errorf("restored run in state %d, want play without a new draft", g2.state)
+	}
+}
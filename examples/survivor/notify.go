@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// initNotifications creates the run's event bus, toast queue, combat log,
+// and damage indicator. Any system can raise a toast by publishing a
+// ui.Notification on g.bus, record combat by publishing a combatlog.Entry,
+// or point at an attacker by publishing a ui.DamageFrom.
+func (g *Game) initNotifications() {
+	if g.toasts != nil {
+		g.toasts.Close()
+	}
+
+	if g.damageDir != nil {
+		g.damageDir.Close()
+	}
+
+	visible := false
+	if g.combatLog != nil {
+		visible = g.combatLog.Visible
+		g.combatLog.Close()
+	}
+
+	g.bus = events.NewBus()
+	g.toasts = ui.NewToastQueue(g.bus, screenWidth)
+	g.combatLog = combatlog.NewLog(g.bus, 200)
+	g.combatLog.Visible = visible
+	g.damageDir = ui.NewDamageIndicator(g.bus)
+}
+
+// logCombat publishes a combat log entry stamped with the run time.
+func (g *Game) logCombat(e combatlog.Entry) {
+	if g.bus == nil {
+		return
+	}
+
+	e.Time = g.gameTime
+	events.Publish(g.bus, e)
+}
+
+// notify publishes a notification on the game's event bus.
+func (g *Game) notify(n ui.Notification) {
+	if g.bus == nil {
+		return
+	}
+
+	events.Publish(g.bus, n)
+}
+
+// notifyItemDrop announces a looted item, coloured by rarity.
+func (g *Game) notifyItemDrop(source string, item *Equipment) {
+	g.logCombat(combatlog.Entry{
+		Category: combatlog.Drop,
+		Source:   source,
+		Target:   item.Name,
+		Detail:   RarityNames[item.Rarity],
+	})
+
+	priority := ui.ToastNormal
+	if item.Rarity >= RarityLegendary {
+		priority = ui.ToastHigh
+	}
+
+	g.notify(ui.Notification{
+		Title:    RarityNames[item.Rarity] + " item!",
+		Message:  item.Name,
+		Color:    RarityColor(item.Rarity),
+		Priority: priority,
+	})
+}
+
+// notifyBoss warns that a boss has spawned.
+func (g *Game) notifyBoss(bossType MonsterType) {
+	g.notify(ui.Notification{
+		Title:    "Boss incoming!",
+		Message:  MonsterDefs[bossType].Name,
+		Icon:     g.monsterImages[bossType],
+		Color:    ui.CurrentTheme().Palette.Danger,
+		Duration: 4,
+		Priority: ui.ToastCritical,
+	})
+}
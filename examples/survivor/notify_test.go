@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+)
+
+func TestCombatEventsReachLogAndToasts(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	boss := &Enemy{X: 50, HP: 1, MaxHP: 1, XP: 100, Type: MonsterBossManager, IsBoss: true}
+	g.enemies = append(g.enemies, boss)
+
+	g.hurtPlayer(10, 0, "Minor Bug")
+	g.killEnemy(boss)
+
+	categories := map[combatlog.Category]int{}
+	for _, e := range g.combatLog.Entries() {
+		categories[e.Category]++
+	}
+
+	if categories[combatlog.DamageTaken] != 1 || categories[combatlog.Kill] != 1 || categories[combatlog.Drop] != 1 {
+		t.Errorf("logged categories = %v", categories)
+	}
+
+	if g.toasts.Len() != 1 {
+		t.Errorf("boss drop should raise a toast, got %d", g.toasts.Len())
+	}
+
+	// A new run starts with a fresh log but keeps the overlay toggle
+	g.combatLog.Visible = true
+	g.startGame(CharJunior)
+
+	if g.combatLog.Len() != 0 || !g.combatLog.Visible {
+		t.Errorf("new run log: len=%d visible=%v", g.combatLog.Len(), g.combatLog.Visible)
+	}
+}
+
+func TestEnemyHitsPointTheDamageIndicator(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	// A slam from an enemy to the player's left, then a puddle underfoot
+	enemy := &Enemy{X: g.player.X - 200, Y: g.player.Y, Type: MonsterBug}
+	g.telegraphs = []*Telegraph{{X: g.player.X, Y: g.player.Y, Radius: 40, Damage: 10, Source: enemy}}
+	g.updateTelegraphs(0)
+	g.player.HitTimer = 0
+	g.hurtPlayer(5, 0, "Bug puddle")
+
+	arcs := g.damageDir.Arcs()
+	if len(arcs) != 1 || math.Abs(math.Abs(arcs[0].Angle)-math.Pi) > 1e-9 {
+		t.Fatalf("arcs = %+v, want one pointing left", arcs)
+	}
+
+	g.startGame(CharJunior)
+
+	if len(g.damageDir.Arcs()) != 0 {
+		t.Error("a new run should start without damage arcs")
+	}
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+// The default passive tree. A tree saved to the data store as
+// passiveTreeSlot replaces it at startup, so trees can be authored without
+// recompiling.
+//
+//go:embed passive_tree.json
+var passiveTreeJSON []byte
+
+const passiveTreeSlot = "passive_tree.json"
+
+// Passive tree view and respec tuning.
+const (
+	treeUnit       = 60.0  // Pixels per tree grid unit at zoom 1
+	treeMinZoom    = 0.3   // Far enough out to see a 100+ node tree
+	treeMaxZoom    = 2.5   // Close enough to read a crowded cluster
+	treeZoomStep   = 1.15  // Per wheel notch or key press
+	treePanSpeed   = 500.0 // Screen pixels per second of key panning
+	treeHitSlack   = 3.0   // Pixels around a node that still count as on it
+	respecBaseCost = 25    // Gold for the first refund of a run
+	respecCostStep = 25    // Added for each refund after that
+)
+
+// modTypeKeys name modifier types in tree files.
+var modTypeKeys = map[string]ModType{
+	"flat_damage":     ModFlatDamage,
+	"percent_damage":  ModPercentDamage,
+	"flat_hp":         ModFlatHP,
+	"percent_hp":      ModPercentHP,
+	"armor":           ModArmor,
+	"speed":           ModSpeed,
+	"crit_chance":     ModCritChance,
+	"crit_multiplier": ModCritMultiplier,
+	"cooldown":        ModCooldown,
+	"area":            ModArea,
+	"duration":        ModDuration,
+	"magnet":          ModMagnet,
+	"xp_gain":         ModXPGain,
+	"recovery":        ModRecovery,
+	"projectiles":     ModProjectiles,
+	"lifesteal":       ModLifesteal,
+	"thorns":          ModThorns,
+	"fork_lightning":  ModForkLightning,
+	"shield":          ModShield,
+}
+
+// nodeTypeKeys name node types in tree files; a node without one is small.
+var nodeTypeKeys = map[string]PassiveNodeType{
+	"":         NodeSmall,
+	"small":    NodeSmall,
+	"notable":  NodeNotable,
+	"keystone": NodeKeystone,
+}
+
+// treeFile is the JSON form of a passive tree. Links only need listing on
+// one of the two nodes they join.
+type treeFile struct {
+	Nodes []treeFileNode `json:"nodes"`
+}
+
+type treeFileNode struct {
+	ID      int              `json:"id"`
+	Name    string           `json:"name"`
+	Desc    string           `json:"desc"`
+	X       float64          `json:"x"`
+	Y       float64          `json:"y"`
+	Links   []int            `json:"links"`
+	Type    string           `json:"type"`
+	Start   string           `json:"start"` // Name of the character that starts here
+	Effects []treeFileEffect `json:"effects"`
+}
+
+type treeFileEffect struct {
+	Mod   string  `json:"mod"`
+	Value float64 `json:"value"`
+}
+
+// parsePassiveTree decodes a tree file, checking that every ID is unique
+// and every link, modifier, node type, and character it names exists.
+func parsePassiveTree(data []byte) ([]*PassiveNode, error) {
+	var f treeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	if len(f.Nodes) == 0 {
+		return nil, errors.New("tree has no nodes")
+	}
+
+	nodes := make([]*PassiveNode, 0, len(f.Nodes))
+	byID := make(map[int]*PassiveNode, len(f.Nodes))
+
+	for i, n := range f.Nodes {
+		node, err := n.node()
+
+		switch {
+		case err != nil:
+		case byID[n.ID] != nil:
+			err = fmt.Errorf("reuses ID %d", n.ID)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("node %d (%s): %w", i, n.Name, err)
+		}
+
+		nodes = append(nodes, node)
+		byID[n.ID] = node
+	}
+
+	for i, n := range f.Nodes {
+		for _, id := range n.Links {
+			other := byID[id]
+			if other == nil || id == n.ID {
+				return nil, fmt.Errorf("node %d (%s): links to unknown node %d", i, n.Name, id)
+			}
+
+			link(nodes[i], other)
+		}
+	}
+
+	return nodes, nil
+}
+
+// node decodes the node's own fields; its links need the whole tree.
+func (n treeFileNode) node() (*PassiveNode, error) {
+	if n.Name == "" {
+		return nil, errors.New("has no name")
+	}
+
+	nt, ok := nodeTypeKeys[n.Type]
+	if !ok {
+		return nil, fmt.Errorf("has unknown type %q", n.Type)
+	}
+
+	node := &PassiveNode{ID: n.ID, Name: n.Name, Desc: n.Desc, X: n.X, Y: n.Y, NodeType: nt, StartClass: -1}
+
+	if n.Start != "" {
+		i := slices.IndexFunc(Characters, func(c CharacterDef) bool { return strings.EqualFold(c.Name, n.Start) })
+		if i < 0 {
+			return nil, fmt.Errorf("starts unknown character %q", n.Start)
+		}
+
+		node.StartClass = CharacterType(i)
+	}
+
+	for _, e := range n.Effects {
+		mt, ok := modTypeKeys[e.Mod]
+		if !ok {
+			return nil, fmt.Errorf("has unknown modifier %q", e.Mod)
+		}
+
+		node.Effects = append(node.Effects, Modifier{Type: mt, Value: e.Value})
+	}
+
+	return node, nil
+}
+
+// link connects two nodes both ways, once.
+func link(a, b *PassiveNode) {
+	if !slices.Contains(a.Connections, b.ID) {
+		a.Connections = append(a.Connections, b.ID)
+	}
+
+	if !slices.Contains(b.Connections, a.ID) {
+		b.Connections = append(b.Connections, a.ID)
+	}
+}
+
+// defaultPassiveTree parses the embedded tree once.
+var defaultPassiveTree = sync.OnceValues(func() ([]*PassiveNode, error) {
+	return parsePassiveTree(passiveTreeJSON)
+})
+
+// passiveTreeDef is the tree runs use, set at startup; nil uses the
+// embedded default.
+var passiveTreeDef []*PassiveNode
+
+// loadPassiveTree makes the tree saved in store, if there is a valid one,
+// the tree every run uses.
+func loadPassiveTree(store paths.FS) {
+	if store == nil || !store.Exists(passiveTreeSlot) {
+		return
+	}
+
+	data, err := store.ReadFile(passiveTreeSlot)
+	if err == nil {
+		var nodes []*PassiveNode
+		if nodes, err = parsePassiveTree(data); err == nil {
+			passiveTreeDef = nodes
+
+			return
+		}
+	}
+
+	log.Printf("passive tree: ignoring %s: %v", passiveTreeSlot, err)
+}
+
+// passiveTreeNodes builds a fresh copy of the passive tree.
+func passiveTreeNodes() []*PassiveNode {
+	def := passiveTreeDef
+	if def == nil {
+		var err error
+		if def, err = defaultPassiveTree(); err != nil {
+			log.Printf("passive tree: %v", err)
+		}
+	}
+
+	nodes := make([]*PassiveNode, len(def))
+	for i, n := range def {
+		node := *n
+		nodes[i] = &node
+	}
+
+	return nodes
+}
+
+// treeNodeRadius returns a node's radius in pixels at zoom 1.
+func treeNodeRadius(node *PassiveNode) float64 {
+	switch node.NodeType {
+	case NodeNotable:
+		return 18
+	case NodeKeystone:
+		return 24
+	}
+
+	return 12
+}
+
+// treeView is the pan and zoom of the passive tree screen. Pan is in tree
+// pixels at zoom 1, and the zero view is centered at zoom 1.
+type treeView struct {
+	PanX, PanY float64
+	Zoom       float64
+
+	dragging     bool
+	dragX, dragY int // Cursor position at the last drag update
+}
+
+// scale returns the screen pixels per tree pixel.
+func (v *treeView) scale() float64 {
+	if v.Zoom <= 0 {
+		return 1
+	}
+
+	return v.Zoom
+}
+
+// toScreen converts tree grid coordinates to screen pixels.
+func (v *treeView) toScreen(x, y float64) (sx, sy float64) {
+	return screenWidth/2 + (x*treeUnit+v.PanX)*v.scale(), screenHeight/2 + (y*treeUnit+v.PanY)*v.scale()
+}
+
+// zoomAt scales the view by factor, keeping the tree point under the screen
+// point (sx, sy) in place.
+func (v *treeView) zoomAt(factor, sx, sy float64) {
+	old := v.scale()
+	zoom := min(max(old*factor, treeMinZoom), treeMaxZoom)
+
+	// Tree pixels under the point stay put: (s - center)/zoom - pan is fixed
+	v.PanX += (sx - screenWidth/2) * (1/zoom - 1/old)
+	v.PanY += (sy - screenHeight/2) * (1/zoom - 1/old)
+	v.Zoom = zoom
+}
+
+// update pans with WASD, the arrow keys, or a middle-button drag, zooms
+// with the wheel or +/-, and recenters with 0.
+func (v *treeView) update(dt float64) {
+	var dx, dy float64
+
+	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyLeft) {
+		dx++
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyRight) {
+		dx--
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyUp) {
+		dy++
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyDown) {
+		dy--
+	}
+
+	v.PanX += dx * treePanSpeed * dt / v.scale()
+	v.PanY += dy * treePanSpeed * dt / v.scale()
+
+	mx, my := ebiten.CursorPosition()
+
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+		if v.dragging {
+			v.PanX += float64(mx-v.dragX) / v.scale()
+			v.PanY += float64(my-v.dragY) / v.scale()
+		}
+
+		v.dragging, v.dragX, v.dragY = true, mx, my
+	} else {
+		v.dragging = false
+	}
+
+	if _, wy := ebiten.Wheel(); wy != 0 {
+		v.zoomAt(math.Pow(treeZoomStep, wy), float64(mx), float64(my))
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || inpututil.IsKeyJustPressed(ebiten.KeyKPAdd) {
+		v.zoomAt(treeZoomStep, screenWidth/2, screenHeight/2)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || inpututil.IsKeyJustPressed(ebiten.KeyKPSubtract) {
+		v.zoomAt(1/treeZoomStep, screenWidth/2, screenHeight/2)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDigit0) {
+		*v = treeView{}
+	}
+}
+
+// treeNodeAt returns the node under the screen point, if any.
+func (g *Game) treeNodeAt(sx, sy float64) *PassiveNode {
+	for _, node := range g.passiveTree {
+		x, y := g.treeView.toScreen(node.X, node.Y)
+		if math.Hypot(sx-x, sy-y) < treeNodeRadius(node)*g.treeView.scale()+treeHitSlack {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// respecCost returns the gold the next refund costs.
+func (p *Player) respecCost() int {
+	return respecBaseCost + respecCostStep*p.Respecs
+}
+
+// refundBlocker returns why an allocated node cannot be refunded now, or ""
+// if it can.
+func (g *Game) refundBlocker(node *PassiveNode) string {
+	switch {
+	case !g.player.AllocatedNodes[node.ID]:
+		return "Not allocated"
+	case node.StartClass >= 0:
+		return "Start nodes cannot be refunded"
+	case g.player.Gold < g.player.respecCost():
+		return fmt.Sprintf("Refund needs %d gold", g.player.respecCost())
+	case !g.treeConnectedWithout(node.ID):
+		return "Refunding would cut off other nodes"
+	}
+
+	return ""
+}
+
+// treeConnectedWithout reports whether every allocated node but the given
+// one still links back to an allocated start node without it.
+func (g *Game) treeConnectedWithout(id int) bool {
+	links := make(map[int][]int, len(g.passiveTree))
+
+	var (
+		queue []int
+		want  int
+	)
+
+	for _, node := range g.passiveTree {
+		if node.ID == id || !g.player.AllocatedNodes[node.ID] {
+			continue
+		}
+
+		links[node.ID] = node.Connections
+		want++
+
+		if node.StartClass >= 0 {
+			queue = append(queue, node.ID)
+		}
+	}
+
+	seen := make(map[int]bool, want)
+	for _, start := range queue {
+		seen[start] = true
+	}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, next := range links[cur] {
+			if _, ok := links[next]; ok && !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return len(seen) == want
+}
+
+// refundNode returns an allocated node's point for gold, if refundBlocker
+// allows it, and reports whether it did.
+func (g *Game) refundNode(node *PassiveNode) bool {
+	if g.refundBlocker(node) != "" {
+		return false
+	}
+
+	p := g.player
+	p.Gold -= p.respecCost()
+	p.Respecs++
+	p.PassivePoints++
+	delete(p.AllocatedNodes, node.ID)
+
+	g.recalculateStats()
+	g.audio.PlaySound("select")
+
+	return true
+}
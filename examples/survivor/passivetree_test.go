@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestEmbeddedPassiveTree(t *testing.T) {
+	nodes, err := defaultPassiveTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byID := make(map[int]*PassiveNode, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+
+	if len(nodes) != 121 || byID[0].StartClass != CharJunior || byID[3].StartClass != Char10x {
+		t.Errorf("tree has %d nodes, starts %v and %v", len(nodes), byID[0].StartClass, byID[3].StartClass)
+	}
+
+	if byID[4].StartClass != -1 || byID[18].NodeType != NodeKeystone || len(byID[12].Effects) != 2 {
+		t.Errorf("node fields decoded wrong: %+v, %+v, %+v", byID[4], byID[18], byID[12])
+	}
+
+	for _, n := range nodes {
+		for _, id := range n.Connections {
+			if !slices.Contains(byID[id].Connections, n.ID) {
+				t.Errorf("%s links to %s but not back", n.Name, byID[id].Name)
+			}
+		}
+	}
+
+	// Every node can be reached from a start node
+	reached := map[int]bool{0: true}
+	for queue := []int{0}; len(queue) > 0; queue = queue[1:] {
+		for _, id := range byID[queue[0]].Connections {
+			if !reached[id] {
+				reached[id] = true
+				queue = append(queue, id)
+			}
+		}
+	}
+
+	if len(reached) != len(nodes) {
+		t.Errorf("%d of %d nodes are reachable from the start", len(reached), len(nodes))
+	}
+
+	seen := make(map[ModType]bool)
+	for _, mt := range modTypeKeys {
+		seen[mt] = true
+	}
+
+	if len(seen) != len(ModTypeNames) {
+		t.Errorf("tree files can name %d of the %d modifier types", len(seen), len(ModTypeNames))
+	}
+}
+
+func TestParsePassiveTreeLinksBothWays(t *testing.T) {
+	nodes, err := parsePassiveTree([]byte(`{"nodes": [
+		{"id": 1, "name": "Start", "start": "tech lead", "links": [2]},
+		{"id": 2, "name": "Fury", "type": "notable", "effects": [{"mod": "armor", "value": 3}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(nodes[1].Connections, []int{1}) || nodes[0].StartClass != CharTechLead {
+		t.Errorf("nodes = %+v, %+v", nodes[0], nodes[1])
+	}
+
+	if got := nodes[1].Effects; len(got) != 1 || got[0] != (Modifier{Type: ModArmor, Value: 3}) {
+		t.Errorf("effects = %v", got)
+	}
+}
+
+func TestParsePassiveTreeRejectsBadTrees(t *testing.T) {
+	for name, src := range map[string]string{
+		"bad json":      `{`,
+		"empty":         `{"nodes": []}`,
+		"reused ID":     `{"nodes": [{"id": 1, "name": "A"}, {"id": 1, "name": "B"}]}`,
+		"no name":       `{"nodes": [{"id": 1}]}`,
+		"unknown link":  `{"nodes": [{"id": 1, "name": "A", "links": [9]}]}`,
+		"self link":     `{"nodes": [{"id": 1, "name": "A", "links": [1]}]}`,
+		"unknown type":  `{"nodes": [{"id": 1, "name": "A", "type": "huge"}]}`,
+		"unknown start": `{"nodes": [{"id": 1, "name": "A", "start": "Intern"}]}`,
+		"unknown mod":   `{"nodes": [{"id": 1, "name": "A", "effects": [{"mod": "luck", "value": 1}]}]}`,
+	} {
+		if _, err := parsePassiveTree([]byte(src)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// bigTreeJSON returns a tree of n nodes: a start node for each character,
+// then a chain of small nodes hanging off the first.
+func bigTreeJSON(n int) string {
+	var b strings.Builder
+
+	b.WriteString(`{"nodes": [`)
+
+	for i, c := range Characters {
+		fmt.Fprintf(&b, `{"id": %d, "name": "Start %d", "start": %q, "links": [0]},`, i+1000, i, c.Name)
+	}
+
+	for i := range n - len(Characters) {
+		sep := ","
+		if i == n-len(Characters)-1 {
+			sep = ""
+		}
+
+		fmt.Fprintf(&b, `{"id": %d, "name": "Node %d", "x": %d, "y": %d, "links": [%d],
+			"effects": [{"mod": "armor", "value": 1}]}%s`, i, i, i%12, i/12, max(i-1, 1000), sep)
+	}
+
+	b.WriteString(`]}`)
+
+	return b.String()
+}
+
+func TestLoadPassiveTreeFromStore(t *testing.T) {
+	t.Cleanup(func() { passiveTreeDef = nil })
+
+	store := paths.MemFS()
+	if err := store.WriteFile(passiveTreeSlot, []byte(`{"nodes": [{"id": 1}]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	loadPassiveTree(store)
+
+	if passiveTreeDef != nil {
+		t.Fatal("an invalid stored tree should leave the default in place")
+	}
+
+	if err := store.WriteFile(passiveTreeSlot, []byte(bigTreeJSON(120))); err != nil {
+		t.Fatal(err)
+	}
+
+	loadPassiveTree(store)
+
+	g := &Game{}
+	g.startGame(CharSenior)
+
+	if len(g.passiveTree) != 120 || !g.player.AllocatedNodes[1001] {
+		t.Errorf("run has %d nodes, allocated %v; want the stored tree", len(g.passiveTree), g.player.AllocatedNodes)
+	}
+
+	g.passiveTree[0].Name = "Renamed"
+	if passiveTreeNodes()[0].Name == "Renamed" {
+		t.Error("runs must get their own copy of the tree")
+	}
+}
+
+// allocatePath gives the player points for each node and allocates them in
+// order.
+func allocatePath(t *testing.T, g *Game, ids ...int) {
+	t.Helper()
+
+	for _, id := range ids {
+		i := slices.IndexFunc(g.passiveTree, func(n *PassiveNode) bool { return n.ID == id })
+		g.player.PassivePoints++
+		g.tryAllocateNode(g.passiveTree[i])
+
+		if !g.player.AllocatedNodes[id] {
+			t.Fatalf("could not allocate node %d", id)
+		}
+	}
+}
+
+func treeNode(g *Game, id int) *PassiveNode {
+	return g.passiveTree[slices.IndexFunc(g.passiveTree, func(n *PassiveNode) bool { return n.ID == id })]
+}
+
+func TestRefundNode(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	allocatePath(t, g, 22, 6) // Recovery, then Thick Skin
+
+	g.player.Gold = 60
+	armor := g.player.Armor
+
+	for id, want := range map[int]string{0: "Start", 22: "cut off", 24: "Not allocated"} {
+		if got := g.refundBlocker(treeNode(g, id)); !strings.Contains(got, want) {
+			t.Errorf("refund node %d blocked by %q, want %q", id, got, want)
+		}
+	}
+
+	if !g.refundNode(treeNode(g, 6)) {
+		t.Fatal("Thick Skin is a leaf and should refund")
+	}
+
+	p := g.player
+	if p.Gold != 35 || p.PassivePoints != 1 || p.Respecs != 1 || p.AllocatedNodes[6] || p.Armor != armor-5 {
+		t.Errorf("after refund: gold %d, points %d, respecs %d, armor %d",
+			p.Gold, p.PassivePoints, p.Respecs, p.Armor)
+	}
+
+	if p.respecCost() != 50 || !strings.Contains(g.refundBlocker(treeNode(g, 22)), "50 gold") {
+		t.Errorf("second refund costs %d, blocker %q", p.respecCost(), g.refundBlocker(treeNode(g, 22)))
+	}
+}
+
+func TestRefundRemovesTreeProjectiles(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	allocatePath(t, g, 3, 9, 17, 18) // Down to Code Reviewer, +2 projectiles
+
+	g.recalculateStats()
+	g.recalculateStats()
+
+	if got := g.player.projectileBonus(); got != 2 {
+		t.Fatalf("projectile bonus = %d, want 2 however often stats are recalculated", got)
+	}
+
+	g.player.Gold = respecBaseCost
+	if !g.refundNode(treeNode(g, 18)) || g.player.projectileBonus() != 0 {
+		t.Errorf("projectile bonus after refund = %d, want 0", g.player.projectileBonus())
+	}
+}
+
+func TestTreeViewZoomKeepsCursorPoint(t *testing.T) {
+	var v treeView
+
+	treeAt := func(sx, sy float64) (float64, float64) {
+		x := ((sx-screenWidth/2)/v.scale() - v.PanX) / treeUnit
+
+		return x, ((sy-screenHeight/2)/v.scale() - v.PanY) / treeUnit
+	}
+
+	x0, y0 := treeAt(700, 200)
+	v.zoomAt(2, 700, 200)
+
+	if x, y := treeAt(700, 200); math.Abs(x-x0) > 1e-9 || math.Abs(y-y0) > 1e-9 || v.Zoom != 2 {
+		t.Errorf("point under the cursor moved from (%v, %v) to (%v, %v) at zoom %v", x0, y0, x, y, v.Zoom)
+	}
+
+	if sx, sy := v.toScreen(x0, y0); math.Abs(sx-700) > 1e-9 || math.Abs(sy-200) > 1e-9 {
+		t.Errorf("toScreen = (%v, %v), want (700, 200)", sx, sy)
+	}
+
+	for range 50 {
+		v.zoomAt(0.5, 0, 0)
+	}
+
+	if v.Zoom != treeMinZoom {
+		t.Errorf("zoom = %v, want clamped to %v", v.Zoom, treeMinZoom)
+	}
+}
+
+func TestTreeNodeAtFollowsView(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.treeView.zoomAt(2, 100, 100)
+	g.treeView.PanX += 40
+
+	node := treeNode(g, 12)
+	sx, sy := g.treeView.toScreen(node.X, node.Y)
+
+	if got := g.treeNodeAt(sx+treeNodeRadius(node)*1.5, sy); got != node {
+		t.Errorf("node at zoomed keystone edge = %v, want %s", got, node.Name)
+	}
+
+	if got := g.treeNodeAt(sx+treeNodeRadius(node)*3, sy); got != nil {
+		t.Errorf("node beyond the keystone = %s, want none", got.Name)
+	}
+}
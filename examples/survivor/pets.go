@@ -0,0 +1,310 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/steering"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// PetType identifies a companion pet.
+type PetType int
+
+const (
+	PetNone PetType = iota
+	PetRubberDuck
+	PetOfficeCat
+	PetCount
+)
+
+// PetDef describes a companion pet.
+type PetDef struct {
+	Name  string
+	Desc  string
+	Color color.RGBA
+	// Unlock is the lifetime achievement that unlocks the pet; empty means always available.
+	Unlock        string
+	Speed         float64
+	FetchInterval float64 // Seconds between gem fetches; 0 never fetches
+	BlockCooldown float64 // Seconds between blocked hits; 0 never blocks
+}
+
+// PetDefs holds every pet, indexed by PetType.
+var PetDefs = [PetCount]PetDef{
+	PetNone: {Name: "None", Desc: "Go it alone"},
+	PetRubberDuck: {
+		Name: "Rubber Duck", Desc: "Fetches a gem every 8s, blocks a hit every 45s",
+		Color: color.RGBA{R: 255, G: 220, B: 60, A: 255}, Unlock: "first_blood",
+		Speed: 260, FetchInterval: 8, BlockCooldown: 45,
+	},
+	PetOfficeCat: {
+		Name: "Office Cat", Desc: "Fetches a gem every 3s",
+		Color: color.RGBA{R: 150, G: 150, B: 160, A: 255}, Unlock: "boss_slayer",
+		Speed: 320, FetchInterval: 3,
+	},
+}
+
+// Pet follow and fetch tuning.
+const (
+	petFollowDistance = 36.0  // Trailing distance behind the player
+	petSlowRadius     = 60.0  // Distance at which the pet starts easing in
+	petStopRadius     = 4.0   // Close enough to stand still
+	petLeashDistance  = 600.0 // Beyond this the pet teleports back behind the player
+	petFetchRange     = 250.0 // Gems farther than this from the player are ignored
+	petPickupRange    = 12.0
+	petBlockFlash     = 0.4
+)
+
+// Pet is a companion that trails the player, fetches gems, and may block hits.
+type Pet struct {
+	Type       PetType
+	X, Y       float64
+	Moving     bool
+	FacingLeft bool
+	Anim       float64 // Animation clock
+	Idle       float64 // Seconds spent standing still
+
+	fetchTimer float64
+	blockTimer float64 // Seconds until the pet can block again
+	flash      float64 // Block flash remaining
+	target     *XPGem
+}
+
+// BlockReady reports whether the pet can block the next hit.
+func (p *Pet) BlockReady() bool {
+	return PetDefs[p.Type].BlockCooldown > 0 && p.blockTimer <= 0
+}
+
+// petUnlocked reports whether the pet's achievement has been earned.
+func (g *Game) petUnlocked(t PetType) bool {
+	id := PetDefs[t].Unlock
+	if id == "" {
+		return true
+	}
+
+	a := g.achievements.Achievements[id]
+
+	return a != nil && a.Unlocked
+}
+
+// cyclePet moves the pet selection by dir, skipping locked pets, and saves it.
+func (g *Game) cyclePet(dir int) {
+	s := g.settings
+
+	for range PetCount {
+		s.Pet = PetType((int(s.Pet) + dir + int(PetCount)) % int(PetCount))
+		if g.petUnlocked(s.Pet) {
+			break
+		}
+	}
+
+	g.setSettings(s)
+}
+
+// spawnPet places the selected pet behind the player, if it is unlocked.
+func (g *Game) spawnPet() {
+	g.pet = nil
+
+	t := g.settings.Pet
+	if t <= PetNone || t >= PetCount || !g.petUnlocked(t) {
+		return
+	}
+
+	g.pet = &Pet{Type: t, X: g.player.X - petFollowDistance, Y: g.player.Y}
+}
+
+// updatePet steers the pet behind the player or toward the gem it is fetching.
+func (g *Game) updatePet(dt float64) {
+	p := g.pet
+	if p == nil {
+		return
+	}
+
+	def := PetDefs[p.Type]
+	p.Anim += dt
+	p.blockTimer = max(0, p.blockTimer-dt)
+	p.flash = max(0, p.flash-dt)
+
+	pos := steering.Vec2{X: p.X, Y: p.Y}
+	player := steering.Vec2{X: g.player.X, Y: g.player.Y}
+	goal := steering.Behind(player, steering.Vec2{X: g.player.FaceX, Y: g.player.FaceY}, petFollowDistance)
+
+	if pos.Sub(player).Len() > petLeashDistance {
+		p.X, p.Y = goal.X, goal.Y
+		p.target = nil
+
+		return
+	}
+
+	// Drop the target once the magnet has it
+	if p.target != nil && p.target.Magnet {
+		p.target = nil
+	}
+
+	if p.target == nil && def.FetchInterval > 0 {
+		p.fetchTimer += dt
+		if p.fetchTimer >= def.FetchInterval {
+			if p.target = g.petFetchTarget(); p.target != nil {
+				p.fetchTimer = 0
+			}
+		}
+	}
+
+	if p.target != nil {
+		goal = steering.Vec2{X: p.target.X, Y: p.target.Y}
+
+		if goal.Sub(pos).Len() < petPickupRange {
+			// Carried gems fly to the player like magnetized ones
+			p.target.Magnet = true
+			p.target = nil
+		}
+	}
+
+	v := steering.Arrive(pos, goal, def.Speed, petSlowRadius, petStopRadius)
+	p.X += v.X * dt
+	p.Y += v.Y * dt
+	p.Moving = v.LenSq() > 1
+
+	if math.Abs(v.X) > 1 {
+		p.FacingLeft = v.X < 0
+	}
+
+	if p.Moving {
+		p.Idle = 0
+	} else {
+		p.Idle += dt
+	}
+}
+
+// petFetchTarget returns the loose gem nearest the player within fetch range.
+func (g *Game) petFetchTarget() *XPGem {
+	var (
+		best     *XPGem
+		bestDist = petFetchRange
+	)
+
+	for _, gem := range g.xpGems {
+		if gem.Magnet {
+			continue
+		}
+
+		if d := math.Hypot(gem.X-g.player.X, gem.Y-g.player.Y); d < bestDist {
+			best, bestDist = gem, d
+		}
+	}
+
+	return best
+}
+
+// petBlocks lets the pet take a hit for the player, reporting whether it did.
+func (g *Game) petBlocks(source string) bool {
+	p := g.pet
+	if p == nil || !p.BlockReady() {
+		return false
+	}
+
+	def := PetDefs[p.Type]
+	p.blockTimer = def.BlockCooldown
+	p.flash = petBlockFlash
+	g.player.HitTimer = 0.5
+
+	g.logCombat(combatlog.Entry{
+		Category: combatlog.DamageTaken,
+		Source:   source,
+		Target:   def.Name,
+		Detail:   "blocked",
+	})
+
+	return true
+}
+
+// drawPet renders the pet with a waddle while moving and a bob, plus the
+// occasional hop, while idle.
+func (g *Game) drawPet(screen *ebiten.Image) {
+	p := g.pet
+	if p == nil {
+		return
+	}
+
+	def := PetDefs[p.Type]
+	sx, sy := p.X-g.cameraX, p.Y-g.cameraY
+
+	if p.Moving {
+		step := math.Sin(p.Anim * 14)
+		sx += step * 2
+		sy -= math.Abs(step) * 2
+	} else {
+		sy += math.Sin(p.Anim*3) * 1.5
+
+		// Hop every few seconds after settling
+		if phase := math.Mod(p.Idle, 4); p.Idle > 3 && phase < 0.3 {
+			sy -= math.Sin(phase/0.3*math.Pi) * 6
+		}
+	}
+
+	dir := 1.0
+	if p.FacingLeft {
+		dir = -1
+	}
+
+	x, y := float32(sx), float32(sy)
+	fwd := float32(dir)
+
+	if p.BlockReady() {
+		vector.StrokeCircle(screen, x, y, 14, 1, color.NRGBA{R: 120, G: 200, B: 255, A: 120}, false)
+	}
+
+	body := def.Color
+	if p.flash > 0 {
+		body = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+
+	switch p.Type {
+	case PetRubberDuck:
+		vector.FillCircle(screen, x, y, 8, body, false)
+		vector.FillCircle(screen, x+5*fwd, y-7, 5, body, false)
+		vector.FillRect(screen, x+9*fwd-2, y-7, 5, 3, color.RGBA{R: 255, G: 140, B: 0, A: 255}, false)
+		vector.FillCircle(screen, x+6*fwd, y-8, 1, color.Black, false)
+	case PetOfficeCat:
+		tail := float32(math.Sin(p.Anim*4) * 3)
+		vector.StrokeLine(screen, x-7*fwd, y, x-12*fwd, y-6+tail, 2, body, false)
+		vector.FillCircle(screen, x, y, 7, body, false)
+		vector.FillCircle(screen, x+6*fwd, y-6, 5, body, false)
+		vector.FillRect(screen, x+3*fwd-1, y-13, 3, 4, body, false)
+		vector.FillRect(screen, x+8*fwd-1, y-13, 3, 4, body, false)
+		vector.FillCircle(screen, x+8*fwd, y-7, 1, color.RGBA{R: 120, G: 220, B: 80, A: 255}, false)
+	}
+}
+
+// drawPetSelect shows the chosen pet under the character boxes, with the
+// achievements that unlock the rest.
+func (g *Game) drawPetSelect(screen *ebiten.Image) {
+	pet := g.settings.Pet
+	if pet < PetNone || pet >= PetCount || !g.petUnlocked(pet) {
+		pet = PetNone
+	}
+
+	def := PetDefs[pet]
+	ui.DebugPrintAt(screen, "Pet: < "+def.Name+" >", screenWidth/2-160, 510)
+	ui.DebugPrintAt(screen, def.Desc, screenWidth/2-160, 528)
+
+	y := 556
+
+	for t := PetNone + 1; t < PetCount; t++ {
+		if g.petUnlocked(t) {
+			continue
+		}
+
+		a := g.achievements.Achievements[PetDefs[t].Unlock]
+		if a == nil {
+			continue
+		}
+
+		ui.DebugPrintAt(screen, "Locked: "+PetDefs[t].Name+" - "+a.Description, screenWidth/2-160, y)
+		y += 18
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/stats"
+)
+
+const petStep = 1.0 / 60
+
+// newPetTestGame starts a run with the given pet's achievement unlocked.
+func newPetTestGame(t *testing.T, pet PetType) *Game {
+	t.Helper()
+
+	g := &Game{}
+	s := stats.NewMemory()
+	s.Counter(statKills).Inc()
+	s.Counter(statBossKills).Inc()
+	g.initLifetime(s)
+	g.settings.Pet = pet
+	g.startGame(CharJunior)
+
+	if g.pet == nil {
+		t.Fatalf("no %s spawned", PetDefs[pet].Name)
+	}
+
+	return g
+}
+
+func TestPetNeedsUnlock(t *testing.T) {
+	g := &Game{}
+	g.initLifetime(stats.NewMemory())
+	g.settings.Pet = PetRubberDuck
+	g.startGame(CharJunior)
+
+	if g.pet != nil {
+		t.Fatal("locked pet spawned")
+	}
+
+	g.cyclePet(1)
+
+	if g.settings.Pet != PetNone {
+		t.Errorf("cyclePet selected locked pet %v", g.settings.Pet)
+	}
+}
+
+func TestCyclePetSkipsLocked(t *testing.T) {
+	g := &Game{}
+	s := stats.NewMemory()
+	s.Counter(statBossKills).Inc()
+	g.initLifetime(s)
+
+	g.cyclePet(1)
+
+	if g.settings.Pet != PetOfficeCat {
+		t.Errorf("cyclePet(1) = %v, want Office Cat past the locked duck", g.settings.Pet)
+	}
+
+	g.cyclePet(-1)
+
+	if g.settings.Pet != PetNone {
+		t.Errorf("cyclePet(-1) = %v, want None", g.settings.Pet)
+	}
+}
+
+func TestPetFollowsPlayer(t *testing.T) {
+	g := newPetTestGame(t, PetRubberDuck)
+	g.player.X, g.player.Y = 300, 0
+	g.player.FaceX = 1
+
+	for range 180 {
+		g.updatePet(petStep)
+	}
+
+	if d := g.player.X - petFollowDistance - g.pet.X; d > petStopRadius+1 || d < -1 {
+		t.Errorf("pet at x=%v, want about %v behind the player", g.pet.X, petFollowDistance)
+	}
+
+	if g.pet.Moving {
+		t.Error("pet still moving after settling")
+	}
+
+	g.player.X = 5000
+	g.updatePet(petStep)
+
+	if g.player.X-g.pet.X > petLeashDistance {
+		t.Errorf("pet left %v behind, want teleport past the leash", g.player.X-g.pet.X)
+	}
+}
+
+func TestPetFetchesGem(t *testing.T) {
+	g := newPetTestGame(t, PetOfficeCat)
+	gem := &XPGem{X: 0, Y: 200, Value: 1}
+	g.xpGems = append(g.xpGems, gem)
+
+	for range 600 {
+		g.updatePet(petStep)
+
+		if gem.Magnet {
+			break
+		}
+	}
+
+	if !gem.Magnet {
+		t.Fatal("pet never fetched the gem")
+	}
+
+	if g.pet.target != nil {
+		t.Error("pet kept the fetched gem as its target")
+	}
+}
+
+func TestPetBlocksHit(t *testing.T) {
+	g := newPetTestGame(t, PetRubberDuck)
+	hp := g.player.HP
+
+	g.hurtPlayer(30, 0, "test")
+
+	if g.player.HP != hp {
+		t.Errorf("HP = %d after blocked hit, want %d", g.player.HP, hp)
+	}
+
+	if g.pet.BlockReady() {
+		t.Error("block still ready after blocking")
+	}
+
+	g.hurtPlayer(30, 0, "test")
+
+	if g.player.HP >= hp {
+		t.Error("second hit was blocked during the cooldown")
+	}
+
+	for range int(PetDefs[PetRubberDuck].BlockCooldown/petStep) + 1 {
+		g.updatePet(petStep)
+	}
+
+	if !g.pet.BlockReady() {
+		t.Error("block not ready after the cooldown")
+	}
+}
+
+func TestOfficeCatNeverBlocks(t *testing.T) {
+	g := newPetTestGame(t, PetOfficeCat)
+	hp := g.player.HP
+
+	g.hurtPlayer(30, 0, "test")
+
+	if g.player.HP >= hp {
+		t.Error("Office Cat blocked a hit")
+	}
+}
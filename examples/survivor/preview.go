@@ -0,0 +1,323 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"maps"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/combo"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Weapon preview tuning.
+const (
+	previewW, previewH = 360, 240 // Offscreen sim size in world pixels
+	previewScale       = 0.5      // The sim is drawn at half size in the pane
+	previewLineH       = 18       // Pixels between stat lines
+	previewLoop        = 3.0      // Seconds before the sim restarts
+	previewDummies     = 6
+	previewDummyDist   = 100.0
+	previewDummyHP     = 1_000_000
+)
+
+// weaponPreview is a miniature headless run that loops one weapon's fire
+// pattern against a ring of target dummies, for the level-up screen.
+type weaponPreview struct {
+	weapon Weapon // Type and level being previewed
+	sim    *Game
+	ring   []*Enemy
+	dealt  int     // Damage dealt this loop
+	dps    float64 // Average over the last full loop
+	target *ebiten.Image
+}
+
+// newWeaponPreview starts a sim with a copy of the player's stats holding only
+// the given weapon. The real run is never touched.
+func (g *Game) newWeaponPreview(w Weapon) *weaponPreview {
+	player := *g.player
+	player.X, player.Y = 0, 0
+	player.FaceX, player.FaceY = 0, 0
+	player.Passives = maps.Clone(g.player.Passives)
+	player.Weapons = []*Weapon{{Type: w.Type, Level: w.Level}}
+	player.Ailments = components.StatusComponent{}
+
+	sim := &Game{
+		state:         StatePlaying,
+		player:        &player,
+		combo:         combo.NewMeter(comboConfig),
+		monsterImages: g.monsterImages,
+	}
+
+	wp := &weaponPreview{weapon: w, sim: sim}
+
+	for i := range previewDummies {
+		angle := 2 * math.Pi * float64(i) / previewDummies
+		wp.ring = append(wp.ring, &Enemy{
+			X: math.Cos(angle) * previewDummyDist, Y: math.Sin(angle) * previewDummyDist * 0.7,
+			HP: previewDummyHP, MaxHP: previewDummyHP,
+			Radius: 14,
+			Color:  color.RGBA{R: 160, G: 140, B: 110, A: 255},
+			Dummy:  true,
+		})
+	}
+
+	sim.enemies = append(sim.enemies, wp.ring...)
+
+	return wp
+}
+
+// step advances the sim one tick, healing the dummies and restarting the loop
+// once it runs out.
+func (p *weaponPreview) step(dt float64) {
+	sim := p.sim
+	sim.gameTime += dt
+
+	sim.updateWeapons(dt)
+	sim.updateProjectiles(dt)
+	sim.updateZones(dt)
+	sim.updateArcs(dt)
+	sim.updateEnemies(dt)
+	sim.updateEnemyAilments(dt)
+	sim.updateParticles(dt)
+
+	for _, e := range sim.enemies {
+		p.dealt += e.MaxHP - e.HP
+		e.HP = e.MaxHP
+	}
+
+	// Damage numbers are too small to read at preview scale
+	for _, d := range sim.damageNumbers {
+		sim.freeDamageNumber(d)
+	}
+
+	sim.damageNumbers = truncate(sim.damageNumbers)
+
+	if sim.gameTime >= previewLoop {
+		p.dps = float64(p.dealt) / sim.gameTime
+		sim.Reset()
+
+		for _, e := range p.ring {
+			e.Ailments = components.StatusComponent{}
+		}
+
+		sim.enemies = append(sim.enemies, p.ring...)
+		sim.player.Weapons[0].Timer = 0
+		p.dealt = 0
+	}
+}
+
+// previewFocus returns the level-up option the preview follows, or -1.
+func (g *Game) previewFocus() int {
+	if g.levelUpFocus < 0 || g.levelUpFocus >= len(g.upgradeOptions) {
+		return -1
+	}
+
+	return g.levelUpFocus
+}
+
+// levelUpOptionRect returns the screen rectangle of the i-th upgrade option.
+func levelUpOptionRect(i int) (x, y, w, h float32) {
+	boxX, boxY := float32(screenWidth-500)/2, float32(screenHeight-levelUpBoxH)/2
+
+	return boxX + 20, boxY + 50 + float32(i)*60, 460, 55
+}
+
+// updateWeaponPreview moves the focus with the arrow keys or the mouse and
+// steps the preview of the focused weapon option.
+func (g *Game) updateWeaponPreview() {
+	n := len(g.upgradeOptions)
+	if n == 0 {
+		g.preview = nil
+
+		return
+	}
+
+	switch controls.JustMoved(input.MoveY) {
+	case 1:
+		g.levelUpFocus = (g.levelUpFocus + 1) % n
+	case -1:
+		g.levelUpFocus = (g.levelUpFocus + n - 1) % n
+	}
+
+	// Hovering moves the focus only when the mouse moves, so it does not
+	// fight the arrow keys
+	mx, my := ebiten.CursorPosition()
+	moved := image.Pt(mx, my) != g.levelUpCursor
+	g.levelUpCursor = image.Pt(mx, my)
+
+	for i := range n {
+		x, y, w, h := levelUpOptionRect(i)
+		if moved && float32(mx) >= x && float32(mx) <= x+w && float32(my) >= y && float32(my) <= y+h {
+			g.levelUpFocus = i
+		}
+	}
+
+	g.levelUpFocus = min(g.levelUpFocus, n-1)
+
+	opt := g.upgradeOptions[g.levelUpFocus]
+	if !opt.IsWeapon {
+		g.preview = nil
+
+		return
+	}
+
+	w := Weapon{Type: opt.WeaponType, Level: opt.CurrentLvl + 1}
+	if g.preview == nil || g.preview.weapon != w {
+		wp := g.newWeaponPreview(w)
+		if g.preview != nil {
+			wp.target = g.preview.target // Reuse the offscreen image
+		}
+
+		g.preview = wp
+	}
+
+	g.preview.step(1.0 / 60.0)
+}
+
+// weaponStats are a weapon's effective numbers with the player's current
+// multipliers.
+type weaponStats struct {
+	Damage   int
+	Cooldown float64
+	Count    int
+	Range    float64
+	Duration float64
+}
+
+func (g *Game) weaponStats(wt WeaponType, level int) weaponStats {
+	def := WeaponDefs[wt]
+
+	return weaponStats{
+		Damage:   g.damageCalc(wt, level).Hit(),
+		Cooldown: def.Cooldown * g.player.EffectiveCooldownMult(),
+		Count:    def.Count + g.player.projectileBonus(),
+		Range:    def.Range * g.player.AreaMult,
+		Duration: def.Duration * g.player.DurationMult,
+	}
+}
+
+// upgradeFrom returns the weapon an option replaces or levels up, if any: the
+// same weapon for a level-up, or the base weapon for an evolution.
+func (g *Game) upgradeFrom(opt UpgradeOption) (Weapon, bool) {
+	base := opt.WeaponType
+
+	for _, recipe := range Evolutions {
+		if recipe.Result == opt.WeaponType {
+			base = recipe.BaseWeapon
+		}
+	}
+
+	for _, w := range g.player.Weapons {
+		if w.Type == opt.WeaponType || w.Type == base {
+			return *w, true
+		}
+	}
+
+	return Weapon{}, false
+}
+
+// previewStatLines describes the option's weapon after picking it, with the
+// change from what it replaces.
+func (g *Game) previewStatLines(opt UpgradeOption) []string {
+	to := g.weaponStats(opt.WeaponType, opt.CurrentLvl+1)
+
+	from, ok := g.upgradeFrom(opt)
+	if !ok {
+		return []string{
+			"New weapon",
+			fmt.Sprintf("Damage   %d", to.Damage),
+			fmt.Sprintf("Cooldown %.2fs", to.Cooldown),
+			fmt.Sprintf("Count    %d", to.Count),
+			fmt.Sprintf("Range    %.0f", to.Range),
+			fmt.Sprintf("Duration %.1fs", to.Duration),
+		}
+	}
+
+	was := g.weaponStats(from.Type, from.Level)
+	delta := func(a, b float64, format string) string {
+		line := fmt.Sprintf(format, b)
+		if d := b - a; math.Abs(d) > 1e-9 {
+			line += fmt.Sprintf(" (%+"+format[1:]+")", d)
+		}
+
+		return line
+	}
+
+	return []string{
+		"Lv " + formatInt(from.Level) + " -> " + formatInt(opt.CurrentLvl+1),
+		"Damage   " + delta(float64(was.Damage), float64(to.Damage), "%.0f"),
+		"Cooldown " + delta(was.Cooldown, to.Cooldown, "%.2f") + "s",
+		"Count    " + delta(float64(was.Count), float64(to.Count), "%.0f"),
+		"Range    " + delta(was.Range, to.Range, "%.0f"),
+		"Duration " + delta(was.Duration, to.Duration, "%.1f") + "s",
+	}
+}
+
+// drawWeaponPreview draws the focused weapon's looping sim, stat changes,
+// and damage breakdown beside the level-up panel.
+func (g *Game) drawWeaponPreview(screen *ebiten.Image) {
+	focus := g.previewFocus()
+	if g.preview == nil || focus < 0 {
+		return
+	}
+
+	opt := g.upgradeOptions[focus]
+	lines := g.previewStatLines(opt)
+	breakdown := g.damageCalc(opt.WeaponType, opt.CurrentLvl+1).Breakdown()
+	textH := (len(lines) + len(breakdown) + 2) * previewLineH
+	paneW, paneH := float32(previewW*previewScale+10), float32(previewH*previewScale+textH+47)
+	paneX := float32(screenWidth+500)/2 + 5
+	paneY := float32(screenHeight-levelUpBoxH) / 2
+
+	g.uiSkin().Panel.Draw(screen, float64(paneX), float64(paneY), float64(paneW), float64(paneH))
+	ui.DebugPrintAt(screen, WeaponDefs[opt.WeaponType].Name, int(paneX)+8, int(paneY)+8)
+
+	if g.preview.target == nil {
+		g.preview.target = ebiten.NewImage(previewW, previewH)
+	}
+
+	g.preview.draw(g.preview.target)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(previewScale, previewScale)
+	op.GeoM.Translate(float64(paneX)+5, float64(paneY)+28)
+	screen.DrawImage(g.preview.target, op)
+
+	y := int(paneY) + 36 + previewH*previewScale
+	for _, line := range lines {
+		ui.DebugPrintAt(screen, line, int(paneX)+8, y)
+		y += previewLineH
+	}
+
+	if g.preview.dps > 0 {
+		ui.DebugPrintAt(screen, fmt.Sprintf("Test DPS ~%.0f", g.preview.dps), int(paneX)+8, y)
+	}
+
+	y += previewLineH * 3 / 2
+	for _, line := range breakdown {
+		ui.DebugPrintAt(screen, line, int(paneX)+8, y)
+		y += previewLineH
+	}
+}
+
+// draw renders the sim centered on the player into target.
+func (p *weaponPreview) draw(target *ebiten.Image) {
+	sim := p.sim
+	sim.cameraX, sim.cameraY = -previewW/2, -previewH/2
+
+	target.Fill(color.RGBA{R: 25, G: 30, B: 40, A: 255})
+	sim.drawZones(target)
+	sim.drawEnemies(target)
+	sim.drawProjectiles(target)
+	sim.drawArcs(target)
+
+	pColor := Characters[sim.player.CharType].Color
+	vector.FillCircle(target, previewW/2, previewH/2, 14, pColor, false)
+	sim.drawParticles(target)
+}
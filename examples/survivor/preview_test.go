@@ -0,0 +1,56 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWeaponPreviewTestFires(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.showLevelUp()
+
+	g.upgradeOptions = []UpgradeOption{
+		{Name: "Git Push", IsWeapon: true, WeaponType: WeaponGitPush},
+		{Name: "Might", PassiveType: PassiveMight},
+	}
+
+	for range int(previewLoop*60) + 1 {
+		g.updateWeaponPreview()
+	}
+
+	if g.preview == nil || g.preview.weapon != (Weapon{Type: WeaponGitPush, Level: 1}) {
+		t.Fatalf("preview = %+v, want Git Push level 1", g.preview)
+	}
+
+	if g.preview.dps <= 0 {
+		t.Error("preview dummies took no damage over a loop")
+	}
+
+	// The sim runs beside the real run without touching it
+	if len(g.projectiles) != 0 || len(g.player.Weapons) != 1 || g.player.Weapons[0].Type != WeaponPrint {
+		t.Errorf("preview leaked into the run: %d projectiles, weapons %v", len(g.projectiles), g.player.Weapons)
+	}
+
+	g.levelUpFocus = 1
+	g.updateWeaponPreview()
+
+	if g.preview != nil {
+		t.Error("passive option should have no preview")
+	}
+}
+
+func TestPreviewStatLines(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	upgrade := UpgradeOption{IsWeapon: true, WeaponType: WeaponPrint, CurrentLvl: 1}
+	if lines := g.previewStatLines(upgrade); !slices.Contains(lines, "Damage   21 (+3)") {
+		t.Errorf("level-up lines %q, want the damage delta", lines)
+	}
+
+	fresh := UpgradeOption{IsWeapon: true, WeaponType: WeaponGitPush}
+	if lines := g.previewStatLines(fresh); lines[0] != "New weapon" {
+		t.Errorf("new weapon lines %q", lines)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+)
+
+// procReferenceCooldown is the weapon cooldown at which on-hit procs roll at
+// their full listed chance. Faster weapons roll proportionally less often, so
+// a 0.1s aura does not proc five times as much as a 0.5s projectile.
+const procReferenceCooldown = 0.5
+
+// char10xLifesteal is the 10x Eng's innate life steal.
+const char10xLifesteal = 0.05
+
+// thornsColor tints the particles of reflected contact damage.
+var thornsColor = color.RGBA{R: 120, G: 220, B: 80, A: 255}
+
+// OnHitProc is an "X% chance on hit to do Y" effect.
+type OnHitProc struct {
+	Name   string
+	Chance float64
+	Effect func(g *Game, p *Projectile, e *Enemy)
+}
+
+// forkLightningProc is the Forked Lightning legendary special as an on-hit proc.
+func forkLightningProc(jumps int) OnHitProc {
+	return OnHitProc{
+		Name:   "Forked Lightning",
+		Chance: forkLightningChance,
+		Effect: func(g *Game, p *Projectile, e *Enemy) {
+			cfg := forkLightning
+			cfg.Jumps = jumps
+			g.chainFrom(e, cfg, p.Damage, lightningColor, p.WeaponType)
+		},
+	}
+}
+
+// procChance normalizes a proc's chance by the cooldown of the weapon that hit.
+func procChance(chance, cooldown float64) float64 {
+	if cooldown >= procReferenceCooldown {
+		return chance
+	}
+
+	return chance * cooldown / procReferenceCooldown
+}
+
+// rollProcs gives each of the player's on-hit procs a chance to fire on e.
+func (g *Game) rollProcs(p *Projectile, e *Enemy) {
+	if len(g.player.Procs) == 0 {
+		return
+	}
+
+	cooldown := g.weaponCooldown(&Weapon{Type: p.WeaponType})
+
+	for _, proc := range g.player.Procs {
+		if g.rng.Stream(streamCombat).Float64() < procChance(proc.Chance, cooldown) {
+			proc.Effect(g, p, e)
+		}
+	}
+}
+
+// applyLifesteal heals a fraction of damage dealt. Fractions carry over
+// between hits so small hits still add up to whole hit points.
+func (g *Game) applyLifesteal(damage int) {
+	if g.player.Lifesteal <= 0 || g.player.HP >= g.player.MaxHP {
+		return
+	}
+
+	g.player.lifestealPool += float64(damage) * g.player.Lifesteal
+
+	heal := int(g.player.lifestealPool)
+	if heal <= 0 {
+		return
+	}
+
+	g.player.lifestealPool -= float64(heal)
+	heal = min(heal, g.player.MaxHP-g.player.HP)
+	g.player.HP += heal
+
+	g.logCombat(combatlog.Entry{
+		Category: combatlog.Heal,
+		Source:   "Life Steal",
+		Target:   "Player",
+		Amount:   heal,
+	})
+}
+
+// applyThorns reflects a fraction of an enemy's contact damage back at it.
+func (g *Game) applyThorns(e *Enemy, contact int) {
+	if g.player.Thorns <= 0 || e.Dead {
+		return
+	}
+
+	reflected := max(1, int(float64(contact)*g.player.Thorns))
+	g.damageEnemy(e, reflected, false, thornsColor, "Thorns")
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProcChanceNormalizedForFastWeapons(t *testing.T) {
+	cases := []struct {
+		cooldown, want float64
+	}{
+		{2.0, 0.2},
+		{procReferenceCooldown, 0.2},
+		{0.1, 0.04},
+	}
+
+	for _, c := range cases {
+		if got := procChance(0.2, c.cooldown); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("procChance(0.2, %v) = %v, want %v", c.cooldown, got, c.want)
+		}
+	}
+}
+
+func TestProcsFireAtNormalizedRate(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	fired := 0
+	g.player.Procs = []OnHitProc{{
+		Name:   "Counter",
+		Chance: 0.5,
+		Effect: func(*Game, *Projectile, *Enemy) { fired++ },
+	}}
+
+	const hits = 4000
+
+	e := &Enemy{HP: 100, MaxHP: 100}
+	for range hits {
+		g.rollProcs(&Projectile{WeaponType: WeaponEspresso}, e)
+	}
+
+	want := procChance(0.5, g.weaponCooldown(&Weapon{Type: WeaponEspresso})) * hits
+	if math.Abs(float64(fired)-want) > want*0.25 {
+		t.Errorf("proc fired %d times over %d hits, want about %.0f", fired, hits, want)
+	}
+}
+
+func TestLifestealCarriesFractions(t *testing.T) {
+	g := &Game{}
+	g.startGame(Char10x)
+
+	if g.player.Lifesteal != char10xLifesteal {
+		t.Fatalf("10x Eng lifesteal = %v, want %v", g.player.Lifesteal, char10xLifesteal)
+	}
+
+	g.recalculateStats()
+
+	if g.player.Lifesteal != char10xLifesteal {
+		t.Fatalf("lifesteal lost on recalculate: %v", g.player.Lifesteal)
+	}
+
+	g.player.HP = 10
+
+	// 5% of 10 damage is half a point; two hits heal one
+	g.applyLifesteal(10)
+
+	if g.player.HP != 10 {
+		t.Errorf("HP = %d after half a point of lifesteal, want 10", g.player.HP)
+	}
+
+	g.applyLifesteal(10)
+
+	if g.player.HP != 11 {
+		t.Errorf("HP = %d after a full point of lifesteal, want 11", g.player.HP)
+	}
+
+	g.player.HP = g.player.MaxHP
+	g.applyLifesteal(1000)
+
+	if g.player.HP != g.player.MaxHP {
+		t.Errorf("lifesteal overhealed: HP = %d, max %d", g.player.HP, g.player.MaxHP)
+	}
+}
+
+func TestThornsReflectContactDamage(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.Thorns = 0.5
+
+	e := &Enemy{X: g.player.X + 5, Y: g.player.Y, HP: 100, MaxHP: 100, Damage: 20, Radius: 10}
+	g.enemies = []*Enemy{e}
+
+	g.updateEnemies(1.0 / 60)
+
+	if e.HP != 90 {
+		t.Errorf("enemy HP = %d after contact, want 90 from 50%% thorns", e.HP)
+	}
+
+	if g.player.HP >= g.player.MaxHP {
+		t.Error("contact should still hurt the player")
+	}
+
+	// No reflection while the player is invulnerable after the hit
+	g.updateEnemies(1.0 / 60)
+
+	if e.HP != 90 {
+		t.Errorf("thorns reflected during hit invulnerability: HP = %d", e.HP)
+	}
+}
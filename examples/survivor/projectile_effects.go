@@ -0,0 +1,228 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/assets"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+)
+
+// DeathEffectKind selects what a projectile turns into when it dies.
+type DeathEffectKind int
+
+const (
+	DeathNone DeathEffectKind = iota
+	// DeathExplode deals area damage around the death point.
+	DeathExplode
+	// DeathSplit fans out smaller child projectiles.
+	DeathSplit
+	// DeathZone leaves a lingering area that damages enemies standing in it.
+	DeathZone
+)
+
+// DeathTrigger is a bit set of the events that fire a projectile's death effect.
+type DeathTrigger int
+
+const (
+	// TriggerExpire fires when the projectile runs out of lifetime or piercing.
+	TriggerExpire DeathTrigger = 1 << iota
+	// TriggerKill fires at the position of every enemy the projectile kills.
+	TriggerKill
+)
+
+// zoneTickInterval is the seconds between damage ticks of a lingering zone.
+const zoneTickInterval = 0.25
+
+// ProjectileDeathEffect configures a weapon's on-expire/on-kill behavior.
+// Damage is scaled from the dying projectile's damage by DamageMult.
+type ProjectileDeathEffect struct {
+	Kind       DeathEffectKind
+	Trigger    DeathTrigger
+	Radius     float64 // Explosion/zone radius, or child projectile radius for splits
+	DamageMult float64
+	Count      int     // Child projectiles for splits
+	Duration   float64 // Zone lifetime, or child projectile lifetime for splits
+	Speed      float64 // Child projectile speed for splits
+}
+
+// DamageZone is a lingering area left behind by a projectile.
+type DamageZone struct {
+	X, Y       float64
+	Radius     float64
+	Damage     int
+	Timer      float64
+	Duration   float64
+	TickTimer  float64
+	Color      color.RGBA
+	WeaponType WeaponType
+}
+
+// hitEnemy applies weapon damage to an enemy, rolling crits and life steal.
+// It returns true if the hit killed the enemy.
+func (g *Game) hitEnemy(e *Enemy, damage int, c color.RGBA, wt WeaponType) bool {
+	return g.hitEnemyTagged(e, damage, c, wt, weaponTags(wt))
+}
+
+// hitEnemyTagged is hitEnemy with explicit damage tags, so area hits can add
+// TagArea. Immune enemies take nothing and resistant ones take resistMult.
+func (g *Game) hitEnemyTagged(e *Enemy, damage int, c color.RGBA, wt WeaponType, tags DamageTag) bool {
+	taken := MonsterDefs[e.Type].damageTaken(tags)
+	if taken == 0 {
+		g.resistCue(e, "IMMUNE")
+
+		return false
+	}
+
+	crit, mult := g.rollCrit(wt)
+	damage = int(float64(damage) * mult)
+
+	if taken < 1 {
+		damage = max(1, int(float64(damage)*taken))
+		g.resistCue(e, "RESIST")
+	}
+
+	g.applyLifesteal(damage)
+	g.inflictEnemy(e, wt, damage)
+
+	return g.damageEnemy(e, damage, crit, c, WeaponDefs[wt].Name)
+}
+
+// damageEnemy deals final damage to an enemy with the usual feedback and a
+// combat log entry naming its source. It returns true if the enemy died.
+func (g *Game) damageEnemy(e *Enemy, damage int, crit bool, c color.RGBA, detail string) bool {
+	e.HP -= damage
+	e.HitFlash = 0.1
+
+	g.emitSound(e, assets.SoundHit)
+	g.spawnParticle(e.X, e.Y, 5, c)
+	g.addDamageNumber(e.X, e.Y, damage, crit)
+	g.logCombat(combatlog.Entry{
+		Category: combatlog.DamageDealt,
+		Source:   "Player",
+		Target:   MonsterDefs[e.Type].Name,
+		Amount:   damage,
+		Detail:   detail,
+	})
+
+	if e.HP <= 0 {
+		g.killEnemy(e)
+
+		return true
+	}
+
+	return false
+}
+
+// triggerDeathEffect runs the weapon's death effect at (x, y) if it listens for
+// the given trigger. Child projectiles never trigger, so splits cannot recurse.
+func (g *Game) triggerDeathEffect(p *Projectile, trigger DeathTrigger, x, y float64) {
+	fx := WeaponDefs[p.WeaponType].OnDeath
+	if p.Child || fx.Kind == DeathNone || fx.Trigger&trigger == 0 {
+		return
+	}
+
+	damage := max(1, int(float64(p.Damage)*fx.DamageMult))
+
+	switch fx.Kind {
+	case DeathExplode:
+		g.explode(x, y, fx.Radius, damage, p.Color, p.WeaponType)
+	case DeathSplit:
+		g.splitProjectile(p, x, y, fx, damage)
+	case DeathZone:
+		g.zones = append(g.zones, &DamageZone{
+			X: x, Y: y,
+			Radius:     fx.Radius * g.player.AreaMult,
+			Damage:     damage,
+			Duration:   fx.Duration * g.player.DurationMult,
+			Color:      p.Color,
+			WeaponType: p.WeaponType,
+		})
+	case DeathNone:
+	}
+}
+
+// explode damages every living enemy within radius of (x, y).
+func (g *Game) explode(x, y, radius float64, damage int, c color.RGBA, wt WeaponType) {
+	radius *= g.player.AreaMult
+
+	for _, e := range g.enemies {
+		if e.Dead || math.Hypot(e.X-x, e.Y-y) > radius+e.Radius {
+			continue
+		}
+
+		g.hitEnemyTagged(e, damage, c, wt, weaponTags(wt)|TagArea)
+	}
+
+	g.spawnParticle(x, y, 20, c)
+}
+
+// splitProjectile fans child projectiles evenly around (x, y), starting from
+// the parent's heading so pods keep flying forward.
+func (g *Game) splitProjectile(parent *Projectile, x, y float64, fx ProjectileDeathEffect, damage int) {
+	heading := math.Atan2(parent.VY, parent.VX)
+	c, wt := parent.Color, parent.WeaponType
+
+	for i := range fx.Count {
+		if !g.projectileRoom() {
+			break
+		}
+
+		angle := heading + float64(i)*2*math.Pi/float64(fx.Count)
+
+		child := g.newProjectile()
+		child.X, child.Y = x, y
+		child.VX, child.VY = math.Cos(angle)*fx.Speed, math.Sin(angle)*fx.Speed
+		child.Damage = damage
+		child.Lifetime = fx.Duration
+		child.Radius = fx.Radius
+		child.Piercing = 1
+		child.Color = c
+		child.WeaponType = wt
+		child.Child, child.Combo = true, parent.Combo
+		g.projectiles = append(g.projectiles, child)
+	}
+}
+
+// updateZones ticks lingering damage zones and removes expired ones.
+func (g *Game) updateZones(dt float64) {
+	kept := g.zones[:0]
+
+	for _, z := range g.zones {
+		z.Timer += dt
+
+		z.TickTimer -= dt
+		if z.TickTimer <= 0 {
+			z.TickTimer = zoneTickInterval
+
+			for _, e := range g.enemies {
+				if !e.Dead && math.Hypot(e.X-z.X, e.Y-z.Y) <= z.Radius+e.Radius {
+					g.hitEnemyTagged(e, z.Damage, z.Color, z.WeaponType, weaponTags(z.WeaponType)|TagArea)
+				}
+			}
+		}
+
+		if z.Timer < z.Duration {
+			kept = append(kept, z)
+		}
+	}
+
+	g.zones = kept
+}
+
+// drawZones renders lingering zones as translucent discs that fade out.
+func (g *Game) drawZones(screen *ebiten.Image) {
+	for _, z := range g.zones {
+		sx, sy := float32(z.X-g.cameraX), float32(z.Y-g.cameraY)
+		fade := float32(1 - z.Timer/z.Duration)
+		pulse := float32(1 + 0.05*math.Sin(z.Timer*8))
+
+		fill := color.NRGBA{R: z.Color.R, G: z.Color.G, B: z.Color.B, A: uint8(70 * fade)}
+		edge := color.NRGBA{R: z.Color.R, G: z.Color.G, B: z.Color.B, A: uint8(180 * fade)}
+
+		vector.FillCircle(screen, sx, sy, float32(z.Radius)*pulse, fill, false)
+		vector.StrokeCircle(screen, sx, sy, float32(z.Radius)*pulse, 2, edge, false)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import "testing"
+
+func TestK8sSplitsIntoPodsOnExpire(t *testing.T) {
+	g, _ := newWeaponTestGame(WeaponK8s)
+	g.enemies = nil
+
+	g.player.Weapons[0].Timer = 100
+	g.updateWeapons(0)
+	g.projectiles[0].Lifetime = 0
+	g.updateProjectiles(1.0 / 60)
+
+	fx := WeaponDefs[WeaponK8s].OnDeath
+	if len(g.projectiles) != fx.Count {
+		t.Fatalf("projectiles = %d after expiry, want %d pods", len(g.projectiles), fx.Count)
+	}
+
+	for _, p := range g.projectiles {
+		if !p.Child || p.Radius != fx.Radius {
+			t.Errorf("pod = %+v, want child with radius %v", p, fx.Radius)
+		}
+	}
+
+	if n := g.liveInstances(WeaponK8s); n != 0 {
+		t.Errorf("pods should not hold the queued cast: live = %d", n)
+	}
+
+	// Pods expire without splitting again
+	for _, p := range g.projectiles {
+		p.Lifetime = 0
+	}
+
+	g.updateProjectiles(1.0 / 60)
+
+	if len(g.projectiles) != 0 {
+		t.Errorf("pods split recursively: %d projectiles left", len(g.projectiles))
+	}
+}
+
+func TestForcePushExplodesOnKill(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	disableCrits(g)
+
+	victim := &Enemy{X: 100, HP: 1, MaxHP: 1, Radius: 10}
+	bystander := &Enemy{X: 130, HP: 1000, MaxHP: 1000, Radius: 10}
+	farAway := &Enemy{X: 400, HP: 1000, MaxHP: 1000, Radius: 10}
+	g.enemies = []*Enemy{victim, bystander, farAway}
+
+	p := g.newProjectile()
+	p.X, p.Y = 100, 0
+	p.Damage, p.Lifetime, p.Radius, p.Piercing = 50, 1, 6, 1
+	p.WeaponType = WeaponForcePush
+	g.projectiles = append(g.projectiles, p)
+
+	g.updateProjectiles(0)
+
+	if !victim.Dead {
+		t.Fatal("projectile should have killed the victim")
+	}
+
+	want := 1000 - int(50*WeaponDefs[WeaponForcePush].OnDeath.DamageMult)
+	if bystander.HP != want {
+		t.Errorf("bystander HP = %d, want %d from explosion", bystander.HP, want)
+	}
+
+	if farAway.HP != 1000 {
+		t.Errorf("enemy outside the blast took damage: HP = %d", farAway.HP)
+	}
+}
+
+func TestDockerLeavesTickingZone(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	disableCrits(g)
+
+	dummy := &Enemy{X: 300, HP: 1000, MaxHP: 1000, Radius: 10}
+	g.enemies = []*Enemy{dummy}
+
+	p := g.newProjectile()
+	p.X, p.Y = 300, 0
+	p.Damage, p.Lifetime, p.Radius, p.Piercing = 40, 0, 15, 999
+	p.WeaponType = WeaponDocker
+	p.HitList[dummy] = true
+	g.projectiles = append(g.projectiles, p)
+
+	g.updateProjectiles(0)
+
+	if len(g.zones) != 1 {
+		t.Fatalf("zones = %d after Docker expired, want 1", len(g.zones))
+	}
+
+	fx := WeaponDefs[WeaponDocker].OnDeath
+	dt := 1.0 / 60
+
+	for elapsed := 0.0; elapsed < fx.Duration+0.5; elapsed += dt {
+		g.updateZones(dt)
+	}
+
+	if len(g.zones) != 0 {
+		t.Errorf("zone outlived its %vs duration", fx.Duration)
+	}
+
+	ticks := int(fx.Duration/zoneTickInterval) + 1
+	perTick := int(40 * fx.DamageMult)
+
+	if taken := dummy.MaxHP - dummy.HP; taken < (ticks-1)*perTick || taken > ticks*perTick {
+		t.Errorf("zone dealt %d damage, want about %d ticks of %d", taken, ticks, perTick)
+	}
+}
@@ -31,7 +31,9 @@ func TestQASession(t *testing.T) {
 	detector := ai.NewAnomalyDetector()
 	detector.EntityLeakThreshold = 300 // More lenient for survivor
 	detector.StuckThreshold = 300      // 5 seconds stuck
-	detector.BoundsWidth = 10000       // Large world
+	detector.BoundsX = -5000           // Large world centered on the spawn point
+	detector.BoundsY = -5000
+	detector.BoundsWidth = 10000
 	detector.BoundsHeight = 10000
 	session.SetDetector(detector)
 
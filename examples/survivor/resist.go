@@ -0,0 +1,130 @@
+package main
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+)
+
+// DamageTag classifies a hit for monster resistances: its element, plus Area
+// for explosions, lingering zones, and aura weapons.
+type DamageTag uint8
+
+const (
+	TagPhysical DamageTag = 1 << iota
+	TagFire
+	TagLightning
+	TagArea
+	TagPoison
+)
+
+// Resistance tuning.
+const (
+	resistMult     = 0.5 // Damage taken from a resisted tag
+	resistCueDelay = 0.6 // Seconds between IMMUNE/RESIST cues on one enemy
+)
+
+// damageTagDefs names the tags in badge order.
+var damageTagDefs = []struct {
+	Tag   DamageTag
+	Name  string
+	Color color.RGBA
+}{
+	{TagPhysical, "Physical", color.RGBA{R: 200, G: 200, B: 200, A: 255}},
+	{TagFire, "Fire", color.RGBA{R: 255, G: 120, B: 30, A: 255}},
+	{TagLightning, "Lightning", color.RGBA{R: 120, G: 200, B: 255, A: 255}},
+	{TagPoison, "Poison", color.RGBA{R: 140, G: 220, B: 60, A: 255}},
+	{TagArea, "Area", color.RGBA{R: 190, G: 120, B: 255, A: 255}},
+}
+
+// weaponTags returns the tags of a direct hit from a weapon.
+func weaponTags(wt WeaponType) DamageTag {
+	def := WeaponDefs[wt]
+
+	var tags DamageTag
+
+	switch def.DamageType {
+	case "", components.DamagePhysical:
+		tags = TagPhysical
+	case components.DamageFire:
+		tags = TagFire
+	case components.DamageLightning:
+		tags = TagLightning
+	case components.DamageChaos:
+		tags = TagPoison
+	}
+
+	if def.Area {
+		tags |= TagArea
+	}
+
+	return tags
+}
+
+// tagNames lists a set of tags, e.g. "Fire, Area".
+func tagNames(tags DamageTag) string {
+	var names []string
+
+	for _, td := range damageTagDefs {
+		if tags&td.Tag != 0 {
+			names = append(names, td.Name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// damageTaken returns the fraction of a tagged hit the monster takes: 0 if it
+// is immune to any of the tags, resistMult if it resists any, else 1.
+func (d *MonsterDef) damageTaken(tags DamageTag) float64 {
+	switch {
+	case d.Immune&tags != 0:
+		return 0
+	case d.Resists&tags != 0:
+		return resistMult
+	default:
+		return 1
+	}
+}
+
+// resistCue floats IMMUNE or RESIST over an enemy, at most once per
+// resistCueDelay so rapid hits do not bury it.
+func (g *Game) resistCue(e *Enemy, text string) {
+	if g.gameTime < e.resistCueAt {
+		return
+	}
+
+	e.resistCueAt = g.gameTime + resistCueDelay
+
+	g.addDamageNumber(e.X, e.Y-e.Radius, 0, false)
+	g.damageNumbers[len(g.damageNumbers)-1].Text = text
+}
+
+// drawTagBadges draws a monster's immunities and resistances as labeled
+// badges starting at (x, y).
+func drawTagBadges(screen *ebiten.Image, def *MonsterDef, x, y int) {
+	for _, td := range damageTagDefs {
+		label := ""
+
+		switch {
+		case def.Immune&td.Tag != 0:
+			label = "IMMUNE " + td.Name
+		case def.Resists&td.Tag != 0:
+			label = "RESIST " + td.Name
+		default:
+			continue
+		}
+
+		w := float32(len(label)*6 + 12)
+		vector.FillRect(screen, float32(x), float32(y), w, 18, color.NRGBA{R: 0, G: 0, B: 0, A: 120}, false)
+		vector.StrokeRect(screen, float32(x), float32(y), w, 18, 1, td.Color, false)
+		vector.FillRect(screen, float32(x)+3, float32(y)+6, 6, 6, td.Color, false)
+		ebitenutil.DebugPrintAt(screen, label, x+12, y+1)
+
+		x += int(w) + 8
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import "testing"
+
+func TestMonsterResistances(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	disableCrits(g)
+
+	strike := func(mt MonsterType, area bool, wt WeaponType) (lost int, cue string) {
+		e := &Enemy{Type: mt, HP: 1000, MaxHP: 1000, Radius: 10}
+		g.damageNumbers = nil
+
+		tags := weaponTags(wt)
+		if area {
+			tags |= TagArea
+		}
+
+		g.hitEnemyTagged(e, 100, WeaponDefs[wt].Color, wt, tags)
+
+		if len(g.damageNumbers) > 0 {
+			cue = g.damageNumbers[0].Text
+		}
+
+		return e.MaxHP - e.HP, cue
+	}
+
+	tests := []struct {
+		name     string
+		monster  MonsterType
+		area     bool
+		weapon   WeaponType
+		lost     int
+		wantText string
+	}{
+		{"physical on Downtime", MonsterDowntime, false, WeaponPrint, 50, "RESIST"},
+		{"fire on Downtime", MonsterDowntime, false, WeaponFirewall, 100, ""},
+		{"direct hit on Race Condition", MonsterRaceCond, false, WeaponGitPush, 100, ""},
+		{"explosion on Race Condition", MonsterRaceCond, true, WeaponForcePush, 50, "RESIST"},
+		{"aura on Race Condition", MonsterRaceCond, false, WeaponFirewall, 50, "RESIST"},
+		{"fire on Legacy Code", MonsterLegacy, false, WeaponZeroTrust, 50, "RESIST"},
+		{"physical on Minor Bug", MonsterBug, false, WeaponPrint, 100, ""},
+	}
+
+	for _, tt := range tests {
+		lost, cue := strike(tt.monster, tt.area, tt.weapon)
+		if lost != tt.lost || cue != tt.wantText {
+			t.Errorf("%s: lost %d with cue %q, want %d with %q", tt.name, lost, cue, tt.lost, tt.wantText)
+		}
+	}
+}
+
+func TestResistCueThrottled(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	cues := func() int {
+		n := 0
+
+		for _, d := range g.damageNumbers {
+			if d.Text == "RESIST" {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	e := &Enemy{Type: MonsterDowntime, HP: 1000, MaxHP: 1000, Radius: 10}
+	for range 5 {
+		g.hitEnemy(e, 10, WeaponDefs[WeaponPrint].Color, WeaponPrint)
+	}
+
+	if cues() != 1 {
+		t.Errorf("%d cues after rapid resisted hits, want 1", cues())
+	}
+
+	g.gameTime += resistCueDelay
+	g.hitEnemy(e, 10, WeaponDefs[WeaponPrint].Color, WeaponPrint)
+
+	if cues() != 2 {
+		t.Errorf("%d cues after the delay, want 2", cues())
+	}
+}
+
+func TestStartingWeaponsDamageEveryMonster(t *testing.T) {
+	for _, c := range Characters {
+		tags := weaponTags(c.StartWeapon)
+
+		for mt, def := range MonsterDefs {
+			if !def.IsBoss && def.damageTaken(tags) == 0 {
+				t.Errorf("%s's %s cannot damage %s (type %d)", c.Name, WeaponDefs[c.StartWeapon].Name, def.Name, mt)
+			}
+		}
+	}
+}
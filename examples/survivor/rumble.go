@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/input"
+)
+
+// Controller rumble strength steps on the help screen: RumbleLevel counts
+// steps down from full strength, so the unset default rumbles fully and
+// rumbleLevels turns it off.
+const (
+	rumbleLevels    = 4
+	rumbleLevelStep = 1.0 / rumbleLevels
+)
+
+// Rumble effects played on game events.
+var (
+	rumbleSample = input.Pulse(1, 0.2)     // Lets the player feel a new strength
+	rumbleBoss   = input.Ramp(0.2, 1, 1.2) // Builds as the boss closes in
+	rumbleEvolve = input.Pattern(
+		input.Step{Duration: 0.12, Strong: 0.8, Weak: 0.3},
+		input.Step{Duration: 0.08},
+		input.Step{Duration: 0.25, Strong: 0.4, Weak: 1},
+	)
+)
+
+// rumbleHit returns the rumble of a hit that cost the player taken HP,
+// stronger the bigger a share of their health it took.
+func rumbleHit(taken, maxHP int) input.Effect {
+	share := float64(taken) / float64(max(maxHP, 1))
+
+	return input.Pulse(min(0.3+share*3, 1), 0.15)
+}
+
+// clampRumbleLevel keeps a rumble level between full strength and off.
+func clampRumbleLevel(level int) int {
+	return min(max(level, 0), rumbleLevels)
+}
+
+// rumbleIntensity returns the rumble strength from the settings, 0 to 1.
+func (s Settings) rumbleIntensity() float64 {
+	return 1 - float64(clampRumbleLevel(s.RumbleLevel))*rumbleLevelStep
+}
+
+// rumbleLabel describes the rumble setting for the help screen.
+func (g *Game) rumbleLabel() string {
+	if g.rumble == nil || !g.rumble.Supported() {
+		return "Unavailable"
+	}
+
+	intensity := g.settings.rumbleIntensity()
+	if intensity <= 0 {
+		return "Off"
+	}
+
+	return formatInt(int(math.Round(intensity*100))) + "%"
+}
+
+// initRumble creates the controller rumble at the saved strength.
+func (g *Game) initRumble() {
+	g.rumble = input.NewRumble()
+	g.rumble.Intensity = g.settings.rumbleIntensity()
+}
+
+// playRumble plays an effect on the player's gamepad, except in demo runs.
+func (g *Game) playRumble(e input.Effect) {
+	if g.rumble != nil && !g.autopilot {
+		g.rumble.Play(e)
+	}
+}
+
+// updateRumble advances the rumble by one tick. Leaving the run for a
+// paused screen, e.g. the pause menu, stills the effects it started.
+func (g *Game) updateRumble() {
+	if g.rumble == nil {
+		return
+	}
+
+	live := g.state == StatePlaying || g.state == StateLevelUp
+	if g.rumbleLive && !live {
+		g.rumble.Stop()
+	}
+
+	g.rumbleLive = live
+	g.rumble.Update(1 / float64(max(g.tps, 1)))
+}
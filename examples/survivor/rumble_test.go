@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestRumbleSettingSteps(t *testing.T) {
+	g := &Game{}
+	g.initRumble()
+
+	if g.rumble.Intensity != 1 {
+		t.Fatalf("default intensity = %v, want full", g.rumble.Intensity)
+	}
+
+	g.adjustSetting(helpRowRumble, -1)
+
+	if g.settings.RumbleLevel != 1 || g.rumble.Intensity != 0.75 {
+		t.Errorf("one step down: level %d, intensity %v", g.settings.RumbleLevel, g.rumble.Intensity)
+	}
+
+	for range rumbleLevels + 2 {
+		g.adjustSetting(helpRowRumble, -1)
+	}
+
+	if g.settings.RumbleLevel != rumbleLevels || g.rumble.Intensity != 0 {
+		t.Errorf("all the way down: level %d, intensity %v; want off", g.settings.RumbleLevel, g.rumble.Intensity)
+	}
+
+	g.adjustSetting(helpRowRumble, 1)
+
+	if g.rumble.Intensity != 0.25 {
+		t.Errorf("one step back up: intensity %v", g.rumble.Intensity)
+	}
+}
+
+func TestRumbleHitScalesWithDamage(t *testing.T) {
+	graze, _ := rumbleHit(1, 100).At(0)
+	heavy, _ := rumbleHit(40, 100).At(0)
+
+	if graze >= heavy || heavy != 1 {
+		t.Errorf("graze %v, heavy hit %v; want a bigger share of HP to rumble harder, capped at 1", graze, heavy)
+	}
+}
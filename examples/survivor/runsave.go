@@ -0,0 +1,480 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// Run save slots: runSlotCount manual slots picked from the pause menu, and
+// one written when the window is closed mid-run.
+const (
+	runSlotCount = 3
+	runAutoSlot  = "run_auto"
+)
+
+var errNoRunSaves = errors.New("run saves are unavailable")
+
+// runSlots lists the slots in menu order; the save menu skips the autosave.
+func runSlots() []string {
+	slots := make([]string, 0, runSlotCount+1)
+	for i := range runSlotCount {
+		slots = append(slots, fmt.Sprintf("run_%d", i+1))
+	}
+
+	return append(slots, runAutoSlot)
+}
+
+// runSave is the state of a run in progress. The rest of the run, such as
+// the passive tree, the seeded streams, and the player's derived stats, is
+// rebuilt from the config and recalculated on load. Enemies, projectiles,
+// and XP and gold pickups are not kept: a loaded run resumes on a field
+// holding only the item drops that were lying on it.
+type runSave struct {
+	Run        RunConfig
+	GameTime   float64
+	SpawnTimer float64
+	BossTimer  float64
+	Kills      int
+
+	// The game over note's markers; see Game.runAssisted
+	Assisted  bool
+	GameSpeed float64
+
+	X, Y           float64
+	HP             int
+	Shield         float64
+	XP             int
+	Level          int
+	Gold           int
+	Weapons        []Weapon
+	Passives       map[PassiveType]int
+	Equipment      map[EquipSlot]*Equipment
+	Inventory      []*Equipment
+	Drops          []ItemDrop
+	Scrap          int
+	PassivePoints  int
+	AllocatedNodes []int
+	Respecs        int
+	Tokens         LevelUpTokens
+	PendingLevels  int
+	UsedRevival    bool
+	Mutation       string
+}
+
+// runSlotInfo is a save slot as listed in the save and load menus.
+type runSlotInfo struct {
+	Slot    string
+	Saved   time.Time
+	Summary string // Empty for an empty slot
+}
+
+// runSaveManager returns the save manager for run saves, kept with the
+// lifetime stats, or nil when there is nowhere to keep them.
+func runSaveManager() *game.SaveManager {
+	store, err := survivorApp.Open(paths.Data)
+	if err != nil {
+		log.Printf("run saves: %v", err)
+
+		return nil
+	}
+
+	return game.NewSaveManagerFS(store)
+}
+
+// snapshotRun captures the current run.
+func (g *Game) snapshotRun() runSave {
+	p := g.player
+
+	s := runSave{
+		Run: g.run, GameTime: g.gameTime, SpawnTimer: g.spawnTimer, BossTimer: g.bossTimer,
+		Kills: g.killCount, Assisted: g.runAssisted, GameSpeed: g.runGameSpeed,
+		X: p.X, Y: p.Y, HP: p.HP, Shield: p.Shield, XP: p.XP, Level: p.Level, Gold: p.Gold,
+		Passives: p.Passives, Equipment: p.Equipment, Inventory: slices.Clone(p.Inventory),
+		PassivePoints: p.PassivePoints, Respecs: p.Respecs, Tokens: p.Tokens, UsedRevival: p.UsedRevival,
+		Mutation: p.Mutation, PendingLevels: p.PendingLevels, Scrap: p.Scrap,
+	}
+
+	// A choice on screen is still owed
+	if g.state == StateLevelUp {
+		s.PendingLevels++
+	}
+
+	for _, d := range g.itemDrops {
+		s.Drops = append(s.Drops, *d)
+	}
+
+	for _, w := range p.Weapons {
+		s.Weapons = append(s.Weapons, *w)
+	}
+
+	for id, ok := range p.AllocatedNodes {
+		if ok {
+			s.AllocatedNodes = append(s.AllocatedNodes, id)
+		}
+	}
+
+	slices.Sort(s.AllocatedNodes)
+
+	return s
+}
+
+// restoreRun starts the saved run's config and puts the player back as they
+// were.
+func (g *Game) restoreRun(s runSave) {
+	g.sandbox = nil
+	g.startRun(s.Run)
+
+	g.gameTime, g.spawnTimer, g.bossTimer = s.GameTime, s.SpawnTimer, s.BossTimer
+	g.killCount = s.Kills
+
+	// startRun marked the run from the current settings; keep what it was
+	// played with before the save too
+	g.runAssisted = g.runAssisted || s.Assisted
+	if s.GameSpeed > 0 {
+		g.runGameSpeed = min(g.runGameSpeed, s.GameSpeed)
+	}
+
+	p := g.player
+	p.X, p.Y = s.X, s.Y
+	p.XP, p.Level, p.Gold, p.Scrap = s.XP, max(s.Level, 1), s.Gold, s.Scrap
+	p.PassivePoints, p.PendingLevels, p.Respecs = s.PassivePoints, s.PendingLevels, s.Respecs
+	p.UsedRevival = s.UsedRevival
+	p.Mutation = s.Mutation
+
+	p.Weapons = p.Weapons[:0]
+	for _, w := range s.Weapons {
+		p.Weapons = append(p.Weapons, &w)
+	}
+
+	if s.Passives != nil {
+		p.Passives = s.Passives
+	}
+
+	if s.Equipment != nil {
+		p.Equipment = s.Equipment
+	}
+
+	p.Inventory = append(p.Inventory[:0], s.Inventory...)
+
+	for _, d := range s.Drops {
+		g.itemDrops = append(g.itemDrops, &d)
+	}
+
+	for _, id := range s.AllocatedNodes {
+		p.AllocatedNodes[id] = true
+	}
+
+	p.Tokens = s.Tokens
+	if p.Tokens.Banished == nil {
+		p.Tokens.Banished = make(map[string]bool)
+	}
+
+	p.Tokens.Banishing = false
+	p.HasRevival = p.Passives[PassiveRevival] > 0
+
+	g.recalculateStats()
+	p.HP = min(max(s.HP, 1), p.MaxHP)
+	p.Shield = min(s.Shield, p.MaxShield)
+	g.discoverLoadout()
+}
+
+// saveRun writes the current run to slot.
+func (g *Game) saveRun(slot string) error {
+	if g.runSaves == nil {
+		return errNoRunSaves
+	}
+
+	// Round-trip through JSON so the checksum sees the same map on load
+	raw, err := json.Marshal(g.snapshotRun())
+	if err != nil {
+		return err
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return err
+	}
+
+	save := game.NewSaveData(slot)
+	save.PlayTime = g.gameTime
+	save.Set("run", data)
+
+	return g.runSaves.SaveSafe(slot, save)
+}
+
+// loadRunSave reads the run saved in slot.
+func (g *Game) loadRunSave(slot string) (runSave, time.Time, error) {
+	var s runSave
+
+	if g.runSaves == nil {
+		return s, time.Time{}, errNoRunSaves
+	}
+
+	save, recovered, err := g.runSaves.LoadSafe(slot)
+	if err != nil {
+		return s, time.Time{}, err
+	}
+
+	if recovered {
+		log.Printf("run saves: %s was corrupt; restored the last good backup", slot)
+	}
+
+	data, ok := save.Get("run")
+	if !ok {
+		return s, time.Time{}, fmt.Errorf("%w: no run in %s", game.ErrSaveCorrupted, slot)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return s, time.Time{}, err
+	}
+
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return s, time.Time{}, fmt.Errorf("%w: %w", game.ErrSaveCorrupted, err)
+	}
+
+	if int(s.Run.Char) < 0 || int(s.Run.Char) >= len(Characters) {
+		return s, time.Time{}, fmt.Errorf("%w: unknown character %d", game.ErrSaveCorrupted, s.Run.Char)
+	}
+
+	return s, time.Unix(save.Timestamp, 0), nil
+}
+
+// inRun reports whether a run that can be saved is in progress: not over,
+// not in the training arena, and not behind a menu outside the run.
+func (g *Game) inRun() bool {
+	if g.player == nil || g.sandbox != nil {
+		return false
+	}
+
+	switch g.state {
+	case StatePlaying, StateLevelUp, StatePaused, StateEquipment, StatePassiveTree, StateHelp, StateSaveMenu,
+		StateStats, StateCrafting:
+		return true
+	}
+
+	return false
+}
+
+// saveOnClose writes the lifetime stats and, mid-run, keeps the run in the
+// autosave slot when the window is closed, so quitting does not lose either.
+func (g *Game) saveOnClose() {
+	g.flushLifetime()
+
+	if !g.inRun() {
+		return
+	}
+
+	if err := g.saveRun(runAutoSlot); err != nil {
+		log.Printf("run saves: %v", err)
+	}
+}
+
+// closeSave handles the window's close button around every other wrapper,
+// so a close while the demo plays or the window is unfocused, when the game
+// itself is not updated, still saves the run and quits.
+type closeSave struct {
+	ebiten.Game
+
+	host    *Game
+	closing func() bool // Platform hook, replaced in tests
+}
+
+// withCloseSave wraps game, the outermost one, to save host's run on close.
+func withCloseSave(game ebiten.Game, host *Game) *closeSave {
+	return &closeSave{Game: game, host: host, closing: ebiten.IsWindowBeingClosed}
+}
+
+// Update saves and quits on a close request, else updates the game.
+func (c *closeSave) Update() error {
+	if c.closing() {
+		c.host.saveOnClose()
+
+		return ebiten.Termination
+	}
+
+	return c.Game.Update()
+}
+
+// refreshRunSlots re-reads the slot list for the save and load menus.
+func (g *Game) refreshRunSlots() {
+	g.runSlots = g.runSlots[:0]
+
+	for _, slot := range runSlots() {
+		info := runSlotInfo{Slot: slot}
+
+		if g.runSaves != nil && g.runSaves.HasSafeSave(slot) {
+			if s, saved, err := g.loadRunSave(slot); err != nil {
+				info.Summary = "(unreadable)"
+			} else {
+				info.Saved = saved
+				info.Summary = fmt.Sprintf("%s  Lv %d  %s  %d kills",
+					Characters[s.Run.Char].Name, s.Level, formatTime(s.GameTime), s.Kills)
+			}
+		}
+
+		g.runSlots = append(g.runSlots, info)
+	}
+}
+
+// openSaveMenu lists the manual slots to save the current run into.
+func (g *Game) openSaveMenu() {
+	g.refreshRunSlots()
+	g.runSlots = g.runSlots[:runSlotCount]
+	g.runSlotSel, g.runSlotMsg = 0, ""
+	g.state = StateSaveMenu
+}
+
+// openLoadMenu lists every slot, the autosave included, to resume from.
+func (g *Game) openLoadMenu() {
+	g.refreshRunSlots()
+	g.runSlotSel, g.runSlotMsg = 0, ""
+	g.state = StateLoadMenu
+}
+
+// moveRunSlot moves the menu selection, wrapping around.
+func (g *Game) moveRunSlot(d int) {
+	n := len(g.runSlots)
+	g.runSlotSel = ((g.runSlotSel+d)%n + n) % n
+}
+
+func (g *Game) updateSaveMenu() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.state = StatePaused
+
+		return nil
+	}
+
+	g.updateRunSlotCursor()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		slot := g.runSlots[g.runSlotSel].Slot
+		if err := g.saveRun(slot); err != nil {
+			g.runSlotMsg = "Save failed: " + err.Error()
+
+			return nil
+		}
+
+		sel := g.runSlotSel
+		g.openSaveMenu()
+		g.runSlotSel, g.runSlotMsg = sel, "Saved"
+		g.audio.PlaySound("select")
+	}
+
+	return nil
+}
+
+func (g *Game) updateLoadMenu() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.state = StateCharSelect
+
+		return nil
+	}
+
+	g.updateRunSlotCursor()
+
+	info := g.runSlots[g.runSlotSel]
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		if info.Summary == "" {
+			return nil
+		}
+
+		s, _, err := g.loadRunSave(info.Slot)
+		if err != nil {
+			g.runSlotMsg = "Load failed: " + err.Error()
+
+			return nil
+		}
+
+		g.restoreRun(s)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete) && info.Summary != "":
+		if err := g.runSaves.DeleteSafe(info.Slot); err != nil {
+			g.runSlotMsg = "Delete failed: " + err.Error()
+
+			return nil
+		}
+
+		sel := g.runSlotSel
+		g.openLoadMenu()
+		g.runSlotSel = sel
+	}
+
+	return nil
+}
+
+func (g *Game) updateRunSlotCursor() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
+		g.moveRunSlot(-1)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.moveRunSlot(1)
+	}
+}
+
+// drawRunSlots draws the save or load menu over the dimmed screen.
+func (g *Game) drawRunSlots(screen *ebiten.Image, title, hint string) {
+	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, ui.CurrentTheme().Palette.Overlay, false)
+
+	boxW, boxH := 460, 110+len(g.runSlots)*40
+	boxX, boxY := (screenWidth-boxW)/2, (screenHeight-boxH)/2
+
+	g.uiSkin().Panel.Draw(screen, float64(boxX), float64(boxY), float64(boxW), float64(boxH))
+	ui.DebugPrintAt(screen, title, boxX+(boxW-len(title)*6)/2, boxY+20)
+
+	palette := ui.CurrentTheme().Palette
+
+	for i, info := range g.runSlots {
+		y := boxY + 50 + i*40
+
+		if i == g.runSlotSel {
+			vector.FillRect(screen, float32(boxX+15), float32(y-4), float32(boxW-30), 36, palette.Button, false)
+			vector.FillRect(screen, float32(boxX+15), float32(y-4), 3, 36, palette.Highlight, false)
+		}
+
+		name := fmt.Sprintf("Slot %d", i+1)
+		if info.Slot == runAutoSlot {
+			name = "Autosave"
+		}
+
+		summary := "(empty)"
+		if info.Summary != "" {
+			summary = info.Summary
+		}
+
+		ui.DebugPrintAt(screen, name+": "+summary, boxX+25, y)
+
+		if !info.Saved.IsZero() {
+			ui.DebugPrintAt(screen, "Saved "+info.Saved.Format("Jan 2 15:04"), boxX+25, y+14)
+		}
+	}
+
+	if g.runSlotMsg != "" {
+		ui.DebugPrintAt(screen, g.runSlotMsg, boxX+25, boxY+boxH-50)
+	}
+
+	ui.DebugPrintAt(screen, hint, boxX+(boxW-len(hint)*6)/2, boxY+boxH-30)
+}
+
+func (g *Game) drawSaveMenu(screen *ebiten.Image) {
+	g.drawGame(screen)
+	g.drawRunSlots(screen, "SAVE RUN", "UP/DOWN slot | ENTER save | ESC back")
+}
+
+func (g *Game) drawLoadMenu(screen *ebiten.Image) {
+	g.drawCharSelect(screen)
+	g.drawRunSlots(screen, "LOAD RUN", "UP/DOWN slot | ENTER load | DEL delete | ESC back")
+}
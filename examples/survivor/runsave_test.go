@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+func TestRunSaveRoundTrip(t *testing.T) {
+	g := &Game{runSaves: game.NewSaveManagerFS(paths.MemFS())}
+	g.startGame(CharSenior)
+
+	p := g.player
+	p.Weapons = append(p.Weapons, &Weapon{Type: WeaponType(1), Level: 3})
+	g.applyPassive(PassiveMight)
+	g.applyPassive(PassiveRevival)
+	p.AllocatedNodes[4] = true
+	p.Equipment[EquipSlot(0)] = &Equipment{
+		Name: "Legendary Keyboard", Rarity: Rarity(3), ItemLevel: 12,
+		Modifiers: []Modifier{{Type: ModFlatHP, Value: 40, Tier: 5}},
+	}
+	g.recalculateStats()
+	p.HP, p.Level, p.XP, p.Gold = 7, 9, 55, 321
+	g.gameTime, g.killCount = 412.5, 1234
+
+	if err := g.saveRun("run_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := g.player
+
+	// A new session finds the run in the menu and resumes it
+	g2 := &Game{runSaves: g.runSaves}
+	g2.openLoadMenu()
+
+	if len(g2.runSlots) != runSlotCount+1 || g2.runSlots[0].Summary == "" || g2.runSlots[1].Summary != "" {
+		t.Fatalf("slots = %+v", g2.runSlots)
+	}
+
+	s, _, err := g2.loadRunSave("run_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g2.restoreRun(s)
+	got := g2.player
+
+	if g2.state != StatePlaying || g2.gameTime != 412.5 || g2.killCount != 1234 {
+		t.Errorf("state %v, time %v, kills %d", g2.state, g2.gameTime, g2.killCount)
+	}
+
+	if got.CharType != CharSenior || got.Level != 9 || got.XP != 55 || got.Gold != 321 || got.HP != 7 {
+		t.Errorf("player = %+v", got)
+	}
+
+	if len(got.Weapons) != 2 || got.Weapons[1].Level != 3 || got.Weapons[0] == got.Weapons[1] {
+		t.Errorf("weapons = %+v", got.Weapons)
+	}
+
+	if got.Passives[PassiveMight] != 1 || !got.HasRevival || !got.AllocatedNodes[4] {
+		t.Errorf("passives %v, revival %v, nodes %v", got.Passives, got.HasRevival, got.AllocatedNodes)
+	}
+
+	e := got.Equipment[EquipSlot(0)]
+	if e == nil || e.Name != "Legendary Keyboard" || e.Modifiers[0].Value != 40 {
+		t.Errorf("equipment = %+v", e)
+	}
+
+	// Derived stats come back from the restored gear, tree, and passives
+	if got.MaxHP != want.MaxHP || got.DamageMult != want.DamageMult || got.AreaMult != want.AreaMult {
+		t.Errorf("max HP %d damage %v area %v, want %d %v %v",
+			got.MaxHP, got.DamageMult, got.AreaMult, want.MaxHP, want.DamageMult, want.AreaMult)
+	}
+}
+
+func TestRunSaveOnCloseOnlyMidRun(t *testing.T) {
+	g := &Game{runSaves: game.NewSaveManagerFS(paths.MemFS())}
+
+	g.saveOnClose()
+
+	if g.runSaves.Exists(runAutoSlot) {
+		t.Fatal("saved with no run in progress")
+	}
+
+	g.startGame(CharJunior)
+	g.state = StatePaused
+	g.saveOnClose()
+
+	if !g.runSaves.Exists(runAutoSlot) {
+		t.Fatal("closing mid-run should autosave")
+	}
+
+	if err := g.runSaves.DeleteSafe(runAutoSlot); err != nil {
+		t.Fatal(err)
+	}
+
+	g.state = StateGameOver
+	g.saveOnClose()
+
+	if g.runSaves.Exists(runAutoSlot) {
+		t.Error("a finished run should not be saved")
+	}
+}
+
+func TestRunSaveKeepsQueuedLevelUps(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.player.PendingLevels = 1
+	g.showLevelUp()
+
+	s := g.snapshotRun()
+	if s.PendingLevels != 2 {
+		t.Fatalf("saved %d pending level-ups, want the open one and the queued one", s.PendingLevels)
+	}
+
+	g2 := &Game{}
+	g2.restoreRun(s)
+	_ = g2.updatePlaying()
+
+	if g2.state != StateLevelUp || g2.player.PendingLevels != 1 {
+		t.Errorf("resumed run: state %v, pending %d; want a choice open and 1 queued",
+			g2.state, g2.player.PendingLevels)
+	}
+}
+
+func TestRunSaveLeavesDropsOnTheGround(t *testing.T) {
+	g := &Game{runSaves: game.NewSaveManagerFS(paths.MemFS())}
+	g.startGame(CharJunior)
+
+	g.dropItem(&Equipment{Name: "Far Mug", Rarity: Rarity(1)}, g.player.X+500, g.player.Y)
+	g.itemDrops[0].Life = 3
+
+	if err := g.saveRun("run_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	g2 := &Game{runSaves: g.runSaves}
+
+	s, _, err := g2.loadRunSave("run_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g2.restoreRun(s)
+
+	if len(g2.player.Inventory) != 0 {
+		t.Errorf("inventory = %v, want the drop left on the ground", g2.player.Inventory)
+	}
+
+	if len(g2.itemDrops) != 1 || g2.itemDrops[0].Item.Name != "Far Mug" || g2.itemDrops[0].Life != 3 ||
+		g2.itemDrops[0].X != g.player.X+500 {
+		t.Errorf("drops = %+v, want the mug where it lay with its despawn timer", g2.itemDrops)
+	}
+}
+
+// frozenGame never updates, like the game behind a playing demo or an
+// unfocused window.
+type frozenGame struct{ ebiten.Game }
+
+func (frozenGame) Update() error { return nil }
+
+func TestCloseSavesWhileTheGameIsNotUpdating(t *testing.T) {
+	g := &Game{runSaves: game.NewSaveManagerFS(paths.MemFS())}
+	g.startGame(CharJunior)
+
+	closing := false
+	c := withCloseSave(frozenGame{g}, g)
+	c.closing = func() bool { return closing }
+
+	if err := c.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	closing = true
+
+	if err := c.Update(); !errors.Is(err, ebiten.Termination) {
+		t.Fatalf("Update = %v on close, want ebiten.Termination", err)
+	}
+
+	if !g.runSaves.Exists(runAutoSlot) {
+		t.Error("closing mid-run should autosave")
+	}
+}
+
+func TestRunSaveKeepsAssistAndSpeedMarkers(t *testing.T) {
+	g := &Game{}
+	g.settings.GemMagnet = true
+	g.settings.GameSpeed = 0.7
+	g.startGame(CharJunior)
+
+	s := g.snapshotRun()
+
+	// The player turns both options off before loading
+	g2 := &Game{}
+	g2.restoreRun(s)
+
+	if note := g2.runNote(); note != "Assists enabled | Speed 70%" {
+		t.Errorf("resumed run note = %q, want both markers kept", note)
+	}
+}
@@ -0,0 +1,509 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/debug"
+)
+
+// Sandbox tuning.
+const (
+	sandboxDummyHP     = 1_000_000_000
+	sandboxDummyOffset = 200.0 // Dummy distance to the right of the spawn point
+	sandboxSpawnDist   = 250.0 // Palette spawns ring the player at this distance
+	sandboxMaxSpawn    = 100   // Per command, so a typo cannot spawn millions
+	dpsWindow          = 5.0   // Seconds of damage averaged by the DPS meter
+	maxWeaponLevel     = 8
+)
+
+const (
+	monsterTypeCount = MonsterBossDeadline + 1
+	weaponTypeCount  = WeaponMemoryLeak + 1
+)
+
+// sandboxSpawnKeys spawn the monster type at the same index.
+var sandboxSpawnKeys = [monsterTypeCount]ebiten.Key{
+	ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4,
+	ebiten.Key5, ebiten.Key6, ebiten.Key7, ebiten.Key8,
+}
+
+// Sandbox is the training arena: spawning starts frozen, any monster can be
+// spawned on demand, weapons and levels are granted instantly, and a target
+// dummy measures live DPS. The player cannot die and nothing counts toward
+// lifetime stats.
+type Sandbox struct {
+	Frozen bool
+
+	dummy   *Enemy
+	dps     dpsMeter
+	console *debug.Console
+}
+
+// dpsSample is the damage the dummy took during one frame.
+type dpsSample struct {
+	t      float64
+	damage int
+}
+
+// dpsMeter averages damage over a sliding window.
+type dpsMeter struct {
+	samples []dpsSample
+	start   float64 // Time of the last reset
+	total   int
+	peak    float64
+}
+
+// Add records damage dealt at time t and drops samples older than the window.
+func (m *dpsMeter) Add(t float64, damage int) {
+	m.samples = append(m.samples, dpsSample{t: t, damage: damage})
+	m.total += damage
+	m.prune(t)
+	m.peak = math.Max(m.peak, m.DPS(t))
+}
+
+// DPS returns the average damage per second over the last window, or since
+// the reset if that was more recent.
+func (m *dpsMeter) DPS(now float64) float64 {
+	m.prune(now)
+
+	sum := 0
+	for _, s := range m.samples {
+		sum += s.damage
+	}
+
+	span := math.Min(dpsWindow, now-m.start)
+	if span <= 0 {
+		return 0
+	}
+
+	return float64(sum) / math.Max(span, 1)
+}
+
+// Reset clears the meter starting at time now.
+func (m *dpsMeter) Reset(now float64) {
+	m.samples = m.samples[:0]
+	m.start = now
+	m.total = 0
+	m.peak = 0
+}
+
+func (m *dpsMeter) prune(now float64) {
+	i := 0
+	for i < len(m.samples) && m.samples[i].t <= now-dpsWindow {
+		i++
+	}
+
+	m.samples = append(m.samples[:0], m.samples[i:]...)
+}
+
+// startSandbox starts a training arena run with the given character.
+func (g *Game) startSandbox(charType CharacterType) {
+	g.sandbox = &Sandbox{Frozen: true}
+	g.sandbox.console = g.newSandboxConsole()
+	g.startGame(charType)
+	g.spawnDummy()
+}
+
+// spawnsFrozen reports whether the sandbox has paused the regular spawns.
+func (g *Game) spawnsFrozen() bool {
+	return g.sandbox != nil && g.sandbox.Frozen
+}
+
+// spawnDummy places a fresh target dummy beside the player.
+func (g *Game) spawnDummy() {
+	sb := g.sandbox
+	sb.dummy = &Enemy{
+		X: g.player.X + sandboxDummyOffset, Y: g.player.Y,
+		HP: sandboxDummyHP, MaxHP: sandboxDummyHP,
+		Radius: 24,
+		Color:  color.RGBA{R: 160, G: 140, B: 110, A: 255},
+		Dummy:  true,
+	}
+	g.enemies = append(g.enemies, sb.dummy)
+	sb.dps.Reset(g.gameTime)
+}
+
+// updateSandbox handles the palette keys and feeds damage on the dummy into
+// the DPS meter, healing it back to full.
+func (g *Game) updateSandbox(dt float64) {
+	sb := g.sandbox
+	if sb == nil {
+		return
+	}
+
+	g.handleSandboxKeys()
+
+	if sb.dummy.Dead {
+		g.spawnDummy()
+	}
+
+	if dealt := sb.dummy.MaxHP - sb.dummy.HP; dealt > 0 {
+		sb.dps.Add(g.gameTime, dealt)
+		sb.dummy.HP = sb.dummy.MaxHP
+	}
+}
+
+// handleSandboxKeys runs the spawn palette and the other arena shortcuts.
+func (g *Game) handleSandboxKeys() {
+	for i, key := range sandboxSpawnKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			g.sandboxSpawn(MonsterType(i), 1)
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyF):
+		g.sandbox.Frozen = !g.sandbox.Frozen
+	case inpututil.IsKeyJustPressed(ebiten.KeyG):
+		g.grantNextWeapon()
+	case inpututil.IsKeyJustPressed(ebiten.KeyN):
+		g.grantLevel()
+	case inpututil.IsKeyJustPressed(ebiten.KeyK):
+		g.clearSandboxEnemies()
+	case inpututil.IsKeyJustPressed(ebiten.KeyR):
+		g.sandbox.dps.Reset(g.gameTime)
+	}
+}
+
+// updateConsole updates the sandbox console and reports whether it is open,
+// in which case the game ignores other input.
+func (sb *Sandbox) updateConsole() bool {
+	sb.console.Update()
+
+	return sb.console.Enabled()
+}
+
+// sandboxSpawn spawns n monsters of type t evenly around the player.
+func (g *Game) sandboxSpawn(t MonsterType, n int) {
+	def := MonsterDefs[t]
+	offset := math.Pi / 2 // First spawn above the player, clear of the dummy
+
+	for i := range n {
+		angle := offset + 2*math.Pi*float64(i)/float64(n)
+		x := g.player.X + math.Cos(angle)*sandboxSpawnDist
+		y := g.player.Y - math.Sin(angle)*sandboxSpawnDist
+		e := g.spawnMonster(t, x, y)
+
+		if e.IsBoss = def.IsBoss; e.IsBoss {
+			g.attachPattern(e)
+		}
+	}
+
+	if def.IsBoss {
+		g.notifyBoss(t)
+	}
+}
+
+// grantWeapon gives the player a weapon, or levels it up if already owned.
+// The sandbox ignores the usual weapon slot limit.
+func (g *Game) grantWeapon(wt WeaponType, level int) {
+	level = min(max(level, 1), maxWeaponLevel)
+
+	for _, w := range g.player.Weapons {
+		if w.Type == wt {
+			w.Level = max(w.Level, level)
+
+			return
+		}
+	}
+
+	g.player.Weapons = append(g.player.Weapons, &Weapon{Type: wt, Level: level})
+}
+
+// grantNextWeapon adds the first weapon the player lacks, or levels up the
+// lowest one once every weapon is owned.
+func (g *Game) grantNextWeapon() {
+	owned := make(map[WeaponType]*Weapon, len(g.player.Weapons))
+	for _, w := range g.player.Weapons {
+		owned[w.Type] = w
+	}
+
+	for wt := range weaponTypeCount {
+		if owned[wt] == nil {
+			g.grantWeapon(wt, 1)
+
+			return
+		}
+	}
+
+	var lowest *Weapon
+
+	for _, w := range g.player.Weapons {
+		if w.Level < maxWeaponLevel && (lowest == nil || w.Level < lowest.Level) {
+			lowest = w
+		}
+	}
+
+	if lowest != nil {
+		lowest.Level++
+	}
+}
+
+// grantLevel levels the player up immediately, opening the upgrade choice.
+func (g *Game) grantLevel() {
+	g.player.Level++
+	g.player.PassivePoints++
+	g.showLevelUp()
+}
+
+// clearSandboxEnemies removes every enemy except the dummy, without kill rewards.
+func (g *Game) clearSandboxEnemies() int {
+	kept := g.enemies[:0]
+	for _, e := range g.enemies {
+		if e.Dummy {
+			kept = append(kept, e)
+		}
+	}
+
+	cleared := len(g.enemies) - len(kept)
+	clear(g.enemies[len(kept):])
+	g.enemies = kept
+	g.telegraphs = truncate(g.telegraphs)
+	g.bossShots = truncate(g.bossShots)
+
+	return cleared
+}
+
+// newSandboxConsole creates the arena console with its spawn and grant commands.
+func (g *Game) newSandboxConsole() *debug.Console {
+	c := debug.NewConsole(nil)
+
+	c.Register("spawn", "spawn <monster> [count]: spawn around you", func(args []string) (string, error) {
+		if len(args) == 0 {
+			return "", errors.New("expected a monster name or number")
+		}
+
+		t, err := lookupMonster(args[0])
+		if err != nil {
+			return "", err
+		}
+
+		n, err := optionalCount(args[1:], 1)
+		if err != nil {
+			return "", err
+		}
+
+		g.sandboxSpawn(t, min(n, sandboxMaxSpawn))
+
+		return fmt.Sprintf("spawned %d %s", min(n, sandboxMaxSpawn), MonsterDefs[t].Name), nil
+	})
+
+	c.Register("weapon", "weapon <name> [level]: grant or level a weapon", func(args []string) (string, error) {
+		if len(args) == 0 {
+			return "", errors.New("expected a weapon name or number")
+		}
+
+		wt, err := lookupWeapon(args[0])
+		if err != nil {
+			return "", err
+		}
+
+		level, err := optionalCount(args[1:], 1)
+		if err != nil {
+			return "", err
+		}
+
+		g.grantWeapon(wt, level)
+
+		return "granted " + WeaponDefs[wt].Name, nil
+	})
+
+	c.Register("level", "level: level up now", func([]string) (string, error) {
+		g.grantLevel()
+
+		return fmt.Sprintf("level %d", g.player.Level), nil
+	})
+
+	c.Register("freeze", "freeze: toggle regular spawning", func([]string) (string, error) {
+		g.sandbox.Frozen = !g.sandbox.Frozen
+		if g.sandbox.Frozen {
+			return "spawning frozen", nil
+		}
+
+		return "spawning resumed", nil
+	})
+
+	c.Register("clear", "clear: remove all enemies but the dummy", func([]string) (string, error) {
+		return fmt.Sprintf("cleared %d enemies", g.clearSandboxEnemies()), nil
+	})
+
+	c.Register("loot", "loot <monster> [kills]: simulate drops (10k)", func(args []string) (string, error) {
+		if len(args) == 0 {
+			return "", errors.New("expected a monster name or number")
+		}
+
+		t, err := lookupMonster(args[0])
+		if err != nil {
+			return "", err
+		}
+
+		n, err := optionalCount(args[1:], lootSimKills)
+		if err != nil {
+			return "", err
+		}
+
+		table := LootTables[t]
+		if table == nil {
+			return MonsterDefs[t].Name + " has no loot table", nil
+		}
+
+		return simulateLoot(table, min(n, lootSimMaxKills), g.player.Level, rand.Int63()), nil
+	})
+
+	c.Register("build", "build [code]: load a build, or the last exported", func(args []string) (string, error) {
+		code := g.buildCode
+		if code == "" {
+			code = g.savedBuildCode()
+		}
+
+		if len(args) > 0 {
+			code = strings.Join(args, "")
+		}
+
+		if code == "" {
+			return "", errors.New("expected a build code; export one at game over")
+		}
+
+		b, err := ParseBuildCode(code)
+		if err != nil {
+			return "", err
+		}
+
+		g.loadBuild(b)
+
+		return fmt.Sprintf("loaded a level %d %s build", g.player.Level, Characters[b.Char].Name), nil
+	})
+
+	c.Register("dps", "dps: reset the DPS meter", func([]string) (string, error) {
+		g.sandbox.dps.Reset(g.gameTime)
+
+		return "dps reset", nil
+	})
+
+	return c
+}
+
+// optionalCount parses an optional positive integer argument.
+func optionalCount(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid count %q", args[0])
+	}
+
+	return n, nil
+}
+
+// lookupMonster resolves a monster by number (1-based, as on the palette) or
+// by part of its name, e.g. "deadline".
+func lookupMonster(arg string) (MonsterType, error) {
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 || n > int(monsterTypeCount) {
+			return 0, fmt.Errorf("no monster %d", n)
+		}
+
+		return MonsterType(n - 1), nil
+	}
+
+	for t := range monsterTypeCount {
+		if nameMatches(MonsterDefs[t].Name, arg) {
+			return t, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no monster matching %q", arg)
+}
+
+// lookupWeapon resolves a weapon by number (1-based) or by part of its name.
+func lookupWeapon(arg string) (WeaponType, error) {
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 || n > int(weaponTypeCount) {
+			return 0, fmt.Errorf("no weapon %d", n)
+		}
+
+		return WeaponType(n - 1), nil
+	}
+
+	for wt := range weaponTypeCount {
+		if nameMatches(WeaponDefs[wt].Name, arg) {
+			return wt, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no weapon matching %q", arg)
+}
+
+// nameMatches reports whether query appears in name, ignoring case and spaces.
+func nameMatches(name, query string) bool {
+	squash := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, " ", "")) }
+
+	return strings.Contains(squash(name), squash(query))
+}
+
+// drawSandbox draws the palette, the dummy's DPS readout, and the console.
+func (g *Game) drawSandbox(screen *ebiten.Image) {
+	sb := g.sandbox
+	if sb == nil {
+		return
+	}
+
+	lines := []string{"TRAINING ARENA", ""}
+	for i := range monsterTypeCount {
+		lines = append(lines, fmt.Sprintf("%d  %s", i+1, MonsterDefs[i].Name))
+	}
+
+	spawning := "on"
+	if sb.Frozen {
+		spawning = "frozen"
+	}
+
+	lines = append(lines,
+		"",
+		"F  spawning: "+spawning,
+		"G  grant weapon",
+		"N  level up",
+		"K  clear enemies",
+		"R  reset DPS",
+		"`  console",
+		"   build <code>",
+	)
+
+	const lineHeight = 16
+
+	x, y := 10, 110
+	vector.FillRect(
+		screen,
+		float32(x-4),
+		float32(y-4),
+		170,
+		float32(len(lines)*lineHeight+8),
+		color.NRGBA{R: 0, G: 0, B: 0, A: 160},
+		false,
+	)
+
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x, y+i*lineHeight)
+	}
+
+	// DPS readout over the dummy
+	d := sb.dummy
+	dx, dy := int(d.X-g.cameraX), int(d.Y-g.cameraY-d.Radius)
+	ebitenutil.DebugPrintAt(screen, "DUMMY", dx-15, dy-50)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("DPS %.0f", sb.dps.DPS(g.gameTime)), dx-30, dy-34)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("peak %.0f  total %d", sb.dps.peak, sb.dps.total), dx-60, dy-18)
+
+	sb.console.Draw(screen)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/stats"
+)
+
+func newSandboxTestGame(t *testing.T) *Game {
+	t.Helper()
+
+	g := &Game{}
+	g.initLifetime(stats.NewMemory())
+	g.startSandbox(CharJunior)
+
+	if g.sandbox == nil || g.sandbox.dummy == nil {
+		t.Fatal("sandbox started without a dummy")
+	}
+
+	return g
+}
+
+func TestSandboxStartsFrozenWithDummy(t *testing.T) {
+	g := newSandboxTestGame(t)
+
+	if !g.spawnsFrozen() {
+		t.Error("sandbox spawning not frozen")
+	}
+
+	if len(g.enemies) != 1 || !g.enemies[0].Dummy {
+		t.Fatalf("enemies = %d, want only the dummy", len(g.enemies))
+	}
+
+	x, y := g.sandbox.dummy.X, g.sandbox.dummy.Y
+	for range 120 {
+		g.updateEnemies(1.0 / 60)
+	}
+
+	if g.sandbox.dummy.X != x || g.sandbox.dummy.Y != y {
+		t.Error("dummy moved")
+	}
+
+	if g.player.HP != g.player.MaxHP {
+		t.Error("dummy hurt the player")
+	}
+}
+
+func TestSandboxDummyMeasuresDPS(t *testing.T) {
+	g := newSandboxTestGame(t)
+	disableCrits(g)
+
+	dummy := g.sandbox.dummy
+
+	for range 120 {
+		g.gameTime += 1.0 / 60
+		g.hitEnemy(dummy, 10, WeaponDefs[WeaponPrint].Color, WeaponPrint)
+		g.updateSandbox(1.0 / 60)
+	}
+
+	if dummy.HP != dummy.MaxHP || dummy.Dead {
+		t.Errorf("dummy HP = %d, want healed to full", dummy.HP)
+	}
+
+	if got := g.sandbox.dps.DPS(g.gameTime); math.Abs(got-600) > 15 {
+		t.Errorf("DPS = %.1f, want about 600", got)
+	}
+
+	if g.sandbox.dps.total != 1200 {
+		t.Errorf("total = %d, want 1200", g.sandbox.dps.total)
+	}
+}
+
+func TestDPSMeterWindow(t *testing.T) {
+	var m dpsMeter
+
+	m.Add(1, 100)
+	m.Add(2, 100)
+
+	if got := m.DPS(2); got != 100 {
+		t.Errorf("DPS after 2s = %v, want 100", got)
+	}
+
+	if got := m.DPS(2 + dpsWindow); got != 0 {
+		t.Errorf("DPS after the window = %v, want 0", got)
+	}
+
+	if m.peak != 100 || m.total != 200 {
+		t.Errorf("peak, total = %v, %d", m.peak, m.total)
+	}
+}
+
+func TestSandboxSpawnAndClear(t *testing.T) {
+	g := newSandboxTestGame(t)
+
+	g.sandboxSpawn(MonsterBossDeadline, 1)
+	g.sandboxSpawn(MonsterBug, 4)
+
+	if len(g.enemies) != 6 {
+		t.Fatalf("enemies = %d, want dummy + 5", len(g.enemies))
+	}
+
+	if boss := g.trackedBoss(); boss == nil || boss.Type != MonsterBossDeadline {
+		t.Error("spawned boss is not tracked as a boss")
+	}
+
+	if n := g.clearSandboxEnemies(); n != 5 {
+		t.Errorf("cleared %d, want 5", n)
+	}
+
+	if len(g.enemies) != 1 || g.enemies[0] != g.sandbox.dummy {
+		t.Error("clear removed the dummy")
+	}
+}
+
+func TestSandboxConsoleCommands(t *testing.T) {
+	g := newSandboxTestGame(t)
+	c := g.sandbox.console
+
+	for _, line := range []string{"spawn deadline", "spawn 1 3", "weapon docker 5", "freeze"} {
+		if _, err := c.Exec(line); err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+	}
+
+	if len(g.enemies) != 5 {
+		t.Errorf("enemies = %d, want dummy + 4", len(g.enemies))
+	}
+
+	if w := g.player.Weapons[len(g.player.Weapons)-1]; w.Type != WeaponDocker || w.Level != 5 {
+		t.Errorf("granted %v level %d, want Docker level 5", w.Type, w.Level)
+	}
+
+	if g.spawnsFrozen() {
+		t.Error("freeze did not resume spawning")
+	}
+
+	for _, line := range []string{"spawn nobody", "weapon 99", "spawn bug -2"} {
+		if _, err := c.Exec(line); err == nil {
+			t.Errorf("%q: expected an error", line)
+		}
+	}
+}
+
+func TestGrantNextWeaponFillsThenLevels(t *testing.T) {
+	g := newSandboxTestGame(t)
+
+	for range int(weaponTypeCount) {
+		g.grantNextWeapon()
+	}
+
+	if len(g.player.Weapons) != int(weaponTypeCount) {
+		t.Fatalf("weapons = %d, want every weapon", len(g.player.Weapons))
+	}
+
+	g.grantNextWeapon()
+
+	if g.player.Weapons[0].Level != 2 {
+		t.Errorf("lowest weapon level = %d, want 2", g.player.Weapons[0].Level)
+	}
+}
+
+func TestSandboxDoesNotCountLifetimeOrDie(t *testing.T) {
+	g := newSandboxTestGame(t)
+
+	g.sandboxSpawn(MonsterBossManager, 1)
+	g.killEnemy(g.enemies[len(g.enemies)-1])
+
+	if n := g.lifetime.CounterValue(statKills) + g.lifetime.CounterValue(statRunsStarted); n != 0 {
+		t.Errorf("sandbox recorded %d lifetime stats", n)
+	}
+
+	g.player.HP = 1
+	g.hurtPlayer(1000, 0, "test")
+
+	if g.state != StatePlaying || g.player.HP != g.player.MaxHP {
+		t.Errorf("state %v, HP %d: sandbox run ended", g.state, g.player.HP)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// bossInterval is the seconds between boss spawns.
+	bossInterval = 180.0
+	// phaseWarning is how early the HUD announces the next spawn phase.
+	phaseWarning = 15.0
+	// previewHorizon is how far ahead the wave preview looks, in seconds.
+	previewHorizon = 30.0
+	previewMax     = 3
+)
+
+// SpawnPhase is a named spawn-intensity phase of a run.
+type SpawnPhase struct {
+	Name  string
+	Start float64 // Run time the phase begins, seconds
+}
+
+// SpawnPhases line up with the spawn mix tiers in spawnEnemy and the boss upgrade.
+var SpawnPhases = []SpawnPhase{
+	{Name: "Sprint Planning", Start: 0},
+	{Name: "Feature Creep", Start: 60},
+	{Name: "Crunch", Start: 180},
+	{Name: "Death March", Start: 360},
+}
+
+// ScheduledSpawn is an upcoming entry in the spawn director's schedule.
+type ScheduledSpawn struct {
+	Name    string
+	At      float64 // Run time of the spawn
+	Monster MonsterType
+	Elite   bool
+	Boss    bool
+}
+
+// bossTypeAt returns the boss that spawns at the given run time.
+func bossTypeAt(t float64) MonsterType {
+	if t > 360 {
+		return MonsterBossDeadline
+	}
+
+	return MonsterBossManager
+}
+
+// nextBossIn returns the seconds until the next boss spawns.
+func (g *Game) nextBossIn() float64 {
+	return max(0, bossInterval-g.bossTimer)
+}
+
+// spawnPhase returns the current phase and the next one, if any.
+func (g *Game) spawnPhase() (SpawnPhase, *SpawnPhase) {
+	i := sort.Search(len(SpawnPhases), func(i int) bool { return SpawnPhases[i].Start > g.gameTime }) - 1
+	if i+1 < len(SpawnPhases) {
+		return SpawnPhases[i], &SpawnPhases[i+1]
+	}
+
+	return SpawnPhases[i], nil
+}
+
+// phaseLabel names the current phase, or announces the next one when it is close.
+func (g *Game) phaseLabel() string {
+	cur, next := g.spawnPhase()
+	if next != nil && next.Start-g.gameTime <= phaseWarning {
+		return next.Name + " approaching"
+	}
+
+	return cur.Name
+}
+
+// upcomingSpawns returns scripted events and the next boss due within horizon
+// seconds, soonest first.
+func (g *Game) upcomingSpawns(horizon float64) []ScheduledSpawn {
+	var out []ScheduledSpawn
+
+	limit := g.gameTime + horizon
+
+	for _, st := range g.spawnEvents {
+		if st.done || st.next > limit {
+			continue
+		}
+
+		out = append(out, ScheduledSpawn{
+			Name:    st.def.Name,
+			At:      st.next,
+			Monster: st.def.Monster,
+			Elite:   st.def.Kind == SpawnElitePack,
+		})
+	}
+
+	if bossAt := g.gameTime + g.nextBossIn(); bossAt <= limit {
+		boss := bossTypeAt(bossAt)
+		out = append(out, ScheduledSpawn{Name: MonsterDefs[boss].Name, At: bossAt, Monster: boss, Boss: true})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].At < out[j].At })
+
+	return out
+}
+
+// drawScheduleHUD shows the boss countdown and phase in the top bar, and the
+// optional preview of upcoming waves beneath it.
+func (g *Game) drawScheduleHUD(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, "Boss in "+formatTime(g.nextBossIn()), 690, 10)
+	ebitenutil.DebugPrintAt(screen, g.phaseLabel(), 690, 30)
+
+	if g.hideWavePreview {
+		return
+	}
+
+	upcoming := g.upcomingSpawns(previewHorizon)
+	for i, s := range upcoming[:min(previewMax, len(upcoming))] {
+		x, y := float32(screenWidth-200), float32(70+i*30)
+		g.drawSpawnIcon(screen, s, x+12, y+12)
+		ebitenutil.DebugPrintAt(screen, s.Name+" "+formatTime(s.At-g.gameTime), int(x)+30, int(y)+4)
+	}
+}
+
+// drawSpawnIcon draws a small monster portrait, ringed gold for elites and red for bosses.
+func (g *Game) drawSpawnIcon(screen *ebiten.Image, s ScheduledSpawn, cx, cy float32) {
+	vector.FillCircle(screen, cx, cy, 12, color.RGBA{R: 20, G: 20, B: 30, A: 200}, false)
+
+	if img := g.monsterImages[s.Monster]; img != nil {
+		b := img.Bounds()
+		scale := 20 / float64(max(b.Dx(), b.Dy()))
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(float64(cx)-float64(b.Dx())*scale/2, float64(cy)-float64(b.Dy())*scale/2)
+		screen.DrawImage(img, op)
+	} else {
+		vector.FillCircle(screen, cx, cy, 8, MonsterDefs[s.Monster].Color, false)
+	}
+
+	switch {
+	case s.Boss:
+		vector.StrokeCircle(screen, cx, cy, 12, 2, color.RGBA{R: 255, G: 60, B: 60, A: 255}, false)
+	case s.Elite:
+		vector.StrokeCircle(screen, cx, cy, 12, 2, color.RGBA{R: 255, G: 215, B: 0, A: 255}, false)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPhaseLabelAnnouncesNextPhase(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	tests := []struct {
+		time float64
+		want string
+	}{
+		{0, "Sprint Planning"},
+		{50, "Feature Creep approaching"},
+		{60, "Feature Creep"},
+		{170, "Crunch approaching"},
+		{200, "Crunch"},
+		{1000, "Death March"},
+	}
+
+	for _, tt := range tests {
+		g.gameTime = tt.time
+		if got := g.phaseLabel(); got != tt.want {
+			t.Errorf("phaseLabel at %vs = %q, want %q", tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestUpcomingSpawnsFollowSchedule(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	runEventsTo(g, 140)
+	g.bossTimer = 152
+
+	upcoming := g.upcomingSpawns(previewHorizon)
+	if len(upcoming) != 3 {
+		t.Fatalf("upcoming = %+v, want Legacy Pack, Bug Swarm, and the boss", upcoming)
+	}
+
+	if pack := upcoming[0]; pack.Name != "Legacy Pack" || !pack.Elite || pack.At != 150 {
+		t.Errorf("first = %+v, want elite Legacy Pack at 150s", pack)
+	}
+
+	if swarm := upcoming[1]; swarm.Name != "Bug Swarm" || swarm.Elite {
+		t.Errorf("second = %+v, want the repeating Bug Swarm", swarm)
+	}
+
+	boss := upcoming[2]
+	if !boss.Boss || math.Abs(boss.At-g.gameTime-28) > 1e-9 || boss.Monster != MonsterBossManager {
+		t.Errorf("third = %+v, want Micro Manager in 28s", boss)
+	}
+
+	if got := g.nextBossIn(); got != 28 {
+		t.Errorf("nextBossIn = %v, want 28", got)
+	}
+
+	for _, s := range g.upcomingSpawns(5) {
+		if s.At > g.gameTime+5 {
+			t.Errorf("%s at %vs is past the 5s horizon", s.Name, s.At)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// RunMod is a set of challenge modifiers chosen at character select.
+type RunMod uint8
+
+const (
+	RunSwarm       RunMod = 1 << iota // Enemies spawn more often
+	RunGlassCannon                    // Half max HP, double damage
+	RunFrenzy                         // Enemies move faster
+)
+
+// Challenge modifier tuning.
+const (
+	swarmSpawnScale = 1 / 1.5
+	frenzySpeedMult = 1.25
+)
+
+// RunModDef describes a challenge modifier.
+type RunModDef struct {
+	Mod  RunMod
+	Name string
+	Key  ebiten.Key // Toggles the modifier at character select
+}
+
+// RunModDefs lists the challenge modifiers in toggle-key order.
+var RunModDefs = []RunModDef{
+	{Mod: RunSwarm, Name: "Swarm", Key: ebiten.Key1},
+	{Mod: RunGlassCannon, Name: "Glass Cannon", Key: ebiten.Key2},
+	{Mod: RunFrenzy, Name: "Frenzy", Key: ebiten.Key3},
+}
+
+// RunConfig is everything that sets up a run. The seed drives the run's
+// deterministic streams (world chunks, world events, and ambient spawns), so
+// replaying a config replays the same world.
+type RunConfig struct {
+	Seed  uint32
+	Char  CharacterType
+	Stage int
+	Mods  RunMod
+}
+
+// seedAlphabet is Crockford's base32: no I, L, O, or U, so codes read aloud
+// and typed back survive.
+const seedAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Seed code layout: 32-bit seed, 4-bit character, 4-bit stage, 8 modifier
+// bits, and a 12-bit checksum, written as 12 base32 digits.
+const (
+	seedCodeDigits = 12
+	seedCodeGroup  = 4
+	seedCheckBits  = 12
+)
+
+var errSeedChecksum = errors.New("seed code checksum mismatch")
+
+// Code encodes the config as a short code like "0H4Q-7ZK2-M1B8".
+func (c RunConfig) Code() string {
+	payload := uint64(c.Seed)<<16 | uint64(c.Char&0xf)<<12 | uint64(c.Stage&0xf)<<8 | uint64(c.Mods)
+	bits := payload<<seedCheckBits | seedChecksum(payload)
+
+	var b strings.Builder
+
+	for i := range seedCodeDigits {
+		if i > 0 && i%seedCodeGroup == 0 {
+			b.WriteByte('-')
+		}
+
+		shift := 5 * (seedCodeDigits - 1 - i)
+		b.WriteByte(seedAlphabet[bits>>shift&0x1f])
+	}
+
+	return b.String()
+}
+
+// ParseRunCode decodes a seed code. Case, dashes, and spaces are ignored, and
+// I, L, and O are read as 1, 1, and 0.
+func ParseRunCode(code string) (RunConfig, error) {
+	var (
+		bits   uint64
+		digits int
+	)
+
+	for _, r := range strings.ToUpper(code) {
+		switch r {
+		case '-', ' ':
+			continue
+		case 'I', 'L':
+			r = '1'
+		case 'O':
+			r = '0'
+		}
+
+		v := strings.IndexRune(seedAlphabet, r)
+		if v < 0 {
+			return RunConfig{}, fmt.Errorf("invalid seed code character %q", r)
+		}
+
+		bits = bits<<5 | uint64(v)
+		digits++
+	}
+
+	if digits != seedCodeDigits {
+		return RunConfig{}, fmt.Errorf("seed code has %d characters, want %d", digits, seedCodeDigits)
+	}
+
+	payload := bits >> seedCheckBits
+	if seedChecksum(payload) != bits&(1<<seedCheckBits-1) {
+		return RunConfig{}, errSeedChecksum
+	}
+
+	cfg := RunConfig{
+		Seed:  uint32(payload >> 16),
+		Char:  CharacterType(payload >> 12 & 0xf),
+		Stage: int(payload >> 8 & 0xf),
+		Mods:  RunMod(payload),
+	}
+
+	var known RunMod
+	for _, def := range RunModDefs {
+		known |= def.Mod
+	}
+
+	switch {
+	case int(cfg.Char) >= len(Characters):
+		return RunConfig{}, fmt.Errorf("seed code names unknown character %d", cfg.Char)
+	case cfg.Stage >= len(Stages):
+		return RunConfig{}, fmt.Errorf("seed code names unknown stage %d", cfg.Stage)
+	case cfg.Mods&^known != 0:
+		return RunConfig{}, fmt.Errorf("seed code has unknown modifiers %#x", uint8(cfg.Mods&^known))
+	}
+
+	return cfg, nil
+}
+
+// seedChecksum catches typos in the 48-bit payload of a code.
+func seedChecksum(payload uint64) uint64 {
+	var buf [6]byte
+	for i := range buf {
+		buf[i] = byte(payload >> (8 * (len(buf) - 1 - i)))
+	}
+
+	return uint64(crc32.ChecksumIEEE(buf[:])) & (1<<seedCheckBits - 1)
+}
+
+// newRun returns a fresh config with a random seed and the modifiers chosen
+// at character select.
+func (g *Game) newRun(charType CharacterType) RunConfig {
+	return RunConfig{Seed: rand.Uint32(), Char: charType, Stage: g.stage, Mods: g.runMods}
+}
+
+// runHas reports whether the current run was started with a modifier.
+func (g *Game) runHas(mod RunMod) bool {
+	return g.run.Mods&mod != 0
+}
+
+// Random streams of a run, drawn from g.rng. Each system rolls its own, so
+// an extra crit roll never changes which loot drops.
+const (
+	streamSpawn       = "spawn"
+	streamSpawnEvents = "spawn events"
+	streamWorldEvents = "world events"
+	streamLoot        = "loot"
+	streamCombat      = "combat"
+	streamUpgrades    = "upgrades"
+)
+
+// initSpawnRng picks the ambient spawn stream of the run.
+func (g *Game) initSpawnRng() {
+	g.spawnRng = g.rng.Stream(streamSpawn)
+}
+
+// applyRunMods applies the player-side challenge modifiers to freshly
+// computed stats.
+func (g *Game) applyRunMods() {
+	if g.runHas(RunGlassCannon) {
+		g.player.MaxHP = max(1, g.player.MaxHP/2)
+		g.player.DamageMult *= 2
+	}
+}
+
+// spawnRateScale scales the time between ambient spawns.
+func (g *Game) spawnRateScale() float64 {
+	if g.runHas(RunSwarm) {
+		return swarmSpawnScale
+	}
+
+	return 1
+}
+
+// enemySpeedMult scales the speed of newly spawned enemies.
+func (g *Game) enemySpeedMult() float64 {
+	if g.runHas(RunFrenzy) {
+		return frenzySpeedMult
+	}
+
+	return 1
+}
+
+// runModNames lists the names of a set of modifiers.
+func runModNames(mods RunMod) []string {
+	var names []string
+
+	for _, def := range RunModDefs {
+		if mods&def.Mod != 0 {
+			names = append(names, def.Name)
+		}
+	}
+
+	return names
+}
+
+// newSeedEntry returns the seed code prompt. It accepts the code's digits,
+// the dashes, and I, L, and O, which ParseRunCode reads as digits; entering
+// a valid code starts its run.
+func (g *Game) newSeedEntry() *ui.TextInput {
+	in := ui.NewTextInput("Seed code (ENTER play, ESC cancel)", seedAlphabet+"-ILO",
+		seedCodeDigits+seedCodeDigits/seedCodeGroup)
+	in.X, in.Y = screenWidth/2-200, 163
+	in.Upper = true
+	in.Validate = func(code string) error {
+		_, err := ParseRunCode(code)
+
+		return err
+	}
+	in.OnSubmit = func(code string) {
+		cfg, _ := ParseRunCode(code)
+		g.seedEntry = nil
+		g.selectedChar = int(cfg.Char)
+		g.runMods = cfg.Mods
+		g.sandbox = nil
+		g.draftRun(cfg)
+	}
+	in.OnCancel = func() { g.seedEntry = nil }
+
+	return in
+}
+
+// updateRunSetup toggles challenge modifiers and handles the seed code
+// prompt at character select. It reports whether the prompt has the keyboard.
+func (g *Game) updateRunSetup() bool {
+	if g.seedEntry == nil {
+		for _, def := range RunModDefs {
+			if inpututil.IsKeyJustPressed(def.Key) {
+				g.runMods ^= def.Mod
+				g.audio.PlaySound("select")
+			}
+		}
+
+		if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+			g.seedEntry = g.newSeedEntry()
+		}
+
+		return g.seedEntry != nil
+	}
+
+	if err := g.seedEntry.Update(); err != nil {
+		g.seedEntry = nil
+	}
+
+	return true
+}
+
+// drawRunSetup shows the challenge modifiers and the seed code prompt at
+// character select.
+func (g *Game) drawRunSetup(screen *ebiten.Image) {
+	x := screenWidth/2 - 200
+
+	mods := "Challenges:"
+	for i, def := range RunModDefs {
+		mark := " "
+		if g.runMods&def.Mod != 0 {
+			mark = "x"
+		}
+
+		mods += fmt.Sprintf("  %d [%s] %s", i+1, mark, def.Name)
+	}
+
+	ui.DebugPrintAt(screen, mods, x, 120)
+
+	if g.seedEntry == nil {
+		ui.DebugPrintAt(screen, "K - enter a seed code", x, 145)
+
+		return
+	}
+
+	g.seedEntry.Draw(screen)
+}
+
+// runCodeLine describes the finished run's seed code for the game over screen.
+func (g *Game) runCodeLine() string {
+	line := "Seed " + g.run.Code()
+	if names := runModNames(g.run.Mods); len(names) > 0 {
+		line += " (" + strings.Join(names, ", ") + ")"
+	}
+
+	return line
+}
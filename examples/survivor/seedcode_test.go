@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunCodeRoundTrip(t *testing.T) {
+	configs := []RunConfig{
+		{},
+		{Seed: 0xdeadbeef, Char: Char10x, Mods: RunSwarm | RunFrenzy},
+		{Seed: 1<<32 - 1, Char: CharacterType(len(Characters) - 1), Mods: RunGlassCannon},
+	}
+
+	for _, cfg := range configs {
+		code := cfg.Code()
+		if len(code) != 14 || strings.Count(code, "-") != 2 {
+			t.Errorf("code %q, want three dash-separated groups of four", code)
+		}
+
+		got, err := ParseRunCode(code)
+		if err != nil || got != cfg {
+			t.Errorf("ParseRunCode(%q) = %+v, %v, want %+v", code, got, err, cfg)
+		}
+
+		// Typed sloppily: lowercase, no dashes, O for 0 and l for 1
+		sloppy := strings.NewReplacer("-", "", "0", "o", "1", "l").Replace(strings.ToLower(code))
+		if got, err := ParseRunCode(sloppy); err != nil || got != cfg {
+			t.Errorf("ParseRunCode(%q) = %+v, %v, want %+v", sloppy, got, err, cfg)
+		}
+	}
+}
+
+func TestRunCodeRejectsTypos(t *testing.T) {
+	code := RunConfig{Seed: 12345, Char: CharSenior, Mods: RunSwarm}.Code()
+
+	// Change one digit
+	typo := []byte(code)
+	if typo[3] == 'A' {
+		typo[3] = 'B'
+	} else {
+		typo[3] = 'A'
+	}
+
+	if _, err := ParseRunCode(string(typo)); !errors.Is(err, errSeedChecksum) {
+		t.Errorf("ParseRunCode(%q) error = %v, want a checksum mismatch", typo, err)
+	}
+
+	for _, bad := range []string{"", code[:9], code + "0", "UUUU-UUUU-UUUU"} {
+		if _, err := ParseRunCode(bad); err == nil {
+			t.Errorf("ParseRunCode(%q) accepted", bad)
+		}
+	}
+}
+
+func TestRunCodeReplaysRun(t *testing.T) {
+	play := func(cfg RunConfig) (*Game, []MonsterType) {
+		g := &Game{}
+		g.startRun(cfg)
+
+		var spawned []MonsterType
+
+		for range 20 {
+			g.spawnEnemy()
+			spawned = append(spawned, g.enemies[len(g.enemies)-1].Type)
+		}
+
+		return g, spawned
+	}
+
+	first := &Game{}
+	first.runMods = RunGlassCannon | RunFrenzy
+	first.startGame(CharSenior)
+
+	cfg, err := ParseRunCode(first.run.Code())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, spawnsA := play(cfg)
+	b, spawnsB := play(cfg)
+
+	sameEvent := a.worldEvent.def == b.worldEvent.def && a.worldEvent.start == b.worldEvent.start
+	if a.worldSeed != b.worldSeed || !sameEvent {
+		t.Error("replayed runs have different worlds")
+	}
+
+	for i := range spawnsA {
+		if spawnsA[i] != spawnsB[i] || a.enemies[i].X != b.enemies[i].X || a.enemies[i].Y != b.enemies[i].Y {
+			t.Fatalf("spawn %d differs between replays", i)
+		}
+	}
+
+	// The modifiers and character came through the code
+	def := MonsterDefs[a.enemies[0].Type]
+	if a.player.CharType != CharSenior || a.player.MaxHP != Characters[CharSenior].HP/2 ||
+		a.player.DamageMult != 2 || a.enemies[0].Speed != def.Speed*frenzySpeedMult {
+		t.Errorf("replay lost the config: char %d, max HP %d, damage x%v",
+			a.player.CharType, a.player.MaxHP, a.player.DamageMult)
+	}
+
+	// Glass Cannon survives a stat recalculation
+	a.recalculateStats()
+
+	if a.player.MaxHP != Characters[CharSenior].HP/2 {
+		t.Errorf("max HP %d after recalculation, want half", a.player.MaxHP)
+	}
+}
+
+func TestRunSeedReplaysLootAndCrits(t *testing.T) {
+	play := func() (*Equipment, []bool) {
+		g := &Game{}
+		g.startRun(RunConfig{Seed: 1234, Char: CharJunior})
+		g.player.CritChance = 0.5
+
+		crits := make([]bool, 20)
+		for i := range crits {
+			crits[i], _ = g.rollCrit(WeaponPrint)
+		}
+
+		return g.generateEquipment(SlotMonitor, 40, RarityLegendary), crits
+	}
+
+	itemA, critsA := play()
+	itemB, critsB := play()
+
+	if !reflect.DeepEqual(itemA, itemB) || !reflect.DeepEqual(critsA, critsB) {
+		t.Errorf("same seed rolled differently:\n%+v %v\n%+v %v", itemA, critsA, itemB, critsB)
+	}
+}
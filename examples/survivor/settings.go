@@ -0,0 +1,171 @@
+package main
+
+import (
+	"log"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/game"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+)
+
+const settingsSlot = "settings"
+
+// survivorApp locates the survivor's settings and lifetime stats.
+var survivorApp = paths.App{Vendor: "neuralway", Name: "survivor"}
+
+// AimMode selects how aimed weapons pick their direction.
+type AimMode int
+
+const (
+	AimAuto   AimMode = iota // Aim at the nearest enemy
+	AimManual                // Aim along the movement direction
+)
+
+// Settings are player preferences kept between sessions. The zero value is
+// the default: auto-aim with every assist off.
+type Settings struct {
+	AimMode AimMode
+
+	// Assist mode
+	AimAssist       bool    // Widens the manual-aim snap cone
+	ToggleMove      bool    // Direction keys latch movement instead of being held
+	GemMagnet       bool    // Boosts the pickup radius
+	DamageReduction float64 // Fraction of damage taken removed, 0 to maxDamageReduction
+
+	GameSpeed float64 // Simulation speed while playing, minGameSpeed to 1; 0 is full speed
+
+	Pet PetType // Companion chosen on the character screen
+
+	LowMemory bool // Use LowBudgets for object caps
+
+	Graphics       GraphicsQuality
+	GraphicsProbed bool // Frame time was measured on the first run
+
+	RumbleLevel int // Controller rumble steps below full strength; rumbleLevels is off
+
+	Theme string // UI theme name; empty keeps the Survivor theme
+
+	Window engine.WindowState // Placement last session; zero before the window was first saved
+}
+
+// AssistsEnabled reports whether any assist option is on.
+func (s Settings) AssistsEnabled() bool {
+	return s.AimAssist || s.ToggleMove || s.GemMagnet || s.DamageReduction > 0
+}
+
+// settingsManager returns the save manager for the settings file, kept in
+// localStorage on the web build, or nil when there is nowhere to keep it.
+func settingsManager() *game.SaveManager {
+	store, err := survivorApp.Open(paths.Config)
+	if err != nil {
+		log.Printf("settings: %v", err)
+
+		return nil
+	}
+
+	return game.NewSaveManagerFS(store)
+}
+
+// loadSettings reads saved settings, falling back to defaults.
+func loadSettings(sm *game.SaveManager) Settings {
+	if sm == nil || !sm.Exists(settingsSlot) {
+		return Settings{}
+	}
+
+	save, err := sm.Load(settingsSlot)
+	if err != nil {
+		log.Printf("settings: %v", err)
+
+		return Settings{}
+	}
+
+	return Settings{
+		AimMode:         AimMode(save.GetInt("aim_mode", int(AimAuto))),
+		AimAssist:       save.GetBool("aim_assist", false),
+		ToggleMove:      save.GetBool("toggle_move", false),
+		GemMagnet:       save.GetBool("gem_magnet", false),
+		DamageReduction: min(max(save.GetFloat("damage_reduction", 0), 0), maxDamageReduction),
+		GameSpeed:       clampGameSpeed(save.GetFloat("game_speed", 0)),
+		Pet:             PetType(save.GetInt("pet", int(PetNone))),
+		LowMemory:       save.GetBool("low_memory", false),
+		Graphics:        clampQuality(GraphicsQuality(save.GetInt("graphics", int(QualityHigh)))),
+		GraphicsProbed:  save.GetBool("graphics_probed", false),
+		RumbleLevel:     clampRumbleLevel(save.GetInt("rumble_level", 0)),
+		Theme:           save.GetString("theme", ""),
+		Window: engine.WindowState{
+			Width:      save.GetInt("window_width", 0),
+			Height:     save.GetInt("window_height", 0),
+			X:          save.GetInt("window_x", 0),
+			Y:          save.GetInt("window_y", 0),
+			Fullscreen: save.GetBool("window_fullscreen", false),
+			Monitor:    save.GetString("window_monitor", ""),
+		},
+	}
+}
+
+// saveSettings writes the settings; failures are logged and otherwise ignored.
+func saveSettings(sm *game.SaveManager, s Settings) {
+	if sm == nil {
+		return
+	}
+
+	save := game.NewSaveData(settingsSlot)
+	save.Set("aim_mode", int(s.AimMode))
+	save.Set("aim_assist", s.AimAssist)
+	save.Set("toggle_move", s.ToggleMove)
+	save.Set("gem_magnet", s.GemMagnet)
+	save.Set("damage_reduction", s.DamageReduction)
+	save.Set("game_speed", s.GameSpeed)
+	save.Set("pet", int(s.Pet))
+	save.Set("low_memory", s.LowMemory)
+	save.Set("graphics", int(s.Graphics))
+	save.Set("graphics_probed", s.GraphicsProbed)
+	save.Set("rumble_level", s.RumbleLevel)
+	save.Set("theme", s.Theme)
+	save.Set("window_width", s.Window.Width)
+	save.Set("window_height", s.Window.Height)
+	save.Set("window_x", s.Window.X)
+	save.Set("window_y", s.Window.Y)
+	save.Set("window_fullscreen", s.Window.Fullscreen)
+	save.Set("window_monitor", s.Window.Monitor)
+
+	if err := sm.Save(settingsSlot, save); err != nil {
+		log.Printf("settings: %v", err)
+	}
+}
+
+// windowConfig keeps the window's placement in the settings file rather
+// than a file of its own.
+func (g *Game) windowConfig() engine.WindowConfig {
+	return engine.WindowConfig{
+		App: survivorApp,
+		Load: func() (engine.WindowState, bool) {
+			return g.settings.Window, g.settings.Window.Width > 0
+		},
+		Save: func(state engine.WindowState) {
+			g.settings.Window = state
+			saveSettings(g.settingsStore, g.settings)
+		},
+	}
+}
+
+// setSettings applies new settings, persists them, and marks the run as
+// assisted if an assist was turned on mid-run.
+func (g *Game) setSettings(s Settings) {
+	g.settings = s
+	saveSettings(g.settingsStore, s)
+
+	if g.rumble != nil {
+		g.rumble.Intensity = s.rumbleIntensity()
+	}
+
+	if g.player == nil {
+		return
+	}
+
+	if s.AssistsEnabled() {
+		g.runAssisted = true
+	}
+
+	g.noteGameSpeed()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Energy shield tuning.
+const (
+	shieldPerPassive = 20.0 // Max shield per Energy Shield level
+	shieldRegenDelay = 3.0  // Seconds without a hit before the shield recharges
+	shieldRegenRate  = 0.25 // Fraction of max shield recharged per second
+)
+
+// shieldColor tints the shield overlay on the HP bar and its hit sparks.
+var shieldColor = color.RGBA{R: 80, G: 170, B: 255, A: 255}
+
+// updateShield recharges the energy shield once the player has gone
+// shieldRegenDelay seconds without being hit.
+func (g *Game) updateShield(dt float64) {
+	p := g.player
+	if p.MaxShield <= 0 {
+		return
+	}
+
+	if p.ShieldDelay > 0 {
+		p.ShieldDelay -= dt
+
+		return
+	}
+
+	p.Shield = math.Min(p.Shield+p.MaxShield*shieldRegenRate*dt, p.MaxShield)
+}
+
+// absorbShield soaks up as much of a hit as the shield holds and returns the
+// damage left for HP. Every hit, absorbed or not, restarts the recharge delay.
+func (g *Game) absorbShield(damage int) int {
+	p := g.player
+	p.ShieldDelay = shieldRegenDelay
+
+	absorbed := min(damage, int(p.Shield))
+	if absorbed <= 0 {
+		return damage
+	}
+
+	p.Shield -= float64(absorbed)
+	g.audio.PlaySound("shield")
+	g.spawnParticle(p.X, p.Y, 6, shieldColor)
+
+	return damage - absorbed
+}
+
+// hpLabel is the HP readout, with the shield after it when the player has one.
+func (g *Game) hpLabel() string {
+	label := formatInt(g.player.HP) + "/" + formatInt(g.player.MaxHP)
+	if g.player.MaxShield > 0 {
+		label += " +" + formatInt(int(g.player.Shield))
+	}
+
+	return label
+}
+
+// drawShieldOverlay draws the shield as a translucent blue layer over the HP
+// bar, scaled to max HP so a full bar of shield matches a full bar of health.
+func (g *Game) drawShieldOverlay(screen *ebiten.Image) {
+	p := g.player
+	if p.Shield <= 0 || p.MaxHP <= 0 {
+		return
+	}
+
+	x, y, w, h := g.hpBar.X, g.hpBar.Y, g.hpBar.Width, g.hpBar.Height
+	frac := math.Min(p.Shield/float64(p.MaxHP), 1)
+	overlay := color.NRGBA{R: shieldColor.R, G: shieldColor.G, B: shieldColor.B, A: 150}
+
+	vector.FillRect(screen, float32(x), float32(y), float32(w*frac), float32(h), overlay, false)
+	vector.StrokeLine(screen, float32(x), float32(y+1), float32(x+w*frac), float32(y+1), 2, shieldColor, false)
+}
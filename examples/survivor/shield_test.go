@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestShieldAbsorbsDamageBeforeHP(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.MaxShield, g.player.Shield = 20, 20
+	hp := g.player.HP
+
+	g.hurtPlayer(15, 0, "test")
+
+	if g.player.HP != hp || g.player.Shield != 5 {
+		t.Fatalf("after 15 damage: HP %d (was %d), shield %v; want the shield to take it all",
+			g.player.HP, hp, g.player.Shield)
+	}
+
+	g.hurtPlayer(15, 0, "test")
+
+	if g.player.HP != hp-10 || g.player.Shield != 0 {
+		t.Errorf("after breaking the shield: HP %d, shield %v; want HP %d and no shield",
+			g.player.HP, g.player.Shield, hp-10)
+	}
+}
+
+func TestShieldRechargesAfterNotBeingHit(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.MaxShield = 40
+	g.absorbShield(1)
+
+	for range int(shieldRegenDelay*60) - 1 {
+		g.updateShield(1.0 / 60)
+	}
+
+	if g.player.Shield != 0 {
+		t.Fatalf("shield recharged to %v during the delay", g.player.Shield)
+	}
+
+	for range 10 * 60 {
+		g.updateShield(1.0 / 60)
+	}
+
+	if g.player.Shield != g.player.MaxShield {
+		t.Errorf("shield = %v after recharging, want %v", g.player.Shield, g.player.MaxShield)
+	}
+}
+
+func TestShieldFromPassiveAndGear(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.applyPassive(PassiveShield)
+	g.player.Equipment[SlotMonitor] = &Equipment{
+		Slot: SlotMonitor, Modifiers: []Modifier{{Type: ModShield, Value: 30}},
+	}
+	g.player.Shield = 100
+	g.recalculateStats()
+
+	if want := shieldPerPassive + 30; g.player.MaxShield != want || g.player.Shield != want {
+		t.Errorf("max shield %v, shield %v; want both %v", g.player.MaxShield, g.player.Shield, want)
+	}
+
+	delete(g.player.Equipment, SlotMonitor)
+	g.recalculateStats()
+
+	if g.player.MaxShield != shieldPerPassive {
+		t.Errorf("max shield %v after unequipping, want %v", g.player.MaxShield, shieldPerPassive)
+	}
+}
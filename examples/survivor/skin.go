@@ -0,0 +1,71 @@
+package main
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// survivorTheme is the game's own look, registered alongside the built-in
+// themes so it can be switched from the options screen.
+func survivorTheme() *ui.Theme {
+	t := ui.DarkTheme().Clone("Survivor")
+	t.Palette.Panel = color.RGBA{R: 40, G: 45, B: 60, A: 255}
+	t.Palette.PanelBorder = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	t.Palette.Accent = color.RGBA{R: 100, G: 200, B: 255, A: 255}
+	t.Palette.Highlight = color.RGBA{R: 255, G: 215, B: 0, A: 255}
+	t.Border = ui.BorderStyle{Width: 3, Radius: 8}
+
+	return t
+}
+
+func init() {
+	ui.RegisterTheme(survivorTheme())
+	_ = ui.SetTheme("Survivor")
+}
+
+// applyTheme switches to the theme saved in the settings. An unknown name,
+// such as a theme from a newer build, keeps the current one.
+func (g *Game) applyTheme() {
+	if g.settings.Theme == "" {
+		return
+	}
+
+	if err := ui.SetTheme(g.settings.Theme); err != nil {
+		log.Printf("settings: %v", err)
+	}
+}
+
+// survivorSkin holds the nine-slice art for survivor's dialogs, generated from
+// the active theme. Drop in art assets with ui.NewNineSlice to restyle them.
+type survivorSkin struct {
+	ui.Skin
+
+	LevelUp  *ui.NineSlice
+	GameOver *ui.NineSlice
+	Help     *ui.NineSlice
+
+	theme *ui.Theme
+}
+
+func newSurvivorSkin(t *ui.Theme) *survivorSkin {
+	p, b := t.Palette, t.Border
+
+	return &survivorSkin{
+		Skin:     *t.Skin(),
+		LevelUp:  ui.GenerateNineSlice(p.Panel, p.Highlight, b.Width, b.Radius),
+		GameOver: ui.GenerateNineSlice(p.Panel, p.Danger, b.Width, b.Radius),
+		Help:     ui.GenerateNineSlice(p.Panel, p.Accent, b.Width, b.Radius),
+		theme:    t,
+	}
+}
+
+// uiSkin returns the skin for the active theme, regenerating it after a theme switch.
+func (g *Game) uiSkin() *survivorSkin {
+	if g.skin == nil || g.skin.theme != ui.CurrentTheme() {
+		g.skin = newSurvivorSkin(ui.CurrentTheme())
+	}
+
+	return g.skin
+}
@@ -0,0 +1,51 @@
+package main
+
+import "github.com/skyrocket-qy/NeuralWay/engine/assets"
+
+const (
+	// soundRange is how far from the player monster sounds fade to silence;
+	// bosses spawn a little over halfway out.
+	soundRange = 1000.0
+	// hitSoundCooldown rate-limits hit sounds so a shotgun volley is one hit.
+	hitSoundCooldown = 0.05
+)
+
+// listener hears monster sounds from the player's position.
+func (g *Game) listener() assets.Listener {
+	return assets.Listener{X: g.player.X, Y: g.player.Y, Range: soundRange}
+}
+
+// emitSound plays a monster's sound for a lifecycle event, as declared by
+// its MonsterDef, at the monster's position.
+func (g *Game) emitSound(e *Enemy, event assets.SoundEvent) {
+	if event == assets.SoundHit {
+		if g.hitAudioTimer > 0 {
+			return
+		}
+
+		g.hitAudioTimer = hitSoundCooldown
+	}
+
+	g.audio.Emit(MonsterDefs[e.Type].Sounds, event, e.X, e.Y, g.listener())
+}
+
+// updateAmbience loops the ambient sound of every monster type in earshot,
+// at the volume of the nearest one. Everything falls silent off the
+// battlefield, including while paused or levelling up.
+func (g *Game) updateAmbience() {
+	if g.player == nil {
+		g.ambience.Begin(assets.Listener{})
+	} else {
+		g.ambience.Begin(g.listener())
+	}
+
+	if g.state == StatePlaying {
+		for _, e := range g.enemies {
+			if !e.Dead {
+				g.ambience.Add(MonsterDefs[e.Type].Sounds, e.X, e.Y)
+			}
+		}
+	}
+
+	g.audio.ApplyAmbience(&g.ambience)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/assets"
+)
+
+func TestMonsterSoundsAreGenerated(t *testing.T) {
+	for mt, def := range MonsterDefs {
+		for _, event := range []assets.SoundEvent{assets.SoundSpawn, assets.SoundHit, assets.SoundDeath} {
+			if name := def.Sounds.Sound(event); name != "" && monsterSounds[name] == nil {
+				t.Errorf("%s (%d) names sound %q, which is never generated", def.Name, mt, name)
+			}
+		}
+
+		if name := def.Sounds.Ambient; name != "" && ambientSounds[name] == nil {
+			t.Errorf("%s (%d) names ambient loop %q, which is never generated", def.Name, mt, name)
+		}
+
+		if def.Sounds.Hit == "" || def.Sounds.Death == "" {
+			t.Errorf("%s (%d) is silent when hit or killed", def.Name, mt)
+		}
+	}
+}
+
+func TestAmbienceFollowsNearestMonster(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.spawnMonster(MonsterDowntime, g.player.X+soundRange/2, g.player.Y)
+	far := g.spawnMonster(MonsterDowntime, g.player.X+soundRange*2, g.player.Y)
+	g.spawnMonster(MonsterBug, g.player.X, g.player.Y)
+
+	g.updateAmbience()
+
+	want := 0.5 * MonsterDefs[MonsterDowntime].Sounds.Volume
+	if got := g.ambience.Level("hum"); got != want {
+		t.Errorf("hum level = %v, want %v from the nearer ghost", got, want)
+	}
+
+	if got := g.ambience.Level("drone"); got != 0 {
+		t.Errorf("drone level = %v with no boss", got)
+	}
+
+	// Even a ghost right beside the player is silent while paused
+	far.X = g.player.X + soundRange/4
+
+	g.state = StatePaused
+	g.updateAmbience()
+
+	if got := g.ambience.Level("hum"); got != 0 {
+		t.Errorf("hum level while paused = %v, want silence", got)
+	}
+}
+
+func TestHitSoundsAreRateLimited(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	e := g.spawnMonster(MonsterBug, g.player.X, g.player.Y)
+
+	g.emitSound(e, assets.SoundHit)
+
+	if g.hitAudioTimer != hitSoundCooldown {
+		t.Fatalf("hit timer = %v after a hit, want %v", g.hitAudioTimer, hitSoundCooldown)
+	}
+
+	// A second hit in the same volley neither plays nor extends the cooldown
+	g.hitAudioTimer = hitSoundCooldown / 2
+	g.emitSound(e, assets.SoundHit)
+	g.emitSound(e, assets.SoundDeath)
+
+	if g.hitAudioTimer != hitSoundCooldown/2 {
+		t.Errorf("hit timer = %v, want the cooldown untouched", g.hitAudioTimer)
+	}
+}
+
+func TestAutoPauseOpensPauseMenuAndDucksAudio(t *testing.T) {
+	g := &Game{audio: &AudioPlayer{manager: assets.NewAudioManager(nil)}}
+	g.startGame(CharJunior)
+
+	g.state = StateLevelUp
+	g.Pause()
+
+	if g.state != StateLevelUp || g.audio.manager.Ducked() {
+		t.Fatal("auto-pause should leave screens other than play alone")
+	}
+
+	g.state = StatePlaying
+	g.Pause()
+
+	if g.state != StatePaused || !g.audio.manager.Ducked() {
+		t.Fatalf("state %d, ducked %v; want the pause menu with ducked audio", g.state, g.audio.manager.Ducked())
+	}
+
+	g.state = StatePlaying
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.audio.manager.Ducked() {
+		t.Error("the audio should come back when play resumes")
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// SpawnEventKind is the formation of a scripted spawn event.
+type SpawnEventKind int
+
+const (
+	SpawnRing      SpawnEventKind = iota // Full circle of enemies converging on the player
+	SpawnWall                            // Line of enemies sweeping across the arena
+	SpawnElitePack                       // Tight group of buffed enemies from one direction
+)
+
+// SpawnEventDef defines a scripted spawn event and its timing.
+type SpawnEventDef struct {
+	Name    string
+	Kind    SpawnEventKind
+	Monster MonsterType
+	Count   int
+	Start   float64 // Run time of the first occurrence, seconds
+	Repeat  float64 // Seconds between occurrences (0 = once)
+	Until   float64 // No occurrences after this run time (0 = no limit)
+	Warning float64 // Seconds of warning cue before the spawn
+}
+
+// SpawnEvents is the spawn director's event script.
+var SpawnEvents = []SpawnEventDef{
+	{Name: "Bug Swarm", Kind: SpawnRing, Monster: MonsterBug, Count: 24, Start: 45, Repeat: 60, Until: 240, Warning: 2},
+	{Name: "Null Wall", Kind: SpawnWall, Monster: MonsterNull, Count: 18, Start: 90, Repeat: 90, Warning: 3},
+	{Name: "Legacy Pack", Kind: SpawnElitePack, Monster: MonsterLegacy, Count: 5, Start: 150, Repeat: 120, Warning: 3},
+	{Name: "Spaghetti Ring", Kind: SpawnRing, Monster: MonsterSpaghetti, Count: 30, Start: 200, Repeat: 75, Warning: 2},
+	{Name: "Race Wall", Kind: SpawnWall, Monster: MonsterRaceCond, Count: 24, Start: 300, Repeat: 80, Warning: 3},
+}
+
+const (
+	wallSweepSpeed = 2.5 // Pixels per frame
+	eliteHPMult    = 3.0
+)
+
+// spawnEventState tracks the schedule of one scripted event during a run.
+type spawnEventState struct {
+	def    SpawnEventDef
+	next   float64 // Run time of the next occurrence
+	done   bool
+	warned bool
+	angle  float64 // Direction of the wall side or pack, fixed when the warning starts
+}
+
+// initSpawnEvents schedules the scripted events for a new run.
+func (g *Game) initSpawnEvents() {
+	g.spawnEvents = make([]*spawnEventState, len(SpawnEvents))
+	for i, def := range SpawnEvents {
+		g.spawnEvents[i] = &spawnEventState{def: def, next: def.Start}
+	}
+}
+
+// updateSpawnEvents raises warning cues and fires scripted events when due.
+func (g *Game) updateSpawnEvents() {
+	for _, st := range g.spawnEvents {
+		if st.done {
+			continue
+		}
+
+		if !st.warned && g.gameTime >= st.next-st.def.Warning {
+			st.warned = true
+			r := g.rng.Stream(streamSpawnEvents)
+			st.angle = r.Float64() * math.Pi * 2
+
+			if st.def.Kind == SpawnWall {
+				st.angle = float64(r.Intn(4)) * math.Pi / 2
+			}
+
+			g.audio.PlaySound("select")
+			g.notify(ui.Notification{
+				Title:    st.def.Name + "!",
+				Message:  spawnEventHint(st.def.Kind),
+				Color:    ui.CurrentTheme().Palette.Warning,
+				Duration: st.def.Warning + 1,
+				Priority: ui.ToastHigh,
+			})
+		}
+
+		if g.gameTime < st.next {
+			continue
+		}
+
+		g.fireSpawnEvent(st)
+
+		st.warned = false
+		st.next += st.def.Repeat
+		st.done = st.def.Repeat <= 0 || (st.def.Until > 0 && st.next > st.def.Until)
+	}
+}
+
+// spawnEventHint describes how to react to an event kind.
+func spawnEventHint(kind SpawnEventKind) string {
+	switch kind {
+	case SpawnRing:
+		return "You're being surrounded"
+	case SpawnWall:
+		return "A wall is sweeping in"
+	default:
+		return "Elites approaching"
+	}
+}
+
+// fireSpawnEvent spawns the formation for an event.
+func (g *Game) fireSpawnEvent(st *spawnEventState) {
+	def := st.def
+	px, py := g.player.X, g.player.Y
+
+	switch def.Kind {
+	case SpawnRing:
+		radius := math.Max(screenWidth, screenHeight)/2 + 40
+		for i := range def.Count {
+			a := float64(i) / float64(def.Count) * math.Pi * 2
+			g.spawnMonster(def.Monster, px+math.Cos(a)*radius, py+math.Sin(a)*radius)
+		}
+	case SpawnWall:
+		// Start beyond the screen edge on one side and sweep past the player
+		dirX, dirY := math.Cos(st.angle), math.Sin(st.angle)
+		dist := math.Abs(dirX)*screenWidth/2 + math.Abs(dirY)*screenHeight/2 + 60
+		span := math.Abs(dirY)*screenWidth + math.Abs(dirX)*screenHeight
+		lifetime := (2*dist + 200) / (wallSweepSpeed * 60)
+
+		for i := range def.Count {
+			offset := (float64(i)/float64(max(def.Count-1, 1)) - 0.5) * span * 1.2
+			x := px + dirX*dist - dirY*offset
+			y := py + dirY*dist + dirX*offset
+
+			e := g.spawnMonster(def.Monster, x, y)
+			e.SweepX, e.SweepY = -dirX*wallSweepSpeed, -dirY*wallSweepSpeed
+			e.Lifetime = lifetime
+		}
+	case SpawnElitePack:
+		dist := float64(screenWidth)/2 + 80
+		cx, cy := px+math.Cos(st.angle)*dist, py+math.Sin(st.angle)*dist
+
+		r := g.rng.Stream(streamSpawnEvents)
+
+		for range def.Count {
+			e := g.spawnMonster(def.Monster, cx+(r.Float64()-0.5)*80, cy+(r.Float64()-0.5)*80)
+			e.Elite = true
+			e.HP = int(float64(e.HP) * eliteHPMult)
+			e.MaxHP = e.HP
+			e.XP *= 3
+			e.Damage = e.Damage * 3 / 2
+			e.Radius *= 1.3
+		}
+	}
+}
+
+// sweepEnemy moves a wall enemy along its fixed heading and expires it once past.
+// It returns false if the enemy is not sweeping.
+func (g *Game) sweepEnemy(e *Enemy, dt float64) bool {
+	if e.SweepX == 0 && e.SweepY == 0 {
+		return false
+	}
+
+	e.X += e.SweepX * g.enemySpeedScale()
+	e.Y += e.SweepY * g.enemySpeedScale()
+
+	e.Lifetime -= dt
+	if e.Lifetime <= 0 {
+		e.Dead = true // Walked off; no kill credit
+	}
+
+	return true
+}
+
+// drawSpawnWarnings draws the cue for each event that is about to fire.
+func (g *Game) drawSpawnWarnings(screen *ebiten.Image) {
+	pulse := uint8(80 + 80*math.Sin(g.gameTime*10))
+	warn := color.NRGBA{R: 255, G: 60, B: 40, A: pulse}
+
+	for _, st := range g.spawnEvents {
+		if !st.warned {
+			continue
+		}
+
+		switch st.def.Kind {
+		case SpawnRing:
+			radius := float32(math.Max(screenWidth, screenHeight)/2 - 20)
+			vector.StrokeCircle(screen, screenWidth/2, screenHeight/2, radius, 4, warn, false)
+		case SpawnWall:
+			const band = 14
+
+			switch int(math.Round(st.angle / (math.Pi / 2))) {
+			case 0:
+				vector.FillRect(screen, screenWidth-band, 0, band, screenHeight, warn, false)
+			case 1:
+				vector.FillRect(screen, 0, screenHeight-band, screenWidth, band, warn, false)
+			case 2:
+				vector.FillRect(screen, 0, 0, band, screenHeight, warn, false)
+			default:
+				vector.FillRect(screen, 0, 0, screenWidth, band, warn, false)
+			}
+		case SpawnElitePack:
+			// Marker on the screen edge toward the incoming pack
+			dx, dy := math.Cos(st.angle), math.Sin(st.angle)
+			scale := math.Min((screenWidth/2-20)/math.Max(math.Abs(dx), 1e-6),
+				(screenHeight/2-20)/math.Max(math.Abs(dy), 1e-6))
+			x, y := float32(screenWidth/2+dx*scale), float32(screenHeight/2+dy*scale)
+			vector.FillCircle(screen, x, y, 14, warn, false)
+			vector.StrokeCircle(screen, x, y, 18, 2, color.RGBA{R: 255, G: 215, B: 0, A: 255}, false)
+		}
+	}
+}
+
+// drawEliteMarkers outlines elite enemies so packs stand out.
+func (g *Game) drawEliteMarkers(screen *ebiten.Image) {
+	for _, e := range g.enemies {
+		if !e.Elite || e.Dead {
+			continue
+		}
+
+		sx, sy := float32(e.X-g.cameraX), float32(e.Y-g.cameraY)
+		vector.StrokeCircle(screen, sx, sy, float32(e.Radius)+4, 2, color.RGBA{R: 255, G: 215, B: 0, A: 255}, false)
+	}
+}
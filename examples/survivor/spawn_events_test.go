@@ -0,0 +1,107 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// runEventsTo advances run time to t, updating the spawn director every frame.
+func runEventsTo(g *Game, t float64) {
+	for g.gameTime < t {
+		g.gameTime += 1.0 / 60
+		g.updateSpawnEvents()
+	}
+}
+
+func TestRingEventWarnsThenSurrounds(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	def := SpawnEventDef{Name: "Ring", Kind: SpawnRing, Monster: MonsterBug, Count: 12, Start: 10, Warning: 2}
+	g.spawnEvents = []*spawnEventState{{def: def, next: def.Start}}
+
+	runEventsTo(g, 8.5)
+
+	if !g.spawnEvents[0].warned || len(g.enemies) != 0 {
+		t.Fatalf("expected warning before spawn: warned=%v enemies=%d", g.spawnEvents[0].warned, len(g.enemies))
+	}
+
+	if g.toasts.Len() != 1 {
+		t.Errorf("warning cue should raise a toast, got %d", g.toasts.Len())
+	}
+
+	runEventsTo(g, 10.1)
+
+	if len(g.enemies) != def.Count {
+		t.Fatalf("ring spawned %d enemies, want %d", len(g.enemies), def.Count)
+	}
+
+	want := math.Hypot(g.enemies[0].X, g.enemies[0].Y)
+	for _, e := range g.enemies {
+		if math.Abs(math.Hypot(e.X, e.Y)-want) > 0.001 {
+			t.Fatalf("ring enemies should be equidistant from the player")
+		}
+	}
+
+	if !g.spawnEvents[0].done {
+		t.Error("one-shot event should be done")
+	}
+}
+
+func TestWallSweepsPastAndExpires(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.HP = 1_000_000
+	g.player.MaxHP = 1_000_000
+
+	def := SpawnEventDef{Kind: SpawnWall, Monster: MonsterNull, Count: 10, Start: 1, Repeat: 5, Until: 7}
+	st := &spawnEventState{def: def, next: def.Start}
+	g.spawnEvents = []*spawnEventState{st}
+
+	runEventsTo(g, 1.01)
+
+	if len(g.enemies) != def.Count || g.enemies[0].Lifetime <= 0 {
+		t.Fatalf("wall spawned %d enemies", len(g.enemies))
+	}
+
+	// The wall heads toward the player's side and is gone after its lifetime
+	e := g.enemies[0]
+	before := math.Hypot(e.X-g.player.X, e.Y-g.player.Y)
+	g.updateEnemies(1.0 / 60)
+
+	if after := math.Hypot(e.X-g.player.X, e.Y-g.player.Y); after >= before {
+		t.Errorf("wall enemy should approach: %v -> %v", before, after)
+	}
+
+	for range int(e.Lifetime*60) + 2 {
+		g.updateEnemies(1.0 / 60)
+	}
+
+	g.updateEnemies(1.0 / 60)
+
+	if len(g.enemies) != 0 || g.killCount != 0 {
+		t.Errorf("wall should expire without kills: %d left, %d kills", len(g.enemies), g.killCount)
+	}
+
+	// Repeats at 6s, then stops because the next one (11s) is past Until
+	runEventsTo(g, 12)
+
+	if !st.done || len(g.enemies) != def.Count {
+		t.Errorf("repeat schedule: done=%v enemies=%d", st.done, len(g.enemies))
+	}
+}
+
+func TestElitePackIsBuffed(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	st := &spawnEventState{def: SpawnEventDef{Kind: SpawnElitePack, Monster: MonsterLegacy, Count: 4}}
+	g.fireSpawnEvent(st)
+
+	base := MonsterDefs[MonsterLegacy]
+	for _, e := range g.enemies {
+		if !e.Elite || e.MaxHP < base.HP*3 || e.Radius <= base.Radius {
+			t.Errorf("elite not buffed: %+v", e)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// spawnReportFlag makes the game play a headless run and export the spawn
+// director's curves instead of starting: survivor -spawn-report [prefix]
+// writes prefix.csv and prefix.svg.
+const spawnReportFlag = "-spawn-report"
+
+const (
+	spawnReportPrefix = "spawn_report"
+	spawnReportLength = 15 * 60.0 // Seconds of game time the report covers
+	spawnReportStep   = 5.0       // Seconds between samples
+	spawnReportSeed   = 1         // Fixed so reports from two builds compare
+)
+
+// SpawnSample is the state of a report run at one moment.
+type SpawnSample struct {
+	Time          float64
+	OnScreen      int // Enemies inside the view around the player
+	Alive         int // Enemies anywhere in the world
+	XPAvailable   int // XP carried by every enemy spawned so far
+	ExpectedLevel int // Level reached by collecting all of XPAvailable
+	Level         int // Level the autopilot actually reached
+}
+
+// spawnReportRequested reports whether args ask for a spawn report, and the
+// file prefix to write it to.
+func spawnReportRequested(args []string) (string, bool) {
+	i := slices.Index(args, spawnReportFlag)
+	if i < 0 {
+		return "", false
+	}
+
+	if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+		return args[i+1], true
+	}
+
+	return spawnReportPrefix, true
+}
+
+// spawnReport plays length seconds of a run with the autopilot and an
+// unkillable player, sampling every spawnReportStep seconds. The autopilot
+// flees rather than farms, so its level trails the expected level; the gap
+// between the two is how much XP a run leaves uncollected.
+func spawnReport(length float64) []SpawnSample {
+	g := &Game{autopilot: true}
+	g.settings.GraphicsProbed = true
+	g.startRun(RunConfig{Seed: spawnReportSeed, Char: CharJunior})
+
+	samples := []SpawnSample{g.spawnSample()}
+	next := spawnReportStep
+
+	for g.gameTime < length {
+		g.player.HP = g.player.MaxHP
+
+		switch g.state {
+		case StatePlaying:
+			_ = g.updatePlaying()
+		case StateLevelUp:
+			g.autopilotLevelUp()
+		default:
+			g.state = StatePlaying
+		}
+
+		if g.gameTime >= next {
+			samples = append(samples, g.spawnSample())
+			next += spawnReportStep
+		}
+	}
+
+	return samples
+}
+
+// spawnSample records the run as it stands.
+func (g *Game) spawnSample() SpawnSample {
+	s := SpawnSample{
+		Time:          g.gameTime,
+		Alive:         len(g.enemies),
+		XPAvailable:   g.spawnedXP,
+		ExpectedLevel: levelForXP(g.spawnedXP),
+		Level:         g.player.Level,
+	}
+
+	for _, e := range g.enemies {
+		if math.Abs(e.X-g.player.X) <= screenWidth/2 && math.Abs(e.Y-g.player.Y) <= screenHeight/2 {
+			s.OnScreen++
+		}
+	}
+
+	return s
+}
+
+// levelForXP returns the level a player starting at level 1 reaches with xp,
+// on the same curve as the player's XPToNext.
+func levelForXP(xp int) int {
+	p := Player{Level: 1}
+	for xp >= p.XPToNext() {
+		xp -= p.XPToNext()
+		p.Level++
+	}
+
+	return p.Level
+}
+
+// writeSpawnCSV writes samples as CSV with a header row.
+func writeSpawnCSV(w io.Writer, samples []SpawnSample) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{
+		"time", "enemies_on_screen", "enemies_alive", "xp_available", "expected_level", "level",
+	})
+
+	for _, s := range samples {
+		_ = cw.Write([]string{
+			strconv.FormatFloat(s.Time, 'f', 1, 64),
+			strconv.Itoa(s.OnScreen),
+			strconv.Itoa(s.Alive),
+			strconv.Itoa(s.XPAvailable),
+			strconv.Itoa(s.ExpectedLevel),
+			strconv.Itoa(s.Level),
+		})
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// Graph layout for writeSpawnSVG.
+const (
+	graphW, graphH = 900, 400
+	graphPad       = 50
+)
+
+// spawnSeries is one line of the graph.
+type spawnSeries struct {
+	name  string
+	color string
+	value func(SpawnSample) int
+}
+
+var spawnGraphSeries = []spawnSeries{
+	{"enemies on screen", "#e05050", func(s SpawnSample) int { return s.OnScreen }},
+	{"xp available", "#50a0e0", func(s SpawnSample) int { return s.XPAvailable }},
+	{"expected level", "#50c060", func(s SpawnSample) int { return s.ExpectedLevel }},
+	{"autopilot level", "#c0a040", func(s SpawnSample) int { return s.Level }},
+}
+
+// writeSpawnSVG draws samples as an SVG line graph over time. Each line is
+// scaled to its own peak, which the legend lists.
+func writeSpawnSVG(w io.Writer, samples []SpawnSample) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" `+
+		`font-family="monospace" font-size="12">`+"\n", graphW, graphH)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#1e1e28"/>`+"\n", graphW, graphH)
+
+	plotW, plotH := float64(graphW-2*graphPad), float64(graphH-2*graphPad)
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%g" height="%g" fill="none" stroke="#666"/>`+"\n",
+		graphPad, graphPad, plotW, plotH)
+
+	end := 1.0
+	if len(samples) > 0 {
+		end = max(end, samples[len(samples)-1].Time)
+	}
+
+	// A tick every minute along the time axis
+	for t := 0.0; t <= end; t += 60 {
+		x := graphPad + t/end*plotW
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" fill="#aaa" text-anchor="middle">%s</text>`+"\n",
+			x, graphH-graphPad+16, formatTime(t))
+	}
+
+	for i, series := range spawnGraphSeries {
+		peak := 1
+		for _, s := range samples {
+			peak = max(peak, series.value(s))
+		}
+
+		points := make([]string, len(samples))
+		for j, s := range samples {
+			x := graphPad + s.Time/end*plotW
+			y := graphPad + plotH - float64(series.value(s))/float64(peak)*plotH
+			points[j] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`+"\n",
+			series.color, strings.Join(points, " "))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s">%s (peak %d)</text>`+"\n",
+			graphPad+i*(graphW-2*graphPad)/len(spawnGraphSeries), graphPad-12, series.color, series.name, peak)
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// writeSpawnReport plays a report run and writes prefix.csv and prefix.svg.
+func writeSpawnReport(prefix string, length float64) error {
+	samples := spawnReport(length)
+
+	outputs := []struct {
+		ext   string
+		write func(io.Writer, []SpawnSample) error
+	}{
+		{".csv", writeSpawnCSV},
+		{".svg", writeSpawnSVG},
+	}
+
+	for _, out := range outputs {
+		f, err := os.Create(prefix + out.ext)
+		if err != nil {
+			return err
+		}
+
+		if err := out.write(f, samples); err != nil {
+			f.Close()
+
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		fmt.Println("wrote", prefix+out.ext)
+	}
+
+	return nil
+}
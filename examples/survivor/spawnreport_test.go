@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestSpawnReportTracksTheDirector(t *testing.T) {
+	samples := spawnReport(60)
+
+	if len(samples) != 13 || samples[0].Time != 0 || samples[12].Time < 60 {
+		t.Fatalf("%d samples, from %v to %v", len(samples), samples[0].Time, samples[len(samples)-1].Time)
+	}
+
+	last := samples[len(samples)-1]
+	if last.XPAvailable == 0 || last.Alive == 0 || last.OnScreen > last.Alive {
+		t.Errorf("after a minute: %+v", last)
+	}
+
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		if cur.XPAvailable < prev.XPAvailable || cur.ExpectedLevel < prev.ExpectedLevel {
+			t.Errorf("cumulative XP fell at %v: %+v after %+v", cur.Time, cur, prev)
+		}
+	}
+
+	// The same seed gives the same report
+	if again := spawnReport(60); again[12] != last {
+		t.Errorf("second report ends %+v, first %+v", again[12], last)
+	}
+
+	var buf bytes.Buffer
+	if err := writeSpawnCSV(&buf, samples); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != len(samples)+1 || rows[0][0] != "time" {
+		t.Errorf("CSV has %d rows, err %v", len(rows), err)
+	}
+
+	buf.Reset()
+
+	err = writeSpawnSVG(&buf, samples)
+	if lines := strings.Count(buf.String(), "<polyline"); err != nil || lines != len(spawnGraphSeries) {
+		t.Errorf("SVG has %d lines, err %v", lines, err)
+	}
+}
+
+func TestLevelForXPFollowsTheLevelCurve(t *testing.T) {
+	for _, c := range []struct{ xp, level int }{{0, 1}, {24, 1}, {25, 2}, {74, 2}, {75, 3}, {150, 4}} {
+		if got := levelForXP(c.xp); got != c.level {
+			t.Errorf("levelForXP(%d) = %d, want %d", c.xp, got, c.level)
+		}
+	}
+}
+
+func TestSpawnReportFlag(t *testing.T) {
+	if _, ok := spawnReportRequested([]string{"-dev"}); ok {
+		t.Error("no flag, but a report was requested")
+	}
+
+	if p, ok := spawnReportRequested([]string{spawnReportFlag, "-dev"}); !ok || p != spawnReportPrefix {
+		t.Errorf("bare flag: prefix %q, ok %v", p, ok)
+	}
+
+	if p, _ := spawnReportRequested([]string{spawnReportFlag, "out/curve"}); p != "out/curve" {
+		t.Errorf("prefix %q", p)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+// Cooldown reduction tuning. Reduction past the soft floor only counts at
+// cooldownDRRate effectiveness, and the result never drops below the hard floor,
+// so stacking cooldown passives and gear cannot make weapons fire every frame.
+const (
+	cooldownSoftFloor = 0.6
+	cooldownHardFloor = 0.35
+	cooldownDRRate    = 0.5
+)
+
+// InstanceRule controls what happens when a weapon fires while projectiles
+// from its previous cast are still alive.
+type InstanceRule int
+
+const (
+	// InstanceStack lets casts overlap freely (orbits, thrown projectiles).
+	InstanceStack InstanceRule = iota
+	// InstanceRefresh replaces the live instances, restarting their duration.
+	// Used by pulses and auras so faster casting never double-hits an enemy.
+	InstanceRefresh
+	// InstanceQueue holds the next cast until the previous one has expired.
+	InstanceQueue
+)
+
+// EffectiveCooldownMult applies diminishing returns to a raw cooldown multiplier.
+func EffectiveCooldownMult(raw float64) float64 {
+	if raw >= cooldownSoftFloor {
+		return raw
+	}
+
+	eff := cooldownSoftFloor - (cooldownSoftFloor-raw)*cooldownDRRate
+
+	return max(eff, cooldownHardFloor)
+}
+
+// EffectiveCooldownMult returns the player's cooldown multiplier after diminishing returns.
+func (p *Player) EffectiveCooldownMult() float64 {
+	return EffectiveCooldownMult(p.CooldownMult)
+}
+
+// projectileBonus returns the projectiles every weapon fires on top of its
+// own count: the Amount passive plus gear and tree bonuses.
+func (p *Player) projectileBonus() int {
+	return p.Passives[PassiveAmount] + p.BonusProjectiles
+}
+
+// weaponCooldown returns the seconds between casts for a weapon.
+func (g *Game) weaponCooldown(w *Weapon) float64 {
+	return WeaponDefs[w.Type].Cooldown * g.player.EffectiveCooldownMult()
+}
+
+// liveInstances counts projectiles still alive from the given weapon's casts,
+// ignoring children spawned by death effects and combo attacks.
+func (g *Game) liveInstances(wt WeaponType) int {
+	count := 0
+
+	for _, p := range g.projectiles {
+		if p.WeaponType == wt && p.Lifetime > 0 && !p.Child && !p.Combo {
+			count++
+		}
+	}
+
+	return count
+}
+
+// expireInstances removes all live projectiles of the given weapon immediately,
+// so they cannot deal one last hit in the frame they are replaced. Combo
+// attacks that share its look are left alone.
+func (g *Game) expireInstances(wt WeaponType) {
+	kept := g.projectiles[:0]
+
+	for _, p := range g.projectiles {
+		if p.WeaponType == wt && !p.Combo {
+			g.freeProjectile(p)
+
+			continue
+		}
+
+		kept = append(kept, p)
+	}
+
+	g.projectiles = kept
+}
+
+// updateWeapons advances weapon timers and fires them according to their instance rule.
+func (g *Game) updateWeapons(dt float64) {
+	for _, w := range g.player.Weapons {
+		cooldown := g.weaponCooldown(w)
+
+		w.Timer += dt
+		if w.Timer < cooldown {
+			continue
+		}
+
+		switch WeaponDefs[w.Type].InstanceRule {
+		case InstanceQueue:
+			if g.liveInstances(w.Type) > 0 {
+				// Stay ready and fire as soon as the previous cast ends
+				w.Timer = cooldown
+
+				continue
+			}
+		case InstanceRefresh:
+			g.expireInstances(w.Type)
+		case InstanceStack:
+		}
+
+		g.fireWeapon(w)
+		w.Timer = 0
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEffectiveCooldownMult(t *testing.T) {
+	tests := []struct {
+		raw, want float64
+	}{
+		{1.0, 1.0},
+		{0.8, 0.8},
+		{cooldownSoftFloor, cooldownSoftFloor},
+		{0.4, 0.5},   // 0.2 past the soft floor counts half
+		{0.2, 0.4},   // 0.4 past counts as 0.2
+		{0.05, 0.35}, // clamped to the hard floor
+		{0, cooldownHardFloor},
+	}
+
+	for _, tt := range tests {
+		if got := EffectiveCooldownMult(tt.raw); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("EffectiveCooldownMult(%v) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+
+	// Monotonic: more raw reduction never makes cooldowns longer
+	prev := EffectiveCooldownMult(1)
+	for raw := 1.0; raw >= 0; raw -= 0.01 {
+		got := EffectiveCooldownMult(raw)
+		if got > prev+1e-9 {
+			t.Fatalf("not monotonic at raw=%v: %v > %v", raw, got, prev)
+		}
+
+		prev = got
+	}
+}
+
+// newWeaponTestGame starts a run with a single weapon and a tanky dummy enemy on the player.
+func newWeaponTestGame(wt WeaponType) (*Game, *Enemy) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.player.Weapons = []*Weapon{{Type: wt, Level: 1}}
+
+	dummy := &Enemy{HP: 1_000_000, MaxHP: 1_000_000, Radius: 10}
+	g.enemies = append(g.enemies, dummy)
+
+	return g, dummy
+}
+
+func TestRefreshRulePreventsOverlappingPulses(t *testing.T) {
+	g, dummy := newWeaponTestGame(WeaponUnitTests)
+	g.player.CooldownMult = 0.01 // Far past the floor
+
+	dt := 1.0 / 60
+	casts := 0
+
+	for range 300 {
+		before := g.player.Weapons[0].Timer
+		g.updateWeapons(dt)
+
+		if g.player.Weapons[0].Timer < before {
+			casts++
+		}
+
+		if n := g.liveInstances(WeaponUnitTests); n > 1 {
+			t.Fatalf("%d Unit Test pulses alive at once", n)
+		}
+
+		g.updateProjectiles(dt)
+	}
+
+	damagePerCast := int(float64(WeaponDefs[WeaponUnitTests].Damage+3) * g.player.DamageMult)
+	if taken := dummy.MaxHP - dummy.HP; taken > casts*damagePerCast {
+		t.Errorf("dummy took %d damage from %d casts, want at most %d", taken, casts, casts*damagePerCast)
+	}
+
+	wantCasts := int(5 / (WeaponDefs[WeaponUnitTests].Cooldown * cooldownHardFloor))
+	if casts > wantCasts+1 {
+		t.Errorf("casts = %d, want at most %d with hard floor", casts, wantCasts+1)
+	}
+}
+
+func TestQueueRuleWaitsForPreviousCast(t *testing.T) {
+	g, _ := newWeaponTestGame(WeaponK8s)
+	g.enemies = nil // K8s flies straight when no target, living its full duration
+	g.player.CooldownMult = 0
+
+	g.player.Weapons[0].Timer = 100
+	g.updateWeapons(0)
+
+	if n := g.liveInstances(WeaponK8s); n != 1 {
+		t.Fatalf("live = %d after first cast, want 1", n)
+	}
+
+	// Cooldown is ready again long before the 2s cast expires
+	g.updateWeapons(1)
+
+	if n := g.liveInstances(WeaponK8s); n != 1 {
+		t.Errorf("queued weapon fired over a live cast: live = %d", n)
+	}
+
+	for _, p := range g.projectiles {
+		p.Lifetime = 0
+	}
+
+	g.updateWeapons(0)
+
+	if n := g.liveInstances(WeaponK8s); n != 1 {
+		t.Errorf("queued cast did not fire once previous expired: live = %d", n)
+	}
+}
+
+func TestDurationScalesProjectileLifetimes(t *testing.T) {
+	g, dummy := newWeaponTestGame(WeaponGitPush)
+	dummy.X = 100
+	g.player.Passives[PassiveDuration] = 2
+	g.player.Equipment[SlotHeadphones] = &Equipment{
+		Slot: SlotHeadphones, Modifiers: []Modifier{{Type: ModDuration, Value: 30}},
+	}
+	g.recalculateStats()
+
+	if want := 1.5; math.Abs(g.player.DurationMult-want) > 1e-9 {
+		t.Fatalf("DurationMult = %v, want %v from two passive levels and a +30%% mod", g.player.DurationMult, want)
+	}
+
+	g.fireWeapon(g.player.Weapons[0])
+
+	if len(g.projectiles) == 0 {
+		t.Fatal("Git Push fired nothing at the dummy")
+	}
+
+	want := WeaponDefs[WeaponGitPush].Duration * 1.5
+	if got := g.projectiles[0].Lifetime; math.Abs(got-want) > 1e-9 {
+		t.Errorf("lifetime = %v, want %v", got, want)
+	}
+}
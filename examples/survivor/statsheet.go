@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// Character sheet layout.
+const (
+	statSheetW    = 860
+	statSheetH    = 660
+	statSheetRowH = 20
+)
+
+// String fills in the modifier's value, e.g. "+12 Armor". A negative value
+// flips the sign, so a loss of 12 armor reads "-12 Armor".
+func (m Modifier) String() string {
+	name, value := ModTypeNames[m.Type], m.Value
+	if value < 0 {
+		value = -value
+
+		if rest, ok := strings.CutPrefix(name, "+"); ok {
+			name = "-" + rest
+		} else if rest, ok := strings.CutPrefix(name, "-"); ok {
+			name = "+" + rest
+		}
+	}
+
+	return strings.Replace(name, "#", strconv.FormatFloat(value, 'f', -1, 64), 1)
+}
+
+// statSource is something feeding the player's stats through modifiers: an
+// allocated tree node, an equipped item, or the drafted mutation.
+type statSource struct {
+	Name string
+	Mods []Modifier
+}
+
+// statSources lists what recalculateStats applies modifiers from, in the
+// order it applies them.
+func (g *Game) statSources() []statSource {
+	var sources []statSource
+
+	for _, node := range g.passiveTree {
+		if g.player.AllocatedNodes[node.ID] && len(node.Effects) > 0 {
+			sources = append(sources, statSource{Name: "Tree: " + node.Name, Mods: node.Effects})
+		}
+	}
+
+	for slot := range SlotCount {
+		if equip := g.player.Equipment[slot]; equip != nil && len(equip.Modifiers) > 0 {
+			sources = append(sources, statSource{Name: equip.Name, Mods: equip.Modifiers})
+		}
+	}
+
+	if m, ok := mutationByName(g.player.Mutation); ok && len(m.Effects) > 0 {
+		sources = append(sources, statSource{Name: "Mutation: " + m.Name, Mods: m.Effects})
+	}
+
+	return sources
+}
+
+// statRow is one computed stat on the character sheet, with the modifier
+// and level-up passive types that change it.
+type statRow struct {
+	Label    string
+	Value    string
+	Mods     []ModType
+	Passives []PassiveType
+}
+
+// statRows lists the player's computed stats.
+func (g *Game) statRows() []statRow {
+	p := g.player
+	armor := playerMitigation.Reduction(float64(p.Armor)) * 100
+
+	return []statRow{
+		{"Max HP", strconv.Itoa(p.MaxHP), []ModType{ModFlatHP, ModPercentHP}, nil},
+		{"Armor", fmt.Sprintf("%d (-%.0f%% dmg)", p.Armor, armor), []ModType{ModArmor}, []PassiveType{PassiveArmor}},
+		{"Energy Shield", fmt.Sprintf("%.0f", p.MaxShield), []ModType{ModShield}, []PassiveType{PassiveShield}},
+		{"Recovery", fmt.Sprintf("%.1f HP/s", p.Recovery), []ModType{ModRecovery}, []PassiveType{PassiveRecovery}},
+		{"Move Speed", fmt.Sprintf("%.2f", p.Speed), []ModType{ModSpeed}, []PassiveType{PassiveSpeed}},
+		{
+			"Damage", fmt.Sprintf("x%.2f, %+d flat", p.DamageMult, p.FlatDamage),
+			[]ModType{ModPercentDamage, ModFlatDamage}, []PassiveType{PassiveMight},
+		},
+		{
+			"Crit Chance", fmt.Sprintf("%.0f%%", p.CritChance*100),
+			[]ModType{ModCritChance}, []PassiveType{PassiveLuck},
+		},
+		{"Crit Damage", fmt.Sprintf("x%.2f", p.CritMultiplier), []ModType{ModCritMultiplier}, nil},
+		{
+			"Cooldown", fmt.Sprintf("x%.2f (raw x%.2f)", p.EffectiveCooldownMult(), p.CooldownMult),
+			[]ModType{ModCooldown}, []PassiveType{PassiveCooldown},
+		},
+		{"Area", fmt.Sprintf("x%.2f", p.AreaMult), []ModType{ModArea}, []PassiveType{PassiveArea}},
+		{"Duration", fmt.Sprintf("x%.2f", p.DurationMult), []ModType{ModDuration}, []PassiveType{PassiveDuration}},
+		{
+			"Projectiles", fmt.Sprintf("%+d", p.projectileBonus()),
+			[]ModType{ModProjectiles}, []PassiveType{PassiveAmount},
+		},
+		{"Pickup Range", fmt.Sprintf("%.0f", p.MagnetRange), []ModType{ModMagnet}, []PassiveType{PassiveMagnet}},
+		{"XP Gain", fmt.Sprintf("x%.2f", p.XPMult), []ModType{ModXPGain}, []PassiveType{PassiveGrowth}},
+		{"Life Steal", fmt.Sprintf("%.0f%%", p.Lifesteal*100), []ModType{ModLifesteal}, nil},
+		{"Thorns", fmt.Sprintf("%.0f%%", p.Thorns*100), []ModType{ModThorns}, nil},
+		{"Forked Arcs", strconv.Itoa(p.ForkCount), []ModType{ModForkLightning}, nil},
+	}
+}
+
+// contributors names the sources and level-up passives that change a stat,
+// e.g. "Tree: Iron Skin, Armor 2".
+func (g *Game) contributors(row statRow, sources []statSource) []string {
+	var names []string
+
+	for _, s := range sources {
+		for _, m := range s.Mods {
+			if containsMod(row.Mods, m.Type) {
+				names = append(names, s.Name)
+
+				break
+			}
+		}
+	}
+
+	for _, pt := range row.Passives {
+		if lvl := g.player.Passives[pt]; lvl > 0 {
+			names = append(names, PassiveDefs[pt].Name+" "+strconv.Itoa(lvl))
+		}
+	}
+
+	return names
+}
+
+func containsMod(types []ModType, t ModType) bool {
+	for _, mt := range types {
+		if mt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// weaponDPS estimates a weapon's damage per second against one target: the
+// average hit, crits included, times the projectiles per cast over the
+// effective cooldown.
+func (g *Game) weaponDPS(w *Weapon) float64 {
+	stats := g.weaponStats(w.Type, w.Level)
+	if stats.Cooldown <= 0 {
+		return 0
+	}
+
+	return g.damageCalc(w.Type, w.Level).Average() * float64(stats.Count) / stats.Cooldown
+}
+
+func (g *Game) updateStats() error {
+	// ESC or C to close
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.state = StatePlaying
+	}
+
+	return nil
+}
+
+func (g *Game) drawStats(screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, color.RGBA{A: 180}, false)
+
+	panelX, panelY := float32(screenWidth-statSheetW)/2, float32(screenHeight-statSheetH)/2
+	panelBg, panelEdge := color.RGBA{R: 30, G: 30, B: 40, A: 255}, color.RGBA{R: 100, G: 150, B: 200, A: 255}
+	vector.FillRect(screen, panelX, panelY, statSheetW, statSheetH, panelBg, false)
+	vector.StrokeRect(screen, panelX, panelY, statSheetW, statSheetH, 3, panelEdge, false)
+
+	x, y := int(panelX), int(panelY)
+	title := Characters[g.player.CharType].Name + " - Level " + strconv.Itoa(g.player.Level)
+	drawText(screen, "CHARACTER SHEET", x+statSheetW/2, y+8, headingText())
+	drawText(screen, title, x+statSheetW/2, y+38, text.Options{Align: text.AlignCenter})
+
+	hint := smallText()
+	hint.Align = text.AlignRight
+	drawText(screen, "C: close", x+statSheetW-12, y+12, hint)
+
+	g.drawStatRows(screen, x+20, y+70)
+	g.drawWeaponTable(screen, x+490, y+70, y+statSheetH-40)
+}
+
+// drawStatRows draws each stat with its value and, muted beside it, what
+// contributes to it.
+func (g *Game) drawStatRows(screen *ebiten.Image, x, y int) {
+	drawText(screen, "Stats", x, y, text.Options{Font: text.Bold()})
+	y += 26
+
+	sources := g.statSources()
+	from := smallText()
+	from.Width = 200
+
+	for _, row := range g.statRows() {
+		drawText(screen, row.Label, x, y, smallText())
+		drawText(screen, row.Value, x+110, y, text.Options{Size: from.Size})
+
+		if names := g.contributors(row, sources); len(names) > 0 {
+			lines := text.Wrap(strings.Join(names, ", "), from)
+			if len(lines) > 1 {
+				lines[0] += "..."
+			}
+
+			drawText(screen, lines[0], x+260, y, from)
+		}
+
+		y += statSheetRowH
+	}
+}
+
+// weaponColumns are the x offsets of the weapon table's columns.
+var weaponColumns = []int{0, 130, 165, 215, 270, 300}
+
+// drawWeaponTable draws each weapon's hit, cooldown, and DPS, then every
+// source's modifiers down to bottom.
+func (g *Game) drawWeaponTable(screen *ebiten.Image, x, y, bottom int) {
+	drawText(screen, "Weapons", x, y, text.Options{Font: text.Bold()})
+	y += 26
+
+	head := smallText()
+	for i, label := range []string{"", "Lv", "Hit", "CD", "x", "DPS"} {
+		drawText(screen, label, x+weaponColumns[i], y, head)
+	}
+
+	y += statSheetRowH
+	total := 0.0
+	cell := text.Options{Size: head.Size}
+
+	for _, w := range g.player.Weapons {
+		stats := g.weaponStats(w.Type, w.Level)
+		dps := g.weaponDPS(w)
+		total += dps
+
+		cells := []string{
+			WeaponDefs[w.Type].Name, strconv.Itoa(w.Level), strconv.Itoa(stats.Damage),
+			fmt.Sprintf("%.2fs", stats.Cooldown), strconv.Itoa(stats.Count), fmt.Sprintf("%.0f", dps),
+		}
+		for i, c := range cells {
+			drawText(screen, c, x+weaponColumns[i], y, cell)
+		}
+
+		y += statSheetRowH
+	}
+
+	bold := text.Options{Font: text.Bold(), Size: head.Size}
+	drawText(screen, fmt.Sprintf("Total DPS %.0f", total), x, y+4, bold)
+	y += statSheetRowH + 20
+
+	drawText(screen, "Sources", x, y, text.Options{Font: text.Bold()})
+	y += 26
+
+	sources := g.statSources()
+	if len(sources) == 0 {
+		drawText(screen, "No gear, tree nodes, or mutation yet", x, y, head)
+
+		return
+	}
+
+	wrap := smallText()
+	wrap.Width = 340
+
+	for i, s := range sources {
+		if y > bottom {
+			drawText(screen, "+"+strconv.Itoa(len(sources)-i)+" more", x, y, head)
+
+			return
+		}
+
+		mods := make([]string, len(s.Mods))
+		for j, m := range s.Mods {
+			mods[j] = m.String()
+		}
+
+		y += drawText(screen, s.Name+": "+strings.Join(mods, ", "), x, y, wrap) + 4
+	}
+}
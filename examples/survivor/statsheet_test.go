@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestModifierString(t *testing.T) {
+	tests := []struct {
+		mod  Modifier
+		want string
+	}{
+		{Modifier{Type: ModArmor, Value: 12}, "+12 Armor"},
+		{Modifier{Type: ModPercentDamage, Value: 7.5}, "+7.5% Damage"},
+		{Modifier{Type: ModCooldown, Value: 5}, "-5% Cooldown"},
+		{Modifier{Type: ModArmor, Value: -12}, "-12 Armor"},
+		{Modifier{Type: ModCooldown, Value: -2.5}, "+2.5% Cooldown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mod.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestStatSheetNamesContributors(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.player.AllocatedNodes[6] = true // Thick Skin, +5 Armor
+	g.player.Equipment[SlotKeyboard] = &Equipment{
+		Name:      "Plated Keyboard",
+		Modifiers: []Modifier{{Type: ModArmor, Value: 10}, {Type: ModFlatHP, Value: 20}},
+	}
+	g.player.Passives[PassiveArmor] = 2
+	g.recalculateStats()
+
+	sources := g.statSources()
+	names := make([]string, len(sources))
+
+	for i, s := range sources {
+		names[i] = s.Name
+	}
+
+	if i, j := slices.Index(names, "Tree: Thick Skin"), slices.Index(names, "Plated Keyboard"); i < 0 || j < i {
+		t.Fatalf("sources = %v, want the tree node before the keyboard", names)
+	}
+
+	rows := g.statRows()
+	armor := rows[slices.IndexFunc(rows, func(r statRow) bool { return r.Label == "Armor" })]
+
+	if armor.Value != "25 (-38% dmg)" {
+		t.Errorf("armor = %q, want 25 armor and its reduction", armor.Value)
+	}
+
+	got := g.contributors(armor, sources)
+	for _, want := range []string{"Tree: Thick Skin", "Plated Keyboard", "Armor 2"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("armor contributors %v, missing %q", got, want)
+		}
+	}
+
+	speed := rows[slices.IndexFunc(rows, func(r statRow) bool { return r.Label == "Move Speed" })]
+	if got := g.contributors(speed, sources); slices.Contains(got, "Plated Keyboard") {
+		t.Errorf("move speed contributors %v, want only sources that change it", got)
+	}
+}
+
+func TestWeaponDPS(t *testing.T) {
+	g, _ := newWeaponTestGame(WeaponCoffee)
+	g.recalculateStats()
+
+	w := g.player.Weapons[0]
+	stats := g.weaponStats(w.Type, w.Level)
+	want := g.damageCalc(w.Type, w.Level).Average() * float64(stats.Count) / stats.Cooldown
+
+	before := g.weaponDPS(w)
+	if math.Abs(before-want) > 1e-9 {
+		t.Fatalf("DPS = %v, want %v", before, want)
+	}
+
+	g.player.CooldownMult = 0.8
+
+	if after := g.weaponDPS(w); math.Abs(after-before/0.8) > 1e-6 {
+		t.Errorf("DPS at 0.8 cooldown = %v, want %v", after, before/0.8)
+	}
+}
+
+func TestStatSheetCountsAsRun(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	g.state = StateStats
+	if !g.inRun() {
+		t.Error("the character sheet is shown mid-run and should save with it")
+	}
+}
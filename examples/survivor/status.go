@@ -0,0 +1,358 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/assets"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// StatusID is a buff or debuff shown in a status tray.
+type StatusID int
+
+const (
+	StatusSprint StatusID = iota
+	StatusTaunt
+	StatusFreeze
+	StatusInvulnerable
+	StatusSureCrit
+	StatusShieldDown
+	StatusWorldEvent
+	StatusEnraged
+	StatusSlowed
+	StatusCharging
+	StatusBurning
+	StatusPoisoned
+	StatusHindered
+	StatusStunned
+	StatusCount
+)
+
+// StatusDef describes how a status looks in the tray and the character sheet.
+type StatusDef struct {
+	Name   string
+	Desc   string
+	Glyph  string // Single character drawn on the icon
+	Color  color.RGBA
+	Debuff bool
+}
+
+// StatusDefs are the statuses the player and bosses can show.
+var StatusDefs = map[StatusID]StatusDef{
+	StatusSprint: {
+		Name: "Hotfix Sprint", Desc: "Dashing and untouchable", Glyph: ">",
+		Color: color.RGBA{R: 90, G: 200, B: 120, A: 255},
+	},
+	StatusTaunt: {
+		Name: "Stand-up Meeting", Desc: "Nearby enemies walk to you", Glyph: "T",
+		Color: color.RGBA{R: 255, G: 120, B: 80, A: 255},
+	},
+	StatusFreeze: {
+		Name: "Code Freeze", Desc: "Enemies move at 30% speed", Glyph: "*",
+		Color: color.RGBA{R: 120, G: 200, B: 255, A: 255},
+	},
+	StatusInvulnerable: {
+		Name: "Invulnerable", Desc: "Hits are ignored", Glyph: "I",
+		Color: color.RGBA{R: 230, G: 230, B: 240, A: 255},
+	},
+	StatusSureCrit: {
+		Name: "Sure Crit", Desc: "The next hits always crit", Glyph: "!",
+		Color: color.RGBA{R: 255, G: 215, B: 0, A: 255},
+	},
+	StatusShieldDown: {
+		Name: "Shield Down", Desc: "Shield recharges after this", Glyph: "S",
+		Color: shieldColor, Debuff: true,
+	},
+	StatusWorldEvent: {
+		Name: "World Event", Glyph: "W",
+		Color: color.RGBA{R: 200, G: 120, B: 255, A: 255},
+	},
+	StatusEnraged: {
+		Name: "Enraged", Desc: "Faster and hits harder", Glyph: "E",
+		Color: color.RGBA{R: 230, G: 50, B: 50, A: 255},
+	},
+	StatusSlowed: {
+		Name: "Slowed", Desc: "Caught in Code Freeze", Glyph: "*",
+		Color: color.RGBA{R: 120, G: 200, B: 255, A: 255}, Debuff: true,
+	},
+	StatusCharging: {
+		Name: "Charging", Desc: "About to dash at the player", Glyph: "C",
+		Color: color.RGBA{R: 230, G: 70, B: 60, A: 255},
+	},
+	StatusBurning: {
+		Name: "Burning", Desc: "Takes fire damage over time", Glyph: "B",
+		Color: color.RGBA{R: 255, G: 130, B: 40, A: 255}, Debuff: true,
+	},
+	StatusPoisoned: {
+		Name: "Poisoned", Desc: "Takes damage over time per dose", Glyph: "P",
+		Color: color.RGBA{R: 140, G: 220, B: 60, A: 255}, Debuff: true,
+	},
+	StatusHindered: {
+		Name: "Tangled", Desc: "Moves 40% slower", Glyph: "~",
+		Color: color.RGBA{R: 120, G: 200, B: 255, A: 255}, Debuff: true,
+	},
+	StatusStunned: {
+		Name: "Stunned", Desc: "Cannot move; dodge to break free", Glyph: "Z",
+		Color: color.RGBA{R: 255, G: 230, B: 90, A: 255}, Debuff: true,
+	},
+}
+
+// Status tray layout.
+const (
+	statusIconSize = 16
+	statusIconGap  = 2
+)
+
+// Status is one active status.
+type Status struct {
+	ID        StatusID
+	Name      string  // Overrides the def's name, e.g. with the world event
+	Desc      string  // Overrides the def's description
+	Debuff    bool    // Harmful; outlined in red
+	Stacks    int     // Shown on the icon when above 1
+	Remaining float64 // Seconds left, or 0 if it lasts until something ends it
+	Duration  float64 // Full length for the sweep, or 0 for no sweep
+}
+
+// newStatus returns a status filled in from its def.
+func newStatus(id StatusID, remaining, duration float64) Status {
+	def := StatusDefs[id]
+
+	return Status{
+		ID: id, Name: def.Name, Desc: def.Desc, Debuff: def.Debuff,
+		Remaining: remaining, Duration: duration,
+	}
+}
+
+// playerStatuses lists the player's active statuses, buffs first.
+func (g *Game) playerStatuses() []Status {
+	p := g.player
+
+	var buffs, debuffs []Status
+
+	for _, a := range p.Abilities.Slots {
+		if !a.IsActive() {
+			continue
+		}
+
+		id := StatusFreeze
+
+		switch a.ID {
+		case AbilityDash:
+			id = StatusSprint
+		case AbilityTaunt:
+			id = StatusTaunt
+		}
+
+		buffs = append(buffs, newStatus(id, a.Active, a.Duration))
+	}
+
+	if p.HitTimer > 0 {
+		buffs = append(buffs, newStatus(StatusInvulnerable, p.HitTimer, 0))
+	}
+
+	if p.GuaranteedCrits > 0 {
+		s := newStatus(StatusSureCrit, 0, 0)
+		s.Stacks = p.GuaranteedCrits
+		buffs = append(buffs, s)
+	}
+
+	if p.MaxShield > 0 && p.ShieldDelay > 0 {
+		debuffs = append(debuffs, newStatus(StatusShieldDown, p.ShieldDelay, shieldRegenDelay))
+	}
+
+	debuffs = append(debuffs, ailmentStatuses(&p.Ailments)...)
+
+	if ev := g.activeWorldEvent(); ev != nil {
+		s := newStatus(StatusWorldEvent, g.worldEvent.start+ev.Duration-g.gameTime, ev.Duration)
+		s.Name, s.Desc, s.Debuff = ev.Name, ev.Hint, ev.InvertControls
+
+		if s.Debuff {
+			debuffs = append(debuffs, s)
+		} else {
+			buffs = append(buffs, s)
+		}
+	}
+
+	return append(buffs, debuffs...)
+}
+
+// enemyStatuses lists a boss's active statuses.
+func (g *Game) enemyStatuses(e *Enemy) []Status {
+	var list []Status
+
+	if e.Enraged {
+		list = append(list, newStatus(StatusEnraged, 0, 0))
+	}
+
+	if e.Charge != nil && !e.Charge.Dashing {
+		list = append(list, newStatus(StatusCharging, e.Charge.Windup, 0))
+	}
+
+	if slow := g.player.Abilities.Get(AbilitySlow); slow != nil && slow.IsActive() {
+		list = append(list, newStatus(StatusSlowed, slow.Active, slow.Duration))
+	}
+
+	return append(list, ailmentStatuses(&e.Ailments)...)
+}
+
+// statusIconAtlas draws every status icon once and packs them into a single
+// atlas, so a tray is drawn from one texture.
+func statusIconAtlas() *assets.TextureAtlas {
+	b := assets.NewAtlasBuilder()
+
+	for id := range StatusCount {
+		def := StatusDefs[id]
+		img := ebiten.NewImage(statusIconSize, statusIconSize)
+
+		bg := color.RGBA{R: def.Color.R / 3, G: def.Color.G / 3, B: def.Color.B / 3, A: 255}
+		img.Fill(bg)
+		vector.StrokeRect(img, 0.5, 0.5, statusIconSize-1, statusIconSize-1, 1, def.Color, false)
+		ebitenutil.DebugPrintAt(img, def.Glyph, 5, 0)
+
+		b.Add(statusIconKey(id), img)
+	}
+
+	atlas, err := b.Build(statusIconSize * 4)
+	if err != nil {
+		panic(err) // Only fails with no images
+	}
+
+	return atlas
+}
+
+func statusIconKey(id StatusID) string {
+	return fmt.Sprintf("status_%d", id)
+}
+
+// statusIcon returns the icon of a status, packing the atlas on first use.
+func (g *Game) statusIcon(id StatusID) *ebiten.Image {
+	if g.statusIcons == nil {
+		g.statusIcons = statusIconAtlas()
+	}
+
+	return g.statusIcons.GetSubImage(statusIconKey(id))
+}
+
+// drawStatusIcon draws a status's icon with its top-left corner at (x, y):
+// the elapsed part of its duration is swept clockwise in shadow, debuffs get
+// a red outline, and stacks are counted in the corner.
+func (g *Game) drawStatusIcon(screen *ebiten.Image, s Status, x, y float32) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(g.statusIcon(s.ID), op)
+
+	if s.Duration > 0 {
+		elapsed := 1 - min(max(s.Remaining/s.Duration, 0), 1)
+		drawSweep(screen, x, y, elapsed)
+	}
+
+	if s.Debuff {
+		vector.StrokeRect(screen, x-1, y-1, statusIconSize+2, statusIconSize+2, 1,
+			color.RGBA{R: 255, G: 60, B: 60, A: 255}, false)
+	}
+
+	if s.Stacks > 1 {
+		ebitenutil.DebugPrintAt(screen, formatInt(s.Stacks), int(x)+statusIconSize-5, int(y)+4)
+	}
+}
+
+// drawSweep shades the fraction frac of the icon at (x, y), clockwise from
+// 12 o'clock.
+func drawSweep(screen *ebiten.Image, x, y float32, frac float64) {
+	if frac <= 0 {
+		return
+	}
+
+	ix, iy := int(x), int(y)
+	clip := screen.SubImage(image.Rect(ix, iy, ix+statusIconSize, iy+statusIconSize)).(*ebiten.Image)
+	cx, cy := float32(ix)+statusIconSize/2, float32(iy)+statusIconSize/2
+	r := float32(statusIconSize) // Past the corners, so the clip squares it off
+
+	var path vector.Path
+
+	start := -math.Pi / 2
+	path.MoveTo(cx, cy)
+	path.LineTo(cx, cy-r)
+	path.Arc(cx, cy, r, float32(start), float32(start+frac*2*math.Pi), vector.Clockwise)
+	path.Close()
+
+	var cs ebiten.ColorScale
+	cs.ScaleWithColor(color.NRGBA{A: 160})
+	vector.FillPath(clip, &path, nil, &vector.DrawPathOptions{ColorScale: cs})
+}
+
+// drawStatusTray draws statuses in a centered row just above screen point
+// (cx, top).
+func (g *Game) drawStatusTray(screen *ebiten.Image, list []Status, cx, top float32) {
+	if len(list) == 0 {
+		return
+	}
+
+	w := float32(len(list))*(statusIconSize+statusIconGap) - statusIconGap
+	x, y := cx-w/2, top-statusIconSize
+
+	for i, s := range list {
+		g.drawStatusIcon(screen, s, x+float32(i)*(statusIconSize+statusIconGap), y)
+	}
+}
+
+// drawStatusTrays draws the trays above the player and on-screen bosses.
+func (g *Game) drawStatusTrays(screen *ebiten.Image) {
+	px, py := float32(g.player.X-g.cameraX), float32(g.player.Y-g.cameraY)
+	g.drawStatusTray(screen, g.playerStatuses(), px, py-30)
+
+	for _, e := range g.enemies {
+		if !e.IsBoss || e.Dead {
+			continue
+		}
+
+		sx, sy := float32(e.X-g.cameraX), float32(e.Y-g.cameraY)
+		if sx < -50 || sx > screenWidth+50 || sy < -50 || sy > screenHeight+50 {
+			continue
+		}
+
+		g.drawStatusTray(screen, g.enemyStatuses(e), sx, sy-float32(e.Radius)-10)
+	}
+}
+
+// drawStatusList draws the player's statuses for the character sheet: the
+// icon, name, stacks, and time left, with the description underneath.
+func (g *Game) drawStatusList(screen *ebiten.Image, x, y int) {
+	ui.DebugPrintAt(screen, "Statuses:", x, y)
+	y += 20
+
+	list := g.playerStatuses()
+	if len(list) == 0 {
+		ui.DebugPrintAt(screen, "(none)", x, y)
+
+		return
+	}
+
+	for _, s := range list {
+		g.drawStatusIcon(screen, s, float32(x), float32(y))
+		ui.DebugPrintAt(screen, statusTitle(s), x+statusIconSize+8, y)
+		ui.DebugPrintAt(screen, s.Desc, x+statusIconSize+8, y+14)
+		y += 36
+	}
+}
+
+// statusTitle is a status's name with its stacks and time left.
+func statusTitle(s Status) string {
+	line := s.Name
+	if s.Stacks > 1 {
+		line += fmt.Sprintf(" x%d", s.Stacks)
+	}
+
+	if s.Remaining > 0 {
+		line += fmt.Sprintf(" (%.1fs)", s.Remaining)
+	}
+
+	return line
+}
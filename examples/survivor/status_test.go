@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func statusIDs(list []Status) []StatusID {
+	ids := make([]StatusID, len(list))
+	for i, s := range list {
+		ids[i] = s.ID
+	}
+
+	return ids
+}
+
+func TestPlayerStatusesListBuffsBeforeDebuffs(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	if list := g.playerStatuses(); len(list) != 0 {
+		t.Fatalf("fresh run has statuses %v", statusIDs(list))
+	}
+
+	p := g.player
+	p.MaxShield, p.ShieldDelay = 20, 1
+	p.GuaranteedCrits = 3
+	g.activateAbility(0) // Dash, which also grants i-frames
+
+	list := g.playerStatuses()
+	want := []StatusID{StatusSprint, StatusInvulnerable, StatusSureCrit, StatusShieldDown}
+
+	if len(list) != len(want) {
+		t.Fatalf("statuses = %v, want %v", statusIDs(list), want)
+	}
+
+	for i, s := range list {
+		if s.ID != want[i] {
+			t.Fatalf("statuses = %v, want %v", statusIDs(list), want)
+		}
+	}
+
+	if sprint := list[0]; sprint.Remaining != sprint.Duration || sprint.Duration <= 0 {
+		t.Errorf("sprint = %+v, want a full timer", sprint)
+	}
+
+	if list[2].Stacks != 3 || !list[3].Debuff {
+		t.Errorf("sure crit = %+v, shield down = %+v", list[2], list[3])
+	}
+}
+
+func TestWorldEventStatusTakesTheEventName(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	storm := forceWorldEvent(t, g, "Glitch Storm", 1)
+	runWorldTo(g, 2)
+
+	list := g.playerStatuses()
+	if len(list) != 1 || list[0].Name != storm.Name || !list[0].Debuff ||
+		math.Abs(list[0].Remaining-(storm.Duration-1)) > 0.05 {
+		t.Errorf("statuses = %+v, want the storm as a debuff with %vs left", list, storm.Duration-1)
+	}
+}
+
+func TestBossStatuses(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharSenior) // Code Freeze in slot 0
+
+	boss := &Enemy{IsBoss: true}
+	if list := g.enemyStatuses(boss); len(list) != 0 {
+		t.Fatalf("calm boss has statuses %v", statusIDs(list))
+	}
+
+	g.enrage(boss)
+	boss.Charge = &bossCharge{Windup: 0.5}
+	g.activateAbility(0)
+
+	list := g.enemyStatuses(boss)
+	want := []StatusID{StatusEnraged, StatusCharging, StatusSlowed}
+
+	if len(list) != len(want) || list[0].ID != want[0] || list[1].ID != want[1] || list[2].ID != want[2] {
+		t.Errorf("statuses = %v, want %v", statusIDs(list), want)
+	}
+}
+
+func TestStatusDefsAreComplete(t *testing.T) {
+	for id := range StatusCount {
+		if def, ok := StatusDefs[id]; !ok || def.Name == "" || len(def.Glyph) != 1 {
+			t.Errorf("status %d has def %+v", id, def)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/chunks"
+	"github.com/skyrocket-qy/NeuralWay/engine/components"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+)
+
+// Stage surfaces react to the damage type of the weapons that touch them:
+// fire ignites oil spills into burn zones wider than the spill, and
+// lightning hits on enemies standing in water chain further.
+const (
+	oilBurnGrowth     = 1.6 // Burn zone radius over the spill's
+	oilBurnDuration   = 5.0
+	oilBurnDamageMult = 0.4 // Burn tick damage over the igniting hit
+	waterChainJumps   = 2   // Extra arcs for a chain starting in water
+	waterChainRange   = 1.5 // Arc range multiplier for a chain starting in water
+)
+
+var (
+	oilBurnColor = color.RGBA{R: 255, G: 120, B: 30, A: 255}
+
+	// waterArc lets lightning weapons that do not chain arc from a target in
+	// water; the water bonus supplies its jumps.
+	waterArc = systems.ChainConfig{Range: 120, Falloff: 0.6}
+)
+
+// SurfaceSpawn scatters one kind of surface across each chunk of a stage.
+type SurfaceSpawn struct {
+	Kind                 PropKind
+	MaxPerChunk          int // Each chunk gets 0 to MaxPerChunk
+	MinRadius, MaxRadius float64
+}
+
+// StageDef is the data for a stage: the surfaces generated in its chunks.
+type StageDef struct {
+	Name     string
+	Surfaces []SurfaceSpawn
+}
+
+// Stages lists the stages; runs are played on the first.
+var Stages = []StageDef{
+	{
+		Name: "Open Office",
+		Surfaces: []SurfaceSpawn{
+			{Kind: PropOil, MaxPerChunk: 3, MinRadius: 35, MaxRadius: 60},
+			{Kind: PropWater, MaxPerChunk: 3, MinRadius: 50, MaxRadius: 90},
+		},
+	},
+}
+
+// stageDef returns the stage the run is played on.
+func (g *Game) stageDef() *StageDef {
+	return &Stages[g.stage]
+}
+
+// fireTouching returns the weapon and damage of a fire projectile or fire
+// zone overlapping p, so burning oil spreads to neighboring spills.
+func (g *Game) fireTouching(p *Prop) (WeaponType, int, bool) {
+	for _, proj := range g.projectiles {
+		if proj.Lifetime <= 0 || WeaponDefs[proj.WeaponType].DamageType != components.DamageFire {
+			continue
+		}
+
+		if math.Hypot(proj.X-p.X, proj.Y-p.Y) <= proj.Radius+p.Radius {
+			return proj.WeaponType, int(float64(proj.Damage) * oilBurnDamageMult), true
+		}
+	}
+
+	for _, z := range g.zones {
+		if WeaponDefs[z.WeaponType].DamageType != components.DamageFire {
+			continue
+		}
+
+		if math.Hypot(z.X-p.X, z.Y-p.Y) <= z.Radius+p.Radius {
+			return z.WeaponType, z.Damage, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// igniteOil burns away an oil spill, leaving a burn zone wider than the spill.
+func (g *Game) igniteOil(p *Prop, wt WeaponType, damage int) {
+	p.Done = true
+	g.zones = append(g.zones, &DamageZone{
+		X:          p.X,
+		Y:          p.Y,
+		Radius:     p.Radius * oilBurnGrowth,
+		Damage:     max(1, damage),
+		Duration:   oilBurnDuration,
+		Color:      oilBurnColor,
+		WeaponType: wt,
+	})
+	g.spawnParticle(p.X, p.Y, 16, oilBurnColor)
+}
+
+// inWater reports whether (x, y) is on a water surface in a live chunk.
+func (g *Game) inWater(x, y float64) bool {
+	if g.world == nil {
+		return false
+	}
+
+	k := chunks.KeyAt(x, y, chunkSize)
+	if !g.world.Loaded(k) {
+		return false
+	}
+
+	state, err := g.world.Get(k)
+	if err != nil {
+		return false
+	}
+
+	for _, p := range state.Props {
+		if p.Kind == PropWater && !p.Done && math.Hypot(x-p.X, y-p.Y) < p.Radius {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conduct extends a lightning chain that starts on an enemy standing in water.
+func (g *Game) conduct(e *Enemy, cfg systems.ChainConfig) systems.ChainConfig {
+	if g.inWater(e.X, e.Y) {
+		cfg.Jumps += waterChainJumps
+		cfg.Range *= waterChainRange
+	}
+
+	return cfg
+}
+
+// drawSurface draws an oil or water surface centered at (sx, sy) on screen.
+func drawSurface(screen *ebiten.Image, p Prop, sx, sy float32) {
+	r := float32(p.Radius)
+
+	switch p.Kind {
+	case PropOil:
+		vector.FillCircle(screen, sx, sy, r, color.NRGBA{R: 35, G: 25, B: 45, A: 180}, false)
+		// Rainbow sheen
+		sheen := color.NRGBA{R: 150, G: 90, B: 200, A: 90}
+		vector.StrokeCircle(screen, sx-r*0.3, sy-r*0.3, r*0.4, 2, sheen, false)
+	case PropWater:
+		vector.FillCircle(screen, sx, sy, r, color.NRGBA{R: 60, G: 140, B: 230, A: 90}, false)
+		vector.StrokeCircle(screen, sx, sy, r, 2, color.NRGBA{R: 120, G: 190, B: 255, A: 140}, false)
+	}
+}
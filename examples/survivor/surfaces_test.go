@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/chunks"
+)
+
+// dropProjectile adds a live projectile of the given weapon at (x, y).
+func dropProjectile(g *Game, wt WeaponType, x, y float64, damage int) {
+	p := g.newProjectile()
+	p.X, p.Y = x, y
+	p.Damage, p.Lifetime, p.Radius, p.Piercing = damage, 0.2, 5, 1
+	p.WeaponType = wt
+	p.Color = WeaponDefs[wt].Color
+	g.projectiles = append(g.projectiles, p)
+}
+
+func TestFireIgnitesOilIntoWiderBurnZone(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	state := ChunkState{Props: []Prop{
+		{Kind: PropOil, X: 1000, Y: 1000, Radius: 40},
+		{Kind: PropOil, X: 1090, Y: 1000, Radius: 30}, // Inside the first spill's burn zone
+		{Kind: PropOil, X: 1400, Y: 1000, Radius: 30}, // Out of reach
+	}}
+
+	// A physical hit leaves the oil alone
+	dropProjectile(g, WeaponPrint, 1000, 1000, 50)
+
+	if g.updateChunk(&state, 1.0/60); state.Props[0].Done || len(g.zones) != 0 {
+		t.Fatal("a physical projectile ignited oil")
+	}
+
+	dropProjectile(g, WeaponFirewall, 1000, 1000, 50)
+
+	if !g.updateChunk(&state, 1.0/60) {
+		t.Error("igniting oil did not mark the chunk changed")
+	}
+
+	if !state.Props[0].Done || !state.Props[1].Done || state.Props[2].Done {
+		t.Fatalf("burned %v %v %v, want the spill and its neighbor only",
+			state.Props[0].Done, state.Props[1].Done, state.Props[2].Done)
+	}
+
+	if len(g.zones) != 2 {
+		t.Fatalf("zones = %d, want one burn zone per spill", len(g.zones))
+	}
+
+	z := g.zones[0]
+	if z.Radius != 40*oilBurnGrowth || z.WeaponType != WeaponFirewall || z.Damage != 20 {
+		t.Errorf("burn zone radius %v, weapon %v, damage %d", z.Radius, z.WeaponType, z.Damage)
+	}
+}
+
+func TestWaterConductsLightning(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	disableCrits(g)
+
+	k, i := findProp(t, g, PropWater)
+	state, _ := g.world.Get(k)
+	water := state.Props[i]
+
+	// A line of enemies 150 apart, beyond StackOverflow's dry reach
+	strike := func(x, y float64) []int {
+		g.enemies = g.enemies[:0]
+		for j := range 3 {
+			g.enemies = append(g.enemies, &Enemy{X: x + float64(j)*150, Y: y, HP: 1000, MaxHP: 1000, Radius: 10})
+		}
+
+		dropProjectile(g, WeaponStackOverflow, x, y, 100)
+		g.updateProjectiles(0)
+
+		hp := make([]int, len(g.enemies))
+		for j, e := range g.enemies {
+			hp[j] = e.HP
+		}
+
+		return hp
+	}
+
+	if got := strike(water.X, water.Y); got[1] == 1000 || got[2] == 1000 {
+		t.Errorf("enemy HP %v after a lightning hit in water, want the arc to reach all three", got)
+	}
+
+	// Far from any loaded chunk there is no water
+	far := chunks.Key{X: k.X + 1000, Y: k.Y}
+	if got := strike(float64(far.X)*chunkSize, 0); got[1] != 1000 || got[2] != 1000 {
+		t.Errorf("enemy HP %v after a dry lightning hit, want only the first hit", got)
+	}
+}
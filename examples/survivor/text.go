@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+// drawText draws s with its top-left at (x, y), or its top-center or
+// top-right with o.Align, in the theme's font sizes and colors unless o
+// sets them. It returns the height drawn.
+func drawText(screen *ebiten.Image, s string, x, y int, o text.Options) int {
+	return int(text.Draw(screen, s, float64(x), float64(y), o))
+}
+
+// headingText styles panel titles: bold, heading size, centered on x.
+func headingText() text.Options {
+	return text.Options{Font: text.Bold(), Size: ui.CurrentTheme().Fonts.Heading, Align: text.AlignCenter}
+}
+
+// smallText styles secondary lines such as descriptions and hints.
+func smallText() text.Options {
+	return text.Options{Size: ui.CurrentTheme().Fonts.Small, Color: ui.CurrentTheme().Palette.TextMuted}
+}
+
+// hudText styles text drawn over the game world, shadowed to stay legible.
+var hudText = text.Options{Shadow: true}
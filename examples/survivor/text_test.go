@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui/text"
+)
+
+func TestTextStylesFollowTheme(t *testing.T) {
+	name := ui.CurrentTheme().Name
+	defer func() { _ = ui.SetTheme(name) }()
+
+	_, dark := text.Measure("LEVEL UP!", headingText())
+
+	if err := ui.SetTheme("High Contrast"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, large := text.Measure("LEVEL UP!", headingText())
+	if large <= dark {
+		t.Errorf("High Contrast heading height %.1f should exceed %.1f", large, dark)
+	}
+
+	if c := smallText().Color; c != ui.CurrentTheme().Palette.TextMuted {
+		t.Errorf("small text color = %v, want the theme's muted text", c)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// openTokenShop shows the framework token shop over the title screen.
+func (g *Game) openTokenShop() {
+	if g.profile == nil {
+		g.profile = profile.New(nil)
+	}
+
+	shop := profile.NewShop(g.profile)
+	shop.OnExit = g.closeTokenShop
+
+	if err := shop.Load(); err != nil {
+		log.Printf("profile: %v", err)
+	}
+
+	g.tokenShop = shop
+}
+
+// closeTokenShop returns to the title screen, wearing whatever was bought.
+func (g *Game) closeTokenShop() {
+	g.tokenShop = nil
+	g.applyPalette()
+}
+
+// applyPalette re-registers the survivor theme recolored by the palette the
+// profile wears, so the options screen keeps offering it under its own name.
+func (g *Game) applyPalette() {
+	t := g.profile.Theme(survivorTheme())
+	t.Name = survivorTheme().Name
+	ui.RegisterTheme(t)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+func TestTokenShopPaletteRecolorsTheSurvivorTheme(t *testing.T) {
+	g := &Game{state: StateCharSelect, profile: profile.New(nil)}
+
+	g.openTokenShop()
+
+	if g.tokenShop == nil || g.atTitle() {
+		t.Fatal("the shop should open over the title and hold off the demo")
+	}
+
+	ember, _ := profile.Lookup("palette-ember")
+	if err := g.profile.Earn("survivor", ember.Price); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.profile.Buy(ember); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.profile.Equip(ember); err != nil {
+		t.Fatal(err)
+	}
+
+	g.closeTokenShop()
+
+	theme, _ := ui.LookupTheme("Survivor")
+	if g.tokenShop != nil || theme.Palette.Accent != ember.Color {
+		t.Errorf("after closing: shop %v, accent %v", g.tokenShop, theme.Palette.Accent)
+	}
+
+	// Taking the palette off restores the original look
+	if err := g.profile.Unequip(profile.Palette); err != nil {
+		t.Fatal(err)
+	}
+
+	g.applyPalette()
+
+	if theme, _ := ui.LookupTheme("Survivor"); theme.Palette.Accent != survivorTheme().Palette.Accent {
+		t.Errorf("accent %v after unequipping", theme.Palette.Accent)
+	}
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/chunks"
+	"github.com/skyrocket-qy/NeuralWay/engine/combatlog"
+	"github.com/skyrocket-qy/NeuralWay/engine/events"
+	"github.com/skyrocket-qy/NeuralWay/engine/rng"
+	"github.com/skyrocket-qy/NeuralWay/engine/systems"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+const (
+	chunkSize       = 600.0
+	chunkLoadRadius = 1  // Chunks around the player kept live (3x3 covers the screen)
+	chunkCacheSize  = 32 // Resident chunks before the least recently visited is serialized
+	spawnSafeRadius = 150.0
+)
+
+// PropKind is a kind of world object placed by chunk generation.
+type PropKind int
+
+const (
+	PropCrate  PropKind = iota // Destructible, drops XP
+	PropChest                  // Opened on touch, gives gold
+	PropHazard                 // Damages the player until it dries up
+	PropOil                    // Surface: fire ignites it into a burn zone
+	PropWater                  // Surface: lightning chains further from it
+)
+
+// Prop is a destructible or interactive object in a world chunk.
+type Prop struct {
+	Kind   PropKind `json:"kind"`
+	X      float64  `json:"x"`
+	Y      float64  `json:"y"`
+	Radius float64  `json:"r"`
+	HP     int      `json:"hp,omitempty"`    // Crates
+	Gold   int      `json:"gold,omitempty"`  // Chests
+	Timer  float64  `json:"timer,omitempty"` // Hazard lifetime remaining
+	Done   bool     `json:"done,omitempty"`  // Destroyed, opened, or dried up
+}
+
+// ChunkState is the persisted state of one world chunk.
+type ChunkState struct {
+	Props []Prop `json:"props"`
+}
+
+// initWorld creates the chunk store and random streams for a new run.
+func (g *Game) initWorld() {
+	g.worldSeed = int64(g.run.Seed)
+	g.rng = rng.New(g.worldSeed)
+	g.world = chunks.NewStore(chunkCacheSize, generateChunk(g.worldSeed, g.stageDef()))
+}
+
+// generateChunk returns a deterministic chunk generator for a run seed and
+// stage, so chunks that were never modified can be dropped and regenerated
+// identically.
+func generateChunk(seed int64, stage *StageDef) chunks.GenerateFunc[ChunkState] {
+	return func(k chunks.Key) ChunkState {
+		r := rand.New(rand.NewSource(seed ^ int64(k.X)*73856093 ^ int64(k.Y)*19349663))
+		originX, originY := float64(k.X)*chunkSize, float64(k.Y)*chunkSize
+
+		var state ChunkState
+
+		place := func(kind PropKind, radius float64) *Prop {
+			x := originX + radius + r.Float64()*(chunkSize-2*radius)
+			y := originY + radius + r.Float64()*(chunkSize-2*radius)
+
+			// Keep the spawn point clear
+			if math.Hypot(x, y) < spawnSafeRadius {
+				return nil
+			}
+
+			state.Props = append(state.Props, Prop{Kind: kind, X: x, Y: y, Radius: radius})
+
+			return &state.Props[len(state.Props)-1]
+		}
+
+		for range r.Intn(4) {
+			if p := place(PropCrate, 14); p != nil {
+				p.HP = 30
+			}
+		}
+
+		if r.Float64() < 0.2 {
+			if p := place(PropChest, 16); p != nil {
+				p.Gold = 20 + r.Intn(31)
+			}
+		}
+
+		for range r.Intn(3) {
+			if p := place(PropHazard, 30+r.Float64()*20); p != nil {
+				p.Timer = 60
+			}
+		}
+
+		for _, s := range stage.Surfaces {
+			for range r.Intn(s.MaxPerChunk + 1) {
+				place(s.Kind, s.MinRadius+r.Float64()*(s.MaxRadius-s.MinRadius))
+			}
+		}
+
+		return state
+	}
+}
+
+// updateWorld streams chunks around the player and resolves prop interactions.
+func (g *Game) updateWorld(dt float64) {
+	if g.world == nil {
+		return
+	}
+
+	center := chunks.KeyAt(g.player.X, g.player.Y, chunkSize)
+	for _, k := range chunks.Around(center, chunkLoadRadius) {
+		state, err := g.world.Get(k)
+		if err != nil {
+			log.Printf("world: %v", err)
+
+			continue
+		}
+
+		if g.updateChunk(state, dt) {
+			g.world.MarkDirty(k)
+		}
+	}
+}
+
+// updateChunk updates the props of a live chunk and reports whether any changed.
+func (g *Game) updateChunk(state *ChunkState, dt float64) bool {
+	changed := false
+
+	for i := range state.Props {
+		p := &state.Props[i]
+		if p.Done {
+			continue
+		}
+
+		switch p.Kind {
+		case PropCrate:
+			for _, proj := range g.projectiles {
+				if proj.Lifetime <= 0 || math.Hypot(proj.X-p.X, proj.Y-p.Y) > proj.Radius+p.Radius {
+					continue
+				}
+
+				p.HP -= proj.Damage
+				proj.Lifetime = 0
+				changed = true
+
+				if p.HP <= 0 {
+					p.Done = true
+					g.xpGems = append(g.xpGems, &XPGem{X: p.X, Y: p.Y, Value: 5})
+					g.spawnParticle(p.X, p.Y, 12, color.RGBA{R: 160, G: 110, B: 60, A: 255})
+
+					break
+				}
+			}
+		case PropChest:
+			if math.Hypot(g.player.X-p.X, g.player.Y-p.Y) < 20+p.Radius {
+				p.Done = true
+				changed = true
+				g.player.Gold += p.Gold
+				g.spawnParticle(p.X, p.Y, 20, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+				g.notify(ui.Notification{
+					Title:   "Chest opened",
+					Message: "+" + formatInt(p.Gold) + " gold",
+					Color:   color.RGBA{R: 255, G: 215, B: 0, A: 255},
+				})
+			}
+		case PropHazard:
+			p.Timer -= dt
+			changed = true
+
+			if p.Timer <= 0 {
+				p.Done = true
+
+				continue
+			}
+
+			if g.player.HitTimer <= 0 && math.Hypot(g.player.X-p.X, g.player.Y-p.Y) < p.Radius {
+				g.hurtPlayer(5, 0, "Bug puddle")
+			}
+		case PropOil:
+			if wt, damage, ok := g.fireTouching(p); ok {
+				g.igniteOil(p, wt, damage)
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// playerMitigation turns the player's armor into diminishing damage reduction:
+// 40 armor halves a hit, at least 20% of any hit lands, and no single hit
+// takes more than 60 HP.
+var playerMitigation = systems.Mitigation{K: 40, Floor: 0.2, MinDamage: 1, Cap: 60}
+
+// damageArcScale turns the share of max HP a hit took into the damage
+// indicator's strength, so a third of the bar is a full-strength arc.
+const damageArcScale = 3
+
+// hurtPlayer applies damage after armor and the attacker's armor penetration,
+// with invulnerability frames and revival. A ready pet may block the hit.
+// source names the attacker in the combat log. It returns true if the hit
+// landed.
+func (g *Game) hurtPlayer(damage int, armorPen float64, source string) bool {
+	return g.hurtPlayerFrom(g.player.X, g.player.Y, damage, armorPen, source)
+}
+
+// hurtPlayerFrom is hurtPlayer for an attacker at (x, y), which the damage
+// indicator points toward.
+func (g *Game) hurtPlayerFrom(x, y float64, damage int, armorPen float64, source string) bool {
+	if g.petBlocks(source) {
+		return false
+	}
+
+	pen := systems.Penetration{Percent: armorPen}
+	taken := int(math.Round(playerMitigation.Apply(float64(damage), float64(g.player.Armor), pen)))
+	g.player.HitTimer = 0.5
+	g.losePlayerHP(taken, source)
+	g.playRumble(rumbleHit(taken, g.player.MaxHP))
+
+	if g.bus != nil {
+		events.Publish(g.bus, ui.DamageFrom{
+			X: x, Y: y,
+			TargetX: g.player.X, TargetY: g.player.Y,
+			Strength: float64(taken) / float64(max(g.player.MaxHP, 1)) * damageArcScale,
+		})
+	}
+
+	return true
+}
+
+// losePlayerHP takes damage past armor from the shield and then HP, without
+// invulnerability frames, so ailment ticks land through them.
+func (g *Game) losePlayerHP(taken int, source string) {
+	taken = g.assistDamage(taken)
+	taken = g.absorbShield(taken)
+	g.player.HP -= taken
+	g.runDamageTaken += taken
+
+	g.logCombat(combatlog.Entry{
+		Category: combatlog.DamageTaken,
+		Source:   source,
+		Target:   "Player",
+		Amount:   taken,
+	})
+
+	if g.player.HP <= 0 {
+		if g.sandbox != nil {
+			// The training arena never ends the run
+			g.player.HP = g.player.MaxHP
+
+			return
+		}
+
+		if g.player.HasRevival && !g.player.UsedRevival {
+			g.player.HP = g.player.MaxHP / 2
+			g.player.UsedRevival = true
+		} else {
+			g.endRun()
+		}
+	}
+}
+
+// drawWorld renders props in live chunks, beneath enemies.
+func (g *Game) drawWorld(screen *ebiten.Image) {
+	if g.world == nil {
+		return
+	}
+
+	center := chunks.KeyAt(g.player.X, g.player.Y, chunkSize)
+	for _, k := range chunks.Around(center, chunkLoadRadius) {
+		if !g.world.Loaded(k) {
+			continue
+		}
+
+		state, err := g.world.Get(k)
+		if err != nil {
+			continue
+		}
+
+		// Surfaces lie under the other props
+		for _, surfaces := range []bool{true, false} {
+			for _, p := range state.Props {
+				if !p.Done {
+					g.drawProp(screen, p, surfaces)
+				}
+			}
+		}
+	}
+}
+
+// drawProp draws p if it is on screen: only surfaces when surfaces is set,
+// and only the other props when it is not.
+func (g *Game) drawProp(screen *ebiten.Image, p Prop, surfaces bool) {
+	sx, sy := float32(p.X-g.cameraX), float32(p.Y-g.cameraY)
+	r := float32(p.Radius)
+
+	if sx < -r || sx > screenWidth+r || sy < -r || sy > screenHeight+r {
+		return
+	}
+
+	if surfaces {
+		drawSurface(screen, p, sx, sy)
+
+		return
+	}
+
+	switch p.Kind {
+	case PropCrate:
+		vector.FillRect(screen, sx-r, sy-r, r*2, r*2, color.RGBA{R: 140, G: 95, B: 50, A: 255}, false)
+		vector.StrokeRect(screen, sx-r, sy-r, r*2, r*2, 2, color.RGBA{R: 90, G: 60, B: 30, A: 255}, false)
+	case PropChest:
+		vector.FillRect(screen, sx-r, sy-r*0.7, r*2, r*1.4, color.RGBA{R: 200, G: 150, B: 40, A: 255}, false)
+		vector.FillRect(screen, sx-3, sy-3, 6, 6, color.RGBA{R: 255, G: 240, B: 150, A: 255}, false)
+	case PropHazard:
+		// Fade out as the hazard dries up
+		alpha := uint8(40 + 80*math.Min(p.Timer/60, 1))
+		vector.FillCircle(screen, sx, sy, r, color.NRGBA{R: 120, G: 200, B: 60, A: alpha}, false)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/ui"
+)
+
+// World event schedule: the first event starts at worldEventFirst seconds,
+// and each later one a random gap after the previous one ends.
+const (
+	worldEventFirst  = 120.0
+	worldEventGapMin = 100.0
+	worldEventGapMax = 200.0
+)
+
+// MegaEliteDef is a boss-sized elite a world event spawns, with rewards to match.
+type MegaEliteDef struct {
+	Monster MonsterType
+	HPMult  float64 // Multiplies the monster's time-scaled HP
+	XPMult  int
+	Coins   int // Dropped on death, on top of a guaranteed boss loot roll
+}
+
+// WorldEventDef defines a rare timed world event and the effects it has
+// while active.
+type WorldEventDef struct {
+	Name     string
+	Hint     string  // Warning toast text
+	Weight   float64 // Relative chance of being rolled
+	Warning  float64 // Seconds of warning before the event starts
+	Duration float64 // Seconds the effects last
+
+	XPMult         float64 // Multiplies XP picked up; 0 leaves it unchanged
+	InvertControls bool
+	HaltSpawns     bool          // Pauses ambient spawns and scripted spawn events
+	MegaElite      *MegaEliteDef // Spawned when the event starts
+
+	Tint color.NRGBA // Screen overlay while active
+}
+
+// WorldEvents are the events rolled during a run.
+var WorldEvents = []WorldEventDef{
+	{
+		Name:           "Glitch Storm",
+		Hint:           "Controls invert, XP doubles",
+		Weight:         3,
+		Warning:        3,
+		Duration:       12,
+		XPMult:         2,
+		InvertControls: true,
+		Tint:           color.NRGBA{R: 180, G: 40, B: 255, A: 40},
+	},
+	{
+		Name:     "Production Incident",
+		Hint:     "A mega-elite is paging you",
+		Weight:   2,
+		Warning:  4,
+		Duration: 30,
+		MegaElite: &MegaEliteDef{
+			Monster: MonsterLegacy,
+			HPMult:  15,
+			XPMult:  20,
+			Coins:   150,
+		},
+		Tint: color.NRGBA{R: 255, G: 30, B: 30, A: 20},
+	},
+	{
+		Name:       "Code Freeze",
+		Hint:       "Nothing spawns for 10 seconds",
+		Weight:     2,
+		Warning:    3,
+		Duration:   10,
+		HaltSpawns: true,
+		Tint:       color.NRGBA{R: 80, G: 180, B: 255, A: 50},
+	},
+}
+
+// worldEventState is the next or current world event of a run.
+type worldEventState struct {
+	def    *WorldEventDef
+	start  float64 // Run time the effects begin
+	warned bool
+	active bool
+}
+
+// initWorldEvents seeds the world event roll from the run seed, so a seed
+// replays the same events at the same times, and schedules the first one.
+func (g *Game) initWorldEvents() {
+	g.worldEventRng = g.rng.Stream(streamWorldEvents)
+	g.worldEvent = g.rollWorldEvent(worldEventFirst)
+}
+
+// rollWorldEvent picks an event by weight, starting at run time at.
+func (g *Game) rollWorldEvent(at float64) *worldEventState {
+	total := 0.0
+	for _, def := range WorldEvents {
+		total += def.Weight
+	}
+
+	r := g.worldEventRng.Float64() * total
+	for i := range WorldEvents {
+		if r -= WorldEvents[i].Weight; r < 0 || i == len(WorldEvents)-1 {
+			return &worldEventState{def: &WorldEvents[i], start: at}
+		}
+	}
+
+	return nil
+}
+
+// updateWorldEvents warns about, starts, and ends world events.
+func (g *Game) updateWorldEvents() {
+	ev := g.worldEvent
+	if ev == nil {
+		return
+	}
+
+	if !ev.warned && g.gameTime >= ev.start-ev.def.Warning {
+		ev.warned = true
+
+		g.audio.PlaySound("select")
+		g.notify(ui.Notification{
+			Title:    ev.def.Name + " incoming!",
+			Message:  ev.def.Hint,
+			Color:    ui.CurrentTheme().Palette.Warning,
+			Duration: ev.def.Warning + 1,
+			Priority: ui.ToastHigh,
+		})
+	}
+
+	if !ev.active && g.gameTime >= ev.start {
+		ev.active = true
+		g.startWorldEvent(ev.def)
+	}
+
+	if ev.active && g.gameTime >= ev.start+ev.def.Duration {
+		gap := worldEventGapMin + g.worldEventRng.Float64()*(worldEventGapMax-worldEventGapMin)
+		g.worldEvent = g.rollWorldEvent(g.gameTime + gap)
+	}
+}
+
+// startWorldEvent applies an event's one-off effects.
+func (g *Game) startWorldEvent(def *WorldEventDef) {
+	g.recordWorldEvent()
+
+	if m := def.MegaElite; m != nil {
+		angle := g.worldEventRng.Float64() * math.Pi * 2
+		dist := float64(screenWidth)/2 + 120
+
+		e := g.spawnMonster(m.Monster, g.player.X+math.Cos(angle)*dist, g.player.Y+math.Sin(angle)*dist)
+		e.Elite, e.MegaElite = true, m
+		e.HP = int(float64(e.HP) * m.HPMult)
+		e.MaxHP = e.HP
+		e.XP *= m.XPMult
+		e.Damage *= 2
+		e.Radius *= 2
+	}
+}
+
+// activeWorldEvent returns the event whose effects are running, or nil.
+func (g *Game) activeWorldEvent() *WorldEventDef {
+	if g.worldEvent == nil || !g.worldEvent.active {
+		return nil
+	}
+
+	return g.worldEvent.def
+}
+
+// worldXP scales picked-up XP by the active event.
+func (g *Game) worldXP(value int) int {
+	if ev := g.activeWorldEvent(); ev != nil && ev.XPMult > 0 {
+		return int(math.Round(float64(value) * ev.XPMult))
+	}
+
+	return value
+}
+
+// controlsInverted reports whether an event is inverting movement.
+func (g *Game) controlsInverted() bool {
+	ev := g.activeWorldEvent()
+
+	return ev != nil && ev.InvertControls
+}
+
+// spawnsHalted reports whether an event is holding back spawns.
+func (g *Game) spawnsHalted() bool {
+	ev := g.activeWorldEvent()
+
+	return ev != nil && ev.HaltSpawns
+}
+
+// rewardMegaElite pays out a mega-elite's bonus coins and loot.
+func (g *Game) rewardMegaElite(e *Enemy) {
+	g.dropCoins(e.X, e.Y, e.MegaElite.Coins)
+	g.rollLootTable(bossLoot, "Mega "+MonsterDefs[e.Type].Name, e.X, e.Y)
+	g.spawnParticle(e.X, e.Y, 40, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+}
+
+// drawWorldEvents draws the active event's overlay, its banner, and a ring
+// around any mega-elite.
+func (g *Game) drawWorldEvents(screen *ebiten.Image) {
+	for _, e := range g.enemies {
+		if e.MegaElite == nil || e.Dead {
+			continue
+		}
+
+		radius := float32(e.Radius) + 10 + float32(3*math.Sin(g.gameTime*6))
+		sx, sy := float32(e.X-g.cameraX), float32(e.Y-g.cameraY)
+		vector.StrokeCircle(screen, sx, sy, radius, 3, color.RGBA{R: 255, G: 40, B: 40, A: 255}, false)
+	}
+
+	ev := g.activeWorldEvent()
+	if ev == nil {
+		return
+	}
+
+	vector.FillRect(screen, 0, 0, screenWidth, screenHeight, ev.Tint, false)
+
+	if ev.InvertControls {
+		// Tearing scanlines that jump every few frames
+		scan := color.NRGBA{R: 0, G: 255, B: 200, A: 60}
+		frame := int(g.gameTime * 12)
+
+		for i := range 6 {
+			y := float32((i*97 + frame*37) % screenHeight)
+			shift := float32((i*53+frame*29)%40 - 20)
+			vector.FillRect(screen, shift, y, screenWidth, float32(2+i%3*2), scan, false)
+		}
+	}
+
+	if ev.HaltSpawns {
+		const frame = 6
+
+		ice := color.NRGBA{R: 200, G: 240, B: 255, A: 140}
+		vector.FillRect(screen, 0, 0, screenWidth, frame, ice, false)
+		vector.FillRect(screen, 0, screenHeight-frame, screenWidth, frame, ice, false)
+		vector.FillRect(screen, 0, 0, frame, screenHeight, ice, false)
+		vector.FillRect(screen, screenWidth-frame, 0, frame, screenHeight, ice, false)
+	}
+
+	left := g.worldEvent.start + ev.Duration - g.gameTime
+	banner := fmt.Sprintf("%s  %.0fs - %s", ev.Name, math.Ceil(left), ev.Hint)
+	ebitenutil.DebugPrintAt(screen, banner, screenWidth/2-len(banner)*3, 52)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+)
+
+// runWorldTo advances run time to t, updating world events every frame.
+func runWorldTo(g *Game, t float64) {
+	for g.gameTime < t {
+		g.gameTime += 1.0 / 60
+		g.updateWorldEvents()
+	}
+}
+
+// forceWorldEvent schedules the named event to start at run time at.
+func forceWorldEvent(t *testing.T, g *Game, name string, at float64) *WorldEventDef {
+	t.Helper()
+
+	for i := range WorldEvents {
+		if WorldEvents[i].Name == name {
+			g.worldEvent = &worldEventState{def: &WorldEvents[i], start: at}
+
+			return &WorldEvents[i]
+		}
+	}
+
+	t.Fatalf("no world event %q", name)
+
+	return nil
+}
+
+func TestWorldEventsFollowTheRunSeed(t *testing.T) {
+	schedule := func(seed uint32) []string {
+		g := &Game{}
+		g.startRun(RunConfig{Seed: seed, Char: CharJunior})
+
+		var names []string
+
+		for range 5 {
+			ev := g.worldEvent
+			names = append(names, ev.def.Name)
+			runWorldTo(g, ev.start+ev.def.Duration+0.1)
+		}
+
+		return names
+	}
+
+	a, b := schedule(42), schedule(42)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same seed rolled different events: %v vs %v", a, b)
+		}
+	}
+}
+
+func TestGlitchStormInvertsControlsAndDoublesXP(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	def := forceWorldEvent(t, g, "Glitch Storm", 10)
+	runWorldTo(g, 10-def.Warning+0.05)
+
+	if g.controlsInverted() || g.toasts.Len() != 1 {
+		t.Fatalf("warning phase: inverted=%v toasts=%d", g.controlsInverted(), g.toasts.Len())
+	}
+
+	runWorldTo(g, 10.05)
+
+	if !g.controlsInverted() || g.worldXP(5) != 10 {
+		t.Errorf("storm active: inverted=%v xp(5)=%d", g.controlsInverted(), g.worldXP(5))
+	}
+
+	runWorldTo(g, 10+def.Duration+0.05)
+
+	if g.controlsInverted() || g.worldXP(5) != 5 || g.worldEvent.start <= g.gameTime {
+		t.Errorf("storm should end and the next event be scheduled later")
+	}
+}
+
+func TestCodeFreezeHaltsSpawns(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+	g.gameTime = 100
+	forceWorldEvent(t, g, "Code Freeze", 100)
+
+	for range 5 * 60 {
+		g.gameTime += 1.0 / 60
+		g.updateSpawning(1.0 / 60)
+	}
+
+	if len(g.enemies) != 0 {
+		t.Fatalf("%d enemies spawned during the freeze", len(g.enemies))
+	}
+
+	for range 10 * 60 {
+		g.gameTime += 1.0 / 60
+		g.updateSpawning(1.0 / 60)
+	}
+
+	if len(g.enemies) == 0 {
+		t.Error("spawning should resume after the freeze")
+	}
+}
+
+func TestProductionIncidentMegaEliteRewards(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	def := forceWorldEvent(t, g, "Production Incident", 1)
+	runWorldTo(g, 1.05)
+
+	if len(g.enemies) != 1 || g.enemies[0].MegaElite == nil {
+		t.Fatalf("incident should spawn one mega-elite, got %d enemies", len(g.enemies))
+	}
+
+	e := g.enemies[0]
+	base := MonsterDefs[def.MegaElite.Monster]
+
+	if e.MaxHP < base.HP*10 || e.XP < base.XP*def.MegaElite.XPMult {
+		t.Errorf("mega-elite not buffed: HP %d, XP %d", e.MaxHP, e.XP)
+	}
+
+	g.killEnemy(e)
+
+	if len(g.itemDrops) == 0 || len(g.coins) == 0 {
+		t.Errorf("mega-elite should drop boss loot and coins: items %d, coins %d",
+			len(g.itemDrops), len(g.coins))
+	}
+}
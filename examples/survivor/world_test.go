@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/skyrocket-qy/NeuralWay/engine/chunks"
+)
+
+// findProp returns the first chunk with a prop of the given kind, searching outward from origin.
+func findProp(t *testing.T, g *Game, kind PropKind) (chunks.Key, int) {
+	t.Helper()
+
+	for _, k := range chunks.Around(chunks.Key{}, 6) {
+		state, err := g.world.Get(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, p := range state.Props {
+			if p.Kind == kind {
+				return k, i
+			}
+		}
+	}
+
+	t.Fatalf("no prop of kind %d generated", kind)
+
+	return chunks.Key{}, 0
+}
+
+func TestOpenedChestPersistsAcrossEviction(t *testing.T) {
+	g := &Game{}
+	g.startGame(CharJunior)
+
+	k, i := findProp(t, g, PropChest)
+	state, _ := g.world.Get(k)
+	chest := state.Props[i]
+
+	// Walk onto the chest
+	g.player.X, g.player.Y = chest.X, chest.Y
+	g.updateWorld(1.0 / 60)
+
+	if g.player.Gold != chest.Gold {
+		t.Fatalf("gold = %d, want %d", g.player.Gold, chest.Gold)
+	}
+
+	// Roam far enough that the chunk is serialized and unloaded
+	for x := 1; x <= chunkCacheSize+1; x++ {
+		if _, err := g.world.Get(chunks.Key{X: k.X + 100 + x, Y: k.Y}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if g.world.Loaded(k) {
+		t.Fatal("chest chunk should have been evicted")
+	}
+
+	// Return: the chest stays open and pays out only once
+	g.updateWorld(1.0 / 60)
+
+	state, _ = g.world.Get(k)
+	if !state.Props[i].Done {
+		t.Error("opened chest was restored closed")
+	}
+
+	if g.player.Gold != chest.Gold {
+		t.Errorf("gold = %d after revisit, want %d", g.player.Gold, chest.Gold)
+	}
+}
+
+func TestChunkGenerationDeterministic(t *testing.T) {
+	gen := generateChunk(42, &Stages[0])
+	a, b := gen(chunks.Key{X: 3, Y: -2}), gen(chunks.Key{X: 3, Y: -2})
+
+	if len(a.Props) != len(b.Props) {
+		t.Fatalf("prop counts differ: %d vs %d", len(a.Props), len(b.Props))
+	}
+
+	for i := range a.Props {
+		if a.Props[i] != b.Props[i] {
+			t.Errorf("prop %d differs: %+v vs %+v", i, a.Props[i], b.Props[i])
+		}
+	}
+
+	for _, p := range gen(chunks.Key{}).Props {
+		if p.X*p.X+p.Y*p.Y < spawnSafeRadius*spawnSafeRadius {
+			t.Errorf("prop %+v placed inside the spawn area", p)
+		}
+	}
+}
+
+func TestHurtPlayerArmorMitigation(t *testing.T) {
+	tests := []struct {
+		name     string
+		armor    int
+		damage   int
+		armorPen float64
+		want     int
+	}{
+		{"no armor", 0, 20, 0, 20},
+		{"armor halves at K", 40, 20, 0, 10},
+		{"stacked armor still takes the floor", 1000, 20, 0, 4},
+		{"boss penetration halves armor", 80, 40, MonsterDefs[MonsterBossDeadline].ArmorPen, 20},
+		{"cap", 0, 500, 0, 60},
+		{"minimum", 1000, 1, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Game{}
+			g.startGame(CharJunior)
+			g.player.MaxHP, g.player.HP = 1000, 1000
+			g.player.Armor = tt.armor
+
+			g.hurtPlayer(tt.damage, tt.armorPen, "test")
+
+			if taken := 1000 - g.player.HP; taken != tt.want {
+				t.Errorf("took %d, want %d", taken, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Wave arena is the wavegame template's smallest client: a turret ship that
+// auto-fires at the nearest drone while WASD moves it.
+package main
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/skyrocket-qy/NeuralWay/engine/engine"
+	"github.com/skyrocket-qy/NeuralWay/engine/paths"
+	"github.com/skyrocket-qy/NeuralWay/engine/profile"
+	"github.com/skyrocket-qy/NeuralWay/engine/template/wavegame"
+)
+
+const (
+	screenWidth  = 800
+	screenHeight = 600
+)
+
+type body struct{ X, Y, VX, VY, HP float64 }
+
+// arena implements wavegame.Rules.
+type arena struct {
+	ship                     body
+	drones, shots            []*body
+	damage, fireRate, reload float64
+}
+
+func (a *arena) Reset() {
+	*a = arena{ship: body{X: screenWidth / 2, Y: screenHeight / 2, HP: 5}, damage: 1, fireRate: 2}
+}
+
+// Spawn sends a drone in from a random edge. Later waves can afford tougher
+// ones, which cost more.
+func (a *arena) Spawn(wave int, budget float64) float64 {
+	cost := 1 + float64(rand.Intn(min(wave, 3)))
+	if budget < cost {
+		return 0
+	}
+
+	angle := rand.Float64() * 2 * math.Pi
+	a.drones = append(a.drones, &body{
+		X: screenWidth/2 + math.Cos(angle)*500, Y: screenHeight/2 + math.Sin(angle)*500, HP: cost * 2,
+	})
+
+	return cost
+}
+
+func (a *arena) Update(g *wavegame.Game, dt float64) {
+	s := &a.ship
+	if ebiten.IsKeyPressed(ebiten.KeyA) {
+		s.X -= 200 * dt
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyD) {
+		s.X += 200 * dt
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyW) {
+		s.Y -= 200 * dt
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyS) {
+		s.Y += 200 * dt
+	}
+
+	s.X, s.Y = min(max(s.X, 0), screenWidth), min(max(s.Y, 0), screenHeight)
+
+	// Fire at the nearest drone
+	if a.reload -= dt; a.reload <= 0 && len(a.drones) > 0 {
+		a.reload = 1 / a.fireRate
+
+		near := a.drones[0]
+		for _, d := range a.drones {
+			if math.Hypot(d.X-s.X, d.Y-s.Y) < math.Hypot(near.X-s.X, near.Y-s.Y) {
+				near = d
+			}
+		}
+
+		dist := max(math.Hypot(near.X-s.X, near.Y-s.Y), 1)
+		vx, vy := (near.X-s.X)/dist*500, (near.Y-s.Y)/dist*500
+		a.shots = append(a.shots, &body{X: s.X, Y: s.Y, VX: vx, VY: vy, HP: 1})
+	}
+
+	for _, p := range a.shots {
+		p.X, p.Y, p.HP = p.X+p.VX*dt, p.Y+p.VY*dt, p.HP-dt
+
+		for _, d := range a.drones {
+			if d.HP > 0 && p.HP > 0 && math.Hypot(d.X-p.X, d.Y-p.Y) < 14 {
+				d.HP -= a.damage
+				p.HP = 0
+
+				if d.HP <= 0 {
+					g.AddScore(10 * g.Wave())
+				}
+			}
+		}
+	}
+
+	for _, d := range a.drones {
+		dist := max(math.Hypot(s.X-d.X, s.Y-d.Y), 1)
+		d.X, d.Y = d.X+(s.X-d.X)/dist*70*dt, d.Y+(s.Y-d.Y)/dist*70*dt
+
+		if d.HP > 0 && dist < 20 {
+			d.HP, s.HP = 0, s.HP-1
+		}
+	}
+
+	a.drones = keep(a.drones)
+	a.shots = keep(a.shots)
+
+	if s.HP <= 0 {
+		g.End()
+	}
+}
+
+// keep drops dead bodies in place.
+func keep(bodies []*body) []*body {
+	kept := bodies[:0]
+	for _, b := range bodies {
+		if b.HP > 0 {
+			kept = append(kept, b)
+		}
+	}
+
+	return kept
+}
+
+func (a *arena) Alive() int { return len(a.drones) }
+
+func (a *arena) Upgrades(int) []wavegame.Upgrade {
+	return []wavegame.Upgrade{
+		{Name: "Overcharge", Description: "+1 damage per shot", Apply: func() { a.damage++ }},
+		{Name: "Autoloader", Description: "Fire 30% faster", Apply: func() { a.fireRate *= 1.3 }},
+		{Name: "Patch Hull", Description: "+2 HP", Apply: func() { a.ship.HP += 2 }},
+	}
+}
+
+func (a *arena) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{R: 16, G: 18, B: 28, A: 255})
+
+	for _, d := range a.drones {
+		r := 8 + float32(d.HP)
+		vector.FillCircle(screen, float32(d.X), float32(d.Y), r, color.RGBA{R: 230, G: 80, B: 90, A: 255}, false)
+	}
+
+	for _, p := range a.shots {
+		vector.FillCircle(screen, float32(p.X), float32(p.Y), 3, color.RGBA{R: 255, G: 230, B: 120, A: 255}, false)
+	}
+
+	s := a.ship
+	vector.FillCircle(screen, float32(s.X), float32(s.Y), 12, color.RGBA{R: 90, G: 200, B: 255, A: 255}, false)
+
+	for i := range int(s.HP) {
+		vector.FillRect(screen, float32(10+i*14), 30, 10, 10, color.RGBA{R: 90, G: 220, B: 120, A: 255}, false)
+	}
+}
+
+func main() {
+	g := wavegame.New(&arena{}, wavegame.Config{
+		Title: "Wave Arena", Width: screenWidth, Height: screenHeight, Director: wavegame.DefaultDirectorConfig(),
+	})
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Wave Arena")
+
+	window := engine.WindowConfig{App: paths.App{Vendor: "neuralway", Name: "wave_arena"}}
+	focus := engine.FocusConfig{Policy: engine.FocusPause, AutoPause: true}
+	windowed := engine.WithWindow(engine.WithFocus(g, focus), window)
+	if err := ebiten.RunGame(profile.WithTokens(windowed, window.App.Name)); err != nil {
+		log.Fatal(err)
+	}
+}